@@ -0,0 +1,81 @@
+// Copyright 2026 The Ebitengine Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ebiten
+
+import (
+	"fmt"
+	"image"
+	"os"
+
+	"github.com/duplicants-ai/ebiten/internal/graphics"
+)
+
+// PremultiplyAlpha converts straight-alpha (non-premultiplied) RGBA pixels to premultiplied-alpha RGBA pixels in place.
+//
+// The pixels passed to WritePixels must be premultiplied by alpha. Use PremultiplyAlpha for pixels with straight alpha,
+// e.g., pixels decoded by a library or loaded from a raw file, before WritePixels.
+// The result is the same as converting *image.NRGBA by image/draw.
+//
+// PremultiplyAlpha doesn't allocate memory, and skips opaque and transparent pixels quickly.
+//
+// If len(pix) is not a multiple of 4, PremultiplyAlpha panics.
+func PremultiplyAlpha(pix []byte) {
+	if len(pix)%4 != 0 {
+		panic(fmt.Sprintf("ebiten: len(pix) must be a multiple of 4 but %d at PremultiplyAlpha", len(pix)))
+	}
+	graphics.PremultiplyAlpha(pix)
+}
+
+// UnpremultiplyAlpha converts premultiplied-alpha RGBA pixels to straight-alpha (non-premultiplied) RGBA pixels in place.
+//
+// UnpremultiplyAlpha is useful to pass the result of ReadPixels to a library that expects straight alpha.
+// The result is the same as converting colors by color.NRGBAModel.
+// A color value more than its alpha value is clamped.
+//
+// UnpremultiplyAlpha doesn't allocate memory, and skips opaque and transparent pixels quickly.
+//
+// If len(pix) is not a multiple of 4, UnpremultiplyAlpha panics.
+func UnpremultiplyAlpha(pix []byte) {
+	if len(pix)%4 != 0 {
+		panic(fmt.Sprintf("ebiten: len(pix) must be a multiple of 4 but %d at UnpremultiplyAlpha", len(pix)))
+	}
+	graphics.UnpremultiplyAlpha(pix)
+}
+
+// premultipliedAlphaValidationEnabled reports whether the pixels given to WritePixels are validated as premultiplied-alpha colors.
+var premultipliedAlphaValidationEnabled = envValidatePremultipliedAlpha()
+
+func envValidatePremultipliedAlpha() bool {
+	env := os.Getenv("EBITENGINE_VALIDATE_PREMULTIPLIED_ALPHA")
+	return env != "" && env != "0"
+}
+
+// validatePremultipliedAlpha panics if pix includes a pixel that is invalid as a premultiplied-alpha color.
+// region is the region of pix in the image's coordinates.
+//
+// validatePremultipliedAlpha does nothing unless the validation is enabled.
+func validatePremultipliedAlpha(pix []byte, region image.Rectangle) {
+	if !premultipliedAlphaValidationEnabled {
+		return
+	}
+	idx := graphics.FindNonPremultipliedPixel(pix)
+	if idx < 0 {
+		return
+	}
+	x := region.Min.X + idx%region.Dx()
+	y := region.Min.Y + idx/region.Dx()
+	p := pix[4*idx : 4*idx+4]
+	panic(fmt.Sprintf("ebiten: the pixel at (%d, %d) is not a premultiplied-alpha color: R: %d, G: %d, B: %d, A: %d; use PremultiplyAlpha to convert straight-alpha pixels", x, y, p[0], p[1], p[2], p[3]))
+}
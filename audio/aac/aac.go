@@ -0,0 +1,146 @@
+// Copyright 2026 The Ebitengine Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package aac provides AAC decoder.
+//
+// Both raw AAC in ADTS (.aac) and AAC in MP4 containers (.m4a and .mp4) are supported.
+// The gapless playback information in MP4, i.e., the edit list or iTunes' iTunSMPB, is respected.
+//
+// This package uses a decoder provided by the platform:
+//
+//   - Windows: Media Foundation
+//   - macOS and iOS: AudioToolbox
+//   - Linux: libfdk-aac, which must be installed
+//   - Browsers: WebCodecs
+//
+// On the other platforms, or when the platform decoder is not available, decoding returns an error
+// wrapping errors.ErrUnsupported.
+package aac
+
+import (
+	"io"
+
+	"github.com/duplicants-ai/ebiten/audio/internal/convert"
+)
+
+const (
+	bitDepthInBytesInt16   = 2
+	bitDepthInBytesFloat32 = 4
+)
+
+// Stream is a decoded stream.
+type Stream struct {
+	readSeeker io.ReadSeeker
+	length     int64
+	sampleRate int
+}
+
+// Read is implementation of io.Reader's Read.
+func (s *Stream) Read(buf []byte) (int, error) {
+	return s.readSeeker.Read(buf)
+}
+
+// Seek is implementation of io.Seeker's Seek.
+//
+// Note that Seek can take long since decoding is a relatively heavy task.
+func (s *Stream) Seek(offset int64, whence int) (int64, error) {
+	return s.readSeeker.Seek(offset, whence)
+}
+
+// Length returns the size of decoded stream in bytes.
+//
+// If the length is unknown, e.g., when the source is ADTS but not io.Seeker, Length returns 0.
+func (s *Stream) Length() int64 {
+	return s.length
+}
+
+// SampleRate returns the sample rate of the decoded stream.
+//
+// For HE-AAC, the sample rate is the one after the spectral band replication.
+func (s *Stream) SampleRate() int {
+	return s.sampleRate
+}
+
+// DecodeF32 decodes an AAC source and returns a decoded stream in 32bit float, little endian, 2 channels (stereo) format.
+//
+// DecodeF32 returns error when decoding fails or IO error happens.
+//
+// The returned Stream's Seek is available only when src is an io.Seeker.
+//
+// A Stream doesn't close src even if src implements io.Closer.
+// Closing the source is src owner's responsibility.
+func DecodeF32(src io.Reader) (*Stream, error) {
+	s, err := newStream(src, bitDepthInBytesFloat32)
+	if err != nil {
+		return nil, err
+	}
+	return &Stream{
+		readSeeker: s,
+		length:     s.Length(),
+		sampleRate: s.sampleRate,
+	}, nil
+}
+
+// DecodeWithoutResampling decodes an AAC source and returns a decoded stream in signed 16bit integer, little endian, 2 channels (stereo) format.
+//
+// DecodeWithoutResampling returns error when decoding fails or IO error happens.
+//
+// The returned Stream's Seek is available only when src is an io.Seeker.
+//
+// A Stream doesn't close src even if src implements io.Closer.
+// Closing the source is src owner's responsibility.
+func DecodeWithoutResampling(src io.Reader) (*Stream, error) {
+	s, err := newStream(src, bitDepthInBytesInt16)
+	if err != nil {
+		return nil, err
+	}
+	return &Stream{
+		readSeeker: s,
+		length:     s.Length(),
+		sampleRate: s.sampleRate,
+	}, nil
+}
+
+// DecodeWithSampleRate decodes an AAC source and returns a decoded stream in signed 16bit integer, little endian, 2 channels (stereo) format.
+//
+// DecodeWithSampleRate returns error when decoding fails or IO error happens.
+//
+// DecodeWithSampleRate automatically resamples the stream to fit with sampleRate if necessary.
+//
+// The returned Stream's Seek is available only when src is an io.Seeker.
+//
+// A Stream doesn't close src even if src implements io.Closer.
+// Closing the source is src owner's responsibility.
+//
+// Resampling can be a very heavy task. Stream has a cache for resampling, but the size is limited.
+// Do not expect that Stream has a resampling cache even after whole data is played.
+func DecodeWithSampleRate(sampleRate int, src io.Reader) (*Stream, error) {
+	s, err := newStream(src, bitDepthInBytesInt16)
+	if err != nil {
+		return nil, err
+	}
+
+	var r io.ReadSeeker = s
+	length := s.Length()
+	if s.sampleRate != sampleRate {
+		r2 := convert.NewResampling(s, length, s.sampleRate, sampleRate, bitDepthInBytesInt16)
+		r = r2
+		length = r2.Length()
+	}
+	return &Stream{
+		readSeeker: r,
+		length:     length,
+		sampleRate: sampleRate,
+	}, nil
+}
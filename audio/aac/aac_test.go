@@ -0,0 +1,236 @@
+// Copyright 2026 The Ebitengine Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package aac_test
+
+import (
+	"bytes"
+	"encoding/binary"
+	"io"
+	"reflect"
+	"slices"
+	"testing"
+
+	"github.com/duplicants-ai/ebiten/audio/aac"
+)
+
+func TestAudioSpecificConfig(t *testing.T) {
+	testCases := []struct {
+		name   string
+		config []byte
+		want   aac.Track
+	}{
+		{
+			name:   "AAC-LC",
+			config: []byte{0x12, 0x10},
+			want: aac.Track{
+				ObjectType:           2,
+				SampleRate:           44100,
+				ChannelConfiguration: 2,
+				FrameLength:          1024,
+			},
+		},
+		{
+			name:   "AAC-LC 960",
+			config: []byte{0x11, 0x94},
+			want: aac.Track{
+				ObjectType:           2,
+				SampleRate:           48000,
+				ChannelConfiguration: 2,
+				FrameLength:          960,
+			},
+		},
+		{
+			name: "HE-AAC",
+			// objectType: 5, samplingFrequencyIndex: 6 (24000), channelConfiguration: 2,
+			// extensionSamplingFrequencyIndex: 3 (48000), objectType: 2
+			config: []byte{0x2b, 0x11, 0x88, 0x00},
+			want: aac.Track{
+				ObjectType:           2,
+				SampleRate:           24000,
+				ChannelConfiguration: 2,
+				ExtensionSampleRate:  48000,
+				FrameLength:          1024,
+			},
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			got, err := aac.ParseAudioSpecificConfig(tc.config)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !reflect.DeepEqual(*got, tc.want) {
+				t.Errorf("got: %+v, want: %+v", *got, tc.want)
+			}
+		})
+	}
+}
+
+func mp4Box(typ string, body ...[]byte) []byte {
+	b := binary.BigEndian.AppendUint32(nil, uint32(8+len(bytes.Join(body, nil))))
+	b = append(b, typ...)
+	return append(b, bytes.Join(body, nil)...)
+}
+
+func u32s(vs ...uint32) []byte {
+	var b []byte
+	for _, v := range vs {
+		b = binary.BigEndian.AppendUint32(b, v)
+	}
+	return b
+}
+
+// makeM4A makes an M4A file with the frames of the given sizes.
+// The frames are stored in two chunks.
+func makeM4A(frameSizes []int, priming, length uint32) []byte {
+	ftyp := mp4Box("ftyp", []byte("M4A "), u32s(0), []byte("M4A mp42isom"))
+
+	var data []byte
+	for i, size := range frameSizes {
+		data = append(data, bytes.Repeat([]byte{byte(i)}, size)...)
+	}
+	mdatOffset := uint32(len(ftyp) + 8)
+	mdat := mp4Box("mdat", data)
+
+	const timescale = 44100
+	const movieTimescale = 1000
+
+	mvhd := mp4Box("mvhd", u32s(0, 0, 0, movieTimescale, 0), make([]byte, 80))
+	elst := mp4Box("elst", u32s(0, 1, length*movieTimescale/timescale, priming, 0x00010000))
+	mdhd := mp4Box("mdhd", u32s(0, 0, 0, timescale, uint32(len(frameSizes)*1024), 0))
+	hdlr := mp4Box("hdlr", u32s(0, 0), []byte("soun"), make([]byte, 13))
+
+	esds := mp4Box("esds", u32s(0),
+		[]byte{0x03, 0x19, 0x00, 0x01, 0x00},
+		[]byte{0x04, 0x11, 0x40, 0x15, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0},
+		[]byte{0x05, 0x02, 0x12, 0x10},
+		[]byte{0x06, 0x01, 0x02})
+	mp4a := mp4Box("mp4a", make([]byte, 6), []byte{0, 1}, make([]byte, 8), []byte{0, 2, 0, 16}, make([]byte, 4), u32s(timescale<<16), esds)
+	stsd := mp4Box("stsd", u32s(0, 1), mp4a)
+	stts := mp4Box("stts", u32s(0, 1, uint32(len(frameSizes)), 1024))
+
+	half := len(frameSizes) / 2
+	stsc := mp4Box("stsc", u32s(0, 2, 1, uint32(half), 1, 2, uint32(len(frameSizes)-half), 1))
+	stsz := mp4Box("stsz", u32s(0, 0, uint32(len(frameSizes))))
+	for _, size := range frameSizes {
+		stsz = append(stsz, u32s(uint32(size))...)
+	}
+	binary.BigEndian.PutUint32(stsz, uint32(len(stsz)))
+	var secondChunk uint32
+	for _, size := range frameSizes[:half] {
+		secondChunk += uint32(size)
+	}
+	stco := mp4Box("stco", u32s(0, 2, mdatOffset, mdatOffset+secondChunk))
+
+	stbl := mp4Box("stbl", stsd, stts, stsc, stsz, stco)
+	minf := mp4Box("minf", stbl)
+	mdia := mp4Box("mdia", mdhd, hdlr, minf)
+	trak := mp4Box("trak", mp4Box("edts", elst), mdia)
+	moov := mp4Box("moov", mvhd, trak)
+
+	return slices.Concat(ftyp, mdat, moov)
+}
+
+type readerOnly struct {
+	r io.Reader
+}
+
+func (r *readerOnly) Read(buf []byte) (int, error) {
+	return r.r.Read(buf)
+}
+
+func TestMP4(t *testing.T) {
+	sizes := []int{10, 20, 30, 40, 50}
+	m4a := makeM4A(sizes, 2112, 3000)
+
+	for _, seekable := range []bool{true, false} {
+		var src io.Reader = bytes.NewReader(m4a)
+		if !seekable {
+			src = &readerOnly{r: src}
+		}
+		track, err := aac.OpenTrack(src)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got, want := track.SampleRate, 44100; got != want {
+			t.Errorf("SampleRate: got: %d, want: %d", got, want)
+		}
+		if got, want := track.ChannelConfiguration, 2; got != want {
+			t.Errorf("ChannelConfiguration: got: %d, want: %d", got, want)
+		}
+		if got, want := track.Priming, int64(2112); got != want {
+			t.Errorf("Priming: got: %d, want: %d", got, want)
+		}
+		// The duration in the edit list is in the movie timescale, and loses the precision.
+		if got, want := track.Length, int64(2998); got != want {
+			t.Errorf("Length: got: %d, want: %d", got, want)
+		}
+		if got, want := track.FrameSizes, sizes; !slices.Equal(got, want) {
+			t.Errorf("FrameSizes: got: %v, want: %v", got, want)
+		}
+		for i, offset := range track.FrameOffsets {
+			if got, want := m4a[offset:offset+int64(sizes[i])], bytes.Repeat([]byte{byte(i)}, sizes[i]); !bytes.Equal(got, want) {
+				t.Errorf("frame %d: got: %v, want: %v", i, got, want)
+			}
+		}
+	}
+}
+
+func makeADTS(frameSizes []int) []byte {
+	var b []byte
+	for i, size := range frameSizes {
+		l := size + 7
+		// AAC-LC, 48000 Hz, stereo, without CRC
+		b = append(b, 0xff, 0xf1, 0x4c, 0x80|byte(l>>11), byte(l>>3), byte(l<<5)|0x1f, 0xfc)
+		b = append(b, bytes.Repeat([]byte{byte(i)}, size)...)
+	}
+	return b
+}
+
+func TestADTS(t *testing.T) {
+	sizes := []int{10, 20, 30}
+	adts := makeADTS(sizes)
+
+	track, err := aac.OpenTrack(bytes.NewReader(adts))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got, want := track.SampleRate, 48000; got != want {
+		t.Errorf("SampleRate: got: %d, want: %d", got, want)
+	}
+	if got, want := track.ChannelConfiguration, 2; got != want {
+		t.Errorf("ChannelConfiguration: got: %d, want: %d", got, want)
+	}
+	if got, want := track.FrameSizes, sizes; !slices.Equal(got, want) {
+		t.Errorf("FrameSizes: got: %v, want: %v", got, want)
+	}
+	for i, offset := range track.FrameOffsets {
+		if got, want := adts[offset:offset+int64(sizes[i])], bytes.Repeat([]byte{byte(i)}, sizes[i]); !bytes.Equal(got, want) {
+			t.Errorf("frame %d: got: %v, want: %v", i, got, want)
+		}
+	}
+
+	// The frame positions are not available for a non-seekable source.
+	track, err = aac.OpenTrack(&readerOnly{r: bytes.NewReader(adts)})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got, want := track.SampleRate, 48000; got != want {
+		t.Errorf("SampleRate: got: %d, want: %d", got, want)
+	}
+	if got := track.FrameOffsets; got != nil {
+		t.Errorf("FrameOffsets: got: %v, want: nil", got)
+	}
+}
@@ -0,0 +1,184 @@
+// Copyright 2026 The Ebitengine Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package aac
+
+import (
+	"errors"
+	"fmt"
+	"io"
+)
+
+// adtsHeader is the header of an ADTS frame.
+type adtsHeader struct {
+	objectType             int
+	samplingFrequencyIndex int
+	channelConfiguration   int
+
+	// headerSize is the size of the header including the CRC.
+	headerSize int
+
+	// frameSize is the size of the frame including the header.
+	frameSize int
+}
+
+const adtsHeaderSize = 7
+
+func isADTSSync(b []byte) bool {
+	return len(b) >= 2 && b[0] == 0xff && b[1]&0xf6 == 0xf0
+}
+
+func parseADTSHeader(b []byte) (adtsHeader, error) {
+	if len(b) < adtsHeaderSize || !isADTSSync(b) {
+		return adtsHeader{}, errors.New("aac: ADTS sync word not found")
+	}
+	h := adtsHeader{
+		objectType:             int(b[2]>>6) + 1,
+		samplingFrequencyIndex: int(b[2]>>2) & 0xf,
+		channelConfiguration:   int(b[2]&1)<<2 | int(b[3]>>6),
+		headerSize:             adtsHeaderSize,
+		frameSize:              int(b[3]&3)<<11 | int(b[4])<<3 | int(b[5]>>5),
+	}
+	if b[1]&1 == 0 {
+		// protection_absent is 0.
+		h.headerSize += 2
+	}
+	if h.samplingFrequencyIndex >= len(samplingFrequencies) {
+		return adtsHeader{}, fmt.Errorf("aac: invalid sampling frequency index: %d", h.samplingFrequencyIndex)
+	}
+	if h.frameSize <= h.headerSize {
+		return adtsHeader{}, fmt.Errorf("aac: invalid ADTS frame size: %d", h.frameSize)
+	}
+	if b[6]&3 != 0 {
+		return adtsHeader{}, fmt.Errorf("aac: multiple raw data blocks in an ADTS frame are not supported: %w", errors.ErrUnsupported)
+	}
+	return h, nil
+}
+
+// id3v2Size returns the size of the ID3v2 tag at the beginning of b.
+func id3v2Size(b []byte) int {
+	if len(b) < 10 || string(b[:3]) != "ID3" {
+		return 0
+	}
+	size := int(b[6]&0x7f)<<21 | int(b[7]&0x7f)<<14 | int(b[8]&0x7f)<<7 | int(b[9]&0x7f)
+	size += 10
+	if b[5]&0x10 != 0 {
+		// Footer
+		size += 10
+	}
+	return size
+}
+
+// parseADTS scans an ADTS stream and returns the track with the positions of the frames.
+func parseADTS(r io.ReadSeeker) (*track, error) {
+	var header [10]byte
+	if _, err := r.Seek(0, io.SeekStart); err != nil {
+		return nil, err
+	}
+	n, err := io.ReadFull(r, header[:])
+	if err != nil && err != io.ErrUnexpectedEOF {
+		return nil, err
+	}
+	pos := int64(id3v2Size(header[:n]))
+
+	t := &track{}
+	for {
+		if _, err := r.Seek(pos, io.SeekStart); err != nil {
+			return nil, err
+		}
+		if _, err := io.ReadFull(r, header[:adtsHeaderSize]); err != nil {
+			if err == io.EOF || err == io.ErrUnexpectedEOF {
+				break
+			}
+			return nil, err
+		}
+		if string(header[:3]) == "TAG" {
+			// ID3v1 tag at the end
+			break
+		}
+		h, err := parseADTSHeader(header[:adtsHeaderSize])
+		if err != nil {
+			if len(t.frames) > 0 {
+				// Ignore the broken data at the end.
+				break
+			}
+			return nil, err
+		}
+		if t.config == nil {
+			c, err := newAudioSpecificConfig(h.objectType, h.samplingFrequencyIndex, h.channelConfiguration)
+			if err != nil {
+				return nil, err
+			}
+			t.config = c
+			t.timescale = c.sampleRate
+		}
+		t.frames = append(t.frames, frame{
+			offset: pos + int64(h.headerSize),
+			size:   h.frameSize - h.headerSize,
+		})
+		pos += int64(h.frameSize)
+	}
+	if t.config == nil {
+		return nil, errors.New("aac: ADTS frame not found")
+	}
+	return t, nil
+}
+
+// adtsFrameReader reads ADTS frames sequentially.
+type adtsFrameReader struct {
+	src io.Reader
+	buf []byte
+}
+
+func (a *adtsFrameReader) readHeader() (adtsHeader, error) {
+	var header [9]byte
+	if _, err := io.ReadFull(a.src, header[:adtsHeaderSize]); err != nil {
+		if err == io.ErrUnexpectedEOF {
+			err = io.EOF
+		}
+		return adtsHeader{}, err
+	}
+	if string(header[:3]) == "TAG" {
+		return adtsHeader{}, io.EOF
+	}
+	h, err := parseADTSHeader(header[:adtsHeaderSize])
+	if err != nil {
+		return adtsHeader{}, err
+	}
+	if h.headerSize > adtsHeaderSize {
+		if _, err := io.ReadFull(a.src, header[adtsHeaderSize:h.headerSize]); err != nil {
+			return adtsHeader{}, err
+		}
+	}
+	return h, nil
+}
+
+func (a *adtsFrameReader) next() ([]byte, error) {
+	h, err := a.readHeader()
+	if err != nil {
+		return nil, err
+	}
+	n := h.frameSize - h.headerSize
+	if cap(a.buf) < n {
+		a.buf = make([]byte, n)
+	}
+	a.buf = a.buf[:n]
+	if _, err := io.ReadFull(a.src, a.buf); err != nil {
+		if err == io.ErrUnexpectedEOF {
+			err = io.EOF
+		}
+		return nil, err
+	}
+	return a.buf, nil
+}
@@ -0,0 +1,183 @@
+// Copyright 2026 The Ebitengine Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package aac
+
+import (
+	"errors"
+	"fmt"
+)
+
+// Audio object types.
+const (
+	objectTypeAACMain = 1
+	objectTypeAACLC   = 2
+	objectTypeSBR     = 5
+	objectTypePS      = 29
+)
+
+var samplingFrequencies = [...]int{
+	96000, 88200, 64000, 48000, 44100, 32000, 24000, 22050, 16000, 12000, 11025, 8000, 7350,
+}
+
+// audioSpecificConfig is the AudioSpecificConfig defined in ISO/IEC 14496-3.
+type audioSpecificConfig struct {
+	// raw is the encoded data.
+	raw []byte
+
+	objectType int
+
+	// extensionObjectType is the object type of the explicit extension, i.e., SBR or PS. 0 means no explicit extension.
+	extensionObjectType int
+
+	// sampleRate is the sample rate of the core AAC.
+	sampleRate int
+
+	// channelConfiguration is the channel configuration. 0 means that the channels are defined in a program config element.
+	channelConfiguration int
+
+	// extensionSampleRate is the sample rate of the explicit SBR (HE-AAC). 0 means that SBR is not signaled explicitly.
+	extensionSampleRate int
+
+	// frameLength is the number of samples in a frame of the core AAC.
+	frameLength int
+}
+
+type bitReader struct {
+	buf []byte
+	pos int
+}
+
+func (r *bitReader) read(n int) (int, error) {
+	var v int
+	for i := 0; i < n; i++ {
+		if r.pos >= len(r.buf)*8 {
+			return 0, errors.New("aac: unexpected end of AudioSpecificConfig")
+		}
+		b := (r.buf[r.pos/8] >> (7 - r.pos%8)) & 1
+		v = v<<1 | int(b)
+		r.pos++
+	}
+	return v, nil
+}
+
+func (r *bitReader) readObjectType() (int, error) {
+	t, err := r.read(5)
+	if err != nil {
+		return 0, err
+	}
+	if t == 31 {
+		t2, err := r.read(6)
+		if err != nil {
+			return 0, err
+		}
+		t = 32 + t2
+	}
+	return t, nil
+}
+
+func (r *bitReader) readSamplingFrequency() (int, error) {
+	i, err := r.read(4)
+	if err != nil {
+		return 0, err
+	}
+	if i == 0xf {
+		return r.read(24)
+	}
+	if i >= len(samplingFrequencies) {
+		return 0, fmt.Errorf("aac: invalid sampling frequency index: %d", i)
+	}
+	return samplingFrequencies[i], nil
+}
+
+func parseAudioSpecificConfig(b []byte) (*audioSpecificConfig, error) {
+	r := &bitReader{buf: b}
+	c := &audioSpecificConfig{
+		raw:         b,
+		frameLength: 1024,
+	}
+
+	var err error
+	if c.objectType, err = r.readObjectType(); err != nil {
+		return nil, err
+	}
+	if c.sampleRate, err = r.readSamplingFrequency(); err != nil {
+		return nil, err
+	}
+	if c.channelConfiguration, err = r.read(4); err != nil {
+		return nil, err
+	}
+	if c.objectType == objectTypeSBR || c.objectType == objectTypePS {
+		c.extensionObjectType = c.objectType
+		if c.extensionSampleRate, err = r.readSamplingFrequency(); err != nil {
+			return nil, err
+		}
+		if c.objectType, err = r.readObjectType(); err != nil {
+			return nil, err
+		}
+	}
+
+	switch c.objectType {
+	case objectTypeAACMain, objectTypeAACLC, 3, 4, 6, 7, 17, 19, 20, 21, 22, 23:
+		// GASpecificConfig
+		frameLengthFlag, err := r.read(1)
+		if err != nil {
+			return nil, err
+		}
+		if frameLengthFlag == 1 {
+			c.frameLength = 960
+		}
+	default:
+		return nil, fmt.Errorf("aac: unsupported audio object type: %d", c.objectType)
+	}
+	return c, nil
+}
+
+// newAudioSpecificConfig creates an AudioSpecificConfig from the fields of an ADTS header.
+func newAudioSpecificConfig(objectType, samplingFrequencyIndex, channelConfiguration int) (*audioSpecificConfig, error) {
+	v := objectType<<11 | samplingFrequencyIndex<<7 | channelConfiguration<<3
+	return parseAudioSpecificConfig([]byte{byte(v >> 8), byte(v)})
+}
+
+// outputSampleRate returns the expected sample rate of the decoded data.
+func (c *audioSpecificConfig) outputSampleRate() int {
+	if c.extensionSampleRate != 0 {
+		return c.extensionSampleRate
+	}
+	return c.sampleRate
+}
+
+// esds returns the ES_Descriptor containing the AudioSpecificConfig.
+func (c *audioSpecificConfig) esds() []byte {
+	descriptor := func(tag byte, body []byte) []byte {
+		// Use 4 bytes for the size as many encoders do.
+		n := len(body)
+		b := []byte{tag, byte(n>>21) | 0x80, byte(n>>14) | 0x80, byte(n>>7) | 0x80, byte(n) & 0x7f}
+		return append(b, body...)
+	}
+
+	decoderSpecificInfo := descriptor(0x05, c.raw)
+	decoderConfig := descriptor(0x04, append([]byte{
+		0x40,             // objectTypeIndication: MPEG-4 audio
+		0x15,             // streamType: audio, upStream: 0, reserved: 1
+		0x00, 0x00, 0x00, // bufferSizeDB
+		0x00, 0x00, 0x00, 0x00, // maxBitrate
+		0x00, 0x00, 0x00, 0x00, // avgBitrate
+	}, decoderSpecificInfo...))
+	slConfig := descriptor(0x06, []byte{0x02})
+	return descriptor(0x03, append(append([]byte{
+		0x00, 0x00, // ES_ID
+		0x00, // flags
+	}, decoderConfig...), slConfig...))
+}
@@ -0,0 +1,31 @@
+// Copyright 2026 The Ebitengine Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package aac
+
+// frameDecoder decodes AAC access units with a decoder provided by the platform.
+type frameDecoder interface {
+	// decode decodes an access unit and appends the decoded interleaved samples to dst.
+	decode(dst []float32, frame []byte) ([]float32, error)
+
+	// reset resets the internal state to decode a non-consecutive access unit.
+	reset() error
+
+	// format returns the sample rate and the number of channels of the decoded samples.
+	// format is valid after decode is called at least once.
+	format() (sampleRate, channels int)
+
+	// close releases the resources.
+	close()
+}
@@ -0,0 +1,259 @@
+// Copyright 2026 The Ebitengine Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package aac
+
+import (
+	"fmt"
+	"runtime"
+	"sync"
+	"unsafe"
+
+	"github.com/ebitengine/purego"
+)
+
+// The decoder uses AudioConverter of AudioToolbox.
+
+const (
+	kAudioFormatLinearPCM      = 'l'<<24 | 'p'<<16 | 'c'<<8 | 'm'
+	kAudioFormatMPEG4AAC       = 'a'<<24 | 'a'<<16 | 'c'<<8 | ' '
+	kAudioFormatMPEG4AAC_HE    = 'a'<<24 | 'a'<<16 | 'c'<<8 | 'h'
+	kAudioFormatMPEG4AAC_HE_V2 = 'a'<<24 | 'a'<<16 | 'c'<<8 | 'p'
+
+	kAudioFormatFlagIsFloat  = 1 << 0
+	kAudioFormatFlagIsPacked = 1 << 3
+
+	kAudioConverterDecompressionMagicCookie = 'd'<<24 | 'm'<<16 | 'g'<<8 | 'c'
+
+	// noMoreInputData is the status returned by the input callback when there is no more packet for the current call.
+	noMoreInputData = 'n'<<24 | 'm'<<16 | 'o'<<8 | 'r'
+)
+
+type _AudioStreamBasicDescription struct {
+	mSampleRate       float64
+	mFormatID         uint32
+	mFormatFlags      uint32
+	mBytesPerPacket   uint32
+	mFramesPerPacket  uint32
+	mBytesPerFrame    uint32
+	mChannelsPerFrame uint32
+	mBitsPerChannel   uint32
+	mReserved         uint32
+}
+
+type _AudioBuffer struct {
+	mNumberChannels uint32
+	mDataByteSize   uint32
+	mData           unsafe.Pointer
+}
+
+type _AudioBufferList struct {
+	mNumberBuffers uint32
+	mBuffers       [1]_AudioBuffer
+}
+
+type _AudioStreamPacketDescription struct {
+	mStartOffset            int64
+	mVariableFramesInPacket uint32
+	mDataByteSize           uint32
+}
+
+var (
+	_AudioConverterNew               func(inSourceFormat *_AudioStreamBasicDescription, inDestinationFormat *_AudioStreamBasicDescription, outAudioConverter *uintptr) int32
+	_AudioConverterSetProperty       func(inAudioConverter uintptr, inPropertyID uint32, inPropertyDataSize uint32, inPropertyData unsafe.Pointer) int32
+	_AudioConverterFillComplexBuffer func(inAudioConverter uintptr, inInputDataProc uintptr, inInputDataProcUserData uintptr, ioOutputDataPacketSize *uint32, outOutputData *_AudioBufferList, outPacketDescription *_AudioStreamPacketDescription) int32
+	_AudioConverterReset             func(inAudioConverter uintptr) int32
+	_AudioConverterDispose           func(inAudioConverter uintptr) int32
+
+	inputDataProc uintptr
+)
+
+var (
+	initOnce sync.Once
+	initErr  error
+)
+
+func initializeAudioToolbox() error {
+	audiotoolbox, err := purego.Dlopen("/System/Library/Frameworks/AudioToolbox.framework/AudioToolbox", purego.RTLD_LAZY|purego.RTLD_GLOBAL)
+	if err != nil {
+		return err
+	}
+	purego.RegisterLibFunc(&_AudioConverterNew, audiotoolbox, "AudioConverterNew")
+	purego.RegisterLibFunc(&_AudioConverterSetProperty, audiotoolbox, "AudioConverterSetProperty")
+	purego.RegisterLibFunc(&_AudioConverterFillComplexBuffer, audiotoolbox, "AudioConverterFillComplexBuffer")
+	purego.RegisterLibFunc(&_AudioConverterReset, audiotoolbox, "AudioConverterReset")
+	purego.RegisterLibFunc(&_AudioConverterDispose, audiotoolbox, "AudioConverterDispose")
+	inputDataProc = purego.NewCallback(audioConverterInputDataProc)
+	return nil
+}
+
+var (
+	theDecoders   = map[uintptr]*audioToolboxDecoder{}
+	nextDecoderID = uintptr(1)
+	decodersM     sync.Mutex
+)
+
+func audioConverterInputDataProc(inAudioConverter uintptr, ioNumberDataPackets *uint32, ioData *_AudioBufferList, outDataPacketDescription **_AudioStreamPacketDescription, inUserData uintptr) int32 {
+	decodersM.Lock()
+	d := theDecoders[inUserData]
+	decodersM.Unlock()
+
+	if d == nil || d.input == nil {
+		*ioNumberDataPackets = 0
+		return noMoreInputData
+	}
+
+	*ioNumberDataPackets = 1
+	ioData.mNumberBuffers = 1
+	ioData.mBuffers[0].mNumberChannels = d.src.mChannelsPerFrame
+	ioData.mBuffers[0].mDataByteSize = uint32(len(d.input))
+	ioData.mBuffers[0].mData = unsafe.Pointer(&d.input[0])
+	if outDataPacketDescription != nil {
+		d.packetDescription = _AudioStreamPacketDescription{
+			mDataByteSize: uint32(len(d.input)),
+		}
+		*outDataPacketDescription = &d.packetDescription
+	}
+	d.input = nil
+	return 0
+}
+
+type audioToolboxDecoder struct {
+	id        uintptr
+	converter uintptr
+	src       _AudioStreamBasicDescription
+	dst       _AudioStreamBasicDescription
+
+	input             []byte
+	packetDescription _AudioStreamPacketDescription
+	pcm               []float32
+}
+
+func newFrameDecoder(config *audioSpecificConfig) (frameDecoder, error) {
+	initOnce.Do(func() {
+		initErr = initializeAudioToolbox()
+	})
+	if initErr != nil {
+		return nil, initErr
+	}
+
+	channels := config.channelConfiguration
+	if channels == 0 || channels == 7 {
+		return nil, fmt.Errorf("aac: unsupported channel configuration: %d", config.channelConfiguration)
+	}
+
+	d := &audioToolboxDecoder{}
+	d.src = _AudioStreamBasicDescription{
+		mSampleRate:       float64(config.outputSampleRate()),
+		mFormatID:         kAudioFormatMPEG4AAC,
+		mFramesPerPacket:  uint32(config.frameLength),
+		mChannelsPerFrame: uint32(channels),
+	}
+	switch config.extensionObjectType {
+	case objectTypeSBR:
+		d.src.mFormatID = kAudioFormatMPEG4AAC_HE
+		d.src.mFramesPerPacket *= 2
+	case objectTypePS:
+		d.src.mFormatID = kAudioFormatMPEG4AAC_HE_V2
+		d.src.mFramesPerPacket *= 2
+	}
+
+	dstChannels := min(channels, 2)
+	d.dst = _AudioStreamBasicDescription{
+		mSampleRate:       d.src.mSampleRate,
+		mFormatID:         kAudioFormatLinearPCM,
+		mFormatFlags:      kAudioFormatFlagIsFloat | kAudioFormatFlagIsPacked,
+		mBytesPerPacket:   uint32(4 * dstChannels),
+		mFramesPerPacket:  1,
+		mBytesPerFrame:    uint32(4 * dstChannels),
+		mChannelsPerFrame: uint32(dstChannels),
+		mBitsPerChannel:   32,
+	}
+	if status := _AudioConverterNew(&d.src, &d.dst, &d.converter); status != 0 {
+		return nil, fmt.Errorf("aac: AudioConverterNew failed: %d", status)
+	}
+
+	cookie := config.esds()
+	if status := _AudioConverterSetProperty(d.converter, kAudioConverterDecompressionMagicCookie, uint32(len(cookie)), unsafe.Pointer(&cookie[0])); status != 0 {
+		d.close()
+		return nil, fmt.Errorf("aac: AudioConverterSetProperty failed: %d", status)
+	}
+	runtime.KeepAlive(cookie)
+
+	d.pcm = make([]float32, int(d.src.mFramesPerPacket)*dstChannels)
+
+	decodersM.Lock()
+	d.id = nextDecoderID
+	nextDecoderID++
+	theDecoders[d.id] = d
+	decodersM.Unlock()
+
+	return d, nil
+}
+
+func (d *audioToolboxDecoder) decode(dst []float32, frame []byte) ([]float32, error) {
+	if len(frame) == 0 {
+		return dst, nil
+	}
+
+	var pinner runtime.Pinner
+	defer pinner.Unpin()
+	pinner.Pin(&frame[0])
+	pinner.Pin(&d.packetDescription)
+	pinner.Pin(&d.pcm[0])
+
+	d.input = frame
+	defer func() {
+		d.input = nil
+	}()
+
+	bufs := _AudioBufferList{
+		mNumberBuffers: 1,
+		mBuffers: [1]_AudioBuffer{
+			{
+				mNumberChannels: d.dst.mChannelsPerFrame,
+				mDataByteSize:   uint32(len(d.pcm) * 4),
+				mData:           unsafe.Pointer(&d.pcm[0]),
+			},
+		},
+	}
+	n := uint32(len(d.pcm)) / d.dst.mChannelsPerFrame
+	if status := _AudioConverterFillComplexBuffer(d.converter, inputDataProc, d.id, &n, &bufs, nil); status != 0 && status != noMoreInputData {
+		return nil, fmt.Errorf("aac: AudioConverterFillComplexBuffer failed: %d", status)
+	}
+	return append(dst, d.pcm[:int(n)*int(d.dst.mChannelsPerFrame)]...), nil
+}
+
+func (d *audioToolboxDecoder) reset() error {
+	if status := _AudioConverterReset(d.converter); status != 0 {
+		return fmt.Errorf("aac: AudioConverterReset failed: %d", status)
+	}
+	return nil
+}
+
+func (d *audioToolboxDecoder) format() (sampleRate, channels int) {
+	return int(d.dst.mSampleRate), int(d.dst.mChannelsPerFrame)
+}
+
+func (d *audioToolboxDecoder) close() {
+	decodersM.Lock()
+	delete(theDecoders, d.id)
+	decodersM.Unlock()
+
+	if d.converter == 0 {
+		return
+	}
+	_AudioConverterDispose(d.converter)
+	d.converter = 0
+}
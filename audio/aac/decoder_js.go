@@ -0,0 +1,200 @@
+// Copyright 2026 The Ebitengine Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package aac
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"syscall/js"
+)
+
+// The decoder uses AudioDecoder of WebCodecs.
+// See https://developer.mozilla.org/en-US/docs/Web/API/AudioDecoder
+
+var (
+	audioDecoder      = js.Global().Get("AudioDecoder")
+	encodedAudioChunk = js.Global().Get("EncodedAudioChunk")
+	uint8Array        = js.Global().Get("Uint8Array")
+)
+
+type webCodecsDecoder struct {
+	decoder js.Value
+	output  js.Func
+	error   js.Func
+
+	audioData []js.Value
+	err       error
+
+	timestamp  int
+	sampleRate int
+	channels   int
+	buf        []byte
+}
+
+func newFrameDecoder(config *audioSpecificConfig) (frameDecoder, error) {
+	if !audioDecoder.Truthy() || !encodedAudioChunk.Truthy() {
+		return nil, fmt.Errorf("aac: WebCodecs is not available: %w", errors.ErrUnsupported)
+	}
+
+	d := &webCodecsDecoder{}
+	d.output = js.FuncOf(func(this js.Value, args []js.Value) any {
+		d.audioData = append(d.audioData, args[0])
+		return nil
+	})
+	d.error = js.FuncOf(func(this js.Value, args []js.Value) any {
+		d.err = fmt.Errorf("aac: AudioDecoder failed: %s", args[0].Call("toString").String())
+		return nil
+	})
+	d.decoder = audioDecoder.New(map[string]any{
+		"output": d.output,
+		"error":  d.error,
+	})
+
+	objectType := config.objectType
+	if config.extensionObjectType != 0 {
+		objectType = config.extensionObjectType
+	}
+	channels := config.channelConfiguration
+	if channels == 0 {
+		channels = 2
+	}
+	description := uint8Array.New(len(config.raw))
+	js.CopyBytesToJS(description, config.raw)
+	c := map[string]any{
+		"codec":            fmt.Sprintf("mp4a.40.%d", objectType),
+		"sampleRate":       config.outputSampleRate(),
+		"numberOfChannels": channels,
+		"description":      description,
+	}
+
+	// isConfigSupported is not used as it is asynchronous. configure throws an exception for an invalid configuration.
+	if err := d.configure(c); err != nil {
+		d.close()
+		return nil, err
+	}
+	return d, nil
+}
+
+func (d *webCodecsDecoder) configure(config map[string]any) (err error) {
+	defer func() {
+		if e := recover(); e != nil {
+			err = fmt.Errorf("aac: AudioDecoder.configure failed: %v: %w", e, errors.ErrUnsupported)
+		}
+	}()
+	d.decoder.Call("configure", config)
+	return nil
+}
+
+// flush waits for the pending frames to be decoded.
+func (d *webCodecsDecoder) flush() error {
+	ch := make(chan error, 1)
+	then := js.FuncOf(func(this js.Value, args []js.Value) any {
+		ch <- nil
+		return nil
+	})
+	defer then.Release()
+	catch := js.FuncOf(func(this js.Value, args []js.Value) any {
+		ch <- fmt.Errorf("aac: AudioDecoder.flush failed: %s", args[0].Call("toString").String())
+		return nil
+	})
+	defer catch.Release()
+
+	d.decoder.Call("flush").Call("then", then).Call("catch", catch)
+	return <-ch
+}
+
+func (d *webCodecsDecoder) decode(dst []float32, frame []byte) ([]float32, error) {
+	if d.err != nil {
+		return nil, d.err
+	}
+	if len(frame) == 0 {
+		return dst, nil
+	}
+
+	data := uint8Array.New(len(frame))
+	js.CopyBytesToJS(data, frame)
+	chunk := encodedAudioChunk.New(map[string]any{
+		"type":      "key",
+		"timestamp": d.timestamp,
+		"data":      data,
+	})
+	d.timestamp++
+	d.decoder.Call("decode", chunk)
+
+	// Waiting for each frame is slow, but this keeps the decoded samples in sync with the frames.
+	if err := d.flush(); err != nil {
+		return nil, err
+	}
+	if d.err != nil {
+		return nil, d.err
+	}
+
+	for _, a := range d.audioData {
+		dst = d.appendAudioData(dst, a)
+		a.Call("close")
+	}
+	d.audioData = d.audioData[:0]
+	return dst, nil
+}
+
+func (d *webCodecsDecoder) appendAudioData(dst []float32, audioData js.Value) []float32 {
+	d.sampleRate = audioData.Get("sampleRate").Int()
+	d.channels = audioData.Get("numberOfChannels").Int()
+	frames := audioData.Get("numberOfFrames").Int()
+
+	size := 4 * frames
+	if cap(d.buf) < size*d.channels {
+		d.buf = make([]byte, size*d.channels)
+	}
+	d.buf = d.buf[:size*d.channels]
+	plane := uint8Array.New(size)
+	for ch := 0; ch < d.channels; ch++ {
+		audioData.Call("copyTo", plane, map[string]any{
+			"planeIndex": ch,
+			"format":     "f32-planar",
+		})
+		js.CopyBytesToGo(d.buf[size*ch:size*(ch+1)], plane)
+	}
+
+	for i := 0; i < frames; i++ {
+		for ch := 0; ch < d.channels; ch++ {
+			b := d.buf[size*ch+4*i:]
+			dst = append(dst, math.Float32frombits(uint32(b[0])|uint32(b[1])<<8|uint32(b[2])<<16|uint32(b[3])<<24))
+		}
+	}
+	return dst
+}
+
+func (d *webCodecsDecoder) reset() error {
+	for _, a := range d.audioData {
+		a.Call("close")
+	}
+	d.audioData = d.audioData[:0]
+	// reset discards the configuration. Flushing is enough to discard the pending frames.
+	return d.flush()
+}
+
+func (d *webCodecsDecoder) format() (sampleRate, channels int) {
+	return d.sampleRate, d.channels
+}
+
+func (d *webCodecsDecoder) close() {
+	if d.decoder.Truthy() && d.decoder.Get("state").String() != "closed" {
+		d.decoder.Call("close")
+	}
+	d.output.Release()
+	d.error.Release()
+}
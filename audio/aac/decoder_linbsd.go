@@ -0,0 +1,190 @@
+// Copyright 2026 The Ebitengine Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build (freebsd || linux || netbsd || openbsd) && !android && !nintendosdk && !playstation5
+
+package aac
+
+import (
+	"errors"
+	"fmt"
+	"runtime"
+	"sync"
+
+	"github.com/ebitengine/purego"
+)
+
+// The decoder uses libfdk-aac.
+// See https://github.com/mstorsjo/fdk-aac/blob/master/libAACdec/include/aacdecoder_lib.h
+
+const (
+	_TT_MP4_RAW = 0
+
+	_AAC_PCM_MAX_OUTPUT_CHANNELS = 0x0011
+	_AAC_TPDEC_CLEAR_BUFFER      = 0x0603
+
+	_AACDEC_INTR    = 4
+	_AACDEC_CLRHIST = 8
+
+	_AAC_DEC_NOT_ENOUGH_BITS = 0x1002
+)
+
+// _CStreamInfo is the beginning of CStreamInfo. Only the first fields are used.
+type _CStreamInfo struct {
+	sampleRate  int32
+	frameSize   int32
+	numChannels int32
+}
+
+var (
+	_aacDecoder_Open          func(transportFmt int32, nrOfLayers uint32) uintptr
+	_aacDecoder_ConfigRaw     func(self uintptr, conf **byte, length *uint32) int32
+	_aacDecoder_SetParam      func(self uintptr, param int32, value int32) int32
+	_aacDecoder_Fill          func(self uintptr, pBuffer **byte, bufferSize *uint32, bytesValid *uint32) int32
+	_aacDecoder_DecodeFrame   func(self uintptr, pTimeData *int16, timeDataSize int32, flags uint32) int32
+	_aacDecoder_GetStreamInfo func(self uintptr) *_CStreamInfo
+	_aacDecoder_Close         func(self uintptr)
+)
+
+var (
+	initOnce sync.Once
+	initErr  error
+)
+
+func initializeFDKAAC() error {
+	var errs []error
+	var lib uintptr
+	for _, name := range []string{"libfdk-aac.so.2", "libfdk-aac.so.1", "libfdk-aac.so"} {
+		l, err := purego.Dlopen(name, purego.RTLD_LAZY|purego.RTLD_GLOBAL)
+		if err == nil {
+			lib = l
+			break
+		}
+		errs = append(errs, fmt.Errorf("aac: Dlopen failed: name: %s: %w", name, err))
+	}
+	if lib == 0 {
+		errs = append([]error{fmt.Errorf("aac: failed to load libfdk-aac.so: %w", errors.ErrUnsupported)}, errs...)
+		return errors.Join(errs...)
+	}
+
+	purego.RegisterLibFunc(&_aacDecoder_Open, lib, "aacDecoder_Open")
+	purego.RegisterLibFunc(&_aacDecoder_ConfigRaw, lib, "aacDecoder_ConfigRaw")
+	purego.RegisterLibFunc(&_aacDecoder_SetParam, lib, "aacDecoder_SetParam")
+	purego.RegisterLibFunc(&_aacDecoder_Fill, lib, "aacDecoder_Fill")
+	purego.RegisterLibFunc(&_aacDecoder_DecodeFrame, lib, "aacDecoder_DecodeFrame")
+	purego.RegisterLibFunc(&_aacDecoder_GetStreamInfo, lib, "aacDecoder_GetStreamInfo")
+	purego.RegisterLibFunc(&_aacDecoder_Close, lib, "aacDecoder_Close")
+	return nil
+}
+
+type fdkDecoder struct {
+	handle     uintptr
+	pcm        []int16
+	flags      uint32
+	sampleRate int
+	channels   int
+}
+
+func newFrameDecoder(config *audioSpecificConfig) (frameDecoder, error) {
+	initOnce.Do(func() {
+		initErr = initializeFDKAAC()
+	})
+	if initErr != nil {
+		return nil, initErr
+	}
+
+	h := _aacDecoder_Open(_TT_MP4_RAW, 1)
+	if h == 0 {
+		return nil, errors.New("aac: aacDecoder_Open failed")
+	}
+	d := &fdkDecoder{
+		handle: h,
+		// 2048 samples per channel at most for HE-AAC.
+		pcm: make([]int16, 2048*8),
+	}
+
+	conf := &config.raw[0]
+	length := uint32(len(config.raw))
+	if err := _aacDecoder_ConfigRaw(h, &conf, &length); err != 0 {
+		d.close()
+		return nil, fmt.Errorf("aac: aacDecoder_ConfigRaw failed: 0x%x", err)
+	}
+	runtime.KeepAlive(config.raw)
+
+	// Downmix the channels to stereo.
+	if err := _aacDecoder_SetParam(h, _AAC_PCM_MAX_OUTPUT_CHANNELS, 2); err != 0 {
+		d.close()
+		return nil, fmt.Errorf("aac: aacDecoder_SetParam failed: 0x%x", err)
+	}
+	return d, nil
+}
+
+func (d *fdkDecoder) decode(dst []float32, frame []byte) ([]float32, error) {
+	if len(frame) == 0 {
+		return dst, nil
+	}
+
+	buf := &frame[0]
+	size := uint32(len(frame))
+	valid := size
+	if err := _aacDecoder_Fill(d.handle, &buf, &size, &valid); err != 0 {
+		return nil, fmt.Errorf("aac: aacDecoder_Fill failed: 0x%x", err)
+	}
+	runtime.KeepAlive(frame)
+
+	err := _aacDecoder_DecodeFrame(d.handle, &d.pcm[0], int32(len(d.pcm)), d.flags)
+	d.flags = 0
+	if err == _AAC_DEC_NOT_ENOUGH_BITS {
+		return dst, nil
+	}
+	// Decode errors (0x4000-0x4fff) are concealed and the output is still available.
+	if err != 0 && (err < 0x4000 || err > 0x4fff) {
+		return nil, fmt.Errorf("aac: aacDecoder_DecodeFrame failed: 0x%x", err)
+	}
+
+	info := _aacDecoder_GetStreamInfo(d.handle)
+	if info == nil {
+		return nil, errors.New("aac: aacDecoder_GetStreamInfo failed")
+	}
+	d.sampleRate = int(info.sampleRate)
+	d.channels = int(info.numChannels)
+	n := int(info.frameSize) * d.channels
+	if n > len(d.pcm) {
+		return nil, fmt.Errorf("aac: too many samples in a frame: %d", n)
+	}
+	for _, v := range d.pcm[:n] {
+		dst = append(dst, float32(v)/(1<<15))
+	}
+	return dst, nil
+}
+
+func (d *fdkDecoder) reset() error {
+	if err := _aacDecoder_SetParam(d.handle, _AAC_TPDEC_CLEAR_BUFFER, 1); err != 0 {
+		return fmt.Errorf("aac: aacDecoder_SetParam failed: 0x%x", err)
+	}
+	d.flags = _AACDEC_INTR | _AACDEC_CLRHIST
+	return nil
+}
+
+func (d *fdkDecoder) format() (sampleRate, channels int) {
+	return d.sampleRate, d.channels
+}
+
+func (d *fdkDecoder) close() {
+	if d.handle == 0 {
+		return
+	}
+	_aacDecoder_Close(d.handle)
+	d.handle = 0
+}
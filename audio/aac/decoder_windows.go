@@ -0,0 +1,633 @@
+// Copyright 2026 The Ebitengine Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package aac
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"runtime"
+	"sync"
+	"syscall"
+	"unsafe"
+
+	"golang.org/x/sys/windows"
+)
+
+// The decoder uses the AAC decoder MFT of Media Foundation.
+// See https://learn.microsoft.com/en-us/windows/win32/medfound/aac-decoder
+
+const (
+	_CLSCTX_INPROC_SERVER = 0x1
+
+	_MF_VERSION                     = 0x00020070
+	_MFSTARTUP_LITE                 = 0x1
+	_MF_E_NO_MORE_TYPES             = 0xc00d36b9
+	_MF_E_TRANSFORM_NEED_MORE_INPUT = 0xc00d6d72
+
+	_MFT_MESSAGE_COMMAND_FLUSH          = 0x00000000
+	_MFT_MESSAGE_NOTIFY_BEGIN_STREAMING = 0x10000000
+	_MFT_MESSAGE_NOTIFY_START_OF_STREAM = 0x10000003
+	_MFT_OUTPUT_STREAM_PROVIDES_SAMPLES = 0x00000100
+)
+
+var (
+	_CLSID_CMSAACDecMFT = windows.GUID{Data1: 0x32d186a7, Data2: 0x218f, Data3: 0x4c75, Data4: [...]byte{0x88, 0x76, 0xdd, 0x77, 0x27, 0x3a, 0x89, 0x99}}
+	_IID_IMFTransform   = windows.GUID{Data1: 0xbf94c121, Data2: 0x5b05, Data3: 0x4e6f, Data4: [...]byte{0x80, 0x00, 0xba, 0x59, 0x89, 0x61, 0x41, 0x4d}}
+
+	_MFMediaType_Audio      = windows.GUID{Data1: 0x73647561, Data2: 0x0000, Data3: 0x0010, Data4: [...]byte{0x80, 0x00, 0x00, 0xaa, 0x00, 0x38, 0x9b, 0x71}}
+	_MFAudioFormat_AAC      = windows.GUID{Data1: 0x00001610, Data2: 0x0000, Data3: 0x0010, Data4: [...]byte{0x80, 0x00, 0x00, 0xaa, 0x00, 0x38, 0x9b, 0x71}}
+	_MFAudioFormat_Float    = windows.GUID{Data1: 0x00000003, Data2: 0x0000, Data3: 0x0010, Data4: [...]byte{0x80, 0x00, 0x00, 0xaa, 0x00, 0x38, 0x9b, 0x71}}
+	_MF_MT_MAJOR_TYPE       = windows.GUID{Data1: 0x48eba18e, Data2: 0xf8c9, Data3: 0x4687, Data4: [...]byte{0xbf, 0x11, 0x0a, 0x74, 0xc9, 0xf9, 0x6a, 0x8f}}
+	_MF_MT_SUBTYPE          = windows.GUID{Data1: 0xf7e34c9a, Data2: 0x42e8, Data3: 0x4714, Data4: [...]byte{0xb7, 0x4b, 0xcb, 0x29, 0xd7, 0x2c, 0x35, 0xe5}}
+	_MF_MT_USER_DATA        = windows.GUID{Data1: 0xb6bc765f, Data2: 0x4c3b, Data3: 0x40a4, Data4: [...]byte{0xbd, 0x51, 0x25, 0x35, 0xb6, 0x6f, 0xe0, 0x9d}}
+	_MF_MT_AAC_PAYLOAD_TYPE = windows.GUID{Data1: 0xbfbabe79, Data2: 0x7434, Data3: 0x4d1c, Data4: [...]byte{0x94, 0xf0, 0x72, 0xa3, 0xb9, 0xe1, 0x71, 0x88}}
+
+	_MF_MT_AUDIO_NUM_CHANNELS       = windows.GUID{Data1: 0x37e48bf5, Data2: 0x645e, Data3: 0x4c5b, Data4: [...]byte{0x89, 0xde, 0xad, 0xa9, 0xe2, 0x9b, 0x69, 0x6a}}
+	_MF_MT_AUDIO_SAMPLES_PER_SECOND = windows.GUID{Data1: 0x5faeeae7, Data2: 0x0290, Data3: 0x4c31, Data4: [...]byte{0x9e, 0x8a, 0xc5, 0x34, 0xf6, 0x8d, 0x9d, 0xba}}
+)
+
+var (
+	ole32  = windows.NewLazySystemDLL("ole32.dll")
+	mfplat = windows.NewLazySystemDLL("mfplat.dll")
+
+	procCoCreateInstance     = ole32.NewProc("CoCreateInstance")
+	procCoIncrementMTAUsage  = ole32.NewProc("CoIncrementMTAUsage")
+	procMFStartup            = mfplat.NewProc("MFStartup")
+	procMFCreateMediaType    = mfplat.NewProc("MFCreateMediaType")
+	procMFCreateSample       = mfplat.NewProc("MFCreateSample")
+	procMFCreateMemoryBuffer = mfplat.NewProc("MFCreateMemoryBuffer")
+)
+
+type _MFT_OUTPUT_STREAM_INFO struct {
+	dwFlags     uint32
+	cbSize      uint32
+	cbAlignment uint32
+}
+
+type _MFT_OUTPUT_DATA_BUFFER struct {
+	dwStreamID uint32
+	pSample    *_IMFSample
+	dwStatus   uint32
+	pEvents    uintptr
+}
+
+type _IMFAttributes_Vtbl struct {
+	QueryInterface     uintptr
+	AddRef             uintptr
+	Release            uintptr
+	GetItem            uintptr
+	GetItemType        uintptr
+	CompareItem        uintptr
+	Compare            uintptr
+	GetUINT32          uintptr
+	GetUINT64          uintptr
+	GetDouble          uintptr
+	GetGUID            uintptr
+	GetStringLength    uintptr
+	GetString          uintptr
+	GetAllocatedString uintptr
+	GetBlobSize        uintptr
+	GetBlob            uintptr
+	GetAllocatedBlob   uintptr
+	GetUnknown         uintptr
+	SetItem            uintptr
+	DeleteItem         uintptr
+	DeleteAllItems     uintptr
+	SetUINT32          uintptr
+	SetUINT64          uintptr
+	SetDouble          uintptr
+	SetGUID            uintptr
+	SetString          uintptr
+	SetBlob            uintptr
+	SetUnknown         uintptr
+	LockStore          uintptr
+	UnlockStore        uintptr
+	GetCount           uintptr
+	GetItemByIndex     uintptr
+	CopyAllItems       uintptr
+}
+
+type _IMFMediaType struct {
+	vtbl *_IMFMediaType_Vtbl
+}
+
+type _IMFMediaType_Vtbl struct {
+	_IMFAttributes_Vtbl
+
+	GetMajorType       uintptr
+	IsCompressedFormat uintptr
+	IsEqual            uintptr
+	GetRepresentation  uintptr
+	FreeRepresentation uintptr
+}
+
+func (i *_IMFMediaType) GetUINT32(key *windows.GUID) (uint32, error) {
+	var v uint32
+	r, _, _ := syscall.SyscallN(i.vtbl.GetUINT32, uintptr(unsafe.Pointer(i)), uintptr(unsafe.Pointer(key)), uintptr(unsafe.Pointer(&v)))
+	if uint32(r) != uint32(windows.S_OK) {
+		return 0, fmt.Errorf("aac: IMFAttributes::GetUINT32 failed: HRESULT(%d)", uint32(r))
+	}
+	return v, nil
+}
+
+func (i *_IMFMediaType) GetGUID(key *windows.GUID) (windows.GUID, error) {
+	var v windows.GUID
+	r, _, _ := syscall.SyscallN(i.vtbl.GetGUID, uintptr(unsafe.Pointer(i)), uintptr(unsafe.Pointer(key)), uintptr(unsafe.Pointer(&v)))
+	if uint32(r) != uint32(windows.S_OK) {
+		return windows.GUID{}, fmt.Errorf("aac: IMFAttributes::GetGUID failed: HRESULT(%d)", uint32(r))
+	}
+	return v, nil
+}
+
+func (i *_IMFMediaType) SetUINT32(key *windows.GUID, value uint32) error {
+	r, _, _ := syscall.SyscallN(i.vtbl.SetUINT32, uintptr(unsafe.Pointer(i)), uintptr(unsafe.Pointer(key)), uintptr(value))
+	if uint32(r) != uint32(windows.S_OK) {
+		return fmt.Errorf("aac: IMFAttributes::SetUINT32 failed: HRESULT(%d)", uint32(r))
+	}
+	return nil
+}
+
+func (i *_IMFMediaType) SetGUID(key *windows.GUID, value *windows.GUID) error {
+	r, _, _ := syscall.SyscallN(i.vtbl.SetGUID, uintptr(unsafe.Pointer(i)), uintptr(unsafe.Pointer(key)), uintptr(unsafe.Pointer(value)))
+	if uint32(r) != uint32(windows.S_OK) {
+		return fmt.Errorf("aac: IMFAttributes::SetGUID failed: HRESULT(%d)", uint32(r))
+	}
+	return nil
+}
+
+func (i *_IMFMediaType) SetBlob(key *windows.GUID, buf []byte) error {
+	r, _, _ := syscall.SyscallN(i.vtbl.SetBlob, uintptr(unsafe.Pointer(i)), uintptr(unsafe.Pointer(key)), uintptr(unsafe.Pointer(&buf[0])), uintptr(len(buf)))
+	runtime.KeepAlive(buf)
+	if uint32(r) != uint32(windows.S_OK) {
+		return fmt.Errorf("aac: IMFAttributes::SetBlob failed: HRESULT(%d)", uint32(r))
+	}
+	return nil
+}
+
+func (i *_IMFMediaType) Release() {
+	_, _, _ = syscall.SyscallN(i.vtbl.Release, uintptr(unsafe.Pointer(i)))
+}
+
+type _IMFSample struct {
+	vtbl *_IMFSample_Vtbl
+}
+
+type _IMFSample_Vtbl struct {
+	_IMFAttributes_Vtbl
+
+	GetSampleFlags            uintptr
+	SetSampleFlags            uintptr
+	GetSampleTime             uintptr
+	SetSampleTime             uintptr
+	GetSampleDuration         uintptr
+	SetSampleDuration         uintptr
+	GetBufferCount            uintptr
+	GetBufferByIndex          uintptr
+	ConvertToContiguousBuffer uintptr
+	AddBuffer                 uintptr
+	RemoveBufferByIndex       uintptr
+	RemoveAllBuffers          uintptr
+	GetTotalLength            uintptr
+	CopyToBuffer              uintptr
+}
+
+func (i *_IMFSample) AddBuffer(buffer *_IMFMediaBuffer) error {
+	r, _, _ := syscall.SyscallN(i.vtbl.AddBuffer, uintptr(unsafe.Pointer(i)), uintptr(unsafe.Pointer(buffer)))
+	if uint32(r) != uint32(windows.S_OK) {
+		return fmt.Errorf("aac: IMFSample::AddBuffer failed: HRESULT(%d)", uint32(r))
+	}
+	return nil
+}
+
+func (i *_IMFSample) ConvertToContiguousBuffer() (*_IMFMediaBuffer, error) {
+	var buffer *_IMFMediaBuffer
+	r, _, _ := syscall.SyscallN(i.vtbl.ConvertToContiguousBuffer, uintptr(unsafe.Pointer(i)), uintptr(unsafe.Pointer(&buffer)))
+	if uint32(r) != uint32(windows.S_OK) {
+		return nil, fmt.Errorf("aac: IMFSample::ConvertToContiguousBuffer failed: HRESULT(%d)", uint32(r))
+	}
+	return buffer, nil
+}
+
+func (i *_IMFSample) SetSampleTime(time int64) error {
+	r, _, _ := syscall.SyscallN(i.vtbl.SetSampleTime, uintptr(unsafe.Pointer(i)), uintptr(time))
+	if uint32(r) != uint32(windows.S_OK) {
+		return fmt.Errorf("aac: IMFSample::SetSampleTime failed: HRESULT(%d)", uint32(r))
+	}
+	return nil
+}
+
+func (i *_IMFSample) Release() {
+	_, _, _ = syscall.SyscallN(i.vtbl.Release, uintptr(unsafe.Pointer(i)))
+}
+
+type _IMFMediaBuffer struct {
+	vtbl *_IMFMediaBuffer_Vtbl
+}
+
+type _IMFMediaBuffer_Vtbl struct {
+	QueryInterface   uintptr
+	AddRef           uintptr
+	Release          uintptr
+	Lock             uintptr
+	Unlock           uintptr
+	GetCurrentLength uintptr
+	SetCurrentLength uintptr
+	GetMaxLength     uintptr
+}
+
+func (i *_IMFMediaBuffer) Lock() ([]byte, error) {
+	var ptr *byte
+	var maxLength, currentLength uint32
+	r, _, _ := syscall.SyscallN(i.vtbl.Lock, uintptr(unsafe.Pointer(i)), uintptr(unsafe.Pointer(&ptr)), uintptr(unsafe.Pointer(&maxLength)), uintptr(unsafe.Pointer(&currentLength)))
+	if uint32(r) != uint32(windows.S_OK) {
+		return nil, fmt.Errorf("aac: IMFMediaBuffer::Lock failed: HRESULT(%d)", uint32(r))
+	}
+	return unsafe.Slice(ptr, maxLength)[:currentLength], nil
+}
+
+func (i *_IMFMediaBuffer) Unlock() error {
+	r, _, _ := syscall.SyscallN(i.vtbl.Unlock, uintptr(unsafe.Pointer(i)))
+	if uint32(r) != uint32(windows.S_OK) {
+		return fmt.Errorf("aac: IMFMediaBuffer::Unlock failed: HRESULT(%d)", uint32(r))
+	}
+	return nil
+}
+
+func (i *_IMFMediaBuffer) SetCurrentLength(length uint32) error {
+	r, _, _ := syscall.SyscallN(i.vtbl.SetCurrentLength, uintptr(unsafe.Pointer(i)), uintptr(length))
+	if uint32(r) != uint32(windows.S_OK) {
+		return fmt.Errorf("aac: IMFMediaBuffer::SetCurrentLength failed: HRESULT(%d)", uint32(r))
+	}
+	return nil
+}
+
+func (i *_IMFMediaBuffer) Release() {
+	_, _, _ = syscall.SyscallN(i.vtbl.Release, uintptr(unsafe.Pointer(i)))
+}
+
+type _IMFTransform struct {
+	vtbl *_IMFTransform_Vtbl
+}
+
+type _IMFTransform_Vtbl struct {
+	QueryInterface            uintptr
+	AddRef                    uintptr
+	Release                   uintptr
+	GetStreamLimits           uintptr
+	GetStreamCount            uintptr
+	GetStreamIDs              uintptr
+	GetInputStreamInfo        uintptr
+	GetOutputStreamInfo       uintptr
+	GetAttributes             uintptr
+	GetInputStreamAttributes  uintptr
+	GetOutputStreamAttributes uintptr
+	DeleteInputStream         uintptr
+	AddInputStreams           uintptr
+	GetInputAvailableType     uintptr
+	GetOutputAvailableType    uintptr
+	SetInputType              uintptr
+	SetOutputType             uintptr
+	GetInputCurrentType       uintptr
+	GetOutputCurrentType      uintptr
+	GetInputStatus            uintptr
+	GetOutputStatus           uintptr
+	SetOutputBounds           uintptr
+	ProcessEvent              uintptr
+	ProcessMessage            uintptr
+	ProcessInput              uintptr
+	ProcessOutput             uintptr
+}
+
+func (i *_IMFTransform) GetOutputStreamInfo(outputStreamID uint32) (_MFT_OUTPUT_STREAM_INFO, error) {
+	var info _MFT_OUTPUT_STREAM_INFO
+	r, _, _ := syscall.SyscallN(i.vtbl.GetOutputStreamInfo, uintptr(unsafe.Pointer(i)), uintptr(outputStreamID), uintptr(unsafe.Pointer(&info)))
+	if uint32(r) != uint32(windows.S_OK) {
+		return _MFT_OUTPUT_STREAM_INFO{}, fmt.Errorf("aac: IMFTransform::GetOutputStreamInfo failed: HRESULT(%d)", uint32(r))
+	}
+	return info, nil
+}
+
+// GetOutputAvailableType returns nil when there are no more types.
+func (i *_IMFTransform) GetOutputAvailableType(outputStreamID uint32, typeIndex uint32) (*_IMFMediaType, error) {
+	var t *_IMFMediaType
+	r, _, _ := syscall.SyscallN(i.vtbl.GetOutputAvailableType, uintptr(unsafe.Pointer(i)), uintptr(outputStreamID), uintptr(typeIndex), uintptr(unsafe.Pointer(&t)))
+	if uint32(r) == _MF_E_NO_MORE_TYPES {
+		return nil, nil
+	}
+	if uint32(r) != uint32(windows.S_OK) {
+		return nil, fmt.Errorf("aac: IMFTransform::GetOutputAvailableType failed: HRESULT(%d)", uint32(r))
+	}
+	return t, nil
+}
+
+func (i *_IMFTransform) SetInputType(inputStreamID uint32, t *_IMFMediaType) error {
+	r, _, _ := syscall.SyscallN(i.vtbl.SetInputType, uintptr(unsafe.Pointer(i)), uintptr(inputStreamID), uintptr(unsafe.Pointer(t)), 0)
+	if uint32(r) != uint32(windows.S_OK) {
+		return fmt.Errorf("aac: IMFTransform::SetInputType failed: HRESULT(%d)", uint32(r))
+	}
+	return nil
+}
+
+func (i *_IMFTransform) SetOutputType(outputStreamID uint32, t *_IMFMediaType) error {
+	r, _, _ := syscall.SyscallN(i.vtbl.SetOutputType, uintptr(unsafe.Pointer(i)), uintptr(outputStreamID), uintptr(unsafe.Pointer(t)), 0)
+	if uint32(r) != uint32(windows.S_OK) {
+		return fmt.Errorf("aac: IMFTransform::SetOutputType failed: HRESULT(%d)", uint32(r))
+	}
+	return nil
+}
+
+func (i *_IMFTransform) ProcessMessage(message uint32, param uintptr) error {
+	r, _, _ := syscall.SyscallN(i.vtbl.ProcessMessage, uintptr(unsafe.Pointer(i)), uintptr(message), param)
+	if uint32(r) != uint32(windows.S_OK) {
+		return fmt.Errorf("aac: IMFTransform::ProcessMessage failed: HRESULT(%d)", uint32(r))
+	}
+	return nil
+}
+
+func (i *_IMFTransform) ProcessInput(inputStreamID uint32, sample *_IMFSample) error {
+	r, _, _ := syscall.SyscallN(i.vtbl.ProcessInput, uintptr(unsafe.Pointer(i)), uintptr(inputStreamID), uintptr(unsafe.Pointer(sample)), 0)
+	if uint32(r) != uint32(windows.S_OK) {
+		return fmt.Errorf("aac: IMFTransform::ProcessInput failed: HRESULT(%d)", uint32(r))
+	}
+	return nil
+}
+
+// ProcessOutput returns false when the transform needs more input.
+func (i *_IMFTransform) ProcessOutput(buffer *_MFT_OUTPUT_DATA_BUFFER) (bool, error) {
+	var status uint32
+	r, _, _ := syscall.SyscallN(i.vtbl.ProcessOutput, uintptr(unsafe.Pointer(i)), 0, 1, uintptr(unsafe.Pointer(buffer)), uintptr(unsafe.Pointer(&status)))
+	if uint32(r) == _MF_E_TRANSFORM_NEED_MORE_INPUT {
+		return false, nil
+	}
+	if uint32(r) != uint32(windows.S_OK) {
+		return false, fmt.Errorf("aac: IMFTransform::ProcessOutput failed: HRESULT(%d)", uint32(r))
+	}
+	return true, nil
+}
+
+func (i *_IMFTransform) Release() {
+	_, _, _ = syscall.SyscallN(i.vtbl.Release, uintptr(unsafe.Pointer(i)))
+}
+
+var (
+	initOnce sync.Once
+	initErr  error
+)
+
+func initializeMediaFoundation() error {
+	if err := procCoIncrementMTAUsage.Find(); err != nil {
+		return fmt.Errorf("aac: CoIncrementMTAUsage is not available: %w", errors.ErrUnsupported)
+	}
+	if err := mfplat.Load(); err != nil {
+		return fmt.Errorf("aac: Media Foundation is not available: %w", errors.ErrUnsupported)
+	}
+
+	// Keep the multithreaded apartment alive so that the COM objects can be used from any goroutine
+	// without initializing COM on each thread.
+	var cookie uintptr
+	if r, _, _ := procCoIncrementMTAUsage.Call(uintptr(unsafe.Pointer(&cookie))); uint32(r) != uint32(windows.S_OK) {
+		return fmt.Errorf("aac: CoIncrementMTAUsage failed: HRESULT(%d)", uint32(r))
+	}
+	if r, _, _ := procMFStartup.Call(_MF_VERSION, _MFSTARTUP_LITE); uint32(r) != uint32(windows.S_OK) {
+		return fmt.Errorf("aac: MFStartup failed: HRESULT(%d)", uint32(r))
+	}
+	return nil
+}
+
+type mediaFoundationDecoder struct {
+	transform    *_IMFTransform
+	outputSample *_IMFSample
+	outputBuffer *_IMFMediaBuffer
+	sampleRate   int
+	channels     int
+	time         int64
+}
+
+func newFrameDecoder(config *audioSpecificConfig) (frameDecoder, error) {
+	initOnce.Do(func() {
+		initErr = initializeMediaFoundation()
+	})
+	if initErr != nil {
+		return nil, initErr
+	}
+
+	d := &mediaFoundationDecoder{}
+	if err := d.init(config); err != nil {
+		d.close()
+		return nil, err
+	}
+	return d, nil
+}
+
+func (d *mediaFoundationDecoder) init(config *audioSpecificConfig) error {
+	var t *_IMFTransform
+	if r, _, _ := procCoCreateInstance.Call(uintptr(unsafe.Pointer(&_CLSID_CMSAACDecMFT)), 0, _CLSCTX_INPROC_SERVER, uintptr(unsafe.Pointer(&_IID_IMFTransform)), uintptr(unsafe.Pointer(&t))); uint32(r) != uint32(windows.S_OK) {
+		return fmt.Errorf("aac: CoCreateInstance failed: %w: HRESULT(%d)", errors.ErrUnsupported, uint32(r))
+	}
+	d.transform = t
+
+	var inputType *_IMFMediaType
+	if r, _, _ := procMFCreateMediaType.Call(uintptr(unsafe.Pointer(&inputType))); uint32(r) != uint32(windows.S_OK) {
+		return fmt.Errorf("aac: MFCreateMediaType failed: HRESULT(%d)", uint32(r))
+	}
+	defer inputType.Release()
+
+	// MF_MT_USER_DATA is the part of HEAACWAVEINFO after WAVEFORMATEX, followed by AudioSpecificConfig.
+	userData := make([]byte, 12, 12+len(config.raw))
+	binary.LittleEndian.PutUint16(userData[2:], 0xfe) // wAudioProfileLevelIndication: unknown
+	userData = append(userData, config.raw...)
+
+	if err := inputType.SetGUID(&_MF_MT_MAJOR_TYPE, &_MFMediaType_Audio); err != nil {
+		return err
+	}
+	if err := inputType.SetGUID(&_MF_MT_SUBTYPE, &_MFAudioFormat_AAC); err != nil {
+		return err
+	}
+	if err := inputType.SetUINT32(&_MF_MT_AUDIO_SAMPLES_PER_SECOND, uint32(config.sampleRate)); err != nil {
+		return err
+	}
+	if config.channelConfiguration > 0 {
+		if err := inputType.SetUINT32(&_MF_MT_AUDIO_NUM_CHANNELS, uint32(config.channelConfiguration)); err != nil {
+			return err
+		}
+	}
+	if err := inputType.SetUINT32(&_MF_MT_AAC_PAYLOAD_TYPE, 0); err != nil {
+		return err
+	}
+	if err := inputType.SetBlob(&_MF_MT_USER_DATA, userData); err != nil {
+		return err
+	}
+	if err := t.SetInputType(0, inputType); err != nil {
+		return err
+	}
+
+	// Find the output type in 32bit float.
+	for i := uint32(0); ; i++ {
+		outputType, err := t.GetOutputAvailableType(0, i)
+		if err != nil {
+			return err
+		}
+		if outputType == nil {
+			return errors.New("aac: output type in float32 not found")
+		}
+		subtype, err := outputType.GetGUID(&_MF_MT_SUBTYPE)
+		if err != nil || subtype != _MFAudioFormat_Float {
+			outputType.Release()
+			continue
+		}
+		sampleRate, err := outputType.GetUINT32(&_MF_MT_AUDIO_SAMPLES_PER_SECOND)
+		if err != nil {
+			outputType.Release()
+			return err
+		}
+		channels, err := outputType.GetUINT32(&_MF_MT_AUDIO_NUM_CHANNELS)
+		if err != nil {
+			outputType.Release()
+			return err
+		}
+		err = t.SetOutputType(0, outputType)
+		outputType.Release()
+		if err != nil {
+			return err
+		}
+		d.sampleRate = int(sampleRate)
+		d.channels = int(channels)
+		break
+	}
+
+	info, err := t.GetOutputStreamInfo(0)
+	if err != nil {
+		return err
+	}
+	if info.dwFlags&_MFT_OUTPUT_STREAM_PROVIDES_SAMPLES != 0 {
+		return errors.New("aac: the transform providing samples is not supported")
+	}
+	// The buffer size might be smaller than a frame of HE-AAC.
+	size := max(info.cbSize, uint32(2048*4*d.channels))
+	if d.outputSample, d.outputBuffer, err = newMFSample(size); err != nil {
+		return err
+	}
+
+	if err := t.ProcessMessage(_MFT_MESSAGE_NOTIFY_BEGIN_STREAMING, 0); err != nil {
+		return err
+	}
+	if err := t.ProcessMessage(_MFT_MESSAGE_NOTIFY_START_OF_STREAM, 0); err != nil {
+		return err
+	}
+	return nil
+}
+
+func newMFSample(size uint32) (*_IMFSample, *_IMFMediaBuffer, error) {
+	var sample *_IMFSample
+	if r, _, _ := procMFCreateSample.Call(uintptr(unsafe.Pointer(&sample))); uint32(r) != uint32(windows.S_OK) {
+		return nil, nil, fmt.Errorf("aac: MFCreateSample failed: HRESULT(%d)", uint32(r))
+	}
+	var buffer *_IMFMediaBuffer
+	if r, _, _ := procMFCreateMemoryBuffer.Call(uintptr(size), uintptr(unsafe.Pointer(&buffer))); uint32(r) != uint32(windows.S_OK) {
+		sample.Release()
+		return nil, nil, fmt.Errorf("aac: MFCreateMemoryBuffer failed: HRESULT(%d)", uint32(r))
+	}
+	if err := sample.AddBuffer(buffer); err != nil {
+		buffer.Release()
+		sample.Release()
+		return nil, nil, err
+	}
+	return sample, buffer, nil
+}
+
+func (d *mediaFoundationDecoder) decode(dst []float32, frame []byte) ([]float32, error) {
+	if len(frame) == 0 {
+		return dst, nil
+	}
+
+	sample, buffer, err := newMFSample(uint32(len(frame)))
+	if err != nil {
+		return nil, err
+	}
+	defer sample.Release()
+	defer buffer.Release()
+
+	if err := buffer.SetCurrentLength(uint32(len(frame))); err != nil {
+		return nil, err
+	}
+	buf, err := buffer.Lock()
+	if err != nil {
+		return nil, err
+	}
+	copy(buf, frame)
+	if err := buffer.Unlock(); err != nil {
+		return nil, err
+	}
+	// The time is in 100-nanosecond units. The exact value doesn't matter.
+	if err := sample.SetSampleTime(d.time); err != nil {
+		return nil, err
+	}
+	d.time++
+
+	if err := d.transform.ProcessInput(0, sample); err != nil {
+		return nil, err
+	}
+
+	for {
+		if err := d.outputBuffer.SetCurrentLength(0); err != nil {
+			return nil, err
+		}
+		output := _MFT_OUTPUT_DATA_BUFFER{
+			pSample: d.outputSample,
+		}
+		ok, err := d.transform.ProcessOutput(&output)
+		if err != nil {
+			return nil, err
+		}
+		if !ok {
+			break
+		}
+		buf, err := d.outputBuffer.Lock()
+		if err != nil {
+			return nil, err
+		}
+		if len(buf) > 0 {
+			dst = append(dst, unsafe.Slice((*float32)(unsafe.Pointer(&buf[0])), len(buf)/4)...)
+		}
+		if err := d.outputBuffer.Unlock(); err != nil {
+			return nil, err
+		}
+	}
+	return dst, nil
+}
+
+func (d *mediaFoundationDecoder) reset() error {
+	return d.transform.ProcessMessage(_MFT_MESSAGE_COMMAND_FLUSH, 0)
+}
+
+func (d *mediaFoundationDecoder) format() (sampleRate, channels int) {
+	return d.sampleRate, d.channels
+}
+
+func (d *mediaFoundationDecoder) close() {
+	if d.outputBuffer != nil {
+		d.outputBuffer.Release()
+		d.outputBuffer = nil
+	}
+	if d.outputSample != nil {
+		d.outputSample.Release()
+		d.outputSample = nil
+	}
+	if d.transform != nil {
+		d.transform.Release()
+		d.transform = nil
+	}
+}
@@ -0,0 +1,70 @@
+// Copyright 2026 The Ebitengine Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package aac
+
+import (
+	"io"
+)
+
+type Track struct {
+	ObjectType           int
+	SampleRate           int
+	ChannelConfiguration int
+	ExtensionSampleRate  int
+	FrameLength          int
+
+	FrameOffsets []int64
+	FrameSizes   []int
+
+	Timescale int
+	Priming   int64
+	Length    int64
+}
+
+func ParseAudioSpecificConfig(b []byte) (*Track, error) {
+	c, err := parseAudioSpecificConfig(b)
+	if err != nil {
+		return nil, err
+	}
+	return &Track{
+		ObjectType:           c.objectType,
+		SampleRate:           c.sampleRate,
+		ChannelConfiguration: c.channelConfiguration,
+		ExtensionSampleRate:  c.extensionSampleRate,
+		FrameLength:          c.frameLength,
+	}, nil
+}
+
+func OpenTrack(src io.Reader) (*Track, error) {
+	t, _, _, err := openTrack(src)
+	if err != nil {
+		return nil, err
+	}
+	track := &Track{
+		ObjectType:           t.config.objectType,
+		SampleRate:           t.config.sampleRate,
+		ChannelConfiguration: t.config.channelConfiguration,
+		ExtensionSampleRate:  t.config.extensionSampleRate,
+		FrameLength:          t.config.frameLength,
+		Timescale:            t.timescale,
+		Priming:              t.priming,
+		Length:               t.length,
+	}
+	for _, f := range t.frames {
+		track.FrameOffsets = append(track.FrameOffsets, f.offset)
+		track.FrameSizes = append(track.FrameSizes, f.size)
+	}
+	return track, nil
+}
@@ -0,0 +1,546 @@
+// Copyright 2026 The Ebitengine Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package aac
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+)
+
+// frame is the position of an access unit in the source.
+type frame struct {
+	offset int64
+	size   int
+}
+
+// track is an AAC track in a container.
+type track struct {
+	config *audioSpecificConfig
+
+	// frames is the positions of the access units. frames is nil when the positions are unknown.
+	frames []frame
+
+	// timescale is the unit of priming and length in samples per second.
+	timescale int
+
+	// priming is the number of samples to be discarded at the beginning.
+	priming int64
+
+	// length is the number of the valid samples. 0 means that the length is unknown.
+	length int64
+}
+
+// mp4Box is a box in an ISO base media file.
+type mp4Box struct {
+	typ  string
+	body []byte
+}
+
+func readMP4Boxes(b []byte) ([]mp4Box, error) {
+	var boxes []mp4Box
+	for len(b) > 0 {
+		if len(b) < 8 {
+			return nil, errors.New("aac: invalid MP4 box")
+		}
+		size := uint64(binary.BigEndian.Uint32(b))
+		typ := string(b[4:8])
+		header := uint64(8)
+		switch size {
+		case 0:
+			size = uint64(len(b))
+		case 1:
+			if len(b) < 16 {
+				return nil, errors.New("aac: invalid MP4 box")
+			}
+			size = binary.BigEndian.Uint64(b[8:])
+			header = 16
+		}
+		if size < header || size > uint64(len(b)) {
+			return nil, fmt.Errorf("aac: invalid MP4 box size: %q", typ)
+		}
+		boxes = append(boxes, mp4Box{
+			typ:  typ,
+			body: b[header:size],
+		})
+		b = b[size:]
+	}
+	return boxes, nil
+}
+
+// findMP4Box finds the first box at the path.
+func findMP4Box(b []byte, path ...string) ([]byte, bool) {
+	boxes, err := readMP4Boxes(b)
+	if err != nil {
+		return nil, false
+	}
+	for _, box := range boxes {
+		if box.typ != path[0] {
+			continue
+		}
+		if len(path) == 1 {
+			return box.body, true
+		}
+		return findMP4Box(box.body, path[1:]...)
+	}
+	return nil, false
+}
+
+// readMoov reads the moov box from the source.
+func readMoov(r io.ReadSeeker) ([]byte, error) {
+	var pos int64
+	var fragmented bool
+	for {
+		if _, err := r.Seek(pos, io.SeekStart); err != nil {
+			return nil, err
+		}
+		var header [16]byte
+		if _, err := io.ReadFull(r, header[:8]); err != nil {
+			if err == io.EOF || err == io.ErrUnexpectedEOF {
+				return nil, errors.New("aac: moov box not found")
+			}
+			return nil, err
+		}
+		size := int64(binary.BigEndian.Uint32(header[:]))
+		typ := string(header[4:8])
+		headerSize := int64(8)
+		switch size {
+		case 0:
+			end, err := r.Seek(0, io.SeekEnd)
+			if err != nil {
+				return nil, err
+			}
+			size = end - pos
+		case 1:
+			if _, err := io.ReadFull(r, header[8:16]); err != nil {
+				return nil, err
+			}
+			size = int64(binary.BigEndian.Uint64(header[8:]))
+			headerSize = 16
+		}
+		if size < headerSize {
+			return nil, fmt.Errorf("aac: invalid MP4 box size: %q", typ)
+		}
+
+		switch typ {
+		case "moov":
+			if size > 1<<30 {
+				return nil, errors.New("aac: moov box is too big")
+			}
+			body := make([]byte, size-headerSize)
+			if _, err := io.ReadFull(r, body); err != nil {
+				return nil, err
+			}
+			if _, ok := findMP4Box(body, "mvex"); ok {
+				fragmented = true
+				break
+			}
+			return body, nil
+		case "moof":
+			fragmented = true
+		}
+		if fragmented {
+			return nil, fmt.Errorf("aac: fragmented MP4 is not supported: %w", errors.ErrUnsupported)
+		}
+		pos += size
+	}
+}
+
+// parseMP4 parses an MP4 (M4A) file and returns the first AAC track.
+func parseMP4(r io.ReadSeeker) (*track, error) {
+	moov, err := readMoov(r)
+	if err != nil {
+		return nil, err
+	}
+	boxes, err := readMP4Boxes(moov)
+	if err != nil {
+		return nil, err
+	}
+
+	movieTimescale := 0
+	if mvhd, ok := findMP4Box(moov, "mvhd"); ok {
+		movieTimescale, _ = parseTimescale(mvhd)
+	}
+
+	var found bool
+	for _, box := range boxes {
+		if box.typ != "trak" {
+			continue
+		}
+		hdlr, ok := findMP4Box(box.body, "mdia", "hdlr")
+		if !ok || len(hdlr) < 12 || string(hdlr[8:12]) != "soun" {
+			continue
+		}
+		found = true
+		t, err := parseMP4Track(box.body, movieTimescale)
+		if err != nil {
+			// Try the next audio track.
+			continue
+		}
+		if t.length == 0 {
+			t.priming, t.length = parseITunSMPB(moov)
+		}
+		return t, nil
+	}
+	if found {
+		return nil, fmt.Errorf("aac: AAC track not found: %w", errors.ErrUnsupported)
+	}
+	return nil, errors.New("aac: audio track not found")
+}
+
+// parseTimescale parses the timescale and the duration of mvhd or mdhd.
+func parseTimescale(b []byte) (timescale int, duration int64) {
+	if len(b) < 4 {
+		return 0, 0
+	}
+	if b[0] == 1 {
+		if len(b) < 32 {
+			return 0, 0
+		}
+		return int(binary.BigEndian.Uint32(b[20:])), int64(binary.BigEndian.Uint64(b[24:]))
+	}
+	if len(b) < 20 {
+		return 0, 0
+	}
+	return int(binary.BigEndian.Uint32(b[12:])), int64(binary.BigEndian.Uint32(b[16:]))
+}
+
+func parseMP4Track(trak []byte, movieTimescale int) (*track, error) {
+	mdhd, ok := findMP4Box(trak, "mdia", "mdhd")
+	if !ok {
+		return nil, errors.New("aac: mdhd box not found")
+	}
+	timescale, _ := parseTimescale(mdhd)
+	if timescale == 0 {
+		return nil, errors.New("aac: invalid timescale")
+	}
+
+	stbl, ok := findMP4Box(trak, "mdia", "minf", "stbl")
+	if !ok {
+		return nil, errors.New("aac: stbl box not found")
+	}
+	stsd, ok := findMP4Box(stbl, "stsd")
+	if !ok || len(stsd) < 8 {
+		return nil, errors.New("aac: stsd box not found")
+	}
+	entries, err := readMP4Boxes(stsd[8:])
+	if err != nil {
+		return nil, err
+	}
+	if len(entries) == 0 || entries[0].typ != "mp4a" {
+		return nil, fmt.Errorf("aac: unsupported sample entry: %w", errors.ErrUnsupported)
+	}
+	config, err := parseMP4AudioSampleEntry(entries[0].body)
+	if err != nil {
+		return nil, err
+	}
+
+	frames, err := parseSampleTable(stbl)
+	if err != nil {
+		return nil, err
+	}
+
+	t := &track{
+		config:    config,
+		frames:    frames,
+		timescale: timescale,
+	}
+	if elst, ok := findMP4Box(trak, "edts", "elst"); ok && movieTimescale > 0 {
+		t.priming, t.length = parseEditList(elst, timescale, movieTimescale)
+	}
+	return t, nil
+}
+
+func parseMP4AudioSampleEntry(b []byte) (*audioSpecificConfig, error) {
+	// The fields of AudioSampleEntry, and QuickTime's SoundDescription.
+	if len(b) < 28 {
+		return nil, errors.New("aac: invalid mp4a box")
+	}
+	version := binary.BigEndian.Uint16(b[8:])
+	channels := int(binary.BigEndian.Uint16(b[16:]))
+	sampleRate := int(binary.BigEndian.Uint32(b[24:]) >> 16)
+	children := b[28:]
+	switch version {
+	case 1:
+		if len(children) < 16 {
+			return nil, errors.New("aac: invalid mp4a box")
+		}
+		children = children[16:]
+	case 2:
+		if len(children) < 36 {
+			return nil, errors.New("aac: invalid mp4a box")
+		}
+		children = children[36:]
+	}
+
+	esds, ok := findMP4Box(children, "esds")
+	if !ok {
+		esds, ok = findMP4Box(children, "wave", "esds")
+	}
+	if !ok || len(esds) < 4 {
+		return nil, errors.New("aac: esds box not found")
+	}
+	return parseESDescriptor(esds[4:], sampleRate, channels)
+}
+
+// readDescriptor reads an MPEG-4 descriptor defined in ISO/IEC 14496-1.
+func readDescriptor(b []byte) (tag byte, body []byte, rest []byte, err error) {
+	if len(b) < 2 {
+		return 0, nil, nil, errors.New("aac: invalid descriptor")
+	}
+	tag = b[0]
+	b = b[1:]
+	var size int
+	for i := 0; ; i++ {
+		if i >= 4 || len(b) == 0 {
+			return 0, nil, nil, errors.New("aac: invalid descriptor")
+		}
+		size = size<<7 | int(b[0]&0x7f)
+		more := b[0]&0x80 != 0
+		b = b[1:]
+		if !more {
+			break
+		}
+	}
+	if size > len(b) {
+		return 0, nil, nil, errors.New("aac: invalid descriptor size")
+	}
+	return tag, b[:size], b[size:], nil
+}
+
+func parseESDescriptor(b []byte, sampleRate, channels int) (*audioSpecificConfig, error) {
+	tag, es, _, err := readDescriptor(b)
+	if err != nil {
+		return nil, err
+	}
+	if tag != 0x03 || len(es) < 3 {
+		return nil, errors.New("aac: ES_Descriptor not found")
+	}
+	flags := es[2]
+	es = es[3:]
+	if flags&0x80 != 0 {
+		// dependsOn_ES_ID
+		es = es[min(2, len(es)):]
+	}
+	if flags&0x40 != 0 && len(es) > 0 {
+		// URL
+		es = es[min(1+int(es[0]), len(es)):]
+	}
+	if flags&0x20 != 0 {
+		// OCR_ES_Id
+		es = es[min(2, len(es)):]
+	}
+
+	for len(es) > 0 {
+		tag, dc, rest, err := readDescriptor(es)
+		if err != nil {
+			return nil, err
+		}
+		es = rest
+		if tag != 0x04 {
+			continue
+		}
+		if len(dc) < 13 {
+			return nil, errors.New("aac: invalid DecoderConfigDescriptor")
+		}
+		oti := dc[0]
+		dc = dc[13:]
+
+		switch oti {
+		case 0x40:
+			// MPEG-4 audio
+		case 0x66, 0x67, 0x68:
+			// MPEG-2 AAC Main, LC and SSR
+			if len(dc) == 0 {
+				return newAudioSpecificConfigFromSampleRate(int(oti)-0x65, sampleRate, channels)
+			}
+		default:
+			return nil, fmt.Errorf("aac: unsupported object type indication: 0x%02x: %w", oti, errors.ErrUnsupported)
+		}
+
+		for len(dc) > 0 {
+			tag, dsi, rest, err := readDescriptor(dc)
+			if err != nil {
+				return nil, err
+			}
+			dc = rest
+			if tag == 0x05 {
+				return parseAudioSpecificConfig(bytes.Clone(dsi))
+			}
+		}
+		return nil, errors.New("aac: DecoderSpecificInfo not found")
+	}
+	return nil, errors.New("aac: DecoderConfigDescriptor not found")
+}
+
+func newAudioSpecificConfigFromSampleRate(objectType, sampleRate, channels int) (*audioSpecificConfig, error) {
+	for i, f := range samplingFrequencies {
+		if f == sampleRate {
+			return newAudioSpecificConfig(objectType, i, channels)
+		}
+	}
+	return nil, fmt.Errorf("aac: unsupported sample rate: %d", sampleRate)
+}
+
+// parseSampleTable returns the positions of the samples from the sample table.
+func parseSampleTable(stbl []byte) ([]frame, error) {
+	// Sample sizes
+	stsz, ok := findMP4Box(stbl, "stsz")
+	if !ok || len(stsz) < 12 {
+		return nil, errors.New("aac: stsz box not found")
+	}
+	uniformSize := int(binary.BigEndian.Uint32(stsz[4:]))
+	count := int(binary.BigEndian.Uint32(stsz[8:]))
+	if uniformSize == 0 && len(stsz)-12 < count*4 {
+		return nil, errors.New("aac: invalid stsz box")
+	}
+	frames := make([]frame, count)
+	for i := range frames {
+		if uniformSize != 0 {
+			frames[i].size = uniformSize
+			continue
+		}
+		frames[i].size = int(binary.BigEndian.Uint32(stsz[12+4*i:]))
+	}
+
+	// Chunk offsets
+	var chunkOffsets []int64
+	if stco, ok := findMP4Box(stbl, "stco"); ok && len(stco) >= 8 {
+		n := int(binary.BigEndian.Uint32(stco[4:]))
+		if len(stco)-8 < n*4 {
+			return nil, errors.New("aac: invalid stco box")
+		}
+		chunkOffsets = make([]int64, n)
+		for i := range chunkOffsets {
+			chunkOffsets[i] = int64(binary.BigEndian.Uint32(stco[8+4*i:]))
+		}
+	} else if co64, ok := findMP4Box(stbl, "co64"); ok && len(co64) >= 8 {
+		n := int(binary.BigEndian.Uint32(co64[4:]))
+		if len(co64)-8 < n*8 {
+			return nil, errors.New("aac: invalid co64 box")
+		}
+		chunkOffsets = make([]int64, n)
+		for i := range chunkOffsets {
+			chunkOffsets[i] = int64(binary.BigEndian.Uint64(co64[8+8*i:]))
+		}
+	} else {
+		return nil, errors.New("aac: chunk offsets not found")
+	}
+
+	// Samples per chunk
+	stsc, ok := findMP4Box(stbl, "stsc")
+	if !ok || len(stsc) < 8 {
+		return nil, errors.New("aac: stsc box not found")
+	}
+	n := int(binary.BigEndian.Uint32(stsc[4:]))
+	if len(stsc)-8 < n*12 {
+		return nil, errors.New("aac: invalid stsc box")
+	}
+
+	var sampleIndex int
+	for i := 0; i < n; i++ {
+		e := stsc[8+12*i:]
+		firstChunk := int(binary.BigEndian.Uint32(e)) - 1
+		samplesPerChunk := int(binary.BigEndian.Uint32(e[4:]))
+		lastChunk := len(chunkOffsets)
+		if i+1 < n {
+			lastChunk = int(binary.BigEndian.Uint32(stsc[8+12*(i+1):])) - 1
+		}
+		if firstChunk < 0 || lastChunk > len(chunkOffsets) {
+			return nil, errors.New("aac: invalid stsc box")
+		}
+		for c := firstChunk; c < lastChunk; c++ {
+			offset := chunkOffsets[c]
+			for j := 0; j < samplesPerChunk && sampleIndex < len(frames); j++ {
+				frames[sampleIndex].offset = offset
+				offset += int64(frames[sampleIndex].size)
+				sampleIndex++
+			}
+		}
+	}
+	if sampleIndex < len(frames) {
+		return nil, errors.New("aac: sample table is inconsistent")
+	}
+	return frames, nil
+}
+
+// parseEditList returns the priming samples and the valid samples in the media timescale.
+func parseEditList(elst []byte, timescale, movieTimescale int) (priming, length int64) {
+	if len(elst) < 8 {
+		return 0, 0
+	}
+	version := elst[0]
+	n := int(binary.BigEndian.Uint32(elst[4:]))
+	b := elst[8:]
+	for i := 0; i < n; i++ {
+		var duration, mediaTime int64
+		if version == 1 {
+			if len(b) < 20 {
+				return 0, 0
+			}
+			duration = int64(binary.BigEndian.Uint64(b))
+			mediaTime = int64(binary.BigEndian.Uint64(b[8:]))
+			b = b[20:]
+		} else {
+			if len(b) < 12 {
+				return 0, 0
+			}
+			duration = int64(binary.BigEndian.Uint32(b))
+			mediaTime = int64(int32(binary.BigEndian.Uint32(b[4:])))
+			b = b[12:]
+		}
+		// An empty edit (mediaTime = -1) is ignored.
+		if mediaTime < 0 {
+			continue
+		}
+		return mediaTime, duration * int64(timescale) / int64(movieTimescale)
+	}
+	return 0, 0
+}
+
+// parseITunSMPB parses the gapless playback information by iTunes.
+func parseITunSMPB(moov []byte) (priming, length int64) {
+	i := bytes.Index(moov, []byte("iTunSMPB"))
+	if i < 0 {
+		return 0, 0
+	}
+	b := moov[i:]
+	j := bytes.Index(b, []byte("data"))
+	if j < 4 {
+		return 0, 0
+	}
+	size := int(binary.BigEndian.Uint32(b[j-4:]))
+	// The data box has the type indicator and the locale.
+	if size < 16 || j-4+size > len(b) {
+		return 0, 0
+	}
+	fields := strings.Fields(string(b[j+12 : j-4+size]))
+	if len(fields) < 4 {
+		return 0, 0
+	}
+	p, err := strconv.ParseInt(fields[1], 16, 64)
+	if err != nil {
+		return 0, 0
+	}
+	l, err := strconv.ParseInt(fields[3], 16, 64)
+	if err != nil {
+		return 0, 0
+	}
+	return p, l
+}
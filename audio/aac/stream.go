@@ -0,0 +1,352 @@
+// Copyright 2026 The Ebitengine Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package aac
+
+import (
+	"bufio"
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"runtime"
+)
+
+// frameReader reads AAC access units sequentially.
+type frameReader interface {
+	// next returns the next access unit. next returns io.EOF at the end.
+	// The returned slice is valid until the next call of next.
+	next() ([]byte, error)
+}
+
+// indexedFrameReader reads access units at the known positions.
+type indexedFrameReader struct {
+	src    io.ReadSeeker
+	frames []frame
+	index  int
+	buf    []byte
+}
+
+func (r *indexedFrameReader) next() ([]byte, error) {
+	if r.index >= len(r.frames) {
+		return nil, io.EOF
+	}
+	f := r.frames[r.index]
+	if _, err := r.src.Seek(f.offset, io.SeekStart); err != nil {
+		return nil, err
+	}
+	if cap(r.buf) < f.size {
+		r.buf = make([]byte, f.size)
+	}
+	r.buf = r.buf[:f.size]
+	if _, err := io.ReadFull(r.src, r.buf); err != nil {
+		if err == io.EOF || err == io.ErrUnexpectedEOF {
+			// The file is truncated.
+			r.index = len(r.frames)
+			return nil, io.EOF
+		}
+		return nil, err
+	}
+	r.index++
+	return r.buf, nil
+}
+
+func isMP4(head []byte) bool {
+	if len(head) < 8 {
+		return false
+	}
+	switch string(head[4:8]) {
+	case "ftyp", "moov", "mdat", "free", "skip", "wide", "pnot":
+		return true
+	}
+	return false
+}
+
+// openTrack detects the container format and opens the AAC track.
+func openTrack(src io.Reader) (*track, frameReader, *indexedFrameReader, error) {
+	if rs, ok := src.(io.ReadSeeker); ok {
+		var head [8]byte
+		n, err := io.ReadFull(rs, head[:])
+		if err != nil && err != io.ErrUnexpectedEOF {
+			return nil, nil, nil, err
+		}
+		var t *track
+		if isMP4(head[:n]) {
+			t, err = parseMP4(rs)
+		} else {
+			t, err = parseADTS(rs)
+		}
+		if err != nil {
+			return nil, nil, nil, err
+		}
+		r := &indexedFrameReader{
+			src:    rs,
+			frames: t.frames,
+		}
+		return t, r, r, nil
+	}
+
+	br := bufio.NewReader(src)
+	head, err := br.Peek(10)
+	if err != nil && err != io.EOF {
+		return nil, nil, nil, err
+	}
+	if isMP4(head) {
+		// The sample table might be at the end of the file. Read all the data.
+		b, err := io.ReadAll(br)
+		if err != nil {
+			return nil, nil, nil, err
+		}
+		return openTrack(bytes.NewReader(b))
+	}
+
+	if n := id3v2Size(head); n > 0 {
+		if _, err := br.Discard(n); err != nil {
+			return nil, nil, nil, err
+		}
+	}
+	head, err = br.Peek(adtsHeaderSize)
+	if err != nil && err != io.EOF {
+		return nil, nil, nil, err
+	}
+	h, err := parseADTSHeader(head)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	c, err := newAudioSpecificConfig(h.objectType, h.samplingFrequencyIndex, h.channelConfiguration)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	t := &track{
+		config:    c,
+		timescale: c.sampleRate,
+	}
+	return t, &adtsFrameReader{src: br}, nil, nil
+}
+
+// stream is a decoded stream in interleaved stereo samples.
+type stream struct {
+	frames  frameReader
+	indexed *indexedFrameReader
+	decoder frameDecoder
+
+	bitDepthInBytes int
+	sampleRate      int
+	channels        int
+	samplesPerFrame int64
+
+	// priming is the number of samples to be discarded at the beginning.
+	priming int64
+
+	// length is the number of the valid samples. 0 means that the length is unknown.
+	length int64
+
+	// decodedPos is the position of the next decoded sample, including the priming samples.
+	decodedPos int64
+
+	// start is the position where samples start to be emitted, including the priming samples.
+	start int64
+
+	posInBytes int64
+	buf        []byte
+	bufPos     int
+	fbuf       []float32
+	eof        bool
+}
+
+func newStream(src io.Reader, bitDepthInBytes int) (*stream, error) {
+	t, frames, indexed, err := openTrack(src)
+	if err != nil {
+		return nil, err
+	}
+	d, err := newFrameDecoder(t.config)
+	if err != nil {
+		return nil, err
+	}
+
+	s := &stream{
+		frames:          frames,
+		indexed:         indexed,
+		decoder:         d,
+		bitDepthInBytes: bitDepthInBytes,
+	}
+	runtime.SetFinalizer(s, (*stream).close)
+
+	// Decode the first frame to determine the format of the decoded samples.
+	f, err := frames.next()
+	if err != nil {
+		if err == io.EOF {
+			return nil, errors.New("aac: no audio frame found")
+		}
+		return nil, err
+	}
+	out, err := d.decode(nil, f)
+	if err != nil {
+		return nil, err
+	}
+	s.sampleRate, s.channels = d.format()
+	if s.channels != 1 && s.channels != 2 {
+		return nil, fmt.Errorf("aac: number of channels must be 1 or 2 but was %d", s.channels)
+	}
+	if s.sampleRate <= 0 {
+		return nil, fmt.Errorf("aac: invalid sample rate: %d", s.sampleRate)
+	}
+	s.samplesPerFrame = int64(t.config.frameLength) * int64(s.sampleRate) / int64(t.config.sampleRate)
+
+	if t.timescale > 0 {
+		s.priming = t.priming * int64(s.sampleRate) / int64(t.timescale)
+		s.length = t.length * int64(s.sampleRate) / int64(t.timescale)
+	}
+	if s.length == 0 && len(t.frames) > 0 {
+		s.length = max(int64(len(t.frames))*s.samplesPerFrame-s.priming, 0)
+	}
+	s.start = s.priming
+
+	if indexed != nil {
+		indexed.index = 0
+		if err := d.reset(); err != nil {
+			return nil, err
+		}
+	} else {
+		s.emit(out)
+	}
+	return s, nil
+}
+
+func (s *stream) close() {
+	s.decoder.close()
+}
+
+func (s *stream) bytesPerSample() int64 {
+	return 2 * int64(s.bitDepthInBytes)
+}
+
+// Read is implementation of io.Reader's Read.
+func (s *stream) Read(buf []byte) (int, error) {
+	if len(buf) == 0 {
+		return 0, nil
+	}
+	for s.bufPos >= len(s.buf) {
+		if s.eof {
+			return 0, io.EOF
+		}
+		if err := s.decodeFrame(); err != nil {
+			return 0, err
+		}
+	}
+	n := copy(buf, s.buf[s.bufPos:])
+	s.bufPos += n
+	s.posInBytes += int64(n)
+	return n, nil
+}
+
+func (s *stream) decodeFrame() error {
+	f, err := s.frames.next()
+	if err != nil {
+		if err == io.EOF {
+			s.eof = true
+			return nil
+		}
+		return err
+	}
+	out, err := s.decoder.decode(s.fbuf[:0], f)
+	if err != nil {
+		return err
+	}
+	s.fbuf = out
+	s.emit(out)
+	return nil
+}
+
+// emit appends the decoded samples in the valid range to the buffer.
+func (s *stream) emit(samples []float32) {
+	if s.bufPos >= len(s.buf) {
+		s.buf = s.buf[:0]
+		s.bufPos = 0
+	}
+
+	n := int64(len(samples) / s.channels)
+	from := max(s.start-s.decodedPos, 0)
+	to := n
+	if s.length > 0 {
+		end := s.priming + s.length
+		to = min(to, end-s.decodedPos)
+		if s.decodedPos+n >= end {
+			s.eof = true
+		}
+	}
+	s.decodedPos += n
+
+	for i := from; i < to; i++ {
+		l := samples[int(i)*s.channels]
+		r := samples[int(i)*s.channels+s.channels-1]
+		s.appendSample(l)
+		s.appendSample(r)
+	}
+}
+
+func (s *stream) appendSample(v float32) {
+	v = max(min(v, 1), -1)
+	if s.bitDepthInBytes == 2 {
+		i := int16(v * (1<<15 - 1))
+		s.buf = append(s.buf, byte(i), byte(i>>8))
+		return
+	}
+	b := math.Float32bits(v)
+	s.buf = append(s.buf, byte(b), byte(b>>8), byte(b>>16), byte(b>>24))
+}
+
+// Seek is implementation of io.Seeker's Seek.
+func (s *stream) Seek(offset int64, whence int) (int64, error) {
+	if s.indexed == nil {
+		return 0, fmt.Errorf("aac: the source must be io.Seeker but not: %w", errors.ErrUnsupported)
+	}
+
+	next := offset
+	switch whence {
+	case io.SeekStart:
+	case io.SeekCurrent:
+		next += s.posInBytes
+	case io.SeekEnd:
+		next += s.Length()
+	default:
+		return 0, errors.New("aac: invalid whence")
+	}
+	if next < 0 {
+		return 0, errors.New("aac: negative position")
+	}
+
+	sample := next / s.bytesPerSample()
+	target := s.priming + sample
+
+	// Decode the previous frame too, as the decoded samples of a frame depend on the previous frame.
+	f := max(target/s.samplesPerFrame-1, 0)
+	s.indexed.index = int(min(f, int64(len(s.indexed.frames))))
+	if err := s.decoder.reset(); err != nil {
+		return 0, err
+	}
+	s.decodedPos = f * s.samplesPerFrame
+	s.start = target
+	s.buf = s.buf[:0]
+	s.bufPos = 0
+	s.eof = s.length > 0 && sample >= s.length
+	s.posInBytes = sample * s.bytesPerSample()
+	return s.posInBytes, nil
+}
+
+// Length returns the size of decoded stream in bytes.
+func (s *stream) Length() int64 {
+	return s.length * s.bytesPerSample()
+}
@@ -42,6 +42,7 @@ import (
 	"time"
 
 	"github.com/duplicants-ai/ebiten/audio/internal/convert"
+	"github.com/duplicants-ai/ebiten/audio/internal/outputdevice"
 	"github.com/duplicants-ai/ebiten/internal/hook"
 )
 
@@ -60,12 +61,23 @@ const (
 type Context struct {
 	playerFactory *playerFactory
 
-	sampleRate int
-	err        error
-	ready      bool
+	sampleRate      int
+	err             error
+	ready           bool
+	unlockRequested bool
 
 	playingPlayers map[*playerImpl]struct{}
 
+	outputDeviceChanged        bool
+	outputDeviceChangedHandler func()
+
+	errorCallback func(err error)
+
+	limiter limiter
+
+	// lastReportedError is the message of the error last passed to errorCallback.
+	lastReportedError string
+
 	m         sync.Mutex
 	semaphore chan struct{}
 }
@@ -122,38 +134,60 @@ func NewContext(sampleRate int) *Context {
 
 	h.AppendHookOnBeforeUpdate(func() error {
 		var err error
+		var ctx *Context
 		theContextLock.Lock()
 		if theContext != nil {
+			ctx = theContext
 			err = theContext.error()
 		}
 		theContextLock.Unlock()
 		if err != nil {
-			return err
+			return ctx.handleError(err)
+		}
+
+		if f := c.takeOutputDeviceChangedHandler(); f != nil {
+			f()
 		}
 
 		// Initialize the context here in the case when there is no player and
 		// the program waits for IsReady() to be true (#969, #970, #2715).
-		ready, err := c.playerFactory.initContextIfNeeded()
-		if err != nil {
-			return err
-		}
-		if ready != nil {
-			go func() {
-				<-ready
-				c.setReady()
-			}()
+		if err := c.initContextIfNeeded(); err != nil {
+			return c.handleError(err)
 		}
 		return nil
 	})
 
+	// On browsers, resuming the audio requires a user gesture.
+	// Try to unlock the audio in a user gesture event handler when requested.
+	h.AppendHookOnUserGesture(func() error {
+		if !c.isUnlockRequested() || c.IsReady() {
+			return nil
+		}
+		if err := c.initContextIfNeeded(); err != nil {
+			return err
+		}
+		return c.playerFactory.resume()
+	})
+
 	// In the current Ebitengine implementation, update might not be called when the window is in background (#3154).
 	// In this case, an audio player position is not updated correctly with AppendHookOnBeforeUpdate.
 	// Use a distinct goroutine to update the player states.
 	go func() {
-		for {
+		var outputDevice string
+		for i := 0; ; i++ {
 			if err := c.updatePlayers(); err != nil {
 				c.setError(err)
-				return
+				if !c.hasErrorCallback() {
+					return
+				}
+			}
+			// Checking the output device might be expensive. Check it once per second.
+			if i%100 == 0 {
+				d := outputdevice.Identity()
+				if i > 0 && d != outputDevice {
+					c.setOutputDeviceChanged()
+				}
+				outputDevice = d
 			}
 			time.Sleep(time.Second / 100)
 		}
@@ -186,6 +220,112 @@ func (c *Context) error() error {
 	return c.playerFactory.error()
 }
 
+// SetErrorCallback sets a function called when an audio error happens,
+// e.g., when initializing the audio device fails or the audio device stops working.
+//
+// Without an error callback, an audio error terminates the game as an error returned from RunGame.
+// With an error callback, an audio error is passed to the callback instead, and the game continues without audio.
+// This is useful to show a user-facing message, e.g., asking the user to check the audio settings.
+// The same error is passed only once.
+//
+// callback is called on the game's goroutine before Update.
+// If callback is nil, the callback is removed.
+//
+// Note that the audio device cannot be initialized again in the same process so far.
+// To retry the initialization, the game has to be restarted.
+//
+// SetErrorCallback is concurrent-safe.
+func (c *Context) SetErrorCallback(callback func(err error)) {
+	c.m.Lock()
+	defer c.m.Unlock()
+	c.errorCallback = callback
+}
+
+func (c *Context) hasErrorCallback() bool {
+	c.m.Lock()
+	defer c.m.Unlock()
+	return c.errorCallback != nil
+}
+
+// handleError passes err to the error callback if exists, and returns nil.
+// If there is no error callback, handleError returns err as it is.
+func (c *Context) handleError(err error) error {
+	c.m.Lock()
+	f := c.errorCallback
+	if f == nil {
+		c.m.Unlock()
+		return err
+	}
+	// c.err is reported only once. An error from the underlying context might be persistent.
+	c.err = nil
+	if c.lastReportedError == err.Error() {
+		c.m.Unlock()
+		return nil
+	}
+	c.lastReportedError = err.Error()
+	c.m.Unlock()
+
+	f(err)
+	return nil
+}
+
+// SetOutputDeviceChangedHandler sets a function called when the audio output devices are changed,
+// e.g., when the default output device is switched or Bluetooth headphones are disconnected.
+// This is useful to show a message or to adjust the audio settings for the new device.
+//
+// handler is called on the game's goroutine before Update.
+// If handler is nil, the handler is removed.
+//
+// On Windows and macOS, the playback follows the new default output device automatically.
+// On browsers, the routing is up to the browser.
+// On Linux, only plugging in or out a sound card is detected.
+// If the device in use is lost and the playback cannot continue, the context reports an error instead.
+// On mobiles, SetOutputDeviceChangedHandler does nothing so far.
+//
+// SetOutputDeviceChangedHandler is concurrent-safe.
+func (c *Context) SetOutputDeviceChangedHandler(handler func()) {
+	c.m.Lock()
+	defer c.m.Unlock()
+	c.outputDeviceChangedHandler = handler
+}
+
+func (c *Context) setOutputDeviceChanged() {
+	c.m.Lock()
+	defer c.m.Unlock()
+	c.outputDeviceChanged = true
+}
+
+// takeOutputDeviceChangedHandler returns the handler to be called if the output devices are changed, and resets the state.
+func (c *Context) takeOutputDeviceChangedHandler() func() {
+	c.m.Lock()
+	defer c.m.Unlock()
+	if !c.outputDeviceChanged {
+		return nil
+	}
+	c.outputDeviceChanged = false
+	return c.outputDeviceChangedHandler
+}
+
+func (c *Context) initContextIfNeeded() error {
+	ready, err := c.playerFactory.initContextIfNeeded()
+	if err != nil {
+		return err
+	}
+	if ready != nil {
+		go func() {
+			<-ready
+			c.setReady()
+		}()
+	}
+	return nil
+}
+
+func (c *Context) isUnlockRequested() bool {
+	c.m.Lock()
+	defer c.m.Unlock()
+	return c.unlockRequested
+}
+
 func (c *Context) setReady() {
 	c.m.Lock()
 	c.ready = true
@@ -299,12 +439,30 @@ func (c *Context) updatePlayers() error {
 // IsReady returns a boolean value indicating whether the audio is ready or not.
 //
 // On some browsers, user interaction like click or pressing keys is required to start audio.
+// IsReady can be used to show a prompt like "tap to enable sound" until the audio is unlocked.
 func (c *Context) IsReady() bool {
 	c.m.Lock()
 	defer c.m.Unlock()
 	return c.ready
 }
 
+// RequestUnlock requests to unlock the audio at the next user gesture like click or pressing keys.
+//
+// On browsers, the audio is locked by the autoplay policy until a user gesture happens.
+// Ebitengine tries to unlock the audio at some user gestures automatically,
+// but calling RequestUnlock makes Ebitengine try to unlock it at any user gesture on the game's canvas.
+// It is recommended to call RequestUnlock e.g. when a "tap to enable sound" prompt is shown.
+// Use IsReady to know whether the audio is unlocked.
+//
+// RequestUnlock does nothing on the other platforms.
+//
+// RequestUnlock is concurrent-safe.
+func (c *Context) RequestUnlock() {
+	c.m.Lock()
+	defer c.m.Unlock()
+	c.unlockRequested = true
+}
+
 // SampleRate returns the sample rate.
 func (c *Context) SampleRate() int {
 	return c.sampleRate
@@ -528,6 +686,7 @@ type hooker interface {
 	OnSuspendAudio(f func() error)
 	OnResumeAudio(f func() error)
 	AppendHookOnBeforeUpdate(f func() error)
+	AppendHookOnUserGesture(f func() error)
 }
 
 var hookerForTesting hooker
@@ -553,6 +712,10 @@ func (h *hookerImpl) AppendHookOnBeforeUpdate(f func() error) {
 	hook.AppendHookOnBeforeUpdate(f)
 }
 
+func (h *hookerImpl) AppendHookOnUserGesture(f func() error) {
+	hook.AppendHookOnUserGesture(f)
+}
+
 // ResampleReader converts the sample rate of the given singed 16bit integer, little-endian, 2 channels (stereo) stream.
 // size is the length of the source stream in bytes.
 // from is the original sample rate.
@@ -587,6 +750,77 @@ func ResampleReaderF32(source io.Reader, size int64, from, to int) io.Reader {
 	return convert.NewResampling(source, size, from, to, bitDepthInBytesFloat32)
 }
 
+// ResampleQuality represents the quality of resampling.
+type ResampleQuality int
+
+const (
+	// ResampleQualityDefault is the quality used by ResampleReader and ResampleReaderF32.
+	ResampleQualityDefault ResampleQuality = ResampleQuality(convert.ResamplingQualityDefault)
+
+	// ResampleQualityLow is the lowest quality with the least CPU usage.
+	ResampleQualityLow ResampleQuality = ResampleQuality(convert.ResamplingQualityLow)
+
+	// ResampleQualityHigh is the highest quality with the most CPU usage.
+	// ResampleQualityHigh suppresses aliasing noises especially on downsampling.
+	ResampleQualityHigh ResampleQuality = ResampleQuality(convert.ResamplingQualityHigh)
+)
+
+// ResampleOptions represents options for ResampleReaderWithOptions and ResampleReaderF32WithOptions.
+type ResampleOptions struct {
+	// Quality is the quality of resampling.
+	//
+	// The default (zero) value is ResampleQualityDefault.
+	Quality ResampleQuality
+}
+
+// ResampleReaderWithOptions converts the sample rate of the given singed 16bit integer, little-endian, 2 channels (stereo) stream
+// with the options.
+// size is the length of the source stream in bytes.
+// from is the original sample rate.
+// to is the target sample rate.
+// options can be nil. In this case, the default options are used.
+//
+// ResampleReaderWithOptions can also be used to change the pitch of a stream,
+// e.g., specifying a from value larger than the actual sample rate raises the pitch.
+//
+// If the original sample rate equals to the new one, ResampleReaderWithOptions returns source as it is.
+//
+// The returned value implements io.Seeker when the source implements io.Seeker.
+// The returned value might implement io.Seeker even when the source doesn't implement io.Seeker, but
+// there is no guarantee that the Seek function works correctly.
+func ResampleReaderWithOptions(source io.Reader, size int64, from, to int, options *ResampleOptions) io.Reader {
+	if from == to {
+		return source
+	}
+	return convert.NewResamplingWithQuality(source, size, from, to, bitDepthInBytesInt16, resamplingQuality(options))
+}
+
+// ResampleReaderF32WithOptions converts the sample rate of the given 32bit float, little-endian, 2 channels (stereo) stream
+// with the options.
+// size is the length of the source stream in bytes.
+// from is the original sample rate.
+// to is the target sample rate.
+// options can be nil. In this case, the default options are used.
+//
+// If the original sample rate equals to the new one, ResampleReaderF32WithOptions returns source as it is.
+//
+// The returned value implements io.Seeker when the source implements io.Seeker.
+// The returned value might implement io.Seeker even when the source doesn't implement io.Seeker, but
+// there is no guarantee that the Seek function works correctly.
+func ResampleReaderF32WithOptions(source io.Reader, size int64, from, to int, options *ResampleOptions) io.Reader {
+	if from == to {
+		return source
+	}
+	return convert.NewResamplingWithQuality(source, size, from, to, bitDepthInBytesFloat32, resamplingQuality(options))
+}
+
+func resamplingQuality(options *ResampleOptions) convert.ResamplingQuality {
+	if options == nil {
+		return convert.ResamplingQualityDefault
+	}
+	return convert.ResamplingQuality(options.Quality)
+}
+
 // Resample converts the sample rate of the given singed 16bit integer, little-endian, 2 channels (stereo) stream.
 // size is the length of the source stream in bytes.
 // from is the original sample rate.
@@ -104,6 +104,43 @@ func TestSameSourcePlayers(t *testing.T) {
 	t.Errorf("time out")
 }
 
+func TestErrorCallback(t *testing.T) {
+	setup()
+	defer teardown()
+
+	var errs []error
+	context.SetErrorCallback(func(err error) {
+		errs = append(errs, err)
+	})
+
+	src := bytes.NewReader(make([]byte, 4))
+	p0, err := context.NewPlayer(src)
+	if err != nil {
+		t.Fatal(err)
+	}
+	p1, err := context.NewPlayer(src)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	p0.Play()
+	p1.Play()
+
+	for i := 0; i < 10; i++ {
+		// With an error callback, the error is not returned.
+		if err := audio.UpdateForTesting(); err != nil {
+			t.Fatal(err)
+		}
+		if len(errs) > 0 {
+			break
+		}
+		time.Sleep(200 * time.Millisecond)
+	}
+	if got, want := len(errs), 1; got != want {
+		t.Errorf("len(errs): got: %d, want: %d", got, want)
+	}
+}
+
 func TestPauseBeforeInit(t *testing.T) {
 	setup()
 	defer teardown()
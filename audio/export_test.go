@@ -135,6 +135,9 @@ func (h *dummyHook) AppendHookOnBeforeUpdate(f func() error) {
 	h.updates = append(h.updates, f)
 }
 
+func (h *dummyHook) AppendHookOnUserGesture(f func() error) {
+}
+
 func init() {
 	hookerForTesting = &dummyHook{}
 }
@@ -163,3 +166,42 @@ func ResetContextForTesting() {
 func (i *InfiniteLoop) SetNoBlendForTesting(value bool) {
 	i.noBlendForTesting = value
 }
+
+// SetLimiterNowForTesting replaces the current time used by the limiter, and returns a function to restore it.
+func SetLimiterNowForTesting(f func() time.Time) func() {
+	orig := limiterNow
+	limiterNow = f
+	return func() {
+		limiterNow = orig
+	}
+}
+
+// LimiterForTesting is a limiter with streams that don't read from any source.
+type LimiterForTesting struct {
+	limiter    limiter
+	sampleRate int
+	streams    map[int]*timeStream
+}
+
+func NewLimiterForTesting(options *LimiterOptions, sampleRate int) *LimiterForTesting {
+	l := &LimiterForTesting{
+		sampleRate: sampleRate,
+		streams:    map[int]*timeStream{},
+	}
+	l.limiter.setOptions(options)
+	return l
+}
+
+// Process applies the limiter to buf, which is 32bit float stereo data read from the stream of the ID.
+func (l *LimiterForTesting) Process(streamID int, buf []byte, volume float64) {
+	s, ok := l.streams[streamID]
+	if !ok {
+		s = &timeStream{
+			sampleRate: l.sampleRate,
+			limiter:    &l.limiter,
+			kWeighting: newKWeightingFilter(l.sampleRate),
+		}
+		l.streams[streamID] = s
+	}
+	l.limiter.process(s, buf, volume)
+}
@@ -79,12 +79,28 @@ func sinc01(x float64) float64 {
 	return fastSin01(x) / (x * 2 * math.Pi)
 }
 
+// ResamplingQuality represents the quality of resampling.
+type ResamplingQuality int
+
+const (
+	// ResamplingQualityDefault uses a windowed sinc filter with 8 taps on each side.
+	ResamplingQualityDefault ResamplingQuality = iota
+
+	// ResamplingQualityLow uses a linear interpolation.
+	ResamplingQualityLow
+
+	// ResamplingQualityHigh uses a windowed sinc filter with 32 taps on each side,
+	// with a low-pass filter to avoid aliasing on downsampling.
+	ResamplingQualityHigh
+)
+
 type Resampling struct {
 	source          io.Reader
 	size            int64
 	from            int
 	to              int
 	bitDepthInBytes int
+	quality         ResamplingQuality
 	pos             int64
 	srcBlock        int64
 	srcBufL         map[int64][]float64
@@ -95,12 +111,17 @@ type Resampling struct {
 }
 
 func NewResampling(source io.Reader, size int64, from, to int, bitDepthInBytes int) *Resampling {
+	return NewResamplingWithQuality(source, size, from, to, bitDepthInBytes, ResamplingQualityDefault)
+}
+
+func NewResamplingWithQuality(source io.Reader, size int64, from, to int, bitDepthInBytes int, quality ResamplingQuality) *Resampling {
 	r := &Resampling{
 		source:          source,
 		size:            size,
 		from:            from,
 		bitDepthInBytes: bitDepthInBytes,
 		to:              to,
+		quality:         quality,
 		srcBlock:        -1,
 		srcBufL:         map[int64][]float64{},
 		srcBufR:         map[int64][]float64{},
@@ -208,8 +229,23 @@ func (r *Resampling) src(i int64) (float64, float64, error) {
 }
 
 func (r *Resampling) at(t int64) (float64, float64, error) {
-	windowSize := 8.0
 	tInSrc := float64(t) * float64(r.from) / float64(r.to)
+	if r.quality == ResamplingQualityLow {
+		return r.linearAt(tInSrc)
+	}
+
+	windowSize := 8.0
+	// cutoff is the cutoff frequency of the low-pass filter relative to the source Nyquist frequency.
+	cutoff := 1.0
+	if r.quality == ResamplingQualityHigh {
+		windowSize = 32.0
+		if r.to < r.from {
+			// On downsampling, the frequencies above the destination Nyquist frequency must be removed.
+			// Widen the window so that the number of the zero crossings in the window is kept.
+			cutoff = float64(r.to) / float64(r.from)
+			windowSize /= cutoff
+		}
+	}
 	startN := int64(tInSrc - windowSize)
 	if startN < 0 {
 		startN = 0
@@ -228,26 +264,42 @@ func (r *Resampling) at(t int64) (float64, float64, error) {
 		}
 		d := tInSrc - float64(n)
 		w := 0.5 + 0.5*fastCos01(d/(windowSize*2+1))
-		s := sinc01(d/2) * w
+		s := cutoff * sinc01(d*cutoff/2) * w
 		lv += srcL * s
 		rv += srcR * s
 	}
-	if lv < -1 {
-		lv = -1
+	lv, rv = clamp(lv), clamp(rv)
+	if eof {
+		return lv, rv, io.EOF
 	}
-	if lv > 1 {
-		lv = 1
+	return lv, rv, nil
+}
+
+func (r *Resampling) linearAt(tInSrc float64) (float64, float64, error) {
+	n := int64(tInSrc)
+	a := tInSrc - float64(n)
+	l0, r0, err := r.src(n)
+	if err != nil {
+		// Even if err is io.EOF, the sample value is valid.
+		return l0, r0, err
 	}
-	if rv < -1 {
-		rv = -1
+	l1, r1, err := r.src(n + 1)
+	if err != nil && err != io.EOF {
+		return 0, 0, err
 	}
-	if rv > 1 {
-		rv = 1
+	lv := clamp(l0*(1-a) + l1*a)
+	rv := clamp(r0*(1-a) + r1*a)
+	return lv, rv, err
+}
+
+func clamp(v float64) float64 {
+	if v < -1 {
+		return -1
 	}
-	if eof {
-		return lv, rv, io.EOF
+	if v > 1 {
+		return 1
 	}
-	return lv, rv, nil
+	return v
 }
 
 func (r *Resampling) Read(b []byte) (int, error) {
@@ -0,0 +1,26 @@
+// Copyright 2026 The Ebitengine Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package outputdevice provides a way to detect changes of the audio output devices.
+package outputdevice
+
+// Identity returns a string that represents the current default output device.
+// When the default output device is changed or a device is plugged in or out, Identity returns a different string.
+//
+// Identity returns an empty string when the platform doesn't support the detection.
+//
+// Identity is not concurrent-safe.
+func Identity() string {
+	return identity()
+}
@@ -0,0 +1,76 @@
+// Copyright 2026 The Ebitengine Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build !ios
+
+package outputdevice
+
+import (
+	"strconv"
+	"sync"
+	"unsafe"
+
+	"github.com/ebitengine/purego"
+)
+
+const (
+	kAudioObjectSystemObject = 1
+
+	kAudioHardwarePropertyDefaultOutputDevice = 'd'<<24 | 'O'<<16 | 'u'<<8 | 't'
+	kAudioObjectPropertyScopeGlobal           = 'g'<<24 | 'l'<<16 | 'o'<<8 | 'b'
+	kAudioObjectPropertyElementMain           = 0
+)
+
+type _AudioObjectPropertyAddress struct {
+	mSelector uint32
+	mScope    uint32
+	mElement  uint32
+}
+
+var _AudioObjectGetPropertyData func(inObjectID uint32, inAddress *_AudioObjectPropertyAddress, inQualifierDataSize uint32, inQualifierData unsafe.Pointer, ioDataSize *uint32, outData unsafe.Pointer) int32
+
+var (
+	initOnce sync.Once
+	initErr  error
+)
+
+func initializeCoreAudio() error {
+	coreaudio, err := purego.Dlopen("/System/Library/Frameworks/CoreAudio.framework/CoreAudio", purego.RTLD_LAZY|purego.RTLD_GLOBAL)
+	if err != nil {
+		return err
+	}
+	purego.RegisterLibFunc(&_AudioObjectGetPropertyData, coreaudio, "AudioObjectGetPropertyData")
+	return nil
+}
+
+func identity() string {
+	initOnce.Do(func() {
+		initErr = initializeCoreAudio()
+	})
+	if initErr != nil {
+		return ""
+	}
+
+	addr := _AudioObjectPropertyAddress{
+		mSelector: kAudioHardwarePropertyDefaultOutputDevice,
+		mScope:    kAudioObjectPropertyScopeGlobal,
+		mElement:  kAudioObjectPropertyElementMain,
+	}
+	var deviceID uint32
+	size := uint32(unsafe.Sizeof(deviceID))
+	if r := _AudioObjectGetPropertyData(kAudioObjectSystemObject, &addr, 0, nil, &size, unsafe.Pointer(&deviceID)); r != 0 {
+		return ""
+	}
+	return strconv.FormatUint(uint64(deviceID), 10)
+}
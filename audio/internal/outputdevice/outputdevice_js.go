@@ -0,0 +1,40 @@
+// Copyright 2026 The Ebitengine Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package outputdevice
+
+import (
+	"strconv"
+	"sync/atomic"
+	"syscall/js"
+)
+
+var (
+	listening   bool
+	changeCount atomic.Int64
+)
+
+func identity() string {
+	if !listening {
+		listening = true
+		// navigator.mediaDevices is not available in an insecure context.
+		if d := js.Global().Get("navigator").Get("mediaDevices"); d.Truthy() {
+			d.Call("addEventListener", "devicechange", js.FuncOf(func(this js.Value, args []js.Value) any {
+				changeCount.Add(1)
+				return nil
+			}))
+		}
+	}
+	return strconv.FormatInt(changeCount.Load(), 10)
+}
@@ -0,0 +1,31 @@
+// Copyright 2026 The Ebitengine Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build linux && !android
+
+package outputdevice
+
+import (
+	"os"
+)
+
+func identity() string {
+	// The list of the sound cards changes when a device like USB headphones is plugged in or out.
+	// The default device selected by a sound server like PulseAudio or PipeWire is not detected.
+	b, err := os.ReadFile("/proc/asound/cards")
+	if err != nil {
+		return ""
+	}
+	return string(b)
+}
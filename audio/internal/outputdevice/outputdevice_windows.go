@@ -0,0 +1,129 @@
+// Copyright 2026 The Ebitengine Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package outputdevice
+
+import (
+	"runtime"
+	"syscall"
+	"unsafe"
+
+	"golang.org/x/sys/windows"
+)
+
+const (
+	_CLSCTX_ALL = 0x17
+
+	_eRender  = 0
+	_eConsole = 0
+)
+
+var (
+	_CLSID_MMDeviceEnumerator = windows.GUID{Data1: 0xbcde0395, Data2: 0xe52f, Data3: 0x467c, Data4: [...]byte{0x8e, 0x3d, 0xc4, 0x57, 0x92, 0x91, 0x69, 0x2e}}
+	_IID_IMMDeviceEnumerator  = windows.GUID{Data1: 0xa95664d2, Data2: 0x9614, Data3: 0x4f35, Data4: [...]byte{0xa7, 0x46, 0xde, 0x8d, 0xb6, 0x36, 0x17, 0xe6}}
+)
+
+var (
+	ole32 = windows.NewLazySystemDLL("ole32.dll")
+
+	procCoCreateInstance = ole32.NewProc("CoCreateInstance")
+)
+
+type _IMMDeviceEnumerator struct {
+	vtbl *_IMMDeviceEnumerator_Vtbl
+}
+
+type _IMMDeviceEnumerator_Vtbl struct {
+	QueryInterface                         uintptr
+	AddRef                                 uintptr
+	Release                                uintptr
+	EnumAudioEndpoints                     uintptr
+	GetDefaultAudioEndpoint                uintptr
+	GetDevice                              uintptr
+	RegisterEndpointNotificationCallback   uintptr
+	UnregisterEndpointNotificationCallback uintptr
+}
+
+func (i *_IMMDeviceEnumerator) GetDefaultAudioEndpoint(dataFlow, role int32) (*_IMMDevice, error) {
+	var device *_IMMDevice
+	r, _, _ := syscall.SyscallN(i.vtbl.GetDefaultAudioEndpoint, uintptr(unsafe.Pointer(i)), uintptr(dataFlow), uintptr(role), uintptr(unsafe.Pointer(&device)))
+	if uint32(r) != uint32(windows.S_OK) {
+		return nil, windows.Errno(r)
+	}
+	return device, nil
+}
+
+func (i *_IMMDeviceEnumerator) Release() {
+	_, _, _ = syscall.SyscallN(i.vtbl.Release, uintptr(unsafe.Pointer(i)))
+}
+
+type _IMMDevice struct {
+	vtbl *_IMMDevice_Vtbl
+}
+
+type _IMMDevice_Vtbl struct {
+	QueryInterface    uintptr
+	AddRef            uintptr
+	Release           uintptr
+	Activate          uintptr
+	OpenPropertyStore uintptr
+	GetId             uintptr
+	GetState          uintptr
+}
+
+func (i *_IMMDevice) GetId() (string, error) {
+	var id *uint16
+	r, _, _ := syscall.SyscallN(i.vtbl.GetId, uintptr(unsafe.Pointer(i)), uintptr(unsafe.Pointer(&id)))
+	if uint32(r) != uint32(windows.S_OK) {
+		return "", windows.Errno(r)
+	}
+	defer windows.CoTaskMemFree(unsafe.Pointer(id))
+	return windows.UTF16PtrToString(id), nil
+}
+
+func (i *_IMMDevice) Release() {
+	_, _, _ = syscall.SyscallN(i.vtbl.Release, uintptr(unsafe.Pointer(i)))
+}
+
+func identity() string {
+	// COM requires the same thread between CoInitializeEx and CoUninitialize.
+	runtime.LockOSThread()
+	defer runtime.UnlockOSThread()
+
+	// CoInitializeEx returns an error when COM is already initialized in a different mode on this thread.
+	// COM is still usable in this case.
+	if err := windows.CoInitializeEx(0, windows.COINIT_MULTITHREADED); err == nil {
+		defer windows.CoUninitialize()
+	}
+
+	var e *_IMMDeviceEnumerator
+	r, _, _ := procCoCreateInstance.Call(uintptr(unsafe.Pointer(&_CLSID_MMDeviceEnumerator)), 0, _CLSCTX_ALL, uintptr(unsafe.Pointer(&_IID_IMMDeviceEnumerator)), uintptr(unsafe.Pointer(&e)))
+	if uint32(r) != uint32(windows.S_OK) {
+		return ""
+	}
+	defer e.Release()
+
+	d, err := e.GetDefaultAudioEndpoint(_eRender, _eConsole)
+	if err != nil {
+		// There is no output device.
+		return ""
+	}
+	defer d.Release()
+
+	id, err := d.GetId()
+	if err != nil {
+		return ""
+	}
+	return id
+}
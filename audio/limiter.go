@@ -0,0 +1,334 @@
+// Copyright 2026 The Ebitengine Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package audio
+
+import (
+	"encoding/binary"
+	"math"
+	"sync"
+	"time"
+)
+
+// LimiterMode represents a mode of the master limiter.
+type LimiterMode int
+
+const (
+	// LimiterModePeak limits the peak level of the output.
+	LimiterModePeak LimiterMode = iota
+
+	// LimiterModeLoudness normalizes the loudness of the output to the target loudness, and then limits the peak level.
+	LimiterModeLoudness
+)
+
+// LimiterOptions represents options for the master limiter.
+type LimiterOptions struct {
+	// Mode is the mode of the limiter.
+	//
+	// The default (zero) value is LimiterModePeak.
+	Mode LimiterMode
+
+	// CeilingDB is the maximum peak level of the output in dBFS.
+	// A positive value is treated as 0.
+	//
+	// The default (zero) value is 0.
+	CeilingDB float64
+
+	// TargetLUFS is the target short-term loudness in LUFS.
+	// TargetLUFS is used only when Mode is LimiterModeLoudness.
+	//
+	// The default (zero) value is -16.
+	TargetLUFS float64
+
+	// Release is the time for the gain to recover after the peak level goes down.
+	//
+	// The default (zero) value is 200 milliseconds.
+	Release time.Duration
+}
+
+// SetLimiter sets the limiter at the final stage of the mixer, so that the mixed output doesn't clip
+// even when many players play loud sounds at the same time.
+// If options is nil, the limiter is disabled. The limiter is disabled by default.
+//
+// The limiter reduces the gain of all the playing players at the same time, based on the peak levels of the players.
+// As the players are mixed by the underlying audio driver, the reduction is applied to the data before being buffered.
+// Then, there is a delay of the buffer size until the reduction is reflected.
+//
+// The limiter doesn't have a look-ahead buffer and doesn't add latency.
+// Player.Position is not affected by the limiter.
+//
+// SetLimiter is concurrent-safe.
+func (c *Context) SetLimiter(options *LimiterOptions) {
+	c.limiter.setOptions(options)
+}
+
+const (
+	defaultLimiterTargetLUFS = -16
+	defaultLimiterRelease    = 200 * time.Millisecond
+
+	// limiterStreamLifetime is the duration after which a stream that doesn't read any data is ignored.
+	limiterStreamLifetime = 500 * time.Millisecond
+
+	// limiterLoudnessWindow is the time constant to measure the short-term loudness.
+	// The short-term loudness of EBU R 128 uses a sliding window of 3 seconds.
+	limiterLoudnessWindow = 3 * time.Second
+
+	// limiterNormalizationTime is the time constant for the normalization gain to follow the loudness.
+	limiterNormalizationTime = time.Second
+
+	// limiterMaxNormalizationGain is the maximum gain to amplify a quiet output (+12 dB).
+	limiterMaxNormalizationGain = 4
+
+	// limiterAbsoluteGateLUFS is the absolute gate of EBU R 128.
+	// A quieter output is not amplified.
+	limiterAbsoluteGateLUFS = -70
+)
+
+// limiterNow returns the current time for the limiter. This is replaced in tests.
+var limiterNow = time.Now
+
+type limiterStreamState struct {
+	peak       float64
+	meanSquare float64
+	at         time.Time
+}
+
+// limiter is the master limiter.
+//
+// The players are mixed by the underlying driver, and there is no single final stage where the mixed data is available.
+// Instead, each stream reports its peak level and loudness, and applies the same gain computed from all the streams.
+// As the peak level of the mixed data never exceeds the sum of the peak levels, the gain keeps the mixed data under the ceiling.
+type limiter struct {
+	enabled bool
+	options LimiterOptions
+	ceiling float64
+
+	streams map[*timeStream]limiterStreamState
+
+	// gain is the gain to limit the peak level.
+	gain float64
+
+	// normalizationGain is the gain to normalize the loudness.
+	normalizationGain float64
+
+	// meanSquare is the smoothed mean square of the K-weighted mixed data.
+	meanSquare float64
+
+	lastAt time.Time
+
+	m sync.Mutex
+}
+
+func (l *limiter) setOptions(options *LimiterOptions) {
+	l.m.Lock()
+	defer l.m.Unlock()
+
+	l.streams = nil
+	l.gain = 1
+	l.normalizationGain = 1
+	l.meanSquare = 0
+	l.lastAt = time.Time{}
+
+	if options == nil {
+		l.enabled = false
+		return
+	}
+	l.enabled = true
+	l.options = *options
+	if l.options.CeilingDB > 0 {
+		l.options.CeilingDB = 0
+	}
+	if l.options.TargetLUFS == 0 {
+		l.options.TargetLUFS = defaultLimiterTargetLUFS
+	}
+	if l.options.Release <= 0 {
+		l.options.Release = defaultLimiterRelease
+	}
+	l.ceiling = math.Pow(10, l.options.CeilingDB/20)
+}
+
+func (l *limiter) isEnabled() bool {
+	l.m.Lock()
+	defer l.m.Unlock()
+	return l.enabled
+}
+
+// process applies the gain to buf, which is 32bit float stereo data read from s.
+func (l *limiter) process(s *timeStream, buf []byte, volume float64) {
+	if !l.isEnabled() {
+		return
+	}
+
+	const bytesPerFrame = bitDepthInBytesFloat32 * channelCount
+	frames := len(buf) / bytesPerFrame
+	if frames == 0 {
+		return
+	}
+
+	var peak, sumSquare float64
+	for i := 0; i < frames*channelCount; i++ {
+		v := float64(math.Float32frombits(binary.LittleEndian.Uint32(buf[4*i:])))
+		peak = math.Max(peak, math.Abs(v))
+		k := s.kWeighting.apply(i%channelCount, v)
+		sumSquare += k * k
+	}
+	peak *= volume
+	// The loudness is the sum of the mean squares of the channels.
+	meanSquare := sumSquare / float64(frames) * volume * volume
+
+	gain := l.update(s, peak, meanSquare)
+	if gain == 1 {
+		return
+	}
+	for i := 0; i < frames*channelCount; i++ {
+		v := math.Float32frombits(binary.LittleEndian.Uint32(buf[4*i:]))
+		binary.LittleEndian.PutUint32(buf[4*i:], math.Float32bits(v*float32(gain)))
+	}
+}
+
+// update records the levels of s and returns the gain to apply.
+func (l *limiter) update(s *timeStream, peak, meanSquare float64) float64 {
+	l.m.Lock()
+	defer l.m.Unlock()
+
+	if !l.enabled {
+		return 1
+	}
+
+	now := limiterNow()
+	var dt time.Duration
+	if !l.lastAt.IsZero() {
+		dt = min(now.Sub(l.lastAt), time.Second)
+	}
+	l.lastAt = now
+
+	if l.streams == nil {
+		l.streams = map[*timeStream]limiterStreamState{}
+	}
+	l.streams[s] = limiterStreamState{
+		peak:       peak,
+		meanSquare: meanSquare,
+		at:         now,
+	}
+
+	var sumPeak, sumMeanSquare float64
+	for s, st := range l.streams {
+		if now.Sub(st.at) > limiterStreamLifetime {
+			delete(l.streams, s)
+			continue
+		}
+		sumPeak += st.peak
+		sumMeanSquare += st.meanSquare
+	}
+
+	if l.options.Mode == LimiterModeLoudness {
+		l.meanSquare += (sumMeanSquare - l.meanSquare) * smoothingRate(dt, limiterLoudnessWindow)
+		if l.meanSquare > 0 {
+			loudness := -0.691 + 10*math.Log10(l.meanSquare)
+			if loudness > limiterAbsoluteGateLUFS {
+				target := math.Min(math.Pow(10, (l.options.TargetLUFS-loudness)/20), limiterMaxNormalizationGain)
+				l.normalizationGain += (target - l.normalizationGain) * smoothingRate(dt, limiterNormalizationTime)
+			}
+		}
+	}
+
+	target := 1.0
+	if p := sumPeak * l.normalizationGain; p > l.ceiling {
+		target = l.ceiling / p
+	}
+	if target < l.gain {
+		// Reduce the gain immediately not to clip the output.
+		l.gain = target
+	} else {
+		l.gain += (target - l.gain) * smoothingRate(dt, l.options.Release)
+	}
+
+	return l.gain * l.normalizationGain
+}
+
+// smoothingRate returns the rate of an exponential smoothing with the time constant tc for the elapsed time dt.
+func smoothingRate(dt, tc time.Duration) float64 {
+	return 1 - math.Exp(-float64(dt)/float64(tc))
+}
+
+// biquad is a biquad filter in the direct form I.
+type biquad struct {
+	b0, b1, b2 float64
+	a1, a2     float64
+	x1, x2     float64
+	y1, y2     float64
+}
+
+func (b *biquad) apply(x float64) float64 {
+	y := b.b0*x + b.b1*b.x1 + b.b2*b.x2 - b.a1*b.y1 - b.a2*b.y2
+	b.x2, b.x1 = b.x1, x
+	b.y2, b.y1 = b.y1, y
+	return y
+}
+
+// kWeightingFilter is the K-weighting filter of ITU-R BS.1770 for stereo data.
+type kWeightingFilter struct {
+	shelf    [channelCount]biquad
+	highpass [channelCount]biquad
+}
+
+func newKWeightingFilter(sampleRate int) kWeightingFilter {
+	var f kWeightingFilter
+
+	// The coefficients are calculated for an arbitrary sample rate in the same way as libebur128.
+	{
+		const (
+			f0 = 1681.974450955533
+			g  = 3.999843853973347
+			q  = 0.7071752369554196
+		)
+		k := math.Tan(math.Pi * f0 / float64(sampleRate))
+		vh := math.Pow(10, g/20)
+		vb := math.Pow(vh, 0.4996667741545416)
+		a0 := 1 + k/q + k*k
+		b := biquad{
+			b0: (vh + vb*k/q + k*k) / a0,
+			b1: 2 * (k*k - vh) / a0,
+			b2: (vh - vb*k/q + k*k) / a0,
+			a1: 2 * (k*k - 1) / a0,
+			a2: (1 - k/q + k*k) / a0,
+		}
+		for i := range f.shelf {
+			f.shelf[i] = b
+		}
+	}
+	{
+		const (
+			f0 = 38.13547087602444
+			q  = 0.5003270373238773
+		)
+		k := math.Tan(math.Pi * f0 / float64(sampleRate))
+		a0 := 1 + k/q + k*k
+		b := biquad{
+			b0: 1,
+			b1: -2,
+			b2: 1,
+			a1: 2 * (k*k - 1) / a0,
+			a2: (1 - k/q + k*k) / a0,
+		}
+		for i := range f.highpass {
+			f.highpass[i] = b
+		}
+	}
+	return f
+}
+
+func (f *kWeightingFilter) apply(channel int, x float64) float64 {
+	return f.highpass[channel].apply(f.shelf[channel].apply(x))
+}
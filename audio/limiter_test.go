@@ -0,0 +1,292 @@
+// Copyright 2018 The Ebiten Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package audio_test
+
+import (
+	"encoding/binary"
+	"math"
+	"testing"
+	"time"
+
+	"github.com/duplicants-ai/ebiten/audio"
+)
+
+const (
+	limiterTestSampleRate   = 48000
+	limiterTestBufferFrames = limiterTestSampleRate / 100
+	limiterTestBufferPeriod = 10 * time.Millisecond
+)
+
+func newLimiterTestBuffer(offset int, f func(frame int) float64) []byte {
+	buf := make([]byte, limiterTestBufferFrames*2*4)
+	for i := 0; i < limiterTestBufferFrames; i++ {
+		v := math.Float32bits(float32(f(offset + i)))
+		binary.LittleEndian.PutUint32(buf[8*i:], v)
+		binary.LittleEndian.PutUint32(buf[8*i+4:], v)
+	}
+	return buf
+}
+
+func limiterTestSample(buf []byte, i int) float64 {
+	return float64(math.Float32frombits(binary.LittleEndian.Uint32(buf[4*i:])))
+}
+
+func limiterTestPeak(buf []byte) float64 {
+	var peak float64
+	for i := 0; i < len(buf)/4; i++ {
+		peak = math.Max(peak, math.Abs(limiterTestSample(buf, i)))
+	}
+	return peak
+}
+
+func limiterTestDC(value float64) func(int) float64 {
+	return func(int) float64 {
+		return value
+	}
+}
+
+func limiterTestSine(amplitude float64) func(int) float64 {
+	return func(frame int) float64 {
+		return amplitude * math.Sin(2*math.Pi*1000*float64(frame)/limiterTestSampleRate)
+	}
+}
+
+func setLimiterTestClock(t *testing.T) func(time.Duration) {
+	now := time.Unix(0, 0)
+	t.Cleanup(audio.SetLimiterNowForTesting(func() time.Time {
+		return now
+	}))
+	return func(d time.Duration) {
+		now = now.Add(d)
+	}
+}
+
+func TestLimiterDisabled(t *testing.T) {
+	setLimiterTestClock(t)
+
+	l := audio.NewLimiterForTesting(nil, limiterTestSampleRate)
+	buf := newLimiterTestBuffer(0, limiterTestDC(2))
+	l.Process(0, buf, 1)
+	if got, want := limiterTestPeak(buf), 2.0; got != want {
+		t.Errorf("got: %f, want: %f", got, want)
+	}
+}
+
+func TestLimiterCeiling(t *testing.T) {
+	setLimiterTestClock(t)
+
+	testCases := []struct {
+		Name      string
+		CeilingDB float64
+		Value     float64
+		Volume    float64
+		Want      float64
+	}{
+		{
+			Name:      "under the ceiling",
+			CeilingDB: 0,
+			Value:     0.5,
+			Volume:    1,
+			Want:      0.5,
+		},
+		{
+			Name:      "over the ceiling",
+			CeilingDB: 0,
+			Value:     2,
+			Volume:    1,
+			Want:      1,
+		},
+		{
+			Name:      "-6 dB",
+			CeilingDB: -6,
+			Value:     1,
+			Volume:    1,
+			Want:      math.Pow(10, -6.0/20),
+		},
+		{
+			Name:      "positive ceiling",
+			CeilingDB: 6,
+			Value:     2,
+			Volume:    1,
+			Want:      1,
+		},
+		{
+			Name:      "volume",
+			CeilingDB: 0,
+			Value:     1,
+			Volume:    4,
+			Want:      0.25,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.Name, func(t *testing.T) {
+			l := audio.NewLimiterForTesting(&audio.LimiterOptions{
+				CeilingDB: tc.CeilingDB,
+			}, limiterTestSampleRate)
+			buf := newLimiterTestBuffer(0, limiterTestDC(tc.Value))
+			l.Process(0, buf, tc.Volume)
+			if got := limiterTestPeak(buf); math.Abs(got-tc.Want) > 1e-6 {
+				t.Errorf("got: %f, want: %f", got, tc.Want)
+			}
+		})
+	}
+}
+
+func TestLimiterAttackAndRelease(t *testing.T) {
+	advance := setLimiterTestClock(t)
+
+	const release = 100 * time.Millisecond
+	l := audio.NewLimiterForTesting(&audio.LimiterOptions{
+		Release: release,
+	}, limiterTestSampleRate)
+
+	buf := newLimiterTestBuffer(0, limiterTestDC(0.5))
+	l.Process(0, buf, 1)
+	if got, want := limiterTestPeak(buf), 0.5; math.Abs(got-want) > 1e-6 {
+		t.Errorf("before the peak: got: %f, want: %f", got, want)
+	}
+
+	// The gain is reduced immediately at the buffer that exceeds the ceiling.
+	advance(limiterTestBufferPeriod)
+	buf = newLimiterTestBuffer(0, limiterTestDC(2))
+	l.Process(0, buf, 1)
+	if got, want := limiterTestPeak(buf), 1.0; math.Abs(got-want) > 1e-6 {
+		t.Errorf("at the peak: got: %f, want: %f", got, want)
+	}
+
+	// The gain recovers exponentially with the time constant of the release.
+	for i := 1; i <= 50; i++ {
+		advance(limiterTestBufferPeriod)
+		buf = newLimiterTestBuffer(0, limiterTestDC(0.5))
+		l.Process(0, buf, 1)
+		gain := 1 - 0.5*math.Exp(-float64(i)*float64(limiterTestBufferPeriod)/float64(release))
+		if got, want := limiterTestPeak(buf), 0.5*gain; math.Abs(got-want) > 1e-6 {
+			t.Errorf("%d buffers after the peak: got: %f, want: %f", i, got, want)
+		}
+	}
+}
+
+func TestLimiterNoClipping(t *testing.T) {
+	advance := setLimiterTestClock(t)
+
+	l := audio.NewLimiterForTesting(&audio.LimiterOptions{}, limiterTestSampleRate)
+
+	var offset int
+	for i := 0; i < 100; i++ {
+		advance(limiterTestBufferPeriod)
+		buf0 := newLimiterTestBuffer(offset, limiterTestSine(0.8))
+		buf1 := newLimiterTestBuffer(offset, limiterTestSine(0.8))
+		offset += limiterTestBufferFrames
+		l.Process(0, buf0, 1)
+		l.Process(1, buf1, 1)
+
+		// The reduction is reflected from the next buffer of each stream.
+		if i == 0 {
+			continue
+		}
+		var peak float64
+		for j := 0; j < len(buf0)/4; j++ {
+			peak = math.Max(peak, math.Abs(limiterTestSample(buf0, j)+limiterTestSample(buf1, j)))
+		}
+		if peak > 1+1e-6 {
+			t.Errorf("buffer %d: mixed peak: got: %f, want: <= 1", i, peak)
+		}
+		if peak < 0.99 {
+			t.Errorf("buffer %d: mixed peak: got: %f, want: >= 0.99", i, peak)
+		}
+	}
+}
+
+func TestLimiterStoppedStream(t *testing.T) {
+	advance := setLimiterTestClock(t)
+
+	l := audio.NewLimiterForTesting(&audio.LimiterOptions{}, limiterTestSampleRate)
+
+	l.Process(0, newLimiterTestBuffer(0, limiterTestDC(0.8)), 1)
+	l.Process(1, newLimiterTestBuffer(0, limiterTestDC(0.8)), 1)
+
+	// The stream 1 stops reading. The limiter still takes the stream into account for a while.
+	for i := 0; i < 40; i++ {
+		advance(limiterTestBufferPeriod)
+		buf := newLimiterTestBuffer(0, limiterTestDC(0.8))
+		l.Process(0, buf, 1)
+		if got, want := limiterTestPeak(buf), 0.5; math.Abs(got-want) > 1e-6 {
+			t.Errorf("%d buffers after the stop: got: %f, want: %f", i, got, want)
+		}
+	}
+
+	for i := 0; i < 300; i++ {
+		advance(limiterTestBufferPeriod)
+		l.Process(0, newLimiterTestBuffer(0, limiterTestDC(0.8)), 1)
+	}
+	buf := newLimiterTestBuffer(0, limiterTestDC(0.8))
+	l.Process(0, buf, 1)
+	if got, want := limiterTestPeak(buf), 0.8; math.Abs(got-want) > 1e-3 {
+		t.Errorf("after the release: got: %f, want: %f", got, want)
+	}
+}
+
+func TestLimiterLoudness(t *testing.T) {
+	testCases := []struct {
+		Name      string
+		Amplitude float64
+		WantMin   float64
+		WantMax   float64
+	}{
+		{
+			Name:      "quiet",
+			Amplitude: 0.01,
+			// The gain to amplify is up to +12 dB.
+			WantMin: 0.04 * 0.99,
+			WantMax: 0.04 * 1.01,
+		},
+		{
+			Name:      "loud",
+			Amplitude: 0.9,
+			WantMin:   0.1,
+			WantMax:   0.3,
+		},
+		{
+			Name:      "under the absolute gate",
+			Amplitude: 0.0001,
+			WantMin:   0.0001 * 0.99,
+			WantMax:   0.0001 * 1.01,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.Name, func(t *testing.T) {
+			advance := setLimiterTestClock(t)
+
+			l := audio.NewLimiterForTesting(&audio.LimiterOptions{
+				Mode: audio.LimiterModeLoudness,
+			}, limiterTestSampleRate)
+
+			var offset int
+			var buf []byte
+			for i := 0; i < 2000; i++ {
+				advance(limiterTestBufferPeriod)
+				buf = newLimiterTestBuffer(offset, limiterTestSine(tc.Amplitude))
+				offset += limiterTestBufferFrames
+				l.Process(0, buf, 1)
+				if got := limiterTestPeak(buf); got > 1+1e-6 {
+					t.Fatalf("buffer %d: got: %f, want: <= 1", i, got)
+				}
+			}
+			if got := limiterTestPeak(buf); got < tc.WantMin || got > tc.WantMax {
+				t.Errorf("got: %f, want: [%f, %f]", got, tc.WantMin, tc.WantMax)
+			}
+		})
+	}
+}
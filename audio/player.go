@@ -17,6 +17,7 @@ package audio
 import (
 	"errors"
 	"io"
+	"math"
 	"runtime"
 	"sync"
 	"sync/atomic"
@@ -49,6 +50,10 @@ type playerFactory struct {
 	context    context
 	sampleRate int
 
+	// initErr is the error at the initialization of the context.
+	// The underlying context cannot be initialized twice.
+	initErr error
+
 	m sync.Mutex
 }
 
@@ -144,9 +149,13 @@ func (f *playerFactory) initContextIfNeeded() (<-chan struct{}, error) {
 	if f.context != nil {
 		return nil, nil
 	}
+	if f.initErr != nil {
+		return nil, f.initErr
+	}
 
 	c, ready, err := newContext(f.sampleRate)
 	if err != nil {
+		f.initErr = err
 		return nil, err
 	}
 	f.context = c
@@ -171,7 +180,7 @@ func (p *playerImpl) ensurePlayer() error {
 	}
 
 	if p.stream == nil {
-		s, err := newTimeStream(p.src, p.seekable, p.factory.sampleRate, p.bytesPerSample/channelCount)
+		s, err := newTimeStream(p.src, p.seekable, p.factory.sampleRate, p.bytesPerSample/channelCount, &p.context.limiter)
 		if err != nil {
 			return err
 		}
@@ -252,6 +261,7 @@ func (p *playerImpl) SetVolume(volume float64) {
 		return
 	}
 	p.player.SetVolume(volume)
+	p.stream.setVolume(volume)
 }
 
 func (p *playerImpl) Close() error {
@@ -393,18 +403,27 @@ type timeStream struct {
 	pos            atomic.Int64
 	bytesPerSample int
 
+	limiter    *limiter
+	kWeighting kWeightingFilter
+
+	// volume is the volume of the player as float64 bits.
+	volume atomic.Uint64
+
 	// m is a mutex for this stream.
 	// All the exported functions are protected by this mutex as Read can be read from a different goroutine than Seek.
 	m sync.Mutex
 }
 
-func newTimeStream(r io.Reader, seekable bool, sampleRate int, bitDepthInBytes int) (*timeStream, error) {
+func newTimeStream(r io.Reader, seekable bool, sampleRate int, bitDepthInBytes int, limiter *limiter) (*timeStream, error) {
 	s := &timeStream{
 		r:              r,
 		seekable:       seekable,
 		sampleRate:     sampleRate,
 		bytesPerSample: bitDepthInBytes * channelCount,
+		limiter:        limiter,
+		kWeighting:     newKWeightingFilter(sampleRate),
 	}
+	s.volume.Store(math.Float64bits(1))
 	if seekable {
 		// Get the current position of the source.
 		pos, err := s.r.(io.Seeker).Seek(0, io.SeekCurrent)
@@ -425,8 +444,17 @@ func (s *timeStream) Read(buf []byte) (int, error) {
 	s.m.Lock()
 	defer s.m.Unlock()
 
+	pos := s.pos.Load()
 	n, err := s.r.Read(buf)
 	s.pos.Add(int64(n))
+
+	if s.limiter != nil {
+		// Process only the complete samples.
+		start := (int64(s.bytesPerSample) - pos%int64(s.bytesPerSample)) % int64(s.bytesPerSample)
+		if start < int64(n) {
+			s.limiter.process(s, buf[start:n], math.Float64frombits(s.volume.Load()))
+		}
+	}
 	return n, err
 }
 
@@ -447,6 +475,10 @@ func (s *timeStream) Seek(offset int64, whence int) (int64, error) {
 	return pos, nil
 }
 
+func (s *timeStream) setVolume(volume float64) {
+	s.volume.Store(math.Float64bits(volume))
+}
+
 func (s *timeStream) timeDurationToPos(offset time.Duration) int64 {
 	o := int64(offset) * int64(s.bytesPerSample) * int64(s.sampleRate) / int64(time.Second)
 
@@ -19,13 +19,11 @@ import (
 	"fmt"
 	"io"
 	"math"
-
-	"github.com/jfreymuth/oggvorbis"
 )
 
 var _ io.ReadSeeker = (*float32BytesReadSeeker)(nil)
 
-func newFloat32BytesReadSeeker(r *oggvorbis.Reader, seekable bool) *float32BytesReadSeeker {
+func newFloat32BytesReadSeeker(r *vorbisReader, seekable bool) *float32BytesReadSeeker {
 	return &float32BytesReadSeeker{
 		r:        r,
 		seekable: seekable,
@@ -33,7 +31,7 @@ func newFloat32BytesReadSeeker(r *oggvorbis.Reader, seekable bool) *float32Bytes
 }
 
 type float32BytesReadSeeker struct {
-	r        *oggvorbis.Reader
+	r        *vorbisReader
 	seekable bool
 	fbuf     []float32
 	pos      int64
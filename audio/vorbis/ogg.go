@@ -0,0 +1,415 @@
+// Copyright 2026 The Ebitengine Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package vorbis
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"io"
+)
+
+const (
+	oggHeaderTypeContinued = 1 << 0
+	oggHeaderTypeFirst     = 1 << 1
+	oggHeaderTypeLast      = 1 << 2
+
+	oggPageHeaderSize = 27
+)
+
+var oggCapturePattern = []byte("OggS")
+
+var oggCRCTable = func() [256]uint32 {
+	var t [256]uint32
+	for i := range t {
+		r := uint32(i) << 24
+		for j := 0; j < 8; j++ {
+			if r&0x80000000 != 0 {
+				r = r<<1 ^ 0x04c11db7
+			} else {
+				r <<= 1
+			}
+		}
+		t[i] = r
+	}
+	return t
+}()
+
+func oggCRC(crc uint32, b []byte) uint32 {
+	for _, v := range b {
+		crc = crc<<8 ^ oggCRCTable[byte(crc>>24)^v]
+	}
+	return crc
+}
+
+// oggPage is an Ogg page.
+type oggPage struct {
+	header [oggPageHeaderSize]byte
+	lacing []byte
+	body   []byte
+
+	// offset is the byte offset of the page in the source.
+	offset int64
+}
+
+func (p *oggPage) headerType() byte {
+	return p.header[5]
+}
+
+func (p *oggPage) isContinued() bool {
+	return p.headerType()&oggHeaderTypeContinued != 0
+}
+
+func (p *oggPage) isFirst() bool {
+	return p.headerType()&oggHeaderTypeFirst != 0
+}
+
+func (p *oggPage) isLast() bool {
+	return p.headerType()&oggHeaderTypeLast != 0
+}
+
+// granule returns the absolute granule position, which is the sample position at the end of the last packet completed in the page.
+// granule returns -1 when no packet is completed in the page.
+func (p *oggPage) granule() int64 {
+	return int64(binary.LittleEndian.Uint64(p.header[6:14]))
+}
+
+func (p *oggPage) serial() uint32 {
+	return binary.LittleEndian.Uint32(p.header[14:18])
+}
+
+func (p *oggPage) bodySize() int {
+	var n int
+	for _, l := range p.lacing {
+		n += int(l)
+	}
+	return n
+}
+
+// completedPacketCount returns the number of the packets completed in the page.
+func (p *oggPage) completedPacketCount() int {
+	var n int
+	for _, l := range p.lacing {
+		if l < 255 {
+			n++
+		}
+	}
+	return n
+}
+
+// hasOwnPacket reports whether the page has a packet that begins and completes in the page.
+func (p *oggPage) hasOwnPacket() bool {
+	n := p.completedPacketCount()
+	if p.isContinued() {
+		return n >= 2
+	}
+	return n >= 1
+}
+
+func (p *oggPage) isValid() bool {
+	var h [oggPageHeaderSize]byte
+	copy(h[:], p.header[:])
+	// The checksum is calculated with the checksum field filled with zeros.
+	want := binary.LittleEndian.Uint32(h[22:26])
+	h[22], h[23], h[24], h[25] = 0, 0, 0, 0
+	crc := oggCRC(0, h[:])
+	crc = oggCRC(crc, p.lacing)
+	crc = oggCRC(crc, p.body)
+	return crc == want
+}
+
+func (p *oggPage) copyFrom(src *oggPage) {
+	p.header = src.header
+	p.lacing = append(p.lacing[:0], src.lacing...)
+	p.body = append(p.body[:0], src.body...)
+	p.offset = src.offset
+}
+
+// oggReader reads Ogg pages from a source.
+type oggReader struct {
+	src    io.Reader
+	seeker io.Seeker
+	offset int64
+
+	// unreadPage is the page pushed back by unread.
+	unreadPage *oggPage
+
+	// lost reports whether a corrupted page was skipped since the last call of takeLost.
+	lost bool
+}
+
+func newOggReader(src io.Reader) *oggReader {
+	r := &oggReader{
+		src: src,
+	}
+	r.seeker, _ = src.(io.Seeker)
+	return r
+}
+
+func (r *oggReader) read(buf []byte) error {
+	n, err := io.ReadFull(r.src, buf)
+	r.offset += int64(n)
+	return err
+}
+
+func (r *oggReader) seek(offset int64) error {
+	if r.seeker == nil {
+		return errors.New("vorbis: the source must be io.Seeker but not")
+	}
+	if _, err := r.seeker.Seek(offset, io.SeekStart); err != nil {
+		return err
+	}
+	r.offset = offset
+	r.unreadPage = nil
+	return nil
+}
+
+// readPageHeader reads the header and the lacing values of the next page.
+// If the source is not at the beginning of a page, readPageHeader skips bytes until a capture pattern is found.
+//
+// The body must be read by readPageBody or skipped by skipPageBody after readPageHeader.
+func (r *oggReader) readPageHeader(p *oggPage) error {
+	h := p.header[:]
+	if err := r.read(h[:4]); err != nil {
+		return err
+	}
+	for !bytes.Equal(h[:4], oggCapturePattern) {
+		r.lost = true
+		copy(h[:3], h[1:4])
+		if err := r.read(h[3:4]); err != nil {
+			return err
+		}
+	}
+	p.offset = r.offset - 4
+	if err := r.read(h[4:]); err != nil {
+		return err
+	}
+	if h[4] != 0 {
+		return errors.New("vorbis: unsupported Ogg version")
+	}
+	n := int(h[26])
+	if cap(p.lacing) < n {
+		p.lacing = make([]byte, n)
+	}
+	p.lacing = p.lacing[:n]
+	if err := r.read(p.lacing); err != nil {
+		return err
+	}
+	return nil
+}
+
+func (r *oggReader) readPageBody(p *oggPage) error {
+	n := p.bodySize()
+	if cap(p.body) < n {
+		p.body = make([]byte, n)
+	}
+	p.body = p.body[:n]
+	return r.read(p.body)
+}
+
+func (r *oggReader) skipPageBody(p *oggPage) error {
+	n := int64(p.bodySize())
+	if r.seeker != nil {
+		return r.seek(r.offset + n)
+	}
+	m, err := io.CopyN(io.Discard, r.src, n)
+	r.offset += m
+	return err
+}
+
+// readPage reads the next valid page.
+// Corrupted pages are skipped.
+func (r *oggReader) readPage(p *oggPage) error {
+	if r.unreadPage != nil {
+		p.copyFrom(r.unreadPage)
+		r.unreadPage = nil
+		return nil
+	}
+	for {
+		if err := r.readPageHeader(p); err != nil {
+			return err
+		}
+		if err := r.readPageBody(p); err != nil {
+			return err
+		}
+		if p.isValid() {
+			return nil
+		}
+		r.lost = true
+	}
+}
+
+// unread pushes back the page so that the next readPage returns it.
+func (r *oggReader) unread(p *oggPage) {
+	var page oggPage
+	page.copyFrom(p)
+	r.unreadPage = &page
+}
+
+func (r *oggReader) takeLost() bool {
+	lost := r.lost
+	r.lost = false
+	return lost
+}
+
+// oggPacketReader reads packets of a logical stream.
+type oggPacketReader struct {
+	r      *oggReader
+	serial uint32
+
+	page    oggPage
+	loaded  bool
+	segment int
+	pos     int
+
+	packet []byte
+
+	// skipContinued reports whether a packet continued from the previous page must be skipped.
+	skipContinued bool
+
+	// hasData reports whether a page without the beginning-of-stream flag was read.
+	hasData bool
+
+	done bool
+}
+
+func newOggPacketReader(r *oggReader, serial uint32) *oggPacketReader {
+	return &oggPacketReader{
+		r:      r,
+		serial: serial,
+	}
+}
+
+// setPage sets the first page of the logical stream that is already read.
+func (p *oggPacketReader) setPage(page *oggPage) {
+	p.page.copyFrom(page)
+	p.loaded = true
+	p.segment = 0
+	p.pos = 0
+}
+
+// seek moves the position to the page at offset.
+// A packet continued from the previous page is skipped.
+func (p *oggPacketReader) seek(offset int64) error {
+	if err := p.r.seek(offset); err != nil {
+		return err
+	}
+	p.loaded = false
+	p.packet = p.packet[:0]
+	p.skipContinued = true
+	p.hasData = true
+	p.done = false
+	return nil
+}
+
+// next returns the next packet.
+//
+// granule is the granule position of the page if the packet is the last packet completed in the page.
+// Otherwise, granule is -1.
+// last reports whether the packet is the last packet of the logical stream.
+//
+// next returns io.EOF at the end of the logical stream.
+// The returned packet is valid until the next call of next.
+func (p *oggPacketReader) next() (packet []byte, granule int64, last bool, err error) {
+	p.packet = p.packet[:0]
+	for {
+		if p.loaded {
+			for p.segment < len(p.page.lacing) {
+				l := int(p.page.lacing[p.segment])
+				p.packet = append(p.packet, p.page.body[p.pos:p.pos+l]...)
+				p.segment++
+				p.pos += l
+				if l == 255 {
+					continue
+				}
+				granule := int64(-1)
+				if !containsPacketEnd(p.page.lacing[p.segment:]) {
+					granule = p.page.granule()
+				}
+				last := granule != -1 && p.page.isLast()
+				return p.packet, granule, last, nil
+			}
+			if p.page.isLast() {
+				p.done = true
+			}
+		}
+		if p.done {
+			return nil, -1, false, io.EOF
+		}
+		if err := p.loadPage(); err != nil {
+			if err == io.EOF || err == io.ErrUnexpectedEOF {
+				// Treat a truncated stream as the end of the stream.
+				p.done = true
+				return nil, -1, false, io.EOF
+			}
+			return nil, -1, false, err
+		}
+	}
+}
+
+func (p *oggPacketReader) loadPage() error {
+	for {
+		if err := p.r.readPage(&p.page); err != nil {
+			return err
+		}
+		if p.r.takeLost() {
+			p.packet = p.packet[:0]
+			p.skipContinued = true
+		}
+		if p.page.serial() != p.serial {
+			// A beginning-of-stream page after data pages starts a new chained stream.
+			// This happens when the logical stream doesn't have an end-of-stream page.
+			if p.page.isFirst() && p.hasData {
+				p.r.unread(&p.page)
+				return io.EOF
+			}
+			continue
+		}
+		if !p.page.isFirst() {
+			p.hasData = true
+		}
+		p.loaded = true
+		p.segment = 0
+		p.pos = 0
+		if !p.page.isContinued() {
+			// An incomplete packet is discarded.
+			p.packet = p.packet[:0]
+			p.skipContinued = false
+			return nil
+		}
+		if p.skipContinued || len(p.packet) == 0 {
+			for p.segment < len(p.page.lacing) {
+				l := int(p.page.lacing[p.segment])
+				p.segment++
+				p.pos += l
+				if l < 255 {
+					p.skipContinued = false
+					break
+				}
+			}
+		}
+		return nil
+	}
+}
+
+// containsPacketEnd reports whether lacing values contain the end of a packet.
+func containsPacketEnd(lacing []byte) bool {
+	for _, l := range lacing {
+		if l < 255 {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,526 @@
+// Copyright 2026 The Ebitengine Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package vorbis
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"sort"
+
+	"github.com/jfreymuth/vorbis"
+)
+
+// vorbisLink is a Vorbis logical stream in a chained Ogg stream.
+type vorbisLink struct {
+	serial   uint32
+	decoder  *vorbis.Decoder
+	channels int
+
+	// firstPageOffset is the byte offset of the beginning-of-stream page.
+	firstPageOffset int64
+
+	// audioPageOffset is the byte offset of the first page containing audio data.
+	audioPageOffset int64
+
+	// seekPoints are the pages that can be a starting point to decode.
+	seekPoints []seekPoint
+
+	// start is the sample position where the link starts in the whole stream.
+	start int64
+
+	// length is the number of samples of the link.
+	length int64
+}
+
+// seekPoint is an index entry of a page.
+type seekPoint struct {
+	offset  int64
+	granule int64
+}
+
+// vorbisReader is a decoder of Ogg/Vorbis data.
+//
+// vorbisReader supports chained streams, where multiple logical streams are concatenated, and
+// multiplexed streams, where pages of multiple logical streams are interleaved.
+// For each chain, the first Vorbis logical stream is decoded and the other logical streams are ignored.
+// Chained streams whose sample rate is different from the first one, or that have more than 2 channels, are skipped.
+//
+// When the source is io.Seeker, vorbisReader builds an index of pages on creation to make seeking sample-accurate and fast.
+type vorbisReader struct {
+	ogg      *oggReader
+	seekable bool
+
+	channels   int
+	sampleRate int
+
+	// links are the logical streams to decode.
+	// When the source is not seekable, links has only the current link.
+	links     []*vorbisLink
+	linkIndex int
+	packets   *oggPacketReader
+	length    int64
+
+	decodeBuf []float32
+	remixBuf  []float32
+
+	// buf is the decoded samples to be read.
+	buf      []float32
+	bufStore []float32
+
+	// pending is the decoded samples whose position is not determined yet.
+	pending []float32
+
+	// posKnown reports whether the position of the next decoded sample is known.
+	posKnown bool
+
+	// pos is the position of the next decoded sample in the current link.
+	pos int64
+
+	// target is the position in the current link from which samples are read.
+	target int64
+
+	// fromLinkStart reports whether the decoding started at the beginning of the current link.
+	fromLinkStart bool
+
+	eof bool
+}
+
+func newVorbisReader(src io.Reader) (*vorbisReader, error) {
+	r := &vorbisReader{
+		ogg: newOggReader(src),
+	}
+	r.seekable = r.ogg.seeker != nil
+
+	if r.seekable {
+		if err := r.buildIndex(); err != nil {
+			return nil, err
+		}
+		if err := r.startLink(0, 0); err != nil {
+			return nil, err
+		}
+	} else {
+		link, packets, err := r.readNextLink()
+		if err != nil {
+			if err == io.EOF {
+				return nil, errors.New("vorbis: Vorbis stream not found")
+			}
+			return nil, err
+		}
+		r.channels = link.channels
+		r.sampleRate = link.decoder.SampleRate()
+		r.setLink(link, packets)
+	}
+	return r, nil
+}
+
+// SampleRate returns the sample rate.
+func (r *vorbisReader) SampleRate() int {
+	return r.sampleRate
+}
+
+// Channels returns the number of channels.
+func (r *vorbisReader) Channels() int {
+	return r.channels
+}
+
+// Length returns the number of samples.
+// Length returns 0 if the source is not io.Seeker.
+func (r *vorbisReader) Length() int64 {
+	return r.length
+}
+
+// buildIndex scans all the pages and builds the links and their seek points.
+// Only the page headers are read except for the beginning-of-stream pages.
+func (r *vorbisReader) buildIndex() error {
+	if err := r.ogg.seek(0); err != nil {
+		return err
+	}
+
+	var links []*vorbisLink
+	var (
+		page oggPage
+		// cur is the link whose pages are being scanned.
+		cur *vorbisLink
+		// headerPackets is the number of the header packets of cur.
+		headerPackets int
+		// inFirstPages reports whether the last page was a beginning-of-stream page.
+		inFirstPages bool
+	)
+	for {
+		if err := r.ogg.readPageHeader(&page); err != nil {
+			if err == io.EOF || err == io.ErrUnexpectedEOF {
+				break
+			}
+			return err
+		}
+
+		if page.isFirst() {
+			// A group of beginning-of-stream pages starts a new chain.
+			if !inFirstPages {
+				cur = nil
+			}
+			inFirstPages = true
+			if err := r.ogg.readPageBody(&page); err != nil {
+				if err == io.EOF || err == io.ErrUnexpectedEOF {
+					break
+				}
+				return err
+			}
+			// Pick the first Vorbis logical stream in the chain.
+			if cur == nil && len(page.body) > 0 && page.body[0] == 1 && vorbis.IsHeader(page.body) {
+				cur = &vorbisLink{
+					serial:          page.serial(),
+					firstPageOffset: page.offset,
+				}
+				links = append(links, cur)
+				headerPackets = page.completedPacketCount()
+			}
+			continue
+		}
+
+		inFirstPages = false
+		if err := r.ogg.skipPageBody(&page); err != nil {
+			if err == io.EOF || err == io.ErrUnexpectedEOF {
+				break
+			}
+			return err
+		}
+		if cur == nil || page.serial() != cur.serial {
+			continue
+		}
+		// The first audio packet begins on a fresh page after the header packets.
+		if headerPackets < 3 {
+			headerPackets += page.completedPacketCount()
+			continue
+		}
+		if cur.audioPageOffset == 0 {
+			cur.audioPageOffset = page.offset
+		}
+		g := page.granule()
+		if g == -1 {
+			continue
+		}
+		cur.length = g
+		if page.hasOwnPacket() {
+			cur.seekPoints = append(cur.seekPoints, seekPoint{
+				offset:  page.offset,
+				granule: g,
+			})
+		}
+	}
+
+	for i, link := range links {
+		if err := r.readHeaders(link); err != nil {
+			if i == 0 {
+				return err
+			}
+			// Skip a broken chained stream.
+			continue
+		}
+		if i == 0 {
+			r.channels = link.channels
+			r.sampleRate = link.decoder.SampleRate()
+		} else if !r.isCompatible(link) {
+			continue
+		}
+		if link.audioPageOffset == 0 {
+			continue
+		}
+		link.start = r.length
+		r.length += link.length
+		r.links = append(r.links, link)
+	}
+	if len(links) == 0 {
+		return errors.New("vorbis: Vorbis stream not found")
+	}
+	if len(r.links) == 0 {
+		// The first link doesn't have audio data.
+		r.links = links[:1]
+	}
+	return nil
+}
+
+func (r *vorbisReader) readHeaders(link *vorbisLink) error {
+	packets := newOggPacketReader(r.ogg, link.serial)
+	if err := r.ogg.seek(link.firstPageOffset); err != nil {
+		return err
+	}
+	return r.readHeadersFromPackets(link, packets)
+}
+
+func (r *vorbisReader) readHeadersFromPackets(link *vorbisLink, packets *oggPacketReader) error {
+	link.decoder = &vorbis.Decoder{}
+	for i := 0; i < 3; i++ {
+		packet, _, _, err := packets.next()
+		if err != nil {
+			if err == io.EOF {
+				return io.ErrUnexpectedEOF
+			}
+			return err
+		}
+		if err := link.decoder.ReadHeader(packet); err != nil {
+			return err
+		}
+	}
+	link.channels = link.decoder.Channels()
+	return nil
+}
+
+func (r *vorbisReader) isCompatible(link *vorbisLink) bool {
+	return link.decoder.SampleRate() == r.sampleRate && link.channels <= 2
+}
+
+// readNextLink reads pages until the next Vorbis logical stream is found.
+// readNextLink is used when the source is not seekable.
+func (r *vorbisReader) readNextLink() (*vorbisLink, *oggPacketReader, error) {
+	var page oggPage
+	for {
+		if err := r.ogg.readPage(&page); err != nil {
+			if err == io.ErrUnexpectedEOF {
+				return nil, nil, io.EOF
+			}
+			return nil, nil, err
+		}
+		if !page.isFirst() || len(page.body) == 0 || page.body[0] != 1 || !vorbis.IsHeader(page.body) {
+			continue
+		}
+
+		link := &vorbisLink{
+			serial: page.serial(),
+		}
+		packets := newOggPacketReader(r.ogg, link.serial)
+		packets.setPage(&page)
+		if err := r.readHeadersFromPackets(link, packets); err != nil {
+			if r.channels == 0 {
+				return nil, nil, err
+			}
+			// Skip a broken chained stream.
+			continue
+		}
+		if r.channels != 0 && !r.isCompatible(link) {
+			continue
+		}
+		return link, packets, nil
+	}
+}
+
+func (r *vorbisReader) setLink(link *vorbisLink, packets *oggPacketReader) {
+	if len(r.links) == 0 {
+		r.links = append(r.links, link)
+	} else {
+		r.links[0] = link
+	}
+	r.linkIndex = 0
+	r.packets = packets
+	r.resetDecoding(0)
+	r.fromLinkStart = true
+}
+
+func (r *vorbisReader) link() *vorbisLink {
+	return r.links[r.linkIndex]
+}
+
+func (r *vorbisReader) resetDecoding(target int64) {
+	r.link().decoder.Clear()
+	if n := r.link().decoder.BufferSize(); len(r.decodeBuf) < n {
+		r.decodeBuf = make([]float32, n)
+	}
+	r.buf = nil
+	r.pending = r.pending[:0]
+	r.posKnown = false
+	r.pos = 0
+	r.target = target
+}
+
+// startLink starts decoding the link at the index from the position in the link.
+// startLink is used when the source is seekable.
+func (r *vorbisReader) startLink(index int, pos int64) error {
+	r.linkIndex = index
+	link := r.link()
+	r.packets = newOggPacketReader(r.ogg, link.serial)
+	r.resetDecoding(pos)
+	if link.audioPageOffset == 0 {
+		// The link doesn't have audio data.
+		r.eof = true
+		return nil
+	}
+
+	// Start decoding from the last page where a packet is completed before the position.
+	// The packets completed in the page are decoded but the samples are discarded.
+	offset := link.audioPageOffset
+	r.fromLinkStart = true
+	if i := sort.Search(len(link.seekPoints), func(i int) bool {
+		return link.seekPoints[i].granule > pos
+	}); i > 0 {
+		offset = link.seekPoints[i-1].offset
+		r.fromLinkStart = false
+	}
+	return r.packets.seek(offset)
+}
+
+// SetPosition sets the position in samples.
+func (r *vorbisReader) SetPosition(pos int64) error {
+	if !r.seekable {
+		return fmt.Errorf("vorbis: the source must be io.Seeker but not: %w", errors.ErrUnsupported)
+	}
+	if pos < 0 {
+		pos = 0
+	}
+	if pos >= r.length {
+		r.eof = true
+		r.buf = nil
+		return nil
+	}
+	r.eof = false
+	i := sort.Search(len(r.links), func(i int) bool {
+		return r.links[i].start > pos
+	}) - 1
+	return r.startLink(i, pos-r.links[i].start)
+}
+
+// Read reads interleaved samples.
+func (r *vorbisReader) Read(p []float32) (int, error) {
+	p = p[:len(p)/r.channels*r.channels]
+	if len(p) == 0 {
+		return 0, nil
+	}
+	for len(r.buf) == 0 {
+		if r.eof {
+			return 0, io.EOF
+		}
+		if err := r.decodePacket(); err != nil {
+			return 0, err
+		}
+	}
+	n := copy(p, r.buf)
+	r.buf = r.buf[n:]
+	return n, nil
+}
+
+func (r *vorbisReader) decodePacket() error {
+	packet, granule, last, err := r.packets.next()
+	if err == io.EOF {
+		return r.startNextLink()
+	}
+	if err != nil {
+		return err
+	}
+	out, err := r.link().decoder.DecodeInto(packet, r.decodeBuf)
+	if err != nil {
+		return err
+	}
+	out = r.remix(out)
+
+	if r.posKnown {
+		n := int64(len(out) / r.channels)
+		end := r.pos + n
+		if last && granule < end {
+			// The end of the last page is trimmed.
+			end = granule
+		}
+		r.emit(out, r.pos, end)
+		r.pos += n
+		if granule != -1 {
+			r.pos = granule
+		}
+		return nil
+	}
+
+	// The position of the decoded samples is determined by the granule position at the end of a page.
+	r.pending = append(r.pending, out...)
+	if granule == -1 {
+		return nil
+	}
+	n := int64(len(r.pending) / r.channels)
+	start := granule - n
+	if last && r.fromLinkStart {
+		// The whole link is in one page. The end of the page is trimmed.
+		start = 0
+	}
+	r.emit(r.pending, start, granule)
+	r.pending = r.pending[:0]
+	r.posKnown = true
+	r.pos = granule
+	return nil
+}
+
+// emit appends the samples in [start, end) at or after the target position to the buffer.
+// samples starts at start.
+func (r *vorbisReader) emit(samples []float32, start, end int64) {
+	if r.seekable {
+		end = min(end, r.link().length)
+	}
+	end = min(end, start+int64(len(samples)/r.channels))
+	from := max(start, r.target, 0)
+	if from >= end {
+		return
+	}
+	if len(r.buf) == 0 {
+		r.buf = r.bufStore[:0]
+	}
+	for _, v := range samples[(from-start)*int64(r.channels) : (end-start)*int64(r.channels)] {
+		r.buf = append(r.buf, min(max(v, -1), 1))
+	}
+	r.bufStore = r.buf[:0]
+}
+
+// remix converts the decoded samples to the number of channels of the first link.
+func (r *vorbisReader) remix(samples []float32) []float32 {
+	ch := r.link().channels
+	if ch == r.channels {
+		return samples
+	}
+	n := len(samples) / ch
+	if cap(r.remixBuf) < n*r.channels {
+		r.remixBuf = make([]float32, n*r.channels)
+	}
+	dst := r.remixBuf[:n*r.channels]
+	switch {
+	case ch == 1 && r.channels == 2:
+		for i := 0; i < n; i++ {
+			dst[2*i] = samples[i]
+			dst[2*i+1] = samples[i]
+		}
+	case ch == 2 && r.channels == 1:
+		for i := 0; i < n; i++ {
+			dst[i] = (samples[2*i] + samples[2*i+1]) / 2
+		}
+	default:
+		panic(fmt.Sprintf("vorbis: unexpected number of channels: %d to %d", ch, r.channels))
+	}
+	return dst
+}
+
+func (r *vorbisReader) startNextLink() error {
+	if r.seekable {
+		if r.linkIndex+1 >= len(r.links) {
+			r.eof = true
+			return nil
+		}
+		return r.startLink(r.linkIndex+1, 0)
+	}
+
+	link, packets, err := r.readNextLink()
+	if err != nil {
+		if err == io.EOF {
+			r.eof = true
+			return nil
+		}
+		return err
+	}
+	r.setLink(link, packets)
+	return nil
+}
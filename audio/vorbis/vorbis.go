@@ -20,8 +20,6 @@ import (
 	"fmt"
 	"io"
 
-	"github.com/jfreymuth/oggvorbis"
-
 	"github.com/duplicants-ai/ebiten/audio"
 	"github.com/duplicants-ai/ebiten/audio/internal/convert"
 )
@@ -71,7 +69,7 @@ func (s *Stream) SampleRate() int {
 // A Stream doesn't close src even if src implements io.Closer.
 // Closing the source is src owner's responsibility.
 func DecodeF32(src io.Reader) (*Stream, error) {
-	r, err := oggvorbis.NewReader(src)
+	r, err := newVorbisReader(src)
 	if err != nil {
 		return nil, err
 	}
@@ -107,7 +105,7 @@ func DecodeF32(src io.Reader) (*Stream, error) {
 type i16Stream struct {
 	posInBytes   int64
 	seekable     bool
-	vorbisReader *oggvorbis.Reader
+	vorbisReader *vorbisReader
 	i16Reader    io.Reader
 }
 
@@ -161,7 +159,7 @@ func (s *i16Stream) totalBytes() int64 {
 
 // decodeI16 accepts an ogg stream and returns a decorded stream.
 func decodeI16(in io.Reader) (*i16Stream, error) {
-	r, err := oggvorbis.NewReader(in)
+	r, err := newVorbisReader(in)
 	if err != nil {
 		return nil, err
 	}
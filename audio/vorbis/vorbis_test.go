@@ -167,3 +167,71 @@ func TestNonSeekerF32(t *testing.T) {
 		t.Errorf("len(buf): got: %d, want: > 0", len(buf))
 	}
 }
+
+func TestChained(t *testing.T) {
+	bs := test_mono_ogg
+
+	single, err := vorbis.DecodeF32(bytes.NewReader(bs))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	chained := append(append([]byte{}, bs...), bs...)
+	s, err := vorbis.DecodeF32(bytes.NewReader(chained))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got, want := s.Length(), single.Length()*2; got != want {
+		t.Errorf("s.Length(): got: %d, want: %d", got, want)
+	}
+
+	buf, err := io.ReadAll(s)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got, want := int64(len(buf)), s.Length(); got != want {
+		t.Errorf("len(buf): got: %d, want: %d", got, want)
+	}
+
+	// A non-seekable chained stream should be decoded to the end.
+	ns, err := vorbis.DecodeF32(&reader{r: bytes.NewReader(chained)})
+	if err != nil {
+		t.Fatal(err)
+	}
+	nsBuf, err := io.ReadAll(ns)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(nsBuf, buf) {
+		t.Errorf("decoded data from a non-seekable source doesn't match")
+	}
+}
+
+func TestSeek(t *testing.T) {
+	bs := append(append([]byte{}, test_mono_ogg...), test_mono_ogg...)
+
+	s, err := vorbis.DecodeF32(bytes.NewReader(bs))
+	if err != nil {
+		t.Fatal(err)
+	}
+	all, err := io.ReadAll(s)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	const bytesPerSample = 8
+	for _, pos := range []int64{0, 1, 1000, 12345, s.Length()/bytesPerSample/2 - 1, s.Length()/bytesPerSample/2 + 777, s.Length()/bytesPerSample - 10} {
+		offset := pos * bytesPerSample
+		if _, err := s.Seek(offset, io.SeekStart); err != nil {
+			t.Fatal(err)
+		}
+		buf := make([]byte, 64*bytesPerSample)
+		n, err := io.ReadFull(s, buf)
+		if err != nil && err != io.ErrUnexpectedEOF {
+			t.Fatal(err)
+		}
+		if got, want := buf[:n], all[offset:min(offset+int64(n), int64(len(all)))]; !bytes.Equal(got, want) {
+			t.Errorf("position %d: decoded data after seeking doesn't match", pos)
+		}
+	}
+}
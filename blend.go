@@ -372,3 +372,44 @@ var (
 	//     α_out = α_src + α_dst
 	BlendLighter = internalBlendToBlend(graphicsdriver.BlendLighter)
 )
+
+// Blend presets for common separable blend modes in image editors.
+//
+// Fixed-function blending cannot express some of the modes exactly, especially when the destination is not opaque.
+// The formulas below are what the presets actually compute.
+// For the modes that cannot be expressed at all, like darken and overlay, use the package exp/blendmode, which uses shaders.
+var (
+	// BlendMultiply is a preset Blend for the 'multiply' blend mode.
+	// The result is exact when the destination is opaque.
+	//
+	//     c_out = c_src × c_dst + c_dst × (1 - α_src)
+	//     α_out = α_src + α_dst × (1 - α_src)
+	BlendMultiply = internalBlendToBlend(graphicsdriver.BlendMultiply)
+
+	// BlendScreen is a preset Blend for the 'screen' blend mode.
+	//
+	//     c_out = c_src + c_dst × (1 - c_src)
+	//     α_out = α_src + α_dst × (1 - α_src)
+	BlendScreen = internalBlendToBlend(graphicsdriver.BlendScreen)
+
+	// BlendAdditive is a preset Blend for the additive blending with alpha.
+	// Unlike BlendLighter, the alpha is composed in the same way as BlendSourceOver,
+	// so the destination alpha doesn't become more opaque than with the regular alpha blending.
+	//
+	//     c_out = c_src + c_dst
+	//     α_out = α_src + α_dst × (1 - α_src)
+	BlendAdditive = internalBlendToBlend(graphicsdriver.BlendAdditive)
+
+	// BlendSubtract is a preset Blend for the 'subtract' blend mode, which subtracts the source color from the destination color.
+	//
+	//     c_out = c_dst - c_src
+	//     α_out = α_dst
+	BlendSubtract = internalBlendToBlend(graphicsdriver.BlendSubtract)
+
+	// BlendLighten is a preset Blend for the 'lighten' blend mode.
+	// The result is exact when the source is opaque or fully transparent.
+	//
+	//     c_out = max(c_src, c_dst)
+	//     α_out = max(α_src, α_dst)
+	BlendLighten = internalBlendToBlend(graphicsdriver.BlendLighten)
+)
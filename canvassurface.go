@@ -0,0 +1,59 @@
+// Copyright 2026 The Ebitengine Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ebiten
+
+import (
+	"github.com/duplicants-ai/ebiten/internal/ui"
+)
+
+// CanvasSurface is an additional render surface backed by an existing canvas element on browsers.
+//
+// CanvasSurface is useful to show a part of the game, e.g., a minimap or a status panel, at another place in the web page.
+type CanvasSurface struct {
+	surface *ui.CanvasSurface
+	pixels  []byte
+}
+
+// NewCanvasSurface creates a new CanvasSurface for the existing canvas element specified by the CSS selector, e.g., "#minimap".
+//
+// The canvas must not be the canvas for the main screen.
+//
+// NewCanvasSurface works only on browsers. NewCanvasSurface returns an error on the other environments.
+func NewCanvasSurface(selector string) (*CanvasSurface, error) {
+	s, err := ui.NewCanvasSurface(selector)
+	if err != nil {
+		return nil, err
+	}
+	return &CanvasSurface{
+		surface: s,
+	}, nil
+}
+
+// Present copies the content of img to the canvas.
+// The canvas's pixel size is changed to img's size.
+//
+// Present reads pixels from GPU, so this might be slow. Avoid calling Present for a big image every frame.
+//
+// Present must be called from the game's Update or Draw.
+func (c *CanvasSurface) Present(img *Image) {
+	b := img.Bounds()
+	n := 4 * b.Dx() * b.Dy()
+	if cap(c.pixels) < n {
+		c.pixels = make([]byte, n)
+	}
+	c.pixels = c.pixels[:n]
+	img.ReadPixels(c.pixels)
+	c.surface.Present(c.pixels, b.Dx(), b.Dy())
+}
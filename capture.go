@@ -0,0 +1,142 @@
+// Copyright 2026 The Ebitengine Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ebiten
+
+import (
+	"fmt"
+	"image"
+	"sync"
+
+	"github.com/duplicants-ai/ebiten/internal/atlas"
+)
+
+// CaptureOptions represents options for CaptureScreen.
+type CaptureOptions struct {
+	// Width and Height are the size of the captured image in pixels.
+	// If Width or Height is 0, the current offscreen size is used.
+	Width  int
+	Height int
+
+	// Supersampling is the supersampling factor.
+	// The game is drawn onto an image Supersampling times as large as the captured image,
+	// and the image is downsampled to the captured image size.
+	// A power of two is recommended for the quality of the downsampling.
+	// If Supersampling is 0, 1 is used.
+	Supersampling int
+}
+
+type captureRequest struct {
+	width         int
+	height        int
+	supersampling int
+	callback      func(img *image.RGBA, err error)
+}
+
+var (
+	captureRequests  []captureRequest
+	captureRequestsM sync.Mutex
+)
+
+// CaptureScreen requests to capture the game screen at a custom resolution, e.g. for marketing captures and photo modes.
+//
+// After the next Draw, the game's Draw is called once more with a temporary screen of the capture size,
+// and callback is called with the result.
+// The live game resolution is not changed.
+//
+// As the screen given to Draw is larger than usual, the game should render its contents based on the screen's bounds,
+// for example by scaling the contents by the screen width divided by the logical screen width.
+//
+// The captured image's pixels are in premultiplied alpha, and in the color space of the screen (see ScreenColorSpace).
+// callback is called on the same goroutine as Draw.
+// options can be nil.
+//
+// If the capture size multiplied by Supersampling exceeds the maximum image size of the graphics device,
+// nothing is drawn and callback is called with a nil image and an error.
+//
+// CaptureScreen works only with RunGame and RunGameWithOptions.
+//
+// CaptureScreen is concurrent-safe.
+func CaptureScreen(options *CaptureOptions, callback func(img *image.RGBA, err error)) {
+	if options == nil {
+		options = &CaptureOptions{}
+	}
+	if options.Width < 0 || options.Height < 0 {
+		panic(fmt.Sprintf("ebiten: the capture size must be non-negative but (%d, %d)", options.Width, options.Height))
+	}
+	if options.Supersampling < 0 {
+		panic(fmt.Sprintf("ebiten: Supersampling must be non-negative but %d", options.Supersampling))
+	}
+	if callback == nil {
+		panic("ebiten: callback must not be nil")
+	}
+
+	captureRequestsM.Lock()
+	defer captureRequestsM.Unlock()
+	captureRequests = append(captureRequests, captureRequest{
+		width:         options.Width,
+		height:        options.Height,
+		supersampling: max(options.Supersampling, 1),
+		callback:      callback,
+	})
+}
+
+// processCaptureRequests processes the requests by CaptureScreen.
+// processCaptureRequests must be called in a frame.
+func (g *gameForUI) processCaptureRequests() {
+	captureRequestsM.Lock()
+	reqs := captureRequests
+	captureRequests = nil
+	captureRequestsM.Unlock()
+
+	for _, req := range reqs {
+		req.callback(g.capture(req))
+	}
+}
+
+func (g *gameForUI) capture(req captureRequest) (*image.RGBA, error) {
+	w, h := req.width, req.height
+	if w == 0 || h == 0 {
+		b := g.offscreen.Bounds()
+		w, h = b.Dx(), b.Dy()
+	}
+
+	// Compare the sizes by division so that the multiplication doesn't overflow.
+	if s := atlas.MaxImageSize(); w > s/req.supersampling || h > s/req.supersampling {
+		return nil, fmt.Errorf("ebiten: the capture size (%d, %d) with Supersampling %d exceeds the maximum image size %d", w, h, req.supersampling, s)
+	}
+
+	// Use unmanaged images like the offscreen.
+	screen := newImage(image.Rect(0, 0, w*req.supersampling, h*req.supersampling), atlas.ImageTypeUnmanaged)
+	defer screen.Deallocate()
+	g.game.Draw(screen)
+
+	dst := screen
+	if req.supersampling > 1 {
+		dst = newImage(image.Rect(0, 0, w, h), atlas.ImageTypeUnmanaged)
+		defer dst.Deallocate()
+
+		// Mipmaps are used for the linear filter with a scale less than 1.
+		op := &DrawImageOptions{}
+		s := 1 / float64(req.supersampling)
+		op.GeoM.Scale(s, s)
+		op.Filter = FilterLinear
+		op.Blend = BlendCopy
+		dst.DrawImage(screen, op)
+	}
+
+	img := image.NewRGBA(image.Rect(0, 0, w, h))
+	dst.ReadPixels(img.Pix)
+	return img, nil
+}
@@ -0,0 +1,107 @@
+// Copyright 2026 The Ebitengine Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ebiten_test
+
+import (
+	"image"
+	"image/color"
+	"testing"
+
+	"github.com/duplicants-ai/ebiten"
+)
+
+type captureGame struct {
+	drawn int
+}
+
+func (g *captureGame) Update() error {
+	return nil
+}
+
+func (g *captureGame) Draw(screen *ebiten.Image) {
+	g.drawn++
+
+	// Fill the left half with red and the right half with blue, based on the screen's bounds.
+	w, h := screen.Bounds().Dx(), screen.Bounds().Dy()
+	screen.SubImage(image.Rect(0, 0, w/2, h)).(*ebiten.Image).Fill(color.RGBA{R: 0xff, A: 0xff})
+	screen.SubImage(image.Rect(w/2, 0, w, h)).(*ebiten.Image).Fill(color.RGBA{B: 0xff, A: 0xff})
+}
+
+func (g *captureGame) Layout(outsideWidth, outsideHeight int) (int, int) {
+	return outsideWidth, outsideHeight
+}
+
+func TestCaptureScreen(t *testing.T) {
+	offscreen := ebiten.NewImage(16, 8)
+	defer offscreen.Deallocate()
+
+	for _, s := range []int{0, 1, 2, 4} {
+		for _, size := range []image.Point{{}, {32, 16}} {
+			g := &captureGame{}
+			img, err := ebiten.CaptureForTesting(g, offscreen, &ebiten.CaptureOptions{
+				Width:         size.X,
+				Height:        size.Y,
+				Supersampling: s,
+			})
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got, want := g.drawn, 1; got != want {
+				t.Errorf("supersampling: %d, size: %v: drawn: got: %d, want: %d", s, size, got, want)
+			}
+
+			w, h := size.X, size.Y
+			if w == 0 || h == 0 {
+				w, h = 16, 8
+			}
+			if got, want := img.Bounds(), image.Rect(0, 0, w, h); got != want {
+				t.Fatalf("supersampling: %d, size: %v: bounds: got: %v, want: %v", s, size, got, want)
+			}
+			for j := 0; j < h; j++ {
+				for i := 0; i < w; i++ {
+					got := img.RGBAAt(i, j)
+					want := color.RGBA{R: 0xff, A: 0xff}
+					if i >= w/2 {
+						want = color.RGBA{B: 0xff, A: 0xff}
+					}
+					if got != want {
+						t.Errorf("supersampling: %d, size: %v: (%d, %d): got: %v, want: %v", s, size, i, j, got, want)
+					}
+				}
+			}
+		}
+	}
+}
+
+func TestCaptureScreenTooLarge(t *testing.T) {
+	offscreen := ebiten.NewImage(16, 8)
+	defer offscreen.Deallocate()
+
+	g := &captureGame{}
+	img, err := ebiten.CaptureForTesting(g, offscreen, &ebiten.CaptureOptions{
+		Width:         1024,
+		Height:        1024,
+		Supersampling: 1 << 20,
+	})
+	if err == nil {
+		t.Errorf("err must not be nil")
+	}
+	if img != nil {
+		t.Errorf("img must be nil but %v", img.Bounds())
+	}
+	if got, want := g.drawn, 0; got != want {
+		t.Errorf("drawn: got: %d, want: %d", got, want)
+	}
+}
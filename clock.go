@@ -0,0 +1,161 @@
+// Copyright 2026 The Ebitengine Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ebiten
+
+import (
+	"sync"
+	"time"
+
+	"github.com/duplicants-ai/ebiten/internal/clock"
+	"github.com/duplicants-ai/ebiten/internal/ui"
+)
+
+// FrameDelta returns the wall-clock duration between the previous frame and the current frame.
+//
+// FrameDelta is for e.g. animations in Draw that don't rely on the game logic.
+// For the game logic in Update, use a fixed delta 1/TPS or Clock's Delta instead.
+//
+// FrameDelta is concurrent-safe.
+func FrameDelta() time.Duration {
+	return clock.FrameDelta()
+}
+
+// TickInterpolation returns how far the current time is from the last tick toward the next tick, in [0, 1).
+//
+// TickInterpolation is useful to interpolate the positions of objects in Draw
+// when FPS is higher than TPS, e.g., prev + (curr - prev) * TickInterpolation().
+//
+// TickInterpolation always returns 0 when TPS is SyncWithFPS.
+//
+// TickInterpolation is concurrent-safe.
+func TickInterpolation() float64 {
+	return clock.TickProgress()
+}
+
+// Clock is a game-time clock that advances by Update ticks.
+//
+// A Clock can be paused and scaled independently of other Clocks,
+// e.g. a clock for the game world that is paused in a menu and a clock for the UI that is never paused.
+//
+// The zero value of Clock is not usable. Use NewClock to create a Clock.
+//
+// Clock's functions are concurrent-safe.
+type Clock struct {
+	timeScale float64
+	paused    bool
+	elapsed   time.Duration
+	m         sync.Mutex
+
+	// lastTickTime is the total tick time at the last advance.
+	lastTickTime time.Duration
+}
+
+// NewClock creates a new Clock with the time scale 1.
+func NewClock() *Clock {
+	return &Clock{
+		timeScale:    1,
+		lastTickTime: currentTickTime(),
+	}
+}
+
+// currentTickTime returns the total duration of the ticks since the game started in the real time.
+// currentTickTime is a variable for testing.
+var currentTickTime = func() time.Duration {
+	return ui.Get().TickTime()
+}
+
+// advance advances the elapsed time by the ticks since the last call.
+// advance must be called with c.m locked.
+func (c *Clock) advance() {
+	t := currentTickTime()
+	d := t - c.lastTickTime
+	c.lastTickTime = t
+	if d == 0 || c.paused {
+		return
+	}
+	c.elapsed += time.Duration(float64(d) * c.timeScale)
+}
+
+// Delta returns the game time per tick, which is 1/TPS [s] multiplied by the time scale.
+//
+// Delta returns 0 when the clock is paused.
+func (c *Clock) Delta() time.Duration {
+	c.m.Lock()
+	defer c.m.Unlock()
+
+	if c.paused {
+		return 0
+	}
+	return time.Duration(float64(clock.TickDuration()) * c.timeScale)
+}
+
+// Elapsed returns the total game time elapsed since the clock was created.
+//
+// Elapsed doesn't advance while the clock is paused.
+func (c *Clock) Elapsed() time.Duration {
+	c.m.Lock()
+	defer c.m.Unlock()
+
+	c.advance()
+	return c.elapsed
+}
+
+// TimeScale returns the time scale.
+func (c *Clock) TimeScale() float64 {
+	c.m.Lock()
+	defer c.m.Unlock()
+	return c.timeScale
+}
+
+// SetTimeScale sets the time scale, e.g., 0.5 for a slow motion and 2 for a fast-forward.
+//
+// SetTimeScale panics if scale is negative.
+func (c *Clock) SetTimeScale(scale float64) {
+	if scale < 0 {
+		panic("ebiten: scale must be >= 0")
+	}
+
+	c.m.Lock()
+	defer c.m.Unlock()
+
+	// Apply the current scale to the past ticks before changing the scale.
+	c.advance()
+	c.timeScale = scale
+}
+
+// IsPaused reports whether the clock is paused.
+func (c *Clock) IsPaused() bool {
+	c.m.Lock()
+	defer c.m.Unlock()
+	return c.paused
+}
+
+// SetPaused pauses or resumes the clock.
+func (c *Clock) SetPaused(paused bool) {
+	c.m.Lock()
+	defer c.m.Unlock()
+
+	c.advance()
+	c.paused = paused
+}
+
+// Reset resets the elapsed time to 0.
+func (c *Clock) Reset() {
+	c.m.Lock()
+	defer c.m.Unlock()
+
+	c.advance()
+	c.elapsed = 0
+}
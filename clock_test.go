@@ -0,0 +1,143 @@
+// Copyright 2026 The Ebitengine Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ebiten_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/duplicants-ai/ebiten"
+)
+
+func TestClock(t *testing.T) {
+	var tickTime time.Duration
+	defer ebiten.SetCurrentTickTimeForTesting(func() time.Duration {
+		return tickTime
+	})()
+
+	c := ebiten.NewClock()
+	d := c.Delta()
+	if got, want := d, time.Second/time.Duration(ebiten.TPS()); got != want {
+		t.Errorf("c.Delta(): got: %v, want: %v", got, want)
+	}
+
+	tickTime += 10 * d
+	if got, want := c.Elapsed(), 10*d; got != want {
+		t.Errorf("c.Elapsed(): got: %v, want: %v", got, want)
+	}
+
+	c.SetTimeScale(2)
+	if got, want := c.Delta(), 2*d; got != want {
+		t.Errorf("c.Delta(): got: %v, want: %v", got, want)
+	}
+	tickTime += 10 * d
+	if got, want := c.Elapsed(), 30*d; got != want {
+		t.Errorf("c.Elapsed(): got: %v, want: %v", got, want)
+	}
+
+	c.SetPaused(true)
+	if got, want := c.Delta(), time.Duration(0); got != want {
+		t.Errorf("c.Delta(): got: %v, want: %v", got, want)
+	}
+	tickTime += 10 * d
+	if got, want := c.Elapsed(), 30*d; got != want {
+		t.Errorf("c.Elapsed(): got: %v, want: %v", got, want)
+	}
+
+	c.SetPaused(false)
+	c.Reset()
+	tickTime += 10 * d
+	if got, want := c.Elapsed(), 20*d; got != want {
+		t.Errorf("c.Elapsed(): got: %v, want: %v", got, want)
+	}
+}
+
+func TestClockWithVariableTickDurations(t *testing.T) {
+	var tickTime time.Duration
+	defer ebiten.SetCurrentTickTimeForTesting(func() time.Duration {
+		return tickTime
+	})()
+
+	c := ebiten.NewClock()
+
+	// The duration of each tick varies, e.g., by SetTPS, a TPS limit, or frame durations with SyncWithFPS.
+	// The elapsed time must be the sum of the tick durations, and must never go backwards.
+	durations := []time.Duration{
+		time.Second / 60, time.Second / 60, time.Second / 30,
+		5 * time.Millisecond, 40 * time.Millisecond, time.Millisecond, 100 * time.Millisecond, 2 * time.Millisecond,
+	}
+	var want, prev time.Duration
+	for _, d := range durations {
+		tickTime += d
+		want += d
+		got := c.Elapsed()
+		if got != want {
+			t.Errorf("c.Elapsed(): got: %v, want: %v", got, want)
+		}
+		if got < prev {
+			t.Errorf("c.Elapsed() went backwards: %v -> %v", prev, got)
+		}
+		prev = got
+	}
+
+	// A clock created later counts only the ticks after the clock is created.
+	c2 := ebiten.NewClock()
+	c2.SetTimeScale(0.5)
+	tickTime += 40 * time.Millisecond
+	tickTime += 2 * time.Millisecond
+	if got, want := c2.Elapsed(), 21*time.Millisecond; got != want {
+		t.Errorf("c2.Elapsed(): got: %v, want: %v", got, want)
+	}
+}
+
+func TestClockWithTPSLimit(t *testing.T) {
+	var tickTime time.Duration
+	defer ebiten.SetCurrentTickTimeForTesting(func() time.Duration {
+		return tickTime
+	})()
+
+	defer ebiten.SetTPS(ebiten.TPS())
+	ebiten.SetTPS(60)
+	defer ebiten.SetTPSLimitForTesting(0)
+
+	c := ebiten.NewClock()
+	if got, want := c.Delta(), time.Second/60; got != want {
+		t.Errorf("c.Delta(): got: %v, want: %v", got, want)
+	}
+	tickTime += 60 * (time.Second / 60)
+	want := 60 * (time.Second / 60)
+	if got := c.Elapsed(); got != want {
+		t.Errorf("c.Elapsed(): got: %v, want: %v", got, want)
+	}
+
+	// Limiting TPS, e.g. by the low-power mode, changes the tick duration but must not change the past ticks.
+	ebiten.SetTPSLimitForTesting(30)
+	if got, want := c.Delta(), time.Second/30; got != want {
+		t.Errorf("c.Delta(): got: %v, want: %v", got, want)
+	}
+	if got := c.Elapsed(); got != want {
+		t.Errorf("c.Elapsed(): got: %v, want: %v", got, want)
+	}
+	tickTime += 30 * (time.Second / 30)
+	want += 30 * (time.Second / 30)
+	if got := c.Elapsed(); got != want {
+		t.Errorf("c.Elapsed(): got: %v, want: %v", got, want)
+	}
+
+	ebiten.SetTPSLimitForTesting(0)
+	if got := c.Elapsed(); got != want {
+		t.Errorf("c.Elapsed(): got: %v, want: %v", got, want)
+	}
+}
@@ -0,0 +1,58 @@
+// Copyright 2026 The Ebitengine Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package {{.JavaPkg}}.{{.PrefixLower}}
+
+import androidx.compose.runtime.Composable
+import androidx.compose.runtime.DisposableEffect
+import androidx.compose.runtime.remember
+import androidx.compose.ui.Modifier
+import androidx.compose.ui.platform.LocalContext
+import androidx.compose.ui.viewinterop.AndroidView
+import androidx.lifecycle.Lifecycle
+import androidx.lifecycle.LifecycleEventObserver
+import androidx.lifecycle.compose.LocalLifecycleOwner
+
+// EbitenGameView is a Jetpack Compose function showing the game.
+//
+// Add this file to your application module, which depends on the generated AAR, and call it in your composition:
+//
+//     setContent {
+//         EbitenGameView(modifier = Modifier.fillMaxSize())
+//     }
+//
+// The game is suspended at ON_PAUSE and resumed at ON_RESUME of the current lifecycle owner.
+// Thus, you don't have to call suspendGame and resumeGame in your activity.
+@Composable
+fun EbitenGameView(modifier: Modifier = Modifier) {
+    val context = LocalContext.current
+    val lifecycleOwner = LocalLifecycleOwner.current
+    val view = remember { EbitenView(context) }
+
+    DisposableEffect(lifecycleOwner) {
+        val observer = LifecycleEventObserver { _, event ->
+            when (event) {
+                Lifecycle.Event.ON_PAUSE -> view.suspendGame()
+                Lifecycle.Event.ON_RESUME -> view.resumeGame()
+                else -> {}
+            }
+        }
+        lifecycleOwner.lifecycle.addObserver(observer)
+        onDispose {
+            lifecycleOwner.lifecycle.removeObserver(observer)
+        }
+    }
+
+    AndroidView(factory = { view }, modifier = modifier)
+}
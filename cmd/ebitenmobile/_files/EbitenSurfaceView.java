@@ -16,19 +16,22 @@ package {{.JavaPkg}}.{{.PrefixLower}};
 
 import android.content.Context;
 import android.opengl.GLSurfaceView;
+import android.os.Build;
 import android.os.Handler;
 import android.os.Looper;
 import android.util.AttributeSet;
 import android.util.Log;
+import android.view.Surface;
 
 import javax.microedition.khronos.egl.EGLConfig;
 import javax.microedition.khronos.opengles.GL10;
 
 import {{.JavaPkg}}.ebitenmobileview.Ebitenmobileview;
+import {{.JavaPkg}}.ebitenmobileview.FrameRateController;
 import {{.JavaPkg}}.ebitenmobileview.Renderer;
 import {{.JavaPkg}}.{{.PrefixLower}}.EbitenView;
 
-class EbitenSurfaceView extends GLSurfaceView implements Renderer {
+class EbitenSurfaceView extends GLSurfaceView implements Renderer, FrameRateController {
     // As GLSurfaceView can be recreated, the states must be static (#3097).
     static private boolean errored_ = false;
     static private boolean onceSurfaceCreated_ = false;
@@ -78,6 +81,16 @@ class EbitenSurfaceView extends GLSurfaceView implements Renderer {
 
         @Override
         public void onSurfaceChanged(GL10 gl, int width, int height) {
+            // The surface can be resized without recreation e.g., at folding or unfolding a foldable device.
+            // Request a new layout so that the game's outside size follows the surface size.
+            new Handler(Looper.getMainLooper()).post(new Runnable() {
+                @Override
+                public void run() {
+                    if (getParent() != null) {
+                        getParent().requestLayout();
+                    }
+                }
+            });
         }
     }
 
@@ -99,6 +112,7 @@ class EbitenSurfaceView extends GLSurfaceView implements Renderer {
         setRenderer(new EbitenRenderer());
 
         Ebitenmobileview.setRenderer(this);
+        Ebitenmobileview.setFrameRateController(this);
     }
 
     private void onErrorOnGameUpdate(Exception e) {
@@ -128,4 +142,18 @@ class EbitenSurfaceView extends GLSurfaceView implements Renderer {
             requestRender();
         }
     }
+
+    @Override
+    public void setPreferredFrameRateRange(long min, long max, long preferred) {
+        if (Build.VERSION.SDK_INT < Build.VERSION_CODES.R) {
+            return;
+        }
+        Surface surface = getHolder().getSurface();
+        if (surface == null || !surface.isValid()) {
+            return;
+        }
+        // Android doesn't have an API to specify a range. Use the preferred rate, or the maximum rate if there is no preference.
+        float rate = (float)(preferred != 0 ? preferred : max);
+        surface.setFrameRate(rate, Surface.FRAME_RATE_COMPATIBILITY_DEFAULT);
+    }
 }
@@ -19,10 +19,21 @@ import java.util.Collections;
 import java.util.Comparator;
 import java.util.List;
 
+import android.app.Activity;
+import android.content.BroadcastReceiver;
 import android.content.Context;
+import android.content.Intent;
+import android.content.IntentFilter;
+import android.content.pm.ActivityInfo;
+import android.content.res.Configuration;
+import android.graphics.Rect;
 import android.hardware.input.InputManager;
+import android.os.BatteryManager;
+import android.os.Build;
 import android.os.Handler;
 import android.os.Looper;
+import android.os.PowerManager;
+import android.provider.Settings;
 import android.util.AttributeSet;
 import android.util.DisplayMetrics;
 import android.util.Log;
@@ -30,12 +41,49 @@ import android.view.Display;
 import android.view.KeyEvent;
 import android.view.InputDevice;
 import android.view.MotionEvent;
+import android.view.Surface;
 import android.view.ViewGroup;
+import android.view.WindowInsets;
 import android.view.WindowManager;
 
+import {{.JavaPkg}}.ebitenmobileview.Announcer;
 import {{.JavaPkg}}.ebitenmobileview.Ebitenmobileview;
+import {{.JavaPkg}}.ebitenmobileview.MessageReceiver;
+import {{.JavaPkg}}.ebitenmobileview.OrientationLocker;
+import {{.JavaPkg}}.ebitenmobileview.ScreenSaverInhibitor;
+
+public class EbitenView extends ViewGroup implements InputManager.InputDeviceListener, OrientationLocker, MessageReceiver, ScreenSaverInhibitor, Announcer {
+    // These values must be synced with ui.Orientation.
+    private static final int ORIENTATION_UNKNOWN = 0;
+    private static final int ORIENTATION_PORTRAIT = 1;
+    private static final int ORIENTATION_PORTRAIT_UPSIDE_DOWN = 2;
+    private static final int ORIENTATION_LANDSCAPE_LEFT = 3;
+    private static final int ORIENTATION_LANDSCAPE_RIGHT = 4;
+
+    // These values must be synced with ui.MultiWindowMode.
+    private static final int MULTI_WINDOW_MODE_NONE = 0;
+    private static final int MULTI_WINDOW_MODE_MULTI_WINDOW = 1;
+    private static final int MULTI_WINDOW_MODE_PICTURE_IN_PICTURE = 2;
+
+    // These values must be synced with ui.ColorScheme.
+    private static final int COLOR_SCHEME_UNKNOWN = 0;
+    private static final int COLOR_SCHEME_LIGHT = 1;
+    private static final int COLOR_SCHEME_DARK = 2;
+
+    // These values must be synced with ui.BatteryState.
+    private static final int BATTERY_STATE_UNKNOWN = 0;
+    private static final int BATTERY_STATE_NO_BATTERY = 1;
+    private static final int BATTERY_STATE_CHARGING = 2;
+    private static final int BATTERY_STATE_DISCHARGING = 3;
+    private static final int BATTERY_STATE_FULL = 4;
+
+    // These values must be synced with ui.ThermalState.
+    private static final int THERMAL_STATE_UNKNOWN = 0;
+    private static final int THERMAL_STATE_NOMINAL = 1;
+    private static final int THERMAL_STATE_FAIR = 2;
+    private static final int THERMAL_STATE_SERIOUS = 3;
+    private static final int THERMAL_STATE_CRITICAL = 4;
 
-public class EbitenView extends ViewGroup implements InputManager.InputDeviceListener {
     static class Gamepad {
         public int deviceId;
         public ArrayList<InputDevice.MotionRange> axes;
@@ -122,6 +170,11 @@ public class EbitenView extends ViewGroup implements InputManager.InputDeviceLis
         for (int id : this.inputManager.getInputDeviceIds()) {
             this.onInputDeviceAdded(id);
         }
+
+        Ebitenmobileview.setOrientationLocker(this);
+        Ebitenmobileview.setMessageReceiver(this);
+        Ebitenmobileview.setScreenSaverInhibitor(this);
+        Ebitenmobileview.setAnnouncer(this);
     }
 
     @Override
@@ -129,9 +182,262 @@ public class EbitenView extends ViewGroup implements InputManager.InputDeviceLis
         this.ebitenSurfaceView.layout(0, 0, right - left, bottom - top);
         double widthInDp = pxToDp(right - left);
         double heightInDp = pxToDp(bottom - top);
+        this.updateOrientation();
+        this.updateSafeAreaInsets(getRootWindowInsets());
+        this.updateMultiWindowMode();
+        this.updateSystemSettings();
         Ebitenmobileview.layout(widthInDp, heightInDp);
     }
 
+    @Override
+    protected void onAttachedToWindow() {
+        super.onAttachedToWindow();
+
+        IntentFilter filter = new IntentFilter();
+        filter.addAction(Intent.ACTION_BATTERY_CHANGED);
+        filter.addAction(PowerManager.ACTION_POWER_SAVE_MODE_CHANGED);
+        getContext().registerReceiver(this.powerStatusReceiver, filter);
+
+        if (Build.VERSION.SDK_INT >= Build.VERSION_CODES.Q) {
+            PowerManager powerManager = (PowerManager)getContext().getSystemService(Context.POWER_SERVICE);
+            this.thermalStatusListener = new PowerManager.OnThermalStatusChangedListener() {
+                @Override
+                public void onThermalStatusChanged(int status) {
+                    updatePowerStatus();
+                }
+            };
+            powerManager.addThermalStatusListener(this.thermalStatusListener);
+        }
+
+        this.updatePowerStatus();
+    }
+
+    @Override
+    protected void onDetachedFromWindow() {
+        getContext().unregisterReceiver(this.powerStatusReceiver);
+
+        if (Build.VERSION.SDK_INT >= Build.VERSION_CODES.Q && this.thermalStatusListener != null) {
+            PowerManager powerManager = (PowerManager)getContext().getSystemService(Context.POWER_SERVICE);
+            powerManager.removeThermalStatusListener(this.thermalStatusListener);
+            this.thermalStatusListener = null;
+        }
+
+        super.onDetachedFromWindow();
+    }
+
+    private void updatePowerStatus() {
+        Context context = getContext();
+
+        int batteryState = BATTERY_STATE_UNKNOWN;
+        double batteryLevel = -1;
+        // ACTION_BATTERY_CHANGED is sticky, and the current battery status can be retrieved without a receiver.
+        Intent battery = context.registerReceiver(null, new IntentFilter(Intent.ACTION_BATTERY_CHANGED));
+        if (battery != null) {
+            if (!battery.getBooleanExtra(BatteryManager.EXTRA_PRESENT, true)) {
+                batteryState = BATTERY_STATE_NO_BATTERY;
+            } else {
+                boolean plugged = battery.getIntExtra(BatteryManager.EXTRA_PLUGGED, 0) != 0;
+                switch (battery.getIntExtra(BatteryManager.EXTRA_STATUS, BatteryManager.BATTERY_STATUS_UNKNOWN)) {
+                case BatteryManager.BATTERY_STATUS_CHARGING:
+                    batteryState = BATTERY_STATE_CHARGING;
+                    break;
+                case BatteryManager.BATTERY_STATUS_DISCHARGING:
+                case BatteryManager.BATTERY_STATUS_NOT_CHARGING:
+                    batteryState = plugged ? BATTERY_STATE_FULL : BATTERY_STATE_DISCHARGING;
+                    break;
+                case BatteryManager.BATTERY_STATUS_FULL:
+                    batteryState = BATTERY_STATE_FULL;
+                    break;
+                }
+                int level = battery.getIntExtra(BatteryManager.EXTRA_LEVEL, -1);
+                int scale = battery.getIntExtra(BatteryManager.EXTRA_SCALE, -1);
+                if (level >= 0 && scale > 0) {
+                    batteryLevel = (double)level / scale;
+                }
+            }
+        }
+
+        PowerManager powerManager = (PowerManager)context.getSystemService(Context.POWER_SERVICE);
+        boolean powerSaving = powerManager.isPowerSaveMode();
+
+        int thermalState = THERMAL_STATE_UNKNOWN;
+        if (Build.VERSION.SDK_INT >= Build.VERSION_CODES.Q) {
+            switch (powerManager.getCurrentThermalStatus()) {
+            case PowerManager.THERMAL_STATUS_NONE:
+                thermalState = THERMAL_STATE_NOMINAL;
+                break;
+            case PowerManager.THERMAL_STATUS_LIGHT:
+            case PowerManager.THERMAL_STATUS_MODERATE:
+                thermalState = THERMAL_STATE_FAIR;
+                break;
+            case PowerManager.THERMAL_STATUS_SEVERE:
+                thermalState = THERMAL_STATE_SERIOUS;
+                break;
+            default:
+                thermalState = THERMAL_STATE_CRITICAL;
+                break;
+            }
+        }
+
+        Ebitenmobileview.setPowerStatus(batteryState, batteryLevel, powerSaving, thermalState);
+    }
+
+    // onConfigurationChanged is called when the activity handles configuration changes by itself.
+    //
+    // On foldables and in multi-window mode, it is recommended to declare
+    // android:configChanges="orientation|screenSize|screenLayout|smallestScreenSize" for the activity.
+    // Otherwise, the activity and its GL surface are recreated at folding, unfolding, and resizing.
+    @Override
+    protected void onConfigurationChanged(Configuration newConfig) {
+        super.onConfigurationChanged(newConfig);
+        this.updateOrientation();
+        this.updateMultiWindowMode();
+        this.updateSystemSettings();
+        requestLayout();
+    }
+
+    private void updateSystemSettings() {
+        Configuration config = getResources().getConfiguration();
+        int colorScheme = COLOR_SCHEME_UNKNOWN;
+        switch (config.uiMode & Configuration.UI_MODE_NIGHT_MASK) {
+        case Configuration.UI_MODE_NIGHT_NO:
+            colorScheme = COLOR_SCHEME_LIGHT;
+            break;
+        case Configuration.UI_MODE_NIGHT_YES:
+            colorScheme = COLOR_SCHEME_DARK;
+            break;
+        }
+        // Android doesn't have a dedicated setting for the reduced motion. Disabled animations are treated as the reduced motion.
+        boolean reducedMotion = Settings.Global.getFloat(getContext().getContentResolver(), Settings.Global.ANIMATOR_DURATION_SCALE, 1.0f) == 0.0f;
+        // Android doesn't have a public API for the high contrast mode.
+        Ebitenmobileview.setSystemSettings(colorScheme, reducedMotion, false, config.fontScale);
+    }
+
+    private void updateMultiWindowMode() {
+        if (Build.VERSION.SDK_INT < Build.VERSION_CODES.N) {
+            return;
+        }
+        if (!(getContext() instanceof Activity)) {
+            return;
+        }
+        Activity activity = (Activity)getContext();
+        int mode = MULTI_WINDOW_MODE_NONE;
+        if (activity.isInPictureInPictureMode()) {
+            mode = MULTI_WINDOW_MODE_PICTURE_IN_PICTURE;
+        } else if (activity.isInMultiWindowMode()) {
+            mode = MULTI_WINDOW_MODE_MULTI_WINDOW;
+        }
+        Ebitenmobileview.setMultiWindowMode(mode);
+    }
+
+    // setHingeBounds sets the bounds of a hinge or a fold in pixels relative to this view.
+    // Pass null when there is no hinge.
+    //
+    // Call this with the bounds of FoldingFeature reported by Jetpack WindowManager's WindowInfoTracker.
+    public void setHingeBounds(Rect bounds) {
+        if (bounds == null) {
+            Ebitenmobileview.setHingeBounds(0, 0, 0, 0);
+            return;
+        }
+        Ebitenmobileview.setHingeBounds(pxToDp(bounds.left), pxToDp(bounds.top), pxToDp(bounds.width()), pxToDp(bounds.height()));
+    }
+
+    @Override
+    public WindowInsets onApplyWindowInsets(WindowInsets insets) {
+        this.updateSafeAreaInsets(insets);
+        return super.onApplyWindowInsets(insets);
+    }
+
+    private void updateOrientation() {
+        WindowManager windowManager = (WindowManager)getContext().getSystemService(Context.WINDOW_SERVICE);
+        int orientation = ORIENTATION_UNKNOWN;
+        // The rotation is relative to the natural orientation, which is assumed to be portrait.
+        switch (windowManager.getDefaultDisplay().getRotation()) {
+        case Surface.ROTATION_0:
+            orientation = ORIENTATION_PORTRAIT;
+            break;
+        case Surface.ROTATION_90:
+            orientation = ORIENTATION_LANDSCAPE_LEFT;
+            break;
+        case Surface.ROTATION_180:
+            orientation = ORIENTATION_PORTRAIT_UPSIDE_DOWN;
+            break;
+        case Surface.ROTATION_270:
+            orientation = ORIENTATION_LANDSCAPE_RIGHT;
+            break;
+        }
+        Ebitenmobileview.setDeviceOrientation(orientation);
+        Ebitenmobileview.setDisplayRefreshRate(Math.round(windowManager.getDefaultDisplay().getRefreshRate()));
+    }
+
+    private void updateSafeAreaInsets(WindowInsets insets) {
+        if (insets == null) {
+            return;
+        }
+        int left = insets.getSystemWindowInsetLeft();
+        int top = insets.getSystemWindowInsetTop();
+        int right = insets.getSystemWindowInsetRight();
+        int bottom = insets.getSystemWindowInsetBottom();
+        if (Build.VERSION.SDK_INT >= Build.VERSION_CODES.P && insets.getDisplayCutout() != null) {
+            left = Math.max(left, insets.getDisplayCutout().getSafeInsetLeft());
+            top = Math.max(top, insets.getDisplayCutout().getSafeInsetTop());
+            right = Math.max(right, insets.getDisplayCutout().getSafeInsetRight());
+            bottom = Math.max(bottom, insets.getDisplayCutout().getSafeInsetBottom());
+        }
+        Ebitenmobileview.setSafeAreaInsets(pxToDp(left), pxToDp(top), pxToDp(right), pxToDp(bottom));
+    }
+
+    @Override
+    public void lockOrientation(long orientation) {
+        if (!(getContext() instanceof Activity)) {
+            return;
+        }
+        final Activity activity = (Activity)getContext();
+        int requested = ActivityInfo.SCREEN_ORIENTATION_UNSPECIFIED;
+        switch ((int)orientation) {
+        case ORIENTATION_PORTRAIT:
+            requested = ActivityInfo.SCREEN_ORIENTATION_PORTRAIT;
+            break;
+        case ORIENTATION_PORTRAIT_UPSIDE_DOWN:
+            requested = ActivityInfo.SCREEN_ORIENTATION_REVERSE_PORTRAIT;
+            break;
+        case ORIENTATION_LANDSCAPE_LEFT:
+            requested = ActivityInfo.SCREEN_ORIENTATION_LANDSCAPE;
+            break;
+        case ORIENTATION_LANDSCAPE_RIGHT:
+            requested = ActivityInfo.SCREEN_ORIENTATION_REVERSE_LANDSCAPE;
+            break;
+        }
+        final int requestedOrientation = requested;
+        new Handler(Looper.getMainLooper()).post(new Runnable() {
+            @Override
+            public void run() {
+                activity.setRequestedOrientation(requestedOrientation);
+            }
+        });
+    }
+
+    @Override
+    public void setScreenSaverInhibited(final boolean inhibited) {
+        new Handler(Looper.getMainLooper()).post(new Runnable() {
+            @Override
+            public void run() {
+                setKeepScreenOn(inhibited);
+            }
+        });
+    }
+
+    @Override
+    public void announce(final String text, final boolean assertive) {
+        new Handler(Looper.getMainLooper()).post(new Runnable() {
+            @Override
+            public void run() {
+                // Android doesn't have a way to interrupt the current speech, then assertive is ignored.
+                announceForAccessibility(text);
+            }
+        });
+    }
+
     @Override
     public boolean onKeyDown(int keyCode, KeyEvent event) {
         Ebitenmobileview.onKeyDownOnAndroid(keyCode, event.getUnicodeChar(), event.getSource(), event.getDeviceId());
@@ -430,6 +736,27 @@ public class EbitenView extends ViewGroup implements InputManager.InputDeviceLis
         }
     }
 
+    // sendMessageToGame sends a custom message to the game.
+    // The message is delivered to the handler registered by mobile.SetMessageHandler on the Go side.
+    public void sendMessageToGame(String name, byte[] payload) {
+        Ebitenmobileview.sendMessageToGame(name, payload);
+    }
+
+    @Override
+    public void onMessage(final String name, final byte[] payload) {
+        new Handler(Looper.getMainLooper()).post(new Runnable() {
+            @Override
+            public void run() {
+                onMessageFromGame(name, payload);
+            }
+        });
+    }
+
+    // onMessageFromGame is called on the main thread when the game sends a custom message by mobile.SendMessage.
+    // You can handle messages, e.g., for in-app purchases or native dialogs, by overriding this method.
+    protected void onMessageFromGame(String name, byte[] payload) {
+    }
+
     // onErrorOnGameUpdate is called on the main thread when an error happens when updating a game.
     // You can define your own error handler, e.g., using Crashlytics, by overriding this method.
     protected void onErrorOnGameUpdate(Exception e) {
@@ -439,4 +766,12 @@ public class EbitenView extends ViewGroup implements InputManager.InputDeviceLis
     private EbitenSurfaceView ebitenSurfaceView;
     private InputManager inputManager;
     private ArrayList<Gamepad> gamepads;
+    private PowerManager.OnThermalStatusChangedListener thermalStatusListener;
+
+    private final BroadcastReceiver powerStatusReceiver = new BroadcastReceiver() {
+        @Override
+        public void onReceive(Context context, Intent intent) {
+            updatePowerStatus();
+        }
+    };
 }
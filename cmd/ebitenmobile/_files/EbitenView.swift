@@ -0,0 +1,49 @@
+// Copyright 2026 The Ebitengine Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+import SwiftUI
+import {{.PrefixUpper}}
+
+// {{.PrefixUpper}}EbitenView is a SwiftUI view showing the game.
+//
+// Add this file to your Xcode project together with the generated framework, and put the view in your view hierarchy:
+//
+//     struct ContentView: View {
+//         var body: some View {
+//             {{.PrefixUpper}}EbitenView()
+//                 .ignoresSafeArea()
+//         }
+//     }
+//
+// The game is suspended when the scene is not active, and resumed when the scene becomes active again.
+// Thus, you don't have to call suspendGame and resumeGame in your application delegate.
+public struct {{.PrefixUpper}}EbitenView: UIViewControllerRepresentable {
+    public init() {
+    }
+
+    public func makeUIViewController(context: Context) -> {{.PrefixUpper}}EbitenViewController {
+        return {{.PrefixUpper}}EbitenViewController()
+    }
+
+    public func updateUIViewController(_ viewController: {{.PrefixUpper}}EbitenViewController, context: Context) {
+        switch context.environment.scenePhase {
+        case .active:
+            viewController.resumeGame()
+        case .inactive, .background:
+            viewController.suspendGame()
+        @unknown default:
+            break
+        }
+    }
+}
@@ -12,6 +12,7 @@
 // See the License for the specific language governing permissions and
 // limitations under the License.
 
+#import <TargetConditionals.h>
 #import <UIKit/UIKit.h>
 
 @interface {{.PrefixUpper}}EbitenViewController : UIViewController
@@ -20,6 +21,14 @@
 // You can define your own error handler, e.g., using Crashlytics, by overwriting this method.
 - (void)onErrorOnGameUpdate:(NSError*)err;
 
+// sendMessageToGame sends a custom message to the game.
+// The message is delivered to the handler registered by mobile.SetMessageHandler on the Go side.
+- (void)sendMessageToGame:(NSString*)name payload:(NSData*)payload;
+
+// onMessageFromGame is called on the main thread when the game sends a custom message by mobile.SendMessage.
+// You can handle messages, e.g., for in-app purchases or native dialogs, by overwriting this method.
+- (void)onMessageFromGame:(NSString*)name payload:(NSData*)payload;
+
 // suspendGame suspends the game.
 // It is recommended to call this when the application is being suspended e.g.,
 // UIApplicationDelegate's applicationWillResignActive is called.
@@ -30,4 +39,12 @@
 // UIApplicationDelegate's applicationDidBecomeActive is called.
 - (void)resumeGame;
 
+#if TARGET_OS_TV
+// handlesMenuButton indicates whether the game handles the Menu button of the Siri Remote by itself.
+// If NO, the Menu button is forwarded to the system, which returns to the Home screen.
+// The default value is NO.
+// Apple recommends to set NO at the game's top-level screen.
+@property (nonatomic) BOOL handlesMenuButton;
+#endif
+
 @end
@@ -20,7 +20,7 @@
 
 #import "Ebitenmobileview.objc.h"
 
-@interface {{.PrefixUpper}}EbitenViewController : UIViewController<EbitenmobileviewRenderer, EbitenmobileviewSetGameNotifier>
+@interface {{.PrefixUpper}}EbitenViewController : UIViewController<EbitenmobileviewRenderer, EbitenmobileviewSetGameNotifier, EbitenmobileviewOrientationLocker, EbitenmobileviewFrameRateController, EbitenmobileviewMessageReceiver, EbitenmobileviewScreenSaverInhibitor, EbitenmobileviewAnnouncer>
 @end
 
 @implementation {{.PrefixUpper}}EbitenViewController {
@@ -34,6 +34,10 @@
   NSThread*      renderThread_;
   bool           viewDidLoad_;
   bool           gameSet_;
+  long           lockedOrientation_;
+  long           minFrameRate_;
+  long           maxFrameRate_;
+  long           preferredFrameRate_;
 }
 
 - (id)initWithNibName:(NSString *)nibNameOrNil
@@ -42,6 +46,11 @@
                          bundle:nibBundleOrNil];
   if (self) {
     EbitenmobileviewSetSetGameNotifier(self);
+    EbitenmobileviewSetOrientationLocker(self);
+    EbitenmobileviewSetFrameRateController(self);
+    EbitenmobileviewSetMessageReceiver(self);
+    EbitenmobileviewSetScreenSaverInhibitor(self);
+    EbitenmobileviewSetAnnouncer(self);
   }
   return self;
 }
@@ -52,6 +61,11 @@
   self = [super initWithCoder:coder];
   if (self) {
     EbitenmobileviewSetSetGameNotifier(self);
+    EbitenmobileviewSetOrientationLocker(self);
+    EbitenmobileviewSetFrameRateController(self);
+    EbitenmobileviewSetMessageReceiver(self);
+    EbitenmobileviewSetScreenSaverInhibitor(self);
+    EbitenmobileviewSetAnnouncer(self);
   }
   return self;
 }
@@ -59,7 +73,9 @@
 - (UIView*)metalView {
   if (!metalView_) {
     metalView_ = [[UIView alloc] init];
+#if !TARGET_OS_TV
     metalView_.multipleTouchEnabled = YES;
+#endif
   }
   return metalView_;
 }
@@ -67,7 +83,9 @@
 - (GLKView*)glkView {
   if (!glkView_) {
     glkView_ = [[GLKView alloc] init];
+#if !TARGET_OS_TV
     glkView_.multipleTouchEnabled = YES;
+#endif
   }
   return glkView_;
 }
@@ -75,6 +93,35 @@
 - (void)viewDidLoad {
   [super viewDidLoad];
 
+  [[NSNotificationCenter defaultCenter] addObserver:self
+                                           selector:@selector(accessibilitySettingsDidChange:)
+                                               name:UIAccessibilityReduceMotionStatusDidChangeNotification
+                                             object:nil];
+  [[NSNotificationCenter defaultCenter] addObserver:self
+                                           selector:@selector(accessibilitySettingsDidChange:)
+                                               name:UIAccessibilityDarkerSystemColorsStatusDidChangeNotification
+                                             object:nil];
+
+#if !TARGET_OS_TV
+  [[UIDevice currentDevice] setBatteryMonitoringEnabled:YES];
+  [[NSNotificationCenter defaultCenter] addObserver:self
+                                           selector:@selector(powerStatusDidChange:)
+                                               name:UIDeviceBatteryStateDidChangeNotification
+                                             object:nil];
+  [[NSNotificationCenter defaultCenter] addObserver:self
+                                           selector:@selector(powerStatusDidChange:)
+                                               name:UIDeviceBatteryLevelDidChangeNotification
+                                             object:nil];
+#endif
+  [[NSNotificationCenter defaultCenter] addObserver:self
+                                           selector:@selector(powerStatusDidChange:)
+                                               name:NSProcessInfoPowerStateDidChangeNotification
+                                             object:nil];
+  [[NSNotificationCenter defaultCenter] addObserver:self
+                                           selector:@selector(powerStatusDidChange:)
+                                               name:NSProcessInfoThermalStateDidChangeNotification
+                                             object:nil];
+
   viewDidLoad_ = true;
   if (viewDidLoad_ && gameSet_) {
     [self initView];
@@ -147,7 +194,10 @@
     [EAGLContext setCurrentContext:context];
   }
 
-  displayLink_ = [CADisplayLink displayLinkWithTarget:self selector:@selector(drawFrame)];
+  @synchronized(self) {
+    displayLink_ = [CADisplayLink displayLinkWithTarget:self selector:@selector(drawFrame)];
+    [self applyPreferredFrameRateRange];
+  }
   [displayLink_ addToRunLoop:[NSRunLoop currentRunLoop] forMode:NSDefaultRunLoopMode];
   EbitenmobileviewSetRenderer(self);
 
@@ -188,9 +238,201 @@
 
   CGRect viewRect = [[self view] frame];
 
+  [self updateOrientation];
+  UIScreen* screen = self.view.window.windowScene.screen;
+  if (screen) {
+    EbitenmobileviewSetDisplayRefreshRate(screen.maximumFramesPerSecond);
+  }
+  UIEdgeInsets insets = [[self view] safeAreaInsets];
+  EbitenmobileviewSetSafeAreaInsets(insets.left, insets.top, insets.right, insets.bottom);
+  [self updateSystemSettings];
+  [self updatePowerStatus];
   EbitenmobileviewLayout(viewRect.size.width, viewRect.size.height);
 }
 
+// These values must be synced with ui.ColorScheme.
+static const long kColorSchemeUnknown = 0;
+static const long kColorSchemeLight = 1;
+static const long kColorSchemeDark = 2;
+
+- (void)updateSystemSettings {
+  long colorScheme = kColorSchemeUnknown;
+  switch (self.traitCollection.userInterfaceStyle) {
+  case UIUserInterfaceStyleLight:
+    colorScheme = kColorSchemeLight;
+    break;
+  case UIUserInterfaceStyleDark:
+    colorScheme = kColorSchemeDark;
+    break;
+  default:
+    break;
+  }
+  // The text scale follows Dynamic Type.
+  double textScale = [[UIFontMetrics defaultMetrics] scaledValueForValue:1.0];
+  EbitenmobileviewSetSystemSettings(colorScheme, UIAccessibilityIsReduceMotionEnabled(), UIAccessibilityDarkerSystemColorsEnabled(), textScale);
+}
+
+- (void)traitCollectionDidChange:(UITraitCollection*)previousTraitCollection {
+  [super traitCollectionDidChange:previousTraitCollection];
+  [self updateSystemSettings];
+}
+
+- (void)accessibilitySettingsDidChange:(NSNotification*)notification {
+  [self updateSystemSettings];
+}
+
+// These values must be synced with ui.BatteryState.
+static const long kBatteryStateUnknown = 0;
+static const long kBatteryStateNoBattery = 1;
+static const long kBatteryStateCharging = 2;
+static const long kBatteryStateDischarging = 3;
+static const long kBatteryStateFull = 4;
+
+// These values must be synced with ui.ThermalState.
+static const long kThermalStateUnknown = 0;
+static const long kThermalStateNominal = 1;
+static const long kThermalStateFair = 2;
+static const long kThermalStateSerious = 3;
+static const long kThermalStateCritical = 4;
+
+- (void)updatePowerStatus {
+  long batteryState = kBatteryStateUnknown;
+  double batteryLevel = -1;
+#if TARGET_OS_TV
+  // Apple TV doesn't have a battery.
+  batteryState = kBatteryStateNoBattery;
+#else
+  UIDevice* device = [UIDevice currentDevice];
+  switch (device.batteryState) {
+  case UIDeviceBatteryStateUnplugged:
+    batteryState = kBatteryStateDischarging;
+    break;
+  case UIDeviceBatteryStateCharging:
+    batteryState = kBatteryStateCharging;
+    break;
+  case UIDeviceBatteryStateFull:
+    batteryState = kBatteryStateFull;
+    break;
+  default:
+    break;
+  }
+  // batteryLevel is -1 when the battery state is unknown.
+  batteryLevel = device.batteryLevel;
+#endif
+
+  NSProcessInfo* info = [NSProcessInfo processInfo];
+  long thermalState = kThermalStateUnknown;
+  switch (info.thermalState) {
+  case NSProcessInfoThermalStateNominal:
+    thermalState = kThermalStateNominal;
+    break;
+  case NSProcessInfoThermalStateFair:
+    thermalState = kThermalStateFair;
+    break;
+  case NSProcessInfoThermalStateSerious:
+    thermalState = kThermalStateSerious;
+    break;
+  case NSProcessInfoThermalStateCritical:
+    thermalState = kThermalStateCritical;
+    break;
+  }
+  EbitenmobileviewSetPowerStatus(batteryState, batteryLevel, info.lowPowerModeEnabled, thermalState);
+}
+
+// powerStatusDidChange might be called on a background thread.
+- (void)powerStatusDidChange:(NSNotification*)notification {
+  dispatch_async(dispatch_get_main_queue(), ^{
+    [self updatePowerStatus];
+  });
+}
+
+// These values must be synced with ui.Orientation.
+static const long kOrientationUnknown = 0;
+static const long kOrientationPortrait = 1;
+static const long kOrientationPortraitUpsideDown = 2;
+static const long kOrientationLandscapeLeft = 3;
+static const long kOrientationLandscapeRight = 4;
+
+- (void)updateOrientation {
+#if TARGET_OS_TV
+  // tvOS doesn't have a concept of device orientations.
+  return;
+#else
+  UIWindowScene* scene = self.view.window.windowScene;
+  if (!scene) {
+    return;
+  }
+  long orientation = kOrientationUnknown;
+  // UIInterfaceOrientationLandscapeLeft means that the home button is on the left i.e. the device's top edge is on the right.
+  switch (scene.interfaceOrientation) {
+  case UIInterfaceOrientationPortrait:
+    orientation = kOrientationPortrait;
+    break;
+  case UIInterfaceOrientationPortraitUpsideDown:
+    orientation = kOrientationPortraitUpsideDown;
+    break;
+  case UIInterfaceOrientationLandscapeLeft:
+    orientation = kOrientationLandscapeRight;
+    break;
+  case UIInterfaceOrientationLandscapeRight:
+    orientation = kOrientationLandscapeLeft;
+    break;
+  default:
+    break;
+  }
+  EbitenmobileviewSetDeviceOrientation(orientation);
+#endif
+}
+
+#if !TARGET_OS_TV
+- (UIInterfaceOrientationMask)supportedInterfaceOrientations {
+  @synchronized(self) {
+    switch (lockedOrientation_) {
+    case kOrientationPortrait:
+      return UIInterfaceOrientationMaskPortrait;
+    case kOrientationPortraitUpsideDown:
+      return UIInterfaceOrientationMaskPortraitUpsideDown;
+    case kOrientationLandscapeLeft:
+      return UIInterfaceOrientationMaskLandscapeRight;
+    case kOrientationLandscapeRight:
+      return UIInterfaceOrientationMaskLandscapeLeft;
+    default:
+      return [super supportedInterfaceOrientations];
+    }
+  }
+}
+#endif
+
+- (void)lockOrientation:(long)orientation {
+  @synchronized(self) {
+    lockedOrientation_ = orientation;
+  }
+#if !TARGET_OS_TV
+  dispatch_async(dispatch_get_main_queue(), ^{
+      if (@available(iOS 16.0, *)) {
+        [self setNeedsUpdateOfSupportedInterfaceOrientations];
+      } else {
+        [UIViewController attemptRotationToDeviceOrientation];
+      }
+    });
+#endif
+}
+
+- (void)setScreenSaverInhibited:(BOOL)inhibited {
+  dispatch_async(dispatch_get_main_queue(), ^{
+      [UIApplication sharedApplication].idleTimerDisabled = inhibited;
+    });
+}
+
+- (void)announce:(NSString*)text assertive:(BOOL)assertive {
+  dispatch_async(dispatch_get_main_queue(), ^{
+      // A non-queued announcement interrupts the current speech.
+      NSAttributedString* announcement = [[NSAttributedString alloc] initWithString:text
+                                                                         attributes:@{UIAccessibilitySpeechAttributeQueueAnnouncement: @(!assertive)}];
+      UIAccessibilityPostNotification(UIAccessibilityAnnouncementNotification, announcement);
+    });
+}
+
 - (void)didReceiveMemoryWarning {
   [super didReceiveMemoryWarning];
   // Dispose of any resources that can be recreated.
@@ -259,6 +501,19 @@
   NSLog(@"Error: %@", err);
 }
 
+- (void)sendMessageToGame:(NSString*)name payload:(NSData*)payload {
+  EbitenmobileviewSendMessageToGame(name, payload);
+}
+
+- (void)onMessage:(NSString*)name payload:(NSData*)payload {
+  dispatch_async(dispatch_get_main_queue(), ^{
+      [self onMessageFromGame:name payload:payload];
+    });
+}
+
+- (void)onMessageFromGame:(NSString*)name payload:(NSData*)payload {
+}
+
 - (void)updateTouches:(NSSet*)touches {
   if (!started_) {
     return;
@@ -326,12 +581,38 @@
 
 - (void)pressesBegan:(NSSet<UIPress *> *)presses withEvent:(UIPressesEvent *)event {
   [self updatePresses:presses];
+#if TARGET_OS_TV
+  [self forwardMenuPresses:presses withEvent:event began:YES];
+#endif
 }
 
 - (void)pressesEnded:(NSSet<UIPress *> *)presses withEvent:(UIPressesEvent *)event {
   [self updatePresses:presses];
+#if TARGET_OS_TV
+  [self forwardMenuPresses:presses withEvent:event began:NO];
+#endif
 }
 
+#if TARGET_OS_TV
+- (void)forwardMenuPresses:(NSSet<UIPress *> *)presses withEvent:(UIPressesEvent *)event began:(BOOL)began {
+  // Forward the Menu button to the system unless the game handles it,
+  // so that the user can go back to the Home screen (the top shelf) from the game.
+  if (self.handlesMenuButton) {
+    return;
+  }
+  for (UIPress* press in presses) {
+    if (press.type != UIPressTypeMenu) {
+      continue;
+    }
+    if (began) {
+      [super pressesBegan:[NSSet setWithObject:press] withEvent:event];
+    } else {
+      [super pressesEnded:[NSSet setWithObject:press] withEvent:event];
+    }
+  }
+}
+#endif
+
 - (void)suspendGame {
   if (!started_) {
     return;
@@ -383,6 +664,30 @@
   }
 }
 
+- (void)setPreferredFrameRateRange:(long)min max:(long)max preferred:(long)preferred {
+  @synchronized(self) {
+    minFrameRate_ = min;
+    maxFrameRate_ = max;
+    preferredFrameRate_ = preferred;
+    [self applyPreferredFrameRateRange];
+  }
+}
+
+- (void)applyPreferredFrameRateRange {
+  // applyPreferredFrameRateRange must be called in a @synchronized block.
+  if (!displayLink_) {
+    return;
+  }
+  if (maxFrameRate_ == 0) {
+    return;
+  }
+  if (@available(iOS 15.0, *)) {
+    displayLink_.preferredFrameRateRange = CAFrameRateRangeMake(minFrameRate_, maxFrameRate_, preferredFrameRate_);
+  } else {
+    displayLink_.preferredFramesPerSecond = preferredFrameRate_ ? preferredFrameRate_ : maxFrameRate_;
+  }
+}
+
 - (void)notifySetGame {
   dispatch_async(dispatch_get_main_queue(), ^{
       gameSet_ = true;
@@ -43,6 +43,12 @@ const (
 //go:embed _files/EbitenViewController.h
 var objcH string
 
+//go:embed _files/EbitenView.swift
+var viewSwift string
+
+//go:embed _files/EbitenGameView.kt
+var gameViewKotlin string
+
 func goEnv(name string) string {
 	if val := os.Getenv(name); val != "" {
 		return val
@@ -75,12 +81,13 @@ var (
 	bindJavaPkg       string // -javapkg
 	bindClasspath     string // -classpath
 	bindBootClasspath string // -bootclasspath
+	bindWrapper       bool   // -wrapper
 )
 
 func main() {
 	flag.Usage = func() {
 		// This message is copied from `gomobile bind -h`
-		fmt.Fprintf(os.Stderr, "%s bind [-target android|ios] [-bootclasspath <path>] [-classpath <path>] [-o output] [build flags] [package]\n", ebitenmobileCommand)
+		fmt.Fprintf(os.Stderr, "%s bind [-target android|ios|tvos] [-bootclasspath <path>] [-classpath <path>] [-o output] [-wrapper] [build flags] [package]\n", ebitenmobileCommand)
 		os.Exit(2)
 	}
 	flag.Parse()
@@ -119,14 +126,28 @@ func main() {
 	flagset.StringVar(&bindPrefix, "prefix", "", "")
 	flagset.StringVar(&bindClasspath, "classpath", "", "")
 	flagset.StringVar(&bindBootClasspath, "bootclasspath", "", "")
+	flagset.BoolVar(&bindWrapper, "wrapper", false, "")
 
 	_ = flagset.Parse(args[1:])
 
+	// -wrapper is ebitenmobile's own flag. Do not pass it to gomobile.
+	args = removeFlag(args, "wrapper")
+
+	tvOS := isTVOSBuildTarget(buildTarget)
 	buildTarget, err := osFromBuildTarget(buildTarget)
 	if err != nil {
 		log.Fatal(err)
 	}
 
+	// The tvos tag lets Go files distinguish tvOS from iOS, as GOOS is ios for both.
+	if tvOS {
+		if buildTags != "" {
+			buildTags += " "
+		}
+		buildTags += "tvos"
+		args = appendTags(args, "tvos")
+	}
+
 	// Add ldflags to suppress linker errors (#932).
 	// See https://github.com/golang/go/issues/17807
 	if buildTarget == "android" {
@@ -180,12 +201,56 @@ func osFromBuildTarget(buildTarget string) (string, error) {
 			return "", fmt.Errorf("ebitenmobile: cannot target different OSes")
 		}
 	}
-	if os == "ios" {
+	switch os {
+	case "ios", "tvos", "tvossimulator":
 		os = "darwin"
 	}
 	return os, nil
 }
 
+// isTVOSBuildTarget reports whether the given build target is for tvOS.
+func isTVOSBuildTarget(buildTarget string) bool {
+	for _, pair := range strings.Split(buildTarget, ",") {
+		switch strings.SplitN(pair, "/", 2)[0] {
+		case "tvos", "tvossimulator":
+			return true
+		}
+	}
+	return false
+}
+
+// removeFlag removes the given boolean flag from args.
+func removeFlag(args []string, name string) []string {
+	var newArgs []string
+	for _, arg := range args {
+		if !strings.HasPrefix(arg, "-") {
+			newArgs = append(newArgs, arg)
+			continue
+		}
+		a := strings.TrimPrefix(strings.TrimPrefix(arg, "-"), "-")
+		if a == name || strings.HasPrefix(a, name+"=") {
+			continue
+		}
+		newArgs = append(newArgs, arg)
+	}
+	return newArgs
+}
+
+// appendTags adds the given tag to the -tags flag in args, or adds a new -tags flag after the subcommand.
+func appendTags(args []string, tag string) []string {
+	for i, arg := range args {
+		if arg == "-tags" && i+1 < len(args) {
+			args[i+1] += " " + tag
+			return args
+		}
+		if v, ok := strings.CutPrefix(arg, "-tags="); ok {
+			args[i] = "-tags=" + v + " " + tag
+			return args
+		}
+	}
+	return append([]string{args[0], "-tags", tag}, args[1:]...)
+}
+
 func doBind(args []string, flagset *flag.FlagSet, buildOS string) error {
 	tags := buildTags
 	cfg := &packages.Config{}
@@ -237,9 +302,26 @@ func doBind(args []string, flagset *flag.FlagSet, buildOS string) error {
 	replacePrefixes := func(content string) string {
 		content = strings.ReplaceAll(content, "{{.PrefixUpper}}", prefixUpper)
 		content = strings.ReplaceAll(content, "{{.PrefixLower}}", prefixLower)
+		content = strings.ReplaceAll(content, "{{.JavaPkg}}", bindJavaPkg)
 		return content
 	}
 
+	// Generate a SwiftUI view or a Jetpack Compose function next to the output.
+	// These files are not compiled into the output, as the output includes only Objective-C or Java code.
+	if bindWrapper {
+		dir := filepath.Dir(buildO)
+		switch buildOS {
+		case "darwin":
+			if err := os.WriteFile(filepath.Join(dir, prefixUpper+"EbitenView.swift"), []byte(replacePrefixes(viewSwift)), 0644); err != nil {
+				return err
+			}
+		case "android":
+			if err := os.WriteFile(filepath.Join(dir, "EbitenGameView.kt"), []byte(replacePrefixes(gameViewKotlin)), 0644); err != nil {
+				return err
+			}
+		}
+	}
+
 	if buildOS == "darwin" {
 		// TODO: Use os.ReadDir after Ebitengine stops supporting Go 1.15.
 		f, err := os.Open(buildO)
@@ -15,6 +15,7 @@
 package main
 
 import (
+	"slices"
 	"testing"
 )
 
@@ -74,3 +75,88 @@ func TestJavaPackageName(t *testing.T) {
 		}
 	}
 }
+
+func TestOSFromBuildTarget(t *testing.T) {
+	testCases := []struct {
+		in   string
+		os   string
+		tvOS bool
+		err  bool
+	}{
+		{
+			in: "android",
+			os: "android",
+		},
+		{
+			in: "android/arm64,android/amd64",
+			os: "android",
+		},
+		{
+			in: "ios",
+			os: "darwin",
+		},
+		{
+			in:   "tvos",
+			os:   "darwin",
+			tvOS: true,
+		},
+		{
+			in:   "tvos/arm64",
+			os:   "darwin",
+			tvOS: true,
+		},
+		{
+			in:  "android,ios",
+			err: true,
+		},
+	}
+	for _, tc := range testCases {
+		got, err := osFromBuildTarget(tc.in)
+		if tc.err {
+			if err == nil {
+				t.Errorf("osFromBuildTarget(%q) must return an error", tc.in)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("osFromBuildTarget(%q) failed: %v", tc.in, err)
+			continue
+		}
+		if want := tc.os; got != want {
+			t.Errorf("osFromBuildTarget(%q) = %q; want %q", tc.in, got, want)
+		}
+		if got, want := isTVOSBuildTarget(tc.in), tc.tvOS; got != want {
+			t.Errorf("isTVOSBuildTarget(%q) = %v; want %v", tc.in, got, want)
+		}
+	}
+}
+
+func TestRemoveFlag(t *testing.T) {
+	testCases := []struct {
+		in  []string
+		out []string
+	}{
+		{
+			in:  []string{"bind", "-target", "ios", "-wrapper", "-o", "Foo.xcframework", "./mobile"},
+			out: []string{"bind", "-target", "ios", "-o", "Foo.xcframework", "./mobile"},
+		},
+		{
+			in:  []string{"bind", "--wrapper=true", "./mobile"},
+			out: []string{"bind", "./mobile"},
+		},
+		{
+			in:  []string{"bind", "-wrapperx", "./wrapper"},
+			out: []string{"bind", "-wrapperx", "./wrapper"},
+		},
+		{
+			in:  []string{"bind", "wrapper"},
+			out: []string{"bind", "wrapper"},
+		},
+	}
+	for _, tc := range testCases {
+		got := removeFlag(tc.in, "wrapper")
+		if !slices.Equal(got, tc.out) {
+			t.Errorf("removeFlag(%q, %q) = %q; want %q", tc.in, "wrapper", got, tc.out)
+		}
+	}
+}
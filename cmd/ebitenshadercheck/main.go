@@ -0,0 +1,37 @@
+// Copyright 2026 The Ebitengine Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// ebitenshadercheck checks Kage shaders referred by Go packages.
+//
+// ebitenshadercheck reports compile errors of Kage shaders and mismatches of uniform variables with the positions.
+// For the details, see the package github.com/duplicants-ai/ebiten/exp/shadercheck.
+//
+// ebitenshadercheck can be run standalone:
+//
+//	ebitenshadercheck ./...
+//
+// or as a tool of go vet:
+//
+//	go vet -vettool=$(which ebitenshadercheck) ./...
+package main
+
+import (
+	"golang.org/x/tools/go/analysis/multichecker"
+
+	"github.com/duplicants-ai/ebiten/exp/shadercheck"
+)
+
+func main() {
+	multichecker.Main(shadercheck.Analyzer)
+}
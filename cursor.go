@@ -28,6 +28,42 @@ const (
 	CursorModeCaptured CursorModeType = CursorModeType(ui.CursorModeCaptured)
 )
 
+// CursorCaptureInterruption represents a reason why capturing the cursor was interrupted.
+type CursorCaptureInterruption int
+
+const (
+	// CursorCaptureInterruptionExited indicates that the user or the browser exited the captured mode,
+	// e.g. by pressing Esc on browsers.
+	// The cursor mode has been reverted to the previous mode.
+	CursorCaptureInterruptionExited CursorCaptureInterruption = CursorCaptureInterruption(ui.CursorCaptureInterruptionExited)
+
+	// CursorCaptureInterruptionDenied indicates that the browser denied the request to capture the cursor,
+	// e.g. because the request was not triggered by a user gesture.
+	// The cursor mode has been reverted to the previous mode.
+	CursorCaptureInterruptionDenied CursorCaptureInterruption = CursorCaptureInterruption(ui.CursorCaptureInterruptionDenied)
+
+	// CursorCaptureInterruptionFocusLost indicates that the window or the page lost focus while the cursor was captured.
+	//
+	// On desktops, the cursor mode is kept CursorModeCaptured, and the cursor is captured again when the window is focused.
+	// On browsers, the cursor mode has been reverted to the previous mode.
+	CursorCaptureInterruptionFocusLost CursorCaptureInterruption = CursorCaptureInterruption(ui.CursorCaptureInterruptionFocusLost)
+)
+
+// AppendCursorCaptureInterruptions appends the interruptions of the cursor capture that happened since the previous tick
+// to interruptions, and returns the extended buffer.
+// Giving a slice that already has enough capacity works efficiently.
+//
+// FPS-style games can use this to pause the game and ask the user to click the screen,
+// then call SetCursorMode(CursorModeCaptured) again on the click.
+// On browsers, capturing the cursor again requires a user gesture.
+//
+// AppendCursorCaptureInterruptions works only on desktops and browsers.
+//
+// AppendCursorCaptureInterruptions is concurrent-safe.
+func AppendCursorCaptureInterruptions(interruptions []CursorCaptureInterruption) []CursorCaptureInterruption {
+	return theInputState.appendCursorCaptureInterruptions(interruptions)
+}
+
 // CursorShapeType represents a shape of a mouse cursor.
 type CursorShapeType int
 
@@ -0,0 +1,55 @@
+// Copyright 2026 The Ebitengine Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ebiten_test
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/duplicants-ai/ebiten"
+	"github.com/duplicants-ai/ebiten/internal/ui"
+)
+
+func TestAppendCursorCaptureInterruptions(t *testing.T) {
+	t.Cleanup(func() {
+		ebiten.UpdateInputStateForTesting(func(state *ui.InputState) {
+			*state = ui.InputState{}
+		})
+	})
+
+	ebiten.UpdateInputStateForTesting(func(state *ui.InputState) {
+		state.CursorCaptureInterruptions = []ui.CursorCaptureInterruption{
+			ui.CursorCaptureInterruptionDenied,
+			ui.CursorCaptureInterruptionFocusLost,
+		}
+	})
+
+	got := ebiten.AppendCursorCaptureInterruptions([]ebiten.CursorCaptureInterruption{ebiten.CursorCaptureInterruptionExited})
+	want := []ebiten.CursorCaptureInterruption{
+		ebiten.CursorCaptureInterruptionExited,
+		ebiten.CursorCaptureInterruptionDenied,
+		ebiten.CursorCaptureInterruptionFocusLost,
+	}
+	if !slices.Equal(got, want) {
+		t.Errorf("got: %v, want: %v", got, want)
+	}
+
+	ebiten.UpdateInputStateForTesting(func(state *ui.InputState) {
+		state.CursorCaptureInterruptions = state.CursorCaptureInterruptions[:0]
+	})
+	if got := ebiten.AppendCursorCaptureInterruptions(nil); len(got) != 0 {
+		t.Errorf("got: %v, want: empty", got)
+	}
+}
@@ -54,12 +54,19 @@
 // DeviceScaleFactor) that must be called on the main thread under some conditions (typically, before ebiten.RunGame
 // is called).
 //
+// On WASI (GOOS=wasip1), a game runs without any graphics library, e.g. for server-side simulation.
+// Update and Draw are called as usual, and images are rendered by the CPU.
+// Rendering by the CPU is much slower than by the GPU.
+// There is no input device, and the screen size is specified by the environment variable EBITENGINE_SCREEN_SIZE.
+// With the build tag `ebitenginehostsurface`, the screen is shown on a surface provided by the host.
+//
 // # Environment variables
 //
 // `EBITENGINE_SCREENSHOT_KEY` environment variable specifies the key
 // to take a screenshot. For example, if you run your game with
 // `EBITENGINE_SCREENSHOT_KEY=q`, you can take a game screen's screenshot
 // by pressing Q key. This works only on desktops and browsers.
+// The screenshot is tagged with the color space of the screen. See also SetScreenshotConvertedToSRGB.
 //
 // `EBITENGINE_INTERNAL_IMAGES_KEY` environment variable specifies the key
 // to dump all the internal images. This is valid only when the build tag
@@ -83,6 +90,7 @@
 //	"warp":                       Use WARP (i.e. software rendering).
 //	"version=VERSION":            Specify a DirectX version (e.g. 11).
 //	"featurelevel=FEATURE_LEVEL": Specify a feature level (e.g. 11_0). This is for DirectX 12.
+//	"swapeffect=SWAP_EFFECT":     Specify a swap effect of the swap chain (e.g. flipdiscard).
 //
 // The options taking arguments are exclusive, and if multiples are specified, the lastly specified value is adopted.
 //
@@ -93,6 +101,27 @@
 // The option "featurelevel" is valid only for DirectX 12.
 // The possible values are "11_0", "11_1", "12_0", "12_1", and "12_2". The default value is "11_0".
 //
+// The possible values for the option "swapeffect" are "flipsequential", "flipdiscard", and "bitblt".
+// If the swap effect is not specified, "flipsequential" is adopted on Windows 10 or later, and "bitblt" is adopted otherwise.
+// Screen capturing and streaming software can capture the flip model swap effects more reliably in most cases,
+// but some software might require a specific swap effect.
+// "bitblt" is ignored with DirectX 12. On Xbox, the "swapeffect" option is ignored.
+//
+// `EBITENGINE_SHADER` environment variable specifies various parameters for shaders.
+// You can specify multiple values separated by a comma. The default value is empty (i.e. no parameters).
+//
+//	"dumpir":     Dump the intermediate representation of shaders before and after the optimization to the standard error.
+//	"nooptimize": Disable the optimization of shaders, e.g., constant folding and loop unrolling.
+//
+// `EBITENGINE_VALIDATE_PREMULTIPLIED_ALPHA` environment variable enables the validation of pixels given to
+// WritePixels and WritePixelsFromImage. If the value is not empty nor "0", these functions panic when a pixel's color value
+// exceeds its alpha value, which means the pixels are likely straight-alpha (non-premultiplied) pixels.
+// This validation is useful for debugging, but is slow. Use PremultiplyAlpha to convert straight-alpha pixels.
+//
+// `EBITENGINE_SCREEN_SIZE` environment variable specifies the screen size in the form of WIDTHxHEIGHT (e.g. 1280x720).
+// This works only on WASI (GOOS=wasip1). The size of the host surface takes precedence if available.
+// The default value is 640x480.
+//
 // # Build tags
 //
 // `ebitenginedebug` outputs a log of graphics commands. This is useful to know what happens in Ebitengine. In general, the
@@ -107,7 +136,18 @@
 // `ebitenginesinglethread` works only with desktops and consoles.
 // `ebitenginesinglethread` was deprecated as of v2.7. Use RunGameOptions.SingleThread instead.
 //
+// `ebitenginehostsurface` enables the host surface protocol on WASI (GOOS=wasip1).
+// The host must implement these functions in the module "ebitengine":
+//
+//	surface_size(width, height *int32): writes the surface size in pixels.
+//	surface_present(pixels *byte, width, height int32): shows the screen's pixels in premultiplied-alpha RGBA.
+//
+// surface_size is called every frame, and the screen size follows the surface size.
+//
 // `microsoftgdk` is for Microsoft GDK (e.g. Xbox).
+// On Xbox, DirectX 12 is used for rendering and GameInput is used for gamepads including vibration.
+// There is no Xbox-specific graphics driver: the DirectX 12 driver loads the Xbox's Direct3D 12 (d3d12_x.dll or d3d12_xs.dll).
+// When the title is suspended by the system, the game loop and the audio are paused until the title is resumed.
 //
 // `nintendosdk` is for NintendoSDK (e.g. Nintendo Switch).
 //
@@ -30,3 +30,55 @@ func TestScreenSizeInFullscreen(t *testing.T) {
 		t.Errorf("h must be positive but not: %d", h)
 	}
 }
+
+func TestVsyncMode(t *testing.T) {
+	t.Cleanup(func() {
+		ebiten.SetVsyncEnabled(true)
+	})
+
+	ebiten.SetVsyncEnabled(false)
+	if ebiten.IsVsyncEnabled() {
+		t.Skip("vsync cannot be disabled in this environment")
+	}
+
+	testCases := []struct {
+		Mode        ebiten.VsyncModeType
+		WantEnabled bool
+	}{
+		{
+			Mode:        ebiten.VsyncModeMailbox,
+			WantEnabled: true,
+		},
+		{
+			Mode:        ebiten.VsyncModeOff,
+			WantEnabled: false,
+		},
+		{
+			Mode:        ebiten.VsyncModeAdaptive,
+			WantEnabled: true,
+		},
+		{
+			Mode:        ebiten.VsyncModeOn,
+			WantEnabled: true,
+		},
+	}
+	for _, tc := range testCases {
+		ebiten.SetVsyncMode(tc.Mode)
+		if got := ebiten.VsyncMode(); got != tc.Mode {
+			t.Errorf("VsyncMode: got: %d, want: %d", got, tc.Mode)
+		}
+		if got := ebiten.IsVsyncEnabled(); got != tc.WantEnabled {
+			t.Errorf("mode: %d, IsVsyncEnabled: got: %t, want: %t", tc.Mode, got, tc.WantEnabled)
+		}
+	}
+
+	ebiten.SetVsyncMode(ebiten.VsyncModeMailbox)
+	ebiten.SetVsyncEnabled(false)
+	if got, want := ebiten.VsyncMode(), ebiten.VsyncModeOff; got != want {
+		t.Errorf("VsyncMode after SetVsyncEnabled(false): got: %d, want: %d", got, want)
+	}
+	ebiten.SetVsyncEnabled(true)
+	if got, want := ebiten.VsyncMode(), ebiten.VsyncModeOn; got != want {
+		t.Errorf("VsyncMode after SetVsyncEnabled(true): got: %d, want: %d", got, want)
+	}
+}
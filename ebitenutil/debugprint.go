@@ -19,10 +19,15 @@ package ebitenutil
 import (
 	"bytes"
 	_ "embed"
+	"fmt"
 	"image"
+	"image/color"
 	_ "image/png"
+	"strings"
 
 	"github.com/duplicants-ai/ebiten"
+	"github.com/duplicants-ai/ebiten/text/v2"
+	"github.com/duplicants-ai/ebiten/vector"
 )
 
 //go:embed text.png
@@ -41,6 +46,11 @@ func init() {
 	debugPrintTextImage = ebiten.NewImageFromImage(img)
 }
 
+const (
+	debugPrintCharWidth  = 6
+	debugPrintCharHeight = 16
+)
+
 // DebugPrint draws the string str on the image at (0, 0) position (the upper-left corner in most cases).
 //
 // The available runes are in U+0000 to U+00FF, which is C0 Controls and Basic Latin and C1 Controls and Latin-1 Supplement.
@@ -52,36 +62,140 @@ func DebugPrint(image *ebiten.Image, str string) {
 //
 // The available runes are in U+0000 to U+00FF, which is C0 Controls and Basic Latin and C1 Controls and Latin-1 Supplement.
 func DebugPrintAt(image *ebiten.Image, str string, x, y int) {
-	drawDebugText(image, str, x, y)
+	drawDebugText(image, str, x, y, nil)
+}
+
+// DebugPrintf formats according to a format specifier and draws the result on the image at (0, 0) position.
+//
+// The available runes are in U+0000 to U+00FF, which is C0 Controls and Basic Latin and C1 Controls and Latin-1 Supplement.
+func DebugPrintf(image *ebiten.Image, format string, args ...any) {
+	DebugPrintAt(image, fmt.Sprintf(format, args...), 0, 0)
 }
 
-func drawDebugText(rt *ebiten.Image, str string, ox, oy int) {
+// DebugPrintOptions represents options for DebugPrintWithOptions.
+//
+// The zero value of DebugPrintOptions draws a text in the same way as DebugPrintAt.
+type DebugPrintOptions struct {
+	// Color is the color of the text.
+	//
+	// The default (nil) value is white.
+	Color color.Color
+
+	// BackgroundColor is the color of the rectangle drawn behind the text.
+	//
+	// The default (nil) value means that no background is drawn.
+	BackgroundColor color.Color
+
+	// Scale is the scale of the text.
+	//
+	// The default (zero) value means 1.
+	Scale float64
+
+	// Align is the horizontal alignment of each line relative to the given position.
+	//
+	// The default (zero) value is text.AlignStart, which means that lines are left-aligned.
+	Align text.Align
+
+	// Face is the font face for the text.
+	// A monospace face is recommended for debugging.
+	//
+	// The default (nil) value means the built-in bitmap font, which is used by DebugPrint.
+	// With the built-in font, the available runes are in U+0000 to U+00FF.
+	Face text.Face
+}
+
+// DebugPrintWithOptions draws the string str on the image at (x, y) position with the given options.
+//
+// options can be nil. In this case, DebugPrintWithOptions works in the same way as DebugPrintAt.
+func DebugPrintWithOptions(image *ebiten.Image, str string, x, y int, options *DebugPrintOptions) {
+	drawDebugText(image, str, x, y, options)
+}
+
+func drawDebugText(rt *ebiten.Image, str string, ox, oy int, options *DebugPrintOptions) {
+	var opts DebugPrintOptions
+	if options != nil {
+		opts = *options
+	}
+	scale := opts.Scale
+	if scale == 0 {
+		scale = 1
+	}
+
+	if opts.Face != nil {
+		drawDebugTextWithFace(rt, str, float64(ox), float64(oy), scale, &opts)
+		return
+	}
+
+	lines := strings.Split(str, "\n")
+	lineWidth := func(line string) float64 {
+		return float64(len([]rune(line))*debugPrintCharWidth) * scale
+	}
+
+	if opts.BackgroundColor != nil {
+		var maxWidth float64
+		for _, line := range lines {
+			maxWidth = max(maxWidth, lineWidth(line))
+		}
+		bx := float64(ox) + alignOffset(maxWidth, opts.Align)
+		// Add a 1px margin on the left and the right as the glyphs are shifted by 1px.
+		vector.DrawFilledRect(rt, float32(bx), float32(oy), float32(maxWidth+2*scale), float32(float64(len(lines)*debugPrintCharHeight)*scale), opts.BackgroundColor, false)
+	}
+
 	op := &ebiten.DrawImageOptions{}
-	x := 0
-	y := 0
+	if opts.Color != nil {
+		op.ColorScale.ScaleWithColor(opts.Color)
+	}
 	w := debugPrintTextImage.Bounds().Dx()
-	for _, c := range str {
-		const (
-			cw = 6
-			ch = 16
-		)
-		if c == '\n' {
-			x = 0
-			y += ch
-			continue
-		}
-		s, ok := debugPrintTextSubImages[c]
-		if !ok {
-			n := w / cw
-			sx := (int(c) % n) * cw
-			sy := (int(c) / n) * ch
-			s = debugPrintTextImage.SubImage(image.Rect(sx, sy, sx+cw, sy+ch)).(*ebiten.Image)
-			debugPrintTextSubImages[c] = s
+	for i, line := range lines {
+		x := float64(ox) + alignOffset(lineWidth(line), opts.Align)
+		y := float64(oy) + float64(i*debugPrintCharHeight)*scale
+		for j, c := range []rune(line) {
+			s, ok := debugPrintTextSubImages[c]
+			if !ok {
+				n := w / debugPrintCharWidth
+				sx := (int(c) % n) * debugPrintCharWidth
+				sy := (int(c) / n) * debugPrintCharHeight
+				s = debugPrintTextImage.SubImage(image.Rect(sx, sy, sx+debugPrintCharWidth, sy+debugPrintCharHeight)).(*ebiten.Image)
+				debugPrintTextSubImages[c] = s
+			}
+			op.GeoM.Reset()
+			op.GeoM.Translate(float64(j*debugPrintCharWidth+1), 0)
+			op.GeoM.Scale(scale, scale)
+			op.GeoM.Translate(x, y)
+			rt.DrawImage(s, op)
 		}
-		op.GeoM.Reset()
-		op.GeoM.Translate(float64(x), float64(y))
-		op.GeoM.Translate(float64(ox+1), float64(oy))
-		rt.DrawImage(s, op)
-		x += cw
+	}
+}
+
+func drawDebugTextWithFace(rt *ebiten.Image, str string, x, y float64, scale float64, opts *DebugPrintOptions) {
+	m := opts.Face.Metrics()
+	lineSpacing := m.HAscent + m.HDescent + m.HLineGap
+
+	if opts.BackgroundColor != nil {
+		w, h := text.Measure(str, opts.Face, lineSpacing)
+		bx := x + alignOffset(w*scale, opts.Align)
+		vector.DrawFilledRect(rt, float32(bx), float32(y), float32(w*scale), float32(h*scale), opts.BackgroundColor, false)
+	}
+
+	op := &text.DrawOptions{}
+	op.GeoM.Scale(scale, scale)
+	op.GeoM.Translate(x, y)
+	if opts.Color != nil {
+		op.ColorScale.ScaleWithColor(opts.Color)
+	}
+	op.LineSpacing = lineSpacing
+	op.PrimaryAlign = opts.Align
+	text.Draw(rt, str, opts.Face, op)
+}
+
+// alignOffset returns the horizontal offset to align a line with the given width.
+func alignOffset(width float64, align text.Align) float64 {
+	switch align {
+	case text.AlignCenter:
+		return -width / 2
+	case text.AlignEnd:
+		return -width
+	default:
+		return 0
 	}
 }
@@ -0,0 +1,104 @@
+// Copyright 2026 The Ebitengine Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ebiten
+
+import (
+	"errors"
+	"sync/atomic"
+
+	"github.com/duplicants-ai/ebiten/internal/debug"
+	"github.com/duplicants-ai/ebiten/internal/ui"
+)
+
+// EngineError is an error with Ebitengine's state at the time when the error happened.
+//
+// An error passed to the handler specified by SetErrorHandler is always an *EngineError.
+type EngineError struct {
+	// Err is the original error.
+	Err error
+
+	// Panic is the value given to panic if the error is caused by a panic.
+	// Otherwise, Panic is nil.
+	Panic any
+
+	// Stack is the stack trace of the goroutine where the panic happened.
+	// Stack is nil if the error is not caused by a panic.
+	Stack []byte
+
+	// GraphicsLibrary is the graphics library in use.
+	GraphicsLibrary GraphicsLibrary
+
+	// RecentGraphicsCommands is the recently executed graphics commands, which are logged by the debug logger.
+	//
+	// RecentGraphicsCommands is available only with the build tag ebitenginedebug.
+	// Otherwise, RecentGraphicsCommands is nil.
+	RecentGraphicsCommands []string
+}
+
+// Error implements the error interface.
+func (e *EngineError) Error() string {
+	return e.Err.Error()
+}
+
+// Unwrap returns the original error.
+func (e *EngineError) Unwrap() error {
+	return e.Err
+}
+
+var theErrorHandler atomic.Pointer[func(err error) error]
+
+// SetErrorHandler sets a function called when the game is about to terminate with an error.
+//
+// The handler is called with an *EngineError, which has Ebitengine's state at the time when the error happened.
+// The value returned by the handler is returned from RunGame instead of the original error.
+// This is useful to send crash reports from the field.
+//
+// After SetErrorHandler is called with a non-nil handler, a panic in Update, Draw, or Ebitengine's render thread is
+// recovered and treated as an error.
+// Termination is not passed to the handler.
+//
+// handler is called on the same goroutine as RunGame.
+// If handler is nil, the error handler is removed, but panics are still recovered.
+//
+// SetErrorHandler works only with RunGame and RunGameWithOptions.
+//
+// SetErrorHandler is concurrent-safe.
+func SetErrorHandler(handler func(err error) error) {
+	if handler == nil {
+		theErrorHandler.Store(nil)
+		return
+	}
+	debug.EnablePanicCapture()
+	theErrorHandler.Store(&handler)
+}
+
+func handleError(err error) error {
+	h := theErrorHandler.Load()
+	if h == nil {
+		return err
+	}
+
+	e := &EngineError{
+		Err:                    err,
+		GraphicsLibrary:        GraphicsLibrary(ui.Get().GraphicsLibrary()),
+		RecentGraphicsCommands: debug.RecentFrameLogs(),
+	}
+	var p *debug.PanicError
+	if errors.As(err, &p) {
+		e.Panic = p.Value
+		e.Stack = p.Stack
+	}
+	return (*h)(e)
+}
@@ -30,8 +30,8 @@ import (
 )
 
 const (
-	screenWidth  = 800
-	screenHeight = 800
+	screenWidth  = 960
+	screenHeight = 880
 )
 
 var (
@@ -83,6 +83,11 @@ func NewGame() (*Game, error) {
 		{blend: ebiten.BlendDestinationOut, name: "BlendDestinationOut"},
 		{blend: ebiten.BlendXor, name: "BlendXor"},
 		{blend: ebiten.BlendLighter, name: "BlendLighter"},
+		{blend: ebiten.BlendMultiply, name: "BlendMultiply"},
+		{blend: ebiten.BlendScreen, name: "BlendScreen"},
+		{blend: ebiten.BlendAdditive, name: "BlendAdditive"},
+		{blend: ebiten.BlendSubtract, name: "BlendSubtract"},
+		{blend: ebiten.BlendLighten, name: "BlendLighten"},
 		{blend: ebiten.BlendClear, name: "BlendClear"},
 	}
 
@@ -101,7 +106,7 @@ func (g *Game) Draw(screen *ebiten.Image) {
 	const (
 		tileGap = 64
 		textGap = 16
-		gridW   = 4
+		gridW   = 5
 	)
 
 	// Clear the screen.
@@ -0,0 +1,91 @@
+// Copyright 2026 The Ebitengine Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package accessibility provides features to communicate with the platform's screen reader.
+//
+// This package is experimental and the API might be changed in the future.
+//
+// This package is supported on Windows, macOS, Android, iOS, and browsers.
+// On the other platforms, the functions in this package do nothing.
+package accessibility
+
+import (
+	"sync"
+)
+
+// Politeness represents how urgently an announcement is read by the screen reader.
+type Politeness int
+
+const (
+	// PolitenessPolite indicates that the announcement is read after the current speech finishes.
+	PolitenessPolite Politeness = iota
+
+	// PolitenessAssertive indicates that the announcement interrupts the current speech.
+	PolitenessAssertive
+)
+
+// Announce sends the text to the platform's screen reader to be read aloud.
+//
+// Announce does nothing if the text is empty, no screen reader is running, or the platform is not supported.
+//
+// Announce is concurrent-safe.
+func Announce(text string, politeness Politeness) {
+	if text == "" {
+		return
+	}
+	announce(text, politeness)
+}
+
+// Region represents a focusable named area in the game screen like a button or a menu item.
+type Region struct {
+	// Name is the name read by the screen reader.
+	Name string
+}
+
+var (
+	regionsM       sync.Mutex
+	regionsFocused string
+)
+
+// SetRegions sets the focusable named regions in the game screen.
+//
+// focused is the index of the currently focused region in regions, or -1 if no region is focused.
+// When the focused region changes, the screen reader reads its name.
+//
+// On browsers, the regions are exposed to the screen reader as the fallback content of the canvas.
+// On the other platforms, SetRegions only announces the name of the newly focused region.
+//
+// SetRegions is concurrent-safe.
+func SetRegions(regions []Region, focused int) {
+	if focused >= len(regions) {
+		focused = -1
+	}
+	if setRegions(regions, focused) {
+		return
+	}
+
+	var name string
+	if focused >= 0 {
+		name = regions[focused].Name
+	}
+
+	regionsM.Lock()
+	defer regionsM.Unlock()
+
+	if regionsFocused == name {
+		return
+	}
+	regionsFocused = name
+	Announce(name, PolitenessPolite)
+}
@@ -0,0 +1,124 @@
+// Copyright 2026 The Ebitengine Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build !ios
+
+package accessibility
+
+import (
+	"sync"
+	"unsafe"
+
+	"github.com/ebitengine/purego"
+	"github.com/ebitengine/purego/objc"
+
+	"github.com/duplicants-ai/ebiten/internal/cocoa"
+	"github.com/duplicants-ai/ebiten/internal/ui"
+)
+
+const (
+	_NSAccessibilityPriorityMedium = 50
+	_NSAccessibilityPriorityHigh   = 90
+)
+
+var (
+	class_NSApplication       = objc.GetClass("NSApplication")
+	class_NSMutableDictionary = objc.GetClass("NSMutableDictionary")
+	class_NSNumber            = objc.GetClass("NSNumber")
+)
+
+var (
+	sel_mainWindow        = objc.RegisterName("mainWindow")
+	sel_new               = objc.RegisterName("new")
+	sel_numberWithInteger = objc.RegisterName("numberWithInteger:")
+	sel_release           = objc.RegisterName("release")
+	sel_setObjectForKey   = objc.RegisterName("setObject:forKey:")
+	sel_sharedApplication = objc.RegisterName("sharedApplication")
+)
+
+var (
+	_NSAccessibilityPostNotificationWithUserInfo func(element objc.ID, notification objc.ID, userInfo objc.ID)
+
+	_NSAccessibilityAnnouncementRequestedNotification objc.ID
+	_NSAccessibilityAnnouncementKey                   objc.ID
+	_NSAccessibilityPriorityKey                       objc.ID
+
+	appKitOnce sync.Once
+	appKitErr  error
+)
+
+func initializeAppKit() error {
+	appKitOnce.Do(func() {
+		appKit, err := purego.Dlopen("/System/Library/Frameworks/AppKit.framework/AppKit", purego.RTLD_LAZY|purego.RTLD_GLOBAL)
+		if err != nil {
+			appKitErr = err
+			return
+		}
+		purego.RegisterLibFunc(&_NSAccessibilityPostNotificationWithUserInfo, appKit, "NSAccessibilityPostNotificationWithUserInfo")
+
+		// The constants are NSString pointers.
+		for _, c := range []struct {
+			name string
+			dst  *objc.ID
+		}{
+			{"NSAccessibilityAnnouncementRequestedNotification", &_NSAccessibilityAnnouncementRequestedNotification},
+			{"NSAccessibilityAnnouncementKey", &_NSAccessibilityAnnouncementKey},
+			{"NSAccessibilityPriorityKey", &_NSAccessibilityPriorityKey},
+		} {
+			ptr, err := purego.Dlsym(appKit, c.name)
+			if err != nil {
+				appKitErr = err
+				return
+			}
+			*c.dst = **(**objc.ID)(unsafe.Pointer(&ptr))
+		}
+	})
+	return appKitErr
+}
+
+func announce(text string, politeness Politeness) {
+	priority := _NSAccessibilityPriorityMedium
+	if politeness == PolitenessAssertive {
+		priority = _NSAccessibilityPriorityHigh
+	}
+
+	// NSAccessibilityPostNotificationWithUserInfo must be called on the main thread.
+	ui.Get().RunOnMainThread(func() {
+		if err := initializeAppKit(); err != nil {
+			return
+		}
+
+		pool := cocoa.NSAutoreleasePool_new()
+		defer pool.Release()
+
+		announcement := cocoa.NSString_alloc().InitWithUTF8String(text)
+		defer announcement.Send(sel_release)
+
+		userInfo := objc.ID(class_NSMutableDictionary).Send(sel_new)
+		defer userInfo.Send(sel_release)
+		userInfo.Send(sel_setObjectForKey, announcement.ID, _NSAccessibilityAnnouncementKey)
+		userInfo.Send(sel_setObjectForKey, objc.ID(class_NSNumber).Send(sel_numberWithInteger, priority), _NSAccessibilityPriorityKey)
+
+		app := objc.ID(class_NSApplication).Send(sel_sharedApplication)
+		element := app.Send(sel_mainWindow)
+		if element == 0 {
+			element = app
+		}
+		_NSAccessibilityPostNotificationWithUserInfo(element, _NSAccessibilityAnnouncementRequestedNotification, userInfo)
+	})
+}
+
+func setRegions(regions []Region, focused int) bool {
+	return false
+}
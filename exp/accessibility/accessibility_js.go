@@ -0,0 +1,152 @@
+// Copyright 2026 The Ebitengine Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package accessibility
+
+import (
+	"fmt"
+	"sync"
+	"syscall/js"
+
+	"github.com/duplicants-ai/ebiten/internal/ui"
+)
+
+var (
+	document   = js.Global().Get("document")
+	setTimeout = js.Global().Get("setTimeout")
+)
+
+type liveRegion struct {
+	element js.Value
+	setText js.Func
+}
+
+func newLiveRegion(role string, ariaLive string) *liveRegion {
+	e := document.Call("createElement", "div")
+	e.Call("setAttribute", "role", role)
+	e.Call("setAttribute", "aria-live", ariaLive)
+	e.Call("setAttribute", "aria-atomic", "true")
+
+	// Hide the element visually while keeping it visible to screen readers.
+	style := e.Get("style")
+	style.Set("position", "absolute")
+	style.Set("width", "1px")
+	style.Set("height", "1px")
+	style.Set("margin", "-1px")
+	style.Set("padding", "0")
+	style.Set("border", "0")
+	style.Set("overflow", "hidden")
+	style.Set("clip", "rect(0 0 0 0)")
+	style.Set("clipPath", "inset(50%)")
+	style.Set("whiteSpace", "nowrap")
+
+	document.Get("body").Call("appendChild", e)
+
+	return &liveRegion{
+		element: e,
+		setText: js.FuncOf(func(this js.Value, args []js.Value) any {
+			e.Set("textContent", args[0])
+			return nil
+		}),
+	}
+}
+
+func (l *liveRegion) announce(text string) {
+	// Clear the text first so that the same text is announced again.
+	// Screen readers might miss a change in the same task, then set the text after a short delay.
+	l.element.Set("textContent", "")
+	setTimeout.Invoke(l.setText, 100, text)
+}
+
+type accessibility struct {
+	polite    *liveRegion
+	assertive *liveRegion
+
+	regionElements []js.Value
+
+	initOnce sync.Once
+	m        sync.Mutex
+}
+
+var theAccessibility accessibility
+
+func (a *accessibility) init() {
+	a.polite = newLiveRegion("status", "polite")
+	a.assertive = newLiveRegion("alert", "assertive")
+}
+
+func announce(text string, politeness Politeness) {
+	// document is undefined on node.js and Web Workers.
+	if !document.Truthy() || !document.Get("body").Truthy() {
+		return
+	}
+
+	a := &theAccessibility
+	a.initOnce.Do(a.init)
+
+	a.m.Lock()
+	defer a.m.Unlock()
+
+	switch politeness {
+	case PolitenessAssertive:
+		a.assertive.announce(text)
+	default:
+		a.polite.announce(text)
+	}
+}
+
+func setRegions(regions []Region, focused int) bool {
+	canvas := ui.Get().Canvas()
+	if !canvas.Truthy() {
+		return false
+	}
+
+	a := &theAccessibility
+
+	a.m.Lock()
+	defer a.m.Unlock()
+
+	// The children of a canvas element are not rendered, but they are exposed to screen readers as the fallback content.
+	for len(a.regionElements) < len(regions) {
+		e := document.Call("createElement", "div")
+		e.Set("id", fmt.Sprintf("ebitengine-accessibility-region-%d", len(a.regionElements)))
+		e.Call("setAttribute", "role", "button")
+		a.regionElements = append(a.regionElements, e)
+	}
+	for i, e := range a.regionElements {
+		if i >= len(regions) {
+			e.Call("remove")
+			continue
+		}
+		e.Call("setAttribute", "aria-label", regions[i].Name)
+		// The canvas might be replaced with a custom canvas after the game starts.
+		if !e.Get("parentNode").Equal(canvas) {
+			canvas.Call("appendChild", e)
+		}
+	}
+	a.regionElements = a.regionElements[:len(regions)]
+
+	if len(regions) == 0 {
+		canvas.Call("removeAttribute", "role")
+	} else {
+		canvas.Call("setAttribute", "role", "application")
+	}
+	if focused >= 0 {
+		canvas.Call("setAttribute", "aria-activedescendant", a.regionElements[focused].Get("id"))
+	} else {
+		canvas.Call("removeAttribute", "aria-activedescendant")
+	}
+
+	return true
+}
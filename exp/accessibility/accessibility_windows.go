@@ -0,0 +1,80 @@
+// Copyright 2026 The Ebitengine Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package accessibility
+
+import (
+	"github.com/duplicants-ai/ebiten/internal/microsoftgdk"
+	"github.com/duplicants-ai/ebiten/internal/ui"
+)
+
+func announce(text string, politeness Politeness) {
+	if microsoftgdk.IsXbox() {
+		return
+	}
+
+	// UiaRaiseNotificationEvent is available on Windows 10 version 1709 or later.
+	if procUiaRaiseNotificationEvent.Find() != nil {
+		return
+	}
+
+	processing := int32(_NotificationProcessing_All)
+	if politeness == PolitenessAssertive {
+		processing = _NotificationProcessing_ImportantMostRecent
+	}
+
+	// GetActiveWindow must be called on the thread that owns the window.
+	ui.Get().RunOnMainThread(func() {
+		// Errors are ignored as the announcement is best-effort.
+		_ = raiseNotificationEvent(text, processing)
+	})
+}
+
+func raiseNotificationEvent(text string, processing int32) error {
+	if !_UiaClientsAreListening() {
+		return nil
+	}
+
+	hwnd := _GetActiveWindow()
+	if hwnd == 0 {
+		return nil
+	}
+
+	provider, err := _UiaHostProviderFromHwnd(hwnd)
+	if err != nil {
+		return err
+	}
+	if provider == nil {
+		return nil
+	}
+	defer provider.Release()
+
+	displayString, err := _SysAllocString(text)
+	if err != nil {
+		return err
+	}
+	defer _SysFreeString(displayString)
+
+	activityID, err := _SysAllocString("ebitengine-accessibility-announcement")
+	if err != nil {
+		return err
+	}
+	defer _SysFreeString(activityID)
+
+	return _UiaRaiseNotificationEvent(provider, _NotificationKind_Other, processing, displayString, activityID)
+}
+
+func setRegions(regions []Region, focused int) bool {
+	return false
+}
@@ -0,0 +1,110 @@
+// Copyright 2026 The Ebitengine Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package accessibility
+
+import (
+	"fmt"
+	"runtime"
+	"syscall"
+	"unsafe"
+
+	"golang.org/x/sys/windows"
+)
+
+const (
+	_NotificationKind_Other = 4
+
+	_NotificationProcessing_ImportantMostRecent = 1
+	_NotificationProcessing_All                 = 2
+)
+
+type _BSTR uintptr
+
+type _IRawElementProviderSimple struct {
+	vtbl *_IRawElementProviderSimple_Vtbl
+}
+
+type _IRawElementProviderSimple_Vtbl struct {
+	QueryInterface uintptr
+	AddRef         uintptr
+	Release        uintptr
+
+	// The other methods are omitted as they are not used.
+}
+
+func (i *_IRawElementProviderSimple) Release() uint32 {
+	r, _, _ := syscall.Syscall(i.vtbl.Release, 1, uintptr(unsafe.Pointer(i)), 0, 0)
+	return uint32(r)
+}
+
+var (
+	oleaut32         = windows.NewLazySystemDLL("oleaut32.dll")
+	uiautomationcore = windows.NewLazySystemDLL("uiautomationcore.dll")
+	user32           = windows.NewLazySystemDLL("user32.dll")
+
+	procSysAllocString = oleaut32.NewProc("SysAllocString")
+	procSysFreeString  = oleaut32.NewProc("SysFreeString")
+
+	procUiaClientsAreListening    = uiautomationcore.NewProc("UiaClientsAreListening")
+	procUiaHostProviderFromHwnd   = uiautomationcore.NewProc("UiaHostProviderFromHwnd")
+	procUiaRaiseNotificationEvent = uiautomationcore.NewProc("UiaRaiseNotificationEvent")
+
+	procGetActiveWindow = user32.NewProc("GetActiveWindow")
+)
+
+func _GetActiveWindow() windows.HWND {
+	r, _, _ := procGetActiveWindow.Call()
+	return windows.HWND(r)
+}
+
+func _SysAllocString(psz string) (_BSTR, error) {
+	p, err := windows.UTF16PtrFromString(psz)
+	if err != nil {
+		return 0, err
+	}
+	r, _, _ := procSysAllocString.Call(uintptr(unsafe.Pointer(p)))
+	runtime.KeepAlive(p)
+	if r == 0 {
+		return 0, fmt.Errorf("accessibility: SysAllocString failed")
+	}
+	return _BSTR(r), nil
+}
+
+func _SysFreeString(bstrString _BSTR) {
+	_, _, _ = procSysFreeString.Call(uintptr(bstrString))
+}
+
+func _UiaClientsAreListening() bool {
+	r, _, _ := procUiaClientsAreListening.Call()
+	return int32(r) != 0
+}
+
+func _UiaHostProviderFromHwnd(hwnd windows.HWND) (*_IRawElementProviderSimple, error) {
+	var provider *_IRawElementProviderSimple
+	r, _, _ := procUiaHostProviderFromHwnd.Call(uintptr(hwnd), uintptr(unsafe.Pointer(&provider)))
+	if uint32(r) != uint32(windows.S_OK) {
+		return nil, fmt.Errorf("accessibility: UiaHostProviderFromHwnd failed: HRESULT(%d)", uint32(r))
+	}
+	return provider, nil
+}
+
+func _UiaRaiseNotificationEvent(provider *_IRawElementProviderSimple, notificationKind int32, notificationProcessing int32, displayString _BSTR, activityId _BSTR) error {
+	r, _, _ := procUiaRaiseNotificationEvent.Call(uintptr(unsafe.Pointer(provider)), uintptr(notificationKind), uintptr(notificationProcessing), uintptr(displayString), uintptr(activityId))
+	runtime.KeepAlive(provider)
+	if uint32(r) != uint32(windows.S_OK) {
+		return fmt.Errorf("accessibility: UiaRaiseNotificationEvent failed: HRESULT(%d)", uint32(r))
+	}
+	return nil
+}
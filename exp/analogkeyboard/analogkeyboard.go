@@ -0,0 +1,124 @@
+// Copyright 2026 The Ebitengine Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package analogkeyboard provides analog key values for keyboards with analog switches.
+//
+// This package is experimental and the API might be changed in the future.
+//
+// Some keyboards, e.g., Wooting keyboards, can report how deep each key is pressed.
+// KeyValue returns the travel of a key in [0, 1] for such keyboards, and falls back to the digital value
+// (0 or 1) for the other keyboards. This enables analog inputs like analog WASD movement on capable hardware
+// without special-casing.
+//
+// Analog values are read from the HID interface with the usage page 0xFF54, which Wooting keyboards use.
+// Analog values are available only on Linux so far.
+// On Linux, the user must have the permission to read the hidraw device (/dev/hidrawN), e.g., by a udev rule.
+package analogkeyboard
+
+import (
+	"sync"
+	"time"
+
+	"github.com/duplicants-ai/ebiten"
+)
+
+var theState = state{
+	values: map[uint16]float64{},
+}
+
+type state struct {
+	devices  []device
+	values   map[uint16]float64
+	lastScan time.Time
+
+	m sync.Mutex
+}
+
+type device interface {
+	// readValues reads the available reports and updates the analog values.
+	readValues(values map[uint16]float64) error
+	close()
+}
+
+// scanInterval is the interval to look for analog devices when there are no devices.
+const scanInterval = time.Second
+
+// Update reads the current analog values from the devices.
+// Update should be called at the beginning of the game's Update.
+//
+// Update is concurrent-safe.
+func Update() {
+	theState.update()
+}
+
+func (s *state) update() {
+	s.m.Lock()
+	defer s.m.Unlock()
+
+	if len(s.devices) == 0 {
+		if now := time.Now(); now.Sub(s.lastScan) >= scanInterval {
+			s.devices = openDevices()
+			s.lastScan = now
+		}
+	}
+
+	for i := 0; i < len(s.devices); i++ {
+		d := s.devices[i]
+		if err := d.readValues(s.values); err != nil {
+			// The device is disconnected.
+			d.close()
+			s.devices = append(s.devices[:i], s.devices[i+1:]...)
+			i--
+			clear(s.values)
+		}
+	}
+}
+
+// IsAnalogAvailable reports whether an analog keyboard is connected.
+//
+// IsAnalogAvailable is concurrent-safe.
+func IsAnalogAvailable() bool {
+	theState.m.Lock()
+	defer theState.m.Unlock()
+	return len(theState.devices) > 0
+}
+
+// KeyValue returns the travel of the key in [0, 1].
+//
+// If no analog value is available for the key, KeyValue returns 1 when the key is pressed, and 0 otherwise.
+//
+// KeyValue is concurrent-safe.
+func KeyValue(key ebiten.Key) float64 {
+	switch key {
+	case ebiten.KeyAlt:
+		return max(KeyValue(ebiten.KeyAltLeft), KeyValue(ebiten.KeyAltRight))
+	case ebiten.KeyControl:
+		return max(KeyValue(ebiten.KeyControlLeft), KeyValue(ebiten.KeyControlRight))
+	case ebiten.KeyShift:
+		return max(KeyValue(ebiten.KeyShiftLeft), KeyValue(ebiten.KeyShiftRight))
+	case ebiten.KeyMeta:
+		return max(KeyValue(ebiten.KeyMetaLeft), KeyValue(ebiten.KeyMetaRight))
+	}
+
+	var v float64
+	if ebiten.IsKeyPressed(key) {
+		v = 1
+	}
+	if usage, ok := keyToHIDUsage[key]; ok {
+		theState.m.Lock()
+		v = max(v, theState.values[usage])
+		theState.m.Unlock()
+	}
+	return v
+}
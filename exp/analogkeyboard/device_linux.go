@@ -0,0 +1,90 @@
+// Copyright 2026 The Ebitengine Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build linux && !android
+
+package analogkeyboard
+
+import (
+	"bytes"
+	"errors"
+	"os"
+	"path/filepath"
+
+	"golang.org/x/sys/unix"
+)
+
+// analogUsagePage is the 'Usage Page (0xFF54)' item in a HID report descriptor.
+var analogUsagePage = []byte{0x06, 0x54, 0xff}
+
+func openDevices() []device {
+	matches, err := filepath.Glob("/sys/class/hidraw/hidraw*")
+	if err != nil {
+		return nil
+	}
+	var devices []device
+	for _, m := range matches {
+		desc, err := os.ReadFile(filepath.Join(m, "device", "report_descriptor"))
+		if err != nil {
+			continue
+		}
+		if !bytes.Contains(desc, analogUsagePage) {
+			continue
+		}
+		fd, err := unix.Open(filepath.Join("/dev", filepath.Base(m)), unix.O_RDONLY|unix.O_NONBLOCK, 0)
+		if err != nil {
+			// The user might not have the permission.
+			continue
+		}
+		devices = append(devices, &hidrawDevice{fd: fd})
+	}
+	return devices
+}
+
+type hidrawDevice struct {
+	fd  int
+	buf [64]byte
+}
+
+func (d *hidrawDevice) readValues(values map[uint16]float64) error {
+	for {
+		n, err := unix.Read(d.fd, d.buf[:])
+		if errors.Is(err, unix.EAGAIN) || errors.Is(err, unix.EINTR) {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		if n == 0 {
+			return errors.New("analogkeyboard: the device is disconnected")
+		}
+
+		// A report lists the currently pressed keys as pairs of a HID usage (2 bytes in big endian) and a value (1 byte).
+		// The list ends with a zero value.
+		clear(values)
+		report := d.buf[:n]
+		for i := 0; i+2 < len(report); i += 3 {
+			v := report[i+2]
+			if v == 0 {
+				break
+			}
+			usage := uint16(report[i])<<8 | uint16(report[i+1])
+			values[usage] = float64(v) / 255
+		}
+	}
+}
+
+func (d *hidrawDevice) close() {
+	_ = unix.Close(d.fd)
+}
@@ -0,0 +1,268 @@
+// Copyright 2026 The Ebitengine Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package aseprite provides a decoder for Aseprite files (.ase and .aseprite).
+//
+// This package is experimental and the API might be changed in the future.
+//
+// Decode reads layers, frames, tags, and slices, and maps the images to *ebiten.Image.
+// RGBA, grayscale, and indexed color modes are supported. Tilemap layers are not supported and their cels are ignored.
+package aseprite
+
+import (
+	"image"
+	"io"
+	"time"
+
+	"github.com/duplicants-ai/ebiten"
+)
+
+// BlendMode represents a layer's blend mode in Aseprite.
+//
+// The composited frame images are rendered with the normal blend mode regardless of the layers' blend modes.
+type BlendMode int
+
+const (
+	BlendModeNormal BlendMode = iota
+	BlendModeMultiply
+	BlendModeScreen
+	BlendModeOverlay
+	BlendModeDarken
+	BlendModeLighten
+	BlendModeColorDodge
+	BlendModeColorBurn
+	BlendModeHardLight
+	BlendModeSoftLight
+	BlendModeDifference
+	BlendModeExclusion
+	BlendModeHue
+	BlendModeSaturation
+	BlendModeColor
+	BlendModeLuminosity
+	BlendModeAddition
+	BlendModeSubtract
+	BlendModeDivide
+)
+
+// LoopDirection represents an animation direction of a tag.
+type LoopDirection int
+
+const (
+	LoopDirectionForward LoopDirection = iota
+	LoopDirectionReverse
+	LoopDirectionPingPong
+	LoopDirectionPingPongReverse
+)
+
+// Layer represents a layer.
+type Layer struct {
+	Name string
+
+	// Visible reports whether the layer itself is visible.
+	// Even if Visible is true, the layer is not rendered when its parent group is invisible.
+	Visible bool
+
+	Background bool
+	Group      bool
+	Tilemap    bool
+
+	// ChildLevel is the depth in the group hierarchy. A layer at the top level has 0.
+	// The parent group of a layer is the nearest preceding group with a smaller ChildLevel.
+	ChildLevel int
+
+	BlendMode BlendMode
+
+	// Opacity is the opacity in [0, 1].
+	Opacity float64
+}
+
+// Cel represents an image of a layer in a frame.
+type Cel struct {
+	// Layer is the index of the layer.
+	Layer int
+
+	// X and Y are the position of the cel in the canvas.
+	X int
+	Y int
+
+	// Opacity is the opacity of the cel in [0, 1].
+	Opacity float64
+
+	// Image is the cel's image. Linked cels share the same image.
+	Image *ebiten.Image
+}
+
+// Frame represents a frame.
+type Frame struct {
+	Duration time.Duration
+
+	// Image is the frame image of the canvas size, where the visible layers are composited.
+	Image *ebiten.Image
+
+	// Cels is the cels in the frame, ordered from the bottom layer to the top layer.
+	Cels []Cel
+}
+
+// Tag represents an animation tag, which is a range of frames.
+type Tag struct {
+	Name string
+
+	// From and To are the first and the last frame indices. Both are inclusive.
+	From int
+	To   int
+
+	Direction LoopDirection
+
+	// Repeat is the number of times the animation is played. 0 means infinite.
+	// For ping-pong directions, a round trip is counted as one.
+	Repeat int
+}
+
+// SliceKey represents the properties of a slice from a frame.
+type SliceKey struct {
+	// Frame is the frame index where the key starts.
+	Frame int
+
+	// Bounds is the bounds of the slice in the canvas.
+	Bounds image.Rectangle
+
+	// Center is the center area of a 9-patch slice, relative to Bounds.
+	// Center is empty if the slice is not a 9-patch.
+	Center image.Rectangle
+
+	// Pivot is the pivot point relative to Bounds. Pivot is valid only when HasPivot is true.
+	Pivot    image.Point
+	HasPivot bool
+}
+
+// Slice represents a named region in the canvas.
+type Slice struct {
+	Name string
+
+	// Keys is the keys of the slice ordered by the frame indices.
+	Keys []SliceKey
+}
+
+// KeyAt returns the key for the frame.
+// KeyAt returns false if the slice doesn't exist at the frame.
+func (s *Slice) KeyAt(frame int) (SliceKey, bool) {
+	var key SliceKey
+	var found bool
+	for _, k := range s.Keys {
+		if k.Frame > frame {
+			break
+		}
+		key = k
+		found = true
+	}
+	return key, found
+}
+
+// File represents a decoded Aseprite file.
+type File struct {
+	// Width and Height are the canvas size.
+	Width  int
+	Height int
+
+	Layers []Layer
+	Frames []Frame
+	Tags   []Tag
+	Slices []Slice
+}
+
+// Decode decodes an Aseprite file.
+func Decode(r io.Reader) (*File, error) {
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return nil, err
+	}
+	p, err := parse(data)
+	if err != nil {
+		return nil, err
+	}
+
+	f := &File{
+		Width:  p.width,
+		Height: p.height,
+		Layers: p.layers,
+		Tags:   p.tags,
+		Slices: p.slices,
+	}
+
+	// Linked cels share the same image.
+	images := map[*image.NRGBA]*ebiten.Image{}
+	for i := range p.frames {
+		pf := &p.frames[i]
+		frame := Frame{
+			Duration: pf.duration,
+			Image:    ebiten.NewImageFromImage(p.composeFrame(pf)),
+		}
+		for _, c := range pf.cels {
+			img, ok := images[c.image]
+			if !ok {
+				img = ebiten.NewImageFromImage(c.image)
+				images[c.image] = img
+			}
+			frame.Cels = append(frame.Cels, Cel{
+				Layer:   c.layer,
+				X:       c.x,
+				Y:       c.y,
+				Opacity: float64(c.opacity) / 0xff,
+				Image:   img,
+			})
+		}
+		f.Frames = append(f.Frames, frame)
+	}
+	return f, nil
+}
+
+// Tag returns the tag with the name.
+func (f *File) Tag(name string) (*Tag, bool) {
+	for i := range f.Tags {
+		if f.Tags[i].Name == name {
+			return &f.Tags[i], true
+		}
+	}
+	return nil, false
+}
+
+// Slice returns the slice with the name.
+func (f *File) Slice(name string) (*Slice, bool) {
+	for i := range f.Slices {
+		if f.Slices[i].Name == name {
+			return &f.Slices[i], true
+		}
+	}
+	return nil, false
+}
+
+// FrameIndex returns the frame index to show after the elapsed time from the start of the tag's animation.
+// If tag is nil, all the frames are played forward in a loop.
+//
+// After the animation repeats Repeat times, FrameIndex returns the last frame of the animation.
+func (f *File) FrameIndex(tag *Tag, elapsed time.Duration) int {
+	if len(f.Frames) == 0 {
+		return 0
+	}
+	durations := make([]time.Duration, len(f.Frames))
+	for i, fr := range f.Frames {
+		durations[i] = fr.Duration
+	}
+	if tag == nil {
+		tag = &Tag{
+			To: len(f.Frames) - 1,
+		}
+	}
+	return tagFrameIndex(durations, tag, elapsed)
+}
@@ -0,0 +1,582 @@
+// Copyright 2026 The Ebitengine Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package aseprite
+
+import (
+	"bytes"
+	"compress/zlib"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"image"
+	"image/color"
+	"io"
+	"math"
+	"sort"
+	"time"
+)
+
+// The file format is described at https://github.com/aseprite/aseprite/blob/main/docs/ase-file-specs.md.
+
+const (
+	fileMagic  = 0xa5e0
+	frameMagic = 0xf1fa
+
+	headerFlagLayerOpacityValid = 1 << 0
+
+	chunkOldPalette = 0x0004
+	chunkLayer      = 0x2004
+	chunkCel        = 0x2005
+	chunkTags       = 0x2018
+	chunkPalette    = 0x2019
+	chunkSlice      = 0x2022
+
+	celTypeRaw        = 0
+	celTypeLinked     = 1
+	celTypeCompressed = 2
+
+	layerFlagVisible    = 1 << 0
+	layerFlagBackground = 1 << 3
+
+	layerTypeGroup   = 1
+	layerTypeTilemap = 2
+
+	sliceFlagNinePatch = 1 << 0
+	sliceFlagPivot     = 1 << 1
+)
+
+type parsedCel struct {
+	layer   int
+	x       int
+	y       int
+	opacity uint8
+	image   *image.NRGBA
+}
+
+type parsedFrame struct {
+	duration time.Duration
+	cels     []parsedCel
+}
+
+type parsedFile struct {
+	width  int
+	height int
+	depth  int
+	flags  uint32
+
+	transparentIndex uint8
+	palette          color.Palette
+
+	layers []Layer
+	frames []parsedFrame
+	tags   []Tag
+	slices []Slice
+}
+
+// reader reads little-endian values from a byte slice.
+type reader struct {
+	buf []byte
+	err error
+}
+
+func (r *reader) bytes(n int) []byte {
+	if r.err != nil {
+		return nil
+	}
+	if n < 0 || len(r.buf) < n {
+		r.err = io.ErrUnexpectedEOF
+		return nil
+	}
+	b := r.buf[:n]
+	r.buf = r.buf[n:]
+	return b
+}
+
+func (r *reader) u8() uint8 {
+	b := r.bytes(1)
+	if b == nil {
+		return 0
+	}
+	return b[0]
+}
+
+func (r *reader) u16() uint16 {
+	b := r.bytes(2)
+	if b == nil {
+		return 0
+	}
+	return binary.LittleEndian.Uint16(b)
+}
+
+func (r *reader) i16() int16 {
+	return int16(r.u16())
+}
+
+func (r *reader) u32() uint32 {
+	b := r.bytes(4)
+	if b == nil {
+		return 0
+	}
+	return binary.LittleEndian.Uint32(b)
+}
+
+func (r *reader) i32() int32 {
+	return int32(r.u32())
+}
+
+func (r *reader) string() string {
+	n := r.u16()
+	return string(r.bytes(int(n)))
+}
+
+func parse(data []byte) (*parsedFile, error) {
+	r := &reader{buf: data}
+
+	// Header
+	r.u32() // File size
+	if m := r.u16(); r.err == nil && m != fileMagic {
+		return nil, fmt.Errorf("aseprite: invalid magic number: 0x%04x", m)
+	}
+	frameCount := int(r.u16())
+	f := &parsedFile{}
+	f.width = int(r.u16())
+	f.height = int(r.u16())
+	f.depth = int(r.u16())
+	f.flags = r.u32()
+	r.u16() // Speed (deprecated)
+	r.u32()
+	r.u32()
+	f.transparentIndex = r.u8()
+	r.bytes(3)
+	r.u16() // The number of colors
+	r.bytes(1 + 1 + 2 + 2 + 2 + 2 + 84)
+	if r.err != nil {
+		return nil, fmt.Errorf("aseprite: invalid header: %w", r.err)
+	}
+	switch f.depth {
+	case 32, 16, 8:
+	default:
+		return nil, fmt.Errorf("aseprite: unsupported color depth: %d", f.depth)
+	}
+
+	for i := 0; i < frameCount; i++ {
+		if err := f.parseFrame(r); err != nil {
+			return nil, fmt.Errorf("aseprite: frame %d: %w", i, err)
+		}
+	}
+	for _, t := range f.tags {
+		if t.From > t.To || t.To >= len(f.frames) {
+			return nil, fmt.Errorf("aseprite: invalid frame range of tag %q: %d-%d", t.Name, t.From, t.To)
+		}
+	}
+	return f, nil
+}
+
+func (f *parsedFile) parseFrame(r *reader) error {
+	size := int(r.u32())
+	fr := &reader{buf: r.bytes(size - 4)}
+	if r.err != nil {
+		return r.err
+	}
+
+	if m := fr.u16(); fr.err == nil && m != frameMagic {
+		return fmt.Errorf("invalid magic number: 0x%04x", m)
+	}
+	chunkCount := int(fr.u16())
+	duration := time.Duration(fr.u16()) * time.Millisecond
+	fr.bytes(2)
+	if n := fr.u32(); n != 0 {
+		chunkCount = int(n)
+	}
+	if fr.err != nil {
+		return fr.err
+	}
+
+	f.frames = append(f.frames, parsedFrame{
+		duration: duration,
+	})
+	for i := 0; i < chunkCount; i++ {
+		size := int(fr.u32())
+		typ := fr.u16()
+		cr := &reader{buf: fr.bytes(size - 6)}
+		if fr.err != nil {
+			return fr.err
+		}
+		if err := f.parseChunk(typ, cr); err != nil {
+			return fmt.Errorf("chunk 0x%04x: %w", typ, err)
+		}
+	}
+
+	// Order the cels from the bottom layer to the top layer.
+	cels := f.frames[len(f.frames)-1].cels
+	sort.SliceStable(cels, func(i, j int) bool {
+		return cels[i].layer < cels[j].layer
+	})
+	return nil
+}
+
+func (f *parsedFile) parseChunk(typ uint16, r *reader) error {
+	switch typ {
+	case chunkOldPalette:
+		// The old palette chunk is ignored if the new palette chunk exists.
+		if f.palette != nil {
+			return nil
+		}
+		var pal color.Palette
+		packets := int(r.u16())
+		for i := 0; i < packets; i++ {
+			skip := int(r.u8())
+			for j := 0; j < skip; j++ {
+				pal = append(pal, color.NRGBA{A: 0xff})
+			}
+			n := int(r.u8())
+			if n == 0 {
+				n = 256
+			}
+			for j := 0; j < n; j++ {
+				rgb := r.bytes(3)
+				if rgb == nil {
+					break
+				}
+				pal = append(pal, color.NRGBA{R: rgb[0], G: rgb[1], B: rgb[2], A: 0xff})
+			}
+		}
+		if r.err != nil {
+			return r.err
+		}
+		f.palette = pal
+
+	case chunkPalette:
+		size := int(r.u32())
+		first := int(r.u32())
+		last := int(r.u32())
+		r.bytes(8)
+		if size > 1<<16 || first > last || last >= size {
+			return fmt.Errorf("invalid palette range: %d-%d in %d", first, last, size)
+		}
+		if len(f.palette) < size {
+			pal := make(color.Palette, size)
+			copy(pal, f.palette)
+			for i := len(f.palette); i < size; i++ {
+				pal[i] = color.NRGBA{}
+			}
+			f.palette = pal
+		}
+		for i := first; i <= last; i++ {
+			flags := r.u16()
+			c := r.bytes(4)
+			if c == nil {
+				break
+			}
+			f.palette[i] = color.NRGBA{R: c[0], G: c[1], B: c[2], A: c[3]}
+			if flags&1 != 0 {
+				r.string()
+			}
+		}
+		return r.err
+
+	case chunkLayer:
+		flags := r.u16()
+		layerType := r.u16()
+		childLevel := int(r.u16())
+		r.u16() // Default width
+		r.u16() // Default height
+		blendMode := BlendMode(r.u16())
+		opacity := r.u8()
+		r.bytes(3)
+		name := r.string()
+		if r.err != nil {
+			return r.err
+		}
+		if f.flags&headerFlagLayerOpacityValid == 0 {
+			opacity = 0xff
+		}
+		f.layers = append(f.layers, Layer{
+			Name:       name,
+			Visible:    flags&layerFlagVisible != 0,
+			Background: flags&layerFlagBackground != 0,
+			Group:      layerType == layerTypeGroup,
+			Tilemap:    layerType == layerTypeTilemap,
+			ChildLevel: childLevel,
+			BlendMode:  blendMode,
+			Opacity:    float64(opacity) / 0xff,
+		})
+
+	case chunkCel:
+		layer := int(r.u16())
+		x := int(r.i16())
+		y := int(r.i16())
+		opacity := r.u8()
+		celType := r.u16()
+		r.i16() // z-index
+		r.bytes(5)
+		if r.err != nil {
+			return r.err
+		}
+		if layer >= len(f.layers) {
+			return fmt.Errorf("invalid layer index: %d", layer)
+		}
+
+		frame := &f.frames[len(f.frames)-1]
+		switch celType {
+		case celTypeRaw, celTypeCompressed:
+			w := int(r.u16())
+			h := int(r.u16())
+			if r.err != nil {
+				return r.err
+			}
+			pix := r.buf
+			if celType == celTypeCompressed {
+				zr, err := zlib.NewReader(bytes.NewReader(r.buf))
+				if err != nil {
+					return err
+				}
+				p, err := io.ReadAll(io.LimitReader(zr, int64(w*h*f.depth/8)))
+				if err != nil {
+					return err
+				}
+				pix = p
+			}
+			img, err := f.decodePixels(pix, w, h, f.layers[layer].Background)
+			if err != nil {
+				return err
+			}
+			frame.cels = append(frame.cels, parsedCel{
+				layer:   layer,
+				x:       x,
+				y:       y,
+				opacity: opacity,
+				image:   img,
+			})
+
+		case celTypeLinked:
+			pos := int(r.u16())
+			if r.err != nil {
+				return r.err
+			}
+			if pos >= len(f.frames)-1 {
+				return fmt.Errorf("invalid linked frame: %d", pos)
+			}
+			for _, c := range f.frames[pos].cels {
+				// A linked cel shares the position and the opacity with the original cel.
+				if c.layer == layer {
+					frame.cels = append(frame.cels, c)
+					break
+				}
+			}
+
+		default:
+			// Tilemap cels are not supported.
+		}
+
+	case chunkTags:
+		n := int(r.u16())
+		r.bytes(8)
+		for i := 0; i < n; i++ {
+			from := int(r.u16())
+			to := int(r.u16())
+			dir := LoopDirection(r.u8())
+			repeat := int(r.u16())
+			r.bytes(6 + 3 + 1)
+			name := r.string()
+			if r.err != nil {
+				return r.err
+			}
+			f.tags = append(f.tags, Tag{
+				Name:      name,
+				From:      from,
+				To:        to,
+				Direction: dir,
+				Repeat:    repeat,
+			})
+		}
+
+	case chunkSlice:
+		n := int(r.u32())
+		flags := r.u32()
+		r.u32()
+		s := Slice{
+			Name: r.string(),
+		}
+		for i := 0; i < n && r.err == nil; i++ {
+			var k SliceKey
+			k.Frame = int(r.u32())
+			x, y := int(r.i32()), int(r.i32())
+			w, h := int(r.u32()), int(r.u32())
+			k.Bounds = image.Rect(x, y, x+w, y+h)
+			if flags&sliceFlagNinePatch != 0 {
+				cx, cy := int(r.i32()), int(r.i32())
+				cw, ch := int(r.u32()), int(r.u32())
+				k.Center = image.Rect(cx, cy, cx+cw, cy+ch)
+			}
+			if flags&sliceFlagPivot != 0 {
+				k.Pivot = image.Pt(int(r.i32()), int(r.i32()))
+				k.HasPivot = true
+			}
+			s.Keys = append(s.Keys, k)
+		}
+		if r.err != nil {
+			return r.err
+		}
+		f.slices = append(f.slices, s)
+	}
+	return nil
+}
+
+func (f *parsedFile) decodePixels(pix []byte, width, height int, background bool) (*image.NRGBA, error) {
+	bpp := f.depth / 8
+	if len(pix) < width*height*bpp {
+		return nil, errors.New("too few pixels")
+	}
+	img := image.NewNRGBA(image.Rect(0, 0, width, height))
+	for i := 0; i < width*height; i++ {
+		dst := img.Pix[4*i : 4*i+4]
+		switch f.depth {
+		case 32:
+			copy(dst, pix[4*i:4*i+4])
+		case 16:
+			v, a := pix[2*i], pix[2*i+1]
+			dst[0], dst[1], dst[2], dst[3] = v, v, v, a
+		case 8:
+			idx := pix[i]
+			if idx == f.transparentIndex && !background {
+				continue
+			}
+			if int(idx) >= len(f.palette) {
+				continue
+			}
+			c := color.NRGBAModel.Convert(f.palette[idx]).(color.NRGBA)
+			dst[0], dst[1], dst[2], dst[3] = c.R, c.G, c.B, c.A
+		}
+	}
+	return img, nil
+}
+
+// layerStates returns the effective visibility and opacity of the layers, considering their parent groups.
+func (f *parsedFile) layerStates() (visible []bool, opacity []float64) {
+	type group struct {
+		visible bool
+		opacity float64
+	}
+	var groups []group
+
+	visible = make([]bool, len(f.layers))
+	opacity = make([]float64, len(f.layers))
+	for i, l := range f.layers {
+		// groups[i] is the parent group at the child level i.
+		groups = groups[:min(l.ChildLevel, len(groups))]
+		v, o := l.Visible, l.Opacity
+		if len(groups) > 0 {
+			p := groups[len(groups)-1]
+			v = v && p.visible
+			o *= p.opacity
+		}
+		visible[i] = v
+		opacity[i] = o
+		if l.Group {
+			groups = append(groups, group{visible: v, opacity: o})
+		}
+	}
+	return
+}
+
+// composeFrame renders the cels of the visible layers in the frame.
+// All the blend modes are treated as the normal blend mode.
+func (f *parsedFile) composeFrame(frame *parsedFrame) *image.NRGBA {
+	visible, opacity := f.layerStates()
+	dst := image.NewNRGBA(image.Rect(0, 0, f.width, f.height))
+
+	for _, c := range frame.cels {
+		if !visible[c.layer] {
+			continue
+		}
+		o := float64(c.opacity) / 0xff * opacity[c.layer]
+		b := c.image.Bounds().Add(image.Pt(c.x, c.y)).Intersect(dst.Bounds())
+		for y := b.Min.Y; y < b.Max.Y; y++ {
+			for x := b.Min.X; x < b.Max.X; x++ {
+				s := c.image.NRGBAAt(x-c.x, y-c.y)
+				d := dst.NRGBAAt(x, y)
+				sa := float64(s.A) / 0xff * o
+				if sa == 0 {
+					continue
+				}
+				da := float64(d.A) / 0xff
+				a := sa + da*(1-sa)
+				blend := func(sc, dc uint8) uint8 {
+					return uint8(math.Round((float64(sc)*sa + float64(dc)*da*(1-sa)) / a))
+				}
+				dst.SetNRGBA(x, y, color.NRGBA{
+					R: blend(s.R, d.R),
+					G: blend(s.G, d.G),
+					B: blend(s.B, d.B),
+					A: uint8(math.Round(a * 0xff)),
+				})
+			}
+		}
+	}
+	return dst
+}
+
+// tagFrameIndex returns the frame index in the tag's animation after the elapsed time.
+func tagFrameIndex(durations []time.Duration, tag *Tag, elapsed time.Duration) int {
+	var seq []int
+	switch tag.Direction {
+	case LoopDirectionReverse:
+		for i := tag.To; i >= tag.From; i-- {
+			seq = append(seq, i)
+		}
+	case LoopDirectionPingPong:
+		for i := tag.From; i <= tag.To; i++ {
+			seq = append(seq, i)
+		}
+		for i := tag.To - 1; i > tag.From; i-- {
+			seq = append(seq, i)
+		}
+	case LoopDirectionPingPongReverse:
+		for i := tag.To; i >= tag.From; i-- {
+			seq = append(seq, i)
+		}
+		for i := tag.From + 1; i < tag.To; i++ {
+			seq = append(seq, i)
+		}
+	default:
+		for i := tag.From; i <= tag.To; i++ {
+			seq = append(seq, i)
+		}
+	}
+	if len(seq) == 0 {
+		return 0
+	}
+
+	var cycle time.Duration
+	for _, i := range seq {
+		cycle += durations[i]
+	}
+	if cycle <= 0 {
+		return seq[0]
+	}
+	if tag.Repeat > 0 && elapsed >= cycle*time.Duration(tag.Repeat) {
+		return seq[len(seq)-1]
+	}
+
+	t := max(elapsed, 0) % cycle
+	for _, i := range seq {
+		if t < durations[i] {
+			return i
+		}
+		t -= durations[i]
+	}
+	return seq[len(seq)-1]
+}
@@ -0,0 +1,186 @@
+// Copyright 2026 The Ebitengine Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package aseprite
+
+import (
+	"bytes"
+	"compress/zlib"
+	"encoding/binary"
+	"image"
+	"image/color"
+	"testing"
+	"time"
+)
+
+// fileBuilder builds an Aseprite file for testing.
+type fileBuilder struct {
+	frames [][]byte
+}
+
+func le(vs ...any) []byte {
+	var buf bytes.Buffer
+	for _, v := range vs {
+		if s, ok := v.(string); ok {
+			_ = binary.Write(&buf, binary.LittleEndian, uint16(len(s)))
+			buf.WriteString(s)
+			continue
+		}
+		_ = binary.Write(&buf, binary.LittleEndian, v)
+	}
+	return buf.Bytes()
+}
+
+func chunk(typ uint16, data []byte) []byte {
+	return append(le(uint32(6+len(data)), typ), data...)
+}
+
+func (b *fileBuilder) addFrame(duration uint16, chunks ...[]byte) {
+	var data []byte
+	for _, c := range chunks {
+		data = append(data, c...)
+	}
+	header := le(uint32(16+len(data)), uint16(frameMagic), uint16(len(chunks)), duration, uint16(0), uint32(0))
+	b.frames = append(b.frames, append(header, data...))
+}
+
+func (b *fileBuilder) bytes(width, height int) []byte {
+	header := le(uint32(0), uint16(fileMagic), uint16(len(b.frames)), uint16(width), uint16(height), uint16(32),
+		uint32(headerFlagLayerOpacityValid), uint16(0), uint32(0), uint32(0), uint8(0), [3]byte{}, uint16(0), [1 + 1 + 2 + 2 + 2 + 2 + 84]byte{})
+	data := header
+	for _, f := range b.frames {
+		data = append(data, f...)
+	}
+	return data
+}
+
+func layerChunk(name string, flags uint16, layerType uint16, childLevel uint16, opacity uint8) []byte {
+	return chunk(chunkLayer, le(flags, layerType, childLevel, uint16(0), uint16(0), uint16(0), opacity, [3]byte{}, name))
+}
+
+func celChunk(layer uint16, x, y int16, w, h uint16, pix []byte) []byte {
+	var buf bytes.Buffer
+	zw := zlib.NewWriter(&buf)
+	_, _ = zw.Write(pix)
+	_ = zw.Close()
+	return chunk(chunkCel, append(le(layer, x, y, uint8(0xff), uint16(celTypeCompressed), int16(0), [5]byte{}, w, h), buf.Bytes()...))
+}
+
+func linkedCelChunk(layer uint16, frame uint16) []byte {
+	return chunk(chunkCel, le(layer, int16(0), int16(0), uint8(0xff), uint16(celTypeLinked), int16(0), [5]byte{}, frame))
+}
+
+func solid(w, h int, clr color.NRGBA) []byte {
+	var pix []byte
+	for i := 0; i < w*h; i++ {
+		pix = append(pix, clr.R, clr.G, clr.B, clr.A)
+	}
+	return pix
+}
+
+func TestParse(t *testing.T) {
+	red := color.NRGBA{R: 0xff, A: 0xff}
+	blue := color.NRGBA{B: 0xff, A: 0xff}
+
+	var b fileBuilder
+	b.addFrame(100,
+		layerChunk("bg", layerFlagVisible, 0, 0, 0xff),
+		layerChunk("group", 0, layerTypeGroup, 0, 0xff),
+		layerChunk("hidden", layerFlagVisible, 0, 1, 0xff),
+		layerChunk("fg", layerFlagVisible, 0, 0, 0xff),
+		celChunk(3, 1, 1, 1, 1, solid(1, 1, blue)),
+		celChunk(0, 0, 0, 4, 4, solid(4, 4, red)),
+		celChunk(2, 0, 0, 4, 4, solid(4, 4, blue)),
+		chunk(chunkTags, le(uint16(1), [8]byte{}, uint16(0), uint16(1), uint8(LoopDirectionPingPong), uint16(0), [10]byte{}, "walk")),
+		chunk(chunkSlice, le(uint32(1), uint32(sliceFlagPivot), uint32(0), "hitbox", uint32(0), int32(1), int32(2), uint32(3), uint32(4), int32(1), int32(1))),
+	)
+	b.addFrame(200, linkedCelChunk(0, 0))
+
+	f, err := parse(b.bytes(4, 4))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(f.layers) != 4 || f.layers[1].Name != "group" || !f.layers[1].Group || f.layers[2].ChildLevel != 1 {
+		t.Errorf("layers: got: %+v", f.layers)
+	}
+	if len(f.frames) != 2 || f.frames[1].duration != 200*time.Millisecond {
+		t.Fatalf("frames: got: %+v", f.frames)
+	}
+
+	// The cels are ordered by the layers.
+	if got := len(f.frames[0].cels); got != 3 {
+		t.Fatalf("len(cels): got: %d, want: 3", got)
+	}
+	for i, want := range []int{0, 2, 3} {
+		if got := f.frames[0].cels[i].layer; got != want {
+			t.Errorf("cels[%d].layer: got: %d, want: %d", i, got, want)
+		}
+	}
+	if got, want := f.frames[1].cels[0].image, f.frames[0].cels[0].image; got != want {
+		t.Errorf("the linked cel must share the image")
+	}
+
+	// The layer in the invisible group is not rendered.
+	img := f.composeFrame(&f.frames[0])
+	for _, tc := range []struct {
+		X, Y int
+		Want color.NRGBA
+	}{
+		{X: 0, Y: 0, Want: red},
+		{X: 1, Y: 1, Want: blue},
+		{X: 2, Y: 2, Want: red},
+	} {
+		if got := img.NRGBAAt(tc.X, tc.Y); got != tc.Want {
+			t.Errorf("composeFrame().At(%d, %d): got: %v, want: %v", tc.X, tc.Y, got, tc.Want)
+		}
+	}
+
+	if len(f.tags) != 1 || f.tags[0] != (Tag{Name: "walk", From: 0, To: 1, Direction: LoopDirectionPingPong}) {
+		t.Errorf("tags: got: %+v", f.tags)
+	}
+
+	if len(f.slices) != 1 {
+		t.Fatalf("len(slices): got: %d, want: 1", len(f.slices))
+	}
+	k, ok := f.slices[0].KeyAt(1)
+	if !ok || k.Bounds != image.Rect(1, 2, 4, 6) || !k.HasPivot || k.Pivot != image.Pt(1, 1) {
+		t.Errorf("KeyAt(1): got: (%+v, %t)", k, ok)
+	}
+}
+
+func TestTagFrameIndex(t *testing.T) {
+	ms := time.Millisecond
+	durations := []time.Duration{100 * ms, 100 * ms, 100 * ms, 100 * ms}
+	testCases := []struct {
+		Tag     Tag
+		Elapsed time.Duration
+		Want    int
+	}{
+		{Tag: Tag{From: 1, To: 3}, Elapsed: 0, Want: 1},
+		{Tag: Tag{From: 1, To: 3}, Elapsed: 250 * ms, Want: 3},
+		{Tag: Tag{From: 1, To: 3}, Elapsed: 300 * ms, Want: 1},
+		{Tag: Tag{From: 1, To: 3, Direction: LoopDirectionReverse}, Elapsed: 0, Want: 3},
+		{Tag: Tag{From: 0, To: 2, Direction: LoopDirectionPingPong}, Elapsed: 350 * ms, Want: 1},
+		{Tag: Tag{From: 0, To: 2, Direction: LoopDirectionPingPong}, Elapsed: 400 * ms, Want: 0},
+		{Tag: Tag{From: 0, To: 2, Direction: LoopDirectionPingPongReverse}, Elapsed: 0, Want: 2},
+		{Tag: Tag{From: 0, To: 2, Direction: LoopDirectionPingPongReverse}, Elapsed: 300 * ms, Want: 1},
+		{Tag: Tag{From: 0, To: 1, Repeat: 2}, Elapsed: 1000 * ms, Want: 1},
+	}
+	for _, tc := range testCases {
+		if got := tagFrameIndex(durations, &tc.Tag, tc.Elapsed); got != tc.Want {
+			t.Errorf("tagFrameIndex(%+v, %v): got: %d, want: %d", tc.Tag, tc.Elapsed, got, tc.Want)
+		}
+	}
+}
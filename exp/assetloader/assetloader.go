@@ -0,0 +1,236 @@
+// Copyright 2026 The Ebitengine Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package assetloader provides a loader of images from a file system with hot-reloading for development builds.
+//
+// This package is experimental and the API might be changed in the future.
+//
+// A Loader remembers the source path of each image it loads.
+// In the development mode, the Loader watches the source files, and re-decodes and re-uploads an image
+// when its file changes on disk.
+//
+// Image decoders must be imported when using a Loader. For example,
+// if you want to load a PNG image, you'd need to add `_ "image/png"` to the import section.
+package assetloader
+
+import (
+	"image"
+	"io/fs"
+	"time"
+
+	"github.com/duplicants-ai/ebiten"
+)
+
+// LoaderOptions represents options for NewLoader.
+type LoaderOptions struct {
+	// DevelopmentMode specifies whether the loader watches the source files at Update.
+	//
+	// The development mode is for iterating art during development.
+	// In production, embed the assets with go:embed and disable the development mode.
+	DevelopmentMode bool
+
+	// PollInterval is the minimum interval to check the source files in the development mode.
+	// If PollInterval is 0, the files are checked at every Update.
+	PollInterval time.Duration
+}
+
+// ReloadEvent represents a result of reloading an image.
+type ReloadEvent struct {
+	// Path is the source path of the image.
+	Path string
+
+	// Image is the current image for the path.
+	Image *ebiten.Image
+
+	// Stale is the previous image for the path if the image was replaced with a new image.
+	// An image is replaced when the size of the source image changes, since the size of an existing image cannot be changed.
+	// The previous image is not deallocated, but still has the old content.
+	// Stale is nil if the image was updated in place.
+	Stale *ebiten.Image
+
+	// Err is the error at reloading the image, e.g. a decoding error of a partially written file.
+	// If Err is not nil, the image is not changed.
+	Err error
+}
+
+type asset struct {
+	path    string
+	image   *ebiten.Image
+	modTime time.Time
+}
+
+// Loader loads images from a file system.
+//
+// Loader is not concurrent-safe.
+type Loader struct {
+	fsys    fs.FS
+	options LoaderOptions
+
+	assets      map[string]*asset
+	imageToPath map[*ebiten.Image]string
+	stale       map[*ebiten.Image]string
+
+	lastPollTime time.Time
+}
+
+// NewLoader creates a new Loader with the file system.
+//
+// For hot-reloading, use a file system reading the actual files on disk, like os.DirFS.
+// options can be nil.
+func NewLoader(fsys fs.FS, options *LoaderOptions) *Loader {
+	l := &Loader{
+		fsys:        fsys,
+		assets:      map[string]*asset{},
+		imageToPath: map[*ebiten.Image]string{},
+		stale:       map[*ebiten.Image]string{},
+	}
+	if options != nil {
+		l.options = *options
+	}
+	return l
+}
+
+// Image returns the image for the path.
+//
+// The image is loaded at the first call for the path, and the same image is returned after that.
+func (l *Loader) Image(path string) (*ebiten.Image, error) {
+	if a, ok := l.assets[path]; ok {
+		return a.image, nil
+	}
+
+	img, modTime, err := l.decode(path)
+	if err != nil {
+		return nil, err
+	}
+	a := &asset{
+		path:    path,
+		image:   ebiten.NewImageFromImage(img),
+		modTime: modTime,
+	}
+	l.assets[path] = a
+	l.imageToPath[a.image] = path
+	return a.image, nil
+}
+
+// SourcePath returns the source path of the image loaded by the loader.
+// SourcePath also works for a stale image.
+func (l *Loader) SourcePath(img *ebiten.Image) (string, bool) {
+	if p, ok := l.imageToPath[img]; ok {
+		return p, true
+	}
+	if p, ok := l.stale[img]; ok {
+		return p, true
+	}
+	return "", false
+}
+
+// IsStale reports whether the image was replaced with a new image by reloading.
+// A stale image should not be used any longer. Get the new image by Image with the same path.
+func (l *Loader) IsStale(img *ebiten.Image) bool {
+	_, ok := l.stale[img]
+	return ok
+}
+
+// StaleImages returns the source paths of the stale images.
+// This is useful to find stale references in the game.
+func (l *Loader) StaleImages() map[*ebiten.Image]string {
+	m := make(map[*ebiten.Image]string, len(l.stale))
+	for img, p := range l.stale {
+		m[img] = p
+	}
+	return m
+}
+
+// Update checks the source files and reloads the images whose files have changed, in the development mode.
+// Update should be called in the game's Update.
+//
+// Update returns the results of reloading. In the production mode, Update does nothing and returns nil.
+func (l *Loader) Update() []ReloadEvent {
+	if !l.options.DevelopmentMode {
+		return nil
+	}
+
+	now := time.Now()
+	if now.Sub(l.lastPollTime) < l.options.PollInterval {
+		return nil
+	}
+	l.lastPollTime = now
+
+	var events []ReloadEvent
+	for _, a := range l.assets {
+		info, err := fs.Stat(l.fsys, a.path)
+		if err != nil {
+			// The file might be being saved. Try again at the next Update.
+			continue
+		}
+		if info.ModTime().Equal(a.modTime) {
+			continue
+		}
+		// Update the modification time even if reloading fails, so that the error is reported once per change.
+		a.modTime = info.ModTime()
+		events = append(events, l.reload(a))
+	}
+	return events
+}
+
+func (l *Loader) reload(a *asset) ReloadEvent {
+	img, _, err := l.decode(a.path)
+	if err != nil {
+		return ReloadEvent{
+			Path:  a.path,
+			Image: a.image,
+			Err:   err,
+		}
+	}
+
+	if img.Bounds().Size() == a.image.Bounds().Size() {
+		a.image.WritePixelsFromImage(img, a.image.Bounds())
+		return ReloadEvent{
+			Path:  a.path,
+			Image: a.image,
+		}
+	}
+
+	old := a.image
+	delete(l.imageToPath, old)
+	l.stale[old] = a.path
+
+	a.image = ebiten.NewImageFromImage(img)
+	l.imageToPath[a.image] = a.path
+	return ReloadEvent{
+		Path:  a.path,
+		Image: a.image,
+		Stale: old,
+	}
+}
+
+func (l *Loader) decode(path string) (image.Image, time.Time, error) {
+	f, err := l.fsys.Open(path)
+	if err != nil {
+		return nil, time.Time{}, err
+	}
+	defer func() {
+		_ = f.Close()
+	}()
+
+	info, err := f.Stat()
+	if err != nil {
+		return nil, time.Time{}, err
+	}
+	img, _, err := image.Decode(f)
+	if err != nil {
+		return nil, time.Time{}, err
+	}
+	return img, info.ModTime(), nil
+}
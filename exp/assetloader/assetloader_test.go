@@ -0,0 +1,111 @@
+// Copyright 2026 The Ebitengine Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package assetloader_test
+
+import (
+	"bytes"
+	"image"
+	"image/color"
+	"image/png"
+	"testing"
+	"testing/fstest"
+	"time"
+
+	"github.com/duplicants-ai/ebiten/exp/assetloader"
+	etesting "github.com/duplicants-ai/ebiten/internal/testing"
+	"github.com/duplicants-ai/ebiten/internal/ui"
+)
+
+func TestMain(m *testing.M) {
+	ui.SetPanicOnErrorOnReadingPixelsForTesting(true)
+	etesting.MainWithRunLoop(m)
+}
+
+func pngFile(t *testing.T, width, height int, clr color.Color, modTime time.Time) *fstest.MapFile {
+	img := image.NewRGBA(image.Rect(0, 0, width, height))
+	for j := 0; j < height; j++ {
+		for i := 0; i < width; i++ {
+			img.Set(i, j, clr)
+		}
+	}
+	var buf bytes.Buffer
+	if err := png.Encode(&buf, img); err != nil {
+		t.Fatal(err)
+	}
+	return &fstest.MapFile{
+		Data:    buf.Bytes(),
+		ModTime: modTime,
+	}
+}
+
+func TestReload(t *testing.T) {
+	red := color.RGBA{R: 0xff, A: 0xff}
+	blue := color.RGBA{B: 0xff, A: 0xff}
+	t0 := time.Unix(0, 0)
+
+	fsys := fstest.MapFS{
+		"a.png": pngFile(t, 2, 2, red, t0),
+	}
+	l := assetloader.NewLoader(fsys, &assetloader.LoaderOptions{
+		DevelopmentMode: true,
+	})
+	img, err := l.Image("a.png")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if p, ok := l.SourcePath(img); !ok || p != "a.png" {
+		t.Errorf("SourcePath(): got: (%q, %t), want: (%q, true)", p, ok, "a.png")
+	}
+	if got := l.Update(); len(got) != 0 {
+		t.Errorf("len(Update()): got: %d, want: 0", len(got))
+	}
+
+	// The same size: the image is updated in place.
+	fsys["a.png"] = pngFile(t, 2, 2, blue, t0.Add(time.Second))
+	events := l.Update()
+	if len(events) != 1 || events[0].Image != img || events[0].Stale != nil || events[0].Err != nil {
+		t.Fatalf("Update(): got: %v", events)
+	}
+	if got := img.At(0, 0); got != blue {
+		t.Errorf("img.At(0, 0): got: %v, want: %v", got, blue)
+	}
+
+	// A different size: the image is replaced and the old image becomes stale.
+	fsys["a.png"] = pngFile(t, 4, 4, red, t0.Add(2*time.Second))
+	events = l.Update()
+	if len(events) != 1 || events[0].Stale != img {
+		t.Fatalf("Update(): got: %v", events)
+	}
+	if !l.IsStale(img) {
+		t.Errorf("IsStale(): got: false, want: true")
+	}
+	img2, err := l.Image("a.png")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if img2 == img || img2.Bounds().Dx() != 4 {
+		t.Errorf("Image(): the new image must have the new size")
+	}
+	if got := img2.At(0, 0); got != red {
+		t.Errorf("img2.At(0, 0): got: %v, want: %v", got, red)
+	}
+
+	// A broken file: the image is not changed.
+	fsys["a.png"] = &fstest.MapFile{Data: []byte("broken"), ModTime: t0.Add(3 * time.Second)}
+	events = l.Update()
+	if len(events) != 1 || events[0].Err == nil || events[0].Image != img2 {
+		t.Fatalf("Update(): got: %v", events)
+	}
+}
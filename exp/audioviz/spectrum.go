@@ -0,0 +1,166 @@
+// Copyright 2026 The Ebitengine Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package audioviz
+
+import (
+	"fmt"
+	"math"
+	"math/bits"
+	"time"
+)
+
+// Analyzer computes spectrums with the windowed FFT.
+//
+// Analyzer is not concurrent-safe.
+type Analyzer struct {
+	// Smoothing is the ratio in [0, 1) of the previous magnitudes mixed into the new magnitudes.
+	// A larger value makes the spectrum change more smoothly.
+	//
+	// The default (zero) value means no smoothing.
+	Smoothing float64
+
+	size     int
+	window   []float64
+	scale    float64
+	samples  []float32
+	re       []float64
+	im       []float64
+	smoothed []float64
+}
+
+// NewAnalyzer creates a new Analyzer with the FFT size.
+//
+// NewAnalyzer panics if size is not a power of two or less than 2.
+func NewAnalyzer(size int) *Analyzer {
+	if size < 2 || size&(size-1) != 0 {
+		panic(fmt.Sprintf("audioviz: size must be a power of two and 2 or more but was %d", size))
+	}
+
+	// Use the Hann window.
+	window := make([]float64, size)
+	var sum float64
+	for i := range window {
+		window[i] = 0.5 - 0.5*math.Cos(2*math.Pi*float64(i)/float64(size))
+		sum += window[i]
+	}
+
+	return &Analyzer{
+		size:     size,
+		window:   window,
+		scale:    2 / sum,
+		re:       make([]float64, size),
+		im:       make([]float64, size),
+		smoothed: make([]float64, size/2),
+	}
+}
+
+// Size returns the FFT size.
+func (a *Analyzer) Size() int {
+	return a.size
+}
+
+// AppendSpectrum appends the magnitudes of the frequency bins to dst, and returns the extended buffer.
+// The samples are the mono samples of the tap for the FFT size ending at position.
+//
+// The number of the bins is the half of the FFT size.
+// The i-th bin is for the frequency i * sampleRate / size.
+// The magnitudes are normalized so that a full-scale sine wave has a magnitude of about 1.
+func (a *Analyzer) AppendSpectrum(dst []float32, tap *Tap, position time.Duration) []float32 {
+	a.samples = tap.AppendWaveform(a.samples[:0], position, a.size)
+	return a.AppendSpectrumFromSamples(dst, a.samples)
+}
+
+// AppendSpectrumFromSamples is like AppendSpectrum, but uses the given mono samples instead of a Tap.
+//
+// If len(samples) is less than the FFT size, the missing samples are treated as zeros.
+// If len(samples) is more than the FFT size, only the last samples are used.
+func (a *Analyzer) AppendSpectrumFromSamples(dst []float32, samples []float32) []float32 {
+	if len(samples) > a.size {
+		samples = samples[len(samples)-a.size:]
+	}
+	offset := a.size - len(samples)
+	for i := range a.re {
+		var s float64
+		if i >= offset {
+			s = float64(samples[i-offset])
+		}
+		a.re[i] = s * a.window[i]
+		a.im[i] = 0
+	}
+	fft(a.re, a.im)
+
+	for i := range a.smoothed {
+		m := math.Hypot(a.re[i], a.im[i]) * a.scale
+		a.smoothed[i] = a.Smoothing*a.smoothed[i] + (1-a.Smoothing)*m
+		dst = append(dst, float32(a.smoothed[i]))
+	}
+	return dst
+}
+
+// AppendBands groups the bins of a spectrum into count bands with logarithmic frequency ranges from minFreq to maxFreq,
+// appends the maximum magnitude of each band to dst, and returns the extended buffer.
+//
+// spectrum is a result of AppendSpectrum with the sample rate sampleRate.
+// AppendBands is useful to draw bars.
+func AppendBands(dst []float32, spectrum []float32, sampleRate int, count int, minFreq, maxFreq float64) []float32 {
+	if count <= 0 || len(spectrum) == 0 {
+		return dst
+	}
+	binWidth := float64(sampleRate) / float64(2*len(spectrum))
+	minFreq = max(minFreq, binWidth)
+	maxFreq = max(maxFreq, minFreq)
+
+	for i := 0; i < count; i++ {
+		f0 := minFreq * math.Pow(maxFreq/minFreq, float64(i)/float64(count))
+		f1 := minFreq * math.Pow(maxFreq/minFreq, float64(i+1)/float64(count))
+		b0 := int(math.Round(f0 / binWidth))
+		b1 := max(int(math.Round(f1/binWidth)), b0+1)
+		var v float32
+		for b := b0; b < b1 && b < len(spectrum); b++ {
+			v = max(v, spectrum[b])
+		}
+		dst = append(dst, v)
+	}
+	return dst
+}
+
+// fft computes the discrete Fourier transform in place with the iterative radix-2 Cooley-Tukey algorithm.
+// len(re) and len(im) must be the same power of two.
+func fft(re, im []float64) {
+	n := len(re)
+	shift := bits.UintSize - bits.TrailingZeros(uint(n))
+	for i := 0; i < n; i++ {
+		j := int(bits.Reverse(uint(i)) >> shift)
+		if i < j {
+			re[i], re[j] = re[j], re[i]
+			im[i], im[j] = im[j], im[i]
+		}
+	}
+
+	for size := 2; size <= n; size <<= 1 {
+		half := size / 2
+		theta := -2 * math.Pi / float64(size)
+		for start := 0; start < n; start += size {
+			for k := 0; k < half; k++ {
+				wr, wi := math.Cos(theta*float64(k)), math.Sin(theta*float64(k))
+				i, j := start+k, start+k+half
+				tr := wr*re[j] - wi*im[j]
+				ti := wr*im[j] + wi*re[j]
+				re[j], im[j] = re[i]-tr, im[i]-ti
+				re[i], im[i] = re[i]+tr, im[i]+ti
+			}
+		}
+	}
+}
@@ -0,0 +1,89 @@
+// Copyright 2026 The Ebitengine Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package audioviz_test
+
+import (
+	"math"
+	"testing"
+
+	"github.com/duplicants-ai/ebiten/exp/audioviz"
+)
+
+func TestAnalyzerSine(t *testing.T) {
+	const (
+		size       = 256
+		sampleRate = 256 * 100
+		freq       = 32 * 100
+	)
+
+	samples := make([]float32, size)
+	for i := range samples {
+		samples[i] = float32(math.Sin(2 * math.Pi * freq * float64(i) / sampleRate))
+	}
+
+	a := audioviz.NewAnalyzer(size)
+	spectrum := a.AppendSpectrumFromSamples(nil, samples)
+	if got, want := len(spectrum), size/2; got != want {
+		t.Fatalf("len(spectrum): got: %d, want: %d", got, want)
+	}
+
+	peak := 0
+	for i, v := range spectrum {
+		if v > spectrum[peak] {
+			peak = i
+		}
+	}
+	if got, want := peak, 32; got != want {
+		t.Errorf("the peak bin: got: %d, want: %d", got, want)
+	}
+	if got := spectrum[peak]; math.Abs(float64(got)-1) > 0.01 {
+		t.Errorf("the peak magnitude: got: %v, want: 1", got)
+	}
+	if got := spectrum[64]; got > 1e-6 {
+		t.Errorf("the magnitude of an unrelated bin: got: %v, want: 0", got)
+	}
+
+	bands := audioviz.AppendBands(nil, spectrum, sampleRate, 4, 400, 12800)
+	want := []float32{0, 0, spectrum[peak], 0}
+	for i := range bands {
+		if math.Abs(float64(bands[i]-want[i])) > 0.01 {
+			t.Errorf("bands[%d]: got: %v, want: %v", i, bands[i], want[i])
+		}
+	}
+}
+
+func TestAnalyzerSmoothing(t *testing.T) {
+	a := audioviz.NewAnalyzer(64)
+	a.Smoothing = 0.5
+
+	samples := make([]float32, 64)
+	for i := range samples {
+		samples[i] = 1
+	}
+	s0 := a.AppendSpectrumFromSamples(nil, samples)
+	s1 := a.AppendSpectrumFromSamples(nil, make([]float32, 64))
+	if got, want := s1[0], s0[0]/2; math.Abs(float64(got-want)) > 1e-6 {
+		t.Errorf("the smoothed magnitude: got: %v, want: %v", got, want)
+	}
+}
+
+func TestNewAnalyzerPanics(t *testing.T) {
+	defer func() {
+		if recover() == nil {
+			t.Errorf("NewAnalyzer must panic for a size that is not a power of two")
+		}
+	}()
+	audioviz.NewAnalyzer(100)
+}
@@ -0,0 +1,267 @@
+// Copyright 2026 The Ebitengine Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package audioviz provides helpers to visualize audio, like waveforms, levels, and spectrums.
+//
+// This package is experimental and the API might be changed in the future.
+//
+// A Tap wraps a stream passed to a player, and records the samples the player reads on the audio goroutine.
+// On the game goroutine, the recorded samples are queried at the player's position,
+// so that the visualization matches the sound being heard rather than the samples buffered ahead.
+//
+//	tap := audioviz.NewTap(stream, audioviz.FormatFloat32, sampleRate)
+//	player, err := audioContext.NewPlayerF32(tap)
+//
+//	// In Update or Draw:
+//	spectrum = analyzer.AppendSpectrum(spectrum[:0], tap, player.Position())
+package audioviz
+
+import (
+	"encoding/binary"
+	"errors"
+	"io"
+	"math"
+	"sync"
+	"time"
+)
+
+// Format is the format of a stream.
+type Format int
+
+const (
+	// FormatInt16 is 16bit little-endian signed integer stereo, which is used by audio.Context.NewPlayer.
+	FormatInt16 Format = iota
+
+	// FormatFloat32 is 32bit little-endian float stereo, which is used by audio.Context.NewPlayerF32.
+	FormatFloat32
+)
+
+func (f Format) frameSize() int {
+	switch f {
+	case FormatInt16:
+		return 4
+	case FormatFloat32:
+		return 8
+	default:
+		panic("audioviz: invalid format")
+	}
+}
+
+// tapDuration is the duration of the samples kept in a Tap.
+// This must be longer than the buffer size of the player and the analysis window.
+const tapDuration = 2 * time.Second
+
+// Tap is a stream that records the samples read from the source stream.
+//
+// Read and Seek are called on the audio goroutine, and the other methods are called on the game goroutine.
+// Tap is concurrent-safe.
+type Tap struct {
+	src        io.Reader
+	format     Format
+	sampleRate int
+
+	// ring is the recorded samples, interleaved stereo.
+	ring []float32
+
+	// start is the first frame index that is recorded after the last seek.
+	start int64
+
+	// end is the frame index next to the last recorded frame.
+	end int64
+
+	// partial is the bytes of an incomplete frame.
+	partial []byte
+
+	m sync.Mutex
+}
+
+// NewTap creates a new Tap that reads from src.
+//
+// The returned Tap should be passed to a player instead of src.
+// If src is an io.Seeker, the Tap is also an io.Seeker.
+func NewTap(src io.Reader, format Format, sampleRate int) *Tap {
+	// Validate the format.
+	format.frameSize()
+	if sampleRate <= 0 {
+		panic("audioviz: sampleRate must be positive")
+	}
+	return &Tap{
+		src:        src,
+		format:     format,
+		sampleRate: sampleRate,
+		ring:       make([]float32, 2*int(int64(sampleRate)*int64(tapDuration)/int64(time.Second))),
+	}
+}
+
+// SampleRate returns the sample rate of the Tap.
+func (t *Tap) SampleRate() int {
+	return t.sampleRate
+}
+
+// Read implements io.Reader.
+func (t *Tap) Read(buf []byte) (int, error) {
+	n, err := t.src.Read(buf)
+	if n > 0 {
+		t.record(buf[:n])
+	}
+	return n, err
+}
+
+// Seek implements io.Seeker.
+//
+// Seek returns an error if the source stream is not an io.Seeker.
+func (t *Tap) Seek(offset int64, whence int) (int64, error) {
+	s, ok := t.src.(io.Seeker)
+	if !ok {
+		return 0, errors.New("audioviz: the source stream is not an io.Seeker")
+	}
+	pos, err := s.Seek(offset, whence)
+	if err != nil {
+		return pos, err
+	}
+
+	t.m.Lock()
+	defer t.m.Unlock()
+	t.start = pos / int64(t.format.frameSize())
+	t.end = t.start
+	t.partial = t.partial[:0]
+	return pos, nil
+}
+
+func (t *Tap) record(buf []byte) {
+	t.m.Lock()
+	defer t.m.Unlock()
+
+	size := t.format.frameSize()
+	if len(t.partial) > 0 {
+		n := min(size-len(t.partial), len(buf))
+		t.partial = append(t.partial, buf[:n]...)
+		buf = buf[n:]
+		if len(t.partial) < size {
+			return
+		}
+		t.recordFrame(t.partial)
+		t.partial = t.partial[:0]
+	}
+	for len(buf) >= size {
+		t.recordFrame(buf[:size])
+		buf = buf[size:]
+	}
+	t.partial = append(t.partial, buf...)
+}
+
+func (t *Tap) recordFrame(frame []byte) {
+	var l, r float32
+	switch t.format {
+	case FormatInt16:
+		l = float32(int16(binary.LittleEndian.Uint16(frame[0:2]))) / (1 << 15)
+		r = float32(int16(binary.LittleEndian.Uint16(frame[2:4]))) / (1 << 15)
+	case FormatFloat32:
+		l = math.Float32frombits(binary.LittleEndian.Uint32(frame[0:4]))
+		r = math.Float32frombits(binary.LittleEndian.Uint32(frame[4:8]))
+	}
+	i := 2 * int(t.end%int64(len(t.ring)/2))
+	t.ring[i] = l
+	t.ring[i+1] = r
+	t.end++
+}
+
+// frameAt returns the frame at the index, or zeros if the frame is not recorded.
+// frameAt must be called with t.m locked.
+func (t *Tap) frameAt(index int64) (float32, float32, bool) {
+	if index < t.start || index >= t.end || index < t.end-int64(len(t.ring)/2) {
+		return 0, 0, false
+	}
+	i := 2 * int(index%int64(len(t.ring)/2))
+	return t.ring[i], t.ring[i+1], true
+}
+
+func (t *Tap) frameIndex(position time.Duration) int64 {
+	return int64(position) * int64(t.sampleRate) / int64(time.Second)
+}
+
+// Position returns the position of the last recorded sample.
+//
+// The position is ahead of the sound being heard by the buffered samples.
+// Use the player's position instead if possible.
+func (t *Tap) Position() time.Duration {
+	t.m.Lock()
+	defer t.m.Unlock()
+	return time.Duration(t.end * int64(time.Second) / int64(t.sampleRate))
+}
+
+// AppendWaveform appends count mono samples in [-1, 1] ending at position to dst, and returns the extended buffer.
+// A mono sample is the average of the left and right channels.
+// The samples that are not recorded, e.g., before the start or after the end of the stream, are zeros.
+//
+// AppendWaveform is useful to draw an oscilloscope.
+func (t *Tap) AppendWaveform(dst []float32, position time.Duration, count int) []float32 {
+	t.m.Lock()
+	defer t.m.Unlock()
+
+	end := t.frameIndex(position)
+	for i := end - int64(count); i < end; i++ {
+		l, r, _ := t.frameAt(i)
+		dst = append(dst, (l+r)/2)
+	}
+	return dst
+}
+
+// Level represents a level of a channel.
+type Level struct {
+	// Peak is the maximum absolute value of the samples.
+	Peak float64
+
+	// RMS is the root mean square of the samples.
+	RMS float64
+}
+
+// PeakDB returns the peak level in dBFS.
+// If the peak is 0, PeakDB returns -Inf.
+func (l Level) PeakDB() float64 {
+	return 20 * math.Log10(l.Peak)
+}
+
+// RMSDB returns the RMS level in dBFS.
+// If the RMS is 0, RMSDB returns -Inf.
+func (l Level) RMSDB() float64 {
+	return 20 * math.Log10(l.RMS)
+}
+
+// Levels returns the levels of the left and right channels for the duration ending at position.
+//
+// Levels is useful to draw level meters.
+func (t *Tap) Levels(position time.Duration, duration time.Duration) (left, right Level) {
+	t.m.Lock()
+	defer t.m.Unlock()
+
+	end := t.frameIndex(position)
+	count := t.frameIndex(duration)
+	if count <= 0 {
+		return Level{}, Level{}
+	}
+
+	var sumL, sumR float64
+	for i := end - count; i < end; i++ {
+		l, r, _ := t.frameAt(i)
+		fl, fr := math.Abs(float64(l)), math.Abs(float64(r))
+		left.Peak = max(left.Peak, fl)
+		right.Peak = max(right.Peak, fr)
+		sumL += fl * fl
+		sumR += fr * fr
+	}
+	left.RMS = math.Sqrt(sumL / float64(count))
+	right.RMS = math.Sqrt(sumR / float64(count))
+	return left, right
+}
@@ -0,0 +1,132 @@
+// Copyright 2026 The Ebitengine Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package audioviz_test
+
+import (
+	"bytes"
+	"encoding/binary"
+	"io"
+	"math"
+	"testing"
+	"time"
+
+	"github.com/duplicants-ai/ebiten/exp/audioviz"
+)
+
+const testSampleRate = 1000
+
+// newFloat32Stream returns a stereo stream where the left channel is f(i) and the right channel is -f(i) for the i-th frame.
+func newFloat32Stream(frames int, f func(i int) float32) *bytes.Reader {
+	buf := make([]byte, 8*frames)
+	for i := 0; i < frames; i++ {
+		binary.LittleEndian.PutUint32(buf[8*i:], math.Float32bits(f(i)))
+		binary.LittleEndian.PutUint32(buf[8*i+4:], math.Float32bits(-f(i)))
+	}
+	return bytes.NewReader(buf)
+}
+
+// readInChunks reads all the data from r with odd-sized chunks to split frames.
+func readInChunks(t *testing.T, r io.Reader) {
+	buf := make([]byte, 13)
+	for {
+		_, err := r.Read(buf)
+		if err == io.EOF {
+			return
+		}
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+}
+
+func TestTapWaveform(t *testing.T) {
+	tap := audioviz.NewTap(newFloat32Stream(100, func(i int) float32 {
+		return float32(i) / 100
+	}), audioviz.FormatFloat32, testSampleRate)
+	readInChunks(t, tap)
+
+	if got, want := tap.Position(), 100*time.Millisecond; got != want {
+		t.Errorf("Position(): got: %v, want: %v", got, want)
+	}
+
+	// The left and right channels cancel each other in mono.
+	for _, v := range tap.AppendWaveform(nil, 50*time.Millisecond, 10) {
+		if v != 0 {
+			t.Errorf("AppendWaveform must return zeros for the canceled channels but got %v", v)
+		}
+	}
+
+	left, right := tap.Levels(50*time.Millisecond, 10*time.Millisecond)
+	// The frames 40 to 49 are used.
+	if got, want := left.Peak, 0.49; math.Abs(got-want) > 1e-6 {
+		t.Errorf("left.Peak: got: %v, want: %v", got, want)
+	}
+	if got, want := right.Peak, 0.49; math.Abs(got-want) > 1e-6 {
+		t.Errorf("right.Peak: got: %v, want: %v", got, want)
+	}
+	if left.RMS <= 0.40 || left.RMS >= 0.49 {
+		t.Errorf("left.RMS: got: %v, want: in (0.40, 0.49)", left.RMS)
+	}
+}
+
+func TestTapInt16(t *testing.T) {
+	buf := make([]byte, 4*10)
+	for i := 0; i < 10; i++ {
+		binary.LittleEndian.PutUint16(buf[4*i:], uint16(int16(1<<14)))
+		binary.LittleEndian.PutUint16(buf[4*i+2:], uint16(int16(1<<14)))
+	}
+	tap := audioviz.NewTap(bytes.NewReader(buf), audioviz.FormatInt16, testSampleRate)
+	readInChunks(t, tap)
+
+	got := tap.AppendWaveform(nil, 10*time.Millisecond, 12)
+	want := []float32{0, 0, 0.5, 0.5, 0.5, 0.5, 0.5, 0.5, 0.5, 0.5, 0.5, 0.5}
+	if len(got) != len(want) {
+		t.Fatalf("len(AppendWaveform()): got: %d, want: %d", len(got), len(want))
+	}
+	for i := range got {
+		if got[i] != want[i] {
+			t.Errorf("AppendWaveform()[%d]: got: %v, want: %v", i, got[i], want[i])
+		}
+	}
+}
+
+func TestTapSeek(t *testing.T) {
+	tap := audioviz.NewTap(newFloat32Stream(100, func(i int) float32 {
+		return 1
+	}), audioviz.FormatFloat32, testSampleRate)
+	readInChunks(t, tap)
+
+	if _, err := tap.Seek(8*50, io.SeekStart); err != nil {
+		t.Fatal(err)
+	}
+	if got, want := tap.Position(), 50*time.Millisecond; got != want {
+		t.Errorf("Position(): got: %v, want: %v", got, want)
+	}
+
+	// The samples before the seek are discarded.
+	left, _ := tap.Levels(50*time.Millisecond, 10*time.Millisecond)
+	if left.Peak != 0 {
+		t.Errorf("left.Peak: got: %v, want: 0", left.Peak)
+	}
+
+	buf := make([]byte, 8*10)
+	if _, err := io.ReadFull(tap, buf); err != nil {
+		t.Fatal(err)
+	}
+	left, _ = tap.Levels(60*time.Millisecond, 10*time.Millisecond)
+	if left.Peak != 1 {
+		t.Errorf("left.Peak: got: %v, want: 1", left.Peak)
+	}
+}
@@ -0,0 +1,231 @@
+// Copyright 2026 The Ebitengine Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package blendmode provides separable blend modes in image editors, like multiply, overlay, and darken, with shaders.
+//
+// This package is experimental and the API might be changed in the future.
+//
+// Some blend modes can be approximated with the Blend presets like ebiten.BlendMultiply,
+// but fixed-function blending cannot express modes like darken and overlay.
+// This package renders the modes exactly by copying the destination region as a backdrop and composing it in a shader.
+// This is slower than the Blend presets, so prefer the presets when they are enough.
+//
+// The results follow the formulas in the W3C Compositing and Blending specification: https://drafts.fxtf.org/compositing-2/.
+package blendmode
+
+import (
+	"fmt"
+	"image"
+	"math"
+	"sync"
+
+	"github.com/duplicants-ai/ebiten"
+)
+
+// Mode is a blend mode.
+type Mode int
+
+// The values must be synced with the shader.
+const (
+	// ModeMultiply multiplies the source and the backdrop colors.
+	ModeMultiply Mode = iota
+
+	// ModeScreen multiplies the complements of the source and the backdrop colors, and complements the result.
+	ModeScreen
+
+	// ModeOverlay multiplies or screens the colors depending on the backdrop color.
+	ModeOverlay
+
+	// ModeDarken selects the darker of the source and the backdrop colors.
+	ModeDarken
+
+	// ModeLighten selects the lighter of the source and the backdrop colors.
+	ModeLighten
+)
+
+//ebitengine:shadersource
+const shaderSrc = `//kage:unit pixels
+
+package main
+
+var Mode int
+var Linear int
+
+func sampleSrc(pos vec2) vec4 {
+	if Linear == 0 {
+		return imageSrc0At(pos)
+	}
+	p0 := pos - 1/2.0
+	p1 := pos + 1/2.0
+	c0 := imageSrc0At(p0)
+	c1 := imageSrc0At(vec2(p1.x, p0.y))
+	c2 := imageSrc0At(vec2(p0.x, p1.y))
+	c3 := imageSrc0At(p1)
+	rate := fract(p1)
+	return mix(mix(c0, c1, rate.x), mix(c2, c3, rate.x), rate.y)
+}
+
+func blend(s, b vec3) vec3 {
+	if Mode == 0 {
+		return s * b
+	}
+	if Mode == 1 {
+		return s + b - s*b
+	}
+	if Mode == 2 {
+		return mix(2*s*b, 1-2*(1-s)*(1-b), step(0.5, b))
+	}
+	if Mode == 3 {
+		return min(s, b)
+	}
+	return max(s, b)
+}
+
+func Fragment(dstPos vec4, srcPos vec2, color vec4, custom vec4) vec4 {
+	s := sampleSrc(srcPos) * color
+	// custom.xy is the position on the backdrop.
+	b := imageSrc1UnsafeAt(custom.xy + imageSrc0Origin())
+
+	// Un-premultiply alpha. When the alpha is 0, 1-sign(alpha) is 1.0, which means division does nothing.
+	sc := s.rgb / (s.a + (1 - sign(s.a)))
+	bc := b.rgb / (b.a + (1 - sign(b.a)))
+	rgb := s.rgb*(1-b.a) + b.rgb*(1-s.a) + blend(sc, bc)*s.a*b.a
+	return vec4(rgb, s.a+b.a*(1-s.a))
+}
+`
+
+var theShader = sync.OnceValue(func() *ebiten.Shader {
+	s, err := ebiten.NewShader([]byte(shaderSrc))
+	if err != nil {
+		panic(fmt.Sprintf("blendmode: NewShader failed: %v", err))
+	}
+	return s
+})
+
+// DrawImageOptions represents options for Drawer.DrawImage.
+type DrawImageOptions struct {
+	// GeoM is a geometry matrix to draw.
+	// The default (zero) value is identity, which draws the image at (0, 0).
+	GeoM ebiten.GeoM
+
+	// ColorScale is a scale of color.
+	// The default (zero) value is identity, which is (1, 1, 1, 1).
+	ColorScale ebiten.ColorScale
+
+	// Filter is a type of texture filter.
+	// FilterPixelated is treated as FilterLinear.
+	// The default (zero) value is FilterNearest.
+	Filter ebiten.Filter
+}
+
+// Drawer draws images with blend modes.
+//
+// The zero value is ready to use.
+// Drawer keeps a backdrop image to reuse it.
+//
+// Drawer is not concurrent-safe.
+type Drawer struct {
+	backdrop *ebiten.Image
+}
+
+// DrawImage draws src onto dst with the blend mode.
+//
+// DrawImage panics if mode is invalid.
+func (d *Drawer) DrawImage(dst, src *ebiten.Image, mode Mode, options *DrawImageOptions) {
+	if mode < ModeMultiply || mode > ModeLighten {
+		panic(fmt.Sprintf("blendmode: invalid mode: %d", mode))
+	}
+	if options == nil {
+		options = &DrawImageOptions{}
+	}
+
+	sb := src.Bounds()
+	var xs, ys [4]float64
+	for i, p := range [4]image.Point{sb.Min, {sb.Max.X, sb.Min.Y}, {sb.Min.X, sb.Max.Y}, sb.Max} {
+		// The source image is drawn at the origin regardless of its bounds.
+		xs[i], ys[i] = options.GeoM.Apply(float64(p.X-sb.Min.X), float64(p.Y-sb.Min.Y))
+	}
+	r := boundingRect(xs, ys).Intersect(dst.Bounds())
+	if r.Empty() {
+		return
+	}
+
+	// Copy the destination region to the backdrop, as a shader cannot read the destination.
+	if d.backdrop != nil {
+		if b := d.backdrop.Bounds(); b.Dx() < r.Dx() || b.Dy() < r.Dy() {
+			d.backdrop.Deallocate()
+			d.backdrop = nil
+		}
+	}
+	if d.backdrop == nil {
+		d.backdrop = ebiten.NewImageWithOptions(image.Rect(0, 0, r.Dx(), r.Dy()), &ebiten.NewImageOptions{
+			Unmanaged: true,
+		})
+	}
+	backdrop := d.backdrop.SubImage(image.Rect(0, 0, r.Dx(), r.Dy())).(*ebiten.Image)
+	op := &ebiten.DrawImageOptions{}
+	op.Blend = ebiten.BlendCopy
+	backdrop.DrawImage(dst.SubImage(r).(*ebiten.Image), op)
+
+	cr, cg, cb, ca := options.ColorScale.R(), options.ColorScale.G(), options.ColorScale.B(), options.ColorScale.A()
+	vs := make([]ebiten.Vertex, 4)
+	for i := range vs {
+		sx, sy := float32(sb.Min.X), float32(sb.Min.Y)
+		if i%2 == 1 {
+			sx = float32(sb.Max.X)
+		}
+		if i >= 2 {
+			sy = float32(sb.Max.Y)
+		}
+		vs[i] = ebiten.Vertex{
+			DstX:    float32(xs[i]),
+			DstY:    float32(ys[i]),
+			SrcX:    sx,
+			SrcY:    sy,
+			ColorR:  cr,
+			ColorG:  cg,
+			ColorB:  cb,
+			ColorA:  ca,
+			Custom0: float32(xs[i]) - float32(r.Min.X),
+			Custom1: float32(ys[i]) - float32(r.Min.Y),
+		}
+	}
+
+	var linear int
+	if options.Filter != ebiten.FilterNearest {
+		linear = 1
+	}
+	sop := &ebiten.DrawTrianglesShaderOptions{}
+	sop.Blend = ebiten.BlendCopy
+	sop.Uniforms = map[string]any{
+		"Mode":   int(mode),
+		"Linear": linear,
+	}
+	sop.Images[0] = src
+	sop.Images[1] = backdrop
+	dst.DrawTrianglesShader(vs, []uint16{0, 1, 2, 1, 2, 3}, theShader(), sop)
+}
+
+// boundingRect returns the smallest integer rectangle that contains all the points.
+func boundingRect(xs, ys [4]float64) image.Rectangle {
+	x0, y0 := math.Inf(1), math.Inf(1)
+	x1, y1 := math.Inf(-1), math.Inf(-1)
+	for i := range xs {
+		x0 = min(x0, xs[i])
+		y0 = min(y0, ys[i])
+		x1 = max(x1, xs[i])
+		y1 = max(y1, ys[i])
+	}
+	return image.Rect(int(math.Floor(x0)), int(math.Floor(y0)), int(math.Ceil(x1)), int(math.Ceil(y1)))
+}
@@ -0,0 +1,107 @@
+// Copyright 2026 The Ebitengine Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package blendmode_test
+
+import (
+	"image/color"
+	"testing"
+
+	"github.com/duplicants-ai/ebiten"
+	"github.com/duplicants-ai/ebiten/exp/blendmode"
+	etesting "github.com/duplicants-ai/ebiten/internal/testing"
+	"github.com/duplicants-ai/ebiten/internal/ui"
+)
+
+func TestMain(m *testing.M) {
+	ui.SetPanicOnErrorOnReadingPixelsForTesting(true)
+	etesting.MainWithRunLoop(m)
+}
+
+func TestDrawImage(t *testing.T) {
+	backdrop := color.RGBA{R: 0x40, G: 0xc0, B: 0x80, A: 0xff}
+	source := color.RGBA{R: 0x80, G: 0x80, B: 0xff, A: 0xff}
+
+	cases := []struct {
+		Mode blendmode.Mode
+		Want color.RGBA
+	}{
+		{
+			Mode: blendmode.ModeMultiply,
+			Want: color.RGBA{R: 0x20, G: 0x60, B: 0x80, A: 0xff},
+		},
+		{
+			Mode: blendmode.ModeScreen,
+			Want: color.RGBA{R: 0xa0, G: 0xe0, B: 0xff, A: 0xff},
+		},
+		{
+			Mode: blendmode.ModeOverlay,
+			Want: color.RGBA{R: 0x40, G: 0xc0, B: 0xff, A: 0xff},
+		},
+		{
+			Mode: blendmode.ModeDarken,
+			Want: color.RGBA{R: 0x40, G: 0x80, B: 0x80, A: 0xff},
+		},
+		{
+			Mode: blendmode.ModeLighten,
+			Want: color.RGBA{R: 0x80, G: 0xc0, B: 0xff, A: 0xff},
+		},
+	}
+
+	const w, h = 16, 16
+	src := ebiten.NewImage(w/2, h/2)
+	src.Fill(source)
+
+	var d blendmode.Drawer
+	for _, c := range cases {
+		dst := ebiten.NewImage(w, h)
+		dst.Fill(backdrop)
+		op := &blendmode.DrawImageOptions{}
+		op.GeoM.Translate(4, 4)
+		d.DrawImage(dst, src, c.Mode, op)
+
+		for j := 0; j < h; j++ {
+			for i := 0; i < w; i++ {
+				got := dst.At(i, j).(color.RGBA)
+				want := backdrop
+				if 4 <= i && i < 12 && 4 <= j && j < 12 {
+					want = c.Want
+				}
+				if !etesting.SameColors(got, want, 2) {
+					t.Errorf("mode: %d, dst.At(%d, %d): got: %v, want: %v", c.Mode, i, j, got, want)
+				}
+			}
+		}
+	}
+}
+
+func TestDrawImageTransparentSource(t *testing.T) {
+	backdrop := color.RGBA{R: 0x40, G: 0xc0, B: 0x80, A: 0xff}
+
+	const w, h = 16, 16
+	// A transparent source doesn't change the backdrop, even with the darken mode.
+	src := ebiten.NewImage(w, h)
+	dst := ebiten.NewImage(w, h)
+	dst.Fill(backdrop)
+
+	var d blendmode.Drawer
+	d.DrawImage(dst, src, blendmode.ModeDarken, nil)
+	for j := 0; j < h; j++ {
+		for i := 0; i < w; i++ {
+			if got := dst.At(i, j).(color.RGBA); got != backdrop {
+				t.Errorf("dst.At(%d, %d): got: %v, want: %v", i, j, got, backdrop)
+			}
+		}
+	}
+}
@@ -0,0 +1,248 @@
+// Copyright 2026 The Ebitengine Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package collision provides pixel-perfect collision detection with bitmasks.
+//
+// This package is experimental and the API might be changed in the future.
+//
+// A Mask is built from an image's alpha channel once, typically when the image is loaded.
+// Then, testing an overlap of two masks doesn't read pixels from GPU at all.
+package collision
+
+import (
+	"image"
+	"math"
+	"math/bits"
+
+	"github.com/duplicants-ai/ebiten"
+)
+
+// Mask is a bitmask that represents which pixels of an image are solid.
+//
+// Mask is immutable and concurrent-safe.
+type Mask struct {
+	width  int
+	height int
+
+	// stride is the number of words per row.
+	stride int
+
+	// words has a bit for each pixel. The bit i of the word k in a row represents the pixel at x = 64*k + i.
+	words []uint64
+}
+
+// NewMask creates a new mask from img's alpha channel.
+// A pixel is solid when its alpha value is more than threshold.
+//
+// The mask's origin (0, 0) corresponds to img.Bounds().Min.
+//
+// If img is *ebiten.Image, its pixels are read from GPU only once.
+// NewMask should be called when the image is loaded, not every frame.
+func NewMask(img image.Image, threshold uint8) *Mask {
+	b := img.Bounds()
+	m := newMask(b.Dx(), b.Dy())
+
+	switch img := img.(type) {
+	case *ebiten.Image:
+		pix := img.Pixels(b)
+		for j := 0; j < m.height; j++ {
+			for i := 0; i < m.width; i++ {
+				if pix[4*(j*m.width+i)+3] > threshold {
+					m.set(i, j)
+				}
+			}
+		}
+	case *image.RGBA:
+		for j := 0; j < m.height; j++ {
+			for i := 0; i < m.width; i++ {
+				if img.Pix[j*img.Stride+4*i+3] > threshold {
+					m.set(i, j)
+				}
+			}
+		}
+	case *image.NRGBA:
+		for j := 0; j < m.height; j++ {
+			for i := 0; i < m.width; i++ {
+				if img.Pix[j*img.Stride+4*i+3] > threshold {
+					m.set(i, j)
+				}
+			}
+		}
+	case *image.Alpha:
+		for j := 0; j < m.height; j++ {
+			for i := 0; i < m.width; i++ {
+				if img.Pix[j*img.Stride+i] > threshold {
+					m.set(i, j)
+				}
+			}
+		}
+	default:
+		for j := 0; j < m.height; j++ {
+			for i := 0; i < m.width; i++ {
+				_, _, _, a := img.At(b.Min.X+i, b.Min.Y+j).RGBA()
+				if uint8(a>>8) > threshold {
+					m.set(i, j)
+				}
+			}
+		}
+	}
+
+	return m
+}
+
+func newMask(width, height int) *Mask {
+	stride := (width + 63) / 64
+	return &Mask{
+		width:  width,
+		height: height,
+		stride: stride,
+		words:  make([]uint64, stride*height),
+	}
+}
+
+func (m *Mask) set(x, y int) {
+	m.words[y*m.stride+x/64] |= 1 << (x % 64)
+}
+
+// Bounds returns the bounds of the mask. The origin is always (0, 0).
+func (m *Mask) Bounds() image.Rectangle {
+	return image.Rect(0, 0, m.width, m.height)
+}
+
+// Solid reports whether the pixel at (x, y) is solid.
+//
+// Solid returns false if (x, y) is out of the bounds.
+func (m *Mask) Solid(x, y int) bool {
+	if x < 0 || y < 0 || x >= m.width || y >= m.height {
+		return false
+	}
+	return m.words[y*m.stride+x/64]&(1<<(x%64)) != 0
+}
+
+// SolidCount returns the number of the solid pixels.
+func (m *Mask) SolidCount() int {
+	var n int
+	for _, w := range m.words {
+		n += bits.OnesCount64(w)
+	}
+	return n
+}
+
+// bitsAt returns up to n bits of the row y starting from x.
+// x must be in [0, m.width).
+func (m *Mask) bitsAt(x, y int, n int) uint64 {
+	row := m.words[y*m.stride : (y+1)*m.stride]
+	k, s := x/64, x%64
+	v := row[k] >> s
+	if s != 0 && k+1 < len(row) {
+		v |= row[k+1] << (64 - s)
+	}
+	if n < 64 {
+		v &= (1 << n) - 1
+	}
+	return v
+}
+
+// Overlaps reports whether any solid pixels of the two masks overlap.
+//
+// geoMA and geoMB are the transforms of the masks a and b, like the GeoM of DrawImageOptions to render the images.
+// When both transforms are translations by integers, Overlaps compares 64 pixels at a time.
+// Otherwise, Overlaps samples the masks at the centers of the pixels in the region where the masks' bounding boxes overlap.
+//
+// If a transform is not invertible, Overlaps returns false.
+func Overlaps(a *Mask, geoMA ebiten.GeoM, b *Mask, geoMB ebiten.GeoM) bool {
+	ra, ok := transformedBounds(a, &geoMA)
+	if !ok {
+		return false
+	}
+	rb, ok := transformedBounds(b, &geoMB)
+	if !ok {
+		return false
+	}
+	r := ra.Intersect(rb)
+	if r.Empty() {
+		return false
+	}
+
+	if ax, ay, ok := integerTranslation(&geoMA); ok {
+		if bx, by, ok := integerTranslation(&geoMB); ok {
+			return overlapsTranslated(a, ax, ay, b, bx, by, r)
+		}
+	}
+
+	invA := geoMA
+	invA.Invert()
+	invB := geoMB
+	invB.Invert()
+	for y := r.Min.Y; y < r.Max.Y; y++ {
+		for x := r.Min.X; x < r.Max.X; x++ {
+			wx, wy := float64(x)+0.5, float64(y)+0.5
+			lx, ly := invA.Apply(wx, wy)
+			if !a.Solid(int(math.Floor(lx)), int(math.Floor(ly))) {
+				continue
+			}
+			lx, ly = invB.Apply(wx, wy)
+			if b.Solid(int(math.Floor(lx)), int(math.Floor(ly))) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func overlapsTranslated(a *Mask, ax, ay int, b *Mask, bx, by int, r image.Rectangle) bool {
+	for y := r.Min.Y; y < r.Max.Y; y++ {
+		for x := r.Min.X; x < r.Max.X; x += 64 {
+			n := min(64, r.Max.X-x)
+			if a.bitsAt(x-ax, y-ay, n)&b.bitsAt(x-bx, y-by, n) != 0 {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func integerTranslation(g *ebiten.GeoM) (int, int, bool) {
+	if g.Element(0, 0) != 1 || g.Element(0, 1) != 0 || g.Element(1, 0) != 0 || g.Element(1, 1) != 1 {
+		return 0, 0, false
+	}
+	tx, ty := g.Element(0, 2), g.Element(1, 2)
+	if tx != math.Trunc(tx) || ty != math.Trunc(ty) {
+		return 0, 0, false
+	}
+	return int(tx), int(ty), true
+}
+
+// transformedBounds returns the bounding box of the transformed mask in integer coordinates.
+func transformedBounds(m *Mask, g *ebiten.GeoM) (image.Rectangle, bool) {
+	if !g.IsInvertible() {
+		return image.Rectangle{}, false
+	}
+
+	minX, minY := math.Inf(1), math.Inf(1)
+	maxX, maxY := math.Inf(-1), math.Inf(-1)
+	for _, p := range [4][2]float64{
+		{0, 0},
+		{float64(m.width), 0},
+		{0, float64(m.height)},
+		{float64(m.width), float64(m.height)},
+	} {
+		x, y := g.Apply(p[0], p[1])
+		minX = min(minX, x)
+		minY = min(minY, y)
+		maxX = max(maxX, x)
+		maxY = max(maxY, y)
+	}
+	return image.Rect(int(math.Floor(minX)), int(math.Floor(minY)), int(math.Ceil(maxX)), int(math.Ceil(maxY))), true
+}
@@ -0,0 +1,136 @@
+// Copyright 2026 The Ebitengine Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package collision_test
+
+import (
+	"image"
+	"image/color"
+	"math"
+	"testing"
+
+	"github.com/duplicants-ai/ebiten"
+	"github.com/duplicants-ai/ebiten/exp/collision"
+)
+
+// newRingImage returns an image with a hollow square of the given size.
+func newRingImage(size int) *image.Alpha {
+	img := image.NewAlpha(image.Rect(0, 0, size, size))
+	for j := 0; j < size; j++ {
+		for i := 0; i < size; i++ {
+			if i == 0 || j == 0 || i == size-1 || j == size-1 {
+				img.SetAlpha(i, j, color.Alpha{A: 0xff})
+			}
+		}
+	}
+	return img
+}
+
+func translated(x, y float64) ebiten.GeoM {
+	var g ebiten.GeoM
+	g.Translate(x, y)
+	return g
+}
+
+func TestMask(t *testing.T) {
+	m := collision.NewMask(newRingImage(100), 0)
+	if got, want := m.Bounds(), image.Rect(0, 0, 100, 100); got != want {
+		t.Errorf("Bounds(): got: %v, want: %v", got, want)
+	}
+	if got, want := m.SolidCount(), 4*99; got != want {
+		t.Errorf("SolidCount(): got: %d, want: %d", got, want)
+	}
+	if !m.Solid(99, 50) {
+		t.Errorf("Solid(99, 50): got: false, want: true")
+	}
+	if m.Solid(50, 50) {
+		t.Errorf("Solid(50, 50): got: true, want: false")
+	}
+	if m.Solid(100, 0) {
+		t.Errorf("Solid(100, 0): got: true, want: false")
+	}
+}
+
+func TestOverlaps(t *testing.T) {
+	big := collision.NewMask(newRingImage(100), 0)
+	small := collision.NewMask(newRingImage(10), 0)
+
+	rotated := func(x, y float64) ebiten.GeoM {
+		var g ebiten.GeoM
+		g.Translate(-5, -5)
+		g.Rotate(math.Pi / 4)
+		g.Translate(x, y)
+		return g
+	}
+
+	cases := []struct {
+		Name  string
+		GeoMA ebiten.GeoM
+		GeoMB ebiten.GeoM
+		Want  bool
+	}{
+		{
+			Name:  "inside the hole",
+			GeoMA: translated(0, 0),
+			GeoMB: translated(45, 45),
+			Want:  false,
+		},
+		{
+			Name:  "on the right edge",
+			GeoMA: translated(0, 0),
+			GeoMB: translated(95, 45),
+			Want:  true,
+		},
+		{
+			Name:  "next to the right edge",
+			GeoMA: translated(0, 0),
+			GeoMB: translated(100, 45),
+			Want:  false,
+		},
+		{
+			Name:  "negative offsets",
+			GeoMA: translated(-200, -300),
+			GeoMB: translated(-110, -260),
+			Want:  true,
+		},
+		{
+			Name:  "fractional offsets",
+			GeoMA: translated(0.5, 0.5),
+			GeoMB: translated(45.5, 45.5),
+			Want:  false,
+		},
+		{
+			Name:  "rotated inside the hole",
+			GeoMA: translated(0, 0),
+			GeoMB: rotated(50, 50),
+			Want:  false,
+		},
+		{
+			Name:  "rotated on the edge",
+			GeoMA: translated(0, 0),
+			GeoMB: rotated(50, 99),
+			Want:  true,
+		},
+	}
+	for _, c := range cases {
+		t.Run(c.Name, func(t *testing.T) {
+			if got := collision.Overlaps(big, c.GeoMA, small, c.GeoMB); got != c.Want {
+				t.Errorf("got: %t, want: %t", got, c.Want)
+			}
+			if got := collision.Overlaps(small, c.GeoMB, big, c.GeoMA); got != c.Want {
+				t.Errorf("got (swapped): %t, want: %t", got, c.Want)
+			}
+		})
+	}
+}
@@ -0,0 +1,313 @@
+// Copyright 2026 The Ebitengine Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package debugdraw provides an immediate-mode debug drawing layer.
+//
+// This package is experimental and the API might be changed in the future.
+//
+// Lines, rectangles, circles, text, and vectors can be queued from anywhere in the game code, even outside Draw,
+// e.g., in Update or in a physics step.
+// Call Draw once per frame at the end of the game's Draw to render the queued items on top of the screen.
+//
+//	func (g *Game) Update() error {
+//		debugdraw.Vector(g.player.X, g.player.Y, g.player.VX, g.player.VY, nil)
+//		return nil
+//	}
+//
+//	func (g *Game) Draw(screen *ebiten.Image) {
+//		// Draw the game...
+//		debugdraw.Draw(screen, g.camera)
+//	}
+//
+// The positions are in the world coordinates by default, and are transformed by the camera given to Draw.
+package debugdraw
+
+import (
+	"image/color"
+	"math"
+	"sync"
+	"time"
+
+	"github.com/duplicants-ai/ebiten"
+	"github.com/duplicants-ai/ebiten/ebitenutil"
+	"github.com/duplicants-ai/ebiten/vector"
+)
+
+// Options represents options for a queued item.
+type Options struct {
+	// Color is the color of the item.
+	//
+	// The default (nil) value is white.
+	Color color.Color
+
+	// Width is the stroke width in pixels on the screen.
+	//
+	// The default (zero) value is 1.
+	Width float32
+
+	// Fill reports whether the shape is filled instead of stroked.
+	// Fill is used only for Rect and Circle.
+	//
+	// The default (zero) value is false.
+	Fill bool
+
+	// Duration is how long the item is kept.
+	// The item is drawn at every Draw until Duration passes.
+	//
+	// The default (zero) value means that the item is drawn only at the next Draw.
+	Duration time.Duration
+
+	// ScreenSpace reports whether the positions are in the screen coordinates.
+	// If ScreenSpace is true, the camera given to Draw is not applied.
+	//
+	// The default (zero) value is false.
+	ScreenSpace bool
+}
+
+type itemType int
+
+const (
+	itemTypeShape itemType = iota
+	itemTypeVector
+	itemTypeText
+)
+
+type point struct {
+	x, y float64
+}
+
+type item struct {
+	typ    itemType
+	points []point
+	closed bool
+	text   string
+	opts   Options
+	expire time.Time
+}
+
+var (
+	items     []item
+	enabled   = true
+	tmpPoints []point
+	m         sync.Mutex
+)
+
+// now is a function to get the current time. This is replaced in tests.
+var now = time.Now
+
+// SetEnabled enables or disables the debug drawing.
+// While the debug drawing is disabled, queuing items does nothing and Draw draws nothing.
+// Disabling the debug drawing also clears the queued items.
+//
+// The debug drawing is enabled by default.
+//
+// SetEnabled is concurrent-safe.
+func SetEnabled(enable bool) {
+	m.Lock()
+	defer m.Unlock()
+	enabled = enable
+	if !enable {
+		items = items[:0]
+	}
+}
+
+// IsEnabled reports whether the debug drawing is enabled.
+//
+// IsEnabled is concurrent-safe.
+func IsEnabled() bool {
+	m.Lock()
+	defer m.Unlock()
+	return enabled
+}
+
+// Clear removes all the queued items including the ones whose durations have not passed yet.
+//
+// Clear is concurrent-safe.
+func Clear() {
+	m.Lock()
+	defer m.Unlock()
+	items = items[:0]
+}
+
+func push(it item, options *Options) {
+	m.Lock()
+	defer m.Unlock()
+	if !enabled {
+		return
+	}
+	if options != nil {
+		it.opts = *options
+	}
+	if it.opts.Duration > 0 {
+		it.expire = now().Add(it.opts.Duration)
+	}
+	items = append(items, it)
+}
+
+// Line queues a line from (x0, y0) to (x1, y1).
+//
+// Line is concurrent-safe.
+func Line(x0, y0, x1, y1 float64, options *Options) {
+	push(item{
+		points: []point{{x0, y0}, {x1, y1}},
+	}, options)
+}
+
+// Rect queues a rectangle.
+//
+// Rect is concurrent-safe.
+func Rect(x, y, width, height float64, options *Options) {
+	push(item{
+		points: []point{{x, y}, {x + width, y}, {x + width, y + height}, {x, y + height}},
+		closed: true,
+	}, options)
+}
+
+// circleSegmentCount is the number of the segments to approximate a circle.
+const circleSegmentCount = 48
+
+// Circle queues a circle whose center is (x, y).
+//
+// Circle is concurrent-safe.
+func Circle(x, y, radius float64, options *Options) {
+	ps := make([]point, circleSegmentCount)
+	for i := range ps {
+		theta := 2 * math.Pi * float64(i) / circleSegmentCount
+		ps[i] = point{x + radius*math.Cos(theta), y + radius*math.Sin(theta)}
+	}
+	push(item{
+		points: ps,
+		closed: true,
+	}, options)
+}
+
+// Vector queues an arrow from (x, y) to (x+dx, y+dy).
+// Vector is useful to visualize velocities and forces.
+//
+// Vector is concurrent-safe.
+func Vector(x, y, dx, dy float64, options *Options) {
+	// The arrowhead is added at Draw, as its size is in pixels on the screen.
+	push(item{
+		typ:    itemTypeVector,
+		points: []point{{x, y}, {x + dx, y + dy}},
+	}, options)
+}
+
+// Text queues a text whose upper-left corner is at (x, y).
+// The text is drawn with the built-in debug font, and is not scaled by the camera.
+//
+// Text is concurrent-safe.
+func Text(str string, x, y float64, options *Options) {
+	push(item{
+		typ:    itemTypeText,
+		points: []point{{x, y}},
+		text:   str,
+	}, options)
+}
+
+// Draw draws the queued items on screen, and removes the items whose durations have passed.
+//
+// camera is the transform from the world coordinates to the screen coordinates.
+// The zero GeoM is the identity.
+//
+// Draw should be called once per frame at the end of the game's Draw.
+func Draw(screen *ebiten.Image, camera ebiten.GeoM) {
+	m.Lock()
+	defer m.Unlock()
+	if !enabled {
+		return
+	}
+
+	t := now()
+	var path vector.Path
+	for _, it := range items {
+		clr := it.opts.Color
+		if clr == nil {
+			clr = color.White
+		}
+		width := it.opts.Width
+		if width == 0 {
+			width = 1
+		}
+
+		tmpPoints = tmpPoints[:0]
+		for _, p := range it.points {
+			if !it.opts.ScreenSpace {
+				p.x, p.y = camera.Apply(p.x, p.y)
+			}
+			tmpPoints = append(tmpPoints, p)
+		}
+		ps := tmpPoints
+
+		if it.typ == itemTypeText {
+			ebitenutil.DebugPrintWithOptions(screen, it.text, int(math.Floor(ps[0].x)), int(math.Floor(ps[0].y)), &ebitenutil.DebugPrintOptions{
+				Color: clr,
+			})
+			continue
+		}
+
+		path.Reset()
+		appendPolyline(&path, ps, it.closed)
+		if it.typ == itemTypeVector {
+			appendArrowhead(&path, ps[0], ps[1], width)
+		}
+		if it.closed && it.opts.Fill {
+			vector.DrawFilledPath(screen, &path, clr, true, vector.FillRuleNonZero)
+			continue
+		}
+		vector.StrokePath(screen, &path, clr, true, &vector.StrokeOptions{
+			Width:    width,
+			LineJoin: vector.LineJoinRound,
+		})
+	}
+
+	// Remove the expired items.
+	n := 0
+	for _, it := range items {
+		if it.expire.IsZero() || !t.Before(it.expire) {
+			continue
+		}
+		items[n] = it
+		n++
+	}
+	clear(items[n:])
+	items = items[:n]
+}
+
+func appendPolyline(path *vector.Path, ps []point, closed bool) {
+	for i, p := range ps {
+		if i == 0 {
+			path.MoveTo(float32(p.x), float32(p.y))
+			continue
+		}
+		path.LineTo(float32(p.x), float32(p.y))
+	}
+	if closed {
+		path.Close()
+	}
+}
+
+func appendArrowhead(path *vector.Path, from, to point, width float32) {
+	dx, dy := to.x-from.x, to.y-from.y
+	l := math.Hypot(dx, dy)
+	if l == 0 {
+		return
+	}
+	// The arrowhead size is in pixels on the screen, but not longer than the arrow.
+	size := min(max(8, 4*float64(width)), l/2)
+	ux, uy := dx/l, dy/l
+	path.MoveTo(float32(to.x-size*(ux-uy/2)), float32(to.y-size*(uy+ux/2)))
+	path.LineTo(float32(to.x), float32(to.y))
+	path.LineTo(float32(to.x-size*(ux+uy/2)), float32(to.y-size*(uy-ux/2)))
+}
@@ -0,0 +1,128 @@
+// Copyright 2026 The Ebitengine Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package debugdraw
+
+import (
+	"image/color"
+	"testing"
+	"time"
+
+	"github.com/duplicants-ai/ebiten"
+	etesting "github.com/duplicants-ai/ebiten/internal/testing"
+	"github.com/duplicants-ai/ebiten/internal/ui"
+)
+
+func TestMain(m *testing.M) {
+	ui.SetPanicOnErrorOnReadingPixelsForTesting(true)
+	etesting.MainWithRunLoop(m)
+}
+
+func reset(t *testing.T) {
+	Clear()
+	SetEnabled(true)
+	t.Cleanup(func() {
+		Clear()
+		now = time.Now
+	})
+}
+
+func TestLine(t *testing.T) {
+	reset(t)
+
+	red := color.RGBA{R: 0xff, A: 0xff}
+	Line(0, 8, 16, 8, &Options{Color: red, Width: 2})
+
+	screen := ebiten.NewImage(16, 16)
+	Draw(screen, ebiten.GeoM{})
+	if got := screen.At(8, 8); got != red {
+		t.Errorf("screen.At(8, 8): got: %v, want: %v", got, red)
+	}
+	if got, want := screen.At(8, 2), (color.RGBA{}); got != want {
+		t.Errorf("screen.At(8, 2): got: %v, want: %v", got, want)
+	}
+
+	// An item without a duration is drawn only once.
+	screen.Clear()
+	Draw(screen, ebiten.GeoM{})
+	if got, want := screen.At(8, 8), (color.RGBA{}); got != want {
+		t.Errorf("screen.At(8, 8) after the second Draw: got: %v, want: %v", got, want)
+	}
+}
+
+func TestCamera(t *testing.T) {
+	reset(t)
+
+	Rect(0, 0, 4, 4, &Options{Fill: true})
+	Rect(0, 0, 4, 4, &Options{Fill: true, ScreenSpace: true, Color: color.RGBA{B: 0xff, A: 0xff}})
+
+	var camera ebiten.GeoM
+	camera.Scale(2, 2)
+	camera.Translate(8, 8)
+	screen := ebiten.NewImage(16, 16)
+	Draw(screen, camera)
+
+	if got, want := screen.At(12, 12), (color.RGBA{R: 0xff, G: 0xff, B: 0xff, A: 0xff}); got != want {
+		t.Errorf("screen.At(12, 12): got: %v, want: %v", got, want)
+	}
+	if got, want := screen.At(2, 2), (color.RGBA{B: 0xff, A: 0xff}); got != want {
+		t.Errorf("screen.At(2, 2): got: %v, want: %v", got, want)
+	}
+	if got, want := screen.At(6, 6), (color.RGBA{}); got != want {
+		t.Errorf("screen.At(6, 6): got: %v, want: %v", got, want)
+	}
+}
+
+func TestDuration(t *testing.T) {
+	reset(t)
+
+	t0 := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	now = func() time.Time { return t0 }
+
+	Circle(8, 8, 4, &Options{Fill: true, Duration: time.Second})
+	Line(0, 0, 16, 16, nil)
+
+	screen := ebiten.NewImage(16, 16)
+	Draw(screen, ebiten.GeoM{})
+	if got, want := len(items), 1; got != want {
+		t.Fatalf("len(items) after the first Draw: got: %d, want: %d", got, want)
+	}
+
+	now = func() time.Time { return t0.Add(500 * time.Millisecond) }
+	screen.Clear()
+	Draw(screen, ebiten.GeoM{})
+	if got, want := screen.At(8, 8), (color.RGBA{R: 0xff, G: 0xff, B: 0xff, A: 0xff}); got != want {
+		t.Errorf("screen.At(8, 8): got: %v, want: %v", got, want)
+	}
+
+	now = func() time.Time { return t0.Add(time.Second) }
+	Draw(screen, ebiten.GeoM{})
+	if got, want := len(items), 0; got != want {
+		t.Errorf("len(items) after the duration: got: %d, want: %d", got, want)
+	}
+}
+
+func TestDisabled(t *testing.T) {
+	reset(t)
+
+	SetEnabled(false)
+	Rect(0, 0, 16, 16, &Options{Fill: true})
+	SetEnabled(true)
+
+	screen := ebiten.NewImage(16, 16)
+	Draw(screen, ebiten.GeoM{})
+	if got, want := screen.At(8, 8), (color.RGBA{}); got != want {
+		t.Errorf("screen.At(8, 8): got: %v, want: %v", got, want)
+	}
+}
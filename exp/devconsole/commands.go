@@ -0,0 +1,261 @@
+// Copyright 2026 The Ebitengine Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package devconsole
+
+import (
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+type command struct {
+	help string
+	fn   func(args []string) error
+}
+
+// RegisterCommand registers a command with the name.
+// fn is called with the arguments separated by spaces when the command is executed.
+// If fn returns an error, the error is shown in the console.
+//
+// If a command with the same name is already registered, the command is replaced.
+//
+// RegisterCommand is concurrent-safe.
+func (c *Console) RegisterCommand(name string, help string, fn func(args []string) error) {
+	c.m.Lock()
+	defer c.m.Unlock()
+	c.commands[name] = &command{
+		help: help,
+		fn:   fn,
+	}
+}
+
+// Execute executes a command line as if it were entered in the console.
+//
+// Execute should be called on the game's goroutine, as the command functions are called on the current goroutine.
+func (c *Console) Execute(line string) {
+	args := strings.Fields(line)
+	if len(args) == 0 {
+		return
+	}
+
+	c.m.Lock()
+	cmd, ok := c.commands[args[0]]
+	c.m.Unlock()
+
+	if !ok {
+		c.Printf("unknown command: %s", args[0])
+		return
+	}
+	if err := cmd.fn(args[1:]); err != nil {
+		c.Printf("%s: %v", args[0], err)
+	}
+}
+
+func (c *Console) commandNames() []string {
+	c.m.Lock()
+	defer c.m.Unlock()
+
+	names := make([]string, 0, len(c.commands))
+	for name := range c.commands {
+		names = append(names, name)
+	}
+	slices.Sort(names)
+	return names
+}
+
+type cvar struct {
+	help string
+	get  func() string
+	set  func(value string) error
+}
+
+func (c *Console) registerCVar(name string, help string, get func() string, set func(value string) error) {
+	c.m.Lock()
+	defer c.m.Unlock()
+	c.cvars[name] = &cvar{
+		help: help,
+		get:  get,
+		set:  set,
+	}
+}
+
+// IntVar registers a console variable of int with the name.
+// The variable can be read and written by the commands "get" and "set".
+//
+// IntVar is concurrent-safe, but the variable is accessed on the game's goroutine.
+func (c *Console) IntVar(p *int, name string, help string) {
+	c.registerCVar(name, help, func() string {
+		return strconv.Itoa(*p)
+	}, func(value string) error {
+		v, err := strconv.Atoi(value)
+		if err != nil {
+			return err
+		}
+		*p = v
+		return nil
+	})
+}
+
+// FloatVar registers a console variable of float64 with the name.
+// The variable can be read and written by the commands "get" and "set".
+//
+// FloatVar is concurrent-safe, but the variable is accessed on the game's goroutine.
+func (c *Console) FloatVar(p *float64, name string, help string) {
+	c.registerCVar(name, help, func() string {
+		return strconv.FormatFloat(*p, 'g', -1, 64)
+	}, func(value string) error {
+		v, err := strconv.ParseFloat(value, 64)
+		if err != nil {
+			return err
+		}
+		*p = v
+		return nil
+	})
+}
+
+// BoolVar registers a console variable of bool with the name.
+// The variable can be read and written by the commands "get" and "set".
+//
+// BoolVar is concurrent-safe, but the variable is accessed on the game's goroutine.
+func (c *Console) BoolVar(p *bool, name string, help string) {
+	c.registerCVar(name, help, func() string {
+		return strconv.FormatBool(*p)
+	}, func(value string) error {
+		v, err := strconv.ParseBool(value)
+		if err != nil {
+			return err
+		}
+		*p = v
+		return nil
+	})
+}
+
+// StringVar registers a console variable of string with the name.
+// The variable can be read and written by the commands "get" and "set".
+//
+// StringVar is concurrent-safe, but the variable is accessed on the game's goroutine.
+func (c *Console) StringVar(p *string, name string, help string) {
+	c.registerCVar(name, help, func() string {
+		return *p
+	}, func(value string) error {
+		*p = value
+		return nil
+	})
+}
+
+func (c *Console) lookupCVar(name string) (*cvar, error) {
+	c.m.Lock()
+	defer c.m.Unlock()
+	v, ok := c.cvars[name]
+	if !ok {
+		return nil, fmt.Errorf("unknown variable: %s", name)
+	}
+	return v, nil
+}
+
+func (c *Console) registerBuiltinCommands() {
+	c.commands["help"] = &command{
+		help: "shows the commands and the variables",
+		fn: func(args []string) error {
+			c.m.Lock()
+			var lines []string
+			for name, cmd := range c.commands {
+				lines = append(lines, fmt.Sprintf("%s - %s", name, cmd.help))
+			}
+			for name, v := range c.cvars {
+				lines = append(lines, fmt.Sprintf("%s = %s - %s", name, v.get(), v.help))
+			}
+			c.m.Unlock()
+
+			slices.Sort(lines)
+			for _, line := range lines {
+				c.Printf("%s", line)
+			}
+			return nil
+		},
+	}
+	c.commands["clear"] = &command{
+		help: "clears the output",
+		fn: func(args []string) error {
+			c.Clear()
+			return nil
+		},
+	}
+	c.commands["get"] = &command{
+		help: "shows the value of a variable: get <name>",
+		fn: func(args []string) error {
+			if len(args) != 1 {
+				return fmt.Errorf("usage: get <name>")
+			}
+			v, err := c.lookupCVar(args[0])
+			if err != nil {
+				return err
+			}
+			c.Printf("%s = %s", args[0], v.get())
+			return nil
+		},
+	}
+	c.commands["set"] = &command{
+		help: "sets the value of a variable: set <name> <value>",
+		fn: func(args []string) error {
+			if len(args) < 2 {
+				return fmt.Errorf("usage: set <name> <value>")
+			}
+			v, err := c.lookupCVar(args[0])
+			if err != nil {
+				return err
+			}
+			if err := v.set(strings.Join(args[1:], " ")); err != nil {
+				return err
+			}
+			c.Printf("%s = %s", args[0], v.get())
+			return nil
+		},
+	}
+	c.commands["watch"] = &command{
+		help: "shows the value of a variable on the screen: watch <name>",
+		fn: func(args []string) error {
+			if len(args) != 1 {
+				return fmt.Errorf("usage: watch <name>")
+			}
+			v, err := c.lookupCVar(args[0])
+			if err != nil {
+				return err
+			}
+			c.Watch(args[0], v.get)
+			return nil
+		},
+	}
+	c.commands["unwatch"] = &command{
+		help: "stops showing the value of a variable: unwatch <name>",
+		fn: func(args []string) error {
+			if len(args) != 1 {
+				return fmt.Errorf("usage: unwatch <name>")
+			}
+			c.Unwatch(args[0])
+			return nil
+		},
+	}
+	c.commands["history"] = &command{
+		help: "shows the command history",
+		fn: func(args []string) error {
+			for _, line := range c.history {
+				c.Printf("%s", line)
+			}
+			return nil
+		},
+	}
+}
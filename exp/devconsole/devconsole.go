@@ -0,0 +1,420 @@
+// Copyright 2026 The Ebitengine Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package devconsole provides an on-screen developer console.
+//
+// This package is experimental and the API might be changed in the future.
+//
+// A Console is an overlay toggled by a key. A Console has commands, console variables (cvars),
+// watches that show values every frame, command history, and the log output.
+// To capture the log output, use a Console as an io.Writer, e.g., log.SetOutput(io.MultiWriter(os.Stderr, console)).
+//
+// The text input uses exp/textinput, so IME works on the supported platforms.
+package devconsole
+
+import (
+	"fmt"
+	"image/color"
+	"strings"
+	"sync"
+	"unicode/utf8"
+
+	"github.com/duplicants-ai/ebiten"
+	"github.com/duplicants-ai/ebiten/ebitenutil"
+	"github.com/duplicants-ai/ebiten/exp/textinput"
+	"github.com/duplicants-ai/ebiten/inpututil"
+	"github.com/duplicants-ai/ebiten/text/v2"
+)
+
+// Options represents options for a Console.
+type Options struct {
+	// MaxLines is the maximum number of the output lines kept in the console.
+	//
+	// The default (zero) value is 256.
+	MaxLines int
+
+	// MaxHistory is the maximum number of the command history.
+	//
+	// The default (zero) value is 64.
+	MaxHistory int
+
+	// Face is the font face for the console.
+	//
+	// The default (nil) value means the built-in debug font.
+	Face text.Face
+}
+
+// Console is an on-screen developer console.
+//
+// Update and Draw must be called on the game's goroutine.
+// Write, Printf, RegisterCommand, and the registration of cvars and watches are concurrent-safe.
+type Console struct {
+	toggleKey  ebiten.Key
+	maxLines   int
+	maxHistory int
+	face       text.Face
+
+	open  bool
+	field textinput.Field
+
+	history      []string
+	historyIndex int
+
+	lines    []string
+	partial  string
+	commands map[string]*command
+	cvars    map[string]*cvar
+	watches  []watch
+
+	m sync.Mutex
+}
+
+type watch struct {
+	name  string
+	value func() string
+}
+
+// New creates a new Console.
+//
+// options can be nil. In this case, the default options are used.
+func New(options *Options) *Console {
+	var op Options
+	if options != nil {
+		op = *options
+	}
+	if op.MaxLines == 0 {
+		op.MaxLines = 256
+	}
+	if op.MaxHistory == 0 {
+		op.MaxHistory = 64
+	}
+	c := &Console{
+		toggleKey:  ebiten.KeyBackquote,
+		maxLines:   op.MaxLines,
+		maxHistory: op.MaxHistory,
+		face:       op.Face,
+		commands:   map[string]*command{},
+		cvars:      map[string]*cvar{},
+	}
+	c.registerBuiltinCommands()
+	return c
+}
+
+// SetToggleKey sets the key to open and close the console.
+//
+// The default key is ebiten.KeyBackquote (`).
+func (c *Console) SetToggleKey(key ebiten.Key) {
+	c.toggleKey = key
+}
+
+// IsOpen reports whether the console is open.
+//
+// When the console is open, the game should not handle keyboard inputs.
+func (c *Console) IsOpen() bool {
+	return c.open
+}
+
+// SetOpen opens or closes the console.
+func (c *Console) SetOpen(open bool) {
+	if c.open == open {
+		return
+	}
+	c.open = open
+	if open {
+		c.field.Focus()
+	} else {
+		c.field.Blur()
+	}
+}
+
+// Write appends p to the console output.
+//
+// Write is concurrent-safe.
+func (c *Console) Write(p []byte) (int, error) {
+	c.m.Lock()
+	defer c.m.Unlock()
+
+	str := c.partial + string(p)
+	lines := strings.Split(str, "\n")
+	// The last element is an incomplete line.
+	c.partial = lines[len(lines)-1]
+	c.appendLines(lines[:len(lines)-1]...)
+	return len(p), nil
+}
+
+// Printf formats according to a format specifier and appends the result to the console output as a line.
+//
+// Printf is concurrent-safe.
+func (c *Console) Printf(format string, args ...any) {
+	c.m.Lock()
+	defer c.m.Unlock()
+	c.appendLines(strings.Split(fmt.Sprintf(format, args...), "\n")...)
+}
+
+// appendLines must be called with c.m locked.
+func (c *Console) appendLines(lines ...string) {
+	c.lines = append(c.lines, lines...)
+	if len(c.lines) > c.maxLines {
+		n := len(c.lines) - c.maxLines
+		copy(c.lines, c.lines[n:])
+		clear(c.lines[len(c.lines)-n:])
+		c.lines = c.lines[:len(c.lines)-n]
+	}
+}
+
+// Clear clears the console output.
+//
+// Clear is concurrent-safe.
+func (c *Console) Clear() {
+	c.m.Lock()
+	defer c.m.Unlock()
+	clear(c.lines)
+	c.lines = c.lines[:0]
+	c.partial = ""
+}
+
+// Watch adds a watch that shows the value returned by value on the screen every frame.
+// Watches are shown even when the console is closed.
+//
+// Watch is concurrent-safe.
+func (c *Console) Watch(name string, value func() string) {
+	c.m.Lock()
+	defer c.m.Unlock()
+	for i, w := range c.watches {
+		if w.name == name {
+			c.watches[i].value = value
+			return
+		}
+	}
+	c.watches = append(c.watches, watch{name: name, value: value})
+}
+
+// Unwatch removes the watch with the given name.
+//
+// Unwatch is concurrent-safe.
+func (c *Console) Unwatch(name string) {
+	c.m.Lock()
+	defer c.m.Unlock()
+	for i, w := range c.watches {
+		if w.name == name {
+			c.watches = append(c.watches[:i], c.watches[i+1:]...)
+			return
+		}
+	}
+}
+
+// Update updates the console state.
+// Update should be called at the beginning of the game's Update.
+func (c *Console) Update() error {
+	if inpututil.IsKeyJustPressed(c.toggleKey) {
+		c.SetOpen(!c.open)
+		return nil
+	}
+	if !c.open {
+		return nil
+	}
+
+	// The field position for an IME window is the bottom-left corner.
+	_, h := c.lineSize()
+	handled, err := c.field.HandleInput(0, c.inputLineY()+int(h))
+	if err != nil {
+		return err
+	}
+	if handled {
+		return nil
+	}
+
+	switch {
+	case inpututil.IsKeyJustPressed(ebiten.KeyEscape):
+		c.SetOpen(false)
+	case inpututil.IsKeyJustPressed(ebiten.KeyEnter):
+		line := strings.TrimSpace(c.field.Text())
+		c.field.SetTextAndSelection("", 0, 0)
+		if line != "" {
+			c.appendHistory(line)
+			c.Printf("> %s", line)
+			c.Execute(line)
+		}
+	case isKeyRepeated(ebiten.KeyBackspace):
+		str := c.field.Text()
+		start, end := c.field.Selection()
+		if start != end {
+			str = str[:start] + str[end:]
+		} else if start > 0 {
+			_, l := utf8.DecodeLastRuneInString(str[:start])
+			str = str[:start-l] + str[start:]
+			start -= l
+		}
+		c.field.SetTextAndSelection(str, start, start)
+	case isKeyRepeated(ebiten.KeyLeft):
+		str := c.field.Text()
+		start, _ := c.field.Selection()
+		if start > 0 {
+			_, l := utf8.DecodeLastRuneInString(str[:start])
+			start -= l
+		}
+		c.field.SetTextAndSelection(str, start, start)
+	case isKeyRepeated(ebiten.KeyRight):
+		str := c.field.Text()
+		_, end := c.field.Selection()
+		if end < len(str) {
+			_, l := utf8.DecodeRuneInString(str[end:])
+			end += l
+		}
+		c.field.SetTextAndSelection(str, end, end)
+	case inpututil.IsKeyJustPressed(ebiten.KeyUp):
+		if c.historyIndex > 0 {
+			c.historyIndex--
+			str := c.history[c.historyIndex]
+			c.field.SetTextAndSelection(str, len(str), len(str))
+		}
+	case inpututil.IsKeyJustPressed(ebiten.KeyDown):
+		if c.historyIndex < len(c.history) {
+			c.historyIndex++
+		}
+		var str string
+		if c.historyIndex < len(c.history) {
+			str = c.history[c.historyIndex]
+		}
+		c.field.SetTextAndSelection(str, len(str), len(str))
+	case inpututil.IsKeyJustPressed(ebiten.KeyTab):
+		c.complete()
+	}
+	return nil
+}
+
+func isKeyRepeated(key ebiten.Key) bool {
+	const (
+		delay    = 30
+		interval = 3
+	)
+	d := inpututil.KeyPressDuration(key)
+	if d == 1 {
+		return true
+	}
+	return d >= delay && (d-delay)%interval == 0
+}
+
+func (c *Console) appendHistory(line string) {
+	if len(c.history) == 0 || c.history[len(c.history)-1] != line {
+		c.history = append(c.history, line)
+		if len(c.history) > c.maxHistory {
+			c.history = c.history[len(c.history)-c.maxHistory:]
+		}
+	}
+	c.historyIndex = len(c.history)
+}
+
+// complete completes the command name at the input.
+func (c *Console) complete() {
+	str := c.field.Text()
+	if strings.ContainsRune(str, ' ') {
+		return
+	}
+	var candidates []string
+	for _, name := range c.commandNames() {
+		if strings.HasPrefix(name, str) {
+			candidates = append(candidates, name)
+		}
+	}
+	switch len(candidates) {
+	case 0:
+	case 1:
+		s := candidates[0] + " "
+		c.field.SetTextAndSelection(s, len(s), len(s))
+	default:
+		c.Printf("%s", strings.Join(candidates, " "))
+	}
+}
+
+func (c *Console) lineSize() (charWidth, lineHeight float64) {
+	if c.face == nil {
+		return 6, 16
+	}
+	m := c.face.Metrics()
+	w, _ := text.Measure("M", c.face, 0)
+	return w, m.HAscent + m.HDescent + m.HLineGap
+}
+
+// visibleLineCount is the number of the output lines shown in the console.
+const visibleLineCount = 16
+
+func (c *Console) inputLineY() int {
+	_, h := c.lineSize()
+	return int(h * visibleLineCount)
+}
+
+var (
+	consoleBackgroundColor = color.RGBA{0, 0, 0, 0xc0}
+	consoleInputColor      = color.RGBA{0x20, 0x20, 0x20, 0xe0}
+	watchBackgroundColor   = color.RGBA{0, 0, 0, 0x80}
+)
+
+// Draw draws the console and the watches on the screen.
+// Draw should be called at the end of the game's Draw.
+func (c *Console) Draw(screen *ebiten.Image) {
+	c.m.Lock()
+	watches := append([]watch(nil), c.watches...)
+	var lines []string
+	if c.open {
+		lines = c.lines[max(len(c.lines)-visibleLineCount, 0):]
+		lines = append([]string(nil), lines...)
+	}
+	c.m.Unlock()
+
+	cw, lh := c.lineSize()
+	sw := screen.Bounds().Dx()
+
+	if len(watches) > 0 {
+		var sb strings.Builder
+		for i, w := range watches {
+			if i > 0 {
+				sb.WriteString("\n")
+			}
+			sb.WriteString(w.name)
+			sb.WriteString(": ")
+			sb.WriteString(w.value())
+		}
+		ebitenutil.DebugPrintWithOptions(screen, sb.String(), screen.Bounds().Max.X, screen.Bounds().Min.Y, &ebitenutil.DebugPrintOptions{
+			BackgroundColor: watchBackgroundColor,
+			Align:           text.AlignEnd,
+			Face:            c.face,
+		})
+	}
+
+	if !c.open {
+		return
+	}
+
+	x, y := screen.Bounds().Min.X, screen.Bounds().Min.Y
+	ebitenutil.DebugPrintWithOptions(screen, strings.Repeat(" ", int(float64(sw)/cw)+1)+strings.Repeat("\n", visibleLineCount-1), x, y, &ebitenutil.DebugPrintOptions{
+		BackgroundColor: consoleBackgroundColor,
+		Face:            c.face,
+	})
+	if len(lines) > 0 {
+		ebitenutil.DebugPrintWithOptions(screen, strings.Join(lines, "\n"), x, y+int(lh)*(visibleLineCount-len(lines)), &ebitenutil.DebugPrintOptions{
+			Face: c.face,
+		})
+	}
+
+	// Draw the input line with a cursor.
+	str := c.field.TextForRendering()
+	start, _ := c.field.Selection()
+	start = min(start, len(str))
+	input := "> " + str[:start] + "_" + str[start:]
+	ebitenutil.DebugPrintWithOptions(screen, input+strings.Repeat(" ", max(int(float64(sw)/cw)-utf8.RuneCountInString(input), 0)), x, y+c.inputLineY(), &ebitenutil.DebugPrintOptions{
+		BackgroundColor: consoleInputColor,
+		Face:            c.face,
+	})
+}
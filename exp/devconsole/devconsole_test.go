@@ -0,0 +1,278 @@
+// Copyright 2026 The Ebitengine Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package devconsole
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"testing"
+)
+
+func (c *Console) takeLines() []string {
+	c.m.Lock()
+	defer c.m.Unlock()
+	lines := c.lines
+	c.lines = nil
+	return lines
+}
+
+func TestExecute(t *testing.T) {
+	c := New(nil)
+
+	var got [][]string
+	c.RegisterCommand("echo", "echoes the arguments", func(args []string) error {
+		got = append(got, args)
+		return nil
+	})
+
+	c.Execute("echo a b")
+	c.Execute("  echo   a \tb  ")
+	c.Execute("echo")
+	c.Execute("")
+	c.Execute("   ")
+
+	want := [][]string{{"a", "b"}, {"a", "b"}, {}}
+	if !slices.EqualFunc(got, want, slices.Equal) {
+		t.Errorf("got: %q, want: %q", got, want)
+	}
+	if lines := c.takeLines(); len(lines) != 0 {
+		t.Errorf("lines: got: %q, want: none", lines)
+	}
+}
+
+func TestExecuteUnknownCommand(t *testing.T) {
+	c := New(nil)
+	c.Execute("foo bar")
+	if got, want := c.takeLines(), []string{"unknown command: foo"}; !slices.Equal(got, want) {
+		t.Errorf("got: %q, want: %q", got, want)
+	}
+}
+
+func TestExecuteError(t *testing.T) {
+	c := New(nil)
+	c.RegisterCommand("fail", "", func(args []string) error {
+		return errors.New("failed")
+	})
+	c.Execute("fail")
+	if got, want := c.takeLines(), []string{"fail: failed"}; !slices.Equal(got, want) {
+		t.Errorf("got: %q, want: %q", got, want)
+	}
+}
+
+func TestRegisterCommandReplace(t *testing.T) {
+	c := New(nil)
+
+	var got string
+	c.RegisterCommand("cmd", "", func(args []string) error {
+		got = "first"
+		return nil
+	})
+	c.RegisterCommand("cmd", "", func(args []string) error {
+		got = "second"
+		return nil
+	})
+	c.Execute("cmd")
+	if want := "second"; got != want {
+		t.Errorf("got: %q, want: %q", got, want)
+	}
+}
+
+func TestCommandNames(t *testing.T) {
+	c := New(nil)
+	c.RegisterCommand("zzz", "", func(args []string) error { return nil })
+	c.RegisterCommand("aaa", "", func(args []string) error { return nil })
+
+	got := c.commandNames()
+	want := []string{"aaa", "clear", "get", "help", "history", "set", "unwatch", "watch", "zzz"}
+	if !slices.Equal(got, want) {
+		t.Errorf("got: %q, want: %q", got, want)
+	}
+}
+
+func TestCVars(t *testing.T) {
+	c := New(nil)
+
+	var (
+		i int
+		f float64
+		b bool
+		s string
+	)
+	c.IntVar(&i, "i", "")
+	c.FloatVar(&f, "f", "")
+	c.BoolVar(&b, "b", "")
+	c.StringVar(&s, "s", "")
+
+	c.Execute("set i 42")
+	c.Execute("set f 1.5")
+	c.Execute("set b true")
+	c.Execute("set s hello  world")
+
+	if got, want := i, 42; got != want {
+		t.Errorf("i: got: %d, want: %d", got, want)
+	}
+	if got, want := f, 1.5; got != want {
+		t.Errorf("f: got: %v, want: %v", got, want)
+	}
+	if got, want := b, true; got != want {
+		t.Errorf("b: got: %v, want: %v", got, want)
+	}
+	// The arguments are joined with a single space.
+	if got, want := s, "hello world"; got != want {
+		t.Errorf("s: got: %q, want: %q", got, want)
+	}
+	if got, want := c.takeLines(), []string{"i = 42", "f = 1.5", "b = true", "s = hello world"}; !slices.Equal(got, want) {
+		t.Errorf("got: %q, want: %q", got, want)
+	}
+
+	c.Execute("get i")
+	if got, want := c.takeLines(), []string{"i = 42"}; !slices.Equal(got, want) {
+		t.Errorf("got: %q, want: %q", got, want)
+	}
+}
+
+func TestCVarErrors(t *testing.T) {
+	c := New(nil)
+
+	i := 1
+	c.IntVar(&i, "i", "")
+
+	cases := []struct {
+		line string
+		want string
+	}{
+		{line: "get", want: "get: usage: get <name>"},
+		{line: "get i j", want: "get: usage: get <name>"},
+		{line: "get j", want: "get: unknown variable: j"},
+		{line: "set i", want: "set: usage: set <name> <value>"},
+		{line: "set j 1", want: "set: unknown variable: j"},
+	}
+	for _, tc := range cases {
+		c.Execute(tc.line)
+		if got, want := c.takeLines(), []string{tc.want}; !slices.Equal(got, want) {
+			t.Errorf("%q: got: %q, want: %q", tc.line, got, want)
+		}
+	}
+
+	c.Execute("set i foo")
+	if lines := c.takeLines(); len(lines) != 1 || !strings.HasPrefix(lines[0], "set: strconv.Atoi: ") {
+		t.Errorf("got: %q, want: a strconv.Atoi error", lines)
+	}
+	if got, want := i, 1; got != want {
+		t.Errorf("i: got: %d, want: %d", got, want)
+	}
+}
+
+func TestHelp(t *testing.T) {
+	c := New(nil)
+	c.RegisterCommand("foo", "does foo", func(args []string) error { return nil })
+	i := 3
+	c.IntVar(&i, "bar", "the bar")
+
+	c.Execute("help")
+	got := c.takeLines()
+	want := []string{
+		"bar = 3 - the bar",
+		"clear - clears the output",
+		"foo - does foo",
+		"get - shows the value of a variable: get <name>",
+		"help - shows the commands and the variables",
+		"history - shows the command history",
+		"set - sets the value of a variable: set <name> <value>",
+		"unwatch - stops showing the value of a variable: unwatch <name>",
+		"watch - shows the value of a variable on the screen: watch <name>",
+	}
+	if !slices.Equal(got, want) {
+		t.Errorf("got: %q, want: %q", got, want)
+	}
+}
+
+func TestWatch(t *testing.T) {
+	c := New(nil)
+	i := 1
+	c.IntVar(&i, "i", "")
+
+	c.Execute("watch i")
+	c.Execute("watch i")
+	if got, want := len(c.watches), 1; got != want {
+		t.Fatalf("len(watches): got: %d, want: %d", got, want)
+	}
+	i = 2
+	if got, want := c.watches[0].value(), "2"; got != want {
+		t.Errorf("got: %q, want: %q", got, want)
+	}
+
+	c.Execute("unwatch i")
+	if got, want := len(c.watches), 0; got != want {
+		t.Errorf("len(watches): got: %d, want: %d", got, want)
+	}
+}
+
+func TestWrite(t *testing.T) {
+	c := New(nil)
+
+	fmt.Fprint(c, "foo")
+	if lines := c.takeLines(); len(lines) != 0 {
+		t.Errorf("got: %q, want: none", lines)
+	}
+	fmt.Fprint(c, "bar\nbaz\n\nqux")
+	if got, want := c.takeLines(), []string{"foobar", "baz", ""}; !slices.Equal(got, want) {
+		t.Errorf("got: %q, want: %q", got, want)
+	}
+	fmt.Fprint(c, "\n")
+	if got, want := c.takeLines(), []string{"qux"}; !slices.Equal(got, want) {
+		t.Errorf("got: %q, want: %q", got, want)
+	}
+}
+
+func TestMaxLines(t *testing.T) {
+	c := New(&Options{
+		MaxLines: 3,
+	})
+	for i := 0; i < 5; i++ {
+		c.Printf("%d", i)
+	}
+	if got, want := c.takeLines(), []string{"2", "3", "4"}; !slices.Equal(got, want) {
+		t.Errorf("got: %q, want: %q", got, want)
+	}
+
+	c.Printf("a\nb\nc\nd")
+	c.Execute("clear")
+	if lines := c.takeLines(); len(lines) != 0 {
+		t.Errorf("got: %q, want: none", lines)
+	}
+}
+
+func TestHistory(t *testing.T) {
+	c := New(&Options{
+		MaxHistory: 3,
+	})
+	for _, line := range []string{"a", "b", "b", "c", "d"} {
+		c.appendHistory(line)
+	}
+	if got, want := c.history, []string{"b", "c", "d"}; !slices.Equal(got, want) {
+		t.Errorf("got: %q, want: %q", got, want)
+	}
+	if got, want := c.historyIndex, 3; got != want {
+		t.Errorf("historyIndex: got: %d, want: %d", got, want)
+	}
+
+	c.Execute("history")
+	if got, want := c.takeLines(), []string{"b", "c", "d"}; !slices.Equal(got, want) {
+		t.Errorf("got: %q, want: %q", got, want)
+	}
+}
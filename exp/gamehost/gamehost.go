@@ -0,0 +1,187 @@
+// Copyright 2026 The Ebitengine Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package gamehost runs a Game inside another game, e.g. in a panel of an editor.
+//
+// This package is experimental and the API might be changed in the future.
+//
+// A Host gives the hosted game its own screen image and its own layout, so that the hosted game can be rendered
+// in a panel of the host application's screen.
+// The hosted game's screen is not on the texture atlas, like the screen given to Game.Draw.
+//
+// Each Host has its own image namespace.
+// An image created while the hosted game runs, i.e. in Host.Update and Host.Draw, is on the Host's own texture atlases,
+// and never shares an atlas with the host application's images or another Host's images,
+// unless ebiten.NewImageOptions.AtlasGroup is specified explicitly.
+// Then, the hosted game's atlas reallocations don't affect the host application's images, and vice versa.
+//
+// A Host doesn't have its own graphics device or command queue.
+// Ebitengine has only one graphics device and one command queue in a process,
+// and the hosted game's rendering commands are flushed together with the host application's ones.
+// Images created by the hosted game are not deallocated together with the Host.
+//
+// Input functions like ebiten.CursorPosition report the input of the whole window.
+// Use Host.ScreenPosition to convert a position in the panel to the hosted game's screen.
+package gamehost
+
+import (
+	"image"
+	"math"
+
+	"github.com/duplicants-ai/ebiten"
+	"github.com/duplicants-ai/ebiten/internal/atlas"
+)
+
+// Host runs a Game inside another game.
+//
+// Host is not concurrent-safe.
+type Host struct {
+	game   ebiten.Game
+	screen *ebiten.Image
+
+	// atlasGroup is the private atlas group for the images created by the hosted game.
+	atlasGroup int
+
+	// outsideWidth and outsideHeight are the size of the panel at the last Draw.
+	outsideWidth  float64
+	outsideHeight float64
+
+	screenWidth  float64
+	screenHeight float64
+
+	// geoM is the matrix to render the screen onto the panel at the last Draw.
+	geoM ebiten.GeoM
+}
+
+// New creates a new Host for the game.
+func New(game ebiten.Game) *Host {
+	return &Host{
+		game:       game,
+		atlasGroup: atlas.NewPrivateAtlasGroup(),
+	}
+}
+
+// Game returns the hosted game.
+func (h *Host) Game() ebiten.Game {
+	return h.game
+}
+
+// Update updates the hosted game by calling its Update.
+// Update should be called in the host application's Update.
+//
+// The hosted game is updated at the same TPS as the host application.
+// To pause the hosted game, just skip calling Update.
+func (h *Host) Update() error {
+	defer atlas.SetDefaultAtlasGroup(atlas.SetDefaultAtlasGroup(h.atlasGroup))
+	return h.game.Update()
+}
+
+// Draw renders the hosted game onto dst.
+// Draw should be called in the host application's Draw.
+//
+// The bounds of dst are the panel, and the size is given to the hosted game's Layout as the outside size.
+// The hosted game's screen is scaled to fit the panel without changing the aspect ratio, and is put in the center of the panel.
+// The screen is cleared before the hosted game's Draw is called.
+func (h *Host) Draw(dst *ebiten.Image) {
+	b := dst.Bounds()
+	h.drawGame(float64(b.Dx()), float64(b.Dy()))
+
+	scale := math.Min(h.outsideWidth/h.screenWidth, h.outsideHeight/h.screenHeight)
+	h.geoM.Reset()
+	h.geoM.Scale(scale, scale)
+	h.geoM.Translate((h.outsideWidth-h.screenWidth*scale)/2, (h.outsideHeight-h.screenHeight*scale)/2)
+	h.geoM.Translate(float64(b.Min.X), float64(b.Min.Y))
+
+	op := &ebiten.DrawImageOptions{}
+	op.GeoM = h.geoM
+	op.Filter = ebiten.FilterLinear
+	if scale == math.Trunc(scale) {
+		op.Filter = ebiten.FilterNearest
+	}
+	dst.DrawImage(h.screen, op)
+}
+
+// drawGame calls the hosted game's Layout and Draw with the Host's atlas group.
+func (h *Host) drawGame(outsideWidth, outsideHeight float64) {
+	defer atlas.SetDefaultAtlasGroup(atlas.SetDefaultAtlasGroup(h.atlasGroup))
+
+	h.layout(outsideWidth, outsideHeight)
+
+	sw := max(int(math.Ceil(h.screenWidth)), 1)
+	sh := max(int(math.Ceil(h.screenHeight)), 1)
+	if h.screen != nil {
+		if s := h.screen.Bounds(); s.Dx() != sw || s.Dy() != sh {
+			h.screen.Deallocate()
+			h.screen = nil
+		}
+	}
+	if h.screen == nil {
+		// Keep the screen an unmanaged image isolated from the atlas, like the screen given to Game.Draw.
+		h.screen = ebiten.NewImageWithOptions(image.Rect(0, 0, sw, sh), &ebiten.NewImageOptions{
+			Unmanaged: true,
+		})
+	}
+	h.screen.Clear()
+	h.game.Draw(h.screen)
+}
+
+func (h *Host) layout(outsideWidth, outsideHeight float64) {
+	h.outsideWidth = max(outsideWidth, 1)
+	h.outsideHeight = max(outsideHeight, 1)
+	if l, ok := h.game.(ebiten.LayoutFer); ok {
+		h.screenWidth, h.screenHeight = l.LayoutF(h.outsideWidth, h.outsideHeight)
+	} else {
+		sw, sh := h.game.Layout(int(h.outsideWidth), int(h.outsideHeight))
+		h.screenWidth, h.screenHeight = float64(sw), float64(sh)
+	}
+	if h.screenWidth <= 0 || h.screenHeight <= 0 {
+		panic("gamehost: Layout must return positive numbers")
+	}
+}
+
+// Screen returns the hosted game's screen rendered at the last Draw.
+// Screen returns nil before Draw is called.
+func (h *Host) Screen() *ebiten.Image {
+	return h.screen
+}
+
+// ScreenPosition converts a position in the destination image of the last Draw to a position in the hosted game's screen.
+// ok is false if the position is out of the hosted game's screen, or Draw is not called yet.
+//
+// For example, ScreenPosition(ebiten.CursorPositionF()) returns the cursor position in the hosted game's screen
+// when the destination image is the host application's screen.
+func (h *Host) ScreenPosition(x, y float64) (sx, sy float64, ok bool) {
+	if h.screen == nil {
+		return 0, 0, false
+	}
+	g := h.geoM
+	g.Invert()
+	sx, sy = g.Apply(x, y)
+	if sx < 0 || sy < 0 || sx >= h.screenWidth || sy >= h.screenHeight {
+		return sx, sy, false
+	}
+	return sx, sy, true
+}
+
+// Deallocate deallocates the hosted game's screen.
+// The other images created by the hosted game are not deallocated.
+//
+// The Host can be used again after Deallocate.
+func (h *Host) Deallocate() {
+	if h.screen == nil {
+		return
+	}
+	h.screen.Deallocate()
+	h.screen = nil
+}
@@ -0,0 +1,143 @@
+// Copyright 2026 The Ebitengine Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package gamehost_test
+
+import (
+	"image/color"
+	"testing"
+
+	"github.com/duplicants-ai/ebiten"
+	"github.com/duplicants-ai/ebiten/exp/gamehost"
+	"github.com/duplicants-ai/ebiten/internal/atlas"
+	etesting "github.com/duplicants-ai/ebiten/internal/testing"
+	"github.com/duplicants-ai/ebiten/internal/ui"
+)
+
+func TestMain(m *testing.M) {
+	ui.SetPanicOnErrorOnReadingPixelsForTesting(true)
+	etesting.MainWithRunLoop(m)
+}
+
+type fillGame struct {
+	updateCount int
+}
+
+func (g *fillGame) Update() error {
+	g.updateCount++
+	return nil
+}
+
+func (g *fillGame) Draw(screen *ebiten.Image) {
+	screen.Fill(color.RGBA{R: 0xff, A: 0xff})
+}
+
+func (g *fillGame) Layout(outsideWidth, outsideHeight int) (int, int) {
+	return 8, 8
+}
+
+func TestHostDraw(t *testing.T) {
+	game := &fillGame{}
+	h := gamehost.New(game)
+	if err := h.Update(); err != nil {
+		t.Fatal(err)
+	}
+	if got, want := game.updateCount, 1; got != want {
+		t.Errorf("updateCount: got: %d, want: %d", got, want)
+	}
+
+	// The screen (8x8) is scaled by 2 and put in the center of the panel (32x16).
+	dst := ebiten.NewImage(32, 16)
+	h.Draw(dst)
+
+	if got, want := h.Screen().Bounds().Dx(), 8; got != want {
+		t.Errorf("screen width: got: %d, want: %d", got, want)
+	}
+	for _, tc := range []struct {
+		X    int
+		Y    int
+		Want color.RGBA
+	}{
+		{X: 0, Y: 0, Want: color.RGBA{}},
+		{X: 7, Y: 8, Want: color.RGBA{}},
+		{X: 8, Y: 0, Want: color.RGBA{R: 0xff, A: 0xff}},
+		{X: 23, Y: 15, Want: color.RGBA{R: 0xff, A: 0xff}},
+		{X: 24, Y: 8, Want: color.RGBA{}},
+	} {
+		if got := dst.At(tc.X, tc.Y).(color.RGBA); got != tc.Want {
+			t.Errorf("dst.At(%d, %d): got: %v, want: %v", tc.X, tc.Y, got, tc.Want)
+		}
+	}
+
+	x, y, ok := h.ScreenPosition(10, 4)
+	if !ok || x != 1 || y != 2 {
+		t.Errorf("ScreenPosition(10, 4): got: (%f, %f, %t), want: (1, 2, true)", x, y, ok)
+	}
+	if _, _, ok := h.ScreenPosition(4, 4); ok {
+		t.Errorf("ScreenPosition(4, 4): got: true, want: false")
+	}
+}
+
+type atlasGroupGame struct {
+	updateGroup int
+	drawGroup   int
+	img         *ebiten.Image
+}
+
+func (g *atlasGroupGame) Update() error {
+	g.updateGroup = atlas.DefaultAtlasGroup()
+	return nil
+}
+
+func (g *atlasGroupGame) Draw(screen *ebiten.Image) {
+	g.drawGroup = atlas.DefaultAtlasGroup()
+	if g.img == nil {
+		g.img = ebiten.NewImage(4, 4)
+		g.img.Fill(color.RGBA{G: 0xff, A: 0xff})
+	}
+	screen.DrawImage(g.img, nil)
+}
+
+func (g *atlasGroupGame) Layout(outsideWidth, outsideHeight int) (int, int) {
+	return 4, 4
+}
+
+func TestHostAtlasGroup(t *testing.T) {
+	game0 := &atlasGroupGame{}
+	h0 := gamehost.New(game0)
+	game1 := &atlasGroupGame{}
+	h1 := gamehost.New(game1)
+
+	dst := ebiten.NewImage(4, 4)
+	for _, h := range []*gamehost.Host{h0, h1} {
+		if err := h.Update(); err != nil {
+			t.Fatal(err)
+		}
+		h.Draw(dst)
+	}
+
+	if game0.updateGroup == 0 || game0.updateGroup != game0.drawGroup {
+		t.Errorf("atlas group in Update and Draw: got: %d and %d, want: the same non-default group", game0.updateGroup, game0.drawGroup)
+	}
+	if game0.updateGroup == game1.updateGroup {
+		t.Errorf("atlas groups of different hosts must be different but both are %d", game0.updateGroup)
+	}
+	if got, want := atlas.DefaultAtlasGroup(), 0; got != want {
+		t.Errorf("atlas.DefaultAtlasGroup() after Draw: got: %d, want: %d", got, want)
+	}
+
+	if got, want := dst.At(1, 1).(color.RGBA), (color.RGBA{G: 0xff, A: 0xff}); got != want {
+		t.Errorf("dst.At(1, 1): got: %v, want: %v", got, want)
+	}
+}
@@ -0,0 +1,127 @@
+// Copyright 2026 The Ebitengine Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package gamepadhid provides raw HID access to gamepads.
+//
+// This package is experimental and the API might be changed in the future.
+//
+// This package is an escape hatch for peripherals that the standard gamepad API cannot handle well,
+// e.g., flight sticks, DJ controllers, and dance pads with LEDs.
+// The user code is responsible for parsing and building the device-specific reports.
+//
+// Raw HID access is supported on Linux and macOS.
+// On Linux, the user must have the permission to read and write the hidraw device (/dev/hidrawN), e.g., by a udev rule.
+// On macOS, ReadInputReport returns the current input report of the device instead of a queued report.
+package gamepadhid
+
+import (
+	"github.com/duplicants-ai/ebiten"
+	"github.com/duplicants-ai/ebiten/internal/gamepad"
+)
+
+// ErrNotSupported is returned when raw HID access is not supported for the gamepad.
+var ErrNotSupported = gamepad.ErrHIDNotSupported
+
+// Info represents the identifiers of a gamepad device.
+type Info struct {
+	// VendorID is the USB vendor ID.
+	VendorID uint16
+
+	// ProductID is the USB product ID.
+	ProductID uint16
+}
+
+// DeviceInfo returns the identifiers of the gamepad (id).
+// ok is false if the gamepad doesn't exist or the identifiers are unknown.
+//
+// DeviceInfo is concurrent-safe.
+func DeviceInfo(id ebiten.GamepadID) (info Info, ok bool) {
+	g := gamepad.Get(id)
+	if g == nil {
+		return Info{}, false
+	}
+	vendor, product, ok := g.VendorProductID()
+	if !ok {
+		return Info{}, false
+	}
+	return Info{
+		VendorID:  vendor,
+		ProductID: product,
+	}, true
+}
+
+// Device is a raw HID device of a gamepad.
+//
+// Each report starts with the report ID.
+// If the device doesn't use numbered reports, the first byte must be 0.
+type Device struct {
+	native gamepad.HIDDevice
+}
+
+// Open opens the raw HID device of the gamepad (id).
+// Open returns ErrNotSupported if the platform or the gamepad doesn't support raw HID access.
+//
+// The standard gamepad API keeps working while the device is open.
+// The device should be closed by Close when it is no longer used.
+//
+// Open is concurrent-safe.
+func Open(id ebiten.GamepadID) (*Device, error) {
+	g := gamepad.Get(id)
+	if g == nil {
+		return nil, ErrNotSupported
+	}
+	n, err := g.OpenHID()
+	if err != nil {
+		return nil, err
+	}
+	return &Device{
+		native: n,
+	}, nil
+}
+
+// ReadInputReport reads an input report into report without blocking, and returns the number of the read bytes.
+// ReadInputReport returns 0 if no report is available.
+//
+// ReadInputReport is concurrent-safe.
+func (d *Device) ReadInputReport(report []byte) (int, error) {
+	return d.native.ReadInputReport(report)
+}
+
+// WriteOutputReport writes an output report, e.g., to control LEDs, and returns the number of the written bytes.
+//
+// WriteOutputReport is concurrent-safe.
+func (d *Device) WriteOutputReport(report []byte) (int, error) {
+	return d.native.WriteOutputReport(report)
+}
+
+// GetFeatureReport gets a feature report whose report ID is report[0] into report, and returns the number of the read bytes.
+//
+// GetFeatureReport is concurrent-safe.
+func (d *Device) GetFeatureReport(report []byte) (int, error) {
+	return d.native.GetFeatureReport(report)
+}
+
+// SendFeatureReport sends a feature report, and returns the number of the written bytes.
+//
+// SendFeatureReport is concurrent-safe.
+func (d *Device) SendFeatureReport(report []byte) (int, error) {
+	return d.native.SendFeatureReport(report)
+}
+
+// Close closes the device.
+//
+// Close is concurrent-safe.
+func (d *Device) Close() error {
+	return d.native.Close()
+}
@@ -0,0 +1,188 @@
+// Copyright 2026 The Ebitengine Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package letterbox provides utilities to put a game area with a fixed aspect ratio in a screen of any size.
+//
+// This package is experimental and the API might be changed in the future.
+//
+// A typical usage is a game whose Layout returns the outside size as it is to render in the native resolution.
+// In Layout, the game calls Fit with its logical size and the outside size, and uses the returned Box at Update and Draw
+// to render the game area and to convert input positions.
+package letterbox
+
+import (
+	"math"
+
+	"github.com/duplicants-ai/ebiten"
+)
+
+// Insets represents margins on each side of a rectangle.
+type Insets struct {
+	Left   float64
+	Top    float64
+	Right  float64
+	Bottom float64
+}
+
+// SafeArea returns the safe-area insets of the outside, which is the result of ebiten.SafeAreaInsets.
+//
+// The insets are in device-independent pixels.
+// If Layout returns the outside size multiplied by the device scale factor, multiply the insets by the same factor.
+func SafeArea() Insets {
+	l, t, r, b := ebiten.SafeAreaInsets()
+	return Insets{
+		Left:   l,
+		Top:    t,
+		Right:  r,
+		Bottom: b,
+	}
+}
+
+// Options represents options for Fit.
+type Options struct {
+	// SafeArea is the insets of the outside that might be obscured, e.g. by notches and system bars.
+	SafeArea Insets
+
+	// FitInSafeArea specifies whether the game area is fitted in the safe area.
+	// If FitInSafeArea is false, the game area is fitted in the whole outside, and
+	// the obscured part of the game area is available by Box.SafeAreaInsets.
+	FitInSafeArea bool
+
+	// IntegerScale specifies whether the scale is rounded down to an integer, e.g. for pixel art.
+	// If the outside is smaller than the game area, the scale is not rounded.
+	IntegerScale bool
+}
+
+// Box represents a game area put in the outside.
+//
+// The outside coordinate is the coordinate of the size given to Fit, e.g. the screen given to Draw.
+// The game coordinate is the coordinate of the game's logical size.
+type Box struct {
+	// X and Y are the upper-left position of the game area in the outside coordinate.
+	X float64
+	Y float64
+
+	// Width and Height are the size of the game area in the outside coordinate.
+	Width  float64
+	Height float64
+
+	// Scale is the scale from the game coordinate to the outside coordinate.
+	Scale float64
+
+	gameWidth     float64
+	gameHeight    float64
+	outsideWidth  float64
+	outsideHeight float64
+	safeArea      Insets
+}
+
+// Fit returns a Box to put a game area of the size (gameWidth, gameHeight) in the center of the outside
+// without changing the aspect ratio.
+//
+// The sizes must be positive. Otherwise, Fit panics.
+// options can be nil.
+func Fit(gameWidth, gameHeight float64, outsideWidth, outsideHeight float64, options *Options) Box {
+	if gameWidth <= 0 || gameHeight <= 0 {
+		panic("letterbox: the game size must be positive")
+	}
+	if outsideWidth <= 0 || outsideHeight <= 0 {
+		panic("letterbox: the outside size must be positive")
+	}
+	if options == nil {
+		options = &Options{}
+	}
+
+	// The area to fit the game area in.
+	ax, ay, aw, ah := 0.0, 0.0, outsideWidth, outsideHeight
+	if options.FitInSafeArea {
+		s := options.SafeArea
+		ax, ay = s.Left, s.Top
+		aw = max(outsideWidth-s.Left-s.Right, 0)
+		ah = max(outsideHeight-s.Top-s.Bottom, 0)
+	}
+
+	scale := math.Min(aw/gameWidth, ah/gameHeight)
+	if options.IntegerScale && scale >= 1 {
+		scale = math.Floor(scale)
+	}
+	w := gameWidth * scale
+	h := gameHeight * scale
+	return Box{
+		X:             ax + (aw-w)/2,
+		Y:             ay + (ah-h)/2,
+		Width:         w,
+		Height:        h,
+		Scale:         scale,
+		gameWidth:     gameWidth,
+		gameHeight:    gameHeight,
+		outsideWidth:  outsideWidth,
+		outsideHeight: outsideHeight,
+		safeArea:      options.SafeArea,
+	}
+}
+
+// GeoM returns a geometry matrix to render the game area onto the outside.
+func (b Box) GeoM() ebiten.GeoM {
+	var g ebiten.GeoM
+	g.Scale(b.Scale, b.Scale)
+	g.Translate(b.X, b.Y)
+	return g
+}
+
+// Contains reports whether the position in the outside coordinate is in the game area.
+func (b Box) Contains(x, y float64) bool {
+	return b.X <= x && x < b.X+b.Width && b.Y <= y && y < b.Y+b.Height
+}
+
+// OutsideToGame converts a position in the outside coordinate, e.g. a cursor position, to the game coordinate.
+// ok is false if the position is out of the game area.
+func (b Box) OutsideToGame(x, y float64) (gameX, gameY float64, ok bool) {
+	if b.Scale == 0 {
+		return 0, 0, false
+	}
+	return (x - b.X) / b.Scale, (y - b.Y) / b.Scale, b.Contains(x, y)
+}
+
+// GameToOutside converts a position in the game coordinate to the outside coordinate.
+func (b Box) GameToOutside(x, y float64) (outsideX, outsideY float64) {
+	return x*b.Scale + b.X, y*b.Scale + b.Y
+}
+
+// Margins returns the sizes of the letterbox bars around the game area in the outside coordinate.
+func (b Box) Margins() Insets {
+	return Insets{
+		Left:   b.X,
+		Top:    b.Y,
+		Right:  b.outsideWidth - b.X - b.Width,
+		Bottom: b.outsideHeight - b.Y - b.Height,
+	}
+}
+
+// SafeAreaInsets returns the insets of the game area obscured by the outside's safe-area insets, in the game coordinate.
+//
+// Games can use the insets to keep important UI elements like HUD visible.
+// If the game area is in the safe area, e.g. with Options.FitInSafeArea, SafeAreaInsets returns zeros.
+func (b Box) SafeAreaInsets() Insets {
+	if b.Scale == 0 {
+		return Insets{}
+	}
+	m := b.Margins()
+	s := b.safeArea
+	return Insets{
+		Left:   min(max(s.Left-m.Left, 0)/b.Scale, b.gameWidth),
+		Top:    min(max(s.Top-m.Top, 0)/b.Scale, b.gameHeight),
+		Right:  min(max(s.Right-m.Right, 0)/b.Scale, b.gameWidth),
+		Bottom: min(max(s.Bottom-m.Bottom, 0)/b.Scale, b.gameHeight),
+	}
+}
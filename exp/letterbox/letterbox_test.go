@@ -0,0 +1,120 @@
+// Copyright 2026 The Ebitengine Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package letterbox_test
+
+import (
+	"testing"
+
+	"github.com/duplicants-ai/ebiten/exp/letterbox"
+)
+
+func TestFit(t *testing.T) {
+	testCases := []struct {
+		Name    string
+		Options *letterbox.Options
+		Outside [2]float64
+		X       float64
+		Y       float64
+		Scale   float64
+		Margins letterbox.Insets
+		Safe    letterbox.Insets
+	}{
+		{
+			Name:    "pillarbox",
+			Outside: [2]float64{640, 240},
+			X:       160,
+			Y:       0,
+			Scale:   1,
+			Margins: letterbox.Insets{Left: 160, Right: 160},
+		},
+		{
+			Name:    "letterbox",
+			Outside: [2]float64{640, 960},
+			X:       0,
+			Y:       240,
+			Scale:   2,
+			Margins: letterbox.Insets{Top: 240, Bottom: 240},
+		},
+		{
+			Name:    "integer scale",
+			Options: &letterbox.Options{IntegerScale: true},
+			Outside: [2]float64{800, 600},
+			X:       80,
+			Y:       60,
+			Scale:   2,
+			Margins: letterbox.Insets{Left: 80, Top: 60, Right: 80, Bottom: 60},
+		},
+		{
+			Name: "safe area",
+			Options: &letterbox.Options{
+				SafeArea: letterbox.Insets{Left: 40, Bottom: 20},
+			},
+			Outside: [2]float64{640, 480},
+			X:       0,
+			Y:       0,
+			Scale:   2,
+			Safe:    letterbox.Insets{Left: 20, Bottom: 10},
+		},
+		{
+			Name: "fit in safe area",
+			Options: &letterbox.Options{
+				SafeArea:      letterbox.Insets{Left: 40, Bottom: 20},
+				FitInSafeArea: true,
+			},
+			Outside: [2]float64{680, 500},
+			X:       40,
+			Y:       0,
+			Scale:   2,
+			Margins: letterbox.Insets{Left: 40, Bottom: 20},
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.Name, func(t *testing.T) {
+			b := letterbox.Fit(320, 240, tc.Outside[0], tc.Outside[1], tc.Options)
+			if b.X != tc.X || b.Y != tc.Y || b.Scale != tc.Scale {
+				t.Errorf("(X, Y, Scale): got: (%f, %f, %f), want: (%f, %f, %f)", b.X, b.Y, b.Scale, tc.X, tc.Y, tc.Scale)
+			}
+			if got := b.Margins(); got != tc.Margins {
+				t.Errorf("Margins(): got: %+v, want: %+v", got, tc.Margins)
+			}
+			if got := b.SafeAreaInsets(); got != tc.Safe {
+				t.Errorf("SafeAreaInsets(): got: %+v, want: %+v", got, tc.Safe)
+			}
+		})
+	}
+}
+
+func TestOutsideToGame(t *testing.T) {
+	b := letterbox.Fit(320, 240, 640, 960, nil)
+
+	x, y, ok := b.OutsideToGame(100, 340)
+	if !ok || x != 50 || y != 50 {
+		t.Errorf("OutsideToGame(100, 340): got: (%f, %f, %t), want: (50, 50, true)", x, y, ok)
+	}
+	if _, _, ok := b.OutsideToGame(100, 100); ok {
+		t.Errorf("OutsideToGame(100, 100): got: true, want: false")
+	}
+
+	ox, oy := b.GameToOutside(x, y)
+	if ox != 100 || oy != 340 {
+		t.Errorf("GameToOutside(%f, %f): got: (%f, %f), want: (100, 340)", x, y, ox, oy)
+	}
+
+	g := b.GeoM()
+	gx, gy := g.Apply(50, 50)
+	if gx != 100 || gy != 340 {
+		t.Errorf("GeoM().Apply(50, 50): got: (%f, %f), want: (100, 340)", gx, gy)
+	}
+}
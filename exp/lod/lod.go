@@ -0,0 +1,172 @@
+// Copyright 2026 The Ebitengine Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package lod provides level-of-detail images, which switch their resolutions depending on the drawing scale.
+//
+// This package is experimental and the API might be changed in the future.
+//
+// Drawing a large image shrunk, like a sprite on a zoomed-out strategy map, can cause shimmering.
+// An Image holds multiple resolutions of the same picture, and draws the smallest one that keeps enough details for the scale.
+// The levels can be given explicitly, e.g. hand-drawn icons for small sizes, or generated by halving the original image.
+package lod
+
+import (
+	"fmt"
+	"image"
+	"math"
+
+	"github.com/duplicants-ai/ebiten"
+)
+
+// Image is a set of images of the same picture with different resolutions.
+//
+// The level 0 image has the full resolution, and higher-level images have lower resolutions.
+type Image struct {
+	levels []*ebiten.Image
+	scales []scale
+}
+
+type scale struct {
+	x, y float64
+}
+
+// New creates a new Image from the given levels.
+//
+// levels[0] is the full-resolution image, and the size of the Image is the size of levels[0].
+// Each of the other levels must not be larger than the previous level in each dimension.
+// The levels don't have to be the halves of the previous levels.
+//
+// New panics if no levels are given, or if the sizes of the levels are invalid.
+func New(levels ...*ebiten.Image) *Image {
+	if len(levels) == 0 {
+		panic("lod: at least one level must be given")
+	}
+	w0, h0 := levels[0].Bounds().Dx(), levels[0].Bounds().Dy()
+	if w0 == 0 || h0 == 0 {
+		panic(fmt.Sprintf("lod: the level 0 image must not be empty but the size is (%d, %d)", w0, h0))
+	}
+	i := &Image{
+		levels: make([]*ebiten.Image, len(levels)),
+		scales: make([]scale, len(levels)),
+	}
+	copy(i.levels, levels)
+	pw, ph := w0, h0
+	for l, img := range levels {
+		w, h := img.Bounds().Dx(), img.Bounds().Dy()
+		if w == 0 || h == 0 || w > pw || h > ph {
+			panic(fmt.Sprintf("lod: the level %d image size (%d, %d) must be non-empty and must not be larger than the previous level (%d, %d)", l, w, h, pw, ph))
+		}
+		i.scales[l] = scale{
+			x: float64(w) / float64(w0),
+			y: float64(h) / float64(h0),
+		}
+		pw, ph = w, h
+	}
+	return i
+}
+
+// Generate creates a new Image by halving img repeatedly.
+//
+// Generate creates at most count levels including img itself as the level 0.
+// If count is 0 or less, Generate creates levels until the image becomes 1x1.
+//
+// The generated levels are not updated when img is modified later.
+func Generate(img *ebiten.Image, count int) *Image {
+	levels := []*ebiten.Image{img}
+	w, h := img.Bounds().Dx(), img.Bounds().Dy()
+	for count <= 0 || len(levels) < count {
+		if w <= 1 && h <= 1 {
+			break
+		}
+		nw, nh := max(w/2, 1), max(h/2, 1)
+		next := ebiten.NewImage(nw, nh)
+		op := &ebiten.DrawImageOptions{}
+		op.GeoM.Scale(float64(nw)/float64(w), float64(nh)/float64(h))
+		op.Filter = ebiten.FilterLinear
+		op.Blend = ebiten.BlendCopy
+		// The level is already shrunk by a half, so mipmaps are not needed.
+		op.DisableMipmaps = true
+		next.DrawImage(levels[len(levels)-1], op)
+		levels = append(levels, next)
+		w, h = nw, nh
+	}
+	return New(levels...)
+}
+
+// Bounds returns the bounds of the level 0 image.
+func (i *Image) Bounds() image.Rectangle {
+	return i.levels[0].Bounds()
+}
+
+// LevelCount returns the number of the levels.
+func (i *Image) LevelCount() int {
+	return len(i.levels)
+}
+
+// Level returns the image of the given level.
+//
+// Level panics if level is out of range.
+func (i *Image) Level(level int) *ebiten.Image {
+	if level < 0 || level >= len(i.levels) {
+		panic(fmt.Sprintf("lod: level %d is out of range [0, %d)", level, len(i.levels)))
+	}
+	return i.levels[level]
+}
+
+// LevelForGeoM returns the level used to draw the Image with the given geometry matrix.
+//
+// The chosen level is the smallest one that still has at least one texel per destination pixel in each axis.
+func (i *Image) LevelForGeoM(geoM ebiten.GeoM) int {
+	a, b, c, d := geoM.Element(0, 0), geoM.Element(0, 1), geoM.Element(1, 0), geoM.Element(1, 1)
+	// The lengths of the transformed unit vectors are the scales in each axis.
+	return levelForScale(i.scales, math.Hypot(a, c), math.Hypot(b, d))
+}
+
+// levelForScale returns the highest level whose scale is not smaller than (sx, sy).
+func levelForScale(scales []scale, sx, sy float64) int {
+	// Allow a small error so that an exact scale like 0.5 picks the level of the same scale.
+	const eps = 1e-6
+	level := 0
+	for l := 1; l < len(scales); l++ {
+		if scales[l].x+eps < sx || scales[l].y+eps < sy {
+			break
+		}
+		level = l
+	}
+	return level
+}
+
+// Draw draws the Image onto dst with the level chosen by LevelForGeoM.
+//
+// The options are treated as if the level 0 image were drawn, so the result has the same position and size regardless of the level.
+//
+// Filter should be FilterLinear, as the chosen level can still be drawn shrunk up to a half.
+// DisableMipmaps is respected.
+// With generated levels, mipmaps are rarely needed and disabling them can reduce the cost,
+// though mipmaps still help when the image is shrunk much more in one axis than in the other.
+func (i *Image) Draw(dst *ebiten.Image, options *ebiten.DrawImageOptions) {
+	var op ebiten.DrawImageOptions
+	if options != nil {
+		op = *options
+	}
+	level := i.LevelForGeoM(op.GeoM)
+	if level > 0 {
+		s := i.scales[level]
+		var g ebiten.GeoM
+		g.Scale(1/s.x, 1/s.y)
+		g.Concat(op.GeoM)
+		op.GeoM = g
+	}
+	dst.DrawImage(i.levels[level], &op)
+}
@@ -0,0 +1,50 @@
+// Copyright 2026 The Ebitengine Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package lod
+
+import (
+	"testing"
+)
+
+func TestLevelForScale(t *testing.T) {
+	halves := []scale{{1, 1}, {0.5, 0.5}, {0.25, 0.25}, {0.125, 0.125}}
+	custom := []scale{{1, 1}, {0.75, 0.5}, {0.25, 0.25}}
+	cases := []struct {
+		scales []scale
+		sx, sy float64
+		want   int
+	}{
+		{scales: halves, sx: 2, sy: 2, want: 0},
+		{scales: halves, sx: 1, sy: 1, want: 0},
+		{scales: halves, sx: 0.6, sy: 0.6, want: 0},
+		{scales: halves, sx: 0.5, sy: 0.5, want: 1},
+		{scales: halves, sx: 0.3, sy: 0.3, want: 1},
+		{scales: halves, sx: 0.2, sy: 0.2, want: 2},
+		{scales: halves, sx: 0.01, sy: 0.01, want: 3},
+		{scales: halves, sx: 0, sy: 0, want: 3},
+		{scales: halves, sx: 0.1, sy: 0.4, want: 1},
+		{scales: halves, sx: 0.4, sy: 0.1, want: 1},
+		{scales: custom, sx: 0.7, sy: 0.5, want: 1},
+		{scales: custom, sx: 0.8, sy: 0.5, want: 0},
+		{scales: custom, sx: 0.5, sy: 0.5, want: 1},
+		{scales: custom, sx: 0.25, sy: 0.25, want: 2},
+		{scales: []scale{{1, 1}}, sx: 0.01, sy: 0.01, want: 0},
+	}
+	for _, c := range cases {
+		if got := levelForScale(c.scales, c.sx, c.sy); got != c.want {
+			t.Errorf("levelForScale(%v, %v, %v): got: %d, want: %d", c.scales, c.sx, c.sy, got, c.want)
+		}
+	}
+}
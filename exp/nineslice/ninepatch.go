@@ -0,0 +1,174 @@
+// Copyright 2026 The Ebitengine Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package nineslice
+
+import (
+	"errors"
+	"image"
+	"image/color"
+)
+
+// segment is a range along an axis of the source image.
+type segment struct {
+	start   int
+	end     int
+	stretch bool
+}
+
+// segmentsFromMarkers returns segments from the markers along an axis.
+// marker reports whether the i-th pixel along the axis is marked.
+func segmentsFromMarkers(length int, marker func(i int) bool) []segment {
+	var segs []segment
+	for i := 0; i < length; i++ {
+		m := marker(i)
+		if len(segs) > 0 && segs[len(segs)-1].stretch == m {
+			segs[len(segs)-1].end = i + 1
+			continue
+		}
+		segs = append(segs, segment{start: i, end: i + 1, stretch: m})
+	}
+	return segs
+}
+
+// segmentsFromInsets returns segments with one stretchable segment between the insets.
+func segmentsFromInsets(length int, start, end int) []segment {
+	var segs []segment
+	if start > 0 {
+		segs = append(segs, segment{start: 0, end: start})
+	}
+	if start < length-end {
+		segs = append(segs, segment{start: start, end: length - end, stretch: true})
+	}
+	if end > 0 {
+		segs = append(segs, segment{start: length - end, end: length})
+	}
+	return segs
+}
+
+// markerRange returns the range of the marked pixels along an axis.
+// ok is false if no pixels are marked.
+func markerRange(length int, marker func(i int) bool) (start, end int, ok bool) {
+	start = -1
+	for i := 0; i < length; i++ {
+		if !marker(i) {
+			continue
+		}
+		if start < 0 {
+			start = i
+		}
+		end = i + 1
+	}
+	return start, end, start >= 0
+}
+
+func isMarker(c color.Color) bool {
+	r, g, b, a := c.RGBA()
+	return r == 0 && g == 0 && b == 0 && a == 0xffff
+}
+
+type ninePatch struct {
+	content   image.Rectangle
+	xSegments []segment
+	ySegments []segment
+	padding   image.Rectangle
+}
+
+// parseNinePatch reads the markers of a nine-patch image.
+//
+// The top and left edges mark the stretchable areas, and the bottom and right edges mark the content area.
+// The positions are relative to the image without the edges.
+func parseNinePatch(img image.Image) (*ninePatch, error) {
+	b := img.Bounds()
+	w, h := b.Dx()-2, b.Dy()-2
+	if w <= 0 || h <= 0 {
+		return nil, errors.New("nineslice: a nine-patch image must be larger than 2x2")
+	}
+
+	top := func(i int) bool {
+		return isMarker(img.At(b.Min.X+1+i, b.Min.Y))
+	}
+	left := func(i int) bool {
+		return isMarker(img.At(b.Min.X, b.Min.Y+1+i))
+	}
+	bottom := func(i int) bool {
+		return isMarker(img.At(b.Min.X+1+i, b.Max.Y-1))
+	}
+	right := func(i int) bool {
+		return isMarker(img.At(b.Max.X-1, b.Min.Y+1+i))
+	}
+
+	p := &ninePatch{
+		content:   image.Rect(b.Min.X+1, b.Min.Y+1, b.Max.X-1, b.Max.Y-1),
+		xSegments: segmentsFromMarkers(w, top),
+		ySegments: segmentsFromMarkers(h, left),
+	}
+
+	// If the content area is not marked, the stretchable area is used.
+	x0, x1, ok := markerRange(w, bottom)
+	if !ok {
+		x0, x1, ok = markerRange(w, top)
+	}
+	if !ok {
+		x0, x1 = 0, w
+	}
+	y0, y1, ok := markerRange(h, right)
+	if !ok {
+		y0, y1, ok = markerRange(h, left)
+	}
+	if !ok {
+		y0, y1 = 0, h
+	}
+	p.padding = image.Rect(x0, y0, x1, y1)
+
+	return p, nil
+}
+
+// layoutSegments returns the destination lengths of the segments to fill the length.
+//
+// The fixed segments keep their sizes, and the stretchable segments share the rest in proportion to their sizes.
+// If the length is not enough for the fixed segments, the fixed segments are shrunk and the stretchable segments are collapsed.
+func layoutSegments(segs []segment, length float64) []float64 {
+	var fixed, stretch float64
+	for _, s := range segs {
+		if s.stretch {
+			stretch += float64(s.end - s.start)
+		} else {
+			fixed += float64(s.end - s.start)
+		}
+	}
+
+	lens := make([]float64, len(segs))
+	rest := length - fixed
+	for i, s := range segs {
+		l := float64(s.end - s.start)
+		switch {
+		case rest < 0:
+			if !s.stretch && fixed > 0 {
+				lens[i] = l * length / fixed
+			}
+		case s.stretch:
+			lens[i] = rest * l / stretch
+		default:
+			lens[i] = l
+		}
+	}
+	if stretch == 0 && rest > 0 && fixed > 0 {
+		// Nothing is stretchable. Scale all the segments.
+		for i := range lens {
+			lens[i] *= length / fixed
+		}
+	}
+	return lens
+}
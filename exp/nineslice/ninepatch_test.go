@@ -0,0 +1,143 @@
+// Copyright 2026 The Ebitengine Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package nineslice
+
+import (
+	"image"
+	"image/color"
+	"reflect"
+	"testing"
+)
+
+// newNinePatchImage creates a nine-patch image from rows, where '#' is a marker.
+func newNinePatchImage(rows ...string) *image.NRGBA {
+	img := image.NewNRGBA(image.Rect(0, 0, len(rows[0]), len(rows)))
+	for j, row := range rows {
+		for i, c := range row {
+			switch c {
+			case '#':
+				img.Set(i, j, color.NRGBA{0, 0, 0, 0xff})
+			case 'r':
+				img.Set(i, j, color.NRGBA{0xff, 0, 0, 0xff})
+			case 'o':
+				img.Set(i, j, color.NRGBA{0x80, 0x80, 0x80, 0xff})
+			}
+		}
+	}
+	return img
+}
+
+func TestParseNinePatch(t *testing.T) {
+	img := newNinePatchImage(
+		".##..#..",
+		"#oooooo.",
+		".oooooo.",
+		"#oooooo#",
+		".oooooo#",
+		".##r....",
+	)
+	p, err := parseNinePatch(img)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got, want := p.content, image.Rect(1, 1, 7, 5); got != want {
+		t.Errorf("content: got: %v, want: %v", got, want)
+	}
+	wantX := []segment{
+		{start: 0, end: 2, stretch: true},
+		{start: 2, end: 4},
+		{start: 4, end: 5, stretch: true},
+		{start: 5, end: 6},
+	}
+	if !reflect.DeepEqual(p.xSegments, wantX) {
+		t.Errorf("xSegments: got: %v, want: %v", p.xSegments, wantX)
+	}
+	wantY := []segment{
+		{start: 0, end: 1, stretch: true},
+		{start: 1, end: 2},
+		{start: 2, end: 3, stretch: true},
+		{start: 3, end: 4},
+	}
+	if !reflect.DeepEqual(p.ySegments, wantY) {
+		t.Errorf("ySegments: got: %v, want: %v", p.ySegments, wantY)
+	}
+	// The red pixel is not a marker.
+	if got, want := p.padding, image.Rect(0, 2, 2, 4); got != want {
+		t.Errorf("padding: got: %v, want: %v", got, want)
+	}
+}
+
+func TestParseNinePatchDefaultPadding(t *testing.T) {
+	img := newNinePatchImage(
+		"..#..",
+		"#ooo.",
+		".ooo.",
+		".ooo.",
+		".....",
+	)
+	p, err := parseNinePatch(img)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got, want := p.padding, image.Rect(1, 0, 2, 1); got != want {
+		t.Errorf("padding: got: %v, want: %v", got, want)
+	}
+}
+
+func TestParseNinePatchTooSmall(t *testing.T) {
+	if _, err := parseNinePatch(image.NewNRGBA(image.Rect(0, 0, 2, 5))); err == nil {
+		t.Errorf("parseNinePatch must return an error for a too small image")
+	}
+}
+
+func TestLayoutSegments(t *testing.T) {
+	segs := []segment{
+		{start: 0, end: 4},
+		{start: 4, end: 6, stretch: true},
+		{start: 6, end: 8},
+		{start: 8, end: 14, stretch: true},
+		{start: 14, end: 16},
+	}
+	testCases := []struct {
+		length float64
+		want   []float64
+	}{
+		{length: 16, want: []float64{4, 2, 2, 6, 2}},
+		{length: 24, want: []float64{4, 4, 2, 12, 2}},
+		{length: 8, want: []float64{4, 0, 2, 0, 2}},
+		{length: 4, want: []float64{2, 0, 1, 0, 1}},
+	}
+	for _, tc := range testCases {
+		if got := layoutSegments(segs, tc.length); !reflect.DeepEqual(got, tc.want) {
+			t.Errorf("layoutSegments(%v): got: %v, want: %v", tc.length, got, tc.want)
+		}
+	}
+}
+
+func TestSegmentsFromInsets(t *testing.T) {
+	testCases := []struct {
+		length, start, end int
+		want               []segment
+	}{
+		{length: 10, start: 2, end: 3, want: []segment{{0, 2, false}, {2, 7, true}, {7, 10, false}}},
+		{length: 10, start: 0, end: 0, want: []segment{{0, 10, true}}},
+		{length: 10, start: 4, end: 6, want: []segment{{0, 4, false}, {4, 10, false}}},
+	}
+	for _, tc := range testCases {
+		if got := segmentsFromInsets(tc.length, tc.start, tc.end); !reflect.DeepEqual(got, tc.want) {
+			t.Errorf("segmentsFromInsets(%d, %d, %d): got: %v, want: %v", tc.length, tc.start, tc.end, got, tc.want)
+		}
+	}
+}
@@ -0,0 +1,153 @@
+// Copyright 2026 The Ebitengine Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package nineslice provides a nine-slice drawer, which draws an image scaled without stretching its corners and edges.
+//
+// This package is experimental and the API might be changed in the future.
+//
+// A NineSlice can be created with insets, or from an Android-style nine-patch image (.9.png),
+// whose 1-pixel border marks the stretchable areas and the content area.
+package nineslice
+
+import (
+	"fmt"
+	"image"
+
+	"github.com/duplicants-ai/ebiten"
+)
+
+// NineSlice is an image with stretchable areas.
+type NineSlice struct {
+	img       *ebiten.Image
+	xSegments []segment
+	ySegments []segment
+	padding   image.Rectangle
+}
+
+// New creates a new NineSlice with the insets of the fixed areas.
+//
+// The area between the insets is stretchable, and the corners and the edges keep their sizes.
+// The content area is the stretchable area.
+//
+// New panics if the insets are negative or larger than the image.
+func New(img *ebiten.Image, left, top, right, bottom int) *NineSlice {
+	w, h := img.Bounds().Dx(), img.Bounds().Dy()
+	if left < 0 || top < 0 || right < 0 || bottom < 0 {
+		panic(fmt.Sprintf("nineslice: insets must be non-negative but (%d, %d, %d, %d)", left, top, right, bottom))
+	}
+	if left+right > w || top+bottom > h {
+		panic(fmt.Sprintf("nineslice: insets (%d, %d, %d, %d) must be within the image size (%d, %d)", left, top, right, bottom, w, h))
+	}
+	return &NineSlice{
+		img:       img,
+		xSegments: segmentsFromInsets(w, left, right),
+		ySegments: segmentsFromInsets(h, top, bottom),
+		padding:   image.Rect(left, top, w-right, h-bottom),
+	}
+}
+
+// NewFromNinePatch creates a new NineSlice from an Android-style nine-patch image.
+//
+// A nine-patch image has a 1-pixel border around the image.
+// Opaque black pixels on the top and left edges mark the stretchable areas,
+// and opaque black pixels on the bottom and right edges mark the content area.
+// There can be multiple stretchable areas on each axis, and they share the extra space in proportion to their sizes.
+// If the content area is not marked, the stretchable area is used.
+// The other pixels on the border are ignored.
+//
+// The image without the border is used for drawing.
+//
+// NewFromNinePatch returns an error if the image is too small.
+func NewFromNinePatch(img image.Image) (*NineSlice, error) {
+	p, err := parseNinePatch(img)
+	if err != nil {
+		return nil, err
+	}
+	var eimg *ebiten.Image
+	if sub, ok := img.(interface {
+		SubImage(r image.Rectangle) image.Image
+	}); ok {
+		eimg = ebiten.NewImageFromImage(sub.SubImage(p.content))
+	} else {
+		eimg = ebiten.NewImageFromImage(img).SubImage(p.content).(*ebiten.Image)
+	}
+	return &NineSlice{
+		img:       eimg,
+		xSegments: p.xSegments,
+		ySegments: p.ySegments,
+		padding:   p.padding,
+	}, nil
+}
+
+// Image returns the image of the NineSlice.
+func (n *NineSlice) Image() *ebiten.Image {
+	return n.img
+}
+
+// Padding returns the insets of the content area in the source image's pixels.
+//
+// Padding is useful to place contents like text inside a drawn NineSlice.
+func (n *NineSlice) Padding() (left, top, right, bottom int) {
+	w, h := n.img.Bounds().Dx(), n.img.Bounds().Dy()
+	return n.padding.Min.X, n.padding.Min.Y, w - n.padding.Max.X, h - n.padding.Max.Y
+}
+
+// ContentRect returns the content area when the NineSlice is drawn at rect.
+func (n *NineSlice) ContentRect(rect image.Rectangle) image.Rectangle {
+	left, top, right, bottom := n.Padding()
+	r := image.Rect(rect.Min.X+left, rect.Min.Y+top, rect.Max.X-right, rect.Max.Y-bottom)
+	if r.Dx() < 0 {
+		r.Min.X = (rect.Min.X + rect.Max.X) / 2
+		r.Max.X = r.Min.X
+	}
+	if r.Dy() < 0 {
+		r.Min.Y = (rect.Min.Y + rect.Max.Y) / 2
+		r.Max.Y = r.Min.Y
+	}
+	return r
+}
+
+// Draw draws the NineSlice onto dst so that it fills rect.
+//
+// options can be nil. options.GeoM is applied after the NineSlice is placed at rect.
+func (n *NineSlice) Draw(dst *ebiten.Image, rect image.Rectangle, options *ebiten.DrawImageOptions) {
+	if rect.Empty() {
+		return
+	}
+	if options == nil {
+		options = &ebiten.DrawImageOptions{}
+	}
+
+	xs := layoutSegments(n.xSegments, float64(rect.Dx()))
+	ys := layoutSegments(n.ySegments, float64(rect.Dy()))
+
+	b := n.img.Bounds()
+	op := *options
+	y := float64(rect.Min.Y)
+	for j, sy := range n.ySegments {
+		x := float64(rect.Min.X)
+		for i, sx := range n.xSegments {
+			if xs[i] > 0 && ys[j] > 0 {
+				sub := n.img.SubImage(image.Rect(b.Min.X+sx.start, b.Min.Y+sy.start, b.Min.X+sx.end, b.Min.Y+sy.end)).(*ebiten.Image)
+				op.GeoM.Reset()
+				op.GeoM.Scale(xs[i]/float64(sx.end-sx.start), ys[j]/float64(sy.end-sy.start))
+				op.GeoM.Translate(x, y)
+				op.GeoM.Concat(options.GeoM)
+				dst.DrawImage(sub, &op)
+			}
+			x += xs[i]
+		}
+		y += ys[j]
+	}
+}
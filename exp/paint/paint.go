@@ -0,0 +1,268 @@
+// Copyright 2026 The Ebitengine Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package paint provides a painting API on images, like brush strokes, erasing, and flood fill.
+//
+// This package is experimental and the API might be changed in the future.
+//
+// The API is useful for drawing games, and for revealing fog of war by erasing a fog layer.
+package paint
+
+import (
+	"image"
+	"image/color"
+	"math"
+	"sync"
+
+	"github.com/duplicants-ai/ebiten"
+)
+
+// circleStampSize is the size of the default stamp image.
+const circleStampSize = 64
+
+var circleStamp = sync.OnceValue(func() *ebiten.Image {
+	const r = circleStampSize / 2
+	pix := make([]byte, 4*circleStampSize*circleStampSize)
+	for j := 0; j < circleStampSize; j++ {
+		for i := 0; i < circleStampSize; i++ {
+			d := math.Hypot(float64(i)+0.5-r, float64(j)+0.5-r)
+			// Approximate the coverage of the pixel by the distance to the edge.
+			a := byte(min(max(r-d+0.5, 0), 1) * 0xff)
+			idx := 4 * (j*circleStampSize + i)
+			pix[idx] = a
+			pix[idx+1] = a
+			pix[idx+2] = a
+			pix[idx+3] = a
+		}
+	}
+	img := ebiten.NewImage(circleStampSize, circleStampSize)
+	img.WritePixels(pix)
+	return img
+})
+
+// Brush is a brush to paint stamps on an image.
+type Brush struct {
+	// Image is the stamp image.
+	// If Image is nil, a white filled circle is used.
+	Image *ebiten.Image
+
+	// Size is the size of the stamp in pixels.
+	// The stamp image is scaled so that its larger side is Size.
+	// If Size is 0, the larger side of Image is used, or 1 is used if Image is nil.
+	Size float64
+
+	// Spacing is the distance between the stamps along a stroke, as a ratio of Size.
+	// If Spacing is 0, 0.25 is used.
+	// The distance is at least 1 pixel.
+	Spacing float64
+
+	// ColorScale is the color scale of the stamp.
+	// In the erase mode, only the alpha is used as the strength of erasing.
+	//
+	// The default (zero) value is identity, which is (1, 1, 1, 1).
+	ColorScale ebiten.ColorScale
+
+	// Erase specifies whether the brush erases the destination instead of painting.
+	// In the erase mode, the alpha of the stamp is subtracted from the destination with the 'destination-out' blending.
+	Erase bool
+}
+
+func (b *Brush) stampImage() *ebiten.Image {
+	if b.Image != nil {
+		return b.Image
+	}
+	return circleStamp()
+}
+
+func (b *Brush) size() float64 {
+	if b.Size != 0 {
+		return b.Size
+	}
+	if b.Image == nil {
+		return 1
+	}
+	return float64(max(b.Image.Bounds().Dx(), b.Image.Bounds().Dy()))
+}
+
+func (b *Brush) spacing() float64 {
+	s := b.Spacing
+	if s == 0 {
+		s = 0.25
+	}
+	return max(s*b.size(), 1)
+}
+
+// Stamp draws a stamp centered at (x, y) on dst.
+func (b *Brush) Stamp(dst *ebiten.Image, x, y float64) {
+	img := b.stampImage()
+	w, h := img.Bounds().Dx(), img.Bounds().Dy()
+	if w == 0 || h == 0 {
+		return
+	}
+	s := b.size() / float64(max(w, h))
+
+	op := &ebiten.DrawImageOptions{}
+	op.GeoM.Translate(-float64(w)/2, -float64(h)/2)
+	op.GeoM.Scale(s, s)
+	op.GeoM.Translate(x, y)
+	op.ColorScale = b.ColorScale
+	op.Filter = ebiten.FilterLinear
+	if b.Erase {
+		op.Blend = ebiten.BlendDestinationOut
+	}
+	dst.DrawImage(img, op)
+}
+
+// Stroke is a series of stamps along a path.
+//
+// The stamps are placed at regular intervals along the path, even when the points are added at irregular intervals.
+type Stroke struct {
+	dst   *ebiten.Image
+	brush *Brush
+
+	x, y    float64
+	next    float64
+	started bool
+}
+
+// NewStroke creates a new Stroke to paint on dst with brush.
+//
+// The brush's fields can be changed during the stroke, and the changes are applied to the following stamps.
+func NewStroke(dst *ebiten.Image, brush *Brush) *Stroke {
+	return &Stroke{
+		dst:   dst,
+		brush: brush,
+	}
+}
+
+// AddPoint extends the stroke to (x, y).
+//
+// For the first point, AddPoint draws a stamp at (x, y).
+// For the other points, AddPoint draws stamps on the line segment from the previous point to (x, y).
+func (s *Stroke) AddPoint(x, y float64) {
+	if !s.started {
+		s.brush.Stamp(s.dst, x, y)
+		s.x, s.y = x, y
+		s.next = s.brush.spacing()
+		s.started = true
+		return
+	}
+	s.next = stampPositions(s.x, s.y, x, y, s.next, s.brush.spacing(), func(x, y float64) {
+		s.brush.Stamp(s.dst, x, y)
+	})
+	s.x, s.y = x, y
+}
+
+// Reset ends the current stroke.
+// The next AddPoint starts a new stroke.
+func (s *Stroke) Reset() {
+	s.started = false
+}
+
+// stampPositions calls f with the stamp positions on the line segment from (x0, y0) to (x1, y1).
+//
+// next is the distance from (x0, y0) to the first stamp, and spacing is the distance between the stamps.
+// stampPositions returns the distance from (x1, y1) to the next stamp.
+func stampPositions(x0, y0, x1, y1 float64, next, spacing float64, f func(x, y float64)) float64 {
+	d := math.Hypot(x1-x0, y1-y0)
+	t := next
+	for ; t <= d; t += spacing {
+		r := t / d
+		f(x0+(x1-x0)*r, y0+(y1-y0)*r)
+	}
+	return t - d
+}
+
+// FloodFill fills the area connected to the pixel at (x, y) with clr.
+//
+// The area consists of the 4-connected pixels whose colors differ from the color at (x, y)
+// by at most tolerance in each channel of the premultiplied-alpha 8-bit color.
+//
+// FloodFill works on the CPU: it reads the pixels of dst, fills the area, and writes back only the changed region.
+// As reading pixels from the GPU is slow, FloodFill should not be called every frame.
+//
+// If (x, y) is out of the bounds of dst, FloodFill does nothing.
+func FloodFill(dst *ebiten.Image, x, y int, clr color.Color, tolerance int) {
+	b := dst.Bounds()
+	if !image.Pt(x, y).In(b) {
+		return
+	}
+	w, h := b.Dx(), b.Dy()
+	pix := make([]byte, 4*w*h)
+	dst.ReadPixels(pix)
+
+	r, g, bl, a := clr.RGBA()
+	fill := [4]byte{byte(r >> 8), byte(g >> 8), byte(bl >> 8), byte(a >> 8)}
+	region := floodFill(pix, w, h, x-b.Min.X, y-b.Min.Y, fill, tolerance)
+	if region.Empty() {
+		return
+	}
+
+	sub := make([]byte, 4*region.Dx()*region.Dy())
+	for j := region.Min.Y; j < region.Max.Y; j++ {
+		copy(sub[4*(j-region.Min.Y)*region.Dx():], pix[4*(j*w+region.Min.X):4*(j*w+region.Max.X)])
+	}
+	dst.SubImage(region.Add(b.Min)).(*ebiten.Image).WritePixels(sub)
+}
+
+// floodFill fills the area connected to (x, y) in pix with fill, and returns the changed region.
+func floodFill(pix []byte, width, height int, x, y int, fill [4]byte, tolerance int) image.Rectangle {
+	idx := 4 * (y*width + x)
+	var seed [4]byte
+	copy(seed[:], pix[idx:idx+4])
+
+	match := func(i, j int) bool {
+		idx := 4 * (j*width + i)
+		for c := 0; c < 4; c++ {
+			d := int(pix[idx+c]) - int(seed[c])
+			if d < -tolerance || d > tolerance {
+				return false
+			}
+		}
+		return true
+	}
+
+	// As the filled color might match the seed color, record the visited pixels separately.
+	visited := make([]bool, width*height)
+	var region image.Rectangle
+	stack := []image.Point{{x, y}}
+	for len(stack) > 0 {
+		p := stack[len(stack)-1]
+		stack = stack[:len(stack)-1]
+		if visited[p.Y*width+p.X] || !match(p.X, p.Y) {
+			continue
+		}
+
+		// Scan the row to the left and the right.
+		x0, x1 := p.X, p.X+1
+		for x0 > 0 && !visited[p.Y*width+x0-1] && match(x0-1, p.Y) {
+			x0--
+		}
+		for x1 < width && !visited[p.Y*width+x1] && match(x1, p.Y) {
+			x1++
+		}
+		for i := x0; i < x1; i++ {
+			visited[p.Y*width+i] = true
+			copy(pix[4*(p.Y*width+i):], fill[:])
+			if p.Y > 0 {
+				stack = append(stack, image.Pt(i, p.Y-1))
+			}
+			if p.Y < height-1 {
+				stack = append(stack, image.Pt(i, p.Y+1))
+			}
+		}
+		region = region.Union(image.Rect(x0, p.Y, x1, p.Y+1))
+	}
+	return region
+}
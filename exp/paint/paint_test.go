@@ -0,0 +1,109 @@
+// Copyright 2026 The Ebitengine Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package paint
+
+import (
+	"image"
+	"math"
+	"testing"
+)
+
+func TestStampPositions(t *testing.T) {
+	var got []float64
+	f := func(x, y float64) {
+		if y != 0 {
+			t.Errorf("y: got: %v, want: 0", y)
+		}
+		got = append(got, x)
+	}
+
+	// The first segment continues from a stamp at (0, 0).
+	next := stampPositions(0, 0, 5, 0, 2, 2, f)
+	next = stampPositions(5, 0, 5, 0, next, 2, f)
+	next = stampPositions(5, 0, 9, 0, next, 2, f)
+
+	want := []float64{2, 4, 6, 8}
+	if len(got) != len(want) {
+		t.Fatalf("got: %v, want: %v", got, want)
+	}
+	for i := range got {
+		if math.Abs(got[i]-want[i]) > 1e-9 {
+			t.Errorf("got: %v, want: %v", got, want)
+			break
+		}
+	}
+	if math.Abs(next-1) > 1e-9 {
+		t.Errorf("next: got: %v, want: 1", next)
+	}
+}
+
+// newPixels creates pixels from rows, where each byte is used for all the channels.
+func newPixels(rows ...string) []byte {
+	var pix []byte
+	for _, row := range rows {
+		for _, c := range []byte(row) {
+			pix = append(pix, c, c, c, c)
+		}
+	}
+	return pix
+}
+
+func TestFloodFill(t *testing.T) {
+	pix := newPixels(
+		"..#..",
+		"..#..",
+		"###..",
+		".....",
+	)
+	region := floodFill(pix, 5, 4, 0, 0, [4]byte{'x', 'x', 'x', 'x'}, 0)
+	if want := image.Rect(0, 0, 2, 2); region != want {
+		t.Errorf("region: got: %v, want: %v", region, want)
+	}
+	if got, want := pix, newPixels(
+		"xx#..",
+		"xx#..",
+		"###..",
+		".....",
+	); string(got) != string(want) {
+		t.Errorf("got: %q, want: %q", got, want)
+	}
+
+	region = floodFill(pix, 5, 4, 4, 3, [4]byte{'o', 'o', 'o', 'o'}, 0)
+	if want := image.Rect(0, 0, 5, 4); region != want {
+		t.Errorf("region: got: %v, want: %v", region, want)
+	}
+	if got, want := pix, newPixels(
+		"xx#oo",
+		"xx#oo",
+		"###oo",
+		"ooooo",
+	); string(got) != string(want) {
+		t.Errorf("got: %q, want: %q", got, want)
+	}
+}
+
+func TestFloodFillTolerance(t *testing.T) {
+	pix := newPixels(
+		"abz",
+		"cdz",
+	)
+	floodFill(pix, 3, 2, 0, 0, [4]byte{'b', 'b', 'b', 'b'}, 3)
+	if got, want := pix, newPixels(
+		"bbz",
+		"bbz",
+	); string(got) != string(want) {
+		t.Errorf("got: %q, want: %q", got, want)
+	}
+}
@@ -0,0 +1,162 @@
+// Copyright 2026 The Ebitengine Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pathfind
+
+import (
+	"image"
+	"math"
+
+	"github.com/duplicants-ai/ebiten/exp/tilemap"
+)
+
+// GridGraph is a Graph over the cells of a grid.
+type GridGraph struct {
+	// Grid is the layout of the cells.
+	// The neighbors of a cell are given by Grid.AppendNeighbors.
+	// If Grid is nil, a square grid where each cell has four neighbors is used.
+	Grid tilemap.Grid
+
+	// Bounds is the range of the cells.
+	// Cells outside Bounds are not passable.
+	Bounds image.Rectangle
+
+	// Cost returns the cost to move into the cell.
+	// If Cost returns a negative value, +Inf, or NaN, the cell is not passable.
+	// If Cost is nil, every cell in Bounds costs 1.
+	Cost func(cell tilemap.Cell) float64
+
+	// MinCost is the minimum value Cost returns for a passable cell, which is used for the heuristic.
+	// If MinCost is more than the actual minimum cost, the found paths might not be the shortest.
+	// If MinCost is 0, 1 is used.
+	MinCost float64
+
+	// Diagonal reports whether diagonal moves are allowed.
+	// The cost of a diagonal move is Cost multiplied by √2.
+	//
+	// Diagonal is available only when Grid is nil or *tilemap.IsoGrid, whose cells are squares in cell coordinates.
+	// Otherwise, Diagonal is ignored.
+	Diagonal bool
+
+	// CornerCutting reports whether a diagonal move is allowed when a cell next to both the cells is not passable.
+	CornerCutting bool
+}
+
+func (g *GridGraph) cost(cell tilemap.Cell) (float64, bool) {
+	if !(image.Point{X: cell.X, Y: cell.Y}).In(g.Bounds) {
+		return 0, false
+	}
+	if g.Cost == nil {
+		return 1, true
+	}
+	c := g.Cost(cell)
+	if c < 0 || math.IsInf(c, 1) || math.IsNaN(c) {
+		return 0, false
+	}
+	return c, true
+}
+
+func (g *GridGraph) isSquare() bool {
+	if g.Grid == nil {
+		return true
+	}
+	_, ok := g.Grid.(*tilemap.IsoGrid)
+	return ok
+}
+
+func (g *GridGraph) minCost() float64 {
+	if g.MinCost == 0 {
+		return 1
+	}
+	return g.MinCost
+}
+
+// AppendEdges implements Graph.
+func (g *GridGraph) AppendEdges(edges []Edge[tilemap.Cell], cell tilemap.Cell) []Edge[tilemap.Cell] {
+	if !g.isSquare() {
+		var neighbors [8]tilemap.Cell
+		for _, n := range g.Grid.AppendNeighbors(neighbors[:0], cell) {
+			if c, ok := g.cost(n); ok {
+				edges = append(edges, Edge[tilemap.Cell]{To: n, Cost: c})
+			}
+		}
+		return edges
+	}
+
+	for _, d := range [...]tilemap.Cell{{X: 1, Y: 0}, {X: 0, Y: 1}, {X: -1, Y: 0}, {X: 0, Y: -1}} {
+		n := tilemap.Cell{X: cell.X + d.X, Y: cell.Y + d.Y}
+		if c, ok := g.cost(n); ok {
+			edges = append(edges, Edge[tilemap.Cell]{To: n, Cost: c})
+		}
+	}
+	if !g.Diagonal {
+		return edges
+	}
+	for _, d := range [...]tilemap.Cell{{X: 1, Y: 1}, {X: -1, Y: 1}, {X: -1, Y: -1}, {X: 1, Y: -1}} {
+		n := tilemap.Cell{X: cell.X + d.X, Y: cell.Y + d.Y}
+		c, ok := g.cost(n)
+		if !ok {
+			continue
+		}
+		if !g.CornerCutting {
+			if _, ok := g.cost(tilemap.Cell{X: cell.X + d.X, Y: cell.Y}); !ok {
+				continue
+			}
+			if _, ok := g.cost(tilemap.Cell{X: cell.X, Y: cell.Y + d.Y}); !ok {
+				continue
+			}
+		}
+		edges = append(edges, Edge[tilemap.Cell]{To: n, Cost: c * math.Sqrt2})
+	}
+	return edges
+}
+
+// Heuristic implements Graph.
+func (g *GridGraph) Heuristic(from, to tilemap.Cell) float64 {
+	if g.isSquare() {
+		dx := math.Abs(float64(to.X - from.X))
+		dy := math.Abs(float64(to.Y - from.Y))
+		if !g.Diagonal {
+			return (dx + dy) * g.minCost()
+		}
+		// Octile distance.
+		return (max(dx, dy) + (math.Sqrt2-1)*min(dx, dy)) * g.minCost()
+	}
+	if h, ok := g.Grid.(*tilemap.HexGrid); ok {
+		return float64(h.Distance(from, to)) * g.minCost()
+	}
+
+	// For the other grids, estimate the number of steps from the distance in the world coordinates.
+	// This assumes that the distances between neighbors are the same.
+	var neighbors [8]tilemap.Cell
+	ns := g.Grid.AppendNeighbors(neighbors[:0], from)
+	if len(ns) == 0 {
+		return 0
+	}
+	fx, fy := g.Grid.CellToWorld(from)
+	nx, ny := g.Grid.CellToWorld(ns[0])
+	step := math.Hypot(nx-fx, ny-fy)
+	if step == 0 {
+		return 0
+	}
+	tx, ty := g.Grid.CellToWorld(to)
+	return math.Hypot(tx-fx, ty-fy) / step * g.minCost()
+}
+
+// FindPath finds the path with the lowest cost from start to goal on the grid.
+//
+// FindPath is a shorthand for AStar(g, start, goal).
+func (g *GridGraph) FindPath(start, goal tilemap.Cell) (path []tilemap.Cell, cost float64, ok bool) {
+	return AStar[tilemap.Cell](g, start, goal)
+}
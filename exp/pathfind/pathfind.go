@@ -0,0 +1,143 @@
+// Copyright 2026 The Ebitengine Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package pathfind provides A* pathfinding over graphs and tile grids.
+//
+// This package is experimental and the API might be changed in the future.
+//
+// AStar works with any Graph. GridGraph is a Graph over the cells of a tilemap.Grid,
+// with costs per cell and optional diagonal moves.
+package pathfind
+
+import (
+	"container/heap"
+	"slices"
+)
+
+// Edge is a directed edge to a neighbor node.
+type Edge[N comparable] struct {
+	// To is the neighbor node.
+	To N
+
+	// Cost is the cost to move to the neighbor.
+	// Cost must not be negative.
+	Cost float64
+}
+
+// Graph is a graph to search a path on.
+type Graph[N comparable] interface {
+	// AppendEdges appends the edges from the node to edges, and returns the extended buffer.
+	AppendEdges(edges []Edge[N], node N) []Edge[N]
+
+	// Heuristic returns the estimated cost from a node to another node.
+	// Heuristic must not overestimate the actual cost to find the shortest path.
+	// A Heuristic that always returns 0 makes AStar work as Dijkstra's algorithm.
+	Heuristic(from, to N) float64
+}
+
+// AStar finds the path with the lowest cost from start to goal.
+//
+// path includes both start and goal.
+// If goal is not reachable, AStar returns false.
+//
+// If the graph is infinite, AStar doesn't return when goal is not reachable.
+func AStar[N comparable](graph Graph[N], start, goal N) (path []N, cost float64, ok bool) {
+	type nodeState struct {
+		cost   float64
+		from   N
+		closed bool
+	}
+	states := map[N]*nodeState{
+		start: {cost: 0},
+	}
+
+	var open openList[N]
+	heap.Push(&open, &openItem[N]{node: start, estimate: graph.Heuristic(start, goal)})
+
+	var edges []Edge[N]
+	for open.Len() > 0 {
+		item := heap.Pop(&open).(*openItem[N])
+		s := states[item.node]
+		if s.closed || item.cost > s.cost {
+			continue
+		}
+		s.closed = true
+
+		if item.node == goal {
+			for n := goal; ; n = states[n].from {
+				path = append(path, n)
+				if n == start {
+					break
+				}
+			}
+			slices.Reverse(path)
+			return path, s.cost, true
+		}
+
+		edges = graph.AppendEdges(edges[:0], item.node)
+		for _, e := range edges {
+			c := s.cost + e.Cost
+			ns, ok := states[e.To]
+			if ok && (ns.closed || ns.cost <= c) {
+				continue
+			}
+			if !ok {
+				ns = &nodeState{}
+				states[e.To] = ns
+			}
+			ns.cost = c
+			ns.from = item.node
+			heap.Push(&open, &openItem[N]{node: e.To, cost: c, estimate: c + graph.Heuristic(e.To, goal)})
+		}
+	}
+	return nil, 0, false
+}
+
+type openItem[N comparable] struct {
+	node     N
+	cost     float64
+	estimate float64
+}
+
+// openList is a priority queue of nodes ordered by the estimated total costs.
+type openList[N comparable] []*openItem[N]
+
+func (o openList[N]) Len() int {
+	return len(o)
+}
+
+func (o openList[N]) Less(i, j int) bool {
+	if o[i].estimate != o[j].estimate {
+		return o[i].estimate < o[j].estimate
+	}
+	// Prefer the node closer to the goal.
+	return o[i].cost > o[j].cost
+}
+
+func (o openList[N]) Swap(i, j int) {
+	o[i], o[j] = o[j], o[i]
+}
+
+func (o *openList[N]) Push(x any) {
+	*o = append(*o, x.(*openItem[N]))
+}
+
+func (o *openList[N]) Pop() any {
+	old := *o
+	n := len(old)
+	item := old[n-1]
+	old[n-1] = nil
+	*o = old[:n-1]
+	return item
+}
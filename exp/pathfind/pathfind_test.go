@@ -0,0 +1,202 @@
+// Copyright 2026 The Ebitengine Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pathfind_test
+
+import (
+	"image"
+	"math"
+	"slices"
+	"testing"
+
+	"github.com/duplicants-ai/ebiten/exp/pathfind"
+	"github.com/duplicants-ai/ebiten/exp/tilemap"
+)
+
+type testGraph map[string][]pathfind.Edge[string]
+
+func (g testGraph) AppendEdges(edges []pathfind.Edge[string], node string) []pathfind.Edge[string] {
+	return append(edges, g[node]...)
+}
+
+func (g testGraph) Heuristic(from, to string) float64 {
+	return 0
+}
+
+func TestAStar(t *testing.T) {
+	g := testGraph{
+		"a": {{To: "b", Cost: 1}, {To: "c", Cost: 4}},
+		"b": {{To: "c", Cost: 1}, {To: "d", Cost: 5}},
+		"c": {{To: "d", Cost: 1}},
+		"e": {{To: "a", Cost: 1}},
+	}
+
+	path, cost, ok := pathfind.AStar[string](g, "a", "d")
+	if !ok {
+		t.Fatal("AStar must find a path")
+	}
+	if want := []string{"a", "b", "c", "d"}; !slices.Equal(path, want) {
+		t.Errorf("path: got: %v, want: %v", path, want)
+	}
+	if cost != 3 {
+		t.Errorf("cost: got: %v, want: 3", cost)
+	}
+
+	path, cost, ok = pathfind.AStar[string](g, "a", "a")
+	if !ok || !slices.Equal(path, []string{"a"}) || cost != 0 {
+		t.Errorf("got: (%v, %v, %v), want: ([a], 0, true)", path, cost, ok)
+	}
+
+	if _, _, ok := pathfind.AStar[string](g, "a", "e"); ok {
+		t.Errorf("AStar must not find a path to an unreachable node")
+	}
+}
+
+// newMaze returns a GridGraph from rows, where '#' is a wall and digits are costs.
+func newMaze(rows ...string) *pathfind.GridGraph {
+	return &pathfind.GridGraph{
+		Bounds: image.Rect(0, 0, len(rows[0]), len(rows)),
+		Cost: func(cell tilemap.Cell) float64 {
+			switch c := rows[cell.Y][cell.X]; {
+			case c == '#':
+				return math.Inf(1)
+			case '1' <= c && c <= '9':
+				return float64(c - '0')
+			default:
+				return 1
+			}
+		},
+	}
+}
+
+func TestGridGraph(t *testing.T) {
+	g := newMaze(
+		"..#..",
+		"..#..",
+		".....",
+		"####.",
+	)
+	path, cost, ok := g.FindPath(tilemap.Cell{X: 0, Y: 0}, tilemap.Cell{X: 4, Y: 0})
+	if !ok {
+		t.Fatal("FindPath must find a path")
+	}
+	if got, want := len(path), 9; got != want {
+		t.Errorf("len(path): got: %d, want: %d", got, want)
+	}
+	if cost != 8 {
+		t.Errorf("cost: got: %v, want: 8", cost)
+	}
+	for i := 1; i < len(path); i++ {
+		dx, dy := path[i].X-path[i-1].X, path[i].Y-path[i-1].Y
+		if dx*dx+dy*dy != 1 {
+			t.Errorf("path[%d] and path[%d] are not neighbors: %v", i-1, i, path)
+		}
+	}
+
+	if _, _, ok := g.FindPath(tilemap.Cell{X: 0, Y: 0}, tilemap.Cell{X: 0, Y: 3}); ok {
+		t.Errorf("FindPath must not find a path to a wall")
+	}
+	if _, _, ok := g.FindPath(tilemap.Cell{X: 0, Y: 0}, tilemap.Cell{X: 5, Y: 0}); ok {
+		t.Errorf("FindPath must not find a path out of the bounds")
+	}
+}
+
+func TestGridGraphWeights(t *testing.T) {
+	g := newMaze(
+		".9.",
+		".9.",
+		"...",
+	)
+	path, cost, ok := g.FindPath(tilemap.Cell{X: 0, Y: 0}, tilemap.Cell{X: 2, Y: 0})
+	if !ok {
+		t.Fatal("FindPath must find a path")
+	}
+	if cost != 6 {
+		t.Errorf("cost: got: %v, want: 6 (path: %v)", cost, path)
+	}
+}
+
+func TestGridGraphDiagonal(t *testing.T) {
+	g := newMaze(
+		"...",
+		".#.",
+		"...",
+	)
+	g.Diagonal = true
+
+	start, goal := tilemap.Cell{X: 0, Y: 0}, tilemap.Cell{X: 2, Y: 2}
+	_, cost, ok := g.FindPath(start, goal)
+	if !ok {
+		t.Fatal("FindPath must find a path")
+	}
+	// Without corner cutting, every diagonal move touches the wall.
+	if want := 4.0; math.Abs(cost-want) > 1e-9 {
+		t.Errorf("cost: got: %v, want: %v", cost, want)
+	}
+
+	g.CornerCutting = true
+	_, cost, ok = g.FindPath(start, goal)
+	if !ok {
+		t.Fatal("FindPath must find a path")
+	}
+	if want := 2 + math.Sqrt2; math.Abs(cost-want) > 1e-9 {
+		t.Errorf("cost: got: %v, want: %v", cost, want)
+	}
+
+	g = newMaze(
+		"...",
+		"...",
+		"...",
+	)
+	g.Diagonal = true
+	path, cost, ok := g.FindPath(start, goal)
+	if !ok {
+		t.Fatal("FindPath must find a path")
+	}
+	if want := 2 * math.Sqrt2; len(path) != 3 || math.Abs(cost-want) > 1e-9 {
+		t.Errorf("got: (%v, %v), want: two diagonal moves", path, cost)
+	}
+}
+
+func TestGridGraphHex(t *testing.T) {
+	hex := &tilemap.HexGrid{Size: 10}
+	g := &pathfind.GridGraph{
+		Grid:   hex,
+		Bounds: image.Rect(-10, -10, 10, 10),
+	}
+	for _, goal := range []tilemap.Cell{{X: 3, Y: 0}, {X: 1, Y: 4}, {X: -3, Y: 5}, {X: -5, Y: -5}} {
+		path, _, ok := g.FindPath(tilemap.Cell{}, goal)
+		if !ok {
+			t.Fatalf("FindPath must find a path to %v", goal)
+		}
+		if got, want := len(path)-1, hex.Distance(tilemap.Cell{}, goal); got != want {
+			t.Errorf("the number of steps to %v: got: %d, want: %d", goal, got, want)
+		}
+	}
+}
+
+func TestGridGraphStaggered(t *testing.T) {
+	g := &pathfind.GridGraph{
+		Grid:   &tilemap.StaggeredGrid{TileWidth: 64, TileHeight: 32},
+		Bounds: image.Rect(0, 0, 10, 20),
+	}
+	path, _, ok := g.FindPath(tilemap.Cell{X: 0, Y: 0}, tilemap.Cell{X: 0, Y: 4})
+	if !ok {
+		t.Fatal("FindPath must find a path")
+	}
+	// (0, 4) is two tiles below (0, 0) in the world, which needs four diagonal steps.
+	if got, want := len(path)-1, 4; got != want {
+		t.Errorf("the number of steps: got: %d, want: %d (path: %v)", got, want, path)
+	}
+}
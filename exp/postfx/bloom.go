@@ -0,0 +1,100 @@
+// Copyright 2026 The Ebitengine Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package postfx
+
+import (
+	"github.com/duplicants-ai/ebiten"
+)
+
+//ebitengine:shadersource
+const thresholdShaderSrc = `//kage:unit pixels
+
+package main
+
+var Threshold float
+
+func Fragment(dstPos vec4, srcPos vec2, color vec4) vec4 {
+	clr := imageSrc0UnsafeAt(srcPos)
+	if clr.a == 0 {
+		return vec4(0)
+	}
+	// Use the luminance of the straight-alpha color.
+	l := dot(clr.rgb/clr.a, vec3(0.2126, 0.7152, 0.0722))
+	if l <= Threshold {
+		return vec4(0)
+	}
+	return clr
+}
+`
+
+//ebitengine:shadersource
+const bloomCompositeShaderSrc = `//kage:unit pixels
+
+package main
+
+var Intensity float
+
+func Fragment(dstPos vec4, srcPos vec2, color vec4) vec4 {
+	clr := imageSrc0UnsafeAt(srcPos)
+	glow := imageSrc1UnsafeAt(srcPos)
+	return clamp(clr+glow*Intensity, 0, 1)
+}
+`
+
+var (
+	thresholdShader      = &lazyShader{src: thresholdShaderSrc}
+	bloomCompositeShader = &lazyShader{src: bloomCompositeShaderSrc}
+)
+
+// Bloom is a bloom pass, which makes bright areas glow.
+//
+// Bloom extracts the pixels brighter than the threshold, blurs them, and adds them to the source image.
+//
+// Bloom is not concurrent-safe.
+type Bloom struct {
+	// Threshold is the luminance threshold in [0, 1].
+	// Only the pixels whose luminance is more than Threshold glow.
+	Threshold float64
+
+	// Intensity is the scale of the glow.
+	// If Intensity is 0, 1 is used.
+	Intensity float64
+
+	// Blur is the blur to spread the glow.
+	Blur Blur
+
+	bright  *ebiten.Image
+	blurred *ebiten.Image
+}
+
+// Apply implements Pass.
+func (b *Bloom) Apply(dst, src *ebiten.Image) {
+	intensity := b.Intensity
+	if intensity == 0 {
+		intensity = 1
+	}
+
+	w, h := src.Bounds().Dx(), src.Bounds().Dy()
+	b.bright = ensureImage(b.bright, w, h)
+	b.blurred = ensureImage(b.blurred, w, h)
+
+	drawShader(b.bright, thresholdShader.get(), map[string]any{
+		"Threshold": float32(b.Threshold),
+	}, src)
+	b.Blur.Apply(b.blurred, b.bright)
+	drawShader(dst, bloomCompositeShader.get(), map[string]any{
+		"Intensity": float32(intensity),
+	}, src, b.blurred)
+}
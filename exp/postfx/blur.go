@@ -0,0 +1,114 @@
+// Copyright 2026 The Ebitengine Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package postfx
+
+import (
+	"math"
+
+	"github.com/duplicants-ai/ebiten"
+)
+
+// MaxBlurTaps is the maximum number of the taps on each side of a Blur.
+const MaxBlurTaps = 16
+
+//ebitengine:shadersource
+const blurShaderSrc = `//kage:unit pixels
+
+package main
+
+var Direction vec2
+var Taps int
+var Weights [17]float
+
+func sample(pos vec2) vec4 {
+	origin := imageSrc0Origin()
+	size := imageSrc0Size()
+	return imageSrc0UnsafeAt(clamp(pos, origin+0.5, origin+size-0.5))
+}
+
+func Fragment(dstPos vec4, srcPos vec2, color vec4) vec4 {
+	clr := sample(srcPos) * Weights[0]
+	for i := 1; i <= 16; i++ {
+		if i > Taps {
+			break
+		}
+		d := Direction * float(i)
+		clr += (sample(srcPos+d) + sample(srcPos-d)) * Weights[i]
+	}
+	return clr
+}
+`
+
+var blurShader = &lazyShader{src: blurShaderSrc}
+
+// Blur is a separable gaussian blur pass.
+//
+// Blur is not concurrent-safe.
+type Blur struct {
+	// Sigma is the standard deviation of the gaussian function in pixels.
+	// If Sigma is 0 or less, Blur just copies the source image.
+	Sigma float64
+
+	// Taps is the number of the samples on each side of the center pixel for each axis.
+	// More taps make the result smoother, but slower.
+	// If Taps is 0, ceil(3 * Sigma) is used.
+	// Taps is clamped to MaxBlurTaps.
+	Taps int
+
+	tmp *ebiten.Image
+}
+
+// Apply implements Pass.
+func (b *Blur) Apply(dst, src *ebiten.Image) {
+	taps := b.Taps
+	if taps <= 0 {
+		taps = int(math.Ceil(3 * b.Sigma))
+	}
+	taps = min(taps, MaxBlurTaps)
+	if b.Sigma <= 0 || taps <= 0 {
+		NewChain().Apply(dst, src)
+		return
+	}
+
+	var weights [MaxBlurTaps + 1]float32
+	var sum float64
+	for i := 0; i <= taps; i++ {
+		w := math.Exp(-float64(i*i) / (2 * b.Sigma * b.Sigma))
+		weights[i] = float32(w)
+		if i == 0 {
+			sum += w
+		} else {
+			sum += 2 * w
+		}
+	}
+	for i := range weights {
+		weights[i] /= float32(sum)
+	}
+
+	w, h := src.Bounds().Dx(), src.Bounds().Dy()
+	b.tmp = ensureImage(b.tmp, w, h)
+
+	s := blurShader.get()
+	drawShader(b.tmp, s, map[string]any{
+		"Direction": []float32{1, 0},
+		"Taps":      taps,
+		"Weights":   weights[:],
+	}, src)
+	drawShader(dst, s, map[string]any{
+		"Direction": []float32{0, 1},
+		"Taps":      taps,
+		"Weights":   weights[:],
+	}, b.tmp)
+}
@@ -0,0 +1,93 @@
+// Copyright 2026 The Ebitengine Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package postfx
+
+import (
+	"image/color"
+
+	"github.com/duplicants-ai/ebiten"
+)
+
+// MaxOutlineThickness is the maximum thickness of an Outline.
+const MaxOutlineThickness = 8
+
+//ebitengine:shadersource
+const outlineShaderSrc = `//kage:unit pixels
+
+package main
+
+var Color vec4
+var Thickness int
+
+func Fragment(dstPos vec4, srcPos vec2, color vec4) vec4 {
+	clr := imageSrc0UnsafeAt(srcPos)
+	id := imageSrc1UnsafeAt(srcPos)
+	for j := -8; j <= 8; j++ {
+		for i := -8; i <= 8; i++ {
+			if i*i+j*j > Thickness*Thickness {
+				continue
+			}
+			n := imageSrc1At(srcPos + vec2(float(i), float(j)))
+			d := n - id
+			if n.a > 0 && dot(d, d) > 0 {
+				return Color + clr*(1-Color.a)
+			}
+		}
+	}
+	return clr
+}
+`
+
+var outlineShader = &lazyShader{src: outlineShaderSrc}
+
+// Outline is an outline pass with edge detection on an ID buffer.
+//
+// An ID buffer is an image where each object is rendered with its own unique opaque color, and the background is transparent.
+// Outline draws the outline around the objects in the ID buffer over the source image.
+//
+// Outline is not concurrent-safe.
+type Outline struct {
+	// IDs is the ID buffer. IDs must have the same size as the source image.
+	IDs *ebiten.Image
+
+	// Color is the color of the outline.
+	// If Color is nil, opaque white is used.
+	Color color.Color
+
+	// Thickness is the thickness of the outline in pixels.
+	// If Thickness is 0, 1 is used.
+	// Thickness is clamped to MaxOutlineThickness.
+	Thickness int
+}
+
+// Apply implements Pass.
+func (o *Outline) Apply(dst, src *ebiten.Image) {
+	thickness := o.Thickness
+	if thickness <= 0 {
+		thickness = 1
+	}
+	thickness = min(thickness, MaxOutlineThickness)
+
+	clr := o.Color
+	if clr == nil {
+		clr = color.White
+	}
+	r, g, b, a := clr.RGBA()
+
+	drawShader(dst, outlineShader.get(), map[string]any{
+		"Color":     []float32{float32(r) / 0xffff, float32(g) / 0xffff, float32(b) / 0xffff, float32(a) / 0xffff},
+		"Thickness": thickness,
+	}, src, o.IDs)
+}
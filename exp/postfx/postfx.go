@@ -0,0 +1,120 @@
+// Copyright 2026 The Ebitengine Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package postfx provides screen-space post-processing effects like blur, bloom, and outline.
+//
+// This package is experimental and the API might be changed in the future.
+//
+// An effect is a Pass, which renders a source image onto a destination image with Kage shaders.
+// Passes can be composed by a Chain, which manages intermediate offscreen images.
+package postfx
+
+import (
+	"fmt"
+	"image"
+	"sync"
+
+	"github.com/duplicants-ai/ebiten"
+)
+
+// Pass is a post-processing pass.
+type Pass interface {
+	// Apply renders src with the effect onto dst.
+	// The content of dst is overwritten.
+	//
+	// dst and src must have the same size, and must be different images.
+	Apply(dst, src *ebiten.Image)
+}
+
+// Chain is a Pass that applies passes in order.
+//
+// Chain is not concurrent-safe.
+type Chain struct {
+	passes  []Pass
+	buffers [2]*ebiten.Image
+}
+
+// NewChain creates a new Chain with the given passes.
+func NewChain(passes ...Pass) *Chain {
+	return &Chain{
+		passes: passes,
+	}
+}
+
+// Apply implements Pass.
+//
+// If the chain has no passes, Apply copies src to dst.
+func (c *Chain) Apply(dst, src *ebiten.Image) {
+	if len(c.passes) == 0 {
+		op := &ebiten.DrawImageOptions{}
+		op.GeoM.Translate(float64(dst.Bounds().Min.X), float64(dst.Bounds().Min.Y))
+		op.Blend = ebiten.BlendCopy
+		dst.DrawImage(src, op)
+		return
+	}
+
+	w, h := src.Bounds().Dx(), src.Bounds().Dy()
+	in := src
+	for i, p := range c.passes {
+		if i == len(c.passes)-1 {
+			p.Apply(dst, in)
+			return
+		}
+		c.buffers[i%2] = ensureImage(c.buffers[i%2], w, h)
+		out := c.buffers[i%2]
+		p.Apply(out, in)
+		in = out
+	}
+}
+
+// ensureImage returns img if its size is the given size. Otherwise, ensureImage returns a new image.
+func ensureImage(img *ebiten.Image, width, height int) *ebiten.Image {
+	if img != nil {
+		if b := img.Bounds(); b.Dx() == width && b.Dy() == height {
+			return img
+		}
+		img.Deallocate()
+	}
+	return ebiten.NewImageWithOptions(image.Rect(0, 0, width, height), &ebiten.NewImageOptions{
+		Unmanaged: true,
+	})
+}
+
+// drawShader renders the shader onto the whole dst with the given source images.
+func drawShader(dst *ebiten.Image, shader *ebiten.Shader, uniforms map[string]any, srcs ...*ebiten.Image) {
+	op := &ebiten.DrawRectShaderOptions{}
+	op.GeoM.Translate(float64(dst.Bounds().Min.X), float64(dst.Bounds().Min.Y))
+	op.Blend = ebiten.BlendCopy
+	op.Uniforms = uniforms
+	copy(op.Images[:], srcs)
+	dst.DrawRectShader(dst.Bounds().Dx(), dst.Bounds().Dy(), shader, op)
+}
+
+// lazyShader is a shader compiled when it is used first.
+type lazyShader struct {
+	src    string
+	shader *ebiten.Shader
+	once   sync.Once
+}
+
+func (l *lazyShader) get() *ebiten.Shader {
+	l.once.Do(func() {
+		s, err := ebiten.NewShader([]byte(l.src))
+		if err != nil {
+			panic(fmt.Sprintf("postfx: NewShader failed: %v", err))
+		}
+		l.shader = s
+	})
+	return l.shader
+}
@@ -0,0 +1,98 @@
+// Copyright 2026 The Ebitengine Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package postfx_test
+
+import (
+	"image"
+	"image/color"
+	"testing"
+
+	"github.com/duplicants-ai/ebiten"
+	"github.com/duplicants-ai/ebiten/exp/postfx"
+	etesting "github.com/duplicants-ai/ebiten/internal/testing"
+	"github.com/duplicants-ai/ebiten/internal/ui"
+)
+
+func TestMain(m *testing.M) {
+	ui.SetPanicOnErrorOnReadingPixelsForTesting(true)
+	etesting.MainWithRunLoop(m)
+}
+
+func TestBlurUniform(t *testing.T) {
+	const w, h = 16, 16
+	src := ebiten.NewImage(w, h)
+	clr := color.RGBA{R: 0x80, G: 0x40, B: 0x20, A: 0xff}
+	src.Fill(clr)
+	dst := ebiten.NewImage(w, h)
+
+	// Blurring a uniform image doesn't change the image, including the edges.
+	(&postfx.Blur{Sigma: 2}).Apply(dst, src)
+	for j := 0; j < h; j++ {
+		for i := 0; i < w; i++ {
+			got := dst.At(i, j).(color.RGBA)
+			if !etesting.SameColors(got, clr, 2) {
+				t.Errorf("dst.At(%d, %d): got: %v, want: %v", i, j, got, clr)
+			}
+		}
+	}
+}
+
+func TestOutline(t *testing.T) {
+	const w, h = 16, 16
+	src := ebiten.NewImage(w, h)
+	ids := ebiten.NewImage(w, h)
+	ids.SubImage(image.Rect(4, 4, 12, 12)).(*ebiten.Image).Fill(color.RGBA{R: 1, A: 0xff})
+	dst := ebiten.NewImage(w, h)
+
+	red := color.RGBA{R: 0xff, A: 0xff}
+	postfx.NewChain(&postfx.Outline{
+		IDs:   ids,
+		Color: red,
+	}).Apply(dst, src)
+
+	cases := []struct {
+		X, Y int
+		Want color.RGBA
+	}{
+		{X: 3, Y: 6, Want: red},
+		{X: 12, Y: 6, Want: red},
+		{X: 6, Y: 3, Want: red},
+		{X: 6, Y: 6, Want: color.RGBA{}},
+		{X: 1, Y: 6, Want: color.RGBA{}},
+	}
+	for _, c := range cases {
+		if got := dst.At(c.X, c.Y).(color.RGBA); got != c.Want {
+			t.Errorf("dst.At(%d, %d): got: %v, want: %v", c.X, c.Y, got, c.Want)
+		}
+	}
+}
+
+func TestChain(t *testing.T) {
+	const w, h = 16, 16
+	src := ebiten.NewImage(w, h)
+	src.Fill(color.White)
+	dst := ebiten.NewImage(w, h)
+
+	postfx.NewChain(&postfx.Blur{Sigma: 1}, &postfx.Bloom{Threshold: 0.5, Blur: postfx.Blur{Sigma: 1}}).Apply(dst, src)
+	for j := 0; j < h; j++ {
+		for i := 0; i < w; i++ {
+			got := dst.At(i, j).(color.RGBA)
+			want := color.RGBA{R: 0xff, G: 0xff, B: 0xff, A: 0xff}
+			if !etesting.SameColors(got, want, 2) {
+				t.Errorf("dst.At(%d, %d): got: %v, want: %v", i, j, got, want)
+			}
+		}
+	}
+}
@@ -0,0 +1,107 @@
+// Copyright 2026 The Ebitengine Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package retro
+
+import (
+	"github.com/duplicants-ai/ebiten"
+)
+
+//ebitengine:shadersource
+const crtShaderSrc = `//kage:unit pixels
+
+package main
+
+var Curvature float
+var Scanline float
+var Mask float
+var Vignette float
+
+func Fragment(dstPos vec4, srcPos vec2, color vec4) vec4 {
+	origin := imageSrc0Origin()
+	size := imageSrc0Size()
+
+	// Bend the screen with a barrel distortion in the normalized coordinates [-1, 1].
+	uv := (srcPos-origin)/size*2 - 1
+	uv += uv * uv.yx * uv.yx * Curvature
+	if abs(uv.x) > 1 || abs(uv.y) > 1 {
+		return vec4(0, 0, 0, 1)
+	}
+	pos := origin + (uv+1)/2*size
+	clr := imageSrc0UnsafeAt(clamp(pos, origin+0.5, origin+size-0.5))
+
+	// Darken the boundaries between the rows of the offscreen.
+	line := abs(sin(3.14159265 * pos.y))
+	clr.rgb *= mix(1, line, Scanline)
+
+	// Emphasize one of RGB for each column of the screen, like an aperture grille.
+	mask := vec3(1 - Mask)
+	idx := mod(floor(dstPos.x), 3)
+	if idx < 1 {
+		mask.r = 1
+	} else if idx < 2 {
+		mask.g = 1
+	} else {
+		mask.b = 1
+	}
+	clr.rgb *= mask
+
+	clr.rgb *= clamp(1-Vignette*dot(uv, uv)/2, 0, 1)
+	return clr
+}
+`
+
+var crtShader = &lazyShader{src: crtShaderSrc}
+
+// CRTOptions represents options for DrawCRT.
+type CRTOptions struct {
+	// Curvature is the amount of the barrel distortion of the screen.
+	// The area outside of the bent screen is black.
+	// If Curvature is 0, the screen is flat.
+	Curvature float64
+
+	// Scanline is the darkness of the gaps between the rows of the offscreen, in [0, 1].
+	// Scanlines look good when the offscreen is scaled by 3 or more.
+	Scanline float64
+
+	// Mask is the strength of the aperture grille mask, in [0, 1].
+	// The mask emphasizes red, green, and blue for each column of the final screen in turn.
+	Mask float64
+
+	// Vignette is the darkness of the screen's corners, in [0, 1].
+	Vignette float64
+}
+
+var defaultCRTOptions = CRTOptions{
+	Curvature: 0.1,
+	Scanline:  0.5,
+	Mask:      0.3,
+	Vignette:  0.3,
+}
+
+// DrawCRT draws offscreen onto screen with geoM like a CRT display, with curvature, scanlines, and an aperture grille mask.
+//
+// If options is nil, the default options are used:
+// Curvature is 0.1, Scanline is 0.5, Mask is 0.3, and Vignette is 0.3.
+func DrawCRT(screen ebiten.FinalScreen, offscreen *ebiten.Image, geoM ebiten.GeoM, options *CRTOptions) {
+	if options == nil {
+		options = &defaultCRTOptions
+	}
+	drawShader(screen, offscreen, geoM, crtShader.get(), map[string]any{
+		"Curvature": float32(options.Curvature),
+		"Scanline":  float32(options.Scanline),
+		"Mask":      float32(options.Mask),
+		"Vignette":  float32(options.Vignette),
+	})
+}
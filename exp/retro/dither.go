@@ -0,0 +1,174 @@
+// Copyright 2026 The Ebitengine Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package retro
+
+import (
+	"fmt"
+	"image/color"
+	"math"
+
+	"github.com/duplicants-ai/ebiten"
+)
+
+// MaxDitherPaletteSize is the maximum number of the colors in DitherOptions.Palette.
+const MaxDitherPaletteSize = 16
+
+//ebitengine:shadersource
+const ditherShaderSrc = `//kage:unit pixels
+
+package main
+
+var Palette [16]vec3
+var PaletteSize int
+var Levels float
+var Spread float
+
+// bayer returns the threshold of the 4x4 Bayer matrix in [-0.5, 0.5).
+func bayer(pos vec2) float {
+	a := mod(pos, 2)
+	b := mod(floor(pos/2), 2)
+	m := 4*(2*a.x+3*a.y-4*a.x*a.y) + (2*b.x + 3*b.y - 4*b.x*b.y)
+	return (m+0.5)/16 - 0.5
+}
+
+func Fragment(dstPos vec4, srcPos vec2, color vec4) vec4 {
+	clr := imageSrc0UnsafeAt(srcPos)
+	if clr.a == 0 {
+		return vec4(0)
+	}
+	c := clr.rgb/clr.a + bayer(floor(srcPos-imageSrc0Origin()))*Spread
+
+	if PaletteSize == 0 {
+		n := Levels - 1
+		c = clamp(floor(c*n+0.5)/n, 0, 1)
+		return vec4(c*clr.a, clr.a)
+	}
+
+	nearest := Palette[0]
+	d := c - nearest
+	minDist := dot(d, d)
+	for i := 1; i < 16; i++ {
+		if i >= PaletteSize {
+			break
+		}
+		d := c - Palette[i]
+		if dist := dot(d, d); dist < minDist {
+			nearest = Palette[i]
+			minDist = dist
+		}
+	}
+	return vec4(nearest*clr.a, clr.a)
+}
+`
+
+var ditherShader = &lazyShader{src: ditherShaderSrc}
+
+// DitherOptions represents options for DrawDither.
+type DitherOptions struct {
+	// Palette is the colors to reduce the offscreen's colors to.
+	// The alpha values of the colors are ignored.
+	// If Palette is empty, each RGB channel is reduced to Levels levels instead.
+	//
+	// The length of Palette must be MaxDitherPaletteSize or less.
+	Palette color.Palette
+
+	// Levels is the number of the levels for each RGB channel when Palette is empty.
+	// If Levels is 0, 4 is used. Levels must be 0 or more than 1.
+	Levels int
+
+	// Spread is the amplitude of the ordered dithering in [0, 1] color units.
+	// If Spread is 0, a value based on the distances between the palette colors is used.
+	// If Spread is negative, the colors are reduced without dithering.
+	Spread float64
+}
+
+// DrawDither draws offscreen onto screen with geoM with the colors reduced to a limited palette with ordered dithering.
+//
+// Dithering is done for each offscreen pixel with a 4x4 Bayer matrix.
+// The alpha channel is kept.
+//
+// If options is nil, each RGB channel is reduced to 4 levels.
+//
+// DrawDither panics if the options are invalid.
+func DrawDither(screen ebiten.FinalScreen, offscreen *ebiten.Image, geoM ebiten.GeoM, options *DitherOptions) {
+	if options == nil {
+		options = &DitherOptions{}
+	}
+	if len(options.Palette) > MaxDitherPaletteSize {
+		panic(fmt.Sprintf("retro: the palette size must be %d or less but %d", MaxDitherPaletteSize, len(options.Palette)))
+	}
+	levels := options.Levels
+	if levels == 0 {
+		levels = 4
+	}
+	if levels < 2 {
+		panic(fmt.Sprintf("retro: levels must be 0 or more than 1 but %d", options.Levels))
+	}
+
+	var palette [3 * MaxDitherPaletteSize]float32
+	for i, c := range options.Palette {
+		r, g, b := straightRGB(c)
+		palette[3*i] = float32(r)
+		palette[3*i+1] = float32(g)
+		palette[3*i+2] = float32(b)
+	}
+
+	spread := options.Spread
+	switch {
+	case spread < 0:
+		spread = 0
+	case spread == 0 && len(options.Palette) > 0:
+		spread = paletteSpread(options.Palette)
+	case spread == 0:
+		spread = 1 / float64(levels-1)
+	}
+
+	drawShader(screen, offscreen, geoM, ditherShader.get(), map[string]any{
+		"Palette":     palette[:],
+		"PaletteSize": len(options.Palette),
+		"Levels":      float32(levels),
+		"Spread":      float32(spread),
+	})
+}
+
+// straightRGB returns the non-premultiplied RGB values of c in [0, 1].
+func straightRGB(c color.Color) (float64, float64, float64) {
+	r, g, b, a := c.RGBA()
+	if a == 0 {
+		return 0, 0, 0
+	}
+	return float64(r) / float64(a), float64(g) / float64(a), float64(b) / float64(a)
+}
+
+// paletteSpread returns the average distance from each palette color to its nearest neighbor.
+func paletteSpread(palette color.Palette) float64 {
+	if len(palette) < 2 {
+		return 0
+	}
+	var sum float64
+	for i, c0 := range palette {
+		r0, g0, b0 := straightRGB(c0)
+		minDist := math.Inf(1)
+		for j, c1 := range palette {
+			if i == j {
+				continue
+			}
+			r1, g1, b1 := straightRGB(c1)
+			minDist = min(minDist, math.Sqrt((r0-r1)*(r0-r1)+(g0-g1)*(g0-g1)+(b0-b1)*(b0-b1)))
+		}
+		sum += minDist
+	}
+	return sum / float64(len(palette))
+}
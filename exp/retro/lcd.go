@@ -0,0 +1,94 @@
+// Copyright 2026 The Ebitengine Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package retro
+
+import (
+	"github.com/duplicants-ai/ebiten"
+)
+
+//ebitengine:shadersource
+const lcdShaderSrc = `//kage:unit pixels
+
+package main
+
+var Grid float
+var GridWidth float
+var Subpixel float
+
+func Fragment(dstPos vec4, srcPos vec2, color vec4) vec4 {
+	clr := imageSrc0UnsafeAt(srcPos)
+	f := fract(srcPos - imageSrc0Origin())
+
+	// The gap is GridWidth pixels of the final screen, and at most a half of an offscreen pixel.
+	gap := min(fwidth(srcPos)*GridWidth, 0.5)
+	if f.x < gap.x || f.y < gap.y {
+		clr.rgb *= 1 - Grid
+	}
+
+	// Split each offscreen pixel into vertical red, green, and blue stripes.
+	stripe := vec3(1 - Subpixel)
+	if f.x < 1.0/3.0 {
+		stripe.r = 1
+	} else if f.x < 2.0/3.0 {
+		stripe.g = 1
+	} else {
+		stripe.b = 1
+	}
+	clr.rgb *= stripe
+	return clr
+}
+`
+
+var lcdShader = &lazyShader{src: lcdShaderSrc}
+
+// LCDOptions represents options for DrawLCD.
+type LCDOptions struct {
+	// Grid is the darkness of the grid between the offscreen pixels, in [0, 1].
+	Grid float64
+
+	// GridWidth is the width of the grid lines in the final screen's pixels.
+	// If GridWidth is 0, 1 is used.
+	// The grid lines are at most a half of an offscreen pixel.
+	GridWidth float64
+
+	// Subpixel is the strength of the red, green, and blue stripes in each offscreen pixel, in [0, 1].
+	Subpixel float64
+}
+
+var defaultLCDOptions = LCDOptions{
+	Grid:      0.3,
+	GridWidth: 1,
+}
+
+// DrawLCD draws offscreen onto screen with geoM like an LCD display of handheld consoles, with a visible pixel grid.
+//
+// DrawLCD looks good when the offscreen is scaled by 3 or more.
+//
+// If options is nil, the default options are used:
+// Grid is 0.3, GridWidth is 1, and Subpixel is 0.
+func DrawLCD(screen ebiten.FinalScreen, offscreen *ebiten.Image, geoM ebiten.GeoM, options *LCDOptions) {
+	if options == nil {
+		options = &defaultLCDOptions
+	}
+	gridWidth := options.GridWidth
+	if gridWidth == 0 {
+		gridWidth = 1
+	}
+	drawShader(screen, offscreen, geoM, lcdShader.get(), map[string]any{
+		"Grid":      float32(options.Grid),
+		"GridWidth": float32(gridWidth),
+		"Subpixel":  float32(options.Subpixel),
+	})
+}
@@ -0,0 +1,102 @@
+// Copyright 2026 The Ebitengine Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package retro
+
+import (
+	"github.com/duplicants-ai/ebiten"
+)
+
+//ebitengine:shadersource
+const ntscShaderSrc = `//kage:unit pixels
+
+package main
+
+var Bleed float
+var Fringe float
+
+func sample(pos vec2) vec4 {
+	origin := imageSrc0Origin()
+	size := imageSrc0Size()
+	return imageSrc0UnsafeAt(clamp(pos, origin+0.5, origin+size-0.5))
+}
+
+func rgbToYIQ(c vec3) vec3 {
+	return vec3(
+		dot(c, vec3(0.299, 0.587, 0.114)),
+		dot(c, vec3(0.596, -0.274, -0.322)),
+		dot(c, vec3(0.211, -0.523, 0.312)))
+}
+
+func yiqToRGB(c vec3) vec3 {
+	return vec3(
+		dot(c, vec3(1, 0.956, 0.621)),
+		dot(c, vec3(1, -0.272, -0.647)),
+		dot(c, vec3(1, -1.106, 1.703)))
+}
+
+func Fragment(dstPos vec4, srcPos vec2, color vec4) vec4 {
+	clr := sample(srcPos)
+	yiq := rgbToYIQ(clr.rgb)
+
+	// The chroma signal has a narrower bandwidth than the luma signal.
+	// Blur only the chroma horizontally.
+	iq := vec2(0)
+	sum := 0.0
+	for i := -4; i <= 4; i++ {
+		w := 1 - abs(float(i))/5
+		iq += rgbToYIQ(sample(srcPos+vec2(float(i)*Bleed/4, 0)).rgb).yz * w
+		sum += w
+	}
+	yiq.yz = iq / sum
+
+	// Sharp luma edges leak into the chroma as color fringes, whose phase alternates for each pixel.
+	dl := rgbToYIQ(sample(srcPos+vec2(1, 0)).rgb).x - rgbToYIQ(sample(srcPos-vec2(1, 0)).rgb).x
+	phase := mod(floor(srcPos.x)+floor(srcPos.y), 2)*2 - 1
+	yiq.yz += vec2(phase, -phase) * dl * Fringe
+
+	return vec4(clamp(yiqToRGB(yiq), 0, clr.a), clr.a)
+}
+`
+
+var ntscShader = &lazyShader{src: ntscShaderSrc}
+
+// NTSCOptions represents options for DrawNTSC.
+type NTSCOptions struct {
+	// Bleed is the width of the color bleeding in the offscreen's pixels.
+	// If Bleed is 0, colors don't bleed.
+	Bleed float64
+
+	// Fringe is the strength of the color fringes around sharp edges, in [0, 1].
+	Fringe float64
+}
+
+var defaultNTSCOptions = NTSCOptions{
+	Bleed:  2,
+	Fringe: 0.3,
+}
+
+// DrawNTSC draws offscreen onto screen with geoM with the artifacts of an NTSC composite video signal.
+//
+// If options is nil, the default options are used:
+// Bleed is 2 and Fringe is 0.3.
+func DrawNTSC(screen ebiten.FinalScreen, offscreen *ebiten.Image, geoM ebiten.GeoM, options *NTSCOptions) {
+	if options == nil {
+		options = &defaultNTSCOptions
+	}
+	drawShader(screen, offscreen, geoM, ntscShader.get(), map[string]any{
+		"Bleed":  float32(options.Bleed),
+		"Fringe": float32(options.Fringe),
+	})
+}
@@ -0,0 +1,62 @@
+// Copyright 2026 The Ebitengine Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package retro provides final-pass shaders that emulate retro displays, like CRT, LCD, NTSC artifacting, and dithering.
+//
+// This package is experimental and the API might be changed in the future.
+//
+// Each effect is a function that has the same signature as ebiten.DefaultDrawFinalScreen except for its options,
+// and can be called from ebiten.FinalScreenDrawer's DrawFinalScreen:
+//
+//	func (g *Game) DrawFinalScreen(screen ebiten.FinalScreen, offscreen *ebiten.Image, geoM ebiten.GeoM) {
+//		retro.DrawCRT(screen, offscreen, geoM, nil)
+//	}
+//
+// As *ebiten.Image implements ebiten.FinalScreen, the effects can also render onto a regular image.
+package retro
+
+import (
+	"fmt"
+	"sync"
+
+	"github.com/duplicants-ai/ebiten"
+)
+
+// drawShader renders offscreen with the shader onto screen with geoM.
+func drawShader(screen ebiten.FinalScreen, offscreen *ebiten.Image, geoM ebiten.GeoM, shader *ebiten.Shader, uniforms map[string]any) {
+	op := &ebiten.DrawRectShaderOptions{}
+	op.GeoM = geoM
+	op.Images[0] = offscreen
+	op.Uniforms = uniforms
+	b := offscreen.Bounds()
+	screen.DrawRectShader(b.Dx(), b.Dy(), shader, op)
+}
+
+// lazyShader is a shader compiled when it is used first.
+type lazyShader struct {
+	src    string
+	shader *ebiten.Shader
+	once   sync.Once
+}
+
+func (l *lazyShader) get() *ebiten.Shader {
+	l.once.Do(func() {
+		s, err := ebiten.NewShader([]byte(l.src))
+		if err != nil {
+			panic(fmt.Sprintf("retro: NewShader failed: %v", err))
+		}
+		l.shader = s
+	})
+	return l.shader
+}
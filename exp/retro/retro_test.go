@@ -0,0 +1,117 @@
+// Copyright 2026 The Ebitengine Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package retro_test
+
+import (
+	"image/color"
+	"testing"
+
+	"github.com/duplicants-ai/ebiten"
+	"github.com/duplicants-ai/ebiten/exp/retro"
+	etesting "github.com/duplicants-ai/ebiten/internal/testing"
+	"github.com/duplicants-ai/ebiten/internal/ui"
+)
+
+func TestMain(m *testing.M) {
+	ui.SetPanicOnErrorOnReadingPixelsForTesting(true)
+	etesting.MainWithRunLoop(m)
+}
+
+func TestDrawCRTWithoutEffects(t *testing.T) {
+	const w, h = 8, 8
+	offscreen := ebiten.NewImage(w, h)
+	offscreen.Fill(color.RGBA{R: 0x80, G: 0x40, B: 0x20, A: 0xff})
+	offscreen.Set(3, 4, color.RGBA{R: 0xff, A: 0xff})
+
+	var geoM ebiten.GeoM
+	geoM.Scale(2, 2)
+	screen := ebiten.NewImage(2*w, 2*h)
+	retro.DrawCRT(screen, offscreen, geoM, &retro.CRTOptions{})
+
+	for j := 0; j < 2*h; j++ {
+		for i := 0; i < 2*w; i++ {
+			got := screen.At(i, j).(color.RGBA)
+			want := offscreen.At(i/2, j/2).(color.RGBA)
+			if !etesting.SameColors(got, want, 1) {
+				t.Errorf("screen.At(%d, %d): got: %v, want: %v", i, j, got, want)
+			}
+		}
+	}
+}
+
+func TestDrawLCDGrid(t *testing.T) {
+	const w, h = 4, 4
+	offscreen := ebiten.NewImage(w, h)
+	offscreen.Fill(color.White)
+
+	var geoM ebiten.GeoM
+	geoM.Scale(4, 4)
+	screen := ebiten.NewImage(4*w, 4*h)
+	retro.DrawLCD(screen, offscreen, geoM, &retro.LCDOptions{Grid: 1})
+
+	for j := 0; j < 4*h; j++ {
+		for i := 0; i < 4*w; i++ {
+			got := screen.At(i, j).(color.RGBA)
+			want := color.RGBA{R: 0xff, G: 0xff, B: 0xff, A: 0xff}
+			if i%4 == 0 || j%4 == 0 {
+				want = color.RGBA{A: 0xff}
+			}
+			if !etesting.SameColors(got, want, 1) {
+				t.Errorf("screen.At(%d, %d): got: %v, want: %v", i, j, got, want)
+			}
+		}
+	}
+}
+
+func TestDrawDither(t *testing.T) {
+	const w, h = 8, 8
+	offscreen := ebiten.NewImage(w, h)
+	offscreen.Fill(color.RGBA{R: 0x80, G: 0x80, B: 0x80, A: 0xff})
+
+	screen := ebiten.NewImage(w, h)
+	retro.DrawDither(screen, offscreen, ebiten.GeoM{}, &retro.DitherOptions{
+		Palette: color.Palette{color.Black, color.White},
+	})
+
+	// A middle gray is dithered to black and white with the same ratio.
+	var white int
+	for j := 0; j < h; j++ {
+		for i := 0; i < w; i++ {
+			switch got := screen.At(i, j).(color.RGBA); got {
+			case color.RGBA{A: 0xff}:
+			case color.RGBA{R: 0xff, G: 0xff, B: 0xff, A: 0xff}:
+				white++
+			default:
+				t.Errorf("screen.At(%d, %d): got: %v, want: black or white", i, j, got)
+			}
+		}
+	}
+	if got, want := white, w*h/2; got != want {
+		t.Errorf("the number of white pixels: got: %d, want: %d", got, want)
+	}
+}
+
+func TestDrawDitherPanics(t *testing.T) {
+	defer func() {
+		if recover() == nil {
+			t.Errorf("DrawDither must panic with a too large palette")
+		}
+	}()
+	offscreen := ebiten.NewImage(1, 1)
+	screen := ebiten.NewImage(1, 1)
+	retro.DrawDither(screen, offscreen, ebiten.GeoM{}, &retro.DitherOptions{
+		Palette: make(color.Palette, retro.MaxDitherPaletteSize+1),
+	})
+}
@@ -0,0 +1,135 @@
+// Copyright 2026 The Ebitengine Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package scheduler
+
+import (
+	"runtime"
+	"time"
+)
+
+// Coroutine is a handle for a coroutine started by Scheduler's Go.
+//
+// Coroutine's functions must be called only from the coroutine's function.
+type Coroutine struct {
+	scheduler *Scheduler
+
+	// resumeCh receives true to resume the coroutine, or false to cancel it.
+	resumeCh chan bool
+
+	// yieldCh is sent when the coroutine is suspended, and is closed when the coroutine ends.
+	yieldCh chan struct{}
+
+	panicValue any
+
+	wakeAt   time.Duration
+	wakeTick int64
+	cond     func() bool
+}
+
+func newCoroutine(s *Scheduler, f func(co *Coroutine)) *Coroutine {
+	c := &Coroutine{
+		scheduler: s,
+		resumeCh:  make(chan bool),
+		yieldCh:   make(chan struct{}),
+	}
+	go func() {
+		defer close(c.yieldCh)
+		defer func() {
+			// Goexit by cancel doesn't have a recovered value.
+			if r := recover(); r != nil {
+				c.panicValue = r
+			}
+		}()
+		if !<-c.resumeCh {
+			return
+		}
+		f(c)
+	}()
+	return c
+}
+
+// isReady reports whether the coroutine's waiting condition is satisfied.
+func (c *Coroutine) isReady() bool {
+	s := c.scheduler
+	if s.tick < c.wakeTick {
+		return false
+	}
+	if s.now < c.wakeAt {
+		return false
+	}
+	if c.cond != nil && !c.cond() {
+		return false
+	}
+	return true
+}
+
+// resume resumes the coroutine until it is suspended again.
+// resume returns false if the coroutine has ended.
+func (c *Coroutine) resume() bool {
+	c.cond = nil
+	c.resumeCh <- true
+	_, ok := <-c.yieldCh
+	if !ok && c.panicValue != nil {
+		panic(c.panicValue)
+	}
+	return ok
+}
+
+// cancel ends the coroutine without resuming f.
+func (c *Coroutine) cancel() {
+	c.resumeCh <- false
+	// Wait for the goroutine to end.
+	for range c.yieldCh {
+	}
+}
+
+// suspend suspends the coroutine until the scheduler resumes it.
+func (c *Coroutine) suspend() {
+	c.yieldCh <- struct{}{}
+	if !<-c.resumeCh {
+		runtime.Goexit()
+	}
+}
+
+// Yield suspends the coroutine until the next tick.
+func (c *Coroutine) Yield() {
+	c.WaitTicks(1)
+}
+
+// WaitTicks suspends the coroutine for n ticks.
+func (c *Coroutine) WaitTicks(n int) {
+	c.wakeTick = c.scheduler.tick + int64(n)
+	c.suspend()
+}
+
+// Wait suspends the coroutine for the duration d in the scheduler's time.
+//
+// As the scheduler's time advances by ticks, the coroutine is resumed at the first tick after d elapses.
+func (c *Coroutine) Wait(d time.Duration) {
+	c.wakeAt = c.scheduler.now + d
+	c.suspend()
+}
+
+// WaitUntil suspends the coroutine until cond returns true.
+// cond is called once every tick.
+func (c *Coroutine) WaitUntil(cond func() bool) {
+	c.cond = cond
+	c.suspend()
+}
+
+// Now returns the current time of the scheduler.
+func (c *Coroutine) Now() time.Duration {
+	return c.scheduler.now
+}
@@ -0,0 +1,199 @@
+// Copyright 2026 The Ebitengine Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package scheduler provides delayed and repeated callbacks and coroutine-style sequences driven by Update ticks.
+//
+// This package is experimental and the API might be changed in the future.
+//
+// A Scheduler advances only when its Update is called, typically once in the game's Update.
+// The time of a Scheduler follows an *ebiten.Clock, so the scheduled tasks respect the clock's time scale and pausing.
+package scheduler
+
+import (
+	"time"
+
+	"github.com/duplicants-ai/ebiten"
+)
+
+// Scheduler schedules callbacks and coroutines.
+//
+// Scheduler is not concurrent-safe. Use a Scheduler on the same goroutine as the game's Update.
+type Scheduler struct {
+	clock *ebiten.Clock
+	now   time.Duration
+	tick  int64
+
+	tasks    []*Task
+	newTasks []*Task
+}
+
+// NewScheduler creates a new Scheduler.
+//
+// The Scheduler's time advances by clock's Delta every Update.
+// If clock is nil, a new clock is created, and the time advances by 1/TPS [s] every Update.
+func NewScheduler(clock *ebiten.Clock) *Scheduler {
+	if clock == nil {
+		clock = ebiten.NewClock()
+	}
+	return &Scheduler{
+		clock: clock,
+	}
+}
+
+// Task is a scheduled callback or coroutine.
+type Task struct {
+	at       time.Duration
+	interval time.Duration
+	repeat   bool
+	f        func()
+	co       *Coroutine
+	done     bool
+}
+
+// Cancel cancels the task.
+// Cancel does nothing if the task is already done.
+//
+// Cancel must not be called from the task's own coroutine. Return from the coroutine's function instead.
+func (t *Task) Cancel() {
+	if t.done {
+		return
+	}
+	t.done = true
+	if t.co != nil {
+		t.co.cancel()
+	}
+}
+
+// IsDone reports whether the task is done or canceled.
+func (t *Task) IsDone() bool {
+	return t.done
+}
+
+// Now returns the current time of the scheduler.
+func (s *Scheduler) Now() time.Duration {
+	return s.now
+}
+
+// After schedules f to be called once after the duration d.
+func (s *Scheduler) After(d time.Duration, f func()) *Task {
+	t := &Task{
+		at: s.now + d,
+		f:  f,
+	}
+	s.newTasks = append(s.newTasks, t)
+	return t
+}
+
+// Every schedules f to be called repeatedly at the interval d until the task is canceled.
+// If d is 0 or less, f is called every tick.
+func (s *Scheduler) Every(d time.Duration, f func()) *Task {
+	t := &Task{
+		at:       s.now + d,
+		interval: max(d, 0),
+		repeat:   true,
+		f:        f,
+	}
+	s.newTasks = append(s.newTasks, t)
+	return t
+}
+
+// Go starts a coroutine that runs f.
+//
+// f starts at the next Update, and is suspended when f calls a wait function of the given Coroutine.
+// f never runs in parallel with the game's Update, so f can access the game state without synchronization.
+// If f panics, the panic is propagated to Update.
+func (s *Scheduler) Go(f func(co *Coroutine)) *Task {
+	t := &Task{
+		at: s.now,
+	}
+	t.co = newCoroutine(s, f)
+	s.newTasks = append(s.newTasks, t)
+	return t
+}
+
+// Close cancels all the pending tasks including the coroutines.
+//
+// A coroutine runs on its own goroutine while it is suspended, so call Close when the Scheduler is no longer used.
+// Otherwise, the goroutines of the pending coroutines are leaked.
+//
+// Close can be called from a callback of the Scheduler, but must not be called from a coroutine of the Scheduler.
+// The Scheduler can be used again after Close.
+func (s *Scheduler) Close() {
+	for _, t := range s.tasks {
+		t.Cancel()
+	}
+	for _, t := range s.newTasks {
+		t.Cancel()
+	}
+}
+
+// Update advances the scheduler by one tick, and runs the tasks whose time has come.
+//
+// Update should be called once in the game's Update.
+func (s *Scheduler) Update() {
+	s.tick++
+	s.now += s.clock.Delta()
+
+	s.tasks = append(s.tasks, s.newTasks...)
+	s.newTasks = s.newTasks[:0]
+
+	// Tasks added by the callbacks are processed at the next tick.
+	n := len(s.tasks)
+	for i := 0; i < n; i++ {
+		t := s.tasks[i]
+		if t.done {
+			continue
+		}
+		if t.co != nil {
+			if !t.co.isReady() {
+				continue
+			}
+			if !t.co.resume() {
+				t.done = true
+			}
+			continue
+		}
+		if t.at > s.now {
+			continue
+		}
+		t.f()
+		if t.done {
+			continue
+		}
+		if !t.repeat {
+			t.done = true
+			continue
+		}
+		// A task with a non-positive interval is called every tick.
+		if t.interval == 0 {
+			continue
+		}
+		// Catch up to the current time without calling f multiple times in one tick.
+		for t.at <= s.now {
+			t.at += t.interval
+		}
+	}
+
+	// Remove the done tasks.
+	var idx int
+	for _, t := range s.tasks {
+		if t.done {
+			continue
+		}
+		s.tasks[idx] = t
+		idx++
+	}
+	clear(s.tasks[idx:])
+	s.tasks = s.tasks[:idx]
+}
@@ -0,0 +1,241 @@
+// Copyright 2026 The Ebitengine Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package scheduler_test
+
+import (
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/duplicants-ai/ebiten"
+	"github.com/duplicants-ai/ebiten/exp/scheduler"
+)
+
+func TestAfter(t *testing.T) {
+	c := ebiten.NewClock()
+	s := scheduler.NewScheduler(c)
+	d := c.Delta()
+
+	var ticks []int
+	var tick int
+	task := s.After(3*d, func() {
+		ticks = append(ticks, tick)
+	})
+	for tick = 1; tick <= 5; tick++ {
+		s.Update()
+	}
+	if got, want := ticks, []int{3}; !slices.Equal(got, want) {
+		t.Errorf("got: %v, want: %v", got, want)
+	}
+	if !task.IsDone() {
+		t.Errorf("task.IsDone(): got: false, want: true")
+	}
+}
+
+func TestEvery(t *testing.T) {
+	c := ebiten.NewClock()
+	s := scheduler.NewScheduler(c)
+	d := c.Delta()
+
+	for _, tc := range []struct {
+		interval int
+		want     []int
+	}{
+		{interval: 2, want: []int{2, 4, 6}},
+		{interval: 0, want: []int{1, 2, 3, 4, 5, 6}},
+	} {
+		var ticks []int
+		var tick int
+		task := s.Every(d*time.Duration(tc.interval), func() {
+			ticks = append(ticks, tick)
+		})
+		for tick = 1; tick <= 6; tick++ {
+			s.Update()
+		}
+		task.Cancel()
+		s.Update()
+
+		if got := ticks; !slices.Equal(got, tc.want) {
+			t.Errorf("interval: %d, got: %v, want: %v", tc.interval, got, tc.want)
+		}
+	}
+}
+
+func TestGo(t *testing.T) {
+	c := ebiten.NewClock()
+	s := scheduler.NewScheduler(c)
+	d := c.Delta()
+
+	var ticks []int
+	var tick int
+	var ready bool
+	task := s.Go(func(co *scheduler.Coroutine) {
+		ticks = append(ticks, tick)
+		co.Yield()
+		ticks = append(ticks, tick)
+		co.WaitTicks(2)
+		ticks = append(ticks, tick)
+		co.Wait(3 * d)
+		ticks = append(ticks, tick)
+		co.WaitUntil(func() bool {
+			return ready
+		})
+		ticks = append(ticks, tick)
+	})
+	for tick = 1; tick <= 10; tick++ {
+		if tick == 9 {
+			ready = true
+		}
+		s.Update()
+	}
+	if got, want := ticks, []int{1, 2, 4, 7, 9}; !slices.Equal(got, want) {
+		t.Errorf("got: %v, want: %v", got, want)
+	}
+	if !task.IsDone() {
+		t.Errorf("task.IsDone(): got: false, want: true")
+	}
+}
+
+func TestCancel(t *testing.T) {
+	c := ebiten.NewClock()
+	s := scheduler.NewScheduler(c)
+	d := c.Delta()
+
+	var called bool
+	task0 := s.After(2*d, func() {
+		called = true
+	})
+
+	var resumed, exited bool
+	task1 := s.Go(func(co *scheduler.Coroutine) {
+		defer func() {
+			exited = true
+		}()
+		co.Yield()
+		resumed = true
+	})
+
+	s.Update()
+	task0.Cancel()
+	task1.Cancel()
+	for i := 0; i < 3; i++ {
+		s.Update()
+	}
+
+	if called {
+		t.Errorf("the canceled callback must not be called")
+	}
+	if resumed {
+		t.Errorf("the canceled coroutine must not be resumed")
+	}
+	if !exited {
+		t.Errorf("the canceled coroutine's goroutine must end")
+	}
+	if !task0.IsDone() || !task1.IsDone() {
+		t.Errorf("the canceled tasks must be done")
+	}
+}
+
+func TestCancelFromCallback(t *testing.T) {
+	s := scheduler.NewScheduler(nil)
+
+	var count int
+	var task *scheduler.Task
+	task = s.Every(0, func() {
+		count++
+		if count == 2 {
+			task.Cancel()
+		}
+	})
+	for i := 0; i < 5; i++ {
+		s.Update()
+	}
+	if got, want := count, 2; got != want {
+		t.Errorf("count: got: %d, want: %d", got, want)
+	}
+}
+
+func TestGoPanic(t *testing.T) {
+	s := scheduler.NewScheduler(nil)
+	s.Go(func(co *scheduler.Coroutine) {
+		co.Yield()
+		panic("foo")
+	})
+
+	s.Update()
+
+	defer func() {
+		if got, want := recover(), "foo"; got != want {
+			t.Errorf("recover(): got: %v, want: %v", got, want)
+		}
+	}()
+	s.Update()
+}
+
+func TestClose(t *testing.T) {
+	s := scheduler.NewScheduler(nil)
+
+	var called bool
+	tasks := []*scheduler.Task{
+		s.After(0, func() {
+			called = true
+		}),
+	}
+
+	var exited int
+	for i := 0; i < 3; i++ {
+		tasks = append(tasks, s.Go(func(co *scheduler.Coroutine) {
+			defer func() {
+				exited++
+			}()
+			for {
+				co.Yield()
+			}
+		}))
+	}
+	// Start the coroutines.
+	s.Update()
+	called = false
+
+	// A task not started yet is also canceled.
+	tasks = append(tasks, s.After(0, func() {
+		called = true
+	}))
+
+	s.Close()
+
+	for i, task := range tasks {
+		if !task.IsDone() {
+			t.Errorf("tasks[%d].IsDone(): got: false, want: true", i)
+		}
+	}
+	if got, want := exited, 3; got != want {
+		t.Errorf("exited coroutines: got: %d, want: %d", got, want)
+	}
+
+	s.Update()
+	if called {
+		t.Errorf("the canceled callback must not be called")
+	}
+
+	// The scheduler can be used after Close.
+	s.After(0, func() {
+		called = true
+	})
+	s.Update()
+	if !called {
+		t.Errorf("a callback scheduled after Close must be called")
+	}
+}
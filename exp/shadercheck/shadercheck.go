@@ -0,0 +1,902 @@
+// Copyright 2026 The Ebitengine Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package shadercheck provides an analyzer to check Kage shaders at build time.
+//
+// This package is experimental and the API might be changed in the future.
+//
+// Analyzer compiles the Kage sources referred by a package, and reports the compile errors at the positions in the Kage sources.
+// The Kage sources are:
+//
+//   - Files specified by //ebitengine:shaderfile directives
+//   - Constants with //ebitengine:shadersource directives
+//   - Arguments of ebiten.NewShader that are constant strings or variables with //go:embed directives
+//
+// Analyzer also checks the uniform variables passed via DrawRectShaderOptions and DrawTrianglesShaderOptions.
+// When the shader of a draw call is determined statically, e.g., a variable assigned only by ebiten.NewShader with a known source,
+// Analyzer reports uniform names that the shader doesn't declare, and uniform values whose types don't match the declarations.
+//
+// The command github.com/duplicants-ai/ebiten/cmd/ebitenshadercheck runs Analyzer, and can be used with go vet:
+//
+//	go install github.com/duplicants-ai/ebiten/cmd/ebitenshadercheck@latest
+//	go vet -vettool=$(which ebitenshadercheck) ./...
+package shadercheck
+
+import (
+	"errors"
+	"go/ast"
+	"go/constant"
+	"go/scanner"
+	"go/token"
+	"go/types"
+	"os"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+
+	"golang.org/x/tools/go/analysis"
+	"golang.org/x/tools/go/analysis/passes/inspect"
+	"golang.org/x/tools/go/ast/inspector"
+
+	"github.com/duplicants-ai/ebiten/internal/graphics"
+	"github.com/duplicants-ai/ebiten/internal/shaderir"
+)
+
+// Analyzer is an analyzer to check Kage shaders.
+var Analyzer = &analysis.Analyzer{
+	Name:     "shadercheck",
+	Doc:      "check Kage shaders and the uniform variables passed to them",
+	Requires: []*analysis.Analyzer{inspect.Analyzer},
+	Run:      run,
+}
+
+const ebitenPkgPath = "github.com/duplicants-ai/ebiten"
+
+const (
+	shaderSourceDirective = "ebitengine:shadersource"
+	shaderFileDirective   = "ebitengine:shaderfile"
+)
+
+var (
+	reShaderSourceDirective = regexp.MustCompile(`^\s*//` + regexp.QuoteMeta(shaderSourceDirective) + `$`)
+	reShaderFileDirective   = regexp.MustCompile(`^\s*//` + regexp.QuoteMeta(shaderFileDirective) + ` `)
+	reGoEmbedDirective      = regexp.MustCompile(`^\s*//go:embed\s+(\S+)\s*$`)
+	reErrorPosition         = regexp.MustCompile(`^(\d+):(\d+): (.*)$`)
+)
+
+// kageSource is a Kage source and its position in the package.
+type kageSource struct {
+	src []byte
+
+	// pos returns the position of the line and the column in the source. line and column start with 1.
+	pos func(line, column int) token.Pos
+}
+
+// shader is a compiled shader.
+type shader struct {
+	// uniforms is the uniform variables. uniforms is nil when the compilation fails.
+	uniforms map[string]shaderir.Type
+}
+
+type checker struct {
+	pass *analysis.Pass
+
+	// shaders is the compiled shaders keyed by the sources.
+	shaders map[string]*shader
+
+	// kageFiles is the Kage files added to the file set.
+	kageFiles map[string]*token.File
+
+	// shaderVars is the shaders assigned to the variables and the fields.
+	// A nil value means that the shader cannot be determined statically.
+	shaderVars map[types.Object]*shader
+
+	// constExprs is the expressions of the constants declared in the package.
+	constExprs map[*types.Const]ast.Expr
+
+	// embeddedFiles is the files embedded into the variables declared in the package.
+	embeddedFiles map[*types.Var]string
+}
+
+func run(pass *analysis.Pass) (any, error) {
+	c := &checker{
+		pass:          pass,
+		shaders:       map[string]*shader{},
+		kageFiles:     map[string]*token.File{},
+		shaderVars:    map[types.Object]*shader{},
+		constExprs:    map[*types.Const]ast.Expr{},
+		embeddedFiles: map[*types.Var]string{},
+	}
+	c.collectDecls()
+	if err := c.checkShaderFiles(); err != nil {
+		return nil, err
+	}
+	c.checkShaderSources()
+
+	in := pass.ResultOf[inspect.Analyzer].(*inspector.Inspector)
+	c.checkNewShaderCalls(in)
+	c.checkDrawCalls(in)
+	return nil, nil
+}
+
+// collectDecls collects the top-level constants and the embedded variables.
+func (c *checker) collectDecls() {
+	for _, f := range c.pass.Files {
+		for _, decl := range f.Decls {
+			genDecl, ok := decl.(*ast.GenDecl)
+			if !ok {
+				continue
+			}
+			for _, spec := range genDecl.Specs {
+				spec, ok := spec.(*ast.ValueSpec)
+				if !ok {
+					continue
+				}
+				for i, name := range spec.Names {
+					switch obj := c.pass.TypesInfo.Defs[name].(type) {
+					case *types.Const:
+						if i < len(spec.Values) {
+							c.constExprs[obj] = spec.Values[i]
+						}
+					case *types.Var:
+						doc := spec.Doc
+						if doc == nil && genDecl.Lparen == token.NoPos {
+							doc = genDecl.Doc
+						}
+						if path, ok := embeddedFile(doc); ok && len(spec.Names) == 1 {
+							dir := filepath.Dir(c.pass.Fset.Position(f.Pos()).Filename)
+							c.embeddedFiles[obj] = filepath.Join(dir, filepath.FromSlash(path))
+						}
+					}
+				}
+			}
+		}
+	}
+}
+
+// embeddedFile returns the file path of a //go:embed directive.
+// embeddedFile returns false when the directive doesn't specify exactly one file.
+func embeddedFile(doc *ast.CommentGroup) (string, bool) {
+	if doc == nil {
+		return "", false
+	}
+	var path string
+	for _, c := range doc.List {
+		m := reGoEmbedDirective.FindStringSubmatch(c.Text)
+		if m == nil {
+			continue
+		}
+		if path != "" {
+			return "", false
+		}
+		path = m[1]
+	}
+	if path == "" || strings.ContainsAny(path, "*?[]\"`") {
+		return "", false
+	}
+	return path, true
+}
+
+func (c *checker) compile(src kageSource) *shader {
+	if s, ok := c.shaders[string(src.src)]; ok {
+		return s
+	}
+
+	s := &shader{}
+	c.shaders[string(src.src)] = s
+
+	ir, err := graphics.CompileShader(src.src)
+	if err != nil {
+		for _, e := range splitError(err) {
+			c.pass.Report(analysis.Diagnostic{
+				Pos:     src.pos(e.line, e.column),
+				Message: "shader compile error: " + e.message,
+			})
+		}
+		return s
+	}
+
+	s.uniforms = map[string]shaderir.Type{}
+	for i, name := range ir.UniformNames {
+		if strings.HasPrefix(name, "__") {
+			continue
+		}
+		s.uniforms[name] = ir.Uniforms[i]
+	}
+	return s
+}
+
+type shaderError struct {
+	line    int
+	column  int
+	message string
+}
+
+// splitError splits a compile error into the errors with the positions.
+func splitError(err error) []shaderError {
+	var list scanner.ErrorList
+	if errors.As(err, &list) {
+		errs := make([]shaderError, 0, len(list))
+		for _, e := range list {
+			errs = append(errs, shaderError{
+				line:    e.Pos.Line,
+				column:  e.Pos.Column,
+				message: e.Msg,
+			})
+		}
+		return errs
+	}
+
+	var errs []shaderError
+	for _, line := range strings.Split(err.Error(), "\n") {
+		m := reErrorPosition.FindStringSubmatch(line)
+		if m == nil {
+			errs = append(errs, shaderError{message: line})
+			continue
+		}
+		l, _ := strconv.Atoi(m[1])
+		col, _ := strconv.Atoi(m[2])
+		errs = append(errs, shaderError{
+			line:    l,
+			column:  col,
+			message: m[3],
+		})
+	}
+	return errs
+}
+
+// kageFileSource returns the Kage source of the file.
+func (c *checker) kageFileSource(path string) (kageSource, error) {
+	src, err := os.ReadFile(path)
+	if err != nil {
+		return kageSource{}, err
+	}
+
+	f, ok := c.kageFiles[path]
+	if !ok {
+		f = c.pass.Fset.AddFile(path, -1, len(src))
+		f.SetLinesForContent(src)
+		c.kageFiles[path] = f
+	}
+	return kageSource{
+		src: src,
+		pos: func(line, column int) token.Pos {
+			if line < 1 || line > f.LineCount() {
+				return f.Pos(0)
+			}
+			p := f.LineStart(line)
+			return min(p+token.Pos(max(column-1, 0)), token.Pos(f.Base()+f.Size()))
+		},
+	}, nil
+}
+
+// exprSource returns the Kage source of a constant expression.
+// If expr is a raw string literal, the positions in the source are mapped to the positions in the literal.
+func (c *checker) exprSource(src string, expr ast.Expr) kageSource {
+	fallback := expr.Pos()
+	lit, ok := ast.Unparen(expr).(*ast.BasicLit)
+	if !ok || lit.Kind != token.STRING || !strings.HasPrefix(lit.Value, "`") || strings.Contains(lit.Value, "\r") {
+		return kageSource{
+			src: []byte(src),
+			pos: func(line, column int) token.Pos {
+				return fallback
+			},
+		}
+	}
+
+	// The content of the literal starts after the back quote.
+	start := lit.Pos() + 1
+	lineStarts := []int{0}
+	for i, r := range lit.Value[1 : len(lit.Value)-1] {
+		if r == '\n' {
+			lineStarts = append(lineStarts, i+1)
+		}
+	}
+	return kageSource{
+		src: []byte(src),
+		pos: func(line, column int) token.Pos {
+			if line < 1 || line > len(lineStarts) {
+				return fallback
+			}
+			return start + token.Pos(lineStarts[line-1]+max(column-1, 0))
+		},
+	}
+}
+
+func includesGlobMetaChar(str string) bool {
+	// '-' and '^' are meta characters only when these are in brackets.
+	// So, these don't need to be checked.
+	return strings.ContainsAny(str, "*?[]")
+}
+
+func isASCIISpace(r rune) bool {
+	return r == ' ' || r == '\t' || r == '\v' || r == '\n' || r == '\r'
+}
+
+// checkShaderFiles compiles the files specified by //ebitengine:shaderfile directives.
+func (c *checker) checkShaderFiles() error {
+	visited := map[string]struct{}{}
+	for _, f := range c.pass.Files {
+		var funcs []*ast.FuncDecl
+		for _, decl := range f.Decls {
+			if f, ok := decl.(*ast.FuncDecl); ok {
+				funcs = append(funcs, f)
+			}
+		}
+		dir := filepath.Dir(c.pass.Fset.Position(f.Pos()).Filename)
+
+		for _, cg := range f.Comments {
+			for _, comment := range cg.List {
+				// Ignore the line if it is in a function declaration.
+				if slices.ContainsFunc(funcs, func(f *ast.FuncDecl) bool {
+					return f.Pos() <= comment.Pos() && comment.Pos() < f.End()
+				}) {
+					continue
+				}
+				for _, line := range strings.Split(comment.Text, "\n") {
+					m := reShaderFileDirective.FindString(line)
+					if len(m) == 0 {
+						continue
+					}
+					for _, pattern := range strings.FieldsFunc(strings.TrimPrefix(line, m), isASCIISpace) {
+						paths, err := shaderFilePaths(filepath.Join(dir, filepath.FromSlash(pattern)))
+						if err != nil {
+							return err
+						}
+						if len(paths) == 0 {
+							c.pass.Reportf(comment.Pos(), "no shader file matches %s", pattern)
+							continue
+						}
+						for _, path := range paths {
+							if _, ok := visited[path]; ok {
+								continue
+							}
+							visited[path] = struct{}{}
+							src, err := c.kageFileSource(path)
+							if err != nil {
+								return err
+							}
+							c.compile(src)
+						}
+					}
+				}
+			}
+		}
+	}
+	return nil
+}
+
+// shaderFilePaths returns the file paths matching with the pattern of //ebitengine:shaderfile.
+func shaderFilePaths(pattern string) ([]string, error) {
+	if !includesGlobMetaChar(pattern) {
+		stat, err := os.Stat(pattern)
+		if err != nil && !errors.Is(err, os.ErrNotExist) {
+			return nil, err
+		}
+		if err == nil && stat.IsDir() {
+			// If the pattern is a directory, read all files in the directory recursively.
+			var paths []string
+			if err := filepath.WalkDir(pattern, func(path string, d os.DirEntry, err error) error {
+				if err != nil {
+					return err
+				}
+				if d.IsDir() {
+					return nil
+				}
+				paths = append(paths, path)
+				return nil
+			}); err != nil {
+				return nil, err
+			}
+			return paths, nil
+		}
+	}
+	return filepath.Glob(pattern)
+}
+
+func hasShaderSourceDirective(doc *ast.CommentGroup) bool {
+	if doc == nil {
+		return false
+	}
+	for _, c := range doc.List {
+		for _, line := range strings.Split(c.Text, "\n") {
+			if reShaderSourceDirective.MatchString(line) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// checkShaderSources compiles the constants with //ebitengine:shadersource directives.
+func (c *checker) checkShaderSources() {
+	for _, f := range c.pass.Files {
+		for _, decl := range f.Decls {
+			genDecl, ok := decl.(*ast.GenDecl)
+			if !ok || genDecl.Tok != token.CONST {
+				continue
+			}
+			for _, spec := range genDecl.Specs {
+				spec := spec.(*ast.ValueSpec)
+				doc := spec.Doc
+				if genDecl.Lparen == token.NoPos {
+					doc = genDecl.Doc
+				}
+				if !hasShaderSourceDirective(doc) || len(spec.Names) != 1 {
+					continue
+				}
+				if src, ok := c.constSource(spec.Names[0]); ok {
+					c.compile(src)
+				}
+			}
+		}
+	}
+}
+
+// constSource returns the Kage source of a constant string expression.
+func (c *checker) constSource(expr ast.Expr) (kageSource, bool) {
+	tv, ok := c.pass.TypesInfo.Types[expr]
+	if !ok {
+		// An identifier at its declaration is not recorded in Types.
+		ident, ok := expr.(*ast.Ident)
+		if !ok {
+			return kageSource{}, false
+		}
+		cnst, ok := c.pass.TypesInfo.Defs[ident].(*types.Const)
+		if !ok {
+			return kageSource{}, false
+		}
+		tv.Value = cnst.Val()
+	}
+	if tv.Value == nil || tv.Value.Kind() != constant.String {
+		return kageSource{}, false
+	}
+	src := constant.StringVal(tv.Value)
+
+	// Find the literal of the constant in the package to map the positions.
+	lit := expr
+	for range 16 {
+		ident, ok := ast.Unparen(lit).(*ast.Ident)
+		if !ok {
+			break
+		}
+		obj := c.pass.TypesInfo.ObjectOf(ident)
+		cnst, ok := obj.(*types.Const)
+		if !ok {
+			break
+		}
+		e, ok := c.constExprs[cnst]
+		if !ok {
+			break
+		}
+		lit = e
+	}
+	return c.exprSource(src, lit), true
+}
+
+// isEbitenFunc reports whether obj is the function or the method in the ebiten package.
+func isEbitenFunc(obj types.Object, recv string, name string) bool {
+	f, ok := obj.(*types.Func)
+	if !ok || f.Pkg() == nil || f.Pkg().Path() != ebitenPkgPath || f.Name() != name {
+		return false
+	}
+	sig := f.Type().(*types.Signature)
+	if recv == "" {
+		return sig.Recv() == nil
+	}
+	if sig.Recv() == nil {
+		return false
+	}
+	t := sig.Recv().Type()
+	if p, ok := t.(*types.Pointer); ok {
+		t = p.Elem()
+	}
+	n, ok := t.(*types.Named)
+	return ok && n.Obj().Name() == recv
+}
+
+func (c *checker) calleeObject(call *ast.CallExpr) types.Object {
+	switch fun := ast.Unparen(call.Fun).(type) {
+	case *ast.Ident:
+		return c.pass.TypesInfo.Uses[fun]
+	case *ast.SelectorExpr:
+		return c.pass.TypesInfo.Uses[fun.Sel]
+	}
+	return nil
+}
+
+// exprObject returns the variable or the field that expr refers.
+func (c *checker) exprObject(expr ast.Expr) types.Object {
+	switch expr := ast.Unparen(expr).(type) {
+	case *ast.Ident:
+		return c.pass.TypesInfo.ObjectOf(expr)
+	case *ast.SelectorExpr:
+		return c.pass.TypesInfo.ObjectOf(expr.Sel)
+	}
+	return nil
+}
+
+// newShaderSource returns the Kage source of the argument of ebiten.NewShader.
+func (c *checker) newShaderSource(arg ast.Expr) (kageSource, bool) {
+	arg = ast.Unparen(arg)
+
+	// Unwrap a conversion like []byte(src).
+	if call, ok := arg.(*ast.CallExpr); ok && len(call.Args) == 1 {
+		if tv, ok := c.pass.TypesInfo.Types[call.Fun]; ok && tv.IsType() {
+			arg = ast.Unparen(call.Args[0])
+		}
+	}
+
+	if src, ok := c.constSource(arg); ok {
+		return src, true
+	}
+
+	v, ok := c.exprObject(arg).(*types.Var)
+	if !ok {
+		return kageSource{}, false
+	}
+	path, ok := c.embeddedFiles[v]
+	if !ok {
+		return kageSource{}, false
+	}
+	src, err := c.kageFileSource(path)
+	if err != nil {
+		return kageSource{}, false
+	}
+	return src, true
+}
+
+// checkNewShaderCalls compiles the sources passed to ebiten.NewShader, and records the variables of the shaders.
+func (c *checker) checkNewShaderCalls(in *inspector.Inspector) {
+	in.WithStack([]ast.Node{(*ast.CallExpr)(nil)}, func(n ast.Node, push bool, stack []ast.Node) bool {
+		if !push {
+			return true
+		}
+		call := n.(*ast.CallExpr)
+		if !isEbitenFunc(c.calleeObject(call), "", "NewShader") || len(call.Args) != 1 {
+			return true
+		}
+
+		var s *shader
+		if src, ok := c.newShaderSource(call.Args[0]); ok {
+			s = c.compile(src)
+		}
+
+		// Record the variable or the field that the shader is assigned to.
+		var lhs types.Object
+		switch parent := stack[len(stack)-2].(type) {
+		case *ast.AssignStmt:
+			if len(parent.Rhs) == 1 && parent.Rhs[0] == call {
+				lhs = c.exprObject(parent.Lhs[0])
+			}
+		case *ast.ValueSpec:
+			if len(parent.Values) == 1 && parent.Values[0] == call {
+				lhs = c.pass.TypesInfo.Defs[parent.Names[0]]
+			}
+		}
+		if lhs != nil {
+			c.setShaderVar(lhs, s)
+		}
+		return true
+	})
+
+	// Propagate the shaders assigned via other variables, e.g., `s, err := ebiten.NewShader(src); ...; shader = s`.
+	for range 4 {
+		n := len(c.shaderVars)
+		in.Preorder([]ast.Node{(*ast.AssignStmt)(nil)}, func(n ast.Node) {
+			a := n.(*ast.AssignStmt)
+			if len(a.Lhs) != len(a.Rhs) {
+				return
+			}
+			for i := range a.Lhs {
+				rhs := c.exprObject(a.Rhs[i])
+				if rhs == nil {
+					continue
+				}
+				s, ok := c.shaderVars[rhs]
+				if !ok {
+					continue
+				}
+				if lhs := c.exprObject(a.Lhs[i]); lhs != nil {
+					c.setShaderVar(lhs, s)
+				}
+			}
+		})
+		if len(c.shaderVars) == n {
+			break
+		}
+	}
+}
+
+func (c *checker) setShaderVar(obj types.Object, s *shader) {
+	if old, ok := c.shaderVars[obj]; ok && old != s {
+		// The shader is not determined statically.
+		s = nil
+	}
+	c.shaderVars[obj] = s
+}
+
+// checkDrawCalls checks the uniform variables passed to the draw functions with shaders.
+func (c *checker) checkDrawCalls(in *inspector.Inspector) {
+	in.WithStack([]ast.Node{(*ast.CallExpr)(nil)}, func(n ast.Node, push bool, stack []ast.Node) bool {
+		if !push {
+			return true
+		}
+		call := n.(*ast.CallExpr)
+		obj := c.calleeObject(call)
+		if !isEbitenFunc(obj, "Image", "DrawRectShader") &&
+			!isEbitenFunc(obj, "Image", "DrawTrianglesShader") &&
+			!isEbitenFunc(obj, "Image", "DrawTrianglesShader32") {
+			return true
+		}
+		if len(call.Args) != 4 {
+			return true
+		}
+
+		shaderObj := c.exprObject(call.Args[2])
+		if shaderObj == nil {
+			return true
+		}
+		s := c.shaderVars[shaderObj]
+		if s == nil || s.uniforms == nil {
+			return true
+		}
+
+		var body *ast.BlockStmt
+		for i := len(stack) - 1; i >= 0; i-- {
+			switch f := stack[i].(type) {
+			case *ast.FuncDecl:
+				body = f.Body
+			case *ast.FuncLit:
+				body = f.Body
+			}
+			if body != nil {
+				break
+			}
+		}
+
+		for _, u := range c.uniformEntries(call.Args[3], body, call.Pos()) {
+			c.checkUniform(s, u.key, u.value)
+		}
+		return true
+	})
+}
+
+type uniformEntry struct {
+	key   *ast.BasicLit
+	value ast.Expr
+}
+
+// uniformEntries returns the uniform entries of the options.
+//
+// If the options or the uniforms are local variables, the assignments in body before pos are considered.
+func (c *checker) uniformEntries(options ast.Expr, body *ast.BlockStmt, pos token.Pos) []uniformEntry {
+	options = ast.Unparen(options)
+	if u, ok := options.(*ast.UnaryExpr); ok && u.Op == token.AND {
+		options = ast.Unparen(u.X)
+	}
+
+	if lit, ok := options.(*ast.CompositeLit); ok {
+		if v, ok := uniformsField(lit); ok {
+			return c.mapEntries(v, body, pos)
+		}
+		return nil
+	}
+
+	v, ok := c.exprObject(options).(*types.Var)
+	if !ok || v.IsField() || body == nil {
+		return nil
+	}
+
+	// Find the last assignment to the uniforms before the call.
+	var uniforms ast.Expr
+	var uniformsPos token.Pos
+	var entries []uniformEntry
+	c.visitAssignments(body, pos, func(lhs, rhs ast.Expr) {
+		switch lhs := ast.Unparen(lhs).(type) {
+		case *ast.Ident:
+			if c.pass.TypesInfo.ObjectOf(lhs) != v {
+				return
+			}
+			// op := &ebiten.DrawRectShaderOptions{...}
+			uniforms, uniformsPos, entries = nil, token.NoPos, nil
+			rhs = ast.Unparen(rhs)
+			if u, ok := rhs.(*ast.UnaryExpr); ok && u.Op == token.AND {
+				rhs = ast.Unparen(u.X)
+			}
+			if lit, ok := rhs.(*ast.CompositeLit); ok {
+				if u, ok := uniformsField(lit); ok {
+					uniforms, uniformsPos = u, lhs.Pos()
+				}
+			}
+		case *ast.SelectorExpr:
+			// op.Uniforms = map[string]any{...}
+			if lhs.Sel.Name != "Uniforms" || c.exprObject(lhs.X) != v {
+				return
+			}
+			uniforms, uniformsPos, entries = rhs, lhs.Pos(), nil
+		case *ast.IndexExpr:
+			// op.Uniforms["Foo"] = ...
+			sel, ok := ast.Unparen(lhs.X).(*ast.SelectorExpr)
+			if !ok || sel.Sel.Name != "Uniforms" || c.exprObject(sel.X) != v {
+				return
+			}
+			if key, ok := stringLit(lhs.Index); ok {
+				entries = append(entries, uniformEntry{key: key, value: rhs})
+			}
+		}
+	})
+	if uniforms != nil {
+		entries = append(c.mapEntries(uniforms, body, uniformsPos), entries...)
+	}
+	return entries
+}
+
+// mapEntries returns the entries of the uniforms map.
+func (c *checker) mapEntries(m ast.Expr, body *ast.BlockStmt, pos token.Pos) []uniformEntry {
+	m = ast.Unparen(m)
+	if lit, ok := m.(*ast.CompositeLit); ok {
+		return compositeLitEntries(lit)
+	}
+
+	v, ok := c.exprObject(m).(*types.Var)
+	if !ok || v.IsField() || body == nil {
+		return nil
+	}
+
+	var entries []uniformEntry
+	c.visitAssignments(body, pos, func(lhs, rhs ast.Expr) {
+		switch lhs := ast.Unparen(lhs).(type) {
+		case *ast.Ident:
+			// uniforms := map[string]any{...}
+			if c.pass.TypesInfo.ObjectOf(lhs) != v {
+				return
+			}
+			entries = nil
+			if lit, ok := ast.Unparen(rhs).(*ast.CompositeLit); ok {
+				entries = compositeLitEntries(lit)
+			}
+		case *ast.IndexExpr:
+			// uniforms["Foo"] = ...
+			if c.exprObject(lhs.X) != v {
+				return
+			}
+			if key, ok := stringLit(lhs.Index); ok {
+				entries = append(entries, uniformEntry{key: key, value: rhs})
+			}
+		}
+	})
+	return entries
+}
+
+// visitAssignments visits the assignments and the variable declarations in body before pos.
+func (c *checker) visitAssignments(body *ast.BlockStmt, pos token.Pos, f func(lhs, rhs ast.Expr)) {
+	ast.Inspect(body, func(n ast.Node) bool {
+		if n == nil || n.Pos() >= pos {
+			return false
+		}
+		switch n := n.(type) {
+		case *ast.AssignStmt:
+			if len(n.Lhs) != len(n.Rhs) {
+				return true
+			}
+			for i := range n.Lhs {
+				f(n.Lhs[i], n.Rhs[i])
+			}
+		case *ast.ValueSpec:
+			if len(n.Names) != len(n.Values) {
+				return true
+			}
+			for i := range n.Names {
+				f(n.Names[i], n.Values[i])
+			}
+		}
+		return true
+	})
+}
+
+func uniformsField(lit *ast.CompositeLit) (ast.Expr, bool) {
+	for _, e := range lit.Elts {
+		kv, ok := e.(*ast.KeyValueExpr)
+		if !ok {
+			continue
+		}
+		if key, ok := kv.Key.(*ast.Ident); ok && key.Name == "Uniforms" {
+			return kv.Value, true
+		}
+	}
+	return nil, false
+}
+
+func compositeLitEntries(lit *ast.CompositeLit) []uniformEntry {
+	var entries []uniformEntry
+	for _, e := range lit.Elts {
+		kv, ok := e.(*ast.KeyValueExpr)
+		if !ok {
+			continue
+		}
+		if key, ok := stringLit(kv.Key); ok {
+			entries = append(entries, uniformEntry{key: key, value: kv.Value})
+		}
+	}
+	return entries
+}
+
+func stringLit(expr ast.Expr) (*ast.BasicLit, bool) {
+	lit, ok := ast.Unparen(expr).(*ast.BasicLit)
+	if !ok || lit.Kind != token.STRING {
+		return nil, false
+	}
+	return lit, true
+}
+
+// checkUniform checks the uniform value against the shader's declaration.
+// The accepted values are the same as ui.Shader's AppendUniforms.
+func (c *checker) checkUniform(s *shader, key *ast.BasicLit, value ast.Expr) {
+	name, err := strconv.Unquote(key.Value)
+	if err != nil {
+		return
+	}
+	typ, ok := s.uniforms[name]
+	if !ok {
+		c.pass.Reportf(key.Pos(), "uniform %s is not declared in the shader", key.Value)
+		return
+	}
+
+	t := c.pass.TypesInfo.TypeOf(value)
+	if t == nil {
+		return
+	}
+	if isUniformScalarType(t) {
+		if typ.DwordCount() != 1 {
+			c.pass.Reportf(value.Pos(), "uniform %s is %s but the value is a scalar", key.Value, typ.String())
+		}
+		return
+	}
+
+	switch u := t.Underlying().(type) {
+	case *types.Array:
+		if !isUniformScalarType(u.Elem()) {
+			break
+		}
+		if int(u.Len()) != typ.DwordCount() {
+			c.pass.Reportf(value.Pos(), "uniform %s is %s but the value has %d elements", key.Value, typ.String(), u.Len())
+		}
+		return
+	case *types.Slice:
+		if !isUniformScalarType(u.Elem()) {
+			break
+		}
+		if lit, ok := ast.Unparen(value).(*ast.CompositeLit); ok && !slices.ContainsFunc(lit.Elts, func(e ast.Expr) bool {
+			_, ok := e.(*ast.KeyValueExpr)
+			return ok
+		}) {
+			if len(lit.Elts) != typ.DwordCount() {
+				c.pass.Reportf(value.Pos(), "uniform %s is %s but the value has %d elements", key.Value, typ.String(), len(lit.Elts))
+			}
+		}
+		return
+	}
+	c.pass.Reportf(value.Pos(), "unsupported type %s for uniform %s", t.String(), key.Value)
+}
+
+func isUniformScalarType(t types.Type) bool {
+	b, ok := t.Underlying().(*types.Basic)
+	if !ok {
+		return false
+	}
+	return b.Info()&(types.IsInteger|types.IsFloat) != 0
+}
@@ -0,0 +1,75 @@
+// Copyright 2026 The Ebitengine Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package shadercheck_test
+
+import (
+	"encoding/json"
+	"fmt"
+	"os/exec"
+	"path/filepath"
+	"slices"
+	"testing"
+)
+
+func hasGoCommand() bool {
+	if _, err := exec.LookPath("go"); err != nil {
+		return false
+	}
+	return true
+}
+
+func TestRun(t *testing.T) {
+	if !hasGoCommand() {
+		t.Skip("go command is missing")
+	}
+
+	const pkg = "github.com/duplicants-ai/ebiten/exp/shadercheck/shaderchecktest"
+	cmd := exec.Command("go", "run", "github.com/duplicants-ai/ebiten/cmd/ebitenshadercheck", "-json", pkg)
+	out, err := cmd.Output()
+	if err != nil {
+		if err, ok := err.(*exec.ExitError); ok {
+			t.Fatalf("Error: %v\n%s", err, err.Stderr)
+		}
+		t.Fatal(err)
+	}
+
+	type diagnostic struct {
+		Posn    string `json:"posn"`
+		Message string `json:"message"`
+	}
+	var result map[string]map[string][]diagnostic
+	if err := json.Unmarshal(out, &result); err != nil {
+		t.Fatal(err)
+	}
+
+	var got []string
+	for _, d := range result[pkg]["shadercheck"] {
+		got = append(got, fmt.Sprintf("%s: %s", filepath.Base(d.Posn), d.Message))
+	}
+	slices.Sort(got)
+
+	want := []string{
+		`broken_kage.go:22:6: shader compile error: cannot use type none as type int in variable declaration`,
+		`broken_kage.go:23:9: shader compile error: invalid arguments for vec4: (int)`,
+		`def.go:35:9: shader compile error: unexpected identifier: undefinedFunc`,
+		`def.go:74:4: uniform "Tim" is not declared in the shader`,
+		`def.go:80:11: uniform "Time" is float but the value has 2 elements`,
+		`def.go:82:25: uniform "Color" is vec4 but the value has 3 elements`,
+		`def.go:86:12: unsupported type string for uniform "Scale"`,
+	}
+	if !slices.Equal(got, want) {
+		t.Errorf("got:\n%q\nwant:\n%q", got, want)
+	}
+}
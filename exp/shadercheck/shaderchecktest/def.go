@@ -0,0 +1,98 @@
+// Copyright 2026 The Ebitengine Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package shaderchecktest is a package to test shadercheck.
+package shaderchecktest
+
+import (
+	_ "embed"
+
+	"github.com/duplicants-ai/ebiten"
+)
+
+//ebitengine:shaderfile broken_kage.go
+
+//go:embed good_kage.go
+var goodKageGo []byte
+
+//ebitengine:shadersource
+const brokenSource = `//kage:unit pixels
+
+package main
+
+func Fragment(dstPos vec4, srcPos vec2, color vec4) vec4 {
+	return undefinedFunc()
+}
+`
+
+const inlineSource = `//kage:unit pixels
+
+package main
+
+var Scale vec2
+
+func Fragment(dstPos vec4, srcPos vec2, color vec4) vec4 {
+	return vec4(Scale, 0, 1)
+}
+`
+
+var (
+	goodShader   *ebiten.Shader
+	inlineShader *ebiten.Shader
+)
+
+func init() {
+	s, err := ebiten.NewShader(goodKageGo)
+	if err != nil {
+		panic(err)
+	}
+	goodShader = s
+
+	s2, err := ebiten.NewShader([]byte(inlineSource))
+	if err != nil {
+		panic(err)
+	}
+	inlineShader = s2
+}
+
+func Draw(screen *ebiten.Image, time float32) {
+	screen.DrawRectShader(16, 16, goodShader, &ebiten.DrawRectShaderOptions{
+		Uniforms: map[string]any{
+			"Time":  time,
+			"Color": []float32{1, 1, 1, 1},
+			"Tim":   time,
+		},
+	})
+
+	op := &ebiten.DrawRectShaderOptions{}
+	op.Uniforms = map[string]any{
+		"Time": []float32{1, 2},
+	}
+	op.Uniforms["Color"] = [3]float32{1, 1, 1}
+	screen.DrawRectShader(16, 16, goodShader, op)
+
+	uniforms := map[string]any{
+		"Scale": "large",
+	}
+	var top ebiten.DrawTrianglesShaderOptions
+	top.Uniforms = uniforms
+	screen.DrawTrianglesShader(nil, nil, inlineShader, &top)
+
+	// A valid call.
+	screen.DrawTrianglesShader(nil, nil, inlineShader, &ebiten.DrawTrianglesShaderOptions{
+		Uniforms: map[string]any{
+			"Scale": [...]float64{1, 2},
+		},
+	})
+}
@@ -0,0 +1,370 @@
+// Copyright 2026 The Ebitengine Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package soundbank provides a sound bank to play short sound effects.
+//
+// This package is experimental and the API might be changed in the future.
+//
+// A Bank preloads decoded sounds into memory, and plays them as fire-and-forget one-shots.
+// Players are recycled from a pool for each sound, so that playing a sound doesn't allocate a new player every time.
+package soundbank
+
+import (
+	"encoding/binary"
+	"fmt"
+	"io"
+	"math"
+	"math/rand/v2"
+	"sync"
+
+	"github.com/duplicants-ai/ebiten/audio"
+)
+
+const minPitch = 1.0 / 16
+
+// BankOptions represents options for a Bank.
+type BankOptions struct {
+	// MaxVoicesPerSound is the maximum number of players that play the same sound at the same time.
+	// When a sound is played and all the players for the sound are busy, the oldest one is stopped and reused.
+	//
+	// The default (zero) value is 8.
+	MaxVoicesPerSound int
+}
+
+// PlayOptions represents options for Play.
+type PlayOptions struct {
+	// Volume is the volume in [0, 1].
+	//
+	// The default (zero) value is 1.
+	// To play a silent sound, don't call Play.
+	Volume float64
+
+	// VolumeVariation is the maximum random offset added to Volume for each play.
+	//
+	// The default (zero) value is 0.
+	VolumeVariation float64
+
+	// Pan is the stereo panning in [-1, 1]. -1 is left, 0 is center, and 1 is right.
+	//
+	// The default (zero) value is 0.
+	Pan float64
+
+	// PanVariation is the maximum random offset added to Pan for each play.
+	//
+	// The default (zero) value is 0.
+	PanVariation float64
+
+	// Pitch is the playback speed ratio. 2 is one octave higher, and 0.5 is one octave lower.
+	// A pitch less than 1/16 is treated as 1/16.
+	//
+	// The default (zero) value is 1.
+	Pitch float64
+
+	// PitchVariation is the maximum random offset added to Pitch for each play.
+	//
+	// The default (zero) value is 0.
+	PitchVariation float64
+}
+
+// Bank is a set of preloaded sounds.
+//
+// All the functions of Bank are concurrent-safe.
+type Bank struct {
+	context           *audio.Context
+	maxVoicesPerSound int
+
+	sounds map[string]*sound
+
+	m sync.Mutex
+}
+
+type sound struct {
+	// samples is interleaved 32bit float stereo samples.
+	samples []float32
+	voices  []*voice
+}
+
+// NewBank creates a new Bank.
+//
+// options can be nil. In this case, the default options are used.
+func NewBank(context *audio.Context, options *BankOptions) *Bank {
+	var op BankOptions
+	if options != nil {
+		op = *options
+	}
+	if op.MaxVoicesPerSound <= 0 {
+		op.MaxVoicesPerSound = 8
+	}
+	return &Bank{
+		context:           context,
+		maxVoicesPerSound: op.MaxVoicesPerSound,
+		sounds:            map[string]*sound{},
+	}
+}
+
+// Add reads all the data from src and adds it as a sound with the given name.
+// If a sound with the same name already exists, Add replaces it.
+//
+// src's format must be linear PCM (signed 16bits little endian, 2 channel stereo) without a header,
+// e.g. a stream returned by vorbis.Decode or wav.Decode.
+// The sample rate must be same as that of the audio context.
+func (b *Bank) Add(name string, src io.Reader) error {
+	bs, err := io.ReadAll(src)
+	if err != nil {
+		return fmt.Errorf("soundbank: reading %s failed: %w", name, err)
+	}
+	samples := make([]float32, len(bs)/4*2)
+	for i := range samples {
+		samples[i] = float32(int16(binary.LittleEndian.Uint16(bs[2*i:]))) / (1<<15 - 1)
+	}
+	b.addSamples(name, samples)
+	return nil
+}
+
+// AddF32 reads all the data from src and adds it as a sound with the given name.
+// If a sound with the same name already exists, AddF32 replaces it.
+//
+// src's format must be linear PCM (32bit float, little endian, 2 channel stereo) without a header,
+// e.g. a stream returned by vorbis.DecodeF32 or wav.DecodeF32.
+// The sample rate must be same as that of the audio context.
+func (b *Bank) AddF32(name string, src io.Reader) error {
+	bs, err := io.ReadAll(src)
+	if err != nil {
+		return fmt.Errorf("soundbank: reading %s failed: %w", name, err)
+	}
+	samples := make([]float32, len(bs)/8*2)
+	for i := range samples {
+		samples[i] = math.Float32frombits(binary.LittleEndian.Uint32(bs[4*i:]))
+	}
+	b.addSamples(name, samples)
+	return nil
+}
+
+func (b *Bank) addSamples(name string, samples []float32) {
+	b.m.Lock()
+	defer b.m.Unlock()
+	b.removeSound(name)
+	b.sounds[name] = &sound{
+		samples: samples,
+	}
+}
+
+// Remove stops and removes the sound with the given name.
+// Remove does nothing if the sound doesn't exist.
+func (b *Bank) Remove(name string) {
+	b.m.Lock()
+	defer b.m.Unlock()
+	b.removeSound(name)
+}
+
+func (b *Bank) removeSound(name string) {
+	s, ok := b.sounds[name]
+	if !ok {
+		return
+	}
+	for _, v := range s.voices {
+		_ = v.player.Close()
+	}
+	delete(b.sounds, name)
+}
+
+// Play plays the sound with the given name.
+// Play returns immediately, and the sound is played until its end.
+//
+// options can be nil. In this case, the default options are used.
+//
+// Play returns an error when the sound doesn't exist.
+func (b *Bank) Play(name string, options *PlayOptions) error {
+	var op PlayOptions
+	if options != nil {
+		op = *options
+	}
+	if op.Volume == 0 {
+		op.Volume = 1
+	}
+	if op.Pitch == 0 {
+		op.Pitch = 1
+	}
+
+	b.m.Lock()
+	defer b.m.Unlock()
+
+	s, ok := b.sounds[name]
+	if !ok {
+		return fmt.Errorf("soundbank: sound %s not found", name)
+	}
+
+	v, err := b.voice(s)
+	if err != nil {
+		return err
+	}
+
+	volume := clamp(vary(op.Volume, op.VolumeVariation), 0, 1)
+	pan := clamp(vary(op.Pan, op.PanVariation), -1, 1)
+	pitch := math.Max(vary(op.Pitch, op.PitchVariation), minPitch)
+
+	v.reset(s.samples, pan, pitch)
+	v.player.SetVolume(volume)
+	if err := v.player.Rewind(); err != nil {
+		return err
+	}
+	v.player.Play()
+	return nil
+}
+
+// voice returns a player that is not busy for the sound.
+// If all the players are busy, voice returns the oldest one.
+func (b *Bank) voice(s *sound) (*voice, error) {
+	var oldest *voice
+	for _, v := range s.voices {
+		if !v.player.IsPlaying() {
+			return v, nil
+		}
+		if oldest == nil || v.player.Position() > oldest.player.Position() {
+			oldest = v
+		}
+	}
+	if len(s.voices) < b.maxVoicesPerSound {
+		v := &voice{}
+		p, err := b.context.NewPlayerF32(v)
+		if err != nil {
+			return nil, err
+		}
+		v.player = p
+		s.voices = append(s.voices, v)
+		return v, nil
+	}
+	return oldest, nil
+}
+
+// StopAll stops all the sounds.
+func (b *Bank) StopAll() {
+	b.m.Lock()
+	defer b.m.Unlock()
+	for _, s := range b.sounds {
+		for _, v := range s.voices {
+			v.player.Pause()
+		}
+	}
+}
+
+// Close stops and removes all the sounds, and releases the players.
+func (b *Bank) Close() {
+	b.m.Lock()
+	defer b.m.Unlock()
+	for name := range b.sounds {
+		b.removeSound(name)
+	}
+}
+
+func vary(value, variation float64) float64 {
+	if variation == 0 {
+		return value
+	}
+	return value + (rand.Float64()*2-1)*variation
+}
+
+func clamp(v, min, max float64) float64 {
+	if v < min {
+		return min
+	}
+	if v > max {
+		return max
+	}
+	return v
+}
+
+// voice is a 32bit float stereo stream that plays samples with panning and pitch.
+type voice struct {
+	player *audio.Player
+
+	samples []float32
+	// leftGain and rightGain are the gains for the panning.
+	leftGain  float32
+	rightGain float32
+	pitch     float64
+	// pos is the position in the destination frames.
+	pos int64
+
+	m sync.Mutex
+}
+
+const bytesPerFrame = 8
+
+func (v *voice) reset(samples []float32, pan float64, pitch float64) {
+	v.m.Lock()
+	defer v.m.Unlock()
+
+	v.samples = samples
+	// This is the same panning as Unity's AudioSource.panStereo.
+	v.leftGain = float32(math.Min(1-pan, 1))
+	v.rightGain = float32(math.Min(1+pan, 1))
+	v.pitch = pitch
+	v.pos = 0
+}
+
+// length returns the length in the destination frames.
+func (v *voice) length() int64 {
+	return int64(math.Ceil(float64(len(v.samples)/2) / v.pitch))
+}
+
+func (v *voice) Read(buf []byte) (int, error) {
+	v.m.Lock()
+	defer v.m.Unlock()
+
+	frameNum := len(v.samples) / 2
+	length := v.length()
+	var n int
+	for ; n+bytesPerFrame <= len(buf); n += bytesPerFrame {
+		if v.pos >= length {
+			break
+		}
+		// Interpolate the samples linearly.
+		t := float64(v.pos) * v.pitch
+		i := int(t)
+		a := float32(t - float64(i))
+		l, r := v.samples[2*i], v.samples[2*i+1]
+		if i+1 < frameNum {
+			l = l*(1-a) + v.samples[2*(i+1)]*a
+			r = r*(1-a) + v.samples[2*(i+1)+1]*a
+		}
+		binary.LittleEndian.PutUint32(buf[n:], math.Float32bits(l*v.leftGain))
+		binary.LittleEndian.PutUint32(buf[n+4:], math.Float32bits(r*v.rightGain))
+		v.pos++
+	}
+	if v.pos >= length {
+		return n, io.EOF
+	}
+	return n, nil
+}
+
+func (v *voice) Seek(offset int64, whence int) (int64, error) {
+	v.m.Lock()
+	defer v.m.Unlock()
+
+	var pos int64
+	switch whence {
+	case io.SeekStart:
+		pos = offset
+	case io.SeekCurrent:
+		pos = v.pos*bytesPerFrame + offset
+	case io.SeekEnd:
+		pos = v.length()*bytesPerFrame + offset
+	}
+	if pos < 0 {
+		return 0, fmt.Errorf("soundbank: negative position")
+	}
+	v.pos = pos / bytesPerFrame
+	return v.pos * bytesPerFrame, nil
+}
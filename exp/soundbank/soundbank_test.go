@@ -0,0 +1,266 @@
+// Copyright 2026 The Ebitengine Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package soundbank
+
+import (
+	"bytes"
+	"encoding/binary"
+	"io"
+	"math"
+	"testing"
+
+	"github.com/duplicants-ai/ebiten/audio"
+)
+
+var audioContext = audio.NewContext(48000)
+
+// silence returns a 32bit float stereo silence of the given seconds.
+func silence(seconds int) io.Reader {
+	return bytes.NewReader(make([]byte, audioContext.SampleRate()*seconds*bytesPerFrame))
+}
+
+func TestAdd(t *testing.T) {
+	b := NewBank(audioContext, nil)
+	defer b.Close()
+
+	var buf bytes.Buffer
+	for _, v := range []int16{0, 1<<15 - 1, -(1<<15 - 1), 1 << 14} {
+		_ = binary.Write(&buf, binary.LittleEndian, v)
+	}
+	if err := b.Add("foo", &buf); err != nil {
+		t.Fatal(err)
+	}
+	want := []float32{0, 1, -1, float32(1<<14) / (1<<15 - 1)}
+	got := b.sounds["foo"].samples
+	if len(got) != len(want) {
+		t.Fatalf("len(samples): got: %d, want: %d", len(got), len(want))
+	}
+	for i := range got {
+		if got[i] != want[i] {
+			t.Errorf("samples[%d]: got: %v, want: %v", i, got[i], want[i])
+		}
+	}
+}
+
+func TestAddF32(t *testing.T) {
+	b := NewBank(audioContext, nil)
+	defer b.Close()
+
+	want := []float32{0, 0.5, -0.25, 1}
+	var buf bytes.Buffer
+	_ = binary.Write(&buf, binary.LittleEndian, want)
+	if err := b.AddF32("foo", &buf); err != nil {
+		t.Fatal(err)
+	}
+	got := b.sounds["foo"].samples
+	if len(got) != len(want) {
+		t.Fatalf("len(samples): got: %d, want: %d", len(got), len(want))
+	}
+	for i := range got {
+		if got[i] != want[i] {
+			t.Errorf("samples[%d]: got: %v, want: %v", i, got[i], want[i])
+		}
+	}
+}
+
+func TestPlayUnknownSound(t *testing.T) {
+	b := NewBank(audioContext, nil)
+	defer b.Close()
+
+	if err := b.Play("foo", nil); err == nil {
+		t.Errorf("Play must return an error for an unknown sound")
+	}
+}
+
+func TestPool(t *testing.T) {
+	b := NewBank(audioContext, nil)
+	defer b.Close()
+
+	if err := b.AddF32("foo", silence(10)); err != nil {
+		t.Fatal(err)
+	}
+	if err := b.Play("foo", nil); err != nil {
+		t.Fatal(err)
+	}
+	s := b.sounds["foo"]
+	if got, want := len(s.voices), 1; got != want {
+		t.Fatalf("len(voices): got: %d, want: %d", got, want)
+	}
+	v := s.voices[0]
+
+	// A player that is not busy is reused.
+	b.StopAll()
+	if err := b.Play("foo", nil); err != nil {
+		t.Fatal(err)
+	}
+	if got, want := len(s.voices), 1; got != want {
+		t.Fatalf("len(voices): got: %d, want: %d", got, want)
+	}
+	if s.voices[0] != v {
+		t.Errorf("the player must be reused")
+	}
+
+	// A new player is created while the existing players are busy.
+	if err := b.Play("foo", nil); err != nil {
+		t.Fatal(err)
+	}
+	if got, want := len(s.voices), 2; got != want {
+		t.Errorf("len(voices): got: %d, want: %d", got, want)
+	}
+}
+
+func TestMaxVoicesPerSound(t *testing.T) {
+	b := NewBank(audioContext, &BankOptions{
+		MaxVoicesPerSound: 2,
+	})
+	defer b.Close()
+
+	if err := b.AddF32("foo", silence(10)); err != nil {
+		t.Fatal(err)
+	}
+	if err := b.AddF32("bar", silence(10)); err != nil {
+		t.Fatal(err)
+	}
+	for i := 0; i < 4; i++ {
+		if err := b.Play("foo", nil); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := b.Play("bar", nil); err != nil {
+		t.Fatal(err)
+	}
+
+	// When all the players are busy, the oldest one is stopped and reused.
+	if got, want := len(b.sounds["foo"].voices), 2; got != want {
+		t.Errorf("len(voices) for foo: got: %d, want: %d", got, want)
+	}
+	// The limit is per sound.
+	if got, want := len(b.sounds["bar"].voices), 1; got != want {
+		t.Errorf("len(voices) for bar: got: %d, want: %d", got, want)
+	}
+}
+
+func TestRemove(t *testing.T) {
+	b := NewBank(audioContext, nil)
+	defer b.Close()
+
+	if err := b.AddF32("foo", silence(1)); err != nil {
+		t.Fatal(err)
+	}
+	b.Remove("foo")
+	b.Remove("bar")
+	if err := b.Play("foo", nil); err == nil {
+		t.Errorf("Play must return an error for a removed sound")
+	}
+}
+
+func readFrames(t *testing.T, v *voice) []float32 {
+	var fs []float32
+	buf := make([]byte, 64*bytesPerFrame)
+	for {
+		n, err := v.Read(buf)
+		for i := 0; i < n; i += 4 {
+			fs = append(fs, math.Float32frombits(binary.LittleEndian.Uint32(buf[i:])))
+		}
+		if err == io.EOF {
+			return fs
+		}
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+}
+
+func TestVoicePan(t *testing.T) {
+	samples := []float32{1, 1, 0.5, 0.5}
+	cases := []struct {
+		pan  float64
+		want []float32
+	}{
+		{pan: 0, want: []float32{1, 1, 0.5, 0.5}},
+		{pan: -1, want: []float32{1, 0, 0.5, 0}},
+		{pan: 1, want: []float32{0, 1, 0, 0.5}},
+		{pan: 0.5, want: []float32{0.5, 1, 0.25, 0.5}},
+	}
+	for _, c := range cases {
+		v := &voice{}
+		v.reset(samples, c.pan, 1)
+		got := readFrames(t, v)
+		if len(got) != len(c.want) {
+			t.Errorf("pan: %v: got: %v, want: %v", c.pan, got, c.want)
+			continue
+		}
+		for i := range got {
+			if got[i] != c.want[i] {
+				t.Errorf("pan: %v: got: %v, want: %v", c.pan, got, c.want)
+				break
+			}
+		}
+	}
+}
+
+func TestVoicePitch(t *testing.T) {
+	samples := []float32{0, 0, 1, 1, 0, 0, -1, -1}
+	cases := []struct {
+		pitch float64
+		want  []float32
+	}{
+		{pitch: 1, want: []float32{0, 1, 0, -1}},
+		{pitch: 2, want: []float32{0, 0}},
+		// The samples are interpolated linearly.
+		{pitch: 0.5, want: []float32{0, 0.5, 1, 0.5, 0, -0.5, -1, -1}},
+	}
+	for _, c := range cases {
+		v := &voice{}
+		v.reset(samples, 0, c.pitch)
+		frames := readFrames(t, v)
+		// Take the left channel.
+		var got []float32
+		for i := 0; i < len(frames); i += 2 {
+			got = append(got, frames[i])
+		}
+		if len(got) != len(c.want) {
+			t.Errorf("pitch: %v: got: %v, want: %v", c.pitch, got, c.want)
+			continue
+		}
+		for i := range got {
+			if got[i] != c.want[i] {
+				t.Errorf("pitch: %v: got: %v, want: %v", c.pitch, got, c.want)
+				break
+			}
+		}
+	}
+}
+
+func TestVoiceSeek(t *testing.T) {
+	v := &voice{}
+	v.reset(make([]float32, 2*100), 0, 1)
+
+	if pos, err := v.Seek(0, io.SeekEnd); err != nil || pos != 100*bytesPerFrame {
+		t.Errorf("Seek(0, io.SeekEnd): got: %d, %v, want: %d, nil", pos, err, 100*bytesPerFrame)
+	}
+	if pos, err := v.Seek(10*bytesPerFrame+3, io.SeekStart); err != nil || pos != 10*bytesPerFrame {
+		t.Errorf("Seek(%d, io.SeekStart): got: %d, %v, want: %d, nil", 10*bytesPerFrame+3, pos, err, 10*bytesPerFrame)
+	}
+	if pos, err := v.Seek(-bytesPerFrame, io.SeekCurrent); err != nil || pos != 9*bytesPerFrame {
+		t.Errorf("Seek(%d, io.SeekCurrent): got: %d, %v, want: %d, nil", -bytesPerFrame, pos, err, 9*bytesPerFrame)
+	}
+	if _, err := v.Seek(-1, io.SeekStart); err == nil {
+		t.Errorf("Seek(-1, io.SeekStart) must return an error")
+	}
+	if got, want := len(readFrames(t, v)), 2*91; got != want {
+		t.Errorf("len(frames): got: %d, want: %d", got, want)
+	}
+}
@@ -0,0 +1,91 @@
+// Copyright 2026 The Ebitengine Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package steam provides integration points for games distributed on Steam.
+//
+// This package is experimental and the API might be changed in the future.
+//
+// This package doesn't bind the Steamworks SDK.
+// Use a Steamworks binding for features like Steam Input, achievements, and rich presence,
+// and call its per-frame callback function via AppendHookOnBeforeUpdate.
+//
+// The Steam overlay hooks into the graphics library's presentation (OpenGL, DirectX 11, or DirectX 12).
+// As the overlay is drawn only when the screen is presented, keep the game rendering while the overlay is shown.
+// For example, ebiten.FPSModeVsyncOffMinimum might prevent the overlay from being updated.
+package steam
+
+import (
+	"os"
+	"sync"
+
+	"github.com/duplicants-ai/ebiten/internal/hook"
+)
+
+// IsLaunchedBySteam reports whether the game was launched by the Steam client.
+//
+// IsLaunchedBySteam is concurrent-safe.
+func IsLaunchedBySteam() bool {
+	// The Steam client sets these environment variables when launching a game.
+	for _, key := range []string{"SteamAppId", "SteamGameId", "SteamClientLaunch"} {
+		if os.Getenv(key) != "" {
+			return true
+		}
+	}
+	return false
+}
+
+var (
+	isSteamDeck     bool
+	isSteamDeckOnce sync.Once
+)
+
+// IsSteamDeck reports whether the game is running on Steam Deck.
+//
+// IsSteamDeck is useful to select controller glyphs and performance presets.
+// IsSteamDeck works both with a native Linux build and with a Windows build running on Proton.
+//
+// IsSteamDeck is concurrent-safe.
+func IsSteamDeck() bool {
+	isSteamDeckOnce.Do(func() {
+		// The Steam client on Steam Deck sets the environment variable SteamDeck.
+		if os.Getenv("SteamDeck") == "1" {
+			isSteamDeck = true
+			return
+		}
+		isSteamDeck = isSteamDeckHardware()
+	})
+	return isSteamDeck
+}
+
+func isSteamDeckProduct(vendor, product string) bool {
+	if vendor != "Valve" {
+		return false
+	}
+	switch product {
+	case "Jupiter", "Galileo":
+		// Jupiter is the LCD model and Galileo is the OLED model.
+		return true
+	}
+	return false
+}
+
+// AppendHookOnBeforeUpdate appends a hook function that is run before the game's Update function every tick.
+// This is useful to run the Steamworks SDK's callbacks, e.g., SteamAPI_RunCallbacks, on the same thread as the game.
+//
+// If f returns an error, the game is terminated with the error.
+//
+// AppendHookOnBeforeUpdate is concurrent-safe.
+func AppendHookOnBeforeUpdate(f func() error) {
+	hook.AppendHookOnBeforeUpdate(f)
+}
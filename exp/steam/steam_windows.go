@@ -0,0 +1,57 @@
+// Copyright 2026 The Ebitengine Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package steam
+
+import (
+	"unsafe"
+
+	"golang.org/x/sys/windows"
+)
+
+func readRegistryString(path, name string) string {
+	p, err := windows.UTF16PtrFromString(path)
+	if err != nil {
+		return ""
+	}
+	n, err := windows.UTF16PtrFromString(name)
+	if err != nil {
+		return ""
+	}
+
+	var key windows.Handle
+	if err := windows.RegOpenKeyEx(windows.HKEY_LOCAL_MACHINE, p, 0, windows.KEY_READ, &key); err != nil {
+		return ""
+	}
+	defer func() {
+		_ = windows.RegCloseKey(key)
+	}()
+
+	var buf [256]uint16
+	var typ uint32
+	size := uint32(len(buf) * 2)
+	if err := windows.RegQueryValueEx(key, n, nil, &typ, (*byte)(unsafe.Pointer(&buf[0])), &size); err != nil {
+		return ""
+	}
+	if typ != windows.REG_SZ {
+		return ""
+	}
+	return windows.UTF16ToString(buf[:size/2])
+}
+
+func isSteamDeckHardware() bool {
+	// A Windows build might run on Steam Deck with Windows installed, or on Proton.
+	const path = `HARDWARE\DESCRIPTION\System\BIOS`
+	return isSteamDeckProduct(readRegistryString(path, "BaseBoardManufacturer"), readRegistryString(path, "SystemProductName"))
+}
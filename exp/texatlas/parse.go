@@ -0,0 +1,237 @@
+// Copyright 2026 The Ebitengine Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package texatlas
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"image"
+	"sort"
+	"time"
+)
+
+type jsonRect struct {
+	X int `json:"x"`
+	Y int `json:"y"`
+	W int `json:"w"`
+	H int `json:"h"`
+}
+
+type jsonSize struct {
+	W int `json:"w"`
+	H int `json:"h"`
+}
+
+type jsonPoint struct {
+	X float64 `json:"x"`
+	Y float64 `json:"y"`
+}
+
+type jsonFrame struct {
+	Filename         string     `json:"filename"`
+	Frame            jsonRect   `json:"frame"`
+	Rotated          bool       `json:"rotated"`
+	Trimmed          bool       `json:"trimmed"`
+	SpriteSourceSize *jsonRect  `json:"spriteSourceSize"`
+	SourceSize       *jsonSize  `json:"sourceSize"`
+	Pivot            *jsonPoint `json:"pivot"`
+	Duration         int        `json:"duration"`
+}
+
+type jsonFrameTag struct {
+	Name      string `json:"name"`
+	From      int    `json:"from"`
+	To        int    `json:"to"`
+	Direction string `json:"direction"`
+}
+
+type jsonAtlas struct {
+	Frames     json.RawMessage     `json:"frames"`
+	Animations map[string][]string `json:"animations"`
+	Meta       struct {
+		Image     string         `json:"image"`
+		Size      jsonSize       `json:"size"`
+		FrameTags []jsonFrameTag `json:"frameTags"`
+	} `json:"meta"`
+}
+
+// spriteData is a parsed sprite without images.
+type spriteData struct {
+	name         string
+	region       image.Rectangle
+	rotated      bool
+	trimmed      bool
+	offset       image.Point
+	sourceWidth  int
+	sourceHeight int
+	pivotX       float64
+	pivotY       float64
+	hasPivot     bool
+	duration     time.Duration
+}
+
+type animationData struct {
+	name      string
+	sprites   []int
+	direction Direction
+}
+
+type atlasData struct {
+	imagePath  string
+	sprites    []spriteData
+	animations []animationData
+}
+
+func parse(data []byte) (*atlasData, error) {
+	var j jsonAtlas
+	if err := json.Unmarshal(data, &j); err != nil {
+		return nil, fmt.Errorf("texatlas: %w", err)
+	}
+
+	frames, err := parseFrames(j.Frames)
+	if err != nil {
+		return nil, fmt.Errorf("texatlas: %w", err)
+	}
+
+	a := &atlasData{
+		imagePath: j.Meta.Image,
+	}
+	nameToIndex := map[string]int{}
+	for _, f := range frames {
+		s := spriteData{
+			name:     f.Filename,
+			rotated:  f.Rotated,
+			trimmed:  f.Trimmed,
+			duration: time.Duration(f.Duration) * time.Millisecond,
+		}
+
+		if f.Frame.W <= 0 || f.Frame.H <= 0 {
+			return nil, fmt.Errorf("texatlas: invalid frame size of %q: (%d, %d)", f.Filename, f.Frame.W, f.Frame.H)
+		}
+
+		// frame is the size of the sprite before the rotation.
+		w, h := f.Frame.W, f.Frame.H
+		if f.Rotated {
+			s.region = image.Rect(f.Frame.X, f.Frame.Y, f.Frame.X+h, f.Frame.Y+w)
+		} else {
+			s.region = image.Rect(f.Frame.X, f.Frame.Y, f.Frame.X+w, f.Frame.Y+h)
+		}
+
+		s.sourceWidth, s.sourceHeight = w, h
+		if f.SourceSize != nil {
+			s.sourceWidth, s.sourceHeight = f.SourceSize.W, f.SourceSize.H
+		}
+		if f.SpriteSourceSize != nil {
+			s.offset = image.Pt(f.SpriteSourceSize.X, f.SpriteSourceSize.Y)
+		}
+		if f.Pivot != nil {
+			s.pivotX, s.pivotY = f.Pivot.X, f.Pivot.Y
+			s.hasPivot = true
+		}
+
+		nameToIndex[s.name] = len(a.sprites)
+		a.sprites = append(a.sprites, s)
+	}
+
+	// Aseprite exports frame tags with frame indices.
+	for _, t := range j.Meta.FrameTags {
+		if t.From < 0 || t.From > t.To || t.To >= len(a.sprites) {
+			return nil, fmt.Errorf("texatlas: invalid frame range of tag %q: %d-%d", t.Name, t.From, t.To)
+		}
+		anim := animationData{
+			name: t.Name,
+		}
+		switch t.Direction {
+		case "", "forward":
+			anim.direction = DirectionForward
+		case "reverse":
+			anim.direction = DirectionReverse
+		case "pingpong":
+			anim.direction = DirectionPingPong
+		case "pingpong_reverse":
+			anim.direction = DirectionPingPongReverse
+		default:
+			return nil, fmt.Errorf("texatlas: unknown direction of tag %q: %q", t.Name, t.Direction)
+		}
+		for i := t.From; i <= t.To; i++ {
+			anim.sprites = append(anim.sprites, i)
+		}
+		a.animations = append(a.animations, anim)
+	}
+
+	// TexturePacker exports animations with frame names.
+	names := make([]string, 0, len(j.Animations))
+	for name := range j.Animations {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		frameNames := j.Animations[name]
+		anim := animationData{
+			name: name,
+		}
+		for _, n := range frameNames {
+			idx, ok := nameToIndex[n]
+			if !ok {
+				return nil, fmt.Errorf("texatlas: unknown frame %q in animation %q", n, name)
+			}
+			anim.sprites = append(anim.sprites, idx)
+		}
+		a.animations = append(a.animations, anim)
+	}
+
+	return a, nil
+}
+
+// parseFrames parses frames in the hash format or the array format.
+// In the hash format, the order of the frames is kept.
+func parseFrames(data json.RawMessage) ([]jsonFrame, error) {
+	data = bytes.TrimSpace(data)
+	if len(data) == 0 {
+		return nil, fmt.Errorf("frames not found")
+	}
+
+	if data[0] == '[' {
+		var frames []jsonFrame
+		if err := json.Unmarshal(data, &frames); err != nil {
+			return nil, err
+		}
+		return frames, nil
+	}
+
+	dec := json.NewDecoder(bytes.NewReader(data))
+	if _, err := dec.Token(); err != nil {
+		return nil, err
+	}
+	var frames []jsonFrame
+	for dec.More() {
+		t, err := dec.Token()
+		if err != nil {
+			return nil, err
+		}
+		name, ok := t.(string)
+		if !ok {
+			return nil, fmt.Errorf("unexpected token: %v", t)
+		}
+		var f jsonFrame
+		if err := dec.Decode(&f); err != nil {
+			return nil, err
+		}
+		f.Filename = name
+		frames = append(frames, f)
+	}
+	return frames, nil
+}
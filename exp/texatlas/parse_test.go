@@ -0,0 +1,119 @@
+// Copyright 2026 The Ebitengine Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package texatlas
+
+import (
+	"image"
+	"testing"
+	"time"
+)
+
+func TestParseHash(t *testing.T) {
+	const data = `{
+	"frames": {
+		"walk_1.png": {
+			"frame": {"x": 0, "y": 0, "w": 10, "h": 20},
+			"rotated": false,
+			"trimmed": true,
+			"spriteSourceSize": {"x": 3, "y": 4, "w": 10, "h": 20},
+			"sourceSize": {"w": 16, "h": 32},
+			"pivot": {"x": 0.5, "y": 1},
+			"duration": 100
+		},
+		"walk_0.png": {
+			"frame": {"x": 10, "y": 0, "w": 10, "h": 20},
+			"rotated": true,
+			"trimmed": false,
+			"spriteSourceSize": {"x": 0, "y": 0, "w": 10, "h": 20},
+			"sourceSize": {"w": 10, "h": 20},
+			"duration": 200
+		}
+	},
+	"meta": {
+		"image": "sheet.png",
+		"frameTags": [
+			{"name": "walk", "from": 0, "to": 1, "direction": "pingpong"}
+		]
+	}
+}`
+	a, err := parse([]byte(data))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if a.imagePath != "sheet.png" {
+		t.Errorf("imagePath: got: %q, want: %q", a.imagePath, "sheet.png")
+	}
+
+	// The order in the hash format is kept.
+	if len(a.sprites) != 2 || a.sprites[0].name != "walk_1.png" || a.sprites[1].name != "walk_0.png" {
+		t.Fatalf("sprites: got: %+v", a.sprites)
+	}
+
+	s := a.sprites[0]
+	if s.region != image.Rect(0, 0, 10, 20) || !s.trimmed || s.offset != image.Pt(3, 4) || s.sourceWidth != 16 || s.sourceHeight != 32 {
+		t.Errorf("sprites[0]: got: %+v", s)
+	}
+	if !s.hasPivot || s.pivotX != 0.5 || s.pivotY != 1 || s.duration != 100*time.Millisecond {
+		t.Errorf("sprites[0]: got: %+v", s)
+	}
+
+	// The region of a rotated sprite is swapped.
+	if s := a.sprites[1]; !s.rotated || s.region != image.Rect(10, 0, 30, 10) {
+		t.Errorf("sprites[1]: got: %+v", s)
+	}
+
+	if len(a.animations) != 1 {
+		t.Fatalf("len(animations): got: %d, want: 1", len(a.animations))
+	}
+	if anim := a.animations[0]; anim.name != "walk" || anim.direction != DirectionPingPong || len(anim.sprites) != 2 {
+		t.Errorf("animations[0]: got: %+v", anim)
+	}
+}
+
+func TestParseArray(t *testing.T) {
+	const data = `{
+	"frames": [
+		{"filename": "a", "frame": {"x": 0, "y": 0, "w": 4, "h": 4}},
+		{"filename": "b", "frame": {"x": 4, "y": 0, "w": 4, "h": 4}}
+	],
+	"animations": {
+		"ba": ["b", "a"]
+	},
+	"meta": {}
+}`
+	a, err := parse([]byte(data))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(a.sprites) != 2 || a.sprites[1].name != "b" || a.sprites[1].sourceWidth != 4 {
+		t.Fatalf("sprites: got: %+v", a.sprites)
+	}
+	if len(a.animations) != 1 || a.animations[0].sprites[0] != 1 || a.animations[0].sprites[1] != 0 {
+		t.Errorf("animations: got: %+v", a.animations)
+	}
+}
+
+func TestParseError(t *testing.T) {
+	for _, data := range []string{
+		`{}`,
+		`{"frames": {"a": {"frame": {"x": 0, "y": 0, "w": 0, "h": 4}}}}`,
+		`{"frames": [], "animations": {"x": ["unknown"]}}`,
+		`{"frames": [], "meta": {"frameTags": [{"name": "x", "from": 0, "to": 1}]}}`,
+	} {
+		if _, err := parse([]byte(data)); err == nil {
+			t.Errorf("parse(%q) must return an error", data)
+		}
+	}
+}
@@ -0,0 +1,244 @@
+// Copyright 2026 The Ebitengine Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package texatlas provides an importer for texture atlases in the JSON formats of TexturePacker and compatible tools.
+//
+// This package is experimental and the API might be changed in the future.
+//
+// Both the hash format and the array format are supported.
+// Trimmed and rotated sprites are supported, and sprites are drawn at their positions in the original untrimmed images.
+// The frame durations and the frame tags exported by Aseprite, and the animations exported by TexturePacker are available as Animations.
+package texatlas
+
+import (
+	"image"
+	"math"
+	"time"
+
+	"github.com/duplicants-ai/ebiten"
+)
+
+// Direction represents a playing direction of an animation.
+type Direction int
+
+const (
+	DirectionForward Direction = iota
+	DirectionReverse
+	DirectionPingPong
+	DirectionPingPongReverse
+)
+
+// Sprite represents a sprite in a texture atlas.
+type Sprite struct {
+	// Name is the name of the sprite, which is usually the original file name.
+	Name string
+
+	// Image is a sub-image of the atlas image.
+	// If Rotated is true, Image is rotated by 90 degrees clockwise. Use GeoM to draw Image.
+	Image *ebiten.Image
+
+	// Rotated reports whether the sprite is rotated in the atlas image.
+	Rotated bool
+
+	// Trimmed reports whether the transparent edges of the sprite are trimmed.
+	Trimmed bool
+
+	// Offset is the position of the trimmed sprite in the original untrimmed image.
+	Offset image.Point
+
+	// SourceWidth and SourceHeight are the size of the original untrimmed image.
+	SourceWidth  int
+	SourceHeight int
+
+	// PivotX and PivotY are the pivot position relative to the original image size, e.g. (0.5, 0.5) for the center.
+	// PivotX and PivotY are valid only when HasPivot is true.
+	PivotX   float64
+	PivotY   float64
+	HasPivot bool
+
+	// Duration is the frame duration. Duration is 0 if the atlas doesn't have durations.
+	Duration time.Duration
+
+	geoM ebiten.GeoM
+}
+
+// GeoM returns a geometry matrix to draw Image at its position in the original untrimmed image.
+// The rotation of the sprite is also resolved.
+//
+// Concat the matrix to yours to draw the sprite:
+//
+//	op := &ebiten.DrawImageOptions{}
+//	op.GeoM = sprite.GeoM()
+//	op.GeoM.Translate(x, y)
+//	screen.DrawImage(sprite.Image, op)
+func (s *Sprite) GeoM() ebiten.GeoM {
+	return s.geoM
+}
+
+// Pivot returns the pivot position in pixels in the original untrimmed image.
+// If the sprite doesn't have a pivot, Pivot returns (0, 0).
+func (s *Sprite) Pivot() (x, y float64) {
+	if !s.HasPivot {
+		return 0, 0
+	}
+	return s.PivotX * float64(s.SourceWidth), s.PivotY * float64(s.SourceHeight)
+}
+
+// Animation represents a sequence of sprites.
+type Animation struct {
+	Name      string
+	Sprites   []*Sprite
+	Direction Direction
+}
+
+// SpriteAt returns the sprite to show after the elapsed time from the start of the animation.
+// The animation loops.
+//
+// The sprites' Duration is used as the frame durations.
+// If a sprite's Duration is 0, defaultDuration is used.
+func (a *Animation) SpriteAt(elapsed time.Duration, defaultDuration time.Duration) *Sprite {
+	n := len(a.Sprites)
+	if n == 0 {
+		return nil
+	}
+
+	seq := make([]int, 0, 2*n)
+	switch a.Direction {
+	case DirectionReverse:
+		for i := n - 1; i >= 0; i-- {
+			seq = append(seq, i)
+		}
+	case DirectionPingPong:
+		for i := 0; i < n; i++ {
+			seq = append(seq, i)
+		}
+		for i := n - 2; i > 0; i-- {
+			seq = append(seq, i)
+		}
+	case DirectionPingPongReverse:
+		for i := n - 1; i >= 0; i-- {
+			seq = append(seq, i)
+		}
+		for i := 1; i < n-1; i++ {
+			seq = append(seq, i)
+		}
+	default:
+		for i := 0; i < n; i++ {
+			seq = append(seq, i)
+		}
+	}
+
+	duration := func(i int) time.Duration {
+		if d := a.Sprites[i].Duration; d > 0 {
+			return d
+		}
+		return defaultDuration
+	}
+	var cycle time.Duration
+	for _, i := range seq {
+		cycle += duration(i)
+	}
+	if cycle <= 0 {
+		return a.Sprites[seq[0]]
+	}
+
+	t := max(elapsed, 0) % cycle
+	for _, i := range seq {
+		if t < duration(i) {
+			return a.Sprites[i]
+		}
+		t -= duration(i)
+	}
+	return a.Sprites[seq[len(seq)-1]]
+}
+
+// Atlas represents a texture atlas.
+type Atlas struct {
+	// ImagePath is the path of the atlas image written in the JSON, relative to the JSON file.
+	ImagePath string
+
+	Sprites    []*Sprite
+	Animations []*Animation
+
+	nameToSprite    map[string]*Sprite
+	nameToAnimation map[string]*Animation
+}
+
+// Parse parses the JSON data of a texture atlas, and creates the sprites as sub-images of img.
+//
+// img is the atlas image, which is usually loaded from ImagePath.
+// To know ImagePath before loading the image, call Parse with a nil img first.
+// If img is nil, the sprites' Image is nil.
+func Parse(jsonData []byte, img *ebiten.Image) (*Atlas, error) {
+	d, err := parse(jsonData)
+	if err != nil {
+		return nil, err
+	}
+
+	a := &Atlas{
+		ImagePath:       d.imagePath,
+		nameToSprite:    map[string]*Sprite{},
+		nameToAnimation: map[string]*Animation{},
+	}
+	for _, sd := range d.sprites {
+		s := &Sprite{
+			Name:         sd.name,
+			Rotated:      sd.rotated,
+			Trimmed:      sd.trimmed,
+			Offset:       sd.offset,
+			SourceWidth:  sd.sourceWidth,
+			SourceHeight: sd.sourceHeight,
+			PivotX:       sd.pivotX,
+			PivotY:       sd.pivotY,
+			HasPivot:     sd.hasPivot,
+			Duration:     sd.duration,
+		}
+		if img != nil {
+			s.Image = img.SubImage(sd.region.Add(img.Bounds().Min)).(*ebiten.Image)
+		}
+		if sd.rotated {
+			// The sprite is rotated by 90 degrees clockwise in the atlas. Rotate it back.
+			s.geoM.Rotate(-math.Pi / 2)
+			s.geoM.Translate(0, float64(sd.region.Dx()))
+		}
+		s.geoM.Translate(float64(sd.offset.X), float64(sd.offset.Y))
+
+		a.Sprites = append(a.Sprites, s)
+		a.nameToSprite[s.Name] = s
+	}
+	for _, ad := range d.animations {
+		anim := &Animation{
+			Name:      ad.name,
+			Direction: ad.direction,
+		}
+		for _, i := range ad.sprites {
+			anim.Sprites = append(anim.Sprites, a.Sprites[i])
+		}
+		a.Animations = append(a.Animations, anim)
+		a.nameToAnimation[anim.Name] = anim
+	}
+	return a, nil
+}
+
+// Sprite returns the sprite with the name.
+func (a *Atlas) Sprite(name string) (*Sprite, bool) {
+	s, ok := a.nameToSprite[name]
+	return s, ok
+}
+
+// Animation returns the animation with the name.
+func (a *Atlas) Animation(name string) (*Animation, bool) {
+	anim, ok := a.nameToAnimation[name]
+	return anim, ok
+}
@@ -0,0 +1,143 @@
+// Copyright 2026 The Ebitengine Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tilemap
+
+import (
+	"math"
+	"slices"
+	"testing"
+)
+
+func testGrids() map[string]Grid {
+	return map[string]Grid{
+		"ortho":           &OrthoGrid{TileWidth: 32, TileHeight: 32},
+		"iso":             &IsoGrid{TileWidth: 64, TileHeight: 32},
+		"staggered odd":   &StaggeredGrid{TileWidth: 64, TileHeight: 32},
+		"staggered even":  &StaggeredGrid{TileWidth: 64, TileHeight: 32, ShiftEven: true},
+		"hex pointy odd":  &HexGrid{Size: 10},
+		"hex pointy even": &HexGrid{Size: 10, ShiftEven: true},
+		"hex flat odd":    &HexGrid{Size: 10, Orientation: HexFlatTop},
+		"hex flat even":   &HexGrid{Size: 10, Orientation: HexFlatTop, ShiftEven: true},
+	}
+}
+
+func TestGridRoundTrip(t *testing.T) {
+	for name, g := range testGrids() {
+		if x, y := g.CellToWorld(Cell{}); x != 0 || y != 0 {
+			t.Errorf("%s: CellToWorld(Cell{}): got: (%v, %v), want: (0, 0)", name, x, y)
+		}
+		for j := -5; j <= 5; j++ {
+			for i := -5; i <= 5; i++ {
+				c := Cell{X: i, Y: j}
+				x, y := g.CellToWorld(c)
+				for _, d := range [][2]float64{{0, 0}, {2, 0}, {-2, 0}, {0, 2}, {0, -2}} {
+					if got := g.WorldToCell(x+d[0], y+d[1]); got != c {
+						t.Errorf("%s: WorldToCell(%v, %v): got: %v, want: %v", name, x+d[0], y+d[1], got, c)
+					}
+				}
+			}
+		}
+	}
+}
+
+func TestGridNeighbors(t *testing.T) {
+	for name, g := range testGrids() {
+		var dist float64
+		for j := -4; j <= 4; j++ {
+			for i := -4; i <= 4; i++ {
+				c := Cell{X: i, Y: j}
+				x, y := g.CellToWorld(c)
+				ns := g.AppendNeighbors(nil, c)
+				for k, n := range ns {
+					if n == c || slices.Contains(ns[:k], n) {
+						t.Errorf("%s: AppendNeighbors(%v): invalid neighbors: %v", name, c, ns)
+					}
+					if !slices.Contains(g.AppendNeighbors(nil, n), c) {
+						t.Errorf("%s: %v is a neighbor of %v but not vice versa", name, n, c)
+					}
+					nx, ny := g.CellToWorld(n)
+					d := math.Hypot(nx-x, ny-y)
+					if dist == 0 {
+						dist = d
+					}
+					if math.Abs(d-dist) > 1e-9 {
+						t.Errorf("%s: the distance between %v and %v: got: %v, want: %v", name, c, n, d, dist)
+					}
+				}
+			}
+		}
+	}
+}
+
+func TestStaggeredGridCellToWorld(t *testing.T) {
+	odd := &StaggeredGrid{TileWidth: 64, TileHeight: 32}
+	even := &StaggeredGrid{TileWidth: 64, TileHeight: 32, ShiftEven: true}
+	testCases := []struct {
+		grid  *StaggeredGrid
+		cell  Cell
+		wantX float64
+		wantY float64
+	}{
+		{grid: odd, cell: Cell{X: 1, Y: 0}, wantX: 64, wantY: 0},
+		{grid: odd, cell: Cell{X: 0, Y: 1}, wantX: 32, wantY: 16},
+		{grid: odd, cell: Cell{X: -1, Y: -1}, wantX: -32, wantY: -16},
+		{grid: even, cell: Cell{X: 0, Y: 1}, wantX: -32, wantY: 16},
+		{grid: even, cell: Cell{X: 1, Y: -1}, wantX: 32, wantY: -16},
+	}
+	for _, tc := range testCases {
+		if x, y := tc.grid.CellToWorld(tc.cell); x != tc.wantX || y != tc.wantY {
+			t.Errorf("CellToWorld(%v) (ShiftEven: %v): got: (%v, %v), want: (%v, %v)", tc.cell, tc.grid.ShiftEven, x, y, tc.wantX, tc.wantY)
+		}
+	}
+}
+
+func TestHexGridAxial(t *testing.T) {
+	testCases := []struct {
+		grid *HexGrid
+		cell Cell
+		q, r int
+	}{
+		{grid: &HexGrid{}, cell: Cell{X: 2, Y: 3}, q: 1, r: 3},
+		{grid: &HexGrid{}, cell: Cell{X: 0, Y: -1}, q: 1, r: -1},
+		{grid: &HexGrid{ShiftEven: true}, cell: Cell{X: 2, Y: 3}, q: 0, r: 3},
+		{grid: &HexGrid{Orientation: HexFlatTop}, cell: Cell{X: 3, Y: 2}, q: 3, r: 1},
+		{grid: &HexGrid{Orientation: HexFlatTop, ShiftEven: true}, cell: Cell{X: 3, Y: 2}, q: 3, r: 0},
+	}
+	for _, tc := range testCases {
+		q, r := tc.grid.CellToAxial(tc.cell)
+		if q != tc.q || r != tc.r {
+			t.Errorf("CellToAxial(%v): got: (%d, %d), want: (%d, %d)", tc.cell, q, r, tc.q, tc.r)
+		}
+		if got := tc.grid.AxialToCell(q, r); got != tc.cell {
+			t.Errorf("AxialToCell(%d, %d): got: %v, want: %v", q, r, got, tc.cell)
+		}
+	}
+}
+
+func TestHexGridDistance(t *testing.T) {
+	g := &HexGrid{Size: 10}
+	if got, want := g.Distance(Cell{X: 0, Y: 0}, Cell{X: 0, Y: 0}), 0; got != want {
+		t.Errorf("got: %d, want: %d", got, want)
+	}
+	if got, want := g.Distance(Cell{X: 0, Y: 0}, Cell{X: 3, Y: 0}), 3; got != want {
+		t.Errorf("got: %d, want: %d", got, want)
+	}
+	if got, want := g.Distance(Cell{X: 0, Y: 0}, Cell{X: 1, Y: 4}), 4; got != want {
+		t.Errorf("got: %d, want: %d", got, want)
+	}
+	if got, want := g.Distance(Cell{X: -2, Y: -3}, Cell{X: 2, Y: 3}), 7; got != want {
+		t.Errorf("got: %d, want: %d", got, want)
+	}
+}
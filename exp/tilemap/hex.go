@@ -0,0 +1,154 @@
+// Copyright 2026 The Ebitengine Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tilemap
+
+import (
+	"math"
+)
+
+// HexOrientation is the orientation of hexagons.
+type HexOrientation int
+
+const (
+	// HexPointyTop is the orientation where a corner of a hexagon is at the top.
+	// Cells in a row are aligned horizontally, and every other row is shifted by half a cell.
+	HexPointyTop HexOrientation = iota
+
+	// HexFlatTop is the orientation where an edge of a hexagon is at the top.
+	// Cells in a column are aligned vertically, and every other column is shifted by half a cell.
+	HexFlatTop
+)
+
+// HexGrid is a grid of regular hexagons.
+//
+// A Cell of HexGrid is in offset coordinates, where X is the column and Y is the row.
+// Use CellToAxial and AxialToCell to convert them to axial coordinates, which are convenient for hex math.
+type HexGrid struct {
+	// Size is the distance from the center to a corner of a hexagon.
+	Size float64
+
+	// Orientation is the orientation of hexagons.
+	Orientation HexOrientation
+
+	// ShiftEven reports whether the even rows (or columns for HexFlatTop) are shifted
+	// instead of the odd rows (or columns).
+	// Shifted rows are shifted to the right, and shifted columns are shifted down.
+	ShiftEven bool
+}
+
+// hexDirections is the axial offsets of the neighbors.
+var hexDirections = [6][2]int{
+	{1, 0}, {1, -1}, {0, -1}, {-1, 0}, {-1, 1}, {0, 1},
+}
+
+// CellToAxial converts a cell in offset coordinates to axial coordinates (q, r).
+func (g *HexGrid) CellToAxial(cell Cell) (q, r int) {
+	switch g.Orientation {
+	case HexPointyTop:
+		return cell.X - g.offset(cell.Y), cell.Y
+	case HexFlatTop:
+		return cell.X, cell.Y - g.offset(cell.X)
+	default:
+		panic("tilemap: invalid HexOrientation")
+	}
+}
+
+// AxialToCell converts axial coordinates (q, r) to a cell in offset coordinates.
+func (g *HexGrid) AxialToCell(q, r int) Cell {
+	switch g.Orientation {
+	case HexPointyTop:
+		return Cell{X: q + g.offset(r), Y: r}
+	case HexFlatTop:
+		return Cell{X: q, Y: r + g.offset(q)}
+	default:
+		panic("tilemap: invalid HexOrientation")
+	}
+}
+
+// offset returns the difference between the offset and axial coordinates for the row (or column).
+func (g *HexGrid) offset(i int) int {
+	if g.ShiftEven {
+		return (i + (i & 1)) / 2
+	}
+	return (i - (i & 1)) / 2
+}
+
+// CellToWorld implements Grid.
+func (g *HexGrid) CellToWorld(cell Cell) (x, y float64) {
+	q, r := g.CellToAxial(cell)
+	fq, fr := float64(q), float64(r)
+	switch g.Orientation {
+	case HexPointyTop:
+		return g.Size * math.Sqrt(3) * (fq + fr/2), g.Size * 3 / 2 * fr
+	case HexFlatTop:
+		return g.Size * 3 / 2 * fq, g.Size * math.Sqrt(3) * (fr + fq/2)
+	default:
+		panic("tilemap: invalid HexOrientation")
+	}
+}
+
+// WorldToCell implements Grid.
+func (g *HexGrid) WorldToCell(x, y float64) Cell {
+	var q, r float64
+	switch g.Orientation {
+	case HexPointyTop:
+		q = (math.Sqrt(3)/3*x - y/3) / g.Size
+		r = (2.0 / 3 * y) / g.Size
+	case HexFlatTop:
+		q = (2.0 / 3 * x) / g.Size
+		r = (-x/3 + math.Sqrt(3)/3*y) / g.Size
+	default:
+		panic("tilemap: invalid HexOrientation")
+	}
+	return g.AxialToCell(hexRound(q, r))
+}
+
+// AppendNeighbors implements Grid.
+func (g *HexGrid) AppendNeighbors(neighbors []Cell, cell Cell) []Cell {
+	q, r := g.CellToAxial(cell)
+	for _, d := range hexDirections {
+		neighbors = append(neighbors, g.AxialToCell(q+d[0], r+d[1]))
+	}
+	return neighbors
+}
+
+// Distance returns the number of steps between two cells.
+func (g *HexGrid) Distance(a, b Cell) int {
+	aq, ar := g.CellToAxial(a)
+	bq, br := g.CellToAxial(b)
+	dq, dr := aq-bq, ar-br
+	return (abs(dq) + abs(dr) + abs(dq+dr)) / 2
+}
+
+// hexRound rounds fractional axial coordinates to the nearest hexagon.
+func hexRound(q, r float64) (int, int) {
+	s := -q - r
+	rq, rr, rs := math.Round(q), math.Round(r), math.Round(s)
+	dq, dr, ds := math.Abs(rq-q), math.Abs(rr-r), math.Abs(rs-s)
+	switch {
+	case dq > dr && dq > ds:
+		rq = -rr - rs
+	case dr > ds:
+		rr = -rq - rs
+	}
+	return int(rq), int(rr)
+}
+
+func abs(x int) int {
+	if x < 0 {
+		return -x
+	}
+	return x
+}
@@ -0,0 +1,118 @@
+// Copyright 2026 The Ebitengine Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tilemap
+
+import (
+	"math"
+)
+
+// IsoGrid is an isometric grid of diamond-shaped cells.
+//
+// The X axis of cells goes to the lower right, and the Y axis of cells goes to the lower left.
+type IsoGrid struct {
+	// TileWidth is the width of a diamond.
+	TileWidth float64
+
+	// TileHeight is the height of a diamond.
+	TileHeight float64
+}
+
+// CellToWorld implements Grid.
+func (g *IsoGrid) CellToWorld(cell Cell) (x, y float64) {
+	return float64(cell.X-cell.Y) * g.TileWidth / 2, float64(cell.X+cell.Y) * g.TileHeight / 2
+}
+
+// WorldToCell implements Grid.
+func (g *IsoGrid) WorldToCell(x, y float64) Cell {
+	cx, cy := isoWorldToCell(x, y, g.TileWidth, g.TileHeight)
+	return Cell{X: cx, Y: cy}
+}
+
+// AppendNeighbors implements Grid.
+func (g *IsoGrid) AppendNeighbors(neighbors []Cell, cell Cell) []Cell {
+	return append(neighbors,
+		Cell{X: cell.X + 1, Y: cell.Y},
+		Cell{X: cell.X, Y: cell.Y + 1},
+		Cell{X: cell.X - 1, Y: cell.Y},
+		Cell{X: cell.X, Y: cell.Y - 1})
+}
+
+// isoWorldToCell returns the isometric cell that contains the world position.
+func isoWorldToCell(x, y float64, tileWidth, tileHeight float64) (int, int) {
+	// In the unit of half tiles, a diamond is a square rotated by 45 degrees.
+	u := x / (tileWidth / 2)
+	v := y / (tileHeight / 2)
+	return int(math.Floor((v+u)/2 + 0.5)), int(math.Floor((v-u)/2 + 0.5))
+}
+
+// StaggeredGrid is an isometric grid of diamond-shaped cells, where every other row is shifted by half a tile.
+//
+// This is the same layout as the "staggered" orientation of the Tiled map editor with the Y stagger axis.
+// A row is half a tile high, and the X axis of cells goes to the right.
+type StaggeredGrid struct {
+	// TileWidth is the width of a diamond.
+	TileWidth float64
+
+	// TileHeight is the height of a diamond.
+	TileHeight float64
+
+	// ShiftEven reports whether the even rows are shifted to the right relative to the odd rows
+	// instead of the odd rows.
+	ShiftEven bool
+}
+
+// toIso converts a staggered cell to an isometric cell that has the same center.
+func (g *StaggeredGrid) toIso(cell Cell) (int, int) {
+	// k is the X position in the unit of half tiles, whose parity is the same as the row.
+	k := 2 * cell.X
+	if cell.Y&1 == 1 {
+		if g.ShiftEven {
+			k--
+		} else {
+			k++
+		}
+	}
+	return (cell.Y + k) / 2, (cell.Y - k) / 2
+}
+
+// fromIso is the inverse of toIso.
+func (g *StaggeredGrid) fromIso(ix, iy int) Cell {
+	k := ix - iy
+	if g.ShiftEven {
+		k++
+	}
+	return Cell{X: floorDiv(k, 2), Y: ix + iy}
+}
+
+// CellToWorld implements Grid.
+func (g *StaggeredGrid) CellToWorld(cell Cell) (x, y float64) {
+	ix, iy := g.toIso(cell)
+	return float64(ix-iy) * g.TileWidth / 2, float64(cell.Y) * g.TileHeight / 2
+}
+
+// WorldToCell implements Grid.
+func (g *StaggeredGrid) WorldToCell(x, y float64) Cell {
+	return g.fromIso(isoWorldToCell(x, y, g.TileWidth, g.TileHeight))
+}
+
+// AppendNeighbors implements Grid.
+func (g *StaggeredGrid) AppendNeighbors(neighbors []Cell, cell Cell) []Cell {
+	ix, iy := g.toIso(cell)
+	return append(neighbors,
+		g.fromIso(ix+1, iy),
+		g.fromIso(ix, iy+1),
+		g.fromIso(ix-1, iy),
+		g.fromIso(ix, iy-1))
+}
@@ -0,0 +1,333 @@
+// Copyright 2026 The Ebitengine Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tilemap
+
+import (
+	"fmt"
+	"image"
+	"sync"
+
+	"github.com/duplicants-ai/ebiten"
+)
+
+// MaxTileIndex is the maximum index of a tile in a tileset for a Layer.
+const MaxTileIndex = 0xfffe
+
+// MaxTileData is the maximum value of Tile.Data.
+const MaxTileData = 0x3f
+
+// LayerShaderSource is the Kage source of the default shader for Layer.
+//
+// A custom shader for Layer can be made based on this source, e.g. to animate water tiles or to tint tiles by Tile.Data.
+// The shader is called with these images and uniforms:
+//
+//   - imageSrc0 is the index image, where each pixel represents a tile of the layer.
+//     The tile index plus one is encoded in the red channel (the lower 8 bits) and the green channel (the upper 8 bits).
+//     0 means no tile.
+//     The blue channel has the flags: FlipX at the bit 0, FlipY at the bit 1, and Data at the bits 2-7.
+//     The alpha channel is always 1.
+//   - imageSrc1 is the tileset image.
+//   - srcPos is the position on the index image. The integer part is the cell, and the fractional part is the position in the cell.
+//   - The uniform variable TileSize is the tile size in pixels.
+//
+//ebitengine:shadersource
+const LayerShaderSource = `//kage:unit pixels
+
+package main
+
+var TileSize vec2
+
+func Fragment(dstPos vec4, srcPos vec2, color vec4) vec4 {
+	p := srcPos - imageSrc0Origin()
+	cell := floor(p)
+	v := imageSrc0UnsafeAt(imageSrc0Origin() + cell + 0.5)
+	t := floor(v.r*255+0.5) + floor(v.g*255+0.5)*256
+	if t == 0 {
+		return vec4(0)
+	}
+	flags := floor(v.b*255 + 0.5)
+
+	// Sample the center of a texel so that the neighbor tiles never bleed.
+	local := floor(fract(p)*TileSize) + 0.5
+	if mod(flags, 2) >= 1 {
+		local.x = TileSize.x - local.x
+	}
+	if mod(floor(flags/2), 2) >= 1 {
+		local.y = TileSize.y - local.y
+	}
+
+	columns := floor(imageSrc1Size().x / TileSize.x)
+	row := floor((t - 1) / columns)
+	column := t - 1 - row*columns
+	return imageSrc1UnsafeAt(imageSrc1Origin() + vec2(column, row)*TileSize + local) * color
+}
+`
+
+var (
+	layerShader     *ebiten.Shader
+	layerShaderOnce sync.Once
+)
+
+func defaultLayerShader() *ebiten.Shader {
+	layerShaderOnce.Do(func() {
+		s, err := ebiten.NewShader([]byte(LayerShaderSource))
+		if err != nil {
+			panic(fmt.Sprintf("tilemap: NewShader failed: %v", err))
+		}
+		layerShader = s
+	})
+	return layerShader
+}
+
+// Tile is a tile placed on a Layer.
+type Tile struct {
+	// Index is the index of the tile in the tileset.
+	// The tiles in the tileset are counted from the upper-left to the right, and then to the next row.
+	// Index must be in [0, MaxTileIndex].
+	Index int
+
+	// FlipX and FlipY specify whether the tile is flipped horizontally and vertically.
+	FlipX bool
+	FlipY bool
+
+	// Data is an arbitrary value in [0, MaxTileData] for a custom shader, e.g. to select an effect per tile.
+	// The default shader ignores Data.
+	Data int
+}
+
+// Layer is a tile layer of an orthogonal grid, which is rendered on GPU with one draw call.
+//
+// A Layer keeps the tiles in an index image, and renders the whole layer with one quad and a shader,
+// which looks up the tileset image by the index image.
+// Unlike rendering a quad per tile, the CPU cost of rendering doesn't depend on the number of the tiles.
+// This is useful for a massive map.
+//
+// The center of the cell (0, 0) is at the world origin (0, 0), like OrthoGrid.
+// The cells out of the layer are empty.
+//
+// The layer size must not exceed the maximum image size.
+//
+// Layer is not concurrent-safe.
+type Layer struct {
+	width      int
+	height     int
+	tileWidth  int
+	tileHeight int
+	tileset    *ebiten.Image
+
+	index *ebiten.Image
+	pix   []byte
+	dirty image.Rectangle
+	tmp   []byte
+
+	vertices [4]ebiten.Vertex
+	uniforms map[string]any
+}
+
+// NewLayer creates a new empty Layer.
+//
+// width and height are the numbers of the cells.
+// tileset is the tileset image, and tileWidth and tileHeight are the size of a tile in pixels.
+// There must be no spacing or margin between tiles in the tileset.
+func NewLayer(width, height int, tileset *ebiten.Image, tileWidth, tileHeight int) *Layer {
+	if width <= 0 || height <= 0 {
+		panic(fmt.Sprintf("tilemap: width and height must be positive but (%d, %d)", width, height))
+	}
+	if tileWidth <= 0 || tileHeight <= 0 {
+		panic(fmt.Sprintf("tilemap: tileWidth and tileHeight must be positive but (%d, %d)", tileWidth, tileHeight))
+	}
+	return &Layer{
+		width:      width,
+		height:     height,
+		tileWidth:  tileWidth,
+		tileHeight: tileHeight,
+		tileset:    tileset,
+		index:      ebiten.NewImage(width, height),
+		pix:        make([]byte, 4*width*height),
+	}
+}
+
+// Size returns the numbers of the cells of the layer.
+func (l *Layer) Size() (width, height int) {
+	return l.width, l.height
+}
+
+// Grid returns the grid of the layer.
+func (l *Layer) Grid() *OrthoGrid {
+	return &OrthoGrid{
+		TileWidth:  float64(l.tileWidth),
+		TileHeight: float64(l.tileHeight),
+	}
+}
+
+func (l *Layer) contains(cell Cell) bool {
+	return 0 <= cell.X && cell.X < l.width && 0 <= cell.Y && cell.Y < l.height
+}
+
+// SetTile places the tile at the cell.
+//
+// If the cell is out of the layer, SetTile panics.
+// If the tile's Index or Data is out of range, SetTile panics.
+func (l *Layer) SetTile(cell Cell, tile Tile) {
+	if tile.Index < 0 || tile.Index > MaxTileIndex {
+		panic(fmt.Sprintf("tilemap: tile.Index must be in [0, %d] but %d", MaxTileIndex, tile.Index))
+	}
+	if tile.Data < 0 || tile.Data > MaxTileData {
+		panic(fmt.Sprintf("tilemap: tile.Data must be in [0, %d] but %d", MaxTileData, tile.Data))
+	}
+	flags := byte(tile.Data << 2)
+	if tile.FlipX {
+		flags |= 1
+	}
+	if tile.FlipY {
+		flags |= 2
+	}
+	t := tile.Index + 1
+	l.setPixel(cell, [4]byte{byte(t), byte(t >> 8), flags, 0xff})
+}
+
+// ClearTile removes the tile at the cell.
+//
+// If the cell is out of the layer, ClearTile panics.
+func (l *Layer) ClearTile(cell Cell) {
+	l.setPixel(cell, [4]byte{0, 0, 0, 0xff})
+}
+
+func (l *Layer) setPixel(cell Cell, p [4]byte) {
+	if !l.contains(cell) {
+		panic(fmt.Sprintf("tilemap: cell %v is out of the layer", cell))
+	}
+	idx := 4 * (cell.Y*l.width + cell.X)
+	copy(l.pix[idx:idx+4], p[:])
+	l.dirty = l.dirty.Union(image.Rect(cell.X, cell.Y, cell.X+1, cell.Y+1))
+}
+
+// Tile returns the tile at the cell.
+//
+// Tile returns false if the cell is empty or out of the layer.
+func (l *Layer) Tile(cell Cell) (Tile, bool) {
+	if !l.contains(cell) {
+		return Tile{}, false
+	}
+	idx := 4 * (cell.Y*l.width + cell.X)
+	t := int(l.pix[idx]) | int(l.pix[idx+1])<<8
+	if t == 0 {
+		return Tile{}, false
+	}
+	flags := l.pix[idx+2]
+	return Tile{
+		Index: t - 1,
+		FlipX: flags&1 != 0,
+		FlipY: flags&2 != 0,
+		Data:  int(flags >> 2),
+	}, true
+}
+
+// LayerDrawOptions represents options for Layer.Draw.
+type LayerDrawOptions struct {
+	// GeoM is the camera transform from world positions to screen positions.
+	// The default (zero) value is identity.
+	GeoM ebiten.GeoM
+
+	// ColorScale is a scale of color.
+	// The default (zero) value is identity, which is (1, 1, 1, 1).
+	ColorScale ebiten.ColorScale
+
+	// Blend is a blending way of the source color and the destination color.
+	// The default (zero) value is the regular alpha blending.
+	Blend ebiten.Blend
+
+	// Shader is a custom shader for per-tile effects.
+	// See LayerShaderSource for the details.
+	// If Shader is nil, the default shader from LayerShaderSource is used.
+	Shader *ebiten.Shader
+
+	// Uniforms is a set of uniform variables for Shader.
+	// The uniform variable TileSize is set by Layer.
+	Uniforms map[string]any
+}
+
+// Draw renders the layer onto dst.
+func (l *Layer) Draw(dst *ebiten.Image, options *LayerDrawOptions) {
+	if options == nil {
+		options = &LayerDrawOptions{}
+	}
+
+	l.flush()
+
+	tw, th := float64(l.tileWidth), float64(l.tileHeight)
+	x0, y0 := -tw/2, -th/2
+	x1, y1 := x0+float64(l.width)*tw, y0+float64(l.height)*th
+	cr, cg, cb, ca := options.ColorScale.R(), options.ColorScale.G(), options.ColorScale.B(), options.ColorScale.A()
+	for i, p := range [4][4]float64{
+		{x0, y0, 0, 0},
+		{x1, y0, float64(l.width), 0},
+		{x0, y1, 0, float64(l.height)},
+		{x1, y1, float64(l.width), float64(l.height)},
+	} {
+		dx, dy := options.GeoM.Apply(p[0], p[1])
+		l.vertices[i] = ebiten.Vertex{
+			DstX:   float32(dx),
+			DstY:   float32(dy),
+			SrcX:   float32(p[2]),
+			SrcY:   float32(p[3]),
+			ColorR: cr,
+			ColorG: cg,
+			ColorB: cb,
+			ColorA: ca,
+		}
+	}
+
+	if l.uniforms == nil {
+		l.uniforms = map[string]any{}
+	}
+	clear(l.uniforms)
+	for k, v := range options.Uniforms {
+		l.uniforms[k] = v
+	}
+	l.uniforms["TileSize"] = []float32{float32(l.tileWidth), float32(l.tileHeight)}
+
+	shader := options.Shader
+	if shader == nil {
+		shader = defaultLayerShader()
+	}
+
+	op := &ebiten.DrawTrianglesShaderOptions{}
+	op.Blend = options.Blend
+	op.Uniforms = l.uniforms
+	op.Images[0] = l.index
+	op.Images[1] = l.tileset
+	dst.DrawTrianglesShader(l.vertices[:], []uint16{0, 1, 2, 1, 2, 3}, shader, op)
+}
+
+// flush sends the modified tiles to the index image.
+func (l *Layer) flush() {
+	if l.dirty.Empty() {
+		return
+	}
+
+	r := l.dirty
+	l.dirty = image.Rectangle{}
+	if r.Dx() == l.width {
+		l.index.SubImage(r).(*ebiten.Image).WritePixels(l.pix[4*r.Min.Y*l.width : 4*r.Max.Y*l.width])
+		return
+	}
+
+	l.tmp = l.tmp[:0]
+	for j := r.Min.Y; j < r.Max.Y; j++ {
+		l.tmp = append(l.tmp, l.pix[4*(j*l.width+r.Min.X):4*(j*l.width+r.Max.X)]...)
+	}
+	l.index.SubImage(r).(*ebiten.Image).WritePixels(l.tmp)
+}
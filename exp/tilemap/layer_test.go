@@ -0,0 +1,74 @@
+// Copyright 2026 The Ebitengine Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tilemap
+
+import (
+	"image"
+	"testing"
+
+	"github.com/duplicants-ai/ebiten"
+)
+
+func TestLayerTile(t *testing.T) {
+	l := NewLayer(4, 3, ebiten.NewImage(64, 64), 16, 16)
+
+	tiles := map[Cell]Tile{
+		{0, 0}: {Index: 0},
+		{3, 0}: {Index: 255, FlipX: true},
+		{1, 2}: {Index: 256, FlipY: true, Data: 5},
+		{3, 2}: {Index: MaxTileIndex, FlipX: true, FlipY: true, Data: MaxTileData},
+	}
+	for c, tile := range tiles {
+		l.SetTile(c, tile)
+	}
+	for j := -1; j <= 3; j++ {
+		for i := -1; i <= 4; i++ {
+			c := Cell{i, j}
+			got, ok := l.Tile(c)
+			want, wantOK := tiles[c]
+			if got != want || ok != wantOK {
+				t.Errorf("l.Tile(%v): got: (%v, %t), want: (%v, %t)", c, got, ok, want, wantOK)
+			}
+		}
+	}
+
+	l.ClearTile(Cell{3, 2})
+	if _, ok := l.Tile(Cell{3, 2}); ok {
+		t.Errorf("l.Tile after ClearTile: got: true, want: false")
+	}
+	if got, want := l.dirty, image.Rect(0, 0, 4, 3); got != want {
+		t.Errorf("l.dirty: got: %v, want: %v", got, want)
+	}
+}
+
+func TestLayerSetTileOutOfRange(t *testing.T) {
+	l := NewLayer(4, 3, ebiten.NewImage(64, 64), 16, 16)
+	for name, f := range map[string]func(){
+		"cell":     func() { l.SetTile(Cell{4, 0}, Tile{}) },
+		"negative": func() { l.SetTile(Cell{0, 0}, Tile{Index: -1}) },
+		"index":    func() { l.SetTile(Cell{0, 0}, Tile{Index: MaxTileIndex + 1}) },
+		"data":     func() { l.SetTile(Cell{0, 0}, Tile{Data: MaxTileData + 1}) },
+		"clear":    func() { l.ClearTile(Cell{0, -1}) },
+	} {
+		t.Run(name, func(t *testing.T) {
+			defer func() {
+				if recover() == nil {
+					t.Errorf("panic expected")
+				}
+			}()
+			f()
+		})
+	}
+}
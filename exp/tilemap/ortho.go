@@ -0,0 +1,50 @@
+// Copyright 2026 The Ebitengine Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tilemap
+
+import (
+	"math"
+)
+
+// OrthoGrid is an orthogonal grid of rectangle cells.
+type OrthoGrid struct {
+	// TileWidth is the width of a cell.
+	TileWidth float64
+
+	// TileHeight is the height of a cell.
+	TileHeight float64
+}
+
+// CellToWorld implements Grid.
+func (g *OrthoGrid) CellToWorld(cell Cell) (x, y float64) {
+	return float64(cell.X) * g.TileWidth, float64(cell.Y) * g.TileHeight
+}
+
+// WorldToCell implements Grid.
+func (g *OrthoGrid) WorldToCell(x, y float64) Cell {
+	return Cell{
+		X: int(math.Floor(x/g.TileWidth + 0.5)),
+		Y: int(math.Floor(y/g.TileHeight + 0.5)),
+	}
+}
+
+// AppendNeighbors implements Grid.
+func (g *OrthoGrid) AppendNeighbors(neighbors []Cell, cell Cell) []Cell {
+	return append(neighbors,
+		Cell{X: cell.X + 1, Y: cell.Y},
+		Cell{X: cell.X, Y: cell.Y + 1},
+		Cell{X: cell.X - 1, Y: cell.Y},
+		Cell{X: cell.X, Y: cell.Y - 1})
+}
@@ -0,0 +1,78 @@
+// Copyright 2026 The Ebitengine Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package tilemap provides utilities for tile maps.
+//
+// This package is experimental and the API might be changed in the future.
+//
+// A Grid converts between cells and world positions for a grid layout like isometric, staggered, or hexagonal.
+// With a camera transform, which converts world positions to screen positions,
+// CellToScreen and ScreenToCell convert between cells and screen positions, e.g. to pick a cell under the cursor.
+//
+// A Layer renders a whole layer of tiles on an orthogonal grid with one draw call,
+// with an index image and a tileset image looked up by a shader.
+package tilemap
+
+import (
+	"github.com/duplicants-ai/ebiten"
+)
+
+// Cell is a position of a cell in a grid.
+type Cell struct {
+	X int
+	Y int
+}
+
+// Grid is a layout of cells.
+//
+// For all the grids in this package, the center of the cell (0, 0) is at the world origin (0, 0).
+type Grid interface {
+	// CellToWorld returns the world position of the cell's center.
+	CellToWorld(cell Cell) (x, y float64)
+
+	// WorldToCell returns the cell that contains the world position.
+	WorldToCell(x, y float64) Cell
+
+	// AppendNeighbors appends the cells sharing an edge with the cell to neighbors, and returns the extended buffer.
+	// Giving a slice that already has enough capacity works efficiently.
+	AppendNeighbors(neighbors []Cell, cell Cell) []Cell
+}
+
+// CellToScreen returns the screen position of the cell's center.
+//
+// camera is the transform from world positions to screen positions.
+func CellToScreen(grid Grid, cell Cell, camera ebiten.GeoM) (x, y float64) {
+	return camera.Apply(grid.CellToWorld(cell))
+}
+
+// ScreenToCell returns the cell at the screen position.
+//
+// camera is the transform from world positions to screen positions.
+// ScreenToCell returns false if camera is not invertible.
+func ScreenToCell(grid Grid, x, y float64, camera ebiten.GeoM) (Cell, bool) {
+	if !camera.IsInvertible() {
+		return Cell{}, false
+	}
+	camera.Invert()
+	return grid.WorldToCell(camera.Apply(x, y)), true
+}
+
+// floorDiv returns x / y rounded toward negative infinity.
+func floorDiv(x, y int) int {
+	q := x / y
+	if (x%y != 0) && ((x < 0) != (y < 0)) {
+		q--
+	}
+	return q
+}
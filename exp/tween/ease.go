@@ -0,0 +1,185 @@
+// Copyright 2026 The Ebitengine Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tween
+
+import (
+	"math"
+)
+
+// EaseFunc is an easing function that maps the progress t in [0, 1] to a value.
+// An EaseFunc should return 0 for t = 0 and 1 for t = 1, but the value can overshoot in between.
+type EaseFunc func(t float64) float64
+
+// Linear is the linear easing function.
+func Linear(t float64) float64 {
+	return t
+}
+
+// InQuad is the quadratic ease-in function.
+func InQuad(t float64) float64 {
+	return t * t
+}
+
+// OutQuad is the quadratic ease-out function.
+func OutQuad(t float64) float64 {
+	return 1 - (1-t)*(1-t)
+}
+
+// InOutQuad is the quadratic ease-in-out function.
+func InOutQuad(t float64) float64 {
+	if t < 0.5 {
+		return 2 * t * t
+	}
+	return 1 - math.Pow(-2*t+2, 2)/2
+}
+
+// InCubic is the cubic ease-in function.
+func InCubic(t float64) float64 {
+	return t * t * t
+}
+
+// OutCubic is the cubic ease-out function.
+func OutCubic(t float64) float64 {
+	return 1 - math.Pow(1-t, 3)
+}
+
+// InOutCubic is the cubic ease-in-out function.
+func InOutCubic(t float64) float64 {
+	if t < 0.5 {
+		return 4 * t * t * t
+	}
+	return 1 - math.Pow(-2*t+2, 3)/2
+}
+
+// InSine is the sinusoidal ease-in function.
+func InSine(t float64) float64 {
+	return 1 - math.Cos(t*math.Pi/2)
+}
+
+// OutSine is the sinusoidal ease-out function.
+func OutSine(t float64) float64 {
+	return math.Sin(t * math.Pi / 2)
+}
+
+// InOutSine is the sinusoidal ease-in-out function.
+func InOutSine(t float64) float64 {
+	return -(math.Cos(math.Pi*t) - 1) / 2
+}
+
+// InExpo is the exponential ease-in function.
+func InExpo(t float64) float64 {
+	if t == 0 {
+		return 0
+	}
+	return math.Pow(2, 10*t-10)
+}
+
+// OutExpo is the exponential ease-out function.
+func OutExpo(t float64) float64 {
+	if t == 1 {
+		return 1
+	}
+	return 1 - math.Pow(2, -10*t)
+}
+
+// InOutExpo is the exponential ease-in-out function.
+func InOutExpo(t float64) float64 {
+	switch {
+	case t == 0:
+		return 0
+	case t == 1:
+		return 1
+	case t < 0.5:
+		return math.Pow(2, 20*t-10) / 2
+	default:
+		return (2 - math.Pow(2, -20*t+10)) / 2
+	}
+}
+
+const (
+	backC1 = 1.70158
+	backC2 = backC1 * 1.525
+	backC3 = backC1 + 1
+)
+
+// InBack is the ease-in function that overshoots backward at the beginning.
+func InBack(t float64) float64 {
+	return backC3*t*t*t - backC1*t*t
+}
+
+// OutBack is the ease-out function that overshoots the target at the end.
+func OutBack(t float64) float64 {
+	return 1 + backC3*math.Pow(t-1, 3) + backC1*math.Pow(t-1, 2)
+}
+
+// InOutBack is the ease-in-out function that overshoots at both ends.
+func InOutBack(t float64) float64 {
+	if t < 0.5 {
+		return (math.Pow(2*t, 2) * ((backC2+1)*2*t - backC2)) / 2
+	}
+	return (math.Pow(2*t-2, 2)*((backC2+1)*(t*2-2)+backC2) + 2) / 2
+}
+
+const elasticC4 = (2 * math.Pi) / 3
+
+// InElastic is the elastic ease-in function.
+func InElastic(t float64) float64 {
+	if t == 0 || t == 1 {
+		return t
+	}
+	return -math.Pow(2, 10*t-10) * math.Sin((t*10-10.75)*elasticC4)
+}
+
+// OutElastic is the elastic ease-out function.
+func OutElastic(t float64) float64 {
+	if t == 0 || t == 1 {
+		return t
+	}
+	return math.Pow(2, -10*t)*math.Sin((t*10-0.75)*elasticC4) + 1
+}
+
+// OutBounce is the bouncing ease-out function.
+func OutBounce(t float64) float64 {
+	const (
+		n1 = 7.5625
+		d1 = 2.75
+	)
+	switch {
+	case t < 1/d1:
+		return n1 * t * t
+	case t < 2/d1:
+		t -= 1.5 / d1
+		return n1*t*t + 0.75
+	case t < 2.5/d1:
+		t -= 2.25 / d1
+		return n1*t*t + 0.9375
+	default:
+		t -= 2.625 / d1
+		return n1*t*t + 0.984375
+	}
+}
+
+// InBounce is the bouncing ease-in function.
+func InBounce(t float64) float64 {
+	return 1 - OutBounce(1-t)
+}
+
+// InOutBounce is the bouncing ease-in-out function.
+func InOutBounce(t float64) float64 {
+	if t < 0.5 {
+		return (1 - OutBounce(1-2*t)) / 2
+	}
+	return (1 + OutBounce(2*t-1)) / 2
+}
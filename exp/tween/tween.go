@@ -0,0 +1,297 @@
+// Copyright 2026 The Ebitengine Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package tween provides tweens to animate values over game time with easing functions.
+//
+// This package is experimental and the API might be changed in the future.
+//
+// A Tween changes a target value from the value at the time when the tween starts to the specified value.
+// Tweens are played by a Player, which advances the tweens by an *ebiten.Clock's Delta every Update.
+// Then, tweens respect the clock's time scale and pausing.
+package tween
+
+import (
+	"time"
+
+	"github.com/duplicants-ai/ebiten"
+)
+
+// Tween is an animation of a value.
+//
+// Tween is not concurrent-safe.
+type Tween struct {
+	duration time.Duration
+	delay    time.Duration
+	ease     EaseFunc
+
+	start func()
+	apply func(t float64)
+
+	onUpdate   func()
+	onComplete func()
+	next       *Tween
+
+	elapsed time.Duration
+	started bool
+	done    bool
+}
+
+// New creates a new Tween with a custom target.
+//
+// start is called when the tween starts, and can be used to capture the start values. start can be nil.
+// apply is called with the eased progress every time the tween advances.
+// If ease is nil, Linear is used.
+func New(duration time.Duration, ease EaseFunc, start func(), apply func(t float64)) *Tween {
+	if ease == nil {
+		ease = Linear
+	}
+	return &Tween{
+		duration: duration,
+		ease:     ease,
+		start:    start,
+		apply:    apply,
+	}
+}
+
+// Float creates a new Tween that changes the value of target to the value to.
+func Float(target *float64, to float64, duration time.Duration, ease EaseFunc) *Tween {
+	var from float64
+	return New(duration, ease, func() {
+		from = *target
+	}, func(t float64) {
+		*target = lerp(from, to, t)
+	})
+}
+
+// Vec2 creates a new Tween that changes the values of x and y to the values toX and toY.
+func Vec2(x, y *float64, toX, toY float64, duration time.Duration, ease EaseFunc) *Tween {
+	var fromX, fromY float64
+	return New(duration, ease, func() {
+		fromX, fromY = *x, *y
+	}, func(t float64) {
+		*x = lerp(fromX, toX, t)
+		*y = lerp(fromY, toY, t)
+	})
+}
+
+// ColorScale creates a new Tween that changes target to the color scale to.
+func ColorScale(target *ebiten.ColorScale, to ebiten.ColorScale, duration time.Duration, ease EaseFunc) *Tween {
+	var from ebiten.ColorScale
+	return New(duration, ease, func() {
+		from = *target
+	}, func(t float64) {
+		target.SetR(float32(lerp(float64(from.R()), float64(to.R()), t)))
+		target.SetG(float32(lerp(float64(from.G()), float64(to.G()), t)))
+		target.SetB(float32(lerp(float64(from.B()), float64(to.B()), t)))
+		target.SetA(float32(lerp(float64(from.A()), float64(to.A()), t)))
+	})
+}
+
+// GeoM creates a new Tween that changes target to the geometry matrix to element-wise.
+//
+// The element-wise interpolation works well for translating and scaling.
+// For rotating, use Float with an angle and build a GeoM from the angle instead.
+func GeoM(target *ebiten.GeoM, to ebiten.GeoM, duration time.Duration, ease EaseFunc) *Tween {
+	var from ebiten.GeoM
+	return New(duration, ease, func() {
+		from = *target
+	}, func(t float64) {
+		for i := 0; i < 2; i++ {
+			for j := 0; j < 3; j++ {
+				target.SetElement(i, j, lerp(from.Element(i, j), to.Element(i, j), t))
+			}
+		}
+	})
+}
+
+func lerp(from, to float64, t float64) float64 {
+	return from + (to-from)*t
+}
+
+// Delay sets the delay before the tween starts, and returns the tween itself.
+func (t *Tween) Delay(delay time.Duration) *Tween {
+	t.delay = delay
+	return t
+}
+
+// OnUpdate sets a function called every time the tween advances, and returns the tween itself.
+func (t *Tween) OnUpdate(f func()) *Tween {
+	t.onUpdate = f
+	return t
+}
+
+// OnComplete sets a function called when the tween completes, and returns the tween itself.
+func (t *Tween) OnComplete(f func()) *Tween {
+	t.onComplete = f
+	return t
+}
+
+// Then sets a tween to start after the tween completes, and returns next.
+//
+// Then can be chained like a.Then(b).Then(c).
+func (t *Tween) Then(next *Tween) *Tween {
+	t.next = next
+	return next
+}
+
+// Stop stops the tween and the following tweens.
+// OnComplete functions are not called.
+func (t *Tween) Stop() {
+	for t := t; t != nil; t = t.next {
+		t.done = true
+	}
+}
+
+// IsDone reports whether the tween has completed or stopped.
+func (t *Tween) IsDone() bool {
+	return t.done
+}
+
+// advance advances the tween by delta, and returns the remaining delta after the tween completes.
+func (t *Tween) advance(delta time.Duration) time.Duration {
+	if t.done {
+		return delta
+	}
+
+	if t.delay > 0 {
+		if delta < t.delay {
+			t.delay -= delta
+			return 0
+		}
+		delta -= t.delay
+		t.delay = 0
+	}
+
+	if !t.started {
+		t.started = true
+		if t.start != nil {
+			t.start()
+		}
+	}
+
+	t.elapsed += delta
+	var remaining time.Duration
+	if t.elapsed >= t.duration {
+		remaining = t.elapsed - t.duration
+		t.elapsed = t.duration
+	}
+
+	p := 1.0
+	if t.duration > 0 {
+		p = float64(t.elapsed) / float64(t.duration)
+	}
+	t.apply(t.ease(p))
+	if t.onUpdate != nil {
+		t.onUpdate()
+	}
+
+	if t.elapsed < t.duration {
+		return 0
+	}
+	t.done = true
+	if t.onComplete != nil {
+		t.onComplete()
+	}
+	return remaining
+}
+
+// Player plays tweens.
+//
+// Player is not concurrent-safe. Use a Player on the same goroutine as the game's Update.
+type Player struct {
+	clock     *ebiten.Clock
+	tweens    []*Tween
+	newTweens []*Tween
+
+	// stopCount is incremented every StopAll to detect StopAll in the callbacks.
+	stopCount int
+}
+
+// NewPlayer creates a new Player.
+//
+// The tweens advance by clock's Delta every Update.
+// If clock is nil, a new clock is created, and the tweens advance by 1/TPS [s] every Update.
+func NewPlayer(clock *ebiten.Clock) *Player {
+	if clock == nil {
+		clock = ebiten.NewClock()
+	}
+	return &Player{
+		clock: clock,
+	}
+}
+
+// Play starts playing the tween and the following tweens set by Then.
+// The tween starts advancing at the next Update.
+func (p *Player) Play(tween *Tween) {
+	p.newTweens = append(p.newTweens, tween)
+}
+
+// Update advances the playing tweens.
+//
+// Update should be called once in the game's Update.
+func (p *Player) Update() {
+	delta := p.clock.Delta()
+
+	// Tweens played by the callbacks start at the next Update.
+	p.tweens = append(p.tweens, p.newTweens...)
+	clear(p.newTweens)
+	p.newTweens = p.newTweens[:0]
+
+	// Use a local variable as p.tweens can be modified by StopAll in the callbacks.
+	tweens := p.tweens
+	stopCount := p.stopCount
+	var idx int
+	for _, t := range tweens {
+		d := delta
+		for t != nil {
+			d = t.advance(d)
+			if !t.done {
+				break
+			}
+			t = t.next
+		}
+		if p.stopCount != stopCount {
+			// StopAll was called in a callback, and all the tweens including the rest in tweens are already stopped and removed.
+			// Do not write back the tweens to p.tweens.
+			return
+		}
+		if t == nil {
+			continue
+		}
+		tweens[idx] = t
+		idx++
+	}
+	clear(tweens[idx:])
+	p.tweens = tweens[:idx]
+}
+
+// IsPlaying reports whether any tween is playing.
+func (p *Player) IsPlaying() bool {
+	return len(p.tweens) > 0 || len(p.newTweens) > 0
+}
+
+// StopAll stops all the playing tweens.
+func (p *Player) StopAll() {
+	p.stopCount++
+	for _, t := range p.tweens {
+		t.Stop()
+	}
+	for _, t := range p.newTweens {
+		t.Stop()
+	}
+	clear(p.tweens)
+	p.tweens = p.tweens[:0]
+	clear(p.newTweens)
+	p.newTweens = p.newTweens[:0]
+}
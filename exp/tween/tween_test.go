@@ -0,0 +1,199 @@
+// Copyright 2026 The Ebitengine Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tween_test
+
+import (
+	"math"
+	"testing"
+
+	"github.com/duplicants-ai/ebiten"
+	"github.com/duplicants-ai/ebiten/exp/tween"
+)
+
+func TestEaseEndpoints(t *testing.T) {
+	for name, f := range map[string]tween.EaseFunc{
+		"Linear":      tween.Linear,
+		"InQuad":      tween.InQuad,
+		"OutQuad":     tween.OutQuad,
+		"InOutQuad":   tween.InOutQuad,
+		"InCubic":     tween.InCubic,
+		"OutCubic":    tween.OutCubic,
+		"InOutCubic":  tween.InOutCubic,
+		"InSine":      tween.InSine,
+		"OutSine":     tween.OutSine,
+		"InOutSine":   tween.InOutSine,
+		"InExpo":      tween.InExpo,
+		"OutExpo":     tween.OutExpo,
+		"InOutExpo":   tween.InOutExpo,
+		"InBack":      tween.InBack,
+		"OutBack":     tween.OutBack,
+		"InOutBack":   tween.InOutBack,
+		"InElastic":   tween.InElastic,
+		"OutElastic":  tween.OutElastic,
+		"OutBounce":   tween.OutBounce,
+		"InBounce":    tween.InBounce,
+		"InOutBounce": tween.InOutBounce,
+	} {
+		if got, want := f(0), 0.0; math.Abs(got-want) > 1e-9 {
+			t.Errorf("%s(0): got: %f, want: %f", name, got, want)
+		}
+		if got, want := f(1), 1.0; math.Abs(got-want) > 1e-9 {
+			t.Errorf("%s(1): got: %f, want: %f", name, got, want)
+		}
+	}
+}
+
+func TestFloat(t *testing.T) {
+	c := ebiten.NewClock()
+	p := tween.NewPlayer(c)
+	d := c.Delta()
+
+	v := 10.0
+	tw := tween.Float(&v, 20, 4*d, tween.Linear)
+	p.Play(tw)
+
+	for i, want := range []float64{12.5, 15, 17.5, 20, 20} {
+		p.Update()
+		if math.Abs(v-want) > 1e-9 {
+			t.Errorf("Update #%d: got: %f, want: %f", i+1, v, want)
+		}
+	}
+	if !tw.IsDone() {
+		t.Errorf("tw.IsDone(): got: false, want: true")
+	}
+	if p.IsPlaying() {
+		t.Errorf("p.IsPlaying(): got: true, want: false")
+	}
+}
+
+func TestThen(t *testing.T) {
+	c := ebiten.NewClock()
+	p := tween.NewPlayer(c)
+	d := c.Delta()
+
+	var a, b float64
+	ta := tween.Float(&a, 1, 3*d/2, tween.Linear)
+	tb := tween.Float(&b, 1, 2*d, tween.Linear)
+	ta.Then(tb)
+	p.Play(ta)
+
+	for i, want := range [][2]float64{
+		{2.0 / 3.0, 0},
+		// The delta left after ta completes is given to tb in the same Update.
+		{1, 0.25},
+		{1, 0.75},
+		{1, 1},
+	} {
+		p.Update()
+		if math.Abs(a-want[0]) > 1e-6 || math.Abs(b-want[1]) > 1e-6 {
+			t.Errorf("Update #%d: got: (%f, %f), want: (%f, %f)", i+1, a, b, want[0], want[1])
+		}
+	}
+	if !ta.IsDone() || !tb.IsDone() {
+		t.Errorf("the tweens must be done")
+	}
+}
+
+func TestDelay(t *testing.T) {
+	c := ebiten.NewClock()
+	p := tween.NewPlayer(c)
+	d := c.Delta()
+
+	v := 0.0
+	var started bool
+	tw := tween.New(2*d, tween.Linear, func() {
+		started = true
+	}, func(t float64) {
+		v = t
+	}).Delay(2 * d)
+	p.Play(tw)
+
+	p.Update()
+	if started {
+		t.Errorf("the tween must not start during the delay")
+	}
+	for i, want := range []float64{0, 0.5, 1} {
+		p.Update()
+		if !started {
+			t.Errorf("Update #%d: the tween must start after the delay", i+2)
+		}
+		if math.Abs(v-want) > 1e-9 {
+			t.Errorf("Update #%d: got: %f, want: %f", i+2, v, want)
+		}
+	}
+}
+
+func TestStopAllInOnComplete(t *testing.T) {
+	c := ebiten.NewClock()
+	p := tween.NewPlayer(c)
+	d := c.Delta()
+
+	var a, b, x float64
+	tb := tween.Float(&b, 1, 10*d, tween.Linear)
+	p.Play(tb)
+
+	var tx *tween.Tween
+	ta := tween.Float(&a, 1, d, tween.Linear).OnComplete(func() {
+		p.StopAll()
+		tx = tween.Float(&x, 1, 2*d, tween.Linear)
+		p.Play(tx)
+	})
+	p.Play(ta)
+
+	tc := tween.Float(new(float64), 1, 10*d, tween.Linear)
+	p.Play(tc)
+
+	p.Update()
+	if !ta.IsDone() || !tb.IsDone() || !tc.IsDone() {
+		t.Errorf("all the tweens must be done")
+	}
+	if !p.IsPlaying() {
+		t.Errorf("p.IsPlaying(): got: false, want: true")
+	}
+
+	// The stopped tweens must not advance.
+	bb := b
+	p.Update()
+	if b != bb {
+		t.Errorf("b: got: %f, want: %f", b, bb)
+	}
+	if math.Abs(x-0.5) > 1e-9 {
+		t.Errorf("x: got: %f, want: %f", x, 0.5)
+	}
+
+	p.Update()
+	if !tx.IsDone() {
+		t.Errorf("tx.IsDone(): got: false, want: true")
+	}
+	if p.IsPlaying() {
+		t.Errorf("p.IsPlaying(): got: true, want: false")
+	}
+}
+
+func TestStopAllInOnCompleteWithoutPlay(t *testing.T) {
+	c := ebiten.NewClock()
+	p := tween.NewPlayer(c)
+	d := c.Delta()
+
+	p.Play(tween.Float(new(float64), 1, 10*d, tween.Linear))
+	p.Play(tween.Float(new(float64), 1, d, tween.Linear).OnComplete(func() {
+		p.StopAll()
+	}))
+
+	p.Update()
+	if p.IsPlaying() {
+		t.Errorf("p.IsPlaying(): got: true, want: false")
+	}
+}
@@ -0,0 +1,353 @@
+// Copyright 2026 The Ebitengine Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package virtualgamepad provides an on-screen touch gamepad.
+//
+// This package is experimental and the API might be changed in the future.
+//
+// A Gamepad has sticks and buttons on the screen, and feeds the touch inputs into the standard gamepad API
+// as a gamepad with its own GamepadID.
+// A game designed for controllers can treat the touch inputs in the same way as a physical gamepad,
+// e.g., by ebiten.StandardGamepadAxisValue and inpututil.IsStandardGamepadButtonJustPressed.
+//
+// A Gamepad always has the standard layout.
+package virtualgamepad
+
+import (
+	"image/color"
+	"math"
+
+	"github.com/duplicants-ai/ebiten"
+	"github.com/duplicants-ai/ebiten/ebitenutil"
+	"github.com/duplicants-ai/ebiten/inpututil"
+	"github.com/duplicants-ai/ebiten/internal/gamepad"
+	"github.com/duplicants-ai/ebiten/text/v2"
+	"github.com/duplicants-ai/ebiten/vector"
+)
+
+// Options represents options for a Gamepad.
+type Options struct {
+	// Name is the gamepad name returned by ebiten.GamepadName.
+	//
+	// The default (empty) value is "Virtual Gamepad".
+	Name string
+
+	// Mouse specifies whether the left mouse button is treated as a touch.
+	// This is useful to test the layout on desktops.
+	//
+	// The default (zero) value is false.
+	Mouse bool
+}
+
+// StickOptions represents options for a stick.
+type StickOptions struct {
+	// X and Y are the center position of the stick in the screen coordinates.
+	X float64
+	Y float64
+
+	// Radius is the radius of the stick.
+	Radius float64
+
+	// HorizontalAxis and VerticalAxis are the standard axes the stick feeds.
+	HorizontalAxis ebiten.StandardGamepadAxis
+	VerticalAxis   ebiten.StandardGamepadAxis
+
+	// DeadZone is the ratio of the radius where the stick is treated as neutral.
+	//
+	// The default (zero) value means no dead zone.
+	DeadZone float64
+}
+
+// ButtonOptions represents options for a button.
+type ButtonOptions struct {
+	// X and Y are the center position of the button in the screen coordinates.
+	X float64
+	Y float64
+
+	// Radius is the radius of the button.
+	Radius float64
+
+	// Button is the standard button the button feeds.
+	Button ebiten.StandardGamepadButton
+
+	// Label is the text drawn on the button.
+	Label string
+}
+
+// Stick is a touch stick.
+type Stick struct {
+	options StickOptions
+
+	pointer pointer
+	x       float64
+	y       float64
+}
+
+// Button is a touch button.
+type Button struct {
+	options ButtonOptions
+
+	pressed bool
+}
+
+// pointer is a touch or the mouse.
+type pointer struct {
+	valid bool
+	mouse bool
+	touch ebiten.TouchID
+}
+
+// Gamepad is an on-screen touch gamepad.
+//
+// Update and Draw must be called on the game's goroutine.
+type Gamepad struct {
+	virtual *gamepad.VirtualGamepad
+	mouse   bool
+
+	sticks  []*Stick
+	buttons []*Button
+
+	touchIDs []ebiten.TouchID
+}
+
+// New creates a new Gamepad and adds it to the gamepads.
+//
+// options can be nil. In this case, the default options are used.
+func New(options *Options) *Gamepad {
+	var op Options
+	if options != nil {
+		op = *options
+	}
+	if op.Name == "" {
+		op.Name = "Virtual Gamepad"
+	}
+	return &Gamepad{
+		virtual: gamepad.AddVirtualGamepad(op.Name),
+		mouse:   op.Mouse,
+	}
+}
+
+// ID returns the gamepad ID.
+func (g *Gamepad) ID() ebiten.GamepadID {
+	return g.virtual.ID()
+}
+
+// Close removes the gamepad from the gamepads.
+// After Close is called, the gamepad ID might be reused by another gamepad.
+func (g *Gamepad) Close() {
+	g.virtual.Remove()
+}
+
+// AddStick adds a stick to the gamepad.
+func (g *Gamepad) AddStick(options *StickOptions) *Stick {
+	s := &Stick{
+		options: *options,
+	}
+	g.sticks = append(g.sticks, s)
+	return s
+}
+
+// AddButton adds a button to the gamepad.
+func (g *Gamepad) AddButton(options *ButtonOptions) *Button {
+	b := &Button{
+		options: *options,
+	}
+	g.buttons = append(g.buttons, b)
+	return b
+}
+
+// Update updates the gamepad state with the current touches.
+// Update should be called at the beginning of the game's Update.
+//
+// The state is reflected to ebiten's gamepad functions immediately, and to inpututil's functions from the next tick.
+func (g *Gamepad) Update() {
+	g.touchIDs = ebiten.AppendTouchIDs(g.touchIDs[:0])
+
+	for _, s := range g.sticks {
+		if s.pointer.valid && !g.isPointerAlive(s.pointer) {
+			s.pointer = pointer{}
+		}
+		if !s.pointer.valid {
+			for _, p := range g.justPressedPointers() {
+				if g.isPointerUsed(p) {
+					continue
+				}
+				if x, y := g.pointerPosition(p); s.contains(x, y) {
+					s.pointer = p
+					break
+				}
+			}
+		}
+		s.x, s.y = 0, 0
+		if s.pointer.valid {
+			x, y := g.pointerPosition(s.pointer)
+			s.updateValue(x, y)
+		}
+		g.virtual.SetStandardAxisValue(s.options.HorizontalAxis, s.x)
+		g.virtual.SetStandardAxisValue(s.options.VerticalAxis, s.y)
+	}
+
+	// A button is pressed while a pointer not captured by a stick is on it, so that a player can slide a finger across buttons.
+	for _, b := range g.buttons {
+		b.pressed = false
+		for _, p := range g.pointers() {
+			if g.isPointerUsed(p) {
+				continue
+			}
+			if x, y := g.pointerPosition(p); b.contains(x, y) {
+				b.pressed = true
+				break
+			}
+		}
+	}
+	// Multiple buttons might feed the same standard button.
+	values := map[ebiten.StandardGamepadButton]float64{}
+	for _, b := range g.buttons {
+		if b.pressed {
+			values[b.options.Button] = 1
+		} else if _, ok := values[b.options.Button]; !ok {
+			values[b.options.Button] = 0
+		}
+	}
+	for button, v := range values {
+		g.virtual.SetStandardButtonValue(button, v)
+	}
+}
+
+func (g *Gamepad) pointers() []pointer {
+	ps := make([]pointer, 0, len(g.touchIDs)+1)
+	for _, id := range g.touchIDs {
+		ps = append(ps, pointer{valid: true, touch: id})
+	}
+	if g.mouse && ebiten.IsMouseButtonPressed(ebiten.MouseButtonLeft) {
+		ps = append(ps, pointer{valid: true, mouse: true})
+	}
+	return ps
+}
+
+func (g *Gamepad) justPressedPointers() []pointer {
+	var ps []pointer
+	for _, id := range inpututil.AppendJustPressedTouchIDs(nil) {
+		ps = append(ps, pointer{valid: true, touch: id})
+	}
+	if g.mouse && inpututil.IsMouseButtonJustPressed(ebiten.MouseButtonLeft) {
+		ps = append(ps, pointer{valid: true, mouse: true})
+	}
+	return ps
+}
+
+func (g *Gamepad) isPointerAlive(p pointer) bool {
+	if p.mouse {
+		return g.mouse && ebiten.IsMouseButtonPressed(ebiten.MouseButtonLeft)
+	}
+	for _, id := range g.touchIDs {
+		if id == p.touch {
+			return true
+		}
+	}
+	return false
+}
+
+// isPointerUsed reports whether p is captured by a stick.
+func (g *Gamepad) isPointerUsed(p pointer) bool {
+	for _, s := range g.sticks {
+		if s.pointer == p {
+			return true
+		}
+	}
+	return false
+}
+
+func (g *Gamepad) pointerPosition(p pointer) (float64, float64) {
+	if p.mouse {
+		x, y := ebiten.CursorPosition()
+		return float64(x), float64(y)
+	}
+	x, y := ebiten.TouchPosition(p.touch)
+	return float64(x), float64(y)
+}
+
+func (s *Stick) contains(x, y float64) bool {
+	return math.Hypot(x-s.options.X, y-s.options.Y) <= s.options.Radius
+}
+
+func (s *Stick) updateValue(x, y float64) {
+	if s.options.Radius <= 0 {
+		return
+	}
+	dx := (x - s.options.X) / s.options.Radius
+	dy := (y - s.options.Y) / s.options.Radius
+	l := math.Hypot(dx, dy)
+	if l > 1 {
+		dx /= l
+		dy /= l
+		l = 1
+	}
+	if l <= s.options.DeadZone {
+		return
+	}
+	// Rescale the magnitude so that the value starts from 0 at the edge of the dead zone.
+	scale := (l - s.options.DeadZone) / (1 - s.options.DeadZone) / l
+	s.x = dx * scale
+	s.y = dy * scale
+}
+
+// Value returns the current value of the stick in [-1, 1].
+func (s *Stick) Value() (x, y float64) {
+	return s.x, s.y
+}
+
+func (b *Button) contains(x, y float64) bool {
+	return math.Hypot(x-b.options.X, y-b.options.Y) <= b.options.Radius
+}
+
+// IsPressed reports whether the button is pressed.
+func (b *Button) IsPressed() bool {
+	return b.pressed
+}
+
+var (
+	baseColor    = color.RGBA{0x80, 0x80, 0x80, 0x80}
+	outlineColor = color.RGBA{0xff, 0xff, 0xff, 0xff}
+	knobColor    = color.RGBA{0xc0, 0xc0, 0xc0, 0xc0}
+	pressedColor = color.RGBA{0xff, 0xff, 0xff, 0xc0}
+)
+
+// Draw draws the sticks and the buttons on the screen.
+// Draw should be called at the end of the game's Draw.
+func (g *Gamepad) Draw(screen *ebiten.Image) {
+	for _, s := range g.sticks {
+		cx, cy, r := float32(s.options.X), float32(s.options.Y), float32(s.options.Radius)
+		vector.DrawFilledCircle(screen, cx, cy, r, baseColor, true)
+		vector.StrokeCircle(screen, cx, cy, r, 2, outlineColor, true)
+		kx := cx + float32(s.x)*r
+		ky := cy + float32(s.y)*r
+		vector.DrawFilledCircle(screen, kx, ky, r/2, knobColor, true)
+	}
+	for _, b := range g.buttons {
+		cx, cy, r := float32(b.options.X), float32(b.options.Y), float32(b.options.Radius)
+		clr := baseColor
+		if b.pressed {
+			clr = pressedColor
+		}
+		vector.DrawFilledCircle(screen, cx, cy, r, clr, true)
+		vector.StrokeCircle(screen, cx, cy, r, 2, outlineColor, true)
+		if b.options.Label != "" {
+			// The built-in debug font is 16 pixels high.
+			ebitenutil.DebugPrintWithOptions(screen, b.options.Label, int(b.options.X), int(b.options.Y)-8, &ebitenutil.DebugPrintOptions{
+				Align: text.AlignCenter,
+			})
+		}
+	}
+}
@@ -0,0 +1,175 @@
+// Copyright 2026 The Ebitengine Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package virtualgamepad
+
+import (
+	"math"
+	"testing"
+
+	"github.com/duplicants-ai/ebiten"
+	"github.com/duplicants-ai/ebiten/internal/gamepad"
+	etesting "github.com/duplicants-ai/ebiten/internal/testing"
+	"github.com/duplicants-ai/ebiten/internal/ui"
+)
+
+func TestMain(m *testing.M) {
+	ui.SetPanicOnErrorOnReadingPixelsForTesting(true)
+	etesting.MainWithRunLoop(m)
+}
+
+func TestStickValue(t *testing.T) {
+	const r = 100
+	cases := []struct {
+		deadZone float64
+		x, y     float64
+		wantX    float64
+		wantY    float64
+	}{
+		{x: 0, y: 0, wantX: 0, wantY: 0},
+		{x: 50, y: 0, wantX: 0.5, wantY: 0},
+		{x: 0, y: -100, wantX: 0, wantY: -1},
+		{x: 60, y: 80, wantX: 0.6, wantY: 0.8},
+		// A position outside the stick is clamped to the edge.
+		{x: 300, y: 0, wantX: 1, wantY: 0},
+		{x: -120, y: -160, wantX: -0.6, wantY: -0.8},
+		// A position in the dead zone is neutral.
+		{deadZone: 0.2, x: 20, y: 0, wantX: 0, wantY: 0},
+		{deadZone: 0.2, x: 0, y: 10, wantX: 0, wantY: 0},
+		// The value starts from 0 at the edge of the dead zone.
+		{deadZone: 0.2, x: 60, y: 0, wantX: 0.5, wantY: 0},
+		{deadZone: 0.2, x: 0, y: 100, wantX: 0, wantY: 1},
+		{deadZone: 0.2, x: 0, y: -200, wantX: 0, wantY: -1},
+	}
+	for _, c := range cases {
+		s := &Stick{
+			options: StickOptions{
+				X:        200,
+				Y:        300,
+				Radius:   r,
+				DeadZone: c.deadZone,
+			},
+		}
+		s.updateValue(200+c.x, 300+c.y)
+		x, y := s.Value()
+		if math.Abs(x-c.wantX) > 1e-9 || math.Abs(y-c.wantY) > 1e-9 {
+			t.Errorf("dead zone: %v, (%v, %v): got: (%v, %v), want: (%v, %v)", c.deadZone, c.x, c.y, x, y, c.wantX, c.wantY)
+		}
+	}
+}
+
+func TestStickValueWithZeroRadius(t *testing.T) {
+	s := &Stick{}
+	s.updateValue(10, 10)
+	if x, y := s.Value(); x != 0 || y != 0 {
+		t.Errorf("got: (%v, %v), want: (0, 0)", x, y)
+	}
+}
+
+func TestContains(t *testing.T) {
+	s := &Stick{
+		options: StickOptions{X: 10, Y: 20, Radius: 5},
+	}
+	b := &Button{
+		options: ButtonOptions{X: 10, Y: 20, Radius: 5},
+	}
+	cases := []struct {
+		x, y float64
+		want bool
+	}{
+		{x: 10, y: 20, want: true},
+		{x: 15, y: 20, want: true},
+		{x: 13, y: 24, want: true},
+		{x: 16, y: 20, want: false},
+		{x: 14, y: 24, want: false},
+	}
+	for _, c := range cases {
+		if got := s.contains(c.x, c.y); got != c.want {
+			t.Errorf("Stick.contains(%v, %v): got: %v, want: %v", c.x, c.y, got, c.want)
+		}
+		if got := b.contains(c.x, c.y); got != c.want {
+			t.Errorf("Button.contains(%v, %v): got: %v, want: %v", c.x, c.y, got, c.want)
+		}
+	}
+}
+
+func TestNewAndClose(t *testing.T) {
+	g := New(nil)
+	gp := gamepad.Get(g.ID())
+	if gp == nil {
+		t.Fatalf("gamepad.Get(%d) must not be nil", g.ID())
+	}
+	if got, want := gp.Name(), "Virtual Gamepad"; got != want {
+		t.Errorf("got: %q, want: %q", got, want)
+	}
+
+	g2 := New(&Options{
+		Name: "Foo",
+	})
+	if g2.ID() == g.ID() {
+		t.Errorf("the IDs must be different but both are %d", g.ID())
+	}
+	if got, want := gamepad.Get(g2.ID()).Name(), "Foo"; got != want {
+		t.Errorf("got: %q, want: %q", got, want)
+	}
+
+	g.Close()
+	g2.Close()
+	if gamepad.Get(g.ID()) != nil {
+		t.Errorf("gamepad.Get(%d) must be nil after Close", g.ID())
+	}
+	if gamepad.Get(g2.ID()) != nil {
+		t.Errorf("gamepad.Get(%d) must be nil after Close", g2.ID())
+	}
+}
+
+func TestUpdateWithoutTouches(t *testing.T) {
+	g := New(nil)
+	defer g.Close()
+
+	s := g.AddStick(&StickOptions{
+		X:              50,
+		Y:              50,
+		Radius:         40,
+		HorizontalAxis: ebiten.StandardGamepadAxisLeftStickHorizontal,
+		VerticalAxis:   ebiten.StandardGamepadAxisLeftStickVertical,
+	})
+	b := g.AddButton(&ButtonOptions{
+		X:      200,
+		Y:      50,
+		Radius: 20,
+		Button: ebiten.StandardGamepadButtonRightBottom,
+	})
+
+	// Set the states as if they were touched. Update resets them as there is no touch.
+	s.x, s.y = 0.5, -0.5
+	b.pressed = true
+	g.virtual.SetStandardAxisValue(ebiten.StandardGamepadAxisLeftStickHorizontal, 0.5)
+	g.virtual.SetStandardButtonValue(ebiten.StandardGamepadButtonRightBottom, 1)
+	g.Update()
+
+	if x, y := s.Value(); x != 0 || y != 0 {
+		t.Errorf("stick: got: (%v, %v), want: (0, 0)", x, y)
+	}
+	if b.IsPressed() {
+		t.Errorf("button: got: pressed, want: not pressed")
+	}
+	gp := gamepad.Get(g.ID())
+	if got := gp.StandardAxisValue(ebiten.StandardGamepadAxisLeftStickHorizontal); got != 0 {
+		t.Errorf("axis: got: %v, want: 0", got)
+	}
+	if got := gp.StandardButtonValue(ebiten.StandardGamepadButtonRightBottom); got != 0 {
+		t.Errorf("button value: got: %v, want: 0", got)
+	}
+}
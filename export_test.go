@@ -14,12 +14,78 @@
 
 package ebiten
 
-import "github.com/duplicants-ai/ebiten/internal/builtinshader"
+import (
+	"image"
+	"time"
+
+	"github.com/duplicants-ai/ebiten/internal/builtinshader"
+	"github.com/duplicants-ai/ebiten/internal/clock"
+	"github.com/duplicants-ai/ebiten/internal/ui"
+)
 
 var (
-	ImageToBytes = imageToBytes
+	ImageToBytes         = imageToBytes
+	PanicIfNotSplittable = panicIfNotSplittable
 )
 
 func BuiltinShader(filter builtinshader.Filter, address builtinshader.Address, useColorM bool) *Shader {
 	return builtinShader(filter, address, useColorM)
 }
+
+func SetPremultipliedAlphaValidationEnabledForTesting(enabled bool) {
+	premultipliedAlphaValidationEnabled = enabled
+}
+
+// SetCurrentTickTimeForTesting replaces the total tick time used by Clock, and returns a function to restore it.
+func SetCurrentTickTimeForTesting(f func() time.Duration) func() {
+	orig := currentTickTime
+	currentTickTime = f
+	return func() {
+		currentTickTime = orig
+	}
+}
+
+// CaptureForTesting captures the game screen like CaptureScreen, with offscreen as the current offscreen.
+func CaptureForTesting(game Game, offscreen *Image, options *CaptureOptions) (*image.RGBA, error) {
+	g := &gameForUI{
+		game:      game,
+		offscreen: offscreen,
+	}
+	return g.capture(captureRequest{
+		width:         options.Width,
+		height:        options.Height,
+		supersampling: max(options.Supersampling, 1),
+	})
+}
+
+// SetTPSLimitForTesting sets the upper limit of TPS as the low-power mode does.
+func SetTPSLimitForTesting(limit int) {
+	clock.SetTPSLimit(limit)
+}
+
+// ApplyInjectedInputForTesting merges the injected inputs into state as the input state update does.
+func ApplyInjectedInputForTesting(state *ui.InputState) {
+	theInjectedInput.apply(state)
+}
+
+// UpdateInputStateForTesting updates the input state as reading it from the platform.
+func UpdateInputStateForTesting(f func(state *ui.InputState)) {
+	theInputState.update(f)
+}
+
+type GamepadConnectionNotifierForTesting = gamepadConnectionNotifier
+
+func (g *gamepadConnectionNotifier) NotifyForTesting() {
+	g.notify()
+}
+
+type WindowMonitorChangeNotifierForTesting = windowMonitorChangeNotifier
+
+func (w *windowMonitorChangeNotifier) UpdateForTesting(monitor *MonitorType, deviceScaleFactor float64) {
+	w.update((*ui.Monitor)(monitor), deviceScaleFactor)
+}
+
+// LayoutForTesting returns the offscreen size for the outside size as the UI does.
+func LayoutForTesting(game Game, outsideWidth, outsideHeight float64) (float64, float64) {
+	return newGameForUI(game, false).Layout(outsideWidth, outsideHeight)
+}
@@ -20,11 +20,20 @@ import (
 	"sync/atomic"
 
 	"github.com/duplicants-ai/ebiten/internal/atlas"
+	"github.com/duplicants-ai/ebiten/internal/builtinshader"
+	"github.com/duplicants-ai/ebiten/internal/debug"
 	"github.com/duplicants-ai/ebiten/internal/ui"
 )
 
 var screenFilterEnabled atomic.Bool
 
+type screenDitheringState struct {
+	mode     DitheringMode
+	strength float64
+}
+
+var screenDithering atomic.Pointer[screenDitheringState]
+
 func init() {
 	screenFilterEnabled.Store(true)
 }
@@ -35,6 +44,10 @@ type gameForUI struct {
 	screen      *Image
 	imageDumper imageDumper
 	transparent bool
+
+	lowPowerModeActive bool
+	gamepadConnections gamepadConnectionNotifier
+	windowMonitor      windowMonitorChangeNotifier
 }
 
 func newGameForUI(game Game, transparent bool) *gameForUI {
@@ -61,6 +74,7 @@ func (g *gameForUI) NewOffscreenImage(width, height int) *ui.Image {
 		imageType = atlas.ImageTypeVolatile
 	}
 	g.offscreen = newImage(image.Rect(0, 0, width, height), imageType)
+	theOffscreen.Store(g.offscreen)
 	return g.offscreen.image
 }
 
@@ -75,6 +89,10 @@ func (g *gameForUI) NewScreenImage(width, height int) *ui.Image {
 }
 
 func (g *gameForUI) Layout(outsideWidth, outsideHeight float64) (float64, float64) {
+	if s := theOffscreenSize.Load(); s != nil {
+		return s.width, s.height
+	}
+
 	if l, ok := g.game.(LayoutFer); ok {
 		return l.LayoutF(outsideWidth, outsideHeight)
 	}
@@ -97,21 +115,42 @@ func (g *gameForUI) UpdateInputState(fn func(*ui.InputState)) {
 	theInputState.update(fn)
 }
 
-func (g *gameForUI) Update() error {
+func (g *gameForUI) Update() (err error) {
+	if debug.IsPanicCaptureEnabled() {
+		defer debug.CapturePanic(&err)
+	}
+
+	notifyLowPowerModeChanged(&g.lowPowerModeActive)
+	g.gamepadConnections.notify()
+	g.windowMonitor.notify()
+
 	if err := g.game.Update(); err != nil {
 		return err
 	}
+	return nil
+}
+
+func (g *gameForUI) PublishState() error {
+	if p, ok := g.game.(StatePublisher); ok {
+		p.PublishState()
+	}
+	// The image dumper's state is used at Draw.
 	if err := g.imageDumper.update(); err != nil {
 		return err
 	}
 	return nil
 }
 
-func (g *gameForUI) DrawOffscreen() error {
+func (g *gameForUI) DrawOffscreen() (err error) {
+	if debug.IsPanicCaptureEnabled() {
+		defer debug.CapturePanic(&err)
+	}
+
 	g.game.Draw(g.offscreen)
 	if err := g.imageDumper.dump(g.offscreen, g.transparent); err != nil {
 		return err
 	}
+	g.processCaptureRequests()
 	return nil
 }
 
@@ -135,20 +174,31 @@ func (g *gameForUI) DrawFinalScreen(scale, offsetX, offsetY float64) {
 // in your implementation of [FinalScreenDrawer], for example.
 func DefaultDrawFinalScreen(screen FinalScreen, offscreen *Image, geoM GeoM) {
 	scale := geoM.Element(0, 0)
+	filter := FilterNearest
 	switch {
 	case !screenFilterEnabled.Load(), math.Floor(scale) == scale:
-		op := &DrawImageOptions{}
-		op.GeoM = geoM
-		screen.DrawImage(offscreen, op)
 	case scale < 1:
-		op := &DrawImageOptions{}
-		op.GeoM = geoM
-		op.Filter = FilterLinear
-		screen.DrawImage(offscreen, op)
+		filter = FilterLinear
 	default:
-		op := &DrawImageOptions{}
+		filter = FilterPixelated
+	}
+
+	if mode, strength := ScreenDithering(); mode != DitheringModeNone && strength > 0 {
+		op := &DrawRectShaderOptions{}
 		op.GeoM = geoM
-		op.Filter = FilterPixelated
-		screen.DrawImage(offscreen, op)
+		op.Images[0] = offscreen
+		op.Uniforms = map[string]any{
+			builtinshader.UniformDitherMode:     int(mode),
+			builtinshader.UniformDitherStrength: float32(strength),
+			builtinshader.UniformBlueNoise:      builtinshader.BlueNoise(),
+		}
+		b := offscreen.Bounds()
+		screen.DrawRectShader(b.Dx(), b.Dy(), screenDitherShader(builtinshader.Filter(filter)), op)
+		return
 	}
+
+	op := &DrawImageOptions{}
+	op.GeoM = geoM
+	op.Filter = filter
+	screen.DrawImage(offscreen, op)
 }
@@ -0,0 +1,156 @@
+// Copyright 2026 The Ebitengine Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ebiten
+
+import (
+	"slices"
+	"sync/atomic"
+
+	"github.com/duplicants-ai/ebiten/internal/gamepad"
+)
+
+// GamepadInfo represents the information of a gamepad at the time it is connected.
+type GamepadInfo struct {
+	// ID is the gamepad ID.
+	// After the gamepad is disconnected, the same ID might be reused for another gamepad.
+	ID GamepadID
+
+	// Name is the name of the gamepad. This is the same as GamepadName.
+	Name string
+
+	// SDLID is the GUID of the gamepad generated in the same way as SDL. This is the same as GamepadSDLID.
+	SDLID string
+
+	// VendorID and ProductID are the USB vendor ID and product ID.
+	// VendorID and ProductID are 0 if they are unknown.
+	VendorID  uint16
+	ProductID uint16
+
+	// AxisCount is the number of the axes. This is the same as GamepadAxisCount.
+	AxisCount int
+
+	// ButtonCount is the number of the buttons. This is the same as GamepadButtonCount.
+	ButtonCount int
+
+	// StandardLayoutAvailable reports whether the gamepad has a standard layout mapping.
+	// This is the same as IsStandardGamepadLayoutAvailable.
+	StandardLayoutAvailable bool
+}
+
+// GamepadConnectionHandlers represents the callbacks for gamepad connections and disconnections.
+type GamepadConnectionHandlers struct {
+	// OnConnected is called when a gamepad is connected.
+	OnConnected func(info GamepadInfo)
+
+	// OnDisconnected is called when a gamepad is disconnected.
+	// info is the same value as the one given to OnConnected.
+	OnDisconnected func(info GamepadInfo)
+}
+
+var theGamepadConnectionHandlers atomic.Pointer[GamepadConnectionHandlers]
+
+// SetGamepadConnectionHandlers sets the callbacks for gamepad connections and disconnections.
+// If handlers is nil, the callbacks are removed.
+//
+// The callbacks are called on the same goroutine as Update, before Update is called.
+// Disconnections are reported before connections in the same tick, and each kind is reported in the order of the gamepad IDs.
+//
+// The gamepads connected at the beginning of the game are also reported by OnConnected at the first tick.
+// The gamepads connected before SetGamepadConnectionHandlers is called are not reported by OnConnected.
+// To enumerate them, use AppendGamepadIDs.
+//
+// The device information is taken at the time of the connection, so OnDisconnected can show the name of the disconnected gamepad.
+//
+// SetGamepadConnectionHandlers is concurrent-safe.
+func SetGamepadConnectionHandlers(handlers *GamepadConnectionHandlers) {
+	if handlers == nil {
+		theGamepadConnectionHandlers.Store(nil)
+		return
+	}
+	h := *handlers
+	theGamepadConnectionHandlers.Store(&h)
+}
+
+type connectedGamepad struct {
+	gamepad *gamepad.Gamepad
+	info    GamepadInfo
+}
+
+type gamepadConnectionNotifier struct {
+	gamepads map[GamepadID]connectedGamepad
+	ids      []GamepadID
+}
+
+// notify calls the gamepad connection handlers if gamepads have been connected or disconnected since the previous call.
+func (g *gamepadConnectionNotifier) notify() {
+	g.ids = gamepad.AppendGamepadIDs(g.ids[:0])
+
+	current := map[GamepadID]*gamepad.Gamepad{}
+	for _, id := range g.ids {
+		if gp := gamepad.Get(id); gp != nil {
+			current[id] = gp
+		}
+	}
+
+	h := theGamepadConnectionHandlers.Load()
+
+	// A gamepad with the same ID might be a different device when the device is reconnected in a tick.
+	var disconnected []GamepadID
+	for id, c := range g.gamepads {
+		if gp, ok := current[id]; !ok || gp != c.gamepad {
+			disconnected = append(disconnected, id)
+		}
+	}
+	slices.Sort(disconnected)
+	for _, id := range disconnected {
+		info := g.gamepads[id].info
+		delete(g.gamepads, id)
+		removeGamepadAxisFilter(id)
+		if h != nil && h.OnDisconnected != nil {
+			h.OnDisconnected(info)
+		}
+	}
+
+	for _, id := range g.ids {
+		gp, ok := current[id]
+		if !ok {
+			continue
+		}
+		if _, ok := g.gamepads[id]; ok {
+			continue
+		}
+		vendor, product, _ := gp.VendorProductID()
+		info := GamepadInfo{
+			ID:                      id,
+			Name:                    gp.Name(),
+			SDLID:                   gp.SDLID(),
+			VendorID:                vendor,
+			ProductID:               product,
+			AxisCount:               gp.AxisCount(),
+			ButtonCount:             gp.ButtonCount(),
+			StandardLayoutAvailable: gp.IsStandardLayoutAvailable(),
+		}
+		if g.gamepads == nil {
+			g.gamepads = map[GamepadID]connectedGamepad{}
+		}
+		g.gamepads[id] = connectedGamepad{
+			gamepad: gp,
+			info:    info,
+		}
+		if h != nil && h.OnConnected != nil {
+			h.OnConnected(info)
+		}
+	}
+}
@@ -0,0 +1,97 @@
+// Copyright 2026 The Ebitengine Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ebiten_test
+
+import (
+	"testing"
+
+	"github.com/duplicants-ai/ebiten"
+)
+
+func TestGamepadConnectionHandlers(t *testing.T) {
+	t.Cleanup(func() {
+		ebiten.SetGamepadConnectionHandlers(nil)
+	})
+
+	var n ebiten.GamepadConnectionNotifierForTesting
+	// Ignore the physical gamepads that are already connected.
+	n.NotifyForTesting()
+
+	var connected, disconnected []ebiten.GamepadInfo
+	handlers := &ebiten.GamepadConnectionHandlers{
+		OnConnected: func(info ebiten.GamepadInfo) {
+			connected = append(connected, info)
+		},
+		OnDisconnected: func(info ebiten.GamepadInfo) {
+			disconnected = append(disconnected, info)
+		},
+	}
+	ebiten.SetGamepadConnectionHandlers(handlers)
+	// Modifying the handlers after SetGamepadConnectionHandlers doesn't affect the registered handlers.
+	handlers.OnConnected = nil
+
+	id := ebiten.AddInjectedGamepad("Test Gamepad")
+	n.NotifyForTesting()
+	if len(connected) != 1 {
+		t.Fatalf("len(connected): got: %d, want: 1", len(connected))
+	}
+	if len(disconnected) != 0 {
+		t.Fatalf("len(disconnected): got: %d, want: 0", len(disconnected))
+	}
+	info := connected[0]
+	if got, want := info.ID, id; got != want {
+		t.Errorf("ID: got: %d, want: %d", got, want)
+	}
+	if got, want := info.Name, "Test Gamepad"; got != want {
+		t.Errorf("Name: got: %q, want: %q", got, want)
+	}
+	if got, want := info.Name, ebiten.GamepadName(id); got != want {
+		t.Errorf("Name: got: %q, want: %q", got, want)
+	}
+	if got, want := info.AxisCount, ebiten.GamepadAxisCount(id); got != want {
+		t.Errorf("AxisCount: got: %d, want: %d", got, want)
+	}
+	if got, want := info.ButtonCount, ebiten.GamepadButtonCount(id); got != want {
+		t.Errorf("ButtonCount: got: %d, want: %d", got, want)
+	}
+	if !info.StandardLayoutAvailable {
+		t.Errorf("StandardLayoutAvailable: got: false, want: true")
+	}
+
+	// No notification without any changes.
+	n.NotifyForTesting()
+	if len(connected) != 1 || len(disconnected) != 0 {
+		t.Errorf("len(connected), len(disconnected): got: %d, %d, want: 1, 0", len(connected), len(disconnected))
+	}
+
+	// OnDisconnected is given the information at the time of the connection.
+	ebiten.RemoveInjectedGamepad(id)
+	n.NotifyForTesting()
+	if len(disconnected) != 1 {
+		t.Fatalf("len(disconnected): got: %d, want: 1", len(disconnected))
+	}
+	if got, want := disconnected[0], info; got != want {
+		t.Errorf("got: %+v, want: %+v", got, want)
+	}
+
+	ebiten.SetGamepadConnectionHandlers(nil)
+	id = ebiten.AddInjectedGamepad("Test Gamepad 2")
+	n.NotifyForTesting()
+	ebiten.RemoveInjectedGamepad(id)
+	n.NotifyForTesting()
+	if len(connected) != 1 || len(disconnected) != 1 {
+		t.Errorf("len(connected), len(disconnected) without handlers: got: %d, %d, want: 1, 1", len(connected), len(disconnected))
+	}
+}
@@ -0,0 +1,271 @@
+// Copyright 2026 The Ebitengine Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ebiten
+
+import (
+	"fmt"
+	"math"
+	"sync"
+	"sync/atomic"
+
+	"github.com/duplicants-ai/ebiten/internal/gamepad"
+)
+
+// GamepadDeadZoneShape represents the shape of a dead zone of a gamepad stick.
+type GamepadDeadZoneShape int
+
+const (
+	// GamepadDeadZoneShapeRadial applies a dead zone to the length of the stick vector.
+	// This keeps the direction of the stick, and is suitable for movement.
+	GamepadDeadZoneShapeRadial GamepadDeadZoneShape = iota
+
+	// GamepadDeadZoneShapeAxial applies a dead zone to each axis independently.
+	// This makes it easy to keep a stick straight along an axis, but diagonal directions snap to the axes near the center.
+	GamepadDeadZoneShapeAxial
+)
+
+// GamepadAxisFilter represents dead zones and a response curve for a gamepad stick.
+//
+// The zero value is a filter that doesn't change the values.
+type GamepadAxisFilter struct {
+	// DeadZoneShape is the shape of the dead zones.
+	DeadZoneShape GamepadDeadZoneShape
+
+	// InnerDeadZone is the size of the dead zone around the center in [0, 1).
+	// The stick values within the inner dead zone are treated as 0.
+	InnerDeadZone float64
+
+	// OuterDeadZone is the size of the dead zone around the edge in [0, 1).
+	// The stick values within the outer dead zone are treated as the maximum.
+	//
+	// InnerDeadZone + OuterDeadZone must be less than 1.
+	OuterDeadZone float64
+
+	// ResponseExponent is the exponent of the response curve applied after the dead zones.
+	// 1 is linear, and more than 1 makes small movements more precise.
+	// If ResponseExponent is 0, 1 is used.
+	ResponseExponent float64
+}
+
+func (f *GamepadAxisFilter) validate() {
+	if f.InnerDeadZone < 0 || f.InnerDeadZone >= 1 {
+		panic(fmt.Sprintf("ebiten: GamepadAxisFilter.InnerDeadZone must be in [0, 1) but %f", f.InnerDeadZone))
+	}
+	if f.OuterDeadZone < 0 || f.OuterDeadZone >= 1 {
+		panic(fmt.Sprintf("ebiten: GamepadAxisFilter.OuterDeadZone must be in [0, 1) but %f", f.OuterDeadZone))
+	}
+	if f.InnerDeadZone+f.OuterDeadZone >= 1 {
+		panic(fmt.Sprintf("ebiten: GamepadAxisFilter.InnerDeadZone + GamepadAxisFilter.OuterDeadZone must be less than 1 but %f", f.InnerDeadZone+f.OuterDeadZone))
+	}
+	if f.ResponseExponent < 0 {
+		panic(fmt.Sprintf("ebiten: GamepadAxisFilter.ResponseExponent must be non-negative but %f", f.ResponseExponent))
+	}
+}
+
+// mapMagnitude maps a magnitude in [0, 1] with the dead zones and the response curve.
+func (f *GamepadAxisFilter) mapMagnitude(m float64) float64 {
+	if m <= f.InnerDeadZone {
+		return 0
+	}
+	v := min((m-f.InnerDeadZone)/(1-f.InnerDeadZone-f.OuterDeadZone), 1)
+	if e := f.ResponseExponent; e != 0 && e != 1 {
+		v = math.Pow(v, e)
+	}
+	return v
+}
+
+// Apply applies the filter to the stick values (x, y) in [-1, 1], and returns the filtered values in [-1, 1].
+//
+// Apply is useful to filter the values of GamepadAxisValue, which the engine doesn't filter.
+func (f *GamepadAxisFilter) Apply(x, y float64) (float64, float64) {
+	switch f.DeadZoneShape {
+	case GamepadDeadZoneShapeRadial:
+		m := math.Hypot(x, y)
+		if m == 0 {
+			return 0, 0
+		}
+		s := f.mapMagnitude(min(m, 1)) / m
+		return max(-1, min(x*s, 1)), max(-1, min(y*s, 1))
+	case GamepadDeadZoneShapeAxial:
+		return math.Copysign(f.mapMagnitude(min(math.Abs(x), 1)), x), math.Copysign(f.mapMagnitude(min(math.Abs(y), 1)), y)
+	default:
+		panic(fmt.Sprintf("ebiten: invalid GamepadDeadZoneShape: %d", f.DeadZoneShape))
+	}
+}
+
+var (
+	theDefaultGamepadAxisFilter atomic.Pointer[GamepadAxisFilter]
+
+	theGamepadAxisFilters  map[GamepadID]*GamepadAxisFilter
+	theGamepadAxisFiltersM sync.Mutex
+)
+
+// SetGamepadAxisFilter sets the filter for the sticks of all the gamepads.
+// If filter is nil, the sticks are not filtered. By default, the sticks are not filtered.
+//
+// The filter is applied to the values of StandardGamepadAxisValue and IsStandardGamepadStickPushed.
+// The values of GamepadAxisValue are not filtered, as the axes of a stick are unknown without a standard layout mapping.
+// Use GamepadAxisFilter.Apply to filter them.
+//
+// SetGamepadAxisFilter panics if filter has invalid values.
+//
+// SetGamepadAxisFilter is concurrent-safe.
+func SetGamepadAxisFilter(filter *GamepadAxisFilter) {
+	if filter == nil {
+		theDefaultGamepadAxisFilter.Store(nil)
+		return
+	}
+	filter.validate()
+	f := *filter
+	theDefaultGamepadAxisFilter.Store(&f)
+}
+
+// SetGamepadAxisFilterForGamepad sets the filter for the sticks of the gamepad (id).
+// This overrides the filter set by SetGamepadAxisFilter.
+// If filter is nil, the override is removed.
+//
+// The override is removed when the gamepad is disconnected.
+//
+// SetGamepadAxisFilterForGamepad panics if filter has invalid values.
+//
+// SetGamepadAxisFilterForGamepad is concurrent-safe.
+func SetGamepadAxisFilterForGamepad(id GamepadID, filter *GamepadAxisFilter) {
+	theGamepadAxisFiltersM.Lock()
+	defer theGamepadAxisFiltersM.Unlock()
+
+	if filter == nil {
+		delete(theGamepadAxisFilters, id)
+		return
+	}
+	filter.validate()
+	f := *filter
+	if theGamepadAxisFilters == nil {
+		theGamepadAxisFilters = map[GamepadID]*GamepadAxisFilter{}
+	}
+	theGamepadAxisFilters[id] = &f
+}
+
+func gamepadAxisFilter(id GamepadID) *GamepadAxisFilter {
+	theGamepadAxisFiltersM.Lock()
+	f, ok := theGamepadAxisFilters[id]
+	theGamepadAxisFiltersM.Unlock()
+	if ok {
+		return f
+	}
+	return theDefaultGamepadAxisFilter.Load()
+}
+
+func removeGamepadAxisFilter(id GamepadID) {
+	theGamepadAxisFiltersM.Lock()
+	defer theGamepadAxisFiltersM.Unlock()
+	delete(theGamepadAxisFilters, id)
+}
+
+// StandardGamepadStick represents a stick in the standard layout.
+type StandardGamepadStick int
+
+const (
+	StandardGamepadStickLeft StandardGamepadStick = iota
+	StandardGamepadStickRight
+	StandardGamepadStickMax = StandardGamepadStickRight
+)
+
+func (s StandardGamepadStick) axes() (StandardGamepadAxis, StandardGamepadAxis) {
+	switch s {
+	case StandardGamepadStickLeft:
+		return StandardGamepadAxisLeftStickHorizontal, StandardGamepadAxisLeftStickVertical
+	case StandardGamepadStickRight:
+		return StandardGamepadAxisRightStickHorizontal, StandardGamepadAxisRightStickVertical
+	default:
+		panic(fmt.Sprintf("ebiten: invalid StandardGamepadStick: %d", s))
+	}
+}
+
+// standardStickValue returns the filtered values of the stick.
+func standardStickValue(id GamepadID, g *gamepad.Gamepad, stick StandardGamepadStick) (float64, float64) {
+	h, v := stick.axes()
+	x, y := g.StandardAxisValue(h), g.StandardAxisValue(v)
+	if f := gamepadAxisFilter(id); f != nil {
+		return f.Apply(x, y)
+	}
+	return x, y
+}
+
+// standardAxisValue returns the filtered value of the axis.
+func standardAxisValue(id GamepadID, g *gamepad.Gamepad, axis StandardGamepadAxis) float64 {
+	var stick StandardGamepadStick
+	switch axis {
+	case StandardGamepadAxisLeftStickHorizontal, StandardGamepadAxisLeftStickVertical:
+		stick = StandardGamepadStickLeft
+	case StandardGamepadAxisRightStickHorizontal, StandardGamepadAxisRightStickVertical:
+		stick = StandardGamepadStickRight
+	default:
+		return g.StandardAxisValue(axis)
+	}
+	x, y := standardStickValue(id, g, stick)
+	if axis == StandardGamepadAxisLeftStickHorizontal || axis == StandardGamepadAxisRightStickHorizontal {
+		return x
+	}
+	return y
+}
+
+// GamepadStickDirection represents a digital direction of a gamepad stick.
+type GamepadStickDirection int
+
+const (
+	GamepadStickDirectionUp GamepadStickDirection = iota
+	GamepadStickDirectionDown
+	GamepadStickDirectionLeft
+	GamepadStickDirectionRight
+	GamepadStickDirectionMax = GamepadStickDirectionRight
+)
+
+// defaultStickPushedThreshold is the default threshold for IsStandardGamepadStickPushed.
+const defaultStickPushedThreshold = 0.5
+
+// IsStandardGamepadStickPushed reports whether the given gamepad (id)'s standard stick (stick) is pushed toward the direction.
+//
+// The stick is pushed when the filtered value along the direction is more than threshold.
+// If threshold is 0, 0.5 is used.
+// A stick pushed diagonally can be pushed toward two directions at the same time.
+//
+// IsStandardGamepadStickPushed is useful to navigate menus with sticks like a d-pad.
+// Use inpututil.IsStandardGamepadStickJustPushed to detect the moment when the stick starts being pushed.
+//
+// IsStandardGamepadStickPushed returns false when the gamepad doesn't have a standard gamepad layout mapping.
+//
+// IsStandardGamepadStickPushed is concurrent-safe.
+func IsStandardGamepadStickPushed(id GamepadID, stick StandardGamepadStick, direction GamepadStickDirection, threshold float64) bool {
+	g := gamepad.Get(id)
+	if g == nil {
+		return false
+	}
+	if threshold == 0 {
+		threshold = defaultStickPushedThreshold
+	}
+	x, y := standardStickValue(id, g, stick)
+	switch direction {
+	case GamepadStickDirectionUp:
+		return -y > threshold
+	case GamepadStickDirectionDown:
+		return y > threshold
+	case GamepadStickDirectionLeft:
+		return -x > threshold
+	case GamepadStickDirectionRight:
+		return x > threshold
+	default:
+		panic(fmt.Sprintf("ebiten: invalid GamepadStickDirection: %d", direction))
+	}
+}
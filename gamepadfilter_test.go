@@ -0,0 +1,102 @@
+// Copyright 2026 The Ebitengine Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ebiten_test
+
+import (
+	"math"
+	"testing"
+
+	"github.com/duplicants-ai/ebiten"
+)
+
+func TestGamepadAxisFilterApply(t *testing.T) {
+	testCases := []struct {
+		Name   string
+		Filter ebiten.GamepadAxisFilter
+		X      float64
+		Y      float64
+		WantX  float64
+		WantY  float64
+	}{
+		{
+			Name:  "zero value",
+			X:     0.3,
+			Y:     -0.4,
+			WantX: 0.3,
+			WantY: -0.4,
+		},
+		{
+			Name: "radial, inside the inner dead zone",
+			Filter: ebiten.GamepadAxisFilter{
+				InnerDeadZone: 0.2,
+			},
+			X:     0.1,
+			Y:     0.1,
+			WantX: 0,
+			WantY: 0,
+		},
+		{
+			Name: "radial, rescaled",
+			Filter: ebiten.GamepadAxisFilter{
+				InnerDeadZone: 0.2,
+				OuterDeadZone: 0.2,
+			},
+			X:     0.6,
+			Y:     0.8,
+			WantX: 0.6,
+			WantY: 0.8,
+		},
+		{
+			Name: "radial, inside the outer dead zone",
+			Filter: ebiten.GamepadAxisFilter{
+				OuterDeadZone: 0.2,
+			},
+			X:     0,
+			Y:     -0.9,
+			WantX: 0,
+			WantY: -1,
+		},
+		{
+			Name: "axial",
+			Filter: ebiten.GamepadAxisFilter{
+				DeadZoneShape: ebiten.GamepadDeadZoneShapeAxial,
+				InnerDeadZone: 0.5,
+			},
+			X:     0.4,
+			Y:     -0.75,
+			WantX: 0,
+			WantY: -0.5,
+		},
+		{
+			Name: "response curve",
+			Filter: ebiten.GamepadAxisFilter{
+				DeadZoneShape:    ebiten.GamepadDeadZoneShapeAxial,
+				ResponseExponent: 2,
+			},
+			X:     0.5,
+			Y:     -0.5,
+			WantX: 0.25,
+			WantY: -0.25,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.Name, func(t *testing.T) {
+			x, y := tc.Filter.Apply(tc.X, tc.Y)
+			if math.Abs(x-tc.WantX) > 1e-9 || math.Abs(y-tc.WantY) > 1e-9 {
+				t.Errorf("got: (%f, %f), want: (%f, %f)", x, y, tc.WantX, tc.WantY)
+			}
+		})
+	}
+}
@@ -807,7 +807,7 @@ func main() {
 		case filepath.Join("internal", "ui", "keys_mobile.go"):
 			buildConstraints = "//go:build android || ios"
 		case filepath.Join("internal", "ui", "keys_glfw.go"):
-			buildConstraints = "//go:build !android && !ios && !js && !nintendosdk && !playstation5"
+			buildConstraints = "//go:build !android && !ios && !js && !nintendosdk && !playstation5 && !wasip1"
 		}
 		// NOTE: According to godoc, maps are automatically sorted by key.
 		w := bufio.NewWriter(f)
@@ -14,6 +14,7 @@ require (
 	github.com/jakecoffman/cp/v2 v2.1.0
 	github.com/jezek/xgb v1.1.1
 	github.com/jfreymuth/oggvorbis v1.0.5
+	github.com/jfreymuth/vorbis v1.0.2
 	github.com/kisielk/errcheck v1.8.0
 	golang.org/x/image v0.24.0
 	golang.org/x/sync v0.11.0
@@ -23,7 +24,6 @@ require (
 )
 
 require (
-	github.com/jfreymuth/vorbis v1.0.2 // indirect
 	github.com/pierrec/lz4/v4 v4.1.21 // indirect
 	golang.org/x/mod v0.22.0 // indirect
 )
@@ -0,0 +1,159 @@
+// Copyright 2026 The Ebitengine Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ebiten
+
+import (
+	"fmt"
+	"image"
+	"image/color"
+	"sort"
+
+	"github.com/duplicants-ai/ebiten/internal/builtinshader"
+	"github.com/duplicants-ai/ebiten/internal/graphics"
+	"github.com/duplicants-ai/ebiten/internal/graphicsdriver"
+	"github.com/duplicants-ai/ebiten/internal/restorable"
+	"github.com/duplicants-ai/ebiten/internal/ui"
+)
+
+// ColorStop represents a color at a position of a gradient.
+type ColorStop struct {
+	// Offset is the position of the color in the gradient in [0, 1].
+	Offset float64
+
+	// Color is the color at the offset.
+	Color color.Color
+}
+
+// FillLinearGradient fills the image with a linear gradient from (x0, y0) to (x1, y1).
+//
+// The positions are in the image's coordinate, i.e., the same coordinate as Set and At.
+// The color at a pixel is determined by the projection of the pixel center onto the line from (x0, y0) to (x1, y1).
+// The pixels before the first color stop and after the last color stop are filled with the first and the last color respectively.
+// If (x0, y0) and (x1, y1) are the same, the image is filled with the last color.
+//
+// The gradient is rendered on GPU. The colors are interpolated as premultiplied-alpha colors.
+//
+// The color stops are sorted by their offsets. Stops at the same offset make a sharp edge.
+// The number of the color stops must be in [1, 16]. Otherwise, FillLinearGradient panics.
+//
+// Like Fill, FillLinearGradient replaces the pixels of the image, and works on a sub-image.
+//
+// When the image is disposed, FillLinearGradient does nothing.
+func (i *Image) FillLinearGradient(x0, y0, x1, y1 float64, stops []ColorStop) {
+	i.copyCheck()
+	if i.isDisposed() {
+		return
+	}
+
+	offsets, colors := gradientColorStops(stops)
+	if x0 == x1 && y0 == y1 {
+		i.fillColorElements(colors[4*(len(stops)-1):])
+		return
+	}
+	i.fillGradient(map[string]any{
+		builtinshader.UniformGradientType:      builtinshader.GradientTypeLinear,
+		builtinshader.UniformGradientStart:     []float32{float32(x0), float32(y0)},
+		builtinshader.UniformGradientEnd:       []float32{float32(x1), float32(y1)},
+		builtinshader.UniformGradientStopCount: len(stops),
+		builtinshader.UniformGradientOffsets:   offsets[:],
+		builtinshader.UniformGradientColors:    colors[:],
+	})
+}
+
+// FillRadialGradient fills the image with a radial gradient around (cx, cy) with the radius r.
+//
+// The positions are in the image's coordinate, i.e., the same coordinate as Set and At.
+// The color at a pixel is determined by the distance between the pixel center and (cx, cy) divided by r.
+// The pixels beyond the radius are filled with the last color.
+// If r is 0, the image is filled with the last color.
+//
+// The gradient is rendered on GPU. The colors are interpolated as premultiplied-alpha colors.
+//
+// The color stops are sorted by their offsets. Stops at the same offset make a sharp edge.
+// The number of the color stops must be in [1, 16]. Otherwise, FillRadialGradient panics.
+// If r is negative, FillRadialGradient panics.
+//
+// Like Fill, FillRadialGradient replaces the pixels of the image, and works on a sub-image.
+//
+// When the image is disposed, FillRadialGradient does nothing.
+func (i *Image) FillRadialGradient(cx, cy, r float64, stops []ColorStop) {
+	i.copyCheck()
+	if r < 0 {
+		panic(fmt.Sprintf("ebiten: r must be non-negative but %f", r))
+	}
+	if i.isDisposed() {
+		return
+	}
+
+	offsets, colors := gradientColorStops(stops)
+	if r == 0 {
+		i.fillColorElements(colors[4*(len(stops)-1):])
+		return
+	}
+	i.fillGradient(map[string]any{
+		builtinshader.UniformGradientType:      builtinshader.GradientTypeRadial,
+		builtinshader.UniformGradientStart:     []float32{float32(cx), float32(cy)},
+		builtinshader.UniformGradientRadius:    float32(r),
+		builtinshader.UniformGradientStopCount: len(stops),
+		builtinshader.UniformGradientOffsets:   offsets[:],
+		builtinshader.UniformGradientColors:    colors[:],
+	})
+}
+
+// gradientColorStops returns the sorted offsets and the premultiplied-alpha colors of the color stops.
+func gradientColorStops(stops []ColorStop) ([builtinshader.MaxGradientColorStops]float32, [4 * builtinshader.MaxGradientColorStops]float32) {
+	if len(stops) == 0 || len(stops) > builtinshader.MaxGradientColorStops {
+		panic(fmt.Sprintf("ebiten: the number of the color stops must be in [1, %d] but %d", builtinshader.MaxGradientColorStops, len(stops)))
+	}
+
+	sorted := make([]ColorStop, len(stops))
+	copy(sorted, stops)
+	sort.SliceStable(sorted, func(a, b int) bool {
+		return sorted[a].Offset < sorted[b].Offset
+	})
+
+	var offsets [builtinshader.MaxGradientColorStops]float32
+	var colors [4 * builtinshader.MaxGradientColorStops]float32
+	for idx, s := range sorted {
+		offsets[idx] = float32(s.Offset)
+		r, g, b, a := s.Color.RGBA()
+		colors[4*idx] = float32(r) / 0xffff
+		colors[4*idx+1] = float32(g) / 0xffff
+		colors[4*idx+2] = float32(b) / 0xffff
+		colors[4*idx+3] = float32(a) / 0xffff
+	}
+	return offsets, colors
+}
+
+func (i *Image) fillColorElements(clr []float32) {
+	i.image.Fill(clr[0], clr[1], clr[2], clr[3], i.adjustedBounds())
+}
+
+// fillGradient fills the image with the gradient shader and the given uniforms.
+func (i *Image) fillGradient(uniforms map[string]any) {
+	shader := gradientShader()
+
+	// The source positions are in the image's coordinate so that the shader can calculate the gradient with them.
+	b := i.Bounds()
+	dr := i.adjustedBounds()
+	vs := i.ensureTmpVertices(4 * graphics.VertexFloatCount)
+	graphics.QuadVerticesFromDstAndSrc(vs, float32(dr.Min.X), float32(dr.Min.Y), float32(dr.Max.X), float32(dr.Max.Y), float32(b.Min.X), float32(b.Min.Y), float32(b.Max.X), float32(b.Max.Y), 1, 1, 1, 1)
+	is := graphics.QuadIndices()
+
+	i.tmpUniforms = i.tmpUniforms[:0]
+	i.tmpUniforms = shader.appendUniforms(i.tmpUniforms, uniforms)
+
+	i.image.DrawTriangles([graphics.ShaderSrcImageCount]*ui.Image{}, vs, is, graphicsdriver.BlendCopy, dr, [graphics.ShaderSrcImageCount]image.Rectangle{}, shader.shader, i.tmpUniforms, graphicsdriver.FillRuleFillAll, true, false, false, restorable.HintOverwriteDstRegion)
+}
@@ -16,6 +16,8 @@ package ebiten
 
 import (
 	"github.com/duplicants-ai/ebiten/internal/builtinshader"
+	"github.com/duplicants-ai/ebiten/internal/graphics"
+	"github.com/duplicants-ai/ebiten/internal/graphicscommand"
 	"github.com/duplicants-ai/ebiten/internal/ui"
 )
 
@@ -90,3 +92,44 @@ const (
 	// ColorSpaceDisplayP3 represents the Display P3 color space (https://en.wikipedia.org/wiki/DCI-P3).
 	ColorSpaceDisplayP3
 )
+
+// ScreenColorSpace returns the actual color space of the screen.
+// The pixels of images, e.g. the results of (*Image).ReadPixels, are in this color space.
+//
+// The result depends on RunGameOptions.ColorSpace and the environment.
+// For example, the screen is in Display P3 with Metal on macOS by default.
+// ScreenColorSpace returns ColorSpaceDefault before the game starts or if the color space is unknown.
+// In the latter case, the color space is usually sRGB.
+//
+// ScreenColorSpace is concurrent-safe.
+func ScreenColorSpace() ColorSpace {
+	return ColorSpace(ui.Get().ScreenColorSpace())
+}
+
+// ConvertPixelsToSRGB converts premultiplied-alpha RGBA pixels in the color space to sRGB in place.
+// Colors out of the sRGB gamut are clipped.
+//
+// ConvertPixelsToSRGB is useful to save the pixels read by (*Image).ReadPixels as an sRGB image file:
+//
+//	img.ReadPixels(pixels)
+//	ebiten.ConvertPixelsToSRGB(pixels, ebiten.ScreenColorSpace())
+//
+// If colorSpace is ColorSpaceDefault or ColorSpaceSRGB, ConvertPixelsToSRGB does nothing.
+func ConvertPixelsToSRGB(pixels []byte, colorSpace ColorSpace) {
+	if colorSpace == ColorSpaceDisplayP3 {
+		graphics.ConvertDisplayP3ToSRGB(pixels)
+	}
+}
+
+// SetScreenshotConvertedToSRGB sets whether screenshots are converted to sRGB.
+//
+// Screenshots taken by EBITENGINE_SCREENSHOT_KEY are tagged with the color space metadata of ScreenColorSpace.
+// If SetScreenshotConvertedToSRGB is true and the screen is not in sRGB, the screenshots are converted to sRGB and tagged as sRGB.
+// This is useful for image viewers and services that ignore the color space metadata.
+//
+// The default value is false.
+//
+// SetScreenshotConvertedToSRGB is concurrent-safe.
+func SetScreenshotConvertedToSRGB(convert bool) {
+	graphicscommand.SetDumpConvertedToSRGB(convert)
+}
@@ -19,6 +19,7 @@ import (
 	"image"
 	"image/color"
 	"math"
+	"slices"
 	"unsafe"
 
 	"github.com/duplicants-ai/ebiten/internal/affine"
@@ -52,6 +53,12 @@ type Image struct {
 	// tmpUniforms must not be reused until ui.Image.Draw* is called.
 	tmpUniforms []uint32
 
+	// tmpPixels must not be reused until ui.Image.WritePixels is called.
+	tmpPixels []byte
+
+	// clampGutter reports whether the image has a gutter filled with its edge pixels.
+	clampGutter bool
+
 	// Do not add a 'buffering' member that are resolved lazily.
 	// This tends to forget resolving the buffer easily (#2362).
 }
@@ -155,6 +162,37 @@ type DrawImageOptions struct {
 	//
 	// The default (zero) value is false.
 	DisableMipmaps bool
+
+	// Trilinear specifies whether the two nearest mipmap levels are blended.
+	// Without Trilinear, only one mipmap level is selected for each draw call,
+	// which might cause shimmering or sudden quality changes when the scale changes continuously.
+	// With Trilinear, the levels are blended based on the scale per pixel, which makes the result smoother.
+	// Trilinear is a little more expensive than the regular mipmaps.
+	//
+	// Trilinear is used only when Filter is FilterLinear and mipmaps are used.
+	//
+	// The default (zero) value is false.
+	Trilinear bool
+
+	// Mask is a mask image.
+	// If Mask is not nil, the color of each rendered pixel is multiplied by the mask value at the same position on the source image.
+	// Mask is placed so that its upper-left corner matches the source image's upper-left corner.
+	// Outside of Mask, the mask value is 0 and nothing is rendered.
+	// Mask is sampled with Filter.
+	//
+	// Mask is useful to render an image in a shaped frame, or to dissolve an image with a noise texture,
+	// without multiple draw calls with blend modes or a custom shader.
+	//
+	// When Mask is not nil, mipmaps are not used.
+	//
+	// The default (zero) value is nil.
+	Mask *Image
+
+	// MaskRule is the rule to convert the pixels of Mask into the mask values.
+	// MaskRule is used only when Mask is not nil.
+	//
+	// The default (zero) value is MaskRuleAlpha.
+	MaskRule MaskRule
 }
 
 // adjustPosition converts the position in the *ebiten.Image coordinate to the *ui.Image coordinate.
@@ -187,6 +225,14 @@ func (i *Image) adjustPositionF32(x, y float32) (float32, float32) {
 	return x, y
 }
 
+// originalBounds returns the bounds of the original image if i is a sub-image, or i's bounds otherwise.
+func (i *Image) originalBounds() image.Rectangle {
+	if i.isSubImage() {
+		return i.original.Bounds()
+	}
+	return i.Bounds()
+}
+
 func (i *Image) adjustedBounds() image.Rectangle {
 	b := i.Bounds()
 	x, y := i.adjustPosition(b.Min.X, b.Min.Y)
@@ -239,6 +285,9 @@ func (i *Image) DrawImage(img *Image, options *DrawImageOptions) {
 	if options == nil {
 		options = &DrawImageOptions{}
 	}
+	if options.Mask != nil && options.Mask.isDisposed() {
+		panic("ebiten: the given mask image to DrawImage must not be disposed")
+	}
 
 	var blend graphicsdriver.Blend
 	if options.CompositeMode == CompositeModeCustom {
@@ -268,9 +317,28 @@ func (i *Image) DrawImage(img *Image, options *DrawImageOptions) {
 	is := graphics.QuadIndices()
 
 	srcs := [graphics.ShaderSrcImageCount]*ui.Image{img.image}
+	srcRegions := [graphics.ShaderSrcImageCount]image.Rectangle{img.adjustedBounds()}
 
 	useColorM := !colorm.IsIdentity()
-	shader := builtinShader(filter, builtinshader.AddressUnsafe, useColorM)
+
+	// Mipmaps are not used with a mask, as mipmaps are only for the first source image.
+	skipMipmap := options.DisableMipmaps || options.Mask != nil
+	if !skipMipmap {
+		skipMipmap = canSkipMipmap(det, filter)
+	}
+	trilinear := options.Trilinear && !skipMipmap
+
+	var shader *Shader
+	switch {
+	case options.Mask != nil:
+		srcs[1] = options.Mask.image
+		srcRegions[1] = options.Mask.adjustedBounds()
+		shader = maskShader(filter, builtinshader.AddressUnsafe, useColorM, options.MaskRule.internalMask())
+	case trilinear:
+		shader = trilinearShader(useColorM)
+	default:
+		shader = builtinShader(filter, builtinshader.AddressUnsafe, useColorM)
+	}
 	i.tmpUniforms = i.tmpUniforms[:0]
 	if useColorM {
 		var body [16]float32
@@ -288,11 +356,7 @@ func (i *Image) DrawImage(img *Image, options *DrawImageOptions) {
 		hint = restorable.HintOverwriteDstRegion
 	}
 
-	skipMipmap := options.DisableMipmaps
-	if !skipMipmap {
-		skipMipmap = canSkipMipmap(det, filter)
-	}
-	i.image.DrawTriangles(srcs, vs, is, blend, dr, [graphics.ShaderSrcImageCount]image.Rectangle{img.adjustedBounds()}, shader.shader, i.tmpUniforms, graphicsdriver.FillRuleFillAll, skipMipmap, false, hint)
+	i.image.DrawTriangles(srcs, vs, is, blend, dr, srcRegions, shader.shader, i.tmpUniforms, graphicsdriver.FillRuleFillAll, skipMipmap, trilinear, false, hint)
 }
 
 // overwritesDstRegion reports whether the given parameters overwrite the destination region completely.
@@ -352,8 +416,12 @@ type Vertex struct {
 	Custom3 float32
 }
 
+// The extra vertex attributes are not a part of Vertex, and are given by DrawTrianglesShaderOptions.VertexAttributes.
 var _ [0]byte = [unsafe.Sizeof(Vertex{}) - unsafe.Sizeof(float32(0))*graphics.VertexFloatCount]byte{}
 
+// VertexAttributeCount is the number of the extra attribute values per vertex for DrawTrianglesShaderOptions.VertexAttributes.
+const VertexAttributeCount = graphics.VertexAttributesFloatCount
+
 // Address represents a sampler address mode.
 type Address int
 
@@ -416,6 +484,29 @@ const (
 	ColorScaleModePremultipliedAlpha
 )
 
+// MaskRule is the rule to convert the pixels of a mask image into the mask values.
+type MaskRule int
+
+const (
+	// MaskRuleAlpha indicates the mask value is the alpha of the mask image.
+	MaskRuleAlpha MaskRule = iota
+
+	// MaskRuleLuminance indicates the mask value is the luminance of the mask image.
+	// As the colors are premultiplied, transparent pixels are treated as black.
+	MaskRuleLuminance
+)
+
+func (m MaskRule) internalMask() builtinshader.Mask {
+	switch m {
+	case MaskRuleAlpha:
+		return builtinshader.MaskAlpha
+	case MaskRuleLuminance:
+		return builtinshader.MaskLuminance
+	default:
+		panic(fmt.Sprintf("ebiten: invalid MaskRule: %d", m))
+	}
+}
+
 // DrawTrianglesOptions represents options for DrawTriangles.
 type DrawTrianglesOptions struct {
 	// ColorM is a color matrix to draw.
@@ -476,6 +567,37 @@ type DrawTrianglesOptions struct {
 	//
 	// The default (zero) value is false.
 	DisableMipmaps bool
+
+	// Trilinear specifies whether the two nearest mipmap levels are blended.
+	// Without Trilinear, only one mipmap level is selected for each draw call,
+	// which might cause shimmering or sudden quality changes when the scale changes continuously.
+	// With Trilinear, the levels are blended based on the scale per pixel, which makes the result smoother.
+	// Trilinear is a little more expensive than the regular mipmaps.
+	//
+	// Trilinear is used only when Filter is FilterLinear and mipmaps are used.
+	//
+	// The default (zero) value is false.
+	Trilinear bool
+
+	// Mask is a mask image.
+	// If Mask is not nil, the color of each rendered pixel is multiplied by the mask value at the same position on the source image.
+	// Mask is placed so that its upper-left corner matches the source image's upper-left corner.
+	// Outside of Mask, the mask value is 0 and nothing is rendered.
+	// Mask is sampled with Filter.
+	//
+	// Mask is useful to render an image in a shaped frame, or to dissolve an image with a noise texture,
+	// without multiple draw calls with blend modes or a custom shader.
+	//
+	// When Mask is not nil, mipmaps are not used.
+	//
+	// The default (zero) value is nil.
+	Mask *Image
+
+	// MaskRule is the rule to convert the pixels of Mask into the mask values.
+	// MaskRule is used only when Mask is not nil.
+	//
+	// The default (zero) value is MaskRuleAlpha.
+	MaskRule MaskRule
 }
 
 // MaxIndicesCount is the maximum number of indices for DrawTriangles and DrawTrianglesShader.
@@ -493,9 +615,16 @@ const MaxIndicesNum = MaxIndicesCount
 // Deprecated: as of v2.7. Use MaxVertexCount instead.
 const MaxVerticesCount = graphicscommand.MaxVertexCount
 
-// MaxVertexCount is the maximum number of vertices for DrawTriangles and DrawTrianglesShader.
+// MaxVertexCount is the maximum number of vertices for one draw call.
+//
+// If DrawTriangles or DrawTrianglesShader is called with more vertices, the triangles are split into multiple draw calls.
 const MaxVertexCount = graphicscommand.MaxVertexCount
 
+// MaxIndexCount is the maximum number of indices for one draw call.
+//
+// If DrawTriangles or DrawTrianglesShader is called with more indices, the triangles are split into multiple draw calls.
+const MaxIndexCount = graphicscommand.MaxIndexCount
+
 // DrawTriangles draws triangles with the specified vertices and their indices.
 //
 // img is used as a source image. img cannot be nil.
@@ -506,11 +635,14 @@ const MaxVertexCount = graphicscommand.MaxVertexCount
 // Vertex contains color values, which are interpreted as straight-alpha colors by default.
 // This depends on the option's ColorScaleMode.
 //
-// If len(vertices) is more than MaxVertexCount, the exceeding part is ignored.
+// If len(vertices) is more than MaxVertexCount or len(indices) is more than MaxIndexCount,
+// the triangles are split into multiple draw calls in the original order.
+// In this case, if options.FillRule is not FillRuleFillAll or options.AntiAlias is true, DrawTriangles panics,
+// as the fill rule and the anti-alias need all the triangles in one draw call.
 //
 // If len(indices) is not multiple of 3, DrawTriangles panics.
 //
-// If a value in indices is out of range of vertices, or not less than MaxVertexCount, DrawTriangles panics.
+// If a value in indices is out of range of vertices, DrawTriangles panics.
 //
 // The rule in which DrawTriangles works effectively is same as DrawImage's.
 //
@@ -536,11 +668,14 @@ func (i *Image) DrawTriangles(vertices []Vertex, indices []uint16, img *Image, o
 // Vertex contains color values, which are interpreted as straight-alpha colors by default.
 // This depends on the option's ColorScaleMode.
 //
-// If len(vertices) is more than MaxVertexCount, the exceeding part is ignored.
+// If len(vertices) is more than MaxVertexCount or len(indices) is more than MaxIndexCount,
+// the triangles are split into multiple draw calls in the original order.
+// In this case, if options.FillRule is not FillRuleFillAll or options.AntiAlias is true, DrawTriangles32 panics,
+// as the fill rule and the anti-alias need all the triangles in one draw call.
 //
 // If len(indices) is not multiple of 3, DrawTriangles32 panics.
 //
-// If a value in indices is out of range of vertices, or not less than MaxVertexCount, DrawTriangles32 panics.
+// If a value in indices is out of range of vertices, DrawTriangles32 panics.
 //
 // The rule in which DrawTriangles32 works effectively is same as DrawImage's.
 //
@@ -553,14 +688,13 @@ func (i *Image) DrawTriangles32(vertices []Vertex, indices []uint32, img *Image,
 	if img != nil && img.isDisposed() {
 		panic("ebiten: the given image to DrawTriangles must not be disposed")
 	}
+	if options != nil && options.Mask != nil && options.Mask.isDisposed() {
+		panic("ebiten: the given mask image to DrawTriangles must not be disposed")
+	}
 	if i.isDisposed() {
 		return
 	}
 
-	if len(vertices) > graphicscommand.MaxVertexCount {
-		// The last part cannot be specified by indices. Just omit them.
-		vertices = vertices[:graphicscommand.MaxVertexCount]
-	}
 	if len(indices)%3 != 0 {
 		panic("ebiten: len(indices) % 3 must be 0")
 	}
@@ -570,6 +704,17 @@ func (i *Image) DrawTriangles32(vertices []Vertex, indices []uint32, img *Image,
 		}
 	}
 
+	if len(vertices) > graphicscommand.MaxVertexCount || len(indices) > graphicscommand.MaxIndexCount {
+		if options != nil {
+			panicIfNotSplittable(len(vertices), len(indices), options.FillRule, options.AntiAlias)
+		}
+		// Split the triangles into multiple draw calls, preserving the order.
+		graphics.SplitTriangles(len(vertices), indices, graphicscommand.MaxVertexCount, graphicscommand.MaxIndexCount, func(vertexIndices []uint32, indices []uint32) {
+			i.DrawTriangles32(verticesAt(vertices, vertexIndices), indices, img, options)
+		})
+		return
+	}
+
 	if options == nil {
 		options = &DrawTrianglesOptions{}
 	}
@@ -618,10 +763,25 @@ func (i *Image) DrawTriangles32(vertices []Vertex, indices []uint32, img *Image,
 		}
 	}
 
+	i.drawTrianglesWithBuiltinShader(vs, indices, img, blend, filter, address, colorm, options.FillRule, options.DisableMipmaps, options.AntiAlias, options.Mask, options.MaskRule)
+}
+
+// drawTrianglesWithBuiltinShader draws triangles with the vertices in the internal format and a builtin shader.
+//
+// If mask is not nil, the mask is applied with maskRule.
+func (i *Image) drawTrianglesWithBuiltinShader(vs []float32, indices []uint32, img *Image, blend graphicsdriver.Blend, filter builtinshader.Filter, address builtinshader.Address, colorm affine.ColorM, fillRule FillRule, disableMipmaps bool, antiAlias bool, mask *Image, maskRule MaskRule) {
 	srcs := [graphics.ShaderSrcImageCount]*ui.Image{img.image}
+	srcRegions := [graphics.ShaderSrcImageCount]image.Rectangle{img.adjustedBounds()}
 
 	useColorM := !colorm.IsIdentity()
-	shader := builtinShader(filter, address, useColorM)
+	var shader *Shader
+	if mask != nil {
+		srcs[1] = mask.image
+		srcRegions[1] = mask.adjustedBounds()
+		shader = maskShader(filter, address, useColorM, maskRule.internalMask())
+	} else {
+		shader = builtinShader(filter, address, useColorM)
+	}
 	i.tmpUniforms = i.tmpUniforms[:0]
 	if useColorM {
 		var body [16]float32
@@ -633,11 +793,42 @@ func (i *Image) DrawTriangles32(vertices []Vertex, indices []uint32, img *Image,
 		})
 	}
 
-	skipMipmap := options.DisableMipmaps
+	// Mipmaps are not used with a mask, as mipmaps are only for the first source image.
+	skipMipmap := disableMipmaps || mask != nil
 	if !skipMipmap {
 		skipMipmap = filter != builtinshader.FilterLinear
 	}
-	i.image.DrawTriangles(srcs, vs, indices, blend, i.adjustedBounds(), [graphics.ShaderSrcImageCount]image.Rectangle{img.adjustedBounds()}, shader.shader, i.tmpUniforms, graphicsdriver.FillRule(options.FillRule), skipMipmap, options.AntiAlias, restorable.HintNone)
+	i.image.DrawTriangles(srcs, vs, indices, blend, i.adjustedBounds(), srcRegions, shader.shader, i.tmpUniforms, graphicsdriver.FillRule(fillRule), skipMipmap, false, antiAlias, restorable.HintNone)
+}
+
+// verticesAt returns the vertices at the specified indices.
+// panicIfNotSplittable panics if the triangles cannot be split into multiple draw calls with the given options.
+//
+// With FillRuleNonZero or FillRuleEvenOdd, the stencil is computed over all the triangles in one draw call.
+// With AntiAlias, the coverage is computed over all the triangles in one draw call.
+// Splitting the triangles would change the result in these cases.
+func panicIfNotSplittable(vertexCount, indexCount int, fillRule FillRule, antiAlias bool) {
+	if fillRule == FillRuleFillAll && !antiAlias {
+		return
+	}
+	panic(fmt.Sprintf("ebiten: len(vertices) (%d) must be <= MaxVertexCount (%d) and len(indices) (%d) must be <= MaxIndexCount (%d) when FillRule is not FillRuleFillAll or AntiAlias is true", vertexCount, graphicscommand.MaxVertexCount, indexCount, graphicscommand.MaxIndexCount))
+}
+
+func verticesAt(vertices []Vertex, indices []uint32) []Vertex {
+	vs := make([]Vertex, len(indices))
+	for i, idx := range indices {
+		vs[i] = vertices[idx]
+	}
+	return vs
+}
+
+// vertexAttributesAt returns the extra vertex attributes at the specified indices.
+func vertexAttributesAt(attributes []float32, indices []uint32) []float32 {
+	as := make([]float32, len(indices)*VertexAttributeCount)
+	for i, idx := range indices {
+		copy(as[i*VertexAttributeCount:(i+1)*VertexAttributeCount], attributes[int(idx)*VertexAttributeCount:])
+	}
+	return as
 }
 
 // DrawTrianglesShaderOptions represents options for DrawTrianglesShader.
@@ -668,6 +859,11 @@ type DrawTrianglesShaderOptions struct {
 	// The pixel mode allows images of different sizes.
 	Images [4]*Image
 
+	// ExtraImages is a set of the additional source images following Images.
+	// ExtraImages[i] is treated as the (4+i)th source image, e.g. imageSrc4At in a shader refers to ExtraImages[0].
+	// The same restrictions as Images are applied.
+	ExtraImages [4]*Image
+
 	// FillRule indicates the rule how an overlapped region is rendered.
 	//
 	// The rules FillRuleNonZero and FillRuleEvenOdd are useful when you want to render a complex polygon.
@@ -685,10 +881,43 @@ type DrawTrianglesShaderOptions struct {
 	//
 	// The default (zero) value is false.
 	AntiAlias bool
+
+	// VertexAttributes is a set of additional per-vertex values passed to the shader.
+	// VertexAttributes[VertexAttributeCount*i:VertexAttributeCount*(i+1)] is for the i-th vertex.
+	// In order to use them, Fragment must have an additional vec4 argument after the custom argument, like:
+	//
+	//	func Fragment(dstPos vec4, srcPos vec2, color vec4, custom vec4, attributes vec4) vec4
+	//
+	// The values are interpolated linearly like the custom values.
+	// A custom vertex function can also receive and return them. See NewShader.
+	//
+	// If VertexAttributes is nil, zeros are used.
+	// Otherwise, len(VertexAttributes) must be VertexAttributeCount*len(vertices).
+	//
+	// VertexAttributes is ignored if the shader doesn't take the extra vertex attributes.
+	// Only the vertices for such a shader have the room for the extra vertex attributes.
+	//
+	// The default (zero) value is nil.
+	VertexAttributes []float32
+
+	// ExtraDestinations is a set of the additional destination images for multiple render targets.
+	// When Fragment returns multiple vec4 values, ExtraDestinations[i] receives the (i+1)-th value,
+	// while the destination image receives the first value. See NewShader.
+	//
+	// The triangles are rendered into all the destination images in one draw call,
+	// e.g., a color buffer and a normal buffer for 2D lighting.
+	//
+	// All the destination images must have the same size, must not be the same image, and must not be a source image.
+	// If a destination image is a sub-image, the other destination images must be sub-images at the same position of
+	// their original images of the same size.
+	// Blend is applied to each destination image. AntiAlias must be false when ExtraDestinations is specified.
+	//
+	// The default (zero) value is no extra destinations.
+	ExtraDestinations [graphics.ShaderDstImageCount - 1]*Image
 }
 
 // Check the number of images.
-var _ [len(DrawTrianglesShaderOptions{}.Images) - graphics.ShaderSrcImageCount]struct{} = [0]struct{}{}
+var _ [len(DrawTrianglesShaderOptions{}.Images) + len(DrawTrianglesShaderOptions{}.ExtraImages) - graphics.ShaderSrcImageCount]struct{} = [0]struct{}{}
 
 // DrawTrianglesShader draws triangles with the specified vertices and their indices with the specified shader.
 //
@@ -700,11 +929,16 @@ var _ [len(DrawTrianglesShaderOptions{}.Images) - graphics.ShaderSrcImageCount]s
 // DrawTrianglesShader panics.
 // If one of the specified image is non-nil and is disposed, DrawTrianglesShader panics.
 //
-// If len(vertices) is more than MaxVertexCount, the exceeding part is ignored.
+// If len(vertices) is more than MaxVertexCount or len(indices) is more than MaxIndexCount,
+// the triangles are split into multiple draw calls in the original order.
+// In this case, if options.FillRule is not FillRuleFillAll or options.AntiAlias is true, DrawTrianglesShader panics,
+// as the fill rule and the anti-alias need all the triangles in one draw call.
 //
 // If len(indices) is not multiple of 3, DrawTrianglesShader panics.
 //
-// If a value in indices is out of range of vertices, or not less than MaxVertexCount, DrawTrianglesShader panics.
+// If a value in indices is out of range of vertices, DrawTrianglesShader panics.
+//
+// If options.VertexAttributes is not nil and its length doesn't match with vertices, DrawTrianglesShader panics.
 //
 // When a specified image is non-nil and is disposed, DrawTrianglesShader panics.
 //
@@ -733,11 +967,16 @@ func (i *Image) DrawTrianglesShader(vertices []Vertex, indices []uint16, shader
 // DrawTrianglesShader32 panics.
 // If one of the specified image is non-nil and is disposed, DrawTrianglesShader32 panics.
 //
-// If len(vertices) is more than MaxVertexCount, the exceeding part is ignored.
+// If len(vertices) is more than MaxVertexCount or len(indices) is more than MaxIndexCount,
+// the triangles are split into multiple draw calls in the original order.
+// In this case, if options.FillRule is not FillRuleFillAll or options.AntiAlias is true, DrawTrianglesShader32 panics,
+// as the fill rule and the anti-alias need all the triangles in one draw call.
 //
 // If len(indices) is not multiple of 3, DrawTrianglesShader32 panics.
 //
-// If a value in indices is out of range of vertices, or not less than MaxVertexCount, DrawTrianglesShader32 panics.
+// If a value in indices is out of range of vertices, DrawTrianglesShader32 panics.
+//
+// If options.VertexAttributes is not nil and its length doesn't match with vertices, DrawTrianglesShader32 panics.
 //
 // When a specified image is non-nil and is disposed, DrawTrianglesShader32 panics.
 //
@@ -758,10 +997,6 @@ func (i *Image) DrawTrianglesShader32(vertices []Vertex, indices []uint32, shade
 		panic("ebiten: the given shader to DrawTrianglesShader must not be disposed")
 	}
 
-	if len(vertices) > graphicscommand.MaxVertexCount {
-		// The last part cannot be specified by indices. Just omit them.
-		vertices = vertices[:graphicscommand.MaxVertexCount]
-	}
 	if len(indices)%3 != 0 {
 		panic("ebiten: len(indices) % 3 must be 0")
 	}
@@ -771,6 +1006,52 @@ func (i *Image) DrawTrianglesShader32(vertices []Vertex, indices []uint32, shade
 		}
 	}
 
+	if options != nil && options.VertexAttributes != nil && len(options.VertexAttributes) != VertexAttributeCount*len(vertices) {
+		panic(fmt.Sprintf("ebiten: len(options.VertexAttributes) must be %d but was %d", VertexAttributeCount*len(vertices), len(options.VertexAttributes)))
+	}
+	if options != nil {
+		for k, dst := range options.ExtraDestinations {
+			if dst == nil {
+				continue
+			}
+			if k+1 >= shader.outputCount {
+				panic(fmt.Sprintf("ebiten: options.ExtraDestinations[%d] is specified but the shader has only %d outputs", k, max(shader.outputCount, 1)))
+			}
+			if dst.isDisposed() {
+				panic("ebiten: the given destination image to DrawTrianglesShader must not be disposed")
+			}
+			if dst.adjustedBounds() != i.adjustedBounds() || dst.originalBounds().Size() != i.originalBounds().Size() {
+				panic("ebiten: all the destination images must be the same size at the same position of the same-size original images")
+			}
+			if dst.image == i.image || slices.ContainsFunc(options.ExtraDestinations[:k], func(img *Image) bool { return img != nil && img.image == dst.image }) {
+				panic("ebiten: all the destination images must be different and must not share the same original image")
+			}
+			if slices.Contains(options.Images[:], dst) || slices.Contains(options.ExtraImages[:], dst) {
+				panic("ebiten: a destination image must not be a source image")
+			}
+			if options.AntiAlias {
+				panic("ebiten: options.AntiAlias must be false when options.ExtraDestinations is specified")
+			}
+		}
+	}
+
+	if len(vertices) > graphicscommand.MaxVertexCount || len(indices) > graphicscommand.MaxIndexCount {
+		if options != nil {
+			panicIfNotSplittable(len(vertices), len(indices), options.FillRule, options.AntiAlias)
+		}
+		// Split the triangles into multiple draw calls, preserving the order.
+		graphics.SplitTriangles(len(vertices), indices, graphicscommand.MaxVertexCount, graphicscommand.MaxIndexCount, func(vertexIndices []uint32, indices []uint32) {
+			op := options
+			if options != nil && options.VertexAttributes != nil {
+				o := *options
+				o.VertexAttributes = vertexAttributesAt(options.VertexAttributes, vertexIndices)
+				op = &o
+			}
+			i.DrawTrianglesShader32(verticesAt(vertices, vertexIndices), indices, shader, op)
+		})
+		return
+	}
+
 	if options == nil {
 		options = &DrawTrianglesShaderOptions{}
 	}
@@ -782,33 +1063,51 @@ func (i *Image) DrawTrianglesShader32(vertices []Vertex, indices []uint32, shade
 		blend = options.CompositeMode.blend().internalBlend()
 	}
 
-	vs := i.ensureTmpVertices(len(vertices) * graphics.VertexFloatCount)
+	// The vertices have the extra vertex attributes only when the shader uses them.
+	n := shader.shader.VertexFloatCount()
+	vs := i.ensureTmpVertices(len(vertices) * n)
 	dst := i
 	src := options.Images[0]
 	// Avoid using `for i, v := range vertices` as adding `v` creates a copy from `vertices` unnecessarily on each loop (#3103).
 	for i := range vertices {
 		dx, dy := dst.adjustPositionF32(vertices[i].DstX, vertices[i].DstY)
-		vs[i*graphics.VertexFloatCount] = dx
-		vs[i*graphics.VertexFloatCount+1] = dy
+		vs[i*n] = dx
+		vs[i*n+1] = dy
 		sx, sy := vertices[i].SrcX, vertices[i].SrcY
 		if src != nil {
 			sx, sy = src.adjustPositionF32(sx, sy)
 		}
-		vs[i*graphics.VertexFloatCount+2] = sx
-		vs[i*graphics.VertexFloatCount+3] = sy
-		vs[i*graphics.VertexFloatCount+4] = vertices[i].ColorR
-		vs[i*graphics.VertexFloatCount+5] = vertices[i].ColorG
-		vs[i*graphics.VertexFloatCount+6] = vertices[i].ColorB
-		vs[i*graphics.VertexFloatCount+7] = vertices[i].ColorA
-		vs[i*graphics.VertexFloatCount+8] = vertices[i].Custom0
-		vs[i*graphics.VertexFloatCount+9] = vertices[i].Custom1
-		vs[i*graphics.VertexFloatCount+10] = vertices[i].Custom2
-		vs[i*graphics.VertexFloatCount+11] = vertices[i].Custom3
+		vs[i*n+2] = sx
+		vs[i*n+3] = sy
+		vs[i*n+4] = vertices[i].ColorR
+		vs[i*n+5] = vertices[i].ColorG
+		vs[i*n+6] = vertices[i].ColorB
+		vs[i*n+7] = vertices[i].ColorA
+		vs[i*n+8] = vertices[i].Custom0
+		vs[i*n+9] = vertices[i].Custom1
+		vs[i*n+10] = vertices[i].Custom2
+		vs[i*n+11] = vertices[i].Custom3
+		if n == graphics.VertexFloatCount {
+			continue
+		}
+		attrs := vs[i*n+graphics.VertexFloatCount : (i+1)*n]
+		if options.VertexAttributes != nil {
+			copy(attrs, options.VertexAttributes[i*VertexAttributeCount:(i+1)*VertexAttributeCount])
+		} else {
+			clear(attrs)
+		}
 	}
 
+	i.drawTrianglesShaderWithVertices(vs, indices, shader, &options.Images, &options.ExtraImages, &options.ExtraDestinations, options.Uniforms, blend, options.FillRule, options.AntiAlias)
+}
+
+// drawTrianglesShaderWithVertices draws triangles with the vertices in the internal format and the shader.
+// extraDsts can be nil when there are no extra destination images.
+func (i *Image) drawTrianglesShaderWithVertices(vs []float32, indices []uint32, shader *Shader, images, extraImages *[4]*Image, extraDsts *[graphics.ShaderDstImageCount - 1]*Image, uniforms map[string]any, blend graphicsdriver.Blend, fillRule FillRule, antiAlias bool) {
+	srcs := shaderSrcImages(images, extraImages)
 	var imgs [graphics.ShaderSrcImageCount]*ui.Image
 	var imgSize image.Point
-	for i, img := range options.Images {
+	for i, img := range srcs {
 		if img == nil {
 			continue
 		}
@@ -829,7 +1128,7 @@ func (i *Image) DrawTrianglesShader32(vertices []Vertex, indices []uint32, shade
 	}
 
 	var srcRegions [graphics.ShaderSrcImageCount]image.Rectangle
-	for i, img := range options.Images {
+	for i, img := range srcs {
 		if img == nil {
 			continue
 		}
@@ -837,9 +1136,22 @@ func (i *Image) DrawTrianglesShader32(vertices []Vertex, indices []uint32, shade
 	}
 
 	i.tmpUniforms = i.tmpUniforms[:0]
-	i.tmpUniforms = shader.appendUniforms(i.tmpUniforms, options.Uniforms)
+	i.tmpUniforms = shader.appendUniforms(i.tmpUniforms, uniforms)
 
-	i.image.DrawTriangles(imgs, vs, indices, blend, i.adjustedBounds(), srcRegions, shader.shader, i.tmpUniforms, graphicsdriver.FillRule(options.FillRule), true, options.AntiAlias, restorable.HintNone)
+	if extraDsts != nil && *extraDsts != ([graphics.ShaderDstImageCount - 1]*Image{}) {
+		var dsts [graphics.ShaderDstImageCount - 1]*ui.Image
+		for k, dst := range extraDsts {
+			if dst == nil {
+				continue
+			}
+			dst.copyCheck()
+			dsts[k] = dst.image
+		}
+		i.image.DrawTrianglesMRT(dsts, imgs, vs, indices, blend, i.adjustedBounds(), srcRegions, shader.shader, i.tmpUniforms, graphicsdriver.FillRule(fillRule))
+		return
+	}
+
+	i.image.DrawTriangles(imgs, vs, indices, blend, i.adjustedBounds(), srcRegions, shader.shader, i.tmpUniforms, graphicsdriver.FillRule(fillRule), true, false, antiAlias, restorable.HintNone)
 }
 
 // DrawRectShaderOptions represents options for DrawRectShader.
@@ -877,10 +1189,23 @@ type DrawRectShaderOptions struct {
 	// Images is a set of the source images.
 	// All the images' sizes must be the same.
 	Images [4]*Image
+
+	// ExtraImages is a set of the additional source images following Images.
+	// ExtraImages[i] is treated as the (4+i)th source image, e.g. imageSrc4At in a shader refers to ExtraImages[0].
+	// The same restrictions as Images are applied.
+	ExtraImages [4]*Image
 }
 
 // Check the number of images.
-var _ [len(DrawRectShaderOptions{}.Images)]struct{} = [graphics.ShaderSrcImageCount]struct{}{}
+var _ [len(DrawRectShaderOptions{}.Images) + len(DrawRectShaderOptions{}.ExtraImages)]struct{} = [graphics.ShaderSrcImageCount]struct{}{}
+
+// shaderSrcImages returns the source images for a shader, concatenating images and extraImages.
+func shaderSrcImages(images, extraImages *[4]*Image) [graphics.ShaderSrcImageCount]*Image {
+	var imgs [graphics.ShaderSrcImageCount]*Image
+	copy(imgs[:], images[:])
+	copy(imgs[len(images):], extraImages[:])
+	return imgs
+}
 
 // DrawRectShader draws a rectangle with the specified width and height with the specified shader.
 //
@@ -927,8 +1252,9 @@ func (i *Image) DrawRectShader(width, height int, shader *Shader, options *DrawR
 		blend = options.CompositeMode.blend().internalBlend()
 	}
 
+	srcs := shaderSrcImages(&options.Images, &options.ExtraImages)
 	var imgs [graphics.ShaderSrcImageCount]*ui.Image
-	for i, img := range options.Images {
+	for i, img := range srcs {
 		if img == nil {
 			continue
 		}
@@ -942,7 +1268,7 @@ func (i *Image) DrawRectShader(width, height int, shader *Shader, options *DrawR
 	}
 
 	var srcRegions [graphics.ShaderSrcImageCount]image.Rectangle
-	for i, img := range options.Images {
+	for i, img := range srcs {
 		if img == nil {
 			if shader.unit == shaderir.Pixels && i == 0 {
 				// Give the source size as pixels only when the unit is pixels so that users can get the source size via imageSrc0Size (#2166).
@@ -963,13 +1289,16 @@ func (i *Image) DrawRectShader(width, height int, shader *Shader, options *DrawR
 		return
 	}
 	cr, cg, cb, ca := options.ColorScale.elements()
-	vs := i.ensureTmpVertices(4 * graphics.VertexFloatCount)
+	vs := i.ensureTmpVertices(4 * shader.shader.VertexFloatCount())
 
 	// Do not use srcRegions[0].Dx() and srcRegions[0].Dy() as these might be empty.
 	graphics.QuadVerticesFromSrcAndMatrix(vs,
 		float32(srcRegions[0].Min.X), float32(srcRegions[0].Min.Y),
 		float32(srcRegions[0].Min.X+width), float32(srcRegions[0].Min.Y+height),
 		a, b, c, d, tx, ty, cr, cg, cb, ca)
+	if shader.shader.VertexFloatCount() == graphics.VertexFloatCountWithAttributes {
+		graphics.AddZeroVertexAttributes(vs, 4)
+	}
 	is := graphics.QuadIndices()
 
 	i.tmpUniforms = i.tmpUniforms[:0]
@@ -982,7 +1311,7 @@ func (i *Image) DrawRectShader(width, height int, shader *Shader, options *DrawR
 		hint = restorable.HintOverwriteDstRegion
 	}
 
-	i.image.DrawTriangles(imgs, vs, is, blend, dr, srcRegions, shader.shader, i.tmpUniforms, graphicsdriver.FillRuleFillAll, true, false, hint)
+	i.image.DrawTriangles(imgs, vs, is, blend, dr, srcRegions, shader.shader, i.tmpUniforms, graphicsdriver.FillRuleFillAll, true, false, false, hint)
 }
 
 // SubImage returns an image representing the portion of the image p visible through r.
@@ -998,6 +1327,9 @@ func (i *Image) DrawRectShader(width, height int, shader *Shader, options *DrawR
 //
 // Successive uses of multiple various regions as rendering destination is still efficient
 // when all the underlying images are the same, but some platforms like browsers might not work efficiently.
+//
+// When a sub-image is rendered with a filter other than FilterNearest, the pixels around the sub-image might bleed.
+// See MightBleed for the details.
 func (i *Image) SubImage(r image.Rectangle) image.Image {
 	i.copyCheck()
 	if i.isDisposed() {
@@ -1025,6 +1357,54 @@ func (i *Image) SubImage(r image.Rectangle) image.Image {
 	return img
 }
 
+// MightBleed reports whether rendering the image with the given filter and geometry matrix by DrawImage
+// might sample pixels outside the image bounds.
+//
+// Such pixels are the neighbor pixels in the original image for a sub-image, or the transparent pixels around the image.
+// They bleed into the edges of the rendering result, and cause visible seams e.g. between tiles of a tileset.
+//
+// With FilterNearest, MightBleed always returns false.
+// With the other filters, pixels outside the bounds are not sampled when the image is rendered with the pixel-perfect alignment.
+// Otherwise, MightBleed returns true unless the image's edges are surrounded by a gutter with GutterModeClamp.
+// To avoid bleeding of a tile, create an independent image of the tile with NewImageOptions.Gutter and GutterModeClamp.
+//
+// If the image is disposed, MightBleed returns false.
+func (i *Image) MightBleed(filter Filter, geoM GeoM) bool {
+	i.copyCheck()
+	if i.isDisposed() {
+		return false
+	}
+
+	if filter == FilterNearest {
+		return false
+	}
+
+	a, b, c, d, tx, ty := geoM.elements32()
+	if b == 0 && c == 0 && tx == float32(math.Trunc(float64(tx))) && ty == float32(math.Trunc(float64(ty))) {
+		switch filter {
+		case FilterLinear:
+			// Each pixel is sampled exactly at its center.
+			if math.Abs(float64(a)) == 1 && math.Abs(float64(d)) == 1 {
+				return false
+			}
+		case FilterPixelated:
+			// Each pixel is rendered as an aligned integer-sized block.
+			if a != 0 && d != 0 && a == float32(math.Trunc(float64(a))) && d == float32(math.Trunc(float64(d))) {
+				return false
+			}
+		}
+	}
+
+	orig := i
+	if i.isSubImage() {
+		orig = i.original
+	}
+	if !i.Bounds().Eq(orig.Bounds()) {
+		return true
+	}
+	return !orig.clampGutter
+}
+
 // Bounds returns the bounds of the image.
 //
 // Bounds implements the standard image.Image's Bounds.
@@ -1075,11 +1455,79 @@ func (i *Image) ReadPixels(pixels []byte) {
 	i.image.ReadPixels(pixels, i.adjustedBounds())
 }
 
+// ReadPixelsFloat32 reads the image's pixels from the image as float32 values.
+//
+// The given pixels represent RGBA pre-multiplied alpha values.
+// For an image with PixelFormatRGBA16F or PixelFormatRGBA32F, the values are not quantized to 8-bit and might be out of [0, 1].
+// For an image with PixelFormatRGBA8, the values are in [0, 1] with 8-bit precision.
+//
+// ReadPixelsFloat32 always loads pixels from GPU to system memory, which means that ReadPixelsFloat32 can be slow.
+//
+// ReadPixelsFloat32 always sets a transparent color if the image is disposed.
+//
+// len(pixels) must be 4 * (bounds width) * (bounds height).
+// If len(pixels) is not correct, ReadPixelsFloat32 panics.
+//
+// ReadPixelsFloat32 also works on a sub-image.
+//
+// ReadPixelsFloat32 can't be called outside the main loop (ebiten.Run's updating function) starts.
+func (i *Image) ReadPixelsFloat32(pixels []float32) {
+	b := i.Bounds()
+	if got, want := len(pixels), 4*b.Dx()*b.Dy(); got != want {
+		panic(fmt.Sprintf("ebiten: len(pixels) must be %d but %d at ReadPixelsFloat32", want, got))
+	}
+
+	if i.isDisposed() {
+		for i := range pixels {
+			pixels[i] = 0
+		}
+		return
+	}
+
+	i.image.ReadPixelsFloat32(pixels, i.adjustedBounds())
+}
+
+// Pixels returns a snapshot of the image's pixels in the specified region.
+//
+// The returned pixels represent RGBA pre-multiplied alpha values, and the length is 4 * (region width) * (region height).
+// The returned slice is newly allocated, and is not affected by later modifications of the image.
+//
+// Pixels loads pixels from GPU to system memory if necessary, which means that Pixels can be slow.
+// However, the loaded pixels are cached on system memory until the image is rendered by e.g. DrawImage.
+// Thus, calling Pixels, ReadPixels, or At on the same image again without rendering doesn't access GPU again.
+// When you need many pixels, call Pixels once for the whole region instead of calling At for each pixel.
+//
+// region must be within the image bounds. Otherwise, Pixels panics.
+//
+// Pixels always returns transparent pixels if the image is disposed.
+//
+// Pixels also works on a sub-image.
+//
+// Note that an important logic should not rely on values returned by Pixels, since
+// the returned values can include very slight differences between some machines.
+//
+// Pixels can't be called outside the main loop (ebiten.Run's updating function) starts.
+func (i *Image) Pixels(region image.Rectangle) []byte {
+	if !region.In(i.Bounds()) {
+		panic(fmt.Sprintf("ebiten: region %v must be within the image bounds %v", region, i.Bounds()))
+	}
+
+	pixels := make([]byte, 4*region.Dx()*region.Dy())
+	if i.isDisposed() || region.Empty() {
+		return pixels
+	}
+
+	x, y := i.adjustPosition(region.Min.X, region.Min.Y)
+	i.image.ReadPixels(pixels, image.Rect(x, y, x+region.Dx(), y+region.Dy()))
+	return pixels
+}
+
 // At returns the color of the image at (x, y).
 //
 // At implements the standard image.Image's At.
 //
 // At loads pixels from GPU to system memory if necessary, which means that At can be slow.
+// To read many pixels, use Pixels or ReadPixels instead.
 //
 // At always returns a transparent color if the image is disposed.
 //
@@ -1194,6 +1642,53 @@ func (i *Image) Deallocate() {
 	i.image.Deallocate()
 }
 
+// SetMipmapMaxLevel sets the maximum mipmap level used when the image is rendered with FilterLinear.
+// The level n image is 1/2^n the size of the image.
+// If level is 0, mipmaps are never used for the image.
+// The default value is 6.
+//
+// If the image is a sub-image, SetMipmapMaxLevel affects the original image.
+//
+// SetMipmapMaxLevel panics if level is negative.
+//
+// If the image is disposed, SetMipmapMaxLevel does nothing.
+func (i *Image) SetMipmapMaxLevel(level int) {
+	i.copyCheck()
+
+	if level < 0 {
+		panic(fmt.Sprintf("ebiten: level must be non-negative but %d", level))
+	}
+	if i.isDisposed() {
+		return
+	}
+	if i.isSubImage() {
+		i = i.original
+	}
+	i.image.SetMipmapMaxLevel(level)
+}
+
+// GenerateMipmaps generates the mipmap images up to the maximum level.
+//
+// Usually mipmap images are generated lazily when the image is rendered with a shrinking scale,
+// and this might cause a hitch when the image is large.
+// GenerateMipmaps is useful to generate them in advance, e.g., during loading.
+// When the image is modified after GenerateMipmaps, the mipmap images are generated again when needed.
+//
+// If the image is a sub-image, GenerateMipmaps affects the original image.
+//
+// If the image is disposed, GenerateMipmaps does nothing.
+func (i *Image) GenerateMipmaps() {
+	i.copyCheck()
+
+	if i.isDisposed() {
+		return
+	}
+	if i.isSubImage() {
+		i = i.original
+	}
+	i.image.GenerateMipmaps()
+}
+
 // WritePixels replaces the pixels of the image.
 //
 // The given pixels are treated as RGBA pre-multiplied alpha values.
@@ -1205,6 +1700,9 @@ func (i *Image) Deallocate() {
 //
 // Even if a result is an invalid color as a premultiplied-alpha color, i.e. an alpha value exceeds other color values,
 // the value is kept and is not clamped.
+// Use PremultiplyAlpha to convert straight-alpha pixels.
+// With the environment variable EBITENGINE_VALIDATE_PREMULTIPLIED_ALPHA, WritePixels panics on such an invalid color.
+// See the package document for the details.
 //
 // When the image is disposed, WritePixels does nothing.
 func (i *Image) WritePixels(pixels []byte) {
@@ -1214,12 +1712,83 @@ func (i *Image) WritePixels(pixels []byte) {
 		return
 	}
 
+	validatePremultipliedAlpha(pixels, i.Bounds())
+
 	// Do not need to copy pixels here.
 	// * In internal/mipmap, pixels are copied when necessary.
 	// * In internal/atlas, pixels are copied to make its paddings.
 	i.image.WritePixels(pixels, i.adjustedBounds())
 }
 
+// WritePixelsFloat32 replaces the pixels of the image with float32 values.
+//
+// The given pixels are treated as RGBA pre-multiplied alpha values.
+// For an image with PixelFormatRGBA16F or PixelFormatRGBA32F, the values are kept without clamping to [0, 1].
+// For an image with PixelFormatRGBA8, the values are clamped to [0, 1] and quantized to 8-bit.
+//
+// len(pix) must be 4 * (bounds width) * (bounds height).
+// If len(pix) is not correct, WritePixelsFloat32 panics.
+//
+// WritePixelsFloat32 also works on a sub-image.
+//
+// When the image is disposed, WritePixelsFloat32 does nothing.
+func (i *Image) WritePixelsFloat32(pixels []float32) {
+	i.copyCheck()
+
+	if i.isDisposed() {
+		return
+	}
+
+	b := i.Bounds()
+	if got, want := len(pixels), 4*b.Dx()*b.Dy(); got != want {
+		panic(fmt.Sprintf("ebiten: len(pixels) must be %d but %d at WritePixelsFloat32", want, got))
+	}
+
+	// Do not need to copy pixels here. In internal/atlas, pixels are copied.
+	i.image.WritePixelsFloat32(pixels, i.adjustedBounds())
+}
+
+// WritePixelsFromImage replaces the pixels at the specified region of the image with the pixels of img.
+//
+// region is in the image i's coordinates, and must be within the bounds of i.
+// The size of region must be the same as the size of img's bounds.
+// If these conditions are not satisfied, WritePixelsFromImage panics.
+//
+// WritePixelsFromImage converts img's pixels on the fly without extra allocations
+// when img is *image.RGBA, *image.NRGBA, *image.Gray, *image.Alpha, or *image.Paletted.
+// For single-channel data, wrap the data with *image.Gray or *image.Alpha without copying it.
+// For other types, WritePixelsFromImage works, but might be slower.
+//
+// WritePixelsFromImage also works on a sub-image.
+//
+// When the image is disposed, WritePixelsFromImage does nothing.
+func (i *Image) WritePixelsFromImage(img image.Image, region image.Rectangle) {
+	i.copyCheck()
+
+	if i.isDisposed() {
+		return
+	}
+
+	if !region.In(i.Bounds()) {
+		panic(fmt.Sprintf("ebiten: region %v must be within the image bounds %v", region, i.Bounds()))
+	}
+	if region.Size() != img.Bounds().Size() {
+		panic(fmt.Sprintf("ebiten: the size of region %v must be the same as the size of img's bounds %v", region.Size(), img.Bounds().Size()))
+	}
+	if region.Empty() {
+		return
+	}
+
+	pix := i.ensureTmpPixels(4 * region.Dx() * region.Dy())
+	writeImageToBytes(pix, img)
+
+	validatePremultipliedAlpha(pix, region)
+
+	x, y := i.adjustPosition(region.Min.X, region.Min.Y)
+	// Do not need to copy pixels here. See the comment in WritePixels.
+	i.image.WritePixels(pix, image.Rect(x, y, x+region.Dx(), y+region.Dy()))
+}
+
 // ReplacePixels replaces the pixels of the image.
 //
 // Deprecated: as of v2.4. Use WritePixels instead.
@@ -1249,8 +1818,133 @@ type NewImageOptions struct {
 	// A regular image is a part of an internal texture atlas, and locating them is done automatically in Ebitengine.
 	// Unmanaged is useful when you want finer controls over the image for performance and memory reasons.
 	Unmanaged bool
+
+	// Unrestorable represents whether the image's content is restored or not when the graphics device is lost.
+	// The default (zero) value is false, that means the image's content is restored automatically.
+	//
+	// On some environments, e.g., on Android or with RunGameOptions.RecoverFromGraphicsDeviceLoss, Ebitengine keeps
+	// the drawing history and the pixels of images on CPU to restore them, which might cause stalls to read pixels from GPU.
+	// An unrestorable image skips them, and its content is cleared instead when the graphics device is lost.
+	// Redraw the content of unrestorable images when IsGraphicsDeviceRestored returns true.
+	//
+	// An unrestorable image is also unmanaged, and is never on an internal automatic texture atlas.
+	Unrestorable bool
+
+	// Streaming represents whether the image's pixels are rewritten frequently, e.g., every frame, or not.
+	// The default (zero) value is false.
+	//
+	// Streaming is useful for images whose whole pixels are updated by WritePixels every frame, like video frames
+	// or particle fields calculated on CPU.
+	// A streaming image uploads pixels via rotating staging buffers if the graphics library supports it,
+	// so that WritePixels doesn't wait for GPU to finish using the previous pixels.
+	//
+	// A streaming image is also unrestorable and unmanaged. See Unrestorable for the details.
+	Streaming bool
+
+	// DepthBuffer represents whether the image has a depth buffer or not.
+	// The default (zero) value is false.
+	//
+	// A depth buffer keeps a depth value for each pixel, and enables occlusion on GPU without sorting draw calls.
+	// A shader specifies the depth of a vertex by returning vec3 from its Vertex function,
+	// and uses the depth buffer by the //kage:depth directive. See NewShader for the details.
+	// The other draw functions ignore the depth buffer.
+	//
+	// Clear and Fill reset the depth buffer with the farthest value 1.
+	//
+	// A depth buffer is available with OpenGL, DirectX, and Metal.
+	// With the other graphics libraries, the game is terminated with an error when the image is created.
+	//
+	// An image with a depth buffer is also unrestorable and unmanaged. See Unrestorable for the details.
+	// DepthBuffer cannot be used with Streaming.
+	DepthBuffer bool
+
+	// PixelFormat is the pixel format of the image.
+	// The default (zero) value is PixelFormatRGBA8.
+	//
+	// With PixelFormatRGBA16F or PixelFormatRGBA32F, the results of drawing are not clamped to [0, 1] nor quantized to 8-bit.
+	// This is useful for intermediate images like HDR accumulation, bloom, and general computation with Kage shaders.
+	// Use WritePixelsFloat32 and ReadPixelsFloat32 to write and read the values without quantization.
+	//
+	// Floating-point formats are available only with OpenGL and DirectX 11 for now.
+	// With the other graphics libraries, the image works like an image with PixelFormatRGBA8.
+	// In browsers, blending onto an image with PixelFormatRGBA32F might not be supported.
+	//
+	// An image with a floating-point format is also unrestorable and unmanaged. See Unrestorable for the details.
+	// A floating-point format cannot be used with Streaming or DepthBuffer.
+	PixelFormat PixelFormat
+
+	// Gutter is the number of the extra pixels around the image in the internal texture.
+	// The default (zero) value is 0, that means the image is surrounded by only one transparent pixel on the right and bottom sides.
+	//
+	// When an image is rendered with FilterLinear or FilterPixelated, the pixels just outside the image might be sampled.
+	// With a gutter, such pixels are the gutter pixels, which are filled as specified by GutterMode.
+	// A gutter is useful to avoid seams between adjacent tiles with linear filtering, or to sample pixels around the image in a custom shader.
+	//
+	// Gutter must be non-negative.
+	Gutter int
+
+	// GutterMode specifies how the gutter pixels are filled.
+	// GutterMode is used only when Gutter is positive.
+	//
+	// The default (zero) value is GutterModeTransparent.
+	GutterMode GutterMode
+
+	// AtlasGroup specifies the group of the internal texture atlases the image can be on.
+	// The default (zero) value is AtlasGroupDefault.
+	//
+	// When an image on an atlas is used as a rendering destination, or when an atlas doesn't have enough space,
+	// Ebitengine moves the image or reallocates the whole atlas, which affects all the images on the atlas.
+	// An image that is rendered or rewritten frequently can cause such churn for the other images.
+	// With a positive AtlasGroup, the image shares atlases only with the images with the same AtlasGroup,
+	// and the churn is confined to the group.
+	// With AtlasGroupNone, the image is never on an atlas, like Unmanaged.
+	//
+	// AtlasGroup is used only for managed images. An unmanaged image is never on an atlas anyway.
+	//
+	// For a game hosted by exp/gamehost, AtlasGroupDefault means the hosted game's own atlases,
+	// which are not shared with the host application.
+	AtlasGroup AtlasGroup
 }
 
+// AtlasGroup represents a group of internal texture atlases.
+//
+// A positive value is a dedicated group that is not shared with the default group.
+type AtlasGroup int
+
+const (
+	// AtlasGroupDefault is the group of the atlases shared by all the images by default.
+	AtlasGroupDefault AtlasGroup = 0
+
+	// AtlasGroupNone indicates that the image is never on an atlas.
+	AtlasGroupNone AtlasGroup = -1
+)
+
+// GutterMode represents how the gutter pixels around an image are filled.
+type GutterMode int
+
+const (
+	// GutterModeTransparent fills the gutter with transparent pixels.
+	GutterModeTransparent GutterMode = GutterMode(atlas.GutterModeTransparent)
+
+	// GutterModeClamp fills the gutter with the nearest edge pixels of the image.
+	// The gutter is updated after the image is modified, when the image is used as a rendering source.
+	GutterModeClamp GutterMode = GutterMode(atlas.GutterModeClamp)
+)
+
+// PixelFormat represents a pixel format of an image.
+type PixelFormat int
+
+const (
+	// PixelFormatRGBA8 is a format with 8-bit unsigned normalized integers for each channel.
+	PixelFormatRGBA8 PixelFormat = iota
+
+	// PixelFormatRGBA16F is a format with 16-bit floating-point values for each channel.
+	PixelFormatRGBA16F
+
+	// PixelFormatRGBA32F is a format with 32-bit floating-point values for each channel.
+	PixelFormatRGBA32F
+)
+
 // NewImageWithOptions returns an empty image with the given bounds and the options.
 //
 // If width or height is less than 1 or more than device-dependent maximum size, NewImageWithOptions panics.
@@ -1271,7 +1965,49 @@ func NewImageWithOptions(bounds image.Rectangle, options *NewImageOptions) *Imag
 	if options != nil && options.Unmanaged {
 		imageType = atlas.ImageTypeUnmanaged
 	}
-	return newImage(bounds, imageType)
+	if options != nil && options.AtlasGroup < AtlasGroupDefault {
+		if options.AtlasGroup != AtlasGroupNone {
+			panic(fmt.Sprintf("ebiten: invalid AtlasGroup: %d", options.AtlasGroup))
+		}
+		imageType = atlas.ImageTypeUnmanaged
+	}
+	if options != nil && options.Unrestorable {
+		imageType = atlas.ImageTypeUnrestorable
+	}
+	if options != nil && options.Streaming {
+		imageType = atlas.ImageTypeStreaming
+	}
+	if options != nil && options.DepthBuffer {
+		if options.Streaming {
+			panic("ebiten: DepthBuffer cannot be used with Streaming")
+		}
+		imageType = atlas.ImageTypeDepth
+	}
+	if options != nil && options.PixelFormat != PixelFormatRGBA8 {
+		if options.Streaming || options.DepthBuffer {
+			panic("ebiten: a floating-point PixelFormat cannot be used with Streaming or DepthBuffer")
+		}
+		switch options.PixelFormat {
+		case PixelFormatRGBA16F:
+			imageType = atlas.ImageTypeFloat16
+		case PixelFormatRGBA32F:
+			imageType = atlas.ImageTypeFloat32
+		default:
+			panic(fmt.Sprintf("ebiten: invalid PixelFormat: %d", options.PixelFormat))
+		}
+	}
+	i := newImage(bounds, imageType)
+	if options != nil && options.Gutter != 0 {
+		if options.Gutter < 0 {
+			panic(fmt.Sprintf("ebiten: Gutter must be non-negative but %d", options.Gutter))
+		}
+		i.image.SetGutter(options.Gutter, atlas.GutterMode(options.GutterMode))
+		i.clampGutter = options.GutterMode == GutterModeClamp
+	}
+	if options != nil && options.AtlasGroup > AtlasGroupDefault && imageType == atlas.ImageTypeRegular {
+		i.image.SetAtlasGroup(int(options.AtlasGroup))
+	}
+	return i
 }
 
 func newImage(bounds image.Rectangle, imageType atlas.ImageType) *Image {
@@ -1292,6 +2028,12 @@ func newImage(bounds image.Rectangle, imageType atlas.ImageType) *Image {
 		bounds: bounds,
 	}
 	i.addr = i
+	if imageType == atlas.ImageTypeRegular {
+		// An image created by a game hosted by exp/gamehost is on the host's own atlases.
+		if g := atlas.DefaultAtlasGroup(); g != 0 {
+			i.image.SetAtlasGroup(g)
+		}
+	}
 	return i
 }
 
@@ -1323,6 +2065,18 @@ type NewImageFromImageOptions struct {
 	// PreserveBounds represents whether the new image's bounds are the same as the given image.
 	// The default (zero) value is false, that means the new image's upper-left position is adjusted to (0, 0).
 	PreserveBounds bool
+
+	// Gutter is the number of the extra pixels around the image in the internal texture.
+	// See NewImageOptions.Gutter for the details.
+	Gutter int
+
+	// GutterMode specifies how the gutter pixels are filled.
+	// See NewImageOptions.GutterMode for the details.
+	GutterMode GutterMode
+
+	// AtlasGroup specifies the group of the internal texture atlases the image can be on.
+	// See NewImageOptions.AtlasGroup for the details.
+	AtlasGroup AtlasGroup
 }
 
 // NewImageFromImageWithOptions creates a new image with the given image (source) with the given options.
@@ -1349,7 +2103,10 @@ func NewImageFromImageWithOptions(source image.Image, options *NewImageFromImage
 		r = image.Rect(0, 0, size.X, size.Y)
 	}
 	i := NewImageWithOptions(r, &NewImageOptions{
-		Unmanaged: options.Unmanaged,
+		Unmanaged:  options.Unmanaged,
+		Gutter:     options.Gutter,
+		GutterMode: options.GutterMode,
+		AtlasGroup: options.AtlasGroup,
 	})
 
 	// If the given image is an Ebitengine image, use DrawImage instead of reading pixels from the source.
@@ -1412,6 +2169,13 @@ func (i *Image) ensureTmpVertices(n int) []float32 {
 	return i.tmpVertices[:n]
 }
 
+func (i *Image) ensureTmpPixels(n int) []byte {
+	if cap(i.tmpPixels) < n {
+		i.tmpPixels = make([]byte, n)
+	}
+	return i.tmpPixels[:n]
+}
+
 func (i *Image) ensureTmpIndices(n int) []uint32 {
 	if cap(i.tmpIndices) < n {
 		i.tmpIndices = make([]uint32, n)
@@ -24,6 +24,7 @@ import (
 	"math"
 	"math/rand/v2"
 	"runtime"
+	"strings"
 	"testing"
 
 	"github.com/duplicants-ai/ebiten"
@@ -41,7 +42,7 @@ func skipTooSlowTests(t *testing.T) bool {
 		t.Skip("skipping test in short mode")
 		return true
 	}
-	if runtime.GOOS == "js" {
+	if runtime.GOOS == "js" || runtime.GOOS == "wasip1" {
 		t.Skip("too slow or fragile on Wasm")
 		return true
 	}
@@ -3237,6 +3238,38 @@ func TestImageOptionsUnmanaged(t *testing.T) {
 	}
 }
 
+func TestImageOptionsUnrestorable(t *testing.T) {
+	const (
+		w = 16
+		h = 16
+	)
+
+	src := ebiten.NewImage(w, h)
+	src.Fill(color.RGBA{R: 0xff, A: 0xff})
+
+	op := &ebiten.NewImageOptions{
+		Unrestorable: true,
+	}
+	img := ebiten.NewImageWithOptions(image.Rect(0, 0, w, h), op)
+	img.DrawImage(src, nil)
+
+	// An unrestorable image can be used as a rendering source.
+	dst := ebiten.NewImage(w, h)
+	dst.DrawImage(img, nil)
+
+	for j := 0; j < h; j++ {
+		for i := 0; i < w; i++ {
+			want := color.RGBA{R: 0xff, A: 0xff}
+			if got := img.At(i, j); got != want {
+				t.Errorf("img.At(%d, %d): got: %v, want: %v", i, j, got, want)
+			}
+			if got := dst.At(i, j); got != want {
+				t.Errorf("dst.At(%d, %d): got: %v, want: %v", i, j, got, want)
+			}
+		}
+	}
+}
+
 func TestImageOptionsNegativeBoundsWritePixels(t *testing.T) {
 	const (
 		w = 16
@@ -4808,3 +4841,866 @@ func Fragment(dstPos vec4, srcPos vec2, color vec4) vec4 {
 		}
 	}
 }
+
+func TestImageArrayLayers(t *testing.T) {
+	const w, h = 8, 4
+
+	arr := ebiten.NewImageArray(w, h, 3)
+	if got, want := arr.Len(), 3; got != want {
+		t.Errorf("arr.Len(): got: %d, want: %d", got, want)
+	}
+
+	arr.Layer(1).Fill(color.RGBA{R: 0xff, A: 0xff})
+	for l := 0; l < arr.Len(); l++ {
+		layer := arr.Layer(l)
+		if got, want := layer.Bounds().Size(), image.Pt(w, h); got != want {
+			t.Errorf("arr.Layer(%d).Bounds().Size(): got: %v, want: %v", l, got, want)
+		}
+		var want color.RGBA
+		if l == 1 {
+			want = color.RGBA{R: 0xff, A: 0xff}
+		}
+		b := layer.Bounds()
+		for j := b.Min.Y; j < b.Max.Y; j++ {
+			for i := b.Min.X; i < b.Max.X; i++ {
+				if got := layer.At(i, j).(color.RGBA); got != want {
+					t.Errorf("arr.Layer(%d).At(%d, %d): got: %v, want: %v", l, i, j, got, want)
+				}
+			}
+		}
+	}
+}
+
+func TestImageDrawMesh(t *testing.T) {
+	const w, h = 16, 16
+
+	src := ebiten.NewImage(w, h)
+	src.Fill(color.White)
+
+	mesh := ebiten.NewMesh([]ebiten.Vertex{
+		{DstX: 0, DstY: 0, SrcX: 0, SrcY: 0, ColorR: 1, ColorG: 0, ColorB: 0, ColorA: 1},
+		{DstX: w / 2, DstY: 0, SrcX: w / 2, SrcY: 0, ColorR: 1, ColorG: 0, ColorB: 0, ColorA: 1},
+		{DstX: 0, DstY: h / 2, SrcX: 0, SrcY: h / 2, ColorR: 1, ColorG: 0, ColorB: 0, ColorA: 1},
+		{DstX: w / 2, DstY: h / 2, SrcX: w / 2, SrcY: h / 2, ColorR: 1, ColorG: 0, ColorB: 0, ColorA: 1},
+	}, []uint32{0, 1, 2, 1, 2, 3})
+
+	dst := ebiten.NewImage(w, h)
+	for _, offset := range []image.Point{image.Pt(0, 0), image.Pt(w/2, h/2)} {
+		op := &ebiten.DrawMeshOptions{}
+		op.GeoM.Translate(float64(offset.X), float64(offset.Y))
+		dst.DrawMesh(mesh, src, op)
+	}
+
+	for j := 0; j < h; j++ {
+		for i := 0; i < w; i++ {
+			var want color.RGBA
+			if (i < w/2 && j < h/2) || (i >= w/2 && j >= h/2) {
+				want = color.RGBA{R: 0xff, A: 0xff}
+			}
+			if got := dst.At(i, j).(color.RGBA); got != want {
+				t.Errorf("dst.At(%d, %d): got: %v, want: %v", i, j, got, want)
+			}
+		}
+	}
+}
+
+func TestNewMeshInvalidIndices(t *testing.T) {
+	defer func() {
+		if e := recover(); e == nil {
+			t.Errorf("NewMesh must panic but not")
+		}
+	}()
+	ebiten.NewMesh([]ebiten.Vertex{{}, {}, {}}, []uint32{0, 1, 3})
+}
+
+func TestImageWritePixelsFromImage(t *testing.T) {
+	const (
+		w = 16
+		h = 16
+	)
+
+	nrgba := image.NewNRGBA(image.Rect(0, 0, w, h))
+	gray := image.NewGray(image.Rect(0, 0, w, h))
+	alpha := image.NewAlpha(image.Rect(0, 0, w, h))
+	for j := 0; j < h; j++ {
+		for i := 0; i < w; i++ {
+			nrgba.SetNRGBA(i, j, color.NRGBA{R: byte(16 * i), G: byte(16 * j), B: 0xff, A: 0x80})
+			gray.SetGray(i, j, color.Gray{Y: byte(16 * i)})
+			alpha.SetAlpha(i, j, color.Alpha{A: byte(16 * j)})
+		}
+	}
+
+	for _, src := range []image.Image{nrgba, gray, alpha} {
+		dst := ebiten.NewImage(w*2, h*2)
+		// Write the pixels to a sub-image to check the coordinates.
+		sub := dst.SubImage(image.Rect(w/2, h/2, w*2, h*2)).(*ebiten.Image)
+		sub.WritePixelsFromImage(src, image.Rect(w, h, w*2, h*2))
+
+		for j := 0; j < h*2; j++ {
+			for i := 0; i < w*2; i++ {
+				got := dst.At(i, j)
+				var want color.RGBA
+				if i >= w && j >= h {
+					want = color.RGBAModel.Convert(src.At(i-w, j-h)).(color.RGBA)
+				}
+				if got != want {
+					t.Errorf("%T: dst.At(%d, %d): got: %v, want: %v", src, i, j, got, want)
+				}
+			}
+		}
+	}
+}
+
+func TestImageOptionsStreaming(t *testing.T) {
+	const (
+		w = 16
+		h = 16
+	)
+
+	op := &ebiten.NewImageOptions{
+		Streaming: true,
+	}
+	img := ebiten.NewImageWithOptions(image.Rect(0, 0, w, h), op)
+	pix := make([]byte, 4*w*h)
+
+	// Rewrite the whole pixels more times than the number of internal staging buffers.
+	for n := 0; n < 5; n++ {
+		for i := range pix {
+			pix[i] = byte(n)
+		}
+		img.WritePixels(pix)
+
+		dst := ebiten.NewImage(w, h)
+		dst.DrawImage(img, nil)
+
+		want := color.RGBA{R: byte(n), G: byte(n), B: byte(n), A: byte(n)}
+		for j := 0; j < h; j++ {
+			for i := 0; i < w; i++ {
+				if got := img.At(i, j); got != want {
+					t.Errorf("%d: img.At(%d, %d): got: %v, want: %v", n, i, j, got, want)
+				}
+				if got := dst.At(i, j); got != want {
+					t.Errorf("%d: dst.At(%d, %d): got: %v, want: %v", n, i, j, got, want)
+				}
+			}
+		}
+	}
+}
+
+func TestImagePixelsRegion(t *testing.T) {
+	const (
+		w = 16
+		h = 16
+	)
+
+	img := ebiten.NewImage(w, h)
+	pix := make([]byte, 4*w*h)
+	for j := 0; j < h; j++ {
+		for i := 0; i < w; i++ {
+			idx := 4 * (j*w + i)
+			pix[idx] = byte(i)
+			pix[idx+1] = byte(j)
+			pix[idx+2] = 0
+			pix[idx+3] = 0xff
+		}
+	}
+	img.WritePixels(pix)
+
+	sub := img.SubImage(image.Rect(4, 4, 12, 12)).(*ebiten.Image)
+	region := image.Rect(5, 6, 10, 11)
+	got := sub.Pixels(region)
+	if want := 4 * region.Dx() * region.Dy(); len(got) != want {
+		t.Fatalf("len(Pixels): got: %d, want: %d", len(got), want)
+	}
+	for j := region.Min.Y; j < region.Max.Y; j++ {
+		for i := region.Min.X; i < region.Max.X; i++ {
+			idx := 4 * ((j-region.Min.Y)*region.Dx() + i - region.Min.X)
+			got := color.RGBA{R: got[idx], G: got[idx+1], B: got[idx+2], A: got[idx+3]}
+			want := color.RGBA{R: byte(i), G: byte(j), A: 0xff}
+			if got != want {
+				t.Errorf("(%d, %d): got: %v, want: %v", i, j, got, want)
+			}
+		}
+	}
+
+	// The returned pixels must be a snapshot.
+	img.Fill(color.White)
+	if got[0] != 5 || got[1] != 6 {
+		t.Errorf("the returned pixels must not be changed: got: %v", got[:4])
+	}
+}
+
+func TestDefaultDrawFinalScreenWithDithering(t *testing.T) {
+	for _, mode := range []ebiten.DitheringMode{ebiten.DitheringModeOrdered, ebiten.DitheringModeBlueNoise} {
+		ebiten.SetScreenDithering(mode, 1)
+		if got, _ := ebiten.ScreenDithering(); got != mode {
+			t.Errorf("ebiten.ScreenDithering(): got: %d, want: %d", got, mode)
+		}
+
+		const w, h = 16, 16
+		offscreen := ebiten.NewImage(w, h)
+		clr := color.RGBA{R: 0x80, G: 0x40, B: 0x20, A: 0xff}
+		offscreen.Fill(clr)
+
+		var geoM ebiten.GeoM
+		geoM.Scale(2, 2)
+		screen := ebiten.NewImage(2*w, 2*h)
+		ebiten.DefaultDrawFinalScreen(screen, offscreen, geoM)
+
+		// The noise is less than one 8-bit step, so the colors don't change more than one step.
+		for j := 0; j < 2*h; j++ {
+			for i := 0; i < 2*w; i++ {
+				got := screen.At(i, j).(color.RGBA)
+				if !sameColors(got, clr, 1) {
+					t.Errorf("mode: %d, screen.At(%d, %d): got: %v, want: %v", mode, i, j, got, clr)
+				}
+			}
+		}
+	}
+	ebiten.SetScreenDithering(ebiten.DitheringModeNone, 0)
+}
+
+func TestImageDrawImageWithMask(t *testing.T) {
+	const w, h = 16, 16
+	src := ebiten.NewImage(w, h)
+	src.Fill(color.RGBA{R: 0xff, A: 0xff})
+
+	// The mask is smaller than the source image, and the outside of the mask is masked out.
+	mask := ebiten.NewImage(w/2, h)
+	mask.SubImage(image.Rect(0, 0, w/2, h/2)).(*ebiten.Image).Fill(color.RGBA{G: 0x80, A: 0x80})
+	mask.SubImage(image.Rect(0, h/2, w/2, h)).(*ebiten.Image).Fill(color.White)
+
+	for _, rule := range []ebiten.MaskRule{ebiten.MaskRuleAlpha, ebiten.MaskRuleLuminance} {
+		dst := ebiten.NewImage(w, h)
+		op := &ebiten.DrawImageOptions{}
+		op.Mask = mask
+		op.MaskRule = rule
+		dst.DrawImage(src, op)
+
+		for j := 0; j < h; j++ {
+			for i := 0; i < w; i++ {
+				got := dst.At(i, j).(color.RGBA)
+				var want color.RGBA
+				switch {
+				case i >= w/2:
+				case j >= h/2:
+					want = color.RGBA{R: 0xff, A: 0xff}
+				case rule == ebiten.MaskRuleAlpha:
+					want = color.RGBA{R: 0x80, A: 0x80}
+				case rule == ebiten.MaskRuleLuminance:
+					// The luminance of the premultiplied green (0, 0x80, 0) is 0x80 * 0.7152.
+					want = color.RGBA{R: 0x5b, A: 0x5b}
+				}
+				if !sameColors(got, want, 1) {
+					t.Errorf("rule: %d, dst.At(%d, %d): got: %v, want: %v", rule, i, j, got, want)
+				}
+			}
+		}
+	}
+}
+
+func TestImageDrawImageWithMaskAndSubImage(t *testing.T) {
+	const w, h = 16, 16
+	src := ebiten.NewImage(w, h)
+	src.Fill(color.RGBA{B: 0xff, A: 0xff})
+	mask := ebiten.NewImage(w, h)
+	mask.SubImage(image.Rect(4, 4, 8, 8)).(*ebiten.Image).Fill(color.White)
+
+	// The upper-left corner of the mask matches the upper-left corner of the source.
+	dst := ebiten.NewImage(w, h)
+	op := &ebiten.DrawImageOptions{}
+	op.Mask = mask.SubImage(image.Rect(4, 4, 12, 12)).(*ebiten.Image)
+	op.GeoM.Translate(2, 2)
+	dst.DrawImage(src.SubImage(image.Rect(8, 8, 16, 16)).(*ebiten.Image), op)
+
+	for j := 0; j < h; j++ {
+		for i := 0; i < w; i++ {
+			got := dst.At(i, j).(color.RGBA)
+			var want color.RGBA
+			if 2 <= i && i < 6 && 2 <= j && j < 6 {
+				want = color.RGBA{B: 0xff, A: 0xff}
+			}
+			if got != want {
+				t.Errorf("dst.At(%d, %d): got: %v, want: %v", i, j, got, want)
+			}
+		}
+	}
+}
+
+func TestImageDrawTrianglesWithMask(t *testing.T) {
+	const w, h = 16, 16
+	src := ebiten.NewImage(w, h)
+	src.Fill(color.White)
+	mask := ebiten.NewImage(w, h)
+	mask.SubImage(image.Rect(0, 0, w, h/2)).(*ebiten.Image).Fill(color.White)
+
+	dst := ebiten.NewImage(w, h)
+	vs := []ebiten.Vertex{
+		{DstX: 0, DstY: 0, SrcX: 0, SrcY: 0, ColorR: 1, ColorG: 1, ColorB: 1, ColorA: 1},
+		{DstX: w, DstY: 0, SrcX: w, SrcY: 0, ColorR: 1, ColorG: 1, ColorB: 1, ColorA: 1},
+		{DstX: 0, DstY: h, SrcX: 0, SrcY: h, ColorR: 1, ColorG: 1, ColorB: 1, ColorA: 1},
+		{DstX: w, DstY: h, SrcX: w, SrcY: h, ColorR: 1, ColorG: 1, ColorB: 1, ColorA: 1},
+	}
+	is := []uint16{0, 1, 2, 1, 2, 3}
+	op := &ebiten.DrawTrianglesOptions{}
+	op.Mask = mask
+	dst.DrawTriangles(vs, is, src, op)
+
+	for j := 0; j < h; j++ {
+		for i := 0; i < w; i++ {
+			got := dst.At(i, j).(color.RGBA)
+			var want color.RGBA
+			if j < h/2 {
+				want = color.RGBA{R: 0xff, G: 0xff, B: 0xff, A: 0xff}
+			}
+			if got != want {
+				t.Errorf("dst.At(%d, %d): got: %v, want: %v", i, j, got, want)
+			}
+		}
+	}
+}
+
+func TestImageBlendPresetsForBlendModes(t *testing.T) {
+	// The colors are premultiplied.
+	dstClr := color.RGBA{R: 0x40, G: 0xc0, B: 0x80, A: 0xff}
+	srcClr := color.RGBA{R: 0x40, G: 0x20, B: 0x80, A: 0x80}
+
+	cases := []struct {
+		Name  string
+		Blend ebiten.Blend
+		Want  color.RGBA
+	}{
+		{
+			Name:  "multiply",
+			Blend: ebiten.BlendMultiply,
+			// c_src × c_dst + c_dst × (1 - α_src)
+			Want: color.RGBA{R: 0x30, G: 0x78, B: 0x80, A: 0xff},
+		},
+		{
+			Name:  "screen",
+			Blend: ebiten.BlendScreen,
+			// c_src + c_dst × (1 - c_src)
+			Want: color.RGBA{R: 0x70, G: 0xc8, B: 0xc0, A: 0xff},
+		},
+		{
+			Name:  "additive",
+			Blend: ebiten.BlendAdditive,
+			// c_src + c_dst
+			Want: color.RGBA{R: 0x80, G: 0xe0, B: 0xff, A: 0xff},
+		},
+		{
+			Name:  "subtract",
+			Blend: ebiten.BlendSubtract,
+			// c_dst - c_src
+			Want: color.RGBA{R: 0x00, G: 0xa0, B: 0x00, A: 0xff},
+		},
+		{
+			Name:  "lighten",
+			Blend: ebiten.BlendLighten,
+			// max(c_src, c_dst)
+			Want: color.RGBA{R: 0x40, G: 0xc0, B: 0x80, A: 0xff},
+		},
+	}
+
+	src := ebiten.NewImage(4, 4)
+	src.Fill(srcClr)
+	for _, c := range cases {
+		dst := ebiten.NewImage(4, 4)
+		dst.Fill(dstClr)
+		op := &ebiten.DrawImageOptions{}
+		op.Blend = c.Blend
+		dst.DrawImage(src, op)
+		got := dst.At(0, 0).(color.RGBA)
+		if !sameColors(got, c.Want, 1) {
+			t.Errorf("%s: got: %v, want: %v", c.Name, got, c.Want)
+		}
+	}
+}
+
+func TestImageWritePixelsFloat32OnRGBA8(t *testing.T) {
+	const w, h = 2, 1
+
+	img := ebiten.NewImage(w, h)
+	img.WritePixelsFloat32([]float32{
+		-1, 0.5, 2, 1,
+		0, 0.25, 0.75, 1,
+	})
+
+	// The values are clamped and quantized.
+	want := []color.RGBA{
+		{R: 0, G: 0x80, B: 0xff, A: 0xff},
+		{R: 0, G: 0x40, B: 0xbf, A: 0xff},
+	}
+	for i := 0; i < w; i++ {
+		got := img.At(i, 0).(color.RGBA)
+		if !sameColors(got, want[i], 1) {
+			t.Errorf("img.At(%d, 0): got: %v, want: %v", i, got, want[i])
+		}
+	}
+
+	pix := make([]float32, 4*w*h)
+	img.ReadPixelsFloat32(pix)
+	if got, want := pix[1], float32(0x80)/0xff; got != want {
+		t.Errorf("pix[1]: got: %v, want: %v", got, want)
+	}
+}
+
+func TestImageFloatPixelFormat(t *testing.T) {
+	const w, h = 4, 4
+
+	for _, format := range []ebiten.PixelFormat{ebiten.PixelFormatRGBA16F, ebiten.PixelFormatRGBA32F} {
+		src := ebiten.NewImageWithOptions(image.Rect(0, 0, w, h), &ebiten.NewImageOptions{
+			PixelFormat: format,
+		})
+		pix := make([]float32, 4*w*h)
+		for i := 0; i < len(pix); i += 4 {
+			pix[i] = 0.75
+			pix[i+1] = 2.5
+			pix[i+2] = 0.001
+			pix[i+3] = 1
+		}
+		src.WritePixelsFloat32(pix)
+
+		got := make([]float32, 4*w*h)
+		src.ReadPixelsFloat32(got)
+		if got[1] <= 1 {
+			t.Skipf("floating-point formats are not supported with this graphics library")
+		}
+		for i := range got {
+			if math.Abs(float64(got[i]-pix[i])) > 0.001 {
+				t.Errorf("format: %d, pix[%d]: got: %v, want: %v", format, i, got[i], pix[i])
+				break
+			}
+		}
+
+		// Accumulate the values over 1 without clamping.
+		dst := ebiten.NewImageWithOptions(image.Rect(0, 0, w, h), &ebiten.NewImageOptions{
+			PixelFormat: format,
+		})
+		op := &ebiten.DrawImageOptions{}
+		op.Blend = ebiten.BlendLighter
+		dst.DrawImage(src, op)
+		dst.DrawImage(src, op)
+
+		sub := dst.SubImage(image.Rect(1, 1, 3, 3)).(*ebiten.Image)
+		got = make([]float32, 4*2*2)
+		sub.ReadPixelsFloat32(got)
+		for i := 0; i < len(got); i += 4 {
+			want := [4]float32{1.5, 5, 0.002, 2}
+			for j := range want {
+				if math.Abs(float64(got[i+j]-want[j])) > 0.001 {
+					t.Errorf("format: %d, got[%d]: got: %v, want: %v", format, i+j, got[i+j], want[j])
+				}
+			}
+		}
+	}
+}
+
+func TestImagePremultiplyAlpha(t *testing.T) {
+	pix := []byte{
+		0xff, 0x80, 0x40, 0x80,
+		0x10, 0x20, 0x30, 0xff,
+	}
+	ebiten.PremultiplyAlpha(pix)
+
+	img := ebiten.NewImage(2, 1)
+	img.WritePixels(pix)
+	want := []color.RGBA{
+		{R: 0x80, G: 0x40, B: 0x20, A: 0x80},
+		{R: 0x10, G: 0x20, B: 0x30, A: 0xff},
+	}
+	for i := 0; i < 2; i++ {
+		if got := img.At(i, 0); got != want[i] {
+			t.Errorf("img.At(%d, 0): got: %v, want: %v", i, got, want[i])
+		}
+	}
+
+	img.ReadPixels(pix)
+	ebiten.UnpremultiplyAlpha(pix)
+	if want := []byte{0xff, 0x7f, 0x3f, 0x80, 0x10, 0x20, 0x30, 0xff}; !bytes.Equal(pix, want) {
+		t.Errorf("got: %v, want: %v", pix, want)
+	}
+}
+
+func TestImageWritePixelsWithPremultipliedAlphaValidation(t *testing.T) {
+	ebiten.SetPremultipliedAlphaValidationEnabledForTesting(true)
+	defer ebiten.SetPremultipliedAlphaValidationEnabledForTesting(false)
+
+	img := ebiten.NewImage(4, 4)
+
+	// Premultiplied pixels are valid.
+	pix := make([]byte, 4*2*2)
+	for i := 0; i < len(pix); i += 4 {
+		pix[i] = 0x40
+		pix[i+3] = 0x80
+	}
+	sub := img.SubImage(image.Rect(1, 1, 3, 3)).(*ebiten.Image)
+	sub.WritePixels(pix)
+
+	// A color value exceeding the alpha value is invalid.
+	pix[4*3] = 0xff
+	defer func() {
+		r := recover()
+		if r == nil {
+			t.Fatal("WritePixels must panic but not")
+		}
+		if msg := fmt.Sprint(r); !strings.Contains(msg, "(2, 2)") {
+			t.Errorf("the panic message must include the position (2, 2): %s", msg)
+		}
+	}()
+	sub.WritePixels(pix)
+}
+
+func TestImageSetMipmapMaxLevel(t *testing.T) {
+	const w, h = 64, 64
+	src := ebiten.NewImage(w, h)
+	pix := make([]byte, 4*w*h)
+	for j := 0; j < h; j++ {
+		for i := 0; i < w; i++ {
+			if (i+j)%2 == 0 {
+				continue
+			}
+			idx := 4 * (i + j*w)
+			pix[idx] = 0xff
+			pix[idx+1] = 0xff
+			pix[idx+2] = 0xff
+			pix[idx+3] = 0xff
+		}
+	}
+	src.WritePixels(pix)
+
+	dst0 := ebiten.NewImage(w, h)
+	dst1 := ebiten.NewImage(w, h)
+
+	op := &ebiten.DrawImageOptions{}
+	op.GeoM.Scale(0.125, 0.125)
+	op.Filter = ebiten.FilterLinear
+	op.DisableMipmaps = true
+	dst0.DrawImage(src, op)
+
+	src.SetMipmapMaxLevel(0)
+	op.DisableMipmaps = false
+	dst1.DrawImage(src, op)
+
+	for j := 0; j < h/8; j++ {
+		for i := 0; i < w/8; i++ {
+			got := dst1.At(i, j)
+			want := dst0.At(i, j)
+			if got != want {
+				t.Errorf("dst1.At(%d, %d): got: %v, want: %v", i, j, got, want)
+			}
+		}
+	}
+
+	defer func() {
+		if e := recover(); e == nil {
+			t.Errorf("SetMipmapMaxLevel with a negative level must panic but not")
+		}
+	}()
+	src.SetMipmapMaxLevel(-1)
+}
+
+func TestImageGenerateMipmaps(t *testing.T) {
+	const w, h = 64, 64
+	src0 := ebiten.NewImage(w, h)
+	src1 := ebiten.NewImage(w, h)
+	for _, src := range []*ebiten.Image{src0, src1} {
+		src.Fill(color.RGBA{R: 0xff, A: 0xff})
+		src.SubImage(image.Rect(0, 0, w/2, h)).(*ebiten.Image).Fill(color.RGBA{G: 0xff, A: 0xff})
+	}
+	src0.GenerateMipmaps()
+
+	dst0 := ebiten.NewImage(w, h)
+	dst1 := ebiten.NewImage(w, h)
+	op := &ebiten.DrawImageOptions{}
+	op.GeoM.Scale(0.25, 0.25)
+	op.Filter = ebiten.FilterLinear
+	dst0.DrawImage(src0, op)
+	dst1.DrawImage(src1, op)
+
+	for j := 0; j < h/4; j++ {
+		for i := 0; i < w/4; i++ {
+			got := dst0.At(i, j)
+			want := dst1.At(i, j)
+			if got != want {
+				t.Errorf("dst0.At(%d, %d): got: %v, want: %v", i, j, got, want)
+			}
+		}
+	}
+}
+
+func TestImageDrawImageTrilinear(t *testing.T) {
+	const w, h = 64, 64
+	src := ebiten.NewImage(w, h)
+	src.Fill(color.RGBA{R: 0x80, G: 0x40, B: 0x20, A: 0xff})
+
+	for _, s := range []float64{0.75, 0.5, 0.3, 0.1} {
+		for _, useColorM := range []bool{false, true} {
+			dst := ebiten.NewImage(w, h)
+			op := &ebiten.DrawImageOptions{}
+			op.GeoM.Scale(s, s)
+			op.Filter = ebiten.FilterLinear
+			op.Trilinear = true
+			if useColorM {
+				op.ColorM.Scale(1, 1, 1, 1)
+				op.ColorM.Translate(0, 0, 0, 0)
+				op.ColorM.ChangeHSV(0, 1, 1)
+			}
+			dst.DrawImage(src, op)
+
+			// All the mipmap levels have the same color, then the result must be the same color.
+			got := dst.At(int(float64(w)*s/2), int(float64(h)*s/2)).(color.RGBA)
+			want := color.RGBA{R: 0x80, G: 0x40, B: 0x20, A: 0xff}
+			if !sameColors(got, want, 1) {
+				t.Errorf("scale: %f, useColorM: %t: got: %v, want: %v", s, useColorM, got, want)
+			}
+		}
+	}
+}
+
+func TestImageGutterClamp(t *testing.T) {
+	const w, h = 4, 4
+	tileset := ebiten.NewImage(2*w, h)
+	tileset.SubImage(image.Rect(0, 0, w, h)).(*ebiten.Image).Fill(color.RGBA{R: 0xff, A: 0xff})
+	tileset.SubImage(image.Rect(w, 0, 2*w, h)).(*ebiten.Image).Fill(color.RGBA{G: 0xff, A: 0xff})
+
+	for _, fromEbitenImage := range []bool{false, true} {
+		var src image.Image = tileset.SubImage(image.Rect(0, 0, w, h))
+		if !fromEbitenImage {
+			src = image.NewRGBA(image.Rect(0, 0, w, h))
+			draw.Draw(src.(*image.RGBA), src.Bounds(), image.NewUniform(color.RGBA{R: 0xff, A: 0xff}), image.Point{}, draw.Src)
+		}
+		tile := ebiten.NewImageFromImageWithOptions(src, &ebiten.NewImageFromImageOptions{
+			Gutter:     1,
+			GutterMode: ebiten.GutterModeClamp,
+		})
+
+		const s = 2.5
+		dst := ebiten.NewImage(16, 16)
+		op := &ebiten.DrawImageOptions{}
+		op.GeoM.Scale(s, s)
+		op.GeoM.Translate(0.25, 0.25)
+		op.Filter = ebiten.FilterLinear
+		dst.DrawImage(tile, op)
+
+		// The inner pixels of the rendering result must not be mixed with transparent or green pixels.
+		for j := 1; j < int(w*s); j++ {
+			for i := 1; i < int(w*s); i++ {
+				got := dst.At(i, j).(color.RGBA)
+				want := color.RGBA{R: 0xff, A: 0xff}
+				if got != want {
+					t.Errorf("fromEbitenImage: %t, dst.At(%d, %d): got: %v, want: %v", fromEbitenImage, i, j, got, want)
+				}
+			}
+		}
+	}
+}
+
+func TestImageMightBleed(t *testing.T) {
+	img := ebiten.NewImage(16, 16)
+	sub := img.SubImage(image.Rect(4, 4, 8, 8)).(*ebiten.Image)
+	clamped := ebiten.NewImageWithOptions(image.Rect(0, 0, 16, 16), &ebiten.NewImageOptions{
+		Gutter:     1,
+		GutterMode: ebiten.GutterModeClamp,
+	})
+
+	var identity ebiten.GeoM
+	var translated ebiten.GeoM
+	translated.Translate(3, 5)
+	var fractional ebiten.GeoM
+	fractional.Translate(0.5, 0)
+	var scaled ebiten.GeoM
+	scaled.Scale(2, 2)
+	var shrunk ebiten.GeoM
+	shrunk.Scale(0.5, 0.5)
+
+	testCases := []struct {
+		name   string
+		img    *ebiten.Image
+		filter ebiten.Filter
+		geoM   ebiten.GeoM
+		want   bool
+	}{
+		{"nearest", sub, ebiten.FilterNearest, fractional, false},
+		{"linear identity", sub, ebiten.FilterLinear, identity, false},
+		{"linear translated", sub, ebiten.FilterLinear, translated, false},
+		{"linear fractional", sub, ebiten.FilterLinear, fractional, true},
+		{"linear scaled", sub, ebiten.FilterLinear, scaled, true},
+		{"linear shrunk", sub, ebiten.FilterLinear, shrunk, true},
+		{"pixelated scaled", sub, ebiten.FilterPixelated, scaled, false},
+		{"pixelated shrunk", sub, ebiten.FilterPixelated, shrunk, true},
+		{"linear whole", img, ebiten.FilterLinear, scaled, true},
+		{"linear clamped", clamped, ebiten.FilterLinear, scaled, false},
+		{"linear clamped sub-image", clamped.SubImage(image.Rect(0, 0, 8, 8)).(*ebiten.Image), ebiten.FilterLinear, scaled, true},
+	}
+	for _, tc := range testCases {
+		if got := tc.img.MightBleed(tc.filter, tc.geoM); got != tc.want {
+			t.Errorf("%s: got: %t, want: %t", tc.name, got, tc.want)
+		}
+	}
+}
+
+func TestImageFillLinearGradient(t *testing.T) {
+	const w, h = 16, 4
+	img := ebiten.NewImage(w, h)
+	// The stops are sorted by the offsets.
+	img.FillLinearGradient(0, 0, w, 0, []ebiten.ColorStop{
+		{Offset: 1, Color: color.RGBA{B: 0xff, A: 0xff}},
+		{Offset: 0, Color: color.RGBA{R: 0xff, A: 0xff}},
+	})
+
+	for j := 0; j < h; j++ {
+		for i := 0; i < w; i++ {
+			got := img.At(i, j).(color.RGBA)
+			r := (float64(i) + 0.5) / w
+			want := color.RGBA{R: byte(math.Round(0xff * (1 - r))), B: byte(math.Round(0xff * r)), A: 0xff}
+			if !sameColors(got, want, 1) {
+				t.Errorf("img.At(%d, %d): got: %v, want: %v", i, j, got, want)
+			}
+		}
+	}
+}
+
+func TestImageFillLinearGradientOnSubImage(t *testing.T) {
+	const w, h = 16, 16
+	img := ebiten.NewImage(w, h)
+	img.Fill(color.White)
+
+	// The positions are in the image's coordinate, even on a sub-image.
+	sub := img.SubImage(image.Rect(8, 0, 16, 16)).(*ebiten.Image)
+	sub.FillLinearGradient(0, 8, 0, 12, []ebiten.ColorStop{
+		{Offset: 0, Color: color.RGBA{R: 0xff, A: 0xff}},
+		{Offset: 0.5, Color: color.RGBA{R: 0xff, A: 0xff}},
+		{Offset: 0.5, Color: color.RGBA{G: 0xff, A: 0xff}},
+		{Offset: 1, Color: color.RGBA{G: 0xff, A: 0xff}},
+	})
+
+	for j := 0; j < h; j++ {
+		for i := 0; i < w; i++ {
+			got := img.At(i, j).(color.RGBA)
+			var want color.RGBA
+			switch {
+			case i < 8:
+				want = color.RGBA{R: 0xff, G: 0xff, B: 0xff, A: 0xff}
+			case j < 10:
+				want = color.RGBA{R: 0xff, A: 0xff}
+			default:
+				want = color.RGBA{G: 0xff, A: 0xff}
+			}
+			if got != want {
+				t.Errorf("img.At(%d, %d): got: %v, want: %v", i, j, got, want)
+			}
+		}
+	}
+}
+
+func TestImageFillRadialGradient(t *testing.T) {
+	const w, h = 16, 16
+	img := ebiten.NewImage(w, h)
+	img.FillRadialGradient(8, 8, 4, []ebiten.ColorStop{
+		{Offset: 0, Color: color.RGBA{R: 0xff, A: 0xff}},
+		{Offset: 1, Color: color.RGBA{}},
+	})
+
+	for j := 0; j < h; j++ {
+		for i := 0; i < w; i++ {
+			got := img.At(i, j).(color.RGBA)
+			d := math.Hypot(float64(i)+0.5-8, float64(j)+0.5-8) / 4
+			d = min(d, 1)
+			v := byte(math.Round(0xff * (1 - d)))
+			want := color.RGBA{R: v, A: v}
+			if !sameColors(got, want, 1) {
+				t.Errorf("img.At(%d, %d): got: %v, want: %v", i, j, got, want)
+			}
+		}
+	}
+
+	// A zero radius fills the image with the last color.
+	img.FillRadialGradient(8, 8, 0, []ebiten.ColorStop{
+		{Offset: 0, Color: color.RGBA{R: 0xff, A: 0xff}},
+		{Offset: 1, Color: color.RGBA{B: 0xff, A: 0xff}},
+	})
+	if got, want := img.At(8, 8), (color.RGBA{B: 0xff, A: 0xff}); got != want {
+		t.Errorf("got: %v, want: %v", got, want)
+	}
+}
+
+func TestImageFillGradientWithInvalidStops(t *testing.T) {
+	img := ebiten.NewImage(16, 16)
+	for _, n := range []int{0, 17} {
+		func() {
+			defer func() {
+				if e := recover(); e == nil {
+					t.Errorf("FillLinearGradient with %d stops must panic but not", n)
+				}
+			}()
+			img.FillLinearGradient(0, 0, 16, 0, make([]ebiten.ColorStop, n))
+		}()
+	}
+}
+
+func TestImageAtlasGroup(t *testing.T) {
+	const w, h = 16, 16
+	for _, group := range []ebiten.AtlasGroup{ebiten.AtlasGroupDefault, 1, 2, ebiten.AtlasGroupNone} {
+		src := ebiten.NewImageWithOptions(image.Rect(0, 0, w, h), &ebiten.NewImageOptions{
+			AtlasGroup: group,
+		})
+		src.Fill(color.RGBA{R: 0xff, A: 0xff})
+
+		// Render onto the image many times, and use it as a source.
+		dst := ebiten.NewImageWithOptions(image.Rect(0, 0, w, h), &ebiten.NewImageOptions{
+			AtlasGroup: group,
+		})
+		for i := 0; i < 3; i++ {
+			dst.Clear()
+			dst.DrawImage(src, nil)
+			src.DrawImage(dst, nil)
+		}
+
+		for j := 0; j < h; j++ {
+			for i := 0; i < w; i++ {
+				got := dst.At(i, j).(color.RGBA)
+				want := color.RGBA{R: 0xff, A: 0xff}
+				if got != want {
+					t.Errorf("group: %d, dst.At(%d, %d): got: %v, want: %v", group, i, j, got, want)
+				}
+			}
+		}
+	}
+}
+
+func TestImageInvalidAtlasGroup(t *testing.T) {
+	defer func() {
+		if recover() == nil {
+			t.Errorf("panic expected")
+		}
+	}()
+	ebiten.NewImageWithOptions(image.Rect(0, 0, 16, 16), &ebiten.NewImageOptions{
+		AtlasGroup: -2,
+	})
+}
+
+func TestPanicIfNotSplittable(t *testing.T) {
+	for _, tc := range []struct {
+		fillRule  ebiten.FillRule
+		antiAlias bool
+		panics    bool
+	}{
+		{fillRule: ebiten.FillRuleFillAll, antiAlias: false, panics: false},
+		{fillRule: ebiten.FillRuleFillAll, antiAlias: true, panics: true},
+		{fillRule: ebiten.FillRuleNonZero, antiAlias: false, panics: true},
+		{fillRule: ebiten.FillRuleNonZero, antiAlias: true, panics: true},
+		{fillRule: ebiten.FillRuleEvenOdd, antiAlias: false, panics: true},
+		{fillRule: ebiten.FillRuleEvenOdd, antiAlias: true, panics: true},
+	} {
+		func() {
+			defer func() {
+				r := recover()
+				if got := r != nil; got != tc.panics {
+					t.Errorf("fill rule: %v, anti-alias: %v: panic: got: %v, want: %v", tc.fillRule, tc.antiAlias, r, tc.panics)
+				}
+			}()
+			ebiten.PanicIfNotSplittable(ebiten.MaxVertexCount+1, 3, tc.fillRule, tc.antiAlias)
+		}()
+	}
+}
@@ -0,0 +1,77 @@
+// Copyright 2026 The Ebitengine Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ebiten
+
+import (
+	"fmt"
+	"image"
+)
+
+// ImageArray is an array of images of the same size, called layers.
+//
+// All the layers are located in one internal texture.
+// Then, rendering to various layers can be batched, and a shader can sample any layer with only one source image.
+//
+// A layer is an *Image that can be used as a rendering source and a rendering destination.
+// In a Kage shader, imageSrcNLayerAt samples the specified layer when the 0th layer is given as the Nth source image.
+type ImageArray struct {
+	image  *Image
+	layers []*Image
+}
+
+// NewImageArray returns a new image array with the given size and the number of layers.
+//
+// If width, height or layerCount is less than 1, or the total size is more than device-dependent maximum size, NewImageArray panics.
+//
+// NewImageArray should be called only when necessary, as NewImage should be.
+func NewImageArray(width, height int, layerCount int) *ImageArray {
+	if layerCount <= 0 {
+		panic(fmt.Sprintf("ebiten: layerCount at NewImageArray must be positive but %d", layerCount))
+	}
+	if height <= 0 {
+		panic(fmt.Sprintf("ebiten: height at NewImageArray must be positive but %d", height))
+	}
+
+	// The layers are stacked vertically so that a shader can calculate a layer's position only from the layer's height.
+	img := NewImage(width, height*layerCount)
+	a := &ImageArray{
+		image:  img,
+		layers: make([]*Image, layerCount),
+	}
+	for i := range a.layers {
+		a.layers[i] = img.SubImage(image.Rect(0, i*height, width, (i+1)*height)).(*Image)
+	}
+	return a
+}
+
+// Len returns the number of the layers.
+func (a *ImageArray) Len() int {
+	return len(a.layers)
+}
+
+// Layer returns the layer at index.
+//
+// The returned image's bounds are not (0, 0)-origin in general.
+//
+// If index is out of range, Layer panics.
+func (a *ImageArray) Layer(index int) *Image {
+	return a.layers[index]
+}
+
+// Deallocate clears all the layers and deallocates the internal state of the image array.
+// Even after Deallocate is called, the image array is still available.
+func (a *ImageArray) Deallocate() {
+	a.image.Deallocate()
+}
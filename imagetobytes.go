@@ -22,24 +22,34 @@ import (
 
 // imageToBytes gets RGBA bytes from img.
 //
-// Basically imageToBytes just calls draw.Draw.
-// If img is a paletted image, an optimized copying method is used.
-//
 // If img is *image.RGBA and its length is same as 4*width*height, imageToBytes returns its Pix.
+// Otherwise, imageToBytes converts img by writeImageToBytes.
 func imageToBytes(img image.Image) []byte {
 	size := img.Bounds().Size()
 	w, h := size.X, size.Y
 
-	switch img := img.(type) {
-	case *image.Paletted:
-		bs := make([]byte, 4*w*h)
+	if img, ok := img.(*image.RGBA); ok && len(img.Pix) == 4*w*h {
+		return img.Pix
+	}
+
+	bs := make([]byte, 4*w*h)
+	writeImageToBytes(bs, img)
+	return bs
+}
 
-		b := img.Bounds()
-		x0 := b.Min.X
-		y0 := b.Min.Y
-		x1 := b.Max.X
-		y1 := b.Max.Y
+// writeImageToBytes writes RGBA premultiplied-alpha bytes of img to dst.
+//
+// Basically writeImageToBytes just calls draw.Draw.
+// If img is *image.RGBA, *image.NRGBA, *image.Gray, *image.Alpha, or *image.Paletted, an optimized copying method is used.
+//
+// len(dst) must be 4*width*height of img.
+func writeImageToBytes(dst []byte, img image.Image) {
+	b := img.Bounds()
+	w, h := b.Dx(), b.Dy()
 
+	// Even if img is a subimage of another image, Pix starts with 0-th index.
+	switch img := img.(type) {
+	case *image.Paletted:
 		palette := make([]uint8, len(img.Palette)*4)
 		for i, c := range img.Palette {
 			rgba := color.RGBAModel.Convert(c).(color.RGBA)
@@ -48,43 +58,66 @@ func imageToBytes(img image.Image) []byte {
 			palette[4*i+2] = rgba.B
 			palette[4*i+3] = rgba.A
 		}
-		// Even img is a subimage of another image, Pix starts with 0-th index.
 		idx0 := 0
 		idx1 := 0
-		d := img.Stride - (x1 - x0)
-		for j := 0; j < y1-y0; j++ {
-			for i := 0; i < x1-x0; i++ {
+		d := img.Stride - w
+		for j := 0; j < h; j++ {
+			for i := 0; i < w; i++ {
 				p := int(img.Pix[idx0])
-				bs[idx1] = palette[4*p]
-				bs[idx1+1] = palette[4*p+1]
-				bs[idx1+2] = palette[4*p+2]
-				bs[idx1+3] = palette[4*p+3]
+				dst[idx1] = palette[4*p]
+				dst[idx1+1] = palette[4*p+1]
+				dst[idx1+2] = palette[4*p+2]
+				dst[idx1+3] = palette[4*p+3]
 				idx0++
 				idx1 += 4
 			}
 			idx0 += d
 		}
-		return bs
 	case *image.RGBA:
-		if len(img.Pix) == 4*w*h {
-			return img.Pix
+		for j := 0; j < h; j++ {
+			copy(dst[4*j*w:4*(j+1)*w], img.Pix[j*img.Stride:j*img.Stride+4*w])
+		}
+	case *image.NRGBA:
+		for j := 0; j < h; j++ {
+			src := img.Pix[j*img.Stride : j*img.Stride+4*w]
+			d := dst[4*j*w : 4*(j+1)*w]
+			for i := 0; i < 4*w; i += 4 {
+				// This calculation is the same as image/draw's.
+				a := uint32(src[i+3]) * 0x101
+				d[i] = uint8(uint32(src[i]) * a / 0xff >> 8)
+				d[i+1] = uint8(uint32(src[i+1]) * a / 0xff >> 8)
+				d[i+2] = uint8(uint32(src[i+2]) * a / 0xff >> 8)
+				d[i+3] = src[i+3]
+			}
+		}
+	case *image.Gray:
+		for j := 0; j < h; j++ {
+			src := img.Pix[j*img.Stride : j*img.Stride+w]
+			d := dst[4*j*w : 4*(j+1)*w]
+			for i, y := range src {
+				d[4*i] = y
+				d[4*i+1] = y
+				d[4*i+2] = y
+				d[4*i+3] = 0xff
+			}
+		}
+	case *image.Alpha:
+		for j := 0; j < h; j++ {
+			src := img.Pix[j*img.Stride : j*img.Stride+w]
+			d := dst[4*j*w : 4*(j+1)*w]
+			for i, a := range src {
+				d[4*i] = a
+				d[4*i+1] = a
+				d[4*i+2] = a
+				d[4*i+3] = a
+			}
 		}
-		return imageToBytesSlow(img)
 	default:
-		return imageToBytesSlow(img)
-	}
-}
-
-func imageToBytesSlow(img image.Image) []byte {
-	size := img.Bounds().Size()
-	w, h := size.X, size.Y
-	bs := make([]byte, 4*w*h)
-
-	dstImg := &image.RGBA{
-		Pix:    bs,
-		Stride: 4 * w,
-		Rect:   image.Rect(0, 0, w, h),
+		dstImg := &image.RGBA{
+			Pix:    dst,
+			Stride: 4 * w,
+			Rect:   image.Rect(0, 0, w, h),
+		}
+		draw.Draw(dstImg, image.Rect(0, 0, w, h), img, b.Min, draw.Src)
 	}
-	draw.Draw(dstImg, image.Rect(0, 0, w, h), img, img.Bounds().Min, draw.Src)
-	return bs
 }
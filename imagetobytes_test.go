@@ -91,6 +91,30 @@ func TestImageToBytes(t *testing.T) {
 			}).SubImage(image.Rect(1, 0, 2, 1)),
 			Out: []uint8{0xff, 0xff, 0xff, 0xff},
 		},
+		{
+			In: (&image.NRGBA{
+				Pix:    []uint8{0, 0, 0, 0, 0xff, 0xff, 0xff, 0x80, 0x80, 0x80, 0x80, 0x80, 0, 0, 0, 0},
+				Stride: 8,
+				Rect:   image.Rect(0, 0, 2, 2),
+			}).SubImage(image.Rect(1, 0, 2, 2)),
+			Out: []uint8{0x80, 0x80, 0x80, 0x80, 0, 0, 0, 0},
+		},
+		{
+			In: &image.Gray{
+				Pix:    []uint8{0, 0x40, 0x80, 0xff},
+				Stride: 2,
+				Rect:   image.Rect(0, 0, 2, 2),
+			},
+			Out: []uint8{0, 0, 0, 0xff, 0x40, 0x40, 0x40, 0xff, 0x80, 0x80, 0x80, 0xff, 0xff, 0xff, 0xff, 0xff},
+		},
+		{
+			In: &image.Alpha{
+				Pix:    []uint8{0, 0x40, 0x80, 0xff},
+				Stride: 2,
+				Rect:   image.Rect(0, 0, 2, 2),
+			},
+			Out: []uint8{0, 0, 0, 0, 0x40, 0x40, 0x40, 0x40, 0x80, 0x80, 0x80, 0x80, 0xff, 0xff, 0xff, 0xff},
+		},
 	}
 	for i, c := range cases {
 		got := ebiten.ImageToBytes(c.In)
@@ -117,6 +141,14 @@ func BenchmarkImageToBytesNRGBA(b *testing.B) {
 	}
 }
 
+func BenchmarkImageToBytesGray(b *testing.B) {
+	img := image.NewGray(image.Rect(0, 0, 4096, 4096))
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		ebiten.ImageToBytes(img)
+	}
+}
+
 func BenchmarkImageToBytesPaletted(b *testing.B) {
 	img := image.NewPaletted(image.Rect(0, 0, 4096, 4096), palette.Plan9)
 	b.ResetTimer()
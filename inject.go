@@ -0,0 +1,357 @@
+// Copyright 2026 The Ebitengine Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ebiten
+
+import (
+	"sync"
+
+	"github.com/duplicants-ai/ebiten/internal/gamepad"
+	"github.com/duplicants-ai/ebiten/internal/ui"
+)
+
+// InjectKeyPress injects a synthetic press of the key.
+// The key is treated as pressed until InjectKeyRelease or ClearInjectedInput is called,
+// regardless of the physical keyboard.
+//
+// Injected inputs are reflected from the next tick, and are observed in the same way as physical inputs,
+// e.g., by IsKeyPressed and inpututil.IsKeyJustPressed.
+// To inject a key tap, call InjectKeyRelease at a tick later than InjectKeyPress.
+//
+// InjectKeyPress is concurrent-safe.
+func InjectKeyPress(key Key) {
+	theInjectedInput.setKeyPressed(key, true)
+}
+
+// InjectKeyRelease releases the key pressed by InjectKeyPress.
+// InjectKeyRelease doesn't affect the physical keyboard state.
+//
+// InjectKeyRelease is concurrent-safe.
+func InjectKeyRelease(key Key) {
+	theInjectedInput.setKeyPressed(key, false)
+}
+
+// InjectMouseButtonPress injects a synthetic press of the mouse button.
+// The mouse button is treated as pressed until InjectMouseButtonRelease or ClearInjectedInput is called.
+//
+// InjectMouseButtonPress is concurrent-safe.
+func InjectMouseButtonPress(mouseButton MouseButton) {
+	theInjectedInput.setMouseButtonPressed(mouseButton, true)
+}
+
+// InjectMouseButtonRelease releases the mouse button pressed by InjectMouseButtonPress.
+//
+// InjectMouseButtonRelease is concurrent-safe.
+func InjectMouseButtonRelease(mouseButton MouseButton) {
+	theInjectedInput.setMouseButtonPressed(mouseButton, false)
+}
+
+// InjectCursorPosition injects a synthetic cursor position in the logical screen coordinates,
+// which is the same as CursorPosition.
+// The injected position is used until the physical cursor moves or ClearInjectedInput is called.
+//
+// InjectCursorPosition is concurrent-safe.
+func InjectCursorPosition(x, y float64) {
+	theInjectedInput.setCursorPosition(x, y)
+}
+
+// InjectWheel injects a synthetic wheel offset.
+// The offset is added to the physical wheel offset at the next tick.
+//
+// InjectWheel is concurrent-safe.
+func InjectWheel(xoff, yoff float64) {
+	theInjectedInput.addWheel(xoff, yoff)
+}
+
+// InjectInputChars injects synthetic input characters.
+// The characters are returned by AppendInputChars at the next tick.
+//
+// InjectInputChars is concurrent-safe.
+func InjectInputChars(runes []rune) {
+	theInjectedInput.appendRunes(runes)
+}
+
+// InjectTouchPress injects a synthetic touch at the position in the logical screen coordinates.
+// If a touch with the same ID is already injected, InjectTouchPress moves the touch.
+//
+// The touch is treated as pressed until InjectTouchRelease or ClearInjectedInput is called.
+// If a physical touch has the same ID, the injected touch overrides it.
+// It is recommended to use IDs that physical touches don't use, e.g., negative values.
+//
+// InjectTouchPress is concurrent-safe.
+func InjectTouchPress(id TouchID, x, y int) {
+	theInjectedInput.setTouch(id, x, y)
+}
+
+// InjectTouchRelease releases the touch injected by InjectTouchPress.
+//
+// InjectTouchRelease is concurrent-safe.
+func InjectTouchRelease(id TouchID) {
+	theInjectedInput.removeTouch(id)
+}
+
+// AddInjectedGamepad adds a synthetic gamepad with the standard layout, and returns its ID.
+// The state of the gamepad is set by InjectStandardGamepadButtonValue and InjectStandardGamepadAxisValue.
+//
+// An injected gamepad is observed in the same way as physical gamepads, e.g., by AppendGamepadIDs.
+//
+// AddInjectedGamepad is concurrent-safe.
+func AddInjectedGamepad(name string) GamepadID {
+	return theInjectedInput.addGamepad(name)
+}
+
+// RemoveInjectedGamepad removes the gamepad added by AddInjectedGamepad.
+// RemoveInjectedGamepad does nothing if id is not an injected gamepad.
+//
+// RemoveInjectedGamepad is concurrent-safe.
+func RemoveInjectedGamepad(id GamepadID) {
+	theInjectedInput.removeGamepad(id)
+}
+
+// InjectStandardGamepadButtonValue sets the value of the standard button of the injected gamepad in [0, 1].
+// InjectStandardGamepadButtonValue does nothing if id is not an injected gamepad.
+//
+// Unlike the other injected inputs, the value is reflected to the gamepad functions immediately,
+// and to inpututil's functions from the next tick.
+//
+// InjectStandardGamepadButtonValue is concurrent-safe.
+func InjectStandardGamepadButtonValue(id GamepadID, button StandardGamepadButton, value float64) {
+	if g := theInjectedInput.gamepad(id); g != nil {
+		g.SetStandardButtonValue(button, value)
+	}
+}
+
+// InjectStandardGamepadAxisValue sets the value of the standard axis of the injected gamepad in [-1, 1].
+// InjectStandardGamepadAxisValue does nothing if id is not an injected gamepad.
+//
+// InjectStandardGamepadAxisValue is concurrent-safe.
+func InjectStandardGamepadAxisValue(id GamepadID, axis StandardGamepadAxis, value float64) {
+	if g := theInjectedInput.gamepad(id); g != nil {
+		g.SetStandardAxisValue(axis, value)
+	}
+}
+
+// ClearInjectedInput releases all the injected keys, mouse buttons, and touches,
+// and discards the injected cursor position, wheel offset, and input characters.
+// The gamepads added by AddInjectedGamepad are not removed.
+//
+// ClearInjectedInput is concurrent-safe.
+func ClearInjectedInput() {
+	theInjectedInput.clear()
+}
+
+var theInjectedInput = injectedInput{
+	gamepads: map[GamepadID]*gamepad.VirtualGamepad{},
+}
+
+type injectedInput struct {
+	keyPressed         [ui.KeyMax + 1]bool
+	mouseButtonPressed [ui.MouseButtonMax + 1]bool
+
+	cursorInjected bool
+	cursorX        float64
+	cursorY        float64
+	// physicalCursorX and physicalCursorY are the physical cursor position when the cursor position is injected.
+	physicalCursorX   float64
+	physicalCursorY   float64
+	physicalCursorSet bool
+
+	wheelX  float64
+	wheelY  float64
+	runes   []rune
+	touches []ui.Touch
+
+	gamepads map[GamepadID]*gamepad.VirtualGamepad
+
+	m sync.Mutex
+}
+
+func (i *injectedInput) setKeyPressed(key Key, pressed bool) {
+	if !key.isValid() {
+		return
+	}
+
+	i.m.Lock()
+	defer i.m.Unlock()
+
+	// The virtual modifier keys are treated as the left keys.
+	switch key {
+	case KeyAlt:
+		key = KeyAltLeft
+	case KeyControl:
+		key = KeyControlLeft
+	case KeyShift:
+		key = KeyShiftLeft
+	case KeyMeta:
+		key = KeyMetaLeft
+	}
+	i.keyPressed[key] = pressed
+}
+
+func (i *injectedInput) setMouseButtonPressed(mouseButton MouseButton, pressed bool) {
+	if mouseButton < 0 || mouseButton > MouseButtonMax {
+		return
+	}
+
+	i.m.Lock()
+	defer i.m.Unlock()
+	i.mouseButtonPressed[mouseButton] = pressed
+}
+
+func (i *injectedInput) setCursorPosition(x, y float64) {
+	i.m.Lock()
+	defer i.m.Unlock()
+	i.cursorInjected = true
+	i.cursorX = x
+	i.cursorY = y
+	i.physicalCursorSet = false
+}
+
+func (i *injectedInput) addWheel(xoff, yoff float64) {
+	i.m.Lock()
+	defer i.m.Unlock()
+	i.wheelX += xoff
+	i.wheelY += yoff
+}
+
+func (i *injectedInput) appendRunes(runes []rune) {
+	i.m.Lock()
+	defer i.m.Unlock()
+	i.runes = append(i.runes, runes...)
+}
+
+func (i *injectedInput) setTouch(id TouchID, x, y int) {
+	i.m.Lock()
+	defer i.m.Unlock()
+
+	for j, t := range i.touches {
+		if t.ID == ui.TouchID(id) {
+			i.touches[j].X = x
+			i.touches[j].Y = y
+			return
+		}
+	}
+	i.touches = append(i.touches, ui.Touch{
+		ID: ui.TouchID(id),
+		X:  x,
+		Y:  y,
+	})
+}
+
+func (i *injectedInput) removeTouch(id TouchID) {
+	i.m.Lock()
+	defer i.m.Unlock()
+
+	for j, t := range i.touches {
+		if t.ID == ui.TouchID(id) {
+			i.touches = append(i.touches[:j], i.touches[j+1:]...)
+			return
+		}
+	}
+}
+
+func (i *injectedInput) addGamepad(name string) GamepadID {
+	g := gamepad.AddVirtualGamepad(name)
+
+	i.m.Lock()
+	defer i.m.Unlock()
+	i.gamepads[g.ID()] = g
+	return g.ID()
+}
+
+func (i *injectedInput) removeGamepad(id GamepadID) {
+	i.m.Lock()
+	g, ok := i.gamepads[id]
+	delete(i.gamepads, id)
+	i.m.Unlock()
+
+	if ok {
+		g.Remove()
+	}
+}
+
+func (i *injectedInput) gamepad(id GamepadID) *gamepad.VirtualGamepad {
+	i.m.Lock()
+	defer i.m.Unlock()
+	return i.gamepads[id]
+}
+
+func (i *injectedInput) clear() {
+	i.m.Lock()
+	defer i.m.Unlock()
+
+	i.keyPressed = [ui.KeyMax + 1]bool{}
+	i.mouseButtonPressed = [ui.MouseButtonMax + 1]bool{}
+	i.cursorInjected = false
+	i.physicalCursorSet = false
+	i.wheelX = 0
+	i.wheelY = 0
+	i.runes = i.runes[:0]
+	i.touches = i.touches[:0]
+}
+
+// apply merges the injected inputs into the input state read from the platform.
+func (i *injectedInput) apply(state *ui.InputState) {
+	i.m.Lock()
+	defer i.m.Unlock()
+
+	for k, pressed := range i.keyPressed {
+		if pressed {
+			state.KeyPressed[k] = true
+		}
+	}
+	for b, pressed := range i.mouseButtonPressed {
+		if pressed {
+			state.MouseButtonPressed[b] = true
+		}
+	}
+
+	if i.cursorInjected {
+		if !i.physicalCursorSet {
+			i.physicalCursorX = state.CursorX
+			i.physicalCursorY = state.CursorY
+			i.physicalCursorSet = true
+		}
+		if state.CursorX != i.physicalCursorX || state.CursorY != i.physicalCursorY {
+			// The physical cursor moved. Give the priority to the physical cursor.
+			i.cursorInjected = false
+			i.physicalCursorSet = false
+		} else {
+			state.CursorX = i.cursorX
+			state.CursorY = i.cursorY
+		}
+	}
+
+	state.WheelX += i.wheelX
+	state.WheelY += i.wheelY
+	i.wheelX = 0
+	i.wheelY = 0
+
+	state.Runes = append(state.Runes, i.runes...)
+	i.runes = i.runes[:0]
+
+	for _, t := range i.touches {
+		var found bool
+		for j, t2 := range state.Touches {
+			if t2.ID == t.ID {
+				state.Touches[j] = t
+				found = true
+				break
+			}
+		}
+		if !found {
+			state.Touches = append(state.Touches, t)
+		}
+	}
+}
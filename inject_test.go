@@ -0,0 +1,213 @@
+// Copyright 2026 The Ebitengine Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ebiten_test
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/duplicants-ai/ebiten"
+	"github.com/duplicants-ai/ebiten/internal/ui"
+)
+
+func TestInjectKey(t *testing.T) {
+	t.Cleanup(ebiten.ClearInjectedInput)
+
+	ebiten.InjectKeyPress(ebiten.KeyA)
+	ebiten.InjectKeyPress(ebiten.KeyShift)
+
+	var state ui.InputState
+	state.KeyPressed[ui.KeyB] = true
+	ebiten.ApplyInjectedInputForTesting(&state)
+	if !state.KeyPressed[ui.KeyA] {
+		t.Errorf("KeyA: got: false, want: true")
+	}
+	if !state.KeyPressed[ui.KeyB] {
+		t.Errorf("KeyB: got: false, want: true")
+	}
+	if !state.KeyPressed[ui.KeyShiftLeft] {
+		t.Errorf("KeyShiftLeft: got: false, want: true")
+	}
+	if state.KeyPressed[ui.KeyShiftRight] {
+		t.Errorf("KeyShiftRight: got: true, want: false")
+	}
+
+	ebiten.InjectKeyRelease(ebiten.KeyA)
+	ebiten.InjectKeyRelease(ebiten.KeyB)
+
+	state = ui.InputState{}
+	state.KeyPressed[ui.KeyB] = true
+	ebiten.ApplyInjectedInputForTesting(&state)
+	if state.KeyPressed[ui.KeyA] {
+		t.Errorf("KeyA after release: got: true, want: false")
+	}
+	// Releasing an injected key must not release the physical key.
+	if !state.KeyPressed[ui.KeyB] {
+		t.Errorf("KeyB after release: got: false, want: true")
+	}
+
+	ebiten.ClearInjectedInput()
+
+	state = ui.InputState{}
+	ebiten.ApplyInjectedInputForTesting(&state)
+	if state.KeyPressed[ui.KeyShiftLeft] {
+		t.Errorf("KeyShiftLeft after clear: got: true, want: false")
+	}
+}
+
+func TestInjectMouse(t *testing.T) {
+	t.Cleanup(ebiten.ClearInjectedInput)
+
+	ebiten.InjectMouseButtonPress(ebiten.MouseButtonRight)
+	ebiten.InjectCursorPosition(10, 20)
+	ebiten.InjectWheel(1, 2)
+	ebiten.InjectWheel(0.5, 0)
+
+	state := ui.InputState{
+		CursorX: 1,
+		CursorY: 2,
+		WheelX:  0.25,
+	}
+	state.MouseButtonPressed[ui.MouseButton0] = true
+	ebiten.ApplyInjectedInputForTesting(&state)
+	if !state.MouseButtonPressed[ui.MouseButton0] {
+		t.Errorf("MouseButtonLeft: got: false, want: true")
+	}
+	if !state.MouseButtonPressed[ui.MouseButton2] {
+		t.Errorf("MouseButtonRight: got: false, want: true")
+	}
+	if got, want := [2]float64{state.CursorX, state.CursorY}, [2]float64{10, 20}; got != want {
+		t.Errorf("cursor: got: %v, want: %v", got, want)
+	}
+	if got, want := [2]float64{state.WheelX, state.WheelY}, [2]float64{1.75, 2}; got != want {
+		t.Errorf("wheel: got: %v, want: %v", got, want)
+	}
+
+	// The injected wheel offset is consumed at one tick, while the cursor position is kept.
+	state = ui.InputState{
+		CursorX: 1,
+		CursorY: 2,
+	}
+	ebiten.ApplyInjectedInputForTesting(&state)
+	if got, want := [2]float64{state.CursorX, state.CursorY}, [2]float64{10, 20}; got != want {
+		t.Errorf("cursor at the second tick: got: %v, want: %v", got, want)
+	}
+	if got, want := [2]float64{state.WheelX, state.WheelY}, [2]float64{0, 0}; got != want {
+		t.Errorf("wheel at the second tick: got: %v, want: %v", got, want)
+	}
+
+	// Once the physical cursor moves, the physical position is used.
+	state = ui.InputState{
+		CursorX: 3,
+		CursorY: 4,
+	}
+	ebiten.ApplyInjectedInputForTesting(&state)
+	if got, want := [2]float64{state.CursorX, state.CursorY}, [2]float64{3, 4}; got != want {
+		t.Errorf("cursor after the physical move: got: %v, want: %v", got, want)
+	}
+	state = ui.InputState{
+		CursorX: 3,
+		CursorY: 4,
+	}
+	ebiten.ApplyInjectedInputForTesting(&state)
+	if got, want := [2]float64{state.CursorX, state.CursorY}, [2]float64{3, 4}; got != want {
+		t.Errorf("cursor after the physical move at the next tick: got: %v, want: %v", got, want)
+	}
+
+	ebiten.InjectMouseButtonRelease(ebiten.MouseButtonRight)
+
+	state = ui.InputState{}
+	ebiten.ApplyInjectedInputForTesting(&state)
+	if state.MouseButtonPressed[ui.MouseButton2] {
+		t.Errorf("MouseButtonRight after release: got: true, want: false")
+	}
+}
+
+func TestInjectInputChars(t *testing.T) {
+	t.Cleanup(ebiten.ClearInjectedInput)
+
+	ebiten.InjectInputChars([]rune("ab"))
+	ebiten.InjectInputChars([]rune("c"))
+
+	state := ui.InputState{
+		Runes: []rune("x"),
+	}
+	ebiten.ApplyInjectedInputForTesting(&state)
+	if got, want := string(state.Runes), "xabc"; got != want {
+		t.Errorf("got: %q, want: %q", got, want)
+	}
+
+	state = ui.InputState{}
+	ebiten.ApplyInjectedInputForTesting(&state)
+	if got, want := string(state.Runes), ""; got != want {
+		t.Errorf("at the second tick: got: %q, want: %q", got, want)
+	}
+}
+
+func TestInjectTouch(t *testing.T) {
+	t.Cleanup(ebiten.ClearInjectedInput)
+
+	ebiten.InjectTouchPress(-1, 10, 20)
+	ebiten.InjectTouchPress(2, 30, 40)
+	ebiten.InjectTouchPress(-1, 11, 21)
+
+	sortTouches := func(touches []ui.Touch) {
+		slices.SortFunc(touches, func(a, b ui.Touch) int {
+			return int(a.ID - b.ID)
+		})
+	}
+
+	state := ui.InputState{
+		Touches: []ui.Touch{
+			{ID: 1, X: 1, Y: 2},
+			{ID: 2, X: 3, Y: 4},
+		},
+	}
+	ebiten.ApplyInjectedInputForTesting(&state)
+	sortTouches(state.Touches)
+	want := []ui.Touch{
+		{ID: -1, X: 11, Y: 21},
+		{ID: 1, X: 1, Y: 2},
+		{ID: 2, X: 30, Y: 40},
+	}
+	if !slices.Equal(state.Touches, want) {
+		t.Errorf("got: %v, want: %v", state.Touches, want)
+	}
+
+	ebiten.InjectTouchRelease(-1)
+
+	state = ui.InputState{
+		Touches: []ui.Touch{
+			{ID: 1, X: 1, Y: 2},
+		},
+	}
+	ebiten.ApplyInjectedInputForTesting(&state)
+	sortTouches(state.Touches)
+	want = []ui.Touch{
+		{ID: 1, X: 1, Y: 2},
+		{ID: 2, X: 30, Y: 40},
+	}
+	if !slices.Equal(state.Touches, want) {
+		t.Errorf("after release: got: %v, want: %v", state.Touches, want)
+	}
+
+	ebiten.ClearInjectedInput()
+
+	state = ui.InputState{}
+	ebiten.ApplyInjectedInputForTesting(&state)
+	if len(state.Touches) != 0 {
+		t.Errorf("after clear: got: %v, want: empty", state.Touches)
+	}
+}
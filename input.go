@@ -15,6 +15,7 @@
 package ebiten
 
 import (
+	"image"
 	"io/fs"
 	"sync"
 
@@ -74,6 +75,44 @@ func KeyName(key Key) string {
 	return ui.Get().KeyName(ui.Key(key))
 }
 
+// InputSourceID returns an identifier of the current keyboard input source, i.e. a keyboard layout or an input method.
+//
+// The identifier is platform-specific and opaque. Do not parse it. Use it only to compare with another identifier.
+// For example, InputSourceID returns "com.apple.keylayout.US" on macOS, and a keyboard layout handle like "04090409" on Windows.
+// On browsers, the identifier is made from the key names of the current keyboard layout.
+//
+// When the input source changes, the runes from AppendInputChars and the key names from KeyName might change.
+//
+// InputSourceID returns an empty string if the platform doesn't support it, or the input source is unknown yet.
+//
+// InputSourceID is supported by desktops and browsers. On browsers, the Keyboard API is required.
+//
+// InputSourceID is concurrent-safe.
+func InputSourceID() string {
+	return theInputState.inputSource().ID
+}
+
+// InputSourceLanguage returns a BCP 47 language tag of the current keyboard input source, like "en-US" or "ja".
+//
+// InputSourceLanguage returns an empty string if the language is unknown.
+// The language is available on Windows and macOS.
+//
+// InputSourceLanguage is concurrent-safe.
+func InputSourceLanguage() string {
+	return theInputState.inputSource().Language
+}
+
+// IsInputSourceChanged reports whether the keyboard input source has changed since the previous tick.
+//
+// The input source is polled periodically, so a change might be reported a little later than the actual change.
+//
+// IsInputSourceChanged always returns false if the platform doesn't support InputSourceID.
+//
+// IsInputSourceChanged is concurrent-safe.
+func IsInputSourceChanged() bool {
+	return theInputState.inputSourceChanged()
+}
+
 // CursorPosition returns a position of a mouse cursor relative to the game screen (window). The cursor position is
 // 'logical' position and this considers the scale of the screen.
 //
@@ -81,15 +120,35 @@ func KeyName(key Key) string {
 //
 // CursorPosition always returns (0, 0) on mobile native applications.
 //
+// CursorPosition truncates the position to integers. To get the precise position, use CursorPositionF.
+//
 // CursorPosition is concurrent-safe.
 func CursorPosition() (x, y int) {
 	cx, cy := theInputState.cursorPosition()
 	return int(cx), int(cy)
 }
 
+// CursorPositionF returns a position of a mouse cursor relative to the game screen (window) in floating point.
+// The cursor position is 'logical' position and this considers the scale of the screen.
+//
+// Unlike CursorPosition, CursorPositionF doesn't truncate the position to integers.
+// On high-DPI displays or when the screen is scaled up, the cursor can point to a subpixel position of the game screen.
+// CursorPositionF is useful for smooth inputs like drawing tools.
+//
+// CursorPositionF returns (0, 0) before the main loop on desktops and browsers.
+//
+// CursorPositionF always returns (0, 0) on mobile native applications.
+//
+// CursorPositionF is concurrent-safe.
+func CursorPositionF() (x, y float64) {
+	return theInputState.cursorPosition()
+}
+
 // Wheel returns x and y offsets of the mouse wheel or touchpad scroll.
 // It returns 0 if the wheel isn't being rolled.
 //
+// The offsets can be fractional, e.g. with high-resolution touchpads.
+//
 // Wheel is concurrent-safe.
 func Wheel() (xoff, yoff float64) {
 	return theInputState.wheel()
@@ -243,13 +302,15 @@ func IsGamepadButtonPressed(id GamepadID, button GamepadButton) bool {
 //
 // StandardGamepadAxisValue returns 0 when the gamepad doesn't have a standard gamepad layout mapping.
 //
+// The values of the sticks are filtered by the filter set by SetGamepadAxisFilter or SetGamepadAxisFilterForGamepad.
+//
 // StandardGamepadAxisValue is concurrent safe.
 func StandardGamepadAxisValue(id GamepadID, axis StandardGamepadAxis) float64 {
 	g := gamepad.Get(id)
 	if g == nil {
 		return 0
 	}
-	return g.StandardAxisValue(axis)
+	return standardAxisValue(id, g, axis)
 }
 
 // StandardGamepadButtonValue returns a float value [0.0 - 1.0] of the given gamepad (id)'s standard button (button).
@@ -393,6 +454,7 @@ func (i *inputState) update(fn func(*ui.InputState)) {
 	i.m.Lock()
 	defer i.m.Unlock()
 	fn(&i.state)
+	theInjectedInput.apply(&i.state)
 }
 
 func (i *inputState) appendInputChars(runes []rune) []rune {
@@ -401,6 +463,15 @@ func (i *inputState) appendInputChars(runes []rune) []rune {
 	return append(runes, i.state.Runes...)
 }
 
+func (i *inputState) appendCursorCaptureInterruptions(interruptions []CursorCaptureInterruption) []CursorCaptureInterruption {
+	i.m.Lock()
+	defer i.m.Unlock()
+	for _, c := range i.state.CursorCaptureInterruptions {
+		interruptions = append(interruptions, CursorCaptureInterruption(c))
+	}
+	return interruptions
+}
+
 func (i *inputState) isKeyPressed(key Key) bool {
 	if !key.isValid() {
 		return false
@@ -475,3 +546,99 @@ func (i *inputState) droppedFiles() fs.FS {
 	defer i.m.Unlock()
 	return i.state.DroppedFiles
 }
+
+func (i *inputState) orientation() Orientation {
+	i.m.Lock()
+	defer i.m.Unlock()
+	return Orientation(i.state.Orientation)
+}
+
+func (i *inputState) orientationChanged() bool {
+	i.m.Lock()
+	defer i.m.Unlock()
+	return i.state.OrientationChanged
+}
+
+func (i *inputState) pageHidden() bool {
+	i.m.Lock()
+	defer i.m.Unlock()
+	return i.state.PageHidden
+}
+
+func (i *inputState) pageVisibilityChanged() bool {
+	i.m.Lock()
+	defer i.m.Unlock()
+	return i.state.PageVisibilityChanged
+}
+
+func (i *inputState) graphicsDeviceRestored() bool {
+	i.m.Lock()
+	defer i.m.Unlock()
+	return i.state.GraphicsDeviceRestored
+}
+
+func (i *inputState) systemSettings() ui.SystemSettings {
+	i.m.Lock()
+	defer i.m.Unlock()
+	return i.state.SystemSettings
+}
+
+func (i *inputState) systemSettingsChanged() bool {
+	i.m.Lock()
+	defer i.m.Unlock()
+	return i.state.SystemSettingsChanged
+}
+
+func (i *inputState) powerStatus() ui.PowerStatus {
+	i.m.Lock()
+	defer i.m.Unlock()
+	return i.state.PowerStatus
+}
+
+func (i *inputState) powerStatusChanged() bool {
+	i.m.Lock()
+	defer i.m.Unlock()
+	return i.state.PowerStatusChanged
+}
+
+func (i *inputState) lowPowerModeActive() bool {
+	i.m.Lock()
+	defer i.m.Unlock()
+	return i.state.LowPowerModeActive
+}
+
+func (i *inputState) inputSource() ui.InputSource {
+	i.m.Lock()
+	defer i.m.Unlock()
+	return i.state.InputSource
+}
+
+func (i *inputState) inputSourceChanged() bool {
+	i.m.Lock()
+	defer i.m.Unlock()
+	return i.state.InputSourceChanged
+}
+
+func (i *inputState) safeAreaInsets() ui.Insets {
+	i.m.Lock()
+	defer i.m.Unlock()
+	return i.state.SafeAreaInsets
+}
+
+func (i *inputState) multiWindowMode() MultiWindowModeType {
+	i.m.Lock()
+	defer i.m.Unlock()
+	return MultiWindowModeType(i.state.MultiWindowMode)
+}
+
+func (i *inputState) hingeBounds() image.Rectangle {
+	i.m.Lock()
+	defer i.m.Unlock()
+	return i.state.HingeBounds
+}
+
+func (i *inputState) windowMonitor() *ui.Monitor {
+	i.m.Lock()
+	defer i.m.Unlock()
+	return i.state.WindowMonitor
+}
@@ -0,0 +1,76 @@
+// Copyright 2026 The Ebitengine Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ebiten_test
+
+import (
+	"testing"
+
+	"github.com/duplicants-ai/ebiten"
+	"github.com/duplicants-ai/ebiten/internal/ui"
+)
+
+func TestInputSource(t *testing.T) {
+	t.Cleanup(func() {
+		ebiten.UpdateInputStateForTesting(func(state *ui.InputState) {
+			*state = ui.InputState{}
+		})
+	})
+
+	ebiten.UpdateInputStateForTesting(func(state *ui.InputState) {
+		state.InputSource = ui.InputSource{
+			ID:       "04110411",
+			Language: "ja-JP",
+		}
+		state.InputSourceChanged = true
+	})
+	if got, want := ebiten.InputSourceID(), "04110411"; got != want {
+		t.Errorf("InputSourceID: got: %q, want: %q", got, want)
+	}
+	if got, want := ebiten.InputSourceLanguage(), "ja-JP"; got != want {
+		t.Errorf("InputSourceLanguage: got: %q, want: %q", got, want)
+	}
+	if !ebiten.IsInputSourceChanged() {
+		t.Errorf("IsInputSourceChanged: got: false, want: true")
+	}
+
+	ebiten.UpdateInputStateForTesting(func(state *ui.InputState) {
+		state.InputSourceChanged = false
+	})
+	if got, want := ebiten.InputSourceID(), "04110411"; got != want {
+		t.Errorf("InputSourceID at the next tick: got: %q, want: %q", got, want)
+	}
+	if ebiten.IsInputSourceChanged() {
+		t.Errorf("IsInputSourceChanged at the next tick: got: true, want: false")
+	}
+}
+
+func TestCursorPositionF(t *testing.T) {
+	t.Cleanup(func() {
+		ebiten.UpdateInputStateForTesting(func(state *ui.InputState) {
+			*state = ui.InputState{}
+		})
+	})
+
+	ebiten.UpdateInputStateForTesting(func(state *ui.InputState) {
+		state.CursorX = 10.75
+		state.CursorY = -2.5
+	})
+	if gotX, gotY := ebiten.CursorPositionF(); gotX != 10.75 || gotY != -2.5 {
+		t.Errorf("CursorPositionF: got: (%f, %f), want: (%f, %f)", gotX, gotY, 10.75, -2.5)
+	}
+	if gotX, gotY := ebiten.CursorPosition(); gotX != 10 || gotY != -2 {
+		t.Errorf("CursorPosition: got: (%d, %d), want: (%d, %d)", gotX, gotY, 10, -2)
+	}
+}
@@ -26,6 +26,7 @@ import (
 type gamepadState struct {
 	buttonDurations         [ebiten.GamepadButtonMax + 1]int
 	standardButtonDurations [ebiten.StandardGamepadButtonMax + 1]int
+	standardStickDurations  [ebiten.StandardGamepadStickMax + 1][ebiten.GamepadStickDirectionMax + 1]int
 }
 
 type touchState struct {
@@ -119,6 +120,16 @@ func (i *inputState) update() {
 			}
 		}
 
+		for s := range i.gamepadStates[id].standardStickDurations {
+			for d := range i.gamepadStates[id].standardStickDurations[s] {
+				if ebiten.IsStandardGamepadStickPushed(id, ebiten.StandardGamepadStick(s), ebiten.GamepadStickDirection(d), 0) {
+					state.standardStickDurations[s][d]++
+				} else {
+					state.standardStickDurations[s][d] = 0
+				}
+			}
+		}
+
 		i.gamepadStates[id] = state
 	}
 
@@ -294,6 +305,8 @@ func MouseButtonPressDuration(button ebiten.MouseButton) int {
 //
 // AppendJustConnectedGamepadIDs must be called in a game's Update, not Draw.
 //
+// To get the device information at the time of the connection and disconnection, use ebiten.SetGamepadConnectionHandlers.
+//
 // AppendJustConnectedGamepadIDs is concurrent safe.
 func AppendJustConnectedGamepadIDs(gamepadIDs []ebiten.GamepadID) []ebiten.GamepadID {
 	theInputState.m.RLock()
@@ -596,6 +609,35 @@ func StandardGamepadButtonPressDuration(id ebiten.GamepadID, button ebiten.Stand
 	return state.standardButtonDurations[button]
 }
 
+// IsStandardGamepadStickJustPushed returns a boolean value indicating
+// whether the given standard stick of the gamepad id is pushed toward the direction just in the current tick.
+//
+// The stick is pushed when ebiten.IsStandardGamepadStickPushed with the default threshold returns true.
+//
+// IsStandardGamepadStickJustPushed must be called in a game's Update, not Draw.
+//
+// IsStandardGamepadStickJustPushed is concurrent safe.
+func IsStandardGamepadStickJustPushed(id ebiten.GamepadID, stick ebiten.StandardGamepadStick, direction ebiten.GamepadStickDirection) bool {
+	return StandardGamepadStickPushDuration(id, stick, direction) == 1
+}
+
+// StandardGamepadStickPushDuration returns how long the standard stick of the gamepad id is pushed toward the direction in ticks (Update).
+//
+// StandardGamepadStickPushDuration must be called in a game's Update, not Draw.
+//
+// StandardGamepadStickPushDuration is concurrent safe.
+func StandardGamepadStickPushDuration(id ebiten.GamepadID, stick ebiten.StandardGamepadStick, direction ebiten.GamepadStickDirection) int {
+	theInputState.m.RLock()
+	defer theInputState.m.RUnlock()
+
+	state, ok := theInputState.gamepadStates[id]
+	if !ok {
+		return 0
+	}
+
+	return state.standardStickDurations[stick][direction]
+}
+
 // AppendJustPressedTouchIDs append touch IDs that are created just in the current tick to touchIDs,
 // and returns the extended buffer.
 // Giving a slice that already has enough capacity works efficiently.
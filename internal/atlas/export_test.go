@@ -75,3 +75,9 @@ func BackendCountForTesting() int {
 	defer backendsM.Unlock()
 	return len(theBackends)
 }
+
+func (i *Image) BackendForTesting() *backend {
+	backendsM.Lock()
+	defer backendsM.Unlock()
+	return i.backend
+}
@@ -21,6 +21,7 @@ import (
 	"math/bits"
 	"runtime"
 	"sync"
+	"sync/atomic"
 
 	"github.com/duplicants-ai/ebiten/internal/graphics"
 	"github.com/duplicants-ai/ebiten/internal/graphicsdriver"
@@ -98,6 +99,9 @@ type backend struct {
 	// sourceInThisFrame reports whether this backend is used as a source in this frame.
 	// sourceInThisFrame is reset every frame.
 	sourceInThisFrame bool
+
+	// group is the atlas group of the images on this backend.
+	group int
 }
 
 func (b *backend) tryAlloc(width, height int) (*packing.Node, bool) {
@@ -155,6 +159,37 @@ const (
 
 	// ImageTypeUnmanaged is an unmanaged image that is not on an atlas.
 	ImageTypeUnmanaged
+
+	// ImageTypeUnrestorable is an unmanaged image whose content is not restored when the context is lost.
+	// An unrestorable image is also unmanaged.
+	ImageTypeUnrestorable
+
+	// ImageTypeStreaming is an unrestorable image whose pixels are rewritten frequently, e.g., every frame.
+	// A streaming image is also unmanaged.
+	ImageTypeStreaming
+
+	// ImageTypeDepth is an unrestorable image with a depth buffer.
+	// A depth image is also unmanaged.
+	ImageTypeDepth
+
+	// ImageTypeFloat16 is an unrestorable image with 16-bit floating-point values for each channel.
+	// A float image is also unmanaged.
+	ImageTypeFloat16
+
+	// ImageTypeFloat32 is an unrestorable image with 32-bit floating-point values for each channel.
+	// A float image is also unmanaged.
+	ImageTypeFloat32
+)
+
+// GutterMode represents how the gutter pixels around an image are filled.
+type GutterMode int
+
+const (
+	// GutterModeTransparent fills the gutter with transparent pixels.
+	GutterModeTransparent GutterMode = iota
+
+	// GutterModeClamp fills the gutter with the nearest edge pixels of the image.
+	GutterModeClamp
 )
 
 // Image is a rectangle pixel set that might be on an atlas.
@@ -163,6 +198,18 @@ type Image struct {
 	height    int
 	imageType ImageType
 
+	// gutter is the number of the extra pixels around the image.
+	gutter     int
+	gutterMode GutterMode
+
+	// gutterDirty reports whether the gutter pixels must be updated with the edge pixels before the image is used as a source.
+	// gutterDirty is used only with GutterModeClamp.
+	gutterDirty bool
+
+	// atlasGroup is the group of the atlases the image can be on.
+	// An image is never on the same backend as an image in a different group.
+	atlasGroup int
+
 	backend                   *backend
 	backendCreatedInThisFrame bool
 
@@ -224,6 +271,68 @@ func (i *Image) paddingSize() int {
 	return 0
 }
 
+// sizeWithPadding returns the size of the region including the gutter and the padding.
+func (i *Image) sizeWithPadding() (int, int) {
+	return i.width + 2*i.gutter + i.paddingSize(), i.height + 2*i.gutter + i.paddingSize()
+}
+
+// SetGutter sets the number of the extra pixels around the image, and how the pixels are filled.
+// SetGutter must be called before the image is used.
+func (i *Image) SetGutter(size int, mode GutterMode) {
+	backendsM.Lock()
+	defer backendsM.Unlock()
+
+	if size < 0 {
+		panic(fmt.Sprintf("atlas: the gutter size must be non-negative but %d", size))
+	}
+	if i.backend != nil {
+		panic("atlas: SetGutter must be called before the image is allocated")
+	}
+	i.gutter = size
+	i.gutterMode = mode
+}
+
+var (
+	privateAtlasGroupCount atomic.Int64
+	defaultAtlasGroup      atomic.Int64
+)
+
+// NewPrivateAtlasGroup returns a new atlas group that is different from any other group.
+//
+// A private group is negative, and never equals a group specified by a user, which is non-negative.
+func NewPrivateAtlasGroup() int {
+	return -int(privateAtlasGroupCount.Add(1))
+}
+
+// DefaultAtlasGroup returns the atlas group for a new image whose group is not specified explicitly.
+// The initial value is 0.
+func DefaultAtlasGroup() int {
+	return int(defaultAtlasGroup.Load())
+}
+
+// SetDefaultAtlasGroup sets the atlas group for a new image whose group is not specified explicitly,
+// and returns the previous one.
+func SetDefaultAtlasGroup(group int) int {
+	return int(defaultAtlasGroup.Swap(int64(group)))
+}
+
+// SetAtlasGroup sets the group of the atlases the image can be on.
+// The default group is 0.
+// group must be non-negative or a private group returned by NewPrivateAtlasGroup.
+// SetAtlasGroup must be called before the image is used.
+func (i *Image) SetAtlasGroup(group int) {
+	backendsM.Lock()
+	defer backendsM.Unlock()
+
+	if group < 0 && int64(-group) > privateAtlasGroupCount.Load() {
+		panic(fmt.Sprintf("atlas: the atlas group must be non-negative or private but %d", group))
+	}
+	if i.backend != nil {
+		panic("atlas: SetAtlasGroup must be called before the image is allocated")
+	}
+	i.atlasGroup = group
+}
+
 func (i *Image) ensureIsolatedFromSource(backends []*backend) {
 	i.resetUsedAsSourceCount()
 
@@ -261,6 +370,9 @@ func (i *Image) ensureIsolatedFromSource(backends []*backend) {
 	}
 
 	newI := NewImage(i.width, i.height, i.imageType)
+	newI.gutter = i.gutter
+	newI.gutterMode = i.gutterMode
+	newI.atlasGroup = i.atlasGroup
 
 	// Call allocate explicitly in order to have an isolated backend from the specified backends.
 	// `sourceInThisFrame` of `backends` should be true, so `backends` should be in `bs`.
@@ -272,14 +384,19 @@ func (i *Image) ensureIsolatedFromSource(backends []*backend) {
 	}
 	newI.allocate(bs, false)
 
-	w, h := float32(i.width), float32(i.height)
+	// Copy the gutter as well as the content.
+	g := i.gutter
+	x0, y0 := float32(-g), float32(-g)
+	x1, y1 := float32(i.width+g), float32(i.height+g)
 	vs := make([]float32, 4*graphics.VertexFloatCount)
-	graphics.QuadVerticesFromDstAndSrc(vs, 0, 0, w, h, 0, 0, w, h, 1, 1, 1, 1)
+	graphics.QuadVerticesFromDstAndSrc(vs, x0, y0, x1, y1, x0, y0, x1, y1, 1, 1, 1, 1)
 	is := graphics.QuadIndices()
-	dr := image.Rect(0, 0, i.width, i.height)
-	sr := image.Rect(0, 0, i.width, i.height)
+	dr := image.Rect(-g, -g, i.width+g, i.height+g)
+	sr := image.Rect(-g, -g, i.width+g, i.height+g)
 
 	newI.drawTriangles([graphics.ShaderSrcImageCount]*Image{i}, vs, is, graphicsdriver.BlendCopy, dr, [graphics.ShaderSrcImageCount]image.Rectangle{sr}, NearestFilterShader, nil, graphicsdriver.FillRuleFillAll, restorable.HintOverwriteDstRegion)
+	// The gutter is copied as it is.
+	newI.gutterDirty = i.gutterDirty
 	newI.moveTo(i)
 }
 
@@ -302,15 +419,23 @@ func (i *Image) putOnSourceBackend() {
 	}
 
 	newI := NewImage(i.width, i.height, ImageTypeRegular)
+	newI.gutter = i.gutter
+	newI.gutterMode = i.gutterMode
+	newI.atlasGroup = i.atlasGroup
 	newI.allocate(nil, true)
 
-	w, h := float32(i.width), float32(i.height)
+	// Copy the gutter as well as the content.
+	g := i.gutter
+	x0, y0 := float32(-g), float32(-g)
+	x1, y1 := float32(i.width+g), float32(i.height+g)
 	vs := make([]float32, 4*graphics.VertexFloatCount)
-	graphics.QuadVerticesFromDstAndSrc(vs, 0, 0, w, h, 0, 0, w, h, 1, 1, 1, 1)
+	graphics.QuadVerticesFromDstAndSrc(vs, x0, y0, x1, y1, x0, y0, x1, y1, 1, 1, 1, 1)
 	is := graphics.QuadIndices()
-	dr := image.Rect(0, 0, i.width, i.height)
-	sr := image.Rect(0, 0, i.width, i.height)
+	dr := image.Rect(-g, -g, i.width+g, i.height+g)
+	sr := image.Rect(-g, -g, i.width+g, i.height+g)
 	newI.drawTriangles([graphics.ShaderSrcImageCount]*Image{i}, vs, is, graphicsdriver.BlendCopy, dr, [graphics.ShaderSrcImageCount]image.Rectangle{sr}, NearestFilterShader, nil, graphicsdriver.FillRuleFillAll, restorable.HintOverwriteDstRegion)
+	// The gutter is copied as it is.
+	newI.gutterDirty = i.gutterDirty
 
 	newI.moveTo(i)
 	i.usedAsSourceCount = 0
@@ -325,11 +450,70 @@ func (i *Image) regionWithPadding() image.Rectangle {
 		panic("atlas: backend must not be nil: not allocated yet?")
 	}
 	if !i.isOnAtlas() {
-		return image.Rect(0, 0, i.width+i.paddingSize(), i.height+i.paddingSize())
+		w, h := i.sizeWithPadding()
+		return image.Rect(0, 0, w, h)
 	}
 	return i.node.Region()
 }
 
+// origin returns the position of the image's upper-left pixel on the backend.
+func (i *Image) origin() image.Point {
+	return i.regionWithPadding().Min.Add(image.Pt(i.gutter, i.gutter))
+}
+
+// updateGutter fills the gutter with the edge pixels of the image.
+func (i *Image) updateGutter() {
+	i.gutterDirty = false
+
+	g := i.gutter
+	w, h := i.width, i.height
+
+	// An image cannot be a source and a destination at the same time.
+	// Copy the edge pixels to a temporary image first, and then copy them back to the gutter.
+	tmp := NewImage(w+2*g, h+2*g, ImageTypeUnmanaged)
+	tmp.allocate([]*backend{i.backend}, false)
+	defer func() {
+		tmp.deallocate()
+		runtime.SetFinalizer(tmp, nil)
+	}()
+
+	// Each rectangle is the gutter part in the image's coordinate, and the edge pixels to be stretched there.
+	parts := []struct {
+		dst image.Rectangle
+		src image.Rectangle
+	}{
+		{image.Rect(-g, -g, 0, 0), image.Rect(0, 0, 1, 1)},
+		{image.Rect(0, -g, w, 0), image.Rect(0, 0, w, 1)},
+		{image.Rect(w, -g, w+g, 0), image.Rect(w-1, 0, w, 1)},
+		{image.Rect(-g, 0, 0, h), image.Rect(0, 0, 1, h)},
+		{image.Rect(w, 0, w+g, h), image.Rect(w-1, 0, w, h)},
+		{image.Rect(-g, h, 0, h+g), image.Rect(0, h-1, 1, h)},
+		{image.Rect(0, h, w, h+g), image.Rect(0, h-1, w, h)},
+		{image.Rect(w, h, w+g, h+g), image.Rect(w-1, h-1, w, h)},
+	}
+	vs := make([]float32, 4*graphics.VertexFloatCount*len(parts))
+	is := make([]uint32, 0, 6*len(parts))
+	for idx, p := range parts {
+		// The destination on tmp is shifted by the gutter size.
+		d := p.dst.Add(image.Pt(g, g))
+		graphics.QuadVerticesFromDstAndSrc(vs[4*graphics.VertexFloatCount*idx:], float32(d.Min.X), float32(d.Min.Y), float32(d.Max.X), float32(d.Max.Y), float32(p.src.Min.X), float32(p.src.Min.Y), float32(p.src.Max.X), float32(p.src.Max.Y), 1, 1, 1, 1)
+		for _, v := range graphics.QuadIndices() {
+			is = append(is, v+uint32(4*idx))
+		}
+	}
+	tmp.drawTriangles([graphics.ShaderSrcImageCount]*Image{i}, vs, is, graphicsdriver.BlendCopy, image.Rect(0, 0, w+2*g, h+2*g), [graphics.ShaderSrcImageCount]image.Rectangle{image.Rect(0, 0, w, h)}, NearestFilterShader, nil, graphicsdriver.FillRuleFillAll, restorable.HintNone)
+
+	vs = make([]float32, 4*graphics.VertexFloatCount*len(parts))
+	for idx, p := range parts {
+		s := p.dst.Add(image.Pt(g, g))
+		graphics.QuadVerticesFromDstAndSrc(vs[4*graphics.VertexFloatCount*idx:], float32(p.dst.Min.X), float32(p.dst.Min.Y), float32(p.dst.Max.X), float32(p.dst.Max.Y), float32(s.Min.X), float32(s.Min.Y), float32(s.Max.X), float32(s.Max.Y), 1, 1, 1, 1)
+	}
+	i.drawTriangles([graphics.ShaderSrcImageCount]*Image{tmp}, vs, is, graphicsdriver.BlendCopy, image.Rect(-g, -g, w+g, h+g), [graphics.ShaderSrcImageCount]image.Rectangle{image.Rect(0, 0, w+2*g, h+2*g)}, NearestFilterShader, nil, graphicsdriver.FillRuleFillAll, restorable.HintNone)
+
+	// Drawing onto the gutter doesn't change the image content.
+	i.gutterDirty = false
+}
+
 // DrawTriangles draws triangles with the given image.
 //
 // The vertex floats are:
@@ -364,6 +548,12 @@ func (i *Image) DrawTriangles(srcs [graphics.ShaderSrcImageCount]*Image, vertice
 }
 
 func (i *Image) drawTriangles(srcs [graphics.ShaderSrcImageCount]*Image, vertices []float32, indices []uint32, blend graphicsdriver.Blend, dstRegion image.Rectangle, srcRegions [graphics.ShaderSrcImageCount]image.Rectangle, shader *Shader, uniforms []uint32, fillRule graphicsdriver.FillRule, hint restorable.Hint) {
+	for _, src := range srcs {
+		if src != nil && src.gutterDirty && src.backend != nil {
+			src.updateGutter()
+		}
+	}
+
 	backends := make([]*backend, 0, len(srcs))
 	for _, src := range srcs {
 		if src == nil {
@@ -388,38 +578,11 @@ func (i *Image) drawTriangles(srcs [graphics.ShaderSrcImageCount]*Image, vertice
 		}
 	}
 
-	r := i.regionWithPadding()
+	o := i.origin()
 	// TODO: Check if dstRegion does not to violate the region.
-	dstRegion = dstRegion.Add(r.Min)
-
-	dx, dy := float32(r.Min.X), float32(r.Min.Y)
+	dstRegion = dstRegion.Add(o)
 
-	var oxf, oyf float32
-	if srcs[0] != nil {
-		r := srcs[0].regionWithPadding()
-		oxf, oyf = float32(r.Min.X), float32(r.Min.Y)
-		n := len(vertices)
-		for i := 0; i < n; i += graphics.VertexFloatCount {
-			vertices[i] += dx
-			vertices[i+1] += dy
-			vertices[i+2] += oxf
-			vertices[i+3] += oyf
-		}
-		if shader.unit == shaderir.Texels {
-			sw, sh := srcs[0].backend.restorable.InternalSize()
-			swf, shf := float32(sw), float32(sh)
-			for i := 0; i < n; i += graphics.VertexFloatCount {
-				vertices[i+2] /= swf
-				vertices[i+3] /= shf
-			}
-		}
-	} else {
-		n := len(vertices)
-		for i := 0; i < n; i += graphics.VertexFloatCount {
-			vertices[i] += dx
-			vertices[i+1] += dy
-		}
-	}
+	translateVertices(vertices, shader, srcs, float32(o.X), float32(o.Y))
 
 	var imgs [graphics.ShaderSrcImageCount]*restorable.Image
 	for i, src := range srcs {
@@ -431,8 +594,7 @@ func (i *Image) drawTriangles(srcs [graphics.ShaderSrcImageCount]*Image, vertice
 		// performance issue (#1293).
 		// TODO: This should no longer be needed but is kept just in case. Remove this later.
 		if !srcRegions[i].Empty() {
-			r := src.regionWithPadding()
-			srcRegions[i] = srcRegions[i].Add(r.Min)
+			srcRegions[i] = srcRegions[i].Add(src.origin())
 		}
 		imgs[i] = src.backend.restorable
 		if !src.isOnSourceBackend() && src.canBePutOnAtlas() {
@@ -442,6 +604,195 @@ func (i *Image) drawTriangles(srcs [graphics.ShaderSrcImageCount]*Image, vertice
 	}
 
 	i.backend.restorable.DrawTriangles(imgs, vertices, indices, blend, dstRegion, srcRegions, shader.ensureShader(), uniforms, fillRule, hint)
+
+	if i.gutter > 0 && i.gutterMode == GutterModeClamp {
+		i.gutterDirty = true
+	}
+}
+
+// translateVertices translates the vertices from the images' coordinates to the backends' coordinates.
+// (dx, dy) is the destination image's origin on its backend.
+func translateVertices(vertices []float32, shader *Shader, srcs [graphics.ShaderSrcImageCount]*Image, dx, dy float32) {
+	vertexFloatCount := shader.VertexFloatCount()
+	n := len(vertices)
+	if srcs[0] == nil {
+		for i := 0; i < n; i += vertexFloatCount {
+			vertices[i] += dx
+			vertices[i+1] += dy
+		}
+		return
+	}
+
+	o := srcs[0].origin()
+	oxf, oyf := float32(o.X), float32(o.Y)
+	for i := 0; i < n; i += vertexFloatCount {
+		vertices[i] += dx
+		vertices[i+1] += dy
+		vertices[i+2] += oxf
+		vertices[i+3] += oyf
+	}
+	if shader.unit == shaderir.Texels {
+		sw, sh := srcs[0].backend.restorable.InternalSize()
+		swf, shf := float32(sw), float32(sh)
+		for i := 0; i < n; i += vertexFloatCount {
+			vertices[i+2] /= swf
+			vertices[i+3] /= shf
+		}
+	}
+}
+
+// DrawTrianglesMRT draws triangles with the given image to multiple render targets.
+// See graphicscommand.Image.DrawTrianglesMRT for the details of extraDsts.
+//
+// All the destination images must have the same size and the same gutter.
+// The destination images are moved out of atlases so that they have the same origin on their backends.
+func (i *Image) DrawTrianglesMRT(extraDsts [graphics.ShaderDstImageCount - 1]*Image, srcs [graphics.ShaderSrcImageCount]*Image, vertices []float32, indices []uint32, blend graphicsdriver.Blend, dstRegion image.Rectangle, srcRegions [graphics.ShaderSrcImageCount]image.Rectangle, shader *Shader, uniforms []uint32, fillRule graphicsdriver.FillRule) {
+	backendsM.Lock()
+	defer backendsM.Unlock()
+
+	if !inFrame {
+		vs := make([]float32, len(vertices))
+		copy(vs, vertices)
+		is := make([]uint32, len(indices))
+		copy(is, indices)
+		us := make([]uint32, len(uniforms))
+		copy(us, uniforms)
+
+		appendDeferred(func() {
+			i.drawTrianglesMRT(extraDsts, srcs, vs, is, blend, dstRegion, srcRegions, shader, us, fillRule)
+		})
+		return
+	}
+
+	i.drawTrianglesMRT(extraDsts, srcs, vertices, indices, blend, dstRegion, srcRegions, shader, uniforms, fillRule)
+}
+
+func (i *Image) drawTrianglesMRT(extraDsts [graphics.ShaderDstImageCount - 1]*Image, srcs [graphics.ShaderSrcImageCount]*Image, vertices []float32, indices []uint32, blend graphicsdriver.Blend, dstRegion image.Rectangle, srcRegions [graphics.ShaderSrcImageCount]image.Rectangle, shader *Shader, uniforms []uint32, fillRule graphicsdriver.FillRule) {
+	if extraDsts == ([graphics.ShaderDstImageCount - 1]*Image{}) {
+		i.drawTriangles(srcs, vertices, indices, blend, dstRegion, srcRegions, shader, uniforms, fillRule, restorable.HintNone)
+		return
+	}
+
+	// The backends of the destination images must have the same size, as the projection matrix is calculated with the size.
+	bw, bh := i.sizeWithPadding()
+	for _, extraDst := range extraDsts {
+		if extraDst == nil {
+			continue
+		}
+		if extraDst.width != i.width || extraDst.height != i.height || extraDst.gutter != i.gutter {
+			panic("atlas: all the destination images must have the same size and the same gutter")
+		}
+		if extraDst.imageType == ImageTypeScreen || i.imageType == ImageTypeScreen {
+			panic("atlas: the screen image cannot be one of multiple render targets")
+		}
+		w, h := extraDst.sizeWithPadding()
+		bw = max(bw, w)
+		bh = max(bh, h)
+	}
+
+	for _, src := range srcs {
+		if src != nil && src.gutterDirty && src.backend != nil {
+			src.updateGutter()
+		}
+	}
+
+	i.ensureIsolatedForMRT(bw, bh)
+	for _, extraDst := range extraDsts {
+		if extraDst == nil {
+			continue
+		}
+		extraDst.ensureIsolatedForMRT(bw, bh)
+	}
+
+	var imgs [graphics.ShaderSrcImageCount]*restorable.Image
+	for k, src := range srcs {
+		if src == nil {
+			continue
+		}
+		if src.backend == nil {
+			src.allocate(nil, true)
+		}
+		src.backend.sourceInThisFrame = true
+		if src.backend == i.backend {
+			panic("atlas: Image.DrawTrianglesMRT: source must be different from the destinations")
+		}
+		for _, extraDst := range extraDsts {
+			if extraDst != nil && src.backend == extraDst.backend {
+				panic("atlas: Image.DrawTrianglesMRT: source must be different from the destinations")
+			}
+		}
+
+		if !srcRegions[k].Empty() {
+			srcRegions[k] = srcRegions[k].Add(src.origin())
+		}
+		imgs[k] = src.backend.restorable
+		if !src.isOnSourceBackend() && src.canBePutOnAtlas() {
+			imagesToPutOnSourceBackend.add(src)
+		}
+	}
+
+	// All the destination images have the same origin.
+	o := i.origin()
+	dstRegion = dstRegion.Add(o)
+	translateVertices(vertices, shader, srcs, float32(o.X), float32(o.Y))
+
+	var extraDstImgs [graphics.ShaderDstImageCount - 1]*restorable.Image
+	for k, extraDst := range extraDsts {
+		if extraDst == nil {
+			continue
+		}
+		extraDstImgs[k] = extraDst.backend.restorable
+	}
+
+	i.backend.restorable.DrawTrianglesMRT(extraDstImgs, imgs, vertices, indices, blend, dstRegion, srcRegions, shader.ensureShader(), uniforms, fillRule)
+
+	for _, dst := range append([]*Image{i}, extraDsts[:]...) {
+		if dst != nil && dst.gutter > 0 && dst.gutterMode == GutterModeClamp {
+			dst.gutterDirty = true
+		}
+	}
+}
+
+// ensureIsolatedForMRT ensures that the image is not on an atlas and has its own backend with the given size including the padding.
+func (i *Image) ensureIsolatedForMRT(width, height int) {
+	i.resetUsedAsSourceCount()
+	imagesUsedAsDestination.add(i)
+
+	if i.backend != nil && !i.isOnAtlas() {
+		bw, bh := i.backend.restorable.InternalSize()
+		if bw == graphics.InternalImageSize(width) && bh == graphics.InternalImageSize(height) {
+			return
+		}
+	}
+
+	newI := NewImage(i.width, i.height, i.imageType)
+	newI.gutter = i.gutter
+	newI.gutterMode = i.gutterMode
+	newI.atlasGroup = i.atlasGroup
+	runtime.SetFinalizer(newI, (*Image).finalize)
+	newI.backend = &backend{
+		restorable: restorable.NewImage(width, height, newI.restorableImageType()),
+	}
+	theBackends = append(theBackends, newI.backend)
+
+	if i.backend == nil {
+		newI.backendCreatedInThisFrame = true
+	} else {
+		// Copy the gutter as well as the content.
+		g := i.gutter
+		x0, y0 := float32(-g), float32(-g)
+		x1, y1 := float32(i.width+g), float32(i.height+g)
+		vs := make([]float32, 4*graphics.VertexFloatCount)
+		graphics.QuadVerticesFromDstAndSrc(vs, x0, y0, x1, y1, x0, y0, x1, y1, 1, 1, 1, 1)
+		is := graphics.QuadIndices()
+		dr := image.Rect(-g, -g, i.width+g, i.height+g)
+		sr := image.Rect(-g, -g, i.width+g, i.height+g)
+		newI.drawTriangles([graphics.ShaderSrcImageCount]*Image{i}, vs, is, graphicsdriver.BlendCopy, dr, [graphics.ShaderSrcImageCount]image.Rectangle{sr}, NearestFilterShader, nil, graphicsdriver.FillRuleFillAll, restorable.HintOverwriteDstRegion)
+		newI.gutterDirty = i.gutterDirty
+	}
+
+	newI.moveTo(i)
+	imagesUsedAsDestination.add(i)
 }
 
 // WritePixels replaces the pixels on the image.
@@ -477,10 +828,14 @@ func (i *Image) writePixels(pix []byte, region image.Rectangle) {
 		i.allocate(nil, true)
 	}
 
+	if i.gutter > 0 && i.gutterMode == GutterModeClamp {
+		i.gutterDirty = true
+	}
+
 	r := i.regionWithPadding()
 
-	if !region.Eq(image.Rect(0, 0, i.width, i.height)) || i.paddingSize() == 0 {
-		region = region.Add(r.Min)
+	if !region.Eq(image.Rect(0, 0, i.width, i.height)) || (i.paddingSize() == 0 && i.gutter == 0) {
+		region = region.Add(i.origin())
 
 		if pix == nil {
 			i.backend.restorable.ClearPixels(region)
@@ -495,29 +850,22 @@ func (i *Image) writePixels(pix []byte, region image.Rectangle) {
 		return
 	}
 
-	// TODO: These loops assume that paddingSize is 1.
-	// TODO: Is clearing edges explicitly really needed?
-	const paddingSize = 1
-	if paddingSize != i.paddingSize() {
-		panic(fmt.Sprintf("atlas: writePixels assumes the padding is always 1 but the actual padding was %d", i.paddingSize()))
-	}
-
+	g := i.gutter
 	pixb := graphics.NewManagedBytes(4*r.Dx()*r.Dy(), func(bs []byte) {
-		// Clear the edges. bs might not be zero-cleared.
+		// Clear the gutter and the padding. bs might not be zero-cleared.
 		rowPixels := 4 * r.Dx()
-		for i := 0; i < rowPixels; i++ {
-			bs[rowPixels*(r.Dy()-1)+i] = 0
-		}
-		for j := 1; j < r.Dy(); j++ {
-			bs[rowPixels*j-4] = 0
-			bs[rowPixels*j-3] = 0
-			bs[rowPixels*j-2] = 0
-			bs[rowPixels*j-1] = 0
+		for j := 0; j < r.Dy(); j++ {
+			if j < g || j >= g+region.Dy() {
+				clear(bs[rowPixels*j : rowPixels*(j+1)])
+				continue
+			}
+			clear(bs[rowPixels*j : rowPixels*j+4*g])
+			clear(bs[rowPixels*j+4*(g+region.Dx()) : rowPixels*(j+1)])
 		}
 
 		// Copy the content.
 		for j := 0; j < region.Dy(); j++ {
-			copy(bs[4*j*r.Dx():], pix[4*j*region.Dx():4*(j+1)*region.Dx()])
+			copy(bs[rowPixels*(g+j)+4*g:], pix[4*j*region.Dx():4*(j+1)*region.Dx()])
 		}
 	})
 	i.backend.restorable.WritePixels(pixb, r)
@@ -543,7 +891,65 @@ func (i *Image) ReadPixels(graphicsDriver graphicsdriver.Graphics, pixels []byte
 		return true, nil
 	}
 
-	if err := i.backend.restorable.ReadPixels(graphicsDriver, pixels, region.Add(i.regionWithPadding().Min)); err != nil {
+	if err := i.backend.restorable.ReadPixels(graphicsDriver, pixels, region.Add(i.origin())); err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+// WritePixelsFloat32 replaces the pixels on the image with float32 values.
+func (i *Image) WritePixelsFloat32(pix []float32, region image.Rectangle) {
+	backendsM.Lock()
+	defer backendsM.Unlock()
+
+	// Copy pixels in the case when pix is modified before the graphics command is executed.
+	copied := make([]float32, len(pix))
+	copy(copied, pix)
+
+	if !inFrame {
+		appendDeferred(func() {
+			i.writePixelsFloat32(copied, region)
+		})
+		return
+	}
+
+	i.writePixelsFloat32(copied, region)
+}
+
+func (i *Image) writePixelsFloat32(pix []float32, region image.Rectangle) {
+	if l := 4 * region.Dx() * region.Dy(); len(pix) != l {
+		panic(fmt.Sprintf("atlas: len(p) must be %d but %d", l, len(pix)))
+	}
+
+	i.resetUsedAsSourceCount()
+
+	if i.backend == nil {
+		i.allocate(nil, true)
+	}
+
+	i.backend.restorable.WritePixelsFloat32(pix, region.Add(i.origin()))
+}
+
+// ReadPixelsFloat32 reads the pixels on the image as float32 values.
+func (i *Image) ReadPixelsFloat32(graphicsDriver graphicsdriver.Graphics, pixels []float32, region image.Rectangle) (ok bool, err error) {
+	backendsM.Lock()
+	defer backendsM.Unlock()
+
+	if !inFrame {
+		// Not ready to read pixels. Try this later.
+		return false, nil
+	}
+
+	flushDeferred()
+
+	if i.backend == nil || i.backend.restorable == nil {
+		for i := range pixels {
+			pixels[i] = 0
+		}
+		return true, nil
+	}
+
+	if err := i.backend.restorable.ReadPixelsFloat32(graphicsDriver, pixels, region.Add(i.origin())); err != nil {
 		return false, err
 	}
 	return true, nil
@@ -615,6 +1021,24 @@ func NewImage(width, height int, imageType ImageType) *Image {
 	}
 }
 
+func (i *Image) restorableImageType() restorable.ImageType {
+	switch i.imageType {
+	case ImageTypeVolatile:
+		return restorable.ImageTypeVolatile
+	case ImageTypeUnrestorable:
+		return restorable.ImageTypeUnrestorable
+	case ImageTypeStreaming:
+		return restorable.ImageTypeStreaming
+	case ImageTypeDepth:
+		return restorable.ImageTypeDepth
+	case ImageTypeFloat16:
+		return restorable.ImageTypeFloat16
+	case ImageTypeFloat32:
+		return restorable.ImageTypeFloat32
+	}
+	return restorable.ImageTypeRegular
+}
+
 func (i *Image) canBePutOnAtlas() bool {
 	if minSourceSize == 0 || minDestinationSize == 0 || maxSize == 0 {
 		panic("atlas: min*Size or maxSize must be initialized")
@@ -622,7 +1046,8 @@ func (i *Image) canBePutOnAtlas() bool {
 	if i.imageType != ImageTypeRegular {
 		return false
 	}
-	return i.width+i.paddingSize() <= maxSize && i.height+i.paddingSize() <= maxSize
+	w, h := i.sizeWithPadding()
+	return w <= maxSize && h <= maxSize
 }
 
 func (i *Image) finalize() {
@@ -653,18 +1078,14 @@ func (i *Image) allocate(forbiddenBackends []*backend, asSource bool) {
 		return
 	}
 
-	wp := i.width + i.paddingSize()
-	hp := i.height + i.paddingSize()
+	wp, hp := i.sizeWithPadding()
 
 	if !i.canBePutOnAtlas() {
 		if wp > maxSize || hp > maxSize {
 			panic(fmt.Sprintf("atlas: the image being put on an atlas is too big: width: %d, height: %d", i.width, i.height))
 		}
 
-		typ := restorable.ImageTypeRegular
-		if i.imageType == ImageTypeVolatile {
-			typ = restorable.ImageTypeVolatile
-		}
+		typ := i.restorableImageType()
 		i.backend = &backend{
 			restorable: restorable.NewImage(wp, hp, typ),
 			source:     asSource && typ == restorable.ImageTypeRegular,
@@ -679,6 +1100,9 @@ loop:
 		if b.source != asSource {
 			continue
 		}
+		if b.group != i.atlasGroup {
+			continue
+		}
 		for _, bb := range forbiddenBackends {
 			if b == bb {
 				continue loop
@@ -711,14 +1135,12 @@ loop:
 		height *= 2
 	}
 
-	typ := restorable.ImageTypeRegular
-	if i.imageType == ImageTypeVolatile {
-		typ = restorable.ImageTypeVolatile
-	}
+	typ := i.restorableImageType()
 	b := &backend{
 		restorable: restorable.NewImage(width, height, typ),
 		page:       packing.NewPage(width, height, maxSize),
 		source:     asSource,
+		group:      i.atlasGroup,
 	}
 	theBackends = append(theBackends, b)
 
@@ -828,6 +1250,12 @@ func BeginFrame(graphicsDriver graphicsdriver.Graphics) error {
 	return nil
 }
 
+// MaxImageSize returns the maximum size of an image.
+// MaxImageSize returns 0 before the first frame begins.
+func MaxImageSize() int {
+	return maxSize
+}
+
 func DumpImages(graphicsDriver graphicsdriver.Graphics, dir string) (string, error) {
 	backendsM.Lock()
 	defer backendsM.Unlock()
@@ -914,4 +914,169 @@ func TestDallocateUnmanagedImageBackends(t *testing.T) {
 	}
 }
 
+func TestGutter(t *testing.T) {
+	const (
+		w, h = 4, 4
+		g    = 2
+	)
+
+	for _, mode := range []atlas.GutterMode{atlas.GutterModeTransparent, atlas.GutterModeClamp} {
+		for _, render := range []bool{false, true} {
+			src := atlas.NewImage(w, h, atlas.ImageTypeRegular)
+			src.SetGutter(g, mode)
+
+			pix := make([]byte, 4*w*h)
+			for i := 0; i < w*h; i++ {
+				pix[4*i] = byte(i)
+				pix[4*i+1] = byte(i)
+				pix[4*i+2] = byte(i)
+				pix[4*i+3] = 0xff
+			}
+			if render {
+				// Render the pixels onto the image instead of writing them directly.
+				tmp := atlas.NewImage(w, h, atlas.ImageTypeRegular)
+				tmp.WritePixels(pix, image.Rect(0, 0, w, h))
+				vs := quadVertices(w, h, 0, 0, 1)
+				is := graphics.QuadIndices()
+				dr := image.Rect(0, 0, w, h)
+				sr := image.Rect(0, 0, w, h)
+				src.DrawTriangles([graphics.ShaderSrcImageCount]*atlas.Image{tmp}, vs, is, graphicsdriver.BlendCopy, dr, [graphics.ShaderSrcImageCount]image.Rectangle{sr}, atlas.NearestFilterShader, nil, graphicsdriver.FillRuleFillAll, restorable.HintNone)
+				tmp.Deallocate()
+			} else {
+				src.WritePixels(pix, image.Rect(0, 0, w, h))
+			}
+
+			// Sample the source image including the gutter.
+			const dw, dh = w + 2*g, h + 2*g
+			dst := atlas.NewImage(dw, dh, atlas.ImageTypeRegular)
+			vs := make([]float32, 4*graphics.VertexFloatCount)
+			graphics.QuadVerticesFromDstAndSrc(vs, 0, 0, dw, dh, -g, -g, w+g, h+g, 1, 1, 1, 1)
+			is := graphics.QuadIndices()
+			dr := image.Rect(0, 0, dw, dh)
+			sr := image.Rect(0, 0, w, h)
+			dst.DrawTriangles([graphics.ShaderSrcImageCount]*atlas.Image{src}, vs, is, graphicsdriver.BlendCopy, dr, [graphics.ShaderSrcImageCount]image.Rectangle{sr}, atlas.NearestFilterShader, nil, graphicsdriver.FillRuleFillAll, restorable.HintNone)
+
+			result := make([]byte, 4*dw*dh)
+			ok, err := dst.ReadPixels(ui.Get().GraphicsDriverForTesting(), result, image.Rect(0, 0, dw, dh))
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !ok {
+				t.Fatal("ReadPixels failed")
+			}
+			for j := 0; j < dh; j++ {
+				for i := 0; i < dw; i++ {
+					got := color.RGBA{R: result[4*(dw*j+i)], G: result[4*(dw*j+i)+1], B: result[4*(dw*j+i)+2], A: result[4*(dw*j+i)+3]}
+					var want color.RGBA
+					x, y := i-g, j-g
+					inside := 0 <= x && x < w && 0 <= y && y < h
+					if inside || mode == atlas.GutterModeClamp {
+						x = min(max(x, 0), w-1)
+						y = min(max(y, 0), h-1)
+						c := byte(x + w*y)
+						want = color.RGBA{R: c, G: c, B: c, A: 0xff}
+					}
+					if got != want {
+						t.Errorf("mode: %d, render: %t, at(%d, %d): got %v, want: %v", mode, render, i, j, got, want)
+					}
+				}
+			}
+
+			src.Deallocate()
+			dst.Deallocate()
+		}
+	}
+}
+
 // TODO: Add tests to extend image on an atlas out of the main loop
+
+func TestAtlasGroup(t *testing.T) {
+	newImage := func(group int) *atlas.Image {
+		img := atlas.NewImage(16, 16, atlas.ImageTypeRegular)
+		img.SetAtlasGroup(group)
+		img.EnsureIsolatedFromSourceForTesting(nil)
+		return img
+	}
+
+	img0 := newImage(1)
+	defer img0.Deallocate()
+	img1 := newImage(1)
+	defer img1.Deallocate()
+	img2 := newImage(2)
+	defer img2.Deallocate()
+	img3 := newImage(0)
+	defer img3.Deallocate()
+
+	if img0.BackendForTesting() != img1.BackendForTesting() {
+		t.Errorf("images in the same group must share a backend")
+	}
+	if img0.BackendForTesting() == img2.BackendForTesting() {
+		t.Errorf("images in different groups must not share a backend")
+	}
+	if img0.BackendForTesting() == img3.BackendForTesting() {
+		t.Errorf("an image in a dedicated group must not share a backend with the default group")
+	}
+}
+
+func TestPrivateAtlasGroup(t *testing.T) {
+	g0 := atlas.NewPrivateAtlasGroup()
+	g1 := atlas.NewPrivateAtlasGroup()
+	if g0 >= 0 || g1 >= 0 {
+		t.Fatalf("private groups must be negative but %d and %d", g0, g1)
+	}
+	if g0 == g1 {
+		t.Fatalf("private groups must be different from each other")
+	}
+
+	newImage := func(group int) *atlas.Image {
+		img := atlas.NewImage(16, 16, atlas.ImageTypeRegular)
+		img.SetAtlasGroup(group)
+		img.EnsureIsolatedFromSourceForTesting(nil)
+		return img
+	}
+
+	img0 := newImage(g0)
+	defer img0.Deallocate()
+	img1 := newImage(g0)
+	defer img1.Deallocate()
+	img2 := newImage(g1)
+	defer img2.Deallocate()
+	img3 := newImage(0)
+	defer img3.Deallocate()
+
+	if img0.BackendForTesting() != img1.BackendForTesting() {
+		t.Errorf("images in the same private group must share a backend")
+	}
+	if img0.BackendForTesting() == img2.BackendForTesting() {
+		t.Errorf("images in different private groups must not share a backend")
+	}
+	if img0.BackendForTesting() == img3.BackendForTesting() {
+		t.Errorf("an image in a private group must not share a backend with the default group")
+	}
+}
+
+func TestSetDefaultAtlasGroup(t *testing.T) {
+	g := atlas.NewPrivateAtlasGroup()
+	prev := atlas.SetDefaultAtlasGroup(g)
+	if got, want := atlas.DefaultAtlasGroup(), g; got != want {
+		t.Errorf("atlas.DefaultAtlasGroup(): got: %d, want: %d", got, want)
+	}
+	if got, want := atlas.SetDefaultAtlasGroup(prev), g; got != want {
+		t.Errorf("atlas.SetDefaultAtlasGroup(): got: %d, want: %d", got, want)
+	}
+	if got, want := atlas.DefaultAtlasGroup(), prev; got != want {
+		t.Errorf("atlas.DefaultAtlasGroup(): got: %d, want: %d", got, want)
+	}
+}
+
+func TestInvalidAtlasGroup(t *testing.T) {
+	img := atlas.NewImage(16, 16, atlas.ImageTypeRegular)
+	defer img.Deallocate()
+	defer func() {
+		if recover() == nil {
+			t.Errorf("panic expected")
+		}
+	}()
+	// A negative group that is not returned by NewPrivateAtlasGroup is invalid.
+	img.SetAtlasGroup(-(1 << 30))
+}
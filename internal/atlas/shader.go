@@ -17,26 +17,34 @@ package atlas
 import (
 	"runtime"
 
+	"github.com/duplicants-ai/ebiten/internal/graphics"
 	"github.com/duplicants-ai/ebiten/internal/restorable"
 	"github.com/duplicants-ai/ebiten/internal/shaderir"
 )
 
 type Shader struct {
-	ir     *shaderir.Program
-	shader *restorable.Shader
-	unit   shaderir.Unit
-	name   string
+	ir               *shaderir.Program
+	shader           *restorable.Shader
+	unit             shaderir.Unit
+	vertexFloatCount int
+	name             string
 }
 
 func NewShader(ir *shaderir.Program, name string) *Shader {
 	// A shader is initialized lazily, and the lock is not needed.
 	return &Shader{
-		ir:   ir,
-		name: name,
-		unit: ir.Unit,
+		ir:               ir,
+		name:             name,
+		unit:             ir.Unit,
+		vertexFloatCount: graphics.ShaderVertexFloatCount(ir),
 	}
 }
 
+// VertexFloatCount returns the number of floats in a vertex for the shader.
+func (s *Shader) VertexFloatCount() int {
+	return s.vertexFloatCount
+}
+
 func (s *Shader) finalize() {
 	// A function from finalizer must not be blocked, but disposing operation can be blocked.
 	// Defer this operation until it becomes safe. (#913)
@@ -80,11 +88,13 @@ func (s *Shader) deallocate() {
 
 var (
 	NearestFilterShader = &Shader{
-		shader: restorable.NearestFilterShader,
-		unit:   restorable.NearestFilterShader.Unit(),
+		shader:           restorable.NearestFilterShader,
+		unit:             restorable.NearestFilterShader.Unit(),
+		vertexFloatCount: restorable.NearestFilterShader.VertexFloatCount(),
 	}
 	LinearFilterShader = &Shader{
-		shader: restorable.LinearFilterShader,
-		unit:   restorable.LinearFilterShader.Unit(),
+		shader:           restorable.LinearFilterShader,
+		unit:             restorable.LinearFilterShader.Unit(),
+		vertexFloatCount: restorable.LinearFilterShader.VertexFloatCount(),
 	}
 )
@@ -17,6 +17,7 @@ package buffered
 import (
 	"fmt"
 	"image"
+	"slices"
 
 	"github.com/duplicants-ai/ebiten/internal/atlas"
 	"github.com/duplicants-ai/ebiten/internal/graphics"
@@ -63,6 +64,14 @@ func NewImage(width, height int, imageType atlas.ImageType) *Image {
 	}
 }
 
+func (i *Image) SetGutter(size int, mode atlas.GutterMode) {
+	i.img.SetGutter(size, mode)
+}
+
+func (i *Image) SetAtlasGroup(group int) {
+	i.img.SetAtlasGroup(group)
+}
+
 func (i *Image) Deallocate() {
 	i.img.Deallocate()
 	i.dotsBuffer = nil
@@ -193,6 +202,27 @@ func (i *Image) WritePixels(pix []byte, region image.Rectangle) {
 	i.img.WritePixels(pix, region)
 }
 
+// ReadPixelsFloat32 reads the pixels at the specified region as float32 values.
+//
+// ReadPixelsFloat32 always reads the pixels from GPU, as the pixel cache is in bytes.
+func (i *Image) ReadPixelsFloat32(graphicsDriver graphicsdriver.Graphics, pixels []float32, region image.Rectangle) (bool, error) {
+	i.syncPixelsIfNeeded()
+	return i.img.ReadPixelsFloat32(graphicsDriver, pixels, region)
+}
+
+// WritePixelsFloat32 replaces the pixels at the specified region with float32 values.
+func (i *Image) WritePixelsFloat32(pix []float32, region image.Rectangle) {
+	if l := 4 * region.Dx() * region.Dy(); len(pix) != l {
+		panic(fmt.Sprintf("buffered: len(pix) was %d but must be %d", len(pix), l))
+	}
+
+	i.syncPixelsIfNeeded()
+	i.img.WritePixelsFloat32(pix, region)
+
+	// The pixel cache in bytes is no longer valid.
+	i.pixels = nil
+}
+
 // DrawTriangles draws the src image with the given vertices.
 //
 // Copying vertices and indices is the caller's responsibility.
@@ -224,6 +254,52 @@ func (i *Image) DrawTriangles(srcs [graphics.ShaderSrcImageCount]*Image, vertice
 	i.pixels = nil
 }
 
+// DrawTrianglesMRT draws the src image with the given vertices to multiple render targets.
+// See graphicscommand.Image.DrawTrianglesMRT for the details of extraDsts.
+//
+// Copying vertices and indices is the caller's responsibility.
+func (i *Image) DrawTrianglesMRT(extraDsts [graphics.ShaderDstImageCount - 1]*Image, srcs [graphics.ShaderSrcImageCount]*Image, vertices []float32, indices []uint32, blend graphicsdriver.Blend, dstRegion image.Rectangle, srcRegions [graphics.ShaderSrcImageCount]image.Rectangle, shader *atlas.Shader, uniforms []uint32, fillRule graphicsdriver.FillRule) {
+	for _, src := range srcs {
+		if src == nil {
+			continue
+		}
+		if i == src || slices.Contains(extraDsts[:], src) {
+			panic("buffered: Image.DrawTrianglesMRT: source images must be different from the destinations")
+		}
+		src.syncPixelsIfNeeded()
+	}
+
+	i.syncPixelsIfNeeded()
+
+	var extraDstImgs [graphics.ShaderDstImageCount - 1]*atlas.Image
+	for k, extraDst := range extraDsts {
+		if extraDst == nil {
+			continue
+		}
+		extraDst.syncPixelsIfNeeded()
+		extraDstImgs[k] = extraDst.img
+	}
+
+	var imgs [graphics.ShaderSrcImageCount]*atlas.Image
+	for i, img := range srcs {
+		if img == nil {
+			continue
+		}
+		imgs[i] = img.img
+	}
+
+	i.img.DrawTrianglesMRT(extraDstImgs, imgs, vertices, indices, blend, dstRegion, srcRegions, shader, uniforms, fillRule)
+
+	// After rendering, the pixel caches are no longer valid.
+	i.pixels = nil
+	for _, extraDst := range extraDsts {
+		if extraDst == nil {
+			continue
+		}
+		extraDst.pixels = nil
+	}
+}
+
 // syncPixelsIfNeeded syncs the pixels between CPU and GPU.
 // After syncPixelsIfNeeded, dotsBuffer is cleared, but pixels might remain.
 func (i *Image) syncPixelsIfNeeded() {
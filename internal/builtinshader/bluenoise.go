@@ -0,0 +1,137 @@
+// Copyright 2026 The Ebitengine Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package builtinshader
+
+import (
+	"math"
+	"math/rand/v2"
+	"sync"
+)
+
+const blueNoiseSize = 16
+
+var (
+	blueNoise     []float32
+	blueNoiseOnce sync.Once
+)
+
+// BlueNoise returns a 16x16 blue-noise threshold map in row-major order.
+// Each value is in [-0.5, 0.5).
+//
+// The map is generated by the void-and-cluster method at the first call.
+func BlueNoise() []float32 {
+	blueNoiseOnce.Do(func() {
+		blueNoise = generateBlueNoise()
+	})
+	return blueNoise
+}
+
+func generateBlueNoise() []float32 {
+	const (
+		size  = blueNoiseSize
+		n     = size * size
+		sigma = 1.5
+	)
+
+	// kernel is the gaussian energy for each offset on the torus.
+	var kernel [size][size]float64
+	for dy := 0; dy < size; dy++ {
+		for dx := 0; dx < size; dx++ {
+			x := float64(min(dx, size-dx))
+			y := float64(min(dy, size-dy))
+			kernel[dy][dx] = math.Exp(-(x*x + y*y) / (2 * sigma * sigma))
+		}
+	}
+
+	var pattern [n]bool
+	var energy [n]float64
+	update := func(pattern *[n]bool, energy *[n]float64, i int, on bool) {
+		pattern[i] = on
+		sign := 1.0
+		if !on {
+			sign = -1
+		}
+		x0, y0 := i%size, i/size
+		for j := range energy {
+			dx := (j%size - x0 + size) % size
+			dy := (j/size - y0 + size) % size
+			energy[j] += sign * kernel[dy][dx]
+		}
+	}
+	// tightestCluster returns the set pixel with the highest energy.
+	tightestCluster := func(pattern *[n]bool, energy *[n]float64) int {
+		idx := -1
+		for i := range pattern {
+			if pattern[i] && (idx < 0 || energy[i] > energy[idx]) {
+				idx = i
+			}
+		}
+		return idx
+	}
+	// largestVoid returns the unset pixel with the lowest energy.
+	largestVoid := func(pattern *[n]bool, energy *[n]float64) int {
+		idx := -1
+		for i := range pattern {
+			if !pattern[i] && (idx < 0 || energy[i] < energy[idx]) {
+				idx = i
+			}
+		}
+		return idx
+	}
+
+	// Start with a deterministic random pattern, and relax it by moving the tightest cluster to the largest void.
+	r := rand.New(rand.NewPCG(1, 2))
+	const initialCount = n / 10
+	for count := 0; count < initialCount; {
+		i := r.IntN(n)
+		if pattern[i] {
+			continue
+		}
+		update(&pattern, &energy, i, true)
+		count++
+	}
+	for {
+		c := tightestCluster(&pattern, &energy)
+		update(&pattern, &energy, c, false)
+		v := largestVoid(&pattern, &energy)
+		update(&pattern, &energy, v, true)
+		if v == c {
+			break
+		}
+	}
+
+	var ranks [n]int
+
+	// Rank the initial pixels by removing the tightest clusters one by one.
+	p, e := pattern, energy
+	for rank := initialCount - 1; rank >= 0; rank-- {
+		c := tightestCluster(&p, &e)
+		update(&p, &e, c, false)
+		ranks[c] = rank
+	}
+
+	// Rank the other pixels by filling the largest voids one by one.
+	for rank := initialCount; rank < n; rank++ {
+		v := largestVoid(&pattern, &energy)
+		update(&pattern, &energy, v, true)
+		ranks[v] = rank
+	}
+
+	values := make([]float32, n)
+	for i, rank := range ranks {
+		values[i] = (float32(rank)+0.5)/n - 0.5
+	}
+	return values
+}
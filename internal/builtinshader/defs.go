@@ -73,3 +73,129 @@ const _ = "//kage:unit pixels\n\npackage main\n\n\n\n\nfunc adjustSrcPosForAddre
 
 //ebitengine:shadersource
 const _ = "//kage:unit pixels\n\npackage main\n\n\nvar ColorMBody mat4\nvar ColorMTranslation vec4\n\n\n\nfunc adjustSrcPosForAddressRepeat(p vec2) vec2 {\n\torigin := imageSrc0Origin()\n\tsize := imageSrc0Size()\n\treturn mod(p - origin, size) + origin\n}\n\n\nfunc Fragment(dstPos vec4, srcPos vec2, color vec4) vec4 {\n\n\n\t// inversedScale is the size of the region on the source image.\n\t// The size is the inverse of the geometry-matrix scale.\n\tinversedScale := vec2(abs(dfdx(srcPos.x)), abs(dfdy(srcPos.y)))\n\t// Cap the inversedScale to 1 as dfdx/dfdy is not accurate on some machines (#3182).\n\tinversedScale = min(inversedScale, vec2(1))\n\tp0 := srcPos - inversedScale/2.0\n\tp1 := srcPos + inversedScale/2.0\n\n\n\n\tp0 = adjustSrcPosForAddressRepeat(p0)\n\tp1 = adjustSrcPosForAddressRepeat(p1)\n\n\n\n\tc0 := imageSrc0At(p0)\n\tc1 := imageSrc0At(vec2(p1.x, p0.y))\n\tc2 := imageSrc0At(vec2(p0.x, p1.y))\n\tc3 := imageSrc0At(p1)\n\n\n\n\trate := clamp(fract(p1)/inversedScale, 0, 1)\n\n\tclr := mix(mix(c0, c1, rate.x), mix(c2, c3, rate.x), rate.y)\n\n\n\n\t// Un-premultiply alpha.\n\t// When the alpha is 0, 1-sign(alpha) is 1.0, which means division does nothing.\n\tclr.rgb /= clr.a + (1-sign(clr.a))\n\t// Apply the clr matrix.\n\tclr = (ColorMBody * clr) + ColorMTranslation\n\t// Premultiply alpha\n\tclr.rgb *= clr.a\n\t// Apply the color scale.\n\tclr *= color\n\t// Clamp the output.\n\tclr.rgb = min(clr.rgb, clr.a)\n\n\n\treturn clr\n}\n\n"
+
+//ebitengine:shadersource
+const _ = "//kage:unit pixels\n\npackage main\n\n\n\n\n\nfunc Fragment(dstPos vec4, srcPos vec2, color vec4) vec4 {\n\n\n\tclr := imageSrc0UnsafeAt(srcPos)\n\n\n\n\n\t// Apply the color scale.\n\tclr *= color\n\n\t// Apply the mask, which is the second source image placed at the same position as the first one.\n\n\n\tm := imageSrc1At(srcPos)\n\n\n\n\tclr *= m.a\n\n\n\n\treturn clr\n}\n\n"
+
+//ebitengine:shadersource
+const _ = "//kage:unit pixels\n\npackage main\n\n\n\n\n\nfunc Fragment(dstPos vec4, srcPos vec2, color vec4) vec4 {\n\n\n\tclr := imageSrc0UnsafeAt(srcPos)\n\n\n\n\n\t// Apply the color scale.\n\tclr *= color\n\n\t// Apply the mask, which is the second source image placed at the same position as the first one.\n\n\n\tm := imageSrc1At(srcPos)\n\n\n\n\t// As the mask color is premultiplied, the luminance is 0 where the mask is transparent.\n\tclr *= dot(m.rgb, vec3(0.2126, 0.7152, 0.0722))\n\n\n\n\treturn clr\n}\n\n"
+
+//ebitengine:shadersource
+const _ = "//kage:unit pixels\n\npackage main\n\n\nvar ColorMBody mat4\nvar ColorMTranslation vec4\n\n\n\n\nfunc Fragment(dstPos vec4, srcPos vec2, color vec4) vec4 {\n\n\n\tclr := imageSrc0UnsafeAt(srcPos)\n\n\n\n\n\t// Un-premultiply alpha.\n\t// When the alpha is 0, 1-sign(alpha) is 1.0, which means division does nothing.\n\tclr.rgb /= clr.a + (1-sign(clr.a))\n\t// Apply the clr matrix.\n\tclr = (ColorMBody * clr) + ColorMTranslation\n\t// Premultiply alpha\n\tclr.rgb *= clr.a\n\t// Apply the color scale.\n\tclr *= color\n\t// Clamp the output.\n\tclr.rgb = min(clr.rgb, clr.a)\n\n\t// Apply the mask, which is the second source image placed at the same position as the first one.\n\n\n\tm := imageSrc1At(srcPos)\n\n\n\n\tclr *= m.a\n\n\n\n\treturn clr\n}\n\n"
+
+//ebitengine:shadersource
+const _ = "//kage:unit pixels\n\npackage main\n\n\nvar ColorMBody mat4\nvar ColorMTranslation vec4\n\n\n\n\nfunc Fragment(dstPos vec4, srcPos vec2, color vec4) vec4 {\n\n\n\tclr := imageSrc0UnsafeAt(srcPos)\n\n\n\n\n\t// Un-premultiply alpha.\n\t// When the alpha is 0, 1-sign(alpha) is 1.0, which means division does nothing.\n\tclr.rgb /= clr.a + (1-sign(clr.a))\n\t// Apply the clr matrix.\n\tclr = (ColorMBody * clr) + ColorMTranslation\n\t// Premultiply alpha\n\tclr.rgb *= clr.a\n\t// Apply the color scale.\n\tclr *= color\n\t// Clamp the output.\n\tclr.rgb = min(clr.rgb, clr.a)\n\n\t// Apply the mask, which is the second source image placed at the same position as the first one.\n\n\n\tm := imageSrc1At(srcPos)\n\n\n\n\t// As the mask color is premultiplied, the luminance is 0 where the mask is transparent.\n\tclr *= dot(m.rgb, vec3(0.2126, 0.7152, 0.0722))\n\n\n\n\treturn clr\n}\n\n"
+
+//ebitengine:shadersource
+const _ = "//kage:unit pixels\n\npackage main\n\n\n\n\n\nfunc Fragment(dstPos vec4, srcPos vec2, color vec4) vec4 {\n\n\n\tclr := imageSrc0At(srcPos)\n\n\n\n\n\t// Apply the color scale.\n\tclr *= color\n\n\t// Apply the mask, which is the second source image placed at the same position as the first one.\n\n\n\tm := imageSrc1At(srcPos)\n\n\n\n\tclr *= m.a\n\n\n\n\treturn clr\n}\n\n"
+
+//ebitengine:shadersource
+const _ = "//kage:unit pixels\n\npackage main\n\n\n\n\n\nfunc Fragment(dstPos vec4, srcPos vec2, color vec4) vec4 {\n\n\n\tclr := imageSrc0At(srcPos)\n\n\n\n\n\t// Apply the color scale.\n\tclr *= color\n\n\t// Apply the mask, which is the second source image placed at the same position as the first one.\n\n\n\tm := imageSrc1At(srcPos)\n\n\n\n\t// As the mask color is premultiplied, the luminance is 0 where the mask is transparent.\n\tclr *= dot(m.rgb, vec3(0.2126, 0.7152, 0.0722))\n\n\n\n\treturn clr\n}\n\n"
+
+//ebitengine:shadersource
+const _ = "//kage:unit pixels\n\npackage main\n\n\nvar ColorMBody mat4\nvar ColorMTranslation vec4\n\n\n\n\nfunc Fragment(dstPos vec4, srcPos vec2, color vec4) vec4 {\n\n\n\tclr := imageSrc0At(srcPos)\n\n\n\n\n\t// Un-premultiply alpha.\n\t// When the alpha is 0, 1-sign(alpha) is 1.0, which means division does nothing.\n\tclr.rgb /= clr.a + (1-sign(clr.a))\n\t// Apply the clr matrix.\n\tclr = (ColorMBody * clr) + ColorMTranslation\n\t// Premultiply alpha\n\tclr.rgb *= clr.a\n\t// Apply the color scale.\n\tclr *= color\n\t// Clamp the output.\n\tclr.rgb = min(clr.rgb, clr.a)\n\n\t// Apply the mask, which is the second source image placed at the same position as the first one.\n\n\n\tm := imageSrc1At(srcPos)\n\n\n\n\tclr *= m.a\n\n\n\n\treturn clr\n}\n\n"
+
+//ebitengine:shadersource
+const _ = "//kage:unit pixels\n\npackage main\n\n\nvar ColorMBody mat4\nvar ColorMTranslation vec4\n\n\n\n\nfunc Fragment(dstPos vec4, srcPos vec2, color vec4) vec4 {\n\n\n\tclr := imageSrc0At(srcPos)\n\n\n\n\n\t// Un-premultiply alpha.\n\t// When the alpha is 0, 1-sign(alpha) is 1.0, which means division does nothing.\n\tclr.rgb /= clr.a + (1-sign(clr.a))\n\t// Apply the clr matrix.\n\tclr = (ColorMBody * clr) + ColorMTranslation\n\t// Premultiply alpha\n\tclr.rgb *= clr.a\n\t// Apply the color scale.\n\tclr *= color\n\t// Clamp the output.\n\tclr.rgb = min(clr.rgb, clr.a)\n\n\t// Apply the mask, which is the second source image placed at the same position as the first one.\n\n\n\tm := imageSrc1At(srcPos)\n\n\n\n\t// As the mask color is premultiplied, the luminance is 0 where the mask is transparent.\n\tclr *= dot(m.rgb, vec3(0.2126, 0.7152, 0.0722))\n\n\n\n\treturn clr\n}\n\n"
+
+//ebitengine:shadersource
+const _ = "//kage:unit pixels\n\npackage main\n\n\n\n\nfunc adjustSrcPosForAddressRepeat(p vec2) vec2 {\n\torigin := imageSrc0Origin()\n\tsize := imageSrc0Size()\n\treturn mod(p - origin, size) + origin\n}\n\n\nfunc Fragment(dstPos vec4, srcPos vec2, color vec4) vec4 {\n\n\n\tclr := imageSrc0At(adjustSrcPosForAddressRepeat(srcPos))\n\n\n\n\n\t// Apply the color scale.\n\tclr *= color\n\n\t// Apply the mask, which is the second source image placed at the same position as the first one.\n\n\n\tm := imageSrc1At(adjustSrcPosForAddressRepeat(srcPos))\n\n\n\n\tclr *= m.a\n\n\n\n\treturn clr\n}\n\n"
+
+//ebitengine:shadersource
+const _ = "//kage:unit pixels\n\npackage main\n\n\n\n\nfunc adjustSrcPosForAddressRepeat(p vec2) vec2 {\n\torigin := imageSrc0Origin()\n\tsize := imageSrc0Size()\n\treturn mod(p - origin, size) + origin\n}\n\n\nfunc Fragment(dstPos vec4, srcPos vec2, color vec4) vec4 {\n\n\n\tclr := imageSrc0At(adjustSrcPosForAddressRepeat(srcPos))\n\n\n\n\n\t// Apply the color scale.\n\tclr *= color\n\n\t// Apply the mask, which is the second source image placed at the same position as the first one.\n\n\n\tm := imageSrc1At(adjustSrcPosForAddressRepeat(srcPos))\n\n\n\n\t// As the mask color is premultiplied, the luminance is 0 where the mask is transparent.\n\tclr *= dot(m.rgb, vec3(0.2126, 0.7152, 0.0722))\n\n\n\n\treturn clr\n}\n\n"
+
+//ebitengine:shadersource
+const _ = "//kage:unit pixels\n\npackage main\n\n\nvar ColorMBody mat4\nvar ColorMTranslation vec4\n\n\n\nfunc adjustSrcPosForAddressRepeat(p vec2) vec2 {\n\torigin := imageSrc0Origin()\n\tsize := imageSrc0Size()\n\treturn mod(p - origin, size) + origin\n}\n\n\nfunc Fragment(dstPos vec4, srcPos vec2, color vec4) vec4 {\n\n\n\tclr := imageSrc0At(adjustSrcPosForAddressRepeat(srcPos))\n\n\n\n\n\t// Un-premultiply alpha.\n\t// When the alpha is 0, 1-sign(alpha) is 1.0, which means division does nothing.\n\tclr.rgb /= clr.a + (1-sign(clr.a))\n\t// Apply the clr matrix.\n\tclr = (ColorMBody * clr) + ColorMTranslation\n\t// Premultiply alpha\n\tclr.rgb *= clr.a\n\t// Apply the color scale.\n\tclr *= color\n\t// Clamp the output.\n\tclr.rgb = min(clr.rgb, clr.a)\n\n\t// Apply the mask, which is the second source image placed at the same position as the first one.\n\n\n\tm := imageSrc1At(adjustSrcPosForAddressRepeat(srcPos))\n\n\n\n\tclr *= m.a\n\n\n\n\treturn clr\n}\n\n"
+
+//ebitengine:shadersource
+const _ = "//kage:unit pixels\n\npackage main\n\n\nvar ColorMBody mat4\nvar ColorMTranslation vec4\n\n\n\nfunc adjustSrcPosForAddressRepeat(p vec2) vec2 {\n\torigin := imageSrc0Origin()\n\tsize := imageSrc0Size()\n\treturn mod(p - origin, size) + origin\n}\n\n\nfunc Fragment(dstPos vec4, srcPos vec2, color vec4) vec4 {\n\n\n\tclr := imageSrc0At(adjustSrcPosForAddressRepeat(srcPos))\n\n\n\n\n\t// Un-premultiply alpha.\n\t// When the alpha is 0, 1-sign(alpha) is 1.0, which means division does nothing.\n\tclr.rgb /= clr.a + (1-sign(clr.a))\n\t// Apply the clr matrix.\n\tclr = (ColorMBody * clr) + ColorMTranslation\n\t// Premultiply alpha\n\tclr.rgb *= clr.a\n\t// Apply the color scale.\n\tclr *= color\n\t// Clamp the output.\n\tclr.rgb = min(clr.rgb, clr.a)\n\n\t// Apply the mask, which is the second source image placed at the same position as the first one.\n\n\n\tm := imageSrc1At(adjustSrcPosForAddressRepeat(srcPos))\n\n\n\n\t// As the mask color is premultiplied, the luminance is 0 where the mask is transparent.\n\tclr *= dot(m.rgb, vec3(0.2126, 0.7152, 0.0722))\n\n\n\n\treturn clr\n}\n\n"
+
+//ebitengine:shadersource
+const _ = "//kage:unit pixels\n\npackage main\n\n\n\n\n\nfunc Fragment(dstPos vec4, srcPos vec2, color vec4) vec4 {\n\n\n\tp0 := srcPos - 1/2.0\n\tp1 := srcPos + 1/2.0\n\n\n\n\n\n\tc0 := imageSrc0UnsafeAt(p0)\n\tc1 := imageSrc0UnsafeAt(vec2(p1.x, p0.y))\n\tc2 := imageSrc0UnsafeAt(vec2(p0.x, p1.y))\n\tc3 := imageSrc0UnsafeAt(p1)\n\n\n\n\trate := fract(p1)\n\n\tclr := mix(mix(c0, c1, rate.x), mix(c2, c3, rate.x), rate.y)\n\n\n\n\t// Apply the color scale.\n\tclr *= color\n\n\t// Apply the mask, which is the second source image placed at the same position as the first one.\n\n\tm := mix(mix(imageSrc1At(p0), imageSrc1At(vec2(p1.x, p0.y)), rate.x), mix(imageSrc1At(vec2(p0.x, p1.y)), imageSrc1At(p1), rate.x), rate.y)\n\n\n\tclr *= m.a\n\n\n\n\treturn clr\n}\n\n"
+
+//ebitengine:shadersource
+const _ = "//kage:unit pixels\n\npackage main\n\n\n\n\n\nfunc Fragment(dstPos vec4, srcPos vec2, color vec4) vec4 {\n\n\n\tp0 := srcPos - 1/2.0\n\tp1 := srcPos + 1/2.0\n\n\n\n\n\n\tc0 := imageSrc0UnsafeAt(p0)\n\tc1 := imageSrc0UnsafeAt(vec2(p1.x, p0.y))\n\tc2 := imageSrc0UnsafeAt(vec2(p0.x, p1.y))\n\tc3 := imageSrc0UnsafeAt(p1)\n\n\n\n\trate := fract(p1)\n\n\tclr := mix(mix(c0, c1, rate.x), mix(c2, c3, rate.x), rate.y)\n\n\n\n\t// Apply the color scale.\n\tclr *= color\n\n\t// Apply the mask, which is the second source image placed at the same position as the first one.\n\n\tm := mix(mix(imageSrc1At(p0), imageSrc1At(vec2(p1.x, p0.y)), rate.x), mix(imageSrc1At(vec2(p0.x, p1.y)), imageSrc1At(p1), rate.x), rate.y)\n\n\n\t// As the mask color is premultiplied, the luminance is 0 where the mask is transparent.\n\tclr *= dot(m.rgb, vec3(0.2126, 0.7152, 0.0722))\n\n\n\n\treturn clr\n}\n\n"
+
+//ebitengine:shadersource
+const _ = "//kage:unit pixels\n\npackage main\n\n\nvar ColorMBody mat4\nvar ColorMTranslation vec4\n\n\n\n\nfunc Fragment(dstPos vec4, srcPos vec2, color vec4) vec4 {\n\n\n\tp0 := srcPos - 1/2.0\n\tp1 := srcPos + 1/2.0\n\n\n\n\n\n\tc0 := imageSrc0UnsafeAt(p0)\n\tc1 := imageSrc0UnsafeAt(vec2(p1.x, p0.y))\n\tc2 := imageSrc0UnsafeAt(vec2(p0.x, p1.y))\n\tc3 := imageSrc0UnsafeAt(p1)\n\n\n\n\trate := fract(p1)\n\n\tclr := mix(mix(c0, c1, rate.x), mix(c2, c3, rate.x), rate.y)\n\n\n\n\t// Un-premultiply alpha.\n\t// When the alpha is 0, 1-sign(alpha) is 1.0, which means division does nothing.\n\tclr.rgb /= clr.a + (1-sign(clr.a))\n\t// Apply the clr matrix.\n\tclr = (ColorMBody * clr) + ColorMTranslation\n\t// Premultiply alpha\n\tclr.rgb *= clr.a\n\t// Apply the color scale.\n\tclr *= color\n\t// Clamp the output.\n\tclr.rgb = min(clr.rgb, clr.a)\n\n\t// Apply the mask, which is the second source image placed at the same position as the first one.\n\n\tm := mix(mix(imageSrc1At(p0), imageSrc1At(vec2(p1.x, p0.y)), rate.x), mix(imageSrc1At(vec2(p0.x, p1.y)), imageSrc1At(p1), rate.x), rate.y)\n\n\n\tclr *= m.a\n\n\n\n\treturn clr\n}\n\n"
+
+//ebitengine:shadersource
+const _ = "//kage:unit pixels\n\npackage main\n\n\nvar ColorMBody mat4\nvar ColorMTranslation vec4\n\n\n\n\nfunc Fragment(dstPos vec4, srcPos vec2, color vec4) vec4 {\n\n\n\tp0 := srcPos - 1/2.0\n\tp1 := srcPos + 1/2.0\n\n\n\n\n\n\tc0 := imageSrc0UnsafeAt(p0)\n\tc1 := imageSrc0UnsafeAt(vec2(p1.x, p0.y))\n\tc2 := imageSrc0UnsafeAt(vec2(p0.x, p1.y))\n\tc3 := imageSrc0UnsafeAt(p1)\n\n\n\n\trate := fract(p1)\n\n\tclr := mix(mix(c0, c1, rate.x), mix(c2, c3, rate.x), rate.y)\n\n\n\n\t// Un-premultiply alpha.\n\t// When the alpha is 0, 1-sign(alpha) is 1.0, which means division does nothing.\n\tclr.rgb /= clr.a + (1-sign(clr.a))\n\t// Apply the clr matrix.\n\tclr = (ColorMBody * clr) + ColorMTranslation\n\t// Premultiply alpha\n\tclr.rgb *= clr.a\n\t// Apply the color scale.\n\tclr *= color\n\t// Clamp the output.\n\tclr.rgb = min(clr.rgb, clr.a)\n\n\t// Apply the mask, which is the second source image placed at the same position as the first one.\n\n\tm := mix(mix(imageSrc1At(p0), imageSrc1At(vec2(p1.x, p0.y)), rate.x), mix(imageSrc1At(vec2(p0.x, p1.y)), imageSrc1At(p1), rate.x), rate.y)\n\n\n\t// As the mask color is premultiplied, the luminance is 0 where the mask is transparent.\n\tclr *= dot(m.rgb, vec3(0.2126, 0.7152, 0.0722))\n\n\n\n\treturn clr\n}\n\n"
+
+//ebitengine:shadersource
+const _ = "//kage:unit pixels\n\npackage main\n\n\n\n\n\nfunc Fragment(dstPos vec4, srcPos vec2, color vec4) vec4 {\n\n\n\tp0 := srcPos - 1/2.0\n\tp1 := srcPos + 1/2.0\n\n\n\n\n\n\tc0 := imageSrc0At(p0)\n\tc1 := imageSrc0At(vec2(p1.x, p0.y))\n\tc2 := imageSrc0At(vec2(p0.x, p1.y))\n\tc3 := imageSrc0At(p1)\n\n\n\n\trate := fract(p1)\n\n\tclr := mix(mix(c0, c1, rate.x), mix(c2, c3, rate.x), rate.y)\n\n\n\n\t// Apply the color scale.\n\tclr *= color\n\n\t// Apply the mask, which is the second source image placed at the same position as the first one.\n\n\tm := mix(mix(imageSrc1At(p0), imageSrc1At(vec2(p1.x, p0.y)), rate.x), mix(imageSrc1At(vec2(p0.x, p1.y)), imageSrc1At(p1), rate.x), rate.y)\n\n\n\tclr *= m.a\n\n\n\n\treturn clr\n}\n\n"
+
+//ebitengine:shadersource
+const _ = "//kage:unit pixels\n\npackage main\n\n\n\n\n\nfunc Fragment(dstPos vec4, srcPos vec2, color vec4) vec4 {\n\n\n\tp0 := srcPos - 1/2.0\n\tp1 := srcPos + 1/2.0\n\n\n\n\n\n\tc0 := imageSrc0At(p0)\n\tc1 := imageSrc0At(vec2(p1.x, p0.y))\n\tc2 := imageSrc0At(vec2(p0.x, p1.y))\n\tc3 := imageSrc0At(p1)\n\n\n\n\trate := fract(p1)\n\n\tclr := mix(mix(c0, c1, rate.x), mix(c2, c3, rate.x), rate.y)\n\n\n\n\t// Apply the color scale.\n\tclr *= color\n\n\t// Apply the mask, which is the second source image placed at the same position as the first one.\n\n\tm := mix(mix(imageSrc1At(p0), imageSrc1At(vec2(p1.x, p0.y)), rate.x), mix(imageSrc1At(vec2(p0.x, p1.y)), imageSrc1At(p1), rate.x), rate.y)\n\n\n\t// As the mask color is premultiplied, the luminance is 0 where the mask is transparent.\n\tclr *= dot(m.rgb, vec3(0.2126, 0.7152, 0.0722))\n\n\n\n\treturn clr\n}\n\n"
+
+//ebitengine:shadersource
+const _ = "//kage:unit pixels\n\npackage main\n\n\nvar ColorMBody mat4\nvar ColorMTranslation vec4\n\n\n\n\nfunc Fragment(dstPos vec4, srcPos vec2, color vec4) vec4 {\n\n\n\tp0 := srcPos - 1/2.0\n\tp1 := srcPos + 1/2.0\n\n\n\n\n\n\tc0 := imageSrc0At(p0)\n\tc1 := imageSrc0At(vec2(p1.x, p0.y))\n\tc2 := imageSrc0At(vec2(p0.x, p1.y))\n\tc3 := imageSrc0At(p1)\n\n\n\n\trate := fract(p1)\n\n\tclr := mix(mix(c0, c1, rate.x), mix(c2, c3, rate.x), rate.y)\n\n\n\n\t// Un-premultiply alpha.\n\t// When the alpha is 0, 1-sign(alpha) is 1.0, which means division does nothing.\n\tclr.rgb /= clr.a + (1-sign(clr.a))\n\t// Apply the clr matrix.\n\tclr = (ColorMBody * clr) + ColorMTranslation\n\t// Premultiply alpha\n\tclr.rgb *= clr.a\n\t// Apply the color scale.\n\tclr *= color\n\t// Clamp the output.\n\tclr.rgb = min(clr.rgb, clr.a)\n\n\t// Apply the mask, which is the second source image placed at the same position as the first one.\n\n\tm := mix(mix(imageSrc1At(p0), imageSrc1At(vec2(p1.x, p0.y)), rate.x), mix(imageSrc1At(vec2(p0.x, p1.y)), imageSrc1At(p1), rate.x), rate.y)\n\n\n\tclr *= m.a\n\n\n\n\treturn clr\n}\n\n"
+
+//ebitengine:shadersource
+const _ = "//kage:unit pixels\n\npackage main\n\n\nvar ColorMBody mat4\nvar ColorMTranslation vec4\n\n\n\n\nfunc Fragment(dstPos vec4, srcPos vec2, color vec4) vec4 {\n\n\n\tp0 := srcPos - 1/2.0\n\tp1 := srcPos + 1/2.0\n\n\n\n\n\n\tc0 := imageSrc0At(p0)\n\tc1 := imageSrc0At(vec2(p1.x, p0.y))\n\tc2 := imageSrc0At(vec2(p0.x, p1.y))\n\tc3 := imageSrc0At(p1)\n\n\n\n\trate := fract(p1)\n\n\tclr := mix(mix(c0, c1, rate.x), mix(c2, c3, rate.x), rate.y)\n\n\n\n\t// Un-premultiply alpha.\n\t// When the alpha is 0, 1-sign(alpha) is 1.0, which means division does nothing.\n\tclr.rgb /= clr.a + (1-sign(clr.a))\n\t// Apply the clr matrix.\n\tclr = (ColorMBody * clr) + ColorMTranslation\n\t// Premultiply alpha\n\tclr.rgb *= clr.a\n\t// Apply the color scale.\n\tclr *= color\n\t// Clamp the output.\n\tclr.rgb = min(clr.rgb, clr.a)\n\n\t// Apply the mask, which is the second source image placed at the same position as the first one.\n\n\tm := mix(mix(imageSrc1At(p0), imageSrc1At(vec2(p1.x, p0.y)), rate.x), mix(imageSrc1At(vec2(p0.x, p1.y)), imageSrc1At(p1), rate.x), rate.y)\n\n\n\t// As the mask color is premultiplied, the luminance is 0 where the mask is transparent.\n\tclr *= dot(m.rgb, vec3(0.2126, 0.7152, 0.0722))\n\n\n\n\treturn clr\n}\n\n"
+
+//ebitengine:shadersource
+const _ = "//kage:unit pixels\n\npackage main\n\n\n\n\nfunc adjustSrcPosForAddressRepeat(p vec2) vec2 {\n\torigin := imageSrc0Origin()\n\tsize := imageSrc0Size()\n\treturn mod(p - origin, size) + origin\n}\n\n\nfunc Fragment(dstPos vec4, srcPos vec2, color vec4) vec4 {\n\n\n\tp0 := srcPos - 1/2.0\n\tp1 := srcPos + 1/2.0\n\n\n\n\tp0 = adjustSrcPosForAddressRepeat(p0)\n\tp1 = adjustSrcPosForAddressRepeat(p1)\n\n\n\n\tc0 := imageSrc0At(p0)\n\tc1 := imageSrc0At(vec2(p1.x, p0.y))\n\tc2 := imageSrc0At(vec2(p0.x, p1.y))\n\tc3 := imageSrc0At(p1)\n\n\n\n\trate := fract(p1)\n\n\tclr := mix(mix(c0, c1, rate.x), mix(c2, c3, rate.x), rate.y)\n\n\n\n\t// Apply the color scale.\n\tclr *= color\n\n\t// Apply the mask, which is the second source image placed at the same position as the first one.\n\n\tm := mix(mix(imageSrc1At(p0), imageSrc1At(vec2(p1.x, p0.y)), rate.x), mix(imageSrc1At(vec2(p0.x, p1.y)), imageSrc1At(p1), rate.x), rate.y)\n\n\n\tclr *= m.a\n\n\n\n\treturn clr\n}\n\n"
+
+//ebitengine:shadersource
+const _ = "//kage:unit pixels\n\npackage main\n\n\n\n\nfunc adjustSrcPosForAddressRepeat(p vec2) vec2 {\n\torigin := imageSrc0Origin()\n\tsize := imageSrc0Size()\n\treturn mod(p - origin, size) + origin\n}\n\n\nfunc Fragment(dstPos vec4, srcPos vec2, color vec4) vec4 {\n\n\n\tp0 := srcPos - 1/2.0\n\tp1 := srcPos + 1/2.0\n\n\n\n\tp0 = adjustSrcPosForAddressRepeat(p0)\n\tp1 = adjustSrcPosForAddressRepeat(p1)\n\n\n\n\tc0 := imageSrc0At(p0)\n\tc1 := imageSrc0At(vec2(p1.x, p0.y))\n\tc2 := imageSrc0At(vec2(p0.x, p1.y))\n\tc3 := imageSrc0At(p1)\n\n\n\n\trate := fract(p1)\n\n\tclr := mix(mix(c0, c1, rate.x), mix(c2, c3, rate.x), rate.y)\n\n\n\n\t// Apply the color scale.\n\tclr *= color\n\n\t// Apply the mask, which is the second source image placed at the same position as the first one.\n\n\tm := mix(mix(imageSrc1At(p0), imageSrc1At(vec2(p1.x, p0.y)), rate.x), mix(imageSrc1At(vec2(p0.x, p1.y)), imageSrc1At(p1), rate.x), rate.y)\n\n\n\t// As the mask color is premultiplied, the luminance is 0 where the mask is transparent.\n\tclr *= dot(m.rgb, vec3(0.2126, 0.7152, 0.0722))\n\n\n\n\treturn clr\n}\n\n"
+
+//ebitengine:shadersource
+const _ = "//kage:unit pixels\n\npackage main\n\n\nvar ColorMBody mat4\nvar ColorMTranslation vec4\n\n\n\nfunc adjustSrcPosForAddressRepeat(p vec2) vec2 {\n\torigin := imageSrc0Origin()\n\tsize := imageSrc0Size()\n\treturn mod(p - origin, size) + origin\n}\n\n\nfunc Fragment(dstPos vec4, srcPos vec2, color vec4) vec4 {\n\n\n\tp0 := srcPos - 1/2.0\n\tp1 := srcPos + 1/2.0\n\n\n\n\tp0 = adjustSrcPosForAddressRepeat(p0)\n\tp1 = adjustSrcPosForAddressRepeat(p1)\n\n\n\n\tc0 := imageSrc0At(p0)\n\tc1 := imageSrc0At(vec2(p1.x, p0.y))\n\tc2 := imageSrc0At(vec2(p0.x, p1.y))\n\tc3 := imageSrc0At(p1)\n\n\n\n\trate := fract(p1)\n\n\tclr := mix(mix(c0, c1, rate.x), mix(c2, c3, rate.x), rate.y)\n\n\n\n\t// Un-premultiply alpha.\n\t// When the alpha is 0, 1-sign(alpha) is 1.0, which means division does nothing.\n\tclr.rgb /= clr.a + (1-sign(clr.a))\n\t// Apply the clr matrix.\n\tclr = (ColorMBody * clr) + ColorMTranslation\n\t// Premultiply alpha\n\tclr.rgb *= clr.a\n\t// Apply the color scale.\n\tclr *= color\n\t// Clamp the output.\n\tclr.rgb = min(clr.rgb, clr.a)\n\n\t// Apply the mask, which is the second source image placed at the same position as the first one.\n\n\tm := mix(mix(imageSrc1At(p0), imageSrc1At(vec2(p1.x, p0.y)), rate.x), mix(imageSrc1At(vec2(p0.x, p1.y)), imageSrc1At(p1), rate.x), rate.y)\n\n\n\tclr *= m.a\n\n\n\n\treturn clr\n}\n\n"
+
+//ebitengine:shadersource
+const _ = "//kage:unit pixels\n\npackage main\n\n\nvar ColorMBody mat4\nvar ColorMTranslation vec4\n\n\n\nfunc adjustSrcPosForAddressRepeat(p vec2) vec2 {\n\torigin := imageSrc0Origin()\n\tsize := imageSrc0Size()\n\treturn mod(p - origin, size) + origin\n}\n\n\nfunc Fragment(dstPos vec4, srcPos vec2, color vec4) vec4 {\n\n\n\tp0 := srcPos - 1/2.0\n\tp1 := srcPos + 1/2.0\n\n\n\n\tp0 = adjustSrcPosForAddressRepeat(p0)\n\tp1 = adjustSrcPosForAddressRepeat(p1)\n\n\n\n\tc0 := imageSrc0At(p0)\n\tc1 := imageSrc0At(vec2(p1.x, p0.y))\n\tc2 := imageSrc0At(vec2(p0.x, p1.y))\n\tc3 := imageSrc0At(p1)\n\n\n\n\trate := fract(p1)\n\n\tclr := mix(mix(c0, c1, rate.x), mix(c2, c3, rate.x), rate.y)\n\n\n\n\t// Un-premultiply alpha.\n\t// When the alpha is 0, 1-sign(alpha) is 1.0, which means division does nothing.\n\tclr.rgb /= clr.a + (1-sign(clr.a))\n\t// Apply the clr matrix.\n\tclr = (ColorMBody * clr) + ColorMTranslation\n\t// Premultiply alpha\n\tclr.rgb *= clr.a\n\t// Apply the color scale.\n\tclr *= color\n\t// Clamp the output.\n\tclr.rgb = min(clr.rgb, clr.a)\n\n\t// Apply the mask, which is the second source image placed at the same position as the first one.\n\n\tm := mix(mix(imageSrc1At(p0), imageSrc1At(vec2(p1.x, p0.y)), rate.x), mix(imageSrc1At(vec2(p0.x, p1.y)), imageSrc1At(p1), rate.x), rate.y)\n\n\n\t// As the mask color is premultiplied, the luminance is 0 where the mask is transparent.\n\tclr *= dot(m.rgb, vec3(0.2126, 0.7152, 0.0722))\n\n\n\n\treturn clr\n}\n\n"
+
+//ebitengine:shadersource
+const _ = "//kage:unit pixels\n\npackage main\n\n\n\n\n\nfunc Fragment(dstPos vec4, srcPos vec2, color vec4) vec4 {\n\n\n\t// inversedScale is the size of the region on the source image.\n\t// The size is the inverse of the geometry-matrix scale.\n\tinversedScale := vec2(abs(dfdx(srcPos.x)), abs(dfdy(srcPos.y)))\n\t// Cap the inversedScale to 1 as dfdx/dfdy is not accurate on some machines (#3182).\n\tinversedScale = min(inversedScale, vec2(1))\n\tp0 := srcPos - inversedScale/2.0\n\tp1 := srcPos + inversedScale/2.0\n\n\n\n\n\n\tc0 := imageSrc0UnsafeAt(p0)\n\tc1 := imageSrc0UnsafeAt(vec2(p1.x, p0.y))\n\tc2 := imageSrc0UnsafeAt(vec2(p0.x, p1.y))\n\tc3 := imageSrc0UnsafeAt(p1)\n\n\n\n\trate := clamp(fract(p1)/inversedScale, 0, 1)\n\n\tclr := mix(mix(c0, c1, rate.x), mix(c2, c3, rate.x), rate.y)\n\n\n\n\t// Apply the color scale.\n\tclr *= color\n\n\t// Apply the mask, which is the second source image placed at the same position as the first one.\n\n\tm := mix(mix(imageSrc1At(p0), imageSrc1At(vec2(p1.x, p0.y)), rate.x), mix(imageSrc1At(vec2(p0.x, p1.y)), imageSrc1At(p1), rate.x), rate.y)\n\n\n\tclr *= m.a\n\n\n\n\treturn clr\n}\n\n"
+
+//ebitengine:shadersource
+const _ = "//kage:unit pixels\n\npackage main\n\n\n\n\n\nfunc Fragment(dstPos vec4, srcPos vec2, color vec4) vec4 {\n\n\n\t// inversedScale is the size of the region on the source image.\n\t// The size is the inverse of the geometry-matrix scale.\n\tinversedScale := vec2(abs(dfdx(srcPos.x)), abs(dfdy(srcPos.y)))\n\t// Cap the inversedScale to 1 as dfdx/dfdy is not accurate on some machines (#3182).\n\tinversedScale = min(inversedScale, vec2(1))\n\tp0 := srcPos - inversedScale/2.0\n\tp1 := srcPos + inversedScale/2.0\n\n\n\n\n\n\tc0 := imageSrc0UnsafeAt(p0)\n\tc1 := imageSrc0UnsafeAt(vec2(p1.x, p0.y))\n\tc2 := imageSrc0UnsafeAt(vec2(p0.x, p1.y))\n\tc3 := imageSrc0UnsafeAt(p1)\n\n\n\n\trate := clamp(fract(p1)/inversedScale, 0, 1)\n\n\tclr := mix(mix(c0, c1, rate.x), mix(c2, c3, rate.x), rate.y)\n\n\n\n\t// Apply the color scale.\n\tclr *= color\n\n\t// Apply the mask, which is the second source image placed at the same position as the first one.\n\n\tm := mix(mix(imageSrc1At(p0), imageSrc1At(vec2(p1.x, p0.y)), rate.x), mix(imageSrc1At(vec2(p0.x, p1.y)), imageSrc1At(p1), rate.x), rate.y)\n\n\n\t// As the mask color is premultiplied, the luminance is 0 where the mask is transparent.\n\tclr *= dot(m.rgb, vec3(0.2126, 0.7152, 0.0722))\n\n\n\n\treturn clr\n}\n\n"
+
+//ebitengine:shadersource
+const _ = "//kage:unit pixels\n\npackage main\n\n\nvar ColorMBody mat4\nvar ColorMTranslation vec4\n\n\n\n\nfunc Fragment(dstPos vec4, srcPos vec2, color vec4) vec4 {\n\n\n\t// inversedScale is the size of the region on the source image.\n\t// The size is the inverse of the geometry-matrix scale.\n\tinversedScale := vec2(abs(dfdx(srcPos.x)), abs(dfdy(srcPos.y)))\n\t// Cap the inversedScale to 1 as dfdx/dfdy is not accurate on some machines (#3182).\n\tinversedScale = min(inversedScale, vec2(1))\n\tp0 := srcPos - inversedScale/2.0\n\tp1 := srcPos + inversedScale/2.0\n\n\n\n\n\n\tc0 := imageSrc0UnsafeAt(p0)\n\tc1 := imageSrc0UnsafeAt(vec2(p1.x, p0.y))\n\tc2 := imageSrc0UnsafeAt(vec2(p0.x, p1.y))\n\tc3 := imageSrc0UnsafeAt(p1)\n\n\n\n\trate := clamp(fract(p1)/inversedScale, 0, 1)\n\n\tclr := mix(mix(c0, c1, rate.x), mix(c2, c3, rate.x), rate.y)\n\n\n\n\t// Un-premultiply alpha.\n\t// When the alpha is 0, 1-sign(alpha) is 1.0, which means division does nothing.\n\tclr.rgb /= clr.a + (1-sign(clr.a))\n\t// Apply the clr matrix.\n\tclr = (ColorMBody * clr) + ColorMTranslation\n\t// Premultiply alpha\n\tclr.rgb *= clr.a\n\t// Apply the color scale.\n\tclr *= color\n\t// Clamp the output.\n\tclr.rgb = min(clr.rgb, clr.a)\n\n\t// Apply the mask, which is the second source image placed at the same position as the first one.\n\n\tm := mix(mix(imageSrc1At(p0), imageSrc1At(vec2(p1.x, p0.y)), rate.x), mix(imageSrc1At(vec2(p0.x, p1.y)), imageSrc1At(p1), rate.x), rate.y)\n\n\n\tclr *= m.a\n\n\n\n\treturn clr\n}\n\n"
+
+//ebitengine:shadersource
+const _ = "//kage:unit pixels\n\npackage main\n\n\nvar ColorMBody mat4\nvar ColorMTranslation vec4\n\n\n\n\nfunc Fragment(dstPos vec4, srcPos vec2, color vec4) vec4 {\n\n\n\t// inversedScale is the size of the region on the source image.\n\t// The size is the inverse of the geometry-matrix scale.\n\tinversedScale := vec2(abs(dfdx(srcPos.x)), abs(dfdy(srcPos.y)))\n\t// Cap the inversedScale to 1 as dfdx/dfdy is not accurate on some machines (#3182).\n\tinversedScale = min(inversedScale, vec2(1))\n\tp0 := srcPos - inversedScale/2.0\n\tp1 := srcPos + inversedScale/2.0\n\n\n\n\n\n\tc0 := imageSrc0UnsafeAt(p0)\n\tc1 := imageSrc0UnsafeAt(vec2(p1.x, p0.y))\n\tc2 := imageSrc0UnsafeAt(vec2(p0.x, p1.y))\n\tc3 := imageSrc0UnsafeAt(p1)\n\n\n\n\trate := clamp(fract(p1)/inversedScale, 0, 1)\n\n\tclr := mix(mix(c0, c1, rate.x), mix(c2, c3, rate.x), rate.y)\n\n\n\n\t// Un-premultiply alpha.\n\t// When the alpha is 0, 1-sign(alpha) is 1.0, which means division does nothing.\n\tclr.rgb /= clr.a + (1-sign(clr.a))\n\t// Apply the clr matrix.\n\tclr = (ColorMBody * clr) + ColorMTranslation\n\t// Premultiply alpha\n\tclr.rgb *= clr.a\n\t// Apply the color scale.\n\tclr *= color\n\t// Clamp the output.\n\tclr.rgb = min(clr.rgb, clr.a)\n\n\t// Apply the mask, which is the second source image placed at the same position as the first one.\n\n\tm := mix(mix(imageSrc1At(p0), imageSrc1At(vec2(p1.x, p0.y)), rate.x), mix(imageSrc1At(vec2(p0.x, p1.y)), imageSrc1At(p1), rate.x), rate.y)\n\n\n\t// As the mask color is premultiplied, the luminance is 0 where the mask is transparent.\n\tclr *= dot(m.rgb, vec3(0.2126, 0.7152, 0.0722))\n\n\n\n\treturn clr\n}\n\n"
+
+//ebitengine:shadersource
+const _ = "//kage:unit pixels\n\npackage main\n\n\n\n\n\nfunc Fragment(dstPos vec4, srcPos vec2, color vec4) vec4 {\n\n\n\t// inversedScale is the size of the region on the source image.\n\t// The size is the inverse of the geometry-matrix scale.\n\tinversedScale := vec2(abs(dfdx(srcPos.x)), abs(dfdy(srcPos.y)))\n\t// Cap the inversedScale to 1 as dfdx/dfdy is not accurate on some machines (#3182).\n\tinversedScale = min(inversedScale, vec2(1))\n\tp0 := srcPos - inversedScale/2.0\n\tp1 := srcPos + inversedScale/2.0\n\n\n\n\n\n\tc0 := imageSrc0At(p0)\n\tc1 := imageSrc0At(vec2(p1.x, p0.y))\n\tc2 := imageSrc0At(vec2(p0.x, p1.y))\n\tc3 := imageSrc0At(p1)\n\n\n\n\trate := clamp(fract(p1)/inversedScale, 0, 1)\n\n\tclr := mix(mix(c0, c1, rate.x), mix(c2, c3, rate.x), rate.y)\n\n\n\n\t// Apply the color scale.\n\tclr *= color\n\n\t// Apply the mask, which is the second source image placed at the same position as the first one.\n\n\tm := mix(mix(imageSrc1At(p0), imageSrc1At(vec2(p1.x, p0.y)), rate.x), mix(imageSrc1At(vec2(p0.x, p1.y)), imageSrc1At(p1), rate.x), rate.y)\n\n\n\tclr *= m.a\n\n\n\n\treturn clr\n}\n\n"
+
+//ebitengine:shadersource
+const _ = "//kage:unit pixels\n\npackage main\n\n\n\n\n\nfunc Fragment(dstPos vec4, srcPos vec2, color vec4) vec4 {\n\n\n\t// inversedScale is the size of the region on the source image.\n\t// The size is the inverse of the geometry-matrix scale.\n\tinversedScale := vec2(abs(dfdx(srcPos.x)), abs(dfdy(srcPos.y)))\n\t// Cap the inversedScale to 1 as dfdx/dfdy is not accurate on some machines (#3182).\n\tinversedScale = min(inversedScale, vec2(1))\n\tp0 := srcPos - inversedScale/2.0\n\tp1 := srcPos + inversedScale/2.0\n\n\n\n\n\n\tc0 := imageSrc0At(p0)\n\tc1 := imageSrc0At(vec2(p1.x, p0.y))\n\tc2 := imageSrc0At(vec2(p0.x, p1.y))\n\tc3 := imageSrc0At(p1)\n\n\n\n\trate := clamp(fract(p1)/inversedScale, 0, 1)\n\n\tclr := mix(mix(c0, c1, rate.x), mix(c2, c3, rate.x), rate.y)\n\n\n\n\t// Apply the color scale.\n\tclr *= color\n\n\t// Apply the mask, which is the second source image placed at the same position as the first one.\n\n\tm := mix(mix(imageSrc1At(p0), imageSrc1At(vec2(p1.x, p0.y)), rate.x), mix(imageSrc1At(vec2(p0.x, p1.y)), imageSrc1At(p1), rate.x), rate.y)\n\n\n\t// As the mask color is premultiplied, the luminance is 0 where the mask is transparent.\n\tclr *= dot(m.rgb, vec3(0.2126, 0.7152, 0.0722))\n\n\n\n\treturn clr\n}\n\n"
+
+//ebitengine:shadersource
+const _ = "//kage:unit pixels\n\npackage main\n\n\nvar ColorMBody mat4\nvar ColorMTranslation vec4\n\n\n\n\nfunc Fragment(dstPos vec4, srcPos vec2, color vec4) vec4 {\n\n\n\t// inversedScale is the size of the region on the source image.\n\t// The size is the inverse of the geometry-matrix scale.\n\tinversedScale := vec2(abs(dfdx(srcPos.x)), abs(dfdy(srcPos.y)))\n\t// Cap the inversedScale to 1 as dfdx/dfdy is not accurate on some machines (#3182).\n\tinversedScale = min(inversedScale, vec2(1))\n\tp0 := srcPos - inversedScale/2.0\n\tp1 := srcPos + inversedScale/2.0\n\n\n\n\n\n\tc0 := imageSrc0At(p0)\n\tc1 := imageSrc0At(vec2(p1.x, p0.y))\n\tc2 := imageSrc0At(vec2(p0.x, p1.y))\n\tc3 := imageSrc0At(p1)\n\n\n\n\trate := clamp(fract(p1)/inversedScale, 0, 1)\n\n\tclr := mix(mix(c0, c1, rate.x), mix(c2, c3, rate.x), rate.y)\n\n\n\n\t// Un-premultiply alpha.\n\t// When the alpha is 0, 1-sign(alpha) is 1.0, which means division does nothing.\n\tclr.rgb /= clr.a + (1-sign(clr.a))\n\t// Apply the clr matrix.\n\tclr = (ColorMBody * clr) + ColorMTranslation\n\t// Premultiply alpha\n\tclr.rgb *= clr.a\n\t// Apply the color scale.\n\tclr *= color\n\t// Clamp the output.\n\tclr.rgb = min(clr.rgb, clr.a)\n\n\t// Apply the mask, which is the second source image placed at the same position as the first one.\n\n\tm := mix(mix(imageSrc1At(p0), imageSrc1At(vec2(p1.x, p0.y)), rate.x), mix(imageSrc1At(vec2(p0.x, p1.y)), imageSrc1At(p1), rate.x), rate.y)\n\n\n\tclr *= m.a\n\n\n\n\treturn clr\n}\n\n"
+
+//ebitengine:shadersource
+const _ = "//kage:unit pixels\n\npackage main\n\n\nvar ColorMBody mat4\nvar ColorMTranslation vec4\n\n\n\n\nfunc Fragment(dstPos vec4, srcPos vec2, color vec4) vec4 {\n\n\n\t// inversedScale is the size of the region on the source image.\n\t// The size is the inverse of the geometry-matrix scale.\n\tinversedScale := vec2(abs(dfdx(srcPos.x)), abs(dfdy(srcPos.y)))\n\t// Cap the inversedScale to 1 as dfdx/dfdy is not accurate on some machines (#3182).\n\tinversedScale = min(inversedScale, vec2(1))\n\tp0 := srcPos - inversedScale/2.0\n\tp1 := srcPos + inversedScale/2.0\n\n\n\n\n\n\tc0 := imageSrc0At(p0)\n\tc1 := imageSrc0At(vec2(p1.x, p0.y))\n\tc2 := imageSrc0At(vec2(p0.x, p1.y))\n\tc3 := imageSrc0At(p1)\n\n\n\n\trate := clamp(fract(p1)/inversedScale, 0, 1)\n\n\tclr := mix(mix(c0, c1, rate.x), mix(c2, c3, rate.x), rate.y)\n\n\n\n\t// Un-premultiply alpha.\n\t// When the alpha is 0, 1-sign(alpha) is 1.0, which means division does nothing.\n\tclr.rgb /= clr.a + (1-sign(clr.a))\n\t// Apply the clr matrix.\n\tclr = (ColorMBody * clr) + ColorMTranslation\n\t// Premultiply alpha\n\tclr.rgb *= clr.a\n\t// Apply the color scale.\n\tclr *= color\n\t// Clamp the output.\n\tclr.rgb = min(clr.rgb, clr.a)\n\n\t// Apply the mask, which is the second source image placed at the same position as the first one.\n\n\tm := mix(mix(imageSrc1At(p0), imageSrc1At(vec2(p1.x, p0.y)), rate.x), mix(imageSrc1At(vec2(p0.x, p1.y)), imageSrc1At(p1), rate.x), rate.y)\n\n\n\t// As the mask color is premultiplied, the luminance is 0 where the mask is transparent.\n\tclr *= dot(m.rgb, vec3(0.2126, 0.7152, 0.0722))\n\n\n\n\treturn clr\n}\n\n"
+
+//ebitengine:shadersource
+const _ = "//kage:unit pixels\n\npackage main\n\n\n\n\nfunc adjustSrcPosForAddressRepeat(p vec2) vec2 {\n\torigin := imageSrc0Origin()\n\tsize := imageSrc0Size()\n\treturn mod(p - origin, size) + origin\n}\n\n\nfunc Fragment(dstPos vec4, srcPos vec2, color vec4) vec4 {\n\n\n\t// inversedScale is the size of the region on the source image.\n\t// The size is the inverse of the geometry-matrix scale.\n\tinversedScale := vec2(abs(dfdx(srcPos.x)), abs(dfdy(srcPos.y)))\n\t// Cap the inversedScale to 1 as dfdx/dfdy is not accurate on some machines (#3182).\n\tinversedScale = min(inversedScale, vec2(1))\n\tp0 := srcPos - inversedScale/2.0\n\tp1 := srcPos + inversedScale/2.0\n\n\n\n\tp0 = adjustSrcPosForAddressRepeat(p0)\n\tp1 = adjustSrcPosForAddressRepeat(p1)\n\n\n\n\tc0 := imageSrc0At(p0)\n\tc1 := imageSrc0At(vec2(p1.x, p0.y))\n\tc2 := imageSrc0At(vec2(p0.x, p1.y))\n\tc3 := imageSrc0At(p1)\n\n\n\n\trate := clamp(fract(p1)/inversedScale, 0, 1)\n\n\tclr := mix(mix(c0, c1, rate.x), mix(c2, c3, rate.x), rate.y)\n\n\n\n\t// Apply the color scale.\n\tclr *= color\n\n\t// Apply the mask, which is the second source image placed at the same position as the first one.\n\n\tm := mix(mix(imageSrc1At(p0), imageSrc1At(vec2(p1.x, p0.y)), rate.x), mix(imageSrc1At(vec2(p0.x, p1.y)), imageSrc1At(p1), rate.x), rate.y)\n\n\n\tclr *= m.a\n\n\n\n\treturn clr\n}\n\n"
+
+//ebitengine:shadersource
+const _ = "//kage:unit pixels\n\npackage main\n\n\n\n\nfunc adjustSrcPosForAddressRepeat(p vec2) vec2 {\n\torigin := imageSrc0Origin()\n\tsize := imageSrc0Size()\n\treturn mod(p - origin, size) + origin\n}\n\n\nfunc Fragment(dstPos vec4, srcPos vec2, color vec4) vec4 {\n\n\n\t// inversedScale is the size of the region on the source image.\n\t// The size is the inverse of the geometry-matrix scale.\n\tinversedScale := vec2(abs(dfdx(srcPos.x)), abs(dfdy(srcPos.y)))\n\t// Cap the inversedScale to 1 as dfdx/dfdy is not accurate on some machines (#3182).\n\tinversedScale = min(inversedScale, vec2(1))\n\tp0 := srcPos - inversedScale/2.0\n\tp1 := srcPos + inversedScale/2.0\n\n\n\n\tp0 = adjustSrcPosForAddressRepeat(p0)\n\tp1 = adjustSrcPosForAddressRepeat(p1)\n\n\n\n\tc0 := imageSrc0At(p0)\n\tc1 := imageSrc0At(vec2(p1.x, p0.y))\n\tc2 := imageSrc0At(vec2(p0.x, p1.y))\n\tc3 := imageSrc0At(p1)\n\n\n\n\trate := clamp(fract(p1)/inversedScale, 0, 1)\n\n\tclr := mix(mix(c0, c1, rate.x), mix(c2, c3, rate.x), rate.y)\n\n\n\n\t// Apply the color scale.\n\tclr *= color\n\n\t// Apply the mask, which is the second source image placed at the same position as the first one.\n\n\tm := mix(mix(imageSrc1At(p0), imageSrc1At(vec2(p1.x, p0.y)), rate.x), mix(imageSrc1At(vec2(p0.x, p1.y)), imageSrc1At(p1), rate.x), rate.y)\n\n\n\t// As the mask color is premultiplied, the luminance is 0 where the mask is transparent.\n\tclr *= dot(m.rgb, vec3(0.2126, 0.7152, 0.0722))\n\n\n\n\treturn clr\n}\n\n"
+
+//ebitengine:shadersource
+const _ = "//kage:unit pixels\n\npackage main\n\n\nvar ColorMBody mat4\nvar ColorMTranslation vec4\n\n\n\nfunc adjustSrcPosForAddressRepeat(p vec2) vec2 {\n\torigin := imageSrc0Origin()\n\tsize := imageSrc0Size()\n\treturn mod(p - origin, size) + origin\n}\n\n\nfunc Fragment(dstPos vec4, srcPos vec2, color vec4) vec4 {\n\n\n\t// inversedScale is the size of the region on the source image.\n\t// The size is the inverse of the geometry-matrix scale.\n\tinversedScale := vec2(abs(dfdx(srcPos.x)), abs(dfdy(srcPos.y)))\n\t// Cap the inversedScale to 1 as dfdx/dfdy is not accurate on some machines (#3182).\n\tinversedScale = min(inversedScale, vec2(1))\n\tp0 := srcPos - inversedScale/2.0\n\tp1 := srcPos + inversedScale/2.0\n\n\n\n\tp0 = adjustSrcPosForAddressRepeat(p0)\n\tp1 = adjustSrcPosForAddressRepeat(p1)\n\n\n\n\tc0 := imageSrc0At(p0)\n\tc1 := imageSrc0At(vec2(p1.x, p0.y))\n\tc2 := imageSrc0At(vec2(p0.x, p1.y))\n\tc3 := imageSrc0At(p1)\n\n\n\n\trate := clamp(fract(p1)/inversedScale, 0, 1)\n\n\tclr := mix(mix(c0, c1, rate.x), mix(c2, c3, rate.x), rate.y)\n\n\n\n\t// Un-premultiply alpha.\n\t// When the alpha is 0, 1-sign(alpha) is 1.0, which means division does nothing.\n\tclr.rgb /= clr.a + (1-sign(clr.a))\n\t// Apply the clr matrix.\n\tclr = (ColorMBody * clr) + ColorMTranslation\n\t// Premultiply alpha\n\tclr.rgb *= clr.a\n\t// Apply the color scale.\n\tclr *= color\n\t// Clamp the output.\n\tclr.rgb = min(clr.rgb, clr.a)\n\n\t// Apply the mask, which is the second source image placed at the same position as the first one.\n\n\tm := mix(mix(imageSrc1At(p0), imageSrc1At(vec2(p1.x, p0.y)), rate.x), mix(imageSrc1At(vec2(p0.x, p1.y)), imageSrc1At(p1), rate.x), rate.y)\n\n\n\tclr *= m.a\n\n\n\n\treturn clr\n}\n\n"
+
+//ebitengine:shadersource
+const _ = "//kage:unit pixels\n\npackage main\n\n\nvar ColorMBody mat4\nvar ColorMTranslation vec4\n\n\n\nfunc adjustSrcPosForAddressRepeat(p vec2) vec2 {\n\torigin := imageSrc0Origin()\n\tsize := imageSrc0Size()\n\treturn mod(p - origin, size) + origin\n}\n\n\nfunc Fragment(dstPos vec4, srcPos vec2, color vec4) vec4 {\n\n\n\t// inversedScale is the size of the region on the source image.\n\t// The size is the inverse of the geometry-matrix scale.\n\tinversedScale := vec2(abs(dfdx(srcPos.x)), abs(dfdy(srcPos.y)))\n\t// Cap the inversedScale to 1 as dfdx/dfdy is not accurate on some machines (#3182).\n\tinversedScale = min(inversedScale, vec2(1))\n\tp0 := srcPos - inversedScale/2.0\n\tp1 := srcPos + inversedScale/2.0\n\n\n\n\tp0 = adjustSrcPosForAddressRepeat(p0)\n\tp1 = adjustSrcPosForAddressRepeat(p1)\n\n\n\n\tc0 := imageSrc0At(p0)\n\tc1 := imageSrc0At(vec2(p1.x, p0.y))\n\tc2 := imageSrc0At(vec2(p0.x, p1.y))\n\tc3 := imageSrc0At(p1)\n\n\n\n\trate := clamp(fract(p1)/inversedScale, 0, 1)\n\n\tclr := mix(mix(c0, c1, rate.x), mix(c2, c3, rate.x), rate.y)\n\n\n\n\t// Un-premultiply alpha.\n\t// When the alpha is 0, 1-sign(alpha) is 1.0, which means division does nothing.\n\tclr.rgb /= clr.a + (1-sign(clr.a))\n\t// Apply the clr matrix.\n\tclr = (ColorMBody * clr) + ColorMTranslation\n\t// Premultiply alpha\n\tclr.rgb *= clr.a\n\t// Apply the color scale.\n\tclr *= color\n\t// Clamp the output.\n\tclr.rgb = min(clr.rgb, clr.a)\n\n\t// Apply the mask, which is the second source image placed at the same position as the first one.\n\n\tm := mix(mix(imageSrc1At(p0), imageSrc1At(vec2(p1.x, p0.y)), rate.x), mix(imageSrc1At(vec2(p0.x, p1.y)), imageSrc1At(p1), rate.x), rate.y)\n\n\n\t// As the mask color is premultiplied, the luminance is 0 where the mask is transparent.\n\tclr *= dot(m.rgb, vec3(0.2126, 0.7152, 0.0722))\n\n\n\n\treturn clr\n}\n\n"
+
+//ebitengine:shadersource
+const _ = "//kage:unit pixels\n\npackage main\n\n\n\n\n\nfunc Fragment(dstPos vec4, srcPos vec2, color vec4) vec4 {\n\n\n\tp0 := srcPos - 1/2.0\n\tp1 := srcPos + 1/2.0\n\n\n\n\n\n\tc0 := imageSrc0UnsafeAt(p0)\n\tc1 := imageSrc0UnsafeAt(vec2(p1.x, p0.y))\n\tc2 := imageSrc0UnsafeAt(vec2(p0.x, p1.y))\n\tc3 := imageSrc0UnsafeAt(p1)\n\n\n\n\trate := fract(p1)\n\n\tclr := mix(mix(c0, c1, rate.x), mix(c2, c3, rate.x), rate.y)\n\t// Blend the next mipmap level, which is the second source image, by the number of texels per pixel.\n\t// The second source image is empty when the next level is not available.\n\ttexelsPerPixel := max(length(dfdx(srcPos)), length(dfdy(srcPos)))\n\tif imageSrc1Size().x > 0 {\n\t\tf := clamp(log2(texelsPerPixel), 0, 1)\n\t\t// q is in the first source image's positions, as imageSrc1UnsafeAt converts it to the second source image's positions.\n\t\tq := (srcPos-imageSrc0Origin())/2 + imageSrc0Origin()\n\t\tq0 := q - 1/2.0\n\t\tq1 := q + 1/2.0\n\t\tn0 := imageSrc1UnsafeAt(q0)\n\t\tn1 := imageSrc1UnsafeAt(vec2(q1.x, q0.y))\n\t\tn2 := imageSrc1UnsafeAt(vec2(q0.x, q1.y))\n\t\tn3 := imageSrc1UnsafeAt(q1)\n\t\tnrate := fract(q1)\n\t\tclr = mix(clr, mix(mix(n0, n1, nrate.x), mix(n2, n3, nrate.x), nrate.y), f)\n\t}\n\n\n\n\n\t// Apply the color scale.\n\tclr *= color\n\n\n\treturn clr\n}\n\n"
+
+//ebitengine:shadersource
+const _ = "//kage:unit pixels\n\npackage main\n\n\nvar ColorMBody mat4\nvar ColorMTranslation vec4\n\n\n\n\nfunc Fragment(dstPos vec4, srcPos vec2, color vec4) vec4 {\n\n\n\tp0 := srcPos - 1/2.0\n\tp1 := srcPos + 1/2.0\n\n\n\n\n\n\tc0 := imageSrc0UnsafeAt(p0)\n\tc1 := imageSrc0UnsafeAt(vec2(p1.x, p0.y))\n\tc2 := imageSrc0UnsafeAt(vec2(p0.x, p1.y))\n\tc3 := imageSrc0UnsafeAt(p1)\n\n\n\n\trate := fract(p1)\n\n\tclr := mix(mix(c0, c1, rate.x), mix(c2, c3, rate.x), rate.y)\n\t// Blend the next mipmap level, which is the second source image, by the number of texels per pixel.\n\t// The second source image is empty when the next level is not available.\n\ttexelsPerPixel := max(length(dfdx(srcPos)), length(dfdy(srcPos)))\n\tif imageSrc1Size().x > 0 {\n\t\tf := clamp(log2(texelsPerPixel), 0, 1)\n\t\t// q is in the first source image's positions, as imageSrc1UnsafeAt converts it to the second source image's positions.\n\t\tq := (srcPos-imageSrc0Origin())/2 + imageSrc0Origin()\n\t\tq0 := q - 1/2.0\n\t\tq1 := q + 1/2.0\n\t\tn0 := imageSrc1UnsafeAt(q0)\n\t\tn1 := imageSrc1UnsafeAt(vec2(q1.x, q0.y))\n\t\tn2 := imageSrc1UnsafeAt(vec2(q0.x, q1.y))\n\t\tn3 := imageSrc1UnsafeAt(q1)\n\t\tnrate := fract(q1)\n\t\tclr = mix(clr, mix(mix(n0, n1, nrate.x), mix(n2, n3, nrate.x), nrate.y), f)\n\t}\n\n\n\n\n\t// Un-premultiply alpha.\n\t// When the alpha is 0, 1-sign(alpha) is 1.0, which means division does nothing.\n\tclr.rgb /= clr.a + (1-sign(clr.a))\n\t// Apply the clr matrix.\n\tclr = (ColorMBody * clr) + ColorMTranslation\n\t// Premultiply alpha\n\tclr.rgb *= clr.a\n\t// Apply the color scale.\n\tclr *= color\n\t// Clamp the output.\n\tclr.rgb = min(clr.rgb, clr.a)\n\n\n\treturn clr\n}\n\n"
+
+//ebitengine:shadersource
+const _ = "//kage:unit pixels\n\npackage main\n\nvar GradientType int\nvar Start vec2\nvar End vec2\nvar Radius float\nvar StopCount int\nvar Offsets [16]float\nvar Colors [16]vec4\n\nfunc Fragment(dstPos vec4, srcPos vec2, color vec4) vec4 {\n\tvar t float\n\tif GradientType == 0 {\n\t\td := End - Start\n\t\tt = dot(srcPos-Start, d) / dot(d, d)\n\t} else {\n\t\tt = length(srcPos-Start) / Radius\n\t}\n\n\tclr := Colors[0]\n\tfor i := 1; i < 16; i++ {\n\t\tif i >= StopCount {\n\t\t\tbreak\n\t\t}\n\t\tif t >= Offsets[i] {\n\t\t\tclr = Colors[i]\n\t\t\tcontinue\n\t\t}\n\t\tif t > Offsets[i-1] {\n\t\t\tclr = mix(Colors[i-1], Colors[i], (t-Offsets[i-1])/(Offsets[i]-Offsets[i-1]))\n\t\t}\n\t\tbreak\n\t}\n\treturn clr\n}\n"
+
+//ebitengine:shadersource
+const _ = "//kage:unit pixels\n\npackage main\n\n\nvar DitherMode int\nvar DitherStrength float\nvar BlueNoise [256]float\n\n// ditherThreshold returns the dithering threshold in [-0.5, 0.5) for the pixel p.\nfunc ditherThreshold(p vec2) float {\n\tif DitherMode == 1 {\n\t\t// The 4x4 Bayer matrix.\n\t\ta := mod(p, 2)\n\t\tb := mod(floor(p/2), 2)\n\t\tm := 4*(2*a.x+3*a.y-4*a.x*a.y) + (2*b.x + 3*b.y - 4*b.x*b.y)\n\t\treturn (m+0.5)/16 - 0.5\n\t}\n\tq := mod(p, 16)\n\treturn BlueNoise[int(q.y)*16+int(q.x)]\n}\n\n\n\n\nfunc Fragment(dstPos vec4, srcPos vec2, color vec4) vec4 {\n\n\n\tclr := imageSrc0UnsafeAt(srcPos)\n\n\n\n\n\t// Apply the color scale.\n\tclr *= color\n\n\t// Add a noise less than one 8-bit step so that the quantization to the output doesn't make bands.\n\tclr.rgb = clamp(clr.rgb+ditherThreshold(floor(dstPos.xy))*DitherStrength/255, 0, clr.a)\n\n\n\treturn clr\n}\n\n"
+
+//ebitengine:shadersource
+const _ = "//kage:unit pixels\n\npackage main\n\n\nvar DitherMode int\nvar DitherStrength float\nvar BlueNoise [256]float\n\n// ditherThreshold returns the dithering threshold in [-0.5, 0.5) for the pixel p.\nfunc ditherThreshold(p vec2) float {\n\tif DitherMode == 1 {\n\t\t// The 4x4 Bayer matrix.\n\t\ta := mod(p, 2)\n\t\tb := mod(floor(p/2), 2)\n\t\tm := 4*(2*a.x+3*a.y-4*a.x*a.y) + (2*b.x + 3*b.y - 4*b.x*b.y)\n\t\treturn (m+0.5)/16 - 0.5\n\t}\n\tq := mod(p, 16)\n\treturn BlueNoise[int(q.y)*16+int(q.x)]\n}\n\n\n\n\nfunc Fragment(dstPos vec4, srcPos vec2, color vec4) vec4 {\n\n\n\tp0 := srcPos - 1/2.0\n\tp1 := srcPos + 1/2.0\n\n\n\n\n\n\tc0 := imageSrc0UnsafeAt(p0)\n\tc1 := imageSrc0UnsafeAt(vec2(p1.x, p0.y))\n\tc2 := imageSrc0UnsafeAt(vec2(p0.x, p1.y))\n\tc3 := imageSrc0UnsafeAt(p1)\n\n\n\n\trate := fract(p1)\n\n\tclr := mix(mix(c0, c1, rate.x), mix(c2, c3, rate.x), rate.y)\n\n\n\n\t// Apply the color scale.\n\tclr *= color\n\n\t// Add a noise less than one 8-bit step so that the quantization to the output doesn't make bands.\n\tclr.rgb = clamp(clr.rgb+ditherThreshold(floor(dstPos.xy))*DitherStrength/255, 0, clr.a)\n\n\n\treturn clr\n}\n\n"
+
+//ebitengine:shadersource
+const _ = "//kage:unit pixels\n\npackage main\n\n\nvar DitherMode int\nvar DitherStrength float\nvar BlueNoise [256]float\n\n// ditherThreshold returns the dithering threshold in [-0.5, 0.5) for the pixel p.\nfunc ditherThreshold(p vec2) float {\n\tif DitherMode == 1 {\n\t\t// The 4x4 Bayer matrix.\n\t\ta := mod(p, 2)\n\t\tb := mod(floor(p/2), 2)\n\t\tm := 4*(2*a.x+3*a.y-4*a.x*a.y) + (2*b.x + 3*b.y - 4*b.x*b.y)\n\t\treturn (m+0.5)/16 - 0.5\n\t}\n\tq := mod(p, 16)\n\treturn BlueNoise[int(q.y)*16+int(q.x)]\n}\n\n\n\n\nfunc Fragment(dstPos vec4, srcPos vec2, color vec4) vec4 {\n\n\n\t// inversedScale is the size of the region on the source image.\n\t// The size is the inverse of the geometry-matrix scale.\n\tinversedScale := vec2(abs(dfdx(srcPos.x)), abs(dfdy(srcPos.y)))\n\t// Cap the inversedScale to 1 as dfdx/dfdy is not accurate on some machines (#3182).\n\tinversedScale = min(inversedScale, vec2(1))\n\tp0 := srcPos - inversedScale/2.0\n\tp1 := srcPos + inversedScale/2.0\n\n\n\n\n\n\tc0 := imageSrc0UnsafeAt(p0)\n\tc1 := imageSrc0UnsafeAt(vec2(p1.x, p0.y))\n\tc2 := imageSrc0UnsafeAt(vec2(p0.x, p1.y))\n\tc3 := imageSrc0UnsafeAt(p1)\n\n\n\n\trate := clamp(fract(p1)/inversedScale, 0, 1)\n\n\tclr := mix(mix(c0, c1, rate.x), mix(c2, c3, rate.x), rate.y)\n\n\n\n\t// Apply the color scale.\n\tclr *= color\n\n\t// Add a noise less than one 8-bit step so that the quantization to the output doesn't make bands.\n\tclr.rgb = clamp(clr.rgb+ditherThreshold(floor(dstPos.xy))*DitherStrength/255, 0, clr.a)\n\n\n\treturn clr\n}\n\n"
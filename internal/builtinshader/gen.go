@@ -93,6 +93,64 @@ func xmain() error {
 		}
 	}
 
+	for filter := builtinshader.Filter(0); filter < builtinshader.FilterCount; filter++ {
+		for address := builtinshader.Address(0); address < builtinshader.AddressCount; address++ {
+			for _, useColorM := range []bool{false, true} {
+				for mask := builtinshader.MaskAlpha; mask < builtinshader.MaskCount; mask++ {
+					s := builtinshader.MaskShaderSource(filter, address, useColorM, mask)
+					if _, err := w.WriteString("\n"); err != nil {
+						return err
+					}
+					if _, err := w.WriteString("//ebitengine:shadersource\n"); err != nil {
+						return err
+					}
+					if _, err := fmt.Fprintf(w, "const _ = %q\n", s); err != nil {
+						return err
+					}
+				}
+			}
+		}
+	}
+
+	for _, useColorM := range []bool{false, true} {
+		s := builtinshader.TrilinearShaderSource(useColorM)
+		if _, err := w.WriteString("\n"); err != nil {
+			return err
+		}
+		if _, err := w.WriteString("//ebitengine:shadersource\n"); err != nil {
+			return err
+		}
+		if _, err := fmt.Fprintf(w, "const _ = %q\n", s); err != nil {
+			return err
+		}
+	}
+
+	{
+		s := builtinshader.GradientShaderSource()
+		if _, err := w.WriteString("\n"); err != nil {
+			return err
+		}
+		if _, err := w.WriteString("//ebitengine:shadersource\n"); err != nil {
+			return err
+		}
+		if _, err := fmt.Fprintf(w, "const _ = %q\n", s); err != nil {
+			return err
+		}
+	}
+
+	for filter := builtinshader.Filter(0); filter < builtinshader.FilterCount; filter++ {
+		s := builtinshader.ScreenDitherShaderSource(filter)
+		if _, err := w.WriteString("\n"); err != nil {
+			return err
+		}
+		if _, err := w.WriteString("//ebitengine:shadersource\n"); err != nil {
+			return err
+		}
+		if _, err := fmt.Fprintf(w, "const _ = %q\n", s); err != nil {
+			return err
+		}
+	}
+
 	if err := w.Flush(); err != nil {
 		return err
 	}
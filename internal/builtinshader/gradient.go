@@ -0,0 +1,80 @@
+// Copyright 2026 The Ebitengine Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package builtinshader
+
+// MaxGradientColorStops is the maximum number of the color stops of a gradient.
+const MaxGradientColorStops = 16
+
+const (
+	GradientTypeLinear = 0
+	GradientTypeRadial = 1
+)
+
+const (
+	UniformGradientType      = "GradientType"
+	UniformGradientStart     = "Start"
+	UniformGradientEnd       = "End"
+	UniformGradientRadius    = "Radius"
+	UniformGradientStopCount = "StopCount"
+	UniformGradientOffsets   = "Offsets"
+	UniformGradientColors    = "Colors"
+)
+
+// GradientShaderSource returns the built-in shader source to fill a gradient.
+//
+// The source position is the position in the destination image's coordinate.
+// The colors are premultiplied-alpha colors, and are interpolated as they are.
+func GradientShaderSource() []byte {
+	return []byte(gradientShaderSource)
+}
+
+const gradientShaderSource = `//kage:unit pixels
+
+package main
+
+var GradientType int
+var Start vec2
+var End vec2
+var Radius float
+var StopCount int
+var Offsets [16]float
+var Colors [16]vec4
+
+func Fragment(dstPos vec4, srcPos vec2, color vec4) vec4 {
+	var t float
+	if GradientType == 0 {
+		d := End - Start
+		t = dot(srcPos-Start, d) / dot(d, d)
+	} else {
+		t = length(srcPos-Start) / Radius
+	}
+
+	clr := Colors[0]
+	for i := 1; i < 16; i++ {
+		if i >= StopCount {
+			break
+		}
+		if t >= Offsets[i] {
+			clr = Colors[i]
+			continue
+		}
+		if t > Offsets[i-1] {
+			clr = mix(Colors[i-1], Colors[i], (t-Offsets[i-1])/(Offsets[i]-Offsets[i-1]))
+		}
+		break
+	}
+	return clr
+}
+`
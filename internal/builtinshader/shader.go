@@ -44,14 +44,39 @@ const (
 
 const AddressCount = 3
 
+type DitherMode int
+
+const (
+	DitherModeNone DitherMode = iota
+	DitherModeOrdered
+	DitherModeBlueNoise
+)
+
+type Mask int
+
+const (
+	MaskNone Mask = iota
+	MaskAlpha
+	MaskLuminance
+)
+
+const MaskCount = 3
+
 const (
 	UniformColorMBody        = "ColorMBody"
 	UniformColorMTranslation = "ColorMTranslation"
+	UniformDitherMode        = "DitherMode"
+	UniformDitherStrength    = "DitherStrength"
+	UniformBlueNoise         = "BlueNoise"
 )
 
 var (
 	shaders  [FilterCount][AddressCount][2][]byte
 	shadersM sync.Mutex
+
+	screenDitherShaders [FilterCount][]byte
+	maskShaders         [FilterCount][AddressCount][2][MaskCount][]byte
+	trilinearShaders    [2][]byte
 )
 
 var tmpl = template.Must(template.New("tmpl").Parse(`//kage:unit pixels
@@ -61,6 +86,23 @@ package main
 {{if .UseColorM}}
 var ColorMBody mat4
 var ColorMTranslation vec4
+{{end}}{{if .Dither}}
+var DitherMode int
+var DitherStrength float
+var BlueNoise [256]float
+
+// ditherThreshold returns the dithering threshold in [-0.5, 0.5) for the pixel p.
+func ditherThreshold(p vec2) float {
+	if DitherMode == {{.DitherModeOrdered}} {
+		// The 4x4 Bayer matrix.
+		a := mod(p, 2)
+		b := mod(floor(p/2), 2)
+		m := 4*(2*a.x+3*a.y-4*a.x*a.y) + (2*b.x + 3*b.y - 4*b.x*b.y)
+		return (m+0.5)/16 - 0.5
+	}
+	q := mod(p, 16)
+	return BlueNoise[int(q.y)*16+int(q.x)]
+}
 {{end}}
 
 {{if eq .Address .AddressRepeat}}
@@ -117,6 +159,24 @@ func Fragment(dstPos vec4, srcPos vec2, color vec4) vec4 {
 	rate := clamp(fract(p1)/inversedScale, 0, 1)
 {{end}}
 	clr := mix(mix(c0, c1, rate.x), mix(c2, c3, rate.x), rate.y)
+{{- if .Trilinear}}
+	// Blend the next mipmap level, which is the second source image, by the number of texels per pixel.
+	// The second source image is empty when the next level is not available.
+	texelsPerPixel := max(length(dfdx(srcPos)), length(dfdy(srcPos)))
+	if imageSrc1Size().x > 0 {
+		f := clamp(log2(texelsPerPixel), 0, 1)
+		// q is in the first source image's positions, as imageSrc1UnsafeAt converts it to the second source image's positions.
+		q := (srcPos-imageSrc0Origin())/2 + imageSrc0Origin()
+		q0 := q - 1/2.0
+		q1 := q + 1/2.0
+		n0 := imageSrc1UnsafeAt(q0)
+		n1 := imageSrc1UnsafeAt(vec2(q1.x, q0.y))
+		n2 := imageSrc1UnsafeAt(vec2(q0.x, q1.y))
+		n3 := imageSrc1UnsafeAt(q1)
+		nrate := fract(q1)
+		clr = mix(clr, mix(mix(n0, n1, nrate.x), mix(n2, n3, nrate.x), nrate.y), f)
+	}
+{{end}}
 {{end}}
 
 {{if .UseColorM}}
@@ -134,6 +194,26 @@ func Fragment(dstPos vec4, srcPos vec2, color vec4) vec4 {
 {{else}}
 	// Apply the color scale.
 	clr *= color
+{{end}}{{if ne .Mask .MaskNone}}
+	// Apply the mask, which is the second source image placed at the same position as the first one.
+{{if eq .Filter .FilterNearest}}
+{{if eq .Address .AddressRepeat}}
+	m := imageSrc1At(adjustSrcPosForAddressRepeat(srcPos))
+{{else}}
+	m := imageSrc1At(srcPos)
+{{end}}
+{{else}}
+	m := mix(mix(imageSrc1At(p0), imageSrc1At(vec2(p1.x, p0.y)), rate.x), mix(imageSrc1At(vec2(p0.x, p1.y)), imageSrc1At(p1), rate.x), rate.y)
+{{end}}
+{{if eq .Mask .MaskAlpha}}
+	clr *= m.a
+{{else if eq .Mask .MaskLuminance}}
+	// As the mask color is premultiplied, the luminance is 0 where the mask is transparent.
+	clr *= dot(m.rgb, vec3(0.2126, 0.7152, 0.0722))
+{{end}}
+{{end}}{{if .Dither}}
+	// Add a noise less than one 8-bit step so that the quantization to the output doesn't make bands.
+	clr.rgb = clamp(clr.rgb+ditherThreshold(floor(dstPos.xy))*DitherStrength/255, 0, clr.a)
 {{end}}
 
 	return clr
@@ -156,6 +236,71 @@ func ShaderSource(filter Filter, address Address, useColorM bool) []byte {
 		return s
 	}
 
+	b := executeTemplate(filter, address, useColorM, false, MaskNone, false)
+	shaders[filter][address][c] = b
+	return b
+}
+
+// ScreenDitherShaderSource returns the shader source to render the offscreen onto the final screen with dithering.
+//
+// The shader has the uniform variables UniformDitherMode, UniformDitherStrength, and UniformBlueNoise.
+// The strength is in 8-bit steps.
+func ScreenDitherShaderSource(filter Filter) []byte {
+	shadersM.Lock()
+	defer shadersM.Unlock()
+
+	if s := screenDitherShaders[filter]; s != nil {
+		return s
+	}
+
+	b := executeTemplate(filter, AddressUnsafe, false, true, MaskNone, false)
+	screenDitherShaders[filter] = b
+	return b
+}
+
+// MaskShaderSource returns the built-in shader source with a mask.
+//
+// The mask is the second source image, and is sampled at the same position as the first source image.
+// The color is multiplied by the mask value.
+func MaskShaderSource(filter Filter, address Address, useColorM bool, mask Mask) []byte {
+	shadersM.Lock()
+	defer shadersM.Unlock()
+
+	var c int
+	if useColorM {
+		c = 1
+	}
+	if s := maskShaders[filter][address][c][mask]; s != nil {
+		return s
+	}
+
+	b := executeTemplate(filter, address, useColorM, false, mask, false)
+	maskShaders[filter][address][c][mask] = b
+	return b
+}
+
+// TrilinearShaderSource returns the built-in shader source with the linear filter that blends two mipmap levels.
+//
+// The first source image is a mipmap level, and the second source image is the next level.
+// The second source image's region must be empty when the next level is not available.
+func TrilinearShaderSource(useColorM bool) []byte {
+	shadersM.Lock()
+	defer shadersM.Unlock()
+
+	var c int
+	if useColorM {
+		c = 1
+	}
+	if s := trilinearShaders[c]; s != nil {
+		return s
+	}
+
+	b := executeTemplate(FilterLinear, AddressUnsafe, useColorM, false, MaskNone, true)
+	trilinearShaders[c] = b
+	return b
+}
+
+func executeTemplate(filter Filter, address Address, useColorM bool, dither bool, mask Mask, trilinear bool) []byte {
 	var buf bytes.Buffer
 	if err := tmpl.Execute(&buf, struct {
 		Filter             Filter
@@ -167,6 +312,13 @@ func ShaderSource(filter Filter, address Address, useColorM bool) []byte {
 		AddressClampToZero Address
 		AddressRepeat      Address
 		UseColorM          bool
+		Dither             bool
+		DitherModeOrdered  DitherMode
+		Mask               Mask
+		MaskNone           Mask
+		MaskAlpha          Mask
+		MaskLuminance      Mask
+		Trilinear          bool
 	}{
 		Filter:             filter,
 		FilterNearest:      FilterNearest,
@@ -177,13 +329,17 @@ func ShaderSource(filter Filter, address Address, useColorM bool) []byte {
 		AddressClampToZero: AddressClampToZero,
 		AddressRepeat:      AddressRepeat,
 		UseColorM:          useColorM,
+		Dither:             dither,
+		DitherModeOrdered:  DitherModeOrdered,
+		Mask:               mask,
+		MaskNone:           MaskNone,
+		MaskAlpha:          MaskAlpha,
+		MaskLuminance:      MaskLuminance,
+		Trilinear:          trilinear,
 	}); err != nil {
 		panic(fmt.Sprintf("builtinshader: tmpl.Execute failed: %v", err))
 	}
-
-	b := buf.Bytes()
-	shaders[filter][address][c] = b
-	return b
+	return buf.Bytes()
 }
 
 //ebitengine:shadersource
@@ -195,3 +351,21 @@ func Fragment() vec4 {
 	return vec4(0)
 }
 `
+
+// FillDepthShaderSource is the shader source to fill an image with the vertex colors and reset its depth buffer.
+// The depth buffer is reset with the farthest value 1.
+//
+//ebitengine:shadersource
+const FillDepthShaderSource = `//kage:unit pixels
+//kage:depth write
+
+package main
+
+func Vertex(dstPos vec2, srcPos vec2, color vec4, custom vec4) (vec3, vec2, vec4, vec4) {
+	return vec3(dstPos, 1), srcPos, color, custom
+}
+
+func Fragment(dstPos vec4, srcPos vec2, color vec4) vec4 {
+	return color
+}
+`
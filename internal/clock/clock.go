@@ -16,6 +16,7 @@
 package clock
 
 import (
+	"math"
 	"sync"
 	"time"
 )
@@ -29,8 +30,14 @@ var (
 	// tps represents TPS (ticks per second).
 	tps = DefaultTPS
 
+	// tpsLimit is the upper limit of TPS. 0 means no limit.
+	tpsLimit int
+
 	lastNow int64
 
+	// frameDelta is the duration between the previous UpdateFrame and the current UpdateFrame.
+	frameDelta int64
+
 	// lastSystemTime is the last system time in the previous UpdateFrame.
 	// lastSystemTime indicates the logical time in the game, so this can be bigger than the current time.
 	lastSystemTime int64
@@ -142,8 +149,10 @@ func UpdateFrame() int {
 		// This ensures that now() must be monotonic (#875).
 		panic("clock: lastNow must be older than n")
 	}
+	frameDelta = n - lastNow
 	lastNow = n
 
+	tps := effectiveTPS()
 	c := 0
 	if tps == SyncWithFPS {
 		c = 1
@@ -155,14 +164,79 @@ func UpdateFrame() int {
 	return c
 }
 
+// FrameDelta returns the duration between the previous frame and the current frame.
+func FrameDelta() time.Duration {
+	m.Lock()
+	defer m.Unlock()
+	return time.Duration(frameDelta)
+}
+
+// TickDuration returns the duration of one tick in the real time with the current TPS.
+//
+// If tps is SyncWithFPS, TickDuration returns the duration between the previous frame and the current frame.
+// If tps <= 0 and not SyncWithFPS, TickDuration returns 0.
+func TickDuration() time.Duration {
+	m.Lock()
+	defer m.Unlock()
+
+	tps := effectiveTPS()
+	if tps == SyncWithFPS {
+		return time.Duration(frameDelta)
+	}
+	if tps <= 0 {
+		return 0
+	}
+	return time.Second / time.Duration(tps)
+}
+
+// TickProgress returns the progress from the last tick to the next tick in [0, 1).
+//
+// If tps is SyncWithFPS or tps <= 0, TickProgress always returns 0.
+func TickProgress() float64 {
+	m.Lock()
+	defer m.Unlock()
+
+	tps := effectiveTPS()
+	if tps <= 0 {
+		return 0
+	}
+	diff := now() - lastSystemTime
+	if diff <= 0 {
+		return 0
+	}
+	p := float64(diff) * float64(tps) / float64(time.Second)
+	if p >= 1 {
+		// The next tick is already late. Use the value just before 1.
+		return math.Nextafter(1, 0)
+	}
+	return p
+}
+
 func SetTPS(newTPS int) {
 	m.Lock()
 	defer m.Unlock()
 	tps = newTPS
 }
 
+// SetTPSLimit sets the upper limit of TPS. 0 means no limit.
+//
+// The limit doesn't affect SyncWithFPS.
+func SetTPSLimit(limit int) {
+	m.Lock()
+	defer m.Unlock()
+	tpsLimit = limit
+}
+
+// TPS returns the current TPS with the limit applied.
 func TPS() int {
 	m.Lock()
 	defer m.Unlock()
+	return effectiveTPS()
+}
+
+func effectiveTPS() int {
+	if tpsLimit > 0 && tps > tpsLimit {
+		return tpsLimit
+	}
 	return tps
 }
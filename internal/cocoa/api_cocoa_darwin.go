@@ -49,6 +49,11 @@ var (
 	sel_UTF8String                         = objc.RegisterName("UTF8String")
 	sel_length                             = objc.RegisterName("length")
 	sel_processInfo                        = objc.RegisterName("processInfo")
+	sel_beginActivityWithOptionsReason     = objc.RegisterName("beginActivityWithOptions:reason:")
+	sel_endActivity                        = objc.RegisterName("endActivity:")
+	sel_thermalState                       = objc.RegisterName("thermalState")
+	sel_isLowPowerModeEnabled              = objc.RegisterName("isLowPowerModeEnabled")
+	sel_respondsToSelector                 = objc.RegisterName("respondsToSelector:")
 	sel_frame                              = objc.RegisterName("frame")
 	sel_contentView                        = objc.RegisterName("contentView")
 	sel_setBackgroundColor                 = objc.RegisterName("setBackgroundColor:")
@@ -73,6 +78,11 @@ const (
 	NSWindowCollectionBehaviorFullScreenNone    = 1 << 9
 )
 
+const (
+	NSActivityIdleSystemSleepDisabled  = 1 << 20
+	NSActivityIdleDisplaySleepDisabled = 1 << 40
+)
+
 const (
 	NSWindowStyleMaskResizable  = 1 << 3
 	NSWindowStyleMaskFullScreen = 1 << 14
@@ -124,6 +134,28 @@ func NSProcessInfo_processInfo() NSProcessInfo {
 	return NSProcessInfo{objc.ID(class_NSProcessInfo).Send(sel_processInfo)}
 }
 
+func (p NSProcessInfo) BeginActivityWithOptionsReason(options uint64, reason NSString) objc.ID {
+	return p.Send(sel_beginActivityWithOptionsReason, options, reason.ID)
+}
+
+func (p NSProcessInfo) EndActivity(activity objc.ID) {
+	p.Send(sel_endActivity, activity)
+}
+
+// ThermalState returns NSProcessInfoThermalState.
+func (p NSProcessInfo) ThermalState() NSInteger {
+	return NSInteger(p.Send(sel_thermalState))
+}
+
+// IsLowPowerModeEnabled reports whether Low Power Mode is enabled.
+// IsLowPowerModeEnabled always returns false before macOS 12.
+func (p NSProcessInfo) IsLowPowerModeEnabled() bool {
+	if !objc.Send[bool](p.ID, sel_respondsToSelector, sel_isLowPowerModeEnabled) {
+		return false
+	}
+	return objc.Send[bool](p.ID, sel_isLowPowerModeEnabled)
+}
+
 type NSWindow struct {
 	objc.ID
 }
@@ -0,0 +1,401 @@
+// Copyright 2026 The Ebitengine Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dbus
+
+import (
+	"bufio"
+	"encoding/binary"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"os"
+	"reflect"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+const (
+	messageTypeMethodCall   = 1
+	messageTypeMethodReturn = 2
+	messageTypeError        = 3
+	messageTypeSignal       = 4
+)
+
+const (
+	flagNoReplyExpected = 0x1
+)
+
+const (
+	fieldPath        = 1
+	fieldInterface   = 2
+	fieldMember      = 3
+	fieldErrorName   = 4
+	fieldReplySerial = 5
+	fieldDestination = 6
+	fieldSignature   = 8
+)
+
+// Error represents an error returned by a D-Bus method call.
+type Error struct {
+	Name string
+	Body []any
+}
+
+func (e *Error) Error() string {
+	if len(e.Body) > 0 {
+		if msg, ok := e.Body[0].(string); ok {
+			return fmt.Sprintf("dbus: %s: %s", e.Name, msg)
+		}
+	}
+	return fmt.Sprintf("dbus: %s", e.Name)
+}
+
+type message struct {
+	typ         byte
+	flags       byte
+	serial      uint32
+	path        ObjectPath
+	iface       string
+	member      string
+	errorName   string
+	replySerial uint32
+	destination string
+	body        []any
+}
+
+// header field for encoding.
+type headerField struct {
+	Code  byte
+	Value Variant
+}
+
+func (m *message) encode() ([]byte, error) {
+	sig, err := signatureOfValues(m.body)
+	if err != nil {
+		return nil, err
+	}
+
+	body := &encoder{}
+	for _, v := range m.body {
+		if err := body.encode(reflect.ValueOf(v)); err != nil {
+			return nil, err
+		}
+	}
+
+	var fields []headerField
+	if m.path != "" {
+		fields = append(fields, headerField{fieldPath, Variant{m.path}})
+	}
+	if m.iface != "" {
+		fields = append(fields, headerField{fieldInterface, Variant{m.iface}})
+	}
+	if m.member != "" {
+		fields = append(fields, headerField{fieldMember, Variant{m.member}})
+	}
+	if m.destination != "" {
+		fields = append(fields, headerField{fieldDestination, Variant{m.destination}})
+	}
+	if len(sig) > 0 {
+		fields = append(fields, headerField{fieldSignature, Variant{sig}})
+	}
+
+	e := &encoder{}
+	e.buf = append(e.buf, 'l', m.typ, m.flags, 1)
+	e.uint32(uint32(len(body.buf)))
+	e.uint32(m.serial)
+	if err := e.encode(reflect.ValueOf(fields)); err != nil {
+		return nil, err
+	}
+	e.align(8)
+	return append(e.buf, body.buf...), nil
+}
+
+func readMessage(r io.Reader) (*message, error) {
+	var head [16]byte
+	if _, err := io.ReadFull(r, head[:]); err != nil {
+		return nil, err
+	}
+
+	var order binary.ByteOrder
+	switch head[0] {
+	case 'l':
+		order = binary.LittleEndian
+	case 'B':
+		order = binary.BigEndian
+	default:
+		return nil, fmt.Errorf("dbus: invalid endianness: %d", head[0])
+	}
+
+	bodyLen := int(order.Uint32(head[4:8]))
+	fieldsLen := int(order.Uint32(head[12:16]))
+	// The header is padded to a multiple of 8 bytes.
+	padding := (8 - (16+fieldsLen)%8) % 8
+	buf := make([]byte, fieldsLen+padding+bodyLen)
+	if _, err := io.ReadFull(r, buf); err != nil {
+		return nil, err
+	}
+
+	m := &message{
+		typ:    head[1],
+		flags:  head[2],
+		serial: order.Uint32(head[8:12]),
+	}
+
+	d := &decoder{
+		buf:    buf[:fieldsLen],
+		order:  order,
+		offset: 16,
+	}
+	var sig string
+	for d.pos < len(d.buf) {
+		if err := d.align(8); err != nil {
+			return nil, err
+		}
+		f, err := d.decodeAll("yv")
+		if err != nil {
+			return nil, err
+		}
+		v := f[1].(Variant).Value
+		switch f[0].(byte) {
+		case fieldPath:
+			m.path, _ = v.(ObjectPath)
+		case fieldInterface:
+			m.iface, _ = v.(string)
+		case fieldMember:
+			m.member, _ = v.(string)
+		case fieldErrorName:
+			m.errorName, _ = v.(string)
+		case fieldReplySerial:
+			m.replySerial, _ = v.(uint32)
+		case fieldDestination:
+			m.destination, _ = v.(string)
+		case fieldSignature:
+			s, _ := v.(Signature)
+			sig = string(s)
+		}
+	}
+
+	d = &decoder{
+		buf:   buf[fieldsLen+padding:],
+		order: order,
+	}
+	body, err := d.decodeAll(sig)
+	if err != nil {
+		return nil, err
+	}
+	m.body = body
+	return m, nil
+}
+
+// Conn represents a connection to a message bus.
+type Conn struct {
+	conn   net.Conn
+	r      *bufio.Reader
+	serial uint32
+	m      sync.Mutex
+}
+
+var (
+	theSessionBus  *Conn
+	sessionBusErr  error
+	sessionBusOnce sync.Once
+
+	theSystemBus  *Conn
+	systemBusErr  error
+	systemBusOnce sync.Once
+)
+
+// SessionBus returns a shared connection to the session bus.
+//
+// SessionBus is concurrent-safe.
+func SessionBus() (*Conn, error) {
+	sessionBusOnce.Do(func() {
+		theSessionBus, sessionBusErr = dialBus(sessionBusAddresses(), "session")
+	})
+	return theSessionBus, sessionBusErr
+}
+
+// SystemBus returns a shared connection to the system bus.
+//
+// SystemBus is concurrent-safe.
+func SystemBus() (*Conn, error) {
+	systemBusOnce.Do(func() {
+		theSystemBus, systemBusErr = dialBus(systemBusAddresses(), "system")
+	})
+	return theSystemBus, systemBusErr
+}
+
+func sessionBusAddresses() []string {
+	if addr := os.Getenv("DBUS_SESSION_BUS_ADDRESS"); addr != "" {
+		return strings.Split(addr, ";")
+	}
+	if dir := os.Getenv("XDG_RUNTIME_DIR"); dir != "" {
+		return []string{"unix:path=" + dir + "/bus"}
+	}
+	return []string{"unix:path=/run/user/" + strconv.Itoa(os.Getuid()) + "/bus"}
+}
+
+func systemBusAddresses() []string {
+	if addr := os.Getenv("DBUS_SYSTEM_BUS_ADDRESS"); addr != "" {
+		return strings.Split(addr, ";")
+	}
+	return []string{"unix:path=/var/run/dbus/system_bus_socket"}
+}
+
+func dialBus(addrs []string, name string) (*Conn, error) {
+	var errs []error
+	for _, addr := range addrs {
+		c, err := dial(addr)
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		return c, nil
+	}
+	if len(errs) == 0 {
+		return nil, fmt.Errorf("dbus: no %s bus address", name)
+	}
+	return nil, errors.Join(errs...)
+}
+
+func dial(addr string) (*Conn, error) {
+	transport, params, ok := strings.Cut(addr, ":")
+	if !ok || transport != "unix" {
+		return nil, fmt.Errorf("dbus: unsupported address: %s", addr)
+	}
+
+	var name string
+	for _, p := range strings.Split(params, ",") {
+		k, v, _ := strings.Cut(p, "=")
+		switch k {
+		case "path":
+			name = v
+		case "abstract":
+			name = "@" + v
+		}
+	}
+	if name == "" {
+		return nil, fmt.Errorf("dbus: unsupported address: %s", addr)
+	}
+
+	conn, err := net.Dial("unix", name)
+	if err != nil {
+		return nil, err
+	}
+	c := &Conn{
+		conn: conn,
+		r:    bufio.NewReader(conn),
+	}
+	if err := c.auth(); err != nil {
+		_ = conn.Close()
+		return nil, err
+	}
+	if _, err := c.Call("org.freedesktop.DBus", "/org/freedesktop/DBus", "org.freedesktop.DBus", "Hello"); err != nil {
+		_ = conn.Close()
+		return nil, err
+	}
+	return c, nil
+}
+
+func (c *Conn) auth() error {
+	uid := hex.EncodeToString([]byte(strconv.Itoa(os.Getuid())))
+	if _, err := c.conn.Write([]byte("\x00AUTH EXTERNAL " + uid + "\r\n")); err != nil {
+		return err
+	}
+	line, err := c.r.ReadString('\n')
+	if err != nil {
+		return err
+	}
+	if !strings.HasPrefix(line, "OK ") {
+		return fmt.Errorf("dbus: authentication failed: %s", strings.TrimSpace(line))
+	}
+	if _, err := c.conn.Write([]byte("BEGIN\r\n")); err != nil {
+		return err
+	}
+	return nil
+}
+
+// Close closes the connection.
+func (c *Conn) Close() error {
+	return c.conn.Close()
+}
+
+func (c *Conn) send(m *message) error {
+	c.serial++
+	m.serial = c.serial
+	b, err := m.encode()
+	if err != nil {
+		return err
+	}
+	_, err = c.conn.Write(b)
+	return err
+}
+
+// Call calls a method and waits for its reply.
+//
+// Call is concurrent-safe.
+func (c *Conn) Call(destination string, path ObjectPath, iface, method string, args ...any) ([]any, error) {
+	c.m.Lock()
+	defer c.m.Unlock()
+
+	m := &message{
+		typ:         messageTypeMethodCall,
+		path:        path,
+		iface:       iface,
+		member:      method,
+		destination: destination,
+		body:        args,
+	}
+	if err := c.send(m); err != nil {
+		return nil, err
+	}
+
+	for {
+		r, err := readMessage(c.r)
+		if err != nil {
+			return nil, err
+		}
+		// Skip messages other than the reply, e.g. signals.
+		if r.replySerial != m.serial {
+			continue
+		}
+		switch r.typ {
+		case messageTypeMethodReturn:
+			return r.body, nil
+		case messageTypeError:
+			return nil, &Error{Name: r.errorName, Body: r.body}
+		}
+	}
+}
+
+// Emit emits a signal.
+//
+// Emit is concurrent-safe.
+func (c *Conn) Emit(path ObjectPath, iface, member string, args ...any) error {
+	c.m.Lock()
+	defer c.m.Unlock()
+
+	return c.send(&message{
+		typ:    messageTypeSignal,
+		flags:  flagNoReplyExpected,
+		path:   path,
+		iface:  iface,
+		member: member,
+		body:   args,
+	})
+}
@@ -0,0 +1,73 @@
+// Copyright 2026 The Ebitengine Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dbus_test
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/duplicants-ai/ebiten/internal/dbus"
+)
+
+func TestEncodeAndDecode(t *testing.T) {
+	cases := []struct {
+		In   []any
+		Want []any
+	}{
+		{
+			In:   nil,
+			Want: nil,
+		},
+		{
+			In:   []any{byte(1), true, int16(-2), uint16(3), int32(-4), uint32(5), int64(-6), uint64(7), 8.5, "foo"},
+			Want: []any{byte(1), true, int16(-2), uint16(3), int32(-4), uint32(5), int64(-6), uint64(7), 8.5, "foo"},
+		},
+		{
+			In:   []any{dbus.ObjectPath("/foo/bar"), dbus.Signature("a{sv}"), dbus.Variant{Value: uint32(1)}},
+			Want: []any{dbus.ObjectPath("/foo/bar"), dbus.Signature("a{sv}"), dbus.Variant{Value: uint32(1)}},
+		},
+		{
+			In:   []any{byte(1), []string{"a", "bc"}, []uint64{}, []uint64{1, 2}},
+			Want: []any{byte(1), []any{"a", "bc"}, []any(nil), []any{uint64(1), uint64(2)}},
+		},
+		{
+			In: []any{"application://foo.desktop", map[string]dbus.Variant{
+				"progress": {Value: 0.5},
+			}},
+			Want: []any{"application://foo.desktop", map[any]any{
+				"progress": dbus.Variant{Value: 0.5},
+			}},
+		},
+		{
+			In: []any{struct {
+				A byte
+				B string
+			}{1, "foo"}},
+			Want: []any{[]any{byte(1), "foo"}},
+		},
+	}
+	for _, c := range cases {
+		m, err := dbus.EncodeAndDecodeMessage("Test", c.In)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got, want := m.Member(), "Test"; got != want {
+			t.Errorf("member: got: %s, want: %s", got, want)
+		}
+		if got, want := m.Body(), c.Want; !reflect.DeepEqual(got, want) {
+			t.Errorf("body: got: %#v, want: %#v", got, want)
+		}
+	}
+}
@@ -0,0 +1,503 @@
+// Copyright 2026 The Ebitengine Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package dbus implements a minimal D-Bus client to call desktop services on Linux and other Unix-like systems.
+//
+// Only the features Ebitengine needs are implemented: method calls and signal emissions on the session bus.
+package dbus
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"math"
+	"reflect"
+)
+
+// ObjectPath represents a D-Bus object path.
+type ObjectPath string
+
+// Signature represents a D-Bus type signature.
+type Signature string
+
+// Variant represents a D-Bus variant value.
+type Variant struct {
+	Value any
+}
+
+var (
+	objectPathType = reflect.TypeOf(ObjectPath(""))
+	signatureType  = reflect.TypeOf(Signature(""))
+	variantType    = reflect.TypeOf(Variant{})
+)
+
+// signatureOf returns the D-Bus signature of the given Go type.
+func signatureOf(t reflect.Type) (string, error) {
+	switch t {
+	case objectPathType:
+		return "o", nil
+	case signatureType:
+		return "g", nil
+	case variantType:
+		return "v", nil
+	}
+	switch t.Kind() {
+	case reflect.Uint8:
+		return "y", nil
+	case reflect.Bool:
+		return "b", nil
+	case reflect.Int16:
+		return "n", nil
+	case reflect.Uint16:
+		return "q", nil
+	case reflect.Int32:
+		return "i", nil
+	case reflect.Uint32:
+		return "u", nil
+	case reflect.Int64:
+		return "x", nil
+	case reflect.Uint64:
+		return "t", nil
+	case reflect.Float64:
+		return "d", nil
+	case reflect.String:
+		return "s", nil
+	case reflect.Slice:
+		s, err := signatureOf(t.Elem())
+		if err != nil {
+			return "", err
+		}
+		return "a" + s, nil
+	case reflect.Map:
+		k, err := signatureOf(t.Key())
+		if err != nil {
+			return "", err
+		}
+		v, err := signatureOf(t.Elem())
+		if err != nil {
+			return "", err
+		}
+		return "a{" + k + v + "}", nil
+	case reflect.Struct:
+		s := "("
+		for i := 0; i < t.NumField(); i++ {
+			f, err := signatureOf(t.Field(i).Type)
+			if err != nil {
+				return "", err
+			}
+			s += f
+		}
+		return s + ")", nil
+	}
+	return "", fmt.Errorf("dbus: unsupported type: %v", t)
+}
+
+// signatureOfValues returns the D-Bus signature of the given values.
+func signatureOfValues(values []any) (Signature, error) {
+	var sig string
+	for _, v := range values {
+		s, err := signatureOf(reflect.TypeOf(v))
+		if err != nil {
+			return "", err
+		}
+		sig += s
+	}
+	return Signature(sig), nil
+}
+
+// alignment returns the alignment of the D-Bus type starting with the given signature byte.
+func alignment(c byte) int {
+	switch c {
+	case 'y', 'g', 'v':
+		return 1
+	case 'n', 'q':
+		return 2
+	case 'b', 'i', 'u', 's', 'o', 'a', 'h':
+		return 4
+	case 'x', 't', 'd', '(', '{':
+		return 8
+	}
+	return 1
+}
+
+// nextSignature returns the first complete type in sig and the rest.
+func nextSignature(sig string) (string, string, error) {
+	if len(sig) == 0 {
+		return "", "", errors.New("dbus: empty signature")
+	}
+	switch sig[0] {
+	case 'a':
+		elem, rest, err := nextSignature(sig[1:])
+		if err != nil {
+			return "", "", err
+		}
+		return "a" + elem, rest, nil
+	case '(', '{':
+		end := byte(')')
+		if sig[0] == '{' {
+			end = '}'
+		}
+		depth := 0
+		for i := 0; i < len(sig); i++ {
+			switch sig[i] {
+			case '(', '{':
+				depth++
+			case ')', '}':
+				depth--
+				if depth == 0 {
+					if sig[i] != end {
+						return "", "", fmt.Errorf("dbus: invalid signature: %s", sig)
+					}
+					return sig[:i+1], sig[i+1:], nil
+				}
+			}
+		}
+		return "", "", fmt.Errorf("dbus: invalid signature: %s", sig)
+	}
+	return sig[:1], sig[1:], nil
+}
+
+// encoder encodes values in the D-Bus wire format with the little endian.
+type encoder struct {
+	buf []byte
+
+	// offset is the offset of buf from the beginning of the message, which is used for alignment.
+	offset int
+}
+
+func (e *encoder) align(n int) {
+	for (e.offset+len(e.buf))%n != 0 {
+		e.buf = append(e.buf, 0)
+	}
+}
+
+func (e *encoder) uint32(v uint32) {
+	e.align(4)
+	e.buf = binary.LittleEndian.AppendUint32(e.buf, v)
+}
+
+func (e *encoder) string(v string) {
+	e.uint32(uint32(len(v)))
+	e.buf = append(e.buf, v...)
+	e.buf = append(e.buf, 0)
+}
+
+func (e *encoder) signature(v string) {
+	e.buf = append(e.buf, byte(len(v)))
+	e.buf = append(e.buf, v...)
+	e.buf = append(e.buf, 0)
+}
+
+func (e *encoder) encode(v reflect.Value) error {
+	switch v.Type() {
+	case objectPathType:
+		e.string(v.String())
+		return nil
+	case signatureType:
+		e.signature(v.String())
+		return nil
+	case variantType:
+		val := v.Interface().(Variant).Value
+		if val == nil {
+			return errors.New("dbus: a variant must not be nil")
+		}
+		sig, err := signatureOf(reflect.TypeOf(val))
+		if err != nil {
+			return err
+		}
+		e.signature(sig)
+		return e.encode(reflect.ValueOf(val))
+	}
+
+	switch v.Kind() {
+	case reflect.Uint8:
+		e.buf = append(e.buf, byte(v.Uint()))
+	case reflect.Bool:
+		var b uint32
+		if v.Bool() {
+			b = 1
+		}
+		e.uint32(b)
+	case reflect.Int16, reflect.Uint16:
+		e.align(2)
+		var x uint16
+		if v.Kind() == reflect.Int16 {
+			x = uint16(v.Int())
+		} else {
+			x = uint16(v.Uint())
+		}
+		e.buf = binary.LittleEndian.AppendUint16(e.buf, x)
+	case reflect.Int32:
+		e.uint32(uint32(v.Int()))
+	case reflect.Uint32:
+		e.uint32(uint32(v.Uint()))
+	case reflect.Int64, reflect.Uint64, reflect.Float64:
+		e.align(8)
+		var x uint64
+		switch v.Kind() {
+		case reflect.Int64:
+			x = uint64(v.Int())
+		case reflect.Uint64:
+			x = v.Uint()
+		case reflect.Float64:
+			x = math.Float64bits(v.Float())
+		}
+		e.buf = binary.LittleEndian.AppendUint64(e.buf, x)
+	case reflect.String:
+		e.string(v.String())
+	case reflect.Slice, reflect.Map:
+		elemSig, err := signatureOf(v.Type())
+		if err != nil {
+			return err
+		}
+		e.uint32(0)
+		lenPos := len(e.buf) - 4
+		// The padding for the first element is not included in the array length.
+		e.align(alignment(elemSig[1]))
+		start := len(e.buf)
+		if v.Kind() == reflect.Slice {
+			for i := 0; i < v.Len(); i++ {
+				if err := e.encode(v.Index(i)); err != nil {
+					return err
+				}
+			}
+		} else {
+			iter := v.MapRange()
+			for iter.Next() {
+				e.align(8)
+				if err := e.encode(iter.Key()); err != nil {
+					return err
+				}
+				if err := e.encode(iter.Value()); err != nil {
+					return err
+				}
+			}
+		}
+		binary.LittleEndian.PutUint32(e.buf[lenPos:], uint32(len(e.buf)-start))
+	case reflect.Struct:
+		e.align(8)
+		for i := 0; i < v.NumField(); i++ {
+			if err := e.encode(v.Field(i)); err != nil {
+				return err
+			}
+		}
+	default:
+		return fmt.Errorf("dbus: unsupported type: %v", v.Type())
+	}
+	return nil
+}
+
+// decoder decodes values in the D-Bus wire format.
+type decoder struct {
+	buf   []byte
+	pos   int
+	order binary.ByteOrder
+
+	// offset is the offset of buf from the beginning of the message, which is used for alignment.
+	offset int
+}
+
+var errTruncated = errors.New("dbus: truncated message")
+
+func (d *decoder) align(n int) error {
+	for (d.offset+d.pos)%n != 0 {
+		d.pos++
+	}
+	if d.pos > len(d.buf) {
+		return errTruncated
+	}
+	return nil
+}
+
+func (d *decoder) read(n int) ([]byte, error) {
+	if d.pos+n > len(d.buf) {
+		return nil, errTruncated
+	}
+	b := d.buf[d.pos : d.pos+n]
+	d.pos += n
+	return b, nil
+}
+
+func (d *decoder) uint32() (uint32, error) {
+	if err := d.align(4); err != nil {
+		return 0, err
+	}
+	b, err := d.read(4)
+	if err != nil {
+		return 0, err
+	}
+	return d.order.Uint32(b), nil
+}
+
+func (d *decoder) string() (string, error) {
+	n, err := d.uint32()
+	if err != nil {
+		return "", err
+	}
+	b, err := d.read(int(n) + 1)
+	if err != nil {
+		return "", err
+	}
+	return string(b[:n]), nil
+}
+
+func (d *decoder) signature() (string, error) {
+	b, err := d.read(1)
+	if err != nil {
+		return "", err
+	}
+	s, err := d.read(int(b[0]) + 1)
+	if err != nil {
+		return "", err
+	}
+	return string(s[:b[0]]), nil
+}
+
+// decodeAll decodes all the values of the given signature.
+func (d *decoder) decodeAll(sig string) ([]any, error) {
+	var values []any
+	for len(sig) > 0 {
+		s, rest, err := nextSignature(sig)
+		if err != nil {
+			return nil, err
+		}
+		v, err := d.decode(s)
+		if err != nil {
+			return nil, err
+		}
+		values = append(values, v)
+		sig = rest
+	}
+	return values, nil
+}
+
+// decode decodes a value of the given complete type.
+//
+// Arrays are decoded as []any, dictionaries are decoded as map[any]any, and structs are decoded as []any.
+func (d *decoder) decode(sig string) (any, error) {
+	switch sig[0] {
+	case 'y':
+		b, err := d.read(1)
+		if err != nil {
+			return nil, err
+		}
+		return b[0], nil
+	case 'b':
+		v, err := d.uint32()
+		if err != nil {
+			return nil, err
+		}
+		return v != 0, nil
+	case 'n', 'q':
+		if err := d.align(2); err != nil {
+			return nil, err
+		}
+		b, err := d.read(2)
+		if err != nil {
+			return nil, err
+		}
+		v := d.order.Uint16(b)
+		if sig[0] == 'n' {
+			return int16(v), nil
+		}
+		return v, nil
+	case 'i':
+		v, err := d.uint32()
+		if err != nil {
+			return nil, err
+		}
+		return int32(v), nil
+	case 'u', 'h':
+		return d.uint32()
+	case 'x', 't', 'd':
+		if err := d.align(8); err != nil {
+			return nil, err
+		}
+		b, err := d.read(8)
+		if err != nil {
+			return nil, err
+		}
+		v := d.order.Uint64(b)
+		switch sig[0] {
+		case 'x':
+			return int64(v), nil
+		case 'd':
+			return math.Float64frombits(v), nil
+		}
+		return v, nil
+	case 's':
+		return d.string()
+	case 'o':
+		s, err := d.string()
+		return ObjectPath(s), err
+	case 'g':
+		s, err := d.signature()
+		return Signature(s), err
+	case 'v':
+		s, err := d.signature()
+		if err != nil {
+			return nil, err
+		}
+		v, err := d.decode(s)
+		if err != nil {
+			return nil, err
+		}
+		return Variant{Value: v}, nil
+	case 'a':
+		n, err := d.uint32()
+		if err != nil {
+			return nil, err
+		}
+		elemSig := sig[1:]
+		if err := d.align(alignment(elemSig[0])); err != nil {
+			return nil, err
+		}
+		end := d.pos + int(n)
+		if end > len(d.buf) {
+			return nil, errTruncated
+		}
+		if elemSig[0] == '{' {
+			m := map[any]any{}
+			for d.pos < end {
+				if err := d.align(8); err != nil {
+					return nil, err
+				}
+				kv, err := d.decodeAll(elemSig[1 : len(elemSig)-1])
+				if err != nil {
+					return nil, err
+				}
+				if len(kv) != 2 {
+					return nil, fmt.Errorf("dbus: invalid dictionary entry: %s", elemSig)
+				}
+				m[kv[0]] = kv[1]
+			}
+			return m, nil
+		}
+		var vs []any
+		for d.pos < end {
+			v, err := d.decode(elemSig)
+			if err != nil {
+				return nil, err
+			}
+			vs = append(vs, v)
+		}
+		return vs, nil
+	case '(':
+		if err := d.align(8); err != nil {
+			return nil, err
+		}
+		return d.decodeAll(sig[1 : len(sig)-1])
+	}
+	return nil, fmt.Errorf("dbus: unsupported signature: %s", sig)
+}
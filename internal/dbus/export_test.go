@@ -0,0 +1,44 @@
+// Copyright 2026 The Ebitengine Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dbus
+
+import (
+	"bytes"
+)
+
+type Message = message
+
+func EncodeAndDecodeMessage(member string, body []any) (*Message, error) {
+	m := &message{
+		typ:    messageTypeSignal,
+		path:   "/org/ebitengine/Test",
+		iface:  "org.ebitengine.Test",
+		member: member,
+		body:   body,
+	}
+	b, err := m.encode()
+	if err != nil {
+		return nil, err
+	}
+	return readMessage(bytes.NewReader(b))
+}
+
+func (m *Message) Member() string {
+	return m.member
+}
+
+func (m *Message) Body() []any {
+	return m.body
+}
@@ -27,6 +27,16 @@ var theFrameLogger = &frameLogger{}
 
 var flushM sync.Mutex
 
+// maxRecentLogs is the maximum number of the recent logs kept for error reports.
+const maxRecentLogs = 64
+
+// recentLogs is a ring buffer of the recently flushed logs.
+// recentLogs is protected by flushM.
+var (
+	recentLogs      [maxRecentLogs]string
+	recentLogsCount int
+)
+
 // FrameLogf calls the current global logger's FrameLogf.
 // FrameLogf buffers the arguments and doesn't dump the log immediately.
 // You can dump logs by calling SwitchLogger and Flush.
@@ -70,8 +80,24 @@ func (l *frameLogger) Flush() {
 	defer flushM.Unlock()
 
 	for i, item := range l.items {
-		fmt.Printf(item.format, item.args...)
+		str := fmt.Sprintf(item.format, item.args...)
+		fmt.Print(str)
+		recentLogs[recentLogsCount%maxRecentLogs] = str
+		recentLogsCount++
 		l.items[i] = logItem{}
 	}
 	l.items = l.items[:0]
 }
+
+// RecentFrameLogs returns the recently flushed logs in order.
+func RecentFrameLogs() []string {
+	flushM.Lock()
+	defer flushM.Unlock()
+
+	n := min(recentLogsCount, maxRecentLogs)
+	logs := make([]string, 0, n)
+	for i := recentLogsCount - n; i < recentLogsCount; i++ {
+		logs = append(logs, recentLogs[i%maxRecentLogs])
+	}
+	return logs
+}
@@ -32,3 +32,7 @@ func (dummyFrameLogger) FrameLogf(format string, args ...any) {
 
 func (dummyFrameLogger) Flush() {
 }
+
+func RecentFrameLogs() []string {
+	return nil
+}
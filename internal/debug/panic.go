@@ -0,0 +1,67 @@
+// Copyright 2026 The Ebitengine Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package debug
+
+import (
+	"fmt"
+	"runtime/debug"
+	"sync/atomic"
+)
+
+// PanicError is an error converted from a recovered panic.
+type PanicError struct {
+	// Value is the value given to panic.
+	Value any
+
+	// Stack is the stack trace of the goroutine where the panic happened.
+	Stack []byte
+}
+
+func (p *PanicError) Error() string {
+	return fmt.Sprintf("panic: %v\n\n%s", p.Value, p.Stack)
+}
+
+// Unwrap returns the value given to panic if the value is an error.
+func (p *PanicError) Unwrap() error {
+	if err, ok := p.Value.(error); ok {
+		return err
+	}
+	return nil
+}
+
+var panicCaptureEnabled atomic.Bool
+
+// EnablePanicCapture enables converting panics in the game functions and the render thread to errors.
+func EnablePanicCapture() {
+	panicCaptureEnabled.Store(true)
+}
+
+// IsPanicCaptureEnabled reports whether panics should be converted to errors.
+func IsPanicCaptureEnabled() bool {
+	return panicCaptureEnabled.Load()
+}
+
+// CapturePanic recovers a panic and stores it to err as a *PanicError.
+// CapturePanic must be called directly as a deferred function.
+func CapturePanic(err *error) {
+	r := recover()
+	if r == nil {
+		return
+	}
+	*err = &PanicError{
+		Value: r,
+		Stack: debug.Stack(),
+	}
+}
@@ -24,6 +24,12 @@ const kIOReturnSuccess = 0
 
 const kIOHIDOptionsTypeNone _IOOptionBits = 0
 
+const (
+	kIOHIDReportTypeInput   _IOHIDReportType = 0
+	kIOHIDReportTypeOutput  _IOHIDReportType = 1
+	kIOHIDReportTypeFeature _IOHIDReportType = 2
+)
+
 const (
 	kIOHIDElementTypeInput_Misc   = 1
 	kIOHIDElementTypeInput_Button = 2
@@ -82,6 +88,7 @@ type (
 	_IOHIDValueRef    uintptr
 	_IOReturn         int32
 	_IOHIDElementType uint32
+	_IOHIDReportType  uint32
 )
 
 type _IOHIDDeviceCallback func(context unsafe.Pointer, result _IOReturn, sender unsafe.Pointer, device _IOHIDDeviceRef)
@@ -108,6 +115,8 @@ func initializeIOKit() error {
 	purego.RegisterLibFunc(&_IOHIDDeviceGetValue, iokit, "IOHIDDeviceGetValue")
 	purego.RegisterLibFunc(&_IOHIDValueGetIntegerValue, iokit, "IOHIDValueGetIntegerValue")
 	purego.RegisterLibFunc(&_IOHIDDeviceCopyMatchingElements, iokit, "IOHIDDeviceCopyMatchingElements")
+	purego.RegisterLibFunc(&_IOHIDDeviceSetReport, iokit, "IOHIDDeviceSetReport")
+	purego.RegisterLibFunc(&_IOHIDDeviceGetReport, iokit, "IOHIDDeviceGetReport")
 
 	return nil
 }
@@ -129,4 +138,6 @@ var (
 	_IOHIDDeviceGetValue                        func(device _IOHIDDeviceRef, element _IOHIDElementRef, pValue *_IOHIDValueRef) _IOReturn
 	_IOHIDValueGetIntegerValue                  func(value _IOHIDValueRef) _CFIndex
 	_IOHIDDeviceCopyMatchingElements            func(device _IOHIDDeviceRef, matching _CFDictionaryRef, options _IOOptionBits) _CFArrayRef
+	_IOHIDDeviceSetReport                       func(device _IOHIDDeviceRef, reportType _IOHIDReportType, reportID _CFIndex, report *byte, reportLength _CFIndex) _IOReturn
+	_IOHIDDeviceGetReport                       func(device _IOHIDDeviceRef, reportType _IOHIDReportType, reportID _CFIndex, report *byte, pReportLength *_CFIndex) _IOReturn
 )
@@ -18,6 +18,7 @@ package gamepad
 // #cgo LDFLAGS: -framework Foundation -framework GameController
 //
 // #import <GameController/GameController.h>
+// #import <TargetConditionals.h>
 //
 // static NSString* GCInputXboxShareButton = @"Button Share";
 //
@@ -231,6 +232,20 @@ package gamepad
 //
 //       property->nAxes = 6;
 //       property->nHats = 1;
+//     } else if (controller.microGamepad) {
+//       // A micro gamepad is a Siri Remote on tvOS.
+//       // Follow SDL's way so that the GUID matches with the Remote's mapping in the database.
+//       property->buttonMask |= (1 << kControllerButtonA);
+//       property->buttonMask |= (1 << kControllerButtonB);
+//       property->buttonMask |= (1 << kControllerButtonStart);
+//       property->nButtons = 3;
+//       // Treat the touch surface as two axes.
+//       property->nAxes = 2;
+//       property->nHats = 0;
+//
+//       vendor = kUSBVendorApple;
+//       product = 3;
+//       subtype = 3;
 //     }
 //
 //     const int kSDLHardwareBusBluetooth = 0x05;
@@ -258,10 +273,17 @@ package gamepad
 // }
 //
 // static void addController(GCController* controller) {
+// #if TARGET_OS_TV
+//   // On tvOS, a Siri Remote, which has only a micro gamepad profile, is treated as a gamepad.
+//   if (!controller.extendedGamepad && controller.microGamepad) {
+//     controller.microGamepad.reportsAbsoluteDpadValues = YES;
+//   }
+// #else
 //   // Ignore if the controller is not an actual controller.
 //   if (!controller.extendedGamepad && controller.microGamepad) {
 //     return;
 //   }
+// #endif
 //
 //   struct ControllerProperty property = {};
 //   getControllerPropertyFromController(controller, &property);
@@ -361,6 +383,21 @@ package gamepad
 //       if (nHats) {
 //         controllerState->hat = getHatState(gamepad.dpad);
 //       }
+//     } else if (controller.microGamepad) {
+//       GCMicroGamepad* gamepad = controller.microGamepad;
+//
+//       controllerState->axes[0] = gamepad.dpad.xAxis.value;
+//       controllerState->axes[1] = -gamepad.dpad.yAxis.value;
+//
+//       // The order must match with the Remote's mapping in the database: the Menu button is treated as B.
+//       controllerState->buttons[0] = gamepad.buttonA.isPressed;
+//       controllerState->buttons[1] = gamepad.buttonX.isPressed;
+// #pragma clang diagnostic push
+// #pragma clang diagnostic ignored "-Wunguarded-availability-new"
+//       if ([gamepad respondsToSelector:@selector(buttonMenu)]) {
+//         controllerState->buttons[2] = gamepad.buttonMenu.isPressed;
+//       }
+// #pragma clang diagnostic pop
 //     }
 //   }
 // }
@@ -126,11 +126,16 @@ func (g *gamepads) get(id ID) *Gamepad {
 	return g.gamepads[id]
 }
 
+// find finds a native gamepad satisfying cond.
+// Virtual gamepads are skipped.
 func (g *gamepads) find(cond func(*Gamepad) bool) *Gamepad {
 	for _, gp := range g.gamepads {
 		if gp == nil {
 			continue
 		}
+		if gp.isVirtual() {
+			continue
+		}
 		if cond(gp) {
 			return gp
 		}
@@ -158,11 +163,16 @@ func (g *gamepads) add(name, sdlID string) *Gamepad {
 	return gp
 }
 
+// remove removes native gamepads satisfying cond.
+// Virtual gamepads are skipped.
 func (g *gamepads) remove(cond func(*Gamepad) bool) {
 	for i, gp := range g.gamepads {
 		if gp == nil {
 			continue
 		}
+		if gp.isVirtual() {
+			continue
+		}
 		if cond(gp) {
 			g.gamepads[i] = nil
 		}
@@ -16,6 +16,8 @@ package gamepad
 
 import (
 	"encoding/hex"
+	"regexp"
+	"strconv"
 	"syscall/js"
 	"time"
 
@@ -26,6 +28,35 @@ var (
 	object = js.Global().Get("Object")
 )
 
+var (
+	// chromeGamepadIDPattern matches an ID like "USB Gamepad (Vendor: 0079 Product: 0011)" on Chrome.
+	chromeGamepadIDPattern = regexp.MustCompile(`Vendor: ([0-9a-fA-F]{1,4}) Product: ([0-9a-fA-F]{1,4})`)
+
+	// firefoxGamepadIDPattern matches an ID like "045e-028e-Xbox 360 Wired Controller" on Firefox and Safari.
+	firefoxGamepadIDPattern = regexp.MustCompile(`^([0-9a-fA-F]{1,4})-([0-9a-fA-F]{1,4})-`)
+)
+
+// vendorProductIDFromName extracts the vendor ID and the product ID from a gamepad ID of the Gamepad API.
+// The format depends on browsers.
+func vendorProductIDFromName(name string) (vendor, product uint16, ok bool) {
+	m := chromeGamepadIDPattern.FindStringSubmatch(name)
+	if m == nil {
+		m = firefoxGamepadIDPattern.FindStringSubmatch(name)
+	}
+	if m == nil {
+		return 0, 0, false
+	}
+	v, err := strconv.ParseUint(m[1], 16, 16)
+	if err != nil {
+		return 0, 0, false
+	}
+	p, err := strconv.ParseUint(m[2], 16, 16)
+	if err != nil {
+		return 0, 0, false
+	}
+	return uint16(v), uint16(p), true
+}
+
 type nativeGamepadsImpl struct {
 	indices map[int]struct{}
 }
@@ -52,6 +83,11 @@ func (g *nativeGamepadsImpl) update(gamepads *gamepads) error {
 		return nil
 	}
 
+	// getGamepads doesn't exist on Web Workers.
+	if js.Global().Get("WorkerGlobalScope").Truthy() {
+		return nil
+	}
+
 	// getGamepads might not exist under a non-secure context (#2100).
 	if !nav.Get("getGamepads").Truthy() {
 		js.Global().Get("console").Call("warn", "navigator.getGamepads is not available. This might require a secure (HTTPS) context.")
@@ -89,10 +125,12 @@ func (g *nativeGamepadsImpl) update(gamepads *gamepads) error {
 			copy(sdlID[:], []byte(name))
 
 			gamepad = gamepads.add(name, hex.EncodeToString(sdlID[:]))
-			gamepad.native = &nativeGamepadImpl{
+			n := &nativeGamepadImpl{
 				index:   index,
 				mapping: gp.Get("mapping").String(),
 			}
+			n.vendor, n.product, n.vendorProductOK = vendorProductIDFromName(name)
+			gamepad.native = n
 		}
 		gamepad.native.(*nativeGamepadImpl).value = gp
 	}
@@ -110,6 +148,14 @@ type nativeGamepadImpl struct {
 	value   js.Value
 	index   int
 	mapping string
+
+	vendor          uint16
+	product         uint16
+	vendorProductOK bool
+}
+
+func (g *nativeGamepadImpl) vendorProductID() (uint16, uint16, bool) {
+	return g.vendor, g.product, g.vendorProductOK
 }
 
 func (g *nativeGamepadImpl) hasOwnStandardLayoutMapping() bool {
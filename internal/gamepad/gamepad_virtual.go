@@ -0,0 +1,174 @@
+// Copyright 2026 The Ebitengine Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package gamepad
+
+import (
+	"time"
+
+	"github.com/duplicants-ai/ebiten/internal/gamepaddb"
+)
+
+// VirtualGamepad is a gamepad whose state is set by a program, e.g., an on-screen touch gamepad.
+// A virtual gamepad has the standard layout.
+type VirtualGamepad struct {
+	id      ID
+	gamepad *Gamepad
+	native  *nativeGamepadVirtual
+}
+
+// AddVirtualGamepad adds a new virtual gamepad with the given name.
+//
+// AddVirtualGamepad is concurrent-safe.
+func AddVirtualGamepad(name string) *VirtualGamepad {
+	return theGamepads.addVirtual(name)
+}
+
+func (g *gamepads) addVirtual(name string) *VirtualGamepad {
+	g.m.Lock()
+	defer g.m.Unlock()
+
+	n := &nativeGamepadVirtual{}
+	gp := g.add(name, "")
+	gp.native = n
+
+	var id ID
+	for i, gp2 := range g.gamepads {
+		if gp2 == gp {
+			id = ID(i)
+			break
+		}
+	}
+	return &VirtualGamepad{
+		id:      id,
+		gamepad: gp,
+		native:  n,
+	}
+}
+
+// ID returns the gamepad ID.
+//
+// ID is concurrent-safe.
+func (v *VirtualGamepad) ID() ID {
+	return v.id
+}
+
+// SetStandardAxisValue sets the value of the standard axis in [-1, 1].
+//
+// SetStandardAxisValue is concurrent-safe.
+func (v *VirtualGamepad) SetStandardAxisValue(axis gamepaddb.StandardAxis, value float64) {
+	if axis < 0 || axis > gamepaddb.StandardAxisMax {
+		return
+	}
+	v.gamepad.m.Lock()
+	defer v.gamepad.m.Unlock()
+	v.native.axisValues[axis] = min(max(value, -1), 1)
+}
+
+// SetStandardButtonValue sets the value of the standard button in [0, 1].
+//
+// SetStandardButtonValue is concurrent-safe.
+func (v *VirtualGamepad) SetStandardButtonValue(button gamepaddb.StandardButton, value float64) {
+	if button < 0 || button > gamepaddb.StandardButtonMax {
+		return
+	}
+	v.gamepad.m.Lock()
+	defer v.gamepad.m.Unlock()
+	v.native.buttonValues[button] = min(max(value, 0), 1)
+}
+
+// Remove removes the virtual gamepad.
+//
+// Remove is concurrent-safe.
+func (v *VirtualGamepad) Remove() {
+	theGamepads.m.Lock()
+	defer theGamepads.m.Unlock()
+
+	if int(v.id) < len(theGamepads.gamepads) && theGamepads.gamepads[v.id] == v.gamepad {
+		theGamepads.gamepads[v.id] = nil
+	}
+}
+
+func (g *Gamepad) isVirtual() bool {
+	_, ok := g.native.(*nativeGamepadVirtual)
+	return ok
+}
+
+type nativeGamepadVirtual struct {
+	axisValues   [gamepaddb.StandardAxisMax + 1]float64
+	buttonValues [gamepaddb.StandardButtonMax + 1]float64
+}
+
+func (*nativeGamepadVirtual) update(gamepads *gamepads) error {
+	return nil
+}
+
+func (*nativeGamepadVirtual) hasOwnStandardLayoutMapping() bool {
+	return true
+}
+
+func (n *nativeGamepadVirtual) standardAxisInOwnMapping(axis gamepaddb.StandardAxis) mappingInput {
+	if axis < 0 || axis > gamepaddb.StandardAxisMax {
+		return nil
+	}
+	return axisMappingInput{g: n, axis: int(axis)}
+}
+
+func (n *nativeGamepadVirtual) standardButtonInOwnMapping(button gamepaddb.StandardButton) mappingInput {
+	if button < 0 || button > gamepaddb.StandardButtonMax {
+		return nil
+	}
+	return buttonMappingInput{g: n, button: int(button)}
+}
+
+func (n *nativeGamepadVirtual) axisCount() int {
+	return len(n.axisValues)
+}
+
+func (n *nativeGamepadVirtual) buttonCount() int {
+	return len(n.buttonValues)
+}
+
+func (*nativeGamepadVirtual) hatCount() int {
+	return 0
+}
+
+func (n *nativeGamepadVirtual) isAxisReady(axis int) bool {
+	return axis >= 0 && axis < len(n.axisValues)
+}
+
+func (n *nativeGamepadVirtual) axisValue(axis int) float64 {
+	if axis < 0 || axis >= len(n.axisValues) {
+		return 0
+	}
+	return n.axisValues[axis]
+}
+
+func (n *nativeGamepadVirtual) buttonValue(button int) float64 {
+	if button < 0 || button >= len(n.buttonValues) {
+		return 0
+	}
+	return n.buttonValues[button]
+}
+
+func (n *nativeGamepadVirtual) isButtonPressed(button int) bool {
+	return n.buttonValue(button) > gamepaddb.ButtonPressedThreshold
+}
+
+func (*nativeGamepadVirtual) hatState(hat int) int {
+	return hatCentered
+}
+
+func (*nativeGamepadVirtual) vibrate(duration time.Duration, strongMagnitude float64, weakMagnitude float64) {
+}
@@ -0,0 +1,106 @@
+// Copyright 2026 The Ebitengine Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package gamepad
+
+import (
+	"encoding/hex"
+	"errors"
+	"strings"
+)
+
+// ErrHIDNotSupported is returned when raw HID access is not supported for the gamepad.
+var ErrHIDNotSupported = errors.New("gamepad: raw HID access is not supported")
+
+// HIDDevice is a raw HID device of a gamepad.
+//
+// Each report starts with the report ID. If the device doesn't use numbered reports, the report ID must be 0.
+type HIDDevice interface {
+	// ReadInputReport reads an input report without blocking.
+	// ReadInputReport returns 0 if no report is available.
+	ReadInputReport(report []byte) (int, error)
+
+	// WriteOutputReport writes an output report.
+	WriteOutputReport(report []byte) (int, error)
+
+	// GetFeatureReport gets a feature report whose report ID is report[0].
+	GetFeatureReport(report []byte) (int, error)
+
+	// SendFeatureReport sends a feature report.
+	SendFeatureReport(report []byte) (int, error)
+
+	Close() error
+}
+
+type nativeHIDOpener interface {
+	openHID() (HIDDevice, error)
+}
+
+// nativeVendorProductIDer is implemented by a native gamepad whose vendor and product IDs are not in its SDL GUID.
+type nativeVendorProductIDer interface {
+	vendorProductID() (uint16, uint16, bool)
+}
+
+// VendorProductID returns the USB vendor ID and product ID of the gamepad.
+// ok is false if the IDs are unknown.
+//
+// VendorProductID is concurrent-safe.
+func (g *Gamepad) VendorProductID() (vendor, product uint16, ok bool) {
+	// This is immutable and doesn't have to be protected by a mutex.
+	if n, ok := g.native.(nativeVendorProductIDer); ok {
+		return n.vendorProductID()
+	}
+	return vendorProductIDFromSDLID(g.sdlID)
+}
+
+// vendorProductIDFromSDLID extracts the vendor ID and the product ID from an SDL GUID string.
+// The GUID has the format of bus (2 bytes), CRC (2 bytes), vendor (2 bytes), 0 (2 bytes), product (2 bytes), 0 (2 bytes),
+// and version (2 bytes), in little endian, when the IDs are available.
+func vendorProductIDFromSDLID(sdlID string) (vendor, product uint16, ok bool) {
+	if len(sdlID) != 32 {
+		return 0, 0, false
+	}
+	// XInput devices have a GUID starting with "xinput".
+	if strings.HasPrefix(sdlID, hex.EncodeToString([]byte("xinput"))) {
+		return 0, 0, false
+	}
+	bs, err := hex.DecodeString(sdlID)
+	if err != nil {
+		return 0, 0, false
+	}
+	if bs[6] != 0 || bs[7] != 0 || bs[10] != 0 || bs[11] != 0 {
+		return 0, 0, false
+	}
+	vendor = uint16(bs[4]) | uint16(bs[5])<<8
+	product = uint16(bs[8]) | uint16(bs[9])<<8
+	if vendor == 0 || product == 0 {
+		return 0, 0, false
+	}
+	return vendor, product, true
+}
+
+// OpenHID opens the raw HID device of the gamepad.
+// OpenHID returns ErrHIDNotSupported if the platform or the gamepad doesn't support raw HID access.
+//
+// OpenHID is concurrent-safe.
+func (g *Gamepad) OpenHID() (HIDDevice, error) {
+	g.m.Lock()
+	defer g.m.Unlock()
+
+	n, ok := g.native.(nativeHIDOpener)
+	if !ok {
+		return nil, ErrHIDNotSupported
+	}
+	return n.openHID()
+}
@@ -0,0 +1,111 @@
+// Copyright 2026 The Ebitengine Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build !ios
+
+package gamepad
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+)
+
+func (g *nativeGamepadImpl) openHID() (HIDDevice, error) {
+	// The device is already opened by the HID manager.
+	return &hidDeviceImpl{device: g.device}, nil
+}
+
+type hidDeviceImpl struct {
+	device _IOHIDDeviceRef
+	m      sync.Mutex
+}
+
+// reportBuffer returns the buffer passed to IOKit.
+// As with hidapi, the report ID is not included in the buffer when the report ID is 0.
+func reportBuffer(report []byte) []byte {
+	if report[0] == 0 {
+		return report[1:]
+	}
+	return report
+}
+
+func (h *hidDeviceImpl) ReadInputReport(report []byte) (int, error) {
+	return h.getReport(kIOHIDReportTypeInput, report)
+}
+
+func (h *hidDeviceImpl) WriteOutputReport(report []byte) (int, error) {
+	return h.setReport(kIOHIDReportTypeOutput, report)
+}
+
+func (h *hidDeviceImpl) GetFeatureReport(report []byte) (int, error) {
+	return h.getReport(kIOHIDReportTypeFeature, report)
+}
+
+func (h *hidDeviceImpl) SendFeatureReport(report []byte) (int, error) {
+	return h.setReport(kIOHIDReportTypeFeature, report)
+}
+
+func (h *hidDeviceImpl) getReport(reportType _IOHIDReportType, report []byte) (int, error) {
+	h.m.Lock()
+	defer h.m.Unlock()
+
+	if h.device == 0 {
+		return 0, errors.New("gamepad: the HID device is already closed")
+	}
+	if len(report) == 0 {
+		return 0, nil
+	}
+	buf := reportBuffer(report)
+	if len(buf) == 0 {
+		return 0, nil
+	}
+	length := _CFIndex(len(buf))
+	if r := _IOHIDDeviceGetReport(h.device, reportType, _CFIndex(report[0]), &buf[0], &length); r != kIOReturnSuccess {
+		return 0, fmt.Errorf("gamepad: IOHIDDeviceGetReport failed: %d", r)
+	}
+	if report[0] == 0 {
+		return int(length) + 1, nil
+	}
+	return int(length), nil
+}
+
+func (h *hidDeviceImpl) setReport(reportType _IOHIDReportType, report []byte) (int, error) {
+	h.m.Lock()
+	defer h.m.Unlock()
+
+	if h.device == 0 {
+		return 0, errors.New("gamepad: the HID device is already closed")
+	}
+	if len(report) == 0 {
+		return 0, nil
+	}
+	buf := reportBuffer(report)
+	if len(buf) == 0 {
+		return 0, nil
+	}
+	if r := _IOHIDDeviceSetReport(h.device, reportType, _CFIndex(report[0]), &buf[0], _CFIndex(len(buf))); r != kIOReturnSuccess {
+		return 0, fmt.Errorf("gamepad: IOHIDDeviceSetReport failed: %d", r)
+	}
+	return len(report), nil
+}
+
+func (h *hidDeviceImpl) Close() error {
+	h.m.Lock()
+	defer h.m.Unlock()
+
+	// The device is owned by the HID manager and must not be closed here.
+	h.device = 0
+	return nil
+}
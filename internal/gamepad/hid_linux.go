@@ -0,0 +1,135 @@
+// Copyright 2026 The Ebitengine Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build !android && !nintendosdk && !playstation5
+
+package gamepad
+
+import (
+	"errors"
+	"fmt"
+	"path/filepath"
+	"sync"
+	"unsafe"
+
+	"golang.org/x/sys/unix"
+)
+
+func _HIDIOCSFEATURE(len uint) uint {
+	return _IOC(_IOC_WRITE|_IOC_READ, 'H', 0x06, len)
+}
+
+func _HIDIOCGFEATURE(len uint) uint {
+	return _IOC(_IOC_WRITE|_IOC_READ, 'H', 0x07, len)
+}
+
+func (g *nativeGamepadImpl) openHID() (HIDDevice, error) {
+	// An evdev device /dev/input/eventN has the HID device as the parent of its input device.
+	// The hidraw device is found in the HID device's directory.
+	pattern := filepath.Join("/sys/class/input", filepath.Base(g.path), "device", "device", "hidraw", "hidraw*")
+	matches, err := filepath.Glob(pattern)
+	if err != nil {
+		return nil, fmt.Errorf("gamepad: finding a hidraw device failed: %w", err)
+	}
+	if len(matches) == 0 {
+		// The gamepad is not a HID device, e.g., a Bluetooth device handled by a kernel driver without HID.
+		return nil, ErrHIDNotSupported
+	}
+
+	path := filepath.Join("/dev", filepath.Base(matches[0]))
+	fd, err := unix.Open(path, unix.O_RDWR|unix.O_NONBLOCK, 0)
+	if err != nil {
+		return nil, fmt.Errorf("gamepad: opening %s failed: %w", path, err)
+	}
+	return &hidDeviceImpl{fd: fd}, nil
+}
+
+type hidDeviceImpl struct {
+	fd int
+	m  sync.Mutex
+}
+
+func (h *hidDeviceImpl) ReadInputReport(report []byte) (int, error) {
+	h.m.Lock()
+	defer h.m.Unlock()
+
+	if h.fd == 0 {
+		return 0, errors.New("gamepad: the HID device is already closed")
+	}
+	n, err := unix.Read(h.fd, report)
+	if errors.Is(err, unix.EAGAIN) || errors.Is(err, unix.EINTR) {
+		return 0, nil
+	}
+	if err != nil {
+		return 0, fmt.Errorf("gamepad: reading an input report failed: %w", err)
+	}
+	return n, nil
+}
+
+func (h *hidDeviceImpl) WriteOutputReport(report []byte) (int, error) {
+	h.m.Lock()
+	defer h.m.Unlock()
+
+	if h.fd == 0 {
+		return 0, errors.New("gamepad: the HID device is already closed")
+	}
+	n, err := unix.Write(h.fd, report)
+	if err != nil {
+		return 0, fmt.Errorf("gamepad: writing an output report failed: %w", err)
+	}
+	return n, nil
+}
+
+func (h *hidDeviceImpl) GetFeatureReport(report []byte) (int, error) {
+	if len(report) == 0 {
+		return 0, nil
+	}
+	return h.featureReport(_HIDIOCGFEATURE(uint(len(report))), report)
+}
+
+func (h *hidDeviceImpl) SendFeatureReport(report []byte) (int, error) {
+	if len(report) == 0 {
+		return 0, nil
+	}
+	return h.featureReport(_HIDIOCSFEATURE(uint(len(report))), report)
+}
+
+func (h *hidDeviceImpl) featureReport(request uint, report []byte) (int, error) {
+	h.m.Lock()
+	defer h.m.Unlock()
+
+	if h.fd == 0 {
+		return 0, errors.New("gamepad: the HID device is already closed")
+	}
+	r, _, e := unix.Syscall(unix.SYS_IOCTL, uintptr(h.fd), uintptr(request), uintptr(unsafe.Pointer(&report[0])))
+	if e != 0 {
+		return 0, fmt.Errorf("gamepad: ioctl for a feature report failed: %w", e)
+	}
+	return int(r), nil
+}
+
+func (h *hidDeviceImpl) Close() error {
+	h.m.Lock()
+	defer h.m.Unlock()
+
+	if h.fd == 0 {
+		return nil
+	}
+	err := unix.Close(h.fd)
+	h.fd = 0
+	if err != nil {
+		return fmt.Errorf("gamepad: closing the HID device failed: %w", err)
+	}
+	return nil
+}
@@ -520,8 +520,12 @@ func (w *Window) GetContentScale() (float32, float32) {
 // The initial opacity value for newly created windows is one.
 //
 // This function may only be called from the main thread.
-func (w *Window) GetOpacity() float32 {
-	return float32(C.glfwGetWindowOpacity(w.data))
+func (w *Window) GetOpacity() (float32, error) {
+	o := float32(C.glfwGetWindowOpacity(w.data))
+	if err := fetchErrorIgnoringPlatformError(); err != nil {
+		return 0, err
+	}
+	return o, nil
 }
 
 // SetOpacity function sets the opacity of the window, including any
@@ -534,8 +538,12 @@ func (w *Window) GetOpacity() float32 {
 // transparency. The results of doing this are undefined.
 //
 // This function may only be called from the main thread.
-func (w *Window) SetOpacity(opacity float32) {
+func (w *Window) SetOpacity(opacity float32) error {
 	C.glfwSetWindowOpacity(w.data, C.float(opacity))
+	if err := fetchErrorIgnoringPlatformError(); err != nil {
+		return err
+	}
+	return nil
 }
 
 // RequestAttention function requests user attention to the specified
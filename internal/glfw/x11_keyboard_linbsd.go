@@ -0,0 +1,56 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2026 The Ebitengine Authors
+
+//go:build freebsd || linux || netbsd || openbsd
+
+package glfw
+
+// #include "internal_unix.h"
+//
+// // currentKeyboardGroupName returns the name of the current XKB group, or NULL if not available.
+// // The returned string must be freed by XFree.
+// static char* currentKeyboardGroupName(void)
+// {
+//     if (!_glfw.initialized || !_glfw.x11.xkb.available)
+//         return NULL;
+//     if (_glfw.x11.xkb.group >= XkbNumKbdGroups)
+//         return NULL;
+//
+//     XkbDescPtr desc = XkbAllocKeyboard();
+//     if (!desc)
+//         return NULL;
+//
+//     char* name = NULL;
+//     if (XkbGetNames(_glfw.x11.display, XkbGroupNamesMask, desc) == Success)
+//     {
+//         Atom atom = desc->names->groups[_glfw.x11.xkb.group];
+//         if (atom != None)
+//             name = XGetAtomName(_glfw.x11.display, atom);
+//         XkbFreeNames(desc, XkbGroupNamesMask, True);
+//     }
+//     XkbFreeKeyboard(desc, 0, True);
+//     return name;
+// }
+import "C"
+
+import (
+	"unsafe"
+)
+
+// GetX11KeyboardLayoutName returns the name of the current keyboard layout, i.e. the current XKB group.
+// GetX11KeyboardLayoutName returns an empty string if XKB is not available.
+//
+// The current group is updated when GLFW processes events.
+//
+// This function must only be called from the main thread.
+func GetX11KeyboardLayoutName() (string, error) {
+	name := C.currentKeyboardGroupName()
+	if err := fetchErrorIgnoringPlatformError(); err != nil {
+		return "", err
+	}
+	if name == nil {
+		return "", nil
+	}
+	defer C.XFree(unsafe.Pointer(name))
+	return C.GoString(name), nil
+}
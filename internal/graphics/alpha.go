@@ -0,0 +1,81 @@
+// Copyright 2026 The Ebitengine Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package graphics
+
+import (
+	"encoding/binary"
+)
+
+// PremultiplyAlpha converts straight-alpha RGBA pixels to premultiplied-alpha RGBA pixels in place.
+// The calculation is the same as image/draw's for *image.NRGBA.
+//
+// len(pix) must be a multiple of 4.
+func PremultiplyAlpha(pix []byte) {
+	for i := 0; i+3 < len(pix); i += 4 {
+		// Check the alpha of the pixel at once to skip opaque and transparent pixels quickly.
+		v := binary.LittleEndian.Uint32(pix[i:])
+		switch v >> 24 {
+		case 0xff:
+			continue
+		case 0:
+			binary.LittleEndian.PutUint32(pix[i:], 0)
+			continue
+		}
+		a := (v >> 24) * 0x101
+		pix[i] = uint8((v & 0xff) * a / 0xff >> 8)
+		pix[i+1] = uint8((v >> 8 & 0xff) * a / 0xff >> 8)
+		pix[i+2] = uint8((v >> 16 & 0xff) * a / 0xff >> 8)
+	}
+}
+
+// UnpremultiplyAlpha converts premultiplied-alpha RGBA pixels to straight-alpha RGBA pixels in place.
+// The calculation is the same as color.NRGBAModel's.
+//
+// A color value more than its alpha value is clamped.
+// len(pix) must be a multiple of 4.
+func UnpremultiplyAlpha(pix []byte) {
+	for i := 0; i+3 < len(pix); i += 4 {
+		v := binary.LittleEndian.Uint32(pix[i:])
+		switch v >> 24 {
+		case 0xff:
+			continue
+		case 0:
+			binary.LittleEndian.PutUint32(pix[i:], 0)
+			continue
+		}
+		a := v >> 24
+		pix[i] = uint8(min((v&0xff)*0xffff/a>>8, 0xff))
+		pix[i+1] = uint8(min((v>>8&0xff)*0xffff/a>>8, 0xff))
+		pix[i+2] = uint8(min((v>>16&0xff)*0xffff/a>>8, 0xff))
+	}
+}
+
+// FindNonPremultipliedPixel returns the index of the first pixel whose color value is more than its alpha value.
+// Such a pixel is invalid as a premultiplied-alpha color.
+// FindNonPremultipliedPixel returns -1 if all the pixels are valid.
+//
+// len(pix) must be a multiple of 4.
+func FindNonPremultipliedPixel(pix []byte) int {
+	for i := 0; i+3 < len(pix); i += 4 {
+		a := pix[i+3]
+		if a == 0xff {
+			continue
+		}
+		if pix[i] > a || pix[i+1] > a || pix[i+2] > a {
+			return i / 4
+		}
+	}
+	return -1
+}
@@ -0,0 +1,81 @@
+// Copyright 2026 The Ebitengine Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package graphics
+
+import (
+	"math"
+	"sync"
+)
+
+// displayP3ToSRGBMatrix converts linear Display P3 colors to linear sRGB colors.
+// Both color spaces use the D65 white point.
+var displayP3ToSRGBMatrix = [3][3]float64{
+	{1.2249401, -0.2249404, 0},
+	{-0.0420569, 1.0420571, 0},
+	{-0.0196376, -0.0786361, 1.0982735},
+}
+
+var (
+	srgbToLinearTable     [256]float64
+	srgbToLinearTableOnce sync.Once
+)
+
+func srgbToLinear(v byte) float64 {
+	srgbToLinearTableOnce.Do(func() {
+		for i := range srgbToLinearTable {
+			c := float64(i) / 0xff
+			if c <= 0.04045 {
+				srgbToLinearTable[i] = c / 12.92
+			} else {
+				srgbToLinearTable[i] = math.Pow((c+0.055)/1.055, 2.4)
+			}
+		}
+	})
+	return srgbToLinearTable[v]
+}
+
+func linearToSRGB(v float64) float64 {
+	v = min(max(v, 0), 1)
+	if v <= 0.0031308 {
+		return v * 12.92
+	}
+	return 1.055*math.Pow(v, 1/2.4) - 0.055
+}
+
+// ConvertDisplayP3ToSRGB converts premultiplied-alpha RGBA pixels in Display P3 to sRGB in place.
+// Colors out of the sRGB gamut are clipped.
+//
+// Display P3 uses the same transfer function as sRGB.
+func ConvertDisplayP3ToSRGB(pixels []byte) {
+	for i := 0; i+3 < len(pixels); i += 4 {
+		a := pixels[i+3]
+		if a == 0 {
+			continue
+		}
+
+		// Unpremultiply the color to apply the transfer function.
+		var c [3]byte
+		for j := range c {
+			c[j] = byte(min((int(pixels[i+j])*0xff+int(a)/2)/int(a), 0xff))
+		}
+		r, g, b := srgbToLinear(c[0]), srgbToLinear(c[1]), srgbToLinear(c[2])
+
+		m := &displayP3ToSRGBMatrix
+		for j := range c {
+			v := linearToSRGB(m[j][0]*r + m[j][1]*g + m[j][2]*b)
+			pixels[i+j] = byte(math.Round(v * float64(a)))
+		}
+	}
+}
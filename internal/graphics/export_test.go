@@ -15,3 +15,24 @@
 package graphics
 
 var AdjustDestinationPixelForTesting = adjustDestinationPixel
+
+type CustomVertex = customVertex
+
+const (
+	CustomVertexNone           = customVertexNone
+	CustomVertexBasic          = customVertexBasic
+	CustomVertexWithAttributes = customVertexWithAttributes
+
+	CustomVertexBasicWithDepth         = customVertexBasicWithDepth
+	CustomVertexWithAttributesAndDepth = customVertexWithAttributesAndDepth
+)
+
+var CustomVertexKindForTesting = customVertexKind
+
+func HasCustomVertexForTesting(src []byte) bool {
+	return customVertexKind(src) != customVertexNone
+}
+
+func UsesVertexAttributesForTesting(src []byte) bool {
+	return usesVertexAttributes(src, customVertexKind(src))
+}
@@ -0,0 +1,105 @@
+// Copyright 2026 The Ebitengine Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package graphics
+
+import (
+	"math"
+)
+
+// BytesToFloat32s converts 8-bit color values to float32 values in [0, 1].
+// dst must have the same length as src.
+func BytesToFloat32s(dst []float32, src []byte) {
+	for i, v := range src {
+		dst[i] = float32(v) / 0xff
+	}
+}
+
+// Float32sToBytes converts float32 color values to 8-bit color values.
+// The values are clamped to [0, 1].
+// dst must have the same length as src.
+func Float32sToBytes(dst []byte, src []float32) {
+	for i, v := range src {
+		dst[i] = byte(math.Round(float64(min(max(v, 0), 1)) * 0xff))
+	}
+}
+
+// Float32ToFloat16 converts a float32 value to the bits of a half-precision float value.
+// The value is rounded to the nearest even.
+func Float32ToFloat16(v float32) uint16 {
+	b := math.Float32bits(v)
+	sign := uint16(b>>16) & 0x8000
+	exp := int((b >> 23) & 0xff)
+	mant := b & 0x7fffff
+
+	// NaN and infinity.
+	if exp == 0xff {
+		if mant != 0 {
+			return sign | 0x7e00
+		}
+		return sign | 0x7c00
+	}
+
+	e := exp - 127 + 15
+	switch {
+	case e >= 0x1f:
+		// Overflow.
+		return sign | 0x7c00
+	case e <= 0:
+		// Subnormal or zero.
+		if e < -10 {
+			return sign
+		}
+		mant |= 0x800000
+		shift := uint(14 - e)
+		h := mant >> shift
+		rem := mant & (1<<shift - 1)
+		half := uint32(1) << (shift - 1)
+		if rem > half || (rem == half && h&1 != 0) {
+			h++
+		}
+		return sign | uint16(h)
+	}
+
+	h := uint32(e)<<10 | mant>>13
+	rem := mant & 0x1fff
+	if rem > 0x1000 || (rem == 0x1000 && h&1 != 0) {
+		// A carry to the exponent is valid and might result in infinity.
+		h++
+	}
+	return sign | uint16(h)
+}
+
+// Float16ToFloat32 converts the bits of a half-precision float value to a float32 value.
+func Float16ToFloat32(h uint16) float32 {
+	sign := uint32(h&0x8000) << 16
+	exp := uint32(h>>10) & 0x1f
+	mant := uint32(h & 0x3ff)
+
+	switch exp {
+	case 0:
+		if mant == 0 {
+			return math.Float32frombits(sign)
+		}
+		// Subnormal.
+		f := float32(mant) / (1 << 24)
+		if sign != 0 {
+			f = -f
+		}
+		return f
+	case 0x1f:
+		return math.Float32frombits(sign | 0x7f800000 | mant<<13)
+	}
+	return math.Float32frombits(sign | (exp+127-15)<<23 | mant<<13)
+}
@@ -15,6 +15,9 @@
 package graphics_test
 
 import (
+	"image/color"
+	"math"
+	"slices"
 	"testing"
 
 	"github.com/duplicants-ai/ebiten/internal/graphics"
@@ -79,3 +82,462 @@ func BenchmarkAdjustPixel(b *testing.B) {
 		graphics.AdjustDestinationPixelForTesting(float32(i) / 17)
 	}
 }
+
+func TestHasCustomVertex(t *testing.T) {
+	const fragment = `
+func Fragment(dstPos vec4, srcPos vec2, color vec4) vec4 {
+	return color
+}
+`
+	testCases := []struct {
+		src  string
+		want bool
+	}{
+		{
+			src:  "package main\n" + fragment,
+			want: false,
+		},
+		{
+			src: `package main
+
+func Vertex(dstPos vec2, srcPos vec2, color vec4, custom vec4) (vec2, vec2, vec4, vec4) {
+	return dstPos, srcPos, color, custom
+}
+` + fragment,
+			want: true,
+		},
+		{
+			src: `package main
+
+func Vertex(dstPos, srcPos vec2, color, custom vec4) (p vec2, s vec2, c vec4, cu vec4) {
+	return dstPos, srcPos, color, custom
+}
+` + fragment,
+			want: true,
+		},
+		{
+			src: `package main
+
+func Vertex(x float) float {
+	return x
+}
+` + fragment,
+			want: false,
+		},
+		{
+			src: `package main
+
+func Vertex(dstPos vec2, srcPos vec2, color vec4, custom vec4) (vec4, vec2, vec4, vec4) {
+	return vec4(dstPos, 0, 1), srcPos, color, custom
+}
+` + fragment,
+			want: false,
+		},
+	}
+
+	for _, tc := range testCases {
+		if got := graphics.HasCustomVertexForTesting([]byte(tc.src)); got != tc.want {
+			t.Errorf("HasCustomVertex(%q): got: %v, want: %v", tc.src, got, tc.want)
+		}
+	}
+}
+
+func TestCustomVertexKind(t *testing.T) {
+	const fragment = `
+func Fragment(dstPos vec4, srcPos vec2, color vec4) vec4 {
+	return color
+}
+`
+	testCases := []struct {
+		src  string
+		want graphics.CustomVertex
+	}{
+		{
+			src:  "package main\n" + fragment,
+			want: graphics.CustomVertexNone,
+		},
+		{
+			src: `package main
+
+func Vertex(dstPos vec2, srcPos vec2, color vec4, custom vec4) (vec2, vec2, vec4, vec4) {
+	return dstPos, srcPos, color, custom
+}
+` + fragment,
+			want: graphics.CustomVertexBasic,
+		},
+		{
+			src: `package main
+
+func Vertex(dstPos, srcPos vec2, color, custom vec4) (p vec2, s vec2, c vec4, cu vec4) {
+	return dstPos, srcPos, color, custom
+}
+` + fragment,
+			want: graphics.CustomVertexBasic,
+		},
+		{
+			src: `package main
+
+func Vertex(dstPos vec2, srcPos vec2, color vec4, custom vec4, attributes vec4) (vec2, vec2, vec4, vec4, vec4) {
+	return dstPos, srcPos, color, custom, attributes
+}
+` + fragment,
+			want: graphics.CustomVertexWithAttributes,
+		},
+		{
+			src: `package main
+
+func Vertex(dstPos vec2, srcPos vec2, color vec4, custom vec4) (vec3, vec2, vec4, vec4) {
+	return vec3(dstPos, 0.5), srcPos, color, custom
+}
+` + fragment,
+			want: graphics.CustomVertexBasicWithDepth,
+		},
+		{
+			src: `package main
+
+func Vertex(dstPos vec2, srcPos vec2, color vec4, custom vec4, attributes vec4) (vec3, vec2, vec4, vec4, vec4) {
+	return vec3(dstPos, attributes.x), srcPos, color, custom, attributes
+}
+` + fragment,
+			want: graphics.CustomVertexWithAttributesAndDepth,
+		},
+		{
+			src: `package main
+
+func Vertex(x float) float {
+	return x
+}
+` + fragment,
+			want: graphics.CustomVertexNone,
+		},
+		{
+			src: `package main
+
+func Vertex(dstPos vec2, srcPos vec2, color vec4, custom vec4) (vec4, vec2, vec4, vec4) {
+	return vec4(dstPos, 0, 1), srcPos, color, custom
+}
+` + fragment,
+			want: graphics.CustomVertexNone,
+		},
+	}
+
+	for _, tc := range testCases {
+		if got := graphics.CustomVertexKindForTesting([]byte(tc.src)); got != tc.want {
+			t.Errorf("CustomVertexKind(%q): got: %v, want: %v", tc.src, got, tc.want)
+		}
+	}
+}
+
+func TestUsesVertexAttributes(t *testing.T) {
+	testCases := []struct {
+		src  string
+		want bool
+	}{
+		{
+			src: `package main
+
+func Fragment(dstPos vec4, srcPos vec2, color vec4) vec4 {
+	return color
+}
+`,
+			want: false,
+		},
+		{
+			src: `package main
+
+func Fragment(dstPos vec4, srcPos vec2, color vec4, custom vec4) vec4 {
+	return custom
+}
+`,
+			want: false,
+		},
+		{
+			src: `package main
+
+func Fragment(dstPos vec4, srcPos vec2, color vec4, custom vec4, attributes vec4) vec4 {
+	return attributes
+}
+`,
+			want: true,
+		},
+		{
+			src: `package main
+
+func Fragment(dstPos vec4, srcPos vec2, color, custom, attributes vec4) vec4 {
+	return attributes
+}
+`,
+			want: true,
+		},
+		{
+			src: `package main
+
+func Vertex(dstPos vec2, srcPos vec2, color vec4, custom vec4, attributes vec4) (vec2, vec2, vec4, vec4, vec4) {
+	return dstPos + attributes.xy, srcPos, color, custom, attributes
+}
+
+func Fragment(dstPos vec4, srcPos vec2, color vec4) vec4 {
+	return color
+}
+`,
+			want: true,
+		},
+		{
+			src: `package main
+
+func Vertex(dstPos vec2, srcPos vec2, color vec4, custom vec4) (vec2, vec2, vec4, vec4) {
+	return dstPos, srcPos, color, custom
+}
+
+func Fragment(dstPos vec4, srcPos vec2, color vec4) vec4 {
+	return color
+}
+`,
+			want: false,
+		},
+	}
+
+	for _, tc := range testCases {
+		if got := graphics.UsesVertexAttributesForTesting([]byte(tc.src)); got != tc.want {
+			t.Errorf("UsesVertexAttributes(%q): got: %v, want: %v", tc.src, got, tc.want)
+		}
+		ir, err := graphics.CompileShader([]byte(tc.src))
+		if err != nil {
+			t.Fatal(err)
+		}
+		want := graphics.VertexFloatCount
+		if tc.want {
+			want = graphics.VertexFloatCountWithAttributes
+		}
+		if got := graphics.ShaderVertexFloatCount(ir); got != want {
+			t.Errorf("ShaderVertexFloatCount(%q): got: %d, want: %d", tc.src, got, want)
+		}
+	}
+}
+
+func TestAddZeroVertexAttributes(t *testing.T) {
+	const n = 3
+	vs := make([]float32, n*graphics.VertexFloatCountWithAttributes)
+	for i := range vs {
+		vs[i] = -1
+	}
+	for i := 0; i < n*graphics.VertexFloatCount; i++ {
+		vs[i] = float32(i)
+	}
+	graphics.AddZeroVertexAttributes(vs, n)
+	for i := 0; i < n; i++ {
+		for j := 0; j < graphics.VertexFloatCountWithAttributes; j++ {
+			got := vs[i*graphics.VertexFloatCountWithAttributes+j]
+			var want float32
+			if j < graphics.VertexFloatCount {
+				want = float32(i*graphics.VertexFloatCount + j)
+			}
+			if got != want {
+				t.Errorf("vs[%d]: got: %f, want: %f", i*graphics.VertexFloatCountWithAttributes+j, got, want)
+			}
+		}
+	}
+}
+
+func TestConvertDisplayP3ToSRGB(t *testing.T) {
+	testCases := []struct {
+		In   [4]byte
+		Want [4]byte
+	}{
+		{In: [4]byte{0, 0, 0, 0}, Want: [4]byte{0, 0, 0, 0}},
+		{In: [4]byte{0xff, 0xff, 0xff, 0xff}, Want: [4]byte{0xff, 0xff, 0xff, 0xff}},
+		{In: [4]byte{0x80, 0x80, 0x80, 0xff}, Want: [4]byte{0x80, 0x80, 0x80, 0xff}},
+		// The most saturated red in Display P3 is out of the sRGB gamut.
+		{In: [4]byte{0xff, 0, 0, 0xff}, Want: [4]byte{0xff, 0, 0, 0xff}},
+		// The red in sRGB is less saturated in Display P3.
+		{In: [4]byte{0xea, 0x33, 0x23, 0xff}, Want: [4]byte{0xff, 0, 0, 0xff}},
+		// Premultiplied alpha.
+		{In: [4]byte{0x40, 0x40, 0x40, 0x80}, Want: [4]byte{0x40, 0x40, 0x40, 0x80}},
+	}
+	for _, tc := range testCases {
+		pix := tc.In
+		graphics.ConvertDisplayP3ToSRGB(pix[:])
+		if pix != tc.Want {
+			t.Errorf("ConvertDisplayP3ToSRGB(%v): got: %v, want: %v", tc.In, pix, tc.Want)
+		}
+	}
+}
+
+func TestCompileShaderFragmentOutputCount(t *testing.T) {
+	testCases := []struct {
+		src  string
+		want int
+		err  bool
+	}{
+		{
+			src: `package main
+
+func Fragment(dstPos vec4, srcPos vec2, color vec4) vec4 {
+	return color
+}
+`,
+			want: 0,
+		},
+		{
+			src: `package main
+
+func Fragment(dstPos vec4, srcPos vec2, color vec4) (vec4, vec4) {
+	return color, color
+}
+`,
+			want: 2,
+		},
+		{
+			src: `package main
+
+func Fragment(dstPos vec4, srcPos vec2, color vec4) (a, b, c vec4, d vec4) {
+	return color, color, color, color
+}
+`,
+			want: 4,
+		},
+		{
+			src: `package main
+
+func Fragment(dstPos vec4, srcPos vec2, color vec4) (vec4, vec4, vec4, vec4, vec4) {
+	return color, color, color, color, color
+}
+`,
+			err: true,
+		},
+		{
+			src: `package main
+
+func Fragment(dstPos vec4, srcPos vec2, color vec4) (vec4, vec2) {
+	return color, color.xy
+}
+`,
+			err: true,
+		},
+	}
+	for _, tc := range testCases {
+		ir, err := graphics.CompileShader([]byte(tc.src))
+		if tc.err {
+			if err == nil {
+				t.Errorf("CompileShader(%q) must return an error", tc.src)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("CompileShader(%q) failed: %v", tc.src, err)
+			continue
+		}
+		if got := ir.FragmentOutputCount; got != tc.want {
+			t.Errorf("CompileShader(%q).FragmentOutputCount: got: %d, want: %d", tc.src, got, tc.want)
+		}
+	}
+}
+
+func TestFloat16(t *testing.T) {
+	testCases := []struct {
+		In   float32
+		Bits uint16
+		Out  float32
+	}{
+		{In: 0, Bits: 0x0000, Out: 0},
+		{In: 1, Bits: 0x3c00, Out: 1},
+		{In: -2, Bits: 0xc000, Out: -2},
+		{In: 0.5, Bits: 0x3800, Out: 0.5},
+		{In: 65504, Bits: 0x7bff, Out: 65504},
+		{In: 1e6, Bits: 0x7c00, Out: float32(math.Inf(1))},
+		{In: 1.0 / (1 << 24), Bits: 0x0001, Out: 1.0 / (1 << 24)},
+		{In: 1.0 / (1 << 26), Bits: 0x0000, Out: 0},
+		// Rounded to the nearest.
+		{In: 1.0001, Bits: 0x3c00, Out: 1},
+		{In: 1.0 / 3, Bits: 0x3555, Out: 0.33325195},
+	}
+	for _, tc := range testCases {
+		bits := graphics.Float32ToFloat16(tc.In)
+		if bits != tc.Bits {
+			t.Errorf("Float32ToFloat16(%v): got: 0x%04x, want: 0x%04x", tc.In, bits, tc.Bits)
+		}
+		if got := graphics.Float16ToFloat32(bits); got != tc.Out {
+			t.Errorf("Float16ToFloat32(0x%04x): got: %v, want: %v", bits, got, tc.Out)
+		}
+	}
+}
+
+func TestFloat32sToBytes(t *testing.T) {
+	src := []float32{-1, 0, 0.5, 1, 2}
+	dst := make([]byte, len(src))
+	graphics.Float32sToBytes(dst, src)
+	if want := []byte{0, 0, 0x80, 0xff, 0xff}; !slices.Equal(dst, want) {
+		t.Errorf("Float32sToBytes(%v): got: %v, want: %v", src, dst, want)
+	}
+
+	fs := make([]float32, len(dst))
+	graphics.BytesToFloat32s(fs, dst)
+	if want := []float32{0, 0, float32(0x80) / 0xff, 1, 1}; !slices.Equal(fs, want) {
+		t.Errorf("BytesToFloat32s(%v): got: %v, want: %v", dst, fs, want)
+	}
+}
+
+func TestPremultiplyAlpha(t *testing.T) {
+	testCases := []struct {
+		Straight      [4]byte
+		Premultiplied [4]byte
+	}{
+		{Straight: [4]byte{0, 0, 0, 0}, Premultiplied: [4]byte{0, 0, 0, 0}},
+		{Straight: [4]byte{0xff, 0x80, 0x40, 0}, Premultiplied: [4]byte{0, 0, 0, 0}},
+		{Straight: [4]byte{0xff, 0x80, 0x40, 0xff}, Premultiplied: [4]byte{0xff, 0x80, 0x40, 0xff}},
+		{Straight: [4]byte{0xff, 0x80, 0x40, 0x80}, Premultiplied: [4]byte{0x80, 0x40, 0x20, 0x80}},
+		{Straight: [4]byte{0xff, 0xff, 0xff, 0x01}, Premultiplied: [4]byte{0x01, 0x01, 0x01, 0x01}},
+	}
+	for _, tc := range testCases {
+		// Compare with image/draw's conversion.
+		nrgba := color.NRGBA{R: tc.Straight[0], G: tc.Straight[1], B: tc.Straight[2], A: tc.Straight[3]}
+		rgba := color.RGBAModel.Convert(nrgba).(color.RGBA)
+		if got := [4]byte{rgba.R, rgba.G, rgba.B, rgba.A}; got != tc.Premultiplied {
+			t.Fatalf("test case is wrong: color.RGBAModel.Convert(%v): got: %v, want: %v", nrgba, got, tc.Premultiplied)
+		}
+
+		pix := tc.Straight
+		graphics.PremultiplyAlpha(pix[:])
+		if pix != tc.Premultiplied {
+			t.Errorf("PremultiplyAlpha(%v): got: %v, want: %v", tc.Straight, pix, tc.Premultiplied)
+		}
+		if got := graphics.FindNonPremultipliedPixel(pix[:]); got != -1 {
+			t.Errorf("FindNonPremultipliedPixel(%v): got: %d, want: -1", pix, got)
+		}
+	}
+}
+
+func TestUnpremultiplyAlpha(t *testing.T) {
+	for a := 0; a < 0x100; a++ {
+		for c := 0; c <= a; c++ {
+			pix := []byte{byte(c), byte(c), byte(c), byte(a)}
+			graphics.UnpremultiplyAlpha(pix)
+			want := color.NRGBAModel.Convert(color.RGBA{R: byte(c), G: byte(c), B: byte(c), A: byte(a)}).(color.NRGBA)
+			if pix[0] != want.R || pix[3] != want.A {
+				t.Fatalf("UnpremultiplyAlpha(%v): got: %v, want: %v", []byte{byte(c), byte(c), byte(c), byte(a)}, pix, want)
+			}
+		}
+	}
+
+	// A color value more than its alpha value is clamped.
+	pix := []byte{0xff, 0x40, 0x20, 0x40}
+	graphics.UnpremultiplyAlpha(pix)
+	if want := []byte{0xff, 0xff, 0x7f, 0x40}; !slices.Equal(pix, want) {
+		t.Errorf("UnpremultiplyAlpha: got: %v, want: %v", pix, want)
+	}
+}
+
+func TestFindNonPremultipliedPixel(t *testing.T) {
+	pix := []byte{
+		0x10, 0x10, 0x10, 0x10,
+		0xff, 0xff, 0xff, 0xff,
+		0x10, 0x20, 0x10, 0x18,
+		0x30, 0x30, 0x30, 0x10,
+	}
+	if got, want := graphics.FindNonPremultipliedPixel(pix), 2; got != want {
+		t.Errorf("FindNonPremultipliedPixel: got: %d, want: %d", got, want)
+	}
+	if got, want := graphics.FindNonPremultipliedPixel(pix[:8]), -1; got != want {
+		t.Errorf("FindNonPremultipliedPixel: got: %d, want: %d", got, want)
+	}
+}
@@ -17,12 +17,19 @@ package graphics
 import (
 	"bytes"
 	"fmt"
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"os"
+	"slices"
+	"strings"
+	"sync"
 
 	"github.com/duplicants-ai/ebiten/internal/shader"
 	"github.com/duplicants-ai/ebiten/internal/shaderir"
 )
 
-func shaderSuffix(unit shaderir.Unit) (string, error) {
+func shaderSuffix(unit shaderir.Unit, customVertex customVertex, usesAttributes bool) (string, error) {
 	shaderSuffix := fmt.Sprintf(`
 var __imageDstTextureSize vec2
 
@@ -108,6 +115,39 @@ func imageSrc%[1]dSize() vec2 {
 }
 `, i)
 
+		// The region in pixels is needed to fetch a texel with an integer position.
+		var origin, size string
+		switch unit {
+		case shaderir.Pixels:
+			origin = fmt.Sprintf("ivec2(__imageSrcRegionOrigins[%d])", i)
+			size = fmt.Sprintf("ivec2(__imageSrcRegionSizes[%d])", i)
+		case shaderir.Texels:
+			origin = fmt.Sprintf("ivec2(floor(__imageSrcRegionOrigins[%[1]d] * __imageSrcTextureSizes[%[1]d] + 0.5))", i)
+			size = fmt.Sprintf("ivec2(floor(__imageSrcRegionSizes[%[1]d] * __imageSrcTextureSizes[%[1]d] + 0.5))", i)
+		default:
+			return "", fmt.Errorf("graphics: unexpected unit: %d", unit)
+		}
+		shaderSuffix += fmt.Sprintf(`
+// imageSrc%[1]dFetch returns the source image's pixel at pos without filtering.
+// pos is the position in pixels from the source image's origin, regardless of the unit.
+// If pos is out of the source image, imageSrc%[1]dFetch returns vec4(0).
+func imageSrc%[1]dFetch(pos ivec2) vec4 {
+	size := %[3]s
+	if pos.x < 0 || pos.y < 0 || pos.x >= size.x || pos.y >= size.y {
+		return vec4(0)
+	}
+	return __texelFetch(__t%[1]d, %[2]s + pos)
+}
+
+// imageSrc%[1]dIntAt returns the source image's pixel at pos as integers in [0, 255] without filtering.
+// The values are exactly the bytes of the pixel, which is useful to consume data like tile indices.
+// pos is the position in pixels from the source image's origin, regardless of the unit.
+// If pos is out of the source image, imageSrc%[1]dIntAt returns ivec4(0).
+func imageSrc%[1]dIntAt(pos ivec2) ivec4 {
+	return ivec4(floor(imageSrc%[1]dFetch(pos) * 255 + 0.5))
+}
+`, i, origin, size)
+
 		pos := "pos"
 		if i >= 1 {
 			// Convert the position in texture0's positions to the target texture positions.
@@ -136,6 +176,15 @@ func imageSrc%[1]dAt(pos vec2) vec4 {
 	in := step(__imageSrcRegionOrigins[0], pos) - step(__imageSrcRegionOrigins[0] + __imageSrcRegionSizes[%[1]d], pos)
 	return __texelAt(__t%[1]d, %[2]s) * in.x * in.y
 }
+
+// imageSrc%[1]dLayerAt returns the pixel of the specified layer of an image array at pos.
+// The source image must be the 0th layer of an image array, and pos is the position in the 0th layer.
+// If pos is out of the region, imageSrc%[1]dLayerAt returns vec4(0).
+func imageSrc%[1]dLayerAt(pos vec2, layer int) vec4 {
+	// The layers are stacked vertically.
+	in := step(__imageSrcRegionOrigins[0], pos) - step(__imageSrcRegionOrigins[0] + __imageSrcRegionSizes[%[1]d], pos)
+	return __texelAt(__t%[1]d, %[2]s + vec2(0, float(layer) * __imageSrcRegionSizes[%[1]d].y)) * in.x * in.y
+}
 `, i, pos)
 		case shaderir.Texels:
 			shaderSuffix += fmt.Sprintf(`
@@ -147,26 +196,195 @@ func imageSrc%[1]dAt(pos vec2) vec4 {
 	in := step(__imageSrcRegionOrigins[0], pos) - step(__imageSrcRegionOrigins[0] + __imageSrcRegionSizes[0], pos)
 	return __texelAt(__t%[1]d, %[2]s) * in.x * in.y
 }
+
+// imageSrc%[1]dLayerAt returns the pixel of the specified layer of an image array at pos.
+// The source image must be the 0th layer of an image array, and pos is the position in the 0th layer.
+// If pos is out of the region, imageSrc%[1]dLayerAt returns vec4(0).
+func imageSrc%[1]dLayerAt(pos vec2, layer int) vec4 {
+	// The layers are stacked vertically.
+	// The region size is in texels of this image's texture, so the layer offset is also in texels.
+	in := step(__imageSrcRegionOrigins[0], pos) - step(__imageSrcRegionOrigins[0] + __imageSrcRegionSizes[0], pos)
+	return __texelAt(__t%[1]d, %[2]s + vec2(0, float(layer) * __imageSrcRegionSizes[%[1]d].y)) * in.x * in.y
+}
 `, i, pos)
 		}
 	}
 
 	shaderSuffix += `
 var __projectionMatrix mat4
-
-func __vertex(dstPos vec2, srcPos vec2, color vec4, custom vec4) (vec4, vec2, vec4, vec4) {
-	return __projectionMatrix * vec4(dstPos, 0, 1), srcPos, color, custom
-}
 `
+	// A custom vertex function returning vec3 as the destination position specifies the depth as z.
+	pos := "vec4(p, 0, 1)"
+	if customVertex.hasDepth() {
+		pos = "vec4(p, 1)"
+	}
+	// The extra vertex attributes are an input of the vertex shader only when the shader uses them,
+	// so that the other shaders keep the smaller vertex layout.
+	params := "dstPos vec2, srcPos vec2, color vec4, custom vec4"
+	results := "vec4, vec2, vec4, vec4"
+	var attributes string
+	if usesAttributes {
+		params += ", attributes vec4"
+		results += ", vec4"
+		attributes = ", attributes"
+	}
+	switch customVertex {
+	case customVertexNone:
+		shaderSuffix += fmt.Sprintf(`
+func __vertex(%[1]s) (%[2]s) {
+	return __projectionMatrix * vec4(dstPos, 0, 1), srcPos, color, custom%[3]s
+}
+`, params, results, attributes)
+	case customVertexBasic, customVertexBasicWithDepth:
+		shaderSuffix += fmt.Sprintf(`
+func __vertex(%[1]s) (%[2]s) {
+	p, s, c, cu := Vertex(dstPos, srcPos, color, custom)
+	return __projectionMatrix * %[4]s, s, c, cu%[3]s
+}
+`, params, results, attributes, pos)
+	case customVertexWithAttributes, customVertexWithAttributesAndDepth:
+		shaderSuffix += fmt.Sprintf(`
+func __vertex(%[1]s) (%[2]s) {
+	p, s, c, cu, a := Vertex(dstPos, srcPos, color, custom, attributes)
+	return __projectionMatrix * %[3]s, s, c, cu, a
+}
+`, params, results, pos)
+	}
 	return shaderSuffix, nil
 }
 
+type customVertex int
+
+const (
+	customVertexNone customVertex = iota
+	customVertexBasic
+	customVertexWithAttributes
+	customVertexBasicWithDepth
+	customVertexWithAttributesAndDepth
+)
+
+func (c customVertex) hasDepth() bool {
+	return c == customVertexBasicWithDepth || c == customVertexWithAttributesAndDepth
+}
+
+func (c customVertex) hasAttributes() bool {
+	return c == customVertexWithAttributes || c == customVertexWithAttributesAndDepth
+}
+
+// customVertexKind reports whether src has a custom vertex function, and which signature the function has.
+//
+// A custom vertex function must be named Vertex and have one of these signatures:
+//
+//	func Vertex(dstPos vec2, srcPos vec2, color vec4, custom vec4) (vec2, vec2, vec4, vec4)
+//	func Vertex(dstPos vec2, srcPos vec2, color vec4, custom vec4, attributes vec4) (vec2, vec2, vec4, vec4, vec4)
+//
+// The first result can be vec3 instead of vec2. In this case, z is the depth of the vertex.
+//
+// A function named Vertex with a different signature is treated as a regular function for backward compatibility.
+func customVertexKind(src []byte) customVertex {
+	f, err := parser.ParseFile(token.NewFileSet(), "", src, parser.SkipObjectResolution)
+	if err != nil {
+		// The error will be reported at the compilation.
+		return customVertexNone
+	}
+
+	typeNames := func(fl *ast.FieldList) []string {
+		if fl == nil {
+			return nil
+		}
+		var names []string
+		for _, f := range fl.List {
+			ident, ok := f.Type.(*ast.Ident)
+			if !ok {
+				return nil
+			}
+			n := len(f.Names)
+			if n == 0 {
+				n = 1
+			}
+			for i := 0; i < n; i++ {
+				names = append(names, ident.Name)
+			}
+		}
+		return names
+	}
+
+	for _, d := range f.Decls {
+		fd, ok := d.(*ast.FuncDecl)
+		if !ok || fd.Recv != nil || fd.Name.Name != "Vertex" {
+			continue
+		}
+		params := typeNames(fd.Type.Params)
+		results := typeNames(fd.Type.Results)
+		var depth bool
+		if len(results) > 0 && results[0] == "vec3" {
+			results[0] = "vec2"
+			depth = true
+		}
+		if slices.Equal(params, []string{"vec2", "vec2", "vec4", "vec4"}) &&
+			slices.Equal(results, []string{"vec2", "vec2", "vec4", "vec4"}) {
+			if depth {
+				return customVertexBasicWithDepth
+			}
+			return customVertexBasic
+		}
+		if slices.Equal(params, []string{"vec2", "vec2", "vec4", "vec4", "vec4"}) &&
+			slices.Equal(results, []string{"vec2", "vec2", "vec4", "vec4", "vec4"}) {
+			if depth {
+				return customVertexWithAttributesAndDepth
+			}
+			return customVertexWithAttributes
+		}
+		return customVertexNone
+	}
+	return customVertexNone
+}
+
+// usesVertexAttributes reports whether src uses the extra vertex attributes.
+//
+// The extra vertex attributes are used when the custom vertex function takes them,
+// or when the Fragment function takes them as the fifth argument.
+func usesVertexAttributes(src []byte, customVertex customVertex) bool {
+	if customVertex.hasAttributes() {
+		return true
+	}
+
+	f, err := parser.ParseFile(token.NewFileSet(), "", src, parser.SkipObjectResolution)
+	if err != nil {
+		// The error will be reported at the compilation.
+		return false
+	}
+	for _, d := range f.Decls {
+		fd, ok := d.(*ast.FuncDecl)
+		if !ok || fd.Recv != nil || fd.Name.Name != "Fragment" {
+			continue
+		}
+		return fd.Type.Params.NumFields() > vertexInputCount
+	}
+	return false
+}
+
+// vertexInputCount is the number of the vertex shader's inputs without the extra vertex attributes.
+const vertexInputCount = 4
+
+// ShaderVertexFloatCount returns the number of floats in a vertex for the shader program.
+//
+// ShaderVertexFloatCount returns VertexFloatCountWithAttributes if the shader uses the extra vertex attributes,
+// or VertexFloatCount otherwise.
+func ShaderVertexFloatCount(program *shaderir.Program) int {
+	if len(program.Attributes) > vertexInputCount {
+		return VertexFloatCountWithAttributes
+	}
+	return VertexFloatCount
+}
+
 func completeShaderSource(fragmentSrc []byte) ([]byte, error) {
 	unit, err := shader.ParseCompilerDirectives(fragmentSrc)
 	if err != nil {
 		return nil, err
 	}
-	suffix, err := shaderSuffix(unit)
+	customVertex := customVertexKind(fragmentSrc)
+	suffix, err := shaderSuffix(unit, customVertex, usesVertexAttributes(fragmentSrc, customVertex))
 	if err != nil {
 		return nil, err
 	}
@@ -199,10 +417,45 @@ func CompileShader(fragmentSrc []byte) (*shaderir.Program, error) {
 	if ir.FragmentFunc.Block == nil {
 		return nil, fmt.Errorf("graphics: fragment shader entry point '%s' is missing", frag)
 	}
+	if ir.FragmentOutputCount > ShaderDstImageCount {
+		return nil, fmt.Errorf("graphics: fragment shader entry point '%s' can return at most %d colors but returns %d", frag, ShaderDstImageCount, ir.FragmentOutputCount)
+	}
+
+	op := theShaderIROptions()
+	if op.noOptimize {
+		if op.dump {
+			fmt.Fprintf(os.Stderr, "graphics: shader IR:\n%s", ir.Dump())
+		}
+		return ir, nil
+	}
+
+	optimized := ir.Optimize()
+	if op.dump {
+		fmt.Fprintf(os.Stderr, "graphics: shader IR before optimization:\n%s", ir.Dump())
+		fmt.Fprintf(os.Stderr, "graphics: shader IR after optimization:\n%s", optimized.Dump())
+	}
+	return optimized, nil
+}
 
-	return ir, nil
+type shaderIROptions struct {
+	dump       bool
+	noOptimize bool
 }
 
+// theShaderIROptions returns the options specified by the environment variable EBITENGINE_SHADER.
+var theShaderIROptions = sync.OnceValue(func() shaderIROptions {
+	var op shaderIROptions
+	for _, t := range strings.Split(os.Getenv("EBITENGINE_SHADER"), ",") {
+		switch strings.TrimSpace(t) {
+		case "dumpir":
+			op.dump = true
+		case "nooptimize":
+			op.noOptimize = true
+		}
+	}
+	return op
+})
+
 func CalcSourceHash(fragmentSrc []byte) (shaderir.SourceHash, error) {
 	src, err := completeShaderSource(fragmentSrc)
 	if err != nil {
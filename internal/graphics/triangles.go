@@ -0,0 +1,72 @@
+// Copyright 2026 The Ebitengine Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package graphics
+
+import (
+	"fmt"
+)
+
+// SplitTriangles splits triangles into parts so that each part has at most maxVertexCount vertices and maxIndexCount indices.
+//
+// f is called for each part in the original order of the triangles.
+// vertexIndices are the indices of the original vertices used in the part,
+// and indices are the part's indices referring to vertexIndices.
+// The slices given to f are valid only during the call.
+//
+// All the values in indices must be less than vertexCount.
+// maxVertexCount must be at least 3, and maxIndexCount must be at least 3.
+func SplitTriangles(vertexCount int, indices []uint32, maxVertexCount, maxIndexCount int, f func(vertexIndices []uint32, indices []uint32)) {
+	if maxVertexCount < 3 || maxIndexCount < 3 {
+		panic(fmt.Sprintf("graphics: maxVertexCount and maxIndexCount must be at least 3 but were %d and %d", maxVertexCount, maxIndexCount))
+	}
+
+	// newIndices[i] is the index in the current part + 1 for the original vertex i, or 0 if the vertex is not used yet.
+	newIndices := make([]uint32, vertexCount)
+	var partVertexIndices []uint32
+	var partIndices []uint32
+
+	flush := func() {
+		if len(partIndices) == 0 {
+			return
+		}
+		f(partVertexIndices, partIndices)
+		for _, idx := range partVertexIndices {
+			newIndices[idx] = 0
+		}
+		partVertexIndices = partVertexIndices[:0]
+		partIndices = partIndices[:0]
+	}
+
+	for i := 0; i+2 < len(indices); i += 3 {
+		var newVertexCount int
+		for _, idx := range indices[i : i+3] {
+			if newIndices[idx] == 0 {
+				newVertexCount++
+			}
+		}
+		// The same vertex might be counted twice in one triangle, but this is fine as this is just an upper bound.
+		if len(partVertexIndices)+newVertexCount > maxVertexCount || len(partIndices)+3 > maxIndexCount {
+			flush()
+		}
+		for _, idx := range indices[i : i+3] {
+			if newIndices[idx] == 0 {
+				partVertexIndices = append(partVertexIndices, idx)
+				newIndices[idx] = uint32(len(partVertexIndices))
+			}
+			partIndices = append(partIndices, newIndices[idx]-1)
+		}
+	}
+	flush()
+}
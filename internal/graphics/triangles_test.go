@@ -0,0 +1,103 @@
+// Copyright 2026 The Ebitengine Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package graphics_test
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/duplicants-ai/ebiten/internal/graphics"
+)
+
+func TestSplitTriangles(t *testing.T) {
+	testCases := []struct {
+		name           string
+		vertexCount    int
+		indices        []uint32
+		maxVertexCount int
+		maxIndexCount  int
+		wantParts      int
+	}{
+		{
+			name:           "no split",
+			vertexCount:    4,
+			indices:        []uint32{0, 1, 2, 1, 2, 3},
+			maxVertexCount: 4,
+			maxIndexCount:  6,
+			wantParts:      1,
+		},
+		{
+			name:           "split by vertices",
+			vertexCount:    4,
+			indices:        []uint32{0, 1, 2, 1, 2, 3},
+			maxVertexCount: 3,
+			maxIndexCount:  6,
+			wantParts:      2,
+		},
+		{
+			name:           "split by indices",
+			vertexCount:    4,
+			indices:        []uint32{0, 1, 2, 1, 2, 3, 3, 2, 1},
+			maxVertexCount: 4,
+			maxIndexCount:  6,
+			wantParts:      2,
+		},
+		{
+			name:           "shared vertices",
+			vertexCount:    6,
+			indices:        []uint32{0, 1, 2, 3, 4, 5, 2, 1, 0, 5, 4, 3},
+			maxVertexCount: 3,
+			maxIndexCount:  100,
+			wantParts:      4,
+		},
+		{
+			name:           "empty",
+			vertexCount:    3,
+			indices:        nil,
+			maxVertexCount: 3,
+			maxIndexCount:  3,
+			wantParts:      0,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			var parts int
+			var got []uint32
+			graphics.SplitTriangles(tc.vertexCount, tc.indices, tc.maxVertexCount, tc.maxIndexCount, func(vertexIndices []uint32, indices []uint32) {
+				parts++
+				if len(vertexIndices) > tc.maxVertexCount {
+					t.Errorf("len(vertexIndices): got: %d, want: <= %d", len(vertexIndices), tc.maxVertexCount)
+				}
+				if len(indices) > tc.maxIndexCount {
+					t.Errorf("len(indices): got: %d, want: <= %d", len(indices), tc.maxIndexCount)
+				}
+				for _, idx := range indices {
+					if int(idx) >= len(vertexIndices) {
+						t.Fatalf("index %d is out of range: %d", idx, len(vertexIndices))
+					}
+					got = append(got, vertexIndices[idx])
+				}
+			})
+			if parts != tc.wantParts {
+				t.Errorf("parts: got: %d, want: %d", parts, tc.wantParts)
+			}
+			// The triangles must be drawn in the original order.
+			if !slices.Equal(got, tc.indices) {
+				t.Errorf("indices: got: %v, want: %v", got, tc.indices)
+			}
+		})
+	}
+}
@@ -15,7 +15,13 @@
 package graphics
 
 const (
-	ShaderSrcImageCount = 4
+	// ShaderSrcImageCount is the number of the source images for a shader.
+	// 8 is the minimum number of texture units that all the supported graphics drivers guarantee.
+	ShaderSrcImageCount = 8
+
+	// ShaderDstImageCount is the maximum number of the destination images for a shader, i.e., multiple render targets.
+	// 4 is the minimum number of color attachments that all the supported graphics drivers guarantee.
+	ShaderDstImageCount = 4
 
 	// PreservedUniformVariablesCount represents the number of preserved uniform variables.
 	// Any shaders in Ebitengine must have these uniform variables.
@@ -47,6 +53,13 @@ const (
 
 const (
 	VertexFloatCount = 12
+
+	// VertexAttributesFloatCount is the number of floats of the extra vertex attributes in a vertex.
+	VertexAttributesFloatCount = 4
+
+	// VertexFloatCountWithAttributes is the number of floats in a vertex for a shader using the extra vertex attributes.
+	// The extra vertex attributes follow the regular VertexFloatCount floats.
+	VertexFloatCountWithAttributes = VertexFloatCount + VertexAttributesFloatCount
 )
 
 var (
@@ -153,6 +166,18 @@ func QuadVerticesFromDstAndSrc(dst []float32, dx0, dy0, dx1, dy1, sx0, sy0, sx1,
 	dst[3*VertexFloatCount+7] = ca
 }
 
+// AddZeroVertexAttributes converts the first n vertices in vs from VertexFloatCount floats to
+// VertexFloatCountWithAttributes floats in place. The extra vertex attributes are zeros.
+//
+// len(vs) must be at least n*VertexFloatCountWithAttributes.
+func AddZeroVertexAttributes(vs []float32, n int) {
+	// Iterate backward so that the vertices not converted yet are not overwritten.
+	for i := n - 1; i >= 0; i-- {
+		copy(vs[i*VertexFloatCountWithAttributes:i*VertexFloatCountWithAttributes+VertexFloatCount], vs[i*VertexFloatCount:(i+1)*VertexFloatCount])
+		clear(vs[i*VertexFloatCountWithAttributes+VertexFloatCount : (i+1)*VertexFloatCountWithAttributes])
+	}
+}
+
 func adjustDestinationPixel(x float32) float32 {
 	// Avoid the center of the pixel, which is problematic (#929, #1171).
 	// Instead, align the vertices with about 1/3 pixels.
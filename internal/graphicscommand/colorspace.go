@@ -0,0 +1,55 @@
+// Copyright 2026 The Ebitengine Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package graphicscommand
+
+import (
+	"sync/atomic"
+
+	"github.com/duplicants-ai/ebiten/internal/graphics"
+	"github.com/duplicants-ai/ebiten/internal/graphicsdriver"
+	"github.com/duplicants-ai/ebiten/internal/png"
+)
+
+var (
+	dumpColorSpace     atomic.Int32
+	dumpConvertsToSRGB atomic.Bool
+)
+
+// SetDumpColorSpace sets the color space of the images' pixels, which is the color space of the screen.
+// Dumped PNG files are tagged with the color space.
+func SetDumpColorSpace(colorSpace graphicsdriver.ColorSpace) {
+	dumpColorSpace.Store(int32(colorSpace))
+}
+
+// SetDumpConvertedToSRGB sets whether the pixels are converted to sRGB when the images are dumped.
+func SetDumpConvertedToSRGB(convert bool) {
+	dumpConvertsToSRGB.Store(convert)
+}
+
+// prepareDumpPixels converts the pixels for dumping if needed, and returns the color space to tag the PNG file with.
+func prepareDumpPixels(pixels []byte) png.ColorSpace {
+	switch graphicsdriver.ColorSpace(dumpColorSpace.Load()) {
+	case graphicsdriver.ColorSpaceSRGB:
+		return png.ColorSpaceSRGB
+	case graphicsdriver.ColorSpaceDisplayP3:
+		if !dumpConvertsToSRGB.Load() {
+			return png.ColorSpaceDisplayP3
+		}
+		graphics.ConvertDisplayP3ToSRGB(pixels)
+		return png.ColorSpaceSRGB
+	default:
+		return png.ColorSpaceNone
+	}
+}
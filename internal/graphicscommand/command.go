@@ -63,6 +63,7 @@ func (p *drawTrianglesCommandPool) put(v *drawTrianglesCommand) {
 // drawTrianglesCommand represents a drawing command to draw an image on another image.
 type drawTrianglesCommand struct {
 	dst         *Image
+	extraDsts   [graphics.ShaderDstImageCount - 1]*Image
 	srcs        [graphics.ShaderSrcImageCount]*Image
 	vertices    []float32
 	blend       graphicsdriver.Blend
@@ -100,6 +101,16 @@ func (c *drawTrianglesCommand) String() string {
 		blend = "(xor)"
 	case graphicsdriver.BlendLighter:
 		blend = "(lighter)"
+	case graphicsdriver.BlendMultiply:
+		blend = "(multiply)"
+	case graphicsdriver.BlendScreen:
+		blend = "(screen)"
+	case graphicsdriver.BlendAdditive:
+		blend = "(additive)"
+	case graphicsdriver.BlendSubtract:
+		blend = "(subtract)"
+	case graphicsdriver.BlendLighten:
+		blend = "(lighten)"
 	default:
 		blend = fmt.Sprintf("{src-rgb: %d, src-alpha: %d, dst-rgb: %d, dst-alpha: %d, op-rgb: %d, op-alpha: %d}",
 			c.blend.BlendFactorSourceRGB,
@@ -116,6 +127,21 @@ func (c *drawTrianglesCommand) String() string {
 	} else if c.dst.attribute != "" {
 		dst += " (" + c.dst.attribute + ")"
 	}
+	if c.extraDsts != ([graphics.ShaderDstImageCount - 1]*Image{}) {
+		dststrs := []string{dst}
+		for _, extraDst := range c.extraDsts {
+			if extraDst == nil {
+				dststrs = append(dststrs, "(nil)")
+				continue
+			}
+			str := fmt.Sprintf("%d", extraDst.id)
+			if extraDst.attribute != "" {
+				str += " (" + extraDst.attribute + ")"
+			}
+			dststrs = append(dststrs, str)
+		}
+		dst = "[" + strings.Join(dststrs, ", ") + "]"
+	}
 
 	var srcstrs [graphics.ShaderSrcImageCount]string
 	for i, src := range c.srcs {
@@ -159,7 +185,17 @@ func (c *drawTrianglesCommand) Exec(commandQueue *commandQueue, graphicsDriver g
 		imgs[i] = src.image.ID()
 	}
 
-	return graphicsDriver.DrawTriangles(c.dst.image.ID(), imgs, c.shader.shader.ID(), c.dstRegions, indexOffset, c.blend, c.uniforms, c.fillRule)
+	var dsts [graphics.ShaderDstImageCount]graphicsdriver.ImageID
+	dsts[0] = c.dst.image.ID()
+	for i, extraDst := range c.extraDsts {
+		if extraDst == nil {
+			dsts[i+1] = graphicsdriver.InvalidImageID
+			continue
+		}
+		dsts[i+1] = extraDst.image.ID()
+	}
+
+	return graphicsDriver.DrawTriangles(dsts, imgs, c.shader.shader.ID(), c.dstRegions, indexOffset, c.blend, c.uniforms, c.fillRule)
 }
 
 func (c *drawTrianglesCommand) NeedsSync() bool {
@@ -184,7 +220,7 @@ func (c *drawTrianglesCommand) setVertices(vertices []float32) {
 
 // CanMergeWithDrawTrianglesCommand returns a boolean value indicating whether the other drawTrianglesCommand can be merged
 // with the drawTrianglesCommand c.
-func (c *drawTrianglesCommand) CanMergeWithDrawTrianglesCommand(dst *Image, srcs [graphics.ShaderSrcImageCount]*Image, vertices []float32, blend graphicsdriver.Blend, shader *Shader, uniforms []uint32, fillRule graphicsdriver.FillRule) bool {
+func (c *drawTrianglesCommand) CanMergeWithDrawTrianglesCommand(dst *Image, extraDsts [graphics.ShaderDstImageCount - 1]*Image, srcs [graphics.ShaderSrcImageCount]*Image, vertices []float32, blend graphicsdriver.Blend, shader *Shader, uniforms []uint32, fillRule graphicsdriver.FillRule) bool {
 	if c.shader != shader {
 		return false
 	}
@@ -194,6 +230,9 @@ func (c *drawTrianglesCommand) CanMergeWithDrawTrianglesCommand(dst *Image, srcs
 	if c.dst != dst {
 		return false
 	}
+	if c.extraDsts != extraDsts {
+		return false
+	}
 	if c.srcs != srcs {
 		return false
 	}
@@ -203,7 +242,7 @@ func (c *drawTrianglesCommand) CanMergeWithDrawTrianglesCommand(dst *Image, srcs
 	if c.fillRule != fillRule {
 		return false
 	}
-	if c.fillRule != graphicsdriver.FillRuleFillAll && mightOverlapDstRegions(c.vertices, vertices) {
+	if c.fillRule != graphicsdriver.FillRuleFillAll && mightOverlapDstRegions(c.vertices, vertices, shader.vertexFloatCount()) {
 		return false
 	}
 	return true
@@ -214,13 +253,13 @@ var (
 	negInf32 = float32(math.Inf(-1))
 )
 
-func dstRegionFromVertices(vertices []float32) (minX, minY, maxX, maxY float32) {
+func dstRegionFromVertices(vertices []float32, vertexFloatCount int) (minX, minY, maxX, maxY float32) {
 	minX = posInf32
 	minY = posInf32
 	maxX = negInf32
 	maxY = negInf32
 
-	for i := 0; i < len(vertices); i += graphics.VertexFloatCount {
+	for i := 0; i < len(vertices); i += vertexFloatCount {
 		x := vertices[i]
 		y := vertices[i+1]
 		if x < minX {
@@ -239,9 +278,9 @@ func dstRegionFromVertices(vertices []float32) (minX, minY, maxX, maxY float32)
 	return
 }
 
-func mightOverlapDstRegions(vertices1, vertices2 []float32) bool {
-	minX1, minY1, maxX1, maxY1 := dstRegionFromVertices(vertices1)
-	minX2, minY2, maxX2, maxY2 := dstRegionFromVertices(vertices2)
+func mightOverlapDstRegions(vertices1, vertices2 []float32, vertexFloatCount int) bool {
+	minX1, minY1, maxX1, maxY1 := dstRegionFromVertices(vertices1, vertexFloatCount)
+	minX2, minY2, maxX2, maxY2 := dstRegionFromVertices(vertices2, vertexFloatCount)
 	const margin = 1
 	return minX1 < maxX2+margin && minX2 < maxX1+margin && minY1 < maxY2+margin && minY2 < maxY1+margin
 }
@@ -318,6 +357,81 @@ func (c *readPixelsCommand) String() string {
 	return fmt.Sprintf("read-pixels: image: %d, args: %v", c.img.id, strings.Join(args, ", "))
 }
 
+// writePixelsFloat32Command represents a command to replace pixels of an image with float32 values.
+type writePixelsFloat32Command struct {
+	dst    *Image
+	pixels []float32
+	region image.Rectangle
+}
+
+func (c *writePixelsFloat32Command) String() string {
+	return fmt.Sprintf("write-pixels-float32: dst: %d, region: %s", c.dst.id, c.region.String())
+}
+
+// Exec executes the writePixelsFloat32Command.
+func (c *writePixelsFloat32Command) Exec(commandQueue *commandQueue, graphicsDriver graphicsdriver.Graphics, indexOffset int) error {
+	if f, ok := c.dst.image.(graphicsdriver.FloatPixelsImage); ok {
+		return f.WritePixelsFloat32([]graphicsdriver.PixelsFloat32Args{
+			{
+				Pixels: c.pixels,
+				Region: c.region,
+			},
+		})
+	}
+
+	pix := make([]byte, len(c.pixels))
+	graphics.Float32sToBytes(pix, c.pixels)
+	return c.dst.image.WritePixels([]graphicsdriver.PixelsArgs{
+		{
+			Pixels: pix,
+			Region: c.region,
+		},
+	})
+}
+
+func (c *writePixelsFloat32Command) NeedsSync() bool {
+	return false
+}
+
+type readPixelsFloat32Command struct {
+	img  *Image
+	args []graphicsdriver.PixelsFloat32Args
+}
+
+// Exec executes a readPixelsFloat32Command.
+func (c *readPixelsFloat32Command) Exec(commandQueue *commandQueue, graphicsDriver graphicsdriver.Graphics, indexOffset int) error {
+	if f, ok := c.img.image.(graphicsdriver.FloatPixelsImage); ok {
+		return f.ReadPixelsFloat32(c.args)
+	}
+
+	args := make([]graphicsdriver.PixelsArgs, 0, len(c.args))
+	for _, a := range c.args {
+		args = append(args, graphicsdriver.PixelsArgs{
+			Pixels: make([]byte, len(a.Pixels)),
+			Region: a.Region,
+		})
+	}
+	if err := c.img.image.ReadPixels(args); err != nil {
+		return err
+	}
+	for i, a := range args {
+		graphics.BytesToFloat32s(c.args[i].Pixels, a.Pixels)
+	}
+	return nil
+}
+
+func (c *readPixelsFloat32Command) NeedsSync() bool {
+	return true
+}
+
+func (c *readPixelsFloat32Command) String() string {
+	var args []string
+	for _, a := range c.args {
+		args = append(args, fmt.Sprintf("region: %s", a.Region.String()))
+	}
+	return fmt.Sprintf("read-pixels-float32: image: %d, args: %v", c.img.id, strings.Join(args, ", "))
+}
+
 // disposeImageCommand represents a command to dispose an image.
 type disposeImageCommand struct {
 	target *Image
@@ -362,11 +476,14 @@ type newImageCommand struct {
 	width     int
 	height    int
 	screen    bool
+	streaming bool
+	depth     bool
+	format    graphicsdriver.PixelFormat
 	attribute string
 }
 
 func (c *newImageCommand) String() string {
-	str := fmt.Sprintf("new-image: result: %d, width: %d, height: %d, screen: %t", c.result.id, c.width, c.height, c.screen)
+	str := fmt.Sprintf("new-image: result: %d, width: %d, height: %d, screen: %t, streaming: %t, depth: %t, format: %d", c.result.id, c.width, c.height, c.screen, c.streaming, c.depth, c.format)
 	if c.attribute != "" {
 		str += ", attribute: " + c.attribute
 	}
@@ -378,9 +495,29 @@ func (c *newImageCommand) Exec(commandQueue *commandQueue, graphicsDriver graphi
 	var err error
 	if c.screen {
 		c.result.image, err = graphicsDriver.NewScreenFramebufferImage(c.width, c.height)
-	} else {
-		c.result.image, err = graphicsDriver.NewImage(c.width, c.height)
+		return err
+	}
+	if c.streaming {
+		if s, ok := graphicsDriver.(graphicsdriver.StreamingImageCreator); ok {
+			c.result.image, err = s.NewStreamingImage(c.width, c.height)
+			return err
+		}
 	}
+	if c.depth {
+		d, ok := graphicsDriver.(graphicsdriver.DepthImageCreator)
+		if !ok {
+			return fmt.Errorf("graphicscommand: the graphics driver doesn't support depth buffers")
+		}
+		c.result.image, err = d.NewDepthImage(c.width, c.height)
+		return err
+	}
+	if c.format != graphicsdriver.PixelFormatRGBA8 {
+		if f, ok := graphicsDriver.(graphicsdriver.FloatImageCreator); ok {
+			c.result.image, err = f.NewFloatImage(c.width, c.height, c.format)
+			return err
+		}
+	}
+	c.result.image, err = graphicsDriver.NewImage(c.width, c.height)
 	return err
 }
 
@@ -428,7 +565,7 @@ func ResetGraphicsDriverState(graphicsDriver graphicsdriver.Graphics) (err error
 			err = r.Reset()
 		}, true)
 	}
-	return nil
+	return
 }
 
 // MaxImageSize returns the maximum size of an image.
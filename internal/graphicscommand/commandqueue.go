@@ -38,26 +38,50 @@ const (
 	// This value cannot be exactly 2^32 especially with WebGL 2, as 2^32th vertex is not rendered correctly.
 	// See https://registry.khronos.org/webgl/specs/latest/2.0/#5.18 .
 	//
-	// On 32bit architectures, this value is an adjusted number so that maxVertexFloatCount doesn't overflow int.
-	MaxVertexCount = is64bit*math.MaxUint32 + is32bit*(math.MaxInt32/graphics.VertexFloatCount)
+	// On 32bit architectures, this value is an adjusted number so that the number of floats for the vertices doesn't overflow int,
+	// even when the vertices have the extra vertex attributes.
+	MaxVertexCount = is64bit*math.MaxUint32 + is32bit*(math.MaxInt32/graphics.VertexFloatCountWithAttributes)
 
-	maxVertexFloatCount = MaxVertexCount * graphics.VertexFloatCount
+	// MaxIndexCount is the maximum number of indices for one draw call.
+	//
+	// This value is the maximum multiple of 3 that fits in a signed 32bit integer,
+	// as some graphics APIs like OpenGL take the index count as a signed 32bit integer.
+	MaxIndexCount = math.MaxInt32 / 3 * 3
 )
 
-var vsyncEnabled atomic.Bool
+var (
+	vsyncEnabled    atomic.Bool
+	activeVsyncMode atomic.Int32
+)
 
 func init() {
 	vsyncEnabled.Store(true)
 }
 
-func SetVsyncEnabled(enabled bool, graphicsDriver graphicsdriver.Graphics) {
-	vsyncEnabled.Store(enabled)
+func SetVsyncMode(mode graphicsdriver.VsyncMode, graphicsDriver graphicsdriver.Graphics) {
+	vsyncEnabled.Store(mode != graphicsdriver.VsyncModeOff)
 
 	runOnRenderThread(func() {
+		if s, ok := graphicsDriver.(graphicsdriver.VsyncModeSetter); ok {
+			activeVsyncMode.Store(int32(s.SetVsyncMode(mode)))
+			return
+		}
+
+		enabled := mode != graphicsdriver.VsyncModeOff
 		graphicsDriver.SetVsyncEnabled(enabled)
+		if enabled {
+			activeVsyncMode.Store(int32(graphicsdriver.VsyncModeOn))
+		} else {
+			activeVsyncMode.Store(int32(graphicsdriver.VsyncModeOff))
+		}
 	}, true)
 }
 
+// ActiveVsyncMode returns the vsync mode the graphics driver actually uses.
+func ActiveVsyncMode() graphicsdriver.VsyncMode {
+	return graphicsdriver.VsyncMode(activeVsyncMode.Load())
+}
+
 // FlushCommands flushes the command queue and present the screen if needed.
 // If endFrame is true, the current screen might be used to present.
 func FlushCommands(graphicsDriver graphicsdriver.Graphics, endFrame bool) error {
@@ -78,6 +102,9 @@ type commandQueue struct {
 
 	tmpNumVertexFloats int
 
+	// tmpVertexFloatCount is the number of floats in a vertex of the last draw-triangles command.
+	tmpVertexFloatCount int
+
 	drawTrianglesCommandPool drawTrianglesCommandPool
 
 	uint32sBuffer uint32sBuffer
@@ -101,26 +128,38 @@ func (q *commandQueue) appendIndices(indices []uint32, offset uint32) {
 }
 
 // mustUseDifferentVertexBuffer reports whether a different vertex buffer must be used.
-func mustUseDifferentVertexBuffer(nextNumVertexFloats int) bool {
-	return nextNumVertexFloats > maxVertexFloatCount
+//
+// lastVertexFloatCount is the number of floats in a vertex of the last vertices in the current vertex buffer, or 0 if there are no vertices.
+// A vertex buffer cannot have vertices with different layouts.
+func mustUseDifferentVertexBuffer(nextNumVertexFloats, lastVertexFloatCount, vertexFloatCount int) bool {
+	if lastVertexFloatCount != 0 && lastVertexFloatCount != vertexFloatCount {
+		return true
+	}
+	return nextNumVertexFloats > MaxVertexCount*vertexFloatCount
 }
 
 // EnqueueDrawTrianglesCommand enqueues a drawing-image command.
-func (q *commandQueue) EnqueueDrawTrianglesCommand(dst *Image, srcs [graphics.ShaderSrcImageCount]*Image, vertices []float32, indices []uint32, blend graphicsdriver.Blend, dstRegion image.Rectangle, srcRegions [graphics.ShaderSrcImageCount]image.Rectangle, shader *Shader, uniforms []uint32, fillRule graphicsdriver.FillRule) {
-	if len(vertices) > maxVertexFloatCount {
-		panic(fmt.Sprintf("graphicscommand: len(vertices) must equal to or less than %d but was %d", maxVertexFloatCount, len(vertices)))
+// extraDsts are the destinations for the second and later colors of the shader.
+func (q *commandQueue) EnqueueDrawTrianglesCommand(dst *Image, extraDsts [graphics.ShaderDstImageCount - 1]*Image, srcs [graphics.ShaderSrcImageCount]*Image, vertices []float32, indices []uint32, blend graphicsdriver.Blend, dstRegion image.Rectangle, srcRegions [graphics.ShaderSrcImageCount]image.Rectangle, shader *Shader, uniforms []uint32, fillRule graphicsdriver.FillRule) {
+	vertexFloatCount := shader.vertexFloatCount()
+	if len(vertices) > MaxVertexCount*vertexFloatCount {
+		panic(fmt.Sprintf("graphicscommand: len(vertices) must equal to or less than %d but was %d", MaxVertexCount*vertexFloatCount, len(vertices)))
+	}
+	if len(indices) > MaxIndexCount {
+		panic(fmt.Sprintf("graphicscommand: len(indices) must equal to or less than %d but was %d", MaxIndexCount, len(indices)))
 	}
 
 	split := false
-	if mustUseDifferentVertexBuffer(q.tmpNumVertexFloats + len(vertices)) {
+	if mustUseDifferentVertexBuffer(q.tmpNumVertexFloats+len(vertices), q.tmpVertexFloatCount, vertexFloatCount) {
 		q.tmpNumVertexFloats = 0
 		split = true
 	}
+	q.tmpVertexFloatCount = vertexFloatCount
 
 	// Assume that all the image sizes are same.
 	// Assume that the images are packed from the front in the slice srcs.
 	q.vertices = append(q.vertices, vertices...)
-	q.appendIndices(indices, uint32(q.tmpNumVertexFloats/graphics.VertexFloatCount))
+	q.appendIndices(indices, uint32(q.tmpNumVertexFloats/vertexFloatCount))
 	q.tmpNumVertexFloats += len(vertices)
 
 	// prependPreservedUniforms not only prepends values to the given slice but also creates a new slice.
@@ -134,9 +173,9 @@ func (q *commandQueue) EnqueueDrawTrianglesCommand(dst *Image, srcs [graphics.Sh
 	// TODO: If dst is the screen, reorder the command to be the last.
 	if !split && 0 < len(q.commands) {
 		if last, ok := q.commands[len(q.commands)-1].(*drawTrianglesCommand); ok {
-			if last.CanMergeWithDrawTrianglesCommand(dst, srcs, vertices, blend, shader, uniforms, fillRule) {
+			if last.CanMergeWithDrawTrianglesCommand(dst, extraDsts, srcs, vertices, blend, shader, uniforms, fillRule) {
 				last.setVertices(q.lastVertices(len(vertices) + last.numVertices()))
-				if last.dstRegions[len(last.dstRegions)-1].Region == dstRegion {
+				if r := last.dstRegions[len(last.dstRegions)-1]; r.Region == dstRegion && len(indices) <= MaxIndexCount-r.IndexCount {
 					last.dstRegions[len(last.dstRegions)-1].IndexCount += len(indices)
 				} else {
 					last.dstRegions = append(last.dstRegions, graphicsdriver.DstRegion{
@@ -151,6 +190,7 @@ func (q *commandQueue) EnqueueDrawTrianglesCommand(dst *Image, srcs [graphics.Sh
 
 	c := q.drawTrianglesCommandPool.get()
 	c.dst = dst
+	c.extraDsts = extraDsts
 	c.srcs = srcs
 	c.vertices = q.lastVertices(len(vertices))
 	c.blend = blend
@@ -193,10 +233,14 @@ func (q *commandQueue) Flush(graphicsDriver graphicsdriver.Graphics, endFrame bo
 	if err := q.err.Load(); err != nil {
 		return err.(error)
 	}
+	if err := renderThreadErr.Load(); err != nil {
+		return *err
+	}
 
 	var sync bool
 	// Disable asynchronous rendering when vsync is on, as this causes a rendering delay (#2822).
-	if endFrame && vsyncEnabled.Load() {
+	// In the low latency mode, the next frame must not start before this frame is presented.
+	if endFrame && (vsyncEnabled.Load() || isLowLatencyMode()) {
 		sync = true
 	}
 	if !sync {
@@ -210,6 +254,11 @@ func (q *commandQueue) Flush(graphicsDriver graphicsdriver.Graphics, endFrame bo
 
 	logger := debug.SwitchFrameLogger()
 
+	var frameStart int64
+	if endFrame {
+		frameStart = frameStartTime.Swap(0)
+	}
+
 	var flushErr error
 	runOnRenderThread(func() {
 		defer logger.Flush()
@@ -223,6 +272,10 @@ func (q *commandQueue) Flush(graphicsDriver graphicsdriver.Graphics, endFrame bo
 			return
 		}
 
+		if endFrame {
+			recordPresent(frameStart)
+		}
+
 		theCommandQueueManager.putCommandQueue(q)
 	}, sync)
 
@@ -267,6 +320,7 @@ func (q *commandQueue) flush(graphicsDriver graphicsdriver.Graphics, endFrame bo
 		q.vertices = q.vertices[:0]
 		q.indices = q.indices[:0]
 		q.tmpNumVertexFloats = 0
+		q.tmpVertexFloatCount = 0
 
 		if endFrame {
 			q.uint32sBuffer.reset()
@@ -283,11 +337,14 @@ func (q *commandQueue) flush(graphicsDriver graphicsdriver.Graphics, endFrame bo
 		nv := 0
 		ne := 0
 		nc := 0
+		vertexFloatCount := 0
 		for _, c := range cs {
 			if dtc, ok := c.(*drawTrianglesCommand); ok {
-				if nc > 0 && mustUseDifferentVertexBuffer(nv+dtc.numVertices()) {
+				n := dtc.shader.vertexFloatCount()
+				if nc > 0 && mustUseDifferentVertexBuffer(nv+dtc.numVertices(), vertexFloatCount, n) {
 					break
 				}
+				vertexFloatCount = n
 				nv += dtc.numVertices()
 				ne += dtc.numIndices()
 			}
@@ -393,6 +450,38 @@ func prependPreservedUniforms(uniforms []uint32, shader *Shader, dst *Image, src
 		uniforms[8] = 0
 		uniforms[9] = 0
 	}
+	if srcs[4] != nil {
+		w, h := srcs[4].InternalSize()
+		uniforms[10] = math.Float32bits(float32(w))
+		uniforms[11] = math.Float32bits(float32(h))
+	} else {
+		uniforms[10] = 0
+		uniforms[11] = 0
+	}
+	if srcs[5] != nil {
+		w, h := srcs[5].InternalSize()
+		uniforms[12] = math.Float32bits(float32(w))
+		uniforms[13] = math.Float32bits(float32(h))
+	} else {
+		uniforms[12] = 0
+		uniforms[13] = 0
+	}
+	if srcs[6] != nil {
+		w, h := srcs[6].InternalSize()
+		uniforms[14] = math.Float32bits(float32(w))
+		uniforms[15] = math.Float32bits(float32(h))
+	} else {
+		uniforms[14] = 0
+		uniforms[15] = 0
+	}
+	if srcs[7] != nil {
+		w, h := srcs[7].InternalSize()
+		uniforms[16] = math.Float32bits(float32(w))
+		uniforms[17] = math.Float32bits(float32(h))
+	} else {
+		uniforms[16] = 0
+		uniforms[17] = 0
+	}
 
 	dr := imageRectangleToRectangleF32(dstRegion)
 	if shader.unit() == shaderir.Texels {
@@ -403,12 +492,12 @@ func prependPreservedUniforms(uniforms []uint32, shader *Shader, dst *Image, src
 	}
 
 	// Set the destination region origin.
-	uniforms[10] = math.Float32bits(dr.x)
-	uniforms[11] = math.Float32bits(dr.y)
+	uniforms[18] = math.Float32bits(dr.x)
+	uniforms[19] = math.Float32bits(dr.y)
 
 	// Set the destination region size.
-	uniforms[12] = math.Float32bits(dr.width)
-	uniforms[13] = math.Float32bits(dr.height)
+	uniforms[20] = math.Float32bits(dr.width)
+	uniforms[21] = math.Float32bits(dr.height)
 
 	var srs [graphics.ShaderSrcImageCount]rectangleF32
 	for i, r := range srcRegions {
@@ -428,48 +517,64 @@ func prependPreservedUniforms(uniforms []uint32, shader *Shader, dst *Image, src
 	}
 
 	// Set the source region origins.
-	uniforms[14] = math.Float32bits(srs[0].x)
-	uniforms[15] = math.Float32bits(srs[0].y)
-	uniforms[16] = math.Float32bits(srs[1].x)
-	uniforms[17] = math.Float32bits(srs[1].y)
-	uniforms[18] = math.Float32bits(srs[2].x)
-	uniforms[19] = math.Float32bits(srs[2].y)
-	uniforms[20] = math.Float32bits(srs[3].x)
-	uniforms[21] = math.Float32bits(srs[3].y)
+	uniforms[22] = math.Float32bits(srs[0].x)
+	uniforms[23] = math.Float32bits(srs[0].y)
+	uniforms[24] = math.Float32bits(srs[1].x)
+	uniforms[25] = math.Float32bits(srs[1].y)
+	uniforms[26] = math.Float32bits(srs[2].x)
+	uniforms[27] = math.Float32bits(srs[2].y)
+	uniforms[28] = math.Float32bits(srs[3].x)
+	uniforms[29] = math.Float32bits(srs[3].y)
+	uniforms[30] = math.Float32bits(srs[4].x)
+	uniforms[31] = math.Float32bits(srs[4].y)
+	uniforms[32] = math.Float32bits(srs[5].x)
+	uniforms[33] = math.Float32bits(srs[5].y)
+	uniforms[34] = math.Float32bits(srs[6].x)
+	uniforms[35] = math.Float32bits(srs[6].y)
+	uniforms[36] = math.Float32bits(srs[7].x)
+	uniforms[37] = math.Float32bits(srs[7].y)
 
 	// Set the source region sizes.
-	uniforms[22] = math.Float32bits(srs[0].width)
-	uniforms[23] = math.Float32bits(srs[0].height)
-	uniforms[24] = math.Float32bits(srs[1].width)
-	uniforms[25] = math.Float32bits(srs[1].height)
-	uniforms[26] = math.Float32bits(srs[2].width)
-	uniforms[27] = math.Float32bits(srs[2].height)
-	uniforms[28] = math.Float32bits(srs[3].width)
-	uniforms[29] = math.Float32bits(srs[3].height)
+	uniforms[38] = math.Float32bits(srs[0].width)
+	uniforms[39] = math.Float32bits(srs[0].height)
+	uniforms[40] = math.Float32bits(srs[1].width)
+	uniforms[41] = math.Float32bits(srs[1].height)
+	uniforms[42] = math.Float32bits(srs[2].width)
+	uniforms[43] = math.Float32bits(srs[2].height)
+	uniforms[44] = math.Float32bits(srs[3].width)
+	uniforms[45] = math.Float32bits(srs[3].height)
+	uniforms[46] = math.Float32bits(srs[4].width)
+	uniforms[47] = math.Float32bits(srs[4].height)
+	uniforms[48] = math.Float32bits(srs[5].width)
+	uniforms[49] = math.Float32bits(srs[5].height)
+	uniforms[50] = math.Float32bits(srs[6].width)
+	uniforms[51] = math.Float32bits(srs[6].height)
+	uniforms[52] = math.Float32bits(srs[7].width)
+	uniforms[53] = math.Float32bits(srs[7].height)
 
 	// Set the projection matrix.
-	uniforms[30] = math.Float32bits(2 / float32(dw))
-	uniforms[31] = 0
-	uniforms[32] = 0
-	uniforms[33] = 0
-	uniforms[34] = 0
-	uniforms[35] = math.Float32bits(2 / float32(dh))
-	uniforms[36] = 0
-	uniforms[37] = 0
-	uniforms[38] = 0
-	uniforms[39] = 0
-	uniforms[40] = math.Float32bits(1)
-	uniforms[41] = 0
-	uniforms[42] = math.Float32bits(-1)
-	uniforms[43] = math.Float32bits(-1)
-	uniforms[44] = 0
-	uniforms[45] = math.Float32bits(1)
+	uniforms[54] = math.Float32bits(2 / float32(dw))
+	uniforms[55] = 0
+	uniforms[56] = 0
+	uniforms[57] = 0
+	uniforms[58] = 0
+	uniforms[59] = math.Float32bits(2 / float32(dh))
+	uniforms[60] = 0
+	uniforms[61] = 0
+	uniforms[62] = 0
+	uniforms[63] = 0
+	uniforms[64] = math.Float32bits(1)
+	uniforms[65] = 0
+	uniforms[66] = math.Float32bits(-1)
+	uniforms[67] = math.Float32bits(-1)
+	uniforms[68] = 0
+	uniforms[69] = math.Float32bits(1)
 
 	return uniforms
 }
 
 // Confirm the concrete value of graphics.PreservedUniformDwordCount.
-var _ [0]struct{} = [graphics.PreservedUniformDwordCount - 46]struct{}{}
+var _ [0]struct{} = [graphics.PreservedUniformDwordCount - 70]struct{}{}
 
 type commandQueuePool struct {
 	cache []*commandQueue
@@ -522,11 +627,11 @@ func (c *commandQueueManager) putCommandQueue(commandQueue *commandQueue) {
 	c.pool.put(commandQueue)
 }
 
-func (c *commandQueueManager) enqueueDrawTrianglesCommand(dst *Image, srcs [graphics.ShaderSrcImageCount]*Image, vertices []float32, indices []uint32, blend graphicsdriver.Blend, dstRegion image.Rectangle, srcRegions [graphics.ShaderSrcImageCount]image.Rectangle, shader *Shader, uniforms []uint32, fillRule graphicsdriver.FillRule) {
+func (c *commandQueueManager) enqueueDrawTrianglesCommand(dst *Image, extraDsts [graphics.ShaderDstImageCount - 1]*Image, srcs [graphics.ShaderSrcImageCount]*Image, vertices []float32, indices []uint32, blend graphicsdriver.Blend, dstRegion image.Rectangle, srcRegions [graphics.ShaderSrcImageCount]image.Rectangle, shader *Shader, uniforms []uint32, fillRule graphicsdriver.FillRule) {
 	if c.current == nil {
 		c.current, _ = c.pool.get()
 	}
-	c.current.EnqueueDrawTrianglesCommand(dst, srcs, vertices, indices, blend, dstRegion, srcRegions, shader, uniforms, fillRule)
+	c.current.EnqueueDrawTrianglesCommand(dst, extraDsts, srcs, vertices, indices, blend, dstRegion, srcRegions, shader, uniforms, fillRule)
 }
 
 func (c *commandQueueManager) flush(graphicsDriver graphicsdriver.Graphics, endFrame bool) error {
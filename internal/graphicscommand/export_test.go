@@ -16,6 +16,7 @@ package graphicscommand
 
 import (
 	"image"
+	"time"
 
 	"github.com/duplicants-ai/ebiten/internal/graphics"
 )
@@ -29,3 +30,12 @@ func (i *Image) BufferedWritePixelsArgsForTesting() []WritePixelsCommandArgs {
 func PrependPreservedUniforms(uniforms []uint32, shader *Shader, dst *Image, srcs [graphics.ShaderSrcImageCount]*Image, dstRegion image.Rectangle, srcRegions [graphics.ShaderSrcImageCount]image.Rectangle) []uint32 {
 	return prependPreservedUniforms(uniforms, shader, dst, srcs, dstRegion, srcRegions)
 }
+
+func ResetPresentLatencyForTesting() {
+	presentLatency.Store(0)
+}
+
+// RecordPresentForTesting records a present of a frame that started the given duration ago.
+func RecordPresentForTesting(latency time.Duration) {
+	recordPresent(int64(time.Since(baseTime) - latency))
+}
@@ -61,6 +61,31 @@ func genNextImageID() int {
 //
 // Note that the image is not initialized yet.
 func NewImage(width, height int, screenFramebuffer bool, attribute string) *Image {
+	return newImage(width, height, screenFramebuffer, false, false, graphicsdriver.PixelFormatRGBA8, attribute)
+}
+
+// NewStreamingImage returns a new image whose pixels are rewritten frequently, e.g., every frame.
+//
+// If the graphics driver supports streaming images, WritePixels on the image can be faster than a regular image.
+func NewStreamingImage(width, height int, attribute string) *Image {
+	return newImage(width, height, false, true, false, graphicsdriver.PixelFormatRGBA8, attribute)
+}
+
+// NewDepthImage returns a new image with a depth buffer.
+//
+// If the graphics driver doesn't support depth buffers, creating the image fails with an error.
+func NewDepthImage(width, height int, attribute string) *Image {
+	return newImage(width, height, false, false, true, graphicsdriver.PixelFormatRGBA8, attribute)
+}
+
+// NewFloatImage returns a new image with a floating-point pixel format.
+//
+// If the graphics driver doesn't support floating-point formats, the image works like a regular image.
+func NewFloatImage(width, height int, format graphicsdriver.PixelFormat, attribute string) *Image {
+	return newImage(width, height, false, false, false, format, attribute)
+}
+
+func newImage(width, height int, screenFramebuffer bool, streaming bool, depth bool, format graphicsdriver.PixelFormat, attribute string) *Image {
 	i := &Image{
 		width:     width,
 		height:    height,
@@ -73,6 +98,9 @@ func NewImage(width, height int, screenFramebuffer bool, attribute string) *Imag
 		width:     width,
 		height:    height,
 		screen:    screenFramebuffer,
+		streaming: streaming,
+		depth:     depth,
+		format:    format,
 		attribute: attribute,
 	}
 	theCommandQueueManager.enqueueCommand(c)
@@ -136,6 +164,17 @@ func (i *Image) InternalSize() (int, int) {
 // If the source image is not specified, i.e., src is nil and there is no image in the uniform variables, the
 // elements for the source image are not used.
 func (i *Image) DrawTriangles(srcs [graphics.ShaderSrcImageCount]*Image, vertices []float32, indices []uint32, blend graphicsdriver.Blend, dstRegion image.Rectangle, srcRegions [graphics.ShaderSrcImageCount]image.Rectangle, shader *Shader, uniforms []uint32, fillRule graphicsdriver.FillRule) {
+	i.DrawTrianglesMRT([graphics.ShaderDstImageCount - 1]*Image{}, srcs, vertices, indices, blend, dstRegion, srcRegions, shader, uniforms, fillRule)
+}
+
+// DrawTrianglesMRT draws triangles with the given image to multiple render targets.
+//
+// The receiver receives the first color of the shader, and extraDsts[i] receives the (i+2)-th color of the shader.
+// A nil image in extraDsts means that the color is not rendered anywhere.
+//
+// When extraDsts has a non-nil image, none of the destination images can be the screen,
+// and all the destination images must have the same internal size.
+func (i *Image) DrawTrianglesMRT(extraDsts [graphics.ShaderDstImageCount - 1]*Image, srcs [graphics.ShaderSrcImageCount]*Image, vertices []float32, indices []uint32, blend graphicsdriver.Blend, dstRegion image.Rectangle, srcRegions [graphics.ShaderSrcImageCount]image.Rectangle, shader *Shader, uniforms []uint32, fillRule graphicsdriver.FillRule) {
 	for _, src := range srcs {
 		if src == nil {
 			continue
@@ -145,9 +184,23 @@ func (i *Image) DrawTriangles(srcs [graphics.ShaderSrcImageCount]*Image, vertice
 		}
 		src.flushBufferedWritePixels()
 	}
+	for _, extraDst := range extraDsts {
+		if extraDst == nil {
+			continue
+		}
+		if i.screen || extraDst.screen {
+			panic("graphicscommand: the screen image cannot be one of multiple render targets")
+		}
+		w0, h0 := i.InternalSize()
+		w1, h1 := extraDst.InternalSize()
+		if w0 != w1 || h0 != h1 {
+			panic("graphicscommand: all the render targets must have the same internal size")
+		}
+		extraDst.flushBufferedWritePixels()
+	}
 	i.flushBufferedWritePixels()
 
-	theCommandQueueManager.enqueueDrawTrianglesCommand(i, srcs, vertices, indices, blend, dstRegion, srcRegions, shader, uniforms, fillRule)
+	theCommandQueueManager.enqueueDrawTrianglesCommand(i, extraDsts, srcs, vertices, indices, blend, dstRegion, srcRegions, shader, uniforms, fillRule)
 }
 
 // ReadPixels reads the image's pixels.
@@ -165,6 +218,35 @@ func (i *Image) ReadPixels(graphicsDriver graphicsdriver.Graphics, args []graphi
 	return nil
 }
 
+// ReadPixelsFloat32 reads the image's pixels as float32 values.
+// ReadPixelsFloat32 returns an error when an error happens in the graphics driver.
+func (i *Image) ReadPixelsFloat32(graphicsDriver graphicsdriver.Graphics, args []graphicsdriver.PixelsFloat32Args) error {
+	i.flushBufferedWritePixels()
+	c := &readPixelsFloat32Command{
+		img:  i,
+		args: args,
+	}
+	theCommandQueueManager.enqueueCommand(c)
+	if err := theCommandQueueManager.flush(graphicsDriver, false); err != nil {
+		return err
+	}
+	return nil
+}
+
+// WritePixelsFloat32 writes the pixels as float32 values.
+//
+// pixels must not be modified after WritePixelsFloat32 is called.
+func (i *Image) WritePixelsFloat32(pixels []float32, region image.Rectangle) {
+	// Keep the order with the buffered byte pixels.
+	i.flushBufferedWritePixels()
+	c := &writePixelsFloat32Command{
+		dst:    i,
+		pixels: pixels,
+		region: region,
+	}
+	theCommandQueueManager.enqueueCommand(c)
+}
+
 func (i *Image) WritePixels(pixels *graphics.ManagedBytes, region image.Rectangle) {
 	// Release the previous pixels if the region is included by the new region.
 	// Successive WritePixels calls might accumulate the pixels and never release,
@@ -212,17 +294,19 @@ func (i *Image) dumpTo(w io.Writer, graphicsDriver graphicsdriver.Graphics, blac
 		return err
 	}
 
+	colorSpace := prepareDumpPixels(pix)
+
 	if blackbg {
 		for i := 0; i < len(pix)/4; i++ {
 			pix[4*i+3] = 0xff
 		}
 	}
 
-	if err := png.Encode(w, (&image.RGBA{
+	if err := png.EncodeWithColorSpace(w, (&image.RGBA{
 		Pix:    pix,
 		Stride: 4 * i.width,
 		Rect:   image.Rect(0, 0, i.width, i.height),
-	}).SubImage(rect)); err != nil {
+	}).SubImage(rect), colorSpace); err != nil {
 		return err
 	}
 
@@ -0,0 +1,81 @@
+// Copyright 2026 The Ebitengine Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package graphicscommand
+
+import (
+	"sync/atomic"
+	"time"
+
+	"github.com/duplicants-ai/ebiten/internal/graphicsdriver"
+)
+
+var (
+	latencyMode atomic.Int32
+
+	// frameStartTime is the time when the current frame samples its inputs, in nanoseconds since baseTime.
+	frameStartTime atomic.Int64
+
+	// presentLatency is the smoothed present latency in nanoseconds.
+	presentLatency atomic.Int64
+
+	baseTime = time.Now()
+)
+
+// SetLatencyMode sets the latency mode.
+// The graphics driver is notified if it implements graphicsdriver.LatencyModeSetter.
+func SetLatencyMode(mode graphicsdriver.LatencyMode, graphicsDriver graphicsdriver.Graphics) {
+	latencyMode.Store(int32(mode))
+
+	s, ok := graphicsDriver.(graphicsdriver.LatencyModeSetter)
+	if !ok {
+		return
+	}
+	runOnRenderThread(func() {
+		s.SetLatencyMode(mode)
+	}, true)
+}
+
+func isLowLatencyMode() bool {
+	return graphicsdriver.LatencyMode(latencyMode.Load()) == graphicsdriver.LatencyModeLow
+}
+
+// MarkFrameStart records the current time as the start of the current frame.
+// MarkFrameStart should be called just before the inputs are sampled.
+func MarkFrameStart() {
+	frameStartTime.Store(int64(time.Since(baseTime)))
+}
+
+// PresentLatency returns the smoothed duration from the start of a frame to the end of presenting the frame.
+// PresentLatency returns 0 if no frame has been presented yet.
+func PresentLatency() time.Duration {
+	return time.Duration(presentLatency.Load())
+}
+
+// recordPresent updates the present latency with a frame that started at start.
+// recordPresent must be called on the render thread.
+func recordPresent(start int64) {
+	if start == 0 {
+		return
+	}
+	d := int64(time.Since(baseTime)) - start
+	if d < 0 {
+		return
+	}
+	// Use an exponential moving average so that the value doesn't fluctuate too much.
+	if old := presentLatency.Load(); old != 0 {
+		d = old + (d-old)/8
+	}
+	presentLatency.Store(d)
+}
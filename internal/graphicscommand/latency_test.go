@@ -0,0 +1,109 @@
+// Copyright 2018 The Ebiten Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package graphicscommand_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/duplicants-ai/ebiten/internal/graphicscommand"
+	"github.com/duplicants-ai/ebiten/internal/graphicsdriver"
+	"github.com/duplicants-ai/ebiten/internal/ui"
+)
+
+type latencyModeRecorder struct {
+	graphicsdriver.Graphics
+
+	modes []graphicsdriver.LatencyMode
+}
+
+func (l *latencyModeRecorder) SetLatencyMode(mode graphicsdriver.LatencyMode) {
+	l.modes = append(l.modes, mode)
+}
+
+func TestSetLatencyMode(t *testing.T) {
+	t.Cleanup(func() {
+		graphicscommand.SetLatencyMode(graphicsdriver.LatencyModeDefault, ui.Get().GraphicsDriverForTesting())
+	})
+
+	var r latencyModeRecorder
+	graphicscommand.SetLatencyMode(graphicsdriver.LatencyModeLow, &r)
+	graphicscommand.SetLatencyMode(graphicsdriver.LatencyModeSmooth, &r)
+	want := []graphicsdriver.LatencyMode{graphicsdriver.LatencyModeLow, graphicsdriver.LatencyModeSmooth}
+	if len(r.modes) != len(want) {
+		t.Fatalf("got: %v, want: %v", r.modes, want)
+	}
+	for i := range want {
+		if r.modes[i] != want[i] {
+			t.Errorf("got: %v, want: %v", r.modes, want)
+		}
+	}
+
+	// A driver without graphicsdriver.LatencyModeSetter is not notified.
+	type driver struct {
+		graphicsdriver.Graphics
+	}
+	graphicscommand.SetLatencyMode(graphicsdriver.LatencyModeLow, driver{})
+}
+
+func TestPresentLatency(t *testing.T) {
+	graphicsDriver := ui.Get().GraphicsDriverForTesting()
+	graphicscommand.ResetPresentLatencyForTesting()
+	graphicscommand.SetLatencyMode(graphicsdriver.LatencyModeLow, graphicsDriver)
+	t.Cleanup(func() {
+		graphicscommand.SetLatencyMode(graphicsdriver.LatencyModeDefault, graphicsDriver)
+		graphicscommand.ResetPresentLatencyForTesting()
+	})
+
+	if got := graphicscommand.PresentLatency(); got != 0 {
+		t.Errorf("PresentLatency before the first frame: got: %v, want: 0", got)
+	}
+
+	const d = 20 * time.Millisecond
+	graphicscommand.MarkFrameStart()
+	time.Sleep(d)
+	// In the low latency mode, the frame is presented synchronously.
+	if err := graphicscommand.FlushCommands(graphicsDriver, true); err != nil {
+		t.Fatal(err)
+	}
+	got := graphicscommand.PresentLatency()
+	if got < d {
+		t.Errorf("PresentLatency: got: %v, want: >= %v", got, d)
+	}
+
+	// A frame without MarkFrameStart doesn't update the latency.
+	if err := graphicscommand.FlushCommands(graphicsDriver, true); err != nil {
+		t.Fatal(err)
+	}
+	if got2 := graphicscommand.PresentLatency(); got2 != got {
+		t.Errorf("PresentLatency without MarkFrameStart: got: %v, want: %v", got2, got)
+	}
+}
+
+func TestPresentLatencySmoothing(t *testing.T) {
+	graphicscommand.ResetPresentLatencyForTesting()
+	t.Cleanup(graphicscommand.ResetPresentLatencyForTesting)
+
+	graphicscommand.RecordPresentForTesting(80 * time.Millisecond)
+	if got, want := graphicscommand.PresentLatency(), 80*time.Millisecond; got < want || got >= want+10*time.Millisecond {
+		t.Errorf("got: %v, want: %v", got, want)
+	}
+
+	// The latency is an exponential moving average.
+	graphicscommand.RecordPresentForTesting(160 * time.Millisecond)
+	if got, want := graphicscommand.PresentLatency(), 90*time.Millisecond; got < want || got >= want+10*time.Millisecond {
+		t.Errorf("got: %v, want: %v", got, want)
+	}
+}
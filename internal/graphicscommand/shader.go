@@ -15,6 +15,7 @@
 package graphicscommand
 
 import (
+	"github.com/duplicants-ai/ebiten/internal/graphics"
 	"github.com/duplicants-ai/ebiten/internal/graphicsdriver"
 	"github.com/duplicants-ai/ebiten/internal/shaderir"
 )
@@ -60,3 +61,8 @@ func (s *Shader) Dispose() {
 func (s *Shader) unit() shaderir.Unit {
 	return s.ir.Unit
 }
+
+// vertexFloatCount returns the number of floats in a vertex for the shader.
+func (s *Shader) vertexFloatCount() int {
+	return graphics.ShaderVertexFloatCount(s.ir)
+}
@@ -16,12 +16,17 @@ package graphicscommand
 
 import (
 	"context"
+	"sync/atomic"
 
+	"github.com/duplicants-ai/ebiten/internal/debug"
 	"github.com/duplicants-ai/ebiten/internal/thread"
 )
 
 var theRenderThread thread.Thread = thread.NewNoopThread()
 
+// renderThreadErr is an error converted from a panic on the render thread.
+var renderThreadErr atomic.Pointer[error]
+
 // SetOSThreadAsRenderThread sets an OS thread as rendering thread e.g. for OpenGL.
 func SetOSThreadAsRenderThread() {
 	theRenderThread = thread.NewOSThread()
@@ -33,6 +38,20 @@ func LoopRenderThread(ctx context.Context) {
 
 // runOnRenderThread calls f on the rendering thread.
 func runOnRenderThread(f func(), sync bool) {
+	if debug.IsPanicCaptureEnabled() {
+		origF := f
+		f = func() {
+			var err error
+			defer func() {
+				if err != nil {
+					renderThreadErr.CompareAndSwap(nil, &err)
+				}
+			}()
+			defer debug.CapturePanic(&err)
+			origF()
+		}
+	}
+
 	if sync {
 		theRenderThread.Call(f)
 		return
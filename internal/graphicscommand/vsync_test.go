@@ -0,0 +1,135 @@
+// Copyright 2018 The Ebiten Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package graphicscommand_test
+
+import (
+	"testing"
+
+	"github.com/duplicants-ai/ebiten/internal/graphicscommand"
+	"github.com/duplicants-ai/ebiten/internal/graphicsdriver"
+	"github.com/duplicants-ai/ebiten/internal/ui"
+)
+
+type vsyncEnabledRecorder struct {
+	graphicsdriver.Graphics
+
+	enabled []bool
+}
+
+func (v *vsyncEnabledRecorder) SetVsyncEnabled(enabled bool) {
+	v.enabled = append(v.enabled, enabled)
+}
+
+type vsyncModeRecorder struct {
+	vsyncEnabledRecorder
+
+	modes     []graphicsdriver.VsyncMode
+	supported map[graphicsdriver.VsyncMode]bool
+}
+
+func (v *vsyncModeRecorder) SetVsyncMode(mode graphicsdriver.VsyncMode) graphicsdriver.VsyncMode {
+	v.modes = append(v.modes, mode)
+	if !v.supported[mode] {
+		return graphicsdriver.VsyncModeOn
+	}
+	return mode
+}
+
+func TestSetVsyncModeWithoutVsyncModeSetter(t *testing.T) {
+	t.Cleanup(func() {
+		graphicscommand.SetVsyncMode(graphicsdriver.VsyncModeOn, ui.Get().GraphicsDriverForTesting())
+	})
+
+	testCases := []struct {
+		Mode        graphicsdriver.VsyncMode
+		WantEnabled bool
+		WantActive  graphicsdriver.VsyncMode
+	}{
+		{
+			Mode:        graphicsdriver.VsyncModeOn,
+			WantEnabled: true,
+			WantActive:  graphicsdriver.VsyncModeOn,
+		},
+		{
+			Mode:        graphicsdriver.VsyncModeOff,
+			WantEnabled: false,
+			WantActive:  graphicsdriver.VsyncModeOff,
+		},
+		{
+			Mode:        graphicsdriver.VsyncModeAdaptive,
+			WantEnabled: true,
+			WantActive:  graphicsdriver.VsyncModeOn,
+		},
+		{
+			Mode:        graphicsdriver.VsyncModeMailbox,
+			WantEnabled: true,
+			WantActive:  graphicsdriver.VsyncModeOn,
+		},
+	}
+	for _, tc := range testCases {
+		var r vsyncEnabledRecorder
+		graphicscommand.SetVsyncMode(tc.Mode, &r)
+		if len(r.enabled) != 1 || r.enabled[0] != tc.WantEnabled {
+			t.Errorf("mode: %d, SetVsyncEnabled calls: got: %v, want: [%t]", tc.Mode, r.enabled, tc.WantEnabled)
+		}
+		if got := graphicscommand.ActiveVsyncMode(); got != tc.WantActive {
+			t.Errorf("mode: %d, ActiveVsyncMode: got: %d, want: %d", tc.Mode, got, tc.WantActive)
+		}
+	}
+}
+
+func TestSetVsyncModeWithVsyncModeSetter(t *testing.T) {
+	t.Cleanup(func() {
+		graphicscommand.SetVsyncMode(graphicsdriver.VsyncModeOn, ui.Get().GraphicsDriverForTesting())
+	})
+
+	r := &vsyncModeRecorder{
+		supported: map[graphicsdriver.VsyncMode]bool{
+			graphicsdriver.VsyncModeOn:      true,
+			graphicsdriver.VsyncModeOff:     true,
+			graphicsdriver.VsyncModeMailbox: true,
+		},
+	}
+
+	testCases := []struct {
+		Mode       graphicsdriver.VsyncMode
+		WantActive graphicsdriver.VsyncMode
+	}{
+		{
+			Mode:       graphicsdriver.VsyncModeMailbox,
+			WantActive: graphicsdriver.VsyncModeMailbox,
+		},
+		{
+			Mode:       graphicsdriver.VsyncModeAdaptive,
+			WantActive: graphicsdriver.VsyncModeOn,
+		},
+		{
+			Mode:       graphicsdriver.VsyncModeOff,
+			WantActive: graphicsdriver.VsyncModeOff,
+		},
+	}
+	for i, tc := range testCases {
+		graphicscommand.SetVsyncMode(tc.Mode, r)
+		if got := r.modes[i]; got != tc.Mode {
+			t.Errorf("mode: %d, SetVsyncMode: got: %d, want: %d", tc.Mode, got, tc.Mode)
+		}
+		if got := graphicscommand.ActiveVsyncMode(); got != tc.WantActive {
+			t.Errorf("mode: %d, ActiveVsyncMode: got: %d, want: %d", tc.Mode, got, tc.WantActive)
+		}
+	}
+	if len(r.enabled) != 0 {
+		t.Errorf("SetVsyncEnabled must not be called with graphicsdriver.VsyncModeSetter: got: %v", r.enabled)
+	}
+}
@@ -19,6 +19,8 @@ import (
 	"unsafe"
 
 	"golang.org/x/sys/windows"
+
+	"github.com/duplicants-ai/ebiten/internal/graphicsdriver"
 )
 
 const is64bit = unsafe.Sizeof(uintptr(0)) == 8
@@ -29,6 +31,19 @@ func (h handleError) Error() string {
 	return fmt.Sprintf("HANDLE(%d)", h)
 }
 
+// Is reports whether h matches target.
+// The errors indicating that the device is removed or reset match graphicsdriver.ErrDeviceLost.
+func (h handleError) Is(target error) bool {
+	if target != graphicsdriver.ErrDeviceLost {
+		return false
+	}
+	switch h {
+	case _DXGI_ERROR_DEVICE_HUNG, _DXGI_ERROR_DEVICE_REMOVED, _DXGI_ERROR_DEVICE_RESET, _DXGI_ERROR_DRIVER_INTERNAL_ERROR:
+		return true
+	}
+	return false
+}
+
 type (
 	_BOOL int32
 )
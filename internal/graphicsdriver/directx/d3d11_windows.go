@@ -593,6 +593,11 @@ type _ID3D11DepthStencilState_Vtbl struct {
 	GetDesc uintptr
 }
 
+func (i *_ID3D11DepthStencilState) Release() uint32 {
+	r, _, _ := syscall.Syscall(i.vtbl.Release, 1, uintptr(unsafe.Pointer(i)), 0, 0)
+	return uint32(r)
+}
+
 type _ID3D11DepthStencilView struct {
 	vtbl *_ID3D11DepthStencilView_Vtbl
 }
@@ -832,6 +837,11 @@ func (i *_ID3D11Device) QueryInterface(riid *windows.GUID) (unsafe.Pointer, erro
 	return v, nil
 }
 
+func (i *_ID3D11Device) Release() uint32 {
+	r, _, _ := syscall.Syscall(i.vtbl.Release, 1, uintptr(unsafe.Pointer(i)), 0, 0)
+	return uint32(r)
+}
+
 type _ID3D11DeviceContext struct {
 	vtbl *_ID3D11DeviceContext_Vtbl
 }
@@ -1121,6 +1131,11 @@ func (i *_ID3D11DeviceContext) RSSetViewports(viewports []_D3D11_VIEWPORT) {
 	runtime.KeepAlive(viewports)
 }
 
+func (i *_ID3D11DeviceContext) Release() uint32 {
+	r, _, _ := syscall.Syscall(i.vtbl.Release, 1, uintptr(unsafe.Pointer(i)), 0, 0)
+	return uint32(r)
+}
+
 func (i *_ID3D11DeviceContext) Unmap(pResource unsafe.Pointer, subresource uint32) {
 	_, _, _ = syscall.Syscall(i.vtbl.Unmap, 3, uintptr(unsafe.Pointer(i)),
 		uintptr(pResource), uintptr(subresource))
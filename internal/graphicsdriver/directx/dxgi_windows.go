@@ -46,6 +46,7 @@ type _DXGI_FORMAT int32
 const (
 	_DXGI_FORMAT_UNKNOWN            _DXGI_FORMAT = 0
 	_DXGI_FORMAT_R32G32B32A32_FLOAT _DXGI_FORMAT = 2
+	_DXGI_FORMAT_R16G16B16A16_FLOAT _DXGI_FORMAT = 10
 	_DXGI_FORMAT_R32G32_FLOAT       _DXGI_FORMAT = 16
 	_DXGI_FORMAT_R8G8B8A8_UNORM     _DXGI_FORMAT = 28
 	_DXGI_FORMAT_R32_UINT           _DXGI_FORMAT = 42
@@ -92,7 +93,11 @@ const (
 
 	_DXGI_CREATE_FACTORY_DEBUG = 0x01
 
-	_DXGI_ERROR_NOT_FOUND = handleError(0x887A0002)
+	_DXGI_ERROR_DEVICE_HUNG           = handleError(0x887A0006)
+	_DXGI_ERROR_DEVICE_REMOVED        = handleError(0x887A0005)
+	_DXGI_ERROR_DEVICE_RESET          = handleError(0x887A0007)
+	_DXGI_ERROR_DRIVER_INTERNAL_ERROR = handleError(0x887A0020)
+	_DXGI_ERROR_NOT_FOUND             = handleError(0x887A0002)
 
 	_DXGI_MWA_NO_ALT_ENTER      = 0x2
 	_DXGI_MWA_NO_WINDOW_CHANGES = 0x1
@@ -101,6 +106,7 @@ const (
 var (
 	_IID_IDXGIAdapter1   = windows.GUID{Data1: 0x29038f61, Data2: 0x3839, Data3: 0x4626, Data4: [...]byte{0x91, 0xfd, 0x08, 0x68, 0x79, 0x01, 0x1a, 0x05}}
 	_IID_IDXGIDevice     = windows.GUID{Data1: 0x54ec77fa, Data2: 0x1377, Data3: 0x44e6, Data4: [...]byte{0x8c, 0x32, 0x88, 0xfd, 0x5f, 0x44, 0xc8, 0x4c}}
+	_IID_IDXGIDevice1    = windows.GUID{Data1: 0x77db970f, Data2: 0x6276, Data3: 0x48ba, Data4: [...]byte{0xba, 0x28, 0x07, 0x01, 0x43, 0xb4, 0x39, 0x2c}}
 	_IID_IDXGIFactory    = windows.GUID{Data1: 0x7b7166ec, Data2: 0x21c7, Data3: 0x44ae, Data4: [...]byte{0xb2, 0x1a, 0xc9, 0xae, 0x32, 0x1a, 0xe3, 0x69}}
 	_IID_IDXGIFactory4   = windows.GUID{Data1: 0x1bc6ea02, Data2: 0xef36, Data3: 0x464f, Data4: [...]byte{0xbf, 0x0c, 0x21, 0xca, 0x39, 0xe5, 0x16, 0x8a}}
 	_IID_IDXGIFactory5   = windows.GUID{Data1: 0x7632e1f5, Data2: 0xee65, Data3: 0x4dca, Data4: [...]byte{0x87, 0xfd, 0x84, 0xcd, 0x75, 0xf8, 0x83, 0x8d}}
@@ -286,6 +292,41 @@ func (i *_IDXGIDevice) Release() uint32 {
 	return uint32(r)
 }
 
+type _IDXGIDevice1 struct {
+	vtbl *_IDXGIDevice1_Vtbl
+}
+
+type _IDXGIDevice1_Vtbl struct {
+	QueryInterface uintptr
+	AddRef         uintptr
+	Release        uintptr
+
+	SetPrivateData          uintptr
+	SetPrivateDataInterface uintptr
+	GetPrivateData          uintptr
+	GetParent               uintptr
+	GetAdapter              uintptr
+	CreateSurface           uintptr
+	QueryResourceResidency  uintptr
+	SetGPUThreadPriority    uintptr
+	GetGPUThreadPriority    uintptr
+	SetMaximumFrameLatency  uintptr
+	GetMaximumFrameLatency  uintptr
+}
+
+func (i *_IDXGIDevice1) SetMaximumFrameLatency(maxLatency uint32) error {
+	r, _, _ := syscall.Syscall(i.vtbl.SetMaximumFrameLatency, 2, uintptr(unsafe.Pointer(i)), uintptr(maxLatency), 0)
+	if uint32(r) != uint32(windows.S_OK) {
+		return fmt.Errorf("directx: IDXGIDevice1::SetMaximumFrameLatency failed: %w", handleError(windows.Handle(uint32(r))))
+	}
+	return nil
+}
+
+func (i *_IDXGIDevice1) Release() uint32 {
+	r, _, _ := syscall.Syscall(i.vtbl.Release, 1, uintptr(unsafe.Pointer(i)), 0, 0)
+	return uint32(r)
+}
+
 type _IDXGIFactory struct {
 	vtbl *_IDXGIFactory_Vtbl
 }
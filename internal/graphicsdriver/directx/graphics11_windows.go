@@ -27,10 +27,13 @@ import (
 	"github.com/duplicants-ai/ebiten/internal/shaderir/hlsl"
 )
 
-var inputElementDescsForDX11 []_D3D11_INPUT_ELEMENT_DESC
+var (
+	inputElementDescsForDX11               = newInputElementDescsForDX11(graphics.VertexFloatCount)
+	inputElementDescsWithAttributesForDX11 = newInputElementDescsForDX11(graphics.VertexFloatCountWithAttributes)
+)
 
-func init() {
-	inputElementDescsForDX11 = []_D3D11_INPUT_ELEMENT_DESC{
+func newInputElementDescsForDX11(vertexFloatCount int) []_D3D11_INPUT_ELEMENT_DESC {
+	descs := []_D3D11_INPUT_ELEMENT_DESC{
 		{
 			SemanticName:         &([]byte("POSITION\000"))[0],
 			SemanticIndex:        0,
@@ -59,15 +62,15 @@ func init() {
 			InstanceDataStepRate: 0,
 		},
 	}
-	diff := graphics.VertexFloatCount - 8
+	diff := vertexFloatCount - 8
 	if diff == 0 {
-		return
+		return descs
 	}
 	if diff%4 != 0 {
 		panic("directx: unexpected attribute layout")
 	}
 	for i := 0; i < diff/4; i++ {
-		inputElementDescsForDX11 = append(inputElementDescsForDX11, _D3D11_INPUT_ELEMENT_DESC{
+		descs = append(descs, _D3D11_INPUT_ELEMENT_DESC{
 			SemanticName:         &([]byte("COLOR\000"))[0],
 			SemanticIndex:        uint32(i) + 1,
 			Format:               _DXGI_FORMAT_R32G32B32A32_FLOAT,
@@ -77,6 +80,7 @@ func init() {
 			InstanceDataStepRate: 0,
 		})
 	}
+	return descs
 }
 
 func blendFactorToBlend11(f graphicsdriver.BlendFactor, alpha bool) _D3D11_BLEND {
@@ -142,6 +146,12 @@ type blendStateKey struct {
 	writeMask uint8
 }
 
+type depthStencilStateKey struct {
+	stencilMode stencilMode
+	depthTest   bool
+	depthWrite  bool
+}
+
 type graphics11 struct {
 	graphicsInfra *graphicsInfra
 
@@ -160,33 +170,51 @@ type graphics11 struct {
 	vertexBuffer            *_ID3D11Buffer
 	vertexBufferSizeInBytes uint32
 
+	// vertexBufferStride is the stride in bytes that the vertex buffer is bound with, or 0 if the vertex buffer is not bound yet.
+	vertexBufferStride uint32
+
 	indexBuffer            *_ID3D11Buffer
 	indexBufferSizeInBytes uint32
 
 	rasterizerState    *_ID3D11RasterizerState
 	samplerState       *_ID3D11SamplerState
 	blendStates        map[blendStateKey]*_ID3D11BlendState
-	depthStencilStates map[stencilMode]*_ID3D11DepthStencilState
+	depthStencilStates map[depthStencilStateKey]*_ID3D11DepthStencilState
+
+	vsyncMode graphicsdriver.VsyncMode
+	window    windows.HWND
 
-	vsyncEnabled bool
-	window       windows.HWND
+	latencyMode        graphicsdriver.LatencyMode
+	latencyModeApplied bool
 
 	newScreenWidth  int
 	newScreenHeight int
+
+	useWARP       bool
+	useDebugLayer bool
+	swapEffect    swapEffect
 }
 
-func newGraphics11(useWARP bool, useDebugLayer bool) (gr11 *graphics11, ferr error) {
+func newGraphics11(useWARP bool, useDebugLayer bool, swapEffect swapEffect) (*graphics11, error) {
 	g := &graphics11{
-		vsyncEnabled: true,
+		useWARP:       useWARP,
+		useDebugLayer: useDebugLayer,
+		swapEffect:    swapEffect,
+	}
+	if err := g.initializeDevice(); err != nil {
+		return nil, err
 	}
+	return g, nil
+}
 
+func (g *graphics11) initializeDevice() (ferr error) {
 	driverType := _D3D_DRIVER_TYPE_HARDWARE
-	if useWARP {
+	if g.useWARP {
 		driverType = _D3D_DRIVER_TYPE_WARP
 	}
 
 	var flags _D3D11_CREATE_DEVICE_FLAG
-	if useDebugLayer {
+	if g.useDebugLayer {
 		flags |= _D3D11_CREATE_DEVICE_DEBUG
 	}
 
@@ -202,7 +230,7 @@ func newGraphics11(useWARP bool, useDebugLayer bool) (gr11 *graphics11, ferr err
 	// https://learn.microsoft.com/en-us/windows/win32/api/d3d11/nf-d3d11-d3d11createdevice
 	d, fl, ctx, err := _D3D11CreateDevice(nil, driverType, 0, uint32(flags), featureLevels, true, true)
 	if err != nil {
-		return nil, err
+		return err
 	}
 	g.device = (*_ID3D11Device)(d)
 	g.featureLevel = fl
@@ -212,26 +240,26 @@ func newGraphics11(useWARP bool, useDebugLayer bool) (gr11 *graphics11, ferr err
 	// Or, MakeWindowAssociation doesn't work well (#2661).
 	dd, err := g.device.QueryInterface(&_IID_IDXGIDevice)
 	if err != nil {
-		return nil, err
+		return err
 	}
 	dxgiDevice := (*_IDXGIDevice)(dd)
 	defer dxgiDevice.Release()
 
 	dxgiAdapter, err := dxgiDevice.GetAdapter()
 	if err != nil {
-		return nil, err
+		return err
 	}
 	defer dxgiAdapter.Release()
 
 	df, err := dxgiAdapter.GetParent(&_IID_IDXGIFactory)
 	if err != nil {
-		return nil, err
+		return err
 	}
 	dxgiFactory := (*_IDXGIFactory)(df)
 
-	gi, err := newGraphicsInfra(dxgiFactory)
+	gi, err := newGraphicsInfra(dxgiFactory, g.swapEffect)
 	if err != nil {
-		return nil, err
+		return err
 	}
 	g.graphicsInfra = gi
 	defer func() {
@@ -258,7 +286,7 @@ func newGraphics11(useWARP bool, useDebugLayer bool) (gr11 *graphics11, ferr err
 			AntialiasedLineEnable: 0,
 		})
 		if err != nil {
-			return nil, err
+			return err
 		}
 		g.rasterizerState = rs
 	}
@@ -276,13 +304,73 @@ func newGraphics11(useWARP bool, useDebugLayer bool) (gr11 *graphics11, ferr err
 			MaxLOD:         math.MaxFloat32,
 		})
 		if err != nil {
-			return nil, err
+			return err
 		}
 		g.samplerState = s
 	}
 	g.deviceContext.PSSetSamplers(0, []*_ID3D11SamplerState{g.samplerState})
 
-	return g, nil
+	return nil
+}
+
+// Reset recreates the device after the device is lost, e.g., by a GPU driver update or a GPU hang.
+// The images and the shaders must be recreated by the caller after Reset.
+func (g *graphics11) Reset() error {
+	// The device-dependent objects must be released. Releasing an object of a removed device is fine.
+	if g.vertexBuffer != nil {
+		g.vertexBuffer.Release()
+		g.vertexBuffer = nil
+		g.vertexBufferSizeInBytes = 0
+		g.vertexBufferStride = 0
+	}
+	if g.indexBuffer != nil {
+		g.indexBuffer.Release()
+		g.indexBuffer = nil
+		g.indexBufferSizeInBytes = 0
+	}
+	if g.rasterizerState != nil {
+		g.rasterizerState.Release()
+		g.rasterizerState = nil
+	}
+	if g.samplerState != nil {
+		g.samplerState.Release()
+		g.samplerState = nil
+	}
+	for _, bs := range g.blendStates {
+		bs.Release()
+	}
+	g.blendStates = nil
+	for _, dss := range g.depthStencilStates {
+		dss.Release()
+	}
+	g.depthStencilStates = nil
+
+	// The screen image is recreated with a new swap chain.
+	// Do not dispose the screen image itself since the image's ID is still used until the caller disposes it.
+	if g.screenImage != nil {
+		g.screenImage.disposeBuffers()
+		g.screenImage = nil
+	}
+	g.newScreenWidth = 0
+	g.newScreenHeight = 0
+
+	if g.graphicsInfra != nil {
+		g.graphicsInfra.release()
+		g.graphicsInfra = nil
+	}
+	if g.deviceContext != nil {
+		g.deviceContext.Release()
+		g.deviceContext = nil
+	}
+	if g.device != nil {
+		g.device.Release()
+		g.device = nil
+	}
+
+	// The maximum frame latency is a state of the device.
+	g.latencyModeApplied = false
+
+	return g.initializeDevice()
 }
 
 func (g *graphics11) Initialize() error {
@@ -290,15 +378,39 @@ func (g *graphics11) Initialize() error {
 }
 
 func (g *graphics11) Begin() error {
+	if !g.latencyModeApplied {
+		if err := g.applyLatencyMode(); err != nil {
+			return err
+		}
+		g.latencyModeApplied = true
+	}
 	return nil
 }
 
+// applyLatencyMode sets the maximum number of the frames that can be queued.
+func (g *graphics11) applyLatencyMode() error {
+	dd, err := g.device.QueryInterface(&_IID_IDXGIDevice1)
+	if err != nil {
+		return err
+	}
+	dxgiDevice := (*_IDXGIDevice1)(dd)
+	defer dxgiDevice.Release()
+
+	// 3 is the default value.
+	// https://learn.microsoft.com/en-us/windows/win32/api/dxgi/nf-dxgi-idxgidevice1-setmaximumframelatency
+	latency := uint32(3)
+	if g.latencyMode == graphicsdriver.LatencyModeLow {
+		latency = 1
+	}
+	return dxgiDevice.SetMaximumFrameLatency(latency)
+}
+
 func (g *graphics11) End(present bool) error {
 	if !present {
 		return nil
 	}
 
-	if err := g.graphicsInfra.present(g.vsyncEnabled); err != nil {
+	if err := g.graphicsInfra.present(g.vsyncMode); err != nil {
 		return err
 	}
 
@@ -355,8 +467,8 @@ func (g *graphics11) SetVertices(vertices []float32, indices []uint32) error {
 		}
 		g.vertexBuffer = b
 		g.vertexBufferSizeInBytes = size
-		g.deviceContext.IASetVertexBuffers(0, []*_ID3D11Buffer{g.vertexBuffer},
-			[]uint32{graphics.VertexFloatCount * uint32(unsafe.Sizeof(vertices[0]))}, []uint32{0})
+		// The vertex buffer is bound with the stride for a shader at bindVertexBuffer.
+		g.vertexBufferStride = 0
 	}
 	if size := pow2(uint32(len(indices)) * uint32(unsafe.Sizeof(indices[0]))); g.indexBufferSizeInBytes < size {
 		if g.indexBuffer != nil {
@@ -401,12 +513,20 @@ func (g *graphics11) SetVertices(vertices []float32, indices []uint32) error {
 }
 
 func (g *graphics11) NewImage(width, height int) (graphicsdriver.Image, error) {
+	return g.newImage(width, height, graphicsdriver.PixelFormatRGBA8)
+}
+
+func (g *graphics11) NewFloatImage(width, height int, format graphicsdriver.PixelFormat) (graphicsdriver.Image, error) {
+	return g.newImage(width, height, format)
+}
+
+func (g *graphics11) newImage(width, height int, format graphicsdriver.PixelFormat) (graphicsdriver.Image, error) {
 	t, err := g.device.CreateTexture2D(&_D3D11_TEXTURE2D_DESC{
 		Width:     uint32(graphics.InternalImageSize(width)),
 		Height:    uint32(graphics.InternalImageSize(height)),
 		MipLevels: 1, // 0 doesn't work when shrinking the image.
 		ArraySize: 1,
-		Format:    _DXGI_FORMAT_R8G8B8A8_UNORM,
+		Format:    dxgiFormat(format),
 		SampleDesc: _DXGI_SAMPLE_DESC{
 			Count:   1,
 			Quality: 0,
@@ -426,11 +546,23 @@ func (g *graphics11) NewImage(width, height int) (graphicsdriver.Image, error) {
 		width:    width,
 		height:   height,
 		texture:  t,
+		format:   format,
 	}
 	g.addImage(i)
 	return i, nil
 }
 
+func (g *graphics11) NewDepthImage(width, height int) (graphicsdriver.Image, error) {
+	img, err := g.NewImage(width, height)
+	if err != nil {
+		return nil, err
+	}
+	i := img.(*image11)
+	// The depth buffer is created lazily at the first draw.
+	i.depth = true
+	return i, nil
+}
+
 func (g *graphics11) NewScreenFramebufferImage(width, height int) (graphicsdriver.Image, error) {
 	imageWidth := width
 	imageHeight := height
@@ -482,7 +614,21 @@ func (g *graphics11) removeImage(image *image11) {
 }
 
 func (g *graphics11) SetVsyncEnabled(enabled bool) {
-	g.vsyncEnabled = enabled
+	if enabled {
+		g.vsyncMode = graphicsdriver.VsyncModeOn
+	} else {
+		g.vsyncMode = graphicsdriver.VsyncModeOff
+	}
+}
+
+func (g *graphics11) SetVsyncMode(mode graphicsdriver.VsyncMode) graphicsdriver.VsyncMode {
+	g.vsyncMode = g.graphicsInfra.vsyncModeToUse(mode)
+	return g.vsyncMode
+}
+
+func (g *graphics11) SetLatencyMode(mode graphicsdriver.LatencyMode) {
+	g.latencyMode = mode
+	g.latencyModeApplied = false
 }
 
 func (g *graphics11) NeedsClearingScreen() bool {
@@ -516,11 +662,24 @@ func (g *graphics11) NewShader(program *shaderir.Program) (graphicsdriver.Shader
 		uniformOffsets:   hlsl.UniformVariableOffsetsInDwords(program),
 		vertexShaderBlob: vsh,
 		pixelShaderBlob:  psh,
+		vertexFloatCount: graphics.ShaderVertexFloatCount(program),
+		depthTest:        program.DepthTest,
+		depthWrite:       program.DepthWrite,
 	}
 	g.addShader(s)
 	return s, nil
 }
 
+// bindVertexBuffer binds the vertex buffer with the stride for vertices with vertexFloatCount floats, if needed.
+func (g *graphics11) bindVertexBuffer(vertexFloatCount int) {
+	stride := uint32(vertexFloatCount) * uint32(unsafe.Sizeof(float32(0)))
+	if g.vertexBufferStride == stride {
+		return
+	}
+	g.deviceContext.IASetVertexBuffers(0, []*_ID3D11Buffer{g.vertexBuffer}, []uint32{stride}, []uint32{0})
+	g.vertexBufferStride = stride
+}
+
 func (g *graphics11) addShader(s *shader11) {
 	if g.shaders == nil {
 		g.shaders = map[graphicsdriver.ShaderID]*shader11{}
@@ -536,13 +695,20 @@ func (g *graphics11) removeShader(s *shader11) {
 	delete(g.shaders, s.id)
 }
 
-func (g *graphics11) DrawTriangles(dstID graphicsdriver.ImageID, srcIDs [graphics.ShaderSrcImageCount]graphicsdriver.ImageID, shaderID graphicsdriver.ShaderID, dstRegions []graphicsdriver.DstRegion, indexOffset int, blend graphicsdriver.Blend, uniforms []uint32, fillRule graphicsdriver.FillRule) error {
+func (g *graphics11) DrawTriangles(dstIDs [graphics.ShaderDstImageCount]graphicsdriver.ImageID, srcIDs [graphics.ShaderSrcImageCount]graphicsdriver.ImageID, shaderID graphicsdriver.ShaderID, dstRegions []graphicsdriver.DstRegion, indexOffset int, blend graphicsdriver.Blend, uniforms []uint32, fillRule graphicsdriver.FillRule) error {
 	// Remove bound textures first. This is needed to avoid warnings on the debugger.
 	g.deviceContext.OMSetRenderTargets([]*_ID3D11RenderTargetView{nil}, nil)
 	srvs := [graphics.ShaderSrcImageCount]*_ID3D11ShaderResourceView{}
 	g.deviceContext.PSSetShaderResources(0, srvs[:])
 
-	dst := g.images[dstID]
+	dst := g.images[dstIDs[0]]
+	var extraDsts [graphics.ShaderDstImageCount - 1]*image11
+	for i, id := range dstIDs[1:] {
+		if id == graphicsdriver.InvalidImageID {
+			continue
+		}
+		extraDsts[i] = g.images[id]
+	}
 	var srcs [graphics.ShaderSrcImageCount]*image11
 	for i, id := range srcIDs {
 		img := g.images[id]
@@ -564,7 +730,7 @@ func (g *graphics11) DrawTriangles(dstID graphicsdriver.ImageID, srcIDs [graphic
 		},
 	})
 
-	if err := dst.setAsRenderTarget(fillRule != graphicsdriver.FillRuleFillAll); err != nil {
+	if err := dst.setAsRenderTarget(extraDsts, fillRule != graphicsdriver.FillRuleFillAll); err != nil {
 		return err
 	}
 
@@ -574,6 +740,12 @@ func (g *graphics11) DrawTriangles(dstID graphicsdriver.ImageID, srcIDs [graphic
 		return err
 	}
 
+	var depthTest, depthWrite bool
+	if dst.depth {
+		depthTest = shader.depthTest
+		depthWrite = shader.depthWrite
+	}
+
 	if fillRule == graphicsdriver.FillRuleFillAll {
 		bs, err := g.blendState(blend, noStencil)
 		if err != nil {
@@ -581,7 +753,7 @@ func (g *graphics11) DrawTriangles(dstID graphicsdriver.ImageID, srcIDs [graphic
 		}
 		g.deviceContext.OMSetBlendState(bs, nil, 0xffffffff)
 
-		dss, err := g.depthStencilState(noStencil)
+		dss, err := g.depthStencilState(noStencil, depthTest, depthWrite)
 		if err != nil {
 			return err
 		}
@@ -607,7 +779,7 @@ func (g *graphics11) DrawTriangles(dstID graphicsdriver.ImageID, srcIDs [graphic
 				return err
 			}
 			g.deviceContext.OMSetBlendState(bs, nil, 0xffffffff)
-			dss, err := g.depthStencilState(incrementStencil)
+			dss, err := g.depthStencilState(incrementStencil, depthTest, depthWrite)
 			if err != nil {
 				return err
 			}
@@ -619,7 +791,7 @@ func (g *graphics11) DrawTriangles(dstID graphicsdriver.ImageID, srcIDs [graphic
 				return err
 			}
 			g.deviceContext.OMSetBlendState(bs, nil, 0xffffffff)
-			dss, err := g.depthStencilState(invertStencil)
+			dss, err := g.depthStencilState(invertStencil, depthTest, depthWrite)
 			if err != nil {
 				return err
 			}
@@ -633,7 +805,7 @@ func (g *graphics11) DrawTriangles(dstID graphicsdriver.ImageID, srcIDs [graphic
 				return err
 			}
 			g.deviceContext.OMSetBlendState(bs, nil, 0xffffffff)
-			dss, err := g.depthStencilState(drawWithStencil)
+			dss, err := g.depthStencilState(drawWithStencil, depthTest, depthWrite)
 			if err != nil {
 				return err
 			}
@@ -698,8 +870,13 @@ func (g *graphics11) blendState(blend graphicsdriver.Blend, stencilMode stencilM
 	return bs, nil
 }
 
-func (g *graphics11) depthStencilState(mode stencilMode) (*_ID3D11DepthStencilState, error) {
-	if s, ok := g.depthStencilStates[mode]; ok {
+func (g *graphics11) depthStencilState(mode stencilMode, depthTest, depthWrite bool) (*_ID3D11DepthStencilState, error) {
+	key := depthStencilStateKey{
+		stencilMode: mode,
+		depthTest:   depthTest,
+		depthWrite:  depthWrite,
+	}
+	if s, ok := g.depthStencilStates[key]; ok {
 		return s, nil
 	}
 
@@ -737,6 +914,17 @@ func (g *graphics11) depthStencilState(mode stencilMode) (*_ID3D11DepthStencilSt
 		desc.FrontFace.StencilFunc = _D3D11_COMPARISON_NOT_EQUAL
 		desc.BackFace.StencilFunc = _D3D11_COMPARISON_NOT_EQUAL
 	}
+	if depthTest || depthWrite {
+		desc.DepthEnable = 1
+		if depthTest {
+			desc.DepthFunc = _D3D11_COMPARISON_LESS_EQUAL
+		} else {
+			desc.DepthFunc = _D3D11_COMPARISON_ALWAYS
+		}
+		if !depthWrite {
+			desc.DepthWriteMask = _D3D11_DEPTH_WRITE_MASK_ZERO
+		}
+	}
 
 	s, err := g.device.CreateDepthStencilState(desc)
 	if err != nil {
@@ -744,8 +932,8 @@ func (g *graphics11) depthStencilState(mode stencilMode) (*_ID3D11DepthStencilSt
 	}
 
 	if g.depthStencilStates == nil {
-		g.depthStencilStates = map[stencilMode]*_ID3D11DepthStencilState{}
+		g.depthStencilStates = map[depthStencilStateKey]*_ID3D11DepthStencilState{}
 	}
-	g.depthStencilStates[mode] = s
+	g.depthStencilStates[key] = s
 	return s, nil
 }
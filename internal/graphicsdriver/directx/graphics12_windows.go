@@ -23,6 +23,7 @@ import (
 
 	"github.com/duplicants-ai/ebiten/internal/graphics"
 	"github.com/duplicants-ai/ebiten/internal/graphicsdriver"
+	"github.com/duplicants-ai/ebiten/internal/hook"
 	"github.com/duplicants-ai/ebiten/internal/microsoftgdk"
 	"github.com/duplicants-ai/ebiten/internal/shaderir"
 	"github.com/duplicants-ai/ebiten/internal/shaderir/hlsl"
@@ -48,6 +49,10 @@ type graphics12 struct {
 	renderTargets      [frameCount]*_ID3D12Resource
 	framePipelineToken _D3D12XBOX_FRAME_PIPELINE_TOKEN
 
+	// nullRTVDescriptorHeap is a descriptor heap for a null render target view.
+	// A null render target view is bound to an unused slot between render targets for multiple render targets.
+	nullRTVDescriptorHeap *_ID3D12DescriptorHeap
+
 	fence          *_ID3D12Fence
 	fenceValues    [frameCount]uint64
 	fenceWaitEvent windows.Handle
@@ -94,7 +99,8 @@ type graphics12 struct {
 	nextShaderID    graphicsdriver.ShaderID
 	disposedShaders [frameCount][]*shader12
 
-	vsyncEnabled bool
+	vsyncMode   graphicsdriver.VsyncMode
+	latencyMode graphicsdriver.LatencyMode
 
 	newScreenWidth  int
 	newScreenHeight int
@@ -106,9 +112,14 @@ type graphics12 struct {
 	pipelineStates
 }
 
-func newGraphics12(useWARP bool, useDebugLayer bool, featureLevel _D3D_FEATURE_LEVEL) (*graphics12, error) {
+func newGraphics12(useWARP bool, useDebugLayer bool, featureLevel _D3D_FEATURE_LEVEL, swapEffect swapEffect) (*graphics12, error) {
 	g := &graphics12{}
 
+	// DirectX 12 supports only the flip model.
+	if swapEffect == swapEffectBitBlt {
+		swapEffect = swapEffectDefault
+	}
+
 	// Initialize not only a device but also other members like a fence.
 	// Even if initializing a device succeeds, initializing a fence might fail (#2142).
 	if microsoftgdk.IsXbox() {
@@ -116,7 +127,7 @@ func newGraphics12(useWARP bool, useDebugLayer bool, featureLevel _D3D_FEATURE_L
 			return nil, err
 		}
 	} else {
-		if err := g.initializeDesktop(useWARP, useDebugLayer, featureLevel); err != nil {
+		if err := g.initializeDesktop(useWARP, useDebugLayer, featureLevel, swapEffect); err != nil {
 			return nil, err
 		}
 	}
@@ -124,7 +135,7 @@ func newGraphics12(useWARP bool, useDebugLayer bool, featureLevel _D3D_FEATURE_L
 	return g, nil
 }
 
-func (g *graphics12) initializeDesktop(useWARP bool, useDebugLayer bool, featureLevel _D3D_FEATURE_LEVEL) (ferr error) {
+func (g *graphics12) initializeDesktop(useWARP bool, useDebugLayer bool, featureLevel _D3D_FEATURE_LEVEL, swapEffect swapEffect) (ferr error) {
 	if err := d3d12.Load(); err != nil {
 		return err
 	}
@@ -152,7 +163,7 @@ func (g *graphics12) initializeDesktop(useWARP bool, useDebugLayer bool, feature
 	if err != nil {
 		return err
 	}
-	gi, err := newGraphicsInfra(f)
+	gi, err := newGraphicsInfra(f, swapEffect)
 	if err != nil {
 		return err
 	}
@@ -623,6 +634,14 @@ func (g *graphics12) Begin() error {
 	if microsoftgdk.IsXbox() && !g.frameStarted {
 		select {
 		case <-g.suspendingCh:
+			// Stop the audio during the suspension as the title is not given any CPU time.
+			// The audio might be already suspended for another reason. In this case, leave it as it is.
+			suspendedByPLM := !hook.IsAudioSuspended()
+			if suspendedByPLM {
+				if err := hook.SuspendAudio(); err != nil {
+					return err
+				}
+			}
 			if err := g.commandQueue.SuspendX(0); err != nil {
 				return err
 			}
@@ -634,6 +653,11 @@ func (g *graphics12) Begin() error {
 			if err := g.registerFrameEventForXbox(); err != nil {
 				return err
 			}
+			if suspendedByPLM {
+				if err := hook.ResumeAudio(); err != nil {
+					return err
+				}
+			}
 		default:
 		}
 
@@ -724,6 +748,13 @@ func (g *graphics12) End(present bool) error {
 			return err
 		}
 
+		// In the low latency mode, wait for the GPU so that the next frame doesn't start before this frame is done.
+		if g.latencyMode == graphicsdriver.LatencyModeLow {
+			if err := g.waitForCommandQueue(); err != nil {
+				return err
+			}
+		}
+
 		g.releaseResources(g.frameIndex)
 		g.resetVerticesAndIndices(g.frameIndex, false)
 
@@ -734,7 +765,7 @@ func (g *graphics12) End(present bool) error {
 }
 
 func (g *graphics12) presentDesktop() error {
-	return g.graphicsInfra.present(g.vsyncEnabled)
+	return g.graphicsInfra.present(g.vsyncMode)
 }
 
 func (g *graphics12) presentXbox() error {
@@ -993,6 +1024,17 @@ func (g *graphics12) NewImage(width, height int) (graphicsdriver.Image, error) {
 	return i, nil
 }
 
+func (g *graphics12) NewDepthImage(width, height int) (graphicsdriver.Image, error) {
+	img, err := g.NewImage(width, height)
+	if err != nil {
+		return nil, err
+	}
+	i := img.(*image12)
+	// The depth buffer is created lazily at the first draw.
+	i.depth = true
+	return i, nil
+}
+
 func (g *graphics12) NewScreenFramebufferImage(width, height int) (graphicsdriver.Image, error) {
 	imageWidth := width
 	imageHeight := height
@@ -1051,7 +1093,29 @@ func (g *graphics12) removeShader(s *shader12) {
 }
 
 func (g *graphics12) SetVsyncEnabled(enabled bool) {
-	g.vsyncEnabled = enabled
+	if enabled {
+		g.vsyncMode = graphicsdriver.VsyncModeOn
+	} else {
+		g.vsyncMode = graphicsdriver.VsyncModeOff
+	}
+}
+
+func (g *graphics12) SetVsyncMode(mode graphicsdriver.VsyncMode) graphicsdriver.VsyncMode {
+	if g.graphicsInfra == nil {
+		// Xbox presents frames in its own way.
+		if mode == graphicsdriver.VsyncModeOff {
+			g.vsyncMode = graphicsdriver.VsyncModeOff
+		} else {
+			g.vsyncMode = graphicsdriver.VsyncModeOn
+		}
+		return g.vsyncMode
+	}
+	g.vsyncMode = g.graphicsInfra.vsyncModeToUse(mode)
+	return g.vsyncMode
+}
+
+func (g *graphics12) SetLatencyMode(mode graphicsdriver.LatencyMode) {
+	g.latencyMode = mode
 }
 
 func (g *graphics12) NeedsClearingScreen() bool {
@@ -1070,18 +1134,21 @@ func (g *graphics12) NewShader(program *shaderir.Program) (graphicsdriver.Shader
 	}
 
 	s := &shader12{
-		graphics:       g,
-		id:             g.genNextShaderID(),
-		uniformTypes:   program.Uniforms,
-		uniformOffsets: hlsl.UniformVariableOffsetsInDwords(program),
-		vertexShader:   vsh,
-		pixelShader:    psh,
+		graphics:         g,
+		id:               g.genNextShaderID(),
+		uniformTypes:     program.Uniforms,
+		uniformOffsets:   hlsl.UniformVariableOffsetsInDwords(program),
+		vertexShader:     vsh,
+		pixelShader:      psh,
+		vertexFloatCount: graphics.ShaderVertexFloatCount(program),
+		depthTest:        program.DepthTest,
+		depthWrite:       program.DepthWrite,
 	}
 	g.addShader(s)
 	return s, nil
 }
 
-func (g *graphics12) DrawTriangles(dstID graphicsdriver.ImageID, srcs [graphics.ShaderSrcImageCount]graphicsdriver.ImageID, shaderID graphicsdriver.ShaderID, dstRegions []graphicsdriver.DstRegion, indexOffset int, blend graphicsdriver.Blend, uniforms []uint32, fillRule graphicsdriver.FillRule) error {
+func (g *graphics12) DrawTriangles(dstIDs [graphics.ShaderDstImageCount]graphicsdriver.ImageID, srcs [graphics.ShaderSrcImageCount]graphicsdriver.ImageID, shaderID graphicsdriver.ShaderID, dstRegions []graphicsdriver.DstRegion, indexOffset int, blend graphicsdriver.Blend, uniforms []uint32, fillRule graphicsdriver.FillRule) error {
 	if shaderID == graphicsdriver.InvalidShaderID {
 		return fmt.Errorf("directx: shader ID is invalid")
 	}
@@ -1102,12 +1169,24 @@ func (g *graphics12) DrawTriangles(dstID graphicsdriver.ImageID, srcs [graphics.
 		g.pipelineStates.releaseConstantBuffers(g.frameIndex)
 	}
 
-	dst := g.images[dstID]
+	dst := g.images[dstIDs[0]]
 	var resourceBarriers []_D3D12_RESOURCE_BARRIER_Transition
 	if rb, ok := dst.transiteState(_D3D12_RESOURCE_STATE_RENDER_TARGET); ok {
 		resourceBarriers = append(resourceBarriers, rb)
 	}
 
+	var extraDsts [graphics.ShaderDstImageCount - 1]*image12
+	for i, id := range dstIDs[1:] {
+		if id == graphicsdriver.InvalidImageID {
+			continue
+		}
+		extraDst := g.images[id]
+		extraDsts[i] = extraDst
+		if rb, ok := extraDst.transiteState(_D3D12_RESOURCE_STATE_RENDER_TARGET); ok {
+			resourceBarriers = append(resourceBarriers, rb)
+		}
+	}
+
 	var srcImages [graphics.ShaderSrcImageCount]*image12
 	for i, srcID := range srcs {
 		src := g.images[srcID]
@@ -1124,13 +1203,22 @@ func (g *graphics12) DrawTriangles(dstID graphicsdriver.ImageID, srcs [graphics.
 		g.drawCommandList.ResourceBarrier(resourceBarriers)
 	}
 
-	if err := dst.setAsRenderTarget(g.drawCommandList, g.device, fillRule != graphicsdriver.FillRuleFillAll); err != nil {
+	if err := dst.setAsRenderTarget(g.drawCommandList, g.device, extraDsts, fillRule != graphicsdriver.FillRuleFillAll); err != nil {
 		return err
 	}
 
 	shader := g.shaders[shaderID]
 	adjustedUniforms := adjustUniforms(shader.uniformTypes, shader.uniformOffsets, uniforms)
 
+	var depth depthUsage
+	if dst.depth {
+		depth = depthUsage{
+			buffer: true,
+			test:   shader.depthTest,
+			write:  shader.depthWrite,
+		}
+	}
+
 	w, h := dst.internalSize()
 	g.needFlushDrawCommandList = true
 	g.drawCommandList.RSSetViewports([]_D3D12_VIEWPORT{
@@ -1148,7 +1236,7 @@ func (g *graphics12) DrawTriangles(dstID graphicsdriver.ImageID, srcs [graphics.
 		{
 			BufferLocation: g.vertices[g.frameIndex][len(g.vertices[g.frameIndex])-1].value.GetGPUVirtualAddress(),
 			SizeInBytes:    g.vertices[g.frameIndex][len(g.vertices[g.frameIndex])-1].sizeInBytes,
-			StrideInBytes:  graphics.VertexFloatCount * uint32(unsafe.Sizeof(float32(0))),
+			StrideInBytes:  uint32(shader.vertexFloatCount) * uint32(unsafe.Sizeof(float32(0))),
 		},
 	})
 	g.drawCommandList.IASetIndexBuffer(&_D3D12_INDEX_BUFFER_VIEW{
@@ -1157,13 +1245,41 @@ func (g *graphics12) DrawTriangles(dstID graphicsdriver.ImageID, srcs [graphics.
 		Format:         _DXGI_FORMAT_R32_UINT,
 	})
 
-	if err := g.pipelineStates.drawTriangles(g.device, g.drawCommandList, g.frameIndex, dst.screen, srcImages, shader, dstRegions, adjustedUniforms, blend, indexOffset, fillRule); err != nil {
+	if err := g.pipelineStates.drawTriangles(g.device, g.drawCommandList, g.frameIndex, dst.screen, extraRenderTargetCount(extraDsts), depth, srcImages, shader, dstRegions, adjustedUniforms, blend, indexOffset, fillRule); err != nil {
 		return err
 	}
 
 	return nil
 }
 
+// nullRenderTargetView returns a null render target view. Writing to a null render target view is discarded.
+func (g *graphics12) nullRenderTargetView() (_D3D12_CPU_DESCRIPTOR_HANDLE, error) {
+	if g.nullRTVDescriptorHeap == nil {
+		h, err := g.device.CreateDescriptorHeap(&_D3D12_DESCRIPTOR_HEAP_DESC{
+			Type:           _D3D12_DESCRIPTOR_HEAP_TYPE_RTV,
+			NumDescriptors: 1,
+			Flags:          _D3D12_DESCRIPTOR_HEAP_FLAG_NONE,
+			NodeMask:       0,
+		})
+		if err != nil {
+			return _D3D12_CPU_DESCRIPTOR_HANDLE{}, err
+		}
+		rtv, err := h.GetCPUDescriptorHandleForHeapStart()
+		if err != nil {
+			h.Release()
+			return _D3D12_CPU_DESCRIPTOR_HANDLE{}, err
+		}
+		// A null resource with a description creates a null descriptor.
+		// The format must match with the pipeline state's format for the slot.
+		g.device.CreateRenderTargetView(nil, &_D3D12_RENDER_TARGET_VIEW_DESC{
+			Format:        _DXGI_FORMAT_R8G8B8A8_UNORM,
+			ViewDimension: _D3D12_RTV_DIMENSION_TEXTURE2D,
+		}, rtv)
+		g.nullRTVDescriptorHeap = h
+	}
+	return g.nullRTVDescriptorHeap.GetCPUDescriptorHandleForHeapStart()
+}
+
 func (g *graphics12) genNextImageID() graphicsdriver.ImageID {
 	g.nextImageID++
 	return g.nextImageID
@@ -72,6 +72,29 @@ func parseFeatureLevel(str string) (_D3D_FEATURE_LEVEL, bool) {
 	}
 }
 
+// swapEffect represents a swap effect of a swap chain specified by the user.
+type swapEffect int
+
+const (
+	swapEffectDefault swapEffect = iota
+	swapEffectFlipSequential
+	swapEffectFlipDiscard
+	swapEffectBitBlt
+)
+
+func parseSwapEffect(str string) (swapEffect, bool) {
+	switch str {
+	case "flipsequential":
+		return swapEffectFlipSequential, true
+	case "flipdiscard":
+		return swapEffectFlipDiscard, true
+	case "bitblt":
+		return swapEffectBitBlt, true
+	default:
+		return 0, false
+	}
+}
+
 // NewGraphics creates an implementation of graphicsdriver.Graphics for DirectX.
 // The returned graphics value is nil iff the error is not nil.
 func NewGraphics() (graphicsdriver.Graphics, error) {
@@ -81,6 +104,7 @@ func NewGraphics() (graphicsdriver.Graphics, error) {
 
 	var useWARP bool
 	var useDebugLayer bool
+	var swapEffect swapEffect
 	version := 11
 
 	// Specify the feature level 11 by default.
@@ -120,6 +144,12 @@ func NewGraphics() (graphicsdriver.Graphics, error) {
 				continue
 			}
 			featureLevel = fl
+		case strings.HasPrefix(t, "swapeffect="):
+			se, ok := parseSwapEffect(t[len("swapeffect="):])
+			if !ok {
+				continue
+			}
+			swapEffect = se
 		}
 	}
 
@@ -130,13 +160,13 @@ func NewGraphics() (graphicsdriver.Graphics, error) {
 
 	switch version {
 	case 11:
-		g, err := newGraphics11(useWARP, useDebugLayer)
+		g, err := newGraphics11(useWARP, useDebugLayer, swapEffect)
 		if err != nil {
 			return nil, err
 		}
 		return g, nil
 	case 12:
-		g, err := newGraphics12(useWARP, useDebugLayer, featureLevel)
+		g, err := newGraphics12(useWARP, useDebugLayer, featureLevel, swapEffect)
 		if err != nil {
 			return nil, err
 		}
@@ -152,6 +182,7 @@ type graphicsInfra struct {
 	swapChain4 *_IDXGISwapChain4
 
 	allowTearing bool
+	swapEffect   swapEffect
 
 	// occluded reports whether the screen is invisible or not.
 	occluded bool
@@ -163,9 +194,10 @@ type graphicsInfra struct {
 }
 
 // newGraphicsInfra takes the ownership of the given factory.
-func newGraphicsInfra(factory *_IDXGIFactory) (*graphicsInfra, error) {
+func newGraphicsInfra(factory *_IDXGIFactory, swapEffect swapEffect) (*graphicsInfra, error) {
 	g := &graphicsInfra{
-		factory: factory,
+		factory:    factory,
+		swapEffect: swapEffect,
 	}
 	runtime.SetFinalizer(g, (*graphicsInfra).release)
 
@@ -268,17 +300,16 @@ func (g *graphicsInfra) initSwapChain(width, height int, device unsafe.Pointer,
 		BufferCount:  frameCount,
 		OutputWindow: window,
 		Windowed:     1,
-		SwapEffect:   _DXGI_SWAP_EFFECT_FLIP_SEQUENTIAL,
 	}
 
-	// DXGI_SWAP_EFFECT_FLIP_SEQUENTIAL/DISCARD are not supported for older Windows than 10 or DirectX 12.
-	// https://learn.microsoft.com/en-us/windows/win32/api/dxgi/ne-dxgi-dxgi_swap_effect
-	if !winver.IsWindows10OrGreater() {
-		desc.SwapEffect = _DXGI_SWAP_EFFECT_SEQUENTIAL
+	desc.SwapEffect = g.dxgiSwapEffect()
+	if desc.SwapEffect == _DXGI_SWAP_EFFECT_SEQUENTIAL {
 		// With the non-flip (bitblt) mode, the buffer count should be 1. See also:
 		// * https://bugzilla.mozilla.org/show_bug.cgi?id=1419293#c18
 		// * https://learn.microsoft.com/en-us/windows/win32/direct3ddxgi/dxgi-flip-model
 		desc.BufferCount = 1
+		// Tearing is available only with the flip model.
+		g.allowTearing = false
 	}
 
 	g.bufferCount = int(desc.BufferCount)
@@ -310,6 +341,45 @@ func (g *graphicsInfra) initSwapChain(width, height int, device unsafe.Pointer,
 	return nil
 }
 
+func (g *graphicsInfra) dxgiSwapEffect() _DXGI_SWAP_EFFECT {
+	// DXGI_SWAP_EFFECT_FLIP_SEQUENTIAL/DISCARD are not supported for older Windows than 10 or DirectX 12.
+	// https://learn.microsoft.com/en-us/windows/win32/api/dxgi/ne-dxgi-dxgi_swap_effect
+	if !winver.IsWindows10OrGreater() {
+		return _DXGI_SWAP_EFFECT_SEQUENTIAL
+	}
+
+	// The flip model is friendly to screen capturing and overlay software, but some of them might work
+	// better with a specific swap effect. The swap effect can be specified by the environment variable.
+	switch g.swapEffect {
+	case swapEffectFlipDiscard:
+		return _DXGI_SWAP_EFFECT_FLIP_DISCARD
+	case swapEffectBitBlt:
+		return _DXGI_SWAP_EFFECT_SEQUENTIAL
+	}
+	return _DXGI_SWAP_EFFECT_FLIP_SEQUENTIAL
+}
+
+// isFlipModel reports whether the swap chain uses the flip model.
+func (g *graphicsInfra) isFlipModel() bool {
+	return g.dxgiSwapEffect() != _DXGI_SWAP_EFFECT_SEQUENTIAL
+}
+
+// vsyncModeToUse returns the vsync mode that present actually uses for the given mode.
+func (g *graphicsInfra) vsyncModeToUse(mode graphicsdriver.VsyncMode) graphicsdriver.VsyncMode {
+	switch mode {
+	case graphicsdriver.VsyncModeMailbox:
+		// With the flip model, presenting without vsync in a window doesn't tear,
+		// and the compositor shows the latest frame.
+		if g.isFlipModel() {
+			return graphicsdriver.VsyncModeMailbox
+		}
+		return graphicsdriver.VsyncModeOn
+	case graphicsdriver.VsyncModeAdaptive:
+		return graphicsdriver.VsyncModeOn
+	}
+	return mode
+}
+
 func (g *graphicsInfra) resizeSwapChain(width, height int) error {
 	if g.swapChain == nil {
 		return fmt.Errorf("directx: swap chain must be initialized at resizeSwapChain, but is not")
@@ -332,7 +402,7 @@ func (g *graphicsInfra) currentBackBufferIndex() (int, error) {
 	return int(g.swapChain4.GetCurrentBackBufferIndex()), nil
 }
 
-func (g *graphicsInfra) present(vsyncEnabled bool) error {
+func (g *graphicsInfra) present(vsyncMode graphicsdriver.VsyncMode) error {
 	if g.swapChain == nil {
 		return fmt.Errorf("directx: swap chain must be initialized at present, but is not")
 	}
@@ -344,10 +414,15 @@ func (g *graphicsInfra) present(vsyncEnabled bool) error {
 		flags |= _DXGI_PRESENT_TEST
 	} else {
 		// Do actual rendering only when the screen is visible.
-		if vsyncEnabled {
+		switch vsyncMode {
+		case graphicsdriver.VsyncModeOn:
 			syncInterval = 1
-		} else if g.allowTearing {
-			flags |= _DXGI_PRESENT_ALLOW_TEARING
+		case graphicsdriver.VsyncModeOff:
+			if g.allowTearing {
+				flags |= _DXGI_PRESENT_ALLOW_TEARING
+			}
+		case graphicsdriver.VsyncModeMailbox:
+			// Present without waiting for vsync nor tearing.
 		}
 	}
 
@@ -35,6 +35,33 @@ type image11 struct {
 	renderTargetView   *_ID3D11RenderTargetView
 	stencilView        *_ID3D11DepthStencilView
 	shaderResourceView *_ID3D11ShaderResourceView
+
+	// depth reports whether the image has a depth buffer.
+	// For an image with a depth buffer, stencil is also used as the depth buffer, and is always bound.
+	depth bool
+
+	// format is the pixel format of the texture.
+	format graphicsdriver.PixelFormat
+}
+
+func dxgiFormat(format graphicsdriver.PixelFormat) _DXGI_FORMAT {
+	switch format {
+	case graphicsdriver.PixelFormatRGBA16F:
+		return _DXGI_FORMAT_R16G16B16A16_FLOAT
+	case graphicsdriver.PixelFormatRGBA32F:
+		return _DXGI_FORMAT_R32G32B32A32_FLOAT
+	}
+	return _DXGI_FORMAT_R8G8B8A8_UNORM
+}
+
+func bytesPerPixel(format graphicsdriver.PixelFormat) int {
+	switch format {
+	case graphicsdriver.PixelFormatRGBA16F:
+		return 8
+	case graphicsdriver.PixelFormatRGBA32F:
+		return 16
+	}
+	return 4
 }
 
 func (i *image11) internalSize() (int, int) {
@@ -77,6 +104,62 @@ func (i *image11) disposeBuffers() {
 }
 
 func (i *image11) ReadPixels(args []graphicsdriver.PixelsArgs) error {
+	if i.format == graphicsdriver.PixelFormatRGBA8 {
+		dsts := make([][]byte, len(args))
+		for idx, a := range args {
+			dsts[idx] = a.Pixels
+		}
+		return i.readRawPixels(args, dsts)
+	}
+
+	// Read the float values and quantize them.
+	fargs := make([]graphicsdriver.PixelsFloat32Args, len(args))
+	for idx, a := range args {
+		fargs[idx] = graphicsdriver.PixelsFloat32Args{
+			Pixels: make([]float32, len(a.Pixels)),
+			Region: a.Region,
+		}
+	}
+	if err := i.ReadPixelsFloat32(fargs); err != nil {
+		return err
+	}
+	for idx, a := range args {
+		graphics.Float32sToBytes(a.Pixels, fargs[idx].Pixels)
+	}
+	return nil
+}
+
+func (i *image11) ReadPixelsFloat32(args []graphicsdriver.PixelsFloat32Args) error {
+	bargs := make([]graphicsdriver.PixelsArgs, len(args))
+	dsts := make([][]byte, len(args))
+	for idx, a := range args {
+		bargs[idx].Region = a.Region
+		if i.format == graphicsdriver.PixelFormatRGBA32F {
+			dsts[idx] = unsafe.Slice((*byte)(unsafe.Pointer(&a.Pixels[0])), 4*len(a.Pixels))
+			continue
+		}
+		dsts[idx] = make([]byte, bytesPerPixel(i.format)*a.Region.Dx()*a.Region.Dy())
+	}
+	if err := i.readRawPixels(bargs, dsts); err != nil {
+		return err
+	}
+
+	for idx, a := range args {
+		switch i.format {
+		case graphicsdriver.PixelFormatRGBA8:
+			graphics.BytesToFloat32s(a.Pixels, dsts[idx])
+		case graphicsdriver.PixelFormatRGBA16F:
+			halves := unsafe.Slice((*uint16)(unsafe.Pointer(&dsts[idx][0])), len(a.Pixels))
+			for j, h := range halves {
+				a.Pixels[j] = graphics.Float16ToFloat32(h)
+			}
+		}
+	}
+	return nil
+}
+
+// readRawPixels reads the pixels in the texture's format at the regions of args into dsts.
+func (i *image11) readRawPixels(args []graphicsdriver.PixelsArgs, dsts [][]byte) error {
 	var unionRegion image.Rectangle
 	for _, a := range args {
 		unionRegion = unionRegion.Union(a.Region)
@@ -87,7 +170,7 @@ func (i *image11) ReadPixels(args []graphicsdriver.PixelsArgs) error {
 		Height:    uint32(unionRegion.Dy()),
 		MipLevels: 0,
 		ArraySize: 1,
-		Format:    _DXGI_FORMAT_R8G8B8A8_UNORM,
+		Format:    dxgiFormat(i.format),
 		SampleDesc: _DXGI_SAMPLE_DESC{
 			Count:   1,
 			Quality: 0,
@@ -116,17 +199,19 @@ func (i *image11) ReadPixels(args []graphicsdriver.PixelsArgs) error {
 		return err
 	}
 
+	bpp := bytesPerPixel(i.format)
 	stride := int(mapped.RowPitch)
 	srcPix := unsafe.Slice((*byte)(mapped.pData), stride*unionRegion.Dy())
-	for _, a := range args {
+	for idx, a := range args {
+		dst := dsts[idx]
 		w := a.Region.Dx()
-		if unionRegion == a.Region && stride == 4*w {
-			copy(a.Pixels, srcPix)
+		if unionRegion == a.Region && stride == bpp*w {
+			copy(dst, srcPix)
 			continue
 		}
-		offset := 4*(a.Region.Min.X-unionRegion.Min.X) + stride*(a.Region.Min.Y-unionRegion.Min.Y)
+		offset := bpp*(a.Region.Min.X-unionRegion.Min.X) + stride*(a.Region.Min.Y-unionRegion.Min.Y)
 		for j := 0; j < a.Region.Dy(); j++ {
-			copy(a.Pixels[j*4*w:(j+1)*4*w], srcPix[offset+j*stride:])
+			copy(dst[j*bpp*w:(j+1)*bpp*w], srcPix[offset+j*stride:])
 		}
 	}
 
@@ -137,29 +222,89 @@ func (i *image11) ReadPixels(args []graphicsdriver.PixelsArgs) error {
 
 func (i *image11) WritePixels(args []graphicsdriver.PixelsArgs) error {
 	for _, a := range args {
-		i.graphics.deviceContext.UpdateSubresource(unsafe.Pointer(i.texture), 0, &_D3D11_BOX{
-			left:   uint32(a.Region.Min.X),
-			top:    uint32(a.Region.Min.Y),
-			front:  0,
-			right:  uint32(a.Region.Max.X),
-			bottom: uint32(a.Region.Max.Y),
-			back:   1,
-		}, unsafe.Pointer(&a.Pixels[0]), uint32(4*a.Region.Dx()), 0)
+		if i.format != graphicsdriver.PixelFormatRGBA8 {
+			pix := make([]float32, len(a.Pixels))
+			graphics.BytesToFloat32s(pix, a.Pixels)
+			i.writeRawPixels(a.Region, i.float32sToRawPixels(pix))
+			continue
+		}
+		i.writeRawPixels(a.Region, a.Pixels)
+	}
+	return nil
+}
+
+func (i *image11) WritePixelsFloat32(args []graphicsdriver.PixelsFloat32Args) error {
+	for _, a := range args {
+		if i.format == graphicsdriver.PixelFormatRGBA8 {
+			pix := make([]byte, len(a.Pixels))
+			graphics.Float32sToBytes(pix, a.Pixels)
+			i.writeRawPixels(a.Region, pix)
+			continue
+		}
+		i.writeRawPixels(a.Region, i.float32sToRawPixels(a.Pixels))
 	}
 	return nil
 }
 
-func (i *image11) setAsRenderTarget(useStencil bool) error {
-	if i.renderTargetView == nil {
-		rtv, err := i.graphics.device.CreateRenderTargetView(unsafe.Pointer(i.texture), nil)
-		if err != nil {
+// float32sToRawPixels converts float32 values to the pixels in the texture's floating-point format.
+func (i *image11) float32sToRawPixels(pix []float32) []byte {
+	if i.format == graphicsdriver.PixelFormatRGBA32F {
+		return unsafe.Slice((*byte)(unsafe.Pointer(&pix[0])), 4*len(pix))
+	}
+	halves := make([]uint16, len(pix))
+	for j, v := range pix {
+		halves[j] = graphics.Float32ToFloat16(v)
+	}
+	return unsafe.Slice((*byte)(unsafe.Pointer(&halves[0])), 2*len(halves))
+}
+
+// writeRawPixels writes the pixels in the texture's format at the region.
+func (i *image11) writeRawPixels(region image.Rectangle, pix []byte) {
+	i.graphics.deviceContext.UpdateSubresource(unsafe.Pointer(i.texture), 0, &_D3D11_BOX{
+		left:   uint32(region.Min.X),
+		top:    uint32(region.Min.Y),
+		front:  0,
+		right:  uint32(region.Max.X),
+		bottom: uint32(region.Max.Y),
+		back:   1,
+	}, unsafe.Pointer(&pix[0]), uint32(bytesPerPixel(i.format)*region.Dx()), 0)
+}
+
+func (i *image11) ensureRenderTargetView() error {
+	if i.renderTargetView != nil {
+		return nil
+	}
+	rtv, err := i.graphics.device.CreateRenderTargetView(unsafe.Pointer(i.texture), nil)
+	if err != nil {
+		return err
+	}
+	i.renderTargetView = rtv
+	return nil
+}
+
+// setAsRenderTarget sets the image as the first render target.
+// extraDsts are set as the second and later render targets. A nil image in extraDsts is bound as a nil render target.
+func (i *image11) setAsRenderTarget(extraDsts [graphics.ShaderDstImageCount - 1]*image11, useStencil bool) error {
+	if err := i.ensureRenderTargetView(); err != nil {
+		return err
+	}
+
+	rtvs := []*_ID3D11RenderTargetView{i.renderTargetView}
+	for k, extraDst := range extraDsts {
+		if extraDst == nil {
+			continue
+		}
+		if err := extraDst.ensureRenderTargetView(); err != nil {
 			return err
 		}
-		i.renderTargetView = rtv
+		for len(rtvs) < k+2 {
+			rtvs = append(rtvs, nil)
+		}
+		rtvs[k+1] = extraDst.renderTargetView
 	}
 
-	if !useStencil {
-		i.graphics.deviceContext.OMSetRenderTargets([]*_ID3D11RenderTargetView{i.renderTargetView}, nil)
+	if !useStencil && !i.depth {
+		i.graphics.deviceContext.OMSetRenderTargets(rtvs, nil)
 		return nil
 	}
 
@@ -196,10 +341,16 @@ func (i *image11) setAsRenderTarget(useStencil bool) error {
 			return err
 		}
 		i.stencilView = sv
+		if i.depth {
+			// Clear the depth buffer with the farthest value.
+			i.graphics.deviceContext.ClearDepthStencilView(i.stencilView, uint8(_D3D11_CLEAR_DEPTH), 1, 0)
+		}
 	}
 
-	i.graphics.deviceContext.OMSetRenderTargets([]*_ID3D11RenderTargetView{i.renderTargetView}, i.stencilView)
-	i.graphics.deviceContext.ClearDepthStencilView(i.stencilView, uint8(_D3D11_CLEAR_STENCIL), 0, 0)
+	i.graphics.deviceContext.OMSetRenderTargets(rtvs, i.stencilView)
+	if useStencil {
+		i.graphics.deviceContext.ClearDepthStencilView(i.stencilView, uint8(_D3D11_CLEAR_STENCIL), 0, 0)
+	}
 
 	return nil
 }
@@ -37,6 +37,13 @@ type image12 struct {
 	rtvDescriptorHeap *_ID3D12DescriptorHeap
 	dsvDescriptorHeap *_ID3D12DescriptorHeap
 
+	// depth reports whether the image has a depth buffer.
+	// For an image with a depth buffer, stencil is also used as the depth buffer, and is always bound.
+	depth bool
+
+	// depthInitialized reports whether the depth buffer has been cleared.
+	depthInitialized bool
+
 	uploadingStagingBuffers []*_ID3D12Resource
 }
 
@@ -288,7 +295,10 @@ func (i *image12) internalSize() (int, int) {
 	return graphics.InternalImageSize(i.width), graphics.InternalImageSize(i.height)
 }
 
-func (i *image12) setAsRenderTarget(drawCommandList *_ID3D12GraphicsCommandList, device *_ID3D12Device, useStencil bool) error {
+// setAsRenderTarget sets the image as the first render target.
+// extraDsts are set as the second and later render targets. A nil image in extraDsts is bound as a null descriptor.
+// The images in extraDsts must not be the screen.
+func (i *image12) setAsRenderTarget(drawCommandList *_ID3D12GraphicsCommandList, device *_ID3D12Device, extraDsts [graphics.ShaderDstImageCount - 1]*image12, useStencil bool) error {
 	if err := i.ensureRenderTargetView(device); err != nil {
 		return err
 	}
@@ -311,8 +321,30 @@ func (i *image12) setAsRenderTarget(drawCommandList *_ID3D12GraphicsCommandList,
 		return err
 	}
 
-	if !useStencil {
-		drawCommandList.OMSetRenderTargets([]_D3D12_CPU_DESCRIPTOR_HANDLE{rtv}, false, nil)
+	rtvs := []_D3D12_CPU_DESCRIPTOR_HANDLE{rtv}
+	if n := extraRenderTargetCount(extraDsts); n > 0 {
+		nullRTV, err := i.graphics.nullRenderTargetView()
+		if err != nil {
+			return err
+		}
+		for _, extraDst := range extraDsts[:n] {
+			if extraDst == nil {
+				rtvs = append(rtvs, nullRTV)
+				continue
+			}
+			if err := extraDst.ensureRenderTargetView(device); err != nil {
+				return err
+			}
+			rtv, err := extraDst.rtvDescriptorHeap.GetCPUDescriptorHandleForHeapStart()
+			if err != nil {
+				return err
+			}
+			rtvs = append(rtvs, rtv)
+		}
+	}
+
+	if !useStencil && !i.depth {
+		drawCommandList.OMSetRenderTargets(rtvs, false, nil)
 		return nil
 	}
 
@@ -324,12 +356,29 @@ func (i *image12) setAsRenderTarget(drawCommandList *_ID3D12GraphicsCommandList,
 		return err
 	}
 	drawCommandList.OMSetStencilRef(0)
-	drawCommandList.OMSetRenderTargets([]_D3D12_CPU_DESCRIPTOR_HANDLE{rtv}, false, &dsv)
-	drawCommandList.ClearDepthStencilView(dsv, _D3D12_CLEAR_FLAG_STENCIL, 0, 0, nil)
+	drawCommandList.OMSetRenderTargets(rtvs, false, &dsv)
+	if useStencil {
+		drawCommandList.ClearDepthStencilView(dsv, _D3D12_CLEAR_FLAG_STENCIL, 0, 0, nil)
+	}
+	if i.depth && !i.depthInitialized {
+		// Clear the depth buffer with the farthest value.
+		drawCommandList.ClearDepthStencilView(dsv, _D3D12_CLEAR_FLAG_DEPTH, 1, 0, nil)
+		i.depthInitialized = true
+	}
 
 	return nil
 }
 
+// extraRenderTargetCount returns the number of the render targets after the first one, including null render targets.
+func extraRenderTargetCount(extraDsts [graphics.ShaderDstImageCount - 1]*image12) int {
+	for i := len(extraDsts) - 1; i >= 0; i-- {
+		if extraDsts[i] != nil {
+			return i + 1
+		}
+	}
+	return 0
+}
+
 func (i *image12) ensureRenderTargetView(device *_ID3D12Device) error {
 	if i.screen {
 		return nil
@@ -384,6 +433,13 @@ func (i *image12) ensureDepthStencilView(device *_ID3D12Device) error {
 		return err
 	}
 	if i.stencil == nil {
+		clearValue := _D3D12_CLEAR_VALUE{
+			Format: _DXGI_FORMAT_D24_UNORM_S8_UINT,
+		}
+		if i.depth {
+			// The first member of the union is D3D12_DEPTH_STENCIL_VALUE, whose first member is the depth.
+			clearValue.Color[0] = 1
+		}
 		s, err := device.CreateCommittedResource(&_D3D12_HEAP_PROPERTIES{
 			Type:                 _D3D12_HEAP_TYPE_DEFAULT,
 			CPUPageProperty:      _D3D12_CPU_PAGE_PROPERTY_UNKNOWN,
@@ -404,9 +460,7 @@ func (i *image12) ensureDepthStencilView(device *_ID3D12Device) error {
 			},
 			Layout: _D3D12_TEXTURE_LAYOUT_UNKNOWN,
 			Flags:  _D3D12_RESOURCE_FLAG_ALLOW_DEPTH_STENCIL,
-		}, _D3D12_RESOURCE_STATE_DEPTH_WRITE, &_D3D12_CLEAR_VALUE{
-			Format: _DXGI_FORMAT_D24_UNORM_S8_UINT,
-		})
+		}, _D3D12_RESOURCE_STATE_DEPTH_WRITE, &clearValue)
 		if err != nil {
 			return err
 		}
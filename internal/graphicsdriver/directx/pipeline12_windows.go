@@ -23,10 +23,13 @@ import (
 	"github.com/duplicants-ai/ebiten/internal/graphicsdriver"
 )
 
-var inputElementDescsForDX12 []_D3D12_INPUT_ELEMENT_DESC
+var (
+	inputElementDescsForDX12               = newInputElementDescsForDX12(graphics.VertexFloatCount)
+	inputElementDescsWithAttributesForDX12 = newInputElementDescsForDX12(graphics.VertexFloatCountWithAttributes)
+)
 
-func init() {
-	inputElementDescsForDX12 = []_D3D12_INPUT_ELEMENT_DESC{
+func newInputElementDescsForDX12(vertexFloatCount int) []_D3D12_INPUT_ELEMENT_DESC {
+	descs := []_D3D12_INPUT_ELEMENT_DESC{
 		{
 			SemanticName:         &([]byte("POSITION\000"))[0],
 			SemanticIndex:        0,
@@ -55,15 +58,15 @@ func init() {
 			InstanceDataStepRate: 0,
 		},
 	}
-	diff := graphics.VertexFloatCount - 8
+	diff := vertexFloatCount - 8
 	if diff == 0 {
-		return
+		return descs
 	}
 	if diff%4 != 0 {
 		panic("directx: unexpected attribute layout")
 	}
 	for i := 0; i < diff/4; i++ {
-		inputElementDescsForDX12 = append(inputElementDescsForDX12, _D3D12_INPUT_ELEMENT_DESC{
+		descs = append(descs, _D3D12_INPUT_ELEMENT_DESC{
 			SemanticName:         &([]byte("COLOR\000"))[0],
 			SemanticIndex:        uint32(i) + 1,
 			Format:               _DXGI_FORMAT_R32G32B32A32_FLOAT,
@@ -73,6 +76,7 @@ func init() {
 			InstanceDataStepRate: 0,
 		})
 	}
+	return descs
 }
 
 const numDescriptorsPerFrame = 32
@@ -154,8 +158,8 @@ const numConstantBufferAndSourceTextures = 1 + graphics.ShaderSrcImageCount
 
 func (p *pipelineStates) initialize(device *_ID3D12Device) (ferr error) {
 	// Create a CBV/SRV/UAV descriptor heap.
-	//   5n+0:        constants
-	//   5n+m (1<=4): textures
+	//   (1+N)n+0:        constants
+	//   (1+N)n+m (1<=m<=N): textures, where N is graphics.ShaderSrcImageCount
 	shaderH, err := device.CreateDescriptorHeap(&_D3D12_DESCRIPTOR_HEAP_DESC{
 		Type:           _D3D12_DESCRIPTOR_HEAP_TYPE_CBV_SRV_UAV,
 		NumDescriptors: frameCount * numDescriptorsPerFrame * numConstantBufferAndSourceTextures,
@@ -202,7 +206,7 @@ func (p *pipelineStates) initialize(device *_ID3D12Device) (ferr error) {
 	return nil
 }
 
-func (p *pipelineStates) drawTriangles(device *_ID3D12Device, commandList *_ID3D12GraphicsCommandList, frameIndex int, screen bool, srcs [graphics.ShaderSrcImageCount]*image12, shader *shader12, dstRegions []graphicsdriver.DstRegion, uniforms []uint32, blend graphicsdriver.Blend, indexOffset int, fillRule graphicsdriver.FillRule) error {
+func (p *pipelineStates) drawTriangles(device *_ID3D12Device, commandList *_ID3D12GraphicsCommandList, frameIndex int, screen bool, extraRenderTargetCount int, depth depthUsage, srcs [graphics.ShaderSrcImageCount]*image12, shader *shader12, dstRegions []graphicsdriver.DstRegion, uniforms []uint32, blend graphicsdriver.Blend, indexOffset int, fillRule graphicsdriver.FillRule) error {
 	idx := len(p.constantBuffers[frameIndex])
 	if idx >= numDescriptorsPerFrame {
 		return fmt.Errorf("directx: too many constant buffers")
@@ -312,7 +316,7 @@ func (p *pipelineStates) drawTriangles(device *_ID3D12Device, commandList *_ID3D
 	commandList.SetGraphicsRootDescriptorTable(2, sh)
 
 	if fillRule == graphicsdriver.FillRuleFillAll {
-		s, err := shader.pipelineState(blend, noStencil, screen)
+		s, err := shader.pipelineState(blend, noStencil, screen, extraRenderTargetCount, depth)
 		if err != nil {
 			return err
 		}
@@ -332,14 +336,14 @@ func (p *pipelineStates) drawTriangles(device *_ID3D12Device, commandList *_ID3D
 		case graphicsdriver.FillRuleFillAll:
 			commandList.DrawIndexedInstanced(uint32(dstRegion.IndexCount), 1, uint32(indexOffset), 0, 0)
 		case graphicsdriver.FillRuleNonZero:
-			s, err := shader.pipelineState(blend, incrementStencil, screen)
+			s, err := shader.pipelineState(blend, incrementStencil, screen, extraRenderTargetCount, depth)
 			if err != nil {
 				return err
 			}
 			commandList.SetPipelineState(s)
 			commandList.DrawIndexedInstanced(uint32(dstRegion.IndexCount), 1, uint32(indexOffset), 0, 0)
 		case graphicsdriver.FillRuleEvenOdd:
-			s, err := shader.pipelineState(blend, invertStencil, screen)
+			s, err := shader.pipelineState(blend, invertStencil, screen, extraRenderTargetCount, depth)
 			if err != nil {
 				return err
 			}
@@ -348,7 +352,7 @@ func (p *pipelineStates) drawTriangles(device *_ID3D12Device, commandList *_ID3D
 		}
 
 		if fillRule != graphicsdriver.FillRuleFillAll {
-			s, err := shader.pipelineState(blend, drawWithStencil, screen)
+			s, err := shader.pipelineState(blend, drawWithStencil, screen, extraRenderTargetCount, depth)
 			if err != nil {
 				return err
 			}
@@ -444,7 +448,7 @@ func (p *pipelineStates) ensureRootSignature(device *_ID3D12Device) (rootSignatu
 	return p.rootSignature, nil
 }
 
-func (p *pipelineStates) newPipelineState(device *_ID3D12Device, vsh, psh *_ID3DBlob, blend graphicsdriver.Blend, stencilMode stencilMode, screen bool) (state *_ID3D12PipelineState, ferr error) {
+func (p *pipelineStates) newPipelineState(device *_ID3D12Device, vsh, psh *_ID3DBlob, vertexFloatCount int, blend graphicsdriver.Blend, stencilMode stencilMode, screen bool, extraRenderTargetCount int, depth depthUsage) (state *_ID3D12PipelineState, ferr error) {
 	rootSignature, err := p.ensureRootSignature(device)
 	if err != nil {
 		return nil, err
@@ -496,15 +500,38 @@ func (p *pipelineStates) newPipelineState(device *_ID3D12Device, vsh, psh *_ID3D
 		depthStencilDesc.BackFace.StencilFunc = _D3D12_COMPARISON_FUNC_NOT_EQUAL
 	}
 
+	if depth.test || depth.write {
+		depthStencilDesc.DepthEnable = 1
+		if depth.test {
+			depthStencilDesc.DepthFunc = _D3D12_COMPARISON_FUNC_LESS_EQUAL
+		} else {
+			depthStencilDesc.DepthFunc = _D3D12_COMPARISON_FUNC_ALWAYS
+		}
+		if !depth.write {
+			depthStencilDesc.DepthWriteMask = _D3D12_DEPTH_WRITE_MASK_ZERO
+		}
+	}
+
 	rtvFormat := _DXGI_FORMAT_R8G8B8A8_UNORM
 	if screen {
 		rtvFormat = _DXGI_FORMAT_B8G8R8A8_UNORM
 	}
+	rtvFormats := [8]_DXGI_FORMAT{rtvFormat}
+	// The extra render targets are never the screen. A null render target view has the same format.
+	for i := 0; i < extraRenderTargetCount; i++ {
+		rtvFormats[i+1] = _DXGI_FORMAT_R8G8B8A8_UNORM
+	}
 	dsvFormat := _DXGI_FORMAT_UNKNOWN
-	if stencilMode != noStencil {
+	// For an image with a depth buffer, the depth-stencil view is always bound.
+	if stencilMode != noStencil || depth.buffer {
 		dsvFormat = _DXGI_FORMAT_D24_UNORM_S8_UINT
 	}
 
+	inputElementDescs := inputElementDescsForDX12
+	if vertexFloatCount == graphics.VertexFloatCountWithAttributes {
+		inputElementDescs = inputElementDescsWithAttributesForDX12
+	}
+
 	// Create a pipeline state.
 	psoDesc := _D3D12_GRAPHICS_PIPELINE_STATE_DESC{
 		pRootSignature: rootSignature,
@@ -550,15 +577,13 @@ func (p *pipelineStates) newPipelineState(device *_ID3D12Device, vsh, psh *_ID3D
 		},
 		DepthStencilState: depthStencilDesc,
 		InputLayout: _D3D12_INPUT_LAYOUT_DESC{
-			pInputElementDescs: &inputElementDescsForDX12[0],
-			NumElements:        uint32(len(inputElementDescsForDX12)),
+			pInputElementDescs: &inputElementDescs[0],
+			NumElements:        uint32(len(inputElementDescs)),
 		},
 		PrimitiveTopologyType: _D3D12_PRIMITIVE_TOPOLOGY_TYPE_TRIANGLE,
-		NumRenderTargets:      1,
-		RTVFormats: [8]_DXGI_FORMAT{
-			rtvFormat,
-		},
-		DSVFormat: dsvFormat,
+		NumRenderTargets:      uint32(1 + extraRenderTargetCount),
+		RTVFormats:            rtvFormats,
+		DSVFormat:             dsvFormat,
 		SampleDesc: _DXGI_SAMPLE_DESC{
 			Count:   1,
 			Quality: 0,
@@ -29,11 +29,15 @@ type shader11 struct {
 	uniformOffsets   []int
 	vertexShaderBlob *_ID3DBlob
 	pixelShaderBlob  *_ID3DBlob
+	vertexFloatCount int
 
 	inputLayout    *_ID3D11InputLayout
 	vertexShader   *_ID3D11VertexShader
 	pixelShader    *_ID3D11PixelShader
 	constantBuffer *_ID3D11Buffer
+
+	depthTest  bool
+	depthWrite bool
 }
 
 func (s *shader11) ID() graphicsdriver.ShaderID {
@@ -96,6 +100,7 @@ func (s *shader11) use(uniforms []uint32, srcs [graphics.ShaderSrcImageCount]*im
 		return err
 	}
 	s.graphics.deviceContext.IASetInputLayout(il)
+	s.graphics.bindVertexBuffer(s.vertexFloatCount)
 
 	cb, err := s.ensureConstantBuffer()
 	if err != nil {
@@ -135,7 +140,11 @@ func (s *shader11) ensureInputLayout() (*_ID3D11InputLayout, error) {
 		return s.inputLayout, nil
 	}
 
-	i, err := s.graphics.device.CreateInputLayout(inputElementDescsForDX11, s.vertexShaderBlob.GetBufferPointer(), s.vertexShaderBlob.GetBufferSize())
+	descs := inputElementDescsForDX11
+	if s.vertexFloatCount == graphics.VertexFloatCountWithAttributes {
+		descs = inputElementDescsWithAttributesForDX11
+	}
+	i, err := s.graphics.device.CreateInputLayout(descs, s.vertexShaderBlob.GetBufferPointer(), s.vertexShaderBlob.GetBufferSize())
 	if err != nil {
 		return nil, err
 	}
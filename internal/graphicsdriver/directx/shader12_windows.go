@@ -23,6 +23,19 @@ type pipelineStateKey struct {
 	blend       graphicsdriver.Blend
 	stencilMode stencilMode
 	screen      bool
+	depth       depthUsage
+
+	// extraRenderTargetCount is the number of the render targets after the first one.
+	extraRenderTargetCount int
+}
+
+// depthUsage represents how a draw uses the destination's depth buffer.
+type depthUsage struct {
+	// buffer reports whether the destination has a depth buffer.
+	buffer bool
+
+	test  bool
+	write bool
 }
 
 type shader12 struct {
@@ -33,6 +46,12 @@ type shader12 struct {
 	vertexShader   *_ID3DBlob
 	pixelShader    *_ID3DBlob
 
+	// vertexFloatCount is the number of floats in a vertex for the shader.
+	vertexFloatCount int
+
+	depthTest  bool
+	depthWrite bool
+
 	pipelineStates map[pipelineStateKey]*_ID3D12PipelineState
 }
 
@@ -67,17 +86,19 @@ func (s *shader12) disposeImpl() {
 	}
 }
 
-func (s *shader12) pipelineState(blend graphicsdriver.Blend, stencilMode stencilMode, screen bool) (*_ID3D12PipelineState, error) {
+func (s *shader12) pipelineState(blend graphicsdriver.Blend, stencilMode stencilMode, screen bool, extraRenderTargetCount int, depth depthUsage) (*_ID3D12PipelineState, error) {
 	key := pipelineStateKey{
-		blend:       blend,
-		stencilMode: stencilMode,
-		screen:      screen,
+		blend:                  blend,
+		stencilMode:            stencilMode,
+		screen:                 screen,
+		depth:                  depth,
+		extraRenderTargetCount: extraRenderTargetCount,
 	}
 	if state, ok := s.pipelineStates[key]; ok {
 		return state, nil
 	}
 
-	state, err := s.graphics.pipelineStates.newPipelineState(s.graphics.device, s.vertexShader, s.pixelShader, blend, stencilMode, screen)
+	state, err := s.graphics.pipelineStates.newPipelineState(s.graphics.device, s.vertexShader, s.pixelShader, s.vertexFloatCount, blend, stencilMode, screen, extraRenderTargetCount, depth)
 	if err != nil {
 		return nil, err
 	}
@@ -16,6 +16,7 @@ package directx
 
 import (
 	"fmt"
+	"regexp"
 	"sync"
 	"unsafe"
 
@@ -34,6 +35,10 @@ const (
 	PixelShaderEntryPoint  = "PSMain"
 )
 
+// hlslErrorLocationRe matches the location in D3DCompile's error message, e.g., "shader(12,5-9):".
+// The first submatch is the line number.
+var hlslErrorLocationRe = regexp.MustCompile(`shader\((\d+),[\d-]+\):`)
+
 type fxcPair struct {
 	vertex []byte
 	pixel  []byte
@@ -102,7 +107,7 @@ func compileShader(program *shaderir.Program) (vsh, psh *_ID3DBlob, ferr error)
 		return vsh, psh, nil
 	}
 
-	vs, ps, _ := hlsl.Compile(program)
+	vs, ps, _, vsmap, psmap := hlsl.CompileWithSourceMaps(program)
 	var flag uint32 = uint32(_D3DCOMPILE_OPTIMIZATION_LEVEL3)
 
 	var wg errgroup.Group
@@ -123,6 +128,10 @@ func compileShader(program *shaderir.Program) (vsh, psh *_ID3DBlob, ferr error)
 		wg.Go(func() error {
 			v, err := _D3DCompile([]byte(vs), "shader", nil, nil, VertexShaderEntryPoint, VertexShaderProfile, flag, 0)
 			if err != nil {
+				// Show the positions in the Kage source instead of the generated HLSL, if possible.
+				if msg, ok := vsmap.MapErrorLog(err.Error(), hlslErrorLocationRe); ok {
+					return fmt.Errorf("directx: D3DCompile for VSMain failed: %s, original source: %s", msg, vs)
+				}
 				return fmt.Errorf("directx: D3DCompile for VSMain failed, original source: %s, %w", vs, err)
 			}
 			vsh = v
@@ -132,6 +141,9 @@ func compileShader(program *shaderir.Program) (vsh, psh *_ID3DBlob, ferr error)
 	wg.Go(func() error {
 		p, err := _D3DCompile([]byte(ps), "shader", nil, nil, PixelShaderEntryPoint, PixelShaderProfile, flag, 0)
 		if err != nil {
+			if msg, ok := psmap.MapErrorLog(err.Error(), hlslErrorLocationRe); ok {
+				return fmt.Errorf("directx: D3DCompile for PSMain failed: %s, original source: %s", msg, ps)
+			}
 			return fmt.Errorf("directx: D3DCompile for PSMain failed, original source: %s, %w", ps, err)
 		}
 		psh = p
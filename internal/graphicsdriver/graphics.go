@@ -15,6 +15,7 @@
 package graphicsdriver
 
 import (
+	"errors"
 	"fmt"
 	"image"
 
@@ -48,6 +49,10 @@ func (f FillRule) String() string {
 	}
 }
 
+// ErrDeviceLost is an error indicating that the graphics device is lost, e.g., by a GPU driver update or a GPU hang.
+// An error returned by a graphics driver matches ErrDeviceLost with errors.Is when the device is lost.
+var ErrDeviceLost = errors.New("graphicsdriver: the graphics device is lost")
+
 const (
 	InvalidImageID  = 0
 	InvalidShaderID = 0
@@ -68,13 +73,87 @@ type Graphics interface {
 	NewShader(program *shaderir.Program) (Shader, error)
 
 	// DrawTriangles draws an image onto another image with the given parameters.
-	DrawTriangles(dst ImageID, srcs [graphics.ShaderSrcImageCount]ImageID, shader ShaderID, dstRegions []DstRegion, indexOffset int, blend Blend, uniforms []uint32, fillRule FillRule) error
+	//
+	// dsts[0] is the destination image. The other non-invalid IDs in dsts are the destination images for multiple render targets,
+	// which receive the second and later colors of the shader's fragment entry point.
+	// When there are multiple destination images, they have the same size, and none of them is a screen framebuffer image.
+	DrawTriangles(dsts [graphics.ShaderDstImageCount]ImageID, srcs [graphics.ShaderSrcImageCount]ImageID, shader ShaderID, dstRegions []DstRegion, indexOffset int, blend Blend, uniforms []uint32, fillRule FillRule) error
 }
 
 type Resetter interface {
 	Reset() error
 }
 
+// StreamingImageCreator is an optional interface to create an image whose pixels are rewritten frequently, e.g., every frame.
+type StreamingImageCreator interface {
+	NewStreamingImage(width, height int) (Image, error)
+}
+
+// DepthImageCreator is an optional interface to create an image with a depth buffer.
+//
+// The depth buffer is cleared with the farthest value 1 when the image is created.
+// A draw onto the image tests and writes the depth buffer as specified by the shader's DepthTest and DepthWrite.
+// The depth test passes when the fragment's depth is less than or equal to the buffer's value.
+type DepthImageCreator interface {
+	NewDepthImage(width, height int) (Image, error)
+}
+
+// PixelFormat represents a pixel format of an image.
+type PixelFormat int
+
+const (
+	// PixelFormatRGBA8 is a format with 8-bit unsigned normalized integers for each channel.
+	PixelFormatRGBA8 PixelFormat = iota
+
+	// PixelFormatRGBA16F is a format with 16-bit floating-point values for each channel.
+	PixelFormatRGBA16F
+
+	// PixelFormatRGBA32F is a format with 32-bit floating-point values for each channel.
+	PixelFormatRGBA32F
+)
+
+// FloatImageCreator is an optional interface to create an image with a floating-point pixel format.
+//
+// The values of the image are not clamped to [0, 1] when drawing.
+// An image created by NewFloatImage should implement FloatPixelsImage.
+type FloatImageCreator interface {
+	NewFloatImage(width, height int, format PixelFormat) (Image, error)
+}
+
+type VsyncMode int
+
+const (
+	VsyncModeOn VsyncMode = iota
+	VsyncModeOff
+	VsyncModeAdaptive
+	VsyncModeMailbox
+)
+
+// VsyncModeSetter is an optional interface to set a vsync mode other than on and off.
+// If a graphics driver doesn't implement VsyncModeSetter, SetVsyncEnabled is used instead.
+type VsyncModeSetter interface {
+	// SetVsyncMode sets the vsync mode, and returns the vsync mode actually used.
+	// If the given mode is not supported, SetVsyncMode falls back to VsyncModeOn.
+	SetVsyncMode(mode VsyncMode) VsyncMode
+}
+
+type LatencyMode int
+
+const (
+	LatencyModeDefault LatencyMode = iota
+	LatencyModeLow
+	LatencyModeSmooth
+)
+
+// LatencyModeSetter is an optional interface to control how many frames can be queued before they are presented.
+//
+// In LatencyModeLow, End with present must not return until the presented frame is no longer queued,
+// so that the next frame's inputs are sampled as late as possible.
+// In LatencyModeSmooth, more frames can be queued to absorb spikes of frame times.
+type LatencyModeSetter interface {
+	SetLatencyMode(mode LatencyMode)
+}
+
 type Image interface {
 	ID() ImageID
 	Dispose()
@@ -89,6 +168,19 @@ type PixelsArgs struct {
 	Region image.Rectangle
 }
 
+// FloatPixelsImage is an optional interface to read and write pixels as float32 values without quantization.
+//
+// If an image doesn't implement FloatPixelsImage, the values are converted to bytes and ReadPixels or WritePixels is used instead.
+type FloatPixelsImage interface {
+	ReadPixelsFloat32(args []PixelsFloat32Args) error
+	WritePixelsFloat32(args []PixelsFloat32Args) error
+}
+
+type PixelsFloat32Args struct {
+	Pixels []float32
+	Region image.Rectangle
+}
+
 type Shader interface {
 	ID() ShaderID
 	Dispose()
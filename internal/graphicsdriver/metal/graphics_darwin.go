@@ -42,15 +42,16 @@ type Graphics struct {
 	cq   mtl.CommandQueue
 	cb   mtl.CommandBuffer
 	rce  mtl.RenderCommandEncoder
-	dsss map[stencilMode]mtl.DepthStencilState
+	dsss map[depthStencilStateKey]mtl.DepthStencilState
 
 	screenDrawable ca.MetalDrawable
 
 	buffers       map[mtl.CommandBuffer][]mtl.Buffer
 	unusedBuffers map[mtl.Buffer]struct{}
 
-	lastDst      *Image
-	lastFillRule graphicsdriver.FillRule
+	lastDst       *Image
+	lastExtraDsts [graphics.ShaderDstImageCount - 1]*Image
+	lastFillRule  graphicsdriver.FillRule
 
 	vb mtl.Buffer
 	ib mtl.Buffer
@@ -77,6 +78,12 @@ const (
 	drawWithStencil
 )
 
+type depthStencilStateKey struct {
+	stencilMode stencilMode
+	depthTest   bool
+	depthWrite  bool
+}
+
 var (
 	systemDefaultDevice    mtl.Device
 	systemDefaultDeviceErr error
@@ -248,6 +255,11 @@ func (g *Graphics) flushIfNeeded(present bool) {
 
 	g.cb.Commit()
 
+	// In the low latency mode, wait for the GPU so that the next frame doesn't start before this frame is done.
+	if present && g.view.latencyMode == graphicsdriver.LatencyModeLow {
+		g.cb.WaitUntilCompleted()
+	}
+
 	for _, t := range g.tmpTextures {
 		t.Release()
 	}
@@ -304,6 +316,17 @@ func (g *Graphics) NewImage(width, height int) (graphicsdriver.Image, error) {
 	return i, nil
 }
 
+func (g *Graphics) NewDepthImage(width, height int) (graphicsdriver.Image, error) {
+	img, err := g.NewImage(width, height)
+	if err != nil {
+		return nil, err
+	}
+	i := img.(*Image)
+	// The depth buffer is created lazily at the first draw.
+	i.depth = true
+	return i, nil
+}
+
 func (g *Graphics) NewScreenFramebufferImage(width, height int) (graphicsdriver.Image, error) {
 	g.view.setDrawableSize(width, height)
 	i := &Image{
@@ -388,9 +411,7 @@ func (g *Graphics) Initialize() error {
 	for _, dss := range g.dsss {
 		dss.Release()
 	}
-	if g.dsss == nil {
-		g.dsss = map[stencilMode]mtl.DepthStencilState{}
-	}
+	clear(g.dsss)
 
 	if runtime.GOOS == "ios" {
 		// Initializing a Metal device and a layer must be done in the render thread on iOS.
@@ -402,68 +423,58 @@ func (g *Graphics) Initialize() error {
 		g.view.ml.SetOpaque(false)
 	}
 
-	// The stencil reference value is always 0 (default).
-	g.dsss[noStencil] = g.view.getMTLDevice().NewDepthStencilStateWithDescriptor(mtl.DepthStencilDescriptor{
-		BackFaceStencil: mtl.StencilDescriptor{
-			StencilFailureOperation:   mtl.StencilOperationKeep,
-			DepthFailureOperation:     mtl.StencilOperationKeep,
-			DepthStencilPassOperation: mtl.StencilOperationKeep,
-			StencilCompareFunction:    mtl.CompareFunctionAlways,
-		},
-		FrontFaceStencil: mtl.StencilDescriptor{
-			StencilFailureOperation:   mtl.StencilOperationKeep,
-			DepthFailureOperation:     mtl.StencilOperationKeep,
-			DepthStencilPassOperation: mtl.StencilOperationKeep,
-			StencilCompareFunction:    mtl.CompareFunctionAlways,
-		},
-	})
-	g.dsss[incrementStencil] = g.view.getMTLDevice().NewDepthStencilStateWithDescriptor(mtl.DepthStencilDescriptor{
-		BackFaceStencil: mtl.StencilDescriptor{
-			StencilFailureOperation:   mtl.StencilOperationKeep,
-			DepthFailureOperation:     mtl.StencilOperationKeep,
-			DepthStencilPassOperation: mtl.StencilOperationDecrementWrap,
-			StencilCompareFunction:    mtl.CompareFunctionAlways,
-		},
-		FrontFaceStencil: mtl.StencilDescriptor{
-			StencilFailureOperation:   mtl.StencilOperationKeep,
-			DepthFailureOperation:     mtl.StencilOperationKeep,
-			DepthStencilPassOperation: mtl.StencilOperationIncrementWrap,
-			StencilCompareFunction:    mtl.CompareFunctionAlways,
-		},
-	})
-	g.dsss[invertStencil] = g.view.getMTLDevice().NewDepthStencilStateWithDescriptor(mtl.DepthStencilDescriptor{
-		BackFaceStencil: mtl.StencilDescriptor{
-			StencilFailureOperation:   mtl.StencilOperationKeep,
-			DepthFailureOperation:     mtl.StencilOperationKeep,
-			DepthStencilPassOperation: mtl.StencilOperationInvert,
-			StencilCompareFunction:    mtl.CompareFunctionAlways,
-		},
-		FrontFaceStencil: mtl.StencilDescriptor{
-			StencilFailureOperation:   mtl.StencilOperationKeep,
-			DepthFailureOperation:     mtl.StencilOperationKeep,
-			DepthStencilPassOperation: mtl.StencilOperationInvert,
-			StencilCompareFunction:    mtl.CompareFunctionAlways,
-		},
-	})
-	g.dsss[drawWithStencil] = g.view.getMTLDevice().NewDepthStencilStateWithDescriptor(mtl.DepthStencilDescriptor{
-		BackFaceStencil: mtl.StencilDescriptor{
-			StencilFailureOperation:   mtl.StencilOperationKeep,
-			DepthFailureOperation:     mtl.StencilOperationKeep,
-			DepthStencilPassOperation: mtl.StencilOperationKeep,
-			StencilCompareFunction:    mtl.CompareFunctionNotEqual,
-		},
-		FrontFaceStencil: mtl.StencilDescriptor{
-			StencilFailureOperation:   mtl.StencilOperationKeep,
-			DepthFailureOperation:     mtl.StencilOperationKeep,
-			DepthStencilPassOperation: mtl.StencilOperationKeep,
-			StencilCompareFunction:    mtl.CompareFunctionNotEqual,
-		},
-	})
-
 	g.cq = g.view.getMTLDevice().NewCommandQueue()
 	return nil
 }
 
+// depthStencilState returns a depth-stencil state for the given stencil mode and depth usage.
+// Creating *State objects are expensive, so the created states are cached.
+func (g *Graphics) depthStencilState(mode stencilMode, depthTest, depthWrite bool) mtl.DepthStencilState {
+	key := depthStencilStateKey{
+		stencilMode: mode,
+		depthTest:   depthTest,
+		depthWrite:  depthWrite,
+	}
+	if dss, ok := g.dsss[key]; ok {
+		return dss
+	}
+
+	// The stencil reference value is always 0 (default).
+	sd := mtl.StencilDescriptor{
+		StencilFailureOperation:   mtl.StencilOperationKeep,
+		DepthFailureOperation:     mtl.StencilOperationKeep,
+		DepthStencilPassOperation: mtl.StencilOperationKeep,
+		StencilCompareFunction:    mtl.CompareFunctionAlways,
+	}
+	desc := mtl.DepthStencilDescriptor{
+		DepthCompareFunction: mtl.CompareFunctionAlways,
+		BackFaceStencil:      sd,
+		FrontFaceStencil:     sd,
+	}
+	switch mode {
+	case incrementStencil:
+		desc.BackFaceStencil.DepthStencilPassOperation = mtl.StencilOperationDecrementWrap
+		desc.FrontFaceStencil.DepthStencilPassOperation = mtl.StencilOperationIncrementWrap
+	case invertStencil:
+		desc.BackFaceStencil.DepthStencilPassOperation = mtl.StencilOperationInvert
+		desc.FrontFaceStencil.DepthStencilPassOperation = mtl.StencilOperationInvert
+	case drawWithStencil:
+		desc.BackFaceStencil.StencilCompareFunction = mtl.CompareFunctionNotEqual
+		desc.FrontFaceStencil.StencilCompareFunction = mtl.CompareFunctionNotEqual
+	}
+	if depthTest {
+		desc.DepthCompareFunction = mtl.CompareFunctionLessEqual
+	}
+	desc.DepthWriteEnabled = depthWrite
+
+	dss := g.view.getMTLDevice().NewDepthStencilStateWithDescriptor(desc)
+	if g.dsss == nil {
+		g.dsss = map[depthStencilStateKey]mtl.DepthStencilState{}
+	}
+	g.dsss[key] = dss
+	return dss
+}
+
 func (g *Graphics) flushRenderCommandEncoderIfNeeded() {
 	if g.rce == (mtl.RenderCommandEncoder{}) {
 		return
@@ -471,16 +482,18 @@ func (g *Graphics) flushRenderCommandEncoderIfNeeded() {
 	g.rce.EndEncoding()
 	g.rce = mtl.RenderCommandEncoder{}
 	g.lastDst = nil
+	g.lastExtraDsts = [graphics.ShaderDstImageCount - 1]*Image{}
 }
 
-func (g *Graphics) draw(dst *Image, dstRegions []graphicsdriver.DstRegion, srcs [graphics.ShaderSrcImageCount]*Image, indexOffset int, shader *Shader, uniforms []uint32, blend graphicsdriver.Blend, fillRule graphicsdriver.FillRule) error {
+func (g *Graphics) draw(dst *Image, extraDsts [graphics.ShaderDstImageCount - 1]*Image, dstRegions []graphicsdriver.DstRegion, srcs [graphics.ShaderSrcImageCount]*Image, indexOffset int, shader *Shader, uniforms []uint32, blend graphicsdriver.Blend, fillRule graphicsdriver.FillRule) error {
 	// When preparing a stencil buffer, flush the current render command encoder
 	// to make sure the stencil buffer is cleared when loading.
 	// TODO: What about clearing the stencil buffer by vertices?
-	if g.lastDst != dst || g.lastFillRule != fillRule || fillRule != graphicsdriver.FillRuleFillAll {
+	if g.lastDst != dst || g.lastExtraDsts != extraDsts || g.lastFillRule != fillRule || fillRule != graphicsdriver.FillRuleFillAll {
 		g.flushRenderCommandEncoderIfNeeded()
 	}
 	g.lastDst = dst
+	g.lastExtraDsts = extraDsts
 	g.lastFillRule = fillRule
 
 	if g.rce == (mtl.RenderCommandEncoder{}) {
@@ -503,12 +516,33 @@ func (g *Graphics) draw(dst *Image, dstRegions []graphicsdriver.DstRegion, srcs
 		rpd.ColorAttachments[0].Texture = t
 		rpd.ColorAttachments[0].ClearColor = mtl.ClearColor{}
 
-		if fillRule != graphicsdriver.FillRuleFillAll {
+		for i, extraDst := range extraDsts {
+			if extraDst == nil {
+				continue
+			}
+			rpd.ColorAttachments[i+1].LoadAction = mtl.LoadActionLoad
+			rpd.ColorAttachments[i+1].StoreAction = mtl.StoreActionStore
+			rpd.ColorAttachments[i+1].Texture = extraDst.texture
+		}
+
+		if fillRule != graphicsdriver.FillRuleFillAll || dst.depth {
 			dst.ensureStencil()
 			rpd.StencilAttachment.LoadAction = mtl.LoadActionClear
 			rpd.StencilAttachment.StoreAction = mtl.StoreActionDontCare
 			rpd.StencilAttachment.Texture = dst.stencil
 		}
+		if dst.depth {
+			// The depth buffer must be kept across render passes, unlike the stencil buffer.
+			// The depth buffer is cleared with the farthest value 1 at the first render pass.
+			if dst.depthInitialized {
+				rpd.DepthAttachment.LoadAction = mtl.LoadActionLoad
+			} else {
+				rpd.DepthAttachment.LoadAction = mtl.LoadActionClear
+				dst.depthInitialized = true
+			}
+			rpd.DepthAttachment.StoreAction = mtl.StoreActionStore
+			rpd.DepthAttachment.Texture = dst.stencil
+		}
 
 		if g.cb == (mtl.CommandBuffer{}) {
 			g.cb = g.cq.CommandBuffer()
@@ -517,12 +551,17 @@ func (g *Graphics) draw(dst *Image, dstRegions []graphicsdriver.DstRegion, srcs
 	}
 
 	w, h := dst.internalSize()
+	zNear := -1.0
+	if dst.depth {
+		// Map the NDC's Z [0, 1] to the depth [0, 1] as it is.
+		zNear = 0
+	}
 	g.rce.SetViewport(mtl.Viewport{
 		OriginX: 0,
 		OriginY: 0,
 		Width:   float64(w),
 		Height:  float64(h),
-		ZNear:   -1,
+		ZNear:   zNear,
 		ZFar:    1,
 	})
 	g.rce.SetVertexBuffer(g.vb, 0, 0)
@@ -542,6 +581,11 @@ func (g *Graphics) draw(dst *Image, dstRegions []graphicsdriver.DstRegion, srcs
 		}
 	}
 
+	var hasExtraDsts [graphics.ShaderDstImageCount - 1]bool
+	for i, extraDst := range extraDsts {
+		hasExtraDsts[i] = extraDst != nil
+	}
+
 	var (
 		noStencilRpss        mtl.RenderPipelineState
 		incrementStencilRpss mtl.RenderPipelineState
@@ -550,32 +594,38 @@ func (g *Graphics) draw(dst *Image, dstRegions []graphicsdriver.DstRegion, srcs
 	)
 	switch fillRule {
 	case graphicsdriver.FillRuleFillAll:
-		s, err := shader.RenderPipelineState(&g.view, blend, noStencil, dst.screen)
+		s, err := shader.RenderPipelineState(&g.view, blend, noStencil, dst.screen, dst.depth, hasExtraDsts)
 		if err != nil {
 			return err
 		}
 		noStencilRpss = s
 	case graphicsdriver.FillRuleNonZero:
-		s, err := shader.RenderPipelineState(&g.view, blend, incrementStencil, dst.screen)
+		s, err := shader.RenderPipelineState(&g.view, blend, incrementStencil, dst.screen, dst.depth, hasExtraDsts)
 		if err != nil {
 			return err
 		}
 		incrementStencilRpss = s
 	case graphicsdriver.FillRuleEvenOdd:
-		s, err := shader.RenderPipelineState(&g.view, blend, invertStencil, dst.screen)
+		s, err := shader.RenderPipelineState(&g.view, blend, invertStencil, dst.screen, dst.depth, hasExtraDsts)
 		if err != nil {
 			return err
 		}
 		invertStencilRpss = s
 	}
 	if fillRule != graphicsdriver.FillRuleFillAll {
-		s, err := shader.RenderPipelineState(&g.view, blend, drawWithStencil, dst.screen)
+		s, err := shader.RenderPipelineState(&g.view, blend, drawWithStencil, dst.screen, dst.depth, hasExtraDsts)
 		if err != nil {
 			return err
 		}
 		drawWithStencilRpss = s
 	}
 
+	var depthTest, depthWrite bool
+	if dst.depth {
+		depthTest = shader.ir.DepthTest
+		depthWrite = shader.ir.DepthWrite
+	}
+
 	for _, dstRegion := range dstRegions {
 		g.rce.SetScissorRect(mtl.ScissorRect{
 			X:      dstRegion.Region.Min.X,
@@ -586,20 +636,20 @@ func (g *Graphics) draw(dst *Image, dstRegions []graphicsdriver.DstRegion, srcs
 
 		switch fillRule {
 		case graphicsdriver.FillRuleFillAll:
-			g.rce.SetDepthStencilState(g.dsss[noStencil])
+			g.rce.SetDepthStencilState(g.depthStencilState(noStencil, depthTest, depthWrite))
 			g.rce.SetRenderPipelineState(noStencilRpss)
 			g.rce.DrawIndexedPrimitives(mtl.PrimitiveTypeTriangle, dstRegion.IndexCount, mtl.IndexTypeUInt32, g.ib, indexOffset*int(unsafe.Sizeof(uint32(0))))
 		case graphicsdriver.FillRuleNonZero:
-			g.rce.SetDepthStencilState(g.dsss[incrementStencil])
+			g.rce.SetDepthStencilState(g.depthStencilState(incrementStencil, depthTest, depthWrite))
 			g.rce.SetRenderPipelineState(incrementStencilRpss)
 			g.rce.DrawIndexedPrimitives(mtl.PrimitiveTypeTriangle, dstRegion.IndexCount, mtl.IndexTypeUInt32, g.ib, indexOffset*int(unsafe.Sizeof(uint32(0))))
 		case graphicsdriver.FillRuleEvenOdd:
-			g.rce.SetDepthStencilState(g.dsss[invertStencil])
+			g.rce.SetDepthStencilState(g.depthStencilState(invertStencil, depthTest, depthWrite))
 			g.rce.SetRenderPipelineState(invertStencilRpss)
 			g.rce.DrawIndexedPrimitives(mtl.PrimitiveTypeTriangle, dstRegion.IndexCount, mtl.IndexTypeUInt32, g.ib, indexOffset*int(unsafe.Sizeof(uint32(0))))
 		}
 		if fillRule != graphicsdriver.FillRuleFillAll {
-			g.rce.SetDepthStencilState(g.dsss[drawWithStencil])
+			g.rce.SetDepthStencilState(g.depthStencilState(drawWithStencil, depthTest, depthWrite))
 			g.rce.SetRenderPipelineState(drawWithStencilRpss)
 			g.rce.DrawIndexedPrimitives(mtl.PrimitiveTypeTriangle, dstRegion.IndexCount, mtl.IndexTypeUInt32, g.ib, indexOffset*int(unsafe.Sizeof(uint32(0))))
 		}
@@ -610,12 +660,19 @@ func (g *Graphics) draw(dst *Image, dstRegions []graphicsdriver.DstRegion, srcs
 	return nil
 }
 
-func (g *Graphics) DrawTriangles(dstID graphicsdriver.ImageID, srcIDs [graphics.ShaderSrcImageCount]graphicsdriver.ImageID, shaderID graphicsdriver.ShaderID, dstRegions []graphicsdriver.DstRegion, indexOffset int, blend graphicsdriver.Blend, uniforms []uint32, fillRule graphicsdriver.FillRule) error {
+func (g *Graphics) DrawTriangles(dstIDs [graphics.ShaderDstImageCount]graphicsdriver.ImageID, srcIDs [graphics.ShaderSrcImageCount]graphicsdriver.ImageID, shaderID graphicsdriver.ShaderID, dstRegions []graphicsdriver.DstRegion, indexOffset int, blend graphicsdriver.Blend, uniforms []uint32, fillRule graphicsdriver.FillRule) error {
 	if shaderID == graphicsdriver.InvalidShaderID {
 		return fmt.Errorf("metal: shader ID is invalid")
 	}
 
-	dst := g.images[dstID]
+	dst := g.images[dstIDs[0]]
+	var extraDsts [graphics.ShaderDstImageCount - 1]*Image
+	for i, id := range dstIDs[1:] {
+		if id == graphicsdriver.InvalidImageID {
+			continue
+		}
+		extraDsts[i] = g.images[id]
+	}
 
 	if dst.screen {
 		g.view.update()
@@ -626,7 +683,7 @@ func (g *Graphics) DrawTriangles(dstID graphicsdriver.ImageID, srcIDs [graphics.
 		srcs[i] = g.images[srcID]
 	}
 
-	if err := g.draw(dst, dstRegions, srcs, indexOffset, g.shaders[shaderID], uniforms, blend, fillRule); err != nil {
+	if err := g.draw(dst, extraDsts, dstRegions, srcs, indexOffset, g.shaders[shaderID], uniforms, blend, fillRule); err != nil {
 		return err
 	}
 
@@ -637,6 +694,10 @@ func (g *Graphics) SetVsyncEnabled(enabled bool) {
 	g.view.setDisplaySyncEnabled(enabled)
 }
 
+func (g *Graphics) SetLatencyMode(mode graphicsdriver.LatencyMode) {
+	g.view.setLatencyMode(mode)
+}
+
 func (g *Graphics) NeedsClearingScreen() bool {
 	return false
 }
@@ -721,6 +782,13 @@ type Image struct {
 	screen   bool
 	texture  mtl.Texture
 	stencil  mtl.Texture
+
+	// depth reports whether the image has a depth buffer.
+	// For an image with a depth buffer, stencil is a combined depth-stencil texture.
+	depth bool
+
+	// depthInitialized reports whether the depth buffer has been cleared.
+	depthInitialized bool
 }
 
 func (i *Image) ID() graphicsdriver.ImageID {
@@ -851,9 +919,13 @@ func (i *Image) ensureStencil() {
 		return
 	}
 
+	pix := mtl.PixelFormatStencil8
+	if i.depth {
+		pix = mtl.PixelFormatDepth32FloatStencil8
+	}
 	td := mtl.TextureDescriptor{
 		TextureType: mtl.TextureType2D,
-		PixelFormat: mtl.PixelFormatStencil8,
+		PixelFormat: pix,
 		Width:       graphics.InternalImageSize(i.width),
 		Height:      graphics.InternalImageSize(i.height),
 		StorageMode: mtl.StorageModePrivate,
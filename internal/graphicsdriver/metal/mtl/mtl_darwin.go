@@ -108,11 +108,14 @@ type PixelFormat uint16
 // The data formats that describe the organization and characteristics
 // of individual pixels in a texture.
 const (
+	PixelFormatInvalid        PixelFormat = 0   // The default value of the pixel format, which means no attachment.
 	PixelFormatRGBA8UNorm     PixelFormat = 70  // Ordinary format with four 8-bit normalized unsigned integer components in RGBA order.
 	PixelFormatRGBA8UNormSRGB PixelFormat = 71  // Ordinary format with four 8-bit normalized unsigned integer components in RGBA order with conversion between sRGB and linear space.
 	PixelFormatBGRA8UNorm     PixelFormat = 80  // Ordinary format with four 8-bit normalized unsigned integer components in BGRA order.
 	PixelFormatBGRA8UNormSRGB PixelFormat = 81  // Ordinary format with four 8-bit normalized unsigned integer components in BGRA order with conversion between sRGB and linear space.
 	PixelFormatStencil8       PixelFormat = 253 // A pixel format with an 8-bit unsigned integer component, used for a stencil render target.
+
+	PixelFormatDepth32FloatStencil8 PixelFormat = 260 // A 40-bit combined depth and stencil pixel format with a 32-bit floating-point value for depth and an 8-bit unsigned integer for stencil.
 )
 
 // PrimitiveType defines geometric primitive types for drawing commands.
@@ -364,6 +367,11 @@ type Resource interface {
 	resource() unsafe.Pointer
 }
 
+// MaxColorAttachmentCount is the maximum number of color attachments for a render pass.
+//
+// Reference: https://developer.apple.com/metal/Metal-Feature-Set-Tables.pdf
+const MaxColorAttachmentCount = 8
+
 // RenderPipelineDescriptor configures new RenderPipelineState objects.
 //
 // Reference: https://developer.apple.com/documentation/metal/mtlrenderpipelinedescriptor?language=objc.
@@ -375,7 +383,11 @@ type RenderPipelineDescriptor struct {
 	FragmentFunction Function
 
 	// ColorAttachments is an array of attachments that store color data.
-	ColorAttachments [1]RenderPipelineColorAttachmentDescriptor
+	// An attachment other than the first one is ignored if its pixel format is PixelFormatInvalid.
+	ColorAttachments [MaxColorAttachmentCount]RenderPipelineColorAttachmentDescriptor
+
+	// DepthAttachmentPixelFormat is the pixel format of the attachment that stores depth data.
+	DepthAttachmentPixelFormat PixelFormat
 
 	// StencilAttachmentPixelFormat is the pixel format of the attachment that stores stencil data.
 	StencilAttachmentPixelFormat PixelFormat
@@ -407,7 +419,11 @@ type RenderPipelineColorAttachmentDescriptor struct {
 // Reference: https://developer.apple.com/documentation/metal/mtlrenderpassdescriptor?language=objc.
 type RenderPassDescriptor struct {
 	// ColorAttachments is array of state information for attachments that store color data.
-	ColorAttachments [1]RenderPassColorAttachmentDescriptor
+	// An attachment other than the first one is ignored if its texture is nil.
+	ColorAttachments [MaxColorAttachmentCount]RenderPassColorAttachmentDescriptor
+
+	// DepthAttachment is state information for an attachment that stores depth data.
+	DepthAttachment RenderPassDepthAttachment
 
 	// StencilAttachment is state information for an attachment that stores stencil data.
 	StencilAttachment RenderPassStencilAttachment
@@ -422,6 +438,16 @@ type RenderPassColorAttachmentDescriptor struct {
 	ClearColor ClearColor
 }
 
+// RenderPassDepthAttachment describes a depth render target that serves as the output
+// destination for depth pixels generated by a render pass.
+//
+// The clear value is always the default value 1.
+//
+// Reference: https://developer.apple.com/documentation/metal/mtlrenderpassdepthattachmentdescriptor?language=objc.
+type RenderPassDepthAttachment struct {
+	RenderPassAttachmentDescriptor
+}
+
 // RenderPassStencilAttachment describes a stencil render target that serves as the output
 // destination for stencil pixels generated by a render pass.
 //
@@ -510,6 +536,7 @@ var (
 	sel_setAlphaBlendOperation                                                                                                        = objc.RegisterName("setAlphaBlendOperation:")
 	sel_setRgbBlendOperation                                                                                                          = objc.RegisterName("setRgbBlendOperation:")
 	sel_setWriteMask                                                                                                                  = objc.RegisterName("setWriteMask:")
+	sel_setDepthAttachmentPixelFormat                                                                                                 = objc.RegisterName("setDepthAttachmentPixelFormat:")
 	sel_setStencilAttachmentPixelFormat                                                                                               = objc.RegisterName("setStencilAttachmentPixelFormat:")
 	sel_newRenderPipelineStateWithDescriptor_error                                                                                    = objc.RegisterName("newRenderPipelineStateWithDescriptor:error:")
 	sel_newBufferWithBytes_length_options                                                                                             = objc.RegisterName("newBufferWithBytes:length:options:")
@@ -558,6 +585,9 @@ var (
 	sel_setStencilFailureOperation                                                                                                    = objc.RegisterName("setStencilFailureOperation:")
 	sel_setDepthFailureOperation                                                                                                      = objc.RegisterName("setDepthFailureOperation:")
 	sel_setDepthStencilPassOperation                                                                                                  = objc.RegisterName("setDepthStencilPassOperation:")
+	sel_setDepthCompareFunction                                                                                                       = objc.RegisterName("setDepthCompareFunction:")
+	sel_setDepthWriteEnabled                                                                                                          = objc.RegisterName("setDepthWriteEnabled:")
+	sel_depthAttachment                                                                                                               = objc.RegisterName("depthAttachment")
 	sel_setStencilCompareFunction                                                                                                     = objc.RegisterName("setStencilCompareFunction:")
 	sel_newDepthStencilStateWithDescriptor                                                                                            = objc.RegisterName("newDepthStencilStateWithDescriptor:")
 	sel_replaceRegion_mipmapLevel_withBytes_bytesPerRow                                                                               = objc.RegisterName("replaceRegion:mipmapLevel:withBytes:bytesPerRow:")
@@ -679,16 +709,23 @@ func (d Device) NewRenderPipelineStateWithDescriptor(rpd RenderPipelineDescripto
 	renderPipelineDescriptor := objc.ID(class_MTLRenderPipelineDescriptor).Send(sel_new)
 	renderPipelineDescriptor.Send(sel_setVertexFunction, rpd.VertexFunction.function)
 	renderPipelineDescriptor.Send(sel_setFragmentFunction, rpd.FragmentFunction.function)
-	colorAttachments0 := renderPipelineDescriptor.Send(sel_colorAttachments).Send(sel_objectAtIndexedSubscript, 0)
-	colorAttachments0.Send(sel_setPixelFormat, uintptr(rpd.ColorAttachments[0].PixelFormat))
-	colorAttachments0.Send(sel_setBlendingEnabled, rpd.ColorAttachments[0].BlendingEnabled)
-	colorAttachments0.Send(sel_setDestinationAlphaBlendFactor, uintptr(rpd.ColorAttachments[0].DestinationAlphaBlendFactor))
-	colorAttachments0.Send(sel_setDestinationRGBBlendFactor, uintptr(rpd.ColorAttachments[0].DestinationRGBBlendFactor))
-	colorAttachments0.Send(sel_setSourceAlphaBlendFactor, uintptr(rpd.ColorAttachments[0].SourceAlphaBlendFactor))
-	colorAttachments0.Send(sel_setSourceRGBBlendFactor, uintptr(rpd.ColorAttachments[0].SourceRGBBlendFactor))
-	colorAttachments0.Send(sel_setAlphaBlendOperation, uintptr(rpd.ColorAttachments[0].AlphaBlendOperation))
-	colorAttachments0.Send(sel_setRgbBlendOperation, uintptr(rpd.ColorAttachments[0].RGBBlendOperation))
-	colorAttachments0.Send(sel_setWriteMask, uintptr(rpd.ColorAttachments[0].WriteMask))
+	colorAttachments := renderPipelineDescriptor.Send(sel_colorAttachments)
+	for i, a := range rpd.ColorAttachments {
+		if i > 0 && a.PixelFormat == PixelFormatInvalid {
+			continue
+		}
+		colorAttachment := colorAttachments.Send(sel_objectAtIndexedSubscript, i)
+		colorAttachment.Send(sel_setPixelFormat, uintptr(a.PixelFormat))
+		colorAttachment.Send(sel_setBlendingEnabled, a.BlendingEnabled)
+		colorAttachment.Send(sel_setDestinationAlphaBlendFactor, uintptr(a.DestinationAlphaBlendFactor))
+		colorAttachment.Send(sel_setDestinationRGBBlendFactor, uintptr(a.DestinationRGBBlendFactor))
+		colorAttachment.Send(sel_setSourceAlphaBlendFactor, uintptr(a.SourceAlphaBlendFactor))
+		colorAttachment.Send(sel_setSourceRGBBlendFactor, uintptr(a.SourceRGBBlendFactor))
+		colorAttachment.Send(sel_setAlphaBlendOperation, uintptr(a.AlphaBlendOperation))
+		colorAttachment.Send(sel_setRgbBlendOperation, uintptr(a.RGBBlendOperation))
+		colorAttachment.Send(sel_setWriteMask, uintptr(a.WriteMask))
+	}
+	renderPipelineDescriptor.Send(sel_setDepthAttachmentPixelFormat, uintptr(rpd.DepthAttachmentPixelFormat))
 	renderPipelineDescriptor.Send(sel_setStencilAttachmentPixelFormat, uintptr(rpd.StencilAttachmentPixelFormat))
 	var err cocoa.NSError
 	renderPipelineState := d.device.Send(sel_newRenderPipelineStateWithDescriptor_error,
@@ -740,6 +777,8 @@ func (d Device) NewTextureWithDescriptor(td TextureDescriptor) Texture {
 // Reference: https://developer.apple.com/documentation/metal/mtldevice/1433412-newdepthstencilstatewithdescript?language=objc.
 func (d Device) NewDepthStencilStateWithDescriptor(dsd DepthStencilDescriptor) DepthStencilState {
 	depthStencilDescriptor := objc.ID(class_MTLDepthStencilDescriptor).Send(sel_new)
+	depthStencilDescriptor.Send(sel_setDepthCompareFunction, uintptr(dsd.DepthCompareFunction))
+	depthStencilDescriptor.Send(sel_setDepthWriteEnabled, dsd.DepthWriteEnabled)
 	backFaceStencil := depthStencilDescriptor.Send(sel_backFaceStencil)
 	backFaceStencil.Send(sel_setStencilFailureOperation, uintptr(dsd.BackFaceStencil.StencilFailureOperation))
 	backFaceStencil.Send(sel_setDepthFailureOperation, uintptr(dsd.BackFaceStencil.DepthFailureOperation))
@@ -848,11 +887,21 @@ func (cb CommandBuffer) WaitUntilScheduled() {
 // Reference: https://developer.apple.com/documentation/metal/mtlcommandbuffer/1442999-rendercommandencoderwithdescript?language=objc.
 func (cb CommandBuffer) RenderCommandEncoderWithDescriptor(rpd RenderPassDescriptor) RenderCommandEncoder {
 	var renderPassDescriptor = objc.ID(class_MTLRenderPassDescriptor).Send(sel_new)
-	var colorAttachments0 = renderPassDescriptor.Send(sel_colorAttachments).Send(sel_objectAtIndexedSubscript, 0)
-	colorAttachments0.Send(sel_setLoadAction, int(rpd.ColorAttachments[0].LoadAction))
-	colorAttachments0.Send(sel_setStoreAction, int(rpd.ColorAttachments[0].StoreAction))
-	colorAttachments0.Send(sel_setTexture, rpd.ColorAttachments[0].Texture.texture)
-	colorAttachments0.Send(sel_setClearColor, rpd.ColorAttachments[0].ClearColor)
+	var colorAttachments = renderPassDescriptor.Send(sel_colorAttachments)
+	for i, a := range rpd.ColorAttachments {
+		if i > 0 && a.Texture == (Texture{}) {
+			continue
+		}
+		var colorAttachment = colorAttachments.Send(sel_objectAtIndexedSubscript, i)
+		colorAttachment.Send(sel_setLoadAction, int(a.LoadAction))
+		colorAttachment.Send(sel_setStoreAction, int(a.StoreAction))
+		colorAttachment.Send(sel_setTexture, a.Texture.texture)
+		colorAttachment.Send(sel_setClearColor, a.ClearColor)
+	}
+	var depthAttachment = renderPassDescriptor.Send(sel_depthAttachment)
+	depthAttachment.Send(sel_setLoadAction, int(rpd.DepthAttachment.LoadAction))
+	depthAttachment.Send(sel_setStoreAction, int(rpd.DepthAttachment.StoreAction))
+	depthAttachment.Send(sel_setTexture, rpd.DepthAttachment.Texture.texture)
 	var stencilAttachment = renderPassDescriptor.Send(sel_stencilAttachment)
 	stencilAttachment.Send(sel_setLoadAction, int(rpd.StencilAttachment.LoadAction))
 	stencilAttachment.Send(sel_setStoreAction, int(rpd.StencilAttachment.StoreAction))
@@ -1229,6 +1278,13 @@ func (d DepthStencilState) Release() {
 //
 // Reference: https://developer.apple.com/documentation/metal/mtldepthstencildescriptor?language=objc.
 type DepthStencilDescriptor struct {
+	// DepthCompareFunction is the comparison that is performed between a fragment's depth value and the depth value in the attachment,
+	// which determines whether to discard the fragment.
+	DepthCompareFunction CompareFunction
+
+	// DepthWriteEnabled is a Boolean value that indicates whether depth values can be written to the depth attachment.
+	DepthWriteEnabled bool
+
 	// BackFaceStencil is the stencil descriptor for back-facing primitives.
 	BackFaceStencil StencilDescriptor
 
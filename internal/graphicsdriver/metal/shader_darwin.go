@@ -16,14 +16,20 @@ package metal
 
 import (
 	"fmt"
+	"regexp"
 	"sync"
 
+	"github.com/duplicants-ai/ebiten/internal/graphics"
 	"github.com/duplicants-ai/ebiten/internal/graphicsdriver"
 	"github.com/duplicants-ai/ebiten/internal/graphicsdriver/metal/mtl"
 	"github.com/duplicants-ai/ebiten/internal/shaderir"
 	"github.com/duplicants-ai/ebiten/internal/shaderir/msl"
 )
 
+// mslErrorLocationRe matches the location in the Metal compiler's error message, e.g., "program_source:12:5:".
+// The first submatch is the line number.
+var mslErrorLocationRe = regexp.MustCompile(`program_source:(\d+):\d+:`)
+
 type precompiledLibraries struct {
 	binaries map[shaderir.SourceHash][]byte
 	m        sync.Mutex
@@ -59,6 +65,8 @@ type shaderRpsKey struct {
 	blend       graphicsdriver.Blend
 	stencilMode stencilMode
 	screen      bool
+	depth       bool
+	extraDsts   [graphics.ShaderDstImageCount - 1]bool
 }
 
 type Shader struct {
@@ -110,9 +118,14 @@ func (s *Shader) init(device mtl.Device) error {
 		}
 		s.lib = lib
 	} else {
-		src = msl.Compile(s.ir)
+		var srcMap *shaderir.SourceMap
+		src, srcMap = msl.CompileWithSourceMap(s.ir)
 		lib, err := device.NewLibraryWithSource(src, mtl.CompileOptions{})
 		if err != nil {
+			// Show the positions in the Kage source instead of the generated MSL, if possible.
+			if msg, ok := srcMap.MapErrorLog(err.Error(), mslErrorLocationRe); ok {
+				return fmt.Errorf("metal: device.MakeLibrary failed: %s, source: %s", msg, src)
+			}
 			return fmt.Errorf("metal: device.MakeLibrary failed: %w, source: %s", err, src)
 		}
 		s.lib = lib
@@ -137,11 +150,15 @@ func (s *Shader) init(device mtl.Device) error {
 	return nil
 }
 
-func (s *Shader) RenderPipelineState(view *view, blend graphicsdriver.Blend, stencilMode stencilMode, screen bool) (mtl.RenderPipelineState, error) {
+// RenderPipelineState returns a render pipeline state.
+// extraDsts reports whether the color attachments for the second and later colors exist.
+func (s *Shader) RenderPipelineState(view *view, blend graphicsdriver.Blend, stencilMode stencilMode, screen bool, depth bool, extraDsts [graphics.ShaderDstImageCount - 1]bool) (mtl.RenderPipelineState, error) {
 	key := shaderRpsKey{
 		blend:       blend,
 		stencilMode: stencilMode,
 		screen:      screen,
+		depth:       depth,
+		extraDsts:   extraDsts,
 	}
 	if rps, ok := s.rpss[key]; ok {
 		return rps, nil
@@ -151,7 +168,11 @@ func (s *Shader) RenderPipelineState(view *view, blend graphicsdriver.Blend, ste
 		VertexFunction:   s.vs,
 		FragmentFunction: s.fs,
 	}
-	if stencilMode != noStencil {
+	if depth {
+		// For an image with a depth buffer, the combined depth-stencil texture is always attached.
+		rpld.DepthAttachmentPixelFormat = mtl.PixelFormatDepth32FloatStencil8
+		rpld.StencilAttachmentPixelFormat = mtl.PixelFormatDepth32FloatStencil8
+	} else if stencilMode != noStencil {
 		rpld.StencilAttachmentPixelFormat = mtl.PixelFormatStencil8
 	}
 
@@ -160,20 +181,13 @@ func (s *Shader) RenderPipelineState(view *view, blend graphicsdriver.Blend, ste
 	if screen {
 		pix = view.colorPixelFormat()
 	}
-	rpld.ColorAttachments[0].PixelFormat = pix
-	rpld.ColorAttachments[0].BlendingEnabled = true
-
-	rpld.ColorAttachments[0].DestinationAlphaBlendFactor = blendFactorToMetalBlendFactor(blend.BlendFactorDestinationAlpha)
-	rpld.ColorAttachments[0].DestinationRGBBlendFactor = blendFactorToMetalBlendFactor(blend.BlendFactorDestinationRGB)
-	rpld.ColorAttachments[0].SourceAlphaBlendFactor = blendFactorToMetalBlendFactor(blend.BlendFactorSourceAlpha)
-	rpld.ColorAttachments[0].SourceRGBBlendFactor = blendFactorToMetalBlendFactor(blend.BlendFactorSourceRGB)
-	rpld.ColorAttachments[0].AlphaBlendOperation = blendOperationToMetalBlendOperation(blend.BlendOperationAlpha)
-	rpld.ColorAttachments[0].RGBBlendOperation = blendOperationToMetalBlendOperation(blend.BlendOperationRGB)
-
-	if stencilMode == noStencil || stencilMode == drawWithStencil {
-		rpld.ColorAttachments[0].WriteMask = mtl.ColorWriteMaskAll
-	} else {
-		rpld.ColorAttachments[0].WriteMask = mtl.ColorWriteMaskNone
+	rpld.ColorAttachments[0] = colorAttachmentDescriptor(pix, blend, stencilMode)
+	// The extra destinations are never the screen.
+	for i, ok := range extraDsts {
+		if !ok {
+			continue
+		}
+		rpld.ColorAttachments[i+1] = colorAttachmentDescriptor(mtl.PixelFormatRGBA8UNorm, blend, stencilMode)
 	}
 
 	rps, err := view.getMTLDevice().NewRenderPipelineStateWithDescriptor(rpld)
@@ -184,3 +198,23 @@ func (s *Shader) RenderPipelineState(view *view, blend graphicsdriver.Blend, ste
 	s.rpss[key] = rps
 	return rps, nil
 }
+
+func colorAttachmentDescriptor(pixelFormat mtl.PixelFormat, blend graphicsdriver.Blend, stencilMode stencilMode) mtl.RenderPipelineColorAttachmentDescriptor {
+	d := mtl.RenderPipelineColorAttachmentDescriptor{
+		PixelFormat:     pixelFormat,
+		BlendingEnabled: true,
+
+		DestinationAlphaBlendFactor: blendFactorToMetalBlendFactor(blend.BlendFactorDestinationAlpha),
+		DestinationRGBBlendFactor:   blendFactorToMetalBlendFactor(blend.BlendFactorDestinationRGB),
+		SourceAlphaBlendFactor:      blendFactorToMetalBlendFactor(blend.BlendFactorSourceAlpha),
+		SourceRGBBlendFactor:        blendFactorToMetalBlendFactor(blend.BlendFactorSourceRGB),
+		AlphaBlendOperation:         blendOperationToMetalBlendOperation(blend.BlendOperationAlpha),
+		RGBBlendOperation:           blendOperationToMetalBlendOperation(blend.BlendOperationRGB),
+	}
+	if stencilMode == noStencil || stencilMode == drawWithStencil {
+		d.WriteMask = mtl.ColorWriteMaskAll
+	} else {
+		d.WriteMask = mtl.ColorWriteMaskNone
+	}
+	return d
+}
@@ -28,6 +28,7 @@ type view struct {
 
 	windowChanged bool
 	vsyncDisabled bool
+	latencyMode   graphicsdriver.LatencyMode
 
 	device mtl.Device
 	ml     ca.MetalLayer
@@ -84,11 +85,29 @@ func (v *view) initialize(device mtl.Device, colorSpace graphicsdriver.ColorSpac
 	// nextDrawable took more than one second if the window has other controls like NSTextView (#1029).
 	v.ml.SetPresentsWithTransaction(false)
 
-	v.ml.SetMaximumDrawableCount(v.maximumDrawableCount())
+	v.ml.SetMaximumDrawableCount(v.drawableCount())
 
 	return nil
 }
 
+func (v *view) setLatencyMode(mode graphicsdriver.LatencyMode) {
+	v.latencyMode = mode
+	if v.ml != (ca.MetalLayer{}) {
+		v.ml.SetMaximumDrawableCount(v.drawableCount())
+	}
+}
+
+// drawableCount returns the maximum number of the drawables for the current latency mode.
+func (v *view) drawableCount() int {
+	switch v.latencyMode {
+	case graphicsdriver.LatencyModeLow:
+		return 2
+	case graphicsdriver.LatencyModeSmooth:
+		return 3
+	}
+	return v.maximumDrawableCount()
+}
+
 func (v *view) nextDrawable() ca.MetalDrawable {
 	d, err := v.ml.NextDrawable()
 	if err != nil {
@@ -220,7 +220,7 @@ func (c *context) blend(blend graphicsdriver.Blend) {
 	)
 }
 
-func (c *context) newTexture(width, height int) (textureNative, error) {
+func (c *context) newTexture(width, height int, format graphicsdriver.PixelFormat) (textureNative, error) {
 	t := c.ctx.CreateTexture()
 	if t <= 0 {
 		return 0, errors.New("opengl: creating texture failed")
@@ -242,13 +242,26 @@ func (c *context) newTexture(width, height int) (textureNative, error) {
 	// avoided.
 	//
 	// See also https://stackoverflow.com/questions/57734645.
-	c.ctx.TexImage2D(gl.TEXTURE_2D, 0, gl.RGBA, int32(width), int32(height), gl.RGBA, gl.UNSIGNED_BYTE, nil)
+	switch format {
+	case graphicsdriver.PixelFormatRGBA16F:
+		c.ctx.TexImage2D(gl.TEXTURE_2D, 0, gl.RGBA16F, int32(width), int32(height), gl.RGBA, gl.FLOAT, nil)
+	case graphicsdriver.PixelFormatRGBA32F:
+		c.ctx.TexImage2D(gl.TEXTURE_2D, 0, gl.RGBA32F, int32(width), int32(height), gl.RGBA, gl.FLOAT, nil)
+	default:
+		c.ctx.TexImage2D(gl.TEXTURE_2D, 0, gl.RGBA, int32(width), int32(height), gl.RGBA, gl.UNSIGNED_BYTE, nil)
+	}
 
 	return textureNative(t), nil
 }
 
-func (c *context) framebufferPixels(buf []byte, f *framebuffer, region image.Rectangle) error {
-	if got, want := len(buf), 4*region.Dx()*region.Dy(); got != want {
+// framebufferPixels reads the pixels of the framebuffer.
+// xtype is gl.UNSIGNED_BYTE or gl.FLOAT. For gl.FLOAT, buf is the bytes of float32 values.
+func (c *context) framebufferPixels(buf []byte, f *framebuffer, region image.Rectangle, xtype uint32) error {
+	bytesPerPixel := 4
+	if xtype == gl.FLOAT {
+		bytesPerPixel = 16
+	}
+	if got, want := len(buf), bytesPerPixel*region.Dx()*region.Dy(); got != want {
 		return fmt.Errorf("opengl: len(buf) must be %d but was %d at framebufferPixels", got, want)
 	}
 
@@ -258,7 +271,7 @@ func (c *context) framebufferPixels(buf []byte, f *framebuffer, region image.Rec
 	y := int32(region.Min.Y)
 	width := int32(region.Dx())
 	height := int32(region.Dy())
-	c.ctx.ReadPixels(buf, x, y, width, height, gl.RGBA, gl.UNSIGNED_BYTE)
+	c.ctx.ReadPixels(buf, x, y, width, height, gl.RGBA, xtype)
 	return nil
 }
 
@@ -279,7 +292,9 @@ func (c *context) deleteTexture(t textureNative) {
 	c.ctx.DeleteTexture(uint32(t))
 }
 
-func (c *context) newRenderbuffer(width, height int) (renderbufferNative, error) {
+// newRenderbuffer creates a stencil buffer.
+// If depth is true, newRenderbuffer creates a combined depth-stencil buffer.
+func (c *context) newRenderbuffer(width, height int, depth bool) (renderbufferNative, error) {
 	r := c.ctx.CreateRenderbuffer()
 	if r <= 0 {
 		return 0, errors.New("opengl: creating renderbuffer failed")
@@ -289,7 +304,10 @@ func (c *context) newRenderbuffer(width, height int) (renderbufferNative, error)
 	c.bindRenderbuffer(renderbuffer)
 
 	var stencilFormat uint32
-	if c.ctx.IsES() {
+	if depth {
+		// GL_DEPTH24_STENCIL8 is available with OpenGL 3.2, OpenGL ES 3.0, and WebGL 2.
+		stencilFormat = gl.DEPTH24_STENCIL8
+	} else if c.ctx.IsES() {
 		// https://docs.gl/es2/glRenderbufferStorage
 		// > Must be one of the following symbolic constants: GL_RGBA4, GL_RGB565, GL_RGB5_A1,
 		// > GL_DEPTH_COMPONENT16, or GL_STENCIL_INDEX8.
@@ -351,10 +369,42 @@ func (c *context) newFramebuffer(texture textureNative, width, height int) (*fra
 	}, nil
 }
 
-func (c *context) bindStencilBuffer(f framebufferNative, r renderbufferNative) error {
+// attachExtraColorBuffers attaches the textures to the bound framebuffer as the second and later color attachments,
+// and enables drawing to them for multiple render targets. A zero texture is skipped.
+func (c *context) attachExtraColorBuffers(textures []textureNative) {
+	bufs := make([]uint32, 1, 1+len(textures))
+	bufs[0] = gl.COLOR_ATTACHMENT0
+	for i, t := range textures {
+		if t == 0 {
+			bufs = append(bufs, gl.NONE)
+			continue
+		}
+		attachment := gl.COLOR_ATTACHMENT0 + uint32(i+1)
+		c.ctx.FramebufferTexture2D(gl.FRAMEBUFFER, attachment, gl.TEXTURE_2D, uint32(t), 0)
+		bufs = append(bufs, attachment)
+	}
+	c.ctx.DrawBuffers(bufs)
+}
+
+// detachExtraColorBuffers detaches the textures attached by attachExtraColorBuffers from the bound framebuffer.
+func (c *context) detachExtraColorBuffers(textures []textureNative) {
+	for i, t := range textures {
+		if t == 0 {
+			continue
+		}
+		c.ctx.FramebufferTexture2D(gl.FRAMEBUFFER, gl.COLOR_ATTACHMENT0+uint32(i+1), gl.TEXTURE_2D, 0, 0)
+	}
+	c.ctx.DrawBuffers([]uint32{gl.COLOR_ATTACHMENT0})
+}
+
+func (c *context) bindStencilBuffer(f framebufferNative, r renderbufferNative, depth bool) error {
 	c.bindFramebuffer(f)
 
 	c.ctx.FramebufferRenderbuffer(gl.FRAMEBUFFER, gl.STENCIL_ATTACHMENT, gl.RENDERBUFFER, uint32(r))
+	if depth {
+		// A combined depth-stencil buffer is attached to both the attachment points.
+		c.ctx.FramebufferRenderbuffer(gl.FRAMEBUFFER, gl.DEPTH_ATTACHMENT, gl.RENDERBUFFER, uint32(r))
+	}
 
 	if shouldCheckFramebufferStatus() {
 		if s := c.ctx.CheckFramebufferStatus(gl.FRAMEBUFFER); s != gl.FRAMEBUFFER_COMPLETE {
@@ -392,7 +442,7 @@ func (c *context) newShader(shaderType uint32, source string) (shader, error) {
 	return shader(s), nil
 }
 
-func (c *context) newProgram(shaders []shader, attributes []string) (program, error) {
+func (c *context) newProgram(shaders []shader, attributes []string, fragmentOutputCount int) (program, error) {
 	p := c.ctx.CreateProgram()
 	if p == 0 {
 		return 0, errors.New("opengl: glCreateProgram failed")
@@ -406,6 +456,13 @@ func (c *context) newProgram(shaders []shader, attributes []string) (program, er
 		c.ctx.BindAttribLocation(p, uint32(i), name)
 	}
 
+	// OpenGL ES specifies the locations of the fragment shader's outputs in the shader.
+	if !c.ctx.IsES() {
+		for i := 0; i < fragmentOutputCount; i++ {
+			c.ctx.BindFragDataLocation(p, uint32(i), glsl.FragmentColorName(i))
+		}
+	}
+
 	c.ctx.LinkProgram(p)
 	return program(p), nil
 }
@@ -0,0 +1,307 @@
+// Copyright 2026 The Ebitengine Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// This script is the interpreter of the command buffer in commandbuffer_js.go.
+// This is evaluated as a function body with an argument gl, a WebGL2RenderingContext, and returns the interpreter.
+//
+// The opcodes and the object kinds must be synced with commandbuffer_js.go.
+
+'use strict';
+
+const kindBuffer = 0;
+const kindFramebuffer = 1;
+const kindProgram = 2;
+const kindRenderbuffer = 3;
+const kindShader = 4;
+const kindTexture = 5;
+const kindVertexArray = 6;
+const kindUniformLocation = 7;
+
+const tables = [];
+for (let i = 0; i <= kindUniformLocation; i++) {
+  tables.push(new Map());
+}
+
+function obj(kind, id) {
+  if (id === 0) {
+    return null;
+  }
+  const v = tables[kind].get(id);
+  return v === undefined ? null : v;
+}
+
+function del(kind, id) {
+  const v = obj(kind, id);
+  tables[kind].delete(id);
+  return v;
+}
+
+let buffer = new ArrayBuffer(4096);
+let i32 = new Int32Array(buffer);
+let u32 = new Uint32Array(buffer);
+let f32 = new Float32Array(buffer);
+let u8 = new Uint8Array(buffer);
+
+return {
+  register(kind, id, value) {
+    tables[kind].set(id, value);
+  },
+
+  // bytes returns a Uint8Array whose length is at least byteLength.
+  bytes(byteLength) {
+    if (buffer.byteLength < byteLength) {
+      let l = buffer.byteLength;
+      while (l < byteLength) {
+        l *= 2;
+      }
+      buffer = new ArrayBuffer(l);
+      i32 = new Int32Array(buffer);
+      u32 = new Uint32Array(buffer);
+      f32 = new Float32Array(buffer);
+      u8 = new Uint8Array(buffer);
+    }
+    return u8;
+  },
+
+  flush(n) {
+    let p = 0;
+    while (p < n) {
+      const op = u32[p];
+      const a = p + 1;
+      switch (op) {
+      case 1:
+        gl.activeTexture(u32[a]);
+        p = a + 1;
+        break;
+      case 2:
+        gl.bindBuffer(u32[a], obj(kindBuffer, u32[a+1]));
+        p = a + 2;
+        break;
+      case 3:
+        gl.bindFramebuffer(u32[a], obj(kindFramebuffer, u32[a+1]));
+        p = a + 2;
+        break;
+      case 4:
+        gl.bindRenderbuffer(u32[a], obj(kindRenderbuffer, u32[a+1]));
+        p = a + 2;
+        break;
+      case 5:
+        gl.bindTexture(u32[a], obj(kindTexture, u32[a+1]));
+        p = a + 2;
+        break;
+      case 6:
+        gl.bindVertexArray(obj(kindVertexArray, u32[a]));
+        p = a + 1;
+        break;
+      case 7:
+        gl.blendEquationSeparate(u32[a], u32[a+1]);
+        p = a + 2;
+        break;
+      case 8:
+        gl.blendFuncSeparate(u32[a], u32[a+1], u32[a+2], u32[a+3]);
+        p = a + 4;
+        break;
+      case 9:
+        gl.bufferData(u32[a], i32[a+1], u32[a+2]);
+        p = a + 3;
+        break;
+      case 10: {
+        // The data is padded to 4 bytes.
+        const l = i32[a+2];
+        gl.bufferSubData(u32[a], i32[a+1], u8, (a + 3) * 4, l);
+        p = a + 3 + ((l + 3) >> 2);
+        break;
+      }
+      case 11:
+        gl.clear(u32[a]);
+        p = a + 1;
+        break;
+      case 12:
+        gl.colorMask(u32[a] !== 0, u32[a+1] !== 0, u32[a+2] !== 0, u32[a+3] !== 0);
+        p = a + 4;
+        break;
+      case 13:
+        gl.deleteBuffer(del(kindBuffer, u32[a]));
+        p = a + 1;
+        break;
+      case 14:
+        gl.deleteFramebuffer(del(kindFramebuffer, u32[a]));
+        p = a + 1;
+        break;
+      case 15: {
+        const id = u32[a];
+        gl.deleteProgram(del(kindProgram, id));
+        // Uniform locations are keyed by (program << 5) | index.
+        for (const key of tables[kindUniformLocation].keys()) {
+          if ((key >>> 5) === id) {
+            tables[kindUniformLocation].delete(key);
+          }
+        }
+        p = a + 1;
+        break;
+      }
+      case 16:
+        gl.deleteRenderbuffer(del(kindRenderbuffer, u32[a]));
+        p = a + 1;
+        break;
+      case 17:
+        gl.deleteShader(del(kindShader, u32[a]));
+        p = a + 1;
+        break;
+      case 18:
+        gl.deleteTexture(del(kindTexture, u32[a]));
+        p = a + 1;
+        break;
+      case 19:
+        gl.deleteVertexArray(del(kindVertexArray, u32[a]));
+        p = a + 1;
+        break;
+      case 20:
+        gl.disable(u32[a]);
+        p = a + 1;
+        break;
+      case 21:
+        gl.disableVertexAttribArray(u32[a]);
+        p = a + 1;
+        break;
+      case 22:
+        gl.drawElements(u32[a], i32[a+1], u32[a+2], i32[a+3]);
+        p = a + 4;
+        break;
+      case 23:
+        gl.enable(u32[a]);
+        p = a + 1;
+        break;
+      case 24:
+        gl.enableVertexAttribArray(u32[a]);
+        p = a + 1;
+        break;
+      case 25:
+        gl.framebufferRenderbuffer(u32[a], u32[a+1], u32[a+2], obj(kindRenderbuffer, u32[a+3]));
+        p = a + 4;
+        break;
+      case 26:
+        gl.framebufferTexture2D(u32[a], u32[a+1], u32[a+2], obj(kindTexture, u32[a+3]), i32[a+4]);
+        p = a + 5;
+        break;
+      case 27:
+        gl.pixelStorei(u32[a], i32[a+1]);
+        p = a + 2;
+        break;
+      case 28:
+        gl.renderbufferStorage(u32[a], u32[a+1], i32[a+2], i32[a+3]);
+        p = a + 4;
+        break;
+      case 29:
+        gl.scissor(i32[a], i32[a+1], i32[a+2], i32[a+3]);
+        p = a + 4;
+        break;
+      case 30:
+        gl.stencilFunc(u32[a], i32[a+1], u32[a+2]);
+        p = a + 3;
+        break;
+      case 31:
+        gl.stencilOpSeparate(u32[a], u32[a+1], u32[a+2], u32[a+3]);
+        p = a + 4;
+        break;
+      case 32:
+        gl.texImage2D(u32[a], i32[a+1], i32[a+2], i32[a+3], i32[a+4], 0, u32[a+5], u32[a+6], null);
+        p = a + 7;
+        break;
+      case 33:
+        gl.texParameteri(u32[a], u32[a+1], i32[a+2]);
+        p = a + 3;
+        break;
+      case 34:
+      case 36:
+      case 37:
+      case 38:
+      case 39:
+      case 40:
+      case 41:
+      case 42:
+      case 43:
+      case 44:
+      case 45: {
+        const l = obj(kindUniformLocation, u32[a]);
+        const n = u32[a+1];
+        const offset = a + 2;
+        switch (op) {
+        case 34:
+          gl.uniform1fv(l, f32, offset, n);
+          break;
+        case 36:
+          gl.uniform1iv(l, i32, offset, n);
+          break;
+        case 37:
+          gl.uniform2fv(l, f32, offset, n);
+          break;
+        case 38:
+          gl.uniform2iv(l, i32, offset, n);
+          break;
+        case 39:
+          gl.uniform3fv(l, f32, offset, n);
+          break;
+        case 40:
+          gl.uniform3iv(l, i32, offset, n);
+          break;
+        case 41:
+          gl.uniform4fv(l, f32, offset, n);
+          break;
+        case 42:
+          gl.uniform4iv(l, i32, offset, n);
+          break;
+        case 43:
+          gl.uniformMatrix2fv(l, false, f32, offset, n);
+          break;
+        case 44:
+          gl.uniformMatrix3fv(l, false, f32, offset, n);
+          break;
+        case 45:
+          gl.uniformMatrix4fv(l, false, f32, offset, n);
+          break;
+        }
+        p = offset + n;
+        break;
+      }
+      case 35:
+        gl.uniform1i(obj(kindUniformLocation, u32[a]), i32[a+1]);
+        p = a + 2;
+        break;
+      case 46:
+        gl.useProgram(obj(kindProgram, u32[a]));
+        p = a + 1;
+        break;
+      case 47:
+        gl.vertexAttribPointer(u32[a], i32[a+1], u32[a+2], u32[a+3] !== 0, i32[a+4], i32[a+5]);
+        p = a + 6;
+        break;
+      case 48:
+        gl.viewport(i32[a], i32[a+1], i32[a+2], i32[a+3]);
+        p = a + 4;
+        break;
+      case 49:
+        gl.depthFunc(u32[a]);
+        p = a + 1;
+        break;
+      case 50:
+        gl.depthMask(u32[a] !== 0);
+        p = a + 1;
+        break;
+      default:
+        throw new Error('gl: unexpected opcode: ' + op);
+      }
+    }
+  },
+};
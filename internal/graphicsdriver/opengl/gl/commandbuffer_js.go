@@ -0,0 +1,173 @@
+// Copyright 2026 The Ebitengine Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package gl
+
+import (
+	_ "embed"
+	"math"
+	"runtime"
+	"syscall/js"
+	"unsafe"
+)
+
+//go:embed commandbuffer.js
+var commandBufferJS string
+
+// Object kinds for the command buffer. These values must be synced with commandbuffer.js.
+const (
+	kindBuffer = iota
+	kindFramebuffer
+	kindProgram
+	kindRenderbuffer
+	kindShader
+	kindTexture
+	kindVertexArray
+	kindUniformLocation
+)
+
+// Opcodes for the command buffer. These values must be synced with commandbuffer.js.
+const (
+	opActiveTexture = iota + 1
+	opBindBuffer
+	opBindFramebuffer
+	opBindRenderbuffer
+	opBindTexture
+	opBindVertexArray
+	opBlendEquationSeparate
+	opBlendFuncSeparate
+	opBufferData
+	opBufferSubData
+	opClear
+	opColorMask
+	opDeleteBuffer
+	opDeleteFramebuffer
+	opDeleteProgram
+	opDeleteRenderbuffer
+	opDeleteShader
+	opDeleteTexture
+	opDeleteVertexArray
+	opDisable
+	opDisableVertexAttribArray
+	opDrawElements
+	opEnable
+	opEnableVertexAttribArray
+	opFramebufferRenderbuffer
+	opFramebufferTexture2D
+	opPixelStorei
+	opRenderbufferStorage
+	opScissor
+	opStencilFunc
+	opStencilOpSeparate
+	opTexImage2D
+	opTexParameteri
+	opUniform1fv
+	opUniform1i
+	opUniform1iv
+	opUniform2fv
+	opUniform2iv
+	opUniform3fv
+	opUniform3iv
+	opUniform4fv
+	opUniform4iv
+	opUniformMatrix2fv
+	opUniformMatrix3fv
+	opUniformMatrix4fv
+	opUseProgram
+	opVertexAttribPointer
+	opViewport
+	opDepthFunc
+	opDepthMask
+)
+
+// commandBuffer records WebGL calls that don't return values, and executes them at once by one syscall/js call.
+//
+// Calling a JavaScript function via syscall/js has a big overhead, and this overhead dominates when
+// there are many draw calls. A command buffer reduces the number of syscall/js calls to one per flush.
+type commandBuffer struct {
+	words []uint32
+
+	fnRegister js.Value
+	fnBytes    js.Value
+	fnFlush    js.Value
+
+	jsBytes       js.Value
+	jsBytesLength int
+}
+
+func newCommandBuffer(gl js.Value) *commandBuffer {
+	interpreter := js.Global().Get("Function").New("gl", commandBufferJS).Invoke(gl)
+	return &commandBuffer{
+		fnRegister: interpreter.Get("register").Call("bind", interpreter),
+		fnBytes:    interpreter.Get("bytes").Call("bind", interpreter),
+		fnFlush:    interpreter.Get("flush").Call("bind", interpreter),
+	}
+}
+
+// register registers a JavaScript object with an ID so that commands can refer the object.
+func (c *commandBuffer) register(kind int, id uint32, value js.Value) {
+	c.fnRegister.Invoke(kind, id, value)
+}
+
+func (c *commandBuffer) push(vs ...uint32) {
+	c.words = append(c.words, vs...)
+}
+
+func boolToUint32(v bool) uint32 {
+	if v {
+		return 1
+	}
+	return 0
+}
+
+func (c *commandBuffer) pushFloat32s(op uint32, location int32, vs []float32) {
+	c.push(op, uint32(location), uint32(len(vs)))
+	for _, v := range vs {
+		c.words = append(c.words, math.Float32bits(v))
+	}
+}
+
+func (c *commandBuffer) pushInt32s(op uint32, location int32, vs []int32) {
+	c.push(op, uint32(location), uint32(len(vs)))
+	for _, v := range vs {
+		c.words = append(c.words, uint32(v))
+	}
+}
+
+func (c *commandBuffer) pushBytes(bs []byte) {
+	n := (len(bs) + 3) / 4
+	start := len(c.words)
+	c.words = append(c.words, make([]uint32, n)...)
+	if len(bs) > 0 {
+		copy(unsafe.Slice((*byte)(unsafe.Pointer(&c.words[start])), n*4), bs)
+	}
+}
+
+// flush executes all the recorded commands.
+func (c *commandBuffer) flush() {
+	if len(c.words) == 0 {
+		return
+	}
+
+	byteLength := len(c.words) * 4
+	if c.jsBytesLength < byteLength {
+		c.jsBytes = c.fnBytes.Invoke(byteLength)
+		c.jsBytesLength = c.jsBytes.Length()
+	}
+	js.CopyBytesToJS(c.jsBytes, unsafe.Slice((*byte)(unsafe.Pointer(&c.words[0])), byteLength))
+	runtime.KeepAlive(c.words)
+
+	c.fnFlush.Invoke(len(c.words))
+	c.words = c.words[:0]
+}
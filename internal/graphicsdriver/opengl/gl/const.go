@@ -26,6 +26,9 @@ const (
 	COMPILE_STATUS        = 0x8B81
 	DECR_WRAP             = 0x8508
 	DEPTH24_STENCIL8      = 0x88F0
+	DEPTH_ATTACHMENT      = 0x8D00
+	DEPTH_BUFFER_BIT      = 0x0100
+	DEPTH_TEST            = 0x0B71
 	DST_ALPHA             = 0x0304
 	DST_COLOR             = 0x0306
 	DYNAMIC_DRAW          = 0x88E8
@@ -46,11 +49,13 @@ const (
 	INFO_LOG_LENGTH       = 0x8B84
 	INVERT                = 0x150A
 	KEEP                  = 0x1E00
+	LEQUAL                = 0x0203
 	LINK_STATUS           = 0x8B82
 	MAX                   = 0x8008
 	MAX_TEXTURE_SIZE      = 0x0D33
 	MIN                   = 0x8007
 	NEAREST               = 0x2600
+	NONE                  = 0
 	NO_ERROR              = 0
 	NOTEQUAL              = 0x0205
 	ONE                   = 1
@@ -63,6 +68,8 @@ const (
 	READ_WRITE            = 0x88BA
 	RENDERBUFFER          = 0x8D41
 	RGBA                  = 0x1908
+	RGBA16F               = 0x881A
+	RGBA32F               = 0x8814
 	SCISSOR_TEST          = 0x0C11
 	SHORT                 = 0x1402
 	SRC_ALPHA             = 0x0302
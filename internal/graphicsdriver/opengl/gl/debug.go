@@ -61,6 +61,14 @@ func (d *DebugContext) BindBuffer(arg0 uint32, arg1 uint32) {
 	}
 }
 
+func (d *DebugContext) BindFragDataLocation(arg0 uint32, arg1 uint32, arg2 string) {
+	d.Context.BindFragDataLocation(arg0, arg1, arg2)
+	fmt.Fprintln(os.Stderr, "BindFragDataLocation")
+	if e := d.Context.GetError(); e != NO_ERROR {
+		panic(fmt.Sprintf("gl: GetError() returned %d at BindFragDataLocation", e))
+	}
+}
+
 func (d *DebugContext) BindFramebuffer(arg0 uint32, arg1 uint32) {
 	d.Context.BindFramebuffer(arg0, arg1)
 	fmt.Fprintln(os.Stderr, "BindFramebuffer")
@@ -277,6 +285,22 @@ func (d *DebugContext) DeleteVertexArray(arg0 uint32) {
 	}
 }
 
+func (d *DebugContext) DepthFunc(arg0 uint32) {
+	d.Context.DepthFunc(arg0)
+	fmt.Fprintln(os.Stderr, "DepthFunc")
+	if e := d.Context.GetError(); e != NO_ERROR {
+		panic(fmt.Sprintf("gl: GetError() returned %d at DepthFunc", e))
+	}
+}
+
+func (d *DebugContext) DepthMask(arg0 bool) {
+	d.Context.DepthMask(arg0)
+	fmt.Fprintln(os.Stderr, "DepthMask")
+	if e := d.Context.GetError(); e != NO_ERROR {
+		panic(fmt.Sprintf("gl: GetError() returned %d at DepthMask", e))
+	}
+}
+
 func (d *DebugContext) Disable(arg0 uint32) {
 	d.Context.Disable(arg0)
 	fmt.Fprintln(os.Stderr, "Disable")
@@ -293,6 +317,14 @@ func (d *DebugContext) DisableVertexAttribArray(arg0 uint32) {
 	}
 }
 
+func (d *DebugContext) DrawBuffers(arg0 []uint32) {
+	d.Context.DrawBuffers(arg0)
+	fmt.Fprintln(os.Stderr, "DrawBuffers")
+	if e := d.Context.GetError(); e != NO_ERROR {
+		panic(fmt.Sprintf("gl: GetError() returned %d at DrawBuffers", e))
+	}
+}
+
 func (d *DebugContext) DrawElements(arg0 uint32, arg1 int32, arg2 uint32, arg3 int) {
 	d.Context.DrawElements(arg0, arg1, arg2, arg3)
 	fmt.Fprintln(os.Stderr, "DrawElements")
@@ -317,6 +349,14 @@ func (d *DebugContext) EnableVertexAttribArray(arg0 uint32) {
 	}
 }
 
+func (d *DebugContext) Finish() {
+	d.Context.Finish()
+	fmt.Fprintln(os.Stderr, "Finish")
+	if e := d.Context.GetError(); e != NO_ERROR {
+		panic(fmt.Sprintf("gl: GetError() returned %d at Finish", e))
+	}
+}
+
 func (d *DebugContext) Flush() {
 	d.Context.Flush()
 	fmt.Fprintln(os.Stderr, "Flush")
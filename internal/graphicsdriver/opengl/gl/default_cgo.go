@@ -36,6 +36,10 @@ package gl
 //   typedef void (*fn)(GLenum target, GLuint buffer);
 //   ((fn)(fnptr))(target, buffer);
 // }
+// static void glowBindFragDataLocation(uintptr_t fnptr, GLuint program, GLuint color, const GLchar* name) {
+//   typedef void (*fn)(GLuint program, GLuint color, const GLchar* name);
+//   ((fn)(fnptr))(program, color, name);
+// }
 // static void glowBindFramebuffer(uintptr_t fnptr, GLenum target, GLuint framebuffer) {
 //   typedef void (*fn)(GLenum target, GLuint framebuffer);
 //   ((fn)(fnptr))(target, framebuffer);
@@ -120,6 +124,14 @@ package gl
 //   typedef void (*fn)(GLsizei n, const GLuint* arrays);
 //   ((fn)(fnptr))(n, arrays);
 // }
+// static void glowDepthFunc(uintptr_t fnptr, GLenum func) {
+//   typedef void (*fn)(GLenum func);
+//   ((fn)(fnptr))(func);
+// }
+// static void glowDepthMask(uintptr_t fnptr, GLboolean flag) {
+//   typedef void (*fn)(GLboolean flag);
+//   ((fn)(fnptr))(flag);
+// }
 // static void glowDisable(uintptr_t fnptr, GLenum cap) {
 //   typedef void (*fn)(GLenum cap);
 //   ((fn)(fnptr))(cap);
@@ -128,6 +140,10 @@ package gl
 //   typedef void (*fn)(GLuint index);
 //   ((fn)(fnptr))(index);
 // }
+// static void glowDrawBuffers(uintptr_t fnptr, GLsizei n, const GLenum* bufs) {
+//   typedef void (*fn)(GLsizei n, const GLenum* bufs);
+//   ((fn)(fnptr))(n, bufs);
+// }
 // static void glowDrawElements(uintptr_t fnptr, GLenum mode, GLsizei count, GLenum type, const uintptr_t indices) {
 //   typedef void (*fn)(GLenum mode, GLsizei count, GLenum type, const uintptr_t indices);
 //   ((fn)(fnptr))(mode, count, type, indices);
@@ -140,6 +156,10 @@ package gl
 //   typedef void (*fn)(GLuint index);
 //   ((fn)(fnptr))(index);
 // }
+// static void glowFinish(uintptr_t fnptr) {
+//   typedef void (*fn)();
+//   ((fn)(fnptr))();
+// }
 // static void glowFlush(uintptr_t fnptr) {
 //   typedef void (*fn)();
 //   ((fn)(fnptr))();
@@ -320,6 +340,7 @@ type defaultContext struct {
 	gpAttachShader             C.uintptr_t
 	gpBindAttribLocation       C.uintptr_t
 	gpBindBuffer               C.uintptr_t
+	gpBindFragDataLocation     C.uintptr_t
 	gpBindFramebuffer          C.uintptr_t
 	gpBindRenderbuffer         C.uintptr_t
 	gpBindTexture              C.uintptr_t
@@ -341,11 +362,15 @@ type defaultContext struct {
 	gpDeleteShader             C.uintptr_t
 	gpDeleteTextures           C.uintptr_t
 	gpDeleteVertexArrays       C.uintptr_t
+	gpDepthFunc                C.uintptr_t
+	gpDepthMask                C.uintptr_t
 	gpDisable                  C.uintptr_t
 	gpDisableVertexAttribArray C.uintptr_t
+	gpDrawBuffers              C.uintptr_t
 	gpDrawElements             C.uintptr_t
 	gpEnable                   C.uintptr_t
 	gpEnableVertexAttribArray  C.uintptr_t
+	gpFinish                   C.uintptr_t
 	gpFlush                    C.uintptr_t
 	gpFramebufferRenderbuffer  C.uintptr_t
 	gpFramebufferTexture2D     C.uintptr_t
@@ -429,6 +454,12 @@ func (c *defaultContext) BindBuffer(target uint32, buffer uint32) {
 	C.glowBindBuffer(c.gpBindBuffer, C.GLenum(target), C.GLuint(buffer))
 }
 
+func (c *defaultContext) BindFragDataLocation(program uint32, color uint32, name string) {
+	cname := C.CString(name)
+	defer C.free(unsafe.Pointer(cname))
+	C.glowBindFragDataLocation(c.gpBindFragDataLocation, C.GLuint(program), C.GLuint(color), (*C.GLchar)(unsafe.Pointer(cname)))
+}
+
 func (c *defaultContext) BindFramebuffer(target uint32, framebuffer uint32) {
 	C.glowBindFramebuffer(c.gpBindFramebuffer, C.GLenum(target), C.GLuint(framebuffer))
 }
@@ -547,6 +578,14 @@ func (c *defaultContext) DeleteVertexArray(array uint32) {
 	C.glowDeleteVertexArrays(c.gpDeleteVertexArrays, 1, (*C.GLuint)(unsafe.Pointer(&array)))
 }
 
+func (c *defaultContext) DepthFunc(xfunc uint32) {
+	C.glowDepthFunc(c.gpDepthFunc, C.GLenum(xfunc))
+}
+
+func (c *defaultContext) DepthMask(flag bool) {
+	C.glowDepthMask(c.gpDepthMask, C.GLboolean(boolToInt(flag)))
+}
+
 func (c *defaultContext) Disable(cap uint32) {
 	C.glowDisable(c.gpDisable, C.GLenum(cap))
 }
@@ -555,6 +594,11 @@ func (c *defaultContext) DisableVertexAttribArray(index uint32) {
 	C.glowDisableVertexAttribArray(c.gpDisableVertexAttribArray, C.GLuint(index))
 }
 
+func (c *defaultContext) DrawBuffers(bufs []uint32) {
+	C.glowDrawBuffers(c.gpDrawBuffers, C.GLsizei(len(bufs)), (*C.GLenum)(unsafe.Pointer(&bufs[0])))
+	runtime.KeepAlive(bufs)
+}
+
 func (c *defaultContext) DrawElements(mode uint32, count int32, xtype uint32, offset int) {
 	C.glowDrawElements(c.gpDrawElements, C.GLenum(mode), C.GLsizei(count), C.GLenum(xtype), C.uintptr_t(offset))
 }
@@ -567,6 +611,10 @@ func (c *defaultContext) EnableVertexAttribArray(index uint32) {
 	C.glowEnableVertexAttribArray(c.gpEnableVertexAttribArray, C.GLuint(index))
 }
 
+func (c *defaultContext) Finish() {
+	C.glowFinish(c.gpFinish)
+}
+
 func (c *defaultContext) Flush() {
 	C.glowFlush(c.gpFlush)
 }
@@ -682,7 +730,11 @@ func (c *defaultContext) TexParameteri(target uint32, pname uint32, param int32)
 }
 
 func (c *defaultContext) TexSubImage2D(target uint32, level int32, xoffset int32, yoffset int32, width int32, height int32, format uint32, xtype uint32, pixels []byte) {
-	C.glowTexSubImage2D(c.gpTexSubImage2D, C.GLenum(target), C.GLint(level), C.GLint(xoffset), C.GLint(yoffset), C.GLsizei(width), C.GLsizei(height), C.GLenum(format), C.GLenum(xtype), unsafe.Pointer(&pixels[0]))
+	var ptr unsafe.Pointer
+	if len(pixels) > 0 {
+		ptr = unsafe.Pointer(&pixels[0])
+	}
+	C.glowTexSubImage2D(c.gpTexSubImage2D, C.GLenum(target), C.GLint(level), C.GLint(xoffset), C.GLint(yoffset), C.GLsizei(width), C.GLsizei(height), C.GLenum(format), C.GLenum(xtype), ptr)
 	runtime.KeepAlive(pixels)
 }
 
@@ -764,6 +816,7 @@ func (c *defaultContext) LoadFunctions() error {
 	c.gpAttachShader = C.uintptr_t(g.get("glAttachShader"))
 	c.gpBindAttribLocation = C.uintptr_t(g.get("glBindAttribLocation"))
 	c.gpBindBuffer = C.uintptr_t(g.get("glBindBuffer"))
+	c.gpBindFragDataLocation = C.uintptr_t(g.get("glBindFragDataLocation"))
 	c.gpBindFramebuffer = C.uintptr_t(g.get("glBindFramebuffer"))
 	c.gpBindRenderbuffer = C.uintptr_t(g.get("glBindRenderbuffer"))
 	c.gpBindTexture = C.uintptr_t(g.get("glBindTexture"))
@@ -785,11 +838,15 @@ func (c *defaultContext) LoadFunctions() error {
 	c.gpDeleteShader = C.uintptr_t(g.get("glDeleteShader"))
 	c.gpDeleteTextures = C.uintptr_t(g.get("glDeleteTextures"))
 	c.gpDeleteVertexArrays = C.uintptr_t(g.get("glDeleteVertexArrays"))
+	c.gpDepthFunc = C.uintptr_t(g.get("glDepthFunc"))
+	c.gpDepthMask = C.uintptr_t(g.get("glDepthMask"))
 	c.gpDisable = C.uintptr_t(g.get("glDisable"))
 	c.gpDisableVertexAttribArray = C.uintptr_t(g.get("glDisableVertexAttribArray"))
+	c.gpDrawBuffers = C.uintptr_t(g.get("glDrawBuffers"))
 	c.gpDrawElements = C.uintptr_t(g.get("glDrawElements"))
 	c.gpEnable = C.uintptr_t(g.get("glEnable"))
 	c.gpEnableVertexAttribArray = C.uintptr_t(g.get("glEnableVertexAttribArray"))
+	c.gpFinish = C.uintptr_t(g.get("glFinish"))
 	c.gpFlush = C.uintptr_t(g.get("glFlush"))
 	c.gpFramebufferRenderbuffer = C.uintptr_t(g.get("glFramebufferRenderbuffer"))
 	c.gpFramebufferTexture2D = C.uintptr_t(g.get("glFramebufferTexture2D"))
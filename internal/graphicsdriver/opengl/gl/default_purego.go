@@ -28,6 +28,7 @@ type defaultContext struct {
 	gpAttachShader             uintptr
 	gpBindAttribLocation       uintptr
 	gpBindBuffer               uintptr
+	gpBindFragDataLocation     uintptr
 	gpBindFramebuffer          uintptr
 	gpBindRenderbuffer         uintptr
 	gpBindTexture              uintptr
@@ -49,11 +50,15 @@ type defaultContext struct {
 	gpDeleteShader             uintptr
 	gpDeleteTextures           uintptr
 	gpDeleteVertexArrays       uintptr
+	gpDepthFunc                uintptr
+	gpDepthMask                uintptr
 	gpDisable                  uintptr
 	gpDisableVertexAttribArray uintptr
+	gpDrawBuffers              uintptr
 	gpDrawElements             uintptr
 	gpEnable                   uintptr
 	gpEnableVertexAttribArray  uintptr
+	gpFinish                   uintptr
 	gpFlush                    uintptr
 	gpFramebufferRenderbuffer  uintptr
 	gpFramebufferTexture2D     uintptr
@@ -137,6 +142,12 @@ func (c *defaultContext) BindBuffer(target uint32, buffer uint32) {
 	purego.SyscallN(c.gpBindBuffer, uintptr(target), uintptr(buffer))
 }
 
+func (c *defaultContext) BindFragDataLocation(program uint32, color uint32, name string) {
+	cname, free := cStr(name)
+	defer free()
+	purego.SyscallN(c.gpBindFragDataLocation, uintptr(program), uintptr(color), uintptr(unsafe.Pointer(cname)))
+}
+
 func (c *defaultContext) BindFramebuffer(target uint32, framebuffer uint32) {
 	purego.SyscallN(c.gpBindFramebuffer, uintptr(target), uintptr(framebuffer))
 }
@@ -255,6 +266,14 @@ func (c *defaultContext) DeleteVertexArray(array uint32) {
 	purego.SyscallN(c.gpDeleteVertexArrays, 1, uintptr(unsafe.Pointer(&array)))
 }
 
+func (c *defaultContext) DepthFunc(xfunc uint32) {
+	purego.SyscallN(c.gpDepthFunc, uintptr(xfunc))
+}
+
+func (c *defaultContext) DepthMask(flag bool) {
+	purego.SyscallN(c.gpDepthMask, uintptr(boolToInt(flag)))
+}
+
 func (c *defaultContext) Disable(cap uint32) {
 	purego.SyscallN(c.gpDisable, uintptr(cap))
 }
@@ -263,6 +282,11 @@ func (c *defaultContext) DisableVertexAttribArray(index uint32) {
 	purego.SyscallN(c.gpDisableVertexAttribArray, uintptr(index))
 }
 
+func (c *defaultContext) DrawBuffers(bufs []uint32) {
+	purego.SyscallN(c.gpDrawBuffers, uintptr(len(bufs)), uintptr(unsafe.Pointer(&bufs[0])))
+	runtime.KeepAlive(bufs)
+}
+
 func (c *defaultContext) DrawElements(mode uint32, count int32, xtype uint32, offset int) {
 	purego.SyscallN(c.gpDrawElements, uintptr(mode), uintptr(count), uintptr(xtype), uintptr(offset))
 }
@@ -275,6 +299,10 @@ func (c *defaultContext) EnableVertexAttribArray(index uint32) {
 	purego.SyscallN(c.gpEnableVertexAttribArray, uintptr(index))
 }
 
+func (c *defaultContext) Finish() {
+	purego.SyscallN(c.gpFinish)
+}
+
 func (c *defaultContext) Flush() {
 	purego.SyscallN(c.gpFlush)
 }
@@ -390,7 +418,11 @@ func (c *defaultContext) TexParameteri(target uint32, pname uint32, param int32)
 }
 
 func (c *defaultContext) TexSubImage2D(target uint32, level int32, xoffset int32, yoffset int32, width int32, height int32, format uint32, xtype uint32, pixels []byte) {
-	purego.SyscallN(c.gpTexSubImage2D, uintptr(target), uintptr(level), uintptr(xoffset), uintptr(yoffset), uintptr(width), uintptr(height), uintptr(format), uintptr(xtype), uintptr(unsafe.Pointer(&pixels[0])))
+	var ptr unsafe.Pointer
+	if len(pixels) > 0 {
+		ptr = unsafe.Pointer(&pixels[0])
+	}
+	purego.SyscallN(c.gpTexSubImage2D, uintptr(target), uintptr(level), uintptr(xoffset), uintptr(yoffset), uintptr(width), uintptr(height), uintptr(format), uintptr(xtype), uintptr(ptr))
 	runtime.KeepAlive(pixels)
 }
 
@@ -472,6 +504,10 @@ func (c *defaultContext) LoadFunctions() error {
 	c.gpAttachShader = g.get("glAttachShader")
 	c.gpBindAttribLocation = g.get("glBindAttribLocation")
 	c.gpBindBuffer = g.get("glBindBuffer")
+	if !c.isES {
+		// glBindFragDataLocation is not available in OpenGL ES, where the fragment shader specifies the locations.
+		c.gpBindFragDataLocation = g.get("glBindFragDataLocation")
+	}
 	c.gpBindFramebuffer = g.get("glBindFramebuffer")
 	c.gpBindRenderbuffer = g.get("glBindRenderbuffer")
 	c.gpBindTexture = g.get("glBindTexture")
@@ -493,11 +529,15 @@ func (c *defaultContext) LoadFunctions() error {
 	c.gpDeleteShader = g.get("glDeleteShader")
 	c.gpDeleteTextures = g.get("glDeleteTextures")
 	c.gpDeleteVertexArrays = g.get("glDeleteVertexArrays")
+	c.gpDepthFunc = g.get("glDepthFunc")
+	c.gpDepthMask = g.get("glDepthMask")
 	c.gpDisable = g.get("glDisable")
 	c.gpDisableVertexAttribArray = g.get("glDisableVertexAttribArray")
+	c.gpDrawBuffers = g.get("glDrawBuffers")
 	c.gpDrawElements = g.get("glDrawElements")
 	c.gpEnable = g.get("glEnable")
 	c.gpEnableVertexAttribArray = g.get("glEnableVertexAttribArray")
+	c.gpFinish = g.get("glFinish")
 	c.gpFlush = g.get("glFlush")
 	c.gpFramebufferRenderbuffer = g.get("glFramebufferRenderbuffer")
 	c.gpFramebufferTexture2D = g.get("glFramebufferTexture2D")
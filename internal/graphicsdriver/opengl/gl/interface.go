@@ -31,6 +31,7 @@ type Context interface {
 	AttachShader(program uint32, shader uint32)
 	BindAttribLocation(program uint32, index uint32, name string)
 	BindBuffer(target uint32, buffer uint32)
+	BindFragDataLocation(program uint32, color uint32, name string)
 	BindFramebuffer(target uint32, framebuffer uint32)
 	BindRenderbuffer(target uint32, renderbuffer uint32)
 	BindTexture(target uint32, texture uint32)
@@ -57,11 +58,15 @@ type Context interface {
 	DeleteShader(shader uint32)
 	DeleteTexture(texture uint32)
 	DeleteVertexArray(array uint32)
+	DepthFunc(func_ uint32)
+	DepthMask(flag bool)
 	Disable(cap uint32)
 	DisableVertexAttribArray(index uint32)
+	DrawBuffers(bufs []uint32)
 	DrawElements(mode uint32, count int32, xtype uint32, offset int)
 	Enable(cap uint32)
 	EnableVertexAttribArray(index uint32)
+	Finish()
 	Flush()
 	FramebufferRenderbuffer(target uint32, attachment uint32, renderbuffertarget uint32, renderbuffer uint32)
 	FramebufferTexture2D(target uint32, attachment uint32, textarget uint32, texture uint32, level int32)
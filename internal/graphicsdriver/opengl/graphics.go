@@ -32,9 +32,13 @@ type activatedTexture struct {
 }
 
 type Graphics struct {
-	state   openGLState
-	context context
-	vsync   bool
+	state       openGLState
+	context     context
+	vsync       bool
+	latencyMode graphicsdriver.LatencyMode
+
+	// adaptiveVsync is true when a negative swap interval is used with vsync.
+	adaptiveVsync bool
 
 	nextImageID graphicsdriver.ImageID
 	images      map[graphicsdriver.ImageID]*Image
@@ -85,6 +89,11 @@ func (g *Graphics) End(present bool) error {
 		if err := g.swapBuffers(); err != nil {
 			return err
 		}
+		// Drivers can queue some frames after swapping buffers.
+		// In the low latency mode, wait for the GPU so that the next frame doesn't start before this frame is done.
+		if g.latencyMode == graphicsdriver.LatencyModeLow {
+			g.context.ctx.Finish()
+		}
 	}
 
 	return nil
@@ -121,16 +130,25 @@ func (g *Graphics) genNextShaderID() graphicsdriver.ShaderID {
 }
 
 func (g *Graphics) NewImage(width, height int) (graphicsdriver.Image, error) {
+	return g.newImage(width, height, graphicsdriver.PixelFormatRGBA8)
+}
+
+func (g *Graphics) NewFloatImage(width, height int, format graphicsdriver.PixelFormat) (graphicsdriver.Image, error) {
+	return g.newImage(width, height, format)
+}
+
+func (g *Graphics) newImage(width, height int, format graphicsdriver.PixelFormat) (graphicsdriver.Image, error) {
 	i := &Image{
 		id:       g.genNextImageID(),
 		graphics: g,
 		width:    width,
 		height:   height,
+		format:   format,
 	}
 	w := graphics.InternalImageSize(width)
 	h := graphics.InternalImageSize(height)
 	g.checkSize(w, h)
-	t, err := g.context.newTexture(w, h)
+	t, err := g.context.newTexture(w, h, format)
 	if err != nil {
 		return nil, err
 	}
@@ -139,6 +157,30 @@ func (g *Graphics) NewImage(width, height int) (graphicsdriver.Image, error) {
 	return i, nil
 }
 
+func (g *Graphics) NewStreamingImage(width, height int) (graphicsdriver.Image, error) {
+	img, err := g.NewImage(width, height)
+	if err != nil {
+		return nil, err
+	}
+	i := img.(*Image)
+	i.pixelBuffers = make([]buffer, streamingPixelBufferCount)
+	for idx := range i.pixelBuffers {
+		i.pixelBuffers[idx] = buffer(g.context.ctx.CreateBuffer())
+	}
+	return i, nil
+}
+
+func (g *Graphics) NewDepthImage(width, height int) (graphicsdriver.Image, error) {
+	img, err := g.NewImage(width, height)
+	if err != nil {
+		return nil, err
+	}
+	i := img.(*Image)
+	// The depth buffer is created lazily at the first draw using it.
+	i.depth = true
+	return i, nil
+}
+
 func (g *Graphics) NewScreenFramebufferImage(width, height int) (graphicsdriver.Image, error) {
 	g.checkSize(width, height)
 	i := &Image{
@@ -198,18 +240,33 @@ func (g *Graphics) uniformVariableName(idx int) string {
 	return name
 }
 
-func (g *Graphics) DrawTriangles(dstID graphicsdriver.ImageID, srcIDs [graphics.ShaderSrcImageCount]graphicsdriver.ImageID, shaderID graphicsdriver.ShaderID, dstRegions []graphicsdriver.DstRegion, indexOffset int, blend graphicsdriver.Blend, uniforms []uint32, fillRule graphicsdriver.FillRule) error {
+func (g *Graphics) DrawTriangles(dstIDs [graphics.ShaderDstImageCount]graphicsdriver.ImageID, srcIDs [graphics.ShaderSrcImageCount]graphicsdriver.ImageID, shaderID graphicsdriver.ShaderID, dstRegions []graphicsdriver.DstRegion, indexOffset int, blend graphicsdriver.Blend, uniforms []uint32, fillRule graphicsdriver.FillRule) error {
 	if shaderID == graphicsdriver.InvalidShaderID {
 		return fmt.Errorf("opengl: shader ID is invalid")
 	}
 
-	destination := g.images[dstID]
+	destination := g.images[dstIDs[0]]
 
 	g.drawCalled = true
 
 	if err := destination.setViewport(); err != nil {
 		return err
 	}
+
+	// Render the other destinations as the color attachments of the destination's framebuffer for multiple render targets.
+	var extraTextures [graphics.ShaderDstImageCount - 1]textureNative
+	var mrt bool
+	for i, dstID := range dstIDs[1:] {
+		if dstID == graphicsdriver.InvalidImageID {
+			continue
+		}
+		extraTextures[i] = g.images[dstID].texture
+		mrt = true
+	}
+	if mrt {
+		g.context.attachExtraColorBuffers(extraTextures[:])
+		defer g.context.detachExtraColorBuffers(extraTextures[:])
+	}
 	g.context.blend(blend)
 
 	shader := g.shaders[shaderID]
@@ -253,6 +310,7 @@ func (g *Graphics) DrawTriangles(dstID graphicsdriver.ImageID, srcIDs [graphics.
 	if err := g.useProgram(program, g.uniformVars, imgs); err != nil {
 		return err
 	}
+	g.state.useArrayBufferLayout(&g.context, arrayBufferLayoutForShader(shader.ir))
 
 	for i := range g.uniformVars {
 		g.uniformVars[i] = uniformVariable{}
@@ -266,6 +324,21 @@ func (g *Graphics) DrawTriangles(dstID graphicsdriver.ImageID, srcIDs [graphics.
 		g.context.ctx.Enable(gl.STENCIL_TEST)
 	}
 
+	useDepth := destination.depth && (shader.ir.DepthTest || shader.ir.DepthWrite)
+	if useDepth {
+		if err := destination.ensureStencilBuffer(); err != nil {
+			return err
+		}
+		g.context.ctx.Enable(gl.DEPTH_TEST)
+		if shader.ir.DepthTest {
+			g.context.ctx.DepthFunc(gl.LEQUAL)
+		} else {
+			// Writing a depth value requires GL_DEPTH_TEST enabled.
+			g.context.ctx.DepthFunc(gl.ALWAYS)
+		}
+		g.context.ctx.DepthMask(shader.ir.DepthWrite)
+	}
+
 	for _, dstRegion := range dstRegions {
 		g.context.ctx.Scissor(
 			int32(dstRegion.Region.Min.X),
@@ -301,6 +374,10 @@ func (g *Graphics) DrawTriangles(dstID graphicsdriver.ImageID, srcIDs [graphics.
 	if fillRule != graphicsdriver.FillRuleFillAll {
 		g.context.ctx.Disable(gl.STENCIL_TEST)
 	}
+	if useDepth {
+		g.context.ctx.DepthMask(true)
+		g.context.ctx.Disable(gl.DEPTH_TEST)
+	}
 
 	return nil
 }
@@ -309,6 +386,10 @@ func (g *Graphics) SetVsyncEnabled(enabled bool) {
 	g.vsync = enabled
 }
 
+func (g *Graphics) SetLatencyMode(mode graphicsdriver.LatencyMode) {
+	g.latencyMode = mode
+}
+
 func (g *Graphics) NeedsClearingScreen() bool {
 	return true
 }
@@ -0,0 +1,65 @@
+// Copyright 2026 The Ebitengine Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build (darwin && !ios) || windows || ((freebsd || linux || netbsd || openbsd) && !android && !nintendosdk && !playstation5)
+
+package opengl
+
+import (
+	"github.com/duplicants-ai/ebiten/internal/graphicsdriver"
+)
+
+// SetVsyncMode implements graphicsdriver.VsyncModeSetter.
+//
+// VsyncModeAdaptive is realized by a negative swap interval, which requires WGL_EXT_swap_control_tear or GLX_EXT_swap_control_tear.
+// OpenGL doesn't have a mailbox mode.
+func (g *Graphics) SetVsyncMode(mode graphicsdriver.VsyncMode) graphicsdriver.VsyncMode {
+	g.vsync = mode != graphicsdriver.VsyncModeOff
+	g.adaptiveVsync = false
+
+	switch mode {
+	case graphicsdriver.VsyncModeAdaptive:
+		if !g.isSwapControlTearAvailable() {
+			return graphicsdriver.VsyncModeOn
+		}
+		g.adaptiveVsync = true
+		return graphicsdriver.VsyncModeAdaptive
+	case graphicsdriver.VsyncModeMailbox:
+		return graphicsdriver.VsyncModeOn
+	}
+	return mode
+}
+
+func (g *Graphics) isSwapControlTearAvailable() bool {
+	if g.window == nil {
+		return false
+	}
+	for _, ext := range []string{"WGL_EXT_swap_control_tear", "GLX_EXT_swap_control_tear"} {
+		if ok, err := g.window.ExtensionSupported(ext); err == nil && ok {
+			return true
+		}
+	}
+	return false
+}
+
+func (g *Graphics) swapInterval() int {
+	if !g.vsync {
+		return 0
+	}
+	if g.adaptiveVsync {
+		// A negative interval allows the driver to swap immediately when a frame arrives late.
+		return -1
+	}
+	return 1
+}
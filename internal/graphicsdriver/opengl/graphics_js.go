@@ -41,6 +41,9 @@ func NewGraphics(canvas js.Value, colorSpace graphicsdriver.ColorSpace) (graphic
 		return nil, fmt.Errorf("opengl: getContext for webgl2 failed")
 	}
 
+	// Enable rendering to floating-point textures. If the extension is not available, such images cannot be rendered.
+	glContext.Call("getExtension", "EXT_color_buffer_float")
+
 	switch colorSpace {
 	case graphicsdriver.ColorSpaceSRGB:
 		glContext.Set("drawingBufferColorSpace", "srgb")
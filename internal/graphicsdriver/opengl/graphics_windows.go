@@ -67,14 +67,8 @@ func (g *Graphics) swapBuffers() error {
 	// SwapInterval is affected by the current monitor of the window.
 	// This needs to be called at least after SetMonitor.
 	// Without SwapInterval after SetMonitor, vsynch doesn't work (#375).
-	if g.vsync {
-		if err := g.window.SwapInterval(1); err != nil {
-			return err
-		}
-	} else {
-		if err := g.window.SwapInterval(0); err != nil {
-			return err
-		}
+	if err := g.window.SwapInterval(g.swapInterval()); err != nil {
+		return err
 	}
 
 	if err := g.window.SwapBuffers(); err != nil {
@@ -18,12 +18,16 @@ package opengl
 
 import (
 	"errors"
+	"unsafe"
 
 	"github.com/duplicants-ai/ebiten/internal/graphics"
 	"github.com/duplicants-ai/ebiten/internal/graphicsdriver"
 	"github.com/duplicants-ai/ebiten/internal/graphicsdriver/opengl/gl"
 )
 
+// streamingPixelBufferCount is the number of the pixel buffers for a streaming image.
+const streamingPixelBufferCount = 3
+
 type Image struct {
 	id          graphicsdriver.ImageID
 	graphics    *Graphics
@@ -33,6 +37,18 @@ type Image struct {
 	width       int
 	height      int
 	screen      bool
+
+	// pixelBuffers is a ring of pixel unpack buffers to upload pixels for a streaming image.
+	// pixelBuffers is nil for a regular image.
+	pixelBuffers     []buffer
+	pixelBufferIndex int
+
+	// depth reports whether the image has a depth buffer.
+	// For an image with a depth buffer, stencil is a combined depth-stencil buffer.
+	depth bool
+
+	// format is the pixel format of the texture.
+	format graphicsdriver.PixelFormat
 }
 
 // framebuffer is a wrapper of OpenGL's framebuffer.
@@ -56,6 +72,10 @@ func (i *Image) Dispose() {
 	if i.stencil != 0 {
 		i.graphics.context.deleteRenderbuffer(i.stencil)
 	}
+	for _, b := range i.pixelBuffers {
+		i.graphics.context.ctx.DeleteBuffer(uint32(b))
+	}
+	i.pixelBuffers = nil
 
 	i.graphics.removeImage(i)
 }
@@ -73,13 +93,54 @@ func (i *Image) ReadPixels(args []graphicsdriver.PixelsArgs) error {
 		return err
 	}
 	for _, arg := range args {
-		if err := i.graphics.context.framebufferPixels(arg.Pixels, i.framebuffer, arg.Region); err != nil {
+		if i.isFloat() {
+			// Read the float values and quantize them.
+			pix := make([]float32, len(arg.Pixels))
+			if err := i.graphics.context.framebufferPixels(float32sToBytes(pix), i.framebuffer, arg.Region, gl.FLOAT); err != nil {
+				return err
+			}
+			graphics.Float32sToBytes(arg.Pixels, pix)
+			continue
+		}
+		if err := i.graphics.context.framebufferPixels(arg.Pixels, i.framebuffer, arg.Region, gl.UNSIGNED_BYTE); err != nil {
 			return err
 		}
 	}
 	return nil
 }
 
+func (i *Image) ReadPixelsFloat32(args []graphicsdriver.PixelsFloat32Args) error {
+	if err := i.ensureFramebuffer(); err != nil {
+		return err
+	}
+	for _, arg := range args {
+		if !i.isFloat() {
+			pix := make([]byte, len(arg.Pixels))
+			if err := i.graphics.context.framebufferPixels(pix, i.framebuffer, arg.Region, gl.UNSIGNED_BYTE); err != nil {
+				return err
+			}
+			graphics.BytesToFloat32s(arg.Pixels, pix)
+			continue
+		}
+		if err := i.graphics.context.framebufferPixels(float32sToBytes(arg.Pixels), i.framebuffer, arg.Region, gl.FLOAT); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (i *Image) isFloat() bool {
+	return i.format != graphicsdriver.PixelFormatRGBA8
+}
+
+// float32sToBytes returns the byte representation of the float32 slice without copying.
+func float32sToBytes(pix []float32) []byte {
+	if len(pix) == 0 {
+		return nil
+	}
+	return unsafe.Slice((*byte)(unsafe.Pointer(&pix[0])), 4*len(pix))
+}
+
 func (i *Image) viewportSize() (int, int) {
 	if i.screen {
 		// The (default) framebuffer size can't be converted to a power of 2.
@@ -118,15 +179,23 @@ func (i *Image) ensureStencilBuffer() error {
 		return err
 	}
 
-	r, err := i.graphics.context.newRenderbuffer(i.viewportSize())
+	w, h := i.viewportSize()
+	r, err := i.graphics.context.newRenderbuffer(w, h, i.depth)
 	if err != nil {
 		return err
 	}
 	i.stencil = r
 
-	if err := i.graphics.context.bindStencilBuffer(i.framebuffer.native, i.stencil); err != nil {
+	if err := i.graphics.context.bindStencilBuffer(i.framebuffer.native, i.stencil, i.depth); err != nil {
 		return err
 	}
+
+	if i.depth {
+		// Clear the depth buffer with the farthest value.
+		// The scissor test is always enabled, and affects glClear.
+		i.graphics.context.ctx.Scissor(0, 0, int32(w), int32(h))
+		i.graphics.context.ctx.Clear(gl.DEPTH_BUFFER_BIT)
+	}
 	return nil
 }
 
@@ -151,8 +220,64 @@ func (i *Image) WritePixels(args []graphicsdriver.PixelsArgs) error {
 		y := int32(a.Region.Min.Y)
 		width := int32(a.Region.Dx())
 		height := int32(a.Region.Dy())
+		if i.pixelBuffers != nil {
+			i.writePixelsViaPixelBuffer(x, y, width, height, a.Pixels)
+			continue
+		}
+		if i.isFloat() {
+			pix := make([]float32, len(a.Pixels))
+			graphics.BytesToFloat32s(pix, a.Pixels)
+			i.graphics.context.ctx.TexSubImage2D(gl.TEXTURE_2D, 0, x, y, width, height, gl.RGBA, gl.FLOAT, float32sToBytes(pix))
+			continue
+		}
 		i.graphics.context.ctx.TexSubImage2D(gl.TEXTURE_2D, 0, x, y, width, height, gl.RGBA, gl.UNSIGNED_BYTE, a.Pixels)
 	}
 
 	return nil
 }
+
+func (i *Image) WritePixelsFloat32(args []graphicsdriver.PixelsFloat32Args) error {
+	if i.screen {
+		return errors.New("opengl: WritePixelsFloat32 cannot be called on the screen")
+	}
+	if len(args) == 0 {
+		return nil
+	}
+
+	// glFlush is necessary on Android. See WritePixels.
+	if i.graphics.drawCalled {
+		i.graphics.context.ctx.Flush()
+	}
+	i.graphics.drawCalled = false
+
+	i.graphics.context.bindTexture(i.texture)
+	for _, a := range args {
+		x := int32(a.Region.Min.X)
+		y := int32(a.Region.Min.Y)
+		width := int32(a.Region.Dx())
+		height := int32(a.Region.Dy())
+		if !i.isFloat() {
+			pix := make([]byte, len(a.Pixels))
+			graphics.Float32sToBytes(pix, a.Pixels)
+			i.graphics.context.ctx.TexSubImage2D(gl.TEXTURE_2D, 0, x, y, width, height, gl.RGBA, gl.UNSIGNED_BYTE, pix)
+			continue
+		}
+		i.graphics.context.ctx.TexSubImage2D(gl.TEXTURE_2D, 0, x, y, width, height, gl.RGBA, gl.FLOAT, float32sToBytes(a.Pixels))
+	}
+
+	return nil
+}
+
+func (i *Image) writePixelsViaPixelBuffer(x, y, width, height int32, pixels []byte) {
+	b := i.pixelBuffers[i.pixelBufferIndex]
+	i.pixelBufferIndex = (i.pixelBufferIndex + 1) % len(i.pixelBuffers)
+
+	ctx := i.graphics.context.ctx
+	ctx.BindBuffer(gl.PIXEL_UNPACK_BUFFER, uint32(b))
+	// Reallocate the buffer's storage so that the driver doesn't have to wait for GPU to finish reading the previous pixels.
+	ctx.BufferInit(gl.PIXEL_UNPACK_BUFFER, len(pixels), gl.STREAM_DRAW)
+	ctx.BufferSubData(gl.PIXEL_UNPACK_BUFFER, 0, pixels)
+	// While a pixel unpack buffer is bound, the pixels are read from the buffer. nil means the offset 0.
+	ctx.TexSubImage2D(gl.TEXTURE_2D, 0, x, y, width, height, gl.RGBA, gl.UNSIGNED_BYTE, nil)
+	ctx.BindBuffer(gl.PIXEL_UNPACK_BUFFER, 0)
+}
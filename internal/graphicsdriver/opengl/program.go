@@ -92,11 +92,24 @@ func (a *arrayBufferLayout) disable(context *context) {
 	}
 }
 
-// theArrayBufferLayout is the array buffer layout for Ebitengine.
-var theArrayBufferLayout arrayBufferLayout
+var (
+	// theArrayBufferLayout is the array buffer layout for Ebitengine.
+	theArrayBufferLayout = newArrayBufferLayout(graphics.VertexFloatCount)
 
-func init() {
-	theArrayBufferLayout = arrayBufferLayout{
+	// theArrayBufferLayoutWithAttributes is the array buffer layout for a shader using the extra vertex attributes.
+	theArrayBufferLayoutWithAttributes = newArrayBufferLayout(graphics.VertexFloatCountWithAttributes)
+)
+
+// arrayBufferLayoutForShader returns the array buffer layout for the shader program.
+func arrayBufferLayoutForShader(program *shaderir.Program) *arrayBufferLayout {
+	if graphics.ShaderVertexFloatCount(program) == graphics.VertexFloatCountWithAttributes {
+		return theArrayBufferLayoutWithAttributes
+	}
+	return theArrayBufferLayout
+}
+
+func newArrayBufferLayout(vertexFloatCount int) *arrayBufferLayout {
+	a := &arrayBufferLayout{
 		// Note that GL_MAX_VERTEX_ATTRIBS is at least 16.
 		parts: []arrayBufferLayoutPart{
 			{
@@ -113,20 +126,21 @@ func init() {
 			},
 		},
 	}
-	n := theArrayBufferLayout.float32Count()
-	diff := graphics.VertexFloatCount - n
+	n := a.float32Count()
+	diff := vertexFloatCount - n
 	if diff == 0 {
-		return
+		return a
 	}
 	if diff%4 != 0 {
 		panic("opengl: unexpected attribute layout")
 	}
 	for i := 0; i < diff/4; i++ {
-		theArrayBufferLayout.addPart(arrayBufferLayoutPart{
+		a.addPart(arrayBufferLayoutPart{
 			name: fmt.Sprintf("A%d", i+3),
 			num:  4,
 		})
 	}
+	return a
 }
 
 type openGLState struct {
@@ -142,6 +156,9 @@ type openGLState struct {
 
 	elementArrayBufferSizeInBytes int
 
+	// lastArrayBufferLayout is the array buffer layout enabled for the current array buffer.
+	lastArrayBufferLayout *arrayBufferLayout
+
 	lastProgram       program
 	lastUniforms      map[string][]uint32
 	lastActiveTexture int
@@ -178,6 +195,7 @@ func (s *openGLState) reset(context *context) error {
 	s.elementArrayBuffer = 0
 	s.elementArrayBufferSizeInBytes = 0
 	s.vertexArray = 0
+	s.lastArrayBufferLayout = nil
 
 	return nil
 }
@@ -211,7 +229,8 @@ func (s *openGLState) setVertices(context *context, vertices []float32, indices
 		s.arrayBufferSizeInBytes = newSize
 
 		// Reenable the array buffer layout explicitly after resetting the array buffer.
-		theArrayBufferLayout.enable(context)
+		// The layout is enabled at useArrayBufferLayout.
+		s.lastArrayBufferLayout = nil
 	}
 
 	if size := len(indices) * int(unsafe.Sizeof(indices[0])); s.elementArrayBufferSizeInBytes < size {
@@ -232,6 +251,19 @@ func (s *openGLState) setVertices(context *context, vertices []float32, indices
 	context.ctx.BufferSubData(gl.ELEMENT_ARRAY_BUFFER, 0, is)
 }
 
+// useArrayBufferLayout enables the array buffer layout for the current array buffer if needed.
+// The vertices for a shader using the extra vertex attributes have a different layout from the others.
+func (s *openGLState) useArrayBufferLayout(context *context, layout *arrayBufferLayout) {
+	if s.lastArrayBufferLayout == layout {
+		return
+	}
+	if s.lastArrayBufferLayout != nil {
+		s.lastArrayBufferLayout.disable(context)
+	}
+	layout.enable(context)
+	s.lastArrayBufferLayout = layout
+}
+
 func (s *openGLState) resetLastUniforms() {
 	for k := range s.lastUniforms {
 		delete(s.lastUniforms, k)
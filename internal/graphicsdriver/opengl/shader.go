@@ -18,6 +18,7 @@ package opengl
 
 import (
 	"fmt"
+	"regexp"
 
 	"github.com/duplicants-ai/ebiten/internal/graphicsdriver"
 	"github.com/duplicants-ai/ebiten/internal/graphicsdriver/opengl/gl"
@@ -25,6 +26,10 @@ import (
 	"github.com/duplicants-ai/ebiten/internal/shaderir/glsl"
 )
 
+// glslErrorLocationRe matches the location in a GLSL compiler's error log, e.g., "0:12(5):", "0:12:", or "0(12) :".
+// The first submatch is the line number.
+var glslErrorLocationRe = regexp.MustCompile(`\b\d+[:(](\d+)\)?(?:\(\d+\))?\s*:`)
+
 type Shader struct {
 	id       graphicsdriver.ShaderID
 	graphics *Graphics
@@ -55,7 +60,7 @@ func (s *Shader) Dispose() {
 }
 
 func (s *Shader) compile() error {
-	vssrc, fssrc := glsl.Compile(s.ir, s.graphics.context.glslVersion())
+	vssrc, fssrc, vsmap, fsmap := glsl.CompileWithSourceMaps(s.ir, s.graphics.context.glslVersion())
 
 	vs, err := s.graphics.context.newShader(gl.VERTEX_SHADER, vssrc)
 	if err != nil {
@@ -69,7 +74,7 @@ func (s *Shader) compile() error {
 	}
 	defer s.graphics.context.ctx.DeleteShader(uint32(fs))
 
-	p, err := s.graphics.context.newProgram([]shader{vs, fs}, theArrayBufferLayout.names())
+	p, err := s.graphics.context.newProgram([]shader{vs, fs}, arrayBufferLayoutForShader(s.ir).names(), s.ir.FragmentOutputCount)
 	if err != nil {
 		return err
 	}
@@ -80,6 +85,9 @@ func (s *Shader) compile() error {
 		programInfo := s.graphics.context.ctx.GetProgramInfoLog(uint32(p))
 		vertexShaderInfo := s.graphics.context.ctx.GetShaderInfoLog(uint32(vs))
 		fragmentShaderInfo := s.graphics.context.ctx.GetShaderInfoLog(uint32(fs))
+		// Show the positions in the Kage source instead of the generated GLSL, if possible.
+		vertexShaderInfo, _ = vsmap.MapErrorLog(vertexShaderInfo, glslErrorLocationRe)
+		fragmentShaderInfo, _ = fsmap.MapErrorLog(fragmentShaderInfo, glslErrorLocationRe)
 		return fmt.Errorf("opengl: program error: %s\nvertex shader error: %s\nvertex shader source: %s\nfragment shader error: %s\nfragment shader source: %s",
 			programInfo, vertexShaderInfo, vssrc, fragmentShaderInfo, fssrc)
 	}
@@ -25,15 +25,15 @@ extern "C" ebitengine_Error ebitengine_NewImage(int *image, int width,
   return {};
 }
 
-extern "C" void ebitengine_ReadPixels(int image, uint8_t *pixels,
-                                      ebitengine_Region region) {}
-
-extern "C" ebitengine_Error ebitengine_FlushReadPixels(int image) { return {}; }
-
-extern "C" void ebitengine_WritePixels(int image, const uint8_t *pixels,
-                                       ebitengine_Region region) {}
+extern "C" ebitengine_Error
+ebitengine_ReadPixels(int image, const ebitengine_PixelsArgs *args,
+                      int arg_count) {
+  return {};
+}
 
-extern "C" ebitengine_Error ebitengine_FlushWritePixels(int image) {
+extern "C" ebitengine_Error
+ebitengine_WritePixels(int image, const ebitengine_PixelsArgs *args,
+                       int arg_count) {
   return {};
 }
 
@@ -53,7 +53,8 @@ extern "C" void ebitengine_SetVertices(const float *vertices, int vertex_count,
                                        int index_count) {}
 
 extern "C" ebitengine_Error
-ebitengine_DrawTriangles(int dst, const int *srcs, int src_count, int shader,
+ebitengine_DrawTriangles(const int *dsts, int dst_count, const int *srcs,
+                         int src_count, int shader,
                          const ebitengine_DstRegion *dst_regions,
                          int dst_region_count, int index_offset,
                          ebitengine_Blend blend, const uint32_t *uniforms,
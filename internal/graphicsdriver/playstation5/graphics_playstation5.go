@@ -54,6 +54,11 @@ func (e *playstation5Error) Error() string {
 }
 
 type Graphics struct {
+	// The buffers below are reused to avoid allocations for every call.
+	cDsts       [graphics.ShaderDstImageCount]C.int
+	cSrcs       [graphics.ShaderSrcImageCount]C.int
+	cDstRegions []C.ebitengine_DstRegion
+	cPixelsArgs []C.ebitengine_PixelsArgs
 }
 
 func NewGraphics() (*Graphics, error) {
@@ -99,7 +104,8 @@ func (g *Graphics) NewImage(width, height int) (graphicsdriver.Image, error) {
 		return nil, newPlaystation5Error("(*playstation5.Graphics).NewImage", err)
 	}
 	return &Image{
-		id: graphicsdriver.ImageID(id),
+		id:       graphicsdriver.ImageID(id),
+		graphics: g,
 	}, nil
 }
 
@@ -109,7 +115,8 @@ func (g *Graphics) NewScreenFramebufferImage(width, height int) (graphicsdriver.
 		return nil, newPlaystation5Error("(*playstation5.Graphics).NewScreenFramebufferImage", err)
 	}
 	return &Image{
-		id: graphicsdriver.ImageID(id),
+		id:       graphicsdriver.ImageID(id),
+		graphics: g,
 	}, nil
 }
 
@@ -141,23 +148,23 @@ func (g *Graphics) NewShader(program *shaderir.Program) (graphicsdriver.Shader,
 	}, nil
 }
 
-func (g *Graphics) DrawTriangles(dst graphicsdriver.ImageID, srcs [graphics.ShaderSrcImageCount]graphicsdriver.ImageID, shader graphicsdriver.ShaderID, dstRegions []graphicsdriver.DstRegion, indexOffset int, blend graphicsdriver.Blend, uniforms []uint32, fillRule graphicsdriver.FillRule) error {
-	cSrcs := make([]C.int, len(srcs))
+func (g *Graphics) DrawTriangles(dsts [graphics.ShaderDstImageCount]graphicsdriver.ImageID, srcs [graphics.ShaderSrcImageCount]graphicsdriver.ImageID, shader graphicsdriver.ShaderID, dstRegions []graphicsdriver.DstRegion, indexOffset int, blend graphicsdriver.Blend, uniforms []uint32, fillRule graphicsdriver.FillRule) error {
+	for i, dst := range dsts {
+		g.cDsts[i] = C.int(dst)
+	}
 	for i, src := range srcs {
-		cSrcs[i] = C.int(src)
+		g.cSrcs[i] = C.int(src)
 	}
-	defer runtime.KeepAlive(cSrcs)
 
-	cDstRegions := make([]C.ebitengine_DstRegion, len(dstRegions))
-	defer runtime.KeepAlive(cDstRegions)
-	for i, r := range dstRegions {
-		cDstRegions[i] = C.ebitengine_DstRegion{
+	g.cDstRegions = g.cDstRegions[:0]
+	for _, r := range dstRegions {
+		g.cDstRegions = append(g.cDstRegions, C.ebitengine_DstRegion{
 			min_x:       C.int(r.Region.Min.X),
 			min_y:       C.int(r.Region.Min.Y),
 			max_x:       C.int(r.Region.Max.X),
 			max_y:       C.int(r.Region.Max.Y),
 			index_count: C.int(r.IndexCount),
-		}
+		})
 	}
 
 	cBlend := C.ebitengine_Blend{
@@ -169,20 +176,39 @@ func (g *Graphics) DrawTriangles(dst graphicsdriver.ImageID, srcs [graphics.Shad
 		operation_alpha:  C.uint8_t(blend.BlendOperationAlpha),
 	}
 
-	cUniforms := make([]C.uint32_t, len(uniforms))
-	defer runtime.KeepAlive(cUniforms)
-	for i, u := range uniforms {
-		cUniforms[i] = C.uint32_t(u)
-	}
+	// uint32 and uint32_t have the same memory layout, then the uniforms can be passed without conversion.
+	defer runtime.KeepAlive(uniforms)
+	cUniforms := (*C.uint32_t)(unsafe.Pointer(unsafe.SliceData(uniforms)))
 
-	if err := C.ebitengine_DrawTriangles(C.int(dst), unsafe.SliceData(cSrcs), C.int(len(cSrcs)), C.int(shader), unsafe.SliceData(cDstRegions), C.int(len(cDstRegions)), C.int(indexOffset), cBlend, unsafe.SliceData(cUniforms), C.int(len(cUniforms)), C.int(fillRule)); !C.ebitengine_IsErrorNil(&err) {
+	if err := C.ebitengine_DrawTriangles(&g.cDsts[0], C.int(len(g.cDsts)), &g.cSrcs[0], C.int(len(g.cSrcs)), C.int(shader), unsafe.SliceData(g.cDstRegions), C.int(len(g.cDstRegions)), C.int(indexOffset), cBlend, cUniforms, C.int(len(uniforms)), C.int(fillRule)); !C.ebitengine_IsErrorNil(&err) {
 		return newPlaystation5Error("(*playstation5.Graphics).DrawTriangles", err)
 	}
 	return nil
 }
 
+// pixelsArgsToC converts args to the C representation, and pins the pixels so that they can be referred from C.
+// The returned slice is valid until the next call of pixelsArgsToC.
+func (g *Graphics) pixelsArgsToC(args []graphicsdriver.PixelsArgs, pinner *runtime.Pinner) []C.ebitengine_PixelsArgs {
+	g.cPixelsArgs = g.cPixelsArgs[:0]
+	for _, a := range args {
+		pixels := unsafe.SliceData(a.Pixels)
+		pinner.Pin(pixels)
+		g.cPixelsArgs = append(g.cPixelsArgs, C.ebitengine_PixelsArgs{
+			pixels: (*C.uint8_t)(unsafe.Pointer(pixels)),
+			region: C.ebitengine_Region{
+				min_x: C.int(a.Region.Min.X),
+				min_y: C.int(a.Region.Min.Y),
+				max_x: C.int(a.Region.Max.X),
+				max_y: C.int(a.Region.Max.Y),
+			},
+		})
+	}
+	return g.cPixelsArgs
+}
+
 type Image struct {
-	id graphicsdriver.ImageID
+	id       graphicsdriver.ImageID
+	graphics *Graphics
 }
 
 func (i *Image) ID() graphicsdriver.ImageID {
@@ -194,32 +220,22 @@ func (i *Image) Dispose() {
 }
 
 func (i *Image) ReadPixels(args []graphicsdriver.PixelsArgs) error {
-	for _, a := range args {
-		region := C.ebitengine_Region{
-			min_x: C.int(a.Region.Min.X),
-			min_y: C.int(a.Region.Min.Y),
-			max_x: C.int(a.Region.Max.X),
-			max_y: C.int(a.Region.Max.Y),
-		}
-		C.ebitengine_ReadPixels(C.int(i.id), (*C.uint8_t)(unsafe.Pointer(unsafe.SliceData(a.Pixels))), region)
-	}
-	if err := C.ebitengine_FlushReadPixels(C.int(i.id)); !C.ebitengine_IsErrorNil(&err) {
+	var pinner runtime.Pinner
+	defer pinner.Unpin()
+
+	cArgs := i.graphics.pixelsArgsToC(args, &pinner)
+	if err := C.ebitengine_ReadPixels(C.int(i.id), unsafe.SliceData(cArgs), C.int(len(cArgs))); !C.ebitengine_IsErrorNil(&err) {
 		return newPlaystation5Error("(*playstation5.Image).ReadPixels", err)
 	}
 	return nil
 }
 
 func (i *Image) WritePixels(args []graphicsdriver.PixelsArgs) error {
-	for _, a := range args {
-		region := C.ebitengine_Region{
-			min_x: C.int(a.Region.Min.X),
-			min_y: C.int(a.Region.Min.Y),
-			max_x: C.int(a.Region.Max.X),
-			max_y: C.int(a.Region.Max.Y),
-		}
-		C.ebitengine_WritePixels(C.int(i.id), (*C.uint8_t)(unsafe.Pointer(unsafe.SliceData(a.Pixels))), region)
-	}
-	if err := C.ebitengine_FlushWritePixels(C.int(i.id)); !C.ebitengine_IsErrorNil(&err) {
+	var pinner runtime.Pinner
+	defer pinner.Unpin()
+
+	cArgs := i.graphics.pixelsArgsToC(args, &pinner)
+	if err := C.ebitengine_WritePixels(C.int(i.id), unsafe.SliceData(cArgs), C.int(len(cArgs))); !C.ebitengine_IsErrorNil(&err) {
 		return newPlaystation5Error("(*playstation5.Image).WritePixels", err)
 	}
 	return nil
@@ -43,6 +43,11 @@ typedef struct ebitengine_Region {
   int max_y;
 } ebitengine_Region;
 
+typedef struct ebitengine_PixelsArgs {
+  uint8_t *pixels;
+  ebitengine_Region region;
+} ebitengine_PixelsArgs;
+
 typedef struct ebitengine_DstRegion {
   int min_x;
   int min_y;
@@ -89,12 +94,12 @@ ebitengine_Error ebitengine_InitializeGraphics(void);
 ebitengine_Error ebitengine_NewImage(int *image, int width, int height);
 ebitengine_Error ebitengine_NewScreenFramebufferImage(int *image, int width,
                                                       int height);
-void ebitengine_ReadPixels(int image, uint8_t *pixels,
-                           ebitengine_Region region);
-ebitengine_Error ebitengine_FlushReadPixels(int image);
-void ebitengine_WritePixels(int image, const uint8_t *pixels,
-                            ebitengine_Region region);
-ebitengine_Error ebitengine_FlushWritePixels(int image);
+ebitengine_Error ebitengine_ReadPixels(int image,
+                                      const ebitengine_PixelsArgs *args,
+                                      int arg_count);
+ebitengine_Error ebitengine_WritePixels(int image,
+                                       const ebitengine_PixelsArgs *args,
+                                       int arg_count);
 void ebitengine_DisposeImage(int id);
 
 void ebitengine_Begin();
@@ -103,7 +108,8 @@ void ebitengine_SetVertices(const float *vertices, int vertex_count,
                             const uint32_t *indices, int index_count);
 
 ebitengine_Error
-ebitengine_DrawTriangles(int dst, const int *srcs, int src_count, int shader,
+ebitengine_DrawTriangles(const int *dsts, int dst_count, const int *srcs,
+                         int src_count, int shader,
                          const ebitengine_DstRegion *dst_regions,
                          int dst_region_count, int indexOffset,
                          ebitengine_Blend blend, const uint32_t *uniforms,
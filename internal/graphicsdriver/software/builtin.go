@@ -0,0 +1,513 @@
+// Copyright 2026 The Ebitengine Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package software
+
+import (
+	"fmt"
+	"math"
+
+	"github.com/duplicants-ai/ebiten/internal/shaderir"
+)
+
+func (c *compiler) builtinCall(e *shaderir.Expr) (exprFunc, shaderir.Type, error) {
+	s := c.s
+	f := e.Exprs[0].BuiltinFunc
+	argExprs := e.Exprs[1:]
+
+	switch f {
+	case shaderir.TexelAt, shaderir.TexelFetch:
+		if len(argExprs) != 2 || argExprs[0].Type != shaderir.TextureVariable {
+			return nil, shaderir.Type{}, fmt.Errorf("software: invalid arguments for %s", f)
+		}
+		pos, _, err := c.expr(&argExprs[1])
+		if err != nil {
+			return nil, shaderir.Type{}, err
+		}
+		return c.texel(argExprs[0].Index, pos, f == shaderir.TexelFetch || s.ir.Unit == shaderir.Pixels), shaderir.Type{Main: shaderir.Vec4}, nil
+
+	case shaderir.DiscardF:
+		return func(mask uint8) []float64 {
+			s.discard |= mask
+			return nil
+		}, shaderir.Type{}, nil
+	}
+
+	var args []exprFunc
+	var types []shaderir.Type
+	for i := range argExprs {
+		a, t, err := c.expr(&argExprs[i])
+		if err != nil {
+			return nil, shaderir.Type{}, err
+		}
+		args = append(args, a)
+		types = append(types, t)
+	}
+
+	switch f {
+	case shaderir.Len, shaderir.Cap:
+		if len(types) != 1 || types[0].Main != shaderir.Array {
+			return nil, shaderir.Type{}, fmt.Errorf("software: invalid arguments for %s", f)
+		}
+		buf := broadcast([]float64{float64(types[0].Length)})
+		return func(mask uint8) []float64 {
+			return buf
+		}, shaderir.Type{Main: shaderir.Int}, nil
+
+	case shaderir.BoolF:
+		return constructor(args, types, shaderir.Type{Main: shaderir.Bool})
+	case shaderir.IntF:
+		return constructor(args, types, shaderir.Type{Main: shaderir.Int})
+	case shaderir.FloatF:
+		return constructor(args, types, shaderir.Type{Main: shaderir.Float})
+	case shaderir.Vec2F:
+		return constructor(args, types, shaderir.Type{Main: shaderir.Vec2})
+	case shaderir.Vec3F:
+		return constructor(args, types, shaderir.Type{Main: shaderir.Vec3})
+	case shaderir.Vec4F:
+		return constructor(args, types, shaderir.Type{Main: shaderir.Vec4})
+	case shaderir.IVec2F:
+		return constructor(args, types, shaderir.Type{Main: shaderir.IVec2})
+	case shaderir.IVec3F:
+		return constructor(args, types, shaderir.Type{Main: shaderir.IVec3})
+	case shaderir.IVec4F:
+		return constructor(args, types, shaderir.Type{Main: shaderir.IVec4})
+	case shaderir.Mat2F:
+		return constructor(args, types, shaderir.Type{Main: shaderir.Mat2})
+	case shaderir.Mat3F:
+		return constructor(args, types, shaderir.Type{Main: shaderir.Mat3})
+	case shaderir.Mat4F:
+		return constructor(args, types, shaderir.Type{Main: shaderir.Mat4})
+
+	case shaderir.Radians:
+		return componentWise(args, types, func(x []float64) float64 { return x[0] * math.Pi / 180 })
+	case shaderir.Degrees:
+		return componentWise(args, types, func(x []float64) float64 { return x[0] * 180 / math.Pi })
+	case shaderir.Sin:
+		return componentWise(args, types, func(x []float64) float64 { return math.Sin(x[0]) })
+	case shaderir.Cos:
+		return componentWise(args, types, func(x []float64) float64 { return math.Cos(x[0]) })
+	case shaderir.Tan:
+		return componentWise(args, types, func(x []float64) float64 { return math.Tan(x[0]) })
+	case shaderir.Asin:
+		return componentWise(args, types, func(x []float64) float64 { return math.Asin(x[0]) })
+	case shaderir.Acos:
+		return componentWise(args, types, func(x []float64) float64 { return math.Acos(x[0]) })
+	case shaderir.Atan:
+		if len(args) == 2 {
+			return componentWise(args, types, func(x []float64) float64 { return math.Atan2(x[0], x[1]) })
+		}
+		return componentWise(args, types, func(x []float64) float64 { return math.Atan(x[0]) })
+	case shaderir.Atan2:
+		return componentWise(args, types, func(x []float64) float64 { return math.Atan2(x[0], x[1]) })
+	case shaderir.Pow:
+		return componentWise(args, types, func(x []float64) float64 { return math.Pow(x[0], x[1]) })
+	case shaderir.Exp:
+		return componentWise(args, types, func(x []float64) float64 { return math.Exp(x[0]) })
+	case shaderir.Log:
+		return componentWise(args, types, func(x []float64) float64 { return math.Log(x[0]) })
+	case shaderir.Exp2:
+		return componentWise(args, types, func(x []float64) float64 { return math.Exp2(x[0]) })
+	case shaderir.Log2:
+		return componentWise(args, types, func(x []float64) float64 { return math.Log2(x[0]) })
+	case shaderir.Sqrt:
+		return componentWise(args, types, func(x []float64) float64 { return math.Sqrt(x[0]) })
+	case shaderir.Inversesqrt:
+		return componentWise(args, types, func(x []float64) float64 { return 1 / math.Sqrt(x[0]) })
+	case shaderir.Abs:
+		return componentWise(args, types, func(x []float64) float64 { return math.Abs(x[0]) })
+	case shaderir.Sign:
+		return componentWise(args, types, func(x []float64) float64 {
+			switch {
+			case x[0] > 0:
+				return 1
+			case x[0] < 0:
+				return -1
+			}
+			return 0
+		})
+	case shaderir.Floor:
+		return componentWise(args, types, func(x []float64) float64 { return math.Floor(x[0]) })
+	case shaderir.Ceil:
+		return componentWise(args, types, func(x []float64) float64 { return math.Ceil(x[0]) })
+	case shaderir.Fract:
+		return componentWise(args, types, func(x []float64) float64 { return x[0] - math.Floor(x[0]) })
+	case shaderir.Mod:
+		return componentWise(args, types, func(x []float64) float64 { return x[0] - x[1]*math.Floor(x[0]/x[1]) })
+	case shaderir.Min:
+		return componentWise(args, types, func(x []float64) float64 { return math.Min(x[0], x[1]) })
+	case shaderir.Max:
+		return componentWise(args, types, func(x []float64) float64 { return math.Max(x[0], x[1]) })
+	case shaderir.Clamp:
+		return componentWise(args, types, func(x []float64) float64 { return math.Min(math.Max(x[0], x[1]), x[2]) })
+	case shaderir.Mix:
+		return componentWise(args, types, func(x []float64) float64 { return x[0]*(1-x[2]) + x[1]*x[2] })
+	case shaderir.Step:
+		return componentWise(args, types, func(x []float64) float64 {
+			if x[1] < x[0] {
+				return 0
+			}
+			return 1
+		})
+	case shaderir.Smoothstep:
+		return componentWise(args, types, func(x []float64) float64 {
+			t := math.Min(math.Max((x[2]-x[0])/(x[1]-x[0]), 0), 1)
+			return t * t * (3 - 2*t)
+		})
+
+	case shaderir.Length, shaderir.Distance, shaderir.Dot, shaderir.Cross, shaderir.Normalize, shaderir.Faceforward, shaderir.Reflect, shaderir.Refract:
+		return geometric(f, args, types)
+
+	case shaderir.Transpose:
+		if len(args) != 1 || !types[0].IsMatrix() {
+			return nil, shaderir.Type{}, fmt.Errorf("software: invalid arguments for %s", f)
+		}
+		n := types[0].MatrixSize()
+		buf := make([]float64, laneCount*n*n)
+		arg := args[0]
+		return func(mask uint8) []float64 {
+			v := arg(mask)
+			for l := 0; l < laneCount; l++ {
+				for col := 0; col < n; col++ {
+					for row := 0; row < n; row++ {
+						buf[l*n*n+col*n+row] = v[l*n*n+row*n+col]
+					}
+				}
+			}
+			return buf
+		}, types[0], nil
+
+	case shaderir.Dfdx, shaderir.Dfdy, shaderir.Fwidth:
+		if len(args) != 1 {
+			return nil, shaderir.Type{}, fmt.Errorf("software: invalid arguments for %s", f)
+		}
+		return derivative(f, args[0], types[0])
+	}
+
+	return nil, shaderir.Type{}, fmt.Errorf("software: unexpected builtin function: %s", f)
+}
+
+// constructor returns a function for a type conversion or a constructor of a vector or a matrix.
+func constructor(args []exprFunc, types []shaderir.Type, t shaderir.Type) (exprFunc, shaderir.Type, error) {
+	n := componentCount(&t)
+	k := elementKind(&t)
+	buf := make([]float64, laneCount*n)
+
+	if len(args) == 1 {
+		arg := args[0]
+		an := componentCount(&types[0])
+		switch {
+		case an == 1 && t.IsMatrix():
+			// A matrix constructed from a scalar value is a diagonal matrix.
+			size := t.MatrixSize()
+			return func(mask uint8) []float64 {
+				v := arg(mask)
+				clear(buf)
+				for l := 0; l < laneCount; l++ {
+					for i := 0; i < size; i++ {
+						buf[l*n+i*size+i] = toFloat(v[l])
+					}
+				}
+				return buf
+			}, t, nil
+
+		case an == 1:
+			return func(mask uint8) []float64 {
+				v := arg(mask)
+				for l := 0; l < laneCount; l++ {
+					x := convert(v[l], k)
+					for i := 0; i < n; i++ {
+						buf[l*n+i] = x
+					}
+				}
+				return buf
+			}, t, nil
+
+		case types[0].IsMatrix() && t.IsMatrix():
+			// A matrix constructed from another matrix takes the upper-left part, and the rest is from the identity matrix.
+			size := t.MatrixSize()
+			asize := types[0].MatrixSize()
+			return func(mask uint8) []float64 {
+				v := arg(mask)
+				for l := 0; l < laneCount; l++ {
+					for col := 0; col < size; col++ {
+						for row := 0; row < size; row++ {
+							var x float64
+							switch {
+							case col < asize && row < asize:
+								x = v[l*an+col*asize+row]
+							case col == row:
+								x = 1
+							}
+							buf[l*n+col*size+row] = x
+						}
+					}
+				}
+				return buf
+			}, t, nil
+		}
+	}
+
+	var total int
+	ns := make([]int, len(types))
+	for i := range types {
+		ns[i] = componentCount(&types[i])
+		total += ns[i]
+	}
+	if total < n {
+		return nil, shaderir.Type{}, fmt.Errorf("software: not enough arguments to construct %s", t.String())
+	}
+	vs := make([][]float64, len(args))
+	return func(mask uint8) []float64 {
+		for i, arg := range args {
+			vs[i] = arg(mask)
+		}
+		for l := 0; l < laneCount; l++ {
+			var idx int
+			for i, v := range vs {
+				an := ns[i]
+				for j := 0; j < an && idx < n; j++ {
+					buf[l*n+idx] = convert(v[l*an+j], k)
+					idx++
+				}
+			}
+		}
+		return buf
+	}, t, nil
+}
+
+// componentWise returns a function to apply f to each component.
+// A scalar argument is broadcasted.
+func componentWise(args []exprFunc, types []shaderir.Type, f func(x []float64) float64) (exprFunc, shaderir.Type, error) {
+	if len(args) == 0 {
+		return nil, shaderir.Type{}, fmt.Errorf("software: no arguments")
+	}
+
+	t := types[0]
+	var n int
+	ns := make([]int, len(args))
+	for i := range types {
+		ns[i] = componentCount(&types[i])
+		if ns[i] > n {
+			n = ns[i]
+			t = types[i]
+		}
+	}
+	for i := range types {
+		if ns[i] != 1 && ns[i] != n {
+			return nil, shaderir.Type{}, fmt.Errorf("software: mismatched argument types: %s and %s", types[i].String(), t.String())
+		}
+	}
+
+	// abs, sign, min, max, and clamp can take integers. The other functions take only floats.
+	k := kindInt
+	for i := range types {
+		if elementKind(&types[i]) != kindInt {
+			k = kindFloat
+			break
+		}
+	}
+	if k == kindFloat && elementKind(&t) == kindInt {
+		t = vectorType(kindFloat, n)
+	}
+
+	buf := make([]float64, laneCount*n)
+	x := make([]float64, len(args))
+	vs := make([][]float64, len(args))
+	return func(mask uint8) []float64 {
+		for i, arg := range args {
+			vs[i] = arg(mask)
+		}
+		for l := 0; l < laneCount; l++ {
+			for j := 0; j < n; j++ {
+				for i, v := range vs {
+					x[i] = component(v, ns[i], l, j)
+				}
+				buf[l*n+j] = convert(f(x), k)
+			}
+		}
+		return buf
+	}, t, nil
+}
+
+func geometric(f shaderir.BuiltinFunc, args []exprFunc, types []shaderir.Type) (exprFunc, shaderir.Type, error) {
+	argCount := map[shaderir.BuiltinFunc]int{
+		shaderir.Length:      1,
+		shaderir.Distance:    2,
+		shaderir.Dot:         2,
+		shaderir.Cross:       2,
+		shaderir.Normalize:   1,
+		shaderir.Faceforward: 3,
+		shaderir.Reflect:     2,
+		shaderir.Refract:     3,
+	}[f]
+	if len(args) != argCount {
+		return nil, shaderir.Type{}, fmt.Errorf("software: wrong number of arguments for %s", f)
+	}
+	t := types[0]
+	n := componentCount(&t)
+	if f == shaderir.Cross && n != 3 {
+		return nil, shaderir.Type{}, fmt.Errorf("software: invalid arguments for %s", f)
+	}
+
+	dot := func(a, b []float64) float64 {
+		var v float64
+		for i := range a {
+			v += a[i] * b[i]
+		}
+		return v
+	}
+
+	rt := t
+	rn := n
+	switch f {
+	case shaderir.Length, shaderir.Distance, shaderir.Dot:
+		rt = shaderir.Type{Main: shaderir.Float}
+		rn = 1
+	}
+	buf := make([]float64, laneCount*rn)
+	tmp := make([]float64, n)
+	vs := make([][]float64, len(args))
+	return func(mask uint8) []float64 {
+		for i, arg := range args {
+			vs[i] = arg(mask)
+		}
+		for l := 0; l < laneCount; l++ {
+			a := vs[0][l*n : (l+1)*n]
+			out := buf[l*rn : (l+1)*rn]
+			switch f {
+			case shaderir.Length:
+				out[0] = math.Sqrt(dot(a, a))
+			case shaderir.Distance:
+				b := vs[1][l*n : (l+1)*n]
+				for i := range tmp {
+					tmp[i] = a[i] - b[i]
+				}
+				out[0] = math.Sqrt(dot(tmp, tmp))
+			case shaderir.Dot:
+				out[0] = dot(a, vs[1][l*n:(l+1)*n])
+			case shaderir.Cross:
+				b := vs[1][l*n : (l+1)*n]
+				out[0] = a[1]*b[2] - a[2]*b[1]
+				out[1] = a[2]*b[0] - a[0]*b[2]
+				out[2] = a[0]*b[1] - a[1]*b[0]
+			case shaderir.Normalize:
+				d := math.Sqrt(dot(a, a))
+				for i := range out {
+					out[i] = a[i] / d
+				}
+			case shaderir.Faceforward:
+				i := vs[1][l*n : (l+1)*n]
+				nref := vs[2][l*n : (l+1)*n]
+				sign := 1.0
+				if dot(nref, i) >= 0 {
+					sign = -1
+				}
+				for j := range out {
+					out[j] = sign * a[j]
+				}
+			case shaderir.Reflect:
+				nv := vs[1][l*n : (l+1)*n]
+				d := dot(nv, a)
+				for j := range out {
+					out[j] = a[j] - 2*d*nv[j]
+				}
+			case shaderir.Refract:
+				nv := vs[1][l*n : (l+1)*n]
+				eta := vs[2][l]
+				d := dot(nv, a)
+				k := 1 - eta*eta*(1-d*d)
+				for j := range out {
+					if k < 0 {
+						out[j] = 0
+						continue
+					}
+					out[j] = eta*a[j] - (eta*d+math.Sqrt(k))*nv[j]
+				}
+			}
+			for j := range out {
+				out[j] = toFloat(out[j])
+			}
+		}
+		return buf
+	}, rt, nil
+}
+
+// derivative returns a function for dfdx, dfdy, or fwidth.
+// The derivatives are calculated from the neighboring lanes in a 2x2 quad.
+func derivative(f shaderir.BuiltinFunc, arg exprFunc, t shaderir.Type) (exprFunc, shaderir.Type, error) {
+	n := componentCount(&t)
+	buf := make([]float64, laneCount*n)
+	dx := func(v []float64, l, i int) float64 {
+		// Lane 0 and 1 are in the same row, and so are lane 2 and 3.
+		row := l &^ 1
+		return v[(row+1)*n+i] - v[row*n+i]
+	}
+	dy := func(v []float64, l, i int) float64 {
+		// Lane 0 and 2 are in the same column, and so are lane 1 and 3.
+		col := l & 1
+		return v[(col+2)*n+i] - v[col*n+i]
+	}
+	return func(mask uint8) []float64 {
+		// Evaluate all the lanes, as helper lanes are needed to calculate derivatives.
+		v := arg(allLanes)
+		for l := 0; l < laneCount; l++ {
+			for i := 0; i < n; i++ {
+				var x float64
+				switch f {
+				case shaderir.Dfdx:
+					x = dx(v, l, i)
+				case shaderir.Dfdy:
+					x = dy(v, l, i)
+				case shaderir.Fwidth:
+					x = math.Abs(dx(v, l, i)) + math.Abs(dy(v, l, i))
+				}
+				buf[l*n+i] = toFloat(x)
+			}
+		}
+		return buf
+	}, t, nil
+}
+
+// texel returns a function to read a texel of the index-th source image.
+// If pixels is true, the position is in pixels. Otherwise, the position is normalized by the image size.
+func (c *compiler) texel(index int, pos exprFunc, pixels bool) exprFunc {
+	s := c.s
+	buf := make([]float64, laneCount*4)
+	return func(mask uint8) []float64 {
+		p := pos(mask)
+		img := s.textures[index]
+		clear(buf)
+		if img == nil {
+			return buf
+		}
+		for l := 0; l < laneCount; l++ {
+			x, y := p[l*2], p[l*2+1]
+			var ix, iy int
+			if pixels {
+				// A position is truncated like texelFetch. A texel out of the image is transparent.
+				ix, iy = int(toInt(x)), int(toInt(y))
+				if ix < 0 || iy < 0 || ix >= img.width || iy >= img.height {
+					continue
+				}
+			} else {
+				// The nearest texel is sampled, and the position is clamped to the edge.
+				ix = min(max(int(math.Floor(x*float64(img.width))), 0), img.width-1)
+				iy = min(max(int(math.Floor(y*float64(img.height))), 0), img.height-1)
+			}
+			img.texelAt(buf[l*4:(l+1)*4], ix, iy)
+		}
+		return buf
+	}
+}
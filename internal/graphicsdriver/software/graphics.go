@@ -0,0 +1,179 @@
+// Copyright 2026 The Ebitengine Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package software provides a graphics driver that renders with the CPU.
+//
+// This driver is used in environments without any graphics API, e.g., WASI.
+// Shaders are executed by interpreting their intermediate representation, so this driver is much slower than the GPU drivers.
+package software
+
+import (
+	"fmt"
+	"slices"
+
+	"github.com/duplicants-ai/ebiten/internal/graphics"
+	"github.com/duplicants-ai/ebiten/internal/graphicsdriver"
+	"github.com/duplicants-ai/ebiten/internal/shaderir"
+)
+
+// PresentFunc is called with the screen framebuffer's pixels when a frame is presented.
+// The pixels are in premultiplied-alpha RGBA, and the rows are ordered from top to bottom.
+// The pixels are valid only during the call.
+type PresentFunc func(pixels []byte, width, height int) error
+
+type Graphics struct {
+	images       map[graphicsdriver.ImageID]*Image
+	nextImageID  graphicsdriver.ImageID
+	shaders      map[graphicsdriver.ShaderID]*Shader
+	nextShaderID graphicsdriver.ShaderID
+
+	screen  *Image
+	present PresentFunc
+
+	vertices []float32
+	indices  []uint32
+
+	rasterizer rasterizer
+}
+
+func NewGraphics() *Graphics {
+	return &Graphics{
+		images:  map[graphicsdriver.ImageID]*Image{},
+		shaders: map[graphicsdriver.ShaderID]*Shader{},
+	}
+}
+
+// SetPresentFunc sets the function to show the screen framebuffer.
+// If f is nil, the screen framebuffer is not shown anywhere.
+func (g *Graphics) SetPresentFunc(f PresentFunc) {
+	g.present = f
+}
+
+func (g *Graphics) Initialize() error {
+	return nil
+}
+
+func (g *Graphics) Begin() error {
+	return nil
+}
+
+func (g *Graphics) End(present bool) error {
+	if !present || g.present == nil || g.screen == nil {
+		return nil
+	}
+	return g.present(g.screen.pixels, g.screen.width, g.screen.height)
+}
+
+func (g *Graphics) SetTransparent(transparent bool) {
+}
+
+func (g *Graphics) SetVertices(vertices []float32, indices []uint32) error {
+	g.vertices = slices.Grow(g.vertices[:0], len(vertices))[:len(vertices)]
+	copy(g.vertices, vertices)
+	g.indices = slices.Grow(g.indices[:0], len(indices))[:len(indices)]
+	copy(g.indices, indices)
+	return nil
+}
+
+func (g *Graphics) newImage(width, height int, format graphicsdriver.PixelFormat, screen bool) (*Image, error) {
+	if width <= 0 || height <= 0 || width > g.MaxImageSize() || height > g.MaxImageSize() {
+		return nil, fmt.Errorf("software: invalid image size: %d x %d", width, height)
+	}
+	// Allocate the internal size like a texture of the other drivers, as the projection matrix is calculated with it.
+	if !screen {
+		width = graphics.InternalImageSize(width)
+		height = graphics.InternalImageSize(height)
+	}
+	g.nextImageID++
+	i := &Image{
+		id:       g.nextImageID,
+		graphics: g,
+		width:    width,
+		height:   height,
+		format:   format,
+		screen:   screen,
+	}
+	switch format {
+	case graphicsdriver.PixelFormatRGBA8:
+		i.pixels = make([]byte, 4*width*height)
+	case graphicsdriver.PixelFormatRGBA16F, graphicsdriver.PixelFormatRGBA32F:
+		i.floatPixels = make([]float32, 4*width*height)
+	default:
+		return nil, fmt.Errorf("software: unexpected pixel format: %d", format)
+	}
+	g.images[i.id] = i
+	return i, nil
+}
+
+func (g *Graphics) removeImage(img *Image) {
+	delete(g.images, img.id)
+	if g.screen == img {
+		g.screen = nil
+	}
+}
+
+func (g *Graphics) NewImage(width, height int) (graphicsdriver.Image, error) {
+	return g.newImage(width, height, graphicsdriver.PixelFormatRGBA8, false)
+}
+
+func (g *Graphics) NewDepthImage(width, height int) (graphicsdriver.Image, error) {
+	i, err := g.newImage(width, height, graphicsdriver.PixelFormatRGBA8, false)
+	if err != nil {
+		return nil, err
+	}
+	i.depth = make([]float32, i.width*i.height)
+	for j := range i.depth {
+		i.depth[j] = 1
+	}
+	return i, nil
+}
+
+func (g *Graphics) NewFloatImage(width, height int, format graphicsdriver.PixelFormat) (graphicsdriver.Image, error) {
+	return g.newImage(width, height, format, false)
+}
+
+func (g *Graphics) NewScreenFramebufferImage(width, height int) (graphicsdriver.Image, error) {
+	i, err := g.newImage(width, height, graphicsdriver.PixelFormatRGBA8, true)
+	if err != nil {
+		return nil, err
+	}
+	g.screen = i
+	return i, nil
+}
+
+func (g *Graphics) SetVsyncEnabled(enabled bool) {
+}
+
+func (g *Graphics) NeedsClearingScreen() bool {
+	return true
+}
+
+func (g *Graphics) MaxImageSize() int {
+	return 4096
+}
+
+func (g *Graphics) NewShader(program *shaderir.Program) (graphicsdriver.Shader, error) {
+	g.nextShaderID++
+	s, err := newShader(g.nextShaderID, program)
+	if err != nil {
+		return nil, err
+	}
+	s.graphics = g
+	g.shaders[s.id] = s
+	return s, nil
+}
+
+func (g *Graphics) removeShader(shader *Shader) {
+	delete(g.shaders, shader.id)
+}
@@ -0,0 +1,320 @@
+// Copyright 2026 The Ebitengine Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package software_test
+
+import (
+	"image"
+	"image/color"
+	"math"
+	"testing"
+
+	"github.com/duplicants-ai/ebiten/internal/graphics"
+	"github.com/duplicants-ai/ebiten/internal/graphicsdriver"
+	"github.com/duplicants-ai/ebiten/internal/graphicsdriver/software"
+)
+
+const size = 16
+
+func newShader(t *testing.T, g *software.Graphics, src string) graphicsdriver.Shader {
+	t.Helper()
+	ir, err := graphics.CompileShader([]byte(src))
+	if err != nil {
+		t.Fatal(err)
+	}
+	s, err := g.NewShader(ir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return s
+}
+
+// uniforms returns the preserved uniform values to draw onto a size x size image, followed by the given values.
+func uniforms(values ...float32) []uint32 {
+	us := make([]uint32, graphics.PreservedUniformDwordCount)
+	us[0] = math.Float32bits(size)
+	us[1] = math.Float32bits(size)
+	us[20] = math.Float32bits(size)
+	us[21] = math.Float32bits(size)
+	// The projection matrix in column-major order.
+	const idx = 54
+	us[idx] = math.Float32bits(2.0 / size)
+	us[idx+5] = math.Float32bits(2.0 / size)
+	us[idx+10] = math.Float32bits(1)
+	us[idx+12] = math.Float32bits(-1)
+	us[idx+13] = math.Float32bits(-1)
+	us[idx+15] = math.Float32bits(1)
+	for _, v := range values {
+		us = append(us, math.Float32bits(v))
+	}
+	return us
+}
+
+func drawQuad(t *testing.T, g *software.Graphics, dst graphicsdriver.Image, shader graphicsdriver.Shader, rect image.Rectangle, blend graphicsdriver.Blend, us []uint32) {
+	t.Helper()
+	drawQuadMRT(t, g, [graphics.ShaderDstImageCount]graphicsdriver.Image{dst}, shader, rect, blend, us)
+}
+
+func drawQuadMRT(t *testing.T, g *software.Graphics, dsts [graphics.ShaderDstImageCount]graphicsdriver.Image, shader graphicsdriver.Shader, rect image.Rectangle, blend graphicsdriver.Blend, us []uint32) {
+	t.Helper()
+	vs := make([]float32, 4*graphics.VertexFloatCount)
+	graphics.QuadVerticesFromDstAndSrc(vs, float32(rect.Min.X), float32(rect.Min.Y), float32(rect.Max.X), float32(rect.Max.Y), 0, 0, 1, 1, 1, 0.5, 0.25, 1)
+	if err := g.SetVertices(vs, graphics.QuadIndices()); err != nil {
+		t.Fatal(err)
+	}
+	var srcs [graphics.ShaderSrcImageCount]graphicsdriver.ImageID
+	dstRegions := []graphicsdriver.DstRegion{
+		{
+			Region:     image.Rect(0, 0, size, size),
+			IndexCount: len(graphics.QuadIndices()),
+		},
+	}
+	var dstIDs [graphics.ShaderDstImageCount]graphicsdriver.ImageID
+	for i, dst := range dsts {
+		if dst == nil {
+			dstIDs[i] = graphicsdriver.InvalidImageID
+			continue
+		}
+		dstIDs[i] = dst.ID()
+	}
+	if err := g.DrawTriangles(dstIDs, srcs, shader.ID(), dstRegions, 0, blend, us, graphicsdriver.FillRuleFillAll); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func readPixels(t *testing.T, img graphicsdriver.Image) []byte {
+	t.Helper()
+	pix := make([]byte, 4*size*size)
+	if err := img.ReadPixels([]graphicsdriver.PixelsArgs{{Pixels: pix, Region: image.Rect(0, 0, size, size)}}); err != nil {
+		t.Fatal(err)
+	}
+	return pix
+}
+
+func at(pix []byte, x, y int) color.RGBA {
+	i := 4 * (y*size + x)
+	return color.RGBA{R: pix[i], G: pix[i+1], B: pix[i+2], A: pix[i+3]}
+}
+
+func TestDrawTrianglesCoverage(t *testing.T) {
+	g := software.NewGraphics()
+	dst, err := g.NewImage(size, size)
+	if err != nil {
+		t.Fatal(err)
+	}
+	s := newShader(t, g, `//kage:unit pixels
+
+package main
+
+func Fragment(dstPos vec4, srcPos vec2, color vec4) vec4 {
+	return color
+}
+`)
+	drawQuad(t, g, dst, s, image.Rect(2, 3, 6, 8), graphicsdriver.BlendCopy, uniforms())
+
+	pix := readPixels(t, dst)
+	for j := 0; j < size; j++ {
+		for i := 0; i < size; i++ {
+			got := at(pix, i, j)
+			var want color.RGBA
+			if image.Pt(i, j).In(image.Rect(2, 3, 6, 8)) {
+				want = color.RGBA{R: 0xff, G: 0x80, B: 0x40, A: 0xff}
+			}
+			if got != want {
+				t.Errorf("at(%d, %d): got: %v, want: %v", i, j, got, want)
+			}
+		}
+	}
+}
+
+func TestDrawTrianglesShaderStatements(t *testing.T) {
+	g := software.NewGraphics()
+	dst, err := g.NewImage(size, size)
+	if err != nil {
+		t.Fatal(err)
+	}
+	s := newShader(t, g, `//kage:unit pixels
+
+package main
+
+var Scale float
+
+func divmod(x, y int) (int, int) {
+	return x / y, x % y
+}
+
+func Fragment(dstPos vec4, srcPos vec2, color vec4) vec4 {
+	x := int(dstPos.x)
+	y := int(dstPos.y)
+	q, r := divmod(x, 3)
+
+	sum := 0
+	for i := 0; i < 16; i++ {
+		if i > y {
+			break
+		}
+		if i%2 == 1 {
+			continue
+		}
+		sum += i
+	}
+
+	var b float
+	switch r {
+	case 0:
+		b = 0.25
+	case 1:
+		b = 0.5
+	default:
+		b = 1
+	}
+
+	if r > 2 {
+		// v has the same index as a, which is declared later.
+		v := vec2(1)
+		b = v.x
+	}
+
+	a := [3]float{0.2, 0.4, 0.6}
+	if x >= 12 {
+		discard()
+	}
+	return vec4(float(q)*Scale, float(sum)/64, b, a[r])
+}
+`)
+	drawQuad(t, g, dst, s, image.Rect(0, 0, size, size), graphicsdriver.BlendCopy, uniforms(0.0625))
+
+	pix := readPixels(t, dst)
+	for j := 0; j < size; j++ {
+		for i := 0; i < size; i++ {
+			got := at(pix, i, j)
+			var want color.RGBA
+			if i < 12 {
+				sum := 0
+				for k := 0; k <= j; k += 2 {
+					sum += k
+				}
+				b := []float64{0.25, 0.5, 1}[i%3]
+				a := []float64{0.2, 0.4, 0.6}[i%3]
+				want = color.RGBA{
+					R: byte(math.Round(float64(i/3) * 0.0625 * 0xff)),
+					G: byte(math.Round(math.Min(float64(sum)/64, 1) * 0xff)),
+					B: byte(math.Round(b * 0xff)),
+					A: byte(math.Round(a * 0xff)),
+				}
+			}
+			if got != want {
+				t.Errorf("at(%d, %d): got: %v, want: %v", i, j, got, want)
+			}
+		}
+	}
+}
+
+func TestDrawTrianglesBlend(t *testing.T) {
+	g := software.NewGraphics()
+	dst, err := g.NewImage(size, size)
+	if err != nil {
+		t.Fatal(err)
+	}
+	s := newShader(t, g, `//kage:unit pixels
+
+package main
+
+var Color vec4
+
+func Fragment(dstPos vec4, srcPos vec2, color vec4) vec4 {
+	return Color
+}
+`)
+	drawQuad(t, g, dst, s, image.Rect(0, 0, size, size), graphicsdriver.BlendCopy, uniforms(0, 0, 1, 1))
+	drawQuad(t, g, dst, s, image.Rect(0, 0, size, size), graphicsdriver.BlendSourceOver, uniforms(0.5, 0, 0, 0.5))
+
+	pix := readPixels(t, dst)
+	if got, want := at(pix, 0, 0), (color.RGBA{R: 0x80, G: 0, B: 0x80, A: 0xff}); got != want {
+		t.Errorf("got: %v, want: %v", got, want)
+	}
+}
+
+func TestDrawTrianglesMultipleRenderTargets(t *testing.T) {
+	g := software.NewGraphics()
+	var dsts [graphics.ShaderDstImageCount]graphicsdriver.Image
+	for i := 0; i < 3; i++ {
+		dst, err := g.NewImage(size, size)
+		if err != nil {
+			t.Fatal(err)
+		}
+		dsts[i] = dst
+	}
+	s := newShader(t, g, `//kage:unit pixels
+
+package main
+
+func Fragment(dstPos vec4, srcPos vec2, color vec4) (vec4, vec4, vec4) {
+	if dstPos.x >= 4 {
+		discard()
+	}
+	return color, vec4(0, 1, 0, 1), vec4(dstPos.x/4, 0, 0, 1)
+}
+`)
+	// The second image is not a destination. The third image receives the third color.
+	drawQuadMRT(t, g, [graphics.ShaderDstImageCount]graphicsdriver.Image{dsts[0], nil, dsts[2]}, s, image.Rect(0, 0, size, size), graphicsdriver.BlendCopy, uniforms())
+
+	for i := 0; i < 3; i++ {
+		pix := readPixels(t, dsts[i])
+		for j := 0; j < size; j++ {
+			for k := 0; k < size; k++ {
+				got := at(pix, k, j)
+				var want color.RGBA
+				if k < 4 {
+					switch i {
+					case 0:
+						want = color.RGBA{R: 0xff, G: 0x80, B: 0x40, A: 0xff}
+					case 2:
+						want = color.RGBA{R: byte(math.Round((float64(k) + 0.5) / 4 * 0xff)), A: 0xff}
+					}
+				}
+				if got != want {
+					t.Errorf("dsts[%d].at(%d, %d): got: %v, want: %v", i, k, j, got, want)
+				}
+			}
+		}
+	}
+}
+
+func TestFloat16(t *testing.T) {
+	g := software.NewGraphics()
+	img, err := g.NewFloatImage(size, size, graphicsdriver.PixelFormatRGBA16F)
+	if err != nil {
+		t.Fatal(err)
+	}
+	pix := make([]float32, 4*size*size)
+	pix[0] = 1.0 / 3
+	pix[1] = 70000
+	pix[2] = -2
+	pix[3] = 1e-7
+	r := image.Rect(0, 0, size, size)
+	if err := img.(graphicsdriver.FloatPixelsImage).WritePixelsFloat32([]graphicsdriver.PixelsFloat32Args{{Pixels: pix, Region: r}}); err != nil {
+		t.Fatal(err)
+	}
+	got := make([]float32, 4*size*size)
+	if err := img.(graphicsdriver.FloatPixelsImage).ReadPixelsFloat32([]graphicsdriver.PixelsFloat32Args{{Pixels: got, Region: r}}); err != nil {
+		t.Fatal(err)
+	}
+	want := []float32{0.333251953125, float32(math.Inf(1)), -2, 1.1920929e-07}
+	for i, w := range want {
+		if got[i] != w {
+			t.Errorf("got[%d]: %v, want: %v", i, got[i], w)
+		}
+	}
+}
@@ -0,0 +1,256 @@
+// Copyright 2026 The Ebitengine Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package software
+
+import (
+	"fmt"
+	"image"
+	"math"
+
+	"github.com/duplicants-ai/ebiten/internal/graphicsdriver"
+)
+
+type Image struct {
+	id       graphicsdriver.ImageID
+	graphics *Graphics
+	width    int
+	height   int
+	format   graphicsdriver.PixelFormat
+	screen   bool
+
+	// pixels is the pixels in premultiplied-alpha RGBA for PixelFormatRGBA8.
+	pixels []byte
+
+	// floatPixels is the pixels for PixelFormatRGBA16F and PixelFormatRGBA32F.
+	floatPixels []float32
+
+	// depth is the depth buffer. depth is nil if the image doesn't have a depth buffer.
+	depth []float32
+
+	// stencil is the stencil buffer, which is created lazily.
+	stencil []byte
+}
+
+func (i *Image) ID() graphicsdriver.ImageID {
+	return i.id
+}
+
+func (i *Image) Dispose() {
+	i.pixels = nil
+	i.floatPixels = nil
+	i.depth = nil
+	i.stencil = nil
+	i.graphics.removeImage(i)
+}
+
+func (i *Image) bounds() image.Rectangle {
+	return image.Rect(0, 0, i.width, i.height)
+}
+
+func (i *Image) isFloat() bool {
+	return i.format != graphicsdriver.PixelFormatRGBA8
+}
+
+func (i *Image) checkRegion(args []graphicsdriver.PixelsArgs) error {
+	for _, a := range args {
+		if !a.Region.In(i.bounds()) {
+			return fmt.Errorf("software: region %v is out of the image bounds %v", a.Region, i.bounds())
+		}
+		if len(a.Pixels) < 4*a.Region.Dx()*a.Region.Dy() {
+			return fmt.Errorf("software: pixels for region %v is too short: %d", a.Region, len(a.Pixels))
+		}
+	}
+	return nil
+}
+
+func (i *Image) ReadPixels(args []graphicsdriver.PixelsArgs) error {
+	if err := i.checkRegion(args); err != nil {
+		return err
+	}
+	for _, a := range args {
+		w := a.Region.Dx()
+		for j := 0; j < a.Region.Dy(); j++ {
+			y := a.Region.Min.Y + j
+			dst := a.Pixels[4*j*w : 4*(j+1)*w]
+			if !i.isFloat() {
+				copy(dst, i.pixels[4*(y*i.width+a.Region.Min.X):])
+				continue
+			}
+			src := i.floatPixels[4*(y*i.width+a.Region.Min.X):]
+			for k := range dst {
+				dst[k] = floatToByte(float64(src[k]))
+			}
+		}
+	}
+	return nil
+}
+
+func (i *Image) WritePixels(args []graphicsdriver.PixelsArgs) error {
+	if err := i.checkRegion(args); err != nil {
+		return err
+	}
+	for _, a := range args {
+		w := a.Region.Dx()
+		for j := 0; j < a.Region.Dy(); j++ {
+			y := a.Region.Min.Y + j
+			src := a.Pixels[4*j*w : 4*(j+1)*w]
+			if !i.isFloat() {
+				copy(i.pixels[4*(y*i.width+a.Region.Min.X):], src)
+				continue
+			}
+			dst := i.floatPixels[4*(y*i.width+a.Region.Min.X):]
+			for k, b := range src {
+				dst[k] = float32(b) / 0xff
+			}
+		}
+	}
+	return nil
+}
+
+func (i *Image) ReadPixelsFloat32(args []graphicsdriver.PixelsFloat32Args) error {
+	for _, a := range args {
+		if !a.Region.In(i.bounds()) {
+			return fmt.Errorf("software: region %v is out of the image bounds %v", a.Region, i.bounds())
+		}
+		w := a.Region.Dx()
+		for j := 0; j < a.Region.Dy(); j++ {
+			y := a.Region.Min.Y + j
+			dst := a.Pixels[4*j*w : 4*(j+1)*w]
+			if i.isFloat() {
+				copy(dst, i.floatPixels[4*(y*i.width+a.Region.Min.X):])
+				continue
+			}
+			src := i.pixels[4*(y*i.width+a.Region.Min.X):]
+			for k := range dst {
+				dst[k] = float32(src[k]) / 0xff
+			}
+		}
+	}
+	return nil
+}
+
+func (i *Image) WritePixelsFloat32(args []graphicsdriver.PixelsFloat32Args) error {
+	for _, a := range args {
+		if !a.Region.In(i.bounds()) {
+			return fmt.Errorf("software: region %v is out of the image bounds %v", a.Region, i.bounds())
+		}
+		w := a.Region.Dx()
+		for j := 0; j < a.Region.Dy(); j++ {
+			y := a.Region.Min.Y + j
+			src := a.Pixels[4*j*w : 4*(j+1)*w]
+			if !i.isFloat() {
+				dst := i.pixels[4*(y*i.width+a.Region.Min.X):]
+				for k, v := range src {
+					dst[k] = floatToByte(float64(v))
+				}
+				continue
+			}
+			dst := i.floatPixels[4*(y*i.width+a.Region.Min.X):]
+			for k, v := range src {
+				dst[k] = i.quantizeFloat(float64(v))
+			}
+		}
+	}
+	return nil
+}
+
+// texelAt writes the color at (x, y) to dst.
+func (i *Image) texelAt(dst []float64, x, y int) {
+	idx := 4 * (y*i.width + x)
+	if i.isFloat() {
+		for k := range dst {
+			dst[k] = float64(i.floatPixels[idx+k])
+		}
+		return
+	}
+	for k := range dst {
+		dst[k] = toFloat(float64(i.pixels[idx+k]) / 0xff)
+	}
+}
+
+// colorAt writes the color at the pixel index idx to dst.
+func (i *Image) colorAt(dst *[4]float64, idx int) {
+	if i.isFloat() {
+		for k := range dst {
+			dst[k] = float64(i.floatPixels[4*idx+k])
+		}
+		return
+	}
+	for k := range dst {
+		dst[k] = float64(i.pixels[4*idx+k]) / 0xff
+	}
+}
+
+// setColorAt sets the color at the pixel index idx.
+// The color is clamped and quantized according to the pixel format.
+func (i *Image) setColorAt(idx int, clr *[4]float64) {
+	if i.isFloat() {
+		for k, v := range clr {
+			i.floatPixels[4*idx+k] = i.quantizeFloat(v)
+		}
+		return
+	}
+	for k, v := range clr {
+		i.pixels[4*idx+k] = floatToByte(v)
+	}
+}
+
+func (i *Image) quantizeFloat(v float64) float32 {
+	if i.format == graphicsdriver.PixelFormatRGBA16F {
+		return float16(v)
+	}
+	return float32(v)
+}
+
+func (i *Image) ensureStencil() {
+	if i.stencil == nil {
+		i.stencil = make([]byte, i.width*i.height)
+	}
+}
+
+func floatToByte(v float64) byte {
+	if v != v || v <= 0 {
+		return 0
+	}
+	if v >= 1 {
+		return 0xff
+	}
+	return byte(math.Round(v * 0xff))
+}
+
+// float16 rounds v to the nearest value representable as a half-precision floating-point value.
+func float16(v float64) float32 {
+	const (
+		maxHalf = 65504
+		// minNormal is the smallest positive normal value of a half-precision floating-point value.
+		minNormal = 1.0 / (1 << 14)
+	)
+	if v != v || math.IsInf(v, 0) {
+		return float32(v)
+	}
+	a := math.Abs(v)
+	if a >= maxHalf+16 {
+		return float32(math.Copysign(math.Inf(1), v))
+	}
+	// A half-precision value has 10 bits for the fraction. A subnormal value has a fixed exponent.
+	var step float64
+	if a < minNormal {
+		step = minNormal / (1 << 10)
+	} else {
+		_, exp := math.Frexp(a)
+		step = math.Ldexp(1, exp-11)
+	}
+	return float32(math.Copysign(math.RoundToEven(a/step)*step, v))
+}
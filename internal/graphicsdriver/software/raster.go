@@ -0,0 +1,516 @@
+// Copyright 2026 The Ebitengine Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package software
+
+import (
+	"fmt"
+	"image"
+	"math"
+
+	"github.com/duplicants-ai/ebiten/internal/graphics"
+	"github.com/duplicants-ai/ebiten/internal/graphicsdriver"
+)
+
+// subpixelPrecision is the number of the subpixel steps per pixel.
+// A vertex position is snapped to the subpixel grid so that the edges shared by triangles are evaluated exactly.
+const subpixelPrecision = 256
+
+// shadedVertex is a vertex processed by the vertex shader.
+type shadedVertex struct {
+	// x, y, and z are the position in the destination's pixels.
+	x, y, z float64
+
+	// invW is the reciprocal of the clip-space w, which is used for perspective-correct interpolation.
+	invW float64
+
+	// varyings is the values of the varying variables, which are interpolated for fragments.
+	varyings []float64
+
+	// culled reports whether the vertex is behind the viewer.
+	culled bool
+}
+
+// rasterizer draws triangles for one DrawTriangles call.
+type rasterizer struct {
+	dst    *Image
+	shader *Shader
+	blend  graphicsdriver.Blend
+
+	// extraDsts is the destination images for the second and later colors of multiple render targets.
+	extraDsts [graphics.ShaderDstImageCount - 1]*Image
+
+	useDepth   bool
+	depthTest  bool
+	depthWrite bool
+
+	varyingCount int
+	shaded       []shadedVertex
+	shadedGen    []int
+	gen          int
+}
+
+func (g *Graphics) DrawTriangles(dstIDs [graphics.ShaderDstImageCount]graphicsdriver.ImageID, srcIDs [graphics.ShaderSrcImageCount]graphicsdriver.ImageID, shaderID graphicsdriver.ShaderID, dstRegions []graphicsdriver.DstRegion, indexOffset int, blend graphicsdriver.Blend, uniforms []uint32, fillRule graphicsdriver.FillRule) error {
+	if shaderID == graphicsdriver.InvalidShaderID {
+		return fmt.Errorf("software: shader ID is invalid")
+	}
+
+	dst := g.images[dstIDs[0]]
+	shader := g.shaders[shaderID]
+
+	r := &g.rasterizer
+	for i, dstID := range dstIDs[1:] {
+		if dstID == graphicsdriver.InvalidImageID {
+			continue
+		}
+		extraDst := g.images[dstID]
+		if extraDst.width != dst.width || extraDst.height != dst.height {
+			return fmt.Errorf("software: all the destination images must have the same size")
+		}
+		r.extraDsts[i] = extraDst
+	}
+	defer func() {
+		r.extraDsts = [graphics.ShaderDstImageCount - 1]*Image{}
+	}()
+
+	shader.setUniforms(uniforms)
+	for i, srcID := range srcIDs {
+		if srcID == graphicsdriver.InvalidImageID {
+			shader.textures[i] = nil
+			continue
+		}
+		shader.textures[i] = g.images[srcID]
+	}
+	defer func() {
+		shader.textures = [graphics.ShaderSrcImageCount]*Image{}
+	}()
+
+	r.dst = dst
+	r.shader = shader
+	r.blend = blend
+	r.useDepth = dst.depth != nil && (shader.ir.DepthTest || shader.ir.DepthWrite)
+	r.depthTest = shader.ir.DepthTest
+	r.depthWrite = shader.ir.DepthWrite
+	r.varyingCount = 0
+	for i := range shader.ir.Varyings {
+		r.varyingCount += componentCount(&shader.ir.Varyings[i])
+	}
+	if err := r.shadeVertices(g.vertices, g.indices[indexOffset:indexOffset+indexCount(dstRegions)]); err != nil {
+		return err
+	}
+
+	for _, dstRegion := range dstRegions {
+		indices := g.indices[indexOffset : indexOffset+dstRegion.IndexCount]
+		scissor := dstRegion.Region.Intersect(dst.bounds())
+
+		if fillRule != graphicsdriver.FillRuleFillAll {
+			dst.ensureStencil()
+			for y := scissor.Min.Y; y < scissor.Max.Y; y++ {
+				clear(dst.stencil[y*dst.width+scissor.Min.X : y*dst.width+scissor.Max.X])
+			}
+			for i := 0; i+2 < len(indices); i += 3 {
+				r.drawTriangle(indices[i:i+3], scissor, fillRule, true)
+			}
+		}
+		for i := 0; i+2 < len(indices); i += 3 {
+			r.drawTriangle(indices[i:i+3], scissor, fillRule, false)
+		}
+
+		indexOffset += dstRegion.IndexCount
+	}
+
+	r.dst = nil
+	r.shader = nil
+	return nil
+}
+
+func indexCount(dstRegions []graphicsdriver.DstRegion) int {
+	var n int
+	for _, r := range dstRegions {
+		n += r.IndexCount
+	}
+	return n
+}
+
+// shadeVertices runs the vertex shader for the vertices referred by the indices.
+func (r *rasterizer) shadeVertices(vertices []float32, indices []uint32) error {
+	s := r.shader
+	stride := s.vertexFloatCount
+	if stride == 0 {
+		return fmt.Errorf("software: invalid vertex layout")
+	}
+	n := len(vertices) / stride
+	if len(r.shaded) < n {
+		r.shaded = append(r.shaded, make([]shadedVertex, n-len(r.shaded))...)
+		r.shadedGen = append(r.shadedGen, make([]int, n-len(r.shadedGen))...)
+	}
+	r.gen++
+
+	var batch [laneCount]int
+	var count int
+	for _, idx := range indices {
+		if int(idx) >= n {
+			return fmt.Errorf("software: index %d is out of range", idx)
+		}
+		if r.shadedGen[idx] == r.gen {
+			continue
+		}
+		r.shadedGen[idx] = r.gen
+		batch[count] = int(idx)
+		count++
+		if count == laneCount {
+			r.shadeVertexBatch(vertices, batch[:count])
+			count = 0
+		}
+	}
+	if count > 0 {
+		r.shadeVertexBatch(vertices, batch[:count])
+	}
+	return nil
+}
+
+func (r *rasterizer) shadeVertexBatch(vertices []float32, batch []int) {
+	s := r.shader
+	f := s.vertex
+	stride := s.vertexFloatCount
+
+	var mask uint8
+	for l, idx := range batch {
+		mask |= 1 << l
+		v := vertices[idx*stride : (idx+1)*stride]
+		for i := range s.ir.Attributes {
+			off := f.offsets[i]
+			n := f.counts[i]
+			for j := 0; j < n; j++ {
+				f.frame[off+l*n+j] = float64(v[s.attributeOffsets[i]+j])
+			}
+		}
+	}
+
+	s.ret, s.brk, s.cont, s.discard = 0, 0, 0, 0
+	f.body(mask)
+
+	posIndex := len(s.ir.Attributes)
+	dw, dh := float64(r.dst.width), float64(r.dst.height)
+	for l, idx := range batch {
+		sv := &r.shaded[idx]
+		pos := f.frame[f.offsets[posIndex]+l*4 : f.offsets[posIndex]+(l+1)*4]
+		w := pos[3]
+		sv.culled = !(w > 0)
+		if sv.culled {
+			continue
+		}
+		sv.invW = 1 / w
+		// Convert the normalized device coordinates to the destination's pixels.
+		// Unlike OpenGL, the destination's Y direction is downward in the memory, and then the Y direction is not flipped.
+		sv.x = snap((pos[0]*sv.invW + 1) / 2 * dw)
+		sv.y = snap((pos[1]*sv.invW + 1) / 2 * dh)
+		sv.z = pos[2] * sv.invW
+
+		if cap(sv.varyings) < r.varyingCount {
+			sv.varyings = make([]float64, r.varyingCount)
+		}
+		sv.varyings = sv.varyings[:r.varyingCount]
+		var k int
+		for i := range s.ir.Varyings {
+			idx := posIndex + 1 + i
+			n := f.counts[idx]
+			copy(sv.varyings[k:k+n], f.frame[f.offsets[idx]+l*n:f.offsets[idx]+(l+1)*n])
+			k += n
+		}
+	}
+}
+
+func snap(x float64) float64 {
+	return math.Round(x*subpixelPrecision) / subpixelPrecision
+}
+
+// edge is an edge function of a triangle, which is positive inside the triangle.
+type edge struct {
+	a, b, c float64
+
+	// inclusive reports whether a pixel center exactly on the edge is covered.
+	inclusive bool
+}
+
+func newEdge(x0, y0, x1, y1 float64, sign float64) edge {
+	// e(x, y) = (x1-x0)*(y-y0) - (y1-y0)*(x-x0)
+	dx := (x1 - x0) * sign
+	dy := (y1 - y0) * sign
+	return edge{
+		a:         -dy,
+		b:         dx,
+		c:         dy*x0 - dx*y0,
+		inclusive: dy > 0 || (dy == 0 && dx < 0),
+	}
+}
+
+func (e *edge) eval(x, y float64) float64 {
+	return e.a*x + e.b*y + e.c
+}
+
+func (e *edge) covers(v float64) bool {
+	return v > 0 || (v == 0 && e.inclusive)
+}
+
+// drawTriangle draws a triangle within the scissor rectangle.
+// If stencil is true, drawTriangle updates only the stencil buffer.
+func (r *rasterizer) drawTriangle(indices []uint32, scissor image.Rectangle, fillRule graphicsdriver.FillRule, stencil bool) {
+	v0 := &r.shaded[indices[0]]
+	v1 := &r.shaded[indices[1]]
+	v2 := &r.shaded[indices[2]]
+	// TODO: Clip a triangle partially behind the viewer instead of skipping it.
+	if v0.culled || v1.culled || v2.culled {
+		return
+	}
+
+	area := (v1.x-v0.x)*(v2.y-v0.y) - (v1.y-v0.y)*(v2.x-v0.x)
+	if area == 0 {
+		return
+	}
+	sign := 1.0
+	if area < 0 {
+		sign = -1
+	}
+	// The edge opposite to each vertex.
+	edges := [3]edge{
+		newEdge(v1.x, v1.y, v2.x, v2.y, sign),
+		newEdge(v2.x, v2.y, v0.x, v0.y, sign),
+		newEdge(v0.x, v0.y, v1.x, v1.y, sign),
+	}
+
+	bounds := image.Rect(
+		int(math.Floor(min(v0.x, v1.x, v2.x))),
+		int(math.Floor(min(v0.y, v1.y, v2.y))),
+		int(math.Ceil(max(v0.x, v1.x, v2.x)))+1,
+		int(math.Ceil(max(v0.y, v1.y, v2.y)))+1,
+	).Intersect(scissor)
+	if bounds.Empty() {
+		return
+	}
+
+	dst := r.dst
+
+	if stencil {
+		for y := bounds.Min.Y; y < bounds.Max.Y; y++ {
+			for x := bounds.Min.X; x < bounds.Max.X; x++ {
+				px, py := float64(x)+0.5, float64(y)+0.5
+				if !edges[0].covers(edges[0].eval(px, py)) || !edges[1].covers(edges[1].eval(px, py)) || !edges[2].covers(edges[2].eval(px, py)) {
+					continue
+				}
+				idx := y*dst.width + x
+				switch fillRule {
+				case graphicsdriver.FillRuleNonZero:
+					// Increment or decrement the value depending on the triangle's orientation, like INCR_WRAP and DECR_WRAP in OpenGL.
+					if area > 0 {
+						dst.stencil[idx]++
+					} else {
+						dst.stencil[idx]--
+					}
+				case graphicsdriver.FillRuleEvenOdd:
+					dst.stencil[idx] = ^dst.stencil[idx]
+				}
+			}
+		}
+		return
+	}
+
+	s := r.shader
+	f := s.fragment
+	invArea := 1 / area
+
+	// Process pixels in 2x2 quads to calculate derivatives.
+	for qy := bounds.Min.Y &^ 1; qy < bounds.Max.Y; qy += 2 {
+		for qx := bounds.Min.X &^ 1; qx < bounds.Max.X; qx += 2 {
+			var covered uint8
+			var bs [laneCount][3]float64
+			for l := 0; l < laneCount; l++ {
+				x := qx + l&1
+				y := qy + l>>1
+				px, py := float64(x)+0.5, float64(y)+0.5
+				e0 := edges[0].eval(px, py)
+				e1 := edges[1].eval(px, py)
+				e2 := edges[2].eval(px, py)
+				// The barycentric coordinates are calculated even for an uncovered pixel, as the quad's derivatives need them.
+				bs[l] = [3]float64{e0 * sign * invArea, e1 * sign * invArea, e2 * sign * invArea}
+				if !(image.Pt(x, y).In(bounds)) {
+					continue
+				}
+				if !edges[0].covers(e0) || !edges[1].covers(e1) || !edges[2].covers(e2) {
+					continue
+				}
+				if fillRule != graphicsdriver.FillRuleFillAll && dst.stencil[y*dst.width+x] == 0 {
+					continue
+				}
+				covered |= 1 << l
+			}
+			if covered == 0 {
+				continue
+			}
+
+			var depths [laneCount]float64
+			for l := 0; l < laneCount; l++ {
+				b := bs[l]
+				x := qx + l&1
+				y := qy + l>>1
+
+				// Interpolate the varying values in a perspective-correct way.
+				w0, w1, w2 := b[0]*v0.invW, b[1]*v1.invW, b[2]*v2.invW
+				invW := w0 + w1 + w2
+				w0, w1, w2 = w0/invW, w1/invW, w2/invW
+
+				// The depth is interpolated linearly in the screen space.
+				depths[l] = b[0]*v0.z + b[1]*v1.z + b[2]*v2.z
+
+				fragCoord := f.frame[f.offsets[0]+l*4 : f.offsets[0]+(l+1)*4]
+				fragCoord[0] = float64(x) + 0.5
+				fragCoord[1] = float64(y) + 0.5
+				fragCoord[2] = toFloat(depths[l])
+				fragCoord[3] = toFloat(invW)
+
+				var k int
+				for i := range s.ir.Varyings {
+					idx := 1 + i
+					n := f.counts[idx]
+					vs := f.frame[f.offsets[idx]+l*n : f.offsets[idx]+(l+1)*n]
+					for j := range vs {
+						vs[j] = toFloat(w0*v0.varyings[k+j] + w1*v1.varyings[k+j] + w2*v2.varyings[k+j])
+					}
+					k += n
+				}
+			}
+
+			for i := range f.out {
+				idx := len(f.in) + i
+				clear(f.frame[f.offsets[idx] : f.offsets[idx]+laneCount*4])
+			}
+
+			// All the lanes are executed including uncovered ones, which are helper invocations for derivatives.
+			s.ret, s.brk, s.cont, s.discard = 0, 0, 0, 0
+			f.body(allLanes)
+			covered &^= s.discard
+
+			for l := 0; l < laneCount; l++ {
+				if covered&(1<<l) == 0 {
+					continue
+				}
+				x := qx + l&1
+				y := qy + l>>1
+				idx := y*dst.width + x
+				if r.useDepth {
+					if r.depthTest && !(depths[l] <= float64(dst.depth[idx])) {
+						continue
+					}
+					if r.depthWrite {
+						dst.depth[idx] = float32(depths[l])
+					}
+				}
+				if len(f.out) == 0 {
+					r.blendPixel(dst, idx, f.ret[l*4:(l+1)*4])
+					continue
+				}
+				// The colors for multiple render targets are in the out-params.
+				for i := range f.out {
+					d := dst
+					if i > 0 {
+						d = r.extraDsts[i-1]
+					}
+					if d == nil {
+						continue
+					}
+					off := f.offsets[len(f.in)+i]
+					r.blendPixel(d, idx, f.frame[off+l*4:off+(l+1)*4])
+				}
+			}
+		}
+	}
+}
+
+// blendPixel blends the source color with the pixel at idx of the destination image dst.
+func (r *rasterizer) blendPixel(dst *Image, idx int, src []float64) {
+	var s [4]float64
+	copy(s[:], src)
+	if !dst.isFloat() {
+		// A fixed-point color attachment clamps the source color.
+		for i := range s {
+			s[i] = min(max(s[i], 0), 1)
+		}
+	}
+
+	// Skip reading the destination for the most common copy operation.
+	if r.blend == graphicsdriver.BlendCopy {
+		dst.setColorAt(idx, &s)
+		return
+	}
+
+	var d [4]float64
+	dst.colorAt(&d, idx)
+
+	var out [4]float64
+	for i := 0; i < 4; i++ {
+		sf, df := r.blend.BlendFactorSourceRGB, r.blend.BlendFactorDestinationRGB
+		op := r.blend.BlendOperationRGB
+		if i == 3 {
+			sf, df = r.blend.BlendFactorSourceAlpha, r.blend.BlendFactorDestinationAlpha
+			op = r.blend.BlendOperationAlpha
+		}
+		sv := s[i] * blendFactor(sf, &s, &d, i)
+		dv := d[i] * blendFactor(df, &s, &d, i)
+		switch op {
+		case graphicsdriver.BlendOperationAdd:
+			out[i] = sv + dv
+		case graphicsdriver.BlendOperationSubtract:
+			out[i] = sv - dv
+		case graphicsdriver.BlendOperationReverseSubtract:
+			out[i] = dv - sv
+		case graphicsdriver.BlendOperationMin:
+			// Min and Max ignore the factors.
+			out[i] = min(s[i], d[i])
+		case graphicsdriver.BlendOperationMax:
+			out[i] = max(s[i], d[i])
+		}
+	}
+	dst.setColorAt(idx, &out)
+}
+
+func blendFactor(f graphicsdriver.BlendFactor, src, dst *[4]float64, i int) float64 {
+	switch f {
+	case graphicsdriver.BlendFactorZero:
+		return 0
+	case graphicsdriver.BlendFactorOne:
+		return 1
+	case graphicsdriver.BlendFactorSourceColor:
+		return src[i]
+	case graphicsdriver.BlendFactorOneMinusSourceColor:
+		return 1 - src[i]
+	case graphicsdriver.BlendFactorSourceAlpha:
+		return src[3]
+	case graphicsdriver.BlendFactorOneMinusSourceAlpha:
+		return 1 - src[3]
+	case graphicsdriver.BlendFactorDestinationColor:
+		return dst[i]
+	case graphicsdriver.BlendFactorOneMinusDestinationColor:
+		return 1 - dst[i]
+	case graphicsdriver.BlendFactorDestinationAlpha:
+		return dst[3]
+	case graphicsdriver.BlendFactorOneMinusDestinationAlpha:
+		return 1 - dst[3]
+	case graphicsdriver.BlendFactorSourceAlphaSaturated:
+		if i == 3 {
+			return 1
+		}
+		return min(src[3], 1-dst[3])
+	}
+	return 0
+}
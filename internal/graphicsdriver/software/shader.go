@@ -0,0 +1,1463 @@
+// Copyright 2026 The Ebitengine Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package software
+
+import (
+	"fmt"
+	"go/constant"
+	"math"
+	"slices"
+
+	"github.com/duplicants-ai/ebiten/internal/graphics"
+	"github.com/duplicants-ai/ebiten/internal/graphicsdriver"
+	"github.com/duplicants-ai/ebiten/internal/shaderir"
+)
+
+// A shader is executed for 4 invocations at a time in lockstep, like a GPU executes a fragment shader for a 2x2 quad.
+// The derivative functions like dfdx are calculated from the values of the neighboring invocations.
+//
+// For a fragment shader, the lanes are (x, y), (x+1, y), (x, y+1), and (x+1, y+1) in this order.
+// For a vertex shader, the lanes are 4 vertices.
+const (
+	laneCount = 4
+	allLanes  = 1<<laneCount - 1
+)
+
+// A value is represented as a slice of float64 values for all the lanes.
+// The i-th component of the l-th lane is at l*n+i, where n is the number of the components of the type.
+//
+// A float value is rounded to a float32 value at each operation.
+// An int value is an int32 value, and a bool value is 0 or 1.
+
+// exprFunc evaluates an expression for the lanes in mask.
+// The returned slice must not be modified by the caller.
+type exprFunc func(mask uint8) []float64
+
+// lvalueFunc returns the positions in the function's frame for each component of each lane in mask.
+type lvalueFunc func(mask uint8) *[laneCount][]int
+
+// stmtFunc executes a statement for the lanes in mask.
+type stmtFunc func(mask uint8)
+
+type Shader struct {
+	id       graphicsdriver.ShaderID
+	graphics *Graphics
+	ir       *shaderir.Program
+
+	funcs    map[int]*function
+	vertex   *function
+	fragment *function
+
+	// uniformOffsets is the offsets of the uniform variables in uniformValues.
+	uniformOffsets []int
+	uniformValues  []float64
+
+	// gen is incremented for each draw to update the cached uniform values.
+	gen int
+
+	textures [graphics.ShaderSrcImageCount]*Image
+
+	// ret, brk, cont, and discard are the lanes that returned from the current function,
+	// broke from or continued the current loop, and were discarded.
+	ret     uint8
+	brk     uint8
+	cont    uint8
+	discard uint8
+
+	// attributeOffsets is the offsets of the vertex attributes in a vertex.
+	attributeOffsets []int
+	vertexFloatCount int
+}
+
+// function is a compiled function.
+//
+// As a shader function cannot be called recursively, each function has only one frame for its local variables.
+type function struct {
+	frame   []float64
+	offsets []int
+	counts  []int
+
+	in  []shaderir.Type
+	out []shaderir.Type
+
+	retType shaderir.Type
+	ret     []float64
+
+	body stmtFunc
+}
+
+func newShader(id graphicsdriver.ShaderID, program *shaderir.Program) (*Shader, error) {
+	s := &Shader{
+		id:    id,
+		ir:    program,
+		funcs: map[int]*function{},
+	}
+
+	var offset int
+	for _, u := range program.Uniforms {
+		s.uniformOffsets = append(s.uniformOffsets, offset)
+		offset += componentCount(&u)
+	}
+	s.uniformValues = make([]float64, offset)
+
+	offset = 0
+	for _, a := range program.Attributes {
+		s.attributeOffsets = append(s.attributeOffsets, offset)
+		offset += componentCount(&a)
+	}
+	s.vertexFloatCount = graphics.ShaderVertexFloatCount(program)
+
+	for _, f := range program.Funcs {
+		s.funcs[f.Index] = newFunction(f.InParams, f.OutParams, f.Return, f.Block)
+	}
+
+	// For the pseudo parameters of the entry points, see the comments in internal/shaderir/program.go.
+	vertexParams := append([]shaderir.Type{}, program.Attributes...)
+	vertexParams = append(vertexParams, shaderir.Type{Main: shaderir.Vec4})
+	vertexParams = append(vertexParams, program.Varyings...)
+	s.vertex = newFunction(vertexParams, nil, shaderir.Type{}, program.VertexFunc.Block)
+
+	fragmentParams := []shaderir.Type{{Main: shaderir.Vec4}}
+	fragmentParams = append(fragmentParams, program.Varyings...)
+	if program.FragmentOutputCount > 0 {
+		// The colors for multiple render targets are out-params.
+		colors := make([]shaderir.Type, program.FragmentOutputCount)
+		for i := range colors {
+			colors[i] = shaderir.Type{Main: shaderir.Vec4}
+		}
+		s.fragment = newFunction(fragmentParams, colors, shaderir.Type{}, program.FragmentFunc.Block)
+	} else {
+		s.fragment = newFunction(fragmentParams, nil, shaderir.Type{Main: shaderir.Vec4}, program.FragmentFunc.Block)
+	}
+
+	for _, f := range program.Funcs {
+		if err := s.compileFunction(s.funcs[f.Index], f.Block); err != nil {
+			return nil, err
+		}
+	}
+	if err := s.compileFunction(s.vertex, program.VertexFunc.Block); err != nil {
+		return nil, err
+	}
+	if err := s.compileFunction(s.fragment, program.FragmentFunc.Block); err != nil {
+		return nil, err
+	}
+	return s, nil
+}
+
+func (s *Shader) ID() graphicsdriver.ShaderID {
+	return s.id
+}
+
+func (s *Shader) Dispose() {
+	s.graphics.removeShader(s)
+}
+
+// setUniforms sets the uniform values for the next draw.
+func (s *Shader) setUniforms(uniforms []uint32) {
+	s.gen++
+	var idx int
+	for i, u := range s.ir.Uniforms {
+		n := u.DwordCount()
+		vs := s.uniformValues[s.uniformOffsets[i] : s.uniformOffsets[i]+n]
+		if elementKind(&u) == kindInt {
+			for j := range vs {
+				vs[j] = float64(int32(uniforms[idx+j]))
+			}
+		} else {
+			for j := range vs {
+				vs[j] = float64(math.Float32frombits(uniforms[idx+j]))
+			}
+		}
+		idx += n
+	}
+}
+
+func newFunction(in, out []shaderir.Type, ret shaderir.Type, block *shaderir.Block) *function {
+	f := &function{
+		in:      in,
+		out:     out,
+		retType: ret,
+		ret:     make([]float64, laneCount*componentCount(&ret)),
+	}
+
+	set := func(index int, t shaderir.Type) {
+		for len(f.counts) <= index {
+			f.counts = append(f.counts, 0)
+		}
+		f.counts[index] = max(f.counts[index], componentCount(&t))
+	}
+	for i, t := range in {
+		set(i, t)
+	}
+	for i, t := range out {
+		set(len(in)+i, t)
+	}
+	var walk func(b *shaderir.Block)
+	walk = func(b *shaderir.Block) {
+		if b == nil {
+			return
+		}
+		for i, t := range b.LocalVars {
+			set(b.LocalVarIndexOffset+i, t)
+		}
+		for _, s := range b.Stmts {
+			if s.Type == shaderir.For {
+				set(s.ForVarIndex, s.ForVarType)
+			}
+			for _, b := range s.Blocks {
+				walk(b)
+			}
+		}
+	}
+	walk(block)
+
+	var offset int
+	for _, c := range f.counts {
+		f.offsets = append(f.offsets, offset)
+		offset += laneCount * c
+	}
+	f.frame = make([]float64, offset)
+	return f
+}
+
+type kind int
+
+const (
+	kindFloat kind = iota
+	kindInt
+	kindBool
+)
+
+func elementKind(t *shaderir.Type) kind {
+	switch t.Main {
+	case shaderir.Bool:
+		return kindBool
+	case shaderir.Int, shaderir.IVec2, shaderir.IVec3, shaderir.IVec4:
+		return kindInt
+	case shaderir.Array:
+		return elementKind(&t.Sub[0])
+	}
+	return kindFloat
+}
+
+func componentCount(t *shaderir.Type) int {
+	switch t.Main {
+	case shaderir.Bool, shaderir.Int, shaderir.Float:
+		return 1
+	case shaderir.Vec2, shaderir.IVec2:
+		return 2
+	case shaderir.Vec3, shaderir.IVec3:
+		return 3
+	case shaderir.Vec4, shaderir.IVec4:
+		return 4
+	case shaderir.Mat2:
+		return 4
+	case shaderir.Mat3:
+		return 9
+	case shaderir.Mat4:
+		return 16
+	case shaderir.Array:
+		return t.Length * componentCount(&t.Sub[0])
+	case shaderir.Struct:
+		var n int
+		for i := range t.Sub {
+			n += componentCount(&t.Sub[i])
+		}
+		return n
+	}
+	return 0
+}
+
+func toFloat(x float64) float64 {
+	return float64(float32(x))
+}
+
+func toInt(x float64) float64 {
+	// Avoid an implementation-specific conversion of NaN or an out-of-range value.
+	if x != x {
+		return 0
+	}
+	if x >= math.MaxInt32 {
+		return math.MaxInt32
+	}
+	if x <= math.MinInt32 {
+		return math.MinInt32
+	}
+	return float64(int32(x))
+}
+
+func toBool(x float64) float64 {
+	if x != 0 {
+		return 1
+	}
+	return 0
+}
+
+func convert(x float64, k kind) float64 {
+	switch k {
+	case kindInt:
+		return toInt(x)
+	case kindBool:
+		return toBool(x)
+	}
+	return toFloat(x)
+}
+
+// component returns the i-th component of the l-th lane, broadcasting a scalar value.
+func component(v []float64, n, l, i int) float64 {
+	if n == 1 {
+		return v[l]
+	}
+	return v[l*n+i]
+}
+
+type compiler struct {
+	s  *Shader
+	fn *function
+
+	// varTypes is the types of the local variables visible at the current position.
+	varTypes []shaderir.Type
+}
+
+func (s *Shader) compileFunction(f *function, block *shaderir.Block) error {
+	if block == nil {
+		return fmt.Errorf("software: a function body is missing")
+	}
+	c := &compiler{
+		s:  s,
+		fn: f,
+	}
+	c.varTypes = append(c.varTypes, f.in...)
+	c.varTypes = append(c.varTypes, f.out...)
+	body, err := c.block(block)
+	if err != nil {
+		return err
+	}
+	f.body = body
+	return nil
+}
+
+func (c *compiler) declare(index int, t shaderir.Type) {
+	for len(c.varTypes) <= index {
+		c.varTypes = append(c.varTypes, shaderir.Type{})
+	}
+	c.varTypes[index] = t
+}
+
+func (c *compiler) varType(index int) (shaderir.Type, error) {
+	if index < 0 || index >= len(c.varTypes) || c.varTypes[index].Main == shaderir.None {
+		return shaderir.Type{}, fmt.Errorf("software: unknown local variable: %d", index)
+	}
+	return c.varTypes[index], nil
+}
+
+func (c *compiler) block(b *shaderir.Block) (stmtFunc, error) {
+	s := c.s
+	frame := c.fn.frame
+
+	// A local variable in this block can have the same index as a local variable declared later in the parent block.
+	// Restore the types when leaving this block.
+	// The frame can be shared, as the variables are never alive at the same time.
+	origVarTypes := slices.Clone(c.varTypes)
+	defer func() {
+		c.varTypes = origVarTypes
+	}()
+
+	type varRange struct {
+		start int
+		end   int
+	}
+	var vars []varRange
+	for i, t := range b.LocalVars {
+		idx := b.LocalVarIndexOffset + i
+		if t.Main == shaderir.None {
+			// A for-loop counter is declared at the for statement.
+			continue
+		}
+		c.declare(idx, t)
+		off := c.fn.offsets[idx]
+		vars = append(vars, varRange{start: off, end: off + laneCount*c.fn.counts[idx]})
+	}
+
+	stmts := make([]stmtFunc, 0, len(b.Stmts))
+	for i := range b.Stmts {
+		st, err := c.stmt(&b.Stmts[i])
+		if err != nil {
+			return nil, err
+		}
+		stmts = append(stmts, st)
+	}
+
+	return func(mask uint8) {
+		// A local variable is initialized with zero values.
+		for _, v := range vars {
+			clear(frame[v.start:v.end])
+		}
+		for _, st := range stmts {
+			m := mask &^ (s.ret | s.brk | s.cont | s.discard)
+			if m == 0 {
+				return
+			}
+			st(m)
+		}
+	}, nil
+}
+
+func (c *compiler) stmt(st *shaderir.Stmt) (stmtFunc, error) {
+	s := c.s
+	frame := c.fn.frame
+
+	switch st.Type {
+	case shaderir.ExprStmt:
+		f, _, err := c.expr(&st.Exprs[0])
+		if err != nil {
+			return nil, err
+		}
+		return func(mask uint8) {
+			f(mask)
+		}, nil
+
+	case shaderir.BlockStmt:
+		return c.block(st.Blocks[0])
+
+	case shaderir.Assign:
+		rhs, rt, err := c.expr(&st.Exprs[1])
+		if err != nil {
+			return nil, err
+		}
+		lhs, lt, err := c.lvalue(&st.Exprs[0])
+		if err != nil {
+			return nil, err
+		}
+		n := componentCount(&lt)
+		if componentCount(&rt) != n {
+			return nil, fmt.Errorf("software: mismatched types at an assignment: %s and %s", lt.String(), rt.String())
+		}
+		// A local variable on the right-hand side is a view of the frame. Copy it in case the both sides overlap.
+		var tmp []float64
+		if st.Exprs[1].Type == shaderir.LocalVariable {
+			tmp = make([]float64, laneCount*n)
+		}
+		return func(mask uint8) {
+			v := rhs(mask)
+			if tmp != nil {
+				copy(tmp, v)
+				v = tmp
+			}
+			pos := lhs(mask)
+			for l := 0; l < laneCount; l++ {
+				if mask&(1<<l) == 0 {
+					continue
+				}
+				for i, p := range pos[l] {
+					frame[p] = v[l*n+i]
+				}
+			}
+		}, nil
+
+	case shaderir.Init:
+		t, err := c.varType(st.InitIndex)
+		if err != nil {
+			return nil, err
+		}
+		n := componentCount(&t)
+		off := c.fn.offsets[st.InitIndex]
+		return func(mask uint8) {
+			for l := 0; l < laneCount; l++ {
+				if mask&(1<<l) == 0 {
+					continue
+				}
+				clear(frame[off+l*n : off+(l+1)*n])
+			}
+		}, nil
+
+	case shaderir.If:
+		cond, _, err := c.expr(&st.Exprs[0])
+		if err != nil {
+			return nil, err
+		}
+		thenBlock, err := c.block(st.Blocks[0])
+		if err != nil {
+			return nil, err
+		}
+		var elseBlock stmtFunc
+		if len(st.Blocks) > 1 {
+			elseBlock, err = c.block(st.Blocks[1])
+			if err != nil {
+				return nil, err
+			}
+		}
+		return func(mask uint8) {
+			v := cond(mask)
+			var m uint8
+			for l := 0; l < laneCount; l++ {
+				if mask&(1<<l) != 0 && v[l] != 0 {
+					m |= 1 << l
+				}
+			}
+			if m != 0 {
+				thenBlock(m)
+			}
+			if m := mask &^ m; m != 0 && elseBlock != nil {
+				elseBlock(m)
+			}
+		}, nil
+
+	case shaderir.For:
+		return c.forStmt(st)
+
+	case shaderir.Switch:
+		return c.switchStmt(st)
+
+	case shaderir.Continue:
+		return func(mask uint8) {
+			s.cont |= mask
+		}, nil
+
+	case shaderir.Break:
+		return func(mask uint8) {
+			s.brk |= mask
+		}, nil
+
+	case shaderir.Return:
+		if len(st.Exprs) == 0 {
+			return func(mask uint8) {
+				s.ret |= mask
+			}, nil
+		}
+		f, t, err := c.expr(&st.Exprs[0])
+		if err != nil {
+			return nil, err
+		}
+		n := componentCount(&t)
+		if n != componentCount(&c.fn.retType) {
+			return nil, fmt.Errorf("software: mismatched return type: %s", t.String())
+		}
+		ret := c.fn.ret
+		return func(mask uint8) {
+			v := f(mask)
+			for l := 0; l < laneCount; l++ {
+				if mask&(1<<l) == 0 {
+					continue
+				}
+				copy(ret[l*n:(l+1)*n], v[l*n:(l+1)*n])
+			}
+			s.ret |= mask
+		}, nil
+
+	case shaderir.Discard:
+		return func(mask uint8) {
+			s.discard |= mask
+		}, nil
+	}
+
+	return nil, fmt.Errorf("software: unexpected statement: %d", st.Type)
+}
+
+func (c *compiler) forStmt(st *shaderir.Stmt) (stmtFunc, error) {
+	s := c.s
+	frame := c.fn.frame
+
+	c.declare(st.ForVarIndex, st.ForVarType)
+	k := elementKind(&st.ForVarType)
+	off := c.fn.offsets[st.ForVarIndex]
+
+	var initValue, endValue float64
+	var initFunc, endFunc exprFunc
+	if st.ForInit != nil && st.ForEnd != nil {
+		initValue = convert(constantValue(st.ForInit), k)
+		endValue = convert(constantValue(st.ForEnd), k)
+	} else {
+		var err error
+		initFunc, _, err = c.expr(&st.Exprs[0])
+		if err != nil {
+			return nil, err
+		}
+		endFunc, _, err = c.expr(&st.Exprs[1])
+		if err != nil {
+			return nil, err
+		}
+	}
+	delta := convert(constantValue(st.ForDelta), k)
+
+	var cond func(x, end float64) bool
+	switch st.ForOp {
+	case shaderir.LessThanOp:
+		cond = func(x, end float64) bool { return x < end }
+	case shaderir.LessThanEqualOp:
+		cond = func(x, end float64) bool { return x <= end }
+	case shaderir.GreaterThanOp:
+		cond = func(x, end float64) bool { return x > end }
+	case shaderir.GreaterThanEqualOp:
+		cond = func(x, end float64) bool { return x >= end }
+	case shaderir.EqualOp:
+		cond = func(x, end float64) bool { return x == end }
+	case shaderir.NotEqualOp:
+		cond = func(x, end float64) bool { return x != end }
+	default:
+		return nil, fmt.Errorf("software: unexpected for-loop operator: %d", st.ForOp)
+	}
+
+	body, err := c.block(st.Blocks[0])
+	if err != nil {
+		return nil, err
+	}
+
+	return func(mask uint8) {
+		brk, cont := s.brk, s.cont
+		s.brk, s.cont = 0, 0
+
+		if initFunc != nil {
+			v := initFunc(mask)
+			for l := 0; l < laneCount; l++ {
+				frame[off+l] = convert(v[l], k)
+			}
+		} else {
+			for l := 0; l < laneCount; l++ {
+				frame[off+l] = initValue
+			}
+		}
+
+		active := mask
+		for {
+			active &^= s.brk | s.ret | s.discard
+			if active == 0 {
+				break
+			}
+			var end []float64
+			if endFunc != nil {
+				end = endFunc(active)
+			}
+			for l := 0; l < laneCount; l++ {
+				if active&(1<<l) == 0 {
+					continue
+				}
+				e := endValue
+				if end != nil {
+					e = end[l]
+				}
+				if !cond(frame[off+l], e) {
+					active &^= 1 << l
+				}
+			}
+			if active == 0 {
+				break
+			}
+
+			s.cont = 0
+			body(active)
+			s.cont = 0
+
+			for l := 0; l < laneCount; l++ {
+				frame[off+l] = convert(frame[off+l]+delta, k)
+			}
+		}
+
+		s.brk, s.cont = brk, cont
+	}, nil
+}
+
+func (c *compiler) switchStmt(st *shaderir.Stmt) (stmtFunc, error) {
+	s := c.s
+
+	value, _, err := c.expr(&st.Exprs[0])
+	if err != nil {
+		return nil, err
+	}
+	type switchCase struct {
+		values []float64
+		block  stmtFunc
+	}
+	var cases []switchCase
+	var defaultBlock stmtFunc
+	for i, b := range st.Blocks {
+		block, err := c.block(b)
+		if err != nil {
+			return nil, err
+		}
+		if st.SwitchCases[i] == nil {
+			defaultBlock = block
+			continue
+		}
+		var values []float64
+		for _, v := range st.SwitchCases[i] {
+			values = append(values, constantValue(v))
+		}
+		cases = append(cases, switchCase{
+			values: values,
+			block:  block,
+		})
+	}
+
+	return func(mask uint8) {
+		v := value(mask)
+
+		// break in a switch statement exits the switch statement.
+		brk := s.brk
+		s.brk = 0
+
+		var matched uint8
+		for _, cs := range cases {
+			var m uint8
+			for l := 0; l < laneCount; l++ {
+				if mask&(1<<l) == 0 {
+					continue
+				}
+				for _, x := range cs.values {
+					if v[l] == x {
+						m |= 1 << l
+						break
+					}
+				}
+			}
+			matched |= m
+			if m != 0 {
+				cs.block(m)
+			}
+		}
+		if m := mask &^ matched; m != 0 && defaultBlock != nil {
+			defaultBlock(m)
+		}
+
+		s.brk = brk
+	}, nil
+}
+
+func constantValue(v constant.Value) float64 {
+	switch v.Kind() {
+	case constant.Bool:
+		if constant.BoolVal(v) {
+			return 1
+		}
+		return 0
+	case constant.Int:
+		x, _ := constant.Int64Val(v)
+		return float64(int32(x))
+	case constant.Float:
+		x, _ := constant.Float64Val(v)
+		return toFloat(x)
+	}
+	return 0
+}
+
+func constantType(v constant.Value) shaderir.Type {
+	switch v.Kind() {
+	case constant.Bool:
+		return shaderir.Type{Main: shaderir.Bool}
+	case constant.Int:
+		return shaderir.Type{Main: shaderir.Int}
+	}
+	return shaderir.Type{Main: shaderir.Float}
+}
+
+// broadcast returns a value whose lanes have the same given components.
+func broadcast(v []float64) []float64 {
+	buf := make([]float64, laneCount*len(v))
+	for l := 0; l < laneCount; l++ {
+		copy(buf[l*len(v):], v)
+	}
+	return buf
+}
+
+func (c *compiler) expr(e *shaderir.Expr) (exprFunc, shaderir.Type, error) {
+	s := c.s
+
+	switch e.Type {
+	case shaderir.NumberExpr:
+		buf := broadcast([]float64{constantValue(e.Const)})
+		return func(mask uint8) []float64 {
+			return buf
+		}, constantType(e.Const), nil
+
+	case shaderir.UniformVariable:
+		t := s.ir.Uniforms[e.Index]
+		n := componentCount(&t)
+		off := s.uniformOffsets[e.Index]
+		buf := make([]float64, laneCount*n)
+		gen := -1
+		return func(mask uint8) []float64 {
+			if gen != s.gen {
+				for l := 0; l < laneCount; l++ {
+					copy(buf[l*n:(l+1)*n], s.uniformValues[off:off+n])
+				}
+				gen = s.gen
+			}
+			return buf
+		}, t, nil
+
+	case shaderir.ConstArrayVariable:
+		a := &s.ir.ConstArrays[e.Index]
+		var vs []float64
+		for i := range a.Exprs {
+			f, t, err := c.expr(&a.Exprs[i])
+			if err != nil {
+				return nil, shaderir.Type{}, err
+			}
+			k := elementKind(&a.Type.Sub[0])
+			for _, v := range f(allLanes)[:componentCount(&t)] {
+				vs = append(vs, convert(v, k))
+			}
+		}
+		if len(vs) != componentCount(&a.Type) {
+			return nil, shaderir.Type{}, fmt.Errorf("software: invalid constant array: %s", a.Name)
+		}
+		buf := broadcast(vs)
+		return func(mask uint8) []float64 {
+			return buf
+		}, a.Type, nil
+
+	case shaderir.LocalVariable:
+		t, err := c.varType(e.Index)
+		if err != nil {
+			return nil, shaderir.Type{}, err
+		}
+		off := c.fn.offsets[e.Index]
+		v := c.fn.frame[off : off+laneCount*componentCount(&t)]
+		return func(mask uint8) []float64 {
+			return v
+		}, t, nil
+
+	case shaderir.Unary:
+		return c.unaryExpr(e)
+
+	case shaderir.Binary:
+		return c.binaryExpr(e)
+
+	case shaderir.Selection:
+		cond, _, err := c.expr(&e.Exprs[0])
+		if err != nil {
+			return nil, shaderir.Type{}, err
+		}
+		lhs, t, err := c.expr(&e.Exprs[1])
+		if err != nil {
+			return nil, shaderir.Type{}, err
+		}
+		rhs, _, err := c.expr(&e.Exprs[2])
+		if err != nil {
+			return nil, shaderir.Type{}, err
+		}
+		n := componentCount(&t)
+		buf := make([]float64, laneCount*n)
+		return func(mask uint8) []float64 {
+			cv := cond(mask)
+			lv := lhs(mask)
+			rv := rhs(mask)
+			for l := 0; l < laneCount; l++ {
+				if cv[l] != 0 {
+					copy(buf[l*n:(l+1)*n], lv[l*n:(l+1)*n])
+				} else {
+					copy(buf[l*n:(l+1)*n], rv[l*n:(l+1)*n])
+				}
+			}
+			return buf
+		}, t, nil
+
+	case shaderir.Call:
+		switch e.Exprs[0].Type {
+		case shaderir.BuiltinFuncExpr:
+			return c.builtinCall(e)
+		case shaderir.FunctionExpr:
+			return c.functionCall(e)
+		}
+		return nil, shaderir.Type{}, fmt.Errorf("software: unexpected callee: %d", e.Exprs[0].Type)
+
+	case shaderir.FieldSelector:
+		base, bt, err := c.expr(&e.Exprs[0])
+		if err != nil {
+			return nil, shaderir.Type{}, err
+		}
+		indices, t, err := fieldIndices(&bt, &e.Exprs[1])
+		if err != nil {
+			return nil, shaderir.Type{}, err
+		}
+		bn := componentCount(&bt)
+		n := len(indices)
+		buf := make([]float64, laneCount*n)
+		return func(mask uint8) []float64 {
+			v := base(mask)
+			for l := 0; l < laneCount; l++ {
+				for i, idx := range indices {
+					buf[l*n+i] = v[l*bn+idx]
+				}
+			}
+			return buf
+		}, t, nil
+
+	case shaderir.Index:
+		base, bt, err := c.expr(&e.Exprs[0])
+		if err != nil {
+			return nil, shaderir.Type{}, err
+		}
+		index, _, err := c.expr(&e.Exprs[1])
+		if err != nil {
+			return nil, shaderir.Type{}, err
+		}
+		t, length, err := indexedType(&bt)
+		if err != nil {
+			return nil, shaderir.Type{}, err
+		}
+		bn := componentCount(&bt)
+		n := componentCount(&t)
+		buf := make([]float64, laneCount*n)
+		return func(mask uint8) []float64 {
+			v := base(mask)
+			idx := index(mask)
+			for l := 0; l < laneCount; l++ {
+				i := clampIndex(idx[l], length)
+				copy(buf[l*n:(l+1)*n], v[l*bn+i*n:l*bn+(i+1)*n])
+			}
+			return buf
+		}, t, nil
+	}
+
+	return nil, shaderir.Type{}, fmt.Errorf("software: unexpected expression: %d", e.Type)
+}
+
+// clampIndex clamps an index not to access out of range, as the behavior is undefined in shading languages.
+func clampIndex(index float64, length int) int {
+	i := int(index)
+	if i < 0 {
+		return 0
+	}
+	if i >= length {
+		return length - 1
+	}
+	return i
+}
+
+// indexedType returns the type of an element of t and the number of the elements.
+func indexedType(t *shaderir.Type) (shaderir.Type, int, error) {
+	switch {
+	case t.Main == shaderir.Array:
+		return t.Sub[0], t.Length, nil
+	case t.IsFloatVector():
+		return shaderir.Type{Main: shaderir.Float}, t.VectorElementCount(), nil
+	case t.IsIntVector():
+		return shaderir.Type{Main: shaderir.Int}, t.VectorElementCount(), nil
+	case t.IsMatrix():
+		n := t.MatrixSize()
+		return vectorType(kindFloat, n), n, nil
+	}
+	return shaderir.Type{}, 0, fmt.Errorf("software: type %s cannot be indexed", t.String())
+}
+
+// fieldIndices returns the indices of the components selected by a swizzling or a struct member, and the result type.
+func fieldIndices(t *shaderir.Type, field *shaderir.Expr) ([]int, shaderir.Type, error) {
+	switch field.Type {
+	case shaderir.SwizzlingExpr:
+		if !shaderir.IsValidSwizzling(field.Swizzling) {
+			return nil, shaderir.Type{}, fmt.Errorf("software: invalid swizzling: %s", field.Swizzling)
+		}
+		var indices []int
+		for _, ch := range field.Swizzling {
+			var idx int
+			switch ch {
+			case 'x', 'r', 's':
+				idx = 0
+			case 'y', 'g', 't':
+				idx = 1
+			case 'z', 'b', 'p':
+				idx = 2
+			case 'w', 'a', 'q':
+				idx = 3
+			}
+			if idx >= max(componentCount(t), 1) {
+				return nil, shaderir.Type{}, fmt.Errorf("software: invalid swizzling %s for %s", field.Swizzling, t.String())
+			}
+			indices = append(indices, idx)
+		}
+		return indices, vectorType(elementKind(t), len(indices)), nil
+
+	case shaderir.StructMember:
+		if t.Main != shaderir.Struct || field.Index >= len(t.Sub) {
+			return nil, shaderir.Type{}, fmt.Errorf("software: invalid struct member: %d", field.Index)
+		}
+		var offset int
+		for i := 0; i < field.Index; i++ {
+			offset += componentCount(&t.Sub[i])
+		}
+		mt := t.Sub[field.Index]
+		indices := make([]int, componentCount(&mt))
+		for i := range indices {
+			indices[i] = offset + i
+		}
+		return indices, mt, nil
+	}
+	return nil, shaderir.Type{}, fmt.Errorf("software: unexpected field: %d", field.Type)
+}
+
+// vectorType returns a scalar or vector type with n components.
+func vectorType(k kind, n int) shaderir.Type {
+	switch k {
+	case kindBool:
+		return shaderir.Type{Main: shaderir.Bool}
+	case kindInt:
+		return shaderir.Type{Main: [...]shaderir.BasicType{shaderir.Int, shaderir.IVec2, shaderir.IVec3, shaderir.IVec4}[n-1]}
+	}
+	return shaderir.Type{Main: [...]shaderir.BasicType{shaderir.Float, shaderir.Vec2, shaderir.Vec3, shaderir.Vec4}[n-1]}
+}
+
+func (c *compiler) lvalue(e *shaderir.Expr) (lvalueFunc, shaderir.Type, error) {
+	switch e.Type {
+	case shaderir.LocalVariable:
+		t, err := c.varType(e.Index)
+		if err != nil {
+			return nil, shaderir.Type{}, err
+		}
+		n := componentCount(&t)
+		off := c.fn.offsets[e.Index]
+		var pos [laneCount][]int
+		for l := range pos {
+			pos[l] = make([]int, n)
+			for i := range pos[l] {
+				pos[l][i] = off + l*n + i
+			}
+		}
+		return func(mask uint8) *[laneCount][]int {
+			return &pos
+		}, t, nil
+
+	case shaderir.FieldSelector:
+		base, bt, err := c.lvalue(&e.Exprs[0])
+		if err != nil {
+			return nil, shaderir.Type{}, err
+		}
+		indices, t, err := fieldIndices(&bt, &e.Exprs[1])
+		if err != nil {
+			return nil, shaderir.Type{}, err
+		}
+		var pos [laneCount][]int
+		for l := range pos {
+			pos[l] = make([]int, len(indices))
+		}
+		return func(mask uint8) *[laneCount][]int {
+			b := base(mask)
+			for l := range pos {
+				for i, idx := range indices {
+					pos[l][i] = b[l][idx]
+				}
+			}
+			return &pos
+		}, t, nil
+
+	case shaderir.Index:
+		base, bt, err := c.lvalue(&e.Exprs[0])
+		if err != nil {
+			return nil, shaderir.Type{}, err
+		}
+		index, _, err := c.expr(&e.Exprs[1])
+		if err != nil {
+			return nil, shaderir.Type{}, err
+		}
+		t, length, err := indexedType(&bt)
+		if err != nil {
+			return nil, shaderir.Type{}, err
+		}
+		n := componentCount(&t)
+		var pos [laneCount][]int
+		for l := range pos {
+			pos[l] = make([]int, n)
+		}
+		return func(mask uint8) *[laneCount][]int {
+			idx := index(mask)
+			b := base(mask)
+			for l := range pos {
+				i := clampIndex(idx[l], length)
+				copy(pos[l], b[l][i*n:(i+1)*n])
+			}
+			return &pos
+		}, t, nil
+	}
+	return nil, shaderir.Type{}, fmt.Errorf("software: unexpected expression for an assignment: %d", e.Type)
+}
+
+func (c *compiler) functionCall(e *shaderir.Expr) (exprFunc, shaderir.Type, error) {
+	s := c.s
+	frame := c.fn.frame
+
+	callee, ok := s.funcs[e.Exprs[0].Index]
+	if !ok {
+		return nil, shaderir.Type{}, fmt.Errorf("software: unknown function: %d", e.Exprs[0].Index)
+	}
+	args := e.Exprs[1:]
+	if len(args) != len(callee.in)+len(callee.out) {
+		return nil, shaderir.Type{}, fmt.Errorf("software: wrong number of arguments")
+	}
+
+	var ins []exprFunc
+	for i := range callee.in {
+		f, _, err := c.expr(&args[i])
+		if err != nil {
+			return nil, shaderir.Type{}, err
+		}
+		ins = append(ins, f)
+	}
+	var outs []lvalueFunc
+	for i := range callee.out {
+		f, _, err := c.lvalue(&args[len(callee.in)+i])
+		if err != nil {
+			return nil, shaderir.Type{}, err
+		}
+		outs = append(outs, f)
+	}
+
+	n := componentCount(&callee.retType)
+	buf := make([]float64, laneCount*n)
+	vs := make([][]float64, len(ins))
+	return func(mask uint8) []float64 {
+		if mask == 0 {
+			return buf
+		}
+
+		// Evaluate all the arguments before setting them, as an argument might call the same function.
+		for i, f := range ins {
+			vs[i] = f(mask)
+		}
+		for i, v := range vs {
+			off := callee.offsets[i]
+			n := callee.counts[i]
+			for l := 0; l < laneCount; l++ {
+				if mask&(1<<l) == 0 {
+					continue
+				}
+				copy(callee.frame[off+l*n:off+(l+1)*n], v[l*n:(l+1)*n])
+			}
+			vs[i] = nil
+		}
+		for i := range outs {
+			idx := len(ins) + i
+			off := callee.offsets[idx]
+			clear(callee.frame[off : off+laneCount*callee.counts[idx]])
+		}
+
+		ret, brk, cont := s.ret, s.brk, s.cont
+		s.ret, s.brk, s.cont = 0, 0, 0
+		callee.body(mask)
+		s.ret, s.brk, s.cont = ret, brk, cont
+
+		copy(buf, callee.ret)
+		for i, f := range outs {
+			idx := len(ins) + i
+			off := callee.offsets[idx]
+			n := callee.counts[idx]
+			pos := f(mask)
+			for l := 0; l < laneCount; l++ {
+				if mask&(1<<l) == 0 {
+					continue
+				}
+				for j, p := range pos[l] {
+					frame[p] = callee.frame[off+l*n+j]
+				}
+			}
+		}
+		return buf
+	}, callee.retType, nil
+}
+
+func (c *compiler) unaryExpr(e *shaderir.Expr) (exprFunc, shaderir.Type, error) {
+	f, t, err := c.expr(&e.Exprs[0])
+	if err != nil {
+		return nil, shaderir.Type{}, err
+	}
+	n := componentCount(&t)
+	buf := make([]float64, laneCount*n)
+	switch e.Op {
+	case shaderir.Add:
+		return f, t, nil
+	case shaderir.Sub:
+		if elementKind(&t) == kindInt {
+			return func(mask uint8) []float64 {
+				for i, x := range f(mask) {
+					buf[i] = float64(-int32(x))
+				}
+				return buf
+			}, t, nil
+		}
+		return func(mask uint8) []float64 {
+			for i, x := range f(mask) {
+				buf[i] = -x
+			}
+			return buf
+		}, t, nil
+	case shaderir.NotOp:
+		return func(mask uint8) []float64 {
+			for i, x := range f(mask) {
+				buf[i] = 1 - toBool(x)
+			}
+			return buf
+		}, t, nil
+	}
+	return nil, shaderir.Type{}, fmt.Errorf("software: unexpected unary operator: %d", e.Op)
+}
+
+func (c *compiler) binaryExpr(e *shaderir.Expr) (exprFunc, shaderir.Type, error) {
+	lhs, lt, err := c.expr(&e.Exprs[0])
+	if err != nil {
+		return nil, shaderir.Type{}, err
+	}
+	rhs, rt, err := c.expr(&e.Exprs[1])
+	if err != nil {
+		return nil, shaderir.Type{}, err
+	}
+	ln := componentCount(&lt)
+	rn := componentCount(&rt)
+
+	switch e.Op {
+	case shaderir.MatrixMul:
+		if ln > 1 && rn > 1 {
+			return matrixMul(lhs, rhs, &lt, &rt)
+		}
+		// A matrix multiplied by a scalar is a component-wise multiplication.
+	case shaderir.LessThanOp, shaderir.LessThanEqualOp, shaderir.GreaterThanOp, shaderir.GreaterThanEqualOp:
+		var cmp func(a, b float64) bool
+		switch e.Op {
+		case shaderir.LessThanOp:
+			cmp = func(a, b float64) bool { return a < b }
+		case shaderir.LessThanEqualOp:
+			cmp = func(a, b float64) bool { return a <= b }
+		case shaderir.GreaterThanOp:
+			cmp = func(a, b float64) bool { return a > b }
+		case shaderir.GreaterThanEqualOp:
+			cmp = func(a, b float64) bool { return a >= b }
+		}
+		buf := make([]float64, laneCount)
+		return func(mask uint8) []float64 {
+			lv := lhs(mask)
+			rv := rhs(mask)
+			for l := 0; l < laneCount; l++ {
+				buf[l] = 0
+				if cmp(lv[l], rv[l]) {
+					buf[l] = 1
+				}
+			}
+			return buf
+		}, shaderir.Type{Main: shaderir.Bool}, nil
+	case shaderir.EqualOp, shaderir.NotEqualOp, shaderir.VectorEqualOp, shaderir.VectorNotEqualOp:
+		if ln != rn {
+			return nil, shaderir.Type{}, fmt.Errorf("software: mismatched types at a comparison: %s and %s", lt.String(), rt.String())
+		}
+		eq := e.Op == shaderir.EqualOp || e.Op == shaderir.VectorEqualOp
+		buf := make([]float64, laneCount)
+		return func(mask uint8) []float64 {
+			lv := lhs(mask)
+			rv := rhs(mask)
+			for l := 0; l < laneCount; l++ {
+				same := true
+				for i := 0; i < ln; i++ {
+					if lv[l*ln+i] != rv[l*ln+i] {
+						same = false
+						break
+					}
+				}
+				buf[l] = 0
+				if same == eq {
+					buf[l] = 1
+				}
+			}
+			return buf
+		}, shaderir.Type{Main: shaderir.Bool}, nil
+	case shaderir.AndAnd, shaderir.OrOr:
+		and := e.Op == shaderir.AndAnd
+		buf := make([]float64, laneCount)
+		return func(mask uint8) []float64 {
+			lv := lhs(mask)
+			rv := rhs(mask)
+			for l := 0; l < laneCount; l++ {
+				var v bool
+				if and {
+					v = lv[l] != 0 && rv[l] != 0
+				} else {
+					v = lv[l] != 0 || rv[l] != 0
+				}
+				buf[l] = 0
+				if v {
+					buf[l] = 1
+				}
+			}
+			return buf
+		}, shaderir.Type{Main: shaderir.Bool}, nil
+	}
+
+	// The other operators are component-wise. A scalar operand is broadcasted.
+	t := lt
+	if ln == 1 && rn > 1 {
+		t = rt
+	}
+	if ln > 1 && rn > 1 && ln != rn {
+		return nil, shaderir.Type{}, fmt.Errorf("software: mismatched types at a binary operator: %s and %s", lt.String(), rt.String())
+	}
+	// An untyped constant might be an integer in a float expression.
+	k := elementKind(&lt)
+	if elementKind(&rt) == kindFloat {
+		k = kindFloat
+	}
+	op, err := componentWiseOp(e.Op, k)
+	if err != nil {
+		return nil, shaderir.Type{}, err
+	}
+	n := componentCount(&t)
+	buf := make([]float64, laneCount*n)
+	return func(mask uint8) []float64 {
+		lv := lhs(mask)
+		rv := rhs(mask)
+		switch {
+		case ln == rn:
+			for i := range buf {
+				buf[i] = op(lv[i], rv[i])
+			}
+		case ln == 1:
+			for l := 0; l < laneCount; l++ {
+				for i := 0; i < n; i++ {
+					buf[l*n+i] = op(lv[l], rv[l*n+i])
+				}
+			}
+		default:
+			for l := 0; l < laneCount; l++ {
+				for i := 0; i < n; i++ {
+					buf[l*n+i] = op(lv[l*n+i], rv[l])
+				}
+			}
+		}
+		return buf
+	}, t, nil
+}
+
+func componentWiseOp(op shaderir.Op, k kind) (func(a, b float64) float64, error) {
+	if k == kindInt {
+		switch op {
+		case shaderir.Add:
+			return func(a, b float64) float64 { return float64(int32(a) + int32(b)) }, nil
+		case shaderir.Sub:
+			return func(a, b float64) float64 { return float64(int32(a) - int32(b)) }, nil
+		case shaderir.ComponentWiseMul, shaderir.MatrixMul:
+			return func(a, b float64) float64 { return float64(int32(a) * int32(b)) }, nil
+		case shaderir.Div:
+			return func(a, b float64) float64 {
+				if int32(b) == 0 {
+					return 0
+				}
+				return float64(int32(a) / int32(b))
+			}, nil
+		case shaderir.ModOp:
+			return func(a, b float64) float64 {
+				if int32(b) == 0 {
+					return 0
+				}
+				return float64(int32(a) % int32(b))
+			}, nil
+		case shaderir.LeftShift:
+			return func(a, b float64) float64 { return float64(int32(a) << (uint32(b) & 31)) }, nil
+		case shaderir.RightShift:
+			return func(a, b float64) float64 { return float64(int32(a) >> (uint32(b) & 31)) }, nil
+		case shaderir.And:
+			return func(a, b float64) float64 { return float64(int32(a) & int32(b)) }, nil
+		case shaderir.Or:
+			return func(a, b float64) float64 { return float64(int32(a) | int32(b)) }, nil
+		case shaderir.Xor:
+			return func(a, b float64) float64 { return float64(int32(a) ^ int32(b)) }, nil
+		}
+		return nil, fmt.Errorf("software: unexpected binary operator for integers: %d", op)
+	}
+
+	switch op {
+	case shaderir.Add:
+		return func(a, b float64) float64 { return toFloat(a + b) }, nil
+	case shaderir.Sub:
+		return func(a, b float64) float64 { return toFloat(a - b) }, nil
+	case shaderir.ComponentWiseMul, shaderir.MatrixMul:
+		return func(a, b float64) float64 { return toFloat(a * b) }, nil
+	case shaderir.Div:
+		return func(a, b float64) float64 { return toFloat(a / b) }, nil
+	}
+	return nil, fmt.Errorf("software: unexpected binary operator for floats: %d", op)
+}
+
+// matrixMul returns a function for a matrix multiplication.
+// A matrix is in column-major order.
+func matrixMul(lhs, rhs exprFunc, lt, rt *shaderir.Type) (exprFunc, shaderir.Type, error) {
+	switch {
+	case lt.IsMatrix() && rt.IsMatrix():
+		n := lt.MatrixSize()
+		if rt.MatrixSize() != n {
+			break
+		}
+		buf := make([]float64, laneCount*n*n)
+		return func(mask uint8) []float64 {
+			lv := lhs(mask)
+			rv := rhs(mask)
+			for l := 0; l < laneCount; l++ {
+				a := lv[l*n*n : (l+1)*n*n]
+				b := rv[l*n*n : (l+1)*n*n]
+				for col := 0; col < n; col++ {
+					for row := 0; row < n; row++ {
+						var v float64
+						for k := 0; k < n; k++ {
+							v += a[k*n+row] * b[col*n+k]
+						}
+						buf[l*n*n+col*n+row] = toFloat(v)
+					}
+				}
+			}
+			return buf
+		}, *lt, nil
+
+	case lt.IsMatrix() && rt.IsFloatVector():
+		n := lt.MatrixSize()
+		if rt.VectorElementCount() != n {
+			break
+		}
+		buf := make([]float64, laneCount*n)
+		return func(mask uint8) []float64 {
+			lv := lhs(mask)
+			rv := rhs(mask)
+			for l := 0; l < laneCount; l++ {
+				m := lv[l*n*n : (l+1)*n*n]
+				v := rv[l*n : (l+1)*n]
+				for row := 0; row < n; row++ {
+					var x float64
+					for k := 0; k < n; k++ {
+						x += m[k*n+row] * v[k]
+					}
+					buf[l*n+row] = toFloat(x)
+				}
+			}
+			return buf
+		}, *rt, nil
+
+	case lt.IsFloatVector() && rt.IsMatrix():
+		n := rt.MatrixSize()
+		if lt.VectorElementCount() != n {
+			break
+		}
+		buf := make([]float64, laneCount*n)
+		return func(mask uint8) []float64 {
+			lv := lhs(mask)
+			rv := rhs(mask)
+			for l := 0; l < laneCount; l++ {
+				v := lv[l*n : (l+1)*n]
+				m := rv[l*n*n : (l+1)*n*n]
+				for col := 0; col < n; col++ {
+					var x float64
+					for k := 0; k < n; k++ {
+						x += v[k] * m[col*n+k]
+					}
+					buf[l*n+col] = toFloat(x)
+				}
+			}
+			return buf
+		}, *lt, nil
+	}
+	return nil, shaderir.Type{}, fmt.Errorf("software: mismatched types at a matrix multiplication: %s and %s", lt.String(), rt.String())
+}
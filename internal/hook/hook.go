@@ -42,6 +42,30 @@ func RunBeforeUpdateHooks() error {
 	return nil
 }
 
+var onUserGestureHooks []func() error
+
+// AppendHookOnUserGesture appends a hook function that is run synchronously in a user gesture event handler,
+// e.g., a click or a key press.
+//
+// This is used only on browsers, where some APIs like resuming audio require a user gesture.
+func AppendHookOnUserGesture(f func() error) {
+	m.Lock()
+	onUserGestureHooks = append(onUserGestureHooks, f)
+	m.Unlock()
+}
+
+func RunUserGestureHooks() error {
+	m.Lock()
+	defer m.Unlock()
+
+	for _, f := range onUserGestureHooks {
+		if err := f(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
 var (
 	audioSuspended bool
 	onSuspendAudio func() error
@@ -60,6 +84,13 @@ func OnResumeAudio(f func() error) {
 	m.Unlock()
 }
 
+// IsAudioSuspended reports whether the audio is suspended by SuspendAudio.
+func IsAudioSuspended() bool {
+	m.Lock()
+	defer m.Unlock()
+	return audioSuspended
+}
+
 func SuspendAudio() error {
 	m.Lock()
 	defer m.Unlock()
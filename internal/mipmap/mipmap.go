@@ -28,12 +28,15 @@ import (
 
 func canUseMipmap(imageType atlas.ImageType) bool {
 	switch imageType {
-	case atlas.ImageTypeRegular, atlas.ImageTypeUnmanaged:
+	case atlas.ImageTypeRegular, atlas.ImageTypeUnmanaged, atlas.ImageTypeUnrestorable, atlas.ImageTypeStreaming, atlas.ImageTypeDepth, atlas.ImageTypeFloat16, atlas.ImageTypeFloat32:
 		return true
 	}
 	return false
 }
 
+// DefaultMaxLevel is the default maximum mipmap level.
+const DefaultMaxLevel = 6
+
 // Mipmap is a set of buffered.Image sorted by the order of mipmap level.
 // The level 0 image is a regular image and higher-level images are used for mipmap.
 type Mipmap struct {
@@ -42,6 +45,11 @@ type Mipmap struct {
 	imageType atlas.ImageType
 	orig      *buffered.Image
 	imgs      map[int]imageWithDirtyFlag
+	maxLevel  int
+
+	gutter     int
+	gutterMode atlas.GutterMode
+	atlasGroup int
 }
 
 type imageWithDirtyFlag struct {
@@ -55,6 +63,57 @@ func New(width, height int, imageType atlas.ImageType) *Mipmap {
 		height:    height,
 		orig:      buffered.NewImage(width, height, imageType),
 		imageType: imageType,
+		maxLevel:  DefaultMaxLevel,
+	}
+}
+
+// SetGutter sets the gutter of the image and its mipmap images.
+// SetGutter must be called before the image is used.
+func (m *Mipmap) SetGutter(size int, mode atlas.GutterMode) {
+	m.gutter = size
+	m.gutterMode = mode
+	m.orig.SetGutter(size, mode)
+}
+
+// SetAtlasGroup sets the atlas group of the image and its mipmap images.
+// SetAtlasGroup must be called before the image is used.
+func (m *Mipmap) SetAtlasGroup(group int) {
+	m.atlasGroup = group
+	m.orig.SetAtlasGroup(group)
+}
+
+// SetMaxLevel sets the maximum mipmap level used when the image is a source.
+// 0 means that mipmaps are never used.
+func (m *Mipmap) SetMaxLevel(level int) {
+	if level < 0 {
+		panic(fmt.Sprintf("mipmap: level must be non-negative but %d", level))
+	}
+	if m.maxLevel == level {
+		return
+	}
+	m.maxLevel = level
+
+	// Release the images for the levels that are no longer used.
+	for l, img := range m.imgs {
+		if l <= level {
+			continue
+		}
+		if img.img != nil {
+			img.img.Deallocate()
+		}
+		delete(m.imgs, l)
+	}
+}
+
+// GenerateLevels generates the mipmap images up to the maximum level if they don't exist or are dirty.
+func (m *Mipmap) GenerateLevels() {
+	if !canUseMipmap(m.imageType) {
+		return
+	}
+	for l := 1; l <= m.maxLevel; l++ {
+		if m.level(l) == nil {
+			return
+		}
 	}
 }
 
@@ -67,6 +126,11 @@ func (m *Mipmap) WritePixels(pix []byte, region image.Rectangle) {
 	m.markDirty()
 }
 
+func (m *Mipmap) WritePixelsFloat32(pix []float32, region image.Rectangle) {
+	m.orig.WritePixelsFloat32(pix, region)
+	m.markDirty()
+}
+
 func (m *Mipmap) markDirty() {
 	for i, img := range m.imgs {
 		img.dirty = true
@@ -78,13 +142,21 @@ func (m *Mipmap) ReadPixels(graphicsDriver graphicsdriver.Graphics, pixels []byt
 	return m.orig.ReadPixels(graphicsDriver, pixels, region)
 }
 
-func (m *Mipmap) DrawTriangles(srcs [graphics.ShaderSrcImageCount]*Mipmap, vertices []float32, indices []uint32, blend graphicsdriver.Blend, dstRegion image.Rectangle, srcRegions [graphics.ShaderSrcImageCount]image.Rectangle, shader *atlas.Shader, uniforms []uint32, fillRule graphicsdriver.FillRule, canSkipMipmap bool, hint restorable.Hint) {
+func (m *Mipmap) ReadPixelsFloat32(graphicsDriver graphicsdriver.Graphics, pixels []float32, region image.Rectangle) (ok bool, err error) {
+	return m.orig.ReadPixelsFloat32(graphicsDriver, pixels, region)
+}
+
+// DrawTriangles draws the triangles with the given sources.
+//
+// If trilinear is true, the shader must be a trilinear shader that takes the next mipmap level as the second source image.
+// The second source image and its region are given by DrawTriangles, or are empty if the next level is not available.
+func (m *Mipmap) DrawTriangles(srcs [graphics.ShaderSrcImageCount]*Mipmap, vertices []float32, indices []uint32, blend graphicsdriver.Blend, dstRegion image.Rectangle, srcRegions [graphics.ShaderSrcImageCount]image.Rectangle, shader *atlas.Shader, uniforms []uint32, fillRule graphicsdriver.FillRule, canSkipMipmap bool, trilinear bool, hint restorable.Hint) {
 	if len(indices) == 0 {
 		return
 	}
 
 	// Use the fast path if mipmap is not used.
-	if canSkipMipmap || srcs[0] == nil || !canUseMipmap(srcs[0].imageType) {
+	if canSkipMipmap || srcs[0] == nil || !canUseMipmap(srcs[0].imageType) || srcs[0].maxLevel == 0 {
 		var imgs [graphics.ShaderSrcImageCount]*buffered.Image
 		for i, src := range srcs {
 			if src == nil {
@@ -98,22 +170,23 @@ func (m *Mipmap) DrawTriangles(srcs [graphics.ShaderSrcImageCount]*Mipmap, verti
 	}
 
 	level := math.MaxInt32
+	n := uint32(shader.VertexFloatCount())
 	for i := 0; i < len(indices); i += 3 {
 		idx0 := indices[i]
 		idx1 := indices[i+1]
 		idx2 := indices[i+2]
-		dx0 := vertices[graphics.VertexFloatCount*idx0]
-		dy0 := vertices[graphics.VertexFloatCount*idx0+1]
-		sx0 := vertices[graphics.VertexFloatCount*idx0+2]
-		sy0 := vertices[graphics.VertexFloatCount*idx0+3]
-		dx1 := vertices[graphics.VertexFloatCount*idx1]
-		dy1 := vertices[graphics.VertexFloatCount*idx1+1]
-		sx1 := vertices[graphics.VertexFloatCount*idx1+2]
-		sy1 := vertices[graphics.VertexFloatCount*idx1+3]
-		dx2 := vertices[graphics.VertexFloatCount*idx2]
-		dy2 := vertices[graphics.VertexFloatCount*idx2+1]
-		sx2 := vertices[graphics.VertexFloatCount*idx2+2]
-		sy2 := vertices[graphics.VertexFloatCount*idx2+3]
+		dx0 := vertices[n*idx0]
+		dy0 := vertices[n*idx0+1]
+		sx0 := vertices[n*idx0+2]
+		sy0 := vertices[n*idx0+3]
+		dx1 := vertices[n*idx1]
+		dy1 := vertices[n*idx1+1]
+		sx1 := vertices[n*idx1+2]
+		sy1 := vertices[n*idx1+3]
+		dx2 := vertices[n*idx2]
+		dy2 := vertices[n*idx2+1]
+		sx2 := vertices[n*idx2+2]
+		sy2 := vertices[n*idx2+3]
 		if l := mipmapLevelFromDistance(dx0, dy0, dx1, dy1, sx0, sy0, sx1, sy1); level > l {
 			level = l
 		}
@@ -127,6 +200,7 @@ func (m *Mipmap) DrawTriangles(srcs [graphics.ShaderSrcImageCount]*Mipmap, verti
 	if level == math.MaxInt32 {
 		panic("mipmap: level must be calculated at least once but not")
 	}
+	level = min(level, srcs[0].maxLevel)
 
 	var imgs [graphics.ShaderSrcImageCount]*buffered.Image
 	for i, src := range srcs {
@@ -136,7 +210,7 @@ func (m *Mipmap) DrawTriangles(srcs [graphics.ShaderSrcImageCount]*Mipmap, verti
 		if level != 0 {
 			if img := src.level(level); img != nil {
 				s := float32(pow2(level))
-				for i := 0; i < len(vertices); i += graphics.VertexFloatCount {
+				for i := 0; i < len(vertices); i += shader.VertexFloatCount() {
 					vertices[i+2] /= s
 					vertices[i+3] /= s
 				}
@@ -147,10 +221,64 @@ func (m *Mipmap) DrawTriangles(srcs [graphics.ShaderSrcImageCount]*Mipmap, verti
 		imgs[i] = src.orig
 	}
 
+	if trilinear {
+		// The first source image is the current level, and the second source image is the next level.
+		// The regions are the whole images so that the shader can convert the positions between the levels.
+		src := srcs[0]
+		l := level
+		if imgs[0] == src.orig {
+			l = 0
+		}
+		srcRegions[0] = image.Rect(0, 0, sizeForLevel(src.width, l), sizeForLevel(src.height, l))
+		imgs[1] = nil
+		srcRegions[1] = image.Rectangle{}
+		if l+1 <= src.maxLevel {
+			if img := src.level(l + 1); img != nil {
+				imgs[1] = img
+				srcRegions[1] = image.Rect(0, 0, sizeForLevel(src.width, l+1), sizeForLevel(src.height, l+1))
+			}
+		}
+	}
+
 	m.orig.DrawTriangles(imgs, vertices, indices, blend, dstRegion, srcRegions, shader, uniforms, fillRule, hint)
 	m.markDirty()
 }
 
+// DrawTrianglesMRT draws the triangles with the given sources to multiple render targets.
+// See graphicscommand.Image.DrawTrianglesMRT for the details of extraDsts.
+//
+// Mipmaps of the sources are not used.
+func (m *Mipmap) DrawTrianglesMRT(extraDsts [graphics.ShaderDstImageCount - 1]*Mipmap, srcs [graphics.ShaderSrcImageCount]*Mipmap, vertices []float32, indices []uint32, blend graphicsdriver.Blend, dstRegion image.Rectangle, srcRegions [graphics.ShaderSrcImageCount]image.Rectangle, shader *atlas.Shader, uniforms []uint32, fillRule graphicsdriver.FillRule) {
+	if len(indices) == 0 {
+		return
+	}
+
+	var imgs [graphics.ShaderSrcImageCount]*buffered.Image
+	for i, src := range srcs {
+		if src == nil {
+			continue
+		}
+		imgs[i] = src.orig
+	}
+	var extraDstImgs [graphics.ShaderDstImageCount - 1]*buffered.Image
+	for i, extraDst := range extraDsts {
+		if extraDst == nil {
+			continue
+		}
+		extraDstImgs[i] = extraDst.orig
+	}
+
+	m.orig.DrawTrianglesMRT(extraDstImgs, imgs, vertices, indices, blend, dstRegion, srcRegions, shader, uniforms, fillRule)
+
+	m.markDirty()
+	for _, extraDst := range extraDsts {
+		if extraDst == nil {
+			continue
+		}
+		extraDst.markDirty()
+	}
+}
+
 func (m *Mipmap) setImg(level int, img *buffered.Image) {
 	if m.imgs == nil {
 		m.imgs = map[int]imageWithDirtyFlag{}
@@ -219,6 +347,8 @@ func (m *Mipmap) level(level int) *buffered.Image {
 		s = img.img
 	} else {
 		s = buffered.NewImage(dstW, dstH, m.imageType)
+		s.SetGutter(m.gutter, m.gutterMode)
+		s.SetAtlasGroup(m.atlasGroup)
 	}
 
 	dstRegion := image.Rect(0, 0, dstW, dstH)
@@ -253,9 +383,9 @@ func (m *Mipmap) Deallocate() {
 }
 
 // mipmapLevel returns an appropriate mipmap level for the given distance.
+//
+// The returned level is not limited by the maximum level.
 func mipmapLevelFromDistance(dx0, dy0, dx1, dy1, sx0, sy0, sx1, sy1 float32) int {
-	const maxLevel = 6
-
 	d := (dx1-dx0)*(dx1-dx0) + (dy1-dy0)*(dy1-dy0)
 	s := (sx1-sx0)*(sx1-sx0) + (sy1-sy0)*(sy1-sy0)
 	if s == 0 {
@@ -297,10 +427,6 @@ func mipmapLevelFromDistance(dx0, dy0, dx1, dy1, sx0, sy0, sx1, sy1 float32) int
 		}
 	}
 
-	if level > maxLevel {
-		level = maxLevel
-	}
-
 	return level
 }
 
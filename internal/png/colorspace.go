@@ -0,0 +1,123 @@
+// Copyright 2026 The Ebitengine Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package png
+
+import (
+	"encoding/binary"
+	"hash/crc32"
+	"image"
+	"io"
+)
+
+// ColorSpace represents a color space to tag a PNG file with.
+type ColorSpace int
+
+const (
+	// ColorSpaceNone adds no color space metadata.
+	ColorSpaceNone ColorSpace = iota
+	ColorSpaceSRGB
+	ColorSpaceDisplayP3
+)
+
+// EncodeWithColorSpace writes the Image m to w in PNG format with the metadata of the color space.
+//
+// The metadata are the cICP, cHRM and gAMA chunks, and the sRGB chunk for sRGB.
+// The cICP chunk is defined in the third edition of the PNG specification, and
+// the other chunks are for older decoders.
+func EncodeWithColorSpace(w io.Writer, m image.Image, colorSpace ColorSpace) error {
+	if colorSpace == ColorSpaceNone {
+		return Encode(w, m)
+	}
+	return Encode(&chunkInserter{
+		w:      w,
+		chunks: colorSpaceChunks(colorSpace),
+	}, m)
+}
+
+func colorSpaceChunks(colorSpace ColorSpace) []byte {
+	// The chromaticities are multiplied by 100000.
+	var primaries byte
+	var chrm [8]uint32
+	switch colorSpace {
+	case ColorSpaceSRGB:
+		primaries = 1 // ITU-R BT.709
+		chrm = [8]uint32{31270, 32900, 64000, 33000, 30000, 60000, 15000, 6000}
+	case ColorSpaceDisplayP3:
+		primaries = 12 // SMPTE EG 432-1
+		chrm = [8]uint32{31270, 32900, 68000, 32000, 26500, 69000, 15000, 6000}
+	default:
+		return nil
+	}
+
+	var buf []byte
+
+	// Both color spaces use the sRGB transfer function (13), no matrix (0), and the full range (1).
+	buf = appendChunk(buf, "cICP", []byte{primaries, 13, 0, 1})
+
+	var chrmData [32]byte
+	for i, v := range chrm {
+		binary.BigEndian.PutUint32(chrmData[4*i:], v)
+	}
+	buf = appendChunk(buf, "cHRM", chrmData[:])
+
+	// The gamma 1/2.2 multiplied by 100000, as the spec recommends for the sRGB transfer function.
+	buf = appendChunk(buf, "gAMA", binary.BigEndian.AppendUint32(nil, 45455))
+
+	if colorSpace == ColorSpaceSRGB {
+		// The rendering intent is perceptual.
+		buf = appendChunk(buf, "sRGB", []byte{0})
+	}
+	return buf
+}
+
+func appendChunk(buf []byte, name string, data []byte) []byte {
+	buf = binary.BigEndian.AppendUint32(buf, uint32(len(data)))
+	start := len(buf)
+	buf = append(buf, name...)
+	buf = append(buf, data...)
+	return binary.BigEndian.AppendUint32(buf, crc32.ChecksumIEEE(buf[start:]))
+}
+
+// chunkInserter is an io.Writer to insert chunks just after the IHDR chunk.
+type chunkInserter struct {
+	w       io.Writer
+	chunks  []byte
+	written int
+}
+
+// ihdrEnd is the end position of the IHDR chunk: the PNG signature (8), and the IHDR chunk's length (4), type (4), data (13) and CRC (4).
+const ihdrEnd = 8 + 4 + 4 + 13 + 4
+
+func (c *chunkInserter) Write(p []byte) (int, error) {
+	if c.chunks == nil || c.written+len(p) < ihdrEnd {
+		n, err := c.w.Write(p)
+		c.written += n
+		return n, err
+	}
+
+	head := ihdrEnd - c.written
+	n, err := c.w.Write(p[:head])
+	c.written += n
+	if err != nil {
+		return n, err
+	}
+	if _, err := c.w.Write(c.chunks); err != nil {
+		return n, err
+	}
+	c.chunks = nil
+	m, err := c.w.Write(p[head:])
+	c.written += m
+	return n + m, err
+}
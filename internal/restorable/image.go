@@ -107,6 +107,33 @@ const (
 	// reading pixels from GPU are expensive operations. Volatile images can skip such operations, but the image content
 	// is cleared every frame instead.
 	ImageTypeVolatile
+
+	// ImageTypeUnrestorable indicates the image is never restored.
+	//
+	// Unlike a volatile image, an unrestorable image is not cleared every frame.
+	// An unrestorable image skips recording the drawing history and reading pixels from GPU, and its content is
+	// cleared instead when the context is lost.
+	ImageTypeUnrestorable
+
+	// ImageTypeStreaming indicates the image is never restored and its pixels are rewritten frequently, e.g., every frame.
+	//
+	// A streaming image works like an unrestorable image, but the underlying image might be optimized for frequent WritePixels.
+	ImageTypeStreaming
+
+	// ImageTypeDepth indicates the image has a depth buffer and is never restored.
+	//
+	// A depth image works like an unrestorable image. The depth buffer is also cleared when the context is lost.
+	ImageTypeDepth
+
+	// ImageTypeFloat16 indicates the image has 16-bit floating-point values for each channel and is never restored.
+	//
+	// A float image works like an unrestorable image.
+	ImageTypeFloat16
+
+	// ImageTypeFloat32 indicates the image has 32-bit floating-point values for each channel and is never restored.
+	//
+	// A float image works like an unrestorable image.
+	ImageTypeFloat32
 )
 
 // Hint is a hint to optimize the info to restore the image.
@@ -169,7 +196,7 @@ func NewImage(width, height int, imageType ImageType) *Image {
 	}
 
 	i := &Image{
-		image:     graphicscommand.NewImage(width, height, imageType == ImageTypeScreen, ""),
+		image:     newGraphicsCommandImage(width, height, imageType),
 		width:     width,
 		height:    height,
 		imageType: imageType,
@@ -183,6 +210,26 @@ func NewImage(width, height int, imageType ImageType) *Image {
 	return i
 }
 
+func newGraphicsCommandImage(width, height int, imageType ImageType) *graphicscommand.Image {
+	switch imageType {
+	case ImageTypeScreen:
+		return graphicscommand.NewImage(width, height, true, "")
+	case ImageTypeVolatile:
+		return graphicscommand.NewImage(width, height, false, "volatile")
+	case ImageTypeUnrestorable:
+		return graphicscommand.NewImage(width, height, false, "unrestorable")
+	case ImageTypeStreaming:
+		return graphicscommand.NewStreamingImage(width, height, "streaming")
+	case ImageTypeDepth:
+		return graphicscommand.NewDepthImage(width, height, "depth")
+	case ImageTypeFloat16:
+		return graphicscommand.NewFloatImage(width, height, graphicsdriver.PixelFormatRGBA16F, "float16")
+	case ImageTypeFloat32:
+		return graphicscommand.NewFloatImage(width, height, graphicsdriver.PixelFormatRGBA32F, "float32")
+	}
+	return graphicscommand.NewImage(width, height, false, "")
+}
+
 // Extend extends the image by the given size.
 // Extend creates a new image with the given size and copies the pixels of the given source image.
 // Extend disposes itself after its call.
@@ -363,7 +410,7 @@ func (i *Image) DrawTriangles(srcs [graphics.ShaderSrcImageCount]*Image, vertice
 			continue
 		}
 		srcImages[i] = src.image
-		if src.stale || src.imageType == ImageTypeVolatile {
+		if src.stale || src.imageType == ImageTypeVolatile || src.imageType == ImageTypeUnrestorable || src.imageType == ImageTypeStreaming || src.imageType == ImageTypeDepth || src.imageType == ImageTypeFloat16 || src.imageType == ImageTypeFloat32 {
 			srcstale = true
 		}
 	}
@@ -394,6 +441,42 @@ func (i *Image) DrawTriangles(srcs [graphics.ShaderSrcImageCount]*Image, vertice
 	i.image.DrawTriangles(srcImages, vertices, indices, blend, dstRegion, srcRegions, shader.shader, uniforms, fillRule)
 }
 
+// DrawTrianglesMRT draws triangles with the given image to multiple render targets.
+// See graphicscommand.Image.DrawTrianglesMRT for the details of extraDsts.
+//
+// The drawing history cannot restore multiple render targets, then all the destination images become stale.
+func (i *Image) DrawTrianglesMRT(extraDsts [graphics.ShaderDstImageCount - 1]*Image, srcs [graphics.ShaderSrcImageCount]*Image, vertices []float32, indices []uint32, blend graphicsdriver.Blend, dstRegion image.Rectangle, srcRegions [graphics.ShaderSrcImageCount]image.Rectangle, shader *Shader, uniforms []uint32, fillRule graphicsdriver.FillRule) {
+	if len(vertices) == 0 {
+		return
+	}
+
+	var srcImages [graphics.ShaderSrcImageCount]*graphicscommand.Image
+	for i, src := range srcs {
+		if src == nil {
+			continue
+		}
+		srcImages[i] = src.image
+	}
+
+	var extraDstImages [graphics.ShaderDstImageCount - 1]*graphicscommand.Image
+	for k, extraDst := range extraDsts {
+		if extraDst == nil {
+			continue
+		}
+		if needsRestoration() && extraDst.needsRestoration() {
+			theImages.makeStaleIfDependingOn(extraDst)
+		}
+		extraDst.makeStale(dstRegion)
+		extraDstImages[k] = extraDst.image
+	}
+	if needsRestoration() && i.needsRestoration() {
+		theImages.makeStaleIfDependingOn(i)
+	}
+	i.makeStale(dstRegion)
+
+	i.image.DrawTrianglesMRT(extraDstImages, srcImages, vertices, indices, blend, dstRegion, srcRegions, shader.shader, uniforms, fillRule)
+}
+
 func (i *Image) areStaleRegionsIncludedIn(r image.Rectangle) bool {
 	if !i.stale {
 		return false
@@ -471,6 +554,38 @@ func (i *Image) appendDrawTrianglesHistory(srcs [graphics.ShaderSrcImageCount]*I
 	i.drawTrianglesHistory = append(i.drawTrianglesHistory, item)
 }
 
+// WritePixelsFloat32 replaces the image pixels with the given float32 values.
+//
+// WritePixelsFloat32 is available only for an image that doesn't need restoration.
+func (i *Image) WritePixelsFloat32(pixels []float32, region image.Rectangle) {
+	if region.Dx() <= 0 || region.Dy() <= 0 {
+		panic("restorable: width/height must be positive")
+	}
+	if !region.In(image.Rect(0, 0, i.width, i.height)) {
+		panic(fmt.Sprintf("restorable: out of range %v", region))
+	}
+	if i.needsRestoration() {
+		panic("restorable: WritePixelsFloat32 is not available for an image that needs restoration")
+	}
+
+	theImages.makeStaleIfDependingOnAtRegion(i, region)
+	i.image.WritePixelsFloat32(pixels, region)
+	i.makeStale(region)
+}
+
+// ReadPixelsFloat32 reads the image pixels as float32 values from GPU.
+func (i *Image) ReadPixelsFloat32(graphicsDriver graphicsdriver.Graphics, pixels []float32, region image.Rectangle) error {
+	if got, want := len(pixels), 4*region.Dx()*region.Dy(); got != want {
+		return fmt.Errorf("restorable: len(pixels) must be %d but %d at ReadPixelsFloat32", want, got)
+	}
+	return i.image.ReadPixelsFloat32(graphicsDriver, []graphicsdriver.PixelsFloat32Args{
+		{
+			Pixels: pixels,
+			Region: region,
+		},
+	})
+}
+
 func (i *Image) readPixelsFromGPUIfNeeded(graphicsDriver graphicsdriver.Graphics) error {
 	if len(i.drawTrianglesHistory) > 0 || i.stale {
 		if err := i.readPixelsFromGPU(graphicsDriver); err != nil {
@@ -682,9 +797,17 @@ func (i *Image) restore(graphicsDriver graphicsdriver.Graphics) error {
 		i.staleRegions = i.staleRegions[:0]
 		return nil
 	case ImageTypeVolatile:
-		i.image = graphicscommand.NewImage(w, h, false, "volatile")
+		i.image = newGraphicsCommandImage(w, h, i.imageType)
+		iw, ih := i.image.InternalSize()
+		clearImage(i.image, image.Rect(0, 0, iw, ih))
+		return nil
+	case ImageTypeUnrestorable, ImageTypeStreaming, ImageTypeDepth, ImageTypeFloat16, ImageTypeFloat32:
+		i.image = newGraphicsCommandImage(w, h, i.imageType)
 		iw, ih := i.image.InternalSize()
 		clearImage(i.image, image.Rect(0, 0, iw, ih))
+		i.clearDrawTrianglesHistory()
+		i.stale = false
+		i.staleRegions = i.staleRegions[:0]
 		return nil
 	}
 
@@ -36,18 +36,54 @@ var disabled atomic.Bool
 
 var disabledOnce sync.Once
 
+// enabled indicates that restoration is explicitly enabled or not.
+var enabled atomic.Bool
+
+// backupCompression indicates whether the pixels kept on CPU for restoration are compressed or not.
+var backupCompression atomic.Bool
+
+// restoredCount is the number of times the images have been restored.
+var restoredCount atomic.Int64
+
 // Disable disables restoration.
 func Disable() {
 	disabled.Store(true)
 }
 
+// Enable enables restoration on any platforms.
+// Enable must be called before any image is created.
+func Enable() {
+	enabled.Store(true)
+}
+
+// SetBackupCompression sets whether the pixels kept on CPU for restoration are compressed or not.
+//
+// Compression reduces the memory usage, especially for large images with simple contents,
+// at the cost of CPU time to compress pixels when they are recorded and to decompress them when they are read or restored.
+// SetBackupCompression affects only the pixels recorded after it is called.
+func SetBackupCompression(compress bool) {
+	backupCompression.Store(compress)
+}
+
+// IsRestorationEnabled reports whether restoration is enabled or not.
+func IsRestorationEnabled() bool {
+	return needsRestoration()
+}
+
+// RestoredCount returns the number of times the images have been restored.
+//
+// RestoredCount is concurrent-safe.
+func RestoredCount() int64 {
+	return restoredCount.Load()
+}
+
 // needsRestoration reports whether restoration process works or not.
 func needsRestoration() bool {
 	if forceRestoration {
 		return true
 	}
 	// TODO: If Vulkan is introduced, restoration might not be needed.
-	if runtime.GOOS == "android" {
+	if runtime.GOOS == "android" || enabled.Load() {
 		return !disabled.Load()
 	}
 	return false
@@ -273,6 +309,7 @@ func (i *images) restore(graphicsDriver graphicsdriver.Graphics) error {
 	}
 
 	i.contextLost.Store(false)
+	restoredCount.Add(1)
 
 	return nil
 }
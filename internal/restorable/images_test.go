@@ -15,6 +15,7 @@
 package restorable_test
 
 import (
+	"bytes"
 	"image"
 	"image/color"
 	"testing"
@@ -1187,3 +1188,104 @@ func TestOverwriteDstRegion(t *testing.T) {
 		t.Errorf("got: %v, want: %v", got, want)
 	}
 }
+
+func TestWritePixelsWithBackupCompression(t *testing.T) {
+	restorable.SetBackupCompression(true)
+	defer restorable.SetBackupCompression(false)
+
+	const w, h = 16, 16
+	img := restorable.NewImage(w, h, restorable.ImageTypeRegular)
+	defer img.Dispose()
+
+	pix := make([]byte, 4*w*h)
+	for i := range pix {
+		pix[i] = byte(i)
+	}
+	img.WritePixels(bytesToManagedBytes(pix), image.Rect(0, 0, w, h))
+	if err := restorable.ResolveStaleImages(ui.Get().GraphicsDriverForTesting()); err != nil {
+		t.Fatal(err)
+	}
+	if err := restorable.RestoreIfNeeded(ui.Get().GraphicsDriverForTesting()); err != nil {
+		t.Fatal(err)
+	}
+
+	for j := 0; j < h; j++ {
+		for i := 0; i < w; i++ {
+			idx := 4 * (j*w + i)
+			want := color.RGBA{R: pix[idx], G: pix[idx+1], B: pix[idx+2], A: pix[idx+3]}
+			got := pixelsToColor(img.BasePixelsForTesting(), i, j, w, h)
+			if got != want {
+				t.Errorf("(%d, %d): got: %v, want: %v", i, j, got, want)
+			}
+		}
+	}
+
+	got := make([]byte, 4*4*4)
+	if err := img.ReadPixels(ui.Get().GraphicsDriverForTesting(), got, image.Rect(3, 5, 7, 9)); err != nil {
+		t.Fatal(err)
+	}
+	for j := 5; j < 9; j++ {
+		for i := 3; i < 7; i++ {
+			for k := 0; k < 4; k++ {
+				if got, want := got[4*((j-5)*4+i-3)+k], pix[4*(j*w+i)+k]; got != want {
+					t.Errorf("(%d, %d)[%d]: got: %d, want: %d", i, j, k, got, want)
+				}
+			}
+		}
+	}
+}
+
+func TestWritePixelsPartWithBackupCompression(t *testing.T) {
+	restorable.SetBackupCompression(true)
+	defer restorable.SetBackupCompression(false)
+
+	const w, h = 16, 16
+	img := restorable.NewImage(w, h, restorable.ImageTypeRegular)
+	defer img.Dispose()
+
+	pix := make([]byte, 4*w*h)
+	for i := range pix {
+		pix[i] = byte(i)
+	}
+	img.WritePixels(bytesToManagedBytes(pix), image.Rect(0, 0, w, h))
+
+	// Read the pixels twice so that the second read uses the cached decompressed pixels.
+	for n := 0; n < 2; n++ {
+		got := make([]byte, 4)
+		if err := img.ReadPixels(ui.Get().GraphicsDriverForTesting(), got, image.Rect(2, 3, 3, 4)); err != nil {
+			t.Fatal(err)
+		}
+		if want := pix[4*(3*w+2) : 4*(3*w+2)+4]; !bytes.Equal(got, want) {
+			t.Errorf("got: %v, want: %v", got, want)
+		}
+	}
+
+	// Write a part of the image. The cached pixels must not be used for the written region.
+	part := make([]byte, 4*2*2)
+	for i := range part {
+		part[i] = 0xff
+	}
+	img.WritePixels(bytesToManagedBytes(part), image.Rect(2, 3, 4, 5))
+	for k := 0; k < 4*2*2; k++ {
+		pix[4*((3+k/8)*w+2)+k%8] = 0xff
+	}
+	if err := restorable.ResolveStaleImages(ui.Get().GraphicsDriverForTesting()); err != nil {
+		t.Fatal(err)
+	}
+	if err := restorable.RestoreIfNeeded(ui.Get().GraphicsDriverForTesting()); err != nil {
+		t.Fatal(err)
+	}
+
+	got := make([]byte, 4*w*h)
+	if err := img.ReadPixels(ui.Get().GraphicsDriverForTesting(), got, image.Rect(0, 0, w, h)); err != nil {
+		t.Fatal(err)
+	}
+	for j := 0; j < h; j++ {
+		for i := 0; i < w; i++ {
+			idx := 4 * (j*w + i)
+			if !bytes.Equal(got[idx:idx+4], pix[idx:idx+4]) {
+				t.Errorf("(%d, %d): got: %v, want: %v", i, j, got[idx:idx+4], pix[idx:idx+4])
+			}
+		}
+	}
+}
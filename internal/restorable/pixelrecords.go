@@ -15,8 +15,12 @@
 package restorable
 
 import (
+	"bytes"
+	"compress/flate"
 	"fmt"
 	"image"
+	"io"
+	"sync"
 
 	"github.com/duplicants-ai/ebiten/internal/graphics"
 	"github.com/duplicants-ai/ebiten/internal/graphicscommand"
@@ -25,6 +29,76 @@ import (
 type pixelsRecord struct {
 	rect image.Rectangle
 	pix  *graphics.ManagedBytes
+
+	// compressed is the compressed pixels.
+	// compressed is used instead of pix when the backup compression is enabled.
+	compressed []byte
+
+	// decompressed is a cache of the decompressed pixels of compressed.
+	// decompressed is kept until the next write to the records, so that successive reads don't decompress the pixels every time.
+	decompressed []byte
+}
+
+var flateWriterPool = sync.Pool{
+	New: func() any {
+		w, err := flate.NewWriter(nil, flate.BestSpeed)
+		if err != nil {
+			panic(fmt.Sprintf("restorable: flate.NewWriter failed: %v", err))
+		}
+		return w
+	},
+}
+
+func compressPixels(pixels *graphics.ManagedBytes) []byte {
+	bs, f := pixels.GetAndRelease()
+	defer f()
+
+	var buf bytes.Buffer
+	w := flateWriterPool.Get().(*flate.Writer)
+	defer flateWriterPool.Put(w)
+	w.Reset(&buf)
+	if _, err := w.Write(bs); err != nil {
+		panic(fmt.Sprintf("restorable: compressing pixels failed: %v", err))
+	}
+	if err := w.Close(); err != nil {
+		panic(fmt.Sprintf("restorable: compressing pixels failed: %v", err))
+	}
+	return bytes.Clone(buf.Bytes())
+}
+
+// decompressedBytes returns the decompressed pixels of compressed, and caches them until the next write.
+func (p *pixelsRecord) decompressedBytes() []byte {
+	if p.decompressed != nil {
+		return p.decompressed
+	}
+	bs := make([]byte, 4*p.rect.Dx()*p.rect.Dy())
+	r := flate.NewReader(bytes.NewReader(p.compressed))
+	defer r.Close()
+	if _, err := io.ReadFull(r, bs); err != nil {
+		panic(fmt.Sprintf("restorable: decompressing pixels failed: %v", err))
+	}
+	p.decompressed = bs
+	return bs
+}
+
+// managedBytes returns the pixels as a ManagedBytes, and reports whether the returned value is newly created.
+// A newly created ManagedBytes should be released by the caller.
+func (p *pixelsRecord) managedBytes() (*graphics.ManagedBytes, bool) {
+	if p.compressed == nil {
+		return p.pix, false
+	}
+	if p.decompressed != nil {
+		return graphics.NewManagedBytes(len(p.decompressed), func(bs []byte) {
+			copy(bs, p.decompressed)
+		}), true
+	}
+	return graphics.NewManagedBytes(4*p.rect.Dx()*p.rect.Dy(), func(bs []byte) {
+		r := flate.NewReader(bytes.NewReader(p.compressed))
+		defer r.Close()
+		if _, err := io.ReadFull(r, bs); err != nil {
+			panic(fmt.Sprintf("restorable: decompressing pixels failed: %v", err))
+		}
+	}), true
 }
 
 func (p *pixelsRecord) readPixels(pixels []byte, region image.Rectangle, imageWidth, imageHeight int) {
@@ -36,7 +110,16 @@ func (p *pixelsRecord) readPixels(pixels []byte, region image.Rectangle, imageWi
 	dstBaseX := r.Min.X - region.Min.X
 	dstBaseY := r.Min.Y - region.Min.Y
 	lineWidth := 4 * r.Dx()
-	if p.pix != nil {
+	if p.compressed != nil {
+		pix := p.decompressedBytes()
+		srcBaseX := r.Min.X - p.rect.Min.X
+		srcBaseY := r.Min.Y - p.rect.Min.Y
+		for j := 0; j < r.Dy(); j++ {
+			dstX := 4 * ((dstBaseY+j)*region.Dx() + dstBaseX)
+			srcX := 4 * ((srcBaseY+j)*p.rect.Dx() + srcBaseX)
+			copy(pixels[dstX:dstX+lineWidth], pix[srcX:srcX+lineWidth])
+		}
+	} else if p.pix != nil {
 		srcBaseX := r.Min.X - p.rect.Min.X
 		srcBaseY := r.Min.Y - p.rect.Min.Y
 		for j := 0; j < r.Dy(); j++ {
@@ -67,6 +150,8 @@ func (pr *pixelsRecords) addOrReplace(pixels *graphics.ManagedBytes, region imag
 		panic(msg)
 	}
 
+	pr.dropDecompressedCaches()
+
 	// Remove or update the duplicated records first.
 	var n int
 	for _, r := range pr.records {
@@ -82,6 +167,13 @@ func (pr *pixelsRecords) addOrReplace(pixels *graphics.ManagedBytes, region imag
 	pr.records = pr.records[:n]
 
 	// Add the new record.
+	if backupCompression.Load() {
+		pr.records = append(pr.records, &pixelsRecord{
+			rect:       region,
+			compressed: compressPixels(pixels),
+		})
+		return
+	}
 	pr.records = append(pr.records, &pixelsRecord{
 		rect: region,
 		pix:  pixels,
@@ -93,6 +185,8 @@ func (pr *pixelsRecords) clear(region image.Rectangle) {
 		return
 	}
 
+	pr.dropDecompressedCaches()
+
 	var n int
 	var needsClear bool
 	for _, r := range pr.records {
@@ -116,6 +210,13 @@ func (pr *pixelsRecords) clear(region image.Rectangle) {
 	}
 }
 
+// dropDecompressedCaches drops the cached decompressed pixels so that they don't stay on memory after a write.
+func (pr *pixelsRecords) dropDecompressedCaches() {
+	for _, r := range pr.records {
+		r.decompressed = nil
+	}
+}
+
 func (pr *pixelsRecords) readPixels(pixels []byte, region image.Rectangle, imageWidth, imageHeight int) {
 	for i := range pixels {
 		pixels[i] = 0
@@ -128,7 +229,11 @@ func (pr *pixelsRecords) readPixels(pixels []byte, region image.Rectangle, image
 func (pr *pixelsRecords) apply(img *graphicscommand.Image) {
 	// TODO: Isn't this too heavy? Can we merge the operations?
 	for _, r := range pr.records {
-		if r.pix != nil {
+		if r.compressed != nil {
+			// A decompressed ManagedBytes is newly created, and its lifetime is managed by the package graphicscommand.
+			pix, _ := r.managedBytes()
+			img.WritePixels(pix, r.rect)
+		} else if r.pix != nil {
 			// Clone a ManagedBytes as the package graphicscommand has a different lifetime management.
 			img.WritePixels(r.pix.Clone(), r.rect)
 		} else {
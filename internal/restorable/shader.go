@@ -56,6 +56,11 @@ func (s *Shader) Unit() shaderir.Unit {
 	return s.ir.Unit
 }
 
+// VertexFloatCount returns the number of floats in a vertex for the shader.
+func (s *Shader) VertexFloatCount() int {
+	return graphics.ShaderVertexFloatCount(s.ir)
+}
+
 var (
 	NearestFilterShader *Shader
 	LinearFilterShader  *Shader
@@ -39,10 +39,10 @@ func clearImage(img *restorable.Image, w, h int) {
 	sx1 := float32(2)
 	sy1 := float32(2)
 	vs := []float32{
-		dx0, dy0, sx0, sy0, 0, 0, 0, 0,
-		dx1, dy0, sx1, sy0, 0, 0, 0, 0,
-		dx0, dy1, sx0, sy1, 0, 0, 0, 0,
-		dx1, dy1, sx1, sy1, 0, 0, 0, 0,
+		dx0, dy0, sx0, sy0, 0, 0, 0, 0, 0, 0, 0, 0,
+		dx1, dy0, sx1, sy0, 0, 0, 0, 0, 0, 0, 0, 0,
+		dx0, dy1, sx0, sy1, 0, 0, 0, 0, 0, 0, 0, 0,
+		dx1, dy1, sx1, sy1, 0, 0, 0, 0, 0, 0, 0, 0,
 	}
 	is := graphics.QuadIndices()
 	dr := image.Rect(0, 0, w, h)
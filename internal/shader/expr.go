@@ -421,6 +421,20 @@ func (cs *compileState) parseExpr(block *block, fname string, expr ast.Expr, mar
 					return nil, nil, nil, false
 				}
 				finalType = shaderir.Type{Main: shaderir.Vec4}
+			case shaderir.TexelFetch:
+				if len(args) != 2 {
+					cs.addError(e.Pos(), fmt.Sprintf("number of %s's arguments must be 2 but %d", callee.BuiltinFunc, len(args)))
+					return nil, nil, nil, false
+				}
+				if argts[0].Main != shaderir.Texture {
+					cs.addError(e.Pos(), fmt.Sprintf("cannot use %s as texture value in argument to %s", argts[0].String(), callee.BuiltinFunc))
+					return nil, nil, nil, false
+				}
+				if argts[1].Main != shaderir.IVec2 {
+					cs.addError(e.Pos(), fmt.Sprintf("cannot use %s as ivec2 value in argument to %s", argts[1].String(), callee.BuiltinFunc))
+					return nil, nil, nil, false
+				}
+				finalType = shaderir.Type{Main: shaderir.Vec4}
 			case shaderir.DiscardF:
 				if len(args) != 0 {
 					cs.addError(e.Pos(), fmt.Sprintf("number of %s's arguments must be 0 but %d", callee.BuiltinFunc, len(args)))
@@ -896,6 +910,14 @@ func (cs *compileState) parseExpr(block *block, fname string, expr ast.Expr, mar
 				},
 			}, []shaderir.Type{cs.ir.Uniforms[i]}, nil, true
 		}
+		if i, ok := cs.findConstArray(e.Name); ok {
+			return []shaderir.Expr{
+				{
+					Type:  shaderir.ConstArrayVariable,
+					Index: i,
+				},
+			}, []shaderir.Type{cs.ir.ConstArrays[i].Type}, nil, true
+		}
 		if f, ok := shaderir.ParseBuiltinFunc(e.Name); ok {
 			return []shaderir.Expr{
 				{
@@ -23,6 +23,7 @@ import (
 	"go/parser"
 	"go/token"
 	"regexp"
+	"strconv"
 	"strings"
 
 	"github.com/duplicants-ai/ebiten/internal/shaderir"
@@ -59,9 +60,20 @@ type compileState struct {
 
 	global block
 
+	maxIterations []maxIterationsDirective
+
 	errs []string
 }
 
+// maxIterationsDirective is a //kage:maxiterations directive, which declares the maximum number of iterations
+// of the for-statement at the next line.
+type maxIterationsDirective struct {
+	pos   token.Pos
+	line  int
+	value int
+	used  bool
+}
+
 func (cs *compileState) findFunction(name string) (int, bool) {
 	for i, f := range cs.funcs {
 		if f.name == name {
@@ -80,6 +92,15 @@ func (cs *compileState) findUniformVariable(name string) (int, bool) {
 	return 0, false
 }
 
+func (cs *compileState) findConstArray(name string) (int, bool) {
+	for i, a := range cs.ir.ConstArrays {
+		if a.Name == name {
+			return i, true
+		}
+	}
+	return 0, false
+}
+
 type typ struct {
 	name string
 	ir   shaderir.Type
@@ -189,7 +210,7 @@ func Compile(src []byte, vertexEntry, fragmentEntry string, textureCount int) (*
 	}
 
 	fs := token.NewFileSet()
-	f, err := parser.ParseFile(fs, "", src, parser.AllErrors)
+	f, err := parser.ParseFile(fs, "", src, parser.AllErrors|parser.ParseComments)
 	if err != nil {
 		return nil, err
 	}
@@ -202,7 +223,14 @@ func Compile(src []byte, vertexEntry, fragmentEntry string, textureCount int) (*
 	}
 	s.ir.SourceHash = shaderir.CalcSourceHash(src)
 	s.global.ir = &shaderir.Block{}
+	s.parseMaxIterationsDirectives(f)
+	s.parseDepthDirectives(f)
 	s.parse(f)
+	for _, d := range s.maxIterations {
+		if !d.used {
+			s.addError(d.pos, "//kage:maxiterations must be followed by a for-statement")
+		}
+	}
 
 	if len(s.errs) > 0 {
 		return nil, &ParseError{s.errs}
@@ -250,6 +278,91 @@ func ParseCompilerDirectives(src []byte) (shaderir.Unit, error) {
 	return unit, nil
 }
 
+func (cs *compileState) parseMaxIterationsDirectives(f *ast.File) {
+	const prefix = "//kage:maxiterations"
+	for _, cg := range f.Comments {
+		for _, c := range cg.List {
+			if !strings.HasPrefix(c.Text, prefix) {
+				continue
+			}
+			arg := c.Text[len(prefix):]
+			if arg != "" && arg[0] != ' ' && arg[0] != '\t' {
+				continue
+			}
+			arg = strings.TrimSpace(arg)
+			n, err := strconv.Atoi(arg)
+			if err != nil || n <= 0 {
+				cs.addError(c.Pos(), fmt.Sprintf("invalid value for //kage:maxiterations: %s", arg))
+				continue
+			}
+			cs.maxIterations = append(cs.maxIterations, maxIterationsDirective{
+				pos:   c.Pos(),
+				line:  cs.fs.Position(c.Pos()).Line,
+				value: n,
+			})
+		}
+	}
+}
+
+// parseDepthDirectives parses a //kage:depth directive, which declares how the shader uses the destination's depth buffer.
+// The arguments are one or both of 'test' and 'write'.
+func (cs *compileState) parseDepthDirectives(f *ast.File) {
+	const prefix = "//kage:depth"
+	var parsed bool
+	for _, cg := range f.Comments {
+		for _, c := range cg.List {
+			if !strings.HasPrefix(c.Text, prefix) {
+				continue
+			}
+			arg := c.Text[len(prefix):]
+			if arg != "" && arg[0] != ' ' && arg[0] != '\t' {
+				continue
+			}
+			if parsed {
+				cs.addError(c.Pos(), "at most one //kage:depth can exist in a shader")
+				continue
+			}
+			parsed = true
+
+			args := strings.Fields(arg)
+			if len(args) == 0 {
+				cs.addError(c.Pos(), "//kage:depth must have 'test', 'write', or both")
+				continue
+			}
+			for _, a := range args {
+				switch a {
+				case "test":
+					if cs.ir.DepthTest {
+						cs.addError(c.Pos(), "duplicated 'test' for //kage:depth")
+					}
+					cs.ir.DepthTest = true
+				case "write":
+					if cs.ir.DepthWrite {
+						cs.addError(c.Pos(), "duplicated 'write' for //kage:depth")
+					}
+					cs.ir.DepthWrite = true
+				default:
+					cs.addError(c.Pos(), fmt.Sprintf("invalid value for //kage:depth: %s", a))
+				}
+			}
+		}
+	}
+}
+
+// takeMaxIterations returns the maximum number of iterations declared for the for-statement at pos.
+// takeMaxIterations returns 0 if not declared.
+func (cs *compileState) takeMaxIterations(pos token.Pos) int {
+	line := cs.fs.Position(pos).Line
+	for i := range cs.maxIterations {
+		d := &cs.maxIterations[i]
+		if d.line == line-1 {
+			d.used = true
+			return d.value
+		}
+	}
+	return 0
+}
+
 func (s *compileState) addError(pos token.Pos, str string) {
 	p := s.fs.Position(pos)
 	s.errs = append(s.errs, fmt.Sprintf("%s: %s", p, str))
@@ -360,10 +473,19 @@ func (cs *compileState) parse(f *ast.File) {
 		if vertexOutParams[0].typ.Main != shaderir.Vec4 {
 			cs.addError(0, "vertex entry point must have at least one returning vec4 value for a position")
 		}
-		if len(fragmentOutParams) != 0 || fragmentReturnType.Main != shaderir.Vec4 {
+		// Multiple returning values are colors for multiple render targets.
+		if len(fragmentOutParams) > 0 {
+			for _, p := range fragmentOutParams {
+				if p.typ.Main != shaderir.Vec4 {
+					cs.addError(0, "fragment entry point must have only returning vec4 values for colors")
+					break
+				}
+			}
+		} else if fragmentReturnType.Main != shaderir.Vec4 {
 			cs.addError(0, "fragment entry point must have one returning vec4 value for a color")
 		}
 	}
+	cs.ir.FragmentOutputCount = len(fragmentOutParams)
 
 	if len(cs.errs) > 0 {
 		return
@@ -439,6 +561,13 @@ func (cs *compileState) parseDecl(b *block, fname string, d ast.Decl) ([]shaderi
 		case token.VAR:
 			for _, s := range d.Specs {
 				s := s.(*ast.ValueSpec)
+				if b == &cs.global && len(s.Values) > 0 {
+					if !cs.parseConstArray(s) {
+						return nil, false
+					}
+					continue
+				}
+
 				vs, inits, ss, ok := cs.parseVariable(b, fname, s)
 				if !ok {
 					return nil, false
@@ -446,11 +575,6 @@ func (cs *compileState) parseDecl(b *block, fname string, d ast.Decl) ([]shaderi
 
 				stmts = append(stmts, ss...)
 				if b == &cs.global {
-					if len(inits) > 0 {
-						cs.addError(s.Pos(), "a uniform variable cannot have initial values")
-						return nil, false
-					}
-
 					// TODO: Should rhs be ignored?
 					for i, v := range vs {
 						if !strings.HasPrefix(v.name, "__") {
@@ -464,6 +588,10 @@ func (cs *compileState) parseDecl(b *block, fname string, d ast.Decl) ([]shaderi
 								return nil, false
 							}
 						}
+						if _, ok := cs.findConstArray(v.name); ok {
+							cs.addError(s.Pos(), fmt.Sprintf("%s redeclared in this block", v.name))
+							return nil, false
+						}
 						cs.ir.UniformNames = append(cs.ir.UniformNames, v.name)
 						cs.ir.Uniforms = append(cs.ir.Uniforms, v.typ)
 					}
@@ -751,6 +879,207 @@ func (s *compileState) parseConstant(block *block, fname string, vs *ast.ValueSp
 	return cs, true
 }
 
+// parseConstArray parses a package-level variable with initial values as a constant array.
+// A constant array is embedded in the shader program instead of being passed as a uniform variable.
+func (cs *compileState) parseConstArray(vs *ast.ValueSpec) bool {
+	if len(vs.Names) != len(vs.Values) {
+		cs.addError(vs.Pos(), fmt.Sprintf("assignment mismatch: %d variables but %d values", len(vs.Names), len(vs.Values)))
+		return false
+	}
+
+	var declType shaderir.Type
+	if vs.Type != nil {
+		t, ok := cs.parseType(&cs.global, "", vs.Type)
+		if !ok {
+			return false
+		}
+		declType = t
+	}
+
+	for i, n := range vs.Names {
+		name := n.Name
+		if _, ok := cs.findUniformVariable(name); ok {
+			cs.addError(n.Pos(), fmt.Sprintf("%s redeclared in this block", name))
+			return false
+		}
+		if _, ok := cs.findConstArray(name); ok {
+			cs.addError(n.Pos(), fmt.Sprintf("%s redeclared in this block", name))
+			return false
+		}
+
+		lit, ok := vs.Values[i].(*ast.CompositeLit)
+		if !ok {
+			cs.addError(vs.Values[i].Pos(), "a package-level variable with an initial value must be an array literal")
+			return false
+		}
+		t, ok := cs.parseType(&cs.global, "", lit.Type)
+		if !ok {
+			return false
+		}
+		if t.Main != shaderir.Array {
+			cs.addError(lit.Pos(), fmt.Sprintf("invalid composite literal type %s", t.String()))
+			return false
+		}
+		if t.Length == -1 {
+			t.Length = len(lit.Elts)
+		} else if t.Length < len(lit.Elts) {
+			cs.addError(lit.Pos(), fmt.Sprintf("too many values in %s literal", t.String()))
+			return false
+		}
+		if vs.Type != nil && !declType.Equal(&t) {
+			cs.addError(lit.Pos(), fmt.Sprintf("cannot use %s as %s value in variable declaration", t.String(), declType.String()))
+			return false
+		}
+
+		elem := t.Sub[0]
+		switch {
+		case elem.Main == shaderir.Bool, elem.Main == shaderir.Int, elem.Main == shaderir.Float, elem.IsFloatVector(), elem.IsIntVector():
+		default:
+			cs.addError(lit.Pos(), fmt.Sprintf("a constant array of %s is not supported", elem.String()))
+			return false
+		}
+
+		exprs := make([]shaderir.Expr, 0, t.Length)
+		for _, e := range lit.Elts {
+			es, ts, ss, ok := cs.parseExpr(&cs.global, "", e, true)
+			if !ok {
+				return false
+			}
+			if len(es) != 1 || len(ss) > 0 {
+				cs.addError(e.Pos(), "an element of a constant array must be a constant")
+				return false
+			}
+			expr, ok := cs.constArrayElement(e, es[0], ts[0], elem)
+			if !ok {
+				return false
+			}
+			exprs = append(exprs, expr)
+		}
+		// The rest of the elements are zero values as Go does.
+		for len(exprs) < t.Length {
+			exprs = append(exprs, constArrayZeroElement(elem))
+		}
+
+		cs.ir.ConstArrays = append(cs.ir.ConstArrays, shaderir.ConstArray{
+			Name:  name,
+			Type:  t,
+			Exprs: exprs,
+		})
+	}
+	return true
+}
+
+func (cs *compileState) constArrayElement(e ast.Expr, expr shaderir.Expr, t shaderir.Type, elem shaderir.Type) (shaderir.Expr, bool) {
+	if t.Main != shaderir.None && !t.Equal(&elem) {
+		cs.addError(e.Pos(), fmt.Sprintf("cannot use %s as %s value in array literal", t.String(), elem.String()))
+		return shaderir.Expr{}, false
+	}
+
+	if elem.Main == shaderir.Bool || elem.Main == shaderir.Int || elem.Main == shaderir.Float {
+		if expr.Type != shaderir.NumberExpr || expr.Const == nil || expr.Const.Kind() == gconstant.Unknown {
+			cs.addError(e.Pos(), "an element of a constant array must be a constant")
+			return shaderir.Expr{}, false
+		}
+		switch elem.Main {
+		case shaderir.Bool:
+			if expr.Const.Kind() != gconstant.Bool {
+				cs.addError(e.Pos(), fmt.Sprintf("cannot use %s as bool value in array literal", expr.Const.String()))
+				return shaderir.Expr{}, false
+			}
+		case shaderir.Int:
+			if !canTruncateToInteger(expr.Const) {
+				cs.addError(e.Pos(), fmt.Sprintf("constant %s truncated to integer", expr.Const.String()))
+				return shaderir.Expr{}, false
+			}
+			expr.Const = gconstant.ToInt(expr.Const)
+		case shaderir.Float:
+			if !canTruncateToFloat(expr.Const) {
+				cs.addError(e.Pos(), fmt.Sprintf("constant %s truncated to float", expr.Const.String()))
+				return shaderir.Expr{}, false
+			}
+			expr.Const = gconstant.ToFloat(expr.Const)
+		}
+		return expr, true
+	}
+
+	// A vector element must be a constructor call with constant arguments.
+	if expr.Type != shaderir.Call || expr.Exprs[0].Type != shaderir.BuiltinFuncExpr || expr.Exprs[0].BuiltinFunc != vectorConstructor(elem) {
+		cs.addError(e.Pos(), "an element of a constant array must be a constant")
+		return shaderir.Expr{}, false
+	}
+	args := make([]shaderir.Expr, len(expr.Exprs))
+	copy(args, expr.Exprs)
+	for i := 1; i < len(args); i++ {
+		arg := args[i]
+		if arg.Type != shaderir.NumberExpr || arg.Const == nil || arg.Const.Kind() == gconstant.Unknown {
+			cs.addError(e.Pos(), "an element of a constant array must be a constant")
+			return shaderir.Expr{}, false
+		}
+		if elem.IsFloatVector() {
+			args[i].Const = gconstant.ToFloat(arg.Const)
+		} else {
+			args[i].Const = gconstant.ToInt(arg.Const)
+		}
+	}
+	expr.Exprs = args
+	return expr, true
+}
+
+func vectorConstructor(t shaderir.Type) shaderir.BuiltinFunc {
+	switch t.Main {
+	case shaderir.Vec2:
+		return shaderir.Vec2F
+	case shaderir.Vec3:
+		return shaderir.Vec3F
+	case shaderir.Vec4:
+		return shaderir.Vec4F
+	case shaderir.IVec2:
+		return shaderir.IVec2F
+	case shaderir.IVec3:
+		return shaderir.IVec3F
+	case shaderir.IVec4:
+		return shaderir.IVec4F
+	}
+	return ""
+}
+
+func constArrayZeroElement(t shaderir.Type) shaderir.Expr {
+	switch t.Main {
+	case shaderir.Bool:
+		return shaderir.Expr{
+			Type:  shaderir.NumberExpr,
+			Const: gconstant.MakeBool(false),
+		}
+	case shaderir.Int:
+		return shaderir.Expr{
+			Type:  shaderir.NumberExpr,
+			Const: gconstant.MakeInt64(0),
+		}
+	case shaderir.Float:
+		return shaderir.Expr{
+			Type:  shaderir.NumberExpr,
+			Const: gconstant.MakeFloat64(0),
+		}
+	}
+	zero := gconstant.MakeInt64(0)
+	if t.IsFloatVector() {
+		zero = gconstant.MakeFloat64(0)
+	}
+	return shaderir.Expr{
+		Type: shaderir.Call,
+		Exprs: []shaderir.Expr{
+			{
+				Type:        shaderir.BuiltinFuncExpr,
+				BuiltinFunc: vectorConstructor(t),
+			},
+			{
+				Type:  shaderir.NumberExpr,
+				Const: zero,
+			},
+		},
+	}
+}
+
 func (cs *compileState) parseFuncParams(block *block, fname string, d *ast.FuncDecl) (in, out []variable, ret shaderir.Type) {
 	for _, f := range d.Type.Params.List {
 		t, ok := cs.parseType(block, fname, f.Type)
@@ -937,6 +1266,13 @@ func (cs *compileState) parseBlock(outer *block, fname string, stmts []ast.Stmt,
 		if !ok {
 			return nil, false
 		}
+		// Record the source position so that a backend compiler's error can be mapped to the Kage source.
+		p := cs.fs.Position(stmt.Pos())
+		for i := range ss {
+			if !ss[i].SourcePos.IsValid() {
+				ss[i].SourcePos = shaderir.SourcePos{Line: p.Line, Column: p.Column}
+			}
+		}
 		block.ir.Stmts = append(block.ir.Stmts, ss...)
 	}
 
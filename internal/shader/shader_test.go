@@ -18,6 +18,7 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"regexp"
 	"runtime"
 	"strings"
 	"testing"
@@ -199,3 +200,68 @@ func TestCompile(t *testing.T) {
 		})
 	}
 }
+
+func TestSourceMap(t *testing.T) {
+	src := []byte(`package main
+
+func Vertex(dstPos vec4) vec4 {
+	return dstPos
+}
+
+func Fragment(dstPos vec4) vec4 {
+	x := 1.0
+	if x > 0 {
+		x += 42.0
+	}
+	return vec4(x)
+}
+`)
+	s, err := shader.Compile(src, "Vertex", "Fragment", 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	findLine := func(src, substr string) int {
+		for i, l := range strings.Split(src, "\n") {
+			if strings.Contains(l, substr) {
+				return i + 1
+			}
+		}
+		t.Fatalf("%q is not found in the generated source:\n%s", substr, src)
+		return 0
+	}
+
+	_, fs, _, fsmap := glsl.CompileWithSourceMaps(s, glsl.GLSLVersionDefault)
+	if strings.Contains(fs, "/*kage:") {
+		t.Errorf("the generated GLSL must not include markers:\n%s", fs)
+	}
+	line := findLine(fs, "42.0")
+	pos, ok := fsmap.Position(line)
+	if !ok {
+		t.Fatalf("fsmap.Position(%d) must be mapped", line)
+	}
+	if got, want := pos, (shaderir.SourcePos{Line: 10, Column: 3}); got != want {
+		t.Errorf("fsmap.Position(%d): got: %v, want: %v", line, got, want)
+	}
+
+	log := fmt.Sprintf("0:%d(5): error: some error", line)
+	got, ok := fsmap.MapErrorLog(log, regexp.MustCompile(`\b\d+:(\d+)(?:\(\d+\))?:`))
+	if !ok {
+		t.Fatalf("MapErrorLog(%q) must map the log", log)
+	}
+	if want := "10:3: error: some error"; got != want {
+		t.Errorf("MapErrorLog(%q): got: %q, want: %q", log, got, want)
+	}
+
+	_, ps, _, _, psmap := hlsl.CompileWithSourceMaps(s)
+	line = findLine(ps, "42.0")
+	if pos, ok := psmap.Position(line); !ok || pos.Line != 10 {
+		t.Errorf("psmap.Position(%d): got: %v, want: line 10", line, pos)
+	}
+
+	m, mmap := msl.CompileWithSourceMap(s)
+	line = findLine(m, "42.0")
+	if pos, ok := mmap.Position(line); !ok || pos.Line != 10 {
+		t.Errorf("mmap.Position(%d): got: %v, want: line 10", line, pos)
+	}
+}
@@ -77,6 +77,10 @@ func (cs *compileState) parseStmt(block *block, fname string, stmt ast.Stmt, inP
 				cs.addError(stmt.Pos(), "a uniform variable cannot be assigned")
 				return nil, false
 			}
+			if isConstArrayElement(&lhs[0]) {
+				cs.addError(stmt.Pos(), "a constant array cannot be assigned")
+				return nil, false
+			}
 
 			var op shaderir.Op
 			switch stmt.Tok {
@@ -296,12 +300,51 @@ func (cs *compileState) parseStmt(block *block, fname string, stmt ast.Stmt, inP
 			Blocks: bs,
 		})
 
+	case *ast.SwitchStmt:
+		if stmt.Init != nil {
+			init := stmt.Init
+			stmt.Init = nil
+			b, ok := cs.parseBlock(block, fname, []ast.Stmt{init, stmt}, inParams, outParams, returnType, true)
+			if !ok {
+				return nil, false
+			}
+
+			stmts = append(stmts, shaderir.Stmt{
+				Type:   shaderir.BlockStmt,
+				Blocks: []*shaderir.Block{b.ir},
+			})
+			return stmts, true
+		}
+
+		ss, ok, native := cs.parseIntSwitch(block, fname, stmt, inParams, outParams, returnType)
+		if !ok {
+			return nil, false
+		}
+		if native {
+			stmts = append(stmts, ss...)
+			return stmts, true
+		}
+
+		s, ok := cs.switchToIf(stmt)
+		if !ok {
+			return nil, false
+		}
+		ss, ok = cs.parseStmt(block, fname, s, inParams, outParams, returnType)
+		if !ok {
+			return nil, false
+		}
+		stmts = append(stmts, ss...)
+
 	case *ast.IncDecStmt:
 		exprs, ts, ss, ok := cs.parseExpr(block, fname, stmt.X, true)
 		if !ok {
 			return nil, false
 		}
 		stmts = append(stmts, ss...)
+		if isConstArrayElement(&exprs[0]) {
+			cs.addError(stmt.Pos(), "a constant array cannot be assigned")
+			return nil, false
+		}
 		var op shaderir.Op
 		switch stmt.Tok {
 		case token.INC:
@@ -451,6 +494,9 @@ func (cs *compileState) parseStmt(block *block, fname string, stmt ast.Stmt, inP
 			stmts = append(stmts, shaderir.Stmt{
 				Type: shaderir.Continue,
 			})
+		case token.FALLTHROUGH:
+			cs.addError(stmt.Pos(), "fallthrough is not supported")
+			return nil, false
 		default:
 			cs.addError(stmt.Pos(), fmt.Sprintf("invalid token: %s", stmt.Tok))
 			return nil, false
@@ -576,6 +622,10 @@ func (cs *compileState) assign(block *block, fname string, pos token.Pos, lhs, r
 				return false
 			}
 
+			if isConstArrayElement(&l[0]) {
+				cs.addError(pos, "a constant array cannot be assigned")
+				return nil, false
+			}
 			if isAssignmentForbidden(&l[0]) {
 				cs.addError(pos, "a uniform variable cannot be assigned")
 				return nil, false
@@ -749,7 +799,7 @@ func canAssign(lt *shaderir.Type, rt *shaderir.Type, rc gconstant.Value) bool {
 }
 
 func (cs *compileState) parseFor(block *block, fname string, stmt *ast.ForStmt, inParams, outParams []variable, returnType shaderir.Type, checkLocalVariableUsage bool) ([]shaderir.Stmt, bool) {
-	msg := "for-statement must follow this format: for (varname) := (constant); (varname) (op) (constant); (varname) (op) (constant) { ..."
+	msg := "for-statement must follow this format: for (varname) := (expression); (varname) (op) (expression); (varname) (op) (constant) { ..."
 	if stmt.Init == nil {
 		cs.addError(stmt.Pos(), msg)
 		return nil, false
@@ -785,10 +835,6 @@ func (cs *compileState) parseFor(block *block, fname string, stmt *ast.ForStmt,
 		return nil, false
 	}
 	varidx := ss[0].Exprs[0].Index
-	if ss[0].Exprs[1].Const == nil {
-		cs.addError(stmt.Pos(), msg)
-		return nil, false
-	}
 
 	if len(pseudoBlock.vars) != 1 {
 		cs.addError(stmt.Pos(), msg)
@@ -796,7 +842,15 @@ func (cs *compileState) parseFor(block *block, fname string, stmt *ast.ForStmt,
 	}
 
 	vartype := pseudoBlock.vars[0].typ
-	init := ss[0].Exprs[1].Const
+	if vartype.Main != shaderir.Int && vartype.Main != shaderir.Float {
+		cs.addError(stmt.Pos(), fmt.Sprintf("for-statement's counter must be int or float but %s", vartype.String()))
+		return nil, false
+	}
+	initExpr := ss[0].Exprs[1]
+	// Assigning to an int or float variable might set an unknown constant to a non-constant expression.
+	if initExpr.Const != nil && initExpr.Const.Kind() == gconstant.Unknown {
+		initExpr.Const = nil
+	}
 
 	exprs, ts, ss, ok := cs.parseExpr(pseudoBlock, fname, stmt.Cond, true)
 	if !ok {
@@ -818,24 +872,33 @@ func (cs *compileState) parseFor(block *block, fname string, stmt *ast.ForStmt,
 		cs.addError(stmt.Pos(), msg)
 		return nil, false
 	}
+	// Accept the counter variable at the right-hand side like `n > i` by swapping the operands.
+	if !isLocalVariable(&exprs[0].Exprs[0], varidx) && isLocalVariable(&exprs[0].Exprs[1], varidx) {
+		exprs[0].Exprs[0], exprs[0].Exprs[1] = exprs[0].Exprs[1], exprs[0].Exprs[0]
+		switch exprs[0].Op {
+		case shaderir.LessThanOp:
+			exprs[0].Op = shaderir.GreaterThanOp
+		case shaderir.LessThanEqualOp:
+			exprs[0].Op = shaderir.GreaterThanEqualOp
+		case shaderir.GreaterThanOp:
+			exprs[0].Op = shaderir.LessThanOp
+		case shaderir.GreaterThanEqualOp:
+			exprs[0].Op = shaderir.LessThanEqualOp
+		}
+	}
 	op := exprs[0].Op
 	if op != shaderir.LessThanOp && op != shaderir.LessThanEqualOp && op != shaderir.GreaterThanOp && op != shaderir.GreaterThanEqualOp && op != shaderir.EqualOp && op != shaderir.NotEqualOp {
 		cs.addError(stmt.Pos(), "for-statement's condition must have one of these operators: <, <=, >, >=, ==, !=")
 		return nil, false
 	}
-	if exprs[0].Exprs[0].Type != shaderir.LocalVariable {
+	if !isLocalVariable(&exprs[0].Exprs[0], varidx) {
 		cs.addError(stmt.Pos(), msg)
 		return nil, false
 	}
-	if exprs[0].Exprs[0].Index != varidx {
-		cs.addError(stmt.Pos(), msg)
-		return nil, false
+	endExpr := exprs[0].Exprs[1]
+	if endExpr.Const != nil && endExpr.Const.Kind() == gconstant.Unknown {
+		endExpr.Const = nil
 	}
-	if exprs[0].Exprs[1].Const == nil {
-		cs.addError(stmt.Pos(), msg)
-		return nil, false
-	}
-	end := exprs[0].Exprs[1].Const
 
 	postSs, ok := cs.parseStmt(pseudoBlock, fname, stmt.Post, inParams, outParams, returnType)
 	if !ok {
@@ -899,16 +962,240 @@ func (cs *compileState) parseFor(block *block, fname string, stmt *ast.ForStmt,
 	v.forLoopCounter = true
 	block.vars = append(block.vars, v)
 
-	return []shaderir.Stmt{
-		{
-			Type:        shaderir.For,
-			Blocks:      []*shaderir.Block{bodyir},
-			ForVarType:  vartype,
-			ForVarIndex: varidx,
-			ForInit:     init,
-			ForEnd:      end,
-			ForOp:       op,
-			ForDelta:    delta,
-		},
-	}, true
+	forStmt := shaderir.Stmt{
+		Type:             shaderir.For,
+		Blocks:           []*shaderir.Block{bodyir},
+		ForVarType:       vartype,
+		ForVarIndex:      varidx,
+		ForOp:            op,
+		ForDelta:         delta,
+		ForMaxIterations: cs.takeMaxIterations(stmt.Pos()),
+	}
+	if initExpr.Const != nil && endExpr.Const != nil {
+		forStmt.ForInit = initExpr.Const
+		forStmt.ForEnd = endExpr.Const
+	} else {
+		forStmt.Exprs = []shaderir.Expr{initExpr, endExpr}
+	}
+	return []shaderir.Stmt{forStmt}, true
+}
+
+func isLocalVariable(expr *shaderir.Expr, index int) bool {
+	return expr.Type == shaderir.LocalVariable && expr.Index == index
+}
+
+// parseIntSwitch parses a switch-statement with an integer tag and integer constant cases as a native switch-statement.
+// If the switch-statement cannot be a native one, parseIntSwitch returns false as native.
+func (cs *compileState) parseIntSwitch(block *block, fname string, stmt *ast.SwitchStmt, inParams, outParams []variable, returnType shaderir.Type) (stmts []shaderir.Stmt, ok bool, native bool) {
+	if stmt.Tag == nil {
+		return nil, true, false
+	}
+
+	// Check the case values first as they are constants and parsing them doesn't have side effects.
+	var cases [][]gconstant.Value
+	for _, s := range stmt.Body.List {
+		cc := s.(*ast.CaseClause)
+		if cc.List == nil {
+			cases = append(cases, nil)
+			continue
+		}
+		vs := []gconstant.Value{}
+		for _, e := range cc.List {
+			es, ts, _, ok := cs.parseExpr(block, fname, e, true)
+			if !ok {
+				return nil, false, false
+			}
+			if len(es) != 1 || es[0].Type != shaderir.NumberExpr || es[0].Const == nil || es[0].Const.Kind() == gconstant.Unknown {
+				return nil, true, false
+			}
+			if ts[0].Main != shaderir.Int && (ts[0].Main != shaderir.None || !canTruncateToInteger(es[0].Const)) {
+				return nil, true, false
+			}
+			vs = append(vs, gconstant.ToInt(es[0].Const))
+		}
+		cases = append(cases, vs)
+	}
+
+	tag, ts, ss, ok := cs.parseExpr(block, fname, stmt.Tag, true)
+	if !ok {
+		return nil, false, false
+	}
+	if len(tag) != 1 || ts[0].Main != shaderir.Int {
+		return nil, true, false
+	}
+	stmts = append(stmts, ss...)
+
+	var hasDefault bool
+	var seen []gconstant.Value
+	for i, s := range stmt.Body.List {
+		cc := s.(*ast.CaseClause)
+		if cases[i] == nil {
+			if hasDefault {
+				cs.addError(cc.Pos(), "multiple defaults in switch")
+				return nil, false, false
+			}
+			hasDefault = true
+			continue
+		}
+		for j, v := range cases[i] {
+			for _, v2 := range seen {
+				if gconstant.Compare(v, token.EQL, v2) {
+					cs.addError(cc.List[j].Pos(), fmt.Sprintf("duplicate case %s in expression switch", v.String()))
+					return nil, false, false
+				}
+			}
+			seen = append(seen, v)
+		}
+	}
+
+	var bs []*shaderir.Block
+	for _, s := range stmt.Body.List {
+		cc := s.(*ast.CaseClause)
+		b, ok := cs.parseBlock(block, fname, cc.Body, inParams, outParams, returnType, true)
+		if !ok {
+			return nil, false, false
+		}
+		bs = append(bs, b.ir)
+	}
+
+	stmts = append(stmts, shaderir.Stmt{
+		Type:        shaderir.Switch,
+		Exprs:       tag,
+		Blocks:      bs,
+		SwitchCases: cases,
+	})
+	return stmts, true, true
+}
+
+// switchToIf converts a switch-statement that cannot be a native switch-statement to a chain of if-statements.
+// The tag expression is evaluated for each case, but this is fine as an expression doesn't have side effects in Kage.
+func (cs *compileState) switchToIf(stmt *ast.SwitchStmt) (ast.Stmt, bool) {
+	var root ast.Stmt
+	var last *ast.IfStmt
+	var defaultClause *ast.CaseClause
+	for _, s := range stmt.Body.List {
+		cc := s.(*ast.CaseClause)
+		if !cs.checkSwitchCaseBody(cc.Body) {
+			return nil, false
+		}
+		if cc.List == nil {
+			if defaultClause != nil {
+				cs.addError(cc.Pos(), "multiple defaults in switch")
+				return nil, false
+			}
+			defaultClause = cc
+			continue
+		}
+
+		var cond ast.Expr
+		for _, e := range cc.List {
+			c := e
+			if stmt.Tag != nil {
+				c = &ast.BinaryExpr{
+					X:     stmt.Tag,
+					OpPos: e.Pos(),
+					Op:    token.EQL,
+					Y:     e,
+				}
+			}
+			if cond == nil {
+				cond = c
+				continue
+			}
+			cond = &ast.BinaryExpr{
+				X:     cond,
+				OpPos: e.Pos(),
+				Op:    token.LOR,
+				Y:     c,
+			}
+		}
+
+		s := &ast.IfStmt{
+			If:   cc.Case,
+			Cond: cond,
+			Body: &ast.BlockStmt{
+				Lbrace: cc.Colon,
+				List:   cc.Body,
+			},
+		}
+		if last == nil {
+			root = s
+		} else {
+			last.Else = s
+		}
+		last = s
+	}
+
+	// The default clause is evaluated last wherever it is.
+	if defaultClause != nil {
+		b := &ast.BlockStmt{
+			Lbrace: defaultClause.Colon,
+			List:   defaultClause.Body,
+		}
+		if last == nil {
+			root = b
+		} else {
+			last.Else = b
+		}
+	}
+
+	if stmt.Init == nil && stmt.Tag == nil && root != nil {
+		return root, true
+	}
+
+	b := &ast.BlockStmt{
+		Lbrace: stmt.Switch,
+	}
+	if stmt.Init != nil {
+		b.List = append(b.List, stmt.Init)
+	}
+	if stmt.Tag != nil {
+		// Evaluate the tag even without cases, so that variables in the tag are treated as used.
+		b.List = append(b.List, &ast.AssignStmt{
+			Lhs:    []ast.Expr{ast.NewIdent("_")},
+			TokPos: stmt.Tag.Pos(),
+			Tok:    token.ASSIGN,
+			Rhs:    []ast.Expr{stmt.Tag},
+		})
+	}
+	if root != nil {
+		b.List = append(b.List, root)
+	}
+	return b, true
+}
+
+// checkSwitchCaseBody reports whether the body of a case clause can be converted to an if-statement's body.
+func (cs *compileState) checkSwitchCaseBody(body []ast.Stmt) bool {
+	ok := true
+	for _, s := range body {
+		ast.Inspect(s, func(n ast.Node) bool {
+			switch n := n.(type) {
+			case *ast.ForStmt, *ast.SwitchStmt:
+				// break in these statements doesn't refer to the outer switch-statement.
+				return false
+			case *ast.BranchStmt:
+				switch n.Tok {
+				case token.BREAK:
+					cs.addError(n.Pos(), "break in a switch-statement is not supported")
+					ok = false
+				case token.FALLTHROUGH:
+					cs.addError(n.Pos(), "fallthrough is not supported")
+					ok = false
+				}
+			}
+			return true
+		})
+	}
+	return ok
+}
+
+// isConstArrayElement reports whether e refers to a constant array or its element.
+func isConstArrayElement(e *shaderir.Expr) bool {
+	switch e.Type {
+	case shaderir.ConstArrayVariable:
+		return true
+	case shaderir.FieldSelector, shaderir.Index:
+		return isConstArrayElement(&e.Exprs[0])
+	}
+	return false
 }
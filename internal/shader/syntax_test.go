@@ -4482,3 +4482,170 @@ func Fragment(dstPos vec4, srcPos vec2, color vec4) vec4 {
 		}
 	}
 }
+
+func TestSyntaxForWithNonConstantBounds(t *testing.T) {
+	cases := []struct {
+		stmt string
+		err  bool
+	}{
+		{stmt: "n := 3; for i := 0; i < n; i++ { _ = i }", err: false},
+		{stmt: "n := 3; for i := n; i >= 0; i-- { _ = i }", err: false},
+		{stmt: "n := 3; for i := 0; n > i; i++ { _ = i }", err: false},
+		{stmt: "n := 3.0; for i := 0.0; i < n*2; i += 0.5 { _ = i }", err: false},
+		{stmt: "n := 3; for i := 0; i < float(n); i++ { _ = i }", err: true},
+		{stmt: "n := 3; for i := 0; i < n; i += n { _ = i }", err: true},
+		{stmt: "n := 3; for i := 0; n < 3; i++ { _ = i }", err: true},
+		{stmt: "n := true; for i := n; i; i++ { _ = i }", err: true},
+		{stmt: "n := 3\n\t//kage:maxiterations 8\n\tfor i := 0; i < n; i++ { _ = i }", err: false},
+		{stmt: "n := 3\n\t//kage:maxiterations 8\n\t_ = n", err: true},
+		{stmt: "n := 3\n\t//kage:maxiterations 0\n\tfor i := 0; i < n; i++ { _ = i }", err: true},
+		{stmt: "n := 3\n\t//kage:maxiterations x\n\tfor i := 0; i < n; i++ { _ = i }", err: true},
+	}
+
+	for _, c := range cases {
+		stmt := c.stmt
+		src := fmt.Sprintf(`package main
+
+func Fragment(dstPos vec4, srcPos vec2, color vec4) vec4 {
+	%s
+	return dstPos
+}`, stmt)
+		_, err := compileToIR([]byte(src))
+		if err == nil && c.err {
+			t.Errorf("%s must return an error but does not", stmt)
+		} else if err != nil && !c.err {
+			t.Errorf("%s must not return nil but returned %v", stmt, err)
+		}
+	}
+}
+
+func TestSyntaxDepthDirective(t *testing.T) {
+	cases := []struct {
+		directive string
+		test      bool
+		write     bool
+		err       bool
+	}{
+		{directive: "", test: false, write: false},
+		{directive: "//kage:depth test", test: true, write: false},
+		{directive: "//kage:depth write", test: false, write: true},
+		{directive: "//kage:depth test write", test: true, write: true},
+		{directive: "//kage:depth write test", test: true, write: true},
+		{directive: "//kage:depth", err: true},
+		{directive: "//kage:depth read", err: true},
+		{directive: "//kage:depth test test", err: true},
+		{directive: "//kage:depth test\n//kage:depth write", err: true},
+	}
+
+	for _, c := range cases {
+		src := fmt.Sprintf(`%s
+
+package main
+
+func Fragment(dstPos vec4, srcPos vec2, color vec4) vec4 {
+	return color
+}`, c.directive)
+		ir, err := compileToIR([]byte(src))
+		if err == nil && c.err {
+			t.Errorf("%q must return an error but does not", c.directive)
+			continue
+		}
+		if err != nil {
+			if !c.err {
+				t.Errorf("%q must not return nil but returned %v", c.directive, err)
+			}
+			continue
+		}
+		if ir.DepthTest != c.test || ir.DepthWrite != c.write {
+			t.Errorf("%q: got: (test: %t, write: %t), want: (test: %t, write: %t)", c.directive, ir.DepthTest, ir.DepthWrite, c.test, c.write)
+		}
+	}
+}
+
+func TestSyntaxSwitch(t *testing.T) {
+	cases := []struct {
+		stmt string
+		err  bool
+	}{
+		{stmt: "x := 1; switch x { case 0: x = 2; case 1, 2: x = 3; default: x = 4 }", err: false},
+		{stmt: "x := 1; switch { case x > 0: x = 2 }", err: false},
+		{stmt: "x := 1; switch y := x * 2; y { case 2: x = y }", err: false},
+		{stmt: "x := 1; switch x { }", err: false},
+		{stmt: "x := 1; switch x { default: x = 2 }", err: false},
+		{stmt: "x := 1; switch x { case 0: for i := 0; i < 3; i++ { break } }", err: false},
+		{stmt: "x := 1; switch x { case 0: break }", err: false},
+		{stmt: "x := 1; switch x { case 0: if x > 0 { break } }", err: false},
+		{stmt: "x := 1; switch x { case 0, 1: x = 2; case 1: x = 3 }", err: true},
+		{stmt: "x := 1; switch x { default: x = 2; default: x = 3 }", err: true},
+		{stmt: "x := 1.0; switch x { case 0: break }", err: true},
+		{stmt: "x := 1; switch { case x > 0: break }", err: true},
+		{stmt: "x := 1; switch { case x > 0: if x > 1 { break } }", err: true},
+		{stmt: "x := 1; switch x { case 0: fallthrough; case 1: x = 2 }", err: true},
+		{stmt: "x := 1.0; switch x { case 0: fallthrough; case 1: x = 2 }", err: true},
+		{stmt: "x := 1; switch x { case 0.5: x = 2 }", err: true},
+		{stmt: "x := 1; switch { case x: x = 2 }", err: true},
+	}
+
+	for _, c := range cases {
+		stmt := c.stmt
+		src := fmt.Sprintf(`package main
+
+func Fragment(dstPos vec4, srcPos vec2, color vec4) vec4 {
+	%s
+	return dstPos
+}`, stmt)
+		_, err := compileToIR([]byte(src))
+		if err == nil && c.err {
+			t.Errorf("%s must return an error but does not", stmt)
+		} else if err != nil && !c.err {
+			t.Errorf("%s must not return nil but returned %v", stmt, err)
+		}
+	}
+}
+
+func TestSyntaxConstArray(t *testing.T) {
+	cases := []struct {
+		decl string
+		stmt string
+		err  bool
+	}{
+		{decl: "var k = [3]float{1, 2, 3}", stmt: "_ = k[0]", err: false},
+		{decl: "var k = [...]int{1, 2, 3}", stmt: "_ = k[2]", err: false},
+		{decl: "var k = [4]float{1}", stmt: "_ = k[3]", err: false},
+		{decl: "var k [2]vec2 = [2]vec2{vec2(1), vec2(2, 3)}", stmt: "_ = k[1]", err: false},
+		{decl: "var k = [2]ivec3{ivec3(1, 2, 3)}", stmt: "_ = k[1]", err: false},
+		{decl: "var k = [2]bool{true}", stmt: "_ = k[1]", err: false},
+		{decl: "var k = [3]float{1, 2, 3}", stmt: "i := 1; _ = k[i]", err: false},
+		{decl: "var k = [3]float{1, 2, 3}", stmt: "x := k; _ = x", err: false},
+		{decl: "var k = [3]float{1, 2, 3}", stmt: "_ = k[3]", err: true},
+		{decl: "var k = [3]float{1, 2, 3}", stmt: "k[0] = 1", err: true},
+		{decl: "var k = [3]float{1, 2, 3}", stmt: "k[0] += 1", err: true},
+		{decl: "var k = [3]float{1, 2, 3}", stmt: "k[0]++", err: true},
+		{decl: "var k = [2]vec2{}", stmt: "k[0].x = 1", err: true},
+		{decl: "var k = [2]float{1, 2, 3}", stmt: "_ = k[0]", err: true},
+		{decl: "var k = [2]int{1, 2.5}", stmt: "_ = k[0]", err: true},
+		{decl: "var k = [2]float{1, int(2)}", stmt: "_ = k[0]", err: true},
+		{decl: "var k = [2]mat2{}", stmt: "_ = k[0]", err: true},
+		{decl: "var k = [2][2]float{}", stmt: "_ = k[0]", err: true},
+		{decl: "var k = 1.0", stmt: "_ = k", err: true},
+		{decl: "var k [3]float = [2]float{1, 2}", stmt: "_ = k[0]", err: true},
+		{decl: "var K vec2; var K = [2]float{}", stmt: "_ = K[0]", err: true},
+	}
+
+	for _, c := range cases {
+		src := fmt.Sprintf(`package main
+
+%s
+
+func Fragment(dstPos vec4, srcPos vec2, color vec4) vec4 {
+	%s
+	return dstPos
+}`, c.decl, c.stmt)
+		_, err := compileToIR([]byte(src))
+		if err == nil && c.err {
+			t.Errorf("%s; %s must return an error but does not", c.decl, c.stmt)
+		} else if err != nil && !c.err {
+			t.Errorf("%s; %s must not return nil but returned %v", c.decl, c.stmt, err)
+		}
+	}
+}
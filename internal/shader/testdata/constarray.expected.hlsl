@@ -0,0 +1,40 @@
+cbuffer Uniforms : register(b0) {
+	int U0 : packoffset(c0);
+}
+
+static const float C0[3] = {2.5000000000e-01, 5.0000000000e-01, 2.5000000000e-01};
+static const float3 C1[2] = {float3(1.0, 0.0, 0.0), float3(0.0, 1.0, 0.0)};
+static const int2 C2[4] = {int2(-1, 0), int2(1, 0), (int2)(0), (int2)(0)};
+
+Varyings VSMain(float2 A0 : POSITION) {
+	Varyings varyings;
+	float l0 = 0.0;
+	float3 l2 = 0.0;
+	int2 l3 = 0;
+	l0 = 0.0;
+	for (int l1 = 0; l1 < 3; l1++) {
+		l0 = (l0) + ((C0)[l1]);
+	}
+	l2 = (C1)[U0];
+	l3 = (C2)[3];
+	switch (U0) {
+	case 0:
+		{
+			l0 = (l0) + ((l2).x);
+		}
+		break;
+	case 1:
+	case 2:
+		{
+			l0 = (l0) + (float((l3).x));
+			break;
+		}
+	default:
+		{
+			varyings.Position = (float4)((C0)[1]);
+			return varyings;
+		}
+	}
+	varyings.Position = (float4)(l0);
+	return varyings;
+}
@@ -0,0 +1,47 @@
+struct Uniforms {
+	int U0;
+};
+
+constant array<float, 3> C0 = {2.5000000000e-01, 5.0000000000e-01, 2.5000000000e-01};
+constant array<float3, 2> C1 = {float3(1.0, 0.0, 0.0), float3(0.0, 1.0, 0.0)};
+constant array<int2, 4> C2 = {int2(-1, 0), int2(1, 0), int2(0), int2(0)};
+
+struct Attributes {
+	float2 M0;
+};
+
+vertex Varyings Vertex(
+	uint vid [[vertex_id]],
+	const device Attributes* attributes [[buffer(0)]],
+	constant Uniforms& uniforms [[buffer(1)]]) {
+	Varyings varyings = {};
+	float l0 = float(0);
+	float3 l2 = float3(0);
+	int2 l3 = int2(0);
+	l0 = 0.0;
+	for (int l1 = 0; l1 < 3; l1++) {
+		l0 = (l0) + ((C0)[l1]);
+	}
+	l2 = (C1)[uniforms.U0];
+	l3 = (C2)[3];
+	switch (uniforms.U0) {
+	case 0:
+		{
+			l0 = (l0) + ((l2).x);
+		}
+		break;
+	case 1:
+	case 2:
+		{
+			l0 = (l0) + (static_cast<float>((l3).x));
+			break;
+		}
+	default:
+		{
+			varyings.Position = float4((C0)[1]);
+			return varyings;
+		}
+	}
+	varyings.Position = float4(l0);
+	return varyings;
+}
@@ -0,0 +1,37 @@
+uniform int U0;
+const float C0[3] = float[3](2.5000000000e-01, 5.0000000000e-01, 2.5000000000e-01);
+const vec3 C1[2] = vec3[2](vec3(1.0, 0.0, 0.0), vec3(0.0, 1.0, 0.0));
+const ivec2 C2[4] = ivec2[4](ivec2(-1, 0), ivec2(1, 0), ivec2(0), ivec2(0));
+in vec2 A0;
+
+void main(void) {
+	float l0 = float(0);
+	vec3 l2 = vec3(0);
+	ivec2 l3 = ivec2(0);
+	l0 = 0.0;
+	for (int l1 = 0; l1 < 3; l1++) {
+		l0 = (l0) + ((C0)[l1]);
+	}
+	l2 = (C1)[U0];
+	l3 = (C2)[3];
+	switch (U0) {
+	case 0:
+		{
+			l0 = (l0) + ((l2).x);
+		}
+		break;
+	case 1:
+	case 2:
+		{
+			l0 = (l0) + (float((l3).x));
+			break;
+		}
+	default:
+		{
+			gl_Position = vec4((C0)[1]);
+			return;
+		}
+	}
+	gl_Position = vec4(l0);
+	return;
+}
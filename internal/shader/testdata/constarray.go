@@ -0,0 +1,31 @@
+package main
+
+var U0 int
+
+var kernel = [3]float{0.25, 0.5, 0.25}
+
+var palette = [...]vec3{
+	vec3(1, 0, 0),
+	vec3(0, 1, 0),
+}
+
+var offsets = [4]ivec2{ivec2(-1, 0), ivec2(1, 0)}
+
+func Vertex(pos vec2) vec4 {
+	x := 0.0
+	for i := 0; i < 3; i++ {
+		x += kernel[i]
+	}
+	c := palette[U0]
+	o := offsets[3]
+	switch U0 {
+	case 0:
+		x += c.x
+	case 1, 2:
+		x += float(o.x)
+		break
+	default:
+		return vec4(kernel[1])
+	}
+	return vec4(x)
+}
@@ -0,0 +1,22 @@
+cbuffer Uniforms : register(b0) {
+	int U0 : packoffset(c0);
+	float U1 : packoffset(c0.y);
+}
+
+Varyings VSMain(float2 A0 : POSITION) {
+	Varyings varyings;
+	float l0 = 0.0;
+	l0 = 0.0;
+	for (int l1 = 0; l1 < U0; l1++) {
+		l0 = (l0) + (float(l1));
+	}
+	[unroll(16)]
+	for (int l2 = U0; l2 >= 0; l2 -= 2) {
+		l0 = (l0) + (float(l2));
+	}
+	for (float l3 = 0.0; l3 < (U1) * (2.0); l3 += 5.0000000000e-01) {
+		l0 = (l0) + (l3);
+	}
+	varyings.Position = (float4)(l0);
+	return varyings;
+}
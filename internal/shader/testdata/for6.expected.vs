@@ -0,0 +1,19 @@
+uniform int U0;
+uniform float U1;
+in vec2 A0;
+
+void main(void) {
+	float l0 = float(0);
+	l0 = 0.0;
+	for (int l1 = 0; l1 < U0; l1++) {
+		l0 = (l0) + (float(l1));
+	}
+	for (int l2 = U0; l2 >= 0; l2 -= 2) {
+		l0 = (l0) + (float(l2));
+	}
+	for (float l3 = 0.0; l3 < (U1) * (2.0); l3 += 5.0000000000e-01) {
+		l0 = (l0) + (l3);
+	}
+	gl_Position = vec4(l0);
+	return;
+}
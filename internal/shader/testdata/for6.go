@@ -0,0 +1,21 @@
+package main
+
+var (
+	U0 int
+	U1 float
+)
+
+func Vertex(pos vec2) vec4 {
+	sum := 0.0
+	for i := 0; i < U0; i++ {
+		sum += float(i)
+	}
+	//kage:maxiterations 16
+	for i := U0; i >= 0; i -= 2 {
+		sum += float(i)
+	}
+	for x := 0.0; U1*2 > x; x += 0.5 {
+		sum += x
+	}
+	return vec4(sum)
+}
@@ -0,0 +1,60 @@
+#version 150
+
+#if defined(GL_ES)
+precision highp float;
+precision highp int;
+#else
+#define lowp
+#define mediump
+#define highp
+#endif
+
+out vec4 fragColor0;
+out vec4 fragColor1;
+
+int modInt(int x, int y) {
+	return x - y*(x/y);
+}
+
+ivec2 modInt(ivec2 x, int y) {
+	return x - y*(x/y);
+}
+
+ivec3 modInt(ivec3 x, int y) {
+	return x - y*(x/y);
+}
+
+ivec4 modInt(ivec4 x, int y) {
+	return x - y*(x/y);
+}
+
+ivec2 modInt(ivec2 x, ivec2 y) {
+	return x - y*(x/y);
+}
+
+ivec3 modInt(ivec3 x, ivec3 y) {
+	return x - y*(x/y);
+}
+
+ivec4 modInt(ivec4 x, ivec4 y) {
+	return x - y*(x/y);
+}
+
+in vec2 V0;
+in vec4 V1;
+
+void F0(in vec4 l0, in vec2 l1, in vec4 l2, out vec4 l3, out vec4 l4);
+
+void F0(in vec4 l0, in vec2 l1, in vec4 l2, out vec4 l3, out vec4 l4) {
+	if (((l2).a) == (0.0)) {
+		discard;
+		return;
+	}
+	l3 = l2;
+	l4 = vec4(l1, 0.0, 1.0);
+	return;
+}
+
+void main(void) {
+	F0(gl_FragCoord, V0, V1, fragColor0, fragColor1);
+}
@@ -0,0 +1,38 @@
+struct Attributes {
+	float2 M0;
+	float2 M1;
+	float4 M2;
+};
+
+struct Varyings {
+	float4 Position [[position]];
+	float2 M0;
+	float4 M1;
+};
+
+vertex Varyings Vertex(
+	uint vid [[vertex_id]],
+	const device Attributes* attributes [[buffer(0)]]) {
+	Varyings varyings = {};
+	varyings.Position = float4(attributes[vid].M0, 0.0, 1.0);
+	varyings.M0 = attributes[vid].M1;
+	varyings.M1 = attributes[vid].M2;
+	return varyings;
+}
+
+struct Colors {
+	float4 M0 [[color(0)]];
+	float4 M1 [[color(1)]];
+};
+
+fragment Colors Fragment(
+	Varyings varyings [[stage_in]]) {
+	Colors colors = {};
+	if (((varyings.M1).a) == (0.0)) {
+		discard_fragment();
+		return colors;
+	}
+	colors.M0 = varyings.M1;
+	colors.M1 = float4(varyings.M0, 0.0, 1.0);
+	return colors;
+}
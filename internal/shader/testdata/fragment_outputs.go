@@ -0,0 +1,12 @@
+package main
+
+func Vertex(dstPos vec2, srcPos vec2, color vec4) (dstPos vec4, srcPos vec2, color vec4) {
+	return vec4(dstPos, 0, 1), srcPos, color
+}
+
+func Fragment(dstPos vec4, srcPos vec2, color vec4) (vec4, vec4) {
+	if color.a == 0 {
+		discard()
+	}
+	return color, vec4(srcPos, 0, 1)
+}
@@ -0,0 +1,34 @@
+uniform int U0;
+in vec2 A0;
+
+void main(void) {
+	float l0 = float(0);
+	l0 = 0.0;
+	switch (U0) {
+	case 0:
+		{
+			l0 = 1.0;
+		}
+		break;
+	default:
+		{
+			l0 = 4.0;
+		}
+		break;
+	case 1:
+	case 2:
+		{
+			l0 = 2.0;
+		}
+		break;
+	}
+	{
+		float l1 = float(0);
+		l1 = float(U0);
+		if ((l1) > (1.0)) {
+			l0 = (l0) + (l1);
+		}
+	}
+	gl_Position = vec4(l0);
+	return;
+}
@@ -0,0 +1,20 @@
+package main
+
+var U0 int
+
+func Vertex(pos vec2) vec4 {
+	x := 0.0
+	switch U0 {
+	case 0:
+		x = 1
+	default:
+		x = 4
+	case 1, 2:
+		x = 2
+	}
+	switch y := float(U0); {
+	case y > 1:
+		x += y
+	}
+	return vec4(x)
+}
@@ -0,0 +1,264 @@
+// Copyright 2026 The Ebitengine Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package shaderir
+
+import (
+	"fmt"
+	"go/constant"
+	"strconv"
+	"strings"
+)
+
+// Dump returns a human-readable representation of the program for debugging.
+// The format is not stable and might be changed in the future.
+func (p *Program) Dump() string {
+	var lines []string
+	for i, t := range p.Uniforms {
+		lines = append(lines, fmt.Sprintf("uniform u%d %s // %s", i, t.String(), p.UniformNames[i]))
+	}
+	for i, a := range p.ConstArrays {
+		var elms []string
+		for j := range a.Exprs {
+			elms = append(elms, exprString(&a.Exprs[j]))
+		}
+		lines = append(lines, fmt.Sprintf("const c%d %s = {%s} // %s", i, a.Type.String(), strings.Join(elms, ", "), a.Name))
+	}
+	if p.TextureCount > 0 {
+		lines = append(lines, fmt.Sprintf("textures %d", p.TextureCount))
+	}
+	for i, t := range p.Attributes {
+		lines = append(lines, fmt.Sprintf("attribute %d %s", i, t.String()))
+	}
+	for i, t := range p.Varyings {
+		lines = append(lines, fmt.Sprintf("varying %d %s", i, t.String()))
+	}
+	for _, f := range p.Funcs {
+		var params []string
+		for i, t := range f.InParams {
+			params = append(params, fmt.Sprintf("l%d %s", i, t.String()))
+		}
+		for i, t := range f.OutParams {
+			params = append(params, fmt.Sprintf("out l%d %s", len(f.InParams)+i, t.String()))
+		}
+		ret := ""
+		if f.Return.Main != None {
+			ret = " " + f.Return.String()
+		}
+		lines = append(lines, fmt.Sprintf("func f%d(%s)%s {", f.Index, strings.Join(params, ", "), ret))
+		lines = appendBlockDump(lines, f.Block, 1)
+		lines = append(lines, "}")
+	}
+	if p.VertexFunc.Block != nil {
+		lines = append(lines, "vertex {")
+		lines = appendBlockDump(lines, p.VertexFunc.Block, 1)
+		lines = append(lines, "}")
+	}
+	if p.FragmentFunc.Block != nil {
+		lines = append(lines, "fragment {")
+		lines = appendBlockDump(lines, p.FragmentFunc.Block, 1)
+		lines = append(lines, "}")
+	}
+	return strings.Join(lines, "\n") + "\n"
+}
+
+func appendBlockDump(lines []string, block *Block, level int) []string {
+	if block == nil {
+		return lines
+	}
+	idt := strings.Repeat("\t", level)
+	for i, t := range block.LocalVars {
+		if t.Main == None {
+			continue
+		}
+		lines = append(lines, fmt.Sprintf("%svar l%d %s", idt, block.LocalVarIndexOffset+i, t.String()))
+	}
+	for _, s := range block.Stmts {
+		switch s.Type {
+		case ExprStmt:
+			lines = append(lines, idt+exprString(&s.Exprs[0]))
+		case BlockStmt:
+			lines = append(lines, idt+"{")
+			lines = appendBlockDump(lines, s.Blocks[0], level+1)
+			lines = append(lines, idt+"}")
+		case Assign:
+			lines = append(lines, fmt.Sprintf("%s%s = %s", idt, exprString(&s.Exprs[0]), exprString(&s.Exprs[1])))
+		case Init:
+			lines = append(lines, fmt.Sprintf("%sinit l%d", idt, s.InitIndex))
+		case If:
+			lines = append(lines, fmt.Sprintf("%sif %s {", idt, exprString(&s.Exprs[0])))
+			lines = appendBlockDump(lines, s.Blocks[0], level+1)
+			if len(s.Blocks) > 1 {
+				lines = append(lines, idt+"} else {")
+				lines = appendBlockDump(lines, s.Blocks[1], level+1)
+			}
+			lines = append(lines, idt+"}")
+		case For:
+			var init, end string
+			if s.ForInit != nil && s.ForEnd != nil {
+				init = constantString(s.ForInit)
+				end = constantString(s.ForEnd)
+			} else {
+				init = exprString(&s.Exprs[0])
+				end = exprString(&s.Exprs[1])
+			}
+			if s.ForMaxIterations > 0 {
+				lines = append(lines, fmt.Sprintf("%s//kage:maxiterations %d", idt, s.ForMaxIterations))
+			}
+			lines = append(lines, fmt.Sprintf("%sfor l%[2]d := %[3]s; l%[2]d %[4]s %[5]s; l%[2]d += %[6]s {",
+				idt, s.ForVarIndex, init, opString(s.ForOp), end, constantString(s.ForDelta)))
+			lines = appendBlockDump(lines, s.Blocks[0], level+1)
+			lines = append(lines, idt+"}")
+		case Switch:
+			lines = append(lines, fmt.Sprintf("%sswitch %s {", idt, exprString(&s.Exprs[0])))
+			for i, b := range s.Blocks {
+				if s.SwitchCases[i] == nil {
+					lines = append(lines, idt+"default:")
+				} else {
+					var vs []string
+					for _, v := range s.SwitchCases[i] {
+						vs = append(vs, constantString(v))
+					}
+					lines = append(lines, fmt.Sprintf("%scase %s:", idt, strings.Join(vs, ", ")))
+				}
+				lines = appendBlockDump(lines, b, level+1)
+			}
+			lines = append(lines, idt+"}")
+		case Continue:
+			lines = append(lines, idt+"continue")
+		case Break:
+			lines = append(lines, idt+"break")
+		case Return:
+			if len(s.Exprs) == 0 {
+				lines = append(lines, idt+"return")
+			} else {
+				lines = append(lines, idt+"return "+exprString(&s.Exprs[0]))
+			}
+		case Discard:
+			lines = append(lines, idt+"discard")
+		default:
+			lines = append(lines, fmt.Sprintf("%s?(unexpected stmt: %d)", idt, s.Type))
+		}
+	}
+	return lines
+}
+
+func constantString(v constant.Value) string {
+	if v == nil {
+		return "?(nil)"
+	}
+	if v.Kind() == constant.Float {
+		// Distinguish a float from an int.
+		f, _ := constant.Float64Val(v)
+		str := strconv.FormatFloat(f, 'g', -1, 64)
+		if !strings.ContainsAny(str, ".eIN") {
+			str += ".0"
+		}
+		return str
+	}
+	return v.ExactString()
+}
+
+func opString(op Op) string {
+	switch op {
+	case Add:
+		return "+"
+	case Sub:
+		return "-"
+	case NotOp:
+		return "!"
+	case ComponentWiseMul, MatrixMul:
+		return "*"
+	case Div:
+		return "/"
+	case ModOp:
+		return "%"
+	case LeftShift:
+		return "<<"
+	case RightShift:
+		return ">>"
+	case LessThanOp:
+		return "<"
+	case LessThanEqualOp:
+		return "<="
+	case GreaterThanOp:
+		return ">"
+	case GreaterThanEqualOp:
+		return ">="
+	case EqualOp, VectorEqualOp:
+		return "=="
+	case NotEqualOp, VectorNotEqualOp:
+		return "!="
+	case And:
+		return "&"
+	case Xor:
+		return "^"
+	case Or:
+		return "|"
+	case AndAnd:
+		return "&&"
+	case OrOr:
+		return "||"
+	}
+	return fmt.Sprintf("?(unexpected op: %d)", op)
+}
+
+// exprString returns a string representation of the expression.
+// Two expressions with the same string are equivalent.
+func exprString(e *Expr) string {
+	switch e.Type {
+	case Blank:
+		return "_"
+	case NumberExpr:
+		return constantString(e.Const)
+	case UniformVariable:
+		return fmt.Sprintf("u%d", e.Index)
+	case ConstArrayVariable:
+		return fmt.Sprintf("c%d", e.Index)
+	case TextureVariable:
+		return fmt.Sprintf("t%d", e.Index)
+	case LocalVariable:
+		return fmt.Sprintf("l%d", e.Index)
+	case StructMember:
+		return fmt.Sprintf("m%d", e.Index)
+	case BuiltinFuncExpr:
+		return string(e.BuiltinFunc)
+	case SwizzlingExpr:
+		return e.Swizzling
+	case FunctionExpr:
+		return fmt.Sprintf("f%d", e.Index)
+	case Unary:
+		return fmt.Sprintf("%s(%s)", opString(e.Op), exprString(&e.Exprs[0]))
+	case Binary:
+		// Distinguish a matrix multiplication from a component-wise multiplication.
+		op := opString(e.Op)
+		if e.Op == MatrixMul {
+			op = "*m"
+		}
+		return fmt.Sprintf("(%s %s %s)", exprString(&e.Exprs[0]), op, exprString(&e.Exprs[1]))
+	case Selection:
+		return fmt.Sprintf("(%s ? %s : %s)", exprString(&e.Exprs[0]), exprString(&e.Exprs[1]), exprString(&e.Exprs[2]))
+	case Call:
+		args := make([]string, 0, len(e.Exprs)-1)
+		for i := range e.Exprs[1:] {
+			args = append(args, exprString(&e.Exprs[i+1]))
+		}
+		return fmt.Sprintf("%s(%s)", exprString(&e.Exprs[0]), strings.Join(args, ", "))
+	case FieldSelector:
+		return fmt.Sprintf("%s.%s", exprString(&e.Exprs[0]), exprString(&e.Exprs[1]))
+	case Index:
+		return fmt.Sprintf("%s[%s]", exprString(&e.Exprs[0]), exprString(&e.Exprs[1]))
+	}
+	return fmt.Sprintf("?(unexpected expr: %d)", e.Type)
+}
@@ -20,6 +20,7 @@ import (
 	"go/token"
 	"math"
 	"regexp"
+	"slices"
 	"strings"
 
 	"github.com/duplicants-ai/ebiten/internal/shaderir"
@@ -95,6 +96,14 @@ out vec4 fragColor;`
 	return prelude
 }
 
+// FragmentColorName returns the name of the fragment shader's output for the index-th color
+// when the program has multiple colors (Program.FragmentOutputCount is not 0).
+//
+// With GLSLVersionDefault, the output's location must be bound to index by glBindFragDataLocation.
+func FragmentColorName(index int) string {
+	return fmt.Sprintf("fragColor%d", index)
+}
+
 type compileContext struct {
 	version     GLSLVersion
 	structNames map[string]string
@@ -117,6 +126,13 @@ func (c *compileContext) structName(p *shaderir.Program, t *shaderir.Type) strin
 }
 
 func Compile(p *shaderir.Program, version GLSLVersion) (vertexShader, fragmentShader string) {
+	vertexShader, fragmentShader, _, _ = CompileWithSourceMaps(p, version)
+	return
+}
+
+// CompileWithSourceMaps is the same as Compile, but also returns the source maps of the shaders.
+// The source maps are used to map a GLSL compiler's error to the Kage source.
+func CompileWithSourceMaps(p *shaderir.Program, version GLSLVersion) (vertexShader, fragmentShader string, vertexSourceMap, fragmentSourceMap *shaderir.SourceMap) {
 	p = adjustProgram(p)
 
 	c := &compileContext{
@@ -130,11 +146,14 @@ func Compile(p *shaderir.Program, version GLSLVersion) (vertexShader, fragmentSh
 	{
 		vslines = append(vslines, strings.Split(VertexPrelude(version), "\n")...)
 		vslines = append(vslines, "", "{{.Structs}}")
-		if len(p.Uniforms) > 0 || p.TextureCount > 0 || len(p.Attributes) > 0 || len(p.Varyings) > 0 {
+		if len(p.Uniforms) > 0 || len(p.ConstArrays) > 0 || p.TextureCount > 0 || len(p.Attributes) > 0 || len(p.Varyings) > 0 {
 			vslines = append(vslines, "")
 			for i, t := range p.Uniforms {
 				vslines = append(vslines, fmt.Sprintf("uniform %s;", c.varDecl(p, &t, fmt.Sprintf("U%d", i))))
 			}
+			for i := range p.ConstArrays {
+				vslines = append(vslines, c.constArrayDecl(p, i))
+			}
 			for i := 0; i < p.TextureCount; i++ {
 				vslines = append(vslines, fmt.Sprintf("uniform sampler2D T%d;", i))
 			}
@@ -218,12 +237,29 @@ func Compile(p *shaderir.Program, version GLSLVersion) (vertexShader, fragmentSh
 	var fslines []string
 	{
 		fslines = append(fslines, strings.Split(FragmentPrelude(version), "\n")...)
+		if p.FragmentOutputCount > 0 {
+			// Replace the output for one color with the outputs for multiple render targets.
+			idx := slices.Index(fslines, "out vec4 fragColor;")
+			var outs []string
+			for i := 0; i < p.FragmentOutputCount; i++ {
+				if version == GLSLVersionES300 {
+					outs = append(outs, fmt.Sprintf("layout(location = %d) out vec4 %s;", i, FragmentColorName(i)))
+				} else {
+					// The locations are specified by glBindFragDataLocation.
+					outs = append(outs, fmt.Sprintf("out vec4 %s;", FragmentColorName(i)))
+				}
+			}
+			fslines = slices.Replace(fslines, idx, idx+1, outs...)
+		}
 		fslines = append(fslines, "", "{{.Structs}}")
-		if len(p.Uniforms) > 0 || p.TextureCount > 0 || len(p.Varyings) > 0 {
+		if len(p.Uniforms) > 0 || len(p.ConstArrays) > 0 || p.TextureCount > 0 || len(p.Varyings) > 0 {
 			fslines = append(fslines, "")
 			for i, t := range p.Uniforms {
 				fslines = append(fslines, fmt.Sprintf("uniform %s;", c.varDecl(p, &t, fmt.Sprintf("U%d", i))))
 			}
+			for i := range p.ConstArrays {
+				fslines = append(fslines, c.constArrayDecl(p, i))
+			}
 			for i := 0; i < p.TextureCount; i++ {
 				fslines = append(fslines, fmt.Sprintf("uniform sampler2D T%d;", i))
 			}
@@ -292,7 +328,10 @@ func Compile(p *shaderir.Program, version GLSLVersion) (vertexShader, fragmentSh
 	vs = strings.TrimSpace(vs) + "\n"
 	fs = strings.TrimSpace(fs) + "\n"
 
-	return vs, fs
+	vs, vertexSourceMap = shaderir.ExtractSourceMap(vs)
+	fs, fragmentSourceMap = shaderir.ExtractSourceMap(fs)
+
+	return vs, fs, vertexSourceMap, fragmentSourceMap
 }
 
 func (c *compileContext) typ(p *shaderir.Program, t *shaderir.Type) (string, string) {
@@ -346,6 +385,32 @@ func (c *compileContext) varInit(p *shaderir.Program, t *shaderir.Type) string {
 	}
 }
 
+func (c *compileContext) constArrayDecl(p *shaderir.Program, index int) string {
+	a := &p.ConstArrays[index]
+	es := make([]string, 0, len(a.Exprs))
+	for i := range a.Exprs {
+		es = append(es, c.constExpr(&a.Exprs[i]))
+	}
+	t0, t1 := typeString(&a.Type)
+	return fmt.Sprintf("const %s C%d%s = %s%s(%s);", t0, index, t1, t0, t1, strings.Join(es, ", "))
+}
+
+// constExpr returns a string for a constant expression, which is a number or a constructor call with numbers.
+func (c *compileContext) constExpr(e *shaderir.Expr) string {
+	switch e.Type {
+	case shaderir.NumberExpr:
+		return constantToNumberLiteral(e.Const)
+	case shaderir.Call:
+		args := make([]string, 0, len(e.Exprs)-1)
+		for i := range e.Exprs[1:] {
+			args = append(args, c.constExpr(&e.Exprs[i+1]))
+		}
+		return fmt.Sprintf("%s(%s)", c.builtinFuncString(e.Exprs[0].BuiltinFunc), strings.Join(args, ", "))
+	default:
+		return fmt.Sprintf("?(unexpected constant expr: %d)", e.Type)
+	}
+}
+
 func (c *compileContext) function(p *shaderir.Program, f *shaderir.Func, prototype bool) []string {
 	var args []string
 	var idx int
@@ -414,13 +479,16 @@ func (c *compileContext) localVariableName(p *shaderir.Program, topBlock *shader
 		}
 	case p.FragmentFunc.Block:
 		nv := len(p.Varyings)
+		no := p.FragmentOutputCount
 		switch {
 		case idx == 0:
 			return "gl_FragCoord"
 		case idx < nv+1:
 			return fmt.Sprintf("V%d", idx-1)
+		case idx < nv+no+1:
+			return FragmentColorName(idx - nv - 1)
 		default:
-			return fmt.Sprintf("l%d", idx-(nv+1))
+			return fmt.Sprintf("l%d", idx-(nv+no+1))
 		}
 	default:
 		return fmt.Sprintf("l%d", idx)
@@ -471,6 +539,8 @@ func (c *compileContext) block(p *shaderir.Program, topBlock, block *shaderir.Bl
 			return constantToNumberLiteral(e.Const)
 		case shaderir.UniformVariable:
 			return fmt.Sprintf("U%d", e.Index)
+		case shaderir.ConstArrayVariable:
+			return fmt.Sprintf("C%d", e.Index)
 		case shaderir.TextureVariable:
 			return fmt.Sprintf("T%d", e.Index)
 		case shaderir.LocalVariable:
@@ -525,6 +595,9 @@ func (c *compileContext) block(p *shaderir.Program, topBlock, block *shaderir.Bl
 
 	idt := strings.Repeat("\t", level+1)
 	for _, s := range block.Stmts {
+		if s.SourcePos.IsValid() {
+			lines = append(lines, shaderir.SourceMarker(s.SourcePos))
+		}
 		switch s.Type {
 		case shaderir.ExprStmt:
 			lines = append(lines, fmt.Sprintf("%s%s;", idt, expr(&s.Exprs[0])))
@@ -582,12 +655,35 @@ func (c *compileContext) block(p *shaderir.Program, topBlock, block *shaderir.Bl
 			}
 
 			t := s.ForVarType
-			init := constantToNumberLiteral(s.ForInit)
-			end := constantToNumberLiteral(s.ForEnd)
+			var init, end string
+			if s.ForInit != nil && s.ForEnd != nil {
+				init = constantToNumberLiteral(s.ForInit)
+				end = constantToNumberLiteral(s.ForEnd)
+			} else {
+				init = expr(&s.Exprs[0])
+				end = expr(&s.Exprs[1])
+			}
 			t0, t1 := typeString(&t)
 			lines = append(lines, fmt.Sprintf("%sfor (%s %s%s = %s; %s %s %s; %s) {", idt, t0, v, t1, init, v, op, end, delta))
 			lines = append(lines, c.block(p, topBlock, s.Blocks[0], level+1)...)
 			lines = append(lines, fmt.Sprintf("%s}", idt))
+		case shaderir.Switch:
+			lines = append(lines, fmt.Sprintf("%sswitch (%s) {", idt, expr(&s.Exprs[0])))
+			for i, b := range s.Blocks {
+				if s.SwitchCases[i] == nil {
+					lines = append(lines, fmt.Sprintf("%sdefault:", idt))
+				}
+				for _, v := range s.SwitchCases[i] {
+					lines = append(lines, fmt.Sprintf("%scase %s:", idt, constantToNumberLiteral(v)))
+				}
+				lines = append(lines, fmt.Sprintf("%s\t{", idt))
+				lines = append(lines, c.block(p, topBlock, b, level+2)...)
+				lines = append(lines, fmt.Sprintf("%s\t}", idt))
+				if !b.EndsWithJump() {
+					lines = append(lines, fmt.Sprintf("%s\tbreak;", idt))
+				}
+			}
+			lines = append(lines, fmt.Sprintf("%s}", idt))
 		case shaderir.Continue:
 			lines = append(lines, idt+"continue;")
 		case shaderir.Break:
@@ -604,7 +700,11 @@ func (c *compileContext) block(p *shaderir.Program, topBlock, block *shaderir.Bl
 			}
 		case shaderir.Discard:
 			// 'discard' is invoked only in the fragment shader entry point.
-			lines = append(lines, idt+"discard;", idt+"return vec4(0.0);")
+			if p.FragmentOutputCount > 0 {
+				lines = append(lines, idt+"discard;", idt+"return;")
+			} else {
+				lines = append(lines, idt+"discard;", idt+"return vec4(0.0);")
+			}
 		default:
 			lines = append(lines, fmt.Sprintf("%s?(unexpected stmt: %d)", idt, s.Type))
 		}
@@ -646,6 +746,57 @@ func adjustProgram(p *shaderir.Program) *shaderir.Program {
 	}
 	copy(inParams[1:], newP.Varyings)
 
+	if p.FragmentOutputCount > 0 {
+		// The colors for multiple render targets are out-params.
+		outParams := make([]shaderir.Type, p.FragmentOutputCount)
+		for i := range outParams {
+			outParams[i] = shaderir.Type{
+				Main: shaderir.Vec4,
+			}
+		}
+
+		newP.Funcs = append(newP.Funcs, shaderir.Func{
+			Index:     funcIdx,
+			InParams:  inParams,
+			OutParams: outParams,
+			Block:     newP.FragmentFunc.Block,
+		})
+
+		// Create an AST to call the new function with the outputs.
+		call := []shaderir.Expr{
+			{
+				Type:  shaderir.FunctionExpr,
+				Index: funcIdx,
+			},
+		}
+		for i := 0; i < 1+len(newP.Varyings)+p.FragmentOutputCount; i++ {
+			call = append(call, shaderir.Expr{
+				Type:  shaderir.LocalVariable,
+				Index: i,
+			})
+		}
+
+		newP.FragmentFunc = shaderir.FragmentFunc{
+			Block: &shaderir.Block{
+				LocalVars:           nil,
+				LocalVarIndexOffset: 1 + len(newP.Varyings) + p.FragmentOutputCount,
+				Stmts: []shaderir.Stmt{
+					{
+						Type: shaderir.ExprStmt,
+						Exprs: []shaderir.Expr{
+							{
+								Type:  shaderir.Call,
+								Exprs: call,
+							},
+						},
+					},
+				},
+			},
+		}
+
+		return &newP
+	}
+
 	newP.Funcs = append(newP.Funcs, shaderir.Func{
 		Index:     funcIdx,
 		InParams:  inParams,
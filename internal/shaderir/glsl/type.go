@@ -126,6 +126,8 @@ func (c *compileContext) builtinFuncString(f shaderir.BuiltinFunc) string {
 			return "texelFetch"
 		}
 		return "texture"
+	case shaderir.TexelFetch:
+		return "texelFetch"
 	default:
 		return string(f)
 	}
@@ -27,6 +27,7 @@ import (
 
 const (
 	vsOut = "varyings"
+	psOut = "colors"
 )
 
 type compileContext struct {
@@ -81,6 +82,13 @@ float4x4 float4x4FromScalar(float x) {
 }`
 
 func Compile(p *shaderir.Program) (vertexShader, pixelShader, prelude string) {
+	vertexShader, pixelShader, prelude, _, _ = CompileWithSourceMaps(p)
+	return
+}
+
+// CompileWithSourceMaps is the same as Compile, but also returns the source maps of the shaders.
+// The source maps are used to map an HLSL compiler's error to the Kage source.
+func CompileWithSourceMaps(p *shaderir.Program) (vertexShader, pixelShader, prelude string, vertexSourceMap, pixelSourceMap *shaderir.SourceMap) {
 	offsets := UniformVariableOffsetsInDwords(p)
 
 	c := &compileContext{
@@ -133,6 +141,13 @@ func Compile(p *shaderir.Program) (vertexShader, pixelShader, prelude string) {
 		lines = append(lines, "}")
 	}
 
+	if len(p.ConstArrays) > 0 {
+		lines = append(lines, "")
+		for i := range p.ConstArrays {
+			lines = append(lines, c.constArrayDecl(p, i))
+		}
+	}
+
 	if p.TextureCount > 0 {
 		lines = append(lines, "")
 		for i := 0; i < p.TextureCount; i++ {
@@ -225,9 +240,25 @@ func Compile(p *shaderir.Program) (vertexShader, pixelShader, prelude string) {
 	}
 	if p.FragmentFunc.Block != nil && len(p.FragmentFunc.Block.Stmts) > 0 {
 		pslines = append(pslines, "")
-		pslines = append(pslines, fmt.Sprintf("float4 PSMain(Varyings %s) : SV_TARGET {", vsOut))
-		pslines = append(pslines, c.block(p, p.FragmentFunc.Block, p.FragmentFunc.Block, 0)...)
-		pslines = append(pslines, "}")
+		if p.FragmentOutputCount > 0 {
+			// Output the colors to multiple render targets.
+			pslines = append(pslines, "struct Colors {")
+			for i := 0; i < p.FragmentOutputCount; i++ {
+				pslines = append(pslines, fmt.Sprintf("\tfloat4 M%[1]d : SV_TARGET%[1]d;", i))
+			}
+			pslines = append(pslines, "};", "")
+			pslines = append(pslines, fmt.Sprintf("Colors PSMain(Varyings %s) {", vsOut))
+			pslines = append(pslines, fmt.Sprintf("\tColors %s = (Colors)0;", psOut))
+			pslines = append(pslines, c.block(p, p.FragmentFunc.Block, p.FragmentFunc.Block, 0)...)
+			if last := fmt.Sprintf("\treturn %s;", psOut); pslines[len(pslines)-1] != last {
+				pslines = append(pslines, last)
+			}
+			pslines = append(pslines, "}")
+		} else {
+			pslines = append(pslines, fmt.Sprintf("float4 PSMain(Varyings %s) : SV_TARGET {", vsOut))
+			pslines = append(pslines, c.block(p, p.FragmentFunc.Block, p.FragmentFunc.Block, 0)...)
+			pslines = append(pslines, "}")
+		}
 	}
 
 	vertexShader = strings.Join(vslines, "\n")
@@ -259,8 +290,8 @@ func Compile(p *shaderir.Program) (vertexShader, pixelShader, prelude string) {
 		shaders[i] = shader
 	}
 
-	vertexShader = shaders[0]
-	pixelShader = shaders[1]
+	vertexShader, vertexSourceMap = shaderir.ExtractSourceMap(shaders[0])
+	pixelShader, pixelSourceMap = shaderir.ExtractSourceMap(shaders[1])
 
 	return
 }
@@ -288,6 +319,36 @@ func (c *compileContext) varDecl(p *shaderir.Program, t *shaderir.Type, varname
 	}
 }
 
+func (c *compileContext) constArrayDecl(p *shaderir.Program, index int) string {
+	a := &p.ConstArrays[index]
+	es := make([]string, 0, len(a.Exprs))
+	for i := range a.Exprs {
+		es = append(es, c.constExpr(&a.Exprs[i]))
+	}
+	return fmt.Sprintf("static const %s = {%s};", c.varDecl(p, &a.Type, fmt.Sprintf("C%d", index)), strings.Join(es, ", "))
+}
+
+// constExpr returns a string for a constant expression, which is a number or a constructor call with numbers.
+func (c *compileContext) constExpr(e *shaderir.Expr) string {
+	switch e.Type {
+	case shaderir.NumberExpr:
+		return constantToNumberLiteral(e.Const)
+	case shaderir.Call:
+		args := make([]string, 0, len(e.Exprs)-1)
+		for i := range e.Exprs[1:] {
+			args = append(args, c.constExpr(&e.Exprs[i+1]))
+		}
+		f := c.builtinFuncString(e.Exprs[0].BuiltinFunc)
+		if len(args) == 1 {
+			// Use casting. For example, `float4(1)` doesn't work.
+			return fmt.Sprintf("(%s)(%s)", f, args[0])
+		}
+		return fmt.Sprintf("%s(%s)", f, strings.Join(args, ", "))
+	default:
+		return fmt.Sprintf("?(unexpected constant expr: %d)", e.Type)
+	}
+}
+
 func (c *compileContext) varInit(p *shaderir.Program, t *shaderir.Type) string {
 	switch t.Main {
 	case shaderir.None:
@@ -382,13 +443,16 @@ func (c *compileContext) localVariableName(p *shaderir.Program, topBlock *shader
 		}
 	case p.FragmentFunc.Block:
 		nv := len(p.Varyings)
+		no := p.FragmentOutputCount
 		switch {
 		case idx == 0:
 			return fmt.Sprintf("%s.Position", vsOut)
 		case idx < nv+1:
 			return fmt.Sprintf("%s.M%d", vsOut, idx-1)
+		case idx < nv+no+1:
+			return fmt.Sprintf("%s.M%d", psOut, idx-nv-1)
 		default:
-			return fmt.Sprintf("l%d", idx-(nv+1))
+			return fmt.Sprintf("l%d", idx-(nv+no+1))
 		}
 	default:
 		return fmt.Sprintf("l%d", idx)
@@ -437,6 +501,8 @@ func (c *compileContext) block(p *shaderir.Program, topBlock, block *shaderir.Bl
 		switch e.Type {
 		case shaderir.NumberExpr:
 			return constantToNumberLiteral(e.Const)
+		case shaderir.ConstArrayVariable:
+			return fmt.Sprintf("C%d", e.Index)
 		case shaderir.UniformVariable:
 			return fmt.Sprintf("U%d", e.Index)
 		case shaderir.TextureVariable:
@@ -513,6 +579,8 @@ func (c *compileContext) block(p *shaderir.Program, topBlock, block *shaderir.Bl
 					default:
 						panic(fmt.Sprintf("hlsl: unexpected unit: %d", p.Unit))
 					}
+				case shaderir.TexelFetch:
+					return fmt.Sprintf("%s.Load(int3(%s, 0))", args[0], args[1])
 				}
 			}
 			return fmt.Sprintf("%s(%s)", expr(&e.Exprs[0]), strings.Join(args, ", "))
@@ -527,6 +595,9 @@ func (c *compileContext) block(p *shaderir.Program, topBlock, block *shaderir.Bl
 
 	idt := strings.Repeat("\t", level+1)
 	for _, s := range block.Stmts {
+		if s.SourcePos.IsValid() {
+			lines = append(lines, shaderir.SourceMarker(s.SourcePos))
+		}
 		switch s.Type {
 		case shaderir.ExprStmt:
 			lines = append(lines, fmt.Sprintf("%s%s;", idt, expr(&s.Exprs[0])))
@@ -584,12 +655,39 @@ func (c *compileContext) block(p *shaderir.Program, topBlock, block *shaderir.Bl
 			}
 
 			t := s.ForVarType
-			init := constantToNumberLiteral(s.ForInit)
-			end := constantToNumberLiteral(s.ForEnd)
+			var init, end string
+			if s.ForInit != nil && s.ForEnd != nil {
+				init = constantToNumberLiteral(s.ForInit)
+				end = constantToNumberLiteral(s.ForEnd)
+			} else {
+				init = expr(&s.Exprs[0])
+				end = expr(&s.Exprs[1])
+			}
 			t0, t1 := typeString(&t)
+			if s.ForMaxIterations > 0 {
+				// A loop with non-constant bounds cannot have gradient operations like Sample unless the loop is unrolled.
+				lines = append(lines, fmt.Sprintf("%s[unroll(%d)]", idt, s.ForMaxIterations))
+			}
 			lines = append(lines, fmt.Sprintf("%sfor (%s %s%s = %s; %s %s %s; %s) {", idt, t0, v, t1, init, v, op, end, delta))
 			lines = append(lines, c.block(p, topBlock, s.Blocks[0], level+1)...)
 			lines = append(lines, fmt.Sprintf("%s}", idt))
+		case shaderir.Switch:
+			lines = append(lines, fmt.Sprintf("%sswitch (%s) {", idt, expr(&s.Exprs[0])))
+			for i, b := range s.Blocks {
+				if s.SwitchCases[i] == nil {
+					lines = append(lines, fmt.Sprintf("%sdefault:", idt))
+				}
+				for _, v := range s.SwitchCases[i] {
+					lines = append(lines, fmt.Sprintf("%scase %s:", idt, constantToNumberLiteral(v)))
+				}
+				lines = append(lines, fmt.Sprintf("%s\t{", idt))
+				lines = append(lines, c.block(p, topBlock, b, level+2)...)
+				lines = append(lines, fmt.Sprintf("%s\t}", idt))
+				if !b.EndsWithJump() {
+					lines = append(lines, fmt.Sprintf("%s\tbreak;", idt))
+				}
+			}
+			lines = append(lines, fmt.Sprintf("%s}", idt))
 		case shaderir.Continue:
 			lines = append(lines, idt+"continue;")
 		case shaderir.Break:
@@ -598,6 +696,8 @@ func (c *compileContext) block(p *shaderir.Program, topBlock, block *shaderir.Bl
 			switch {
 			case topBlock == p.VertexFunc.Block:
 				lines = append(lines, fmt.Sprintf("%sreturn %s;", idt, vsOut))
+			case topBlock == p.FragmentFunc.Block && p.FragmentOutputCount > 0:
+				lines = append(lines, fmt.Sprintf("%sreturn %s;", idt, psOut))
 			case len(s.Exprs) == 0:
 				lines = append(lines, idt+"return;")
 			default:
@@ -605,7 +705,11 @@ func (c *compileContext) block(p *shaderir.Program, topBlock, block *shaderir.Bl
 			}
 		case shaderir.Discard:
 			// 'discard' is invoked only in the fragment shader entry point.
-			lines = append(lines, idt+"discard;", idt+"return float4(0.0, 0.0, 0.0, 0.0);")
+			if p.FragmentOutputCount > 0 {
+				lines = append(lines, idt+"discard;", fmt.Sprintf("%sreturn %s;", idt, psOut))
+			} else {
+				lines = append(lines, idt+"discard;", idt+"return float4(0.0, 0.0, 0.0, 0.0);")
+			}
 		default:
 			lines = append(lines, fmt.Sprintf("%s?(unexpected stmt: %d)", idt, s.Type))
 		}
@@ -145,6 +145,8 @@ func (c *compileContext) builtinFuncString(f shaderir.BuiltinFunc) string {
 		return "ddy"
 	case shaderir.TexelAt:
 		return "?(__texelAt)"
+	case shaderir.TexelFetch:
+		return "?(__texelFetch)"
 	default:
 		return string(f)
 	}
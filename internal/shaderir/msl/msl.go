@@ -26,7 +26,8 @@ import (
 )
 
 const (
-	vertexOut = "varyings"
+	vertexOut   = "varyings"
+	fragmentOut = "colors"
 )
 
 type compileContext struct {
@@ -71,6 +72,13 @@ const (
 )
 
 func Compile(p *shaderir.Program) (shader string) {
+	shader, _ = CompileWithSourceMap(p)
+	return
+}
+
+// CompileWithSourceMap is the same as Compile, but also returns the source map of the shader.
+// The source map is used to map a Metal compiler's error to the Kage source.
+func CompileWithSourceMap(p *shaderir.Program) (shader string, sourceMap *shaderir.SourceMap) {
 	c := &compileContext{
 		structNames: map[string]string{},
 	}
@@ -88,6 +96,13 @@ func Compile(p *shaderir.Program) (shader string) {
 		lines = append(lines, "};")
 	}
 
+	if len(p.ConstArrays) > 0 {
+		lines = append(lines, "")
+		for i := range p.ConstArrays {
+			lines = append(lines, c.constArrayDecl(p, i))
+		}
+	}
+
 	if len(p.Attributes) > 0 {
 		lines = append(lines, "")
 		lines = append(lines, "struct Attributes {")
@@ -144,9 +159,19 @@ func Compile(p *shaderir.Program) (shader string) {
 	}
 
 	if p.FragmentFunc.Block != nil && len(p.FragmentFunc.Block.Stmts) > 0 {
+		retType := "float4"
+		if p.FragmentOutputCount > 0 {
+			// Output the colors to multiple render targets.
+			lines = append(lines, "", "struct Colors {")
+			for i := 0; i < p.FragmentOutputCount; i++ {
+				lines = append(lines, fmt.Sprintf("\tfloat4 M%[1]d [[color(%[1]d)]];", i))
+			}
+			lines = append(lines, "};")
+			retType = "Colors"
+		}
 		lines = append(lines, "")
 		lines = append(lines,
-			fmt.Sprintf("fragment float4 %s(", FragmentName),
+			fmt.Sprintf("fragment %s %s(", retType, FragmentName),
 			"\tVaryings varyings [[stage_in]]")
 		if len(p.Uniforms) > 0 {
 			lines[len(lines)-1] += ","
@@ -157,7 +182,15 @@ func Compile(p *shaderir.Program) (shader string) {
 			lines = append(lines, fmt.Sprintf("\ttexture2d<float> T%[1]d [[texture(%[1]d)]]", i))
 		}
 		lines[len(lines)-1] += ") {"
+		if p.FragmentOutputCount > 0 {
+			lines = append(lines, fmt.Sprintf("\tColors %s = {};", fragmentOut))
+		}
 		lines = append(lines, c.block(p, p.FragmentFunc.Block, p.FragmentFunc.Block, 0)...)
+		if p.FragmentOutputCount > 0 {
+			if last := fmt.Sprintf("\treturn %s;", fragmentOut); lines[len(lines)-1] != last {
+				lines = append(lines, last)
+			}
+		}
 		lines = append(lines, "}")
 	}
 
@@ -182,7 +215,7 @@ func Compile(p *shaderir.Program) (shader string) {
 	ls = nls.ReplaceAllString(ls, "\n\n")
 	ls = strings.TrimSpace(ls) + "\n"
 
-	return ls
+	return shaderir.ExtractSourceMap(ls)
 }
 
 func (c *compileContext) typ(p *shaderir.Program, t *shaderir.Type) string {
@@ -196,6 +229,31 @@ func (c *compileContext) typ(p *shaderir.Program, t *shaderir.Type) string {
 	}
 }
 
+func (c *compileContext) constArrayDecl(p *shaderir.Program, index int) string {
+	a := &p.ConstArrays[index]
+	es := make([]string, 0, len(a.Exprs))
+	for i := range a.Exprs {
+		es = append(es, c.constExpr(&a.Exprs[i]))
+	}
+	return fmt.Sprintf("constant %s = {%s};", c.varDecl(p, &a.Type, fmt.Sprintf("C%d", index), false), strings.Join(es, ", "))
+}
+
+// constExpr returns a string for a constant expression, which is a number or a constructor call with numbers.
+func (c *compileContext) constExpr(e *shaderir.Expr) string {
+	switch e.Type {
+	case shaderir.NumberExpr:
+		return constantToNumberLiteral(e.Const)
+	case shaderir.Call:
+		args := make([]string, 0, len(e.Exprs)-1)
+		for i := range e.Exprs[1:] {
+			args = append(args, c.constExpr(&e.Exprs[i+1]))
+		}
+		return fmt.Sprintf("%s(%s)", builtinFuncString(e.Exprs[0].BuiltinFunc), strings.Join(args, ", "))
+	default:
+		return fmt.Sprintf("?(unexpected constant expr: %d)", e.Type)
+	}
+}
+
 func (c *compileContext) varDecl(p *shaderir.Program, t *shaderir.Type, varname string, ref bool) string {
 	switch t.Main {
 	case shaderir.None:
@@ -311,13 +369,16 @@ func localVariableName(p *shaderir.Program, topBlock *shaderir.Block, idx int) s
 		}
 	case p.FragmentFunc.Block:
 		nv := len(p.Varyings)
+		no := p.FragmentOutputCount
 		switch {
 		case idx == 0:
 			return fmt.Sprintf("%s.Position", vertexOut)
 		case idx < nv+1:
 			return fmt.Sprintf("%s.M%d", vertexOut, idx-1)
+		case idx < nv+no+1:
+			return fmt.Sprintf("%s.M%d", fragmentOut, idx-nv-1)
 		default:
-			return fmt.Sprintf("l%d", idx-(nv+1))
+			return fmt.Sprintf("l%d", idx-(nv+no+1))
 		}
 	default:
 		return fmt.Sprintf("l%d", idx)
@@ -360,6 +421,8 @@ func (c *compileContext) block(p *shaderir.Program, topBlock, block *shaderir.Bl
 			return constantToNumberLiteral(e.Const)
 		case shaderir.UniformVariable:
 			return fmt.Sprintf("uniforms.U%d", e.Index)
+		case shaderir.ConstArrayVariable:
+			return fmt.Sprintf("C%d", e.Index)
 		case shaderir.TextureVariable:
 			return fmt.Sprintf("T%d", e.Index)
 		case shaderir.LocalVariable:
@@ -418,6 +481,9 @@ func (c *compileContext) block(p *shaderir.Program, topBlock, block *shaderir.Bl
 					panic(fmt.Sprintf("msl: unexpected unit: %d", p.Unit))
 				}
 			}
+			if callee.Type == shaderir.BuiltinFuncExpr && callee.BuiltinFunc == shaderir.TexelFetch {
+				return fmt.Sprintf("%s.read(static_cast<uint2>(%s))", args[0], args[1])
+			}
 			return fmt.Sprintf("%s(%s)", expr(&callee), strings.Join(args, ", "))
 		case shaderir.FieldSelector:
 			return fmt.Sprintf("(%s).%s", expr(&e.Exprs[0]), expr(&e.Exprs[1]))
@@ -429,6 +495,9 @@ func (c *compileContext) block(p *shaderir.Program, topBlock, block *shaderir.Bl
 	}
 
 	for _, s := range block.Stmts {
+		if s.SourcePos.IsValid() {
+			lines = append(lines, shaderir.SourceMarker(s.SourcePos))
+		}
 		switch s.Type {
 		case shaderir.ExprStmt:
 			lines = append(lines, fmt.Sprintf("%s%s;", idt, expr(&s.Exprs[0])))
@@ -488,12 +557,35 @@ func (c *compileContext) block(p *shaderir.Program, topBlock, block *shaderir.Bl
 			}
 
 			t := s.ForVarType
-			init := constantToNumberLiteral(s.ForInit)
-			end := constantToNumberLiteral(s.ForEnd)
+			var init, end string
+			if s.ForInit != nil && s.ForEnd != nil {
+				init = constantToNumberLiteral(s.ForInit)
+				end = constantToNumberLiteral(s.ForEnd)
+			} else {
+				init = expr(&s.Exprs[0])
+				end = expr(&s.Exprs[1])
+			}
 			ts := typeString(&t, false)
 			lines = append(lines, fmt.Sprintf("%sfor (%s %s = %s; %s %s %s; %s) {", idt, ts, v, init, v, op, end, delta))
 			lines = append(lines, c.block(p, topBlock, s.Blocks[0], level+1)...)
 			lines = append(lines, fmt.Sprintf("%s}", idt))
+		case shaderir.Switch:
+			lines = append(lines, fmt.Sprintf("%sswitch (%s) {", idt, expr(&s.Exprs[0])))
+			for i, b := range s.Blocks {
+				if s.SwitchCases[i] == nil {
+					lines = append(lines, fmt.Sprintf("%sdefault:", idt))
+				}
+				for _, v := range s.SwitchCases[i] {
+					lines = append(lines, fmt.Sprintf("%scase %s:", idt, constantToNumberLiteral(v)))
+				}
+				lines = append(lines, fmt.Sprintf("%s\t{", idt))
+				lines = append(lines, c.block(p, topBlock, b, level+2)...)
+				lines = append(lines, fmt.Sprintf("%s\t}", idt))
+				if !b.EndsWithJump() {
+					lines = append(lines, fmt.Sprintf("%s\tbreak;", idt))
+				}
+			}
+			lines = append(lines, fmt.Sprintf("%s}", idt))
 		case shaderir.Continue:
 			lines = append(lines, idt+"continue;")
 		case shaderir.Break:
@@ -502,6 +594,8 @@ func (c *compileContext) block(p *shaderir.Program, topBlock, block *shaderir.Bl
 			switch {
 			case topBlock == p.VertexFunc.Block:
 				lines = append(lines, fmt.Sprintf("%sreturn %s;", idt, vertexOut))
+			case topBlock == p.FragmentFunc.Block && p.FragmentOutputCount > 0:
+				lines = append(lines, fmt.Sprintf("%sreturn %s;", idt, fragmentOut))
 			case len(s.Exprs) == 0:
 				lines = append(lines, idt+"return;")
 			default:
@@ -509,7 +603,11 @@ func (c *compileContext) block(p *shaderir.Program, topBlock, block *shaderir.Bl
 			}
 		case shaderir.Discard:
 			// 'discard' is invoked only in the fragment shader entry point.
-			lines = append(lines, idt+"discard_fragment();", idt+"return float4(0.0);")
+			if p.FragmentOutputCount > 0 {
+				lines = append(lines, idt+"discard_fragment();", fmt.Sprintf("%sreturn %s;", idt, fragmentOut))
+			} else {
+				lines = append(lines, idt+"discard_fragment();", idt+"return float4(0.0);")
+			}
 		default:
 			lines = append(lines, fmt.Sprintf("%s?(unexpected stmt: %d)", idt, s.Type))
 		}
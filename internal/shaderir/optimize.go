@@ -0,0 +1,1017 @@
+// Copyright 2026 The Ebitengine Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package shaderir
+
+import (
+	"go/constant"
+	"go/token"
+	"math"
+)
+
+const (
+	// maxOptimizationPasses is the maximum number of iterations of the optimization passes except for CSE.
+	maxOptimizationPasses = 8
+
+	// maxUnrolledIterations is the maximum number of iterations of a for-loop to be unrolled.
+	maxUnrolledIterations = 16
+
+	// maxUnrolledStmts is the maximum number of statements of an unrolled for-loop.
+	maxUnrolledStmts = 256
+)
+
+// Optimize returns an optimized copy of the program. Optimize doesn't modify p.
+//
+// Optimize applies these passes:
+//
+//   - Loop unrolling for loops with small constant bounds
+//   - Constant folding
+//   - Dead-code elimination
+//   - Common subexpression elimination in each statement
+//
+// The uniform variables, the attributes, the varyings, and the signatures of the functions are kept.
+func (p *Program) Optimize() *Program {
+	newP := *p
+	newP.uniformFactors = nil
+	newP.Funcs = make([]Func, len(p.Funcs))
+	for i, f := range p.Funcs {
+		f.Block = cloneBlock(f.Block)
+		newP.Funcs[i] = f
+	}
+	newP.VertexFunc.Block = cloneBlock(p.VertexFunc.Block)
+	newP.FragmentFunc.Block = cloneBlock(p.FragmentFunc.Block)
+
+	o := &optimizer{
+		p: &newP,
+	}
+	for i := range newP.Funcs {
+		o.optimizeTopBlock(newP.Funcs[i].Block)
+	}
+	o.optimizeTopBlock(newP.VertexFunc.Block)
+	o.optimizeTopBlock(newP.FragmentFunc.Block)
+	return &newP
+}
+
+func cloneBlock(block *Block) *Block {
+	if block == nil {
+		return nil
+	}
+	b := *block
+	b.LocalVars = append([]Type(nil), block.LocalVars...)
+	b.Stmts = make([]Stmt, len(block.Stmts))
+	for i, s := range block.Stmts {
+		s.Exprs = cloneExprs(s.Exprs)
+		if s.Blocks != nil {
+			bs := make([]*Block, len(s.Blocks))
+			for j, b := range s.Blocks {
+				bs[j] = cloneBlock(b)
+			}
+			s.Blocks = bs
+		}
+		b.Stmts[i] = s
+	}
+	return &b
+}
+
+func cloneExprs(exprs []Expr) []Expr {
+	if exprs == nil {
+		return nil
+	}
+	es := make([]Expr, len(exprs))
+	for i, e := range exprs {
+		e.Exprs = cloneExprs(e.Exprs)
+		es[i] = e
+	}
+	return es
+}
+
+// visitExprs calls f for each expression in the block and its descendant blocks in post-order.
+// f can modify the given expression.
+func visitExprs(block *Block, f func(expr *Expr)) {
+	for i := range block.Stmts {
+		s := &block.Stmts[i]
+		for j := range s.Exprs {
+			visitExpr(&s.Exprs[j], f)
+		}
+		for _, b := range s.Blocks {
+			visitExprs(b, f)
+		}
+	}
+}
+
+func visitExpr(expr *Expr, f func(expr *Expr)) {
+	for i := range expr.Exprs {
+		visitExpr(&expr.Exprs[i], f)
+	}
+	f(expr)
+}
+
+type optimizer struct {
+	p *Program
+}
+
+func (o *optimizer) optimizeTopBlock(block *Block) {
+	if block == nil {
+		return
+	}
+	for i := 0; i < maxOptimizationPasses; i++ {
+		var changed bool
+		if o.unrollLoops(block) {
+			changed = true
+		}
+		if o.foldConstants(block) {
+			changed = true
+		}
+		if o.eliminateDeadCode(block) {
+			changed = true
+		}
+		if !changed {
+			break
+		}
+	}
+	o.eliminateCommonSubexpressions(block, block)
+}
+
+// hasSideEffects reports whether evaluating expr might have side effects.
+func (o *optimizer) hasSideEffects(expr *Expr) bool {
+	var found bool
+	visitExpr(expr, func(e *Expr) {
+		switch e.Type {
+		case FunctionExpr:
+			// A function can affect only its out-params.
+			if f := o.function(e.Index); f == nil || len(f.OutParams) > 0 {
+				found = true
+			}
+		case BuiltinFuncExpr:
+			if e.BuiltinFunc == DiscardF {
+				found = true
+			}
+		}
+	})
+	return found
+}
+
+func (o *optimizer) function(index int) *Func {
+	for i := range o.p.Funcs {
+		if o.p.Funcs[i].Index == index {
+			return &o.p.Funcs[i]
+		}
+	}
+	return nil
+}
+
+func numberExpr(v constant.Value) Expr {
+	return Expr{
+		Type:  NumberExpr,
+		Const: v,
+	}
+}
+
+// Loop unrolling
+
+func (o *optimizer) unrollLoops(block *Block) bool {
+	var changed bool
+	stmts := make([]Stmt, 0, len(block.Stmts))
+	for _, s := range block.Stmts {
+		// Unroll inner loops first.
+		for _, b := range s.Blocks {
+			if o.unrollLoops(b) {
+				changed = true
+			}
+		}
+		if s.Type == For {
+			if ss, ok := o.unrollLoop(&s); ok {
+				stmts = append(stmts, ss...)
+				changed = true
+				continue
+			}
+		}
+		stmts = append(stmts, s)
+	}
+	block.Stmts = stmts
+	return changed
+}
+
+func (o *optimizer) unrollLoop(stmt *Stmt) ([]Stmt, bool) {
+	// Unroll only integer counters, as the number of iterations with a float counter might depend on the precision.
+	if stmt.ForVarType.Main != Int {
+		return nil, false
+	}
+	init, end := stmt.ForInit, stmt.ForEnd
+	if init == nil || end == nil {
+		// The bounds might have been folded into constants.
+		if stmt.Exprs[0].Type != NumberExpr || stmt.Exprs[1].Type != NumberExpr {
+			return nil, false
+		}
+		init, end = stmt.Exprs[0].Const, stmt.Exprs[1].Const
+	}
+	v := constant.ToInt(init)
+	end = constant.ToInt(end)
+	delta := constant.ToInt(stmt.ForDelta)
+	if v.Kind() != constant.Int || end.Kind() != constant.Int || delta.Kind() != constant.Int {
+		return nil, false
+	}
+	tok, ok := comparisonToken(stmt.ForOp)
+	if !ok {
+		return nil, false
+	}
+
+	var values []constant.Value
+	for constant.Compare(v, tok, end) {
+		if len(values) >= maxUnrolledIterations {
+			return nil, false
+		}
+		values = append(values, v)
+		v = constant.BinaryOp(v, token.ADD, delta)
+	}
+
+	body := stmt.Blocks[0]
+	if !o.canUnroll(body, stmt.ForVarIndex, true) {
+		return nil, false
+	}
+	if len(values)*countStmts(body) > maxUnrolledStmts {
+		return nil, false
+	}
+
+	ss := make([]Stmt, 0, len(values))
+	for _, v := range values {
+		b := cloneBlock(body)
+		visitExprs(b, func(e *Expr) {
+			if e.Type == LocalVariable && e.Index == stmt.ForVarIndex {
+				*e = numberExpr(v)
+			}
+		})
+		ss = append(ss, Stmt{
+			Type:      BlockStmt,
+			Blocks:    []*Block{b},
+			SourcePos: stmt.SourcePos,
+		})
+	}
+	return ss, true
+}
+
+// canUnroll reports whether a loop body can be unrolled.
+// The body must not have break or continue for the loop, and must not modify the counter.
+func (o *optimizer) canUnroll(block *Block, counter int, loopLevel bool) bool {
+	for _, s := range block.Stmts {
+		switch s.Type {
+		case Break, Continue:
+			if loopLevel {
+				return false
+			}
+		case Switch:
+			// break in a switch statement is for the switch statement, but continue is for the loop.
+			// For simplicity, a loop with a switch statement is not unrolled.
+			return false
+		case Assign:
+			var modified bool
+			visitExpr(&s.Exprs[0], func(e *Expr) {
+				if e.Type == LocalVariable && e.Index == counter {
+					modified = true
+				}
+			})
+			if modified {
+				return false
+			}
+		}
+		for i := range s.Exprs {
+			if o.hasSideEffects(&s.Exprs[i]) {
+				// The counter might be passed as an out-param.
+				return false
+			}
+		}
+		for _, b := range s.Blocks {
+			if !o.canUnroll(b, counter, loopLevel && s.Type != For) {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+func countStmts(block *Block) int {
+	n := len(block.Stmts)
+	for _, s := range block.Stmts {
+		for _, b := range s.Blocks {
+			n += countStmts(b)
+		}
+	}
+	return n
+}
+
+// Constant folding
+
+func (o *optimizer) foldConstants(block *Block) bool {
+	var changed bool
+	visitExprs(block, func(e *Expr) {
+		if foldExpr(e) {
+			changed = true
+		}
+		if o.foldConstArrayIndex(e) {
+			changed = true
+		}
+	})
+	return changed
+}
+
+// foldConstArrayIndex replaces an element access of a constant array with a constant index by the element value.
+func (o *optimizer) foldConstArrayIndex(e *Expr) bool {
+	if e.Type != Index || e.Exprs[0].Type != ConstArrayVariable || e.Exprs[1].Type != NumberExpr {
+		return false
+	}
+	idx, ok := constant.Int64Val(constant.ToInt(e.Exprs[1].Const))
+	if !ok {
+		return false
+	}
+	a := &o.p.ConstArrays[e.Exprs[0].Index]
+	if idx < 0 || idx >= int64(len(a.Exprs)) {
+		return false
+	}
+	// Only a number is folded. A vector constructor might be more expensive than an array access.
+	if a.Exprs[idx].Type != NumberExpr {
+		return false
+	}
+	*e = a.Exprs[idx]
+	return true
+}
+
+func isNumber(e *Expr, kind constant.Kind) bool {
+	return e.Type == NumberExpr && e.Const != nil && e.Const.Kind() == kind
+}
+
+func isNumberOf(e *Expr, x int64) bool {
+	if e.Type != NumberExpr || e.Const == nil {
+		return false
+	}
+	switch e.Const.Kind() {
+	case constant.Int, constant.Float:
+		return constant.Compare(e.Const, token.EQL, constant.MakeInt64(x))
+	}
+	return false
+}
+
+func foldExpr(e *Expr) bool {
+	switch e.Type {
+	case Unary:
+		x := &e.Exprs[0]
+		switch e.Op {
+		case Add:
+			*e = *x
+			return true
+		case Sub:
+			if isNumber(x, constant.Int) || isNumber(x, constant.Float) {
+				if v, ok := representable(constant.UnaryOp(token.SUB, x.Const, 0)); ok {
+					*e = numberExpr(v)
+					return true
+				}
+			}
+		case NotOp:
+			if isNumber(x, constant.Bool) {
+				*e = numberExpr(constant.UnaryOp(token.NOT, x.Const, 0))
+				return true
+			}
+		}
+	case Binary:
+		lhs, rhs := &e.Exprs[0], &e.Exprs[1]
+		if lhs.Type == NumberExpr && rhs.Type == NumberExpr {
+			if v, ok := foldBinary(e.Op, lhs.Const, rhs.Const); ok {
+				*e = numberExpr(v)
+				return true
+			}
+			return false
+		}
+		switch e.Op {
+		case AndAnd:
+			if isNumber(lhs, constant.Bool) {
+				if constant.BoolVal(lhs.Const) {
+					*e = *rhs
+				} else {
+					*e = *lhs
+				}
+				return true
+			}
+		case OrOr:
+			if isNumber(lhs, constant.Bool) {
+				if constant.BoolVal(lhs.Const) {
+					*e = *lhs
+				} else {
+					*e = *rhs
+				}
+				return true
+			}
+		case Add:
+			if isNumberOf(rhs, 0) {
+				*e = *lhs
+				return true
+			}
+			if isNumberOf(lhs, 0) {
+				*e = *rhs
+				return true
+			}
+		case Sub:
+			if isNumberOf(rhs, 0) {
+				*e = *lhs
+				return true
+			}
+		case ComponentWiseMul, MatrixMul:
+			if isNumberOf(rhs, 1) {
+				*e = *lhs
+				return true
+			}
+			if isNumberOf(lhs, 1) {
+				*e = *rhs
+				return true
+			}
+		case Div:
+			if isNumberOf(rhs, 1) {
+				*e = *lhs
+				return true
+			}
+		}
+	case Selection:
+		if isNumber(&e.Exprs[0], constant.Bool) {
+			if constant.BoolVal(e.Exprs[0].Const) {
+				*e = e.Exprs[1]
+			} else {
+				*e = e.Exprs[2]
+			}
+			return true
+		}
+	case Call:
+		if len(e.Exprs) != 2 || e.Exprs[0].Type != BuiltinFuncExpr || e.Exprs[1].Type != NumberExpr {
+			return false
+		}
+		x := &e.Exprs[1]
+		switch e.Exprs[0].BuiltinFunc {
+		case BoolF:
+			if isNumber(x, constant.Bool) {
+				*e = *x
+				return true
+			}
+		case IntF:
+			if isNumber(x, constant.Int) {
+				*e = *x
+				return true
+			}
+			if isNumber(x, constant.Float) {
+				f, _ := constant.Float64Val(x.Const)
+				if v, ok := representable(constant.MakeInt64(int64(math.Trunc(f)))); ok && !math.IsInf(f, 0) && !math.IsNaN(f) {
+					*e = numberExpr(v)
+					return true
+				}
+			}
+		case FloatF:
+			if isNumber(x, constant.Int) || isNumber(x, constant.Float) {
+				*e = numberExpr(constant.ToFloat(x.Const))
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func comparisonToken(op Op) (token.Token, bool) {
+	switch op {
+	case LessThanOp:
+		return token.LSS, true
+	case LessThanEqualOp:
+		return token.LEQ, true
+	case GreaterThanOp:
+		return token.GTR, true
+	case GreaterThanEqualOp:
+		return token.GEQ, true
+	case EqualOp:
+		return token.EQL, true
+	case NotEqualOp:
+		return token.NEQ, true
+	}
+	return 0, false
+}
+
+func foldBinary(op Op, x, y constant.Value) (constant.Value, bool) {
+	if x == nil || y == nil || x.Kind() != y.Kind() {
+		return nil, false
+	}
+
+	if tok, ok := comparisonToken(op); ok {
+		if x.Kind() == constant.Bool && tok != token.EQL && tok != token.NEQ {
+			return nil, false
+		}
+		return constant.MakeBool(constant.Compare(x, tok, y)), true
+	}
+
+	switch x.Kind() {
+	case constant.Bool:
+		switch op {
+		case AndAnd:
+			return constant.BinaryOp(x, token.LAND, y), true
+		case OrOr:
+			return constant.BinaryOp(x, token.LOR, y), true
+		}
+	case constant.Int:
+		switch op {
+		case Add:
+			return representable(constant.BinaryOp(x, token.ADD, y))
+		case Sub:
+			return representable(constant.BinaryOp(x, token.SUB, y))
+		case ComponentWiseMul:
+			return representable(constant.BinaryOp(x, token.MUL, y))
+		case Div:
+			if constant.Sign(y) == 0 {
+				return nil, false
+			}
+			// QUO_ASSIGN indicates an integer division.
+			return representable(constant.BinaryOp(x, token.QUO_ASSIGN, y))
+		case ModOp:
+			// The result with negative values depends on the backend.
+			if constant.Sign(x) < 0 || constant.Sign(y) <= 0 {
+				return nil, false
+			}
+			return representable(constant.BinaryOp(x, token.REM, y))
+		case And:
+			return representable(constant.BinaryOp(x, token.AND, y))
+		case Or:
+			return representable(constant.BinaryOp(x, token.OR, y))
+		case Xor:
+			return representable(constant.BinaryOp(x, token.XOR, y))
+		case LeftShift, RightShift:
+			s, ok := constant.Uint64Val(y)
+			if !ok || s >= 32 {
+				return nil, false
+			}
+			tok := token.SHL
+			if op == RightShift {
+				tok = token.SHR
+			}
+			return representable(constant.Shift(x, tok, uint(s)))
+		}
+	case constant.Float:
+		switch op {
+		case Add:
+			return representable(constant.BinaryOp(x, token.ADD, y))
+		case Sub:
+			return representable(constant.BinaryOp(x, token.SUB, y))
+		case ComponentWiseMul:
+			return representable(constant.BinaryOp(x, token.MUL, y))
+		case Div:
+			if constant.Sign(y) == 0 {
+				return nil, false
+			}
+			return representable(constant.BinaryOp(x, token.QUO, y))
+		}
+	}
+	return nil, false
+}
+
+// representable returns v if v is representable as a 32-bit integer or a 32-bit float.
+func representable(v constant.Value) (constant.Value, bool) {
+	switch v.Kind() {
+	case constant.Int:
+		i, ok := constant.Int64Val(v)
+		if !ok || i < math.MinInt32 || i > math.MaxInt32 {
+			return nil, false
+		}
+		return v, true
+	case constant.Float:
+		f, _ := constant.Float64Val(v)
+		if math.IsInf(f, 0) || math.IsNaN(f) || math.Abs(f) > math.MaxFloat32 {
+			return nil, false
+		}
+		return v, true
+	case constant.Bool:
+		return v, true
+	}
+	return nil, false
+}
+
+// Dead-code elimination
+
+func (o *optimizer) eliminateDeadCode(block *Block) bool {
+	changed := o.eliminateUnreachableStmts(block)
+	if o.eliminateDeadStores(block) {
+		changed = true
+	}
+	return changed
+}
+
+func isTerminating(s *Stmt) bool {
+	switch s.Type {
+	case Return, Discard, Break, Continue:
+		return true
+	}
+	return false
+}
+
+// eliminateUnreachableStmts removes if-statements with constant conditions, empty blocks, and statements after terminating statements.
+func (o *optimizer) eliminateUnreachableStmts(block *Block) bool {
+	var changed bool
+
+	var stmts []Stmt
+	var terminated bool
+	var appendStmt func(s Stmt)
+	appendStmt = func(s Stmt) {
+		if terminated {
+			changed = true
+			return
+		}
+		switch s.Type {
+		case If:
+			if isNumber(&s.Exprs[0], constant.Bool) {
+				changed = true
+				b := s.Blocks[0]
+				if !constant.BoolVal(s.Exprs[0].Const) {
+					if len(s.Blocks) < 2 {
+						return
+					}
+					b = s.Blocks[1]
+				}
+				appendStmt(Stmt{
+					Type:      BlockStmt,
+					Blocks:    []*Block{b},
+					SourcePos: s.SourcePos,
+				})
+				return
+			}
+		case BlockStmt:
+			// A block without variables can be merged into the outer block.
+			// The offsets of the descendant blocks are still valid, as the block doesn't have any variables.
+			if b := s.Blocks[0]; len(b.LocalVars) == 0 || len(b.Stmts) == 0 {
+				changed = true
+				for _, s := range b.Stmts {
+					appendStmt(s)
+				}
+				return
+			}
+		}
+		stmts = append(stmts, s)
+		if isTerminating(&s) {
+			terminated = true
+		}
+	}
+
+	for _, s := range block.Stmts {
+		for _, b := range s.Blocks {
+			if o.eliminateUnreachableStmts(b) {
+				changed = true
+			}
+		}
+		appendStmt(s)
+	}
+	block.Stmts = stmts
+	return changed
+}
+
+// eliminateDeadStores removes assignments to local variables that are never read, and assignments to themselves.
+func (o *optimizer) eliminateDeadStores(topBlock *Block) bool {
+	// Variables in sibling blocks might share the same index. This is conservative as such variables are treated as one variable.
+	reads := map[int]struct{}{}
+	var countReads func(block *Block)
+	countReads = func(block *Block) {
+		for _, s := range block.Stmts {
+			for i := range s.Exprs {
+				if s.Type == Assign && i == 0 && s.Exprs[0].Type == LocalVariable {
+					continue
+				}
+				visitExpr(&s.Exprs[i], func(e *Expr) {
+					if e.Type == LocalVariable {
+						reads[e.Index] = struct{}{}
+					}
+				})
+			}
+			if s.Type == For {
+				reads[s.ForVarIndex] = struct{}{}
+			}
+			for _, b := range s.Blocks {
+				countReads(b)
+			}
+		}
+	}
+	countReads(topBlock)
+
+	var changed bool
+	var eliminate func(block *Block)
+	eliminate = func(block *Block) {
+		stmts := block.Stmts[:0]
+		for _, s := range block.Stmts {
+			for _, b := range s.Blocks {
+				eliminate(b)
+			}
+			if s.Type == Assign && s.Exprs[0].Type == LocalVariable {
+				idx := s.Exprs[0].Index
+				// An assignment to itself is meaningless.
+				if s.Exprs[1].Type == LocalVariable && s.Exprs[1].Index == idx {
+					changed = true
+					continue
+				}
+				// Parameters, including out-params, must be kept.
+				if _, ok := reads[idx]; !ok && idx >= topBlock.LocalVarIndexOffset && !o.hasSideEffects(&s.Exprs[1]) {
+					changed = true
+					continue
+				}
+			}
+			stmts = append(stmts, s)
+		}
+		block.Stmts = stmts
+	}
+	eliminate(topBlock)
+	return changed
+}
+
+// Common subexpression elimination
+
+func (o *optimizer) eliminateCommonSubexpressions(topBlock, block *Block) {
+	for _, s := range block.Stmts {
+		for _, b := range s.Blocks {
+			o.eliminateCommonSubexpressions(topBlock, b)
+		}
+	}
+
+	stmts := make([]Stmt, 0, len(block.Stmts))
+	for _, s := range block.Stmts {
+		if o.canEliminateCommonSubexpressions(&s) {
+			for {
+				expr, t, ok := o.findCommonSubexpression(topBlock, block, &s)
+				if !ok {
+					break
+				}
+				// The new variable is referred only by this block's statements.
+				// Even if a descendant block has a variable with the same index, the variable just shadows the new variable.
+				v := Expr{
+					Type:  LocalVariable,
+					Index: block.LocalVarIndexOffset + len(block.LocalVars),
+				}
+				block.LocalVars = append(block.LocalVars, t)
+				key := exprString(&expr)
+				for i := range s.Exprs {
+					if s.Type == Assign && i == 0 {
+						continue
+					}
+					replaceExpr(&s.Exprs[i], key, &v)
+				}
+				stmts = append(stmts, Stmt{
+					Type:      Assign,
+					Exprs:     []Expr{v, expr},
+					SourcePos: s.SourcePos,
+				})
+			}
+		}
+		stmts = append(stmts, s)
+	}
+	block.Stmts = stmts
+}
+
+func (o *optimizer) canEliminateCommonSubexpressions(stmt *Stmt) bool {
+	switch stmt.Type {
+	case ExprStmt, Assign, If, Return:
+	default:
+		return false
+	}
+	for i := range stmt.Exprs {
+		if o.hasSideEffects(&stmt.Exprs[i]) {
+			return false
+		}
+	}
+	return true
+}
+
+// visitUnconditionalExprs calls f for each expression that is always evaluated when expr is evaluated, in pre-order.
+// If f returns false, the children of the expression are not visited.
+func visitUnconditionalExprs(expr *Expr, f func(expr *Expr) bool) {
+	if !f(expr) {
+		return
+	}
+	switch {
+	case expr.Type == Selection:
+		visitUnconditionalExprs(&expr.Exprs[0], f)
+	case expr.Type == Binary && (expr.Op == AndAnd || expr.Op == OrOr):
+		visitUnconditionalExprs(&expr.Exprs[0], f)
+	default:
+		for i := range expr.Exprs {
+			visitUnconditionalExprs(&expr.Exprs[i], f)
+		}
+	}
+}
+
+func replaceExpr(expr *Expr, key string, v *Expr) {
+	visitUnconditionalExprs(expr, func(e *Expr) bool {
+		if (e.Type == Binary || e.Type == Call) && exprString(e) == key {
+			*e = *v
+			return false
+		}
+		return true
+	})
+}
+
+func exprSize(expr *Expr) int {
+	n := 1
+	for i := range expr.Exprs {
+		n += exprSize(&expr.Exprs[i])
+	}
+	return n
+}
+
+// findCommonSubexpression finds the largest expression that appears more than once in the statement.
+func (o *optimizer) findCommonSubexpression(topBlock, block *Block, stmt *Stmt) (Expr, Type, bool) {
+	counts := map[string]int{}
+	var candidates []*Expr
+	for i := range stmt.Exprs {
+		if stmt.Type == Assign && i == 0 {
+			continue
+		}
+		visitUnconditionalExprs(&stmt.Exprs[i], func(e *Expr) bool {
+			if e.Type != Binary && e.Type != Call {
+				return true
+			}
+			key := exprString(e)
+			counts[key]++
+			if counts[key] == 2 {
+				candidates = append(candidates, e)
+			}
+			return true
+		})
+	}
+
+	var found *Expr
+	var foundType Type
+	for _, e := range candidates {
+		if found != nil && exprSize(found) >= exprSize(e) {
+			continue
+		}
+		t, ok := o.exprType(topBlock, block, e)
+		if !ok {
+			continue
+		}
+		switch t.Main {
+		case None, Texture, Array, Struct:
+			continue
+		}
+		found = e
+		foundType = t
+	}
+	if found == nil {
+		return Expr{}, Type{}, false
+	}
+	return Expr{
+		Type:  found.Type,
+		Exprs: cloneExprs(found.Exprs),
+		Op:    found.Op,
+	}, foundType, true
+}
+
+// exprType returns the type of the expression if the type can be determined.
+func (o *optimizer) exprType(topBlock, block *Block, expr *Expr) (Type, bool) {
+	switch expr.Type {
+	case NumberExpr:
+		switch expr.Const.Kind() {
+		case constant.Bool:
+			return Type{Main: Bool}, true
+		case constant.Int:
+			return Type{Main: Int}, true
+		case constant.Float:
+			return Type{Main: Float}, true
+		}
+	case UniformVariable:
+		return o.p.Uniforms[expr.Index], true
+	case ConstArrayVariable:
+		return o.p.ConstArrays[expr.Index].Type, true
+	case LocalVariable:
+		t := o.p.LocalVariableType(topBlock, block, expr.Index)
+		return t, t.Main != None
+	case Unary:
+		if expr.Op == NotOp {
+			return Type{Main: Bool}, true
+		}
+		return o.exprType(topBlock, block, &expr.Exprs[0])
+	case Binary:
+		switch expr.Op {
+		case LessThanOp, LessThanEqualOp, GreaterThanOp, GreaterThanEqualOp, EqualOp, NotEqualOp, VectorEqualOp, VectorNotEqualOp, AndAnd, OrOr:
+			return Type{Main: Bool}, true
+		}
+		lt, ok := o.exprType(topBlock, block, &expr.Exprs[0])
+		if !ok {
+			return Type{}, false
+		}
+		rt, ok := o.exprType(topBlock, block, &expr.Exprs[1])
+		if !ok {
+			return Type{}, false
+		}
+		if expr.Op == MatrixMul && lt.IsMatrix() && (rt.IsFloatVector() || rt.IsMatrix()) {
+			// A matrix and a vector's multiplication is a vector.
+			return rt, true
+		}
+		if lt.Main == Float || lt.Main == Int {
+			return rt, true
+		}
+		return lt, true
+	case Selection:
+		return o.exprType(topBlock, block, &expr.Exprs[1])
+	case Call:
+		callee := &expr.Exprs[0]
+		args := expr.Exprs[1:]
+		switch callee.Type {
+		case FunctionExpr:
+			f := o.function(callee.Index)
+			if f == nil || len(f.OutParams) > 0 {
+				return Type{}, false
+			}
+			return f.Return, true
+		case BuiltinFuncExpr:
+			return o.builtinFuncReturnType(topBlock, block, callee.BuiltinFunc, args)
+		}
+	case FieldSelector:
+		if expr.Exprs[1].Type != SwizzlingExpr {
+			return Type{}, false
+		}
+		t, ok := o.exprType(topBlock, block, &expr.Exprs[0])
+		if !ok {
+			return Type{}, false
+		}
+		n := len(expr.Exprs[1].Swizzling)
+		switch {
+		case t.IsFloatVector():
+			return [...]Type{{}, {Main: Float}, {Main: Vec2}, {Main: Vec3}, {Main: Vec4}}[n], n <= 4
+		case t.IsIntVector():
+			return [...]Type{{}, {Main: Int}, {Main: IVec2}, {Main: IVec3}, {Main: IVec4}}[n], n <= 4
+		}
+	case Index:
+		t, ok := o.exprType(topBlock, block, &expr.Exprs[0])
+		if !ok {
+			return Type{}, false
+		}
+		switch {
+		case t.Main == Array:
+			return t.Sub[0], true
+		case t.IsFloatVector():
+			return Type{Main: Float}, true
+		case t.IsIntVector():
+			return Type{Main: Int}, true
+		case t.IsMatrix():
+			return [...]Type{{}, {}, {Main: Vec2}, {Main: Vec3}, {Main: Vec4}}[t.MatrixSize()], true
+		}
+	}
+	return Type{}, false
+}
+
+func (o *optimizer) builtinFuncReturnType(topBlock, block *Block, f BuiltinFunc, args []Expr) (Type, bool) {
+	switch f {
+	case BoolF:
+		return Type{Main: Bool}, true
+	case IntF, Len, Cap:
+		return Type{Main: Int}, true
+	case FloatF, Length, Distance, Dot:
+		return Type{Main: Float}, true
+	case Vec2F:
+		return Type{Main: Vec2}, true
+	case Vec3F, Cross:
+		return Type{Main: Vec3}, true
+	case Vec4F, TexelAt, TexelFetch:
+		return Type{Main: Vec4}, true
+	case IVec2F:
+		return Type{Main: IVec2}, true
+	case IVec3F:
+		return Type{Main: IVec3}, true
+	case IVec4F:
+		return Type{Main: IVec4}, true
+	case Mat2F:
+		return Type{Main: Mat2}, true
+	case Mat3F:
+		return Type{Main: Mat3}, true
+	case Mat4F:
+		return Type{Main: Mat4}, true
+	case Radians, Degrees, Sin, Cos, Tan, Asin, Acos, Atan, Exp, Log, Exp2, Log2, Sqrt, Inversesqrt,
+		Abs, Sign, Floor, Ceil, Fract, Normalize, Transpose, Dfdx, Dfdy, Fwidth,
+		Faceforward, Reflect, Refract:
+		if len(args) == 0 {
+			return Type{}, false
+		}
+		return o.exprType(topBlock, block, &args[0])
+	case Atan2, Pow, Mod, Min, Max, Clamp, Mix, Step, Smoothstep:
+		// The result type is the same as the vector argument if exists.
+		var result Type
+		for i := range args {
+			t, ok := o.exprType(topBlock, block, &args[i])
+			if !ok {
+				return Type{}, false
+			}
+			if i == 0 || t.IsFloatVector() || t.IsIntVector() {
+				result = t
+			}
+			if t.IsFloatVector() || t.IsIntVector() {
+				break
+			}
+		}
+		return result, result.Main != None
+	}
+	return Type{}, false
+}
@@ -0,0 +1,136 @@
+// Copyright 2026 The Ebitengine Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package shaderir_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/duplicants-ai/ebiten/internal/shader"
+	"github.com/duplicants-ai/ebiten/internal/shaderir/glsl"
+	"github.com/duplicants-ai/ebiten/internal/shaderir/hlsl"
+	"github.com/duplicants-ai/ebiten/internal/shaderir/msl"
+)
+
+func TestOptimize(t *testing.T) {
+	tests := []struct {
+		Name    string
+		Src     string
+		Want    []string
+		NotWant []string
+	}{
+		{
+			Name: "loop unrolling and constant folding",
+			Src: `func Fragment(position vec4) vec4 {
+	sum := 0.0
+	for i := 0; i < 3; i++ {
+		sum += float(i) * 2.0
+	}
+	return vec4(sum)
+}`,
+			Want:    []string{"(l1) + (2.0)", "(l1) + (4.0)"},
+			NotWant: []string{"for (", "* (2.0)", "l1 = l1;"},
+		},
+		{
+			Name: "loop with break",
+			Src: `func Fragment(position vec4) vec4 {
+	sum := 0.0
+	for i := 0; i < 3; i++ {
+		if sum > position.x {
+			break
+		}
+		sum += 1.0
+	}
+	return vec4(sum)
+}`,
+			Want: []string{"for (", "break;"},
+		},
+		{
+			Name: "loop with many iterations",
+			Src: `func Fragment(position vec4) vec4 {
+	sum := 0.0
+	for i := 0; i < 100; i++ {
+		sum += position.x
+	}
+	return vec4(sum)
+}`,
+			Want: []string{"for (int l2 = 0; l2 < 100; l2++)"},
+		},
+		{
+			Name: "dead code",
+			Src: `func Fragment(position vec4) vec4 {
+	unused := position.x * 2.0
+	_ = unused
+	if false {
+		return vec4(1)
+	}
+	return position
+	return vec4(0)
+}`,
+			Want:    []string{"return l0;"},
+			NotWant: []string{"(2.0)", "if (", "vec4(1.0)", "vec4(0.0)"},
+		},
+		{
+			Name: "common subexpressions",
+			Src: `func Fragment(position vec4) vec4 {
+	return vec4(sin(position.x * 2.0) + sin(position.x * 2.0) * position.y)
+}`,
+			Want:    []string{"l1 = sin(((l0).x) * (2.0));", "vec4((l1) + ((l1) * ((l0).y)))"},
+			NotWant: []string{"sin(((l0).x) * (2.0)) + sin("},
+		},
+		{
+			Name: "common subexpressions in conditional branches",
+			Src: `func Fragment(position vec4) vec4 {
+	if position.x > 0 && sin(position.y) > 1.0 || sin(position.y) > 1.0 {
+		return vec4(1)
+	}
+	return vec4(0)
+}`,
+			Want: []string{"if (((((l0).x) > (0.0)) && ((sin((l0).y)) > (1.0))) || ((sin((l0).y)) > (1.0)))"},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.Name, func(t *testing.T) {
+			src := "package main\n\nfunc Vertex(position vec2) vec4 {\n\treturn vec4(position, 0, 1)\n}\n\n" + tc.Src + "\n"
+			s, err := shader.Compile([]byte(src), "Vertex", "Fragment", 0)
+			if err != nil {
+				t.Fatal(err)
+			}
+			_, orig := glsl.Compile(s, glsl.GLSLVersionDefault)
+			o := s.Optimize()
+			_, fs := glsl.Compile(o, glsl.GLSLVersionDefault)
+			for _, w := range tc.Want {
+				if !strings.Contains(fs, w) {
+					t.Errorf("%q is not found in the result:\n%s\nIR:\n%s", w, fs, o.Dump())
+				}
+			}
+			for _, w := range tc.NotWant {
+				if strings.Contains(fs, w) {
+					t.Errorf("%q is found in the result:\n%s\nIR:\n%s", w, fs, o.Dump())
+				}
+			}
+
+			// Check the other backends don't panic.
+			hlsl.Compile(o)
+			msl.Compile(o)
+
+			// Optimize must not modify the original program.
+			if _, fs := glsl.Compile(s, glsl.GLSLVersionDefault); fs != orig {
+				t.Errorf("the original program is modified")
+			}
+		})
+	}
+}
@@ -50,6 +50,7 @@ func (s SourceHash) String() string {
 type Program struct {
 	UniformNames []string
 	Uniforms     []Type
+	ConstArrays  []ConstArray
 	TextureCount int
 	Attributes   []Type
 	Varyings     []Type
@@ -58,11 +59,30 @@ type Program struct {
 	FragmentFunc FragmentFunc
 	Unit         Unit
 
+	// DepthTest reports whether fragments are tested against the destination's depth buffer.
+	DepthTest bool
+
+	// DepthWrite reports whether the depth values of fragments are written to the destination's depth buffer.
+	DepthWrite bool
+
+	// FragmentOutputCount is the number of the colors the fragment entry point outputs for multiple render targets.
+	// If FragmentOutputCount is 0, the fragment entry point returns one color as its returning value.
+	// Otherwise, the colors are the fragment entry point's pseudo out-params, and its return statements have no values.
+	FragmentOutputCount int
+
 	SourceHash SourceHash
 
 	uniformFactors []uint32
 }
 
+// ConstArray is a package-level constant array.
+// Each element of Exprs is a constant value or a constructor call of constant values.
+type ConstArray struct {
+	Name  string
+	Type  Type
+	Exprs []Expr
+}
+
 type Func struct {
 	Index     int
 	InParams  []Type
@@ -83,6 +103,8 @@ type VertexFunc struct {
 // FragmentFunc takes pseudo params, and the number is len(varyings) + 2.
 // If index == 0, the param represents the coordinate of the fragment (gl_FragCoord in GLSL).
 // If 0 < index <= len(varyings), the param represents (index-1)th varying variable.
+// If Program.FragmentOutputCount is not 0, the params are followed by out-params for the colors:
+// If len(varyings) < index <= len(varyings) + FragmentOutputCount, the param represents (index-len(varyings)-1)th color.
 type FragmentFunc struct {
 	Block *Block
 }
@@ -93,17 +115,44 @@ type Block struct {
 	Stmts               []Stmt
 }
 
+// EndsWithJump reports whether the last statement of the block is return, break, or continue.
+func (b *Block) EndsWithJump() bool {
+	if len(b.Stmts) == 0 {
+		return false
+	}
+	switch b.Stmts[len(b.Stmts)-1].Type {
+	case Return, Break, Continue:
+		return true
+	}
+	return false
+}
+
 type Stmt struct {
 	Type        StmtType
 	Exprs       []Expr
 	Blocks      []*Block
 	ForVarType  Type
 	ForVarIndex int
-	ForInit     constant.Value
-	ForEnd      constant.Value
-	ForOp       Op
-	ForDelta    constant.Value
-	InitIndex   int
+
+	// ForInit and ForEnd are nil when the bounds of the for-loop are not constant.
+	// In this case, Exprs has the initial value and the end value in this order.
+	ForInit  constant.Value
+	ForEnd   constant.Value
+	ForOp    Op
+	ForDelta constant.Value
+
+	// ForMaxIterations is the maximum number of iterations declared by //kage:maxiterations.
+	// ForMaxIterations is 0 when not declared.
+	ForMaxIterations int
+
+	InitIndex int
+
+	// SwitchCases has the case values of each block for a switch statement.
+	// A nil element represents the default clause.
+	SwitchCases [][]constant.Value
+
+	// SourcePos is the position of the statement in the original Kage source.
+	SourcePos SourcePos
 }
 
 type StmtType int
@@ -119,6 +168,7 @@ const (
 	Break
 	Return
 	Discard
+	Switch
 )
 
 type Expr struct {
@@ -149,6 +199,7 @@ const (
 	Call
 	FieldSelector
 	Index
+	ConstArrayVariable
 )
 
 type Op int
@@ -294,6 +345,7 @@ const (
 	Fwidth      BuiltinFunc = "fwidth"
 	DiscardF    BuiltinFunc = "discard"
 	TexelAt     BuiltinFunc = "__texelAt"
+	TexelFetch  BuiltinFunc = "__texelFetch"
 )
 
 func ParseBuiltinFunc(str string) (BuiltinFunc, bool) {
@@ -351,7 +403,8 @@ func ParseBuiltinFunc(str string) (BuiltinFunc, bool) {
 		Dfdy,
 		Fwidth,
 		DiscardF,
-		TexelAt:
+		TexelAt,
+		TexelFetch:
 		return BuiltinFunc(str), true
 	}
 	return "", false
@@ -0,0 +1,129 @@
+// Copyright 2026 The Ebitengine Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package shaderir
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// SourcePos represents a position in the original Kage source.
+// The zero value means that the position is unknown.
+type SourcePos struct {
+	Line   int
+	Column int
+}
+
+// IsValid reports whether the position is known.
+func (p SourcePos) IsValid() bool {
+	return p.Line > 0
+}
+
+func (p SourcePos) String() string {
+	if p.Column > 0 {
+		return fmt.Sprintf("%d:%d", p.Line, p.Column)
+	}
+	return strconv.Itoa(p.Line)
+}
+
+const sourceMarkerPrefix = "/*kage:"
+
+// SourceMarker returns a line to be inserted before the generated lines of a statement at pos.
+//
+// Marker lines must be removed by ExtractSourceMap before the generated source is passed to a backend compiler.
+func SourceMarker(pos SourcePos) string {
+	return fmt.Sprintf("%s%d:%d*/", sourceMarkerPrefix, pos.Line, pos.Column)
+}
+
+// SourceMap maps lines of a generated shader source to positions in the original Kage source.
+type SourceMap struct {
+	// positions[i] is the position for the (i+1)-th line of the generated source.
+	positions []SourcePos
+}
+
+// ExtractSourceMap removes the marker lines from src, and returns the result and its source map.
+//
+// A line after a marker line is mapped to the marker's position.
+// The following lines are mapped to the same position, until another marker or a line without indentation appears.
+func ExtractSourceMap(src string) (string, *SourceMap) {
+	if !strings.Contains(src, sourceMarkerPrefix) {
+		return src, &SourceMap{}
+	}
+
+	var m SourceMap
+	var lines []string
+	var pos SourcePos
+	var removed bool
+	for _, l := range strings.Split(src, "\n") {
+		if strings.HasPrefix(l, sourceMarkerPrefix) {
+			var line, col int
+			if _, err := fmt.Sscanf(l, sourceMarkerPrefix+"%d:%d*/", &line, &col); err == nil {
+				pos = SourcePos{Line: line, Column: col}
+			}
+			removed = true
+			continue
+		}
+		// Removing a marker line must not make consecutive empty lines.
+		if removed && l == "" && len(lines) > 0 && lines[len(lines)-1] == "" {
+			removed = false
+			continue
+		}
+		removed = false
+		if l == "" || (l[0] != ' ' && l[0] != '\t') {
+			pos = SourcePos{}
+		}
+		lines = append(lines, l)
+		m.positions = append(m.positions, pos)
+	}
+	return strings.Join(lines, "\n"), &m
+}
+
+// Position returns the position in the Kage source for the given 1-based line of the generated source.
+func (s *SourceMap) Position(line int) (SourcePos, bool) {
+	if s == nil || line <= 0 || line > len(s.positions) {
+		return SourcePos{}, false
+	}
+	p := s.positions[line-1]
+	return p, p.IsValid()
+}
+
+// MapErrorLog rewrites the lines of a backend compiler's error log so that they refer to the Kage source.
+//
+// re must match the location part of a log line, and its first submatch must be the 1-based line number of the generated source.
+// If the line is mapped, the location is replaced with the position in the Kage source.
+// MapErrorLog returns the rewritten log, and reports whether any line is mapped.
+func (s *SourceMap) MapErrorLog(log string, re *regexp.Regexp) (string, bool) {
+	var mapped bool
+	lines := strings.Split(log, "\n")
+	for i, l := range lines {
+		m := re.FindStringSubmatchIndex(l)
+		if m == nil || len(m) < 4 || m[2] < 0 {
+			continue
+		}
+		n, err := strconv.Atoi(l[m[2]:m[3]])
+		if err != nil {
+			continue
+		}
+		pos, ok := s.Position(n)
+		if !ok {
+			continue
+		}
+		lines[i] = l[:m[0]] + pos.String() + ": " + strings.TrimLeft(l[m[1]:], " ")
+		mapped = true
+	}
+	return strings.Join(lines, "\n"), mapped
+}
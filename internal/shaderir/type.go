@@ -246,13 +246,16 @@ func (p *Program) LocalVariableType(topBlock, block *Block, idx int) Type {
 		}
 	case p.FragmentFunc.Block:
 		nv := len(p.Varyings)
+		no := p.FragmentOutputCount
 		switch {
 		case idx == 0:
 			return Type{Main: Vec4}
 		case idx < nv+1:
 			return p.Varyings[idx-1]
+		case idx < nv+no+1:
+			return Type{Main: Vec4}
 		default:
-			return localVariableType(p, topBlock, block, idx-(nv+1))
+			return localVariableType(p, topBlock, block, idx-(nv+no+1))
 		}
 	default:
 		return localVariableType(p, topBlock, block, idx)
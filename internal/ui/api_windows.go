@@ -25,12 +25,30 @@ import (
 )
 
 const (
-	_CLSCTX_INPROC_SERVER     = 0x1
-	_CLSCTX_LOCAL_SERVER      = 0x4
-	_CLSCTX_REMOTE_SERVER     = 0x10
-	_CLSCTX_SERVER            = _CLSCTX_INPROC_SERVER | _CLSCTX_LOCAL_SERVER | _CLSCTX_REMOTE_SERVER
-	_MONITOR_DEFAULTTONEAREST = 2
-	_SM_CYCAPTION             = 4
+	_AC_LINE_ONLINE             = 1
+	_BATTERY_FLAG_CHARGING      = 8
+	_BATTERY_FLAG_NO_BATTERY    = 128
+	_BATTERY_FLAG_UNKNOWN       = 255
+	_BATTERY_PERCENTAGE_UNKNOWN = 255
+	_CLSCTX_INPROC_SERVER       = 0x1
+	_CLSCTX_LOCAL_SERVER        = 0x4
+	_CLSCTX_REMOTE_SERVER       = 0x10
+	_CLSCTX_SERVER              = _CLSCTX_INPROC_SERVER | _CLSCTX_LOCAL_SERVER | _CLSCTX_REMOTE_SERVER
+	_ES_CONTINUOUS              = 0x80000000
+	_ES_DISPLAY_REQUIRED        = 0x00000002
+	_ES_SYSTEM_REQUIRED         = 0x00000001
+	_HCF_HIGHCONTRASTON         = 0x00000001
+	_LOCALE_NAME_MAX_LENGTH     = 85
+	_MONITOR_DEFAULTTONEAREST   = 2
+	_SM_CXSMICON                = 49
+	_SM_CYCAPTION               = 4
+	_SPI_GETCLIENTAREAANIMATION = 0x1042
+	_SPI_GETHIGHCONTRAST        = 0x0042
+	_TBPF_NOPROGRESS            = 0x0
+	_TBPF_INDETERMINATE         = 0x1
+	_TBPF_NORMAL                = 0x2
+	_TBPF_ERROR                 = 0x4
+	_TBPF_PAUSED                = 0x8
 )
 
 var (
@@ -46,6 +64,12 @@ var (
 		Data3: 0x11D0,
 		Data4: [...]byte{0x95, 0x8A, 0x00, 0x60, 0x97, 0xC9, 0xA0, 0x90},
 	}
+	_IID_ITaskbarList3 = windows.GUID{
+		Data1: 0xEA1AFB91,
+		Data2: 0x9E28,
+		Data3: 0x4B86,
+		Data4: [...]byte{0x90, 0xE9, 0x9E, 0x9F, 0x8A, 0x5E, 0xEF, 0xAF},
+	}
 )
 
 type _RECT struct {
@@ -62,26 +86,102 @@ type _MONITORINFO struct {
 	dwFlags   uint32
 }
 
+type _HIGHCONTRASTW struct {
+	cbSize            uint32
+	dwFlags           uint32
+	lpszDefaultScheme *uint16
+}
+
+type _SYSTEM_POWER_STATUS struct {
+	ACLineStatus        byte
+	BatteryFlag         byte
+	BatteryLifePercent  byte
+	SystemStatusFlag    byte
+	BatteryLifeTime     uint32
+	BatteryFullLifeTime uint32
+}
+
 type _POINT struct {
 	x int32
 	y int32
 }
 
+type _ICONINFO struct {
+	fIcon    int32
+	xHotspot uint32
+	yHotspot uint32
+	hbmMask  uintptr
+	hbmColor uintptr
+}
+
 var (
-	imm32  = windows.NewLazySystemDLL("imm32.dll")
-	ole32  = windows.NewLazySystemDLL("ole32.dll")
-	user32 = windows.NewLazySystemDLL("user32.dll")
+	gdi32    = windows.NewLazySystemDLL("gdi32.dll")
+	imm32    = windows.NewLazySystemDLL("imm32.dll")
+	kernel32 = windows.NewLazySystemDLL("kernel32.dll")
+	ole32    = windows.NewLazySystemDLL("ole32.dll")
+	user32   = windows.NewLazySystemDLL("user32.dll")
+
+	procCreateBitmap = gdi32.NewProc("CreateBitmap")
+	procDeleteObject = gdi32.NewProc("DeleteObject")
 
 	procImmAssociateContext = imm32.NewProc("ImmAssociateContext")
 
+	procGetSystemPowerStatus    = kernel32.NewProc("GetSystemPowerStatus")
+	procLCIDToLocaleName        = kernel32.NewProc("LCIDToLocaleName")
+	procSetThreadExecutionState = kernel32.NewProc("SetThreadExecutionState")
+
 	procCoCreateInstance = ole32.NewProc("CoCreateInstance")
 
-	procGetSystemMetrics  = user32.NewProc("GetSystemMetrics")
-	procMonitorFromWindow = user32.NewProc("MonitorFromWindow")
-	procGetMonitorInfoW   = user32.NewProc("GetMonitorInfoW")
-	procGetCursorPos      = user32.NewProc("GetCursorPos")
+	procCreateIconIndirect    = user32.NewProc("CreateIconIndirect")
+	procDestroyIcon           = user32.NewProc("DestroyIcon")
+	procGetSystemMetrics      = user32.NewProc("GetSystemMetrics")
+	procMonitorFromWindow     = user32.NewProc("MonitorFromWindow")
+	procGetMonitorInfoW       = user32.NewProc("GetMonitorInfoW")
+	procGetCursorPos          = user32.NewProc("GetCursorPos")
+	procSystemParametersInfoW = user32.NewProc("SystemParametersInfoW")
 )
 
+func _CreateBitmap(nWidth int32, nHeight int32, nPlanes uint32, nBitCount uint32, lpBits unsafe.Pointer) (uintptr, error) {
+	r, _, e := procCreateBitmap.Call(uintptr(nWidth), uintptr(nHeight), uintptr(nPlanes), uintptr(nBitCount), uintptr(lpBits))
+	if r == 0 {
+		if e != nil && !errors.Is(e, windows.ERROR_SUCCESS) {
+			return 0, fmt.Errorf("ui: CreateBitmap failed: error code: %w", e)
+		}
+		return 0, fmt.Errorf("ui: CreateBitmap failed: returned 0")
+	}
+	return r, nil
+}
+
+func _DeleteObject(ho uintptr) error {
+	r, _, _ := procDeleteObject.Call(ho)
+	if int32(r) == 0 {
+		return fmt.Errorf("ui: DeleteObject failed: returned 0")
+	}
+	return nil
+}
+
+func _CreateIconIndirect(piconinfo *_ICONINFO) (uintptr, error) {
+	r, _, e := procCreateIconIndirect.Call(uintptr(unsafe.Pointer(piconinfo)))
+	if r == 0 {
+		if e != nil && !errors.Is(e, windows.ERROR_SUCCESS) {
+			return 0, fmt.Errorf("ui: CreateIconIndirect failed: error code: %w", e)
+		}
+		return 0, fmt.Errorf("ui: CreateIconIndirect failed: returned 0")
+	}
+	return r, nil
+}
+
+func _DestroyIcon(hIcon uintptr) error {
+	r, _, e := procDestroyIcon.Call(hIcon)
+	if int32(r) == 0 {
+		if e != nil && !errors.Is(e, windows.ERROR_SUCCESS) {
+			return fmt.Errorf("ui: DestroyIcon failed: error code: %w", e)
+		}
+		return fmt.Errorf("ui: DestroyIcon failed: returned 0")
+	}
+	return nil
+}
+
 func _ImmAssociateContext(hwnd windows.HWND, hIMC uintptr) (uintptr, error) {
 	r, _, e := procImmAssociateContext.Call(uintptr(hwnd), hIMC)
 	if e != nil && !errors.Is(e, windows.ERROR_SUCCESS) {
@@ -90,6 +190,14 @@ func _ImmAssociateContext(hwnd windows.HWND, hIMC uintptr) (uintptr, error) {
 	return r, nil
 }
 
+func _SetThreadExecutionState(esFlags uint32) error {
+	r, _, _ := procSetThreadExecutionState.Call(uintptr(esFlags))
+	if uint32(r) == 0 {
+		return fmt.Errorf("ui: SetThreadExecutionState failed: returned 0")
+	}
+	return nil
+}
+
 func _CoCreateInstance(rclsid *windows.GUID, pUnkOuter unsafe.Pointer, dwClsContext uint32, riid *windows.GUID) (unsafe.Pointer, error) {
 	var ptr unsafe.Pointer
 	r, _, _ := procCoCreateInstance.Call(uintptr(unsafe.Pointer(rclsid)), uintptr(pUnkOuter), uintptr(dwClsContext), uintptr(unsafe.Pointer(riid)), uintptr(unsafe.Pointer(&ptr)))
@@ -142,6 +250,55 @@ func _GetCursorPos() (int32, int32, error) {
 	return pt.x, pt.y, nil
 }
 
+func _GetSystemPowerStatus() (_SYSTEM_POWER_STATUS, error) {
+	var status _SYSTEM_POWER_STATUS
+	r, _, e := procGetSystemPowerStatus.Call(uintptr(unsafe.Pointer(&status)))
+	if int32(r) == 0 {
+		if e != nil && !errors.Is(e, windows.ERROR_SUCCESS) {
+			return _SYSTEM_POWER_STATUS{}, fmt.Errorf("ui: GetSystemPowerStatus failed: error code: %w", e)
+		}
+		return _SYSTEM_POWER_STATUS{}, fmt.Errorf("ui: GetSystemPowerStatus failed: returned 0")
+	}
+	return status, nil
+}
+
+func _LCIDToLocaleName(locale uint32) (string, error) {
+	var buf [_LOCALE_NAME_MAX_LENGTH]uint16
+	r, _, e := procLCIDToLocaleName.Call(uintptr(locale), uintptr(unsafe.Pointer(&buf[0])), uintptr(len(buf)), 0)
+	if int32(r) == 0 {
+		if e != nil && !errors.Is(e, windows.ERROR_SUCCESS) {
+			return "", fmt.Errorf("ui: LCIDToLocaleName failed: error code: %w", e)
+		}
+		return "", fmt.Errorf("ui: LCIDToLocaleName failed: returned 0")
+	}
+	return windows.UTF16ToString(buf[:]), nil
+}
+
+func _SystemParametersInfoW_Bool(uiAction uint32) (bool, error) {
+	var v int32
+	r, _, e := procSystemParametersInfoW.Call(uintptr(uiAction), 0, uintptr(unsafe.Pointer(&v)), 0)
+	if int32(r) == 0 {
+		if e != nil && !errors.Is(e, windows.ERROR_SUCCESS) {
+			return false, fmt.Errorf("ui: SystemParametersInfoW failed: error code: %w", e)
+		}
+		return false, fmt.Errorf("ui: SystemParametersInfoW failed: returned 0")
+	}
+	return v != 0, nil
+}
+
+func _SystemParametersInfoW_HighContrast() (_HIGHCONTRASTW, error) {
+	var hc _HIGHCONTRASTW
+	hc.cbSize = uint32(unsafe.Sizeof(hc))
+	r, _, e := procSystemParametersInfoW.Call(_SPI_GETHIGHCONTRAST, uintptr(hc.cbSize), uintptr(unsafe.Pointer(&hc)), 0)
+	if int32(r) == 0 {
+		if e != nil && !errors.Is(e, windows.ERROR_SUCCESS) {
+			return _HIGHCONTRASTW{}, fmt.Errorf("ui: SystemParametersInfoW failed: error code: %w", e)
+		}
+		return _HIGHCONTRASTW{}, fmt.Errorf("ui: SystemParametersInfoW failed: returned 0")
+	}
+	return hc, nil
+}
+
 type _ITaskbarList struct {
 	vtbl *_ITaskbarList_Vtbl
 }
@@ -169,3 +326,77 @@ func (i *_ITaskbarList) DeleteTab(hwnd windows.HWND) error {
 func (i *_ITaskbarList) Release() {
 	_, _, _ = syscall.Syscall(i.vtbl.Release, 1, uintptr(unsafe.Pointer(i)), 0, 0)
 }
+
+type _ITaskbarList3 struct {
+	vtbl *_ITaskbarList3_Vtbl
+}
+
+type _ITaskbarList3_Vtbl struct {
+	QueryInterface uintptr
+	AddRef         uintptr
+	Release        uintptr
+
+	HrInit       uintptr
+	AddTab       uintptr
+	DeleteTab    uintptr
+	ActivateTab  uintptr
+	SetActiveAlt uintptr
+
+	MarkFullscreenWindow uintptr
+
+	SetProgressValue      uintptr
+	SetProgressState      uintptr
+	RegisterTab           uintptr
+	UnregisterTab         uintptr
+	SetTabOrder           uintptr
+	SetTabActive          uintptr
+	ThumbBarAddButtons    uintptr
+	ThumbBarUpdateButtons uintptr
+	ThumbBarSetImageList  uintptr
+	SetOverlayIcon        uintptr
+	SetThumbnailTooltip   uintptr
+	SetThumbnailClip      uintptr
+}
+
+func (i *_ITaskbarList3) HrInit() error {
+	r, _, _ := syscall.Syscall(i.vtbl.HrInit, 1, uintptr(unsafe.Pointer(i)), 0, 0)
+	if uint32(r) != uint32(windows.S_OK) {
+		return fmt.Errorf("ui: ITaskbarList3::HrInit failed: HRESULT(%d)", uint32(r))
+	}
+	return nil
+}
+
+func (i *_ITaskbarList3) SetProgressValue(hwnd windows.HWND, completed, total uint64) error {
+	var r uintptr
+	if unsafe.Sizeof(uintptr(0)) == 4 {
+		// ULONGLONG arguments take two words on 32bit machines.
+		r, _, _ = syscall.SyscallN(i.vtbl.SetProgressValue, uintptr(unsafe.Pointer(i)), uintptr(hwnd), uintptr(completed), uintptr(completed>>32), uintptr(total), uintptr(total>>32))
+	} else {
+		r, _, _ = syscall.SyscallN(i.vtbl.SetProgressValue, uintptr(unsafe.Pointer(i)), uintptr(hwnd), uintptr(completed), uintptr(total))
+	}
+	if uint32(r) != uint32(windows.S_OK) {
+		return fmt.Errorf("ui: ITaskbarList3::SetProgressValue failed: HRESULT(%d)", uint32(r))
+	}
+	return nil
+}
+
+func (i *_ITaskbarList3) SetProgressState(hwnd windows.HWND, tbpFlags uint32) error {
+	r, _, _ := syscall.Syscall(i.vtbl.SetProgressState, 3, uintptr(unsafe.Pointer(i)), uintptr(hwnd), uintptr(tbpFlags))
+	if uint32(r) != uint32(windows.S_OK) {
+		return fmt.Errorf("ui: ITaskbarList3::SetProgressState failed: HRESULT(%d)", uint32(r))
+	}
+	return nil
+}
+
+func (i *_ITaskbarList3) SetOverlayIcon(hwnd windows.HWND, hIcon uintptr, pszDescription *uint16) error {
+	r, _, _ := syscall.Syscall6(i.vtbl.SetOverlayIcon, 4, uintptr(unsafe.Pointer(i)), uintptr(hwnd), hIcon, uintptr(unsafe.Pointer(pszDescription)), 0, 0)
+	runtime.KeepAlive(pszDescription)
+	if uint32(r) != uint32(windows.S_OK) {
+		return fmt.Errorf("ui: ITaskbarList3::SetOverlayIcon failed: HRESULT(%d)", uint32(r))
+	}
+	return nil
+}
+
+func (i *_ITaskbarList3) Release() {
+	_, _, _ = syscall.Syscall(i.vtbl.Release, 1, uintptr(unsafe.Pointer(i)), 0, 0)
+}
@@ -0,0 +1,197 @@
+// Copyright 2026 The Ebitengine Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ui
+
+import (
+	"sync"
+)
+
+// maxPendingTicks is the maximum number of ticks that an asynchronous Update can lag behind.
+// Ticks beyond this are dropped so that the game doesn't spiral when Update is too heavy.
+// This is the same number as the clock package's threshold to sync with the system clock.
+const maxPendingTicks = 5
+
+// asyncUpdater runs Update on its own goroutine, decoupled from Draw.
+type asyncUpdater struct {
+	context *context
+
+	// inputStates are the input snapshots for the pending ticks.
+	// A snapshot is taken on the goroutine where frames are updated, and is swapped with the game's input state
+	// on the update goroutine at the beginning of the tick.
+	inputStates     []*InputState
+	freeInputStates []*InputState
+
+	err     error
+	started bool
+	closed  bool
+	m       sync.Mutex
+
+	tickCh chan struct{}
+	doneCh chan struct{}
+	wg     sync.WaitGroup
+
+	// stateM is locked while the game state is handed off from Update to Draw, and while Draw is called.
+	stateM sync.Mutex
+}
+
+func newAsyncUpdater(context *context) *asyncUpdater {
+	return &asyncUpdater{
+		context: context,
+		tickCh:  make(chan struct{}, 1),
+		doneCh:  make(chan struct{}),
+	}
+}
+
+// update requests updateCount ticks to the update goroutine.
+// update must be called on the goroutine where frames are updated.
+func (a *asyncUpdater) update(ui *UserInterface, updateCount int) error {
+	if !a.started {
+		// Call the first Update synchronously so that Update can be used for initialization before Draw.
+		// Until then, Draw is not called.
+		if updateCount == 0 {
+			return nil
+		}
+		if err := a.context.updateTick(ui); err != nil {
+			return err
+		}
+		if err := a.publishState(); err != nil {
+			return err
+		}
+		updateCount--
+
+		a.m.Lock()
+		a.started = true
+		a.m.Unlock()
+
+		a.wg.Add(1)
+		go a.loop(ui)
+	}
+
+	a.m.Lock()
+	defer a.m.Unlock()
+
+	if a.err != nil {
+		return a.err
+	}
+
+	n := min(updateCount, maxPendingTicks-len(a.inputStates))
+	if n <= 0 {
+		return nil
+	}
+	for i := 0; i < n; i++ {
+		var s *InputState
+		if len(a.freeInputStates) > 0 {
+			s = a.freeInputStates[len(a.freeInputStates)-1]
+			a.freeInputStates = a.freeInputStates[:len(a.freeInputStates)-1]
+		} else {
+			s = &InputState{}
+		}
+		// Take the snapshot per tick so that the deltas like wheels and runes are given only to the first tick,
+		// as well as the synchronous Update.
+		a.context.readInputState(ui, s)
+		a.inputStates = append(a.inputStates, s)
+	}
+
+	select {
+	case a.tickCh <- struct{}{}:
+	default:
+	}
+	return nil
+}
+
+func (a *asyncUpdater) loop(ui *UserInterface) {
+	defer a.wg.Done()
+
+	for {
+		select {
+		case <-a.tickCh:
+		case <-a.doneCh:
+			return
+		}
+
+		for {
+			a.m.Lock()
+			if a.closed || len(a.inputStates) == 0 {
+				a.m.Unlock()
+				break
+			}
+			s := a.inputStates[0]
+			copy(a.inputStates, a.inputStates[1:])
+			a.inputStates = a.inputStates[:len(a.inputStates)-1]
+			a.m.Unlock()
+
+			err := a.updateTick(ui, s)
+
+			a.m.Lock()
+			a.freeInputStates = append(a.freeInputStates, s)
+			if err != nil {
+				a.err = err
+			}
+			a.m.Unlock()
+
+			if err != nil {
+				return
+			}
+		}
+	}
+}
+
+func (a *asyncUpdater) updateTick(ui *UserInterface, inputState *InputState) error {
+	// Swap the buffers instead of copying so that the snapshot's slices are not shared with the game's input state.
+	a.context.game.UpdateInputState(func(s *InputState) {
+		*s, *inputState = *inputState, *s
+	})
+	if err := a.context.updateGame(ui); err != nil {
+		return err
+	}
+	return a.publishState()
+}
+
+func (a *asyncUpdater) publishState() error {
+	a.stateM.Lock()
+	defer a.stateM.Unlock()
+	return a.context.game.PublishState()
+}
+
+// draw calls Draw exclusively with the state hand-off.
+// draw does nothing until the first Update is called.
+func (a *asyncUpdater) draw() error {
+	a.m.Lock()
+	started := a.started
+	a.m.Unlock()
+	if !started {
+		return nil
+	}
+
+	a.stateM.Lock()
+	defer a.stateM.Unlock()
+	return a.context.game.DrawOffscreen()
+}
+
+// close stops the update goroutine and waits for it.
+// The pending ticks are discarded.
+// close must be called on the goroutine where frames are updated.
+func (a *asyncUpdater) close() {
+	a.m.Lock()
+	if a.closed {
+		a.m.Unlock()
+		return
+	}
+	a.closed = true
+	a.m.Unlock()
+
+	close(a.doneCh)
+	a.wg.Wait()
+}
@@ -0,0 +1,85 @@
+// Copyright 2026 The Ebitengine Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ui
+
+import (
+	"fmt"
+	"syscall/js"
+)
+
+// CanvasSurface is an additional render surface backed by an existing 2D canvas.
+type CanvasSurface struct {
+	canvas  js.Value
+	context js.Value
+	pixels  js.Value
+	buf     []byte
+}
+
+func NewCanvasSurface(selector string) (*CanvasSurface, error) {
+	if !document.Truthy() {
+		return nil, fmt.Errorf("ui: document is not available")
+	}
+	c := document.Call("querySelector", selector)
+	if !c.Truthy() {
+		return nil, fmt.Errorf("ui: no element matches the selector %q", selector)
+	}
+	if !c.InstanceOf(js.Global().Get("HTMLCanvasElement")) {
+		return nil, fmt.Errorf("ui: the element matching the selector %q is not a canvas", selector)
+	}
+	if c.Equal(canvas) {
+		return nil, fmt.Errorf("ui: the canvas matching the selector %q is already used as the main screen", selector)
+	}
+	ctx := c.Call("getContext", "2d")
+	if !ctx.Truthy() {
+		return nil, fmt.Errorf("ui: getContext(\"2d\") failed for the canvas matching the selector %q", selector)
+	}
+	return &CanvasSurface{
+		canvas:  c,
+		context: ctx,
+	}, nil
+}
+
+// Present copies the given pixels to the canvas.
+// pixels must be RGBA with premultiplied alpha.
+func (c *CanvasSurface) Present(pixels []byte, width, height int) {
+	if c.canvas.Get("width").Int() != width {
+		c.canvas.Set("width", width)
+	}
+	if c.canvas.Get("height").Int() != height {
+		c.canvas.Set("height", height)
+	}
+
+	// ImageData requires non-premultiplied alpha.
+	if cap(c.buf) < len(pixels) {
+		c.buf = make([]byte, len(pixels))
+	}
+	c.buf = c.buf[:len(pixels)]
+	for i := 0; i < len(pixels); i += 4 {
+		r, g, b, a := pixels[i], pixels[i+1], pixels[i+2], pixels[i+3]
+		if a != 0 && a != 0xff {
+			r = byte(uint32(r) * 0xff / uint32(a))
+			g = byte(uint32(g) * 0xff / uint32(a))
+			b = byte(uint32(b) * 0xff / uint32(a))
+		}
+		c.buf[i], c.buf[i+1], c.buf[i+2], c.buf[i+3] = r, g, b, a
+	}
+
+	if !c.pixels.Truthy() || c.pixels.Length() != len(c.buf) {
+		c.pixels = js.Global().Get("Uint8ClampedArray").New(len(c.buf))
+	}
+	js.CopyBytesToJS(c.pixels, c.buf)
+	imageData := js.Global().Get("ImageData").New(c.pixels, width, height)
+	c.context.Call("putImageData", imageData, 0, 0)
+}
@@ -0,0 +1,32 @@
+// Copyright 2026 The Ebitengine Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build !js
+
+package ui
+
+import (
+	"fmt"
+	"runtime"
+)
+
+type CanvasSurface struct{}
+
+func NewCanvasSurface(selector string) (*CanvasSurface, error) {
+	return nil, fmt.Errorf("ui: NewCanvasSurface is not supported on GOOS=%s", runtime.GOOS)
+}
+
+func (c *CanvasSurface) Present(pixels []byte, width, height int) {
+	// Do nothing
+}
@@ -15,14 +15,17 @@
 package ui
 
 import (
+	"errors"
 	"math"
 	"time"
 
 	"github.com/duplicants-ai/ebiten/internal/atlas"
 	"github.com/duplicants-ai/ebiten/internal/clock"
 	"github.com/duplicants-ai/ebiten/internal/debug"
+	"github.com/duplicants-ai/ebiten/internal/graphicscommand"
 	"github.com/duplicants-ai/ebiten/internal/graphicsdriver"
 	"github.com/duplicants-ai/ebiten/internal/hook"
+	"github.com/duplicants-ai/ebiten/internal/restorable"
 )
 
 var (
@@ -36,6 +39,7 @@ type Game interface {
 	Layout(outsideWidth, outsideHeight float64) (screenWidth, screenHeight float64)
 	UpdateInputState(fn func(*InputState))
 	Update() error
+	PublishState() error
 	DrawOffscreen() error
 	DrawFinalScreen(scale, offsetX, offsetY float64)
 }
@@ -53,29 +57,57 @@ type context struct {
 	offscreenWidth  float64
 	offscreenHeight float64
 
+	// pixelAligned indicates whether the offscreen is put on the physical pixel grid of the final screen.
+	pixelAligned bool
+
 	isOffscreenModified bool
 	lastSwapBufferTime  time.Time
 
+	latencyMode        LatencyMode
+	latencyModeApplied bool
+
+	restoredCount int64
+
 	skipCount int
 
 	funcsInFrameCh chan func()
+
+	// asyncUpdater is non-nil when Update runs on its own goroutine.
+	asyncUpdater *asyncUpdater
 }
 
-func newContext(game Game) *context {
-	return &context{
+func newContext(game Game, asyncUpdate bool) *context {
+	c := &context{
 		game:           game,
 		funcsInFrameCh: make(chan func()),
 	}
+	if asyncUpdate {
+		c.asyncUpdater = newAsyncUpdater(c)
+	}
+	return c
+}
+
+// close stops the update goroutine if it exists.
+func (c *context) close() {
+	if c.asyncUpdater != nil {
+		c.asyncUpdater.close()
+	}
 }
 
 func (c *context) updateFrame(graphicsDriver graphicsdriver.Graphics, outsideWidth, outsideHeight float64, deviceScaleFactor float64, ui *UserInterface) error {
+	ui.runQueuedFuncsOnMainThread()
+
+	// Update the low-power mode before the clock so that the TPS limit is applied to this frame.
+	ui.updateLowPowerMode()
+
 	// TODO: If updateCount is 0 and vsync is disabled, swapping buffers can be skipped.
 	needsSwapBuffers, err := c.updateFrameImpl(graphicsDriver, clock.UpdateFrame(), outsideWidth, outsideHeight, deviceScaleFactor, ui, false)
 	if err != nil {
-		return err
+		return handleDeviceLost(err)
 	}
-	if err := c.swapBuffersOrWait(needsSwapBuffers, graphicsDriver, ui.FPSMode() == FPSModeVsyncOn); err != nil {
-		return err
+	maxFrameRate, _ := ui.lowPowerModeEffects()
+	if err := c.swapBuffersOrWait(needsSwapBuffers, graphicsDriver, ui.FPSMode() == FPSModeVsyncOn, maxFrameRate); err != nil {
+		return handleDeviceLost(err)
 	}
 	return nil
 }
@@ -90,15 +122,28 @@ func (c *context) forceUpdateFrame(graphicsDriver graphicsdriver.Graphics, outsi
 	for i := 0; i < n; i++ {
 		needsSwapBuffers, err := c.updateFrameImpl(graphicsDriver, 1, outsideWidth, outsideHeight, deviceScaleFactor, ui, true)
 		if err != nil {
-			return err
+			return handleDeviceLost(err)
 		}
-		if err := c.swapBuffersOrWait(needsSwapBuffers, graphicsDriver, ui.FPSMode() == FPSModeVsyncOn); err != nil {
-			return err
+		if err := c.swapBuffersOrWait(needsSwapBuffers, graphicsDriver, ui.FPSMode() == FPSModeVsyncOn, 0); err != nil {
+			return handleDeviceLost(err)
 		}
 	}
 	return nil
 }
 
+// handleDeviceLost returns nil if err indicates that the graphics device is lost and the device can be restored.
+// In this case, the device and the images are restored at the beginning of the next frame.
+func handleDeviceLost(err error) error {
+	if !errors.Is(err, graphicsdriver.ErrDeviceLost) {
+		return err
+	}
+	if !restorable.IsRestorationEnabled() {
+		return err
+	}
+	restorable.OnContextLost()
+	return nil
+}
+
 func (c *context) updateFrameImpl(graphicsDriver graphicsdriver.Graphics, updateCount int, outsideWidth, outsideHeight float64, deviceScaleFactor float64, ui *UserInterface, forceDraw bool) (needsSwapBuffers bool, err error) {
 	// The given outside size can be 0 e.g. just after restoring from the fullscreen mode on Windows (#1589)
 	// Just ignore such cases. Otherwise, creating a zero-sized framebuffer causes a panic.
@@ -108,6 +153,12 @@ func (c *context) updateFrameImpl(graphicsDriver graphicsdriver.Graphics, update
 
 	debug.FrameLogf("----\n")
 
+	if m := ui.LatencyMode(); !c.latencyModeApplied || m != c.latencyMode {
+		graphicscommand.SetLatencyMode(graphicsdriver.LatencyMode(m), graphicsDriver)
+		c.latencyMode = m
+		c.latencyModeApplied = true
+	}
+
 	if err := atlas.BeginFrame(graphicsDriver); err != nil {
 		return false, err
 	}
@@ -125,11 +176,13 @@ func (c *context) updateFrameImpl(graphicsDriver graphicsdriver.Graphics, update
 	}
 
 	// ForceUpdate can be invoked even if the context is not initialized yet (#1591).
-	if w, h := c.layoutGame(outsideWidth, outsideHeight, deviceScaleFactor); w == 0 || h == 0 {
+	_, resolutionScale := ui.lowPowerModeEffects()
+	if w, h := c.layoutGame(outsideWidth, outsideHeight, deviceScaleFactor, resolutionScale, ui.IsPhysicalPixelLayoutEnabled()); w == 0 || h == 0 {
 		return false, nil
 	}
 
 	// Update the input state after the layout is updated as a cursor position is affected by the layout.
+	graphicscommand.MarkFrameStart()
 	if err := ui.updateInputState(); err != nil {
 		return false, err
 	}
@@ -142,25 +195,19 @@ func (c *context) updateFrameImpl(graphicsDriver graphicsdriver.Graphics, update
 	debug.FrameLogf("Update count per frame: %d\n", updateCount)
 
 	// Update the game.
-	for i := 0; i < updateCount; i++ {
-		// Read the input state and use it for one tick to give a consistent result for one tick (#2496, #2501).
-		c.game.UpdateInputState(func(inputState *InputState) {
-			ui.readInputState(inputState)
-		})
-
-		if err := hook.RunBeforeUpdateHooks(); err != nil {
+	if c.asyncUpdater != nil {
+		if err := c.asyncUpdater.update(ui, updateCount); err != nil {
 			return false, err
 		}
-		if err := c.game.Update(); err != nil {
-			return false, err
-		}
-
-		// Catch the error that happened at (*Image).At.
-		if err := ui.error(); err != nil {
-			return false, err
+	} else {
+		for i := 0; i < updateCount; i++ {
+			if err := c.updateTick(ui); err != nil {
+				return false, err
+			}
+			if err := c.game.PublishState(); err != nil {
+				return false, err
+			}
 		}
-
-		ui.tick.Add(1)
 	}
 
 	// Update window icons during a frame, since an icon might be *ebiten.Image and
@@ -173,7 +220,48 @@ func (c *context) updateFrameImpl(graphicsDriver graphicsdriver.Graphics, update
 	return c.drawGame(graphicsDriver, ui, forceDraw)
 }
 
-func (c *context) swapBuffersOrWait(needsSwapBuffers bool, graphicsDriver graphicsdriver.Graphics, vsyncEnabled bool) error {
+// updateTick updates the game by one tick.
+func (c *context) updateTick(ui *UserInterface) error {
+	// Read the input state and use it for one tick to give a consistent result for one tick (#2496, #2501).
+	c.game.UpdateInputState(func(inputState *InputState) {
+		c.readInputState(ui, inputState)
+	})
+	return c.updateGame(ui)
+}
+
+// readInputState reads the input state for one tick.
+// readInputState must be called on the goroutine where frames are updated.
+func (c *context) readInputState(ui *UserInterface, inputState *InputState) {
+	ui.readInputState(inputState)
+	ui.systemSettings.readAndReset(inputState)
+	ui.powerStatus.readAndReset(inputState)
+	inputState.LowPowerModeActive = ui.IsLowPowerModeActive()
+	n := restorable.RestoredCount()
+	inputState.GraphicsDeviceRestored = n != c.restoredCount
+	c.restoredCount = n
+}
+
+// updateGame calls the game's Update with the current input state.
+func (c *context) updateGame(ui *UserInterface) error {
+	if err := hook.RunBeforeUpdateHooks(); err != nil {
+		return err
+	}
+	if err := c.game.Update(); err != nil {
+		return err
+	}
+
+	// Catch the error that happened at (*Image).At.
+	if err := ui.error(); err != nil {
+		return err
+	}
+
+	ui.tick.Add(1)
+	// Accumulate the duration of each tick, as TPS and the frame duration can change at any time.
+	ui.tickTime.Add(int64(clock.TickDuration()))
+	return nil
+}
+
+func (c *context) swapBuffersOrWait(needsSwapBuffers bool, graphicsDriver graphicsdriver.Graphics, vsyncEnabled bool, maxFrameRate int) error {
 	now := time.Now()
 	defer func() {
 		c.lastSwapBufferTime = now
@@ -194,6 +282,10 @@ func (c *context) swapBuffersOrWait(needsSwapBuffers bool, graphicsDriver graphi
 		// In the case when the display has high refresh rates like 240 [Hz], the wait time should be small.
 		waitTime = time.Millisecond
 	}
+	if maxFrameRate > 0 {
+		// Limit the frame rate e.g. in the low-power mode.
+		waitTime = max(waitTime, time.Second/time.Duration(maxFrameRate))
+	}
 	if waitTime > 0 {
 		if delta := waitTime - now.Sub(c.lastSwapBufferTime); delta > 0 {
 			time.Sleep(delta)
@@ -228,8 +320,14 @@ func (c *context) drawGame(graphicsDriver graphicsdriver.Graphics, ui *UserInter
 		c.offscreen.clear()
 	}
 
-	if err := c.game.DrawOffscreen(); err != nil {
-		return false, err
+	if c.asyncUpdater != nil {
+		if err := c.asyncUpdater.draw(); err != nil {
+			return false, err
+		}
+	} else {
+		if err := c.game.DrawOffscreen(); err != nil {
+			return false, err
+		}
 	}
 
 	const maxSkipCount = 4
@@ -259,14 +357,27 @@ func (c *context) drawGame(graphicsDriver graphicsdriver.Graphics, ui *UserInter
 	return true, nil
 }
 
-func (c *context) layoutGame(outsideWidth, outsideHeight float64, deviceScaleFactor float64) (int, int) {
-	owf, ohf := c.game.Layout(outsideWidth, outsideHeight)
+// layoutGame updates the screen and the offscreen sizes.
+// resolutionScale scales the outside size given to the game's Layout, which lowers the offscreen resolution
+// if the game's Layout depends on the outside size.
+// If physicalPixels is true, the outside size given to the game's Layout is the final screen size in physical pixels,
+// and the offscreen is put on the physical pixel grid.
+func (c *context) layoutGame(outsideWidth, outsideHeight float64, deviceScaleFactor float64, resolutionScale float64, physicalPixels bool) (int, int) {
+	screenWidth := outsideWidth * deviceScaleFactor
+	screenHeight := outsideHeight * deviceScaleFactor
+	if physicalPixels {
+		// Use the actual size of the final screen so that an offscreen of the same size is rendered without scaling.
+		screenWidth = math.Ceil(screenWidth)
+		screenHeight = math.Ceil(screenHeight)
+		outsideWidth = screenWidth
+		outsideHeight = screenHeight
+	}
+
+	owf, ohf := c.game.Layout(outsideWidth*resolutionScale, outsideHeight*resolutionScale)
 	if owf <= 0 || ohf <= 0 {
 		panic("ui: Layout must return positive numbers")
 	}
 
-	screenWidth := outsideWidth * deviceScaleFactor
-	screenHeight := outsideHeight * deviceScaleFactor
 	if c.screenWidth != screenWidth || c.screenHeight != screenHeight {
 		c.skipCount = 0
 	}
@@ -274,6 +385,7 @@ func (c *context) layoutGame(outsideWidth, outsideHeight float64, deviceScaleFac
 	c.screenHeight = screenHeight
 	c.offscreenWidth = owf
 	c.offscreenHeight = ohf
+	c.pixelAligned = physicalPixels
 
 	sw := int(math.Ceil(c.screenWidth))
 	sh := int(math.Ceil(c.screenHeight))
@@ -322,6 +434,11 @@ func (c *context) screenScaleAndOffsets() (scale, offsetX, offsetY float64) {
 	height := c.offscreenHeight * scale
 	offsetX = (c.screenWidth - width) / 2
 	offsetY = (c.screenHeight - height) / 2
+	if c.pixelAligned {
+		// Avoid half-pixel offsets, which make the final screen blurry.
+		offsetX = math.Floor(offsetX)
+		offsetY = math.Floor(offsetY)
+	}
 	return
 }
 
@@ -0,0 +1,158 @@
+// Copyright 2026 The Ebitengine Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ui_test
+
+import (
+	"testing"
+
+	"github.com/duplicants-ai/ebiten/internal/ui"
+)
+
+type layoutGame struct {
+	width  float64
+	height float64
+
+	outsideWidth  float64
+	outsideHeight float64
+}
+
+func (g *layoutGame) NewOffscreenImage(width, height int) *ui.Image {
+	return &ui.Image{}
+}
+
+func (g *layoutGame) NewScreenImage(width, height int) *ui.Image {
+	return &ui.Image{}
+}
+
+func (g *layoutGame) Layout(outsideWidth, outsideHeight float64) (float64, float64) {
+	g.outsideWidth = outsideWidth
+	g.outsideHeight = outsideHeight
+	if g.width == 0 || g.height == 0 {
+		return outsideWidth, outsideHeight
+	}
+	return g.width, g.height
+}
+
+func (g *layoutGame) UpdateInputState(fn func(*ui.InputState)) {
+}
+
+func (g *layoutGame) Update() error {
+	return nil
+}
+
+func (g *layoutGame) PublishState() error {
+	return nil
+}
+
+func (g *layoutGame) DrawOffscreen() error {
+	return nil
+}
+
+func (g *layoutGame) DrawFinalScreen(scale, offsetX, offsetY float64) {
+}
+
+func TestLayoutGame(t *testing.T) {
+	testCases := []struct {
+		Name              string
+		Width             float64
+		Height            float64
+		OutsideWidth      float64
+		OutsideHeight     float64
+		DeviceScaleFactor float64
+		PhysicalPixels    bool
+
+		WantOutsideWidth  float64
+		WantOutsideHeight float64
+		WantScale         float64
+		WantOffsetX       float64
+		WantOffsetY       float64
+	}{
+		{
+			Name:              "device-independent pixels",
+			OutsideWidth:      100.5,
+			OutsideHeight:     100,
+			DeviceScaleFactor: 1.5,
+			WantOutsideWidth:  100.5,
+			WantOutsideHeight: 100,
+			WantScale:         1.5,
+		},
+		{
+			Name:              "physical pixels",
+			OutsideWidth:      100.5,
+			OutsideHeight:     100,
+			DeviceScaleFactor: 1.5,
+			PhysicalPixels:    true,
+			WantOutsideWidth:  151,
+			WantOutsideHeight: 150,
+			WantScale:         1,
+		},
+		{
+			Name:              "device-independent pixels with a fixed size",
+			Width:             100,
+			Height:            100,
+			OutsideWidth:      101,
+			OutsideHeight:     100,
+			DeviceScaleFactor: 1,
+			WantOutsideWidth:  101,
+			WantOutsideHeight: 100,
+			WantScale:         1,
+			WantOffsetX:       0.5,
+		},
+		{
+			Name:              "physical pixels with a fixed size",
+			Width:             100,
+			Height:            100,
+			OutsideWidth:      101,
+			OutsideHeight:     100,
+			DeviceScaleFactor: 1,
+			PhysicalPixels:    true,
+			WantOutsideWidth:  101,
+			WantOutsideHeight: 100,
+			WantScale:         1,
+			WantOffsetX:       0,
+		},
+		{
+			Name:              "physical pixels with a fixed size and a fractional scale",
+			Width:             100,
+			Height:            50,
+			OutsideWidth:      100,
+			OutsideHeight:     100,
+			DeviceScaleFactor: 1.25,
+			PhysicalPixels:    true,
+			WantOutsideWidth:  125,
+			WantOutsideHeight: 125,
+			WantScale:         1.25,
+			WantOffsetY:       31,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.Name, func(t *testing.T) {
+			g := &layoutGame{
+				width:  tc.Width,
+				height: tc.Height,
+			}
+			scale, offsetX, offsetY := ui.LayoutGameForTesting(g, tc.OutsideWidth, tc.OutsideHeight, tc.DeviceScaleFactor, tc.PhysicalPixels)
+			if g.outsideWidth != tc.WantOutsideWidth || g.outsideHeight != tc.WantOutsideHeight {
+				t.Errorf("outside size: got: (%f, %f), want: (%f, %f)", g.outsideWidth, g.outsideHeight, tc.WantOutsideWidth, tc.WantOutsideHeight)
+			}
+			if scale != tc.WantScale {
+				t.Errorf("scale: got: %f, want: %f", scale, tc.WantScale)
+			}
+			if offsetX != tc.WantOffsetX || offsetY != tc.WantOffsetY {
+				t.Errorf("offsets: got: (%f, %f), want: (%f, %f)", offsetX, offsetY, tc.WantOffsetX, tc.WantOffsetY)
+			}
+		})
+	}
+}
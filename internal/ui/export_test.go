@@ -0,0 +1,46 @@
+// Copyright 2026 The Ebitengine Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ui
+
+import (
+	"image"
+)
+
+func (i *InputState) CopyAndResetForTesting(dst *InputState) {
+	i.copyAndReset(dst)
+}
+
+type InputSourceStateForTesting struct {
+	state inputSourceState
+}
+
+func (i *InputSourceStateForTesting) Update(inputState *InputState, current func() (InputSource, bool)) {
+	i.state.update(inputState, current)
+}
+
+func (i *InputSourceStateForTesting) SetInputSource(inputState *InputState, inputSource InputSource) {
+	i.state.setInputSource(inputState, inputSource)
+}
+
+func IsInMousePassthroughRegionsForTesting(regions []image.Rectangle, cursorX, cursorY float64) bool {
+	return isInMousePassthroughRegions(regions, cursorX, cursorY)
+}
+
+// LayoutGameForTesting lays out game with a new context, and returns the scale and the offsets to render the offscreen onto the final screen.
+func LayoutGameForTesting(game Game, outsideWidth, outsideHeight float64, deviceScaleFactor float64, physicalPixels bool) (scale, offsetX, offsetY float64) {
+	c := newContext(game, false)
+	c.layoutGame(outsideWidth, outsideHeight, deviceScaleFactor, 1, physicalPixels)
+	return c.screenScaleAndOffsets()
+}
@@ -0,0 +1,24 @@
+// Copyright 2026 The Ebitengine Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build wasip1 && !ebitenginehostsurface
+
+package ui
+
+func hostSurfaceSize() (width, height int, ok bool) {
+	return 0, 0, false
+}
+
+// presentToHostSurface is nil as there is no host surface.
+var presentToHostSurface func(pixels []byte, width, height int) error
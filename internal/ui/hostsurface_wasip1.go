@@ -0,0 +1,54 @@
+// Copyright 2026 The Ebitengine Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build wasip1 && ebitenginehostsurface
+
+package ui
+
+import (
+	"unsafe"
+)
+
+// The host surface protocol consists of functions in the "ebitengine" module that the host implements.
+
+// surfaceSize writes the surface size in pixels as int32 values to the given pointers.
+//
+//go:wasmimport ebitengine surface_size
+func surfaceSize(width, height unsafe.Pointer)
+
+// surfacePresent shows the pixels on the surface.
+// The pixels are in premultiplied-alpha RGBA and the rows are ordered from top to bottom.
+// The pixels are valid only during the call.
+//
+//go:wasmimport ebitengine surface_present
+func surfacePresent(pixels unsafe.Pointer, width, height int32)
+
+// hostSurfaceSize returns the surface size provided by the host.
+// ok is false if the host doesn't provide a valid size.
+func hostSurfaceSize() (width, height int, ok bool) {
+	var w, h int32
+	surfaceSize(unsafe.Pointer(&w), unsafe.Pointer(&h))
+	if w <= 0 || h <= 0 {
+		return 0, 0, false
+	}
+	return int(w), int(h), true
+}
+
+func presentToHostSurface(pixels []byte, width, height int) error {
+	if len(pixels) == 0 {
+		return nil
+	}
+	surfacePresent(unsafe.Pointer(&pixels[0]), int32(width), int32(height))
+	return nil
+}
@@ -18,8 +18,10 @@ import (
 	"fmt"
 	"image"
 	"math"
+	"sync"
 
 	"github.com/duplicants-ai/ebiten/internal/atlas"
+	"github.com/duplicants-ai/ebiten/internal/builtinshader"
 	"github.com/duplicants-ai/ebiten/internal/graphics"
 	"github.com/duplicants-ai/ebiten/internal/graphicsdriver"
 	"github.com/duplicants-ai/ebiten/internal/mipmap"
@@ -78,7 +80,7 @@ func (i *Image) Deallocate() {
 	i.mipmap.Deallocate()
 }
 
-func (i *Image) DrawTriangles(srcs [graphics.ShaderSrcImageCount]*Image, vertices []float32, indices []uint32, blend graphicsdriver.Blend, dstRegion image.Rectangle, srcRegions [graphics.ShaderSrcImageCount]image.Rectangle, shader *Shader, uniforms []uint32, fillRule graphicsdriver.FillRule, canSkipMipmap bool, antialias bool, hint restorable.Hint) {
+func (i *Image) DrawTriangles(srcs [graphics.ShaderSrcImageCount]*Image, vertices []float32, indices []uint32, blend graphicsdriver.Blend, dstRegion image.Rectangle, srcRegions [graphics.ShaderSrcImageCount]image.Rectangle, shader *Shader, uniforms []uint32, fillRule graphicsdriver.FillRule, canSkipMipmap bool, trilinear bool, antialias bool, hint restorable.Hint) {
 	if i.modifyCallback != nil {
 		i.modifyCallback()
 	}
@@ -93,13 +95,18 @@ func (i *Image) DrawTriangles(srcs [graphics.ShaderSrcImageCount]*Image, vertice
 				imageType = atlas.ImageTypeUnmanaged
 			case atlas.ImageTypeScreen, atlas.ImageTypeVolatile:
 				imageType = atlas.ImageTypeVolatile
+			case atlas.ImageTypeUnrestorable, atlas.ImageTypeStreaming, atlas.ImageTypeDepth:
+				imageType = atlas.ImageTypeUnrestorable
+			case atlas.ImageTypeFloat16, atlas.ImageTypeFloat32:
+				// Keep the precision.
+				imageType = i.imageType
 			default:
 				panic(fmt.Sprintf("ui: unexpected image type: %d", imageType))
 			}
 			i.bigOffscreenBuffer = i.ui.newBigOffscreenImage(i, imageType)
 		}
 
-		i.bigOffscreenBuffer.drawTriangles(srcs, vertices, indices, blend, dstRegion, srcRegions, shader, uniforms, fillRule, canSkipMipmap)
+		i.bigOffscreenBuffer.drawTriangles(srcs, vertices, indices, blend, dstRegion, srcRegions, shader, uniforms, fillRule, canSkipMipmap, trilinear)
 		return
 	}
 
@@ -114,7 +121,39 @@ func (i *Image) DrawTriangles(srcs [graphics.ShaderSrcImageCount]*Image, vertice
 		srcMipmaps[i] = src.mipmap
 	}
 
-	i.mipmap.DrawTriangles(srcMipmaps, vertices, indices, blend, dstRegion, srcRegions, shader.shader, uniforms, fillRule, canSkipMipmap, hint)
+	i.mipmap.DrawTriangles(srcMipmaps, vertices, indices, blend, dstRegion, srcRegions, shader.shader, uniforms, fillRule, canSkipMipmap, trilinear, hint)
+}
+
+// DrawTrianglesMRT draws triangles to multiple render targets.
+// See graphicscommand.Image.DrawTrianglesMRT for the details of extraDsts.
+//
+// Anti-aliasing is not available with multiple render targets.
+func (i *Image) DrawTrianglesMRT(extraDsts [graphics.ShaderDstImageCount - 1]*Image, srcs [graphics.ShaderSrcImageCount]*Image, vertices []float32, indices []uint32, blend graphicsdriver.Blend, dstRegion image.Rectangle, srcRegions [graphics.ShaderSrcImageCount]image.Rectangle, shader *Shader, uniforms []uint32, fillRule graphicsdriver.FillRule) {
+	var extraDstMipmaps [graphics.ShaderDstImageCount - 1]*mipmap.Mipmap
+	for k, dst := range append([]*Image{i}, extraDsts[:]...) {
+		if dst == nil {
+			continue
+		}
+		if dst.modifyCallback != nil {
+			dst.modifyCallback()
+		}
+		dst.lastBlend = blend
+		dst.flushBufferIfNeeded()
+		if k > 0 {
+			extraDstMipmaps[k-1] = dst.mipmap
+		}
+	}
+
+	var srcMipmaps [graphics.ShaderSrcImageCount]*mipmap.Mipmap
+	for i, src := range srcs {
+		if src == nil {
+			continue
+		}
+		src.flushBufferIfNeeded()
+		srcMipmaps[i] = src.mipmap
+	}
+
+	i.mipmap.DrawTrianglesMRT(extraDstMipmaps, srcMipmaps, vertices, indices, blend, dstRegion, srcRegions, shader.shader, uniforms, fillRule)
 }
 
 func (i *Image) WritePixels(pix []byte, region image.Rectangle) {
@@ -141,6 +180,47 @@ func (i *Image) ReadPixels(pixels []byte, region image.Rectangle) {
 	}
 }
 
+func (i *Image) WritePixelsFloat32(pix []float32, region image.Rectangle) {
+	if i.modifyCallback != nil {
+		i.modifyCallback()
+	}
+	i.flushBufferIfNeeded()
+	i.mipmap.WritePixelsFloat32(pix, region)
+}
+
+func (i *Image) ReadPixelsFloat32(pixels []float32, region image.Rectangle) {
+	// Check the error existence and avoid unnecessary calls.
+	if i.ui.error() != nil {
+		return
+	}
+
+	i.flushBigOffscreenBufferIfNeeded()
+
+	if err := i.ui.readPixelsFloat32(i.mipmap, pixels, region); err != nil {
+		if panicOnErrorOnReadingPixels {
+			panic(err)
+		}
+		i.ui.setError(err)
+	}
+}
+
+func (i *Image) SetGutter(size int, mode atlas.GutterMode) {
+	i.mipmap.SetGutter(size, mode)
+}
+
+func (i *Image) SetAtlasGroup(group int) {
+	i.mipmap.SetAtlasGroup(group)
+}
+
+func (i *Image) SetMipmapMaxLevel(level int) {
+	i.mipmap.SetMaxLevel(level)
+}
+
+func (i *Image) GenerateMipmaps() {
+	i.flushBufferIfNeeded()
+	i.mipmap.GenerateLevels()
+}
+
 func (i *Image) DumpScreenshot(name string, blackbg bool) (string, error) {
 	i.flushBufferIfNeeded()
 	return i.ui.dumpScreenshot(i.mipmap, name, blackbg)
@@ -184,10 +264,25 @@ func (i *Image) Fill(r, g, b, a float32, region image.Rectangle) {
 		blend = graphicsdriver.BlendSourceOver
 	}
 	sr := image.Rect(0, 0, i.ui.whiteImage.width, i.ui.whiteImage.height)
+	shader := NearestFilterShader
+	if i.imageType == atlas.ImageTypeDepth {
+		// Reset the depth buffer as well as the colors.
+		shader = fillDepthShader()
+	}
 	// i.lastBlend is updated in DrawTriangles.
-	i.DrawTriangles(srcs, i.tmpVerticesForFill, is, blend, region, [graphics.ShaderSrcImageCount]image.Rectangle{sr}, NearestFilterShader, nil, graphicsdriver.FillRuleFillAll, true, false, restorable.HintOverwriteDstRegion)
+	i.DrawTriangles(srcs, i.tmpVerticesForFill, is, blend, region, [graphics.ShaderSrcImageCount]image.Rectangle{sr}, shader, nil, graphicsdriver.FillRuleFillAll, true, false, false, restorable.HintOverwriteDstRegion)
 }
 
+// fillDepthShader returns the shader to fill an image with a depth buffer.
+// The shader is compiled lazily as depth buffers are rarely used.
+var fillDepthShader = sync.OnceValue(func() *Shader {
+	ir, err := graphics.CompileShader([]byte(builtinshader.FillDepthShaderSource))
+	if err != nil {
+		panic(fmt.Sprintf("ui: compiling the fill-depth shader failed: %v", err))
+	}
+	return NewShader(ir, "fill-depth")
+})
+
 type bigOffscreenImage struct {
 	ui *UserInterface
 
@@ -219,14 +314,14 @@ func (i *bigOffscreenImage) deallocate() {
 	i.dirty = false
 }
 
-func (i *bigOffscreenImage) drawTriangles(srcs [graphics.ShaderSrcImageCount]*Image, vertices []float32, indices []uint32, blend graphicsdriver.Blend, dstRegion image.Rectangle, srcRegions [graphics.ShaderSrcImageCount]image.Rectangle, shader *Shader, uniforms []uint32, fillRule graphicsdriver.FillRule, canSkipMipmap bool) {
+func (i *bigOffscreenImage) drawTriangles(srcs [graphics.ShaderSrcImageCount]*Image, vertices []float32, indices []uint32, blend graphicsdriver.Blend, dstRegion image.Rectangle, srcRegions [graphics.ShaderSrcImageCount]image.Rectangle, shader *Shader, uniforms []uint32, fillRule graphicsdriver.FillRule, canSkipMipmap bool, trilinear bool) {
 	if i.blend != blend {
 		i.flush()
 	}
 	i.blend = blend
 
 	// If the new region doesn't match with the current region, remove the buffer image and recreate it later.
-	if r := i.requiredRegion(vertices); i.region != r {
+	if r := i.requiredRegion(vertices, shader.VertexFloatCount()); i.region != r {
 		i.flush()
 		i.image = nil
 		i.region = r
@@ -255,10 +350,10 @@ func (i *bigOffscreenImage) drawTriangles(srcs [graphics.ShaderSrcImageCount]*Im
 		is := graphics.QuadIndices()
 		dstRegion := image.Rect(0, 0, i.region.Dx()*bigOffscreenScale, i.region.Dy()*bigOffscreenScale)
 		srcRegion := i.region
-		i.image.DrawTriangles(srcs, i.tmpVerticesForCopying, is, graphicsdriver.BlendCopy, dstRegion, [graphics.ShaderSrcImageCount]image.Rectangle{srcRegion}, NearestFilterShader, nil, graphicsdriver.FillRuleFillAll, true, false, restorable.HintOverwriteDstRegion)
+		i.image.DrawTriangles(srcs, i.tmpVerticesForCopying, is, graphicsdriver.BlendCopy, dstRegion, [graphics.ShaderSrcImageCount]image.Rectangle{srcRegion}, NearestFilterShader, nil, graphicsdriver.FillRuleFillAll, true, false, false, restorable.HintOverwriteDstRegion)
 	}
 
-	for idx := 0; idx < len(vertices); idx += graphics.VertexFloatCount {
+	for idx := 0; idx < len(vertices); idx += shader.VertexFloatCount() {
 		vertices[idx] = (vertices[idx] - float32(i.region.Min.X)) * bigOffscreenScale
 		vertices[idx+1] = (vertices[idx+1] - float32(i.region.Min.Y)) * bigOffscreenScale
 	}
@@ -271,7 +366,7 @@ func (i *bigOffscreenImage) drawTriangles(srcs [graphics.ShaderSrcImageCount]*Im
 	dstRegion.Max.X *= bigOffscreenScale
 	dstRegion.Max.Y *= bigOffscreenScale
 
-	i.image.DrawTriangles(srcs, vertices, indices, blend, dstRegion, srcRegions, shader, uniforms, fillRule, canSkipMipmap, false, restorable.HintNone)
+	i.image.DrawTriangles(srcs, vertices, indices, blend, dstRegion, srcRegions, shader, uniforms, fillRule, canSkipMipmap, trilinear, false, restorable.HintNone)
 	i.dirty = true
 }
 
@@ -306,18 +401,18 @@ func (i *bigOffscreenImage) flush() {
 		blend = graphicsdriver.BlendCopy
 		hint = restorable.HintOverwriteDstRegion
 	}
-	i.orig.DrawTriangles(srcs, i.tmpVerticesForFlushing, is, blend, dstRegion, [graphics.ShaderSrcImageCount]image.Rectangle{srcRegion}, LinearFilterShader, nil, graphicsdriver.FillRuleFillAll, true, false, hint)
+	i.orig.DrawTriangles(srcs, i.tmpVerticesForFlushing, is, blend, dstRegion, [graphics.ShaderSrcImageCount]image.Rectangle{srcRegion}, LinearFilterShader, nil, graphicsdriver.FillRuleFillAll, true, false, false, hint)
 
 	i.image.clear()
 	i.dirty = false
 }
 
-func (i *bigOffscreenImage) requiredRegion(vertices []float32) image.Rectangle {
+func (i *bigOffscreenImage) requiredRegion(vertices []float32, vertexFloatCount int) image.Rectangle {
 	minX := float32(i.orig.width)
 	minY := float32(i.orig.height)
 	maxX := float32(0)
 	maxY := float32(0)
-	for i := 0; i < len(vertices); i += graphics.VertexFloatCount {
+	for i := 0; i < len(vertices); i += vertexFloatCount {
 		dstX := vertices[i]
 		dstY := vertices[i+1]
 		if minX > floor(dstX)-1 {
@@ -15,6 +15,7 @@
 package ui
 
 import (
+	"image"
 	"io/fs"
 	"unicode"
 )
@@ -49,6 +50,34 @@ type InputState struct {
 	Runes              []rune
 	WindowBeingClosed  bool
 	DroppedFiles       fs.FS
+	Orientation        Orientation
+	OrientationChanged bool
+	SafeAreaInsets     Insets
+	MultiWindowMode    MultiWindowMode
+	HingeBounds        image.Rectangle
+
+	// PageHidden and PageVisibilityChanged are used only on browsers.
+	PageHidden            bool
+	PageVisibilityChanged bool
+
+	SystemSettings        SystemSettings
+	SystemSettingsChanged bool
+
+	// CursorCaptureInterruptions is used only on desktops and browsers.
+	CursorCaptureInterruptions []CursorCaptureInterruption
+
+	// InputSource and InputSourceChanged are used only on desktops and browsers.
+	InputSource        InputSource
+	InputSourceChanged bool
+
+	// WindowMonitor is the monitor the window belongs to. WindowMonitor is used only on desktops.
+	WindowMonitor *Monitor
+
+	PowerStatus        PowerStatus
+	PowerStatusChanged bool
+	LowPowerModeActive bool
+
+	GraphicsDeviceRestored bool
 }
 
 func (i *InputState) copyAndReset(dst *InputState) {
@@ -60,17 +89,32 @@ func (i *InputState) copyAndReset(dst *InputState) {
 	dst.WheelY = i.WheelY
 	dst.Touches = append(dst.Touches[:0], i.Touches...)
 	dst.Runes = append(dst.Runes[:0], i.Runes...)
+	dst.CursorCaptureInterruptions = append(dst.CursorCaptureInterruptions[:0], i.CursorCaptureInterruptions...)
 	dst.WindowBeingClosed = i.WindowBeingClosed
 	dst.DroppedFiles = i.DroppedFiles
+	dst.Orientation = i.Orientation
+	dst.OrientationChanged = i.OrientationChanged
+	dst.SafeAreaInsets = i.SafeAreaInsets
+	dst.MultiWindowMode = i.MultiWindowMode
+	dst.HingeBounds = i.HingeBounds
+	dst.PageHidden = i.PageHidden
+	dst.PageVisibilityChanged = i.PageVisibilityChanged
+	dst.InputSource = i.InputSource
+	dst.InputSourceChanged = i.InputSourceChanged
+	dst.WindowMonitor = i.WindowMonitor
 
 	// Reset the members that are updated by deltas, rather than absolute values.
 	i.WheelX = 0
 	i.WheelY = 0
 	i.Runes = i.Runes[:0]
+	i.CursorCaptureInterruptions = i.CursorCaptureInterruptions[:0]
 
 	// Reset the members that are never reset until they are explicitly done.
 	i.WindowBeingClosed = false
 	i.DroppedFiles = nil
+	i.OrientationChanged = false
+	i.PageVisibilityChanged = false
+	i.InputSourceChanged = false
 }
 
 func (i *InputState) appendRune(r rune) {
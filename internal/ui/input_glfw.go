@@ -12,7 +12,7 @@
 // See the License for the specific language governing permissions and
 // limitations under the License.
 
-//go:build !android && !ios && !js && !nintendosdk && !playstation5
+//go:build !android && !ios && !js && !nintendosdk && !playstation5 && !wasip1
 
 package ui
 
@@ -41,6 +41,27 @@ func (u *UserInterface) registerInputCallbacks() error {
 		return err
 	}
 
+	if _, err := u.window.SetFocusCallback(func(w *glfw.Window, focused bool) {
+		// As this function is called from GLFW callbacks, the current thread is main.
+		if focused {
+			return
+		}
+		// GLFW releases the captured cursor while the window is unfocused, and captures it again when the window is focused.
+		mode, err := w.GetInputMode(glfw.CursorMode)
+		if err != nil {
+			u.setError(err)
+			return
+		}
+		if mode != glfw.CursorDisabled {
+			return
+		}
+		u.m.Lock()
+		defer u.m.Unlock()
+		u.inputState.CursorCaptureInterruptions = append(u.inputState.CursorCaptureInterruptions, CursorCaptureInterruptionFocusLost)
+	}); err != nil {
+		return err
+	}
+
 	if _, err := u.window.SetScrollCallback(func(w *glfw.Window, xoff float64, yoff float64) {
 		// As this function is called from GLFW callbacks, the current thread is main.
 		u.m.Lock()
@@ -87,6 +108,7 @@ func (u *UserInterface) updateInputStateImpl() error {
 		return err
 	}
 	s := m.DeviceScaleFactor()
+	u.inputState.WindowMonitor = m
 
 	cx, cy := u.savedCursorX, u.savedCursorY
 	defer func() {
@@ -114,8 +136,13 @@ func (u *UserInterface) updateInputStateImpl() error {
 	// AdjustPosition can return NaN at the initialization.
 	if !math.IsNaN(cx) && !math.IsNaN(cy) {
 		u.inputState.CursorX, u.inputState.CursorY = cx, cy
+		if err := u.updateMousePassthroughByRegions(cx, cy); err != nil {
+			return err
+		}
 	}
 
+	u.inputSource.update(&u.inputState, currentInputSource)
+
 	if err := gamepad.Update(); err != nil {
 		return err
 	}
@@ -184,8 +184,9 @@ func (u *UserInterface) setMouseCursorFromEvent(e js.Value) {
 		return
 	}
 
-	u.origCursorXInClient = e.Get("clientX").Float()
-	u.origCursorYInClient = e.Get("clientY").Float()
+	ox, oy := u.canvasOffset()
+	u.origCursorXInClient = e.Get("clientX").Float() - ox
+	u.origCursorYInClient = e.Get("clientY").Float() - oy
 
 	if u.cursorMode == CursorModeCaptured {
 		u.cursorXInClient += e.Get("movementX").Float()
@@ -205,13 +206,15 @@ func (u *UserInterface) recoverCursorPosition() {
 func (u *UserInterface) updateTouchesFromEvent(e js.Value) {
 	u.touchesInClient = u.touchesInClient[:0]
 
+	ox, oy := u.canvasOffset()
 	touches := e.Get("targetTouches")
 	for i := 0; i < touches.Length(); i++ {
-		t := touches.Call("item", i)
+		// Use Index instead of item() as touches can be a plain array proxied from the main thread on Web Workers.
+		t := touches.Index(i)
 		u.touchesInClient = append(u.touchesInClient, touchInClient{
 			id: TouchID(t.Get("identifier").Int()),
-			x:  t.Get("clientX").Float(),
-			y:  t.Get("clientY").Float(),
+			x:  t.Get("clientX").Float() - ox,
+			y:  t.Get("clientY").Float() - oy,
 		})
 	}
 }
@@ -0,0 +1,125 @@
+// Copyright 2026 The Ebitengine Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ui_test
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/duplicants-ai/ebiten/internal/ui"
+)
+
+func TestCopyAndResetCursorCaptureInterruptions(t *testing.T) {
+	src := ui.InputState{
+		CursorCaptureInterruptions: []ui.CursorCaptureInterruption{
+			ui.CursorCaptureInterruptionExited,
+			ui.CursorCaptureInterruptionFocusLost,
+		},
+	}
+	var dst ui.InputState
+	src.CopyAndResetForTesting(&dst)
+	want := []ui.CursorCaptureInterruption{
+		ui.CursorCaptureInterruptionExited,
+		ui.CursorCaptureInterruptionFocusLost,
+	}
+	if !slices.Equal(dst.CursorCaptureInterruptions, want) {
+		t.Errorf("got: %v, want: %v", dst.CursorCaptureInterruptions, want)
+	}
+
+	// The interruptions are reported only once.
+	src.CopyAndResetForTesting(&dst)
+	if len(dst.CursorCaptureInterruptions) != 0 {
+		t.Errorf("got: %v, want: empty", dst.CursorCaptureInterruptions)
+	}
+}
+
+func TestInputSourceState(t *testing.T) {
+	var s ui.InputSourceStateForTesting
+	var state ui.InputState
+
+	var calls int
+	unavailable := func() (ui.InputSource, bool) {
+		calls++
+		return ui.InputSource{}, false
+	}
+	us := ui.InputSource{
+		ID:       "com.apple.keylayout.US",
+		Language: "en-US",
+	}
+	current := func() (ui.InputSource, bool) {
+		calls++
+		return us, true
+	}
+
+	// Until the input source is available, it is queried at every update.
+	s.Update(&state, unavailable)
+	s.Update(&state, unavailable)
+	if got, want := calls, 2; got != want {
+		t.Errorf("calls: got: %d, want: %d", got, want)
+	}
+	if got, want := state.InputSource, (ui.InputSource{}); got != want {
+		t.Errorf("InputSource: got: %v, want: %v", got, want)
+	}
+
+	// The first available input source is not reported as a change.
+	s.Update(&state, current)
+	if got, want := state.InputSource, us; got != want {
+		t.Errorf("InputSource: got: %v, want: %v", got, want)
+	}
+	if state.InputSourceChanged {
+		t.Errorf("InputSourceChanged: got: true, want: false")
+	}
+
+	// Once available, the input source is polled at an interval.
+	calls = 0
+	s.Update(&state, current)
+	if got, want := calls, 0; got != want {
+		t.Errorf("calls within the polling interval: got: %d, want: %d", got, want)
+	}
+
+	s.SetInputSource(&state, us)
+	if state.InputSourceChanged {
+		t.Errorf("InputSourceChanged with the same input source: got: true, want: false")
+	}
+
+	ja := ui.InputSource{
+		ID:       "com.apple.inputmethod.Kotoeri.RomajiTyping.Japanese",
+		Language: "ja",
+	}
+	s.SetInputSource(&state, ja)
+	if got, want := state.InputSource, ja; got != want {
+		t.Errorf("InputSource: got: %v, want: %v", got, want)
+	}
+	if !state.InputSourceChanged {
+		t.Errorf("InputSourceChanged: got: false, want: true")
+	}
+
+	// The change is reported only once, while the input source is kept.
+	var dst ui.InputState
+	state.CopyAndResetForTesting(&dst)
+	if got, want := dst.InputSource, ja; got != want {
+		t.Errorf("copied InputSource: got: %v, want: %v", got, want)
+	}
+	if !dst.InputSourceChanged {
+		t.Errorf("copied InputSourceChanged: got: false, want: true")
+	}
+	state.CopyAndResetForTesting(&dst)
+	if got, want := dst.InputSource, ja; got != want {
+		t.Errorf("copied InputSource at the next tick: got: %v, want: %v", got, want)
+	}
+	if dst.InputSourceChanged {
+		t.Errorf("copied InputSourceChanged at the next tick: got: true, want: false")
+	}
+}
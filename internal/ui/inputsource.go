@@ -0,0 +1,65 @@
+// Copyright 2026 The Ebitengine Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ui
+
+import (
+	"time"
+)
+
+// InputSource represents a keyboard input source, i.e. a keyboard layout or an input method.
+type InputSource struct {
+	// ID is a platform-specific identifier of the input source.
+	ID string
+
+	// Language is a BCP 47 language tag of the input source, or an empty string if unknown.
+	Language string
+}
+
+// inputSourcePollingInterval is the interval to query the current input source.
+const inputSourcePollingInterval = 500 * time.Millisecond
+
+type inputSourceState struct {
+	lastPolled  time.Time
+	initialized bool
+}
+
+// update updates the input source in inputState if needed.
+// current is called to query the current input source, and returns false if the input source is not available.
+func (i *inputSourceState) update(inputState *InputState, current func() (InputSource, bool)) {
+	now := time.Now()
+	if i.initialized && now.Sub(i.lastPolled) < inputSourcePollingInterval {
+		return
+	}
+	i.lastPolled = now
+
+	s, ok := current()
+	if !ok {
+		return
+	}
+	i.setInputSource(inputState, s)
+}
+
+func (i *inputSourceState) setInputSource(inputState *InputState, inputSource InputSource) {
+	if !i.initialized {
+		inputState.InputSource = inputSource
+		i.initialized = true
+		return
+	}
+	if inputState.InputSource == inputSource {
+		return
+	}
+	inputState.InputSource = inputSource
+	inputState.InputSourceChanged = true
+}
@@ -0,0 +1,97 @@
+// Copyright 2026 The Ebitengine Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build !ios && !nintendosdk && !playstation5
+
+package ui
+
+import (
+	"sync"
+	"unsafe"
+
+	"github.com/ebitengine/purego"
+	"github.com/ebitengine/purego/objc"
+
+	"github.com/duplicants-ai/ebiten/internal/cocoa"
+)
+
+// Text Input Sources Services' functions return CoreFoundation objects, which are toll-free bridged with Foundation objects.
+var (
+	_TISCopyCurrentKeyboardInputSource func() uintptr
+	_TISGetInputSourceProperty         func(inputSource uintptr, propertyKey uintptr) uintptr
+
+	kTISPropertyInputSourceID        uintptr
+	kTISPropertyInputSourceLanguages uintptr
+
+	tisOnce sync.Once
+	tisErr  error
+)
+
+func initializeTIS() error {
+	tisOnce.Do(func() {
+		carbon, err := purego.Dlopen("/System/Library/Frameworks/Carbon.framework/Carbon", purego.RTLD_LAZY|purego.RTLD_GLOBAL)
+		if err != nil {
+			tisErr = err
+			return
+		}
+		purego.RegisterLibFunc(&_TISCopyCurrentKeyboardInputSource, carbon, "TISCopyCurrentKeyboardInputSource")
+		purego.RegisterLibFunc(&_TISGetInputSourceProperty, carbon, "TISGetInputSourceProperty")
+
+		kTISPropertyInputSourceID, err = purego.Dlsym(carbon, "kTISPropertyInputSourceID")
+		if err != nil {
+			tisErr = err
+			return
+		}
+		kTISPropertyInputSourceLanguages, err = purego.Dlsym(carbon, "kTISPropertyInputSourceLanguages")
+		if err != nil {
+			tisErr = err
+			return
+		}
+	})
+	return tisErr
+}
+
+// currentInputSource must be called from the main thread.
+func currentInputSource() (InputSource, bool) {
+	if err := initializeTIS(); err != nil {
+		return InputSource{}, false
+	}
+
+	pool := cocoa.NSAutoreleasePool_new()
+	defer pool.Release()
+
+	source := _TISCopyCurrentKeyboardInputSource()
+	if source == 0 {
+		return InputSource{}, false
+	}
+	defer objc.ID(source).Send(sel_release)
+
+	// The input source ID is a reverse-DNS string like "com.apple.keylayout.US".
+	id := objc.ID(_TISGetInputSourceProperty(source, **(**uintptr)(unsafe.Pointer(&kTISPropertyInputSourceID))))
+	if id == 0 {
+		return InputSource{}, false
+	}
+	s := InputSource{
+		ID: cocoa.NSString{ID: id}.String(),
+	}
+
+	// The languages are ordered by the relevance. Take the first one.
+	if langs := objc.ID(_TISGetInputSourceProperty(source, **(**uintptr)(unsafe.Pointer(&kTISPropertyInputSourceLanguages)))); langs != 0 {
+		if langs.Send(sel_count) > 0 {
+			s.Language = cocoa.NSString{ID: langs.Send(sel_objectAtIndex, 0)}.String()
+		}
+	}
+
+	return s, true
+}
@@ -0,0 +1,62 @@
+// Copyright 2026 The Ebitengine Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ui
+
+import (
+	"strings"
+	"syscall/js"
+)
+
+// inputSourceSignatureCodes are the key codes whose characters identify a keyboard layout.
+// For example, QWERTY, AZERTY, QWERTZ and Dvorak have different characters for these keys.
+var inputSourceSignatureCodes = []string{
+	"KeyQ", "KeyW", "KeyY", "KeyZ", "KeyA", "KeyM", "Semicolon", "BracketLeft", "Quote",
+}
+
+// updateInputSource queries the current keyboard layout asynchronously.
+//
+// Browsers don't expose the identifier nor the language of the input source.
+// Instead, the characters of some keys of the keyboard layout map are used as the identifier.
+func (u *UserInterface) updateInputSource() {
+	if !jsKeyboard.Truthy() {
+		return
+	}
+	if u.inputSourceQuerying {
+		return
+	}
+	u.inputSource.update(&u.inputState, func() (InputSource, bool) {
+		u.inputSourceQuerying = true
+		if !u.inputSourceCallback.Truthy() {
+			u.inputSourceCallback = js.FuncOf(func(this js.Value, args []js.Value) any {
+				u.inputSourceQuerying = false
+				m := args[0]
+				var sb strings.Builder
+				for _, code := range inputSourceSignatureCodes {
+					if v := m.Call("get", code); !v.IsUndefined() {
+						sb.WriteString(v.String())
+					}
+					sb.WriteByte(' ')
+				}
+				u.inputSource.setInputSource(&u.inputState, InputSource{
+					ID: strings.TrimRight(sb.String(), " "),
+				})
+				return nil
+			})
+		}
+		jsKeyboardGetLayoutMap.Invoke().Call("then", u.inputSourceCallback)
+		// The result is set by the callback later.
+		return InputSource{}, false
+	})
+}
@@ -0,0 +1,34 @@
+// Copyright 2026 The Ebitengine Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build (freebsd || (linux && !android) || netbsd || openbsd) && !nintendosdk && !playstation5
+
+package ui
+
+import (
+	"github.com/duplicants-ai/ebiten/internal/glfw"
+)
+
+// currentInputSource must be called from the main thread.
+func currentInputSource() (InputSource, bool) {
+	// The XKB group name is a human-readable name like "English (US)".
+	// X11 doesn't provide a language of a keyboard layout.
+	name, err := glfw.GetX11KeyboardLayoutName()
+	if err != nil || name == "" {
+		return InputSource{}, false
+	}
+	return InputSource{
+		ID: name,
+	}, true
+}
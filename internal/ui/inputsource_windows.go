@@ -0,0 +1,42 @@
+// Copyright 2026 The Ebitengine Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build !nintendosdk && !playstation5
+
+package ui
+
+import (
+	"fmt"
+
+	"golang.org/x/sys/windows"
+)
+
+// currentInputSource must be called from the main thread.
+func currentInputSource() (InputSource, bool) {
+	// GetKeyboardLayout with 0 returns the layout of the current thread, which is the window's thread.
+	hkl := windows.GetKeyboardLayout(0)
+	if hkl == 0 {
+		return InputSource{}, false
+	}
+
+	s := InputSource{
+		// The upper word is a device handle, which distinguishes keyboard layouts and IMEs for the same language.
+		ID: fmt.Sprintf("%08x", uint32(hkl)),
+	}
+	// The lower word is a language identifier.
+	if name, err := _LCIDToLocaleName(uint32(hkl) & 0xffff); err == nil {
+		s.Language = name
+	}
+	return s, true
+}
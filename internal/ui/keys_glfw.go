@@ -14,7 +14,7 @@
 
 // Code generated by genkeys.go using 'go generate'. DO NOT EDIT.
 
-//go:build !android && !ios && !js && !nintendosdk && !playstation5
+//go:build !android && !ios && !js && !nintendosdk && !playstation5 && !wasip1
 
 package ui
 
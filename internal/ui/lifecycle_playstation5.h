@@ -0,0 +1,27 @@
+// Copyright 2026 The Ebitengine Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build playstation5
+
+#ifdef __cplusplus
+extern "C" {
+#endif
+
+// ebitengine_PollResumed returns 1 if the application was resumed from the
+// suspended state since the last call, or 0 otherwise.
+int ebitengine_PollResumed();
+
+#ifdef __cplusplus
+} // extern "C"
+#endif
@@ -12,7 +12,7 @@
 // See the License for the specific language governing permissions and
 // limitations under the License.
 
-//go:build !android && !ios && !js && !nintendosdk && !playstation5
+//go:build !android && !ios && !js && !nintendosdk && !playstation5 && !wasip1
 
 package ui
 
@@ -45,6 +45,14 @@ func (m *Monitor) DeviceScaleFactor() float64 {
 	return m.contentScale
 }
 
+// RefreshRate returns the refresh rate of the monitor in Hz, or 0 if the refresh rate is unknown.
+func (m *Monitor) RefreshRate() int {
+	if m.videoMode == nil {
+		return 0
+	}
+	return m.videoMode.RefreshRate
+}
+
 // Size returns the size of the monitor in device-independent pixels.
 func (m *Monitor) Size() (int, int) {
 	w, h := m.sizeInDIP()
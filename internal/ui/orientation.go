@@ -0,0 +1,41 @@
+// Copyright 2026 The Ebitengine Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ui
+
+type Orientation int
+
+const (
+	OrientationUnknown Orientation = iota
+	OrientationPortrait
+	OrientationPortraitUpsideDown
+	OrientationLandscapeLeft
+	OrientationLandscapeRight
+)
+
+// Insets represents insets in device-independent pixels.
+type Insets struct {
+	Left   float64
+	Top    float64
+	Right  float64
+	Bottom float64
+}
+
+type MultiWindowMode int
+
+const (
+	MultiWindowModeNone MultiWindowMode = iota
+	MultiWindowModeMultiWindow
+	MultiWindowModePictureInPicture
+)
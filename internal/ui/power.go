@@ -0,0 +1,236 @@
+// Copyright 2026 The Ebitengine Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ui
+
+import (
+	"sync"
+	"time"
+
+	"github.com/duplicants-ai/ebiten/internal/clock"
+)
+
+type BatteryState int
+
+const (
+	BatteryStateUnknown BatteryState = iota
+	BatteryStateNoBattery
+	BatteryStateCharging
+	BatteryStateDischarging
+	BatteryStateFull
+)
+
+type ThermalState int
+
+const (
+	ThermalStateUnknown ThermalState = iota
+	ThermalStateNominal
+	ThermalStateFair
+	ThermalStateSerious
+	ThermalStateCritical
+)
+
+// PowerStatus represents the state of the power source and the thermal pressure.
+type PowerStatus struct {
+	BatteryState BatteryState
+
+	// BatteryLevel is the battery level in [0, 1], or -1 if unknown.
+	BatteryLevel float64
+
+	// PowerSaving reports whether the OS's power saving mode is on.
+	PowerSaving bool
+
+	ThermalState ThermalState
+}
+
+var defaultPowerStatus = PowerStatus{
+	BatteryLevel: -1,
+}
+
+// powerStatusPollingInterval is the interval to query the power status.
+// The power status changes slowly, and querying it might be relatively expensive, so the interval is longer than the system settings'.
+const powerStatusPollingInterval = 5 * time.Second
+
+type powerStatusState struct {
+	current     PowerStatus
+	initialized bool
+	changed     bool
+
+	pollOnce sync.Once
+	m        sync.Mutex
+}
+
+func (p *powerStatusState) set(status PowerStatus) {
+	p.m.Lock()
+	defer p.m.Unlock()
+
+	if !p.initialized {
+		p.current = status
+		p.initialized = true
+		return
+	}
+	if p.current == status {
+		return
+	}
+	p.current = status
+	p.changed = true
+}
+
+func (p *powerStatusState) startPolling() {
+	p.pollOnce.Do(func() {
+		status, ok := powerStatusForOS()
+		if !ok {
+			// The status might be given by the host, e.g. a mobile view.
+			return
+		}
+		p.set(status)
+		go p.poll()
+	})
+}
+
+func (p *powerStatusState) poll() {
+	for {
+		time.Sleep(powerStatusPollingInterval)
+		status, ok := powerStatusForOS()
+		if !ok {
+			continue
+		}
+		p.set(status)
+	}
+}
+
+func (p *powerStatusState) get() PowerStatus {
+	p.startPolling()
+
+	p.m.Lock()
+	defer p.m.Unlock()
+
+	if !p.initialized {
+		return defaultPowerStatus
+	}
+	return p.current
+}
+
+// readAndReset copies the current status to inputState and resets the changed flag.
+func (p *powerStatusState) readAndReset(inputState *InputState) {
+	p.startPolling()
+
+	p.m.Lock()
+	defer p.m.Unlock()
+
+	if p.initialized {
+		inputState.PowerStatus = p.current
+	} else {
+		inputState.PowerStatus = defaultPowerStatus
+	}
+	inputState.PowerStatusChanged = p.changed
+	p.changed = false
+}
+
+// SetPowerStatus is called from mobile/ebitenmobileview.
+//
+// SetPowerStatus is concurrent safe.
+func (u *UserInterface) SetPowerStatus(status PowerStatus) {
+	u.powerStatus.set(status)
+}
+
+// LowPowerModeOptions represents when the low-power mode is activated and what the low-power mode does.
+type LowPowerModeOptions struct {
+	OnBattery     bool
+	OnPowerSaving bool
+	ThermalState  ThermalState
+
+	TPS             int
+	MaxFrameRate    int
+	ResolutionScale float64
+}
+
+func (o *LowPowerModeOptions) shouldActivate(status PowerStatus) bool {
+	if o.OnBattery && status.BatteryState == BatteryStateDischarging {
+		return true
+	}
+	if o.OnPowerSaving && status.PowerSaving {
+		return true
+	}
+	if o.ThermalState != ThermalStateUnknown && status.ThermalState >= o.ThermalState {
+		return true
+	}
+	return false
+}
+
+type lowPowerModeState struct {
+	options *LowPowerModeOptions
+	active  bool
+
+	m sync.Mutex
+}
+
+// SetLowPowerModeOptions sets the options of the automatic low-power mode. nil disables the low-power mode.
+//
+// SetLowPowerModeOptions is concurrent safe.
+func (u *UserInterface) SetLowPowerModeOptions(options *LowPowerModeOptions) {
+	u.lowPowerMode.m.Lock()
+	defer u.lowPowerMode.m.Unlock()
+	if options == nil {
+		u.lowPowerMode.options = nil
+		return
+	}
+	o := *options
+	u.lowPowerMode.options = &o
+}
+
+// IsLowPowerModeActive reports whether the low-power mode is active.
+//
+// IsLowPowerModeActive is concurrent safe.
+func (u *UserInterface) IsLowPowerModeActive() bool {
+	u.lowPowerMode.m.Lock()
+	defer u.lowPowerMode.m.Unlock()
+	return u.lowPowerMode.active
+}
+
+// updateLowPowerMode activates or deactivates the low-power mode based on the current power status.
+// updateLowPowerMode is called once per frame.
+func (u *UserInterface) updateLowPowerMode() {
+	status := u.powerStatus.get()
+
+	u.lowPowerMode.m.Lock()
+	defer u.lowPowerMode.m.Unlock()
+
+	o := u.lowPowerMode.options
+	u.lowPowerMode.active = o != nil && o.shouldActivate(status)
+
+	var tpsLimit int
+	if u.lowPowerMode.active {
+		tpsLimit = o.TPS
+	}
+	// The tick time for Clock is accumulated per tick with the limited TPS, so changing the limit doesn't rescale the past ticks.
+	clock.SetTPSLimit(tpsLimit)
+}
+
+// lowPowerModeEffects returns the maximum frame rate and the resolution scale in the current low-power mode.
+// 0 for the frame rate means no limit.
+func (u *UserInterface) lowPowerModeEffects() (maxFrameRate int, resolutionScale float64) {
+	u.lowPowerMode.m.Lock()
+	defer u.lowPowerMode.m.Unlock()
+
+	if !u.lowPowerMode.active {
+		return 0, 1
+	}
+	o := u.lowPowerMode.options
+	resolutionScale = o.ResolutionScale
+	if resolutionScale <= 0 || resolutionScale > 1 {
+		resolutionScale = 1
+	}
+	return o.MaxFrameRate, resolutionScale
+}
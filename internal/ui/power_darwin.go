@@ -0,0 +1,149 @@
+// Copyright 2026 The Ebitengine Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build !ios && !nintendosdk && !playstation5
+
+package ui
+
+import (
+	"sync"
+
+	"github.com/ebitengine/purego"
+	"github.com/ebitengine/purego/objc"
+
+	"github.com/duplicants-ai/ebiten/internal/cocoa"
+)
+
+var (
+	sel_boolValue     = objc.RegisterName("boolValue")
+	sel_count         = objc.RegisterName("count")
+	sel_intValue      = objc.RegisterName("intValue")
+	sel_objectAtIndex = objc.RegisterName("objectAtIndex:")
+	sel_objectForKey  = objc.RegisterName("objectForKey:")
+)
+
+// IOKit's power source functions return CoreFoundation objects, which are toll-free bridged with Foundation objects.
+var (
+	_IOPSCopyPowerSourcesInfo      func() uintptr
+	_IOPSCopyPowerSourcesList      func(blob uintptr) uintptr
+	_IOPSGetPowerSourceDescription func(blob uintptr, ps uintptr) uintptr
+
+	ioKitOnce sync.Once
+	ioKitErr  error
+)
+
+func initializeIOKit() error {
+	ioKitOnce.Do(func() {
+		iokit, err := purego.Dlopen("/System/Library/Frameworks/IOKit.framework/IOKit", purego.RTLD_LAZY|purego.RTLD_GLOBAL)
+		if err != nil {
+			ioKitErr = err
+			return
+		}
+		purego.RegisterLibFunc(&_IOPSCopyPowerSourcesInfo, iokit, "IOPSCopyPowerSourcesInfo")
+		purego.RegisterLibFunc(&_IOPSCopyPowerSourcesList, iokit, "IOPSCopyPowerSourcesList")
+		purego.RegisterLibFunc(&_IOPSGetPowerSourceDescription, iokit, "IOPSGetPowerSourceDescription")
+	})
+	return ioKitErr
+}
+
+// dictionaryValue returns the value for the key in the NSDictionary, or 0 if not found.
+func dictionaryValue(dict objc.ID, key string) objc.ID {
+	k := cocoa.NSString_alloc().InitWithUTF8String(key)
+	defer k.Send(sel_release)
+	return dict.Send(sel_objectForKey, k.ID)
+}
+
+func batteryStatus(status *PowerStatus) {
+	if err := initializeIOKit(); err != nil {
+		return
+	}
+
+	blob := _IOPSCopyPowerSourcesInfo()
+	if blob == 0 {
+		return
+	}
+	defer objc.ID(blob).Send(sel_release)
+
+	list := _IOPSCopyPowerSourcesList(blob)
+	if list == 0 {
+		return
+	}
+	defer objc.ID(list).Send(sel_release)
+
+	status.BatteryState = BatteryStateNoBattery
+	n := int(objc.ID(list).Send(sel_count))
+	for i := 0; i < n; i++ {
+		ps := objc.ID(list).Send(sel_objectAtIndex, i)
+		desc := objc.ID(_IOPSGetPowerSourceDescription(blob, uintptr(ps)))
+		if desc == 0 {
+			continue
+		}
+		typ := dictionaryValue(desc, "Type")
+		if typ == 0 || (cocoa.NSString{ID: typ}).String() != "InternalBattery" {
+			continue
+		}
+
+		current := dictionaryValue(desc, "Current Capacity")
+		maximum := dictionaryValue(desc, "Max Capacity")
+		if current != 0 && maximum != 0 {
+			if m := int32(maximum.Send(sel_intValue)); m > 0 {
+				status.BatteryLevel = float64(int32(current.Send(sel_intValue))) / float64(m)
+			}
+		}
+
+		var onAC bool
+		if state := dictionaryValue(desc, "Power Source State"); state != 0 {
+			onAC = (cocoa.NSString{ID: state}).String() == "AC Power"
+		}
+		var charging bool
+		if c := dictionaryValue(desc, "Is Charging"); c != 0 {
+			charging = objc.Send[bool](c, sel_boolValue)
+		}
+		switch {
+		case charging:
+			status.BatteryState = BatteryStateCharging
+		case onAC:
+			status.BatteryState = BatteryStateFull
+		default:
+			status.BatteryState = BatteryStateDischarging
+		}
+		return
+	}
+}
+
+func powerStatusForOS() (PowerStatus, bool) {
+	pool := cocoa.NSAutoreleasePool_new()
+	defer pool.Release()
+
+	status := defaultPowerStatus
+
+	batteryStatus(&status)
+
+	// NSProcessInfo is thread-safe.
+	info := cocoa.NSProcessInfo_processInfo()
+	status.PowerSaving = info.IsLowPowerModeEnabled()
+	// NSProcessInfoThermalState: 0: nominal, 1: fair, 2: serious, 3: critical
+	switch info.ThermalState() {
+	case 0:
+		status.ThermalState = ThermalStateNominal
+	case 1:
+		status.ThermalState = ThermalStateFair
+	case 2:
+		status.ThermalState = ThermalStateSerious
+	case 3:
+		status.ThermalState = ThermalStateCritical
+	}
+
+	return status, true
+}
@@ -0,0 +1,64 @@
+// Copyright 2026 The Ebitengine Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ui
+
+import (
+	"sync/atomic"
+	"syscall/js"
+)
+
+// batteryManager is a BatteryManager object, or undefined if the Battery Status API is not available.
+//
+// See https://developer.mozilla.org/en-US/docs/Web/API/BatteryManager
+var batteryManager atomic.Pointer[js.Value]
+
+func init() {
+	navigator := js.Global().Get("navigator")
+	if !navigator.Truthy() || !navigator.Get("getBattery").Truthy() {
+		return
+	}
+
+	// getBattery returns a promise. The battery status is unknown until the promise is resolved.
+	var f js.Func
+	f = js.FuncOf(func(this js.Value, args []js.Value) any {
+		m := args[0]
+		batteryManager.Store(&m)
+		f.Release()
+		return nil
+	})
+	navigator.Call("getBattery").Call("then", f)
+}
+
+func powerStatusForOS() (PowerStatus, bool) {
+	status := defaultPowerStatus
+
+	m := batteryManager.Load()
+	if m == nil {
+		// Browsers don't expose the power saving mode and the thermal state.
+		return status, true
+	}
+
+	level := m.Get("level").Float()
+	status.BatteryLevel = level
+	switch {
+	case m.Get("charging").Bool() && level >= 1:
+		status.BatteryState = BatteryStateFull
+	case m.Get("charging").Bool():
+		status.BatteryState = BatteryStateCharging
+	default:
+		status.BatteryState = BatteryStateDischarging
+	}
+	return status, true
+}
@@ -0,0 +1,129 @@
+// Copyright 2026 The Ebitengine Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build (freebsd || (linux && !android) || netbsd || openbsd) && !nintendosdk && !playstation5
+
+package ui
+
+import (
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/duplicants-ai/ebiten/internal/dbus"
+)
+
+const powerSupplyDir = "/sys/class/power_supply"
+
+func readSysfsString(path string) string {
+	b, err := os.ReadFile(path)
+	if err != nil {
+		return ""
+	}
+	return strings.TrimSpace(string(b))
+}
+
+// readPowerProfile reads a property of the power profiles daemon.
+//
+// See https://gitlab.freedesktop.org/upower/power-profiles-daemon
+func readPowerProfile(conn *dbus.Conn, name string) (string, bool) {
+	for _, dest := range []string{"org.freedesktop.UPower.PowerProfiles", "net.hadess.PowerProfiles"} {
+		path := dbus.ObjectPath("/" + strings.ReplaceAll(dest, ".", "/"))
+		r, err := conn.Call(dest, path, "org.freedesktop.DBus.Properties", "Get", dest, name)
+		if err != nil || len(r) != 1 {
+			continue
+		}
+		v, ok := r[0].(dbus.Variant)
+		if !ok {
+			continue
+		}
+		s, ok := v.Value.(string)
+		if !ok {
+			continue
+		}
+		return s, true
+	}
+	return "", false
+}
+
+func powerStatusForOS() (PowerStatus, bool) {
+	status := defaultPowerStatus
+
+	// The power supplies are available only on Linux.
+	entries, err := os.ReadDir(powerSupplyDir)
+	if err == nil {
+		var hasBattery bool
+		var online bool
+		var capacity, capacityCount int
+		var charging, discharging, full bool
+		for _, e := range entries {
+			dir := filepath.Join(powerSupplyDir, e.Name())
+			switch readSysfsString(filepath.Join(dir, "type")) {
+			case "Mains":
+				if readSysfsString(filepath.Join(dir, "online")) == "1" {
+					online = true
+				}
+			case "Battery":
+				// Skip batteries of peripherals like mice.
+				if readSysfsString(filepath.Join(dir, "scope")) == "Device" {
+					continue
+				}
+				hasBattery = true
+				switch readSysfsString(filepath.Join(dir, "status")) {
+				case "Charging":
+					charging = true
+				case "Discharging":
+					discharging = true
+				case "Full":
+					full = true
+				}
+				if c, err := strconv.Atoi(readSysfsString(filepath.Join(dir, "capacity"))); err == nil {
+					capacity += c
+					capacityCount++
+				}
+			}
+		}
+
+		switch {
+		case !hasBattery:
+			status.BatteryState = BatteryStateNoBattery
+		case charging:
+			status.BatteryState = BatteryStateCharging
+		case discharging && !online:
+			status.BatteryState = BatteryStateDischarging
+		case full || online:
+			status.BatteryState = BatteryStateFull
+		}
+		if capacityCount > 0 {
+			status.BatteryLevel = float64(capacity) / float64(capacityCount) / 100
+		}
+	}
+
+	if conn, err := dbus.SystemBus(); err == nil {
+		if p, ok := readPowerProfile(conn, "ActiveProfile"); ok {
+			status.PowerSaving = p == "power-saver"
+		}
+		// PerformanceDegraded is a reason why the performance profile is degraded, or an empty string.
+		if r, ok := readPowerProfile(conn, "PerformanceDegraded"); ok {
+			if r == "high-operating-temperature" {
+				status.ThermalState = ThermalStateSerious
+			} else {
+				status.ThermalState = ThermalStateNominal
+			}
+		}
+	}
+
+	return status, true
+}
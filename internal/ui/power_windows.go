@@ -0,0 +1,58 @@
+// Copyright 2026 The Ebitengine Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build !nintendosdk && !playstation5
+
+package ui
+
+import (
+	"github.com/duplicants-ai/ebiten/internal/microsoftgdk"
+)
+
+func powerStatusForOS() (PowerStatus, bool) {
+	if microsoftgdk.IsXbox() {
+		return PowerStatus{}, false
+	}
+
+	s, err := _GetSystemPowerStatus()
+	if err != nil {
+		return PowerStatus{}, false
+	}
+
+	status := defaultPowerStatus
+
+	switch {
+	case s.BatteryFlag == _BATTERY_FLAG_UNKNOWN:
+		status.BatteryState = BatteryStateUnknown
+	case s.BatteryFlag&_BATTERY_FLAG_NO_BATTERY != 0:
+		status.BatteryState = BatteryStateNoBattery
+	case s.BatteryFlag&_BATTERY_FLAG_CHARGING != 0:
+		status.BatteryState = BatteryStateCharging
+	case s.ACLineStatus == _AC_LINE_ONLINE:
+		// The battery is plugged in but not charging, which means the battery is full.
+		status.BatteryState = BatteryStateFull
+	default:
+		status.BatteryState = BatteryStateDischarging
+	}
+
+	if status.BatteryState != BatteryStateNoBattery && s.BatteryLifePercent != _BATTERY_PERCENTAGE_UNKNOWN {
+		status.BatteryLevel = float64(s.BatteryLifePercent) / 100
+	}
+
+	// SystemStatusFlag is 1 when the battery saver is on.
+	status.PowerSaving = s.SystemStatusFlag == 1
+
+	// Windows doesn't have a public API for the thermal state.
+	return status, true
+}
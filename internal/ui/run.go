@@ -27,7 +27,7 @@ import (
 )
 
 func (u *UserInterface) Run(game Game, options *RunOptions) error {
-	if options.SingleThread || buildTagSingleThread || runtime.GOOS == "js" {
+	if options.SingleThread || buildTagSingleThread || runtime.GOOS == "js" || runtime.GOOS == "wasip1" {
 		return u.runSingleThread(game, options)
 	}
 	return u.runMultiThread(game, options)
@@ -37,7 +37,7 @@ func (u *UserInterface) runMultiThread(game Game, options *RunOptions) error {
 	u.mainThread = thread.NewOSThread()
 	graphicscommand.SetOSThreadAsRenderThread()
 
-	u.context = newContext(game)
+	u.context = newContext(game, options.AsyncUpdate)
 
 	ctx, cancel := stdcontext.WithCancel(stdcontext.Background())
 	defer cancel()
@@ -54,6 +54,8 @@ func (u *UserInterface) runMultiThread(game Game, options *RunOptions) error {
 	// Run the game thread.
 	wg.Go(func() error {
 		defer cancel()
+		// Stop Update's goroutine before the main thread and the render thread end.
+		defer u.context.close()
 
 		var err error
 		u.mainThread.Call(func() {
@@ -83,7 +85,10 @@ func (u *UserInterface) runSingleThread(game Game, options *RunOptions) error {
 	u.setRunning(true)
 	defer u.setRunning(false)
 
-	u.context = newContext(game)
+	// As there is no other thread to call the window, input, and graphics functions, Update must run on the main thread.
+	// Then, AsyncUpdate is ignored in the single thread mode.
+	u.context = newContext(game, false)
+	defer u.context.close()
 
 	if err := u.initOnMainThread(options); err != nil {
 		return err
@@ -15,6 +15,9 @@
 package ui
 
 func (u *UserInterface) ScreenSizeInFullscreen() (int, int) {
+	if isWorker {
+		return int(u.worker.outsideWidth), int(u.worker.outsideHeight)
+	}
 	// On browsers, ScreenSizeInFullscreen returns the 'window' (global object) size, not 'screen' size for backward compatibility (#2145).
 	return window.Get("innerWidth").Int(), window.Get("innerHeight").Int()
 }
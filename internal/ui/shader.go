@@ -40,6 +40,11 @@ func NewShader(ir *shaderir.Program, name string) *Shader {
 	}
 }
 
+// VertexFloatCount returns the number of floats in a vertex for the shader.
+func (s *Shader) VertexFloatCount() int {
+	return s.shader.VertexFloatCount()
+}
+
 func (s *Shader) Deallocate() {
 	s.shader.Deallocate()
 }
@@ -0,0 +1,111 @@
+// Copyright 2026 The Ebitengine Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ui
+
+import (
+	"sync"
+	"time"
+)
+
+type ColorScheme int
+
+const (
+	ColorSchemeUnknown ColorScheme = iota
+	ColorSchemeLight
+	ColorSchemeDark
+)
+
+// SystemSettings represents the OS-level settings relevant to games.
+type SystemSettings struct {
+	ColorScheme   ColorScheme
+	ReducedMotion bool
+	HighContrast  bool
+	TextScale     float64
+}
+
+var defaultSystemSettings = SystemSettings{
+	TextScale: 1,
+}
+
+// systemSettingsPollingInterval is the interval to query the system settings.
+// Most of the platforms don't have a handy way to get notified of changes, so the settings are polled.
+const systemSettingsPollingInterval = time.Second
+
+type systemSettingsState struct {
+	current     SystemSettings
+	initialized bool
+	changed     bool
+
+	pollOnce sync.Once
+	m        sync.Mutex
+}
+
+func (s *systemSettingsState) set(settings SystemSettings) {
+	s.m.Lock()
+	defer s.m.Unlock()
+
+	if !s.initialized {
+		s.current = settings
+		s.initialized = true
+		return
+	}
+	if s.current == settings {
+		return
+	}
+	s.current = settings
+	s.changed = true
+}
+
+// readAndReset copies the current settings to inputState and resets the changed flag.
+func (s *systemSettingsState) readAndReset(inputState *InputState) {
+	s.pollOnce.Do(func() {
+		settings, ok := systemSettingsForOS()
+		if !ok {
+			// The settings might be given by the host, e.g. a mobile view.
+			return
+		}
+		s.set(settings)
+		go s.poll()
+	})
+
+	s.m.Lock()
+	defer s.m.Unlock()
+
+	if s.initialized {
+		inputState.SystemSettings = s.current
+	} else {
+		inputState.SystemSettings = defaultSystemSettings
+	}
+	inputState.SystemSettingsChanged = s.changed
+	s.changed = false
+}
+
+func (s *systemSettingsState) poll() {
+	for {
+		time.Sleep(systemSettingsPollingInterval)
+		settings, ok := systemSettingsForOS()
+		if !ok {
+			continue
+		}
+		s.set(settings)
+	}
+}
+
+// SetSystemSettings is called from mobile/ebitenmobileview.
+//
+// SetSystemSettings is concurrent safe.
+func (u *UserInterface) SetSystemSettings(settings SystemSettings) {
+	u.systemSettings.set(settings)
+}
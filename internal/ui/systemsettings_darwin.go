@@ -0,0 +1,60 @@
+// Copyright 2026 The Ebitengine Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build !ios && !nintendosdk && !playstation5
+
+package ui
+
+import (
+	"github.com/ebitengine/purego/objc"
+
+	"github.com/duplicants-ai/ebiten/internal/cocoa"
+)
+
+var (
+	class_NSUserDefaults = objc.GetClass("NSUserDefaults")
+	class_NSWorkspace    = objc.GetClass("NSWorkspace")
+)
+
+var (
+	sel_accessibilityDisplayShouldIncreaseContrast = objc.RegisterName("accessibilityDisplayShouldIncreaseContrast")
+	sel_accessibilityDisplayShouldReduceMotion     = objc.RegisterName("accessibilityDisplayShouldReduceMotion")
+	sel_sharedWorkspace                            = objc.RegisterName("sharedWorkspace")
+	sel_standardUserDefaults                       = objc.RegisterName("standardUserDefaults")
+	sel_stringForKey                               = objc.RegisterName("stringForKey:")
+)
+
+func systemSettingsForOS() (SystemSettings, bool) {
+	pool := cocoa.NSAutoreleasePool_new()
+	defer pool.Release()
+
+	settings := defaultSystemSettings
+
+	// NSUserDefaults is thread-safe.
+	settings.ColorScheme = ColorSchemeLight
+	key := cocoa.NSString_alloc().InitWithUTF8String("AppleInterfaceStyle")
+	defer key.Send(sel_release)
+	if style := objc.ID(class_NSUserDefaults).Send(sel_standardUserDefaults).Send(sel_stringForKey, key.ID); style != 0 {
+		if (cocoa.NSString{ID: style}).String() == "Dark" {
+			settings.ColorScheme = ColorSchemeDark
+		}
+	}
+
+	workspace := objc.ID(class_NSWorkspace).Send(sel_sharedWorkspace)
+	settings.ReducedMotion = objc.Send[bool](workspace, sel_accessibilityDisplayShouldReduceMotion)
+	settings.HighContrast = objc.Send[bool](workspace, sel_accessibilityDisplayShouldIncreaseContrast)
+
+	// macOS doesn't have a system-wide text scale.
+	return settings, true
+}
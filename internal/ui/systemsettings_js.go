@@ -0,0 +1,53 @@
+// Copyright 2026 The Ebitengine Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ui
+
+import (
+	"syscall/js"
+)
+
+var matchMedia = js.Global().Get("matchMedia")
+
+func matchesMedia(query string) bool {
+	return matchMedia.Invoke(query).Get("matches").Bool()
+}
+
+func systemSettingsForOS() (SystemSettings, bool) {
+	// matchMedia is not available on Web Workers.
+	if !matchMedia.Truthy() || !document.Truthy() {
+		return SystemSettings{}, false
+	}
+
+	settings := defaultSystemSettings
+
+	switch {
+	case matchesMedia("(prefers-color-scheme: dark)"):
+		settings.ColorScheme = ColorSchemeDark
+	case matchesMedia("(prefers-color-scheme: light)"):
+		settings.ColorScheme = ColorSchemeLight
+	}
+	settings.ReducedMotion = matchesMedia("(prefers-reduced-motion: reduce)")
+	settings.HighContrast = matchesMedia("(prefers-contrast: more)") || matchesMedia("(forced-colors: active)")
+
+	// The default font size of browsers is 16px, and users can change this in the browser settings.
+	if root := document.Get("documentElement"); root.Truthy() {
+		fontSize := js.Global().Call("getComputedStyle", root).Get("fontSize").String()
+		if size := js.Global().Call("parseFloat", fontSize).Float(); size > 0 {
+			settings.TextScale = size / 16
+		}
+	}
+
+	return settings, true
+}
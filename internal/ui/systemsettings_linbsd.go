@@ -0,0 +1,84 @@
+// Copyright 2026 The Ebitengine Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build (freebsd || (linux && !android) || netbsd || openbsd) && !nintendosdk && !playstation5
+
+package ui
+
+import (
+	"github.com/duplicants-ai/ebiten/internal/dbus"
+)
+
+// readPortalSetting reads a setting by the XDG Desktop Portal.
+//
+// See https://flatpak.github.io/xdg-desktop-portal/docs/doc-org.freedesktop.portal.Settings.html
+func readPortalSetting(conn *dbus.Conn, namespace, key string) (any, bool) {
+	r, err := conn.Call("org.freedesktop.portal.Desktop", "/org/freedesktop/portal/desktop", "org.freedesktop.portal.Settings", "Read", namespace, key)
+	if err != nil || len(r) != 1 {
+		return nil, false
+	}
+	// Read returns a variant wrapped in another variant.
+	v := r[0]
+	for {
+		vv, ok := v.(dbus.Variant)
+		if !ok {
+			break
+		}
+		v = vv.Value
+	}
+	return v, true
+}
+
+func systemSettingsForOS() (SystemSettings, bool) {
+	conn, err := dbus.SessionBus()
+	if err != nil {
+		return SystemSettings{}, false
+	}
+
+	settings := defaultSystemSettings
+
+	// 0: No preference, 1: Prefer dark appearance, 2: Prefer light appearance
+	settings.ColorScheme = ColorSchemeLight
+	if v, ok := readPortalSetting(conn, "org.freedesktop.appearance", "color-scheme"); ok {
+		if v, ok := v.(uint32); ok && v == 1 {
+			settings.ColorScheme = ColorSchemeDark
+		}
+	}
+
+	// 0: No preference, 1: Higher contrast
+	if v, ok := readPortalSetting(conn, "org.freedesktop.appearance", "contrast"); ok {
+		if v, ok := v.(uint32); ok {
+			settings.HighContrast = v == 1
+		}
+	} else if v, ok := readPortalSetting(conn, "org.gnome.desktop.a11y.interface", "high-contrast"); ok {
+		if v, ok := v.(bool); ok {
+			settings.HighContrast = v
+		}
+	}
+
+	// There is no standard setting for the reduced motion. Use GNOME's setting if available.
+	if v, ok := readPortalSetting(conn, "org.gnome.desktop.interface", "enable-animations"); ok {
+		if v, ok := v.(bool); ok {
+			settings.ReducedMotion = !v
+		}
+	}
+
+	if v, ok := readPortalSetting(conn, "org.gnome.desktop.interface", "text-scaling-factor"); ok {
+		if v, ok := v.(float64); ok && v > 0 {
+			settings.TextScale = v
+		}
+	}
+
+	return settings, true
+}
@@ -0,0 +1,83 @@
+// Copyright 2026 The Ebitengine Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build !nintendosdk && !playstation5
+
+package ui
+
+import (
+	"unsafe"
+
+	"golang.org/x/sys/windows"
+
+	"github.com/duplicants-ai/ebiten/internal/microsoftgdk"
+)
+
+func readRegistryDWORD(path, name string) (uint32, bool) {
+	p, err := windows.UTF16PtrFromString(path)
+	if err != nil {
+		return 0, false
+	}
+	n, err := windows.UTF16PtrFromString(name)
+	if err != nil {
+		return 0, false
+	}
+
+	var key windows.Handle
+	if err := windows.RegOpenKeyEx(windows.HKEY_CURRENT_USER, p, 0, windows.KEY_READ, &key); err != nil {
+		return 0, false
+	}
+	defer func() {
+		_ = windows.RegCloseKey(key)
+	}()
+
+	var v uint32
+	var typ uint32
+	size := uint32(unsafe.Sizeof(v))
+	if err := windows.RegQueryValueEx(key, n, nil, &typ, (*byte)(unsafe.Pointer(&v)), &size); err != nil {
+		return 0, false
+	}
+	if typ != windows.REG_DWORD {
+		return 0, false
+	}
+	return v, true
+}
+
+func systemSettingsForOS() (SystemSettings, bool) {
+	if microsoftgdk.IsXbox() {
+		return SystemSettings{}, false
+	}
+
+	settings := defaultSystemSettings
+
+	settings.ColorScheme = ColorSchemeLight
+	if v, ok := readRegistryDWORD(`Software\Microsoft\Windows\CurrentVersion\Themes\Personalize`, "AppsUseLightTheme"); ok && v == 0 {
+		settings.ColorScheme = ColorSchemeDark
+	}
+
+	// TextScaleFactor is a percentage from 100 to 225.
+	if v, ok := readRegistryDWORD(`Software\Microsoft\Accessibility`, "TextScaleFactor"); ok && v > 0 {
+		settings.TextScale = float64(v) / 100
+	}
+
+	if animation, err := _SystemParametersInfoW_Bool(_SPI_GETCLIENTAREAANIMATION); err == nil {
+		settings.ReducedMotion = !animation
+	}
+
+	if hc, err := _SystemParametersInfoW_HighContrast(); err == nil {
+		settings.HighContrast = hc.dwFlags&_HCF_HIGHCONTRASTON != 0
+	}
+
+	return settings, true
+}
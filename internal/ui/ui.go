@@ -17,12 +17,15 @@ package ui
 import (
 	"errors"
 	"image"
+	"runtime"
 	"sync"
 	"sync/atomic"
+	"time"
 
 	_ "github.com/ebitengine/hideconsole"
 
 	"github.com/duplicants-ai/ebiten/internal/atlas"
+	"github.com/duplicants-ai/ebiten/internal/graphicscommand"
 	"github.com/duplicants-ai/ebiten/internal/graphicsdriver"
 	"github.com/duplicants-ai/ebiten/internal/mipmap"
 	"github.com/duplicants-ai/ebiten/internal/thread"
@@ -41,6 +44,24 @@ const (
 	FPSModeVsyncOffMinimum
 )
 
+// VsyncMode is a vsync mode used when the FPS mode is FPSModeVsyncOn.
+type VsyncMode int
+
+const (
+	VsyncModeOn       VsyncMode = VsyncMode(graphicsdriver.VsyncModeOn)
+	VsyncModeOff      VsyncMode = VsyncMode(graphicsdriver.VsyncModeOff)
+	VsyncModeAdaptive VsyncMode = VsyncMode(graphicsdriver.VsyncModeAdaptive)
+	VsyncModeMailbox  VsyncMode = VsyncMode(graphicsdriver.VsyncModeMailbox)
+)
+
+type LatencyMode int
+
+const (
+	LatencyModeDefault LatencyMode = LatencyMode(graphicsdriver.LatencyModeDefault)
+	LatencyModeLow     LatencyMode = LatencyMode(graphicsdriver.LatencyModeLow)
+	LatencyModeSmooth  LatencyMode = LatencyMode(graphicsdriver.LatencyModeSmooth)
+)
+
 type CursorMode int
 
 const (
@@ -49,6 +70,14 @@ const (
 	CursorModeCaptured
 )
 
+type CursorCaptureInterruption int
+
+const (
+	CursorCaptureInterruptionExited CursorCaptureInterruption = iota + 1
+	CursorCaptureInterruptionDenied
+	CursorCaptureInterruptionFocusLost
+)
+
 type CursorShape int
 
 const (
@@ -72,20 +101,42 @@ const (
 	WindowResizingModeEnabled
 )
 
+type WindowProgressState int
+
+const (
+	WindowProgressStateNone WindowProgressState = iota
+	WindowProgressStateNormal
+	WindowProgressStateIndeterminate
+	WindowProgressStatePaused
+	WindowProgressStateError
+)
+
 type UserInterface struct {
 	err  error
 	errM sync.Mutex
 
-	isScreenClearedEveryFrame atomic.Bool
-	graphicsLibrary           atomic.Int32
-	running                   atomic.Bool
-	terminated                atomic.Bool
-	tick                      atomic.Uint64
+	isScreenClearedEveryFrame    atomic.Bool
+	isPhysicalPixelLayoutEnabled atomic.Bool
+	graphicsLibrary              atomic.Int32
+	screenColorSpace             atomic.Int32
+	latencyMode                  atomic.Int32
+	vsyncMode                    atomic.Int32
+	running                      atomic.Bool
+	terminated                   atomic.Bool
+	tick                         atomic.Uint64
+	tickTime                     atomic.Int64
 
 	whiteImage *Image
 
+	systemSettings systemSettingsState
+	powerStatus    powerStatusState
+	lowPowerMode   lowPowerModeState
+
 	mainThread thread.Thread
 
+	mainThreadFuncs  []func()
+	mainThreadFuncsM sync.Mutex
+
 	userInterfaceImpl
 }
 
@@ -128,11 +179,24 @@ func newUserInterface() (*UserInterface, error) {
 }
 
 func (u *UserInterface) readPixels(mipmap *mipmap.Mipmap, pixels []byte, region image.Rectangle) error {
+	return u.readPixelsWithRetry(func() (bool, error) {
+		return mipmap.ReadPixels(u.graphicsDriver, pixels, region)
+	})
+}
+
+func (u *UserInterface) readPixelsFloat32(mipmap *mipmap.Mipmap, pixels []float32, region image.Rectangle) error {
+	return u.readPixelsWithRetry(func() (bool, error) {
+		return mipmap.ReadPixelsFloat32(u.graphicsDriver, pixels, region)
+	})
+}
+
+// readPixelsWithRetry calls read, and calls it again in a frame if read is not ready.
+func (u *UserInterface) readPixelsWithRetry(read func() (bool, error)) error {
 	if !u.running.Load() {
 		panic("ui: ReadPixels cannot be called before the game starts")
 	}
 
-	ok, err := mipmap.ReadPixels(u.graphicsDriver, pixels, region)
+	ok, err := read()
 	if err != nil {
 		return err
 	}
@@ -147,7 +211,7 @@ func (u *UserInterface) readPixels(mipmap *mipmap.Mipmap, pixels []byte, region
 
 		var err1 error
 		u.context.runInFrame(func() {
-			ok, err := mipmap.ReadPixels(u.graphicsDriver, pixels, region)
+			ok, err := read()
 			if err != nil {
 				err1 = err
 				return
@@ -182,6 +246,11 @@ type RunOptions struct {
 	X11ClassName             string
 	X11InstanceName          string
 	StrictContextRestoration bool
+	CanvasSelector           string
+
+	RecoverFromGraphicsDeviceLoss bool
+	CompressRestorationBackups    bool
+	AsyncUpdate                   bool
 }
 
 // InitialWindowPosition returns the position for centering the given second width/height pair within the first width/height pair.
@@ -203,6 +272,41 @@ func (u *UserInterface) setError(err error) {
 	}
 }
 
+// QueueOnMainThread queues f to be called on the main thread between frames.
+//
+// QueueOnMainThread is concurrent-safe.
+func (u *UserInterface) QueueOnMainThread(f func()) {
+	u.mainThreadFuncsM.Lock()
+	defer u.mainThreadFuncsM.Unlock()
+	u.mainThreadFuncs = append(u.mainThreadFuncs, f)
+}
+
+// runQueuedFuncsOnMainThread calls the functions queued by QueueOnMainThread.
+// runQueuedFuncsOnMainThread must be called from the game's goroutine, not from the main thread.
+func (u *UserInterface) runQueuedFuncsOnMainThread() {
+	u.mainThreadFuncsM.Lock()
+	funcs := u.mainThreadFuncs
+	u.mainThreadFuncs = nil
+	u.mainThreadFuncsM.Unlock()
+
+	if len(funcs) == 0 {
+		return
+	}
+
+	run := func() {
+		for _, f := range funcs {
+			f()
+		}
+	}
+
+	// On mobiles, the main thread is not managed by Ebitengine.
+	if u.mainThread == nil {
+		run()
+		return
+	}
+	u.mainThread.Call(run)
+}
+
 func (u *UserInterface) IsScreenClearedEveryFrame() bool {
 	return u.isScreenClearedEveryFrame.Load()
 }
@@ -211,6 +315,41 @@ func (u *UserInterface) SetScreenClearedEveryFrame(cleared bool) {
 	u.isScreenClearedEveryFrame.Store(cleared)
 }
 
+func (u *UserInterface) IsPhysicalPixelLayoutEnabled() bool {
+	return u.isPhysicalPixelLayoutEnabled.Load()
+}
+
+func (u *UserInterface) SetPhysicalPixelLayoutEnabled(enabled bool) {
+	u.isPhysicalPixelLayoutEnabled.Store(enabled)
+}
+
+// VsyncMode returns the vsync mode used when the FPS mode is FPSModeVsyncOn.
+func (u *UserInterface) VsyncMode() VsyncMode {
+	return VsyncMode(u.vsyncMode.Load())
+}
+
+// SetVsyncMode sets the vsync mode used when the FPS mode is FPSModeVsyncOn.
+// mode must not be VsyncModeOff. SetVsyncMode should be followed by SetFPSMode.
+func (u *UserInterface) SetVsyncMode(mode VsyncMode) {
+	u.vsyncMode.Store(int32(mode))
+}
+
+func (u *UserInterface) ActiveVsyncMode() VsyncMode {
+	return VsyncMode(graphicscommand.ActiveVsyncMode())
+}
+
+func (u *UserInterface) LatencyMode() LatencyMode {
+	return LatencyMode(u.latencyMode.Load())
+}
+
+func (u *UserInterface) SetLatencyMode(mode LatencyMode) {
+	u.latencyMode.Store(int32(mode))
+}
+
+func (u *UserInterface) PresentLatency() time.Duration {
+	return graphicscommand.PresentLatency()
+}
+
 func (u *UserInterface) setGraphicsLibrary(library GraphicsLibrary) {
 	u.graphicsLibrary.Store(int32(library))
 }
@@ -219,6 +358,30 @@ func (u *UserInterface) GraphicsLibrary() GraphicsLibrary {
 	return GraphicsLibrary(u.graphicsLibrary.Load())
 }
 
+// setScreenColorSpace sets the actual color space of the screen from the graphics library and the color space option.
+func (u *UserInterface) setScreenColorSpace(library GraphicsLibrary, colorSpace graphicsdriver.ColorSpace) {
+	cs := graphicsdriver.ColorSpaceSRGB
+	switch {
+	case library == GraphicsLibraryMetal && runtime.GOOS == "darwin":
+		// The Metal layer uses Display P3 unless sRGB is specified on macOS.
+		if colorSpace != graphicsdriver.ColorSpaceSRGB {
+			cs = graphicsdriver.ColorSpaceDisplayP3
+		}
+	case runtime.GOOS == "js":
+		if colorSpace == graphicsdriver.ColorSpaceDisplayP3 {
+			cs = graphicsdriver.ColorSpaceDisplayP3
+		}
+	}
+	u.screenColorSpace.Store(int32(cs))
+	graphicscommand.SetDumpColorSpace(cs)
+}
+
+// ScreenColorSpace returns the actual color space of the screen.
+// ScreenColorSpace returns ColorSpaceDefault before the graphics library is initialized or if the color space is unknown.
+func (u *UserInterface) ScreenColorSpace() graphicsdriver.ColorSpace {
+	return graphicsdriver.ColorSpace(u.screenColorSpace.Load())
+}
+
 func (u *UserInterface) isRunning() bool {
 	return u.running.Load() && !u.isTerminated()
 }
@@ -238,3 +401,9 @@ func (u *UserInterface) setTerminated() {
 func (u *UserInterface) Tick() uint64 {
 	return u.tick.Load()
 }
+
+// TickTime returns the total duration of the ticks in the real time.
+// The duration of each tick is the one at the tick, e.g., 1/TPS.
+func (u *UserInterface) TickTime() time.Duration {
+	return time.Duration(u.tickTime.Load())
+}
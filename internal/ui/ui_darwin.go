@@ -20,6 +20,7 @@ import (
 	"errors"
 	"fmt"
 	"reflect"
+	"strconv"
 
 	"github.com/ebitengine/purego/objc"
 
@@ -223,24 +224,44 @@ func (u *UserInterface) adjustWindowPosition(x, y int, monitor *Monitor) (int, i
 }
 
 var (
-	class_NSCursor = objc.GetClass("NSCursor")
-	class_NSEvent  = objc.GetClass("NSEvent")
+	class_NSApplication       = objc.GetClass("NSApplication")
+	class_NSCursor            = objc.GetClass("NSCursor")
+	class_NSEvent             = objc.GetClass("NSEvent")
+	class_NSImageView         = objc.GetClass("NSImageView")
+	class_NSProgressIndicator = objc.GetClass("NSProgressIndicator")
 )
 
 var (
+	sel_addSubview                    = objc.RegisterName("addSubview:")
 	sel_alloc                         = objc.RegisterName("alloc")
+	sel_applicationIconImage          = objc.RegisterName("applicationIconImage")
 	sel_collectionBehavior            = objc.RegisterName("collectionBehavior")
 	sel_delegate                      = objc.RegisterName("delegate")
+	sel_display                       = objc.RegisterName("display")
+	sel_dockTile                      = objc.RegisterName("dockTile")
 	sel_init                          = objc.RegisterName("init")
+	sel_initWithFrame                 = objc.RegisterName("initWithFrame:")
 	sel_initWithOrigDelegate          = objc.RegisterName("initWithOrigDelegate:")
 	sel_mouseLocation                 = objc.RegisterName("mouseLocation")
 	sel_origDelegate                  = objc.RegisterName("origDelegate")
 	sel_origResizable                 = objc.RegisterName("isOrigResizable")
+	sel_release                       = objc.RegisterName("release")
+	sel_retain                        = objc.RegisterName("retain")
+	sel_setBadgeLabel                 = objc.RegisterName("setBadgeLabel:")
 	sel_setCollectionBehavior         = objc.RegisterName("setCollectionBehavior:")
+	sel_setContentView                = objc.RegisterName("setContentView:")
 	sel_setDelegate                   = objc.RegisterName("setDelegate:")
 	sel_setDocumentEdited             = objc.RegisterName("setDocumentEdited:")
+	sel_setDoubleValue                = objc.RegisterName("setDoubleValue:")
+	sel_setImage                      = objc.RegisterName("setImage:")
+	sel_setIndeterminate              = objc.RegisterName("setIndeterminate:")
+	sel_setMaxValue                   = objc.RegisterName("setMaxValue:")
+	sel_setMinValue                   = objc.RegisterName("setMinValue:")
 	sel_setOrigDelegate               = objc.RegisterName("setOrigDelegate:")
 	sel_setOrigResizable              = objc.RegisterName("setOrigResizable:")
+	sel_setStyle                      = objc.RegisterName("setStyle:")
+	sel_sharedApplication             = objc.RegisterName("sharedApplication")
+	sel_size                          = objc.RegisterName("size")
 	sel_toggleFullScreen              = objc.RegisterName("toggleFullScreen:")
 	sel_windowDidBecomeKey            = objc.RegisterName("windowDidBecomeKey:")
 	sel_windowDidEnterFullScreen      = objc.RegisterName("windowDidEnterFullScreen:")
@@ -439,6 +460,93 @@ func (u *UserInterface) setDocumentEdited(edited bool) error {
 	return nil
 }
 
+// dockTileProgressIndicator is an NSProgressIndicator shown on the Dock tile.
+// dockTileProgressIndicator must be accessed from the main thread.
+var dockTileProgressIndicator objc.ID
+
+// setWindowProgressForOS must be called from the main thread.
+func (u *UserInterface) setWindowProgressForOS(state WindowProgressState, progress float64) error {
+	dockTile := objc.ID(class_NSApplication).Send(sel_sharedApplication).Send(sel_dockTile)
+
+	if state == WindowProgressStateNone {
+		if dockTileProgressIndicator != 0 {
+			dockTile.Send(sel_setContentView, objc.ID(0))
+			dockTileProgressIndicator.Send(sel_release)
+			dockTileProgressIndicator = 0
+			dockTile.Send(sel_display)
+		}
+		return nil
+	}
+
+	if dockTileProgressIndicator == 0 {
+		// The Dock tile's content view replaces the application icon. Show the icon with a progress bar on it.
+		size := objc.Send[cocoa.NSSize](dockTile, sel_size)
+		imageView := objc.ID(class_NSImageView).Send(sel_alloc).Send(sel_initWithFrame, cocoa.NSRect{Size: size})
+		imageView.Send(sel_setImage, objc.ID(class_NSApplication).Send(sel_sharedApplication).Send(sel_applicationIconImage))
+
+		const (
+			barHeight                   = 16
+			nsProgressIndicatorStyleBar = 0
+		)
+		indicator := objc.ID(class_NSProgressIndicator).Send(sel_alloc).Send(sel_initWithFrame, cocoa.NSRect{
+			Size: cocoa.NSSize{Width: size.Width, Height: barHeight},
+		})
+		indicator.Send(sel_setStyle, nsProgressIndicatorStyleBar)
+		indicator.Send(sel_setMinValue, 0.0)
+		indicator.Send(sel_setMaxValue, 1.0)
+		imageView.Send(sel_addSubview, indicator)
+		dockTile.Send(sel_setContentView, imageView)
+		imageView.Send(sel_release)
+
+		dockTileProgressIndicator = indicator
+	}
+
+	// The Dock tile is not animated, so an indeterminate progress is shown as a static bar.
+	dockTileProgressIndicator.Send(sel_setIndeterminate, state == WindowProgressStateIndeterminate)
+	dockTileProgressIndicator.Send(sel_setDoubleValue, progress)
+	dockTile.Send(sel_display)
+	return nil
+}
+
+// setWindowBadgeCountForOS must be called from the main thread.
+func (u *UserInterface) setWindowBadgeCountForOS(count int) error {
+	dockTile := objc.ID(class_NSApplication).Send(sel_sharedApplication).Send(sel_dockTile)
+	if count == 0 {
+		dockTile.Send(sel_setBadgeLabel, objc.ID(0))
+		return nil
+	}
+	label := cocoa.NSString_alloc().InitWithUTF8String(strconv.Itoa(count))
+	defer label.Send(sel_release)
+	dockTile.Send(sel_setBadgeLabel, label.ID)
+	return nil
+}
+
+// screenSaverActivity is an activity token to prevent the display from sleeping.
+// screenSaverActivity must be accessed from the main thread.
+var screenSaverActivity objc.ID
+
+// setScreenSaverInhibitedForOS must be called from the main thread.
+func (u *UserInterface) setScreenSaverInhibitedForOS(inhibited bool) error {
+	if inhibited == (screenSaverActivity != 0) {
+		return nil
+	}
+
+	p := cocoa.NSProcessInfo_processInfo()
+	if inhibited {
+		reason := cocoa.NSString_alloc().InitWithUTF8String("Ebitengine inhibits the screen saver")
+		defer reason.Send(sel_release)
+		// The returned token is autoreleased. Retain it until the activity ends.
+		screenSaverActivity = p.BeginActivityWithOptionsReason(cocoa.NSActivityIdleDisplaySleepDisabled|cocoa.NSActivityIdleSystemSleepDisabled, reason)
+		screenSaverActivity.Send(sel_retain)
+		return nil
+	}
+
+	p.EndActivity(screenSaverActivity)
+	screenSaverActivity.Send(sel_release)
+	screenSaverActivity = 0
+	return nil
+}
+
 func (u *UserInterface) afterWindowCreation() error {
 	return nil
 }
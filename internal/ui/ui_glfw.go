@@ -12,7 +12,7 @@
 // See the License for the specific language governing permissions and
 // limitations under the License.
 
-//go:build !android && !ios && !js && !nintendosdk && !playstation5
+//go:build !android && !ios && !js && !nintendosdk && !playstation5 && !wasip1
 
 package ui
 
@@ -23,6 +23,7 @@ import (
 	"math"
 	"os"
 	"runtime"
+	"slices"
 	"sync"
 	"time"
 
@@ -33,6 +34,7 @@ import (
 	"github.com/duplicants-ai/ebiten/internal/graphicsdriver"
 	"github.com/duplicants-ai/ebiten/internal/hook"
 	"github.com/duplicants-ai/ebiten/internal/microsoftgdk"
+	"github.com/duplicants-ai/ebiten/internal/restorable"
 )
 
 func driverCursorModeToGLFWCursorMode(mode CursorMode) int {
@@ -66,6 +68,18 @@ type userInterfaceImpl struct {
 	cursorShape          CursorShape
 	windowClosingHandled bool
 	windowResizingMode   WindowResizingMode
+	screenSaverInhibited bool
+	progressState        WindowProgressState
+	progress             float64
+	badgeCount           int
+	windowOpacity        float64
+
+	// windowMousePassthroughRegions are the regions in the logical screen coordinates where the mouse cursor passes through the window.
+	windowMousePassthroughRegions []image.Rectangle
+
+	// mousePassthroughByRegions reports whether the mouse passthrough is enabled by windowMousePassthroughRegions.
+	// mousePassthroughByRegions must be accessed from the main thread.
+	mousePassthroughByRegions bool
 
 	lastDeviceScaleFactor float64
 
@@ -93,11 +107,17 @@ type userInterfaceImpl struct {
 
 	fpsModeInited bool
 
+	// appliedVsyncMode is the vsync mode given to the graphics driver last time.
+	appliedVsyncMode VsyncMode
+
 	inputState   InputState
 	iwindow      glfwWindow
 	savedCursorX float64
 	savedCursorY float64
 
+	// inputSource must be accessed from the main thread.
+	inputSource inputSourceState
+
 	closeCallback                  glfw.CloseCallback
 	framebufferSizeCallback        glfw.FramebufferSizeCallback
 	defaultFramebufferSizeCallback glfw.FramebufferSizeCallback
@@ -138,6 +158,7 @@ func (u *UserInterface) init() error {
 		initWindowPositionYInDIP: invalidPos,
 		initWindowWidthInDIP:     640,
 		initWindowHeightInDIP:    480,
+		windowOpacity:            1,
 		origWindowPosX:           invalidPos,
 		origWindowPosY:           invalidPos,
 		savedCursorX:             math.NaN(),
@@ -665,6 +686,32 @@ func (u *UserInterface) SetFullscreen(fullscreen bool) {
 	})
 }
 
+func (u *UserInterface) SetScreenSaverInhibited(inhibited bool) {
+	u.m.Lock()
+	if u.screenSaverInhibited == inhibited {
+		u.m.Unlock()
+		return
+	}
+	u.screenSaverInhibited = inhibited
+	u.m.Unlock()
+
+	if u.isTerminated() {
+		return
+	}
+	// If the game is not running yet, the state is applied when the window is created.
+	if !u.isRunning() {
+		return
+	}
+
+	u.mainThread.Call(func() {
+		if u.isTerminated() {
+			return
+		}
+		// Inhibiting the screen saver is best-effort. Ignore the error.
+		_ = u.setScreenSaverInhibitedForOS(inhibited)
+	})
+}
+
 func (u *UserInterface) IsFocused() bool {
 	if !u.isRunning() {
 		return false
@@ -1098,8 +1145,18 @@ func (u *UserInterface) initOnMainThread(options *RunOptions) error {
 	}
 	u.graphicsDriver = g
 	u.setGraphicsLibrary(lib)
+	u.setScreenColorSpace(lib, options.ColorSpace)
 	u.graphicsDriver.SetTransparent(options.ScreenTransparent)
 
+	if options.RecoverFromGraphicsDeviceLoss {
+		// Only DirectX 11 can recreate its device so far.
+		// TODO: Support Metal and OpenGL.
+		if _, ok := g.(graphicsdriver.Resetter); ok && lib == GraphicsLibraryDirectX {
+			restorable.Enable()
+		}
+	}
+	restorable.SetBackupCompression(options.CompressRestorationBackups)
+
 	// internal/glfw is customized and the default client API is NoAPI, not OpenGLAPI.
 	// Then, glfw.WindowHint(glfw.ClientAPI, glfw.NoAPI) doesn't have to be called.
 
@@ -1165,6 +1222,29 @@ func (u *UserInterface) initOnMainThread(options *RunOptions) error {
 		_ = u.skipTaskbar()
 	}
 
+	u.m.RLock()
+	screenSaverInhibited := u.screenSaverInhibited
+	progressState := u.progressState
+	progress := u.progress
+	badgeCount := u.badgeCount
+	opacity := u.windowOpacity
+	u.m.RUnlock()
+	// These features are best-effort. Ignore the errors.
+	if screenSaverInhibited {
+		_ = u.setScreenSaverInhibitedForOS(true)
+	}
+	if progressState != WindowProgressStateNone {
+		_ = u.setWindowProgressForOS(progressState, progress)
+	}
+	if badgeCount != 0 {
+		_ = u.setWindowBadgeCountForOS(badgeCount)
+	}
+	if opacity != 1 {
+		if err := u.window.SetOpacity(float32(opacity)); err != nil {
+			return err
+		}
+	}
+
 	switch g := u.graphicsDriver.(type) {
 	case interface{ SetGLFWWindow(window *glfw.Window) }:
 		g.SetGLFWWindow(u.window)
@@ -1252,28 +1332,34 @@ func (u *UserInterface) outsideSize() (float64, float64, error) {
 
 // setFPSMode must be called from the main thread.
 func (u *UserInterface) setFPSMode(fpsMode FPSModeType) error {
+	vsyncMode := VsyncModeOff
+	if fpsMode == FPSModeVsyncOn {
+		vsyncMode = u.VsyncMode()
+	}
+
 	needUpdate := u.fpsMode != fpsMode || !u.fpsModeInited
+	needVsyncUpdate := needUpdate || u.appliedVsyncMode != vsyncMode
 	u.fpsMode = fpsMode
 	u.fpsModeInited = true
 
-	if !needUpdate {
-		return nil
+	if needUpdate {
+		sticky := glfw.True
+		if fpsMode == FPSModeVsyncOffMinimum {
+			sticky = glfw.False
+		}
+		if err := u.window.SetInputMode(glfw.StickyMouseButtonsMode, sticky); err != nil {
+			return err
+		}
+		if err := u.window.SetInputMode(glfw.StickyKeysMode, sticky); err != nil {
+			return err
+		}
 	}
 
-	sticky := glfw.True
-	if fpsMode == FPSModeVsyncOffMinimum {
-		sticky = glfw.False
-	}
-	if err := u.window.SetInputMode(glfw.StickyMouseButtonsMode, sticky); err != nil {
-		return err
-	}
-	if err := u.window.SetInputMode(glfw.StickyKeysMode, sticky); err != nil {
-		return err
+	if needVsyncUpdate {
+		graphicscommand.SetVsyncMode(graphicsdriver.VsyncMode(vsyncMode), u.graphicsDriver)
+		u.appliedVsyncMode = vsyncMode
 	}
 
-	vsyncEnabled := u.fpsMode == FPSModeVsyncOn
-	graphicscommand.SetVsyncEnabled(vsyncEnabled, u.graphicsDriver)
-
 	return nil
 }
 
@@ -1707,6 +1793,89 @@ func (u *UserInterface) setWindowSizeInDIP(width, height int, callSetSize bool)
 	return nil
 }
 
+// setWindowSizeInPhysicalPixels sets the window size in physical pixels.
+// Unlike setWindowSizeInDIP, the size is not rounded to device-independent pixels unless the size limits are applied.
+//
+// setWindowSizeInPhysicalPixels must be called from the main thread.
+func (u *UserInterface) setWindowSizeInPhysicalPixels(width, height int) error {
+	if microsoftgdk.IsXbox() {
+		// Do nothing. The size is always fixed.
+		return nil
+	}
+
+	mon, err := u.currentMonitor()
+	if err != nil {
+		return err
+	}
+	s := mon.DeviceScaleFactor()
+
+	dipW := max(int(math.Round(float64(width)/s)), 1)
+	dipH := max(int(math.Round(float64(height)/s)), 1)
+	if err := u.setWindowSizeInDIP(dipW, dipH, false); err != nil {
+		return err
+	}
+	// The size might be adjusted by the size limits.
+	if u.origWindowWidthInDIP != dipW {
+		width = int(math.Round(float64(u.origWindowWidthInDIP) * s))
+	}
+	if u.origWindowHeightInDIP != dipH {
+		height = int(math.Round(float64(u.origWindowHeightInDIP) * s))
+	}
+
+	f, err := u.isFullscreen()
+	if err != nil {
+		return err
+	}
+	if f {
+		return nil
+	}
+
+	oldW, oldH, err := u.window.GetSize()
+	if err != nil {
+		return err
+	}
+	newW := int(math.Round(dipToGLFWPixel(float64(width)/s, s)))
+	newH := int(math.Round(dipToGLFWPixel(float64(height)/s, s)))
+	if oldW == newW && oldH == newH {
+		return nil
+	}
+	// Just after SetSize, GetSize is not reliable especially on Linux/UNIX.
+	// Let's wait for FramebufferSize callback in any cases.
+	return u.waitForFramebufferSizeCallback(u.window, func() error {
+		return u.window.SetSize(newW, newH)
+	})
+}
+
+// windowSizeInPhysicalPixels returns the window size in physical pixels.
+// In fullscreen mode, windowSizeInPhysicalPixels returns the original window size.
+//
+// windowSizeInPhysicalPixels must be called from the main thread.
+func (u *UserInterface) windowSizeInPhysicalPixels() (image.Point, error) {
+	m, err := u.currentMonitor()
+	if err != nil {
+		return image.Point{}, err
+	}
+	s := m.DeviceScaleFactor()
+
+	f, err := u.isFullscreen()
+	if err != nil {
+		return image.Point{}, err
+	}
+	a, err := u.window.GetAttrib(glfw.Iconified)
+	if err != nil {
+		return image.Point{}, err
+	}
+	if f || a == glfw.True {
+		return image.Pt(int(math.Round(float64(u.origWindowWidthInDIP)*s)), int(math.Round(float64(u.origWindowHeightInDIP)*s))), nil
+	}
+
+	ww, wh, err := u.window.GetSize()
+	if err != nil {
+		return image.Point{}, err
+	}
+	return image.Pt(int(math.Round(dipFromGLFWPixel(float64(ww), s)*s)), int(math.Round(dipFromGLFWPixel(float64(wh), s)*s))), nil
+}
+
 // setOrigWindowPosWithCurrentPos must be called from the main thread.
 func (u *UserInterface) setOrigWindowPosWithCurrentPos() error {
 	if x, y := u.origWindowPos(); x == invalidPos || y == invalidPos {
@@ -2098,6 +2267,18 @@ func (u *UserInterface) setWindowResizingMode(mode WindowResizingMode) error {
 //
 // setWindowPositionInDIP must be called from the main thread.
 func (u *UserInterface) setWindowPositionInDIP(x, y int, monitor *Monitor) error {
+	s := monitor.DeviceScaleFactor()
+	xf := dipToGLFWPixel(float64(x), s)
+	yf := dipToGLFWPixel(float64(y), s)
+	return u.setWindowPositionInGLFWPixels(int(xf), int(yf), monitor)
+}
+
+// setWindowPositionInGLFWPixels sets the window position.
+//
+// x and y are the position in GLFW pixels relative to the monitor.
+//
+// setWindowPositionInGLFWPixels must be called from the main thread.
+func (u *UserInterface) setWindowPositionInGLFWPixels(x, y int, monitor *Monitor) error {
 	if microsoftgdk.IsXbox() {
 		// Do nothing. The position is always fixed.
 		return nil
@@ -2110,11 +2291,7 @@ func (u *UserInterface) setWindowPositionInDIP(x, y int, monitor *Monitor) error
 
 	mx := monitor.boundsInGLFWPixels.Min.X
 	my := monitor.boundsInGLFWPixels.Min.Y
-	s := monitor.DeviceScaleFactor()
-	xf := dipToGLFWPixel(float64(x), s)
-	yf := dipToGLFWPixel(float64(y), s)
-
-	x, y, err = u.adjustWindowPosition(mx+int(xf), my+int(yf), monitor)
+	x, y, err = u.adjustWindowPosition(mx+x, my+y, monitor)
 	if err != nil {
 		return err
 	}
@@ -2172,6 +2349,51 @@ func (u *UserInterface) setWindowMousePassthrough(enabled bool) error {
 	return nil
 }
 
+func (u *UserInterface) getWindowMousePassthroughRegions() []image.Rectangle {
+	u.m.RLock()
+	defer u.m.RUnlock()
+	return u.windowMousePassthroughRegions
+}
+
+func (u *UserInterface) setWindowMousePassthroughRegions(regions []image.Rectangle) {
+	u.m.Lock()
+	defer u.m.Unlock()
+	u.windowMousePassthroughRegions = slices.Clone(regions)
+}
+
+// updateMousePassthroughByRegions enables or disables the mouse passthrough
+// based on whether the cursor is in the passthrough regions.
+//
+// updateMousePassthroughByRegions must be called from the main thread.
+func (u *UserInterface) updateMousePassthroughByRegions(cursorX, cursorY float64) error {
+	regions := u.getWindowMousePassthroughRegions()
+	if len(regions) == 0 && !u.mousePassthroughByRegions {
+		return nil
+	}
+
+	if !u.mousePassthroughByRegions {
+		// If the mouse passthrough is enabled explicitly, the whole window is passthrough. Do nothing.
+		a, err := u.window.GetAttrib(glfw.MousePassthrough)
+		if err != nil {
+			return err
+		}
+		if a == glfw.True {
+			return nil
+		}
+	}
+
+	// GLFW still reports the cursor position even while the mouse passthrough is enabled.
+	in := isInMousePassthroughRegions(regions, cursorX, cursorY)
+	if in == u.mousePassthroughByRegions {
+		return nil
+	}
+	if err := u.setWindowMousePassthrough(in); err != nil {
+		return err
+	}
+	u.mousePassthroughByRegions = in
+	return nil
+}
+
 func IsScreenTransparentAvailable() bool {
 	return true
 }
@@ -16,6 +16,7 @@ package ui
 
 import (
 	"errors"
+	"fmt"
 	"math"
 	"sync"
 	"syscall/js"
@@ -116,6 +117,19 @@ type userInterfaceImpl struct {
 
 	keyboardLayoutMap js.Value
 
+	inputSource         inputSourceState
+	inputSourceQuerying bool
+	inputSourceCallback js.Func
+
+	screenSaverInhibited bool
+	wakeLock             js.Value
+	wakeLockRequesting   bool
+
+	usesCustomCanvas bool
+	worker           workerState
+	origHTMLCSSText  string
+	origBodyCSSText  string
+
 	m         sync.Mutex
 	dropFileM sync.Mutex
 }
@@ -129,10 +143,17 @@ var (
 	setTimeout            = js.Global().Get("setTimeout")
 )
 
-var (
-	documentHasFocus = document.Get("hasFocus").Call("bind", document)
-	documentHidden   = js.Global().Get("Object").Call("getOwnPropertyDescriptor", js.Global().Get("Document").Get("prototype"), "hidden").Get("get").Call("bind", document)
-)
+var documentHasFocus, documentHidden = documentFocusFuncs()
+
+func documentFocusFuncs() (hasFocus, hidden js.Value) {
+	// document is undefined on node.js and Web Workers.
+	if !document.Truthy() {
+		return js.Undefined(), js.Undefined()
+	}
+	hasFocus = document.Get("hasFocus").Call("bind", document)
+	hidden = js.Global().Get("Object").Call("getOwnPropertyDescriptor", js.Global().Get("Document").Get("prototype"), "hidden").Get("get").Call("bind", document)
+	return
+}
 
 func (u *UserInterface) SetFullscreen(fullscreen bool) {
 	if !canvas.Truthy() {
@@ -226,18 +247,70 @@ func (u *UserInterface) setCursorMode(mode CursorMode) {
 	// Remember the previous cursor mode in the case when the pointer lock exits by pressing ESC.
 	u.cursorPrevMode = u.cursorMode
 	if u.cursorMode == CursorModeCaptured {
-		document.Call("exitPointerLock")
+		u.exitPointerLock()
 		u.lastCaptureExitTime = time.Now()
 	}
 	u.cursorMode = mode
 	switch mode {
 	case CursorModeVisible:
-		canvas.Get("style").Set("cursor", driverCursorShapeToCSSCursor(u.cursorShape))
+		u.setCanvasCursor(driverCursorShapeToCSSCursor(u.cursorShape))
 	case CursorModeHidden:
-		canvas.Get("style").Set("cursor", stringNone)
+		u.setCanvasCursor(stringNone)
 	case CursorModeCaptured:
-		canvas.Call("requestPointerLock")
+		u.requestPointerLock()
+	}
+}
+
+// setCanvasCursor sets the CSS cursor of the canvas. cursor is a string or a js.Value of a string.
+func (u *UserInterface) setCanvasCursor(cursor any) {
+	if isWorker {
+		postMessageToMainThread("ebitengine:cursor", map[string]any{
+			"cursor": cursor,
+		})
+		return
+	}
+	canvas.Get("style").Set("cursor", cursor)
+}
+
+func (u *UserInterface) requestPointerLock() {
+	if isWorker {
+		postMessageToMainThread("ebitengine:requestpointerlock", nil)
+		return
+	}
+	canvas.Call("requestPointerLock")
+}
+
+func (u *UserInterface) exitPointerLock() {
+	if isWorker {
+		postMessageToMainThread("ebitengine:exitpointerlock", nil)
+		return
 	}
+	document.Call("exitPointerLock")
+}
+
+// onPointerLockExited recovers the state correctly when the pointer lock exits.
+func (u *UserInterface) onPointerLockExited() {
+	// A user can exit the pointer lock by pressing ESC. In this case, sync the cursor mode state.
+	if u.cursorMode == CursorModeCaptured {
+		// A browser also exits the pointer lock when the page loses focus, e.g. by switching tabs.
+		interruption := CursorCaptureInterruptionExited
+		if !u.isFocused() {
+			interruption = CursorCaptureInterruptionFocusLost
+		}
+		u.inputState.CursorCaptureInterruptions = append(u.inputState.CursorCaptureInterruptions, interruption)
+		u.recoverCursorMode()
+	}
+	u.recoverCursorPosition()
+}
+
+// onPointerLockError recovers the state correctly when requesting the pointer lock fails.
+func (u *UserInterface) onPointerLockError() {
+	js.Global().Get("console").Call("error", "pointerlockerror event is fired. 'sandbox=\"allow-pointer-lock\"' might be required at an iframe. This function on browsers must be called as a result of a gestural interaction or orientation change.")
+	if u.cursorMode == CursorModeCaptured {
+		u.inputState.CursorCaptureInterruptions = append(u.inputState.CursorCaptureInterruptions, CursorCaptureInterruptionDenied)
+		u.recoverCursorMode()
+	}
+	u.recoverCursorPosition()
 }
 
 func (u *UserInterface) recoverCursorMode() {
@@ -264,11 +337,17 @@ func (u *UserInterface) SetCursorShape(shape CursorShape) {
 
 	u.cursorShape = shape
 	if u.cursorMode == CursorModeVisible {
-		canvas.Get("style").Set("cursor", driverCursorShapeToCSSCursor(u.cursorShape))
+		u.setCanvasCursor(driverCursorShapeToCSSCursor(u.cursorShape))
 	}
 }
 
 func (u *UserInterface) outsideSize() (float64, float64) {
+	if isWorker {
+		return u.worker.outsideWidth, u.worker.outsideHeight
+	}
+	if u.usesCustomCanvas {
+		return canvas.Get("clientWidth").Float(), canvas.Get("clientHeight").Float()
+	}
 	if document.Truthy() {
 		body := document.Get("body")
 		bw := body.Get("clientWidth").Float()
@@ -288,6 +367,12 @@ func (u *UserInterface) suspended() bool {
 }
 
 func (u *UserInterface) isFocused() bool {
+	if isWorker {
+		return u.worker.focused
+	}
+	if !document.Truthy() {
+		return true
+	}
 	if !documentHasFocus.Invoke().Bool() {
 		return false
 	}
@@ -322,6 +407,7 @@ func (u *UserInterface) update() error {
 	if err := hook.ResumeAudio(); err != nil {
 		return err
 	}
+	u.updateInputSource()
 	return u.updateImpl(false)
 }
 
@@ -470,8 +556,11 @@ func (u *UserInterface) init() error {
 		hiDPIEnabled:        true,
 	}
 
-	// document is undefined on node.js
+	// document is undefined on node.js and Web Workers.
 	if !document.Truthy() {
+		if isWorker {
+			u.initWorker()
+		}
 		return nil
 	}
 
@@ -500,11 +589,13 @@ func (u *UserInterface) init() error {
 	document.Get("body").Call("appendChild", canvas)
 
 	htmlStyle := document.Get("documentElement").Get("style")
+	u.origHTMLCSSText = htmlStyle.Get("cssText").String()
 	htmlStyle.Set("height", "100%")
 	htmlStyle.Set("margin", "0")
 	htmlStyle.Set("padding", "0")
 
 	bodyStyle := document.Get("body").Get("style")
+	u.origBodyCSSText = bodyStyle.Get("cssText").String()
 	bodyStyle.Set("backgroundColor", "#000")
 	bodyStyle.Set("height", "100%")
 	bodyStyle.Set("margin", "0")
@@ -523,26 +614,25 @@ func (u *UserInterface) init() error {
 
 	u.setCanvasEventHandlers(canvas)
 
+	// Page Visibility
+	u.inputState.PageHidden = documentHidden.Invoke().Bool()
+	document.Call("addEventListener", "visibilitychange", js.FuncOf(func(this js.Value, args []js.Value) any {
+		u.setPageHidden(documentHidden.Invoke().Bool())
+		// A wake lock is released automatically when the page is hidden. Request it again.
+		u.updateWakeLock()
+		return nil
+	}))
+
 	// Pointer Lock
 	document.Call("addEventListener", "pointerlockchange", js.FuncOf(func(this js.Value, args []js.Value) any {
 		if document.Get("pointerLockElement").Truthy() {
 			return nil
 		}
-		// Recover the state correctly when the pointer lock exits.
-
-		// A user can exit the pointer lock by pressing ESC. In this case, sync the cursor mode state.
-		if u.cursorMode == CursorModeCaptured {
-			u.recoverCursorMode()
-		}
-		u.recoverCursorPosition()
+		u.onPointerLockExited()
 		return nil
 	}))
 	document.Call("addEventListener", "pointerlockerror", js.FuncOf(func(this js.Value, args []js.Value) any {
-		js.Global().Get("console").Call("error", "pointerlockerror event is fired. 'sandbox=\"allow-pointer-lock\"' might be required at an iframe. This function on browsers must be called as a result of a gestural interaction or orientation change.")
-		if u.cursorMode == CursorModeCaptured {
-			u.recoverCursorMode()
-		}
-		u.recoverCursorPosition()
+		u.onPointerLockError()
 		return nil
 	}))
 	document.Call("addEventListener", "fullscreenerror", js.FuncOf(func(this js.Value, args []js.Value) any {
@@ -585,6 +675,7 @@ func (u *UserInterface) setCanvasEventHandlers(v js.Value) {
 			u.setError(err)
 			return nil
 		}
+		u.runUserGestureHooks()
 		return nil
 	}))
 	v.Call("addEventListener", "keyup", js.FuncOf(func(this js.Value, args []js.Value) any {
@@ -608,6 +699,7 @@ func (u *UserInterface) setCanvasEventHandlers(v js.Value) {
 			u.setError(err)
 			return nil
 		}
+		u.runUserGestureHooks()
 		return nil
 	}))
 	v.Call("addEventListener", "mouseup", js.FuncOf(func(this js.Value, args []js.Value) any {
@@ -617,6 +709,7 @@ func (u *UserInterface) setCanvasEventHandlers(v js.Value) {
 			u.setError(err)
 			return nil
 		}
+		u.runUserGestureHooks()
 		return nil
 	}))
 	v.Call("addEventListener", "mousemove", js.FuncOf(func(this js.Value, args []js.Value) any {
@@ -649,6 +742,7 @@ func (u *UserInterface) setCanvasEventHandlers(v js.Value) {
 			u.setError(err)
 			return nil
 		}
+		u.runUserGestureHooks()
 		return nil
 	}))
 	v.Call("addEventListener", "touchend", js.FuncOf(func(this js.Value, args []js.Value) any {
@@ -658,6 +752,7 @@ func (u *UserInterface) setCanvasEventHandlers(v js.Value) {
 			u.setError(err)
 			return nil
 		}
+		u.runUserGestureHooks()
 		return nil
 	}))
 	v.Call("addEventListener", "touchmove", js.FuncOf(func(this js.Value, args []js.Value) any {
@@ -710,6 +805,22 @@ func (u *UserInterface) setCanvasEventHandlers(v js.Value) {
 	}))
 }
 
+// runUserGestureHooks runs the hooks that require a user gesture, e.g., resuming audio.
+// runUserGestureHooks must be called synchronously in a user gesture event handler.
+func (u *UserInterface) runUserGestureHooks() {
+	if err := hook.RunUserGestureHooks(); err != nil {
+		u.setError(err)
+	}
+}
+
+func (u *UserInterface) setPageHidden(hidden bool) {
+	if u.inputState.PageHidden == hidden {
+		return
+	}
+	u.inputState.PageHidden = hidden
+	u.inputState.PageVisibilityChanged = true
+}
+
 func (u *UserInterface) appendDroppedFiles(data js.Value) {
 	u.dropFileM.Lock()
 	defer u.dropFileM.Unlock()
@@ -771,6 +882,12 @@ func (u *UserInterface) initOnMainThread(options *RunOptions) error {
 
 	u.hiDPIEnabled = !options.DisableHiDPI
 
+	if options.CanvasSelector != "" {
+		if err := u.useCanvas(options.CanvasSelector); err != nil {
+			return err
+		}
+	}
+
 	if u.shouldFocusFirst(options) {
 		canvas.Call("focus")
 	}
@@ -784,27 +901,90 @@ func (u *UserInterface) initOnMainThread(options *RunOptions) error {
 	}
 	u.graphicsDriver = g
 	u.setGraphicsLibrary(lib)
+	u.setScreenColorSpace(lib, options.ColorSpace)
+
+	// The document styles belong to the web page when a custom canvas is used.
+	if document.Truthy() && !u.usesCustomCanvas {
+		if bodyStyle := document.Get("body").Get("style"); options.ScreenTransparent {
+			bodyStyle.Set("backgroundColor", "transparent")
+		} else {
+			bodyStyle.Set("backgroundColor", "#000")
+		}
+	}
+
+	return nil
+}
+
+// useCanvas replaces the default canvas with the existing canvas element specified by the CSS selector.
+func (u *UserInterface) useCanvas(selector string) error {
+	// document is undefined on node.js
+	if !document.Truthy() {
+		return nil
+	}
+
+	c := document.Call("querySelector", selector)
+	if !c.Truthy() {
+		return fmt.Errorf("ui: no element matches the selector %q", selector)
+	}
+	if !c.InstanceOf(js.Global().Get("HTMLCanvasElement")) {
+		return fmt.Errorf("ui: the element matching the selector %q is not a canvas", selector)
+	}
 
-	if bodyStyle := document.Get("body").Get("style"); options.ScreenTransparent {
-		bodyStyle.Set("backgroundColor", "transparent")
+	// Remove the default canvas and restore the document styles.
+	canvas.Call("remove")
+	document.Get("documentElement").Get("style").Set("cssText", u.origHTMLCSSText)
+	document.Get("body").Get("style").Set("cssText", u.origBodyCSSText)
+
+	canvas = c
+	u.usesCustomCanvas = true
+
+	// Make the canvas focusable.
+	if !canvas.Call("hasAttribute", "tabindex").Bool() {
+		canvas.Call("setAttribute", "tabindex", 1)
+	}
+	if u.cursorMode == CursorModeHidden {
+		canvas.Get("style").Set("cursor", stringNone)
 	} else {
-		bodyStyle.Set("backgroundColor", "#000")
+		canvas.Get("style").Set("cursor", driverCursorShapeToCSSCursor(u.cursorShape))
+	}
+
+	u.setCanvasEventHandlers(canvas)
+
+	// The canvas can be resized by the page layout without resizing the window.
+	if ro := js.Global().Get("ResizeObserver"); ro.Truthy() {
+		ro.New(js.FuncOf(func(this js.Value, args []js.Value) any {
+			u.updateScreenSize()
+			return nil
+		})).Call("observe", canvas)
 	}
 
 	return nil
 }
 
+// canvasOffset returns the position of the canvas in the client coordinate.
+func (u *UserInterface) canvasOffset() (float64, float64) {
+	if !u.usesCustomCanvas {
+		return 0, 0
+	}
+	r := canvas.Call("getBoundingClientRect")
+	return r.Get("left").Float(), r.Get("top").Float()
+}
+
 func (u *UserInterface) updateScreenSize() {
-	if document.Truthy() {
-		body := document.Get("body")
+	if canvas.Truthy() {
+		w, h := u.outsideSize()
 		f := theMonitor.DeviceScaleFactor()
-		bw := int(body.Get("clientWidth").Float() * f)
-		bh := int(body.Get("clientHeight").Float() * f)
-		canvas.Set("width", bw)
-		canvas.Set("height", bh)
+		canvas.Set("width", int(w*f))
+		canvas.Set("height", int(h*f))
 	}
 }
 
+// Canvas returns the canvas element the game is rendered to.
+// Canvas returns an undefined value when the document is not available, e.g., on Web Workers.
+func (u *UserInterface) Canvas() js.Value {
+	return canvas
+}
+
 func (u *UserInterface) readInputState(inputState *InputState) {
 	u.inputState.copyAndReset(inputState)
 	u.keyboardLayoutMap = js.Value{}
@@ -829,6 +1009,11 @@ func (m *Monitor) DeviceScaleFactor() float64 {
 		return 1
 	}
 
+	// The device scale factor can be changed on the main thread, e.g., by moving the page to another display.
+	if isWorker {
+		return theUI.worker.deviceScaleFactor
+	}
+
 	if m.deviceScaleFactor != 0 {
 		return m.deviceScaleFactor
 	}
@@ -841,7 +1026,15 @@ func (m *Monitor) DeviceScaleFactor() float64 {
 	return m.deviceScaleFactor
 }
 
+func (m *Monitor) RefreshRate() int {
+	// Browsers don't expose the refresh rate of the display.
+	return 0
+}
+
 func (m *Monitor) Size() (int, int) {
+	if isWorker {
+		return theUI.worker.screenWidth, theUI.worker.screenHeight
+	}
 	return screen.Get("width").Int(), screen.Get("height").Int()
 }
 
@@ -19,12 +19,15 @@ package ui
 import (
 	"errors"
 	"fmt"
+	"os"
+	"path/filepath"
 	"runtime"
 
 	"github.com/jezek/xgb"
 	"github.com/jezek/xgb/randr"
 	"github.com/jezek/xgb/xproto"
 
+	"github.com/duplicants-ai/ebiten/internal/dbus"
 	"github.com/duplicants-ai/ebiten/internal/glfw"
 	"github.com/duplicants-ai/ebiten/internal/graphicsdriver"
 	"github.com/duplicants-ai/ebiten/internal/graphicsdriver/opengl"
@@ -206,6 +209,137 @@ func (u *UserInterface) setDocumentEdited(edited bool) error {
 	return nil
 }
 
+// updateLauncherEntry updates the application's launcher entry by the Unity LauncherAPI.
+// The LauncherAPI is supported by e.g. KDE Plasma and Ubuntu Dock.
+//
+// See https://wiki.ubuntu.com/Unity/LauncherAPI
+func updateLauncherEntry(properties map[string]dbus.Variant) error {
+	conn, err := dbus.SessionBus()
+	if err != nil {
+		return err
+	}
+	// The desktop entry is assumed to have the same name as the executable.
+	appURI := "application://" + filepath.Base(os.Args[0]) + ".desktop"
+	return conn.Emit("/org/ebitengine/LauncherEntry", "com.canonical.Unity.LauncherEntry", "Update", appURI, properties)
+}
+
+// setWindowProgressForOS must be called from the main thread.
+func (u *UserInterface) setWindowProgressForOS(state WindowProgressState, progress float64) error {
+	// The LauncherAPI doesn't have states. Show the progress as it is unless the state is none.
+	return updateLauncherEntry(map[string]dbus.Variant{
+		"progress":         {Value: progress},
+		"progress-visible": {Value: state != WindowProgressStateNone},
+	})
+}
+
+// setWindowBadgeCountForOS must be called from the main thread.
+func (u *UserInterface) setWindowBadgeCountForOS(count int) error {
+	return updateLauncherEntry(map[string]dbus.Variant{
+		"count":         {Value: int64(count)},
+		"count-visible": {Value: count != 0},
+	})
+}
+
+// screenSaverCookie is a cookie returned by org.freedesktop.ScreenSaver.Inhibit.
+// screenSaverPortalHandle is a request handle returned by org.freedesktop.portal.Inhibit.Inhibit.
+// Only one of them is used at the same time.
+// screenSaverCookie, screenSaverPortalHandle and screenSaverInhibited must be accessed from the main thread.
+var (
+	screenSaverCookie       uint32
+	screenSaverPortalHandle dbus.ObjectPath
+	screenSaverInhibited    bool
+)
+
+// setScreenSaverInhibitedForOS must be called from the main thread.
+func (u *UserInterface) setScreenSaverInhibitedForOS(inhibited bool) error {
+	if inhibited == screenSaverInhibited {
+		return nil
+	}
+
+	// The inhibition is released automatically when the connection is closed, so use the shared connection.
+	conn, err := dbus.SessionBus()
+	if err != nil {
+		return err
+	}
+
+	const (
+		dest  = "org.freedesktop.ScreenSaver"
+		path  = "/org/freedesktop/ScreenSaver"
+		iface = "org.freedesktop.ScreenSaver"
+	)
+
+	const reason = "Ebitengine inhibits the screen saver"
+
+	if inhibited {
+		r, err := conn.Call(dest, path, iface, "Inhibit", filepath.Base(os.Args[0]), reason)
+		if err != nil {
+			// org.freedesktop.ScreenSaver is not available e.g. in a sandbox like Flatpak.
+			// Try the XDG Desktop Portal, which asks the compositor to inhibit idling on Wayland.
+			h, perr := inhibitScreenSaverByPortal(conn, reason)
+			if perr != nil {
+				return errors.Join(err, perr)
+			}
+			screenSaverPortalHandle = h
+			screenSaverInhibited = true
+			return nil
+		}
+		if len(r) != 1 {
+			return fmt.Errorf("ui: unexpected reply of org.freedesktop.ScreenSaver.Inhibit: %v", r)
+		}
+		cookie, ok := r[0].(uint32)
+		if !ok {
+			return fmt.Errorf("ui: unexpected reply of org.freedesktop.ScreenSaver.Inhibit: %v", r)
+		}
+		screenSaverCookie = cookie
+		screenSaverInhibited = true
+		return nil
+	}
+
+	if screenSaverPortalHandle != "" {
+		// The inhibition by the portal is released by closing the request.
+		//
+		// See https://flatpak.github.io/xdg-desktop-portal/docs/doc-org.freedesktop.portal.Request.html
+		if _, err := conn.Call("org.freedesktop.portal.Desktop", screenSaverPortalHandle, "org.freedesktop.portal.Request", "Close"); err != nil {
+			return err
+		}
+		screenSaverPortalHandle = ""
+		screenSaverInhibited = false
+		return nil
+	}
+
+	if _, err := conn.Call(dest, path, iface, "UnInhibit", screenSaverCookie); err != nil {
+		return err
+	}
+	screenSaverCookie = 0
+	screenSaverInhibited = false
+	return nil
+}
+
+// inhibitScreenSaverByPortal inhibits idling and suspending by the XDG Desktop Portal, and returns the request handle.
+//
+// See https://flatpak.github.io/xdg-desktop-portal/docs/doc-org.freedesktop.portal.Inhibit.html
+func inhibitScreenSaverByPortal(conn *dbus.Conn, reason string) (dbus.ObjectPath, error) {
+	const (
+		flagSuspend = 4
+		flagIdle    = 8
+	)
+	// The window identifier is empty as the portal doesn't need a parent window for the inhibition.
+	r, err := conn.Call("org.freedesktop.portal.Desktop", "/org/freedesktop/portal/desktop", "org.freedesktop.portal.Inhibit", "Inhibit", "", uint32(flagSuspend|flagIdle), map[string]dbus.Variant{
+		"reason": {Value: reason},
+	})
+	if err != nil {
+		return "", err
+	}
+	if len(r) != 1 {
+		return "", fmt.Errorf("ui: unexpected reply of org.freedesktop.portal.Inhibit.Inhibit: %v", r)
+	}
+	h, ok := r[0].(dbus.ObjectPath)
+	if !ok {
+		return "", fmt.Errorf("ui: unexpected reply of org.freedesktop.portal.Inhibit.Inhibit: %v", r)
+	}
+	return h, nil
+}
+
 func (u *UserInterface) afterWindowCreation() error {
 	return nil
 }
@@ -19,6 +19,7 @@ package ui
 import (
 	stdcontext "context"
 	"fmt"
+	"image"
 	"runtime"
 	"runtime/debug"
 	"sync"
@@ -49,6 +50,10 @@ func (u *UserInterface) init() error {
 		outsideHeight: 480,
 	}
 	u.foreground.Store(true)
+	hook.AppendHookOnBeforeUpdate(func() error {
+		u.dispatchMessages()
+		return nil
+	})
 	return nil
 }
 
@@ -98,8 +103,19 @@ type userInterfaceImpl struct {
 	inputState InputState
 	touches    []TouchForInput
 
-	fpsMode  atomic.Int32
-	renderer Renderer
+	fpsMode             atomic.Int32
+	renderer            Renderer
+	orientationLocker   OrientationLocker
+	frameRateController FrameRateController
+
+	screenSaverInhibitor ScreenSaverInhibitor
+	screenSaverInhibited bool
+
+	announcer Announcer
+
+	messageReceiver MessageReceiver
+	messageHandler  func(name string, payload []byte)
+	messages        []message
 
 	strictContextRestoration     atomic.Bool
 	strictContextRestorationOnce sync.Once
@@ -148,7 +164,8 @@ func (u *UserInterface) runMobile(game Game, options *RunOptions) (err error) {
 	u.setRunning(true)
 	defer u.setRunning(false)
 
-	u.context = newContext(game)
+	u.context = newContext(game, options.AsyncUpdate)
+	defer u.context.close()
 
 	g, lib, err := newGraphicsDriver(&graphicsDriverCreatorImpl{
 		colorSpace: options.ColorSpace,
@@ -158,12 +175,14 @@ func (u *UserInterface) runMobile(game Game, options *RunOptions) (err error) {
 	}
 	u.graphicsDriver = g
 	u.setGraphicsLibrary(lib)
+	u.setScreenColorSpace(lib, options.ColorSpace)
 	close(u.graphicsLibraryInitCh)
 	if options.StrictContextRestoration {
 		u.strictContextRestoration.Store(true)
 	} else {
 		restorable.Disable()
 	}
+	restorable.SetBackupCompression(options.CompressRestorationBackups)
 
 	for {
 		if err := u.update(); err != nil {
@@ -271,6 +290,7 @@ type Monitor struct {
 	width             int
 	height            int
 	deviceScaleFactor float64
+	refreshRate       atomic.Int32
 	inited            atomic.Bool
 
 	m sync.Mutex
@@ -313,6 +333,18 @@ func (m *Monitor) Size() (int, int) {
 	return m.width, m.height
 }
 
+// RefreshRate returns the refresh rate of the display in Hz, or 0 if the refresh rate is unknown.
+func (m *Monitor) RefreshRate() int {
+	return int(m.refreshRate.Load())
+}
+
+// SetDisplayRefreshRate is called from mobile/ebitenmobileview.
+//
+// SetDisplayRefreshRate is concurrent safe.
+func (u *UserInterface) SetDisplayRefreshRate(refreshRate int) {
+	theMonitor.refreshRate.Store(int32(refreshRate))
+}
+
 func (u *UserInterface) AppendMonitors(mons []*Monitor) []*Monitor {
 	return append(mons, theMonitor)
 }
@@ -338,6 +370,225 @@ func (u *UserInterface) SetRenderer(renderer Renderer) {
 	u.updateExplicitRenderingModeIfNeeded(FPSModeType(u.fpsMode.Load()))
 }
 
+// OrientationLocker locks the orientation of the device screen.
+//
+// OrientationUnknown means that the orientation is unlocked.
+type OrientationLocker interface {
+	LockOrientation(orientation Orientation)
+}
+
+// SetOrientationLocker is called from mobile/ebitenmobileview.
+func (u *UserInterface) SetOrientationLocker(locker OrientationLocker) {
+	u.m.Lock()
+	defer u.m.Unlock()
+	u.orientationLocker = locker
+}
+
+// LockOrientation is concurrent safe.
+func (u *UserInterface) LockOrientation(orientation Orientation) {
+	u.m.RLock()
+	l := u.orientationLocker
+	u.m.RUnlock()
+	if l == nil {
+		return
+	}
+	l.LockOrientation(orientation)
+}
+
+// SetDeviceOrientation is called from mobile/ebitenmobileview.
+//
+// SetDeviceOrientation is concurrent safe.
+func (u *UserInterface) SetDeviceOrientation(orientation Orientation) {
+	u.m.Lock()
+	defer u.m.Unlock()
+	if u.inputState.Orientation == orientation {
+		return
+	}
+	u.inputState.Orientation = orientation
+	u.inputState.OrientationChanged = true
+}
+
+// SetSafeAreaInsets is called from mobile/ebitenmobileview.
+//
+// SetSafeAreaInsets is concurrent safe.
+func (u *UserInterface) SetSafeAreaInsets(insets Insets) {
+	u.m.Lock()
+	defer u.m.Unlock()
+	u.inputState.SafeAreaInsets = insets
+}
+
+// FrameRateController controls the frame rate of the display link.
+type FrameRateController interface {
+	SetPreferredFrameRateRange(min, max, preferred int)
+}
+
+// SetFrameRateController is called from mobile/ebitenmobileview.
+func (u *UserInterface) SetFrameRateController(controller FrameRateController) {
+	u.m.Lock()
+	defer u.m.Unlock()
+	u.frameRateController = controller
+}
+
+// SetPreferredFrameRateRange is concurrent safe.
+func (u *UserInterface) SetPreferredFrameRateRange(min, max, preferred int) {
+	u.m.RLock()
+	c := u.frameRateController
+	u.m.RUnlock()
+	if c == nil {
+		return
+	}
+	c.SetPreferredFrameRateRange(min, max, preferred)
+}
+
+// ScreenSaverInhibitor inhibits the screen from dimming or locking automatically.
+type ScreenSaverInhibitor interface {
+	SetScreenSaverInhibited(inhibited bool)
+}
+
+// SetScreenSaverInhibitor is called from mobile/ebitenmobileview.
+func (u *UserInterface) SetScreenSaverInhibitor(inhibitor ScreenSaverInhibitor) {
+	u.m.Lock()
+	u.screenSaverInhibitor = inhibitor
+	inhibited := u.screenSaverInhibited
+	u.m.Unlock()
+
+	if inhibitor == nil || !inhibited {
+		return
+	}
+	inhibitor.SetScreenSaverInhibited(true)
+}
+
+// SetScreenSaverInhibited is concurrent safe.
+func (u *UserInterface) SetScreenSaverInhibited(inhibited bool) {
+	u.m.Lock()
+	if u.screenSaverInhibited == inhibited {
+		u.m.Unlock()
+		return
+	}
+	u.screenSaverInhibited = inhibited
+	i := u.screenSaverInhibitor
+	u.m.Unlock()
+
+	if i == nil {
+		return
+	}
+	i.SetScreenSaverInhibited(inhibited)
+}
+
+// Announcer sends announcements to the platform's screen reader.
+type Announcer interface {
+	Announce(text string, assertive bool)
+}
+
+// SetAnnouncer is called from mobile/ebitenmobileview.
+func (u *UserInterface) SetAnnouncer(announcer Announcer) {
+	u.m.Lock()
+	defer u.m.Unlock()
+	u.announcer = announcer
+}
+
+// Announce is concurrent safe.
+func (u *UserInterface) Announce(text string, assertive bool) {
+	u.m.RLock()
+	a := u.announcer
+	u.m.RUnlock()
+
+	if a == nil {
+		return
+	}
+	a.Announce(text, assertive)
+}
+
+// SetMultiWindowMode is called from mobile/ebitenmobileview.
+//
+// SetMultiWindowMode is concurrent safe.
+func (u *UserInterface) SetMultiWindowMode(mode MultiWindowMode) {
+	u.m.Lock()
+	defer u.m.Unlock()
+	u.inputState.MultiWindowMode = mode
+}
+
+// SetHingeBounds is called from mobile/ebitenmobileview.
+// An empty rectangle means that there is no hinge.
+//
+// SetHingeBounds is concurrent safe.
+func (u *UserInterface) SetHingeBounds(bounds image.Rectangle) {
+	u.m.Lock()
+	defer u.m.Unlock()
+	u.inputState.HingeBounds = bounds
+}
+
+// MessageReceiver receives messages sent from the game to the host application.
+type MessageReceiver interface {
+	OnMessage(name string, payload []byte)
+}
+
+type message struct {
+	name    string
+	payload []byte
+}
+
+// SetMessageReceiver is called from mobile/ebitenmobileview.
+func (u *UserInterface) SetMessageReceiver(receiver MessageReceiver) {
+	u.m.Lock()
+	defer u.m.Unlock()
+	u.messageReceiver = receiver
+}
+
+// SendMessageToHost sends a message to the host application.
+//
+// SendMessageToHost is concurrent safe.
+func (u *UserInterface) SendMessageToHost(name string, payload []byte) error {
+	u.m.RLock()
+	r := u.messageReceiver
+	u.m.RUnlock()
+	if r == nil {
+		return fmt.Errorf("ui: no message receiver is registered by the host application")
+	}
+	r.OnMessage(name, payload)
+	return nil
+}
+
+// SetMessageHandler sets the function to handle messages from the host application.
+// The handler is called on the game's goroutine before Update.
+//
+// SetMessageHandler is concurrent safe.
+func (u *UserInterface) SetMessageHandler(handler func(name string, payload []byte)) {
+	u.m.Lock()
+	defer u.m.Unlock()
+	u.messageHandler = handler
+}
+
+// SendMessageToGame is called from mobile/ebitenmobileview.
+// The message is queued and delivered to the message handler at the next tick.
+//
+// SendMessageToGame is concurrent safe.
+func (u *UserInterface) SendMessageToGame(name string, payload []byte) {
+	u.m.Lock()
+	defer u.m.Unlock()
+	u.messages = append(u.messages, message{
+		name:    name,
+		payload: payload,
+	})
+}
+
+func (u *UserInterface) dispatchMessages() {
+	u.m.Lock()
+	h := u.messageHandler
+	if h == nil {
+		// Keep the messages until a handler is registered.
+		u.m.Unlock()
+		return
+	}
+	msgs := u.messages
+	u.messages = nil
+	u.m.Unlock()
+
+	for _, msg := range msgs {
+		h(msg.name, msg.payload)
+	}
+}
+
 func (u *UserInterface) ScheduleFrame() {
 	if u.renderer != nil && FPSModeType(u.fpsMode.Load()) == FPSModeVsyncOffMinimum {
 		u.renderer.RequestRenderIfNeeded()
@@ -131,6 +131,9 @@ func (*UserInterface) IsFullscreen() bool {
 func (*UserInterface) SetFullscreen(fullscreen bool) {
 }
 
+func (*UserInterface) SetScreenSaverInhibited(inhibited bool) {
+}
+
 func (*UserInterface) IsRunnableOnUnfocused() bool {
 	return false
 }
@@ -168,6 +171,11 @@ func (m *Monitor) DeviceScaleFactor() float64 {
 	return 1
 }
 
+// RefreshRate returns 0, which means the refresh rate is unknown, as the refresh rate is not exposed to this package.
+func (m *Monitor) RefreshRate() int {
+	return 0
+}
+
 func (m *Monitor) Size() (int, int) {
 	return int(C.kScreenWidth), int(C.kScreenHeight)
 }
@@ -0,0 +1,38 @@
+// Copyright 2026 The Ebitengine Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build !android && !ios
+
+package ui
+
+import (
+	"fmt"
+	"runtime"
+)
+
+func (u *UserInterface) LockOrientation(orientation Orientation) {
+	// Do nothing
+}
+
+func (u *UserInterface) SetPreferredFrameRateRange(min, max, preferred int) {
+	// Do nothing
+}
+
+func (u *UserInterface) SetMessageHandler(handler func(name string, payload []byte)) {
+	// Do nothing
+}
+
+func (u *UserInterface) SendMessageToHost(name string, payload []byte) error {
+	return fmt.Errorf("ui: SendMessageToHost is not supported on GOOS=%s", runtime.GOOS)
+}
@@ -16,10 +16,17 @@
 
 package ui
 
+// #cgo !darwin LDFLAGS: -Wl,-unresolved-symbols=ignore-all
+// #cgo darwin LDFLAGS: -Wl,-undefined,dynamic_lookup
+//
+// #include "lifecycle_playstation5.h"
+import "C"
+
 import (
 	"errors"
 	"image"
 	"runtime"
+	"sync/atomic"
 
 	"github.com/duplicants-ai/ebiten/internal/graphicsdriver"
 	"github.com/duplicants-ai/ebiten/internal/graphicsdriver/playstation5"
@@ -62,6 +69,10 @@ type userInterfaceImpl struct {
 	graphicsDriver graphicsdriver.Graphics
 
 	context *context
+
+	// resumed is accessed only from the main thread.
+	resumed       bool
+	resumedInTick atomic.Bool
 }
 
 func (u *UserInterface) init() error {
@@ -83,6 +94,9 @@ func (u *UserInterface) initOnMainThread(options *RunOptions) error {
 
 func (u *UserInterface) loopGame() error {
 	for {
+		if C.ebitengine_PollResumed() != 0 {
+			u.resumed = true
+		}
 		if err := u.context.updateFrame(u.graphicsDriver, screenWidth, screenHeight, theMonitor.DeviceScaleFactor(), u); err != nil {
 			return err
 		}
@@ -96,6 +110,16 @@ func (*UserInterface) IsFocused() bool {
 
 func (u *UserInterface) readInputState(inputState *InputState) {
 	// TODO: Implement this.
+
+	u.resumedInTick.Store(u.resumed)
+	u.resumed = false
+}
+
+// IsResumed is called from the playstation5 package.
+//
+// IsResumed is concurrent-safe.
+func (u *UserInterface) IsResumed() bool {
+	return u.resumedInTick.Load()
 }
 
 func (*UserInterface) CursorMode() CursorMode {
@@ -119,6 +143,9 @@ func (*UserInterface) IsFullscreen() bool {
 func (*UserInterface) SetFullscreen(fullscreen bool) {
 }
 
+func (*UserInterface) SetScreenSaverInhibited(inhibited bool) {
+}
+
 func (*UserInterface) IsRunnableOnUnfocused() bool {
 	return false
 }
@@ -161,6 +188,11 @@ func (m *Monitor) DeviceScaleFactor() float64 {
 	return 1
 }
 
+// RefreshRate returns 0, which means the refresh rate is unknown, as the refresh rate is not exposed to this package.
+func (m *Monitor) RefreshRate() int {
+	return 0
+}
+
 func (m *Monitor) Size() (int, int) {
 	return screenWidth, screenHeight
 }
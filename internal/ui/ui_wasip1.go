@@ -0,0 +1,217 @@
+// Copyright 2026 The Ebitengine Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build wasip1
+
+package ui
+
+import (
+	"errors"
+	"fmt"
+	"image"
+	"os"
+	"time"
+
+	"github.com/duplicants-ai/ebiten/internal/graphicsdriver"
+	"github.com/duplicants-ai/ebiten/internal/graphicsdriver/software"
+)
+
+type graphicsDriverCreatorImpl struct{}
+
+func (g *graphicsDriverCreatorImpl) newAuto() (graphicsdriver.Graphics, GraphicsLibrary, error) {
+	// There is no graphics library on WASI. Use the software driver that renders with the CPU.
+	return software.NewGraphics(), GraphicsLibraryUnknown, nil
+}
+
+func (*graphicsDriverCreatorImpl) newOpenGL() (graphicsdriver.Graphics, error) {
+	return nil, errors.New("ui: OpenGL is not supported in this environment")
+}
+
+func (*graphicsDriverCreatorImpl) newDirectX() (graphicsdriver.Graphics, error) {
+	return nil, errors.New("ui: DirectX is not supported in this environment")
+}
+
+func (*graphicsDriverCreatorImpl) newMetal() (graphicsdriver.Graphics, error) {
+	return nil, errors.New("ui: Metal is not supported in this environment")
+}
+
+func (*graphicsDriverCreatorImpl) newPlayStation5() (graphicsdriver.Graphics, error) {
+	return nil, errors.New("ui: PlayStation 5 is not supported in this environment")
+}
+
+const (
+	defaultScreenWidth  = 640
+	defaultScreenHeight = 480
+)
+
+type userInterfaceImpl struct {
+	graphicsDriver graphicsdriver.Graphics
+
+	screenWidth  int
+	screenHeight int
+
+	context *context
+}
+
+func (u *UserInterface) init() error {
+	u.userInterfaceImpl = userInterfaceImpl{
+		screenWidth:  defaultScreenWidth,
+		screenHeight: defaultScreenHeight,
+	}
+
+	// The host can specify the screen size by the environment variable, e.g., EBITENGINE_SCREEN_SIZE=1280x720.
+	if env := os.Getenv("EBITENGINE_SCREEN_SIZE"); env != "" {
+		var w, h int
+		if _, err := fmt.Sscanf(env, "%dx%d", &w, &h); err != nil || w <= 0 || h <= 0 {
+			return fmt.Errorf("ui: invalid EBITENGINE_SCREEN_SIZE: %q", env)
+		}
+		u.screenWidth = w
+		u.screenHeight = h
+	}
+	return nil
+}
+
+func (u *UserInterface) initOnMainThread(options *RunOptions) error {
+	u.setRunning(true)
+
+	g, lib, err := newGraphicsDriver(&graphicsDriverCreatorImpl{}, options.GraphicsLibrary)
+	if err != nil {
+		return err
+	}
+	u.graphicsDriver = g
+	u.setGraphicsLibrary(lib)
+
+	if g, ok := g.(*software.Graphics); ok {
+		g.SetPresentFunc(presentToHostSurface)
+	}
+
+	return nil
+}
+
+func (u *UserInterface) loopGame() error {
+	// There is no vsync. Wait for a frame duration to emulate 60 [Hz] display.
+	const frameDuration = time.Second / 60
+
+	for {
+		start := time.Now()
+		// With the host surface, the screen size follows the surface size.
+		if w, h, ok := hostSurfaceSize(); ok {
+			u.screenWidth = w
+			u.screenHeight = h
+		}
+		if err := u.context.updateFrame(u.graphicsDriver, float64(u.screenWidth), float64(u.screenHeight), theMonitor.DeviceScaleFactor(), u); err != nil {
+			return err
+		}
+		if d := frameDuration - time.Since(start); d > 0 {
+			time.Sleep(d)
+		}
+	}
+}
+
+func (*UserInterface) IsFocused() bool {
+	return true
+}
+
+func (u *UserInterface) readInputState(inputState *InputState) {
+	// There is no input device on WASI.
+}
+
+func (*UserInterface) CursorMode() CursorMode {
+	return CursorModeHidden
+}
+
+func (*UserInterface) SetCursorMode(mode CursorMode) {
+}
+
+func (*UserInterface) CursorShape() CursorShape {
+	return CursorShapeDefault
+}
+
+func (*UserInterface) SetCursorShape(shape CursorShape) {
+}
+
+func (*UserInterface) IsFullscreen() bool {
+	return false
+}
+
+func (*UserInterface) SetFullscreen(fullscreen bool) {
+}
+
+func (*UserInterface) SetScreenSaverInhibited(inhibited bool) {
+}
+
+func (*UserInterface) IsRunnableOnUnfocused() bool {
+	return true
+}
+
+func (*UserInterface) SetRunnableOnUnfocused(runnableOnUnfocused bool) {
+}
+
+func (*UserInterface) FPSMode() FPSModeType {
+	return FPSModeVsyncOn
+}
+
+func (*UserInterface) SetFPSMode(mode FPSModeType) {
+}
+
+func (*UserInterface) ScheduleFrame() {
+}
+
+func (*UserInterface) Window() Window {
+	return &nullWindow{}
+}
+
+func (u *UserInterface) updateIconIfNeeded() error {
+	return nil
+}
+
+type Monitor struct{}
+
+var theMonitor = &Monitor{}
+
+func (m *Monitor) Bounds() image.Rectangle {
+	return image.Rect(0, 0, theUI.screenWidth, theUI.screenHeight)
+}
+
+func (m *Monitor) Name() string {
+	return ""
+}
+
+func (m *Monitor) DeviceScaleFactor() float64 {
+	return 1
+}
+
+func (m *Monitor) RefreshRate() int {
+	return 60
+}
+
+func (m *Monitor) Size() (int, int) {
+	return theUI.screenWidth, theUI.screenHeight
+}
+
+func (u *UserInterface) AppendMonitors(mons []*Monitor) []*Monitor {
+	return append(mons, theMonitor)
+}
+
+func (u *UserInterface) Monitor() *Monitor {
+	return theMonitor
+}
+
+func IsScreenTransparentAvailable() bool {
+	return false
+}
+
+func dipToNativePixels(x float64, scale float64) float64 {
+	return x
+}
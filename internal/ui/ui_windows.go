@@ -19,6 +19,7 @@ import (
 	"fmt"
 	"runtime"
 	"syscall"
+	"unsafe"
 
 	"golang.org/x/sys/windows"
 
@@ -256,6 +257,232 @@ func (u *UserInterface) setDocumentEdited(edited bool) error {
 	return nil
 }
 
+// setWindowProgressForOS must be called from the main thread.
+func (u *UserInterface) setWindowProgressForOS(state WindowProgressState, progress float64) error {
+	if microsoftgdk.IsXbox() {
+		return nil
+	}
+
+	// S_FALSE is returned when CoInitializeEx is nested. This is a successful case.
+	if err := windows.CoInitializeEx(0, windows.COINIT_MULTITHREADED); err != nil && !errors.Is(err, syscall.Errno(windows.S_FALSE)) {
+		return err
+	}
+	// CoUninitialize should be called even when CoInitializeEx returns S_FALSE.
+	defer windows.CoUninitialize()
+
+	ptr, err := _CoCreateInstance(&_CLSID_TaskbarList, nil, _CLSCTX_SERVER, &_IID_ITaskbarList3)
+	if err != nil {
+		return err
+	}
+
+	t := (*_ITaskbarList3)(ptr)
+	defer t.Release()
+
+	if err := t.HrInit(); err != nil {
+		return err
+	}
+
+	w, err := u.window.GetWin32Window()
+	if err != nil {
+		return err
+	}
+
+	var flags uint32
+	switch state {
+	case WindowProgressStateNone:
+		flags = _TBPF_NOPROGRESS
+	case WindowProgressStateNormal:
+		flags = _TBPF_NORMAL
+	case WindowProgressStateIndeterminate:
+		flags = _TBPF_INDETERMINATE
+	case WindowProgressStatePaused:
+		flags = _TBPF_PAUSED
+	case WindowProgressStateError:
+		flags = _TBPF_ERROR
+	}
+	if err := t.SetProgressState(w, flags); err != nil {
+		return err
+	}
+	if flags == _TBPF_NOPROGRESS || flags == _TBPF_INDETERMINATE {
+		return nil
+	}
+
+	const total = 10000
+	if err := t.SetProgressValue(w, uint64(progress*total), total); err != nil {
+		return err
+	}
+	return nil
+}
+
+// setWindowBadgeCountForOS must be called from the main thread.
+func (u *UserInterface) setWindowBadgeCountForOS(count int) error {
+	if microsoftgdk.IsXbox() {
+		return nil
+	}
+
+	// S_FALSE is returned when CoInitializeEx is nested. This is a successful case.
+	if err := windows.CoInitializeEx(0, windows.COINIT_MULTITHREADED); err != nil && !errors.Is(err, syscall.Errno(windows.S_FALSE)) {
+		return err
+	}
+	// CoUninitialize should be called even when CoInitializeEx returns S_FALSE.
+	defer windows.CoUninitialize()
+
+	ptr, err := _CoCreateInstance(&_CLSID_TaskbarList, nil, _CLSCTX_SERVER, &_IID_ITaskbarList3)
+	if err != nil {
+		return err
+	}
+
+	t := (*_ITaskbarList3)(ptr)
+	defer t.Release()
+
+	if err := t.HrInit(); err != nil {
+		return err
+	}
+
+	w, err := u.window.GetWin32Window()
+	if err != nil {
+		return err
+	}
+
+	if count <= 0 {
+		return t.SetOverlayIcon(w, 0, nil)
+	}
+
+	// The overlay icon is shown at the small icon size.
+	size, err := _GetSystemMetrics(_SM_CXSMICON)
+	if err != nil {
+		size = 16
+	}
+	icon, err := createBadgeIcon(int(size), count)
+	if err != nil {
+		return err
+	}
+	// The taskbar keeps its own copy of the icon, so the icon can be destroyed right after the call.
+	defer func() {
+		_ = _DestroyIcon(icon)
+	}()
+
+	desc, err := windows.UTF16PtrFromString(fmt.Sprintf("%d", count))
+	if err != nil {
+		return err
+	}
+	return t.SetOverlayIcon(w, icon, desc)
+}
+
+// badgeDigitGlyphs is a 3x5 bitmap font for the badge. Each row is represented by the lower 3 bits.
+var badgeDigitGlyphs = map[rune][5]byte{
+	'0': {0b111, 0b101, 0b101, 0b101, 0b111},
+	'1': {0b010, 0b110, 0b010, 0b010, 0b111},
+	'2': {0b111, 0b001, 0b111, 0b100, 0b111},
+	'3': {0b111, 0b001, 0b111, 0b001, 0b111},
+	'4': {0b101, 0b101, 0b111, 0b001, 0b001},
+	'5': {0b111, 0b100, 0b111, 0b001, 0b111},
+	'6': {0b111, 0b100, 0b111, 0b101, 0b111},
+	'7': {0b111, 0b001, 0b001, 0b001, 0b001},
+	'8': {0b111, 0b101, 0b111, 0b101, 0b111},
+	'9': {0b111, 0b101, 0b111, 0b001, 0b111},
+	'+': {0b000, 0b010, 0b111, 0b010, 0b000},
+}
+
+// badgePixels returns BGRA pixels of a badge with the given size, which is a red circle with the count in white.
+func badgePixels(size int, count int) []byte {
+	text := fmt.Sprintf("%d", count)
+	if count > 99 {
+		text = "99+"
+	}
+
+	// Each glyph is 3 dots wide with 1 dot spacing.
+	textW := 4*len(text) - 1
+	scale := max(min(size*3/4/textW, size/2/5), 1)
+	originX := (size - textW*scale) / 2
+	originY := (size - 5*scale) / 2
+
+	isText := func(x, y int) bool {
+		gx := (x - originX) / scale
+		gy := (y - originY) / scale
+		if x < originX || y < originY || gx >= textW || gy >= 5 {
+			return false
+		}
+		if gx%4 == 3 {
+			return false
+		}
+		glyph := badgeDigitGlyphs[rune(text[gx/4])]
+		return glyph[gy]&(1<<(2-gx%4)) != 0
+	}
+
+	pix := make([]byte, 4*size*size)
+	r := float64(size) / 2
+	const sub = 4
+	for j := 0; j < size; j++ {
+		for i := 0; i < size; i++ {
+			// Anti-alias the circle edge by super sampling.
+			var n int
+			for sj := 0; sj < sub; sj++ {
+				for si := 0; si < sub; si++ {
+					dx := float64(i) + (float64(si)+0.5)/sub - r
+					dy := float64(j) + (float64(sj)+0.5)/sub - r
+					if dx*dx+dy*dy <= r*r {
+						n++
+					}
+				}
+			}
+			if n == 0 {
+				continue
+			}
+			idx := 4 * (j*size + i)
+			if isText(i, j) {
+				pix[idx], pix[idx+1], pix[idx+2] = 0xff, 0xff, 0xff
+			} else {
+				pix[idx], pix[idx+1], pix[idx+2] = 0x23, 0x11, 0xe8
+			}
+			pix[idx+3] = byte(0xff * n / (sub * sub))
+		}
+	}
+	return pix
+}
+
+func createBadgeIcon(size int, count int) (uintptr, error) {
+	pix := badgePixels(size, count)
+
+	color, err := _CreateBitmap(int32(size), int32(size), 1, 32, unsafe.Pointer(&pix[0]))
+	if err != nil {
+		return 0, err
+	}
+	defer func() {
+		_ = _DeleteObject(color)
+	}()
+
+	// The mask is ignored as the color bitmap has an alpha channel, but it is still required.
+	mask, err := _CreateBitmap(int32(size), int32(size), 1, 1, nil)
+	if err != nil {
+		return 0, err
+	}
+	defer func() {
+		_ = _DeleteObject(mask)
+	}()
+
+	ii := _ICONINFO{
+		fIcon:    1,
+		hbmMask:  mask,
+		hbmColor: color,
+	}
+	return _CreateIconIndirect(&ii)
+}
+
+// setScreenSaverInhibitedForOS must be called from the main thread.
+func (u *UserInterface) setScreenSaverInhibitedForOS(inhibited bool) error {
+	if microsoftgdk.IsXbox() {
+		return nil
+	}
+
+	// SetThreadExecutionState affects only the current thread, so this must be called from the same thread.
+	flags := uint32(_ES_CONTINUOUS)
+	if inhibited {
+		flags |= _ES_DISPLAY_REQUIRED | _ES_SYSTEM_REQUIRED
+	}
+	return _SetThreadExecutionState(flags)
+}
+
 func (u *UserInterface) afterWindowCreation() error {
 	if microsoftgdk.IsXbox() {
 		return nil
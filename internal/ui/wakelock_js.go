@@ -0,0 +1,88 @@
+// Copyright 2026 The Ebitengine Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ui
+
+import (
+	"syscall/js"
+)
+
+var (
+	jsWakeLock = js.Global().Get("navigator").Get("wakeLock")
+
+	jsWakeLockOnFulfilled js.Func
+	jsWakeLockOnRejected  js.Func
+	jsWakeLockOnReleased  js.Func
+)
+
+func (u *UserInterface) SetScreenSaverInhibited(inhibited bool) {
+	if u.screenSaverInhibited == inhibited {
+		return
+	}
+	u.screenSaverInhibited = inhibited
+	u.updateWakeLock()
+}
+
+// updateWakeLock requests or releases a screen wake lock based on u.screenSaverInhibited.
+//
+// See https://developer.mozilla.org/en-US/docs/Web/API/Screen_Wake_Lock_API
+func (u *UserInterface) updateWakeLock() {
+	if !u.screenSaverInhibited {
+		if u.wakeLock.Truthy() {
+			u.wakeLock.Call("release")
+			u.wakeLock = js.Undefined()
+		}
+		return
+	}
+
+	if u.wakeLock.Truthy() || u.wakeLockRequesting {
+		return
+	}
+	// The Screen Wake Lock API is not available on some browsers and on Web Workers.
+	if !jsWakeLock.Truthy() {
+		return
+	}
+	// A wake lock can be requested only when the page is visible.
+	if !document.Truthy() || documentHidden.Invoke().Bool() {
+		return
+	}
+
+	if jsWakeLockOnFulfilled.IsUndefined() {
+		jsWakeLockOnFulfilled = js.FuncOf(func(this js.Value, args []js.Value) any {
+			u.wakeLockRequesting = false
+			sentinel := args[0]
+			if !u.screenSaverInhibited {
+				sentinel.Call("release")
+				return nil
+			}
+			u.wakeLock = sentinel
+			sentinel.Call("addEventListener", "release", jsWakeLockOnReleased)
+			return nil
+		})
+		jsWakeLockOnRejected = js.FuncOf(func(this js.Value, args []js.Value) any {
+			// Inhibiting the screen saver is best-effort. Ignore the error.
+			u.wakeLockRequesting = false
+			return nil
+		})
+		jsWakeLockOnReleased = js.FuncOf(func(this js.Value, args []js.Value) any {
+			if u.wakeLock.Equal(this) {
+				u.wakeLock = js.Undefined()
+			}
+			return nil
+		})
+	}
+
+	u.wakeLockRequesting = true
+	jsWakeLock.Call("request", "screen").Call("then", jsWakeLockOnFulfilled, jsWakeLockOnRejected)
+}
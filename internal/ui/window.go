@@ -16,6 +16,7 @@ package ui
 
 import (
 	"image"
+	"math"
 )
 
 type Window interface {
@@ -28,6 +29,10 @@ type Window interface {
 	SetPosition(x, y int)
 	Size() (int, int)
 	SetSize(width, height int)
+	PositionInPhysicalPixels() (int, int)
+	SetPositionInPhysicalPixels(x, y int)
+	SizeInPhysicalPixels() (int, int)
+	SetSizeInPhysicalPixels(width, height int)
 	SizeLimits() (minw, minh, maxw, maxh int)
 	SetSizeLimits(minw, minh, maxw, maxh int)
 	IsFloating() bool
@@ -43,7 +48,13 @@ type Window interface {
 	IsClosingHandled() bool
 	SetMousePassthrough(enabled bool)
 	IsMousePassthrough() bool
+	SetMousePassthroughRegions(regions []image.Rectangle)
+	MousePassthroughRegions() []image.Rectangle
+	Opacity() float64
+	SetOpacity(opacity float64)
 	RequestAttention()
+	SetProgress(state WindowProgressState, progress float64)
+	SetBadgeCount(count int)
 }
 
 type nullWindow struct{}
@@ -79,6 +90,20 @@ func (*nullWindow) Size() (int, int) {
 func (*nullWindow) SetSize(width, height int) {
 }
 
+func (*nullWindow) PositionInPhysicalPixels() (int, int) {
+	return 0, 0
+}
+
+func (*nullWindow) SetPositionInPhysicalPixels(x, y int) {
+}
+
+func (*nullWindow) SizeInPhysicalPixels() (int, int) {
+	return 0, 0
+}
+
+func (*nullWindow) SetSizeInPhysicalPixels(width, height int) {
+}
+
 func (*nullWindow) SizeLimits() (minw, minh, maxw, maxh int) {
 	return -1, -1, -1, -1
 }
@@ -130,5 +155,36 @@ func (*nullWindow) IsMousePassthrough() bool {
 	return false
 }
 
+func (*nullWindow) SetMousePassthroughRegions(regions []image.Rectangle) {
+}
+
+func (*nullWindow) MousePassthroughRegions() []image.Rectangle {
+	return nil
+}
+
+func (*nullWindow) Opacity() float64 {
+	return 1
+}
+
+func (*nullWindow) SetOpacity(opacity float64) {
+}
+
 func (*nullWindow) RequestAttention() {
 }
+
+func (*nullWindow) SetProgress(state WindowProgressState, progress float64) {
+}
+
+func (*nullWindow) SetBadgeCount(count int) {
+}
+
+// isInMousePassthroughRegions reports whether the cursor position in the logical screen coordinates is in one of the regions.
+func isInMousePassthroughRegions(regions []image.Rectangle, cursorX, cursorY float64) bool {
+	p := image.Pt(int(math.Floor(cursorX)), int(math.Floor(cursorY)))
+	for _, r := range regions {
+		if p.In(r) {
+			return true
+		}
+	}
+	return false
+}
@@ -12,13 +12,15 @@
 // See the License for the specific language governing permissions and
 // limitations under the License.
 
-//go:build !android && !ios && !js && !nintendosdk && !playstation5
+//go:build !android && !ios && !js && !nintendosdk && !playstation5 && !wasip1
 
 package ui
 
 import (
 	"image"
+	"math"
 	"runtime"
+	"slices"
 
 	"github.com/duplicants-ai/ebiten/internal/glfw"
 )
@@ -402,6 +404,130 @@ func (w *glfwWindow) SetSize(width, height int) {
 	})
 }
 
+func (w *glfwWindow) PositionInPhysicalPixels() (int, int) {
+	if w.ui.isTerminated() {
+		return 0, 0
+	}
+	if !w.ui.isRunning() {
+		panic("ui: WindowPositionInPhysicalPixels can't be called before the main loop starts")
+	}
+	var x, y int
+	w.ui.mainThread.Call(func() {
+		if w.ui.isTerminated() {
+			return
+		}
+		f, err := w.ui.isFullscreen()
+		if err != nil {
+			w.ui.setError(err)
+			return
+		}
+
+		var wx, wy int
+		if f {
+			wx, wy = w.ui.origWindowPos()
+		} else {
+			x, y, err := w.ui.window.GetPos()
+			if err != nil {
+				w.ui.setError(err)
+				return
+			}
+			wx, wy = x, y
+		}
+		m, err := w.ui.currentMonitor()
+		if err != nil {
+			w.ui.setError(err)
+			return
+		}
+		wx -= m.boundsInGLFWPixels.Min.X
+		wy -= m.boundsInGLFWPixels.Min.Y
+		s := m.DeviceScaleFactor()
+		x = int(math.Round(dipFromGLFWPixel(float64(wx), s) * s))
+		y = int(math.Round(dipFromGLFWPixel(float64(wy), s) * s))
+	})
+	return x, y
+}
+
+func (w *glfwWindow) SetPositionInPhysicalPixels(x, y int) {
+	if w.ui.isTerminated() {
+		return
+	}
+	if !w.ui.isRunning() {
+		s := w.ui.getInitMonitor().DeviceScaleFactor()
+		w.ui.setInitWindowPositionInDIP(int(math.Round(float64(x)/s)), int(math.Round(float64(y)/s)))
+		return
+	}
+	w.ui.mainThread.Call(func() {
+		if w.ui.isTerminated() {
+			return
+		}
+		m, err := w.ui.currentMonitor()
+		if err != nil {
+			w.ui.setError(err)
+			return
+		}
+		s := m.DeviceScaleFactor()
+		gx := int(math.Round(dipToGLFWPixel(float64(x)/s, s)))
+		gy := int(math.Round(dipToGLFWPixel(float64(y)/s, s)))
+		if err := w.ui.setWindowPositionInGLFWPixels(gx, gy, m); err != nil {
+			w.ui.setError(err)
+			return
+		}
+	})
+}
+
+func (w *glfwWindow) SizeInPhysicalPixels() (int, int) {
+	if w.ui.isTerminated() {
+		return 0, 0
+	}
+	if !w.ui.isRunning() {
+		ww, wh := w.ui.getInitWindowSizeInDIP()
+		ww, wh = w.ui.adjustWindowSizeBasedOnSizeLimitsInDIP(ww, wh)
+		s := w.ui.getInitMonitor().DeviceScaleFactor()
+		return int(math.Round(float64(ww) * s)), int(math.Round(float64(wh) * s))
+	}
+	var ww, wh int
+	w.ui.mainThread.Call(func() {
+		if w.ui.isTerminated() {
+			return
+		}
+		v, err := w.ui.windowSizeInPhysicalPixels()
+		if err != nil {
+			w.ui.setError(err)
+			return
+		}
+		ww, wh = v.X, v.Y
+	})
+	return ww, wh
+}
+
+func (w *glfwWindow) SetSizeInPhysicalPixels(width, height int) {
+	if w.ui.isTerminated() {
+		return
+	}
+	if !w.ui.isRunning() {
+		s := w.ui.getInitMonitor().DeviceScaleFactor()
+		w.ui.setInitWindowSizeInDIP(max(int(math.Round(float64(width)/s)), 1), max(int(math.Round(float64(height)/s)), 1))
+		return
+	}
+	w.ui.mainThread.Call(func() {
+		if w.ui.isTerminated() {
+			return
+		}
+		m, err := w.ui.isWindowMaximized()
+		if err != nil {
+			w.ui.setError(err)
+			return
+		}
+		if m && runtime.GOOS != "darwin" {
+			return
+		}
+		if err := w.ui.setWindowSizeInPhysicalPixels(width, height); err != nil {
+			w.ui.setError(err)
+			return
+		}
+	})
+}
+
 func (w *glfwWindow) SizeLimits() (minw, minh, maxw, maxh int) {
 	return w.ui.getWindowSizeLimitsInDIP()
 }
@@ -482,6 +608,7 @@ func (w *glfwWindow) SetMousePassthrough(enabled bool) {
 			w.ui.setError(err)
 			return
 		}
+		w.ui.mousePassthroughByRegions = false
 	})
 }
 
@@ -502,11 +629,49 @@ func (w *glfwWindow) IsMousePassthrough() bool {
 			w.ui.setError(err)
 			return
 		}
-		v = a == glfw.True
+		// The mouse passthrough by the regions is not reported.
+		v = a == glfw.True && !w.ui.mousePassthroughByRegions
 	})
 	return v
 }
 
+func (w *glfwWindow) SetMousePassthroughRegions(regions []image.Rectangle) {
+	w.ui.setWindowMousePassthroughRegions(regions)
+}
+
+func (w *glfwWindow) MousePassthroughRegions() []image.Rectangle {
+	return slices.Clone(w.ui.getWindowMousePassthroughRegions())
+}
+
+func (w *glfwWindow) Opacity() float64 {
+	w.ui.m.RLock()
+	defer w.ui.m.RUnlock()
+	return w.ui.windowOpacity
+}
+
+func (w *glfwWindow) SetOpacity(opacity float64) {
+	w.ui.m.Lock()
+	w.ui.windowOpacity = opacity
+	w.ui.m.Unlock()
+
+	if w.ui.isTerminated() {
+		return
+	}
+	// If the game is not running yet, the opacity is applied when the window is created.
+	if !w.ui.isRunning() {
+		return
+	}
+	w.ui.mainThread.Call(func() {
+		if w.ui.isTerminated() {
+			return
+		}
+		if err := w.ui.window.SetOpacity(float32(opacity)); err != nil {
+			w.ui.setError(err)
+			return
+		}
+	})
+}
+
 func (w *glfwWindow) RequestAttention() {
 	if w.ui.isTerminated() {
 		return
@@ -525,3 +690,57 @@ func (w *glfwWindow) RequestAttention() {
 		}
 	})
 }
+
+func (w *glfwWindow) SetProgress(state WindowProgressState, progress float64) {
+	if progress < 0 {
+		progress = 0
+	}
+	if progress > 1 {
+		progress = 1
+	}
+
+	w.ui.m.Lock()
+	w.ui.progressState = state
+	w.ui.progress = progress
+	w.ui.m.Unlock()
+
+	if w.ui.isTerminated() {
+		return
+	}
+	// If the game is not running yet, the progress is applied when the window is created.
+	if !w.ui.isRunning() {
+		return
+	}
+	w.ui.mainThread.Call(func() {
+		if w.ui.isTerminated() {
+			return
+		}
+		// Showing the progress is best-effort. Ignore the error.
+		_ = w.ui.setWindowProgressForOS(state, progress)
+	})
+}
+
+func (w *glfwWindow) SetBadgeCount(count int) {
+	if count < 0 {
+		count = 0
+	}
+
+	w.ui.m.Lock()
+	w.ui.badgeCount = count
+	w.ui.m.Unlock()
+
+	if w.ui.isTerminated() {
+		return
+	}
+	// If the game is not running yet, the badge count is applied when the window is created.
+	if !w.ui.isRunning() {
+		return
+	}
+	w.ui.mainThread.Call(func() {
+		if w.ui.isTerminated() {
+			return
+		}
+		// Showing the badge is best-effort. Ignore the error.
+		_ = w.ui.setWindowBadgeCountForOS(count)
+	})
+}
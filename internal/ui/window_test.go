@@ -0,0 +1,52 @@
+// Copyright 2026 The Ebitengine Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ui_test
+
+import (
+	"image"
+	"testing"
+
+	"github.com/duplicants-ai/ebiten/internal/ui"
+)
+
+func TestIsInMousePassthroughRegions(t *testing.T) {
+	regions := []image.Rectangle{
+		image.Rect(0, 0, 10, 10),
+		image.Rect(20, 20, 30, 40),
+	}
+	testCases := []struct {
+		X    float64
+		Y    float64
+		Want bool
+	}{
+		{X: 0, Y: 0, Want: true},
+		{X: 9.9, Y: 9.9, Want: true},
+		{X: 10, Y: 5, Want: false},
+		{X: 5, Y: 10, Want: false},
+		{X: -0.5, Y: 5, Want: false},
+		{X: 15, Y: 15, Want: false},
+		{X: 25.5, Y: 39.5, Want: true},
+		{X: 30, Y: 30, Want: false},
+	}
+	for _, tc := range testCases {
+		if got := ui.IsInMousePassthroughRegionsForTesting(regions, tc.X, tc.Y); got != tc.Want {
+			t.Errorf("(%f, %f): got: %t, want: %t", tc.X, tc.Y, got, tc.Want)
+		}
+	}
+
+	if ui.IsInMousePassthroughRegionsForTesting(nil, 0, 0) {
+		t.Errorf("no regions: got: true, want: false")
+	}
+}
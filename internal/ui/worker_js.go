@@ -0,0 +1,105 @@
+// Copyright 2026 The Ebitengine Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ui
+
+import (
+	"syscall/js"
+)
+
+// isWorker reports whether the program runs on a Web Worker.
+//
+// On a Web Worker, the game is rendered into an OffscreenCanvas transferred from the main thread,
+// and input events are proxied from the main thread by postMessage.
+// See misc/wasm/ebitengine_worker.js for the script on the main thread.
+var isWorker = !document.Truthy() && js.Global().Get("WorkerGlobalScope").Truthy()
+
+// workerState is the state of the main thread's page reported to the Web Worker.
+type workerState struct {
+	outsideWidth      float64
+	outsideHeight     float64
+	deviceScaleFactor float64
+	screenWidth       int
+	screenHeight      int
+	focused           bool
+}
+
+func (w *workerState) updateSize(data js.Value) {
+	w.outsideWidth = data.Get("width").Float()
+	w.outsideHeight = data.Get("height").Float()
+	w.deviceScaleFactor = data.Get("devicePixelRatio").Float()
+	if w.deviceScaleFactor == 0 {
+		w.deviceScaleFactor = 1
+	}
+}
+
+// initWorker waits for an OffscreenCanvas from the main thread and starts receiving messages.
+func (u *UserInterface) initWorker() {
+	ch := make(chan struct{})
+	js.Global().Call("addEventListener", "message", js.FuncOf(func(this js.Value, args []js.Value) any {
+		data := args[0].Get("data")
+		if data.Type() != js.TypeObject {
+			return nil
+		}
+		t := data.Get("type")
+		if t.Type() != js.TypeString {
+			return nil
+		}
+
+		// Ignore messages not for Ebitengine, which the application might use for its own purpose.
+		switch t.String() {
+		case "ebitengine:init":
+			canvas = data.Get("canvas")
+			u.worker.updateSize(data)
+			u.worker.screenWidth = data.Get("screenWidth").Int()
+			u.worker.screenHeight = data.Get("screenHeight").Int()
+			u.worker.focused = data.Get("focused").Bool()
+			u.inputState.PageHidden = data.Get("hidden").Bool()
+			close(ch)
+		case "ebitengine:resize":
+			u.worker.updateSize(data)
+			u.updateScreenSize()
+		case "ebitengine:focus":
+			u.worker.focused = data.Get("focused").Bool()
+			u.setPageHidden(data.Get("hidden").Bool())
+		case "ebitengine:blur":
+			u.inputState.resetForBlur()
+		case "ebitengine:event":
+			if err := u.updateInputFromEvent(data.Get("event")); err != nil {
+				u.setError(err)
+				return nil
+			}
+		case "ebitengine:pointerlockchange":
+			if data.Get("locked").Bool() {
+				return nil
+			}
+			u.onPointerLockExited()
+		case "ebitengine:pointerlockerror":
+			u.onPointerLockError()
+		}
+		return nil
+	}))
+	<-ch
+}
+
+// postMessageToMainThread posts a message to the main thread's page.
+func postMessageToMainThread(typ string, data map[string]any) {
+	msg := map[string]any{
+		"type": typ,
+	}
+	for k, v := range data {
+		msg[k] = v
+	}
+	js.Global().Call("postMessage", msg)
+}
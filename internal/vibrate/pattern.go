@@ -0,0 +1,116 @@
+// Copyright 2026 The Ebitengine Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package vibrate
+
+import (
+	"slices"
+	"time"
+)
+
+// Event is a haptic event in a pattern.
+type Event struct {
+	// Transient specifies whether the event is a short tap. The duration is ignored for a transient event.
+	Transient bool
+
+	// Time is the start time relative to the start of the pattern.
+	Time time.Duration
+
+	Duration  time.Duration
+	Intensity float64
+	Sharpness float64
+}
+
+// Capabilities represents the haptic capabilities of the device.
+type Capabilities struct {
+	Supported          bool
+	IntensityControl   bool
+	SharpnessControl   bool
+	TransientSupported bool
+}
+
+// transientDuration is the duration used for a transient event on platforms without native transient events.
+const transientDuration = 20 * time.Millisecond
+
+// segment is a span of a constant amplitude in a flattened pattern.
+type segment struct {
+	duration  time.Duration
+	amplitude float64
+}
+
+// flatten converts events into a sequence of segments, starting at time 0.
+// Where events overlap, the maximum intensity is used.
+// flatten is used for platforms that accept only a waveform rather than individual events.
+func flatten(events []Event) []segment {
+	span := func(e Event) (time.Duration, time.Duration) {
+		d := e.Duration
+		if e.Transient {
+			d = transientDuration
+		}
+		return e.Time, e.Time + d
+	}
+
+	var boundaries []time.Duration
+	for _, e := range events {
+		start, end := span(e)
+		if start < 0 || end <= start {
+			continue
+		}
+		boundaries = append(boundaries, start, end)
+	}
+	if len(boundaries) == 0 {
+		return nil
+	}
+	boundaries = append(boundaries, 0)
+	slices.Sort(boundaries)
+	boundaries = slices.Compact(boundaries)
+
+	var segments []segment
+	for i := 0; i < len(boundaries)-1; i++ {
+		t0, t1 := boundaries[i], boundaries[i+1]
+		var amp float64
+		for _, e := range events {
+			start, end := span(e)
+			if start <= t0 && t1 <= end {
+				amp = max(amp, min(max(e.Intensity, 0), 1))
+			}
+		}
+		if len(segments) > 0 && segments[len(segments)-1].amplitude == amp {
+			segments[len(segments)-1].duration += t1 - t0
+			continue
+		}
+		segments = append(segments, segment{
+			duration:  t1 - t0,
+			amplitude: amp,
+		})
+	}
+	return segments
+}
+
+// onOffPattern converts segments into alternating off and on durations in milliseconds, starting with an off duration.
+// The amplitudes are ignored except for whether they are zero.
+func onOffPattern(segments []segment) []int64 {
+	pattern := []int64{0}
+	on := false
+	for _, s := range segments {
+		ms := int64(s.duration / time.Millisecond)
+		if (s.amplitude > 0) != on {
+			pattern = append(pattern, ms)
+			on = !on
+			continue
+		}
+		pattern[len(pattern)-1] += ms
+	}
+	return pattern
+}
@@ -0,0 +1,95 @@
+// Copyright 2026 The Ebitengine Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package vibrate_test
+
+import (
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/duplicants-ai/ebiten/internal/vibrate"
+)
+
+func TestFlatten(t *testing.T) {
+	const ms = time.Millisecond
+
+	cases := []struct {
+		Name   string
+		Events []vibrate.Event
+		Want   []vibrate.Segment
+	}{
+		{
+			Name:   "empty",
+			Events: nil,
+			Want:   nil,
+		},
+		{
+			Name: "delayed",
+			Events: []vibrate.Event{
+				{Time: 100 * ms, Duration: 50 * ms, Intensity: 0.5},
+			},
+			Want: []vibrate.Segment{
+				{Duration: 100 * ms, Amplitude: 0},
+				{Duration: 50 * ms, Amplitude: 0.5},
+			},
+		},
+		{
+			Name: "overlapped",
+			Events: []vibrate.Event{
+				{Time: 0, Duration: 100 * ms, Intensity: 0.25},
+				{Time: 50 * ms, Duration: 100 * ms, Intensity: 1},
+			},
+			Want: []vibrate.Segment{
+				{Duration: 50 * ms, Amplitude: 0.25},
+				{Duration: 100 * ms, Amplitude: 1},
+			},
+		},
+		{
+			Name: "transient",
+			Events: []vibrate.Event{
+				{Transient: true, Time: 0, Duration: time.Second, Intensity: 1},
+				{Transient: true, Time: 100 * ms, Intensity: 1},
+			},
+			Want: []vibrate.Segment{
+				{Duration: 20 * ms, Amplitude: 1},
+				{Duration: 80 * ms, Amplitude: 0},
+				{Duration: 20 * ms, Amplitude: 1},
+			},
+		},
+	}
+	for _, c := range cases {
+		t.Run(c.Name, func(t *testing.T) {
+			got := vibrate.Flatten(c.Events)
+			if !slices.Equal(got, c.Want) {
+				t.Errorf("got: %v, want: %v", got, c.Want)
+			}
+		})
+	}
+}
+
+func TestOnOffPattern(t *testing.T) {
+	const ms = time.Millisecond
+
+	events := []vibrate.Event{
+		{Time: 0, Duration: 100 * ms, Intensity: 0.25},
+		{Time: 100 * ms, Duration: 100 * ms, Intensity: 1},
+		{Time: 300 * ms, Duration: 50 * ms, Intensity: 1},
+	}
+	got := vibrate.OnOffPattern(events)
+	want := []int64{0, 200, 100, 50}
+	if !slices.Equal(got, want) {
+		t.Errorf("got: %v, want: %v", got, want)
+	}
+}
@@ -16,6 +16,7 @@ package vibrate
 
 import (
 	"time"
+	"unsafe"
 
 	"github.com/ebitengine/gomobile/app"
 )
@@ -27,24 +28,7 @@ import (
 
 #include <android/log.h>
 
-// Basically same as:
-//
-//     Vibrator v = (Vibrator)getSystemService(Context.VIBRATOR_SERVICE);
-//     if (Build.VERSION.SDK_INT >= 26) {
-//       v.vibrate(VibrationEffect.createOneShot(milliseconds, magnitude * 255))
-//     } else {
-//       v.vibrate(millisecond)
-//     }
-//
-// Note that this requires a manifest setting:
-//
-//     <uses-permission android:name="android.permission.VIBRATE"/>
-//
-static void vibrateOneShot(uintptr_t java_vm, uintptr_t jni_env, uintptr_t ctx, int64_t milliseconds, double magnitude) {
-  JavaVM* vm = (JavaVM*)java_vm;
-  JNIEnv* env = (JNIEnv*)jni_env;
-  jobject context = (jobject)ctx;
-
+static int getAPILevel(JNIEnv* env) {
   static int apiLevel = 0;
   if (!apiLevel) {
     const jclass android_os_Build_VERSION = (*env)->FindClass(env, "android/os/Build$VERSION");
@@ -55,9 +39,15 @@ static void vibrateOneShot(uintptr_t java_vm, uintptr_t jni_env, uintptr_t ctx,
 
     (*env)->DeleteLocalRef(env, android_os_Build_VERSION);
   }
+  return apiLevel;
+}
 
+// getVibrator returns a local reference to the Vibrator:
+//
+//     (Vibrator)getSystemService(Context.VIBRATOR_SERVICE)
+//
+static jobject getVibrator(JNIEnv* env, jobject context) {
   const jclass android_content_Context = (*env)->FindClass(env, "android/content/Context");
-  const jclass android_os_Vibrator = (*env)->FindClass(env, "android/os/Vibrator");
 
   const jobject android_context_Context_VIBRATOR_SERVICE =
       (*env)->GetStaticObjectField(
@@ -70,7 +60,34 @@ static void vibrateOneShot(uintptr_t java_vm, uintptr_t jni_env, uintptr_t ctx,
           (*env)->GetMethodID(env, android_content_Context, "getSystemService", "(Ljava/lang/String;)Ljava/lang/Object;"),
           android_context_Context_VIBRATOR_SERVICE);
 
-  if (apiLevel >= 26) {
+  (*env)->DeleteLocalRef(env, android_content_Context);
+  (*env)->DeleteLocalRef(env, android_context_Context_VIBRATOR_SERVICE);
+
+  return vibrator;
+}
+
+// Basically same as:
+//
+//     Vibrator v = (Vibrator)getSystemService(Context.VIBRATOR_SERVICE);
+//     if (Build.VERSION.SDK_INT >= 26) {
+//       v.vibrate(VibrationEffect.createOneShot(milliseconds, magnitude * 255))
+//     } else {
+//       v.vibrate(millisecond)
+//     }
+//
+// Note that this requires a manifest setting:
+//
+//     <uses-permission android:name="android.permission.VIBRATE"/>
+//
+static void vibrateOneShot(uintptr_t java_vm, uintptr_t jni_env, uintptr_t ctx, int64_t milliseconds, double magnitude) {
+  JavaVM* vm = (JavaVM*)java_vm;
+  JNIEnv* env = (JNIEnv*)jni_env;
+  jobject context = (jobject)ctx;
+
+  const jclass android_os_Vibrator = (*env)->FindClass(env, "android/os/Vibrator");
+  const jobject vibrator = getVibrator(env, context);
+
+  if (getAPILevel(env) >= 26) {
     const jclass android_os_VibrationEffect = (*env)->FindClass(env, "android/os/VibrationEffect");
 
     const jobject vibrationEffect =
@@ -94,10 +111,88 @@ static void vibrateOneShot(uintptr_t java_vm, uintptr_t jni_env, uintptr_t ctx,
         milliseconds);
   }
 
-  (*env)->DeleteLocalRef(env, android_content_Context);
   (*env)->DeleteLocalRef(env, android_os_Vibrator);
+  (*env)->DeleteLocalRef(env, vibrator);
+}
 
-  (*env)->DeleteLocalRef(env, android_context_Context_VIBRATOR_SERVICE);
+// Basically same as:
+//
+//     Vibrator v = (Vibrator)getSystemService(Context.VIBRATOR_SERVICE);
+//     if (Build.VERSION.SDK_INT >= 26) {
+//       v.vibrate(VibrationEffect.createWaveform(timings, amplitudes, -1))
+//     } else {
+//       v.vibrate(onOffTimings, -1)
+//     }
+//
+static void vibrateWaveform(uintptr_t java_vm, uintptr_t jni_env, uintptr_t ctx,
+                            int64_t* timings, int32_t* amplitudes, int n,
+                            int64_t* onOffTimings, int onOffN) {
+  JavaVM* vm = (JavaVM*)java_vm;
+  JNIEnv* env = (JNIEnv*)jni_env;
+  jobject context = (jobject)ctx;
+
+  const jclass android_os_Vibrator = (*env)->FindClass(env, "android/os/Vibrator");
+  const jobject vibrator = getVibrator(env, context);
+
+  if (getAPILevel(env) >= 26) {
+    const jclass android_os_VibrationEffect = (*env)->FindClass(env, "android/os/VibrationEffect");
+
+    jlongArray jtimings = (*env)->NewLongArray(env, n);
+    (*env)->SetLongArrayRegion(env, jtimings, 0, n, (const jlong*)timings);
+    jintArray jamplitudes = (*env)->NewIntArray(env, n);
+    (*env)->SetIntArrayRegion(env, jamplitudes, 0, n, (const jint*)amplitudes);
+
+    const jobject vibrationEffect =
+        (*env)->CallStaticObjectMethod(
+            env, android_os_VibrationEffect,
+            (*env)->GetStaticMethodID(env, android_os_VibrationEffect, "createWaveform", "([J[II)Landroid/os/VibrationEffect;"),
+            jtimings, jamplitudes, -1);
+
+    (*env)->CallVoidMethod(
+        env, vibrator,
+        (*env)->GetMethodID(env, android_os_Vibrator, "vibrate", "(Landroid/os/VibrationEffect;)V"),
+        vibrationEffect);
+
+    (*env)->DeleteLocalRef(env, android_os_VibrationEffect);
+    (*env)->DeleteLocalRef(env, jtimings);
+    (*env)->DeleteLocalRef(env, jamplitudes);
+    (*env)->DeleteLocalRef(env, vibrationEffect);
+  } else {
+    jlongArray jtimings = (*env)->NewLongArray(env, onOffN);
+    (*env)->SetLongArrayRegion(env, jtimings, 0, onOffN, (const jlong*)onOffTimings);
+
+    (*env)->CallVoidMethod(
+        env, vibrator,
+        (*env)->GetMethodID(env, android_os_Vibrator, "vibrate", "([JI)V"),
+        jtimings, -1);
+
+    (*env)->DeleteLocalRef(env, jtimings);
+  }
+
+  (*env)->DeleteLocalRef(env, android_os_Vibrator);
+  (*env)->DeleteLocalRef(env, vibrator);
+}
+
+static void getCapabilities(uintptr_t java_vm, uintptr_t jni_env, uintptr_t ctx, int* hasVibrator, int* hasAmplitudeControl) {
+  JavaVM* vm = (JavaVM*)java_vm;
+  JNIEnv* env = (JNIEnv*)jni_env;
+  jobject context = (jobject)ctx;
+
+  const jclass android_os_Vibrator = (*env)->FindClass(env, "android/os/Vibrator");
+  const jobject vibrator = getVibrator(env, context);
+
+  *hasVibrator = (*env)->CallBooleanMethod(
+      env, vibrator,
+      (*env)->GetMethodID(env, android_os_Vibrator, "hasVibrator", "()Z"));
+
+  *hasAmplitudeControl = 0;
+  if (getAPILevel(env) >= 26) {
+    *hasAmplitudeControl = (*env)->CallBooleanMethod(
+        env, vibrator,
+        (*env)->GetMethodID(env, android_os_Vibrator, "hasAmplitudeControl", "()Z"));
+  }
+
+  (*env)->DeleteLocalRef(env, android_os_Vibrator);
   (*env)->DeleteLocalRef(env, vibrator);
 }
 
@@ -113,3 +208,39 @@ func Vibrate(duration time.Duration, magnitude float64) {
 		})
 	}()
 }
+
+func PlayPattern(events []Event) {
+	segments := flatten(events)
+	if len(segments) == 0 {
+		return
+	}
+
+	timings := make([]int64, len(segments))
+	amplitudes := make([]int32, len(segments))
+	for i, s := range segments {
+		timings[i] = int64(s.duration / time.Millisecond)
+		amplitudes[i] = int32(s.amplitude * 255)
+	}
+	onOff := onOffPattern(segments)
+
+	go func() {
+		_ = app.RunOnJVM(func(vm, env, ctx uintptr) error {
+			C.vibrateWaveform(C.uintptr_t(vm), C.uintptr_t(env), C.uintptr_t(ctx),
+				(*C.int64_t)(unsafe.Pointer(&timings[0])), (*C.int32_t)(unsafe.Pointer(&amplitudes[0])), C.int(len(segments)),
+				(*C.int64_t)(unsafe.Pointer(&onOff[0])), C.int(len(onOff)))
+			return nil
+		})
+	}()
+}
+
+func QueryCapabilities() Capabilities {
+	var hasVibrator, hasAmplitudeControl C.int
+	_ = app.RunOnJVM(func(vm, env, ctx uintptr) error {
+		C.getCapabilities(C.uintptr_t(vm), C.uintptr_t(env), C.uintptr_t(ctx), &hasVibrator, &hasAmplitudeControl)
+		return nil
+	})
+	return Capabilities{
+		Supported:        hasVibrator != 0,
+		IntensityControl: hasAmplitudeControl != 0,
+	}
+}
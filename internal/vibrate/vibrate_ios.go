@@ -48,36 +48,23 @@ package vibrate
 //   return nil;
 // }
 //
-// static void vibrateOnMainThread(double duration, double intensity) {
+// static CHHapticEngine* getHapticEngine(void) API_AVAILABLE(ios(13.0)) {
+//   static BOOL initializeHapticEngineCalled = NO;
+//   static CHHapticEngine* engine = nil;
+//   if (!initializeHapticEngineCalled) {
+//     engine = (CHHapticEngine*)initializeHapticEngine();
+//     initializeHapticEngineCalled = YES;
+//   }
+//   return engine;
+// }
+//
+// static void playPatternOnMainThread(NSDictionary* hapticDict) {
 //   if (@available(iOS 13.0, *)) {
-//     static BOOL initializeHapticEngineCalled = NO;
-//     static CHHapticEngine* engine = nil;
-//     if (!initializeHapticEngineCalled) {
-//       engine = (CHHapticEngine*)initializeHapticEngine();
-//       initializeHapticEngineCalled = YES;
-//     }
+//     CHHapticEngine* engine = getHapticEngine();
 //     if (!engine) {
 //       return;
 //     }
 //     @autoreleasepool {
-//       NSDictionary* hapticDict = @{
-//         (id<NSCopying>)(CHHapticPatternKeyPattern): @[
-//           @{
-//             (id<NSCopying>)(CHHapticPatternKeyEvent): @{
-//               (id<NSCopying>)(CHHapticPatternKeyEventType):CHHapticEventTypeHapticContinuous,
-//               (id<NSCopying>)(CHHapticPatternKeyTime):@0.0,
-//               (id<NSCopying>)(CHHapticPatternKeyEventDuration):[NSNumber numberWithDouble:duration],
-//               (id<NSCopying>)(CHHapticPatternKeyEventParameters):@[
-//                 @{
-//                   (id<NSCopying>)(CHHapticPatternKeyParameterID): CHHapticEventParameterIDHapticIntensity,
-//                   (id<NSCopying>)(CHHapticPatternKeyParameterValue): [NSNumber numberWithDouble:intensity],
-//                 },
-//               ],
-//             },
-//           },
-//         ],
-//       };
-//
 //       NSError* error = nil;
 //       CHHapticPattern* pattern = [[CHHapticPattern alloc] initWithDictionary:hapticDict
 //                                                                        error:&error];
@@ -93,18 +80,83 @@ package vibrate
 //
 //       [player startAtTime:0 error:&error];
 //       if (error) {
-//         NSLog(@"3, %@", [error localizedDescription]);
+//         NSLog(@"CHHapticPatternPlayer::startAtTime failed: %@", [error localizedDescription]);
 //         return;
 //       }
 //     }
 //   }
 // }
 //
+// static void vibrateOnMainThread(double duration, double intensity) {
+//   if (@available(iOS 13.0, *)) {
+//     NSDictionary* hapticDict = @{
+//       (id<NSCopying>)(CHHapticPatternKeyPattern): @[
+//         @{
+//           (id<NSCopying>)(CHHapticPatternKeyEvent): @{
+//             (id<NSCopying>)(CHHapticPatternKeyEventType):CHHapticEventTypeHapticContinuous,
+//             (id<NSCopying>)(CHHapticPatternKeyTime):@0.0,
+//             (id<NSCopying>)(CHHapticPatternKeyEventDuration):[NSNumber numberWithDouble:duration],
+//             (id<NSCopying>)(CHHapticPatternKeyEventParameters):@[
+//               @{
+//                 (id<NSCopying>)(CHHapticPatternKeyParameterID): CHHapticEventParameterIDHapticIntensity,
+//                 (id<NSCopying>)(CHHapticPatternKeyParameterValue): [NSNumber numberWithDouble:intensity],
+//               },
+//             ],
+//           },
+//         },
+//       ],
+//     };
+//     playPatternOnMainThread(hapticDict);
+//   }
+// }
+//
 // static void vibrate(double duration, double intensity) {
 //   dispatch_async(dispatch_get_main_queue(), ^{
 //     vibrateOnMainThread(duration, intensity);
 //   });
 // }
+//
+// static void playPattern(const double* times, const double* durations, const double* intensities, const double* sharpnesses,
+//                         const int* transients, int n) {
+//   if (@available(iOS 13.0, *)) {
+//     // Build the dictionary before dispatching, as the arguments are not valid after this function returns.
+//     NSMutableArray* events = [[NSMutableArray alloc] initWithCapacity:n];
+//     for (int i = 0; i < n; i++) {
+//       NSMutableDictionary* event = [@{
+//         (id<NSCopying>)(CHHapticPatternKeyEventType):
+//             transients[i] ? CHHapticEventTypeHapticTransient : CHHapticEventTypeHapticContinuous,
+//         (id<NSCopying>)(CHHapticPatternKeyTime):[NSNumber numberWithDouble:times[i]],
+//         (id<NSCopying>)(CHHapticPatternKeyEventParameters):@[
+//           @{
+//             (id<NSCopying>)(CHHapticPatternKeyParameterID): CHHapticEventParameterIDHapticIntensity,
+//             (id<NSCopying>)(CHHapticPatternKeyParameterValue): [NSNumber numberWithDouble:intensities[i]],
+//           },
+//           @{
+//             (id<NSCopying>)(CHHapticPatternKeyParameterID): CHHapticEventParameterIDHapticSharpness,
+//             (id<NSCopying>)(CHHapticPatternKeyParameterValue): [NSNumber numberWithDouble:sharpnesses[i]],
+//           },
+//         ],
+//       } mutableCopy];
+//       if (!transients[i]) {
+//         event[(id<NSCopying>)(CHHapticPatternKeyEventDuration)] = [NSNumber numberWithDouble:durations[i]];
+//       }
+//       [events addObject:@{(id<NSCopying>)(CHHapticPatternKeyEvent): event}];
+//     }
+//     NSDictionary* hapticDict = @{
+//       (id<NSCopying>)(CHHapticPatternKeyPattern): events,
+//     };
+//     dispatch_async(dispatch_get_main_queue(), ^{
+//       playPatternOnMainThread(hapticDict);
+//     });
+//   }
+// }
+//
+// static int supportsHaptics(void) {
+//   if (@available(iOS 13.0, *)) {
+//     return CHHapticEngine.capabilitiesForHardware.supportsHaptics;
+//   }
+//   return 0;
+// }
 import "C"
 
 import (
@@ -116,3 +168,37 @@ func Vibrate(duration time.Duration, magnitude float64) {
 		C.vibrate(C.double(float64(duration)/float64(time.Second)), C.double(magnitude))
 	}()
 }
+
+func PlayPattern(events []Event) {
+	if len(events) == 0 {
+		return
+	}
+
+	times := make([]C.double, len(events))
+	durations := make([]C.double, len(events))
+	intensities := make([]C.double, len(events))
+	sharpnesses := make([]C.double, len(events))
+	transients := make([]C.int, len(events))
+	for i, e := range events {
+		times[i] = C.double(float64(e.Time) / float64(time.Second))
+		durations[i] = C.double(float64(e.Duration) / float64(time.Second))
+		intensities[i] = C.double(e.Intensity)
+		sharpnesses[i] = C.double(e.Sharpness)
+		if e.Transient {
+			transients[i] = 1
+		}
+	}
+	C.playPattern(&times[0], &durations[0], &intensities[0], &sharpnesses[0], &transients[0], C.int(len(events)))
+}
+
+func QueryCapabilities() Capabilities {
+	if C.supportsHaptics() == 0 {
+		return Capabilities{}
+	}
+	return Capabilities{
+		Supported:          true,
+		IntensityControl:   true,
+		SharpnessControl:   true,
+		TransientSupported: true,
+	}
+}
@@ -26,3 +26,31 @@ func Vibrate(duration time.Duration, magnitude float64) {
 		js.Global().Get("navigator").Call("vibrate", float64(duration/time.Millisecond))
 	}
 }
+
+func PlayPattern(events []Event) {
+	// Intensities are ignored.
+
+	if !js.Global().Get("navigator").Get("vibrate").Truthy() {
+		return
+	}
+	segments := flatten(events)
+	if len(segments) == 0 {
+		return
+	}
+	// navigator.vibrate takes alternating on and off durations starting with an on duration.
+	pattern := js.Global().Get("Array").New()
+	pattern.Call("push", 0)
+	for _, ms := range onOffPattern(segments) {
+		pattern.Call("push", float64(ms))
+	}
+	js.Global().Get("navigator").Call("vibrate", pattern)
+}
+
+func QueryCapabilities() Capabilities {
+	if !js.Global().Get("navigator").Get("vibrate").Truthy() {
+		return Capabilities{}
+	}
+	return Capabilities{
+		Supported: true,
+	}
+}
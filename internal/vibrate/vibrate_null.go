@@ -23,3 +23,11 @@ import (
 func Vibrate(duration time.Duration, magnitude float64) {
 	// Do nothing.
 }
+
+func PlayPattern(events []Event) {
+	// Do nothing.
+}
+
+func QueryCapabilities() Capabilities {
+	return Capabilities{}
+}
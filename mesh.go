@@ -0,0 +1,258 @@
+// Copyright 2026 The Ebitengine Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ebiten
+
+import (
+	"fmt"
+
+	"github.com/duplicants-ai/ebiten/internal/affine"
+	"github.com/duplicants-ai/ebiten/internal/builtinshader"
+	"github.com/duplicants-ai/ebiten/internal/graphics"
+	"github.com/duplicants-ai/ebiten/internal/graphicscommand"
+)
+
+// Mesh is an immutable set of vertices and indices of triangles.
+//
+// A mesh is validated only once at NewMesh, and can be drawn many times with different transforms and options
+// by DrawMesh or DrawMeshShader.
+// This is useful for static geometry like tessellated vector paths or tile chunks.
+type Mesh struct {
+	vertices []Vertex
+	indices  []uint32
+}
+
+// NewMesh returns a new mesh with the specified vertices and their indices.
+// NewMesh copies the given slices, so modifying them later doesn't affect the mesh.
+//
+// If len(vertices) is more than MaxVertexCount, or len(indices) is more than MaxIndexCount, NewMesh panics.
+//
+// If len(indices) is not multiple of 3, NewMesh panics.
+//
+// If a value in indices is out of range of vertices, NewMesh panics.
+func NewMesh(vertices []Vertex, indices []uint32) *Mesh {
+	if len(vertices) > graphicscommand.MaxVertexCount {
+		panic(fmt.Sprintf("ebiten: len(vertices) must be less than or equal to MaxVertexCount (%d) but was %d", graphicscommand.MaxVertexCount, len(vertices)))
+	}
+	if len(indices) > graphicscommand.MaxIndexCount {
+		panic(fmt.Sprintf("ebiten: len(indices) must be less than or equal to MaxIndexCount (%d) but was %d", graphicscommand.MaxIndexCount, len(indices)))
+	}
+	if len(indices)%3 != 0 {
+		panic("ebiten: len(indices) % 3 must be 0")
+	}
+	for i, idx := range indices {
+		if int(idx) >= len(vertices) {
+			panic(fmt.Sprintf("ebiten: indices[%d] must be less than len(vertices) (%d) but was %d", i, len(vertices), idx))
+		}
+	}
+
+	m := &Mesh{
+		vertices: make([]Vertex, len(vertices)),
+		indices:  make([]uint32, len(indices)),
+	}
+	copy(m.vertices, vertices)
+	copy(m.indices, indices)
+	return m
+}
+
+// VertexCount returns the number of the vertices.
+func (m *Mesh) VertexCount() int {
+	return len(m.vertices)
+}
+
+// IndexCount returns the number of the indices.
+func (m *Mesh) IndexCount() int {
+	return len(m.indices)
+}
+
+// DrawMeshOptions represents options for DrawMesh.
+type DrawMeshOptions struct {
+	// GeoM is a geometry matrix applied to the destination positions of the mesh's vertices.
+	// The default (zero) value is identity.
+	GeoM GeoM
+
+	// ColorScale is a scale of color applied to the colors of the mesh's vertices.
+	// The default (zero) value is identity, which is (1, 1, 1, 1).
+	ColorScale ColorScale
+
+	// ColorScaleMode is the mode of color scales in vertices.
+	// The default (zero) value is ColorScaleModeStraightAlpha.
+	ColorScaleMode ColorScaleMode
+
+	// Blend is a blending way of the source color and the destination color.
+	// The default (zero) value is the regular alpha blending.
+	Blend Blend
+
+	// Filter is a type of texture filter.
+	// The default (zero) value is FilterNearest.
+	Filter Filter
+
+	// Address is a sampler address mode.
+	// The default (zero) value is AddressUnsafe.
+	Address Address
+
+	// FillRule indicates the rule how an overlapped region is rendered.
+	// The default (zero) value is FillRuleFillAll.
+	FillRule FillRule
+
+	// AntiAlias indicates whether the rendering uses anti-alias or not.
+	// The default (zero) value is false.
+	AntiAlias bool
+
+	// DisableMipmaps disables mipmaps.
+	// The default (zero) value is false.
+	DisableMipmaps bool
+}
+
+// DrawMesh draws the mesh with the specified source image.
+//
+// DrawMesh works like DrawTriangles32, but the vertices and the indices are not validated again.
+//
+// img is used as a source image. img cannot be nil.
+//
+// When the given image is disposed, DrawMesh panics.
+//
+// When the image i is disposed, DrawMesh does nothing.
+func (i *Image) DrawMesh(mesh *Mesh, img *Image, options *DrawMeshOptions) {
+	i.copyCheck()
+
+	if img != nil && img.isDisposed() {
+		panic("ebiten: the given image to DrawMesh must not be disposed")
+	}
+	if i.isDisposed() {
+		return
+	}
+
+	if options == nil {
+		options = &DrawMeshOptions{}
+	}
+
+	geoM := options.GeoM
+	if offsetX, offsetY := i.adjustPosition(0, 0); offsetX != 0 || offsetY != 0 {
+		geoM.Translate(float64(offsetX), float64(offsetY))
+	}
+	a, b, c, d, tx, ty := geoM.elements32()
+	cr, cg, cb, ca := options.ColorScale.elements()
+
+	vertices := mesh.vertices
+	vs := i.ensureTmpVertices(len(vertices) * graphics.VertexFloatCount)
+	// Avoid using `for i, v := range vertices` as adding `v` creates a copy from `vertices` unnecessarily on each loop (#3103).
+	for i := range vertices {
+		x, y := vertices[i].DstX, vertices[i].DstY
+		vs[i*graphics.VertexFloatCount] = a*x + b*y + tx
+		vs[i*graphics.VertexFloatCount+1] = c*x + d*y + ty
+		sx, sy := img.adjustPositionF32(vertices[i].SrcX, vertices[i].SrcY)
+		vs[i*graphics.VertexFloatCount+2] = sx
+		vs[i*graphics.VertexFloatCount+3] = sy
+		vr, vg, vb, va := vertices[i].ColorR, vertices[i].ColorG, vertices[i].ColorB, vertices[i].ColorA
+		if options.ColorScaleMode == ColorScaleModeStraightAlpha {
+			vr *= va
+			vg *= va
+			vb *= va
+		}
+		vs[i*graphics.VertexFloatCount+4] = vr * cr
+		vs[i*graphics.VertexFloatCount+5] = vg * cg
+		vs[i*graphics.VertexFloatCount+6] = vb * cb
+		vs[i*graphics.VertexFloatCount+7] = va * ca
+	}
+
+	i.drawTrianglesWithBuiltinShader(vs, mesh.indices, img, options.Blend.internalBlend(), builtinshader.Filter(options.Filter), builtinshader.Address(options.Address), affine.ColorMIdentity{}, options.FillRule, options.DisableMipmaps, options.AntiAlias, nil, MaskRuleAlpha)
+}
+
+// DrawMeshShaderOptions represents options for DrawMeshShader.
+type DrawMeshShaderOptions struct {
+	// GeoM is a geometry matrix applied to the destination positions of the mesh's vertices.
+	// The default (zero) value is identity.
+	GeoM GeoM
+
+	// Blend is a blending way of the source color and the destination color.
+	// The default (zero) value is the regular alpha blending.
+	Blend Blend
+
+	// Uniforms is a set of uniform variables for the shader.
+	// See DrawTrianglesShaderOptions.Uniforms for the details.
+	Uniforms map[string]any
+
+	// Images is a set of the source images.
+	// See DrawTrianglesShaderOptions.Images for the details.
+	Images [4]*Image
+
+	// ExtraImages is a set of the additional source images following Images.
+	// See DrawTrianglesShaderOptions.ExtraImages for the details.
+	ExtraImages [4]*Image
+
+	// FillRule indicates the rule how an overlapped region is rendered.
+	// The default (zero) value is FillRuleFillAll.
+	FillRule FillRule
+
+	// AntiAlias indicates whether the rendering uses anti-alias or not.
+	// The default (zero) value is false.
+	AntiAlias bool
+}
+
+// DrawMeshShader draws the mesh with the specified shader.
+//
+// DrawMeshShader works like DrawTrianglesShader32, but the vertices and the indices are not validated again.
+//
+// When the image i is disposed, DrawMeshShader does nothing.
+func (i *Image) DrawMeshShader(mesh *Mesh, shader *Shader, options *DrawMeshShaderOptions) {
+	i.copyCheck()
+
+	if i.isDisposed() {
+		return
+	}
+
+	if shader.isDisposed() {
+		panic("ebiten: the given shader to DrawMeshShader must not be disposed")
+	}
+
+	if options == nil {
+		options = &DrawMeshShaderOptions{}
+	}
+
+	geoM := options.GeoM
+	if offsetX, offsetY := i.adjustPosition(0, 0); offsetX != 0 || offsetY != 0 {
+		geoM.Translate(float64(offsetX), float64(offsetY))
+	}
+	a, b, c, d, tx, ty := geoM.elements32()
+
+	vertices := mesh.vertices
+	// The vertices have the extra vertex attributes only when the shader uses them.
+	n := shader.shader.VertexFloatCount()
+	vs := i.ensureTmpVertices(len(vertices) * n)
+	src := options.Images[0]
+	// See the comment in DrawMesh (#3103).
+	for i := range vertices {
+		x, y := vertices[i].DstX, vertices[i].DstY
+		vs[i*n] = a*x + b*y + tx
+		vs[i*n+1] = c*x + d*y + ty
+		sx, sy := vertices[i].SrcX, vertices[i].SrcY
+		if src != nil {
+			sx, sy = src.adjustPositionF32(sx, sy)
+		}
+		vs[i*n+2] = sx
+		vs[i*n+3] = sy
+		vs[i*n+4] = vertices[i].ColorR
+		vs[i*n+5] = vertices[i].ColorG
+		vs[i*n+6] = vertices[i].ColorB
+		vs[i*n+7] = vertices[i].ColorA
+		vs[i*n+8] = vertices[i].Custom0
+		vs[i*n+9] = vertices[i].Custom1
+		vs[i*n+10] = vertices[i].Custom2
+		vs[i*n+11] = vertices[i].Custom3
+		clear(vs[i*n+graphics.VertexFloatCount : (i+1)*n])
+	}
+
+	i.drawTrianglesShaderWithVertices(vs, mesh.indices, shader, &options.Images, &options.ExtraImages, nil, options.Uniforms, options.Blend.internalBlend(), options.FillRule, options.AntiAlias)
+}
@@ -0,0 +1,147 @@
+// Copyright 2026 The Ebitengine Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// ebitengine_worker.js runs an Ebitengine game on a Web Worker with OffscreenCanvas.
+// The game's Update and Draw run on the worker, and this script proxies input events from the main thread,
+// so heavy work in the game doesn't block the page.
+//
+// Usage on the main thread:
+//
+//     <canvas id="game" style="width: 640px; height: 480px;"></canvas>
+//     <script src="ebitengine_worker.js"></script>
+//     <script>
+//       ebitengineRunOnWorker(document.getElementById("game"), "worker.js");
+//     </script>
+//
+// worker.js runs the Go program as usual:
+//
+//     importScripts("wasm_exec.js");
+//     const go = new Go();
+//     WebAssembly.instantiateStreaming(fetch("game.wasm"), go.importObject).then(result => {
+//       go.run(result.instance);
+//     });
+//
+// The size of the canvas should be specified by the page, e.g., by CSS.
+//
+// On a Web Worker, audio, gamepads, and fullscreen are not available so far.
+// Messages whose type starts with "ebitengine:" are reserved for Ebitengine.
+
+function ebitengineRunOnWorker(canvas, workerURL) {
+  'use strict';
+
+  const worker = new Worker(workerURL);
+
+  function size() {
+    return {
+      width: canvas.clientWidth,
+      height: canvas.clientHeight,
+      devicePixelRatio: window.devicePixelRatio || 1,
+    };
+  }
+
+  function focused() {
+    return document.hasFocus() && !document.hidden;
+  }
+
+  const offscreen = canvas.transferControlToOffscreen();
+  worker.postMessage(Object.assign({
+    type: 'ebitengine:init',
+    canvas: offscreen,
+    screenWidth: screen.width,
+    screenHeight: screen.height,
+    focused: focused(),
+    hidden: document.hidden,
+  }, size()), [offscreen]);
+
+  function postSize() {
+    worker.postMessage(Object.assign({type: 'ebitengine:resize'}, size()));
+  }
+  new ResizeObserver(postSize).observe(canvas);
+  window.addEventListener('resize', postSize);
+
+  function postFocus() {
+    worker.postMessage({type: 'ebitengine:focus', focused: focused(), hidden: document.hidden});
+  }
+  window.addEventListener('focus', postFocus);
+  window.addEventListener('blur', postFocus);
+  document.addEventListener('visibilitychange', postFocus);
+
+  // Make the canvas focusable.
+  if (!canvas.hasAttribute('tabindex')) {
+    canvas.setAttribute('tabindex', '1');
+  }
+  canvas.style.outline = 'none';
+
+  // The positions are converted to the canvas's coordinate.
+  function forward(e) {
+    e.preventDefault();
+    if (e.type === 'keydown' || e.type === 'mousedown' || e.type === 'touchstart') {
+      // Focus the canvas explicitly to activate the game.
+      canvas.focus();
+    }
+    const rect = canvas.getBoundingClientRect();
+    const event = {
+      type: e.type,
+      key: e.key,
+      code: e.code,
+      button: e.button,
+      clientX: e.clientX - rect.left,
+      clientY: e.clientY - rect.top,
+      movementX: e.movementX,
+      movementY: e.movementY,
+      deltaX: e.deltaX,
+      deltaY: e.deltaY,
+    };
+    if (e.targetTouches) {
+      event.targetTouches = Array.from(e.targetTouches, t => ({
+        identifier: t.identifier,
+        clientX: t.clientX - rect.left,
+        clientY: t.clientY - rect.top,
+      }));
+    }
+    worker.postMessage({type: 'ebitengine:event', event: event});
+  }
+  for (const type of ['keydown', 'keyup', 'mousedown', 'mouseup', 'mousemove', 'wheel', 'touchstart', 'touchend', 'touchmove']) {
+    canvas.addEventListener(type, forward, {passive: false});
+  }
+  canvas.addEventListener('contextmenu', e => e.preventDefault());
+  canvas.addEventListener('blur', () => worker.postMessage({type: 'ebitengine:blur'}));
+
+  document.addEventListener('pointerlockchange', () => {
+    worker.postMessage({type: 'ebitengine:pointerlockchange', locked: document.pointerLockElement === canvas});
+  });
+  document.addEventListener('pointerlockerror', () => {
+    worker.postMessage({type: 'ebitengine:pointerlockerror'});
+  });
+
+  worker.addEventListener('message', e => {
+    const data = e.data;
+    if (!data) {
+      return;
+    }
+    switch (data.type) {
+    case 'ebitengine:cursor':
+      canvas.style.cursor = data.cursor;
+      break;
+    case 'ebitengine:requestpointerlock':
+      canvas.requestPointerLock();
+      break;
+    case 'ebitengine:exitpointerlock':
+      document.exitPointerLock();
+      break;
+    }
+  });
+
+  return worker;
+}
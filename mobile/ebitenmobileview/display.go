@@ -0,0 +1,150 @@
+// Copyright 2026 The Ebitengine Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build android || ios
+
+package ebitenmobileview
+
+import (
+	"image"
+	"math"
+
+	"github.com/duplicants-ai/ebiten/internal/ui"
+)
+
+// OrientationLocker is implemented by the host view to lock the screen orientation.
+//
+// orientation is one of the ui.Orientation values. 0 (unknown) means that the orientation is unlocked.
+type OrientationLocker interface {
+	LockOrientation(orientation int)
+}
+
+type orientationLocker struct {
+	locker OrientationLocker
+}
+
+func (o *orientationLocker) LockOrientation(orientation ui.Orientation) {
+	o.locker.LockOrientation(int(orientation))
+}
+
+func SetOrientationLocker(locker OrientationLocker) {
+	if locker == nil {
+		ui.Get().SetOrientationLocker(nil)
+		return
+	}
+	ui.Get().SetOrientationLocker(&orientationLocker{locker: locker})
+}
+
+func SetDeviceOrientation(orientation int) {
+	ui.Get().SetDeviceOrientation(ui.Orientation(orientation))
+}
+
+func SetSafeAreaInsets(left, top, right, bottom float64) {
+	ui.Get().SetSafeAreaInsets(ui.Insets{
+		Left:   left,
+		Top:    top,
+		Right:  right,
+		Bottom: bottom,
+	})
+}
+
+func SetMultiWindowMode(mode int) {
+	ui.Get().SetMultiWindowMode(ui.MultiWindowMode(mode))
+}
+
+// SetHingeBounds sets the bounds of the hinge of a foldable device in device-independent pixels.
+// Zero width and height mean that there is no hinge.
+func SetHingeBounds(x, y, width, height float64) {
+	ui.Get().SetHingeBounds(image.Rect(int(x), int(y), int(math.Ceil(x+width)), int(math.Ceil(y+height))))
+}
+
+// SetSystemSettings sets the OS-level settings relevant to games.
+// colorScheme is one of the ui.ColorScheme values.
+func SetSystemSettings(colorScheme int, reducedMotion, highContrast bool, textScale float64) {
+	ui.Get().SetSystemSettings(ui.SystemSettings{
+		ColorScheme:   ui.ColorScheme(colorScheme),
+		ReducedMotion: reducedMotion,
+		HighContrast:  highContrast,
+		TextScale:     textScale,
+	})
+}
+
+// SetPowerStatus sets the state of the power source and the thermal pressure.
+// batteryState is one of the ui.BatteryState values, and thermalState is one of the ui.ThermalState values.
+// batteryLevel is in [0, 1], or -1 if unknown.
+func SetPowerStatus(batteryState int, batteryLevel float64, powerSaving bool, thermalState int) {
+	ui.Get().SetPowerStatus(ui.PowerStatus{
+		BatteryState: ui.BatteryState(batteryState),
+		BatteryLevel: batteryLevel,
+		PowerSaving:  powerSaving,
+		ThermalState: ui.ThermalState(thermalState),
+	})
+}
+
+// FrameRateController is implemented by the host view to control the frame rate of the display link.
+type FrameRateController interface {
+	SetPreferredFrameRateRange(min, max, preferred int)
+}
+
+func SetFrameRateController(controller FrameRateController) {
+	ui.Get().SetFrameRateController(controller)
+}
+
+func SetDisplayRefreshRate(refreshRate int) {
+	ui.Get().SetDisplayRefreshRate(refreshRate)
+}
+
+// ScreenSaverInhibitor is implemented by the host view to keep the screen on.
+type ScreenSaverInhibitor interface {
+	SetScreenSaverInhibited(inhibited bool)
+}
+
+func SetScreenSaverInhibitor(inhibitor ScreenSaverInhibitor) {
+	if inhibitor == nil {
+		ui.Get().SetScreenSaverInhibitor(nil)
+		return
+	}
+	ui.Get().SetScreenSaverInhibitor(inhibitor)
+}
+
+// Announcer is implemented by the host view to send announcements to the screen reader.
+type Announcer interface {
+	Announce(text string, assertive bool)
+}
+
+func SetAnnouncer(announcer Announcer) {
+	if announcer == nil {
+		ui.Get().SetAnnouncer(nil)
+		return
+	}
+	ui.Get().SetAnnouncer(announcer)
+}
+
+// MessageReceiver is implemented by the host view to receive messages sent from the game.
+//
+// OnMessage might be called on a goroutine other than the main thread.
+type MessageReceiver interface {
+	OnMessage(name string, payload []byte)
+}
+
+func SetMessageReceiver(receiver MessageReceiver) {
+	ui.Get().SetMessageReceiver(receiver)
+}
+
+// SendMessageToGame sends a message to the game.
+// The message is delivered to the handler registered by mobile.SetMessageHandler before the next Update.
+func SendMessageToGame(name string, payload []byte) {
+	// The payload might be reused by the host side, so copy it.
+	ui.Get().SendMessageToGame(name, append([]byte(nil), payload...))
+}
@@ -21,6 +21,7 @@ package mobile
 
 import (
 	"github.com/duplicants-ai/ebiten"
+	"github.com/duplicants-ai/ebiten/internal/ui"
 )
 
 // SetGame sets a mobile game.
@@ -40,3 +41,27 @@ func SetGame(game ebiten.Game) {
 func SetGameWithOptions(game ebiten.Game, options *ebiten.RunGameOptions) {
 	setGame(game, options)
 }
+
+// SetMessageHandler sets a function to handle custom messages sent from the host application.
+//
+// Messages are sent by the host application via EbitenView's sendMessageToGame (Android) or
+// EbitenViewController's sendMessageToGame:payload: (iOS).
+// The handler is called on the game's goroutine before Update, so it is safe to touch the game state in the handler.
+// Messages sent before a handler is set are kept and delivered once a handler is set.
+//
+// SetMessageHandler is concurrent-safe.
+func SetMessageHandler(handler func(name string, payload []byte)) {
+	ui.Get().SetMessageHandler(handler)
+}
+
+// SendMessage sends a custom message to the host application.
+//
+// The message is received by EbitenView's onMessageFromGame (Android) or
+// EbitenViewController's onMessageFromGame:payload: (iOS) on the main thread.
+//
+// SendMessage returns an error if the host application is not ready to receive messages.
+//
+// SendMessage is concurrent-safe.
+func SendMessage(name string, payload []byte) error {
+	return ui.Get().SendMessageToHost(name, payload)
+}
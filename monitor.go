@@ -50,6 +50,17 @@ func (m *MonitorType) Size() (int, int) {
 	return (*ui.Monitor)(m).Size()
 }
 
+// RefreshRate returns the refresh rate of the monitor in Hz.
+// RefreshRate returns 0 if the refresh rate is unknown.
+//
+// On iOS and Android, RefreshRate returns the maximum refresh rate of the display, e.g., 120 on ProMotion displays.
+// RefreshRate returns 0 before the game's view is shown.
+//
+// In browsers, Nintendo Switch, and PlayStation 5, RefreshRate always returns 0, i.e., the refresh rate is unknown.
+func (m *MonitorType) RefreshRate() int {
+	return (*ui.Monitor)(m).RefreshRate()
+}
+
 // Monitor returns the current monitor.
 func Monitor() *MonitorType {
 	m := ui.Get().Monitor()
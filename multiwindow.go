@@ -0,0 +1,62 @@
+// Copyright 2026 The Ebitengine Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ebiten
+
+import (
+	"image"
+
+	"github.com/duplicants-ai/ebiten/internal/ui"
+)
+
+// MultiWindowModeType represents how the game view shares the device screen with other applications.
+type MultiWindowModeType int
+
+const (
+	// MultiWindowModeNone represents that the game occupies the screen alone.
+	MultiWindowModeNone MultiWindowModeType = MultiWindowModeType(ui.MultiWindowModeNone)
+
+	// MultiWindowModeMultiWindow represents that the game shares the screen with other applications
+	// e.g. in split-screen or freeform windows.
+	MultiWindowModeMultiWindow MultiWindowModeType = MultiWindowModeType(ui.MultiWindowModeMultiWindow)
+
+	// MultiWindowModePictureInPicture represents that the game is shown in a small picture-in-picture window.
+	MultiWindowModePictureInPicture MultiWindowModeType = MultiWindowModeType(ui.MultiWindowModePictureInPicture)
+)
+
+// MultiWindowMode returns the current multi-window mode.
+//
+// The outside size given to Layout changes continuously while the user resizes the game view in a multi-window mode.
+//
+// MultiWindowMode works only on Android.
+// MultiWindowMode returns MultiWindowModeNone on other platforms.
+//
+// MultiWindowMode is concurrent-safe.
+func MultiWindowMode() MultiWindowModeType {
+	return theInputState.multiWindowMode()
+}
+
+// HingeBounds returns the bounds of the hinge or the fold of a foldable device
+// in device-independent pixels of the outside size passed to Layout.
+// ok is false if there is no hinge crossing the game view.
+//
+// Games can use the bounds to avoid placing content across the hinge, e.g. in a tabletop posture.
+//
+// HingeBounds works only on Android, when the host application reports folding features to the view generated by ebitenmobile.
+//
+// HingeBounds is concurrent-safe.
+func HingeBounds() (bounds image.Rectangle, ok bool) {
+	b := theInputState.hingeBounds()
+	return b, !b.Empty()
+}
@@ -0,0 +1,112 @@
+// Copyright 2026 The Ebitengine Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ebiten_test
+
+import (
+	"testing"
+
+	"github.com/duplicants-ai/ebiten"
+)
+
+type layoutCountingGame struct {
+	layoutCount int
+}
+
+func (*layoutCountingGame) Update() error {
+	return nil
+}
+
+func (*layoutCountingGame) Draw(screen *ebiten.Image) {
+}
+
+func (g *layoutCountingGame) Layout(outsideWidth, outsideHeight int) (int, int) {
+	g.layoutCount++
+	return outsideWidth / 2, outsideHeight / 2
+}
+
+func TestOffscreenSize(t *testing.T) {
+	t.Cleanup(func() {
+		ebiten.SetOffscreenSize(0, 0)
+	})
+
+	if w, h := ebiten.OffscreenSize(); w != 0 || h != 0 {
+		t.Errorf("OffscreenSize: got: (%f, %f), want: (0, 0)", w, h)
+	}
+
+	g := &layoutCountingGame{}
+	if w, h := ebiten.LayoutForTesting(g, 640, 480); w != 320 || h != 240 {
+		t.Errorf("Layout: got: (%f, %f), want: (320, 240)", w, h)
+	}
+	if got, want := g.layoutCount, 1; got != want {
+		t.Errorf("layoutCount: got: %d, want: %d", got, want)
+	}
+
+	// The offscreen size overrides Game.Layout.
+	ebiten.SetOffscreenSize(100.5, 200)
+	if w, h := ebiten.OffscreenSize(); w != 100.5 || h != 200 {
+		t.Errorf("OffscreenSize: got: (%f, %f), want: (100.5, 200)", w, h)
+	}
+	if w, h := ebiten.LayoutForTesting(g, 640, 480); w != 100.5 || h != 200 {
+		t.Errorf("Layout with the offscreen size: got: (%f, %f), want: (100.5, 200)", w, h)
+	}
+	if got, want := g.layoutCount, 1; got != want {
+		t.Errorf("layoutCount with the offscreen size: got: %d, want: %d", got, want)
+	}
+
+	// A non-positive size resets the offscreen size.
+	ebiten.SetOffscreenSize(0, 200)
+	if w, h := ebiten.OffscreenSize(); w != 0 || h != 0 {
+		t.Errorf("OffscreenSize after reset: got: (%f, %f), want: (0, 0)", w, h)
+	}
+	if w, h := ebiten.LayoutForTesting(g, 640, 480); w != 320 || h != 240 {
+		t.Errorf("Layout after reset: got: (%f, %f), want: (320, 240)", w, h)
+	}
+	if got, want := g.layoutCount, 2; got != want {
+		t.Errorf("layoutCount after reset: got: %d, want: %d", got, want)
+	}
+}
+
+func TestOffscreen(t *testing.T) {
+	offscreen := ebiten.Offscreen()
+	if offscreen == nil {
+		t.Fatal("Offscreen must not be nil after the game starts")
+	}
+	if got, want := offscreen.Bounds().Dx(), 320; got != want {
+		t.Errorf("width: got: %d, want: %d", got, want)
+	}
+	if got, want := offscreen.Bounds().Dy(), 240; got != want {
+		t.Errorf("height: got: %d, want: %d", got, want)
+	}
+}
+
+func TestPhysicalPixelLayout(t *testing.T) {
+	t.Cleanup(func() {
+		ebiten.SetPhysicalPixelLayoutEnabled(false)
+	})
+
+	s := ebiten.Monitor().DeviceScaleFactor()
+	if w, h := ebiten.PhysicalPixelLayout(100.5, 200); w != 100.5*s || h != 200*s {
+		t.Errorf("got: (%f, %f), want: (%f, %f)", w, h, 100.5*s, 200*s)
+	}
+
+	ebiten.SetPhysicalPixelLayoutEnabled(true)
+	if !ebiten.IsPhysicalPixelLayoutEnabled() {
+		t.Errorf("IsPhysicalPixelLayoutEnabled: got: false, want: true")
+	}
+	// The outside size is already in physical pixels.
+	if w, h := ebiten.PhysicalPixelLayout(100.5, 200); w != 100.5 || h != 200 {
+		t.Errorf("got: (%f, %f), want: (100.5, 200)", w, h)
+	}
+}
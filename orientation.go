@@ -0,0 +1,89 @@
+// Copyright 2026 The Ebitengine Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ebiten
+
+import (
+	"github.com/duplicants-ai/ebiten/internal/ui"
+)
+
+// Orientation represents a device screen orientation.
+type Orientation int
+
+const (
+	// OrientationUnknown represents an unknown orientation.
+	// On platforms other than mobiles, the orientation is always unknown.
+	OrientationUnknown Orientation = Orientation(ui.OrientationUnknown)
+
+	// OrientationPortrait represents the portrait orientation with the device's top edge up.
+	OrientationPortrait Orientation = Orientation(ui.OrientationPortrait)
+
+	// OrientationPortraitUpsideDown represents the portrait orientation with the device's top edge down.
+	OrientationPortraitUpsideDown Orientation = Orientation(ui.OrientationPortraitUpsideDown)
+
+	// OrientationLandscapeLeft represents the landscape orientation with the device's top edge on the left.
+	OrientationLandscapeLeft Orientation = Orientation(ui.OrientationLandscapeLeft)
+
+	// OrientationLandscapeRight represents the landscape orientation with the device's top edge on the right.
+	OrientationLandscapeRight Orientation = Orientation(ui.OrientationLandscapeRight)
+)
+
+// DeviceOrientation returns the current device screen orientation.
+//
+// DeviceOrientation works only on mobiles.
+// DeviceOrientation returns OrientationUnknown if the platform is not a mobile.
+//
+// DeviceOrientation is concurrent-safe.
+func DeviceOrientation() Orientation {
+	return theInputState.orientation()
+}
+
+// IsDeviceOrientationChanged reports whether the device screen orientation has changed
+// since the previous tick.
+//
+// IsDeviceOrientationChanged is useful to distinguish a rotation from other resizing like a change of safe-area insets,
+// as Layout is called with a new outside size in both cases.
+//
+// IsDeviceOrientationChanged always returns false if the platform is not a mobile.
+//
+// IsDeviceOrientationChanged is concurrent-safe.
+func IsDeviceOrientationChanged() bool {
+	return theInputState.orientationChanged()
+}
+
+// LockOrientation locks the device screen orientation to the given orientation.
+// OrientationUnknown unlocks the orientation so that the screen follows the device.
+//
+// LockOrientation works only on mobiles with the view generated by ebitenmobile.
+// LockOrientation does nothing if the platform is not a mobile.
+//
+// LockOrientation is concurrent-safe.
+func LockOrientation(orientation Orientation) {
+	ui.Get().LockOrientation(ui.Orientation(orientation))
+}
+
+// SafeAreaInsets returns the insets of the area obscured by notches, rounded corners, system bars, and home indicators,
+// in device-independent pixels of the outside size passed to Layout.
+//
+// Content within the insets might not be visible or touchable.
+// Games can use the insets to keep important UI elements in the safe area.
+//
+// SafeAreaInsets works only on mobiles.
+// SafeAreaInsets returns zeros if the platform is not a mobile.
+//
+// SafeAreaInsets is concurrent-safe.
+func SafeAreaInsets() (left, top, right, bottom float64) {
+	insets := theInputState.safeAreaInsets()
+	return insets.Left, insets.Top, insets.Right, insets.Bottom
+}
@@ -15,6 +15,9 @@
 //go:build playstation5
 
 // Package playstation5 provides utilities for PlayStation 5.
+//
+// This package provides the user accounts, the save data, and the application lifecycle,
+// which are required to ship a game on PlayStation 5.
 package playstation5
 
-// The actual implementation will be provided by another repository using the -overlay option.
+// The actual implementation of the C functions will be provided by another repository using the -overlay option.
@@ -0,0 +1,32 @@
+// Copyright 2026 The Ebitengine Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build playstation5
+
+package playstation5
+
+import (
+	"github.com/duplicants-ai/ebiten/internal/ui"
+)
+
+// IsResumed reports whether the application was resumed from the suspended state in the current tick.
+//
+// While the application is suspended, neither Update nor Draw is called and the audio is stopped.
+// Network connections and the other time-sensitive states might be invalid after the application is resumed.
+// Use IsResumed to detect the resumption and re-establish such states.
+//
+// IsResumed is concurrent-safe.
+func IsResumed() bool {
+	return ui.Get().IsResumed()
+}
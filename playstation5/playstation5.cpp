@@ -0,0 +1,44 @@
+// Copyright 2026 The Ebitengine Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build playstation5
+
+// The actual implementation will be provided by -overlay.
+
+#include "playstation5.h"
+
+static const int kNotImplemented = -1;
+
+extern "C" int ebitengine_GetInitialUser(int32_t *userId) {
+  return kNotImplemented;
+}
+
+extern "C" int ebitengine_GetLoggedInUserCount() { return 0; }
+
+extern "C" void ebitengine_GetLoggedInUsers(int32_t *userIds) {}
+
+extern "C" int ebitengine_GetUserName(int32_t userId, char *name,
+                                      int nameSize) {
+  return kNotImplemented;
+}
+
+extern "C" int ebitengine_MountSaveData(int32_t userId, const char *dirName,
+                                        int64_t sizeInBytes, char *mountPoint,
+                                        int mountPointSize) {
+  return kNotImplemented;
+}
+
+extern "C" int ebitengine_UnmountSaveData(const char *mountPoint) {
+  return kNotImplemented;
+}
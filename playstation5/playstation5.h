@@ -0,0 +1,37 @@
+// Copyright 2026 The Ebitengine Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build playstation5
+
+#include <stdint.h>
+
+#ifdef __cplusplus
+extern "C" {
+#endif
+
+// The functions returning int return 0 on success, or an error code of the SDK on failure.
+
+int ebitengine_GetInitialUser(int32_t *userId);
+int ebitengine_GetLoggedInUserCount();
+void ebitengine_GetLoggedInUsers(int32_t *userIds);
+int ebitengine_GetUserName(int32_t userId, char *name, int nameSize);
+
+int ebitengine_MountSaveData(int32_t userId, const char *dirName,
+                             int64_t sizeInBytes, char *mountPoint,
+                             int mountPointSize);
+int ebitengine_UnmountSaveData(const char *mountPoint);
+
+#ifdef __cplusplus
+} // extern "C"
+#endif
@@ -0,0 +1,90 @@
+// Copyright 2026 The Ebitengine Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build playstation5
+
+package playstation5
+
+// #include <stdlib.h>
+//
+// #include "playstation5.h"
+import "C"
+
+import (
+	"fmt"
+	"sync"
+	"unsafe"
+)
+
+// SaveData represents a mounted save data directory.
+type SaveData struct {
+	mountPoint string
+	unmounted  bool
+
+	m sync.Mutex
+}
+
+// MountSaveData mounts the save data directory named dirName of the user, and returns the mounted save data.
+// If the save data directory doesn't exist, a new directory with the size sizeInBytes is created.
+//
+// The files in the save data can be read and written with the standard os package under the path SaveData.Path returns.
+// The written files are committed when the save data is unmounted.
+//
+// The application can be suspended by the system at any time.
+// As the data being written might be lost when the application is suspended, keep the save data mounted only as long as needed.
+//
+// MountSaveData is concurrent-safe.
+func MountSaveData(user UserID, dirName string, sizeInBytes int64) (*SaveData, error) {
+	cDirName := C.CString(dirName)
+	defer C.free(unsafe.Pointer(cDirName))
+
+	// The maximum length of a mount point is 16 bytes excluding the null terminator.
+	var buf [17]C.char
+	if r := C.ebitengine_MountSaveData(C.int32_t(user), cDirName, C.int64_t(sizeInBytes), &buf[0], C.int(len(buf))); r != 0 {
+		return nil, fmt.Errorf("playstation5: ebitengine_MountSaveData failed: error code: 0x%08x", uint32(r))
+	}
+	return &SaveData{
+		mountPoint: C.GoString(&buf[0]),
+	}, nil
+}
+
+// Path returns the path of the mounted save data directory.
+//
+// Path is concurrent-safe.
+func (s *SaveData) Path() string {
+	return s.mountPoint
+}
+
+// Unmount commits the written files and unmounts the save data.
+//
+// Unmount does nothing if the save data is already unmounted.
+//
+// Unmount is concurrent-safe.
+func (s *SaveData) Unmount() error {
+	s.m.Lock()
+	defer s.m.Unlock()
+
+	if s.unmounted {
+		return nil
+	}
+
+	cMountPoint := C.CString(s.mountPoint)
+	defer C.free(unsafe.Pointer(cMountPoint))
+
+	if r := C.ebitengine_UnmountSaveData(cMountPoint); r != 0 {
+		return fmt.Errorf("playstation5: ebitengine_UnmountSaveData failed: error code: 0x%08x", uint32(r))
+	}
+	s.unmounted = true
+	return nil
+}
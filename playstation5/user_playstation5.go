@@ -0,0 +1,70 @@
+// Copyright 2026 The Ebitengine Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build playstation5
+
+package playstation5
+
+// #cgo !darwin LDFLAGS: -Wl,-unresolved-symbols=ignore-all
+// #cgo darwin LDFLAGS: -Wl,-undefined,dynamic_lookup
+//
+// #include "playstation5.h"
+import "C"
+
+import (
+	"fmt"
+)
+
+// UserID represents a user account signed in to the system.
+type UserID int32
+
+// InitialUser returns the user who launched the application.
+//
+// InitialUser is concurrent-safe.
+func InitialUser() (UserID, error) {
+	var id C.int32_t
+	if r := C.ebitengine_GetInitialUser(&id); r != 0 {
+		return 0, fmt.Errorf("playstation5: ebitengine_GetInitialUser failed: error code: 0x%08x", uint32(r))
+	}
+	return UserID(id), nil
+}
+
+// AppendLoggedInUsers appends the users currently logged in to the system to users, and returns the extended buffer.
+// Giving a slice that already has enough capacity works efficiently.
+//
+// AppendLoggedInUsers is concurrent-safe.
+func AppendLoggedInUsers(users []UserID) []UserID {
+	n := int(C.ebitengine_GetLoggedInUserCount())
+	if n <= 0 {
+		return users
+	}
+	ids := make([]C.int32_t, n)
+	C.ebitengine_GetLoggedInUsers(&ids[0])
+	for _, id := range ids {
+		users = append(users, UserID(id))
+	}
+	return users
+}
+
+// Name returns the online ID or the local name of the user.
+//
+// Name is concurrent-safe.
+func (u UserID) Name() (string, error) {
+	// The maximum length of a user name is 16 bytes excluding the null terminator.
+	var buf [17]C.char
+	if r := C.ebitengine_GetUserName(C.int32_t(u), &buf[0], C.int(len(buf))); r != 0 {
+		return "", fmt.Errorf("playstation5: ebitengine_GetUserName failed: error code: 0x%08x", uint32(r))
+	}
+	return C.GoString(&buf[0]), nil
+}
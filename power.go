@@ -0,0 +1,215 @@
+// Copyright 2026 The Ebitengine Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ebiten
+
+import (
+	"fmt"
+	"sync/atomic"
+
+	"github.com/duplicants-ai/ebiten/internal/ui"
+)
+
+// BatteryState represents the state of the battery.
+type BatteryState int
+
+const (
+	// BatteryStateUnknown represents an unknown battery state.
+	BatteryStateUnknown BatteryState = BatteryState(ui.BatteryStateUnknown)
+
+	// BatteryStateNoBattery represents that the device doesn't have a battery, e.g. a desktop computer.
+	BatteryStateNoBattery BatteryState = BatteryState(ui.BatteryStateNoBattery)
+
+	// BatteryStateCharging represents that the battery is being charged.
+	BatteryStateCharging BatteryState = BatteryState(ui.BatteryStateCharging)
+
+	// BatteryStateDischarging represents that the device is running on the battery, i.e. unplugged.
+	BatteryStateDischarging BatteryState = BatteryState(ui.BatteryStateDischarging)
+
+	// BatteryStateFull represents that the device is plugged in and the battery is not being charged.
+	BatteryStateFull BatteryState = BatteryState(ui.BatteryStateFull)
+)
+
+// ThermalState represents the thermal pressure of the device.
+//
+// The values are ordered by the severity, so ThermalState values can be compared.
+type ThermalState int
+
+const (
+	// ThermalStateUnknown represents an unknown thermal state.
+	ThermalStateUnknown ThermalState = ThermalState(ui.ThermalStateUnknown)
+
+	// ThermalStateNominal represents that the thermal state is within normal limits.
+	ThermalStateNominal ThermalState = ThermalState(ui.ThermalStateNominal)
+
+	// ThermalStateFair represents that the thermal state is slightly elevated.
+	ThermalStateFair ThermalState = ThermalState(ui.ThermalStateFair)
+
+	// ThermalStateSerious represents that the thermal state is high and the system might throttle the performance.
+	ThermalStateSerious ThermalState = ThermalState(ui.ThermalStateSerious)
+
+	// ThermalStateCritical represents that the thermal state is significantly impacting the performance.
+	ThermalStateCritical ThermalState = ThermalState(ui.ThermalStateCritical)
+)
+
+// SystemBatteryState returns the current state of the battery.
+//
+// On Windows, macOS, and Linux, the state is queried from the OS.
+// On browsers, the state is available only when the Battery Status API is available.
+// On Android and iOS, SystemBatteryState works only with the view generated by ebitenmobile.
+// SystemBatteryState returns BatteryStateUnknown if the state is not available.
+//
+// The power status is queried periodically, and the value is updated at the beginning of a tick.
+//
+// SystemBatteryState is concurrent-safe.
+func SystemBatteryState() BatteryState {
+	return BatteryState(theInputState.powerStatus().BatteryState)
+}
+
+// SystemBatteryLevel returns the current battery level in [0, 1].
+//
+// SystemBatteryLevel returns -1 if the level is not available.
+//
+// SystemBatteryLevel is concurrent-safe.
+func SystemBatteryLevel() float64 {
+	return theInputState.powerStatus().BatteryLevel
+}
+
+// IsSystemPowerSavingEnabled reports whether the OS's power saving mode is on,
+// like Low Power Mode on macOS and iOS, Battery Saver on Windows and Android, and the power-saver profile on Linux.
+//
+// IsSystemPowerSavingEnabled returns false if the setting is not available.
+//
+// IsSystemPowerSavingEnabled is concurrent-safe.
+func IsSystemPowerSavingEnabled() bool {
+	return theInputState.powerStatus().PowerSaving
+}
+
+// SystemThermalState returns the current thermal pressure of the device.
+//
+// SystemThermalState is available on macOS, iOS, and Android 10 or later.
+// On Linux, ThermalStateSerious is reported only when the power profiles daemon reports a high operating temperature.
+// SystemThermalState returns ThermalStateUnknown if the state is not available.
+//
+// SystemThermalState is concurrent-safe.
+func SystemThermalState() ThermalState {
+	return ThermalState(theInputState.powerStatus().ThermalState)
+}
+
+// IsPowerStatusChanged reports whether any of the power status, SystemBatteryState, SystemBatteryLevel,
+// IsSystemPowerSavingEnabled, and SystemThermalState, has changed since the previous tick.
+//
+// IsPowerStatusChanged is concurrent-safe.
+func IsPowerStatusChanged() bool {
+	return theInputState.powerStatusChanged()
+}
+
+// LowPowerModeOptions represents options for the automatic low-power mode.
+//
+// The low-power mode is active while any of the conditions OnBattery, OnPowerSaving, and ThermalState is met.
+type LowPowerModeOptions struct {
+	// OnBattery activates the low-power mode while the device is running on the battery.
+	OnBattery bool
+
+	// OnPowerSaving activates the low-power mode while the OS's power saving mode is on.
+	OnPowerSaving bool
+
+	// ThermalState activates the low-power mode while the thermal state is ThermalState or more severe.
+	// If ThermalState is ThermalStateUnknown, the thermal state is not used.
+	ThermalState ThermalState
+
+	// TPS is the maximum TPS in the low-power mode.
+	// If TPS is 0, TPS is not changed.
+	// TPS doesn't affect SyncWithFPS.
+	TPS int
+
+	// MaxFrameRate is the maximum frame rate in the low-power mode.
+	// If MaxFrameRate is 0, the frame rate is not limited.
+	MaxFrameRate int
+
+	// ResolutionScale is the scale of the outside size given to the game's Layout in the low-power mode.
+	// ResolutionScale must be in [0, 1]. If ResolutionScale is 0, 1 is used.
+	//
+	// ResolutionScale lowers the screen resolution only when the game's Layout depends on the outside size,
+	// e.g. when Layout returns the outside size multiplied by the device scale factor.
+	// The screen is scaled up to the window as usual.
+	ResolutionScale float64
+
+	// OnChanged is called when the low-power mode is activated or deactivated.
+	// OnChanged is called on the same goroutine as Update, before Update is called.
+	//
+	// Use OnChanged to degrade or restore expensive effects.
+	OnChanged func(active bool)
+}
+
+var theLowPowerModeOptions atomic.Pointer[LowPowerModeOptions]
+
+// SetLowPowerMode sets the options for the automatic low-power mode.
+// If options is nil, the low-power mode is disabled. By default, the low-power mode is disabled.
+//
+// The low-power mode reduces TPS, the frame rate, and the screen resolution as specified by options
+// while the device is unplugged, in the power saving mode, or throttled by the heat.
+//
+// SetLowPowerMode panics if options has invalid values.
+//
+// SetLowPowerMode is concurrent-safe.
+func SetLowPowerMode(options *LowPowerModeOptions) {
+	if options == nil {
+		theLowPowerModeOptions.Store(nil)
+		ui.Get().SetLowPowerModeOptions(nil)
+		return
+	}
+
+	if options.TPS < 0 {
+		panic(fmt.Sprintf("ebiten: LowPowerModeOptions.TPS must be non-negative but %d", options.TPS))
+	}
+	if options.MaxFrameRate < 0 {
+		panic(fmt.Sprintf("ebiten: LowPowerModeOptions.MaxFrameRate must be non-negative but %d", options.MaxFrameRate))
+	}
+	if options.ResolutionScale < 0 || options.ResolutionScale > 1 {
+		panic(fmt.Sprintf("ebiten: LowPowerModeOptions.ResolutionScale must be in [0, 1] but %f", options.ResolutionScale))
+	}
+
+	o := *options
+	theLowPowerModeOptions.Store(&o)
+	ui.Get().SetLowPowerModeOptions(&ui.LowPowerModeOptions{
+		OnBattery:       o.OnBattery,
+		OnPowerSaving:   o.OnPowerSaving,
+		ThermalState:    ui.ThermalState(o.ThermalState),
+		TPS:             o.TPS,
+		MaxFrameRate:    o.MaxFrameRate,
+		ResolutionScale: o.ResolutionScale,
+	})
+}
+
+// IsLowPowerModeActive reports whether the low-power mode is active.
+//
+// The value is updated at the beginning of a tick.
+//
+// IsLowPowerModeActive is concurrent-safe.
+func IsLowPowerModeActive() bool {
+	return theInputState.lowPowerModeActive()
+}
+
+// notifyLowPowerModeChanged calls OnChanged if the low-power mode state has changed since the previous call.
+func notifyLowPowerModeChanged(lastActive *bool) {
+	active := theInputState.lowPowerModeActive()
+	if active == *lastActive {
+		return
+	}
+	*lastActive = active
+	if o := theLowPowerModeOptions.Load(); o != nil && o.OnChanged != nil {
+		o.OnChanged(active)
+	}
+}
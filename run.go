@@ -16,11 +16,14 @@ package ebiten
 
 import (
 	"errors"
+	"fmt"
 	"image"
 	"image/color"
 	"io/fs"
 	"sync/atomic"
+	"time"
 
+	"github.com/duplicants-ai/ebiten/internal/builtinshader"
 	"github.com/duplicants-ai/ebiten/internal/clock"
 	"github.com/duplicants-ai/ebiten/internal/graphicsdriver"
 	"github.com/duplicants-ai/ebiten/internal/ui"
@@ -70,6 +73,7 @@ type Game interface {
 	//
 	// On desktops, the outside is a window or a monitor (fullscreen mode). On browsers, the outside is a body
 	// element. On mobiles, the outside is the view's size.
+	// In the physical-pixel layout mode, the outside size is in physical pixels. See SetPhysicalPixelLayoutEnabled.
 	//
 	// Even though the outside size and the screen size differ, the rendering scale is automatically adjusted to
 	// fit with the outside.
@@ -84,6 +88,8 @@ type Game interface {
 	// adjusted with the given outside size.
 	//
 	// If the game implements the interface LayoutFer, Layout is never called and LayoutF is called instead.
+	//
+	// While a size is set by SetOffscreenSize, Layout is not called.
 	Layout(outsideWidth, outsideHeight int) (screenWidth, screenHeight int)
 }
 
@@ -97,6 +103,9 @@ type LayoutFer interface {
 	// size in pixels. The logical size is used for 1) the screen size given at Draw and 2) calculation of the
 	// scale from the screen to the final screen size. For 1), the actual screen size is a rounded up of the
 	// logical size.
+	//
+	// LayoutF is recommended over Layout especially when the device scale factor is fractional.
+	// See also PhysicalPixelLayout and SetPhysicalPixelLayoutEnabled.
 	LayoutF(outsideWidth, outsideHeight float64) (screenWidth, screenHeight float64)
 }
 
@@ -176,6 +185,53 @@ func IsScreenClearedEveryFrame() bool {
 	return ui.Get().IsScreenClearedEveryFrame()
 }
 
+// SetPhysicalPixelLayoutEnabled enables or disables the physical-pixel layout mode.
+//
+// In the physical-pixel layout mode, the outside size given to Game.Layout or LayoutF is the final screen size
+// in physical pixels, i.e. the outside size in device-independent pixels multiplied by the device scale factor and rounded up.
+// Then, the offscreen matches the physical pixel grid exactly if Layout returns the outside size as it is,
+// and the offscreen is rendered without scaling. This avoids the blurry rendering with fractional device scale factors like 1.5.
+// Even if Layout returns another size, the offscreen is put at integer positions on the final screen.
+//
+// An existing game whose Layout returns the outside size can migrate to the native resolution by enabling this mode.
+// Such a game should scale its contents by Monitor().DeviceScaleFactor() at Draw.
+// Note that the cursor and touch positions are in the offscreen's coordinate, i.e. in physical pixels in this case.
+//
+// To use physical pixels without this mode, return the result of PhysicalPixelLayout at LayoutF.
+//
+// The default state is false.
+//
+// SetPhysicalPixelLayoutEnabled is concurrent-safe, but takes effect only at the next frame.
+func SetPhysicalPixelLayoutEnabled(enabled bool) {
+	ui.Get().SetPhysicalPixelLayoutEnabled(enabled)
+}
+
+// IsPhysicalPixelLayoutEnabled reports whether the physical-pixel layout mode is enabled.
+//
+// IsPhysicalPixelLayoutEnabled is concurrent-safe.
+func IsPhysicalPixelLayoutEnabled() bool {
+	return ui.Get().IsPhysicalPixelLayoutEnabled()
+}
+
+// PhysicalPixelLayout converts the outside size given to LayoutF to the size in physical pixels of the current monitor.
+// PhysicalPixelLayout is a helper to implement LayoutF for a game rendering in the native resolution:
+//
+//	func (g *Game) LayoutF(outsideWidth, outsideHeight float64) (float64, float64) {
+//		return ebiten.PhysicalPixelLayout(outsideWidth, outsideHeight)
+//	}
+//
+// The returned size is not rounded so that the offscreen is rendered onto the final screen without scaling.
+// In the physical-pixel layout mode, the outside size is already in physical pixels, and PhysicalPixelLayout returns it as it is.
+//
+// PhysicalPixelLayout is concurrent-safe.
+func PhysicalPixelLayout(outsideWidth, outsideHeight float64) (screenWidth, screenHeight float64) {
+	if IsPhysicalPixelLayoutEnabled() {
+		return outsideWidth, outsideHeight
+	}
+	s := Monitor().DeviceScaleFactor()
+	return outsideWidth * s, outsideHeight * s
+}
+
 // SetScreenFilterEnabled enables/disables the use of the "screen" filter Ebitengine uses.
 //
 // The "screen" filter is a box filter from game to display resolution.
@@ -200,6 +256,106 @@ func IsScreenFilterEnabled() bool {
 	return screenFilterEnabled.Load()
 }
 
+// DitheringMode represents a dithering mode for the final screen.
+type DitheringMode int
+
+const (
+	// DitheringModeNone disables dithering.
+	DitheringModeNone DitheringMode = DitheringMode(builtinshader.DitherModeNone)
+
+	// DitheringModeOrdered dithers with a 4x4 Bayer matrix.
+	// The noise is a regular pattern.
+	DitheringModeOrdered DitheringMode = DitheringMode(builtinshader.DitherModeOrdered)
+
+	// DitheringModeBlueNoise dithers with a 16x16 blue-noise matrix.
+	// The noise is less noticeable than DitheringModeOrdered.
+	DitheringModeBlueNoise DitheringMode = DitheringMode(builtinshader.DitherModeBlueNoise)
+)
+
+// SetScreenDithering sets the dithering mode and strength to render the offscreen onto the final screen.
+//
+// Dithering adds a tiny noise to the colors to reduce banding of smooth gradients, especially in dark scenes,
+// when the final screen has 8-bit or lower precision per channel.
+// strength is the amplitude of the noise in 8-bit steps. 1 is recommended, and 0 disables dithering.
+//
+// Dithering is applied by DefaultDrawFinalScreen.
+// If a game implements FinalScreenDrawer without DefaultDrawFinalScreen, dithering is not applied.
+//
+// The default mode is DitheringModeNone.
+//
+// SetScreenDithering is concurrent-safe, but takes effect only at the next Draw call.
+func SetScreenDithering(mode DitheringMode, strength float64) {
+	screenDithering.Store(&screenDitheringState{
+		mode:     mode,
+		strength: strength,
+	})
+}
+
+// ScreenDithering returns the dithering mode and strength set by SetScreenDithering.
+//
+// ScreenDithering is concurrent-safe.
+func ScreenDithering() (DitheringMode, float64) {
+	s := screenDithering.Load()
+	if s == nil {
+		return DitheringModeNone, 0
+	}
+	return s.mode, s.strength
+}
+
+type offscreenSize struct {
+	width  float64
+	height float64
+}
+
+var (
+	theOffscreenSize atomic.Pointer[offscreenSize]
+	theOffscreen     atomic.Pointer[Image]
+)
+
+// SetOffscreenSize sets the size of the offscreen, the "virtual screen" given to Game.Draw, in pixels.
+//
+// If a positive size is set, the size is used instead of the result of Game.Layout, and Game.Layout is not called.
+// This is useful to change the resolution at runtime without tricks in Game.Layout.
+// If width or height is 0 or less, the size is reset and Game.Layout is used again.
+//
+// The offscreen is rendered onto the final screen as Game.Layout returns the size.
+// To render the offscreen with a custom transform, for example for split-screen or picture-in-picture, implement FinalScreenDrawer.
+// Note that the cursor and touch positions are always calculated with the default transform.
+//
+// SetOffscreenSize is concurrent-safe, but takes effect only at the next frame.
+func SetOffscreenSize(width, height float64) {
+	if width <= 0 || height <= 0 {
+		theOffscreenSize.Store(nil)
+		return
+	}
+	theOffscreenSize.Store(&offscreenSize{
+		width:  width,
+		height: height,
+	})
+}
+
+// OffscreenSize returns the size set by SetOffscreenSize.
+// If the size is not set, OffscreenSize returns (0, 0).
+//
+// OffscreenSize is concurrent-safe.
+func OffscreenSize() (width, height float64) {
+	s := theOffscreenSize.Load()
+	if s == nil {
+		return 0, 0
+	}
+	return s.width, s.height
+}
+
+// Offscreen returns the current offscreen, which is the same image given to Game.Draw and the offscreen given to FinalScreenDrawer.
+//
+// The offscreen is recreated when its size is changed, so don't keep the returned image across frames.
+// Offscreen returns nil before the game starts.
+//
+// Offscreen is concurrent-safe.
+func Offscreen() *Image {
+	return theOffscreen.Load()
+}
+
 // Termination is a special error which indicates Game termination without error.
 var Termination = ui.RegularTermination
 
@@ -238,6 +394,16 @@ func RunGame(game Game) error {
 	return RunGameWithOptions(game, nil)
 }
 
+// StatePublisher is an optional interface for Game to hand off the game state from Update to Draw.
+// StatePublisher is useful especially when RunGameOptions.AsyncUpdate is true.
+type StatePublisher interface {
+	// PublishState is called after every Update on Update's goroutine.
+	//
+	// PublishState and Draw are never called at the same time.
+	// PublishState should copy the state that Draw needs, so that Draw can use the copy while the next Update is running.
+	PublishState()
+}
+
 // RunGameOptions represents options for RunGameWithOptions.
 type RunGameOptions struct {
 	// GraphicsLibrary is a graphics library Ebitengine will use.
@@ -299,6 +465,51 @@ type RunGameOptions struct {
 
 	// X11InstanceName is an instance name in the ICCCM WM_CLASS window property.
 	X11InstanceName string
+
+	// CanvasSelector is a CSS selector of an existing canvas element that Ebitengine renders into, e.g., "#game".
+	// Ebitengine doesn't change the CSS size of the canvas, so the size should be specified by the web page.
+	// Ebitengine doesn't change the styles of the document in this case, and the game can be embedded in a larger web page layout.
+	//
+	// CanvasSelector is available only on browsers.
+	//
+	// The default (empty) value means that Ebitengine creates a new canvas and the canvas fills the document body.
+	CanvasSelector string
+
+	// RecoverFromGraphicsDeviceLoss indicates whether Ebitengine recreates the graphics device and restores images
+	// when the graphics device is lost, e.g., by a GPU driver update or a GPU hang, instead of terminating the game.
+	//
+	// When RecoverFromGraphicsDeviceLoss is true, Ebitengine keeps the pixels of images on CPU to restore them,
+	// which might cause a performance issue.
+	// Images whose contents cannot be restored, like ones cleared every frame, should be redrawn when
+	// IsGraphicsDeviceRestored returns true.
+	//
+	// RecoverFromGraphicsDeviceLoss is available only with DirectX 11 so far. Otherwise, RecoverFromGraphicsDeviceLoss is ignored.
+	//
+	// The default (zero) value is false.
+	RecoverFromGraphicsDeviceLoss bool
+
+	// CompressRestorationBackups indicates whether Ebitengine compresses the pixels of images kept on CPU to restore them.
+	//
+	// The pixels are kept on CPU when the images might need to be restored, e.g., on Android or with RecoverFromGraphicsDeviceLoss.
+	// Compression reduces the memory usage, especially for large images with simple contents,
+	// at the cost of CPU time when the pixels are written, read, or restored.
+	//
+	// The default (zero) value is false.
+	CompressRestorationBackups bool
+
+	// AsyncUpdate indicates whether Update runs on its own goroutine, decoupled from Draw.
+	//
+	// When AsyncUpdate is true, a heavy Update doesn't block rendering, and Draw can be called while Update is running.
+	// The input state is taken as a snapshot for each tick, so the input functions return consistent results in one Update.
+	// Update and Draw must not share the game state without synchronization.
+	// Implement StatePublisher to hand off the state from Update to Draw.
+	// Update should not draw or read images, as drawing images in Update might conflict with Draw.
+	//
+	// AsyncUpdate is ignored on browsers and in the single thread mode (see SingleThread),
+	// as Update must run on the main thread there.
+	//
+	// The default (zero) value is false, which means that Update and Draw are called in order on the same goroutine.
+	AsyncUpdate bool
 }
 
 // RunGameWithOptions starts the main loop and runs the game with the specified options.
@@ -315,6 +526,7 @@ type RunGameOptions struct {
 // game's functions are called on the same goroutine.
 //
 // On browsers, it is strongly recommended to use iframe if you embed an Ebitengine application in your website.
+// On browsers, a game can also run on a Web Worker with OffscreenCanvas. See misc/wasm/ebitengine_worker.js.
 //
 // RunGameWithOptions must be called on the main thread.
 // Note that Ebitengine bounds the main goroutine to the main OS thread by runtime.LockOSThread.
@@ -349,7 +561,7 @@ func RunGameWithOptions(game Game, options *RunGameOptions) error {
 			return nil
 		}
 
-		return err
+		return handleError(err)
 	}
 	return nil
 }
@@ -397,6 +609,7 @@ func CursorMode() CursorModeType {
 // CursorModeCaptured also works on browsers.
 // When the user exits the captured mode not by SetCursorMode but by the UI (e.g., pressing ESC),
 // the previous cursor mode is set automatically.
+// Such interruptions can be detected by AppendCursorCaptureInterruptions.
 //
 // On browsers, setting CursorModeCaptured might be delayed especially just after escaping from a capture.
 //
@@ -452,6 +665,81 @@ func IsFocused() bool {
 	return ui.Get().IsFocused()
 }
 
+// IsPageVisible reports whether the browser page running the game is visible.
+//
+// A page becomes invisible when e.g. the tab is switched or the browser is minimized.
+// Browsers might throttle or stop the game loop while the page is invisible,
+// so games can use this to pause the game or to save the state.
+//
+// IsPageVisible works only on browsers.
+// IsPageVisible always returns true if the platform is not a browser.
+//
+// IsPageVisible is concurrent-safe.
+func IsPageVisible() bool {
+	return !theInputState.pageHidden()
+}
+
+// IsPageVisibilityChanged reports whether the browser page's visibility has changed since the previous tick.
+//
+// IsPageVisibilityChanged always returns false if the platform is not a browser.
+//
+// IsPageVisibilityChanged is concurrent-safe.
+func IsPageVisibilityChanged() bool {
+	return theInputState.pageVisibilityChanged()
+}
+
+// RunOnMainThread queues f to be called on the OS main thread between frames, and returns immediately.
+//
+// Some native APIs like macOS AppKit or Windows COM objects for a window must be called on the main thread.
+// As Ebitengine owns the main thread while the game is running, use RunOnMainThread to call such APIs.
+// The queued functions are called in the same order as RunOnMainThread is called.
+//
+// f must not block for a long time, or the game loop is blocked.
+// Do not wait for f to finish in Update or Draw, or a deadlock happens since f is called between frames.
+// Use a channel to receive f's result at a later tick instead.
+//
+// On mobiles, the main thread is not managed by Ebitengine, and f is called on the goroutine of the game loop.
+//
+// RunOnMainThread is concurrent-safe.
+func RunOnMainThread(f func()) {
+	ui.Get().QueueOnMainThread(f)
+}
+
+// IsGraphicsDeviceRestored reports whether the graphics device has been lost and restored since the previous tick.
+//
+// Ebitengine restores the images' contents automatically, but the contents that cannot be restored,
+// e.g., images cleared every frame and images created with NewImageOptions.Unrestorable,
+// should be redrawn when IsGraphicsDeviceRestored returns true.
+//
+// IsGraphicsDeviceRestored returns true only on Android and with RunGameOptions.RecoverFromGraphicsDeviceLoss.
+// Otherwise, IsGraphicsDeviceRestored always returns false.
+//
+// IsGraphicsDeviceRestored is concurrent-safe.
+func IsGraphicsDeviceRestored() bool {
+	return theInputState.graphicsDeviceRestored()
+}
+
+// SetScreenSaverInhibited sets whether the screen saver and the system sleep are inhibited while the game is running.
+//
+// This is useful to prevent the screen from dimming or locking during e.g. long cutscenes or gamepad-only play,
+// where the OS doesn't detect any user activity.
+//
+// The inhibition is implemented as follows:
+//
+//   - Windows: SetThreadExecutionState
+//   - macOS: NSProcessInfo's activity, which creates power assertions
+//   - Linux and other Unix-like systems: org.freedesktop.ScreenSaver via D-Bus, or the XDG Desktop Portal's Inhibit when it is not available, e.g., in Flatpak
+//   - Android: The view's keepScreenOn with the view generated by ebitenmobile
+//   - iOS: UIApplication's idleTimerDisabled with the view generated by ebitenmobile
+//   - Browsers: Screen Wake Lock API
+//
+// The inhibition is best-effort. SetScreenSaverInhibited does nothing if the platform doesn't support it.
+//
+// SetScreenSaverInhibited is concurrent-safe.
+func SetScreenSaverInhibited(inhibited bool) {
+	ui.Get().SetScreenSaverInhibited(inhibited)
+}
+
 // IsRunnableOnUnfocused returns a boolean value indicating whether
 // the game runs even in background.
 //
@@ -495,18 +783,166 @@ func DeviceScaleFactor() float64 {
 
 // IsVsyncEnabled returns a boolean value indicating whether
 // the game uses the display's vsync.
+//
+// IsVsyncEnabled returns true when the vsync mode is not VsyncModeOff.
 func IsVsyncEnabled() bool {
 	return ui.Get().FPSMode() == ui.FPSModeVsyncOn
 }
 
 // SetVsyncEnabled sets a boolean value indicating whether
 // the game uses the display's vsync.
+//
+// SetVsyncEnabled(true) is the same as SetVsyncMode(VsyncModeOn), and
+// SetVsyncEnabled(false) is the same as SetVsyncMode(VsyncModeOff).
 func SetVsyncEnabled(enabled bool) {
 	if enabled {
-		ui.Get().SetFPSMode(ui.FPSModeVsyncOn)
+		SetVsyncMode(VsyncModeOn)
 	} else {
+		SetVsyncMode(VsyncModeOff)
+	}
+}
+
+// VsyncModeType is a type of vsync modes.
+type VsyncModeType int
+
+const (
+	// VsyncModeOn indicates that presenting a frame waits for the display's vsync.
+	// VsyncModeOn is the default mode.
+	VsyncModeOn VsyncModeType = VsyncModeType(ui.VsyncModeOn)
+
+	// VsyncModeOff indicates that presenting a frame doesn't wait for the display's vsync.
+	// Tearing might happen.
+	VsyncModeOff VsyncModeType = VsyncModeType(ui.VsyncModeOff)
+
+	// VsyncModeAdaptive indicates that presenting a frame waits for the display's vsync,
+	// but a frame that misses the vsync is presented immediately with tearing instead of waiting for the next vsync.
+	// This avoids stutters when the frame rate is slightly lower than the display's refresh rate.
+	//
+	// VsyncModeAdaptive is available only with OpenGL on Windows and Linux when the driver supports it.
+	VsyncModeAdaptive VsyncModeType = VsyncModeType(ui.VsyncModeAdaptive)
+
+	// VsyncModeMailbox indicates that presenting a frame doesn't wait for the display's vsync,
+	// but the display shows the latest presented frame at each vsync without tearing.
+	// The game is updated as fast as possible like VsyncModeOff.
+	//
+	// VsyncModeMailbox is available only with DirectX with the flip-model swap chain.
+	VsyncModeMailbox VsyncModeType = VsyncModeType(ui.VsyncModeMailbox)
+)
+
+// VsyncMode returns the vsync mode set by SetVsyncMode or SetVsyncEnabled.
+//
+// VsyncMode is concurrent-safe.
+func VsyncMode() VsyncModeType {
+	if ui.Get().FPSMode() != ui.FPSModeVsyncOn {
+		return VsyncModeOff
+	}
+	return VsyncModeType(ui.Get().VsyncMode())
+}
+
+// SetVsyncMode sets the vsync mode.
+// The default vsync mode is VsyncModeOn.
+//
+// If the given mode is not supported in the current environment, VsyncModeOn is used instead.
+// Use ActiveVsyncMode to know which mode is actually used.
+//
+// SetVsyncMode is concurrent-safe.
+func SetVsyncMode(mode VsyncModeType) {
+	if mode == VsyncModeOff {
 		ui.Get().SetFPSMode(ui.FPSModeVsyncOffMaximum)
+		return
+	}
+	ui.Get().SetVsyncMode(ui.VsyncMode(mode))
+	ui.Get().SetFPSMode(ui.FPSModeVsyncOn)
+}
+
+// ActiveVsyncMode returns the vsync mode that the graphics library actually uses.
+//
+// ActiveVsyncMode can be different from VsyncMode when the mode set by SetVsyncMode is not supported.
+// Before the game starts, and on browsers and mobiles, ActiveVsyncMode returns VsyncModeOn.
+//
+// ActiveVsyncMode is concurrent-safe.
+func ActiveVsyncMode() VsyncModeType {
+	return VsyncModeType(ui.Get().ActiveVsyncMode())
+}
+
+// LatencyModeType is a type of latency modes.
+type LatencyModeType int
+
+const (
+	// LatencyModeDefault uses the default frame queueing of the graphics driver.
+	// LatencyModeDefault is the default mode.
+	LatencyModeDefault LatencyModeType = LatencyModeType(ui.LatencyModeDefault)
+
+	// LatencyModeLow indicates that a frame is not started until the previous frame is presented.
+	// The inputs are sampled as late as possible, which reduces the latency between inputs and the screen,
+	// at the cost of the throughput.
+	//
+	// LatencyModeLow is useful for games that require quick responses like rhythm games and fighting games.
+	LatencyModeLow LatencyModeType = LatencyModeType(ui.LatencyModeLow)
+
+	// LatencyModeSmooth indicates that more frames can be queued before they are presented.
+	// This absorbs spikes of frame times and makes the frame pacing smoother, at the cost of the latency.
+	LatencyModeSmooth LatencyModeType = LatencyModeType(ui.LatencyModeSmooth)
+)
+
+// LatencyMode returns the current latency mode.
+//
+// LatencyMode is concurrent-safe.
+func LatencyMode() LatencyModeType {
+	return LatencyModeType(ui.Get().LatencyMode())
+}
+
+// SetLatencyMode sets the latency mode, which controls how many frames can be queued before they are presented.
+// The default latency mode is LatencyModeDefault.
+//
+// How the latency mode is realized depends on the graphics library:
+// the maximum frame latency on DirectX 11, the maximum drawable count on Metal,
+// and waiting for the GPU after presenting a frame in LatencyModeLow on DirectX 12 and OpenGL.
+// On browsers, SetLatencyMode does nothing.
+//
+// SetLatencyMode is concurrent-safe, but takes effect only at the next frame.
+func SetLatencyMode(mode LatencyModeType) {
+	ui.Get().SetLatencyMode(ui.LatencyMode(mode))
+}
+
+// PresentLatency returns the measured present latency,
+// which is the duration from sampling the inputs for a frame to the end of presenting the frame.
+// The value is smoothed over recent frames.
+//
+// The present latency doesn't include the time the display takes to show the presented frame,
+// so the actual latency to the screen is longer than this value.
+//
+// PresentLatency returns 0 before the first frame is presented.
+//
+// This value is for measurement and/or debug, and your game logic should not rely on this value.
+//
+// PresentLatency is concurrent-safe.
+func PresentLatency() time.Duration {
+	return ui.Get().PresentLatency()
+}
+
+// SetPreferredFrameRateRange sets the preferred range of the display frame rate in Hz.
+// preferred must be between min and max inclusive. 0 for preferred means no preference.
+//
+// On displays with adaptive refresh rates like ProMotion displays, the system chooses a frame rate within the range.
+// By default, the system's default range is used, which is usually up to 60 Hz on iOS.
+// Note that an iOS application must set CADisableMinimumFrameDurationOnPhone to true in its Info.plist
+// to use frame rates higher than 60 Hz on iPhones.
+//
+// As Update is called TPS times per second regardless of the frame rate, you might also want to use SetTPS.
+//
+// SetPreferredFrameRateRange works only on iOS and Android with the view generated by ebitenmobile.
+// SetPreferredFrameRateRange does nothing on other platforms.
+//
+// SetPreferredFrameRateRange is concurrent-safe.
+func SetPreferredFrameRateRange(min, max, preferred int) {
+	if min < 0 || max < min {
+		panic(fmt.Sprintf("ebiten: invalid frame rate range: [%d, %d]", min, max))
+	}
+	if preferred != 0 && (preferred < min || max < preferred) {
+		panic(fmt.Sprintf("ebiten: preferred frame rate %d must be in [%d, %d]", preferred, min, max))
 	}
+	ui.Get().SetPreferredFrameRateRange(min, max, preferred)
 }
 
 // FPSModeType is a type of FPS modes.
@@ -577,6 +1013,8 @@ func ScheduleFrame() {
 
 // TPS returns the current maximum TPS.
 //
+// While the low-power mode limits TPS, TPS returns the limited value. See SetLowPowerMode.
+//
 // TPS is concurrent-safe.
 func TPS() int {
 	return clock.TPS()
@@ -732,6 +1170,11 @@ func toUIRunOptions(options *RunGameOptions) *ui.RunOptions {
 		ColorSpace:        graphicsdriver.ColorSpace(options.ColorSpace),
 		X11ClassName:      options.X11ClassName,
 		X11InstanceName:   options.X11InstanceName,
+		CanvasSelector:    options.CanvasSelector,
+
+		RecoverFromGraphicsDeviceLoss: options.RecoverFromGraphicsDeviceLoss,
+		CompressRestorationBackups:    options.CompressRestorationBackups,
+		AsyncUpdate:                   options.AsyncUpdate,
 	}
 }
 
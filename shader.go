@@ -31,11 +31,54 @@ import (
 type Shader struct {
 	shader *ui.Shader
 	unit   shaderir.Unit
+
+	// outputCount is the number of the colors the Fragment function returns.
+	outputCount int
 }
 
 // NewShader compiles a shader program in the shading language Kage, and returns the result.
 //
 // If the compilation fails, NewShader returns an error.
+// The error message refers to the positions in src as line:column.
+//
+// A for-statement with non-constant bounds can be preceded by a `//kage:maxiterations N` directive,
+// which declares the maximum number of iterations.
+// Some backends like DirectX need this to sample a texture in such a loop.
+//
+// A shader can define a custom vertex function to transform vertices on GPU:
+//
+//	func Vertex(dstPos vec2, srcPos vec2, color vec4, custom vec4) (vec2, vec2, vec4, vec4)
+//
+// dstPos is the destination position in pixels, and the arguments are the vertex values given by the draw functions.
+// The results are the transformed destination position in pixels, and the values passed to Fragment.
+// To transform the extra vertex attributes given by DrawTrianglesShaderOptions.VertexAttributes, use this signature instead:
+//
+//	func Vertex(dstPos vec2, srcPos vec2, color vec4, custom vec4, attributes vec4) (vec2, vec2, vec4, vec4, vec4)
+//
+// A function named Vertex with a different signature is treated as a regular function.
+//
+// The first result of Vertex can be vec3 instead of vec2. z is the depth of the vertex in [0, 1], and a smaller value is nearer.
+// The depth is interpolated for each fragment.
+// Without such a Vertex function, the depth is always 0.
+//
+// A `//kage:depth` directive specifies how the shader uses the destination's depth buffer (see NewImageOptions.DepthBuffer):
+//
+//	//kage:depth test        // Draw a fragment only if its depth is less than or equal to the buffer's value.
+//	//kage:depth write       // Write the fragment's depth to the buffer.
+//	//kage:depth test write  // Both.
+//
+// Without the directive, or when the destination image doesn't have a depth buffer, the depth buffer is not used.
+//
+// Fragment can return up to 4 vec4 values for multiple render targets:
+//
+//	func Fragment(dstPos vec4, srcPos vec2, color vec4) (vec4, vec4)
+//
+// The first value is written to the destination image, and the other values are written to the images
+// given by DrawTrianglesShaderOptions.ExtraDestinations in the same draw call.
+// The draw functions without ExtraDestinations use only the first value.
+//
+// A package-level variable with an array literal like `var kernel = [3]float{0.25, 0.5, 0.25}` is a constant array
+// embedded in the shader, not a uniform variable. Its elements must be constants, and it cannot be assigned.
 //
 // For the details about the shader, see https://ebitengine.org/en/documents/shader.html.
 func NewShader(src []byte) (*Shader, error) {
@@ -48,8 +91,9 @@ func newShader(src []byte, name string) (*Shader, error) {
 		return nil, err
 	}
 	return &Shader{
-		shader: ui.NewShader(ir, name),
-		unit:   ir.Unit,
+		shader:      ui.NewShader(ir, name),
+		unit:        ir.Unit,
+		outputCount: max(ir.FragmentOutputCount, 1),
 	}, nil
 }
 
@@ -156,3 +200,141 @@ func builtinShader(filter builtinshader.Filter, address builtinshader.Address, u
 	builtinShadersForRead.Store(&shaders)
 	return shader
 }
+
+var (
+	screenDitherShaders  [builtinshader.FilterCount]*Shader
+	screenDitherShadersM sync.Mutex
+)
+
+func screenDitherShader(filter builtinshader.Filter) *Shader {
+	screenDitherShadersM.Lock()
+	defer screenDitherShadersM.Unlock()
+
+	if s := screenDitherShaders[filter]; s != nil {
+		return s
+	}
+
+	var name string
+	switch filter {
+	case builtinshader.FilterNearest:
+		name = "nearest"
+	case builtinshader.FilterLinear:
+		name = "linear"
+	case builtinshader.FilterPixelated:
+		name = "pixelated"
+	}
+	s, err := newShader(builtinshader.ScreenDitherShaderSource(filter), "screen-dither-"+name)
+	if err != nil {
+		panic(fmt.Sprintf("ebiten: NewShader for a built-in shader failed: %v", err))
+	}
+	screenDitherShaders[filter] = s
+	return s
+}
+
+type maskShaderKey struct {
+	filter    builtinshader.Filter
+	address   builtinshader.Address
+	useColorM bool
+	mask      builtinshader.Mask
+}
+
+var (
+	maskShaders  = map[maskShaderKey]*Shader{}
+	maskShadersM sync.Mutex
+)
+
+func maskShader(filter builtinshader.Filter, address builtinshader.Address, useColorM bool, mask builtinshader.Mask) *Shader {
+	maskShadersM.Lock()
+	defer maskShadersM.Unlock()
+
+	key := maskShaderKey{
+		filter:    filter,
+		address:   address,
+		useColorM: useColorM,
+		mask:      mask,
+	}
+	if s, ok := maskShaders[key]; ok {
+		return s
+	}
+
+	var name string
+	switch filter {
+	case builtinshader.FilterNearest:
+		name = "nearest"
+	case builtinshader.FilterLinear:
+		name = "linear"
+	case builtinshader.FilterPixelated:
+		name = "pixelated"
+	}
+	switch address {
+	case builtinshader.AddressClampToZero:
+		name += "-clamptozero"
+	case builtinshader.AddressRepeat:
+		name += "-repeat"
+	}
+	if useColorM {
+		name += "-colorm"
+	}
+	switch mask {
+	case builtinshader.MaskAlpha:
+		name += "-mask-alpha"
+	case builtinshader.MaskLuminance:
+		name += "-mask-luminance"
+	}
+	s, err := newShader(builtinshader.MaskShaderSource(filter, address, useColorM, mask), name)
+	if err != nil {
+		panic(fmt.Sprintf("ebiten: NewShader for a built-in shader failed: %v", err))
+	}
+	maskShaders[key] = s
+	return s
+}
+
+var (
+	trilinearShaders  [2]*Shader
+	trilinearShadersM sync.Mutex
+)
+
+func trilinearShader(useColorM bool) *Shader {
+	trilinearShadersM.Lock()
+	defer trilinearShadersM.Unlock()
+
+	var idx int
+	if useColorM {
+		idx = 1
+	}
+	if s := trilinearShaders[idx]; s != nil {
+		return s
+	}
+
+	name := "linear-trilinear"
+	if useColorM {
+		name += "-colorm"
+	}
+	s, err := newShader(builtinshader.TrilinearShaderSource(useColorM), name)
+	if err != nil {
+		panic(fmt.Sprintf("ebiten: NewShader for a built-in shader failed: %v", err))
+	}
+	trilinearShaders[idx] = s
+	return s
+}
+
+var (
+	theGradientShader  *Shader
+	theGradientShaderM sync.Mutex
+)
+
+func gradientShader() *Shader {
+	theGradientShaderM.Lock()
+	defer theGradientShaderM.Unlock()
+
+	if theGradientShader != nil {
+		return theGradientShader
+	}
+
+	s, err := newShader(builtinshader.GradientShaderSource(), "gradient")
+	if err != nil {
+		panic(fmt.Sprintf("ebiten: NewShader for a built-in shader failed: %v", err))
+	}
+	theGradientShader = s
+	return s
+}
@@ -2724,6 +2724,340 @@ func Fragment(dstPos vec4, srcPos vec2, color vec4, custom vec4) vec4 {
 	}
 }
 
+func TestShaderVertexAttributes(t *testing.T) {
+	const w, h = 16, 16
+
+	s, err := ebiten.NewShader([]byte(`//kage:unit pixels
+
+package main
+
+func Fragment(dstPos vec4, srcPos vec2, color vec4, custom vec4, attributes vec4) vec4 {
+	return attributes
+}
+`))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	vs := []ebiten.Vertex{
+		{DstX: 0, DstY: 0},
+		{DstX: w, DstY: 0},
+		{DstX: 0, DstY: h},
+		{DstX: w, DstY: h},
+	}
+	is := []uint16{0, 1, 2, 1, 2, 3}
+
+	clr := color.RGBA{R: 0x10, G: 0x20, B: 0x30, A: 0x40}
+	attrs := make([]float32, ebiten.VertexAttributeCount*len(vs))
+	for i := range vs {
+		attrs[ebiten.VertexAttributeCount*i] = float32(clr.R) / 0xff
+		attrs[ebiten.VertexAttributeCount*i+1] = float32(clr.G) / 0xff
+		attrs[ebiten.VertexAttributeCount*i+2] = float32(clr.B) / 0xff
+		attrs[ebiten.VertexAttributeCount*i+3] = float32(clr.A) / 0xff
+	}
+
+	dst := ebiten.NewImage(w, h)
+	op := &ebiten.DrawTrianglesShaderOptions{}
+	op.Blend = ebiten.BlendCopy
+	op.VertexAttributes = attrs
+	dst.DrawTrianglesShader(vs, is, s, op)
+
+	for j := 0; j < h; j++ {
+		for i := 0; i < w; i++ {
+			got := dst.At(i, j).(color.RGBA)
+			want := clr
+			if !sameColors(got, want, 2) {
+				t.Errorf("dst.At(%d, %d): got: %v, want: %v", i, j, got, want)
+			}
+		}
+	}
+
+	// Without VertexAttributes, the attributes are zeros.
+	op.VertexAttributes = nil
+	dst.DrawTrianglesShader(vs, is, s, op)
+	for j := 0; j < h; j++ {
+		for i := 0; i < w; i++ {
+			got := dst.At(i, j).(color.RGBA)
+			want := color.RGBA{}
+			if got != want {
+				t.Errorf("dst.At(%d, %d): got: %v, want: %v", i, j, got, want)
+			}
+		}
+	}
+}
+
+func TestShaderVertexAttributesWithCustomVertex(t *testing.T) {
+	const w, h = 16, 16
+
+	s, err := ebiten.NewShader([]byte(`//kage:unit pixels
+
+package main
+
+func Vertex(dstPos vec2, srcPos vec2, color vec4, custom vec4, attributes vec4) (vec2, vec2, vec4, vec4, vec4) {
+	return dstPos + attributes.xy, srcPos, color, custom, attributes
+}
+
+func Fragment(dstPos vec4, srcPos vec2, color vec4, custom vec4, attributes vec4) vec4 {
+	return vec4(attributes.zw, 0, 1)
+}
+`))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	vs := []ebiten.Vertex{
+		{DstX: 0, DstY: 0},
+		{DstX: w / 2, DstY: 0},
+		{DstX: 0, DstY: h / 2},
+		{DstX: w / 2, DstY: h / 2},
+	}
+	is := []uint16{0, 1, 2, 1, 2, 3}
+	var attrs []float32
+	for range vs {
+		attrs = append(attrs, w/2, h/2, 1, 0)
+	}
+
+	dst := ebiten.NewImage(w, h)
+	op := &ebiten.DrawTrianglesShaderOptions{}
+	op.VertexAttributes = attrs
+	dst.DrawTrianglesShader(vs, is, s, op)
+
+	for j := 0; j < h; j++ {
+		for i := 0; i < w; i++ {
+			var want color.RGBA
+			if i >= w/2 && j >= h/2 {
+				want = color.RGBA{R: 0xff, A: 0xff}
+			}
+			if got := dst.At(i, j).(color.RGBA); got != want {
+				t.Errorf("dst.At(%d, %d): got: %v, want: %v", i, j, got, want)
+			}
+		}
+	}
+}
+
+func TestShaderVertexAttributesMixedWithRegularDraws(t *testing.T) {
+	const w, h = 16, 16
+
+	attrShader, err := ebiten.NewShader([]byte(`//kage:unit pixels
+
+package main
+
+func Fragment(dstPos vec4, srcPos vec2, color vec4, custom vec4, attributes vec4) vec4 {
+	return attributes
+}
+`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	regularShader, err := ebiten.NewShader([]byte(`//kage:unit pixels
+
+package main
+
+func Fragment(dstPos vec4, srcPos vec2, color vec4, custom vec4) vec4 {
+	return custom
+}
+`))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	dst := ebiten.NewImage(w, h)
+	is := []uint16{0, 1, 2, 1, 2, 3}
+
+	// Draw the four quadrants alternately with the two shaders in one frame.
+	for k := 0; k < 4; k++ {
+		x := float32(k%2) * w / 2
+		y := float32(k/2) * h / 2
+		vs := []ebiten.Vertex{
+			{DstX: x, DstY: y},
+			{DstX: x + w/2, DstY: y},
+			{DstX: x, DstY: y + h/2},
+			{DstX: x + w/2, DstY: y + h/2},
+		}
+		if k%2 == 0 {
+			op := &ebiten.DrawTrianglesShaderOptions{}
+			for range vs {
+				op.VertexAttributes = append(op.VertexAttributes, 1, 0, 0, 1)
+			}
+			dst.DrawTrianglesShader(vs, is, attrShader, op)
+			continue
+		}
+		for i := range vs {
+			vs[i].Custom1 = 1
+			vs[i].Custom3 = 1
+		}
+		dst.DrawTrianglesShader(vs, is, regularShader, nil)
+	}
+
+	for j := 0; j < h; j++ {
+		for i := 0; i < w; i++ {
+			want := color.RGBA{R: 0xff, A: 0xff}
+			if i >= w/2 {
+				want = color.RGBA{G: 0xff, A: 0xff}
+			}
+			if got := dst.At(i, j).(color.RGBA); got != want {
+				t.Errorf("dst.At(%d, %d): got: %v, want: %v", i, j, got, want)
+			}
+		}
+	}
+
+	// DrawRectShader with a shader using the extra vertex attributes gives zeros as the attributes.
+	dst.Fill(color.White)
+	op := &ebiten.DrawRectShaderOptions{}
+	op.Blend = ebiten.BlendCopy
+	dst.DrawRectShader(w, h, attrShader, op)
+	for j := 0; j < h; j++ {
+		for i := 0; i < w; i++ {
+			if got, want := dst.At(i, j).(color.RGBA), (color.RGBA{}); got != want {
+				t.Errorf("dst.At(%d, %d): got: %v, want: %v", i, j, got, want)
+			}
+		}
+	}
+}
+
+func TestShaderMultipleOutputs(t *testing.T) {
+	const w, h = 16, 16
+
+	s, err := ebiten.NewShader([]byte(`//kage:unit pixels
+
+package main
+
+func Fragment(dstPos vec4, srcPos vec2, color vec4) (vec4, vec4, vec4) {
+	return vec4(1, 0, 0, 1), vec4(0, 1, 0, 1), vec4(0, 0, 1, 1)
+}
+`))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	dst0 := ebiten.NewImage(w, h)
+	dst1 := ebiten.NewImage(w, h)
+	dst2 := ebiten.NewImage(w, h)
+
+	vs := []ebiten.Vertex{
+		{DstX: 0, DstY: 0},
+		{DstX: w / 2, DstY: 0},
+		{DstX: 0, DstY: h / 2},
+		{DstX: w / 2, DstY: h / 2},
+	}
+	is := []uint16{0, 1, 2, 1, 2, 3}
+	op := &ebiten.DrawTrianglesShaderOptions{}
+	op.ExtraDestinations[0] = dst1
+	op.ExtraDestinations[1] = dst2
+	dst0.DrawTrianglesShader(vs, is, s, op)
+
+	for _, tc := range []struct {
+		dst  *ebiten.Image
+		want color.RGBA
+	}{
+		{dst: dst0, want: color.RGBA{R: 0xff, A: 0xff}},
+		{dst: dst1, want: color.RGBA{G: 0xff, A: 0xff}},
+		{dst: dst2, want: color.RGBA{B: 0xff, A: 0xff}},
+	} {
+		b := tc.dst.Bounds()
+		for j := 0; j < h; j++ {
+			for i := 0; i < w; i++ {
+				var want color.RGBA
+				if i < w/2 && j < h/2 {
+					want = tc.want
+				}
+				if got := tc.dst.At(b.Min.X+i, b.Min.Y+j).(color.RGBA); got != want {
+					t.Errorf("dst.At(%d, %d): got: %v, want: %v", b.Min.X+i, b.Min.Y+j, got, want)
+				}
+			}
+		}
+	}
+
+	// Without ExtraDestinations, only the first output is used.
+	dst0.Clear()
+	dst0.DrawTrianglesShader(vs, is, s, nil)
+	if got, want := dst0.At(0, 0).(color.RGBA), (color.RGBA{R: 0xff, A: 0xff}); got != want {
+		t.Errorf("dst0.At(0, 0): got: %v, want: %v", got, want)
+	}
+}
+
+func TestShaderMultipleOutputsTooManyDestinations(t *testing.T) {
+	s, err := ebiten.NewShader([]byte(`//kage:unit pixels
+
+package main
+
+func Fragment(dstPos vec4, srcPos vec2, color vec4) (vec4, vec4) {
+	return color, color
+}
+`))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	dst := ebiten.NewImage(16, 16)
+	op := &ebiten.DrawTrianglesShaderOptions{}
+	op.ExtraDestinations[1] = ebiten.NewImage(16, 16)
+
+	defer func() {
+		if recover() == nil {
+			t.Errorf("DrawTrianglesShader must panic when ExtraDestinations has more images than the outputs")
+		}
+	}()
+	dst.DrawTrianglesShader([]ebiten.Vertex{{}, {}, {}}, []uint16{0, 1, 2}, s, op)
+}
+
+func TestShaderMultipleOutputsSubImages(t *testing.T) {
+	const w, h = 16, 16
+
+	s, err := ebiten.NewShader([]byte(`//kage:unit pixels
+
+package main
+
+func Fragment(dstPos vec4, srcPos vec2, color vec4) (vec4, vec4) {
+	return vec4(1, 0, 0, 1), vec4(0, 1, 0, 1)
+}
+`))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	r := image.Rect(w/2, h/2, w, h)
+	dst0 := ebiten.NewImage(w, h).SubImage(r).(*ebiten.Image)
+	dst1 := ebiten.NewImage(w, h).SubImage(r).(*ebiten.Image)
+
+	vs := []ebiten.Vertex{
+		{DstX: w / 2, DstY: h / 2},
+		{DstX: w, DstY: h / 2},
+		{DstX: w / 2, DstY: h},
+		{DstX: w, DstY: h},
+	}
+	is := []uint16{0, 1, 2, 1, 2, 3}
+	op := &ebiten.DrawTrianglesShaderOptions{}
+	op.ExtraDestinations[0] = dst1
+	dst0.DrawTrianglesShader(vs, is, s, op)
+
+	for _, tc := range []struct {
+		dst  *ebiten.Image
+		want color.RGBA
+	}{
+		{dst: dst0, want: color.RGBA{R: 0xff, A: 0xff}},
+		{dst: dst1, want: color.RGBA{G: 0xff, A: 0xff}},
+	} {
+		for j := r.Min.Y; j < r.Max.Y; j++ {
+			for i := r.Min.X; i < r.Max.X; i++ {
+				if got := tc.dst.At(i, j).(color.RGBA); got != tc.want {
+					t.Errorf("dst.At(%d, %d): got: %v, want: %v", i, j, got, tc.want)
+				}
+			}
+		}
+	}
+
+	// A sub-image at a different position cannot be an extra destination.
+	dst2 := ebiten.NewImage(w, h).SubImage(image.Rect(0, 0, w/2, h/2)).(*ebiten.Image)
+	op.ExtraDestinations[0] = dst2
+	defer func() {
+		if recover() == nil {
+			t.Errorf("DrawTrianglesShader must panic when the destination images are at different positions")
+		}
+	}()
+	dst0.DrawTrianglesShader(vs, is, s, op)
+}
+
 func TestShaderFragmentLessArguments(t *testing.T) {
 	const w, h = 16, 16
 
@@ -2860,6 +3194,60 @@ func Fragment(dstPos vec4, srcPos vec2, color vec4) vec4 {
 	}
 }
 
+func TestShaderFetch(t *testing.T) {
+	const w, h = 4, 2
+
+	pix := make([]byte, 4*w*h)
+	for i := range pix {
+		pix[i] = byte(i * 7)
+	}
+	src := ebiten.NewImage(w, h)
+	src.WritePixels(pix)
+
+	for _, unit := range []string{"pixels", "texels"} {
+		unit := unit
+		t.Run(unit, func(t *testing.T) {
+			// The position in pixels from the source image's origin.
+			pos := "ivec2(srcPos - imageSrc0Origin())"
+			if unit == "texels" {
+				pos = "ivec2((srcPos - imageSrc0Origin()) / imageSrc0Size() * vec2(4, 2))"
+			}
+
+			dst := ebiten.NewImage(w, h)
+			s, err := ebiten.NewShader([]byte(fmt.Sprintf(`//kage:unit %s
+
+package main
+
+func Fragment(dstPos vec4, srcPos vec2, color vec4) vec4 {
+	pos := %s
+	if imageSrc0IntAt(ivec2(-1, 0)).a != 0 || imageSrc0IntAt(ivec2(4, 0)).a != 0 {
+		return vec4(1, 0, 0, 1)
+	}
+	return vec4(imageSrc0IntAt(pos)) / 255
+}
+`, unit, pos)))
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			op := &ebiten.DrawRectShaderOptions{}
+			op.Images[0] = src
+			dst.DrawRectShader(w, h, s, op)
+
+			for j := 0; j < h; j++ {
+				for i := 0; i < w; i++ {
+					got := dst.At(i, j).(color.RGBA)
+					idx := 4 * (j*w + i)
+					want := color.RGBA{R: pix[idx], G: pix[idx+1], B: pix[idx+2], A: pix[idx+3]}
+					if got != want {
+						t.Errorf("dst.At(%d, %d): got: %v, want: %v", i, j, got, want)
+					}
+				}
+			}
+		})
+	}
+}
+
 func BenchmarkBuiltinShader(b *testing.B) {
 	// Create a shader to cache the shader compilation result.
 	_ = ebiten.BuiltinShader(builtinshader.FilterNearest, builtinshader.AddressUnsafe, false)
@@ -2867,3 +3255,277 @@ func BenchmarkBuiltinShader(b *testing.B) {
 		_ = ebiten.BuiltinShader(builtinshader.FilterNearest, builtinshader.AddressUnsafe, false)
 	}
 }
+
+func TestShaderExtraImages(t *testing.T) {
+	const w, h = 16, 16
+
+	s, err := ebiten.NewShader([]byte(`//kage:unit pixels
+
+package main
+
+func Fragment(dstPos vec4, srcPos vec2, color vec4) vec4 {
+	return imageSrc0At(srcPos) + imageSrc3At(srcPos) + imageSrc4At(srcPos) + imageSrc7At(srcPos)
+}
+`))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	newImage := func(clr color.RGBA) *ebiten.Image {
+		img := ebiten.NewImage(w, h)
+		img.Fill(clr)
+		return img
+	}
+	src0 := newImage(color.RGBA{R: 0x10, A: 0x10})
+	src3 := newImage(color.RGBA{G: 0x20, A: 0x20})
+	src4 := newImage(color.RGBA{B: 0x30, A: 0x30})
+	src7 := newImage(color.RGBA{R: 0x40, A: 0x40})
+
+	dst := ebiten.NewImage(w, h)
+	op := &ebiten.DrawRectShaderOptions{}
+	op.Images[0] = src0
+	op.Images[3] = src3
+	op.ExtraImages[0] = src4
+	op.ExtraImages[3] = src7
+	dst.DrawRectShader(w, h, s, op)
+
+	want := color.RGBA{R: 0x50, G: 0x20, B: 0x30, A: 0xa0}
+	for j := 0; j < h; j++ {
+		for i := 0; i < w; i++ {
+			if got := dst.At(i, j).(color.RGBA); !sameColors(got, want, 1) {
+				t.Errorf("dst.At(%d, %d): got: %v, want: %v", i, j, got, want)
+			}
+		}
+	}
+}
+
+func TestShaderImageArray(t *testing.T) {
+	const w, h = 16, 16
+
+	colors := []color.RGBA{
+		{R: 0xff, A: 0xff},
+		{G: 0xff, A: 0xff},
+		{B: 0xff, A: 0xff},
+	}
+	arr := ebiten.NewImageArray(w, h, len(colors))
+	for i, clr := range colors {
+		arr.Layer(i).Fill(clr)
+	}
+
+	for _, unit := range []string{"pixels", "texels"} {
+		unit := unit
+		t.Run(unit, func(t *testing.T) {
+			s, err := ebiten.NewShader([]byte(fmt.Sprintf(`//kage:unit %s
+
+package main
+
+var Layer int
+
+func Fragment(dstPos vec4, srcPos vec2, color vec4) vec4 {
+	return imageSrc0LayerAt(srcPos, Layer)
+}
+`, unit)))
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			for layer, want := range colors {
+				dst := ebiten.NewImage(w, h)
+				op := &ebiten.DrawRectShaderOptions{}
+				op.Images[0] = arr.Layer(0)
+				op.Uniforms = map[string]any{
+					"Layer": layer,
+				}
+				dst.DrawRectShader(w, h, s, op)
+				for j := 0; j < h; j++ {
+					for i := 0; i < w; i++ {
+						if got := dst.At(i, j).(color.RGBA); got != want {
+							t.Errorf("layer %d: dst.At(%d, %d): got: %v, want: %v", layer, i, j, got, want)
+						}
+					}
+				}
+			}
+		})
+	}
+}
+
+func TestShaderCustomVertex(t *testing.T) {
+	const w, h = 16, 16
+
+	s, err := ebiten.NewShader([]byte(`//kage:unit pixels
+
+package main
+
+var Offset vec2
+
+func Vertex(dstPos vec2, srcPos vec2, color vec4, custom vec4) (vec2, vec2, vec4, vec4) {
+	return dstPos + Offset, srcPos, vec4(custom.xyz, 1), custom
+}
+
+func Fragment(dstPos vec4, srcPos vec2, color vec4) vec4 {
+	return color
+}
+`))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	dst := ebiten.NewImage(w, h)
+	vs := []ebiten.Vertex{
+		{DstX: 0, DstY: 0, Custom0: 1},
+		{DstX: w / 2, DstY: 0, Custom0: 1},
+		{DstX: 0, DstY: h / 2, Custom0: 1},
+		{DstX: w / 2, DstY: h / 2, Custom0: 1},
+	}
+	is := []uint16{0, 1, 2, 1, 2, 3}
+	op := &ebiten.DrawTrianglesShaderOptions{}
+	op.Uniforms = map[string]any{
+		"Offset": []float32{w / 2, h / 2},
+	}
+	dst.DrawTrianglesShader(vs, is, s, op)
+
+	for j := 0; j < h; j++ {
+		for i := 0; i < w; i++ {
+			var want color.RGBA
+			if i >= w/2 && j >= h/2 {
+				want = color.RGBA{R: 0xff, A: 0xff}
+			}
+			if got := dst.At(i, j).(color.RGBA); got != want {
+				t.Errorf("dst.At(%d, %d): got: %v, want: %v", i, j, got, want)
+			}
+		}
+	}
+}
+
+func TestShaderDepthBuffer(t *testing.T) {
+	const w, h = 16, 16
+
+	s, err := ebiten.NewShader([]byte(`//kage:unit pixels
+//kage:depth test write
+
+package main
+
+func Vertex(dstPos vec2, srcPos vec2, color vec4, custom vec4) (vec3, vec2, vec4, vec4) {
+	return vec3(dstPos, custom.x), srcPos, color, custom
+}
+
+func Fragment(dstPos vec4, srcPos vec2, color vec4) vec4 {
+	return color
+}
+`))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	quad := func(x0, y0, x1, y1 float32, depth float32, clr color.RGBA) []ebiten.Vertex {
+		vs := []ebiten.Vertex{
+			{DstX: x0, DstY: y0},
+			{DstX: x1, DstY: y0},
+			{DstX: x0, DstY: y1},
+			{DstX: x1, DstY: y1},
+		}
+		for i := range vs {
+			vs[i].ColorR = float32(clr.R) / 0xff
+			vs[i].ColorG = float32(clr.G) / 0xff
+			vs[i].ColorB = float32(clr.B) / 0xff
+			vs[i].ColorA = float32(clr.A) / 0xff
+			vs[i].Custom0 = depth
+		}
+		return vs
+	}
+	is := []uint16{0, 1, 2, 1, 2, 3}
+
+	near := color.RGBA{R: 0xff, A: 0xff}
+	far := color.RGBA{B: 0xff, A: 0xff}
+
+	dst := ebiten.NewImageWithOptions(image.Rect(0, 0, w, h), &ebiten.NewImageOptions{
+		DepthBuffer: true,
+	})
+
+	// Draw the near quad first, and then the far quad. The far quad must be hidden where they overlap.
+	op := &ebiten.DrawTrianglesShaderOptions{}
+	dst.DrawTrianglesShader(quad(0, 0, w/2, h, 0.25, near), is, s, op)
+	dst.DrawTrianglesShader(quad(0, 0, w, h, 0.75, far), is, s, op)
+
+	for j := 0; j < h; j++ {
+		for i := 0; i < w; i++ {
+			got := dst.At(i, j).(color.RGBA)
+			want := far
+			if i < w/2 {
+				want = near
+			}
+			if got != want {
+				t.Errorf("dst.At(%d, %d): got: %v, want: %v", i, j, got, want)
+			}
+		}
+	}
+
+	// Clear resets the depth buffer.
+	dst.Clear()
+	dst.DrawTrianglesShader(quad(0, 0, w, h, 0.75, far), is, s, op)
+	for j := 0; j < h; j++ {
+		for i := 0; i < w; i++ {
+			got := dst.At(i, j).(color.RGBA)
+			want := far
+			if got != want {
+				t.Errorf("dst.At(%d, %d): got: %v, want: %v", i, j, got, want)
+			}
+		}
+	}
+}
+
+func TestShaderDepthBufferWithoutDirective(t *testing.T) {
+	const w, h = 16, 16
+
+	// Without //kage:depth, the depth buffer is not used.
+	s, err := ebiten.NewShader([]byte(`//kage:unit pixels
+
+package main
+
+func Vertex(dstPos vec2, srcPos vec2, color vec4, custom vec4) (vec3, vec2, vec4, vec4) {
+	return vec3(dstPos, custom.x), srcPos, color, custom
+}
+
+func Fragment(dstPos vec4, srcPos vec2, color vec4) vec4 {
+	return color
+}
+`))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	dst := ebiten.NewImageWithOptions(image.Rect(0, 0, w, h), &ebiten.NewImageOptions{
+		DepthBuffer: true,
+	})
+	for _, c := range []struct {
+		depth float32
+		clr   color.RGBA
+	}{
+		{depth: 0.25, clr: color.RGBA{R: 0xff, A: 0xff}},
+		{depth: 0.75, clr: color.RGBA{B: 0xff, A: 0xff}},
+	} {
+		vs := []ebiten.Vertex{
+			{DstX: 0, DstY: 0},
+			{DstX: w, DstY: 0},
+			{DstX: 0, DstY: h},
+			{DstX: w, DstY: h},
+		}
+		for i := range vs {
+			vs[i].ColorR = float32(c.clr.R) / 0xff
+			vs[i].ColorB = float32(c.clr.B) / 0xff
+			vs[i].ColorA = 1
+			vs[i].Custom0 = c.depth
+		}
+		dst.DrawTrianglesShader(vs, []uint16{0, 1, 2, 1, 2, 3}, s, nil)
+	}
+
+	for j := 0; j < h; j++ {
+		for i := 0; i < w; i++ {
+			got := dst.At(i, j).(color.RGBA)
+			want := color.RGBA{B: 0xff, A: 0xff}
+			if got != want {
+				t.Errorf("dst.At(%d, %d): got: %v, want: %v", i, j, got, want)
+			}
+		}
+	}
+}
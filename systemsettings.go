@@ -0,0 +1,94 @@
+// Copyright 2026 The Ebitengine Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ebiten
+
+import (
+	"github.com/duplicants-ai/ebiten/internal/ui"
+)
+
+// ColorScheme represents a color scheme preferred by the user.
+type ColorScheme int
+
+const (
+	// ColorSchemeUnknown represents an unknown color scheme.
+	ColorSchemeUnknown ColorScheme = ColorScheme(ui.ColorSchemeUnknown)
+
+	// ColorSchemeLight represents the light color scheme.
+	ColorSchemeLight ColorScheme = ColorScheme(ui.ColorSchemeLight)
+
+	// ColorSchemeDark represents the dark color scheme.
+	ColorSchemeDark ColorScheme = ColorScheme(ui.ColorSchemeDark)
+)
+
+// SystemColorScheme returns the color scheme preferred by the user in the OS settings.
+//
+// On Linux and other Unix-like systems, the color scheme is queried by the XDG Desktop Portal.
+// On Android and iOS, SystemColorScheme works only with the view generated by ebitenmobile.
+// SystemColorScheme returns ColorSchemeUnknown if the color scheme is not available.
+//
+// The system settings are queried periodically, and the value is updated at the beginning of a tick.
+//
+// SystemColorScheme is concurrent-safe.
+func SystemColorScheme() ColorScheme {
+	return ColorScheme(theInputState.systemSettings().ColorScheme)
+}
+
+// IsReducedMotionPreferred reports whether the user prefers reduced motion in the OS settings.
+//
+// Games can use this to reduce e.g. screen shakes, flashes, and parallax effects.
+//
+// On Linux and other Unix-like systems, GNOME's enable-animations setting is used.
+// On Android, disabled animations are treated as the reduced motion.
+// IsReducedMotionPreferred returns false if the setting is not available.
+//
+// IsReducedMotionPreferred is concurrent-safe.
+func IsReducedMotionPreferred() bool {
+	return theInputState.systemSettings().ReducedMotion
+}
+
+// IsHighContrastEnabled reports whether the user prefers high contrast in the OS settings.
+//
+// IsHighContrastEnabled always returns false on Android.
+// IsHighContrastEnabled returns false if the setting is not available.
+//
+// IsHighContrastEnabled is concurrent-safe.
+func IsHighContrastEnabled() bool {
+	return theInputState.systemSettings().HighContrast
+}
+
+// SystemTextScale returns the text scale preferred by the user in the OS settings.
+// 1 means the default text size.
+//
+// Games can use this to scale the text sizes in their UI.
+//
+// On macOS, SystemTextScale always returns 1.
+// On browsers, the text scale is calculated from the font size of the document.
+// SystemTextScale returns 1 if the setting is not available.
+//
+// SystemTextScale is concurrent-safe.
+func SystemTextScale() float64 {
+	if s := theInputState.systemSettings().TextScale; s > 0 {
+		return s
+	}
+	return 1
+}
+
+// IsSystemSettingsChanged reports whether any of the system settings, SystemColorScheme, IsReducedMotionPreferred,
+// IsHighContrastEnabled, and SystemTextScale, has changed since the previous tick.
+//
+// IsSystemSettingsChanged is concurrent-safe.
+func IsSystemSettingsChanged() bool {
+	return theInputState.systemSettingsChanged()
+}
@@ -70,7 +70,7 @@ type VibrateGamepadOptions struct {
 
 // VibrateGamepad vibrates the specified gamepad with the specified options.
 //
-// VibrateGamepad works only on browsers and Nintendo Switch so far.
+// VibrateGamepad works only on browsers, Nintendo Switch, PlayStation 5, and Xbox so far.
 //
 // VibrateGamepad is concurrent-safe.
 func VibrateGamepad(gamepadID GamepadID, options *VibrateGamepadOptions) {
@@ -80,3 +80,96 @@ func VibrateGamepad(gamepadID GamepadID, options *VibrateGamepadOptions) {
 	}
 	g.Vibrate(options.Duration, options.StrongMagnitude, options.WeakMagnitude)
 }
+
+// HapticEventType represents the type of a haptic event.
+type HapticEventType int
+
+const (
+	// HapticEventTypeContinuous is a haptic event that lasts for the duration.
+	HapticEventTypeContinuous HapticEventType = iota
+
+	// HapticEventTypeTransient is a short tap like a click. The duration is ignored.
+	HapticEventTypeTransient
+)
+
+// HapticEvent represents an event in a haptic pattern.
+type HapticEvent struct {
+	// Type is the type of the event.
+	Type HapticEventType
+
+	// Time is the start time of the event relative to the start of the pattern.
+	Time time.Duration
+
+	// Duration is the time duration of the event.
+	// Duration is ignored for HapticEventTypeTransient.
+	Duration time.Duration
+
+	// Intensity is the strength of the event.
+	// The value is in between 0 and 1.
+	Intensity float64
+
+	// Sharpness is the feel of the event. A smaller value is rounder and a larger value is crisper.
+	// The value is in between 0 and 1.
+	//
+	// Sharpness is used only on iOS.
+	Sharpness float64
+}
+
+// HapticPattern represents a haptic pattern consisting of events.
+type HapticPattern struct {
+	// Events is the events of the pattern. The events can overlap.
+	Events []HapticEvent
+}
+
+// PlayHapticPattern plays the haptic pattern on the device.
+//
+// PlayHapticPattern works on mobiles and browsers.
+// The same requirements as Vibrate apply.
+//
+// On platforms without intensity control, an event is played at the full strength regardless of its Intensity,
+// and overlapping events are merged.
+// On platforms without transient events, a transient event is played as a short continuous event.
+// Use HapticCapabilities to query what the device supports.
+//
+// PlayHapticPattern is concurrent-safe.
+func PlayHapticPattern(pattern *HapticPattern) {
+	events := make([]vibrate.Event, 0, len(pattern.Events))
+	for _, e := range pattern.Events {
+		events = append(events, vibrate.Event{
+			Transient: e.Type == HapticEventTypeTransient,
+			Time:      e.Time,
+			Duration:  e.Duration,
+			Intensity: e.Intensity,
+			Sharpness: e.Sharpness,
+		})
+	}
+	vibrate.PlayPattern(events)
+}
+
+// HapticCapabilitiesType represents the haptic capabilities of the device.
+type HapticCapabilitiesType struct {
+	// Supported reports whether the device can play haptics at all.
+	Supported bool
+
+	// IntensityControl reports whether the intensity of an event is reflected.
+	IntensityControl bool
+
+	// SharpnessControl reports whether the sharpness of an event is reflected.
+	SharpnessControl bool
+
+	// TransientSupported reports whether transient events are played natively.
+	TransientSupported bool
+}
+
+// HapticCapabilities returns the haptic capabilities of the device.
+//
+// HapticCapabilities is concurrent-safe.
+func HapticCapabilities() HapticCapabilitiesType {
+	c := vibrate.QueryCapabilities()
+	return HapticCapabilitiesType{
+		Supported:          c.Supported,
+		IntensityControl:   c.IntensityControl,
+		SharpnessControl:   c.SharpnessControl,
+		TransientSupported: c.TransientSupported,
+	}
+}
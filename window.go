@@ -15,7 +15,9 @@
 package ebiten
 
 import (
+	"fmt"
 	"image"
+	"math"
 	"sync/atomic"
 
 	"github.com/duplicants-ai/ebiten/internal/ui"
@@ -197,6 +199,138 @@ func SetWindowSize(width, height int) {
 	ui.Get().Window().SetSize(width, height)
 }
 
+// WindowPositionInPhysicalPixels returns the window position in physical pixels.
+// The origin position is the upper-left corner of the current monitor.
+//
+// Unlike WindowPosition, WindowPositionInPhysicalPixels doesn't lose precision by the conversion to device-independent pixels.
+// This is useful to save and restore the window geometry exactly.
+//
+// WindowPositionInPhysicalPixels panics if the main loop does not start yet.
+//
+// WindowPositionInPhysicalPixels returns (0, 0) if the platform is not a desktop.
+//
+// WindowPositionInPhysicalPixels is concurrent-safe.
+func WindowPositionInPhysicalPixels() (x, y int) {
+	return ui.Get().Window().PositionInPhysicalPixels()
+}
+
+// SetWindowPositionInPhysicalPixels sets the window position in physical pixels.
+// The origin position is the upper-left corner of the current monitor.
+// The position is converted with the device scale factor of the current monitor.
+// To put the window on another monitor, call SetMonitor first.
+//
+// SetWindowPositionInPhysicalPixels sets the original window position in fullscreen mode.
+//
+// SetWindowPositionInPhysicalPixels does nothing if the platform is not a desktop.
+//
+// SetWindowPositionInPhysicalPixels is concurrent-safe.
+func SetWindowPositionInPhysicalPixels(x, y int) {
+	windowPositionSetExplicitly.Store(true)
+	ui.Get().Window().SetPositionInPhysicalPixels(x, y)
+}
+
+// WindowSizeInPhysicalPixels returns the window size in physical pixels on desktops.
+// WindowSizeInPhysicalPixels returns (0, 0) on other environments.
+//
+// Even if the application is in fullscreen mode, WindowSizeInPhysicalPixels returns the original window size.
+//
+// WindowSizeInPhysicalPixels is concurrent-safe.
+func WindowSizeInPhysicalPixels() (int, int) {
+	return ui.Get().Window().SizeInPhysicalPixels()
+}
+
+// SetWindowSizeInPhysicalPixels sets the window size in physical pixels on desktops.
+// SetWindowSizeInPhysicalPixels does nothing on other environments.
+//
+// The size is converted with the device scale factor of the current monitor.
+// If the window size limits are set, the size might be rounded to device-independent pixels.
+//
+// Even if the application is in fullscreen mode, SetWindowSizeInPhysicalPixels sets the original window size.
+//
+// SetWindowSizeInPhysicalPixels panics if width or height is not a positive number.
+//
+// SetWindowSizeInPhysicalPixels is concurrent-safe.
+func SetWindowSizeInPhysicalPixels(width, height int) {
+	if width <= 0 || height <= 0 {
+		panic("ebiten: width and height must be positive")
+	}
+	ui.Get().Window().SetSizeInPhysicalPixels(width, height)
+}
+
+// WindowMonitorChange represents a change of the monitor the window belongs to.
+type WindowMonitorChange struct {
+	// PrevMonitor is the monitor the window belonged to.
+	PrevMonitor *MonitorType
+
+	// Monitor is the monitor the window belongs to.
+	Monitor *MonitorType
+
+	// PrevDeviceScaleFactor is the device scale factor of PrevMonitor at the time the window belonged to it.
+	PrevDeviceScaleFactor float64
+
+	// DeviceScaleFactor is the device scale factor of Monitor.
+	DeviceScaleFactor float64
+}
+
+var theWindowMonitorChangedHandler atomic.Pointer[func(change WindowMonitorChange)]
+
+// SetWindowMonitorChangedHandler sets a function called when the window moves to another monitor,
+// or the device scale factor of the monitor changes.
+//
+// handler is called on the same goroutine as Update, before Update is called.
+// Use handler to save the window geometry or to reload assets for the new device scale factor.
+//
+// If handler is nil, the handler is removed.
+//
+// SetWindowMonitorChangedHandler works only on desktops.
+//
+// SetWindowMonitorChangedHandler is concurrent-safe.
+func SetWindowMonitorChangedHandler(handler func(change WindowMonitorChange)) {
+	if handler == nil {
+		theWindowMonitorChangedHandler.Store(nil)
+		return
+	}
+	theWindowMonitorChangedHandler.Store(&handler)
+}
+
+type windowMonitorChangeNotifier struct {
+	monitor           *ui.Monitor
+	deviceScaleFactor float64
+}
+
+// notify calls the window monitor changed handler if the monitor or its device scale factor has changed since the previous call.
+func (w *windowMonitorChangeNotifier) notify() {
+	m := theInputState.windowMonitor()
+	if m == nil {
+		return
+	}
+	w.update(m, m.DeviceScaleFactor())
+}
+
+// update calls the window monitor changed handler if m or s is different from the previous call.
+func (w *windowMonitorChangeNotifier) update(m *ui.Monitor, s float64) {
+	if w.monitor == nil {
+		w.monitor = m
+		w.deviceScaleFactor = s
+		return
+	}
+	if w.monitor == m && w.deviceScaleFactor == s {
+		return
+	}
+
+	change := WindowMonitorChange{
+		PrevMonitor:           (*MonitorType)(w.monitor),
+		Monitor:               (*MonitorType)(m),
+		PrevDeviceScaleFactor: w.deviceScaleFactor,
+		DeviceScaleFactor:     s,
+	}
+	w.monitor = m
+	w.deviceScaleFactor = s
+	if h := theWindowMonitorChangedHandler.Load(); h != nil {
+		(*h)(change)
+	}
+}
+
 // WindowSizeLimits returns the limitation of the window size on desktops.
 // A negative value indicates the size is not limited.
 //
@@ -342,6 +476,59 @@ func IsWindowMousePassthrough() bool {
 	return ui.Get().Window().IsMousePassthrough()
 }
 
+// SetWindowMousePassthroughRegions sets the regions where a mouse cursor passthroughs the window on desktops.
+// The regions are in the logical screen coordinates, which is the same as CursorPosition.
+// If regions is empty, no regions are set. By default, no regions are set.
+//
+// While the cursor is in one of the regions, the window lets mouse events pass through to the windows behind it.
+// This is useful for overlay tools with a transparent screen (see RunGameOptions.ScreenTransparent),
+// where only the drawn parts should be interactive.
+//
+// The cursor position is checked every tick, so the passthrough state might be switched a little late.
+// SetWindowMousePassthroughRegions has no effect while SetWindowMousePassthrough is enabled, as the whole window is passthrough.
+//
+// SetWindowMousePassthroughRegions works only on desktops.
+// SetWindowMousePassthroughRegions does nothing if the platform is not a desktop.
+//
+// SetWindowMousePassthroughRegions is concurrent-safe.
+func SetWindowMousePassthroughRegions(regions []image.Rectangle) {
+	ui.Get().Window().SetMousePassthroughRegions(regions)
+}
+
+// WindowMousePassthroughRegions returns the regions set by SetWindowMousePassthroughRegions.
+//
+// WindowMousePassthroughRegions is concurrent-safe.
+func WindowMousePassthroughRegions() []image.Rectangle {
+	return ui.Get().Window().MousePassthroughRegions()
+}
+
+// SetWindowOpacity sets the opacity of the whole window including its decorations on desktops.
+// opacity must be in [0, 1], where 0 is fully transparent and 1 is fully opaque. The default value is 1.
+//
+// Unlike RunGameOptions.ScreenTransparent, the opacity applies to all the pixels uniformly.
+//
+// SetWindowOpacity works only on desktops. On Linux, a compositing window manager is required.
+// SetWindowOpacity does nothing if the platform is not a desktop.
+//
+// SetWindowOpacity panics if opacity is out of range.
+//
+// SetWindowOpacity is concurrent-safe.
+func SetWindowOpacity(opacity float64) {
+	if opacity < 0 || opacity > 1 || math.IsNaN(opacity) {
+		panic(fmt.Sprintf("ebiten: opacity must be in [0, 1] but %f", opacity))
+	}
+	ui.Get().Window().SetOpacity(opacity)
+}
+
+// WindowOpacity returns the opacity of the window set by SetWindowOpacity.
+//
+// WindowOpacity returns 1 if the platform is not a desktop.
+//
+// WindowOpacity is concurrent-safe.
+func WindowOpacity() float64 {
+	return ui.Get().Window().Opacity()
+}
+
 // RequestAttention requests user attention to the current window and/or the current application.
 //
 // RequestAttention works only on desktops.
@@ -351,3 +538,59 @@ func IsWindowMousePassthrough() bool {
 func RequestAttention() {
 	ui.Get().Window().RequestAttention()
 }
+
+// WindowProgressState represents a state of the progress shown on the taskbar button or the Dock icon.
+type WindowProgressState int
+
+const (
+	// WindowProgressStateNone hides the progress.
+	WindowProgressStateNone WindowProgressState = WindowProgressState(ui.WindowProgressStateNone)
+
+	// WindowProgressStateNormal shows the progress normally.
+	WindowProgressStateNormal WindowProgressState = WindowProgressState(ui.WindowProgressStateNormal)
+
+	// WindowProgressStateIndeterminate shows the progress without a specific value.
+	WindowProgressStateIndeterminate WindowProgressState = WindowProgressState(ui.WindowProgressStateIndeterminate)
+
+	// WindowProgressStatePaused shows the progress as paused.
+	WindowProgressStatePaused WindowProgressState = WindowProgressState(ui.WindowProgressStatePaused)
+
+	// WindowProgressStateError shows the progress as an error.
+	WindowProgressStateError WindowProgressState = WindowProgressState(ui.WindowProgressStateError)
+)
+
+// SetWindowProgress sets the progress shown on the taskbar button or the Dock icon.
+// progress is in the range of [0, 1].
+//
+// SetWindowProgress is useful to show e.g. a loading progress or a long computation while the window is in background.
+//
+// On Windows, the progress is shown on the taskbar button with the state.
+// On macOS, the progress is shown as a bar on the Dock icon. The states other than none are shown in the same way.
+// On Linux and other Unix-like systems, the progress is shown by the Unity LauncherAPI, which is supported by e.g. KDE Plasma and Ubuntu Dock.
+// The states other than none are shown in the same way, and the desktop entry must have the same name as the executable.
+//
+// SetWindowProgress works only on desktops.
+// SetWindowProgress does nothing if the platform is not a desktop.
+//
+// SetWindowProgress is concurrent-safe.
+func SetWindowProgress(state WindowProgressState, progress float64) {
+	ui.Get().Window().SetProgress(ui.WindowProgressState(state), progress)
+}
+
+// SetWindowBadgeCount sets the badge count shown on the Dock icon or the launcher icon.
+// 0 hides the badge.
+//
+// SetWindowBadgeCount is useful to notify e.g. the number of unread messages or the turns waiting for the player.
+// To request user attention, use RequestAttention.
+//
+// On Windows, the badge is shown as an overlay icon on the taskbar button.
+//
+// On Linux and other Unix-like systems, the badge is shown by the Unity LauncherAPI, and the desktop entry must have the same name as the executable.
+//
+// SetWindowBadgeCount works only on Windows, macOS, Linux, and other Unix-like systems.
+// SetWindowBadgeCount does nothing on the other platforms.
+//
+// SetWindowBadgeCount is concurrent-safe.
+func SetWindowBadgeCount(count int) {
+	ui.Get().Window().SetBadgeCount(count)
+}
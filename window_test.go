@@ -0,0 +1,155 @@
+// Copyright 2026 The Ebitengine Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ebiten_test
+
+import (
+	"image"
+	"math"
+	"slices"
+	"testing"
+
+	"github.com/duplicants-ai/ebiten"
+)
+
+func TestWindowMonitorChangedHandler(t *testing.T) {
+	t.Cleanup(func() {
+		ebiten.SetWindowMonitorChangedHandler(nil)
+	})
+
+	var changes []ebiten.WindowMonitorChange
+	ebiten.SetWindowMonitorChangedHandler(func(change ebiten.WindowMonitorChange) {
+		changes = append(changes, change)
+	})
+
+	m0 := ebiten.Monitor()
+	var n ebiten.WindowMonitorChangeNotifierForTesting
+
+	// The first monitor is not reported as a change.
+	n.UpdateForTesting(m0, 1)
+	n.UpdateForTesting(m0, 1)
+	if len(changes) != 0 {
+		t.Fatalf("len(changes): got: %d, want: 0", len(changes))
+	}
+
+	n.UpdateForTesting(m0, 2)
+	if len(changes) != 1 {
+		t.Fatalf("len(changes): got: %d, want: 1", len(changes))
+	}
+	want := ebiten.WindowMonitorChange{
+		PrevMonitor:           m0,
+		Monitor:               m0,
+		PrevDeviceScaleFactor: 1,
+		DeviceScaleFactor:     2,
+	}
+	if got := changes[0]; got != want {
+		t.Errorf("got: %+v, want: %+v", got, want)
+	}
+
+	monitors := ebiten.AppendMonitors(nil)
+	var m1 *ebiten.MonitorType
+	for _, m := range monitors {
+		if m != m0 {
+			m1 = m
+			break
+		}
+	}
+	if m1 == nil {
+		return
+	}
+
+	n.UpdateForTesting(m1, 2)
+	if len(changes) != 2 {
+		t.Fatalf("len(changes): got: %d, want: 2", len(changes))
+	}
+	want = ebiten.WindowMonitorChange{
+		PrevMonitor:           m0,
+		Monitor:               m1,
+		PrevDeviceScaleFactor: 2,
+		DeviceScaleFactor:     2,
+	}
+	if got := changes[1]; got != want {
+		t.Errorf("got: %+v, want: %+v", got, want)
+	}
+}
+
+func TestSetWindowSizeInPhysicalPixelsWithNonPositiveSize(t *testing.T) {
+	for _, size := range [][2]int{{0, 1}, {1, 0}, {-1, 1}} {
+		func() {
+			defer func() {
+				if recover() == nil {
+					t.Errorf("SetWindowSizeInPhysicalPixels(%d, %d) must panic", size[0], size[1])
+				}
+			}()
+			ebiten.SetWindowSizeInPhysicalPixels(size[0], size[1])
+		}()
+	}
+}
+
+func TestWindowOpacity(t *testing.T) {
+	for _, opacity := range []float64{-0.1, 1.1, math.NaN()} {
+		func() {
+			defer func() {
+				if recover() == nil {
+					t.Errorf("SetWindowOpacity(%f) must panic", opacity)
+				}
+			}()
+			ebiten.SetWindowOpacity(opacity)
+		}()
+	}
+
+	t.Cleanup(func() {
+		ebiten.SetWindowOpacity(1)
+	})
+	ebiten.SetWindowOpacity(0.5)
+	got := ebiten.WindowOpacity()
+	if got == 1 {
+		t.Skip("window opacity is not available in this environment")
+	}
+	if want := 0.5; got != want {
+		t.Errorf("got: %f, want: %f", got, want)
+	}
+}
+
+func TestWindowMousePassthroughRegions(t *testing.T) {
+	t.Cleanup(func() {
+		ebiten.SetWindowMousePassthroughRegions(nil)
+	})
+
+	regions := []image.Rectangle{
+		image.Rect(0, 0, 10, 10),
+		image.Rect(20, 20, 30, 40),
+	}
+	ebiten.SetWindowMousePassthroughRegions(regions)
+	got := ebiten.WindowMousePassthroughRegions()
+	if got == nil {
+		t.Skip("mouse passthrough regions are not available in this environment")
+	}
+	want := slices.Clone(regions)
+	if !slices.Equal(got, want) {
+		t.Errorf("got: %v, want: %v", got, want)
+	}
+
+	// The regions are copied.
+	regions[0] = image.Rect(1, 1, 2, 2)
+	got[1] = image.Rect(1, 1, 2, 2)
+	if got := ebiten.WindowMousePassthroughRegions(); !slices.Equal(got, want) {
+		t.Errorf("got: %v, want: %v", got, want)
+	}
+
+	ebiten.SetWindowMousePassthroughRegions(nil)
+	if got := ebiten.WindowMousePassthroughRegions(); len(got) != 0 {
+		t.Errorf("got: %v, want: empty", got)
+	}
+}